@@ -29,7 +29,21 @@ func NewAnalyzer(logger *slog.Logger) Analyzer {
 
 // Analyze performs a complete analysis of the given directory and returns a temporal graph.
 func (a *analyzer) Analyze(ctx context.Context, opts config.AnalysisOptions) (*TemporalGraph, error) {
-	return a.service.AnalyzeWorkflows(ctx, opts)
+	return a.AnalyzeStream(ctx, opts, nil)
+}
+
+// AnalyzeStream performs a complete analysis of the given directory, reporting the result of
+// each package parsed to onPackage, and returns a temporal graph.
+func (a *analyzer) AnalyzeStream(ctx context.Context, opts config.AnalysisOptions, onPackage func(PackageResult)) (*TemporalGraph, error) {
+	if onPackage != nil {
+		ctx = withPackageResults(ctx, onPackage)
+	}
+	graph, err := a.service.AnalyzeWorkflows(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	graph.Engine = Engine()
+	return graph, nil
 }
 
 // ValidateGraph is a convenience method to access validation through the analyzer.
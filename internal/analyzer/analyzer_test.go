@@ -2,12 +2,15 @@ package analyzer
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/synthrepo"
 )
 
 func TestNewAnalyzer(t *testing.T) {
@@ -68,6 +71,9 @@ func SendEmailActivity(ctx context.Context, orderID string) error {
 	if graph == nil {
 		t.Fatal("Analyze returned nil graph")
 	}
+	if graph.Engine == nil || graph.Engine.Version != EngineVersion || graph.Engine.GraphSchema != GraphSchemaVersion {
+		t.Errorf("Engine = %+v, want the running engine", graph.Engine)
+	}
 
 	// Should have found both workflow and activity
 	if len(graph.Nodes) < 2 {
@@ -83,6 +89,10 @@ func SendEmailActivity(ctx context.Context, orderID string) error {
 			if node.Type != "workflow" {
 				t.Errorf("ProcessOrderWorkflow type = %s, want workflow", node.Type)
 			}
+			// Call sites record the full path of the caller's file, like nodes
+			if len(node.CallSites) != 1 || node.CallSites[0].FilePath != workflowFile {
+				t.Errorf("Expected one call site in %s, got %+v", workflowFile, node.CallSites)
+			}
 		}
 		if node.Name == "SendEmailActivity" {
 			foundActivity = true
@@ -405,3 +415,78 @@ func SendEmailActivity() {}
 	}
 }
 
+func TestAnalyzeSyntheticRepo(t *testing.T) {
+	dir := t.TempDir()
+	opts := synthrepo.Options{Workflows: 12, Activities: 20, Packages: 3, FanOut: 3, Depth: 4}
+	if err := synthrepo.Generate(dir, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if graph.Stats.TotalWorkflows != opts.Workflows || graph.Stats.TotalActivities != opts.Activities {
+		t.Errorf("Expected %d workflows and %d activities, got %+v", opts.Workflows, opts.Activities, graph.Stats)
+	}
+	if graph.Stats.MaxDepth != opts.Depth {
+		t.Errorf("Expected chains of depth %d, got %d", opts.Depth, graph.Stats.MaxDepth)
+	}
+	for i := range opts.Workflows {
+		node := graph.Nodes[synthrepo.WorkflowName(i)]
+		if node == nil {
+			t.Fatalf("Missing %s in the graph", synthrepo.WorkflowName(i))
+		}
+		want := len(opts.ExecutedActivities(i))
+		if opts.ChildWorkflow(i) >= 0 {
+			want++
+		}
+		if len(node.CallSites) != want {
+			t.Errorf("Expected %d call sites for %s, got %+v", want, node.Name, node.CallSites)
+		}
+	}
+}
+
+// BenchmarkAnalyze measures the analysis of generated projects of increasing size.
+func BenchmarkAnalyze(b *testing.B) {
+	for _, opts := range []synthrepo.Options{
+		{Workflows: 10, Activities: 30, Packages: 2, FanOut: 3, Depth: 2},
+		{Workflows: 100, Activities: 300, Packages: 10, FanOut: 5, Depth: 3},
+		{Workflows: 1000, Activities: 3000, Packages: 50, FanOut: 8, Depth: 5},
+	} {
+		b.Run(fmt.Sprintf("workflows=%d", opts.Workflows), func(b *testing.B) {
+			dir := b.TempDir()
+			if err := synthrepo.Generate(dir, opts); err != nil {
+				b.Fatal(err)
+			}
+			a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := a.Analyze(ctx, config.AnalysisOptions{RootDir: dir}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(opts.Workflows+opts.Activities)*float64(b.N)/b.Elapsed().Seconds(), "nodes/s")
+		})
+	}
+}
+
+func FuzzAnalyze(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	f.Fuzz(func(t *testing.T, src string) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "fuzz.go"), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		// Errors are fine, panics are not
+		_, _ = a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	})
+}
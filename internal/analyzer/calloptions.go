@@ -0,0 +1,58 @@
+package analyzer
+
+import "sort"
+
+// CallOptions is an activity or local activity call site with the options it executes with,
+// for external policy engines checking the options of every call without walking the nodes.
+type CallOptions struct {
+	// Caller is the graph key of the calling node
+	Caller     string `json:"caller"`
+	Target     string `json:"target"`
+	CallType   string `json:"call_type"` // "activity" or "local_activity"
+	FilePath   string `json:"file_path"`
+	LineNumber int    `json:"line_number"`
+	// Options is nil when the call sets no options the analyzer could find
+	Options *ActivityOptions `json:"options,omitempty"`
+}
+
+// executedType returns what a call site executes: the call type of signals, queries and
+// updates, or the target type of "execute" calls (activity, local_activity, child_workflow).
+func executedType(cs CallSite) string {
+	if cs.CallType != "execute" && cs.CallType != "" {
+		return cs.CallType
+	}
+	return cs.TargetType
+}
+
+// CallOptions returns the activity and local activity call sites of the graph with their
+// options, sorted by file and line.
+func (g *TemporalGraph) CallOptions() []CallOptions {
+	var calls []CallOptions
+	for key, node := range g.Nodes {
+		for _, cs := range node.CallSites {
+			executed := executedType(cs)
+			if executed != "activity" && executed != "local_activity" {
+				continue
+			}
+			calls = append(calls, CallOptions{
+				Caller:     key,
+				Target:     cs.TargetName,
+				CallType:   executed,
+				FilePath:   cs.FilePath,
+				LineNumber: cs.LineNumber,
+				Options:    cs.ParsedActivityOpts,
+			})
+		}
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		a, b := calls[i], calls[j]
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		if a.LineNumber != b.LineNumber {
+			return a.LineNumber < b.LineNumber
+		}
+		return a.Caller < b.Caller
+	})
+	return calls
+}
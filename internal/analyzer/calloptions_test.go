@@ -0,0 +1,59 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCallOptions(t *testing.T) {
+	code := `package test
+
+func OrderWorkflow(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		TaskQueue:           "payments",
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy:         &temporal.RetryPolicy{},
+	})
+	workflow.ExecuteActivity(ctx, Charge).Get(ctx, nil)
+	workflow.ExecuteLocalActivity(ctx, Validate).Get(ctx, nil)
+	workflow.ExecuteChildWorkflow(ctx, ShipWorkflow).Get(ctx, nil)
+	return nil
+}
+`
+	details := extractTestFunc(t, code, "OrderWorkflow")
+	graph := &TemporalGraph{Nodes: map[string]*TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", CallSites: details.CallSites},
+	}}
+
+	calls := graph.CallOptions()
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 activity calls, got %+v", calls)
+	}
+	if calls[0].Target != "Charge" || calls[0].CallType != "activity" || calls[1].CallType != "local_activity" {
+		t.Errorf("calls = %+v, want Charge as activity, then Validate as local_activity", calls)
+	}
+	opts := calls[0].Options
+	if opts == nil || opts.TaskQueue != "payments" || opts.StartToCloseTimeout != "5 * time.Minute" {
+		t.Fatalf("Options = %+v, want the payments queue and a 5m timeout", opts)
+	}
+
+	data, err := json.Marshal(calls[0])
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, field := range []string{`"caller":"OrderWorkflow"`, `"task_queue":"payments"`, `"start_to_close_timeout":"5 * time.Minute"`, `"options_provided":true`, `"retry_policy":{"policy_provided":true}`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("JSON should contain %s: %s", field, data)
+		}
+	}
+
+	// The provided flags survive a round trip, so rules on loaded graphs see the empty retry policy
+	var decoded CallOptions
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !decoded.Options.OptionsProvided() || !decoded.Options.HasRetryPolicy() || decoded.Options.TaskQueue != "payments" {
+		t.Errorf("decoded options = %+v, want the provided options and retry policy", decoded.Options)
+	}
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChurnWindow is the period over which ComputeChurn counts commits.
+const ChurnWindow = 90 * 24 * time.Hour
+
+// ChurnInfo summarizes the git history of the file defining a node.
+type ChurnInfo struct {
+	Commits     int       `json:"commits"`      // Commits touching the file within ChurnWindow
+	FirstCommit time.Time `json:"first_commit"` // Oldest commit touching the file
+	LastCommit  time.Time `json:"last_commit"`  // Newest commit touching the file
+}
+
+// Age returns how long ago the file defining the node was first committed.
+func (c *ChurnInfo) Age(now time.Time) time.Duration {
+	return now.Sub(c.FirstCommit)
+}
+
+// ComputeChurn sets the churn of each node from the git history of its file under rootDir:
+// the commits since the given time and the first and last commit dates. Nodes in files
+// without history (untracked or synthetic) keep a nil Churn.
+func ComputeChurn(ctx context.Context, rootDir string, graph *TemporalGraph, since time.Time) error {
+	absRoot, err := filepath.Abs(rootDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	// A unit separator marks commit lines; --relative makes paths relative to rootDir
+	out, err := runGit(ctx, absRoot, "log", "--format=%x1f%ct", "--name-only", "--relative", "--", ".")
+	if err != nil {
+		return fmt.Errorf("failed to read git history: %w", err)
+	}
+
+	files := make(map[string]*ChurnInfo)
+	var commitTime time.Time
+	for _, line := range strings.Split(out, "\n") {
+		if ts, ok := strings.CutPrefix(line, "\x1f"); ok {
+			secs, err := strconv.ParseInt(ts, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid commit time %q in git log", ts)
+			}
+			commitTime = time.Unix(secs, 0)
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		info, ok := files[line]
+		if !ok {
+			info = &ChurnInfo{LastCommit: commitTime}
+			files[line] = info
+		}
+		// git log lists commits newest first
+		info.FirstCommit = commitTime
+		if !commitTime.Before(since) {
+			info.Commits++
+		}
+	}
+
+	for _, node := range graph.Nodes {
+		node.Churn = nil
+		if node.FilePath == "" {
+			continue
+		}
+		absFile, err := filepath.Abs(node.FilePath)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(absRoot, absFile)
+		if err != nil {
+			continue
+		}
+		if info, ok := files[filepath.ToSlash(rel)]; ok {
+			churn := *info
+			node.Churn = &churn
+		}
+	}
+	return nil
+}
+
+// MaxChurn returns the highest commit count of the graph's nodes.
+func (g *TemporalGraph) MaxChurn() int {
+	max := 0
+	for _, node := range g.Nodes {
+		if node.Churn != nil && node.Churn.Commits > max {
+			max = node.Churn.Commits
+		}
+	}
+	return max
+}
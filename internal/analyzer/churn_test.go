@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestComputeChurn(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	subDir := filepath.Join(repo, "workflows")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	hot := filepath.Join(subDir, "orders.go")
+	cold := filepath.Join(subDir, "billing.go")
+
+	commit := func(date, file, content string) {
+		t.Helper()
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		t.Setenv("GIT_COMMITTER_DATE", date)
+		gitCmd(t, repo, "add", "-A")
+		gitCmd(t, repo, "commit", "-q", "--date", date, "-m", date)
+	}
+
+	gitCmd(t, repo, "init", "-q")
+	commit("2024-01-10T12:00:00Z", cold, "package workflows // billing\n")
+	commit("2024-01-20T12:00:00Z", hot, "package workflows // v1\n")
+	commit("2024-05-01T12:00:00Z", hot, "package workflows // v2\n")
+	commit("2024-05-02T12:00:00Z", hot, "package workflows // v3\n")
+
+	graph := &TemporalGraph{Nodes: map[string]*TemporalNode{
+		"OrderWorkflow":  {Name: "OrderWorkflow", FilePath: hot},
+		"ChargeActivity": {Name: "ChargeActivity", FilePath: cold},
+		"External":       {Name: "External", Unresolved: true},
+	}}
+	since := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	if err := ComputeChurn(context.Background(), subDir, graph, since); err != nil {
+		t.Fatalf("ComputeChurn failed: %v", err)
+	}
+
+	orders := graph.Nodes["OrderWorkflow"].Churn
+	if orders == nil {
+		t.Fatal("Expected churn for OrderWorkflow")
+	}
+	if orders.Commits != 2 {
+		t.Errorf("OrderWorkflow commits = %d, want 2", orders.Commits)
+	}
+	if want := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC); !orders.FirstCommit.Equal(want) {
+		t.Errorf("OrderWorkflow first commit = %v, want %v", orders.FirstCommit, want)
+	}
+	if want := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC); !orders.LastCommit.Equal(want) {
+		t.Errorf("OrderWorkflow last commit = %v, want %v", orders.LastCommit, want)
+	}
+
+	billing := graph.Nodes["ChargeActivity"].Churn
+	if billing == nil || billing.Commits != 0 {
+		t.Errorf("ChargeActivity churn = %+v, want 0 recent commits", billing)
+	}
+	if graph.Nodes["External"].Churn != nil {
+		t.Error("Nodes without a file should have no churn")
+	}
+	if got := graph.MaxChurn(); got != 2 {
+		t.Errorf("MaxChurn() = %d, want 2", got)
+	}
+}
+
+func TestComputeChurnOutsideRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	graph := &TemporalGraph{Nodes: map[string]*TemporalNode{}}
+	if err := ComputeChurn(context.Background(), t.TempDir(), graph, time.Now()); err == nil {
+		t.Error("Expected an error outside a git repository")
+	}
+}
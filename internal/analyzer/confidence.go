@@ -0,0 +1,108 @@
+package analyzer
+
+// Detection confidence levels, from least to most reliable.
+const (
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+)
+
+// Detection reasons record the evidence a node was detected from.
+const (
+	// ReasonRegistered marks nodes registered with worker.RegisterWorkflow or RegisterActivity
+	ReasonRegistered = "registered"
+	// ReasonSignature marks functions taking a workflow.Context and calling the workflow SDK
+	ReasonSignature = "signature"
+	// ReasonExecuted marks nodes executed by an analyzed workflow
+	ReasonExecuted = "executed"
+	// ReasonNameOnly marks call targets known only by the name passed to an Execute call
+	ReasonNameOnly = "name_only"
+)
+
+// confidenceRanks orders the confidence levels.
+var confidenceRanks = map[string]int{
+	ConfidenceLow:    1,
+	ConfidenceMedium: 2,
+	ConfidenceHigh:   3,
+}
+
+// ConfidenceAtLeast reports whether the node was detected with at least the given confidence.
+// Nodes without a confidence, such as nodes of graphs not built by the analyzer, qualify.
+func (n *TemporalNode) ConfidenceAtLeast(min string) bool {
+	if n.DetectionConfidence == "" {
+		return true
+	}
+	return confidenceRanks[n.DetectionConfidence] >= confidenceRanks[min]
+}
+
+// assignConfidence completes the detection reasons of the graph's nodes once calls are
+// linked, and derives their confidence: registration, or a workflow signature confirmed by
+// an execution, is high; a signature or an execution alone is medium; a bare name is low.
+func assignConfidence(graph *TemporalGraph) {
+	for _, node := range graph.Nodes {
+		if node.Unresolved {
+			node.DetectionReasons = []string{ReasonNameOnly}
+		} else if executed(node) && !containsReason(node.DetectionReasons, ReasonExecuted) {
+			node.DetectionReasons = append(node.DetectionReasons, ReasonExecuted)
+		}
+		node.DetectionConfidence = confidenceFor(node.DetectionReasons)
+	}
+}
+
+// executed reports whether a node is called by a Temporal call, rather than by internal
+// calls of helpers only.
+func executed(node *TemporalNode) bool {
+	for _, ref := range node.CalledBy {
+		if ref.CallType != "internal" {
+			return true
+		}
+	}
+	return false
+}
+
+// confidenceFor returns the confidence of a node detected for the given reasons, or "" if
+// there are none.
+func confidenceFor(reasons []string) string {
+	switch {
+	case len(reasons) == 0:
+		return ""
+	case containsReason(reasons, ReasonRegistered):
+		return ConfidenceHigh
+	case containsReason(reasons, ReasonSignature) && containsReason(reasons, ReasonExecuted):
+		return ConfidenceHigh
+	case containsReason(reasons, ReasonSignature) || containsReason(reasons, ReasonExecuted):
+		return ConfidenceMedium
+	default:
+		return ConfidenceLow
+	}
+}
+
+func containsReason(reasons []string, reason string) bool {
+	for _, r := range reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByConfidence returns a graph containing only the nodes detected with at least the
+// given confidence. Nodes are shared with the input graph; stats are not recalculated.
+func FilterByConfidence(graph *TemporalGraph, min string) *TemporalGraph {
+	filtered := &TemporalGraph{
+		Nodes:       make(map[string]*TemporalNode),
+		Stats:       graph.Stats,
+		Workers:     graph.Workers,
+		EntryPoints: graph.EntryPoints,
+		Messages:    graph.Messages,
+		WorkflowIDs: graph.WorkflowIDs,
+		FileErrors:  graph.FileErrors,
+		Engine:      graph.Engine,
+	}
+	for name, node := range graph.Nodes {
+		if node.ConfidenceAtLeast(min) {
+			filtered.Nodes[name] = node
+		}
+	}
+	return filtered
+}
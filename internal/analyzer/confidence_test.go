@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+const confidenceSources = `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func OrderWorkflow(ctx workflow.Context) error {
+	if err := workflow.ExecuteActivity(ctx, Charge).Get(ctx, nil); err != nil {
+		return err
+	}
+	workflow.ExecuteActivity(ctx, SendEmail)
+	return workflow.ExecuteChildWorkflow(ctx, ShippingWorkflow).Get(ctx, nil)
+}
+
+func ShippingWorkflow(ctx workflow.Context) error {
+	return workflow.Sleep(ctx, 0)
+}
+
+func DraftWorkflow(ctx workflow.Context) error {
+	return workflow.Sleep(ctx, 0)
+}
+
+func Charge(ctx context.Context) error { return nil }
+
+func register(worker worker.Worker) {
+	worker.RegisterWorkflow(OrderWorkflow)
+	worker.RegisterActivity(Charge)
+}
+`
+
+func TestAnalyzeDetectionConfidence(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "orders.go"), []byte(confidenceSources), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	tests := []struct {
+		node       string
+		confidence string
+		reasons    []string
+	}{
+		{"OrderWorkflow", ConfidenceHigh, []string{ReasonRegistered, ReasonSignature}},
+		{"Charge", ConfidenceHigh, []string{ReasonRegistered, ReasonExecuted}},
+		{"ShippingWorkflow", ConfidenceHigh, []string{ReasonSignature, ReasonExecuted}},
+		{"DraftWorkflow", ConfidenceMedium, []string{ReasonSignature}},
+		{"SendEmail", ConfidenceLow, []string{ReasonNameOnly}},
+	}
+	for _, tt := range tests {
+		node, ok := graph.Nodes[tt.node]
+		if !ok {
+			t.Errorf("Node %s not found", tt.node)
+			continue
+		}
+		if node.DetectionConfidence != tt.confidence {
+			t.Errorf("%s: DetectionConfidence = %q, want %q", tt.node, node.DetectionConfidence, tt.confidence)
+		}
+		if !reflect.DeepEqual(node.DetectionReasons, tt.reasons) {
+			t.Errorf("%s: DetectionReasons = %v, want %v", tt.node, node.DetectionReasons, tt.reasons)
+		}
+	}
+
+	filtered, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir, MinConfidence: ConfidenceHigh})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	for _, name := range []string{"DraftWorkflow", "SendEmail"} {
+		if _, ok := filtered.Nodes[name]; ok {
+			t.Errorf("%s should be filtered out by MinConfidence high", name)
+		}
+	}
+	if len(filtered.Nodes) != 3 {
+		t.Errorf("Expected 3 high-confidence nodes, got %d", len(filtered.Nodes))
+	}
+}
+
+func TestConfidenceAtLeast(t *testing.T) {
+	tests := []struct {
+		confidence, min string
+		want            bool
+	}{
+		{ConfidenceHigh, ConfidenceMedium, true},
+		{ConfidenceMedium, ConfidenceMedium, true},
+		{ConfidenceLow, ConfidenceMedium, false},
+		{ConfidenceMedium, ConfidenceHigh, false},
+		{"", ConfidenceHigh, true},
+	}
+	for _, tt := range tests {
+		node := &TemporalNode{DetectionConfidence: tt.confidence}
+		if got := node.ConfidenceAtLeast(tt.min); got != tt.want {
+			t.Errorf("ConfidenceAtLeast(%q) with %q = %v, want %v", tt.min, tt.confidence, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// messageSource is a variable a consumer reads messages from.
+type messageSource struct {
+	kind  string // "partition", "reader" or "subscription"
+	topic string
+}
+
+// readMethods are the kafka-go Reader methods a consumer loop reads messages with.
+var readMethods = map[string]bool{"ReadMessage": true, "FetchMessage": true}
+
+// scanConsumers records the message consumers a function runs:
+//   - sarama consumer groups, group.Consume(ctx, []string{"orders"}, handler), handled by the
+//     ConsumeClaim method of the handler
+//   - sarama partition consumers, ranging over the Messages() of ConsumePartition("orders", ...)
+//   - kafka-go readers, looping over the ReadMessage or FetchMessage of
+//     kafka.NewReader(kafka.ReaderConfig{Topic: "orders"})
+//   - Google Cloud Pub/Sub subscriptions, client.Subscription("orders-sub").Receive(ctx, handler)
+//
+// Topics and subscriptions are string literals or constants.
+func (s *entryPointScanner) scanConsumers(fn *ast.FuncDecl, fset *token.FileSet, filePath string) {
+	vars := make(map[string]ast.Expr)         // variable -> last value assigned
+	sources := make(map[string]messageSource) // variable -> what it reads messages from
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				var rhs ast.Expr
+				switch {
+				case len(node.Rhs) == len(node.Lhs):
+					rhs = node.Rhs[i]
+				case i == 0 && len(node.Rhs) == 1:
+					rhs = node.Rhs[0] // pc, err := consumer.ConsumePartition(...)
+				default:
+					continue
+				}
+				vars[ident.Name] = rhs
+				if source, ok := consumerSource(rhs); ok {
+					sources[ident.Name] = source
+				}
+			}
+
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			switch {
+			case sel.Sel.Name == "Consume" && len(node.Args) == 3:
+				topics := consumedTopics(node.Args[1], vars)
+				if len(topics) == 0 {
+					return true
+				}
+				handlerType := handlerTypeName(node.Args[2], vars)
+				name := "ConsumeClaim"
+				if handlerType != "" {
+					name = handlerType + ".ConsumeClaim"
+				}
+				s.routes = append(s.routes, route{
+					kind:          "kafka",
+					topics:        topics,
+					handlerMethod: "ConsumeClaim",
+					receiver:      handlerType,
+					name:          name,
+					filePath:      filePath,
+					lineNumber:    fset.Position(node.Pos()).Line,
+				})
+			case sel.Sel.Name == "Receive" && len(node.Args) == 2:
+				subscription := ""
+				if call, ok := sel.X.(*ast.CallExpr); ok {
+					if source, ok := consumerSource(call); ok && source.kind == "subscription" {
+						subscription = source.topic
+					}
+				} else if ident, ok := sel.X.(*ast.Ident); ok && sources[ident.Name].kind == "subscription" {
+					subscription = sources[ident.Name].topic
+				}
+				if subscription == "" {
+					return true
+				}
+				s.routes = append(s.routes, route{
+					kind:       "pubsub",
+					topics:     []string{subscription},
+					handler:    node.Args[1],
+					name:       handlerName(node.Args[1]),
+					filePath:   filePath,
+					lineNumber: fset.Position(node.Pos()).Line,
+				})
+			}
+
+		case *ast.RangeStmt:
+			// for msg := range pc.Messages()
+			call, ok := node.X.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Messages" {
+				return true
+			}
+			if ident, ok := sel.X.(*ast.Ident); ok && sources[ident.Name].kind == "partition" {
+				s.addConsumerLoop(fn, node.Body, sources[ident.Name].topic, fset.Position(node.Pos()).Line, filePath)
+			}
+
+		case *ast.ForStmt:
+			// for { m, err := r.ReadMessage(ctx) ... }
+			var topic string
+			ast.Inspect(node.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if sel, ok := call.Fun.(*ast.SelectorExpr); ok && readMethods[sel.Sel.Name] {
+						if ident, ok := sel.X.(*ast.Ident); ok && sources[ident.Name].kind == "reader" {
+							topic = sources[ident.Name].topic
+						}
+					}
+				}
+				return topic == ""
+			})
+			if topic != "" {
+				s.addConsumerLoop(fn, node.Body, topic, fset.Position(node.Pos()).Line, filePath)
+			}
+		}
+		return true
+	})
+}
+
+// addConsumerLoop records a loop consuming the messages of a Kafka topic.
+func (s *entryPointScanner) addConsumerLoop(fn *ast.FuncDecl, body *ast.BlockStmt, topic string, line int, filePath string) {
+	name := fn.Name.Name
+	if fn.Recv != nil && len(fn.Recv.List) == 1 {
+		name = receiverTypeName(fn.Recv.List[0].Type) + "." + name
+	}
+	s.routes = append(s.routes, route{
+		kind:       "kafka",
+		topics:     []string{topic},
+		fn:         newStartingFunc(body),
+		name:       name,
+		filePath:   filePath,
+		lineNumber: line,
+	})
+}
+
+// consumerSource returns what a partition consumer, kafka-go reader or Pub/Sub subscription
+// created by expr reads messages from.
+func consumerSource(expr ast.Expr) (messageSource, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return messageSource{}, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return messageSource{}, false
+	}
+
+	switch {
+	case sel.Sel.Name == "ConsumePartition":
+		if topic := literalString(call.Args[0]); topic != "" {
+			return messageSource{kind: "partition", topic: topic}, true
+		}
+	case sel.Sel.Name == "Subscription":
+		if subscription := literalString(call.Args[0]); subscription != "" {
+			return messageSource{kind: "subscription", topic: subscription}, true
+		}
+	case isPkgCall(call, "kafka", "NewReader"):
+		arg := call.Args[0]
+		if unary, ok := arg.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			arg = unary.X
+		}
+		lit, ok := arg.(*ast.CompositeLit)
+		if !ok {
+			return messageSource{}, false
+		}
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Topic" {
+				if topic := literalString(kv.Value); topic != "" {
+					return messageSource{kind: "reader", topic: topic}, true
+				}
+			}
+		}
+	}
+	return messageSource{}, false
+}
+
+// consumedTopics returns the topics of a sarama consumer group's []string{...} topics
+// argument, or of the variable it was assigned to.
+func consumedTopics(expr ast.Expr, vars map[string]ast.Expr) []string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		expr = vars[ident.Name]
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	if arr, ok := lit.Type.(*ast.ArrayType); !ok || types.ExprString(arr.Elt) != "string" {
+		return nil
+	}
+	var topics []string
+	for _, elt := range lit.Elts {
+		if topic := literalString(elt); topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+// handlerTypeName returns the type of a consumer group handler created with a composite
+// literal, directly or through the variable it was assigned to.
+func handlerTypeName(expr ast.Expr, vars map[string]ast.Expr) string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		expr = vars[ident.Name]
+	}
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	if lit, ok := expr.(*ast.CompositeLit); ok {
+		return receiverTypeName(lit.Type)
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+const consumerSources = `package api
+
+import (
+	"cloud.google.com/go/pubsub"
+	"github.com/IBM/sarama"
+	"github.com/segmentio/kafka-go"
+	"go.temporal.io/sdk/client"
+)
+
+const shipmentsTopic = "shipments"
+
+type orderHandler struct{ c client.Client }
+
+func (h *orderHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *orderHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (h *orderHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		h.c.ExecuteWorkflow(session.Context(), client.StartWorkflowOptions{}, OrderWorkflow, string(msg.Value))
+	}
+	return nil
+}
+
+type auditHandler struct{}
+
+func (h auditHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	return nil
+}
+
+func consumeOrders(ctx context.Context, group sarama.ConsumerGroup, c client.Client) {
+	handler := &orderHandler{c: c}
+	topics := []string{"orders", "orders-retry"}
+	for {
+		group.Consume(ctx, topics, handler)
+	}
+}
+
+func consumeAudit(ctx context.Context, group sarama.ConsumerGroup) {
+	group.Consume(ctx, []string{"audit"}, auditHandler{})
+}
+
+func consumeRefunds(consumer sarama.Consumer, c client.Client) {
+	pc, _ := consumer.ConsumePartition("refunds", 0, sarama.OffsetNewest)
+	for msg := range pc.Messages() {
+		startRefund(c, string(msg.Value))
+	}
+}
+
+func startRefund(c client.Client, id string) {
+	c.ExecuteWorkflow(context.Background(), client.StartWorkflowOptions{}, RefundWorkflow, id)
+}
+
+func consumeShipments(ctx context.Context, c client.Client) {
+	r := kafka.NewReader(kafka.ReaderConfig{Brokers: []string{"localhost:9092"}, Topic: shipmentsTopic})
+	for {
+		for {
+			m, err := r.FetchMessage(ctx)
+			if err != nil {
+				return
+			}
+			c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{}, ShipWorkflow, string(m.Value))
+		}
+	}
+}
+
+func receiveOrders(ctx context.Context, ps *pubsub.Client, c client.Client) {
+	sub := ps.Subscription("orders-sub")
+	sub.Receive(ctx, func(ctx context.Context, m *pubsub.Message) {
+		c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{}, "OrderWorkflow")
+		m.Ack()
+	})
+}
+`
+
+func TestAnalyzeConsumerEntryPoints(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"workflows.go": entryPointWorkflows,
+		"consumers.go": consumerSources,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	type key struct{ kind, trigger, handler, workflow string }
+	want := []key{
+		{"kafka", "kafka orders,orders-retry", "orderHandler.ConsumeClaim", "OrderWorkflow"},
+		{"pubsub", "pubsub orders-sub", "func literal", "OrderWorkflow"},
+		{"kafka", "kafka refunds", "consumeRefunds", "RefundWorkflow"},
+		{"kafka", "kafka shipments", "consumeShipments", "ShipWorkflow"},
+	}
+	if len(graph.EntryPoints) != len(want) {
+		t.Fatalf("Expected %d entry points, got %+v", len(want), graph.EntryPoints)
+	}
+	for i, ep := range graph.EntryPoints {
+		if got := (key{ep.Kind, ep.Trigger, ep.Handler, ep.Workflow}); got != want[i] {
+			t.Errorf("Entry point %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// Ways a workflow.Context escapes the workflow function.
+const (
+	// EscapeField is a context stored in a struct field
+	EscapeField = "field"
+	// EscapeGlobal is a context stored in a package variable
+	EscapeGlobal = "global"
+	// EscapeGoroutine is a context used in a goroutine started with the go statement
+	EscapeGoroutine = "goroutine"
+	// EscapeCoroutine is a parent context used inside workflow.Go instead of the coroutine's own
+	EscapeCoroutine = "coroutine"
+)
+
+// extractContextEscapes tracks the workflow.Context parameters of a workflow, and the contexts
+// derived from them with workflow.With* calls or plain assignments, and finds where they escape
+// the workflow's deterministic scope: stored in struct fields or package variables, used by
+// native goroutines, or captured by workflow.Go functions that have their own context.
+func (e *callExtractor) extractContextEscapes(fn *ast.FuncDecl, fset *token.FileSet) []ContextEscape {
+	if fn.Body == nil {
+		return nil
+	}
+	contexts := make(map[string]bool)
+	for name := range workflowContextParams(fn) {
+		contexts[name] = true
+	}
+	if len(contexts) == 0 {
+		return nil
+	}
+	locals := declaredNames(fn)
+
+	var escapes []ContextEscape
+	add := func(kind, target, context string, node ast.Node) {
+		escapes = append(escapes, ContextEscape{
+			Kind:       kind,
+			Target:     target,
+			Context:    context,
+			LineNumber: fset.Position(node.Pos()).Line,
+		})
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				var rhs ast.Expr
+				switch {
+				case len(node.Rhs) == len(node.Lhs):
+					rhs = node.Rhs[i]
+				case i == 0 && len(node.Rhs) == 1:
+					rhs = node.Rhs[0] // ctx, cancel := workflow.WithCancel(ctx)
+				default:
+					continue
+				}
+				context := contextExpr(rhs, contexts)
+				switch target := lhs.(type) {
+				case *ast.Ident:
+					switch {
+					case context != "" && locals[target.Name]:
+						contexts[target.Name] = true
+					case context != "" && target.Name != "_":
+						add(EscapeGlobal, target.Name, context, node)
+					case node.Tok == token.DEFINE || locals[target.Name]:
+						delete(contexts, target.Name)
+					}
+				case *ast.SelectorExpr:
+					if context != "" {
+						add(EscapeField, types.ExprString(target), context, node)
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if i < len(node.Values) && contextExpr(node.Values[i], contexts) != "" {
+					contexts[name.Name] = true
+				}
+			}
+		case *ast.CompositeLit:
+			for _, elt := range node.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if context := contextExpr(kv.Value, contexts); context != "" {
+					target := key.Name
+					if node.Type != nil {
+						target = types.ExprString(node.Type) + "." + key.Name
+					}
+					add(EscapeField, target, context, kv)
+				}
+			}
+		case *ast.GoStmt:
+			if context := usedContext(node.Call, contexts); context != "" {
+				target := "go func()"
+				if _, ok := node.Call.Fun.(*ast.FuncLit); !ok {
+					target = "go " + types.ExprString(node.Call.Fun)
+				}
+				add(EscapeGoroutine, target, context, node)
+			}
+		case *ast.CallExpr:
+			if len(node.Args) == 0 || !isPkgCall(node, "workflow", "Go") && !isPkgCall(node, "workflow", "GoNamed") {
+				return true
+			}
+			lit, ok := node.Args[len(node.Args)-1].(*ast.FuncLit)
+			if !ok {
+				return true
+			}
+			// The function's own context parameter shadows the parent's
+			inner := make(map[string]bool, len(contexts))
+			for name := range contexts {
+				inner[name] = true
+			}
+			for _, field := range lit.Type.Params.List {
+				for _, name := range field.Names {
+					delete(inner, name.Name)
+				}
+			}
+			if context := usedContext(lit.Body, inner); context != "" {
+				add(EscapeCoroutine, "workflow.Go", context, lit)
+			}
+		}
+		return true
+	})
+	return escapes
+}
+
+// contextExpr returns the context variable an expression evaluates to or derives from:
+// a tracked variable, or a workflow.With* or NewDisconnectedContext call on one. It returns
+// "" for other expressions.
+func contextExpr(expr ast.Expr, contexts map[string]bool) string {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		if contexts[x.Name] {
+			return x.Name
+		}
+	case *ast.ParenExpr:
+		return contextExpr(x.X, contexts)
+	case *ast.CallExpr:
+		sel, ok := x.Fun.(*ast.SelectorExpr)
+		if !ok || len(x.Args) == 0 {
+			return ""
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "workflow" {
+			return ""
+		}
+		if strings.HasPrefix(sel.Sel.Name, "With") || sel.Sel.Name == "NewDisconnectedContext" {
+			return contextExpr(x.Args[0], contexts)
+		}
+	}
+	return ""
+}
+
+// usedContext returns the first tracked context variable referenced in node, or "". Field
+// names (x.ctx) and composite literal keys are not references.
+func usedContext(node ast.Node, contexts map[string]bool) string {
+	var found string
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found != "" {
+			return false
+		}
+		switch x := n.(type) {
+		case *ast.SelectorExpr:
+			found = usedContext(x.X, contexts)
+			return false
+		case *ast.KeyValueExpr:
+			found = usedContext(x.Value, contexts)
+			return false
+		case *ast.Ident:
+			if contexts[x.Name] {
+				found = x.Name
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// declaredNames returns the names declared by a function: its receiver, parameters and
+// results, and the variables declared in its body, including those of function literals.
+func declaredNames(fn *ast.FuncDecl) map[string]bool {
+	names := make(map[string]bool)
+	addFields := func(fields *ast.FieldList) {
+		if fields == nil {
+			return
+		}
+		for _, field := range fields.List {
+			for _, name := range field.Names {
+				names[name.Name] = true
+			}
+		}
+	}
+	addFields(fn.Recv)
+	addFields(fn.Type.Params)
+	addFields(fn.Type.Results)
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if node.Tok == token.DEFINE {
+				for _, lhs := range node.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						names[ident.Name] = true
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for _, name := range node.Names {
+				names[name.Name] = true
+			}
+		case *ast.RangeStmt:
+			if node.Tok == token.DEFINE {
+				for _, expr := range []ast.Expr{node.Key, node.Value} {
+					if ident, ok := expr.(*ast.Ident); ok {
+						names[ident.Name] = true
+					}
+				}
+			}
+		case *ast.FuncLit:
+			addFields(node.Type.Params)
+			addFields(node.Type.Results)
+		}
+		return true
+	})
+	return names
+}
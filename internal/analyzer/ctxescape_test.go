@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestExtractContextEscapes(t *testing.T) {
+	code := `package test
+
+var currentCtx workflow.Context
+
+func EscapingWorkflow(ctx workflow.Context, s *state) error {
+	ctx = workflow.WithActivityOptions(ctx, ao)
+	s.ctx = ctx
+	currentCtx = ctx
+	h := &helper{ctx: ctx, name: "h"}
+	child, cancel := workflow.WithCancel(ctx)
+	go process(child)
+	workflow.Go(ctx, func(gctx workflow.Context) {
+		_ = workflow.Sleep(ctx, time.Second)
+	})
+	workflow.Go(ctx, func(gctx workflow.Context) {
+		_ = workflow.Sleep(gctx, time.Second)
+		_ = s.ctx
+	})
+	local := ctx
+	_ = local
+	_ = h
+	cancel()
+	return nil
+}
+
+func SafeWorkflow(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, ao)
+	c := context.Background()
+	go func() { _ = c }()
+	other := helper{name: "ctx"}
+	_ = other
+	return nil
+}
+`
+	details := extractTestFunc(t, code, "EscapingWorkflow")
+
+	want := []ContextEscape{
+		{Kind: EscapeField, Target: "s.ctx", Context: "ctx", LineNumber: 7},
+		{Kind: EscapeGlobal, Target: "currentCtx", Context: "ctx", LineNumber: 8},
+		{Kind: EscapeField, Target: "helper.ctx", Context: "ctx", LineNumber: 9},
+		{Kind: EscapeGoroutine, Target: "go process", Context: "child", LineNumber: 11},
+		{Kind: EscapeCoroutine, Target: "workflow.Go", Context: "ctx", LineNumber: 12},
+	}
+	if len(details.ContextEscapes) != len(want) {
+		t.Fatalf("Expected %d context escapes, got %+v", len(want), details.ContextEscapes)
+	}
+	for i, w := range want {
+		if details.ContextEscapes[i] != w {
+			t.Errorf("ContextEscapes[%d] = %+v, want %+v", i, details.ContextEscapes[i], w)
+		}
+	}
+
+	if details := extractTestFunc(t, code, "SafeWorkflow"); len(details.ContextEscapes) != 0 {
+		t.Errorf("Expected no context escapes, got %+v", details.ContextEscapes)
+	}
+}
@@ -0,0 +1,114 @@
+package analyzer
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// DomainCoupling counts the calls from nodes of one business domain to nodes of another.
+type DomainCoupling struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Calls int    `json:"calls"`
+}
+
+// AssignDomains sets the domain of each node from the first mapping matching its package.
+// Nodes matching no mapping keep an empty domain.
+func AssignDomains(graph *TemporalGraph, rootDir string, mappings []config.DomainMapping) {
+	for _, node := range graph.Nodes {
+		node.Domain = ""
+		dir := packageDir(rootDir, node.FilePath)
+		for _, m := range mappings {
+			if matchDomainPattern(m.Pattern, dir, node.Package) {
+				node.Domain = m.Domain
+				break
+			}
+		}
+	}
+}
+
+// MatchesPackagePattern reports whether the package of a file matches a domain-style glob:
+// patterns are matched against the file's directory relative to rootDir and, without
+// slashes, also against the package name.
+func MatchesPackagePattern(pattern, rootDir, filePath, pkg string) bool {
+	return matchDomainPattern(pattern, packageDir(rootDir, filePath), pkg)
+}
+
+// packageDir returns the slash-separated directory of a file relative to the root.
+func packageDir(rootDir, filePath string) string {
+	dir := filepath.Dir(filePath)
+	if rel, err := filepath.Rel(rootDir, dir); err == nil {
+		dir = rel
+	}
+	return filepath.ToSlash(dir)
+}
+
+// matchDomainPattern matches a glob against a package directory or, for patterns without
+// slashes, the package name.
+func matchDomainPattern(pattern, dir, pkg string) bool {
+	if !strings.Contains(pattern, "/") {
+		if ok, _ := path.Match(pattern, pkg); ok {
+			return true
+		}
+	}
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(dir, "/"))
+}
+
+// matchGlobSegments matches path segments, where a "**" segment matches zero or more segments.
+func matchGlobSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchGlobSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return matchGlobSegments(pattern[1:], segments[1:])
+}
+
+// calculateDomainCoupling counts calls between nodes of different domains, most coupled pairs first.
+// Calls to or from nodes without a domain are not counted.
+func calculateDomainCoupling(graph *TemporalGraph) []DomainCoupling {
+	counts := make(map[[2]string]int)
+	for _, node := range graph.Nodes {
+		if node.Domain == "" {
+			continue
+		}
+		for _, call := range node.CallSites {
+			target, ok := graph.Nodes[call.TargetName]
+			if !ok || target.Domain == "" || target.Domain == node.Domain {
+				continue
+			}
+			counts[[2]string{node.Domain, target.Domain}]++
+		}
+	}
+
+	coupling := make([]DomainCoupling, 0, len(counts))
+	for pair, calls := range counts {
+		coupling = append(coupling, DomainCoupling{From: pair[0], To: pair[1], Calls: calls})
+	}
+	sort.Slice(coupling, func(i, j int) bool {
+		if coupling[i].Calls != coupling[j].Calls {
+			return coupling[i].Calls > coupling[j].Calls
+		}
+		if coupling[i].From != coupling[j].From {
+			return coupling[i].From < coupling[j].From
+		}
+		return coupling[i].To < coupling[j].To
+	})
+	return coupling
+}
@@ -0,0 +1,70 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+func TestMatchDomainPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		dir     string
+		pkg     string
+		want    bool
+	}{
+		{pattern: "services/payments/**", dir: "services/payments", pkg: "payments", want: true},
+		{pattern: "services/payments/**", dir: "services/payments/stripe/v2", pkg: "v2", want: true},
+		{pattern: "services/payments/**", dir: "services/orders", pkg: "orders", want: false},
+		{pattern: "services/*", dir: "services/orders", pkg: "orders", want: true},
+		{pattern: "services/*", dir: "services/orders/db", pkg: "db", want: false},
+		{pattern: "**/billing", dir: "internal/core/billing", pkg: "billing", want: true},
+		{pattern: "order*", dir: "internal/orders", pkg: "orders", want: true},
+		{pattern: "orders", dir: "orders", pkg: "main", want: true},
+		{pattern: "orders", dir: "shipping", pkg: "shipping", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := matchDomainPattern(tt.pattern, tt.dir, tt.pkg); got != tt.want {
+			t.Errorf("matchDomainPattern(%q, %q, %q) = %v, want %v", tt.pattern, tt.dir, tt.pkg, got, tt.want)
+		}
+	}
+}
+
+func TestAssignDomains(t *testing.T) {
+	graph := &TemporalGraph{
+		Nodes: map[string]*TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders", FilePath: "/repo/services/orders/workflow.go",
+				CallSites: []CallSite{{TargetName: "Charge"}, {TargetName: "Refund"}, {TargetName: "Reserve"}, {TargetName: "Notify"}}},
+			"Reserve": {Name: "Reserve", Type: "activity", Package: "orders", FilePath: "/repo/services/orders/activities.go"},
+			"Charge":  {Name: "Charge", Type: "activity", Package: "stripe", FilePath: "/repo/services/payments/stripe/charge.go"},
+			"Refund": {Name: "Refund", Type: "activity", Package: "stripe", FilePath: "/repo/services/payments/stripe/refund.go",
+				CallSites: []CallSite{{TargetName: "Reserve"}}},
+			"Notify": {Name: "Notify", Type: "activity", Package: "notify", FilePath: "/repo/notify/notify.go"},
+		},
+	}
+	mappings := []config.DomainMapping{
+		{Pattern: "services/payments/**", Domain: "Payments"},
+		{Pattern: "services/**", Domain: "Core"},
+		{Pattern: "orders", Domain: "Orders"}, // shadowed by services/**
+	}
+
+	AssignDomains(graph, "/repo", mappings)
+
+	want := map[string]string{"OrderWorkflow": "Core", "Reserve": "Core", "Charge": "Payments", "Refund": "Payments", "Notify": ""}
+	for name, domain := range want {
+		if got := graph.Nodes[name].Domain; got != domain {
+			t.Errorf("%s domain = %q, want %q", name, got, domain)
+		}
+	}
+
+	coupling := calculateDomainCoupling(graph)
+	wantCoupling := []DomainCoupling{
+		{From: "Core", To: "Payments", Calls: 2},
+		{From: "Payments", To: "Core", Calls: 1},
+	}
+	if !reflect.DeepEqual(coupling, wantCoupling) {
+		t.Errorf("calculateDomainCoupling() = %+v, want %+v", coupling, wantCoupling)
+	}
+}
@@ -0,0 +1,327 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// durationUnits are the duration constants of the time package.
+var durationUnits = map[string]time.Duration{
+	"Nanosecond":  time.Nanosecond,
+	"Microsecond": time.Microsecond,
+	"Millisecond": time.Millisecond,
+	"Second":      time.Second,
+	"Minute":      time.Minute,
+	"Hour":        time.Hour,
+}
+
+// maxConstantDepth bounds constant chains (const a = b; const b = 2 * time.Second) followed
+// when evaluating durations.
+const maxConstantDepth = 8
+
+// PackageConstants maps the constants declared in a package (const retryWindow = 5 * time.Minute)
+// to their value expressions, so durations and retry attempts referring to them can be evaluated.
+type PackageConstants map[string]ast.Expr
+
+// EvalDuration evaluates a constant duration expression as stored in options, such as
+// "10 * time.Minute" or "time.Duration(90) * time.Second". It returns false for expressions
+// depending on variables, function calls or other non-constant values.
+func EvalDuration(expr string) (time.Duration, bool) {
+	return PackageConstants(nil).Eval(expr)
+}
+
+// Eval evaluates a duration expression like EvalDuration, with the package's constants resolved.
+func (c PackageConstants) Eval(expr string) (time.Duration, bool) {
+	if expr == "" {
+		return 0, false
+	}
+	e, err := parser.ParseExpr(expr)
+	if err != nil {
+		return 0, false
+	}
+	value, ok := c.eval(e, 0)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(value), true
+}
+
+// EvalInt evaluates a constant 32-bit integer expression, such as a MaximumAttempts of
+// "maxAttempts + 1", with the package's constants resolved.
+func (c PackageConstants) EvalInt(expr string) (int, bool) {
+	e, err := parser.ParseExpr(expr)
+	if err != nil {
+		return 0, false
+	}
+	value, ok := c.eval(e, 0)
+	if !ok || value != math.Trunc(value) || math.Abs(value) > math.MaxInt32 {
+		return 0, false
+	}
+	return int(value), true
+}
+
+// EvalString evaluates a constant string expression, such as a signal name of
+// "signalPrefix + \"cancel\"", with the package's constants resolved.
+func (c PackageConstants) EvalString(expr string) (string, bool) {
+	e, err := parser.ParseExpr(expr)
+	if err != nil {
+		return "", false
+	}
+	return c.evalString(e, 0)
+}
+
+// evalString evaluates a constant expression of string literals, constants and concatenations.
+func (c PackageConstants) evalString(expr ast.Expr, depth int) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.Ident:
+		value, ok := c[e.Name]
+		if !ok || depth >= maxConstantDepth {
+			return "", false
+		}
+		return c.evalString(value, depth+1)
+	case *ast.ParenExpr:
+		return c.evalString(e.X, depth)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		x, ok := c.evalString(e.X, depth)
+		if !ok {
+			return "", false
+		}
+		y, ok := c.evalString(e.Y, depth)
+		return x + y, ok
+	}
+	return "", false
+}
+
+// collectConstants records the constants declared with a value in a file. Constants of
+// blocks repeating the previous value (iota) are skipped.
+func (c PackageConstants) collectConstants(file *ast.File) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Values) == len(vs.Names) {
+				for i, name := range vs.Names {
+					c[name.Name] = vs.Values[i]
+				}
+			}
+		}
+	}
+}
+
+// eval evaluates a constant expression of numbers, time units and constants, in nanoseconds.
+func (c PackageConstants) eval(expr ast.Expr, depth int) (float64, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.INT && e.Kind != token.FLOAT {
+			return 0, false
+		}
+		value, err := strconv.ParseFloat(e.Value, 64)
+		if err != nil {
+			if i, err := strconv.ParseInt(e.Value, 0, 64); err == nil {
+				return float64(i), true
+			}
+			return 0, false
+		}
+		return value, true
+	case *ast.Ident:
+		value, ok := c[e.Name]
+		if !ok || depth >= maxConstantDepth {
+			return 0, false
+		}
+		return c.eval(value, depth+1)
+	case *ast.ParenExpr:
+		return c.eval(e.X, depth)
+	case *ast.SelectorExpr:
+		pkg, ok := e.X.(*ast.Ident)
+		if !ok || pkg.Name != "time" {
+			return 0, false
+		}
+		unit, ok := durationUnits[e.Sel.Name]
+		return float64(unit), ok
+	case *ast.UnaryExpr:
+		value, ok := c.eval(e.X, depth)
+		switch {
+		case !ok:
+			return 0, false
+		case e.Op == token.SUB:
+			return -value, true
+		case e.Op == token.ADD:
+			return value, true
+		}
+		return 0, false
+	case *ast.CallExpr:
+		// time.Duration(n) conversions
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok && len(e.Args) == 1 {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "time" && sel.Sel.Name == "Duration" {
+				return c.eval(e.Args[0], depth)
+			}
+		}
+		return 0, false
+	case *ast.BinaryExpr:
+		x, ok := c.eval(e.X, depth)
+		if !ok {
+			return 0, false
+		}
+		y, ok := c.eval(e.Y, depth)
+		if !ok {
+			return 0, false
+		}
+		switch e.Op {
+		case token.MUL:
+			return x * y, true
+		case token.QUO:
+			if y == 0 {
+				return 0, false
+			}
+			return x / y, true
+		case token.ADD:
+			return x + y, true
+		case token.SUB:
+			return x - y, true
+		}
+	}
+	return 0, false
+}
+
+// value evaluates a duration expression, returning 0 when it is not constant.
+func (c PackageConstants) value(expr string) time.Duration {
+	d, _ := c.Eval(expr)
+	return d
+}
+
+// resolveConstants stores the values of the constant activity timeouts, retry attempts,
+// timer durations and message names of a node, evaluated with the constants of its package.
+func (c PackageConstants) resolveConstants(node *TemporalNode) {
+	for i := range node.CallSites {
+		opts := node.CallSites[i].ParsedActivityOpts
+		if opts == nil {
+			continue
+		}
+		opts.ScheduleToStartValue = c.value(opts.ScheduleToStartTimeout)
+		opts.StartToCloseValue = c.value(opts.StartToCloseTimeout)
+		opts.HeartbeatValue = c.value(opts.HeartbeatTimeout)
+		opts.ScheduleToCloseValue = c.value(opts.ScheduleToCloseTimeout)
+		if rp := opts.RetryPolicy; rp != nil && rp.MaximumAttemptsUnresolved {
+			if attempts, ok := c.EvalInt(rp.MaximumAttemptsExpr); ok {
+				rp.MaximumAttempts, rp.MaximumAttemptsUnresolved = attempts, false
+			}
+		}
+	}
+	for i := range node.Timers {
+		node.Timers[i].Value = c.value(node.Timers[i].Duration)
+	}
+	for i := range node.Signals {
+		c.resolveName(&node.Signals[i].Name, node.Signals[i].NameExpr)
+	}
+	for i := range node.Queries {
+		c.resolveName(&node.Queries[i].Name, node.Queries[i].NameExpr)
+	}
+	for i := range node.Updates {
+		c.resolveName(&node.Updates[i].Name, node.Updates[i].NameExpr)
+	}
+}
+
+// resolveName sets a signal, query or update name from its constant expression.
+func (c PackageConstants) resolveName(name *string, expr string) {
+	if *name != "" || expr == "" {
+		return
+	}
+	if value, ok := c.EvalString(expr); ok {
+		*name = value
+	}
+}
+
+// ActivityTimeout is a StartToClose or ScheduleToClose timeout set at an activity call site.
+type ActivityTimeout struct {
+	// Node is the node making the call
+	Node     *TemporalNode
+	CallSite CallSite
+	// Option is "StartToCloseTimeout" or "ScheduleToCloseTimeout"
+	Option string
+	// Expr is the timeout as written
+	Expr string
+	// Duration is the value of Expr, valid if Evaluated is true
+	Duration  time.Duration
+	Evaluated bool
+}
+
+// ActivityTimeouts returns the StartToClose and ScheduleToClose timeouts set at the
+// activity and local activity call sites of the graph, sorted by file and line.
+func (g *TemporalGraph) ActivityTimeouts() []ActivityTimeout {
+	var timeouts []ActivityTimeout
+	for _, node := range g.Nodes {
+		for _, cs := range node.CallSites {
+			opts := cs.ParsedActivityOpts
+			executed := executedType(cs)
+			if opts == nil || executed != "activity" && executed != "local_activity" {
+				continue
+			}
+			for _, option := range []struct {
+				name, expr string
+				value      time.Duration
+			}{
+				{"StartToCloseTimeout", opts.StartToCloseTimeout, opts.StartToCloseValue},
+				{"ScheduleToCloseTimeout", opts.ScheduleToCloseTimeout, opts.ScheduleToCloseValue},
+			} {
+				if option.expr == "" {
+					continue
+				}
+				// Values are resolved with package constants by the graph builder; graphs
+				// built otherwise are evaluated from the expression alone
+				d, ok := option.value, option.value != 0
+				if !ok {
+					d, ok = EvalDuration(option.expr)
+				}
+				timeouts = append(timeouts, ActivityTimeout{
+					Node:      node,
+					CallSite:  cs,
+					Option:    option.name,
+					Expr:      option.expr,
+					Duration:  d,
+					Evaluated: ok,
+				})
+			}
+		}
+	}
+	sort.SliceStable(timeouts, func(i, j int) bool {
+		a, b := timeouts[i], timeouts[j]
+		if a.CallSite.FilePath != b.CallSite.FilePath {
+			return a.CallSite.FilePath < b.CallSite.FilePath
+		}
+		if a.CallSite.LineNumber != b.CallSite.LineNumber {
+			return a.CallSite.LineNumber < b.CallSite.LineNumber
+		}
+		return a.Option > b.Option
+	})
+	return timeouts
+}
+
+// MedianDuration returns the median of durations, or 0 if there are none.
+func MedianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
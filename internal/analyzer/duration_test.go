@@ -0,0 +1,242 @@
+package analyzer
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+func TestEvalDuration(t *testing.T) {
+	tests := []struct {
+		expr   string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"10 * time.Minute", 10 * time.Minute, true},
+		{"time.Minute * 10", 10 * time.Minute, true},
+		{"time.Hour", time.Hour, true},
+		{"1.5 * time.Hour", 90 * time.Minute, true},
+		{"time.Duration(90) * time.Second", 90 * time.Second, true},
+		{"(2 + 3) * time.Second", 5 * time.Second, true},
+		{"time.Hour / 4", 15 * time.Minute, true},
+		{"time.Hour - 30*time.Minute", 30 * time.Minute, true},
+		{"0x10 * time.Second", 16 * time.Second, true},
+		{"timeout", 0, false},
+		{"cfg.Timeout * time.Second", 0, false},
+		{"time.Since(start)", 0, false},
+		{"time.Hour / 0", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := EvalDuration(tt.expr)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("EvalDuration(%q) = %v, %v; want %v, %v", tt.expr, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestPackageConstantsEval(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "timeouts.go", `package orders
+
+import "time"
+
+const chargeTimeout = 5 * time.Minute
+
+const (
+	retryWindow, maxAttempts = 2 * chargeTimeout, 3
+	loop                     = loop
+)
+
+const (
+	low = iota
+	high
+)
+`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	constants := make(PackageConstants)
+	constants.collectConstants(file)
+
+	tests := []struct {
+		expr   string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"chargeTimeout", 5 * time.Minute, true},
+		{"retryWindow + time.Minute", 11 * time.Minute, true},
+		{"maxAttempts * time.Second", 3 * time.Second, true},
+		{"loop", 0, false},
+		{"high * time.Second", 0, false},
+		{"unknown", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := constants.Eval(tt.expr)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Eval(%q) = %v, %v; want %v, %v", tt.expr, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestAnalyzeResolvesConstants(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"timeouts.go": "package orders\n\nimport \"time\"\n\nconst chargeTimeout = 5 * time.Minute\n\nconst maxAttempts = 3\n",
+		"orders.go": `package orders
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+func OrderWorkflow(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: chargeTimeout,
+		HeartbeatTimeout:    time.Duration(30) * time.Second,
+		ScheduleToCloseTimeout: timeoutFor(ctx),
+		RetryPolicy: &temporal.RetryPolicy{MaximumAttempts: maxAttempts + 1},
+	})
+	if err := workflow.ExecuteActivity(ctx, Charge).Get(ctx, nil); err != nil {
+		return err
+	}
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{MaximumAttempts: 0},
+	})
+	if err := workflow.ExecuteActivity(ctx, Ship).Get(ctx, nil); err != nil {
+		return err
+	}
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{MaximumAttempts: cfg.Attempts},
+	})
+	if err := workflow.ExecuteActivity(ctx, Email).Get(ctx, nil); err != nil {
+		return err
+	}
+	return workflow.Sleep(ctx, (1 + 1) * time.Hour)
+}
+`,
+	})
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	node, ok := graph.Nodes["OrderWorkflow"]
+	if !ok || len(node.CallSites) != 3 || node.CallSites[0].ParsedActivityOpts == nil {
+		t.Fatalf("Expected OrderWorkflow with three activity calls with options, got %+v", node)
+	}
+	opts := node.CallSites[0].ParsedActivityOpts
+	if opts.StartToCloseTimeout != "chargeTimeout" || opts.StartToCloseValue != 5*time.Minute {
+		t.Errorf("StartToClose = %q (%v), want chargeTimeout (5m)", opts.StartToCloseTimeout, opts.StartToCloseValue)
+	}
+	if opts.HeartbeatTimeout != "time.Duration(30) * time.Second" || opts.HeartbeatValue != 30*time.Second {
+		t.Errorf("Heartbeat = %q (%v), want 30s", opts.HeartbeatTimeout, opts.HeartbeatValue)
+	}
+	if opts.ScheduleToCloseTimeout != "timeoutFor(ctx)" || opts.ScheduleToCloseValue != 0 {
+		t.Errorf("ScheduleToClose = %q (%v), want an unevaluated call", opts.ScheduleToCloseTimeout, opts.ScheduleToCloseValue)
+	}
+	if rp := opts.RetryPolicy; rp.MaximumAttemptsExpr != "maxAttempts + 1" || rp.MaximumAttempts != 4 || rp.MaximumAttemptsUnresolved {
+		t.Errorf("RetryPolicy = %+v, want MaximumAttempts 4 from maxAttempts + 1", rp)
+	}
+	if rp := node.CallSites[1].ParsedActivityOpts.RetryPolicy; !rp.MaximumAttemptsSet() || rp.MaximumAttempts != 0 || rp.MaximumAttemptsUnresolved {
+		t.Errorf("RetryPolicy = %+v, want an explicit MaximumAttempts: 0", rp)
+	}
+	if rp := node.CallSites[2].ParsedActivityOpts.RetryPolicy; !rp.MaximumAttemptsUnresolved {
+		t.Errorf("RetryPolicy = %+v, want an unresolved MaximumAttempts", rp)
+	}
+	if len(node.Timers) != 1 || node.Timers[0].Value != 2*time.Hour {
+		t.Errorf("Expected a 2h timer, got %+v", node.Timers)
+	}
+}
+
+func TestAnalyzeResolvesMessageNames(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"orders.go": `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+const (
+	prefix      = "order-"
+	StatusQuery = prefix + "status"
+)
+
+func OrderWorkflow(ctx workflow.Context) error {
+	workflow.SetQueryHandler(ctx, "order-status", handleStatus)
+	workflow.SetQueryHandler(ctx, StatusQuery, func() (string, error) { return "", nil })
+	workflow.SetUpdateHandler(ctx, msgs.Approve, handleApprove)
+	workflow.GetSignalChannel(ctx, prefix+"cancel").Receive(ctx, nil)
+	return workflow.Sleep(ctx, 0)
+}
+`,
+	})
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	node, ok := graph.Nodes["OrderWorkflow"]
+	if !ok || len(node.Queries) != 2 || len(node.Updates) != 1 || len(node.Signals) != 1 {
+		t.Fatalf("Expected OrderWorkflow with two queries, an update and a signal, got %+v", node)
+	}
+	if q := node.Queries[0]; q.Name != "order-status" || q.NameExpr != "" || q.Handler != "handleStatus" {
+		t.Errorf("Queries[0] = %+v, want order-status handled by handleStatus", q)
+	}
+	if q := node.Queries[1]; q.Name != "order-status" || q.NameExpr != "StatusQuery" {
+		t.Errorf("Queries[1] = %+v, want order-status from StatusQuery", q)
+	}
+	if u := node.Updates[0]; u.Name != "" || u.NameExpr != "msgs.Approve" || u.Handler != "handleApprove" {
+		t.Errorf("Updates[0] = %+v, want the unresolved msgs.Approve", u)
+	}
+	if s := node.Signals[0]; s.Name != "order-cancel" {
+		t.Errorf("Signals[0] = %+v, want order-cancel", s)
+	}
+}
+
+func TestActivityTimeouts(t *testing.T) {
+	graph := &TemporalGraph{Nodes: map[string]*TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders", CallSites: []CallSite{
+			{TargetName: "Charge", TargetType: "activity", CallType: "execute", FilePath: "orders.go", LineNumber: 20,
+				ParsedActivityOpts: &ActivityOptions{StartToCloseTimeout: "time.Minute", ScheduleToCloseTimeout: "timeout"}},
+			{TargetName: "ChildWorkflow", TargetType: "workflow", CallType: "execute", FilePath: "orders.go", LineNumber: 30,
+				ParsedActivityOpts: &ActivityOptions{StartToCloseTimeout: "time.Hour"}},
+			{TargetName: "Ship", CallType: "local_activity", FilePath: "orders.go", LineNumber: 10,
+				ParsedActivityOpts: &ActivityOptions{StartToCloseTimeout: "5 * time.Second"}},
+			{TargetName: "Email", TargetType: "activity", FilePath: "orders.go", LineNumber: 40},
+		}},
+	}}
+
+	timeouts := graph.ActivityTimeouts()
+	if len(timeouts) != 3 {
+		t.Fatalf("Expected 3 activity timeouts, got %+v", timeouts)
+	}
+	if timeouts[0].CallSite.TargetName != "Ship" || timeouts[0].Duration != 5*time.Second || !timeouts[0].Evaluated {
+		t.Errorf("Unexpected first timeout: %+v", timeouts[0])
+	}
+	if timeouts[1].Option != "StartToCloseTimeout" || timeouts[1].Duration != time.Minute {
+		t.Errorf("Unexpected second timeout: %+v", timeouts[1])
+	}
+	if timeouts[2].Option != "ScheduleToCloseTimeout" || timeouts[2].Evaluated {
+		t.Errorf("Expected a dynamic ScheduleToCloseTimeout, got %+v", timeouts[2])
+	}
+}
+
+func TestMedianDuration(t *testing.T) {
+	if got := MedianDuration(nil); got != 0 {
+		t.Errorf("MedianDuration(nil) = %v, want 0", got)
+	}
+	if got := MedianDuration([]time.Duration{time.Hour, time.Second, time.Minute}); got != time.Minute {
+		t.Errorf("MedianDuration(odd) = %v, want 1m", got)
+	}
+	if got := MedianDuration([]time.Duration{4 * time.Second, time.Second, 2 * time.Second, 3 * time.Second}); got != 2500*time.Millisecond {
+		t.Errorf("MedianDuration(even) = %v, want 2.5s", got)
+	}
+}
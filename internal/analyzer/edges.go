@@ -0,0 +1,67 @@
+package analyzer
+
+import "sort"
+
+// LoopCallWeight is the weight of a call site inside a loop. Loops run an unknown number of
+// times; a loop-carried call counts as a repeated call rather than a guessed iteration count.
+const LoopCallWeight = 2
+
+// Edge aggregates the call sites of a node to one target with one call type.
+type Edge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	CallType string `json:"call_type,omitempty"`
+	// CallSites is the number of call sites; InLoop counts those inside loops
+	CallSites int `json:"call_sites"`
+	InLoop    int `json:"in_loop,omitempty"`
+	// Weight is the static call multiplicity: the sum of the weights of the call sites
+	Weight int `json:"weight"`
+}
+
+// Weight returns the static multiplicity of a call site: LoopCallWeight inside a loop, 1 otherwise.
+func (c CallSite) Weight() int {
+	if c.LoopLine > 0 {
+		return LoopCallWeight
+	}
+	return 1
+}
+
+// Edges returns the node's calls aggregated by target and call type, in the order of their
+// first call site.
+func (n *TemporalNode) Edges() []Edge {
+	var edges []Edge
+	index := make(map[[2]string]int)
+	for _, call := range n.CallSites {
+		key := [2]string{call.TargetName, call.CallType}
+		i, ok := index[key]
+		if !ok {
+			i = len(edges)
+			index[key] = i
+			edges = append(edges, Edge{From: n.ID(), To: call.TargetName, CallType: call.CallType})
+		}
+		edges[i].CallSites++
+		edges[i].Weight += call.Weight()
+		if call.LoopLine > 0 {
+			edges[i].InLoop++
+		}
+	}
+	return edges
+}
+
+// Edges returns the edges of all nodes, by source node key.
+func (g *TemporalGraph) Edges() []Edge {
+	keys := make([]string, 0, len(g.Nodes))
+	for key := range g.Nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var edges []Edge
+	for _, key := range keys {
+		for _, edge := range g.Nodes[key].Edges() {
+			edge.From = key
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
@@ -0,0 +1,57 @@
+package analyzer
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestEdgesWeighCallMultiplicity(t *testing.T) {
+	code := `package test
+
+func BatchWorkflow(ctx workflow.Context, ids []string) error {
+	workflow.ExecuteActivity(ctx, Validate, ids).Get(ctx, nil)
+	for _, id := range ids {
+		workflow.ExecuteActivity(ctx, Charge, id).Get(ctx, nil)
+	}
+	workflow.ExecuteActivity(ctx, Charge, "fee").Get(ctx, nil)
+	return nil
+}
+`
+	details := extractTestFunc(t, code, "BatchWorkflow")
+	if len(details.CallSites) != 3 {
+		t.Fatalf("Expected 3 call sites, got %+v", details.CallSites)
+	}
+	if details.CallSites[1].LoopLine != 5 {
+		t.Errorf("CallSites[1].LoopLine = %d, want 5", details.CallSites[1].LoopLine)
+	}
+
+	// Calls to the same target with another call type are separate edges
+	callSites := append(details.CallSites, CallSite{TargetName: "Charge", CallType: "signal"})
+	node := &TemporalNode{Name: "BatchWorkflow", Type: "workflow", CallSites: callSites}
+	want := []Edge{
+		{From: "BatchWorkflow", To: "Validate", CallType: "execute", CallSites: 1, Weight: 1},
+		{From: "BatchWorkflow", To: "Charge", CallType: "execute", CallSites: 2, InLoop: 1, Weight: 3},
+		{From: "BatchWorkflow", To: "Charge", CallType: "signal", CallSites: 1, Weight: 1},
+	}
+	edges := node.Edges()
+	if len(edges) != len(want) {
+		t.Fatalf("Expected %d edges, got %+v", len(want), edges)
+	}
+	for i, w := range want {
+		if edges[i] != w {
+			t.Errorf("edges[%d] = %+v, want %+v", i, edges[i], w)
+		}
+	}
+
+	graph := &TemporalGraph{Nodes: map[string]*TemporalNode{"BatchWorkflow": node}}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	if err := NewGraphBuilder(logger, NewCallExtractor(logger)).CalculateStats(context.Background(), graph); err != nil {
+		t.Fatalf("CalculateStats failed: %v", err)
+	}
+	if graph.Stats.TotalConnections != 4 || graph.Stats.DistinctConnections != 3 || graph.Stats.WeightedConnections != 5 {
+		t.Errorf("Connections = %d total, %d distinct, %d weighted; want 4, 3, 5",
+			graph.Stats.TotalConnections, graph.Stats.DistinctConnections, graph.Stats.WeightedConnections)
+	}
+}
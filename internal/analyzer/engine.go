@@ -0,0 +1,23 @@
+package analyzer
+
+// EngineVersion is the version of the analyzer producing graphs; main sets it to the
+// build version.
+var EngineVersion = "dev"
+
+// GraphSchemaVersion is the version of the graph's JSON form. It is bumped when fields
+// are removed, renamed or change meaning; added fields don't bump it.
+const GraphSchemaVersion = 1
+
+// EngineInfo identifies the analysis engine that produced an export, so tools diffing
+// exports of two runs can tell whether differences may come from the engine.
+type EngineInfo struct {
+	Version     string `json:"version"`
+	GraphSchema int    `json:"graph_schema"`
+	// RuleSet fingerprints the enabled lint rules and their severities (lint exports only)
+	RuleSet string `json:"rule_set,omitempty"`
+}
+
+// Engine returns the info of the running engine.
+func Engine() *EngineInfo {
+	return &EngineInfo{Version: EngineVersion, GraphSchema: GraphSchemaVersion}
+}
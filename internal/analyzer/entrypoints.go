@@ -0,0 +1,587 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// maxEntryPointDepth is how many function calls deep a handler is traced to the
+// client.ExecuteWorkflow calls it makes.
+const maxEntryPointDepth = 8
+
+// EntryPoint is an external trigger, such as an HTTP route, a gRPC method or a message
+// consumer, whose handler starts a workflow with client.ExecuteWorkflow, directly or through
+// the functions it calls.
+type EntryPoint struct {
+	Kind string `json:"kind"` // "http", "grpc", "kafka", "pubsub"
+	// Trigger names the route, method, topic or subscription, e.g. "POST /orders",
+	// "OrderService/CreateOrder" or "kafka orders"
+	Trigger string `json:"trigger"`
+	// Handler is the function handling the trigger, e.g. createOrder or h.CreateOrder
+	Handler string `json:"handler"`
+	// Workflow is the graph key of the started workflow, or its name if it is not in the graph
+	Workflow   string `json:"workflow"`
+	FilePath   string `json:"file_path"`
+	LineNumber int    `json:"line_number"`
+}
+
+// StartedBy returns the entry points starting the workflow with the given graph key.
+func (g *TemporalGraph) StartedBy(workflow string) []EntryPoint {
+	var entryPoints []EntryPoint
+	for _, ep := range g.EntryPoints {
+		if ep.Workflow == workflow {
+			entryPoints = append(entryPoints, ep)
+		}
+	}
+	return entryPoints
+}
+
+// EntryPointInfo holds the entry points found in a directory, with workflows named as
+// written in the code that starts them.
+type EntryPointInfo struct {
+	EntryPoints []EntryPoint
+}
+
+// httpMethods are the route registration methods of gin, echo, chi and fiber routers.
+var httpMethods = map[string]string{
+	"GET": "GET", "POST": "POST", "PUT": "PUT", "PATCH": "PATCH", "DELETE": "DELETE", "HEAD": "HEAD", "OPTIONS": "OPTIONS",
+	"Get": "GET", "Post": "POST", "Put": "PUT", "Patch": "PATCH", "Delete": "DELETE", "Head": "HEAD", "Options": "OPTIONS",
+}
+
+// unimplementedServer matches the Unimplemented<Service>Server types protoc-gen-go-grpc
+// generates for servers to embed.
+var unimplementedServer = regexp.MustCompile(`^Unimplemented(\w+)Server$`)
+
+// entryPointScanner scans for HTTP routes, gRPC server methods and message consumers and
+// traces their handlers to the workflows they start.
+type entryPointScanner struct {
+	logger *slog.Logger
+
+	// funcs maps function and method names to what their bodies start and call
+	funcs map[string][]*startingFunc
+	// typeMethods maps Type.Method names to what the methods start and call
+	typeMethods map[string]*startingFunc
+	// routes are the registered triggers, in scan order
+	routes []route
+	// grpcServers maps server types to the gRPC service they implement
+	grpcServers map[string]string
+	// constants maps string constants to their values, to name topics and subscriptions
+	constants map[string]string
+	// methods are the exported methods, candidates for gRPC server methods
+	methods []route
+}
+
+// startingFunc is what a function body starts and calls, by name.
+type startingFunc struct {
+	starts  []string
+	callees []string
+}
+
+// route is a trigger and the handler registered for it: a handler expression, a body such
+// as a gRPC method or a consumer loop, or a method of a handler type.
+type route struct {
+	kind    string
+	trigger string
+	handler ast.Expr
+	fn      *startingFunc
+	// handlerMethod is the method of the receiver type that handles the trigger, e.g. ConsumeClaim
+	handlerMethod string
+	// topics are the topics or subscription of a consumer, as written in the code
+	topics     []string
+	name       string // handler name for reports
+	receiver   string // receiver type of a gRPC server method or handler type
+	filePath   string
+	lineNumber int
+}
+
+// NewEntryPointScanner creates a new entry point scanner.
+func NewEntryPointScanner(logger *slog.Logger) *entryPointScanner {
+	return &entryPointScanner{
+		logger:      logger,
+		funcs:       make(map[string][]*startingFunc),
+		typeMethods: make(map[string]*startingFunc),
+		grpcServers: make(map[string]string),
+		constants:   make(map[string]string),
+	}
+}
+
+// ScanDirectory scans all Go files in a directory for entry points starting workflows.
+func (s *entryPointScanner) ScanDirectory(ctx context.Context, rootDir string, opts config.AnalysisOptions) (*EntryPointInfo, error) {
+	fset := token.NewFileSet()
+
+	modules := NewModuleFilter(rootDir, opts)
+	err := filepath.Walk(rootDir, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			s.logger.Warn("Error accessing path during entry point scan", "path", path, "error", err)
+			return nil // Continue walking
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if fileInfo.IsDir() {
+			if opts.ExcludesDir(fileInfo.Name()) {
+				return filepath.SkipDir
+			}
+			if modules.SkipDir(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			s.logger.Warn("Error parsing file for entry points", "path", path, "error", err)
+			return nil
+		}
+
+		if err := recovered(func() { s.scanFile(ctx, file, fset, path) }); err != nil {
+			s.logger.Warn("Error scanning file for entry points", "path", path, "error", err)
+			recordFileError(ctx, StageEntryPoints, path, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info := &EntryPointInfo{}
+	seen := make(map[EntryPoint]bool)
+	routes := s.routes
+	for _, m := range s.methods {
+		if service, ok := s.grpcServers[m.receiver]; ok {
+			m.trigger = service + "/" + m.trigger
+			routes = append(routes, m)
+		}
+	}
+	for _, r := range routes {
+		if len(r.topics) > 0 {
+			topics := make([]string, len(r.topics))
+			for i, topic := range r.topics {
+				topics[i] = topic
+				if value, ok := s.constants[topic]; ok {
+					topics[i] = value
+				}
+			}
+			r.trigger = r.kind + " " + strings.Join(topics, ",")
+		}
+
+		var handlers []*startingFunc
+		switch {
+		case r.fn != nil:
+			handlers = []*startingFunc{r.fn}
+		case r.handlerMethod != "":
+			if fn, ok := s.typeMethods[r.receiver+"."+r.handlerMethod]; ok {
+				handlers = []*startingFunc{fn}
+			} else {
+				handlers = s.funcs[r.handlerMethod]
+			}
+		default:
+			handlers = s.handlerFuncs(r.handler)
+		}
+		for _, workflow := range s.trace(handlers) {
+			ep := EntryPoint{
+				Kind:       r.kind,
+				Trigger:    r.trigger,
+				Handler:    r.name,
+				Workflow:   workflow,
+				FilePath:   r.filePath,
+				LineNumber: r.lineNumber,
+			}
+			// Nested consumer loops reading the same topic are one entry point
+			key := ep
+			key.LineNumber = 0
+			if !seen[key] {
+				seen[key] = true
+				info.EntryPoints = append(info.EntryPoints, ep)
+			}
+		}
+	}
+
+	s.logger.Info("Scanned for entry points", "entry_points", len(info.EntryPoints))
+
+	return info, nil
+}
+
+// scanFile records the functions, routes, consumers and gRPC servers of a single file.
+func (s *entryPointScanner) scanFile(ctx context.Context, file *ast.File, fset *token.FileSet, filePath string) {
+	for _, decl := range file.Decls {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			s.scanServerTypes(d)
+			s.scanConstants(d)
+		case *ast.FuncDecl:
+			if d.Body == nil {
+				continue
+			}
+			fn := newStartingFunc(d.Body)
+			s.funcs[d.Name.Name] = append(s.funcs[d.Name.Name], fn)
+			s.scanRoutes(d.Body, fset, filePath)
+			s.scanConsumers(d, fset, filePath)
+
+			if d.Recv == nil || len(d.Recv.List) != 1 {
+				continue
+			}
+			s.typeMethods[receiverTypeName(d.Recv.List[0].Type)+"."+d.Name.Name] = fn
+			if d.Name.IsExported() && !strings.HasPrefix(d.Name.Name, "mustEmbed") {
+				s.methods = append(s.methods, route{
+					kind:       "grpc",
+					trigger:    d.Name.Name,
+					fn:         fn,
+					name:       receiverTypeName(d.Recv.List[0].Type) + "." + d.Name.Name,
+					receiver:   receiverTypeName(d.Recv.List[0].Type),
+					filePath:   filePath,
+					lineNumber: fset.Position(d.Pos()).Line,
+				})
+			}
+		}
+	}
+}
+
+// scanServerTypes records the struct types embedding an Unimplemented<Service>Server.
+func (s *entryPointScanner) scanServerTypes(decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		typeSpec, ok := spec.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+		st, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+		for _, field := range st.Fields.List {
+			if len(field.Names) > 0 {
+				continue
+			}
+			if m := unimplementedServer.FindStringSubmatch(receiverTypeName(field.Type)); m != nil {
+				s.grpcServers[typeSpec.Name.Name] = m[1]
+			}
+		}
+	}
+}
+
+// scanConstants records the values of string constants.
+func (s *entryPointScanner) scanConstants(decl *ast.GenDecl) {
+	if decl.Tok != token.CONST {
+		return
+	}
+	for _, spec := range decl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for i, name := range valueSpec.Names {
+			if i >= len(valueSpec.Values) {
+				continue
+			}
+			if value, ok := routePath(valueSpec.Values[i]); ok {
+				s.constants[name.Name] = value
+			}
+		}
+	}
+}
+
+// scanRoutes records the HTTP routes a function body registers: net/http and gorilla/mux
+// HandleFunc and Handle, and the per-method registrations of gin, echo, chi and fiber,
+// including those on route groups.
+func (s *entryPointScanner) scanRoutes(body *ast.BlockStmt, fset *token.FileSet, filePath string) {
+	groups := make(map[string]string) // route group variable -> path prefix
+	seen := make(map[*ast.CallExpr]bool)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			// api := r.Group("/api")
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+				return true
+			}
+			ident, ok := node.Lhs[0].(*ast.Ident)
+			call, isCall := node.Rhs[0].(*ast.CallExpr)
+			if !ok || !isCall || len(call.Args) == 0 {
+				return true
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Group" {
+				if prefix, ok := routePath(call.Args[0]); ok {
+					groups[ident.Name] = groupPrefix(groups, sel.X) + prefix
+				}
+			}
+		case *ast.CallExpr:
+			if seen[node] {
+				return true
+			}
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			method := ""
+			call := node
+			// r.HandleFunc("/orders", h).Methods("POST")
+			if sel.Sel.Name == "Methods" && len(node.Args) > 0 {
+				inner, ok := sel.X.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				method, _ = routePath(node.Args[0])
+				call = inner
+				seen[inner] = true
+				if sel, ok = inner.Fun.(*ast.SelectorExpr); !ok {
+					return true
+				}
+			}
+
+			var path string
+			var handler ast.Expr
+			switch {
+			case (sel.Sel.Name == "HandleFunc" || sel.Sel.Name == "Handle") && len(call.Args) == 2:
+				p, ok := routePath(call.Args[0])
+				if !ok || !strings.Contains(p, "/") {
+					return true
+				}
+				path, handler = p, call.Args[1]
+				if method != "" {
+					path = method + " " + path
+				}
+			case httpMethods[sel.Sel.Name] != "" && len(call.Args) >= 2:
+				p, ok := routePath(call.Args[0])
+				if !ok || !strings.HasPrefix(p, "/") {
+					return true
+				}
+				path = httpMethods[sel.Sel.Name] + " " + groupPrefix(groups, sel.X) + p
+				handler = call.Args[len(call.Args)-1]
+			default:
+				return true
+			}
+
+			s.routes = append(s.routes, route{
+				kind:       "http",
+				trigger:    path,
+				handler:    handler,
+				name:       handlerName(handler),
+				filePath:   filePath,
+				lineNumber: fset.Position(call.Pos()).Line,
+			})
+		}
+		return true
+	})
+}
+
+// trace returns the workflows the handlers start, following the functions they call by name.
+func (s *entryPointScanner) trace(handlers []*startingFunc) []string {
+	var workflows []string
+	seenWorkflows := make(map[string]bool)
+	visited := make(map[*startingFunc]bool)
+
+	queue := handlers
+	for depth := 0; depth <= maxEntryPointDepth && len(queue) > 0; depth++ {
+		var next []*startingFunc
+		for _, fn := range queue {
+			if visited[fn] {
+				continue
+			}
+			visited[fn] = true
+			for _, workflow := range fn.starts {
+				if !seenWorkflows[workflow] {
+					seenWorkflows[workflow] = true
+					workflows = append(workflows, workflow)
+				}
+			}
+			for _, callee := range fn.callees {
+				next = append(next, s.funcs[callee]...)
+			}
+		}
+		queue = next
+	}
+
+	return workflows
+}
+
+// handlerFuncs returns the function bodies a handler expression refers to: a function
+// literal, a function or method by name, an http.HandlerFunc conversion, or the handler
+// factory a call such as createOrder(client) refers to.
+func (s *entryPointScanner) handlerFuncs(handler ast.Expr) []*startingFunc {
+	switch h := handler.(type) {
+	case *ast.FuncLit:
+		return []*startingFunc{newStartingFunc(h.Body)}
+	case *ast.Ident:
+		return s.funcs[h.Name]
+	case *ast.SelectorExpr:
+		return s.funcs[h.Sel.Name]
+	case *ast.CallExpr:
+		if isPkgCall(h, "http", "HandlerFunc") && len(h.Args) == 1 {
+			return s.handlerFuncs(h.Args[0])
+		}
+		return s.handlerFuncs(h.Fun)
+	case *ast.ParenExpr:
+		return s.handlerFuncs(h.X)
+	}
+	return nil
+}
+
+// newStartingFunc records the workflows a function body starts and the functions it calls.
+func newStartingFunc(body *ast.BlockStmt) *startingFunc {
+	fn := &startingFunc{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			fn.callees = append(fn.callees, fun.Name)
+		case *ast.SelectorExpr:
+			if workflow := startedWorkflow(fun.Sel.Name, call); workflow != "" {
+				fn.starts = append(fn.starts, workflow)
+				return true
+			}
+			fn.callees = append(fn.callees, fun.Sel.Name)
+		}
+		return true
+	})
+	return fn
+}
+
+// startedWorkflow returns the workflow a client call starts, for
+// ExecuteWorkflow(ctx, options, workflow, ...) and
+// SignalWithStartWorkflow(ctx, workflowID, signalName, signalArg, options, workflow, ...).
+func startedWorkflow(method string, call *ast.CallExpr) string {
+	switch {
+	case method == "ExecuteWorkflow" && len(call.Args) >= 3:
+		return workflowRef(call.Args[2])
+	case method == "SignalWithStartWorkflow" && len(call.Args) >= 6:
+		return workflowRef(call.Args[5])
+	}
+	return ""
+}
+
+// workflowRef returns the name of a workflow passed by function or by type name.
+func workflowRef(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.BasicLit:
+		if t.Kind == token.STRING {
+			if name, err := strconv.Unquote(t.Value); err == nil {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// routePath returns the value of a string literal route path or method.
+func routePath(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	path, err := strconv.Unquote(lit.Value)
+	return path, err == nil
+}
+
+// groupPrefix returns the path prefix of the route group a route is registered on.
+func groupPrefix(groups map[string]string, router ast.Expr) string {
+	if ident, ok := router.(*ast.Ident); ok {
+		return groups[ident.Name]
+	}
+	return ""
+}
+
+// receiverTypeName returns the name of a receiver or embedded type, without pointer,
+// package or type parameters.
+func receiverTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(t.X)
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(t.X)
+	}
+	return ""
+}
+
+// handlerName describes a handler expression for reports.
+func handlerName(handler ast.Expr) string {
+	switch h := handler.(type) {
+	case *ast.FuncLit:
+		return "func literal"
+	case *ast.CallExpr:
+		if isPkgCall(h, "http", "HandlerFunc") && len(h.Args) == 1 {
+			return handlerName(h.Args[0])
+		}
+		return types.ExprString(h.Fun) + "(...)"
+	}
+	return types.ExprString(handler)
+}
+
+// Apply resolves the started workflows to graph keys and sets the graph's entry points,
+// sorted by workflow and trigger. Workflows are matched by name, or by method name for
+// qualified (Receiver.Method) nodes, when the match is unambiguous.
+func (info *EntryPointInfo) Apply(graph *TemporalGraph) {
+	if info == nil || graph == nil {
+		return
+	}
+
+	byName := make(map[string][]string)
+	for id, node := range graph.Nodes {
+		if node.Type != "workflow" || node.Unresolved {
+			continue
+		}
+		shortName := node.Name
+		if idx := strings.LastIndex(shortName, "."); idx >= 0 {
+			shortName = shortName[idx+1:]
+		}
+		byName[shortName] = append(byName[shortName], id)
+	}
+
+	graph.EntryPoints = nil
+	for _, ep := range info.EntryPoints {
+		if _, ok := graph.Nodes[ep.Workflow]; !ok {
+			if ids := byName[ep.Workflow]; len(ids) == 1 {
+				ep.Workflow = ids[0]
+			}
+		}
+		graph.EntryPoints = append(graph.EntryPoints, ep)
+	}
+
+	sort.SliceStable(graph.EntryPoints, func(i, j int) bool {
+		a, b := graph.EntryPoints[i], graph.EntryPoints[j]
+		if a.Workflow != b.Workflow {
+			return a.Workflow < b.Workflow
+		}
+		return a.Trigger < b.Trigger
+	})
+}
@@ -0,0 +1,158 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+const entryPointWorkflows = `package api
+
+import "go.temporal.io/sdk/workflow"
+
+func OrderWorkflow(ctx workflow.Context, id string) error { return workflow.Sleep(ctx, 0) }
+
+func RefundWorkflow(ctx workflow.Context, id string) error { return workflow.Sleep(ctx, 0) }
+
+func ShipWorkflow(ctx workflow.Context, id string) error { return workflow.Sleep(ctx, 0) }
+`
+
+const entryPointHTTP = `package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/mux"
+	"go.temporal.io/sdk/client"
+)
+
+type service struct{ c client.Client }
+
+func (s *service) StartOrder(ctx context.Context, id string) error {
+	_, err := s.c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{TaskQueue: "orders"}, OrderWorkflow, id)
+	return err
+}
+
+type handlers struct{ svc *service }
+
+func (h *handlers) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	_ = h.svc.StartOrder(r.Context(), "1")
+}
+
+func health(w http.ResponseWriter, r *http.Request) {}
+
+func routes(h *handlers, c client.Client) {
+	m := http.NewServeMux()
+	m.HandleFunc("POST /orders", h.CreateOrder)
+	m.HandleFunc("GET /health", health)
+	m.Handle("/refunds", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.SignalWithStartWorkflow(r.Context(), "id", "refund", nil, client.StartWorkflowOptions{}, "RefundWorkflow")
+	}))
+
+	g := gin.Default()
+	api := g.Group("/api")
+	api.POST("/ship", auth, shipHandler(c))
+
+	r := mux.NewRouter()
+	r.HandleFunc("/v2/orders", h.CreateOrder).Methods("PUT")
+}
+
+func shipHandler(c client.Client) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{}, ShipWorkflow)
+	}
+}
+`
+
+const entryPointGRPC = `package api
+
+type orderServer struct {
+	pb.UnimplementedOrderServiceServer
+	svc *service
+}
+
+func (s *orderServer) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
+	return nil, s.svc.StartOrder(ctx, req.Id)
+}
+
+func (s *orderServer) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	return nil, nil
+}
+
+func (s *orderServer) mustEmbedUnimplementedOrderServiceServer() {}
+`
+
+func TestAnalyzeEntryPoints(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"workflows.go": entryPointWorkflows,
+		"http.go":      entryPointHTTP,
+		"grpc.go":      entryPointGRPC,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	type key struct{ kind, trigger, handler, workflow string }
+	want := []key{
+		{"grpc", "OrderService/CreateOrder", "orderServer.CreateOrder", "OrderWorkflow"},
+		{"http", "POST /orders", "h.CreateOrder", "OrderWorkflow"},
+		{"http", "PUT /v2/orders", "h.CreateOrder", "OrderWorkflow"},
+		{"http", "/refunds", "func literal", "RefundWorkflow"},
+		{"http", "POST /api/ship", "shipHandler(...)", "ShipWorkflow"},
+	}
+	if len(graph.EntryPoints) != len(want) {
+		t.Fatalf("Expected %d entry points, got %+v", len(want), graph.EntryPoints)
+	}
+	for i, ep := range graph.EntryPoints {
+		if got := (key{ep.Kind, ep.Trigger, ep.Handler, ep.Workflow}); got != want[i] {
+			t.Errorf("Entry point %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	if ep := graph.EntryPoints[1]; ep.FilePath != filepath.Join(dir, "http.go") || ep.LineNumber != 28 {
+		t.Errorf("Expected POST /orders at http.go:28, got %s:%d", ep.FilePath, ep.LineNumber)
+	}
+	if got := graph.StartedBy("OrderWorkflow"); len(got) != 3 {
+		t.Errorf("Expected 3 entry points starting OrderWorkflow, got %+v", got)
+	}
+}
+
+func TestEntryPointInfoApply(t *testing.T) {
+	graph := &TemporalGraph{Nodes: map[string]*TemporalNode{
+		"billing.ChargeWorkflow":  {Name: "ChargeWorkflow", Key: "billing.ChargeWorkflow", Type: "workflow"},
+		"payments.ChargeWorkflow": {Name: "ChargeWorkflow", Key: "payments.ChargeWorkflow", Type: "workflow"},
+		"Orders.Run":              {Name: "Orders.Run", Type: "workflow"},
+	}}
+	info := &EntryPointInfo{EntryPoints: []EntryPoint{
+		{Trigger: "POST /charges", Workflow: "ChargeWorkflow"},
+		{Trigger: "POST /orders", Workflow: "Run"},
+		{Trigger: "POST /external", Workflow: "ExternalWorkflow"},
+	}}
+
+	info.Apply(graph)
+
+	want := map[string]string{
+		"POST /charges":  "ChargeWorkflow", // ambiguous
+		"POST /orders":   "Orders.Run",
+		"POST /external": "ExternalWorkflow",
+	}
+	for _, ep := range graph.EntryPoints {
+		if ep.Workflow != want[ep.Trigger] {
+			t.Errorf("%s starts %q, want %q", ep.Trigger, ep.Workflow, want[ep.Trigger])
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package analyzer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// DetectionExplanation explains why the functions of a name were, or were not, detected.
+type DetectionExplanation struct {
+	Name string `json:"name"`
+	// Declarations are the functions and methods declared with the name
+	Declarations []DeclarationExplanation `json:"declarations"`
+	// CalledBy are the workflows executing the name, whether or not it is declared
+	CalledBy []ParentRef `json:"called_by,omitempty"`
+	// Unresolved is true if the name is only known from the calls executing it
+	Unresolved bool `json:"unresolved,omitempty"`
+}
+
+// DeclarationExplanation is the detection decision trail of one declaration.
+type DeclarationExplanation struct {
+	FilePath   string `json:"file_path"`
+	LineNumber int    `json:"line_number"`
+	Package    string `json:"package"`
+	Receiver   string `json:"receiver,omitempty"`
+	// Skipped is why the file is not analyzed, "" if it is
+	Skipped string           `json:"skipped,omitempty"`
+	Checks  []DetectionCheck `json:"checks,omitempty"`
+	// Type is the node type the declaration is detected as, "" if it is not detected
+	Type string `json:"type,omitempty"`
+	// Confidence and Reasons are those of the node in the graph
+	Confidence string   `json:"confidence,omitempty"`
+	Reasons    []string `json:"reasons,omitempty"`
+	// Filtered is why the detected node is left out of the output, "" if it is kept
+	Filtered string `json:"filtered,omitempty"`
+}
+
+// DetectionCheck is a heuristic evaluated for a declaration.
+type DetectionCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	// Ignored marks heuristics reported for reference that detection does not use
+	Ignored bool   `json:"ignored,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// Detected reports whether the declaration is a node of the output.
+func (d *DeclarationExplanation) Detected() bool {
+	return d.Skipped == "" && d.Type != "" && d.Filtered == ""
+}
+
+// ExplainDetection explains why the functions named name, or Type.Method, were or were not
+// detected: whether the analysis skips their files, which heuristics each declaration passes
+// and which node filters of opts (--package, --name, --query and --min-confidence) drop it.
+// graph must be analyzed without those filters, so the nodes they drop can be explained.
+func ExplainDetection(ctx context.Context, logger *slog.Logger, graph *TemporalGraph, name string, opts config.AnalysisOptions) (*DetectionExplanation, error) {
+	receiver, funcName, isMethod := strings.Cut(name, ".")
+	if !isMethod {
+		receiver, funcName = "", name
+	}
+
+	var query *Query
+	if opts.Query != "" {
+		q, err := ParseQuery(opts.Query)
+		if err != nil {
+			return nil, err
+		}
+		query = q
+	}
+
+	regInfo, err := NewRegistrationScanner(logger).ScanDirectory(ctx, opts.RootDir, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan for registrations: %w", err)
+	}
+	p := &goParser{logger: logger, registrationInfo: regInfo}
+
+	explanation := &DetectionExplanation{Name: name}
+	modules := NewModuleFilter(opts.RootDir, opts)
+	// skippedDirs maps directories the analysis does not walk to the reason
+	skippedDirs := make(map[string]string)
+	fset := token.NewFileSet()
+	err = filepath.Walk(opts.RootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			switch {
+			case skippedDirs[filepath.Dir(path)] != "" && path != opts.RootDir:
+				skippedDirs[path] = skippedDirs[filepath.Dir(path)]
+			case info.Name() == ".git":
+				return filepath.SkipDir
+			case opts.ExcludesDir(info.Name()):
+				skippedDirs[path] = fmt.Sprintf("directory %s is excluded", path)
+			case modules.SkipDir(path):
+				skippedDirs[path] = fmt.Sprintf("directory %s is a dependency module (see --include-deps)", path)
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil || !bytes.Contains(src, []byte(funcName)) {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+		if err != nil {
+			explanation.Declarations = append(explanation.Declarations, DeclarationExplanation{
+				FilePath: path,
+				Skipped:  fmt.Sprintf("the file does not parse: %v", err),
+			})
+			return nil
+		}
+
+		skipped := skippedDirs[filepath.Dir(path)]
+		if skipped == "" && !opts.IncludeTests && IsTestFile(path) {
+			skipped = "test files are skipped without --include-tests"
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name.Name != funcName {
+				continue
+			}
+			recv := p.extractReceiverTypeName(fn)
+			if isMethod && recv != receiver {
+				continue
+			}
+			d := DeclarationExplanation{
+				FilePath:   path,
+				LineNumber: fset.Position(fn.Pos()).Line,
+				Package:    file.Name.Name,
+				Receiver:   recv,
+				Skipped:    skipped,
+				Checks:     p.detectionChecks(fn, recv),
+			}
+			if skipped == "" {
+				d.Type = p.classifyFunction(fn)
+			}
+			if d.Type != "" {
+				explainFilters(&d, graph, funcName, query, opts)
+			}
+			explanation.Declarations = append(explanation.Declarations, d)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range graph.Nodes {
+		if node.Name != funcName && !strings.HasSuffix(node.Name, "."+funcName) {
+			continue
+		}
+		explanation.CalledBy = append(explanation.CalledBy, node.CalledBy...)
+		explanation.Unresolved = explanation.Unresolved || node.Unresolved
+	}
+	sort.Slice(explanation.CalledBy, func(i, j int) bool {
+		a, b := explanation.CalledBy[i], explanation.CalledBy[j]
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.LineNumber < b.LineNumber
+	})
+	return explanation, nil
+}
+
+// detectionChecks evaluates the detection heuristics of classifyFunction for a declaration.
+func (p *goParser) detectionChecks(fn *ast.FuncDecl, receiver string) []DetectionCheck {
+	funcName := fn.Name.Name
+	info := p.registrationInfo
+
+	workflowReg := DetectionCheck{Name: "registered as a workflow", Detail: "no worker.RegisterWorkflow call found"}
+	if info.IsRegisteredWorkflow(funcName) {
+		workflowReg.Passed, workflowReg.Detail = true, registrationDetail(info.Workflows[funcName])
+	}
+
+	activityReg := DetectionCheck{Name: "registered as an activity", Detail: "no worker.RegisterActivity call found"}
+	if info.IsRegisteredActivity(funcName, receiver) {
+		activityReg.Passed, activityReg.Detail = true, registrationDetail(info.Activities[funcName])
+		if regType, ok := info.IsRegisteredType(strings.TrimPrefix(receiver, "*")); ok && regType == "activity" {
+			activityReg.Detail = fmt.Sprintf("methods of %s are registered", receiver)
+		}
+	}
+
+	signature := DetectionCheck{Name: "first parameter is workflow.Context", Detail: "no parameters"}
+	if fn.Type.Params != nil && len(fn.Type.Params.List) > 0 {
+		first := fn.Type.Params.List[0].Type
+		signature.Passed = p.isWorkflowContext(first)
+		signature.Detail = "first parameter is " + TypeString(first)
+	}
+
+	sdkCalls := DetectionCheck{Name: "calls the workflow SDK", Detail: "no workflow.ExecuteActivity, workflow.Sleep or similar call"}
+	if fn.Body != nil && p.hasWorkflowCalls(fn.Body) {
+		sdkCalls.Passed, sdkCalls.Detail = true, ""
+	}
+
+	suffix := DetectionCheck{
+		Name:    "name ends in Workflow or Activity",
+		Passed:  strings.HasSuffix(funcName, "Workflow") || strings.HasSuffix(funcName, "Activity"),
+		Ignored: true,
+		Detail:  "names are not used for detection",
+	}
+
+	return []DetectionCheck{workflowReg, activityReg, signature, sdkCalls, suffix}
+}
+
+// registrationDetail describes where a function is registered.
+func registrationDetail(reg *Registration) string {
+	if reg == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", reg.FilePath, reg.LineNumber)
+}
+
+// explainFilters records the graph node of a detected declaration, and the node filter
+// dropping it from the output, if any.
+func explainFilters(d *DeclarationExplanation, graph *TemporalGraph, funcName string, query *Query, opts config.AnalysisOptions) {
+	var node *TemporalNode
+	for _, n := range graph.Nodes {
+		if n.FilePath == d.FilePath && n.LineNumber == d.LineNumber {
+			node = n
+			break
+		}
+	}
+	if node != nil {
+		d.Confidence, d.Reasons = node.DetectionConfidence, node.DetectionReasons
+	}
+
+	switch {
+	case opts.FilterPackage != "" && !regexMatches(opts.FilterPackage, d.Package):
+		d.Filtered = fmt.Sprintf("package %s does not match --package %q", d.Package, opts.FilterPackage)
+	case opts.FilterName != "" && !regexMatches(opts.FilterName, funcName):
+		d.Filtered = fmt.Sprintf("name does not match --name %q", opts.FilterName)
+	case node == nil:
+		d.Filtered = "no node was built for the declaration"
+	case query != nil && !query.Match(node):
+		d.Filtered = fmt.Sprintf("the node does not match --query %q", opts.Query)
+	case opts.MinConfidence != "" && !node.ConfidenceAtLeast(opts.MinConfidence):
+		d.Filtered = fmt.Sprintf("detected with %s confidence, below --min-confidence %s", node.DetectionConfidence, opts.MinConfidence)
+	}
+}
+
+func regexMatches(pattern, s string) bool {
+	matched, err := regexp.MatchString(pattern, s)
+	return err == nil && matched
+}
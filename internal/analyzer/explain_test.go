@@ -0,0 +1,182 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+var explainTree = map[string]string{
+	"orders/orders.go": `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, SendEmailActivity).Get(ctx, nil)
+}
+
+func SendEmailActivity(ctx context.Context) error { return nil }
+
+type Activities struct{}
+
+func (a *Activities) Charge(ctx context.Context) error { return nil }
+
+func register(worker worker.Worker) {
+	worker.RegisterWorkflow(OrderWorkflow)
+	worker.RegisterActivity(&Activities{})
+}
+`,
+	"orders/worker.go": `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+)
+
+func RefundActivity(ctx context.Context) error { return nil }
+
+func run(c client.Client) error {
+	w := worker.New(c, "orders", worker.Options{})
+	w.RegisterActivity(RefundActivity)
+	return w.Run(worker.InterruptCh())
+}
+`,
+	"orders/orders_test.go": `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+func FakeWorkflow(ctx workflow.Context) error {
+	return workflow.Sleep(ctx, 0)
+}
+`,
+	"gen/gen.go": `package gen
+
+import "go.temporal.io/sdk/workflow"
+
+func GeneratedWorkflow(ctx workflow.Context) error {
+	return workflow.Sleep(ctx, 0)
+}
+`,
+}
+
+func explain(t *testing.T, name string, opts config.AnalysisOptions) *DetectionExplanation {
+	t.Helper()
+	opts.RootDir = writeTree(t, explainTree)
+	opts.ExcludeDirs = append(opts.ExcludeDirs, "gen")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	unfiltered := opts
+	unfiltered.FilterPackage, unfiltered.Query = "", ""
+	graph, err := NewAnalyzer(logger).Analyze(context.Background(), unfiltered)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	e, err := ExplainDetection(context.Background(), logger, graph, name, opts)
+	if err != nil {
+		t.Fatalf("ExplainDetection failed: %v", err)
+	}
+	return e
+}
+
+func checkNamed(d DeclarationExplanation, name string) DetectionCheck {
+	for _, check := range d.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	return DetectionCheck{}
+}
+
+func TestExplainDetection(t *testing.T) {
+	t.Run("registered workflow", func(t *testing.T) {
+		e := explain(t, "OrderWorkflow", config.AnalysisOptions{})
+		if len(e.Declarations) != 1 {
+			t.Fatalf("got %d declarations, want 1", len(e.Declarations))
+		}
+		d := e.Declarations[0]
+		if !d.Detected() || d.Type != "workflow" || d.Confidence != ConfidenceHigh {
+			t.Errorf("declaration = %+v, want a detected high-confidence workflow", d)
+		}
+		if check := checkNamed(d, "registered as a workflow"); !check.Passed || !strings.Contains(check.Detail, "orders.go:") {
+			t.Errorf("registration check = %+v", check)
+		}
+	})
+
+	t.Run("unregistered activity", func(t *testing.T) {
+		e := explain(t, "SendEmailActivity", config.AnalysisOptions{})
+		d := e.Declarations[0]
+		if d.Detected() {
+			t.Errorf("declaration = %+v, want not detected", d)
+		}
+		if check := checkNamed(d, "name ends in Workflow or Activity"); !check.Passed || !check.Ignored {
+			t.Errorf("suffix check = %+v, want passed and ignored", check)
+		}
+		if check := checkNamed(d, "first parameter is workflow.Context"); check.Passed || check.Detail != "first parameter is context.Context" {
+			t.Errorf("signature check = %+v", check)
+		}
+		if len(e.CalledBy) != 1 || e.CalledBy[0].Name != "OrderWorkflow" || !e.Unresolved {
+			t.Errorf("CalledBy = %+v, Unresolved = %v, want an unresolved call from OrderWorkflow", e.CalledBy, e.Unresolved)
+		}
+	})
+
+	t.Run("registered on a worker variable", func(t *testing.T) {
+		d := explain(t, "RefundActivity", config.AnalysisOptions{}).Declarations[0]
+		if !d.Detected() || d.Type != "activity" {
+			t.Errorf("declaration = %+v, want a detected activity", d)
+		}
+		if check := checkNamed(d, "registered as an activity"); !check.Passed || !strings.Contains(check.Detail, "worker.go:14") {
+			t.Errorf("registration check = %+v, want the w.RegisterActivity call", check)
+		}
+	})
+
+	t.Run("struct method", func(t *testing.T) {
+		e := explain(t, "Activities.Charge", config.AnalysisOptions{})
+		d := e.Declarations[0]
+		if !d.Detected() || d.Type != "activity" {
+			t.Errorf("declaration = %+v, want a detected activity", d)
+		}
+		if check := checkNamed(d, "registered as an activity"); check.Detail != "methods of Activities are registered" {
+			t.Errorf("registration check = %+v", check)
+		}
+	})
+
+	t.Run("skipped files", func(t *testing.T) {
+		if d := explain(t, "GeneratedWorkflow", config.AnalysisOptions{}).Declarations[0]; !strings.Contains(d.Skipped, "is excluded") {
+			t.Errorf("Skipped = %q, want an excluded directory", d.Skipped)
+		}
+		if d := explain(t, "FakeWorkflow", config.AnalysisOptions{}).Declarations[0]; !strings.Contains(d.Skipped, "--include-tests") {
+			t.Errorf("Skipped = %q, want test files skipped", d.Skipped)
+		}
+		if d := explain(t, "FakeWorkflow", config.AnalysisOptions{IncludeTests: true}).Declarations[0]; !d.Detected() {
+			t.Errorf("declaration = %+v, want detected with --include-tests", d)
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		d := explain(t, "OrderWorkflow", config.AnalysisOptions{FilterPackage: "^billing$"}).Declarations[0]
+		if d.Detected() || !strings.Contains(d.Filtered, "--package") {
+			t.Errorf("Filtered = %q, want the package filter", d.Filtered)
+		}
+		d = explain(t, "OrderWorkflow", config.AnalysisOptions{Query: "fanout>5"}).Declarations[0]
+		if d.Detected() || !strings.Contains(d.Filtered, "--query") {
+			t.Errorf("Filtered = %q, want the query", d.Filtered)
+		}
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		if e := explain(t, "MissingWorkflow", config.AnalysisOptions{}); len(e.Declarations) != 0 {
+			t.Errorf("Declarations = %+v, want none", e.Declarations)
+		}
+	})
+}
@@ -6,9 +6,9 @@ import (
 	"go/ast"
 	"go/token"
 	"log/slog"
-	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // callExtractor implements the CallExtractor interface.
@@ -16,6 +16,12 @@ type callExtractor struct {
 	logger *slog.Logger
 }
 
+// callSetPool reuses the sets of chained calls already visited while walking function bodies.
+var callSetPool = sync.Pool{New: func() any { return make(map[*ast.CallExpr]bool) }}
+
+// nameSetPool reuses the sets of call targets already seen while walking function bodies.
+var nameSetPool = sync.Pool{New: func() any { return make(map[string]bool) }}
+
 // NewCallExtractor creates a new CallExtractor instance.
 func NewCallExtractor(logger *slog.Logger) CallExtractor {
 	return &callExtractor{
@@ -44,6 +50,8 @@ type TemporalCallInfo struct {
 
 	// Parsed activity/workflow options
 	ParsedActivityOpts *ActivityOptions
+	// ActivityOptionsContext marks a child workflow call on a context with activity options only
+	ActivityOptionsContext bool
 }
 
 // ExtractCalls finds all temporal workflow and activity calls within a function.
@@ -54,17 +62,26 @@ func (e *callExtractor) ExtractCalls(ctx context.Context, fn *ast.FuncDecl, file
 
 	var callSites []CallSite
 	// Track processed inner calls to avoid duplicates when handling chained .Get() calls
-	processedCalls := make(map[*ast.CallExpr]bool)
+	processedCalls := callSetPool.Get().(map[*ast.CallExpr]bool)
+	defer func() {
+		clear(processedCalls)
+		callSetPool.Put(processedCalls)
+	}()
+	// Track options attached to local context variables
+	scope := newOptionsScope()
+	done := ctx.Done()
 
 	// Walk through the function body to find calls
 	ast.Inspect(fn.Body, func(n ast.Node) bool {
 		// Check context cancellation
 		select {
-		case <-ctx.Done():
+		case <-done:
 			return false
 		default:
 		}
 
+		e.trackOptionsAssignment(scope, n)
+
 		call, ok := n.(*ast.CallExpr)
 		if !ok {
 			return true
@@ -85,6 +102,7 @@ func (e *callExtractor) ExtractCalls(ctx context.Context, fn *ast.FuncDecl, file
 
 		info := e.analyzeCall(call, filePath, nil)
 		if info != nil && info.TargetName != "" {
+			e.applyScopedOptions(scope, call, info)
 			callSites = append(callSites, CallSite{
 				TargetName:         info.TargetName,
 				TargetType:         info.Type,
@@ -121,27 +139,75 @@ func (e *callExtractor) ExtractAllTemporalInfo(ctx context.Context, fn *ast.Func
 		CallSites:   []CallSite{},
 	}
 
+	// Track options attached to local context variables
+	scope := newOptionsScope()
+	contexts := callContexts(fn.Body, fset)
+	processedCalls := callSetPool.Get().(map[*ast.CallExpr]bool)
+	defer func() {
+		clear(processedCalls)
+		callSetPool.Put(processedCalls)
+	}()
+	// How each call site is awaited, to find the calls that run concurrently
+	var awaits []callAwait
+	futures := make(map[*ast.CallExpr]string)   // Execute call -> future variable it is assigned to
+	getLines := make(map[string][]int)          // future variable -> lines of its Get calls
+	channelCalls := make(map[int]*ast.CallExpr) // index in details.Signals -> GetSignalChannel call
+	done := ctx.Done()
+
 	// Walk through the function body
 	ast.Inspect(fn.Body, func(n ast.Node) bool {
 		select {
-		case <-ctx.Done():
+		case <-done:
 			return false
 		default:
 		}
 
+		e.trackOptionsAssignment(scope, n)
+
+		if assign, ok := n.(*ast.AssignStmt); ok && len(assign.Lhs) == len(assign.Rhs) {
+			for i, rhs := range assign.Rhs {
+				if call, ok := rhs.(*ast.CallExpr); ok {
+					if ident, ok := assign.Lhs[i].(*ast.Ident); ok {
+						futures[call] = ident.Name
+					}
+				}
+			}
+		}
+
 		call, ok := n.(*ast.CallExpr)
 		if !ok {
 			return true
 		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Get" {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				getLines[ident.Name] = append(getLines[ident.Name], e.getLineNumber(call, fset))
+			}
+		}
+
+		// Skip if already processed (inner call of a chained .Get())
+		if processedCalls[call] {
+			return true
+		}
+		awaited := false
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+			if innerCall, isCall := sel.X.(*ast.CallExpr); isCall && sel.Sel.Name == "Get" {
+				processedCalls[innerCall] = true
+				awaited = true
+			}
+		}
 
 		info := e.analyzeCall(call, filePath, fset)
 		if info == nil {
 			return true
 		}
+		e.applyScopedOptions(scope, call, info)
 
 		switch info.Type {
 		case "signal":
 			if info.SignalDef != nil {
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "GetSignalChannel" {
+					channelCalls[len(details.Signals)] = call
+				}
 				details.Signals = append(details.Signals, *info.SignalDef)
 			}
 		case "query":
@@ -154,6 +220,7 @@ func (e *callExtractor) ExtractAllTemporalInfo(ctx context.Context, fn *ast.Func
 			}
 		case "timer":
 			if info.TimerDef != nil {
+				info.TimerDef.LoopLine = contexts[call].loopLine
 				details.Timers = append(details.Timers, *info.TimerDef)
 			}
 		case "version":
@@ -166,6 +233,7 @@ func (e *callExtractor) ExtractAllTemporalInfo(ctx context.Context, fn *ast.Func
 			}
 		case "activity", "child_workflow", "local_activity":
 			if info.TargetName != "" {
+				awaits = append(awaits, callAwait{awaited: awaited, future: futures[call], goroutine: contexts[call].goroutine})
 				details.CallSites = append(details.CallSites, CallSite{
 					TargetName:         info.TargetName,
 					TargetType:         info.Type,
@@ -177,6 +245,9 @@ func (e *callExtractor) ExtractAllTemporalInfo(ctx context.Context, fn *ast.Func
 					ArgumentTypes:      info.ArgumentTypes,
 					ResultType:         info.ResultType,
 					ParsedActivityOpts: info.ParsedActivityOpts,
+					LoopLine:           contexts[call].loopLine,
+
+					ActivityOptionsContext: info.ActivityOptionsContext,
 				})
 			}
 		}
@@ -184,21 +255,63 @@ func (e *callExtractor) ExtractAllTemporalInfo(ctx context.Context, fn *ast.Func
 		return true
 	})
 
+	markParallelCalls(details.CallSites, awaits, getLines)
+	details.SignalReceives = e.extractSignalReceives(fn.Body, fset, contexts)
+	markUnreceivedChannels(fn.Body, details.Signals, channelCalls)
+	details.LogCalls = e.extractLogCalls(fn.Body, fset)
+	details.MetricCalls = e.extractMetricCalls(fn.Body, fset)
+	details.ContextEscapes = e.extractContextEscapes(fn, fset)
 	return details, nil
 }
 
+// callAwait records how the result of a call site is awaited.
+type callAwait struct {
+	awaited   bool         // Get is chained to the call
+	future    string       // variable the future is assigned to, if any
+	goroutine *ast.FuncLit // function run with workflow.Go the call is in, if any
+}
+
+// markParallelCalls marks the call sites that run concurrently with the next one: calls
+// whose future is not awaited before the next call, and calls in a workflow.Go function
+// followed by calls outside it.
+func markParallelCalls(callSites []CallSite, awaits []callAwait, getLines map[string][]int) {
+	for i := 0; i+1 < len(callSites); i++ {
+		a, next := awaits[i], awaits[i+1]
+		switch {
+		case a.goroutine != nil && a.goroutine != next.goroutine:
+			callSites[i].Parallel = true
+		case a.awaited:
+		default:
+			awaitLine := 0
+			for _, line := range getLines[a.future] {
+				if line >= callSites[i].LineNumber {
+					awaitLine = line
+					break
+				}
+			}
+			// Futures collected in slices or awaited elsewhere are not awaited in order
+			callSites[i].Parallel = a.future == "" || awaitLine == 0 || awaitLine > callSites[i+1].LineNumber
+		}
+	}
+}
+
 // TemporalNodeDetails holds all extracted Temporal information for a node.
 type TemporalNodeDetails struct {
-	Signals     []SignalDef
-	Queries     []QueryDef
-	Updates     []UpdateDef
-	Timers      []TimerDef
-	Versions    []VersionDef
-	SearchAttrs []SearchAttrDef
-	CallSites   []CallSite
+	Signals        []SignalDef
+	Queries        []QueryDef
+	Updates        []UpdateDef
+	Timers         []TimerDef
+	SignalReceives []SignalReceive
+	LogCalls       []LogCall
+	MetricCalls    []MetricCall
+	ContextEscapes []ContextEscape
+	Versions       []VersionDef
+	SearchAttrs    []SearchAttrDef
+	CallSites      []CallSite
 }
 
-// analyzeCall analyzes a call expression to extract Temporal information.
+// analyzeCall analyzes a call expression to extract Temporal information. filePath is the
+// path of the file the call is in.
 func (e *callExtractor) analyzeCall(call *ast.CallExpr, filePath string, fset *token.FileSet) *TemporalCallInfo {
 	sel, ok := call.Fun.(*ast.SelectorExpr)
 	if !ok {
@@ -210,7 +323,7 @@ func (e *callExtractor) analyzeCall(call *ast.CallExpr, filePath string, fset *t
 					Type:       e.inferTypeFromName(ident.Name),
 					TargetName: ident.Name,
 					LineNumber: lineNum,
-					FilePath:   filepath.Base(filePath),
+					FilePath:   filePath,
 				}
 			}
 		}
@@ -251,7 +364,7 @@ func (e *callExtractor) analyzeCall(call *ast.CallExpr, filePath string, fset *t
 			Type:       e.inferTypeFromName(sel.Sel.Name),
 			TargetName: sel.Sel.Name,
 			LineNumber: lineNum,
-			FilePath:   filepath.Base(filePath),
+			FilePath:   filePath,
 		}
 	}
 
@@ -266,11 +379,16 @@ func (e *callExtractor) extractInternalCalls(ctx context.Context, fn *ast.FuncDe
 	}
 
 	var calls []InternalCall
-	seen := make(map[string]bool) // Dedupe by target name
+	seen := nameSetPool.Get().(map[string]bool) // Dedupe by target name
+	defer func() {
+		clear(seen)
+		nameSetPool.Put(seen)
+	}()
+	done := ctx.Done()
 
 	ast.Inspect(fn.Body, func(n ast.Node) bool {
 		select {
-		case <-ctx.Done():
+		case <-done:
 			return false
 		default:
 		}
@@ -294,7 +412,7 @@ func (e *callExtractor) extractInternalCalls(ctx context.Context, fn *ast.FuncDe
 					TargetName: name,
 					CallType:   "function",
 					LineNumber: lineNum,
-					FilePath:   filepath.Base(filePath),
+					FilePath:   filePath,
 				}
 			}
 
@@ -337,7 +455,7 @@ func (e *callExtractor) extractInternalCalls(ctx context.Context, fn *ast.FuncDe
 					Receiver:   receiverName,
 					CallType:   "method",
 					LineNumber: lineNum,
-					FilePath:   filepath.Base(filePath),
+					FilePath:   filePath,
 				}
 			}
 		}
@@ -354,50 +472,52 @@ func (e *callExtractor) extractInternalCalls(ctx context.Context, fn *ast.FuncDe
 
 // isBuiltinOrCommon returns true for builtin functions and very common stdlib functions.
 func (e *callExtractor) isBuiltinOrCommon(name string) bool {
-	builtins := map[string]bool{
-		"append": true, "cap": true, "close": true, "complex": true,
-		"copy": true, "delete": true, "imag": true, "len": true,
-		"make": true, "new": true, "panic": true, "print": true,
-		"println": true, "real": true, "recover": true,
-	}
-	return builtins[name]
+	return builtinFuncs[name]
+}
+
+// builtinFuncs are Go's builtin functions.
+var builtinFuncs = map[string]bool{
+	"append": true, "cap": true, "close": true, "complex": true,
+	"copy": true, "delete": true, "imag": true, "len": true,
+	"make": true, "new": true, "panic": true, "print": true,
+	"println": true, "real": true, "recover": true,
+}
+
+// boringMethods are methods not interesting for analysis, whatever their receiver.
+var boringMethods = map[string]bool{
+	// Error handling
+	"Error": true, "Unwrap": true, "Is": true, "As": true, "Wrap": true, "Wrapf": true,
+	// Logging
+	"Info": true, "Debug": true, "Warn": true, "Errorf": true,
+	"Infof": true, "Debugf": true, "Warnf": true,
+	"InfoContext": true, "DebugContext": true, "WarnContext": true, "ErrorContext": true,
+	"Printf": true, "Println": true, "Print": true, "Sprintf": true,
+	"Log": true, "Logf": true,
+	// Common getters/utilities
+	"String": true, "Int": true, "Bool": true, "Float64": true,
+	"Bytes": true, "Len": true, "Cap": true, "Close": true,
+	"Read": true, "Write": true, "Seek": true, "Flush": true,
+}
+
+// boringReceivers are standard library packages and common receivers whose calls are not
+// interesting for analysis.
+var boringReceivers = map[string]bool{
+	"ctx": true, "context": true,
+	"strings": true, "strconv": true, "fmt": true, "bytes": true,
+	"time": true, "sync": true, "atomic": true, "math": true,
+	"sort": true, "json": true, "xml": true, "io": true,
+	"os": true, "path": true, "filepath": true, "regexp": true,
+	"reflect": true, "runtime": true, "unsafe": true,
+	"log": true, "slog": true, "logger": true, "l": true,
+	"errors": true, "http": true, "net": true, "url": true,
+	"bufio": true, "ioutil": true, "testing": true, "flag": true,
+	"encoding": true, "crypto": true, "hash": true,
+	"ast": true, "token": true, "parser": true, "printer": true,
 }
 
 // isBoringCall returns true for calls that are generally not interesting for analysis.
 func (e *callExtractor) isBoringCall(receiver, method string) bool {
-	// Skip error handling patterns
-	boringMethods := map[string]bool{
-		"Error": true, "Unwrap": true, "Is": true, "As": true, "Wrap": true, "Wrapf": true,
-		// Logging
-		"Info": true, "Debug": true, "Warn": true, "Errorf": true,
-		"Infof": true, "Debugf": true, "Warnf": true,
-		"InfoContext": true, "DebugContext": true, "WarnContext": true, "ErrorContext": true,
-		"Printf": true, "Println": true, "Print": true, "Sprintf": true,
-		"Log": true, "Logf": true,
-		// Common getters/utilities
-		"String": true, "Int": true, "Bool": true, "Float64": true,
-		"Bytes": true, "Len": true, "Cap": true, "Close": true,
-		"Read": true, "Write": true, "Seek": true, "Flush": true,
-	}
-	if boringMethods[method] {
-		return true
-	}
-
-	// Skip standard library packages
-	boringReceivers := map[string]bool{
-		"ctx": true, "context": true,
-		"strings": true, "strconv": true, "fmt": true, "bytes": true,
-		"time": true, "sync": true, "atomic": true, "math": true,
-		"sort": true, "json": true, "xml": true, "io": true,
-		"os": true, "path": true, "filepath": true, "regexp": true,
-		"reflect": true, "runtime": true, "unsafe": true,
-		"log": true, "slog": true, "logger": true, "l": true,
-		"errors": true, "http": true, "net": true, "url": true,
-		"bufio": true, "ioutil": true, "testing": true, "flag": true,
-		"encoding": true, "crypto": true, "hash": true,
-		"ast": true, "token": true, "parser": true, "printer": true,
-	}
-	return boringReceivers[receiver]
+	return boringMethods[method] || boringReceivers[receiver]
 }
 
 // analyzeWorkflowCall analyzes workflow.* calls.
@@ -409,7 +529,7 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 			Type:               "activity",
 			TargetName:         target,
 			LineNumber:         lineNum,
-			FilePath:           filepath.Base(filePath),
+			FilePath:           filePath,
 			Options:            e.extractOptions(call),
 			ArgumentCount:      argCount,
 			ArgumentTypes:      argTypes,
@@ -422,7 +542,7 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 			Type:               "child_workflow",
 			TargetName:         target,
 			LineNumber:         lineNum,
-			FilePath:           filepath.Base(filePath),
+			FilePath:           filePath,
 			Options:            e.extractOptions(call),
 			ArgumentCount:      argCount,
 			ArgumentTypes:      argTypes,
@@ -435,7 +555,7 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 			Type:               "local_activity",
 			TargetName:         target,
 			LineNumber:         lineNum,
-			FilePath:           filepath.Base(filePath),
+			FilePath:           filePath,
 			Options:            e.extractOptions(call),
 			ArgumentCount:      argCount,
 			ArgumentTypes:      argTypes,
@@ -448,7 +568,7 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 			Type:       "signal",
 			TargetName: signalDef.Name,
 			LineNumber: lineNum,
-			FilePath:   filepath.Base(filePath),
+			FilePath:   filePath,
 			SignalDef:  &signalDef,
 		}
 
@@ -458,7 +578,7 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 			Type:       "signal",
 			TargetName: signalDef.Name,
 			LineNumber: lineNum,
-			FilePath:   filepath.Base(filePath),
+			FilePath:   filePath,
 			SignalDef:  &signalDef,
 		}
 
@@ -468,7 +588,7 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 			Type:       "query",
 			TargetName: queryDef.Name,
 			LineNumber: lineNum,
-			FilePath:   filepath.Base(filePath),
+			FilePath:   filePath,
 			QueryDef:   &queryDef,
 		}
 
@@ -478,7 +598,7 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 			Type:       "update",
 			TargetName: updateDef.Name,
 			LineNumber: lineNum,
-			FilePath:   filepath.Base(filePath),
+			FilePath:   filePath,
 			UpdateDef:  &updateDef,
 		}
 
@@ -488,7 +608,7 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 			Type:       "timer",
 			TargetName: fmt.Sprintf("timer_%d", lineNum),
 			LineNumber: lineNum,
-			FilePath:   filepath.Base(filePath),
+			FilePath:   filePath,
 			TimerDef:   &timerDef,
 		}
 
@@ -498,7 +618,7 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 			Type:       "version",
 			TargetName: versionDef.ChangeID,
 			LineNumber: lineNum,
-			FilePath:   filepath.Base(filePath),
+			FilePath:   filePath,
 			VersionDef: &versionDef,
 		}
 
@@ -508,7 +628,7 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 			Type:          "search_attr",
 			TargetName:    searchAttrDef.Name,
 			LineNumber:    lineNum,
-			FilePath:      filepath.Base(filePath),
+			FilePath:      filePath,
 			SearchAttrDef: &searchAttrDef,
 		}
 
@@ -517,7 +637,7 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 			Type:       "continue_as_new",
 			TargetName: "continue_as_new",
 			LineNumber: lineNum,
-			FilePath:   filepath.Base(filePath),
+			FilePath:   filePath,
 		}
 	}
 
@@ -528,18 +648,10 @@ func (e *callExtractor) analyzeWorkflowCall(method string, call *ast.CallExpr, f
 func (e *callExtractor) extractSignalHandler(call *ast.CallExpr, lineNum int) SignalDef {
 	signalDef := SignalDef{LineNumber: lineNum}
 
-	if len(call.Args) >= 1 {
-		// First arg is signal name
-		if lit, ok := call.Args[0].(*ast.BasicLit); ok {
-			signalDef.Name = strings.Trim(lit.Value, `"`)
-		}
-	}
-
-	if len(call.Args) >= 2 {
-		// Second arg is handler function
-		if ident, ok := call.Args[1].(*ast.Ident); ok {
-			signalDef.Handler = ident.Name
-		}
+	nameArg, handlerArg := handlerArgs(call)
+	signalDef.Name, signalDef.NameExpr = e.messageName(nameArg)
+	if ident, ok := handlerArg.(*ast.Ident); ok {
+		signalDef.Handler = ident.Name
 	}
 
 	return signalDef
@@ -551,9 +663,7 @@ func (e *callExtractor) extractSignalChannel(call *ast.CallExpr, lineNum int) Si
 
 	if len(call.Args) >= 2 {
 		// Second arg is signal name (first is ctx)
-		if lit, ok := call.Args[1].(*ast.BasicLit); ok {
-			signalDef.Name = strings.Trim(lit.Value, `"`)
-		}
+		signalDef.Name, signalDef.NameExpr = e.messageName(call.Args[1])
 	}
 
 	return signalDef
@@ -563,16 +673,10 @@ func (e *callExtractor) extractSignalChannel(call *ast.CallExpr, lineNum int) Si
 func (e *callExtractor) extractQueryHandler(call *ast.CallExpr, lineNum int) QueryDef {
 	queryDef := QueryDef{LineNumber: lineNum}
 
-	if len(call.Args) >= 1 {
-		if lit, ok := call.Args[0].(*ast.BasicLit); ok {
-			queryDef.Name = strings.Trim(lit.Value, `"`)
-		}
-	}
-
-	if len(call.Args) >= 2 {
-		if ident, ok := call.Args[1].(*ast.Ident); ok {
-			queryDef.Handler = ident.Name
-		}
+	nameArg, handlerArg := handlerArgs(call)
+	queryDef.Name, queryDef.NameExpr = e.messageName(nameArg)
+	if ident, ok := handlerArg.(*ast.Ident); ok {
+		queryDef.Handler = ident.Name
 	}
 
 	return queryDef
@@ -582,19 +686,43 @@ func (e *callExtractor) extractQueryHandler(call *ast.CallExpr, lineNum int) Que
 func (e *callExtractor) extractUpdateHandler(call *ast.CallExpr, lineNum int) UpdateDef {
 	updateDef := UpdateDef{LineNumber: lineNum}
 
-	if len(call.Args) >= 1 {
-		if lit, ok := call.Args[0].(*ast.BasicLit); ok {
-			updateDef.Name = strings.Trim(lit.Value, `"`)
-		}
+	nameArg, handlerArg := handlerArgs(call)
+	updateDef.Name, updateDef.NameExpr = e.messageName(nameArg)
+	if ident, ok := handlerArg.(*ast.Ident); ok {
+		updateDef.Handler = ident.Name
 	}
 
-	if len(call.Args) >= 2 {
-		if ident, ok := call.Args[1].(*ast.Ident); ok {
-			updateDef.Handler = ident.Name
-		}
+	return updateDef
+}
+
+// handlerArgs returns the name and handler arguments of a Set*Handler call. The SDK takes
+// the workflow context first (SetQueryHandler(ctx, "status", fn)); calls without it are
+// accepted too.
+func handlerArgs(call *ast.CallExpr) (ast.Expr, ast.Expr) {
+	switch {
+	case len(call.Args) >= 3:
+		return call.Args[1], call.Args[2]
+	case len(call.Args) == 2:
+		return call.Args[0], call.Args[1]
 	}
+	return nil, nil
+}
 
-	return updateDef
+// messageName returns the signal, query or update name of a string literal argument, or
+// the argument as written when it names a constant, resolved later with the package's
+// constants.
+func (e *callExtractor) messageName(expr ast.Expr) (string, string) {
+	switch arg := expr.(type) {
+	case *ast.BasicLit:
+		if arg.Kind == token.STRING {
+			if name, err := strconv.Unquote(arg.Value); err == nil {
+				return name, ""
+			}
+		}
+	case *ast.Ident, *ast.SelectorExpr, *ast.BinaryExpr:
+		return "", e.exprToString(arg)
+	}
+	return "", ""
 }
 
 // extractTimer extracts timer information.
@@ -748,15 +876,13 @@ func (e *callExtractor) parseActivityOptionsExpr(expr ast.Expr) *ActivityOptions
 				return e.parseActivityOptionsLiteral(lit)
 			}
 		}
-	case *ast.Ident:
-		// Variable reference - we can't trace it statically without more context,
-		// but we'll mark it as "options present but not parsed"
-		return &ActivityOptions{
-			// Mark that options were provided via variable (can't parse contents)
-			optionsProvided: true,
-		}
 	}
-	return nil
+	// Variables, fields and function results can't be traced statically without more
+	// context, so mark the options as present but not parsed
+	return &ActivityOptions{
+		optionsProvided: true,
+		Unparsed:        true,
+	}
 }
 
 // parseActivityOptionsLiteral parses a workflow.ActivityOptions{...} composite literal.
@@ -788,6 +914,8 @@ func (e *callExtractor) parseActivityOptionsLiteral(lit *ast.CompositeLit) *Acti
 			opts.ScheduleToStartTimeout = e.extractDurationString(kv.Value)
 		case "HeartbeatTimeout":
 			opts.HeartbeatTimeout = e.extractDurationString(kv.Value)
+		case "TaskQueue":
+			opts.TaskQueue = literalString(kv.Value)
 		}
 	}
 
@@ -828,7 +956,10 @@ func (e *callExtractor) parseRetryPolicy(expr ast.Expr) *RetryPolicy {
 		case "MaximumInterval":
 			policy.MaximumInterval = e.extractDurationString(kv.Value)
 		case "MaximumAttempts":
-			policy.MaximumAttempts = e.extractIntValue(kv.Value)
+			// Named constants are resolved once the package's constants are collected
+			policy.MaximumAttemptsExpr = e.exprToString(kv.Value)
+			attempts, ok := PackageConstants(nil).EvalInt(policy.MaximumAttemptsExpr)
+			policy.MaximumAttempts, policy.MaximumAttemptsUnresolved = attempts, !ok
 		}
 	}
 
@@ -848,18 +979,14 @@ func (e *callExtractor) extractFloatString(expr ast.Expr) string {
 	return e.exprToString(expr)
 }
 
-// extractIntValue extracts an integer value from an expression.
-func (e *callExtractor) extractIntValue(expr ast.Expr) int {
-	if lit, ok := expr.(*ast.BasicLit); ok {
-		if val, err := strconv.Atoi(lit.Value); err == nil {
-			return val
-		}
-	}
-	return 0
-}
-
 // ExtractParameters extracts parameter information from a function declaration.
 func (e *callExtractor) ExtractParameters(fn *ast.FuncDecl) map[string]string {
+	return extractParameters(fn, nil)
+}
+
+// extractParameters maps the parameter names of a function to their types, rendered with
+// aliases resolved. Unnamed parameters are keyed param_N.
+func extractParameters(fn *ast.FuncDecl, aliases TypeAliases) map[string]string {
 	params := make(map[string]string)
 
 	if fn.Type.Params == nil {
@@ -867,7 +994,7 @@ func (e *callExtractor) ExtractParameters(fn *ast.FuncDecl) map[string]string {
 	}
 
 	for i, field := range fn.Type.Params.List {
-		paramType := e.typeToString(field.Type)
+		paramType := aliases.String(field.Type)
 
 		// Handle multiple names for the same type (e.g., a, b int)
 		if len(field.Names) > 0 {
@@ -983,6 +1110,10 @@ func (e *callExtractor) extractResultType(expr ast.Expr) string {
 			}
 		}
 	case *ast.Ident:
+		// nil - result discarded
+		if t.Name == "nil" {
+			return "nil"
+		}
 		// result - variable (usually already a pointer)
 		return "var:" + t.Name
 	case *ast.CompositeLit:
@@ -1038,6 +1169,14 @@ func (e *callExtractor) extractFunctionReference(expr ast.Expr) string {
 			return ident.Name + "." + e.Sel.Name
 		}
 		return e.Sel.Name
+	case *ast.BasicLit:
+		// Calls by registered name, like ExecuteActivity(ctx, "v2.Charge")
+		if e.Kind == token.STRING {
+			if name, err := strconv.Unquote(e.Value); err == nil {
+				return name
+			}
+		}
+		return ""
 	case *ast.FuncLit:
 		return ""
 	default:
@@ -1064,6 +1203,16 @@ func (e *callExtractor) exprToString(expr ast.Expr) string {
 		return e.exprToString(t.X) + "." + t.Sel.Name
 	case *ast.BinaryExpr:
 		return e.exprToString(t.X) + " " + t.Op.String() + " " + e.exprToString(t.Y)
+	case *ast.ParenExpr:
+		return "(" + e.exprToString(t.X) + ")"
+	case *ast.UnaryExpr:
+		return t.Op.String() + e.exprToString(t.X)
+	case *ast.CallExpr:
+		args := make([]string, len(t.Args))
+		for i, arg := range t.Args {
+			args[i] = e.exprToString(arg)
+		}
+		return e.exprToString(t.Fun) + "(" + strings.Join(args, ", ") + ")"
 	default:
 		return "<expr>"
 	}
@@ -1071,33 +1220,7 @@ func (e *callExtractor) exprToString(expr ast.Expr) string {
 
 // typeToString converts an AST type expression to a string representation.
 func (e *callExtractor) typeToString(expr ast.Expr) string {
-	switch t := expr.(type) {
-	case *ast.Ident:
-		return t.Name
-	case *ast.SelectorExpr:
-		if pkg, ok := t.X.(*ast.Ident); ok {
-			return pkg.Name + "." + t.Sel.Name
-		}
-		return t.Sel.Name
-	case *ast.StarExpr:
-		return "*" + e.typeToString(t.X)
-	case *ast.ArrayType:
-		return "[]" + e.typeToString(t.Elt)
-	case *ast.MapType:
-		return "map[" + e.typeToString(t.Key) + "]" + e.typeToString(t.Value)
-	case *ast.InterfaceType:
-		return "interface{}"
-	case *ast.StructType:
-		return "struct{}"
-	case *ast.FuncType:
-		return "func"
-	case *ast.ChanType:
-		return "chan " + e.typeToString(t.Value)
-	case *ast.Ellipsis:
-		return "..." + e.typeToString(t.Elt)
-	default:
-		return "unknown"
-	}
+	return TypeString(expr)
 }
 
 // ExtractCallsWithFileSet extracts calls with proper position information using a file set.
@@ -1107,15 +1230,19 @@ func (e *callExtractor) ExtractCallsWithFileSet(ctx context.Context, fn *ast.Fun
 	}
 
 	var callSites []CallSite
+	scope := newOptionsScope()
+	done := ctx.Done()
 
 	// Walk through the function body to find calls
 	ast.Inspect(fn.Body, func(n ast.Node) bool {
 		select {
-		case <-ctx.Done():
+		case <-done:
 			return false
 		default:
 		}
 
+		e.trackOptionsAssignment(scope, n)
+
 		call, ok := n.(*ast.CallExpr)
 		if !ok {
 			return true
@@ -1123,6 +1250,7 @@ func (e *callExtractor) ExtractCallsWithFileSet(ctx context.Context, fn *ast.Fun
 
 		info := e.analyzeCall(call, filePath, fset)
 		if info != nil && info.TargetName != "" {
+			e.applyScopedOptions(scope, call, info)
 			callSites = append(callSites, CallSite{
 				TargetName:         info.TargetName,
 				TargetType:         info.Type,
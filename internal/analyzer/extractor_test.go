@@ -2,11 +2,14 @@ package analyzer
 
 import (
 	"context"
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -594,6 +597,11 @@ func TestExtractResultType(t *testing.T) {
 			code:     `package test; var _ = result`,
 			wantType: "var:result",
 		},
+		{
+			name:     "nil",
+			code:     `package test; var _ = nil`,
+			wantType: "nil",
+		},
 		{
 			name:     "new call",
 			code:     `package test; var _ = new(MyType)`,
@@ -1026,6 +1034,9 @@ func MyWorkflow(ctx workflow.Context) error {
 					if !call.ParsedActivityOpts.OptionsProvided() {
 						t.Error("Expected OptionsProvided to be true for variable reference")
 					}
+					if !call.ParsedActivityOpts.Unparsed {
+						t.Error("Expected Unparsed to be true for variable reference")
+					}
 					return
 				}
 			}
@@ -1150,6 +1161,7 @@ func MyWorkflow(ctx workflow.Context) error {
 			ScheduleToCloseTimeout: 30 * time.Minute,
 			ScheduleToStartTimeout: 5 * time.Minute,
 			HeartbeatTimeout:       time.Minute,
+			TaskQueue:              "bulk",
 		}),
 		MyActivity,
 	)
@@ -1192,6 +1204,9 @@ func MyWorkflow(ctx workflow.Context) error {
 					if opts.HeartbeatTimeout == "" {
 						t.Error("Expected HeartbeatTimeout to be parsed")
 					}
+					if opts.TaskQueue != "bulk" {
+						t.Errorf("TaskQueue = %q, want %q", opts.TaskQueue, "bulk")
+					}
 					return
 				}
 			}
@@ -1311,3 +1326,168 @@ func TestActivityOptionsHelperMethods(t *testing.T) {
 		t.Error("ActivityOptions with RetryPolicy.BackoffCoefficient should return true for HasRetryPolicy")
 	}
 }
+
+// largeWorkflowFile generates a file of n workflows, each executing activities, sending
+// signals, setting timers and calling helpers, like the largest files of real projects.
+func largeWorkflowFile(n int) string {
+	var b strings.Builder
+	b.WriteString(`package orders
+
+import (
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+)
+`)
+	for i := range n {
+		fmt.Fprintf(&b, `
+func Order%[1]dWorkflow(ctx workflow.Context, orderID string) error {
+	ao := workflow.ActivityOptions{StartToCloseTimeout: time.Minute}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+	logger := workflow.GetLogger(ctx)
+	logger.Info("starting", "order", orderID)
+	var result string
+	if err := workflow.ExecuteActivity(ctx, Charge%[1]dActivity, orderID, 42).Get(ctx, &result); err != nil {
+		return fmt.Errorf("charge: %%w", err)
+	}
+	ch := workflow.GetSignalChannel(ctx, "cancel-%[1]d")
+	ch.Receive(ctx, nil)
+	_ = workflow.Sleep(ctx, time.Hour)
+	items := make([]string, 0, len(orderID))
+	items = append(items, validate%[1]d(orderID))
+	return workflow.ExecuteChildWorkflow(ctx, Ship%[1]dWorkflow, items).Get(ctx, nil)
+}
+`, i)
+	}
+	return b.String()
+}
+
+func benchmarkExtractorFile(b *testing.B) (*callExtractor, *token.FileSet, []*ast.FuncDecl) {
+	b.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "/repo/orders/workflows.go", largeWorkflowFile(200), 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var funcs []*ast.FuncDecl
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			funcs = append(funcs, fn)
+		}
+	}
+	return &callExtractor{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}, fset, funcs
+}
+
+func BenchmarkExtractAllTemporalInfo(b *testing.B) {
+	e, fset, funcs := benchmarkExtractorFile(b)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		for _, fn := range funcs {
+			if _, err := e.ExtractAllTemporalInfo(ctx, fn, "/repo/orders/workflows.go", fset); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkExtractCallsWithFileSet(b *testing.B) {
+	e, fset, funcs := benchmarkExtractorFile(b)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		for _, fn := range funcs {
+			if _, err := e.ExtractCallsWithFileSet(ctx, fn, "/repo/orders/workflows.go", fset); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkExtractInternalCalls(b *testing.B) {
+	e, fset, funcs := benchmarkExtractorFile(b)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		for _, fn := range funcs {
+			e.extractInternalCalls(ctx, fn, "/repo/orders/workflows.go", fset)
+		}
+	}
+}
+
+// fuzzSeeds are Temporal code and exotic Go the fuzz targets start from.
+var fuzzSeeds = []string{
+	largeWorkflowFile(1),
+	`package p
+
+import "go.temporal.io/sdk/workflow"
+
+func W(ctx workflow.Context) error {
+	ao := &workflow.ActivityOptions{RetryPolicy: &temporal.RetryPolicy{MaximumAttempts: 1 << 70}}
+	ctx = workflow.WithActivityOptions(ctx, *ao)
+	sel := workflow.NewSelector(ctx)
+	sel.AddReceive(workflow.GetSignalChannel(ctx, "s"), func(c workflow.ReceiveChannel, more bool) {})
+	_ = workflow.SetQueryHandler(ctx, "q", func() (string, error) { return "", nil })
+	_ = workflow.GetVersion(ctx, "change", workflow.DefaultVersion, 1)
+	return workflow.ExecuteActivity(ctx, a.b.c, 'x', 0x1p-2, 1_000i, ` + "`raw`" + `).Get(ctx, &[]map[string]*int{})
+}
+`,
+	`package p
+
+func Run[T any, PT interface{ *T }](ctx workflow.Context, fn func(T) error, args ...T) error {
+	return workflow.ExecuteActivity(ctx, fn, args...).Get(ctx, new(T))
+}
+
+func (s *Service[K]) Do(ctx workflow.Context) { Run[int](ctx, s.Act) }
+`,
+	`package p
+
+import "go.temporal.io/sdk/worker"
+
+type Acts[K comparable, V any] struct{}
+
+func (Acts[K, V]) M(ctx workflow.Context) error { return workflow.ExecuteActivity(ctx, Acts[K, V]{}.M).Get(ctx, nil) }
+func (*Acts[K, V]) N(ctx workflow.Context) error { return workflow.ExecuteChildWorkflow(ctx, (*Acts[K, V]).N).Get(ctx, nil) }
+func main() { worker.RegisterActivity(&Acts[int, string]{}); worker.RegisterWorkflow(); worker.RegisterActivity(new(Acts[int, int])) }
+`,
+	`package p
+
+// #include <stdio.h>
+import "C"
+
+func W(ctx workflow.Context) { C.puts(C.CString("x")); workflow.ExecuteChildWorkflow(ctx) }
+`,
+	`package p
+
+func W(ctx workflow.Context) { workflow.ExecuteActivity(); workflow.ExecuteActivity(ctx, func() {}); (workflow.ExecuteActivity)(ctx, A) }
+`,
+}
+
+func FuzzExtractAllTemporalInfo(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	e := &callExtractor{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	ctx := context.Background()
+
+	f.Fuzz(func(t *testing.T, src string) {
+		// Partial syntax trees of code that does not parse must not crash the extractor either
+		fset := token.NewFileSet()
+		file, _ := parser.ParseFile(fset, "fuzz.go", src, parser.ParseComments|parser.SkipObjectResolution)
+		if file == nil {
+			return
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			_, _ = e.ExtractAllTemporalInfo(ctx, fn, "fuzz.go", fset)
+			_, _ = e.ExtractCalls(ctx, fn, "fuzz.go")
+			_, _ = e.ExtractCallsWithFileSet(ctx, fn, "fuzz.go", fset)
+			e.extractInternalCalls(ctx, fn, "fuzz.go", fset)
+			e.ExtractParameters(fn)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+)
+
+// FileError records a Go file the analysis skipped, entirely or in part, because it could
+// not be parsed or analyzing it panicked.
+type FileError struct {
+	FilePath string `json:"file_path"`
+	// Stage is the analysis stage that failed, e.g. parse or graph
+	Stage string `json:"stage"`
+	Error string `json:"error"`
+	// Stack is the stack of the panic, if the analysis of the file panicked
+	Stack string `json:"stack,omitempty"`
+}
+
+// FailedFiles returns the sorted paths of the files that failed to analyze.
+func (g *TemporalGraph) FailedFiles() []string {
+	seen := make(map[string]bool, len(g.FileErrors))
+	var files []string
+	for _, fe := range g.FileErrors {
+		if !seen[fe.FilePath] {
+			seen[fe.FilePath] = true
+			files = append(files, fe.FilePath)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// panicError is a panic recovered while analyzing a file.
+type panicError struct {
+	value any
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.value)
+}
+
+// recovered runs the analysis of a file, turning a panic into an error so a file the
+// analyzer chokes on is skipped instead of crashing the whole run.
+func recovered(analyze func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &panicError{value: r, stack: debug.Stack()}
+		}
+	}()
+	analyze()
+	return nil
+}
+
+// fileErrors collects the FileErrors of an analysis.
+type fileErrors struct {
+	errs []FileError
+}
+
+type fileErrorsKey struct{}
+
+// withFileErrors returns a context that collects the file errors recorded during an analysis.
+func withFileErrors(ctx context.Context) (context.Context, *fileErrors) {
+	fe := &fileErrors{}
+	return context.WithValue(ctx, fileErrorsKey{}, fe), fe
+}
+
+// recordFileError records that a stage of the analysis failed for a file.
+func recordFileError(ctx context.Context, stage, filePath string, err error) {
+	fe, ok := ctx.Value(fileErrorsKey{}).(*fileErrors)
+	if !ok {
+		return
+	}
+	record := FileError{FilePath: filePath, Stage: stage, Error: err.Error()}
+	if p, ok := err.(*panicError); ok {
+		record.Stack = string(p.stack)
+	}
+	fe.errs = append(fe.errs, record)
+}
@@ -0,0 +1,126 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+func TestRecovered(t *testing.T) {
+	if err := recovered(func() {}); err != nil {
+		t.Errorf("recovered() without a panic = %v, want nil", err)
+	}
+
+	err := recovered(func() { panic("boom") })
+	p, ok := err.(*panicError)
+	if !ok || err.Error() != "panic: boom" {
+		t.Fatalf("recovered() = %v, want the panic as an error", err)
+	}
+	if !strings.Contains(string(p.stack), "TestRecovered") {
+		t.Errorf("Expected the stack of the panic, got:\n%s", p.stack)
+	}
+}
+
+func TestAnalyzeReportsUnparsableFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"workflow.go": `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, ChargeCard).Get(ctx, nil)
+}
+`,
+		"broken.go": "package orders\n\nfunc Broken( {\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if graph.Nodes["OrderWorkflow"] == nil {
+		t.Error("Expected the workflow of the parsable file in the graph")
+	}
+	if graph.Stats.FailedFiles != 1 || len(graph.FileErrors) != 1 {
+		t.Fatalf("Expected 1 failed file, got %d: %+v", graph.Stats.FailedFiles, graph.FileErrors)
+	}
+	if fe := graph.FileErrors[0]; fe.FilePath != filepath.Join(dir, "broken.go") || fe.Stage != StageParse || fe.Stack != "" {
+		t.Errorf("Unexpected file error: %+v", fe)
+	}
+}
+
+// panickingExtractor is a CallExtractor that panics on one function.
+type panickingExtractor struct {
+	CallExtractor
+}
+
+func (e panickingExtractor) ExtractCalls(ctx context.Context, fn *ast.FuncDecl, filePath string) ([]CallSite, error) {
+	if fn.Name.Name == "Crashing" {
+		var calls []CallSite
+		_ = calls[1]
+	}
+	return e.CallExtractor.ExtractCalls(ctx, fn, filePath)
+}
+
+func TestBuildGraphRecoversPanics(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	src := `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+func Crashing(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, ChargeCard).Get(ctx, nil)
+}
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, ChargeCard).Get(ctx, nil)
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "orders.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var matches []NodeMatch
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			matches = append(matches, NodeMatch{Node: fn, FileSet: fset, FilePath: "orders.go", Package: "orders", NodeType: "workflow"})
+		}
+	}
+
+	ctx, fileErrs := withFileErrors(context.Background())
+	builder := NewGraphBuilder(logger, panickingExtractor{NewCallExtractor(logger)})
+	graph, err := builder.BuildGraph(ctx, matches)
+	if err != nil {
+		t.Fatalf("BuildGraph failed: %v", err)
+	}
+
+	if len(graph.Nodes["OrderWorkflow"].CallSites) != 1 {
+		t.Errorf("Expected the other functions of the file to be analyzed, got %+v", graph.Nodes["OrderWorkflow"])
+	}
+	if len(fileErrs.errs) != 1 {
+		t.Fatalf("Expected 1 file error, got %+v", fileErrs.errs)
+	}
+	fe := fileErrs.errs[0]
+	if fe.Stage != StageGraph || fe.FilePath != "orders.go" || !strings.Contains(fe.Error, "index out of range") ||
+		!strings.Contains(fe.Stack, "panickingExtractor.ExtractCalls") {
+		t.Errorf("Unexpected file error: %+v", fe)
+	}
+}
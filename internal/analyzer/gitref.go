@@ -0,0 +1,180 @@
+package analyzer
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// SnapshotGitRef extracts the tree of a git ref into a temporary directory so it can be analyzed.
+// It returns the directory corresponding to dir inside the snapshot and a cleanup function
+// that removes the snapshot.
+func SnapshotGitRef(ctx context.Context, dir, ref string) (string, func(), error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	topLevel, err := runGit(ctx, absDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find git repository: %w", err)
+	}
+	prefix, err := runGit(ctx, absDir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find repository prefix: %w", err)
+	}
+	if _, err := runGit(ctx, absDir, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return "", nil, fmt.Errorf("unknown git ref %q: %w", ref, err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "temporal-analyzer-base-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(tmpDir) }
+
+	args := []string{"archive", "--format=tar", ref}
+	if prefix != "" {
+		args = append(args, "--", prefix)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = topLevel
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to run git archive: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to run git archive: %w", err)
+	}
+
+	extractErr := extractTar(stdout, tmpDir)
+	// Drain the pipe so git can exit if extraction stopped early
+	_, _ = io.Copy(io.Discard, stdout)
+	if err := cmd.Wait(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to archive %s: %w: %s", ref, err, strings.TrimSpace(stderr.String()))
+	}
+	if extractErr != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to extract %s: %w", ref, extractErr)
+	}
+
+	return filepath.Join(tmpDir, filepath.FromSlash(prefix)), cleanup, nil
+}
+
+// RebasePath returns path moved from under the directory from to the same place under to,
+// or path unchanged if it is not under from.
+func RebasePath(path, from, to string) string {
+	if path == "" || filepath.IsAbs(path) != filepath.IsAbs(from) {
+		return path
+	}
+	rel, err := filepath.Rel(from, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return path
+	}
+	return filepath.Join(to, rel)
+}
+
+// RebasePaths moves the file paths of the graph from under the directory from to under to,
+// e.g. from a SnapshotGitRef directory back to the working tree it was taken from.
+func (g *TemporalGraph) RebasePaths(from, to string) {
+	for _, node := range g.Nodes {
+		node.FilePath = RebasePath(node.FilePath, from, to)
+		for i := range node.Tests {
+			node.Tests[i].FilePath = RebasePath(node.Tests[i].FilePath, from, to)
+		}
+		for i := range node.CallSites {
+			node.CallSites[i].FilePath = RebasePath(node.CallSites[i].FilePath, from, to)
+		}
+		for i := range node.InternalCalls {
+			node.InternalCalls[i].FilePath = RebasePath(node.InternalCalls[i].FilePath, from, to)
+		}
+		for i := range node.CalledBy {
+			node.CalledBy[i].FilePath = RebasePath(node.CalledBy[i].FilePath, from, to)
+		}
+	}
+	for i := range g.Workers {
+		g.Workers[i].FilePath = RebasePath(g.Workers[i].FilePath, from, to)
+	}
+	for i := range g.EntryPoints {
+		g.EntryPoints[i].FilePath = RebasePath(g.EntryPoints[i].FilePath, from, to)
+	}
+	for i := range g.Messages {
+		g.Messages[i].FilePath = RebasePath(g.Messages[i].FilePath, from, to)
+	}
+	for i := range g.WorkflowIDs {
+		g.WorkflowIDs[i].FilePath = RebasePath(g.WorkflowIDs[i].FilePath, from, to)
+	}
+	for i := range g.FileErrors {
+		g.FileErrors[i].FilePath = RebasePath(g.FileErrors[i].FilePath, from, to)
+	}
+}
+
+// runGit runs a git command in dir and returns its trimmed output.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// extractTar writes the directories and regular files of a tar stream into dest.
+// Symlinks and other entry types are skipped.
+func extractTar(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !filepath.IsLocal(header.Name) {
+			return fmt.Errorf("invalid path in archive: %s", header.Name)
+		}
+		target := filepath.Join(dest, filepath.FromSlash(header.Name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			_, copyErr := io.Copy(f, tr)
+			closeErr := f.Close()
+			if copyErr != nil {
+				return copyErr
+			}
+			if closeErr != nil {
+				return closeErr
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func gitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+}
+
+func TestSnapshotGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	subDir := filepath.Join(repo, "workflows")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	file := filepath.Join(subDir, "workflow.go")
+
+	gitCmd(t, repo, "init", "-q")
+	if err := os.WriteFile(file, []byte("package workflows // base\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	gitCmd(t, repo, "add", "-A")
+	gitCmd(t, repo, "commit", "-q", "-m", "base")
+	gitCmd(t, repo, "tag", "base")
+
+	if err := os.WriteFile(file, []byte("package workflows // head\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	gitCmd(t, repo, "commit", "-q", "-am", "head")
+
+	snapshotDir, cleanup, err := SnapshotGitRef(context.Background(), subDir, "base")
+	if err != nil {
+		t.Fatalf("SnapshotGitRef failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(snapshotDir, "workflow.go"))
+	if err != nil {
+		t.Fatalf("Expected workflow.go in snapshot: %v", err)
+	}
+	if string(data) != "package workflows // base\n" {
+		t.Errorf("Snapshot content = %q, want base version", data)
+	}
+
+	cleanup()
+	if _, err := os.Stat(snapshotDir); !os.IsNotExist(err) {
+		t.Error("Expected cleanup to remove the snapshot")
+	}
+
+	if _, _, err := SnapshotGitRef(context.Background(), subDir, "does-not-exist"); err == nil {
+		t.Error("Expected error for unknown ref")
+	}
+}
+
+func TestRebasePaths(t *testing.T) {
+	snapshot := filepath.Join(os.TempDir(), "temporal-analyzer-base-1", "svc")
+	graph := &TemporalGraph{
+		Nodes: map[string]*TemporalNode{
+			"OrderWorkflow": {
+				Name:      "OrderWorkflow",
+				FilePath:  filepath.Join(snapshot, "orders", "workflow.go"),
+				CallSites: []CallSite{{TargetName: "ChargeCard", FilePath: filepath.Join(snapshot, "orders", "workflow.go")}},
+				Tests:     []TestReference{{TestName: "TestOrderWorkflow", FilePath: filepath.Join(snapshot, "orders", "workflow_test.go")}},
+			},
+			"SendEmail": {Name: "SendEmail", Unresolved: true},
+		},
+		Workers: []WorkerConfig{{TaskQueue: "orders", FilePath: filepath.Join(snapshot, "cmd", "worker.go")}},
+	}
+
+	graph.RebasePaths(snapshot, ".")
+
+	node := graph.Nodes["OrderWorkflow"]
+	for got, want := range map[string]string{
+		node.FilePath:              filepath.Join("orders", "workflow.go"),
+		node.Tests[0].FilePath:     filepath.Join("orders", "workflow_test.go"),
+		graph.Workers[0].FilePath:  filepath.Join("cmd", "worker.go"),
+		node.CallSites[0].FilePath: filepath.Join("orders", "workflow.go"),
+	} {
+		if got != want {
+			t.Errorf("Rebased path = %q, want %q", got, want)
+		}
+	}
+	if graph.Nodes["SendEmail"].FilePath != "" {
+		t.Errorf("Stub path = %q, want empty", graph.Nodes["SendEmail"].FilePath)
+	}
+
+	// Paths outside the snapshot are left alone
+	if got := RebasePath("/elsewhere/x.go", snapshot, "/repo"); got != "/elsewhere/x.go" {
+		t.Errorf("RebasePath() outside the snapshot = %q", got)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"go/ast"
 	"log/slog"
+	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -12,6 +14,13 @@ import (
 type graphBuilder struct {
 	logger        *slog.Logger
 	callExtractor CallExtractor
+
+	// Set by BuildGraph for names defined in several packages
+	keys      map[*ast.FuncDecl]string // Package-qualified graph key of each colliding function
+	qualified map[string][]string      // Bare name -> package-qualified keys
+
+	// Set by BuildGraph: name registered with RegisterOptions{Name: ...} -> graph key
+	aliases map[string]string
 }
 
 // NewGraphBuilder creates a new GraphBuilder instance.
@@ -31,20 +40,37 @@ func (g *graphBuilder) BuildGraph(ctx context.Context, nodes []NodeMatch) (*Temp
 	}
 
 	// First pass: create nodes
-	for _, match := range nodes {
+	created := make([]*TemporalNode, len(nodes))
+	for i, match := range nodes {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
 		}
 
-		node, err := g.createNodeFromMatch(ctx, match)
+		var node *TemporalNode
+		var err error
+		if panicErr := recovered(func() { node, err = g.createNodeFromMatch(ctx, match) }); panicErr != nil {
+			recordFileError(ctx, StageGraph, match.FilePath, panicErr)
+			err = panicErr
+		}
 		if err != nil {
 			g.logger.Warn("Failed to create node from match", "error", err)
 			continue
 		}
+		created[i] = node
+	}
 
-		graph.Nodes[node.Name] = node
+	// Names defined in several packages are keyed by package-qualified names so they don't overwrite each other
+	g.assignNodeKeys(nodes, created)
+	g.aliases = make(map[string]string)
+	for _, node := range created {
+		if node != nil {
+			graph.Nodes[node.ID()] = node
+			for _, alias := range node.Aliases {
+				g.aliases[alias] = node.ID()
+			}
+		}
 	}
 
 	// Second pass: build relationships and extract temporal info
@@ -55,13 +81,38 @@ func (g *graphBuilder) BuildGraph(ctx context.Context, nodes []NodeMatch) (*Temp
 		default:
 		}
 
-		err := g.buildRelationships(ctx, match, graph)
+		var err error
+		if panicErr := recovered(func() { err = g.buildRelationships(ctx, match, graph) }); panicErr != nil {
+			recordFileError(ctx, StageGraph, match.FilePath, panicErr)
+			err = panicErr
+		}
 		if err != nil {
 			fn := match.Node.(*ast.FuncDecl)
 			g.logger.Warn("Failed to build relationships", "node", fn.Name.Name, "error", err)
 		}
 	}
 
+	// Third pass: route calls through wrappers of workflow.Execute* to the targets passed to them
+	g.routeExecuteWrappers(ctx, nodes, graph)
+
+	// Fourth pass: propagate context options into shared helper functions
+	g.propagateContextOptions(ctx, nodes, graph)
+
+	// Fifth pass: resolve the helper functions nodes call into helper nodes (--internal-depth)
+	g.resolveHelpers(ctx, nodes, graph)
+
+	// Evaluate constant timeouts and timer durations, now that all options are known
+	for _, match := range nodes {
+		if fn, ok := match.Node.(*ast.FuncDecl); ok && fn.Name != nil {
+			if node, exists := graph.Nodes[g.nodeKey(fn)]; exists {
+				match.Constants.resolveConstants(node)
+			}
+		}
+	}
+
+	// Rate how reliably each node was detected, now that calls into it are known
+	assignConfidence(graph)
+
 	// Calculate statistics
 	if err := g.CalculateStats(ctx, graph); err != nil {
 		return nil, fmt.Errorf("failed to calculate stats: %w", err)
@@ -91,14 +142,15 @@ func (g *graphBuilder) createNodeFromMatch(ctx context.Context, match NodeMatch)
 	// Get position information
 	pos := match.FileSet.Position(fn.Pos())
 
-	// Extract parameters
-	parameters := g.callExtractor.ExtractParameters(fn)
+	// Extract parameters, with the package's type aliases resolved
+	parameters := extractParameters(fn, match.Aliases)
 
-	// Extract description from comments
+	// Extract description and annotations from comments
 	description := g.extractDescription(fn)
+	annotations := g.extractAnnotations(fn)
 
-	// Extract return type
-	returnType := g.extractReturnType(fn)
+	// Extract return types
+	returnTypes := g.extractReturnTypes(fn, match.Aliases)
 
 	// Extract receiver type for methods to create a qualified name
 	receiver := g.extractReceiverType(fn)
@@ -110,22 +162,31 @@ func (g *graphBuilder) createNodeFromMatch(ctx context.Context, match NodeMatch)
 	}
 
 	node := &TemporalNode{
-		Name:        qualifiedName,
-		Type:        match.NodeType,
-		Package:     match.Package,
-		FilePath:    match.FilePath,
-		LineNumber:  pos.Line,
-		Description: description,
-		Parameters:  parameters,
-		ReturnType:  returnType,
-		CallSites:   []CallSite{},
-		Parents:     []string{},
-		Signals:     []SignalDef{},
-		Queries:     []QueryDef{},
-		Updates:     []UpdateDef{},
-		Timers:      []TimerDef{},
-		SearchAttrs: []SearchAttrDef{},
-		Versioning:  []VersionDef{},
+		Name:           qualifiedName,
+		Type:           match.NodeType,
+		Package:        match.Package,
+		FilePath:       match.FilePath,
+		TestOrigin:     IsTestFile(match.FilePath),
+		LineNumber:     pos.Line,
+		Description:    description,
+		Annotations:    annotations,
+		Parameters:     parameters,
+		ParameterTypes: g.extractParameterTypes(fn, match.Aliases),
+		ReturnType:     g.extractReturnType(fn, match.Aliases),
+		ReturnTypes:    returnTypes,
+		CallSites:      []CallSite{},
+		Parents:        []string{},
+		Signals:        []SignalDef{},
+		Queries:        []QueryDef{},
+		Updates:        []UpdateDef{},
+		Timers:         []TimerDef{},
+		SearchAttrs:    []SearchAttrDef{},
+		Versioning:     []VersionDef{},
+
+		DetectionReasons: append([]string(nil), match.Reasons...),
+	}
+	if len(match.Names) > 0 {
+		node.Aliases = append([]string(nil), match.Names...)
 	}
 
 	return node, nil
@@ -139,7 +200,7 @@ func (g *graphBuilder) extractReceiverType(fn *ast.FuncDecl) string {
 	}
 
 	recv := fn.Recv.List[0]
-	return g.typeToString(recv.Type)
+	return TypeString(recv.Type)
 }
 
 // buildRelationships builds call relationships between nodes.
@@ -153,13 +214,7 @@ func (g *graphBuilder) buildRelationships(ctx context.Context, match NodeMatch,
 		return fmt.Errorf("function declaration has no name")
 	}
 
-	// Use qualified name (with receiver) to match how nodes are stored
-	receiver := g.extractReceiverType(fn)
-	nodeName := fn.Name.Name
-	if receiver != "" {
-		nodeName = receiver + "." + fn.Name.Name
-	}
-
+	nodeName := g.nodeKey(fn)
 	node, exists := graph.Nodes[nodeName]
 	if !exists {
 		return fmt.Errorf("node %s not found in graph", nodeName)
@@ -174,37 +229,7 @@ func (g *graphBuilder) buildRelationships(ctx context.Context, match NodeMatch,
 		}
 
 		if details != nil {
-			node.CallSites = details.CallSites
-			node.Signals = details.Signals
-			node.Queries = details.Queries
-			node.Updates = details.Updates
-			node.Timers = details.Timers
-			node.Versioning = details.Versions
-			node.SearchAttrs = details.SearchAttrs
-
-			// Build parent relationships with fuzzy matching
-			// Also create stub nodes for unresolved activity/workflow targets
-			for i, callSite := range details.CallSites {
-				resolvedName := g.resolveTargetName(callSite.TargetName, graph)
-				if resolvedName != callSite.TargetName {
-					// Update the call site with resolved name
-					details.CallSites[i].TargetName = resolvedName
-				}
-				if targetNode, exists := graph.Nodes[resolvedName]; exists {
-					targetNode.Parents = g.addUniqueParent(targetNode.Parents, nodeName)
-				} else if callSite.TargetType == "activity" || callSite.TargetType == "child_workflow" || callSite.TargetType == "local_activity" {
-					// Create stub node for unresolved activity/workflow targets
-					// This handles cases where the function is called via ExecuteActivity
-					// but wasn't detected during parsing
-					stubNode := &TemporalNode{
-						Name:    resolvedName,
-						Type:    callSite.TargetType,
-						Parents: []string{nodeName},
-					}
-					graph.Nodes[resolvedName] = stubNode
-				}
-			}
-			node.CallSites = details.CallSites
+			g.applyTemporalInfo(node, details, graph)
 		}
 
 		// Extract internal (non-Temporal) function calls
@@ -220,23 +245,8 @@ func (g *graphBuilder) buildRelationships(ctx context.Context, match NodeMatch,
 		}
 
 		// Resolve target names with fuzzy matching
-		// Also create stub nodes for unresolved activity/workflow targets
-		for i, callSite := range callSites {
-			resolvedName := g.resolveTargetName(callSite.TargetName, graph)
-			if resolvedName != callSite.TargetName {
-				callSites[i].TargetName = resolvedName
-			}
-			if targetNode, exists := graph.Nodes[resolvedName]; exists {
-				targetNode.Parents = g.addUniqueParent(targetNode.Parents, nodeName)
-			} else if callSite.TargetType == "activity" || callSite.TargetType == "child_workflow" || callSite.TargetType == "local_activity" {
-				// Create stub node for unresolved activity/workflow targets
-				stubNode := &TemporalNode{
-					Name:    resolvedName,
-					Type:    callSite.TargetType,
-					Parents: []string{nodeName},
-				}
-				graph.Nodes[resolvedName] = stubNode
-			}
+		for i := range callSites {
+			g.linkCallSite(&callSites[i], node, graph)
 		}
 		node.CallSites = callSites
 	}
@@ -244,6 +254,31 @@ func (g *graphBuilder) buildRelationships(ctx context.Context, match NodeMatch,
 	return nil
 }
 
+// applyTemporalInfo sets the Temporal calls and handlers extracted from a node's function,
+// linking its call sites to their targets unless graph is nil.
+func (g *graphBuilder) applyTemporalInfo(node *TemporalNode, details *TemporalNodeDetails, graph *TemporalGraph) {
+	node.Signals = details.Signals
+	node.Queries = details.Queries
+	node.Updates = details.Updates
+	node.Timers = details.Timers
+	node.SignalReceives = details.SignalReceives
+	if node.Type == "workflow" {
+		node.LogCalls = details.LogCalls
+		node.MetricCalls = details.MetricCalls
+		node.ContextEscapes = details.ContextEscapes
+	}
+	node.Versioning = details.Versions
+	node.SearchAttrs = details.SearchAttrs
+
+	// Build parent relationships with fuzzy matching
+	if graph != nil {
+		for i := range details.CallSites {
+			g.linkCallSite(&details.CallSites[i], node, graph)
+		}
+	}
+	node.CallSites = details.CallSites
+}
+
 // CalculateStats computes statistics for the given graph.
 func (g *graphBuilder) CalculateStats(ctx context.Context, graph *TemporalGraph) error {
 	stats := GraphStats{}
@@ -281,6 +316,10 @@ func (g *graphBuilder) CalculateStats(ctx context.Context, graph *TemporalGraph)
 		// Count connections
 		fanOut := len(node.CallSites)
 		stats.TotalConnections += fanOut
+		for _, edge := range node.Edges() {
+			stats.DistinctConnections++
+			stats.WeightedConnections += edge.Weight
+		}
 		totalFanOut += fanOut
 		nodeCount++
 
@@ -302,6 +341,14 @@ func (g *graphBuilder) CalculateStats(ctx context.Context, graph *TemporalGraph)
 
 	// Calculate maximum depth
 	stats.MaxDepth = g.calculateMaxDepth(ctx, graph)
+	stats.UnresolvedTargets = len(graph.UnresolvedTargets())
+	stats.FailedFiles = len(graph.FailedFiles())
+
+	// Calculate coupling between business domains
+	stats.DomainCoupling = calculateDomainCoupling(graph)
+	for _, c := range stats.DomainCoupling {
+		stats.CrossDomainCalls += c.Calls
+	}
 
 	graph.Stats = stats
 	return nil
@@ -342,12 +389,12 @@ func (g *graphBuilder) calculateNodeDepth(ctx context.Context, node *TemporalNod
 	}
 
 	// Prevent infinite recursion
-	if visited[node.Name] {
+	if visited[node.ID()] {
 		return currentDepth
 	}
 
-	visited[node.Name] = true
-	defer func() { visited[node.Name] = false }()
+	visited[node.ID()] = true
+	defer func() { visited[node.ID()] = false }()
 
 	maxChildDepth := currentDepth
 
@@ -364,89 +411,163 @@ func (g *graphBuilder) calculateNodeDepth(ctx context.Context, node *TemporalNod
 }
 
 // extractDescription extracts documentation from function comments.
-// Optimized for Go 1.25 with reduced allocations using strings.Builder.
+// The full doc comment is joined into a single line; annotation lines (@key value) are skipped.
 func (g *graphBuilder) extractDescription(fn *ast.FuncDecl) string {
 	if fn.Doc == nil || len(fn.Doc.List) == 0 {
 		return ""
 	}
 
-	// Fast path: return first non-empty comment line (most common case)
-	for _, comment := range fn.Doc.List {
-		text := comment.Text
-		// Remove comment markers
-		text = strings.TrimPrefix(text, "//")
-		text = strings.TrimPrefix(text, " ")
+	var sb strings.Builder
+	for _, line := range strings.Split(fn.Doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "@") {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(line)
+	}
+
+	return sb.String()
+}
+
+// extractAnnotations parses structured annotations from function comments, e.g.
+//
+//	// @owner team-payments
+//	// @sla 5m
+//	// @temporal:taskqueue payments
+//
+// Annotations without a value are recorded with an empty value; repeated keys are joined with ", ".
+func (g *graphBuilder) extractAnnotations(fn *ast.FuncDecl) map[string]string {
+	if fn.Doc == nil || len(fn.Doc.List) == 0 {
+		return nil
+	}
+
+	var annotations map[string]string
+	for _, line := range strings.Split(fn.Doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		key, value, _ := strings.Cut(line[1:], " ")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if key == "" {
+			continue
+		}
 
-		if text != "" {
-			return text
+		if annotations == nil {
+			annotations = make(map[string]string)
 		}
+		if existing := annotations[key]; existing != "" {
+			if value == "" {
+				value = existing
+			} else {
+				value = existing + ", " + value
+			}
+		}
+		annotations[key] = value
 	}
 
-	return ""
+	return annotations
 }
 
 // extractReturnType extracts the return type from a function declaration.
-func (g *graphBuilder) extractReturnType(fn *ast.FuncDecl) string {
+func (g *graphBuilder) extractReturnType(fn *ast.FuncDecl, aliases TypeAliases) string {
 	if fn.Type.Results == nil || len(fn.Type.Results.List) == 0 {
 		return ""
 	}
 
 	// Get the first return type (usually the main return value before error)
 	if len(fn.Type.Results.List) > 0 {
-		return g.typeToString(fn.Type.Results.List[0].Type)
+		return aliases.String(fn.Type.Results.List[0].Type)
 	}
 
 	return ""
 }
 
-// typeToString converts an AST type to a string.
-// Optimized for common cases with minimal allocations.
-func (g *graphBuilder) typeToString(expr ast.Expr) string {
-	switch t := expr.(type) {
-	case *ast.Ident:
-		return t.Name
-	case *ast.SelectorExpr:
-		if pkg, ok := t.X.(*ast.Ident); ok {
-			// Use strings.Builder for concatenation (more efficient than +)
-			var sb strings.Builder
-			sb.Grow(len(pkg.Name) + 1 + len(t.Sel.Name))
-			sb.WriteString(pkg.Name)
-			sb.WriteByte('.')
-			sb.WriteString(t.Sel.Name)
-			return sb.String()
-		}
-		return t.Sel.Name
-	case *ast.StarExpr:
-		inner := g.typeToString(t.X)
-		var sb strings.Builder
-		sb.Grow(1 + len(inner))
-		sb.WriteByte('*')
-		sb.WriteString(inner)
-		return sb.String()
-	case *ast.ArrayType:
-		inner := g.typeToString(t.Elt)
-		var sb strings.Builder
-		sb.Grow(2 + len(inner))
-		sb.WriteString("[]")
-		sb.WriteString(inner)
-		return sb.String()
-	case *ast.MapType:
-		key := g.typeToString(t.Key)
-		val := g.typeToString(t.Value)
-		var sb strings.Builder
-		sb.Grow(4 + len(key) + 1 + len(val)) // "map[" + key + "]" + val
-		sb.WriteString("map[")
-		sb.WriteString(key)
-		sb.WriteByte(']')
-		sb.WriteString(val)
-		return sb.String()
-	case *ast.InterfaceType:
-		return "interface{}"
-	default:
-		return "unknown"
+// extractReturnTypes returns all result types of a function in order.
+func (g *graphBuilder) extractReturnTypes(fn *ast.FuncDecl, aliases TypeAliases) []string {
+	if fn.Type.Results == nil {
+		return nil
+	}
+
+	var types []string
+	for _, field := range fn.Type.Results.List {
+		resultType := aliases.String(field.Type)
+		// (a, b int) declares two results
+		for i := 0; i < max(len(field.Names), 1); i++ {
+			types = append(types, resultType)
+		}
+	}
+	return types
+}
+
+// extractParameterTypes returns the parameter types of a function in declaration order.
+func (g *graphBuilder) extractParameterTypes(fn *ast.FuncDecl, aliases TypeAliases) []string {
+	if fn.Type.Params == nil {
+		return nil
+	}
+
+	var types []string
+	for _, field := range fn.Type.Params.List {
+		paramType := aliases.String(field.Type)
+		// a, b int declares two parameters
+		for i := 0; i < max(len(field.Names), 1); i++ {
+			types = append(types, paramType)
+		}
+	}
+	return types
+}
+
+// stubTargetTypes are the call target types that name another workflow, activity or
+// handler. Calls to them that resolve to no node get an unresolved stub node; timers,
+// version markers and search attributes name no target and get none.
+var stubTargetTypes = map[string]bool{
+	"workflow":       true,
+	"activity":       true,
+	"local_activity": true,
+	"child_workflow": true,
+	"signal":         true,
+	"query":          true,
+	"update":         true,
+}
+
+// linkCallSite resolves a call site of caller to its target node and records the caller as
+// a parent of it. Targets that are not defined in the analyzed code (e.g. called via
+// ExecuteActivity but not detected during parsing) get a stub node marked Unresolved;
+// ambiguous names keep their candidates and get none.
+func (g *graphBuilder) linkCallSite(callSite *CallSite, caller *TemporalNode, graph *TemporalGraph) {
+	resolvedName, candidates := g.resolveTargetName(callSite.TargetName, caller, graph)
+	callSite.TargetName = resolvedName
+	callSite.Candidates = candidates
+
+	if targetNode, exists := graph.Nodes[resolvedName]; exists {
+		g.addParent(targetNode, caller.ID(), *callSite)
+	} else if len(candidates) == 0 && stubTargetTypes[callSite.TargetType] {
+		stubNode := &TemporalNode{
+			Name:       resolvedName,
+			Type:       callSite.TargetType,
+			Unresolved: true,
+		}
+		g.addParent(stubNode, caller.ID(), *callSite)
+		graph.Nodes[resolvedName] = stubNode
 	}
 }
 
+// addParent records a call into target from caller, with a back-reference to the call site.
+func (g *graphBuilder) addParent(target *TemporalNode, caller string, call CallSite) {
+	target.Parents = g.addUniqueParent(target.Parents, caller)
+	target.CalledBy = append(target.CalledBy, ParentRef{
+		Name:       caller,
+		FilePath:   call.FilePath,
+		LineNumber: call.LineNumber,
+		CallType:   call.CallType,
+	})
+}
+
 // addUniqueParent adds a parent to the list if it's not already present.
 func (g *graphBuilder) addUniqueParent(parents []string, parent string) []string {
 	for _, p := range parents {
@@ -458,17 +579,38 @@ func (g *graphBuilder) addUniqueParent(parents []string, parent string) []string
 }
 
 // resolveTargetName tries to resolve a target name to a node in the graph.
-// Handles cases where the target is "varName.MethodName" but the graph has "TypeName.MethodName".
-func (g *graphBuilder) resolveTargetName(targetName string, graph *TemporalGraph) string {
+// Handles cases where the target is "varName.MethodName" but the graph has "TypeName.MethodName",
+// and names registered with RegisterOptions{Name: ...} that activities are called by.
+// A bare name defined in several packages resolves to the caller's own package; otherwise the
+// name is returned unresolved together with the candidate keys.
+func (g *graphBuilder) resolveTargetName(targetName string, caller *TemporalNode, graph *TemporalGraph) (string, []string) {
 	// Try exact match first
 	if _, exists := graph.Nodes[targetName]; exists {
-		return targetName
+		return targetName, nil
+	}
+
+	if key, ok := g.aliases[targetName]; ok {
+		return key, nil
+	}
+
+	if keys, ok := g.qualified[targetName]; ok {
+		for _, key := range keys {
+			if n := graph.Nodes[key]; n != nil && filepath.Dir(n.FilePath) == filepath.Dir(caller.FilePath) {
+				return key, nil
+			}
+		}
+		return targetName, keys
 	}
 
 	// If target contains a dot (like "handler.GetMethod"), try to match by method name
 	if idx := strings.LastIndex(targetName, "."); idx > 0 {
 		methodName := targetName[idx+1:]
 
+		// A package-qualified call of a function, like "activities.Charge"
+		if n := graph.Nodes[methodName]; n != nil && n.Package == targetName[:idx] {
+			return n.ID(), nil
+		}
+
 		// Look for nodes whose name ends with .MethodName
 		var candidates []*TemporalNode
 		for name, node := range graph.Nodes {
@@ -479,12 +621,75 @@ func (g *graphBuilder) resolveTargetName(targetName string, graph *TemporalGraph
 
 		// If exactly one candidate, use it
 		if len(candidates) == 1 {
-			return candidates[0].Name
+			return candidates[0].ID(), nil
 		}
 
 		// If multiple candidates, we can't resolve uniquely, so return original
 		// The cycle detection will handle this case appropriately
 	}
 
-	return targetName
+	return targetName, nil
+}
+
+// nodeKey returns the graph key of a function: its package-qualified name if the name is
+// defined in several packages, otherwise the name with its receiver type.
+func (g *graphBuilder) nodeKey(fn *ast.FuncDecl) string {
+	if key, ok := g.keys[fn]; ok {
+		return key
+	}
+	name := fn.Name.Name
+	if receiver := g.extractReceiverType(fn); receiver != "" {
+		name = receiver + "." + name
+	}
+	return name
+}
+
+// assignNodeKeys keys nodes whose names are defined in more than one directory by
+// "package.Name", or "dir/package.Name" when the package names collide as well; their
+// display names stay short. Definitions in the same directory (e.g. per-platform files)
+// keep sharing one key.
+func (g *graphBuilder) assignNodeKeys(matches []NodeMatch, nodes []*TemporalNode) {
+	g.keys = make(map[*ast.FuncDecl]string)
+	g.qualified = make(map[string][]string)
+
+	byName := make(map[string][]int)
+	for i, node := range nodes {
+		if node != nil {
+			byName[node.Name] = append(byName[node.Name], i)
+		}
+	}
+
+	for name, indexes := range byName {
+		dirs := make(map[string]bool)
+		packages := make(map[string]map[string]bool)
+		for _, i := range indexes {
+			dir := filepath.Dir(nodes[i].FilePath)
+			dirs[dir] = true
+			if packages[nodes[i].Package] == nil {
+				packages[nodes[i].Package] = make(map[string]bool)
+			}
+			packages[nodes[i].Package][dir] = true
+		}
+		if len(dirs) < 2 {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, i := range indexes {
+			node := nodes[i]
+			key := node.Package + "." + name
+			if len(packages[node.Package]) > 1 {
+				key = filepath.ToSlash(filepath.Dir(node.FilePath)) + "." + name
+			}
+			node.Key = key
+			if fn, ok := matches[i].Node.(*ast.FuncDecl); ok {
+				g.keys[fn] = key
+			}
+			if !seen[key] {
+				seen[key] = true
+				g.qualified[name] = append(g.qualified[name], key)
+			}
+		}
+		sort.Strings(g.qualified[name])
+	}
 }
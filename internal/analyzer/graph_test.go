@@ -7,6 +7,8 @@ import (
 	"go/token"
 	"log/slog"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -159,6 +161,19 @@ func MyActivity() error {
 	} else {
 		t.Error("MyWorkflow not found")
 	}
+
+	// The activity records a back-reference to the call site
+	activity, ok := graph.Nodes["MyActivity"]
+	if !ok {
+		t.Fatal("MyActivity not found")
+	}
+	ref, ok := activity.CallFrom("MyWorkflow")
+	if !ok {
+		t.Fatalf("Expected a call from MyWorkflow, got %+v", activity.CalledBy)
+	}
+	if ref.FilePath != "test.go" || ref.LineNumber != 6 || ref.CallType != "execute" {
+		t.Errorf("Unexpected parent ref %+v", ref)
+	}
 }
 
 func TestBuildGraphContextCancellation(t *testing.T) {
@@ -309,8 +324,9 @@ func NoCommentFunc() {}
 			desc := builder.extractDescription(fn)
 			switch fn.Name.Name {
 			case "MyWorkflow":
-				if desc == "" {
-					t.Error("Expected description for MyWorkflow")
+				want := "MyWorkflow processes orders. It calls activities to complete the order."
+				if desc != want {
+					t.Errorf("Expected full description %q, got %q", want, desc)
 				}
 			case "NoCommentFunc":
 				if desc != "" {
@@ -321,6 +337,64 @@ func NoCommentFunc() {}
 	}
 }
 
+func TestExtractAnnotations(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	extractor := NewCallExtractor(logger)
+	builder := NewGraphBuilder(logger, extractor).(*graphBuilder)
+
+	code := `package test
+
+// PaymentWorkflow charges a customer.
+//
+// @owner team-payments
+// @sla 5m
+// @temporal:taskqueue payments
+// @owner team-billing
+// @deprecated
+func PaymentWorkflow() {}
+
+// PlainWorkflow has no annotations.
+func PlainWorkflow() {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse code: %v", err)
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		annotations := builder.extractAnnotations(fn)
+		switch fn.Name.Name {
+		case "PaymentWorkflow":
+			want := map[string]string{
+				"owner":              "team-payments, team-billing",
+				"sla":                "5m",
+				"temporal:taskqueue": "payments",
+				"deprecated":         "",
+			}
+			if len(annotations) != len(want) {
+				t.Errorf("Expected %d annotations, got %v", len(want), annotations)
+			}
+			for key, value := range want {
+				if got, ok := annotations[key]; !ok || got != value {
+					t.Errorf("annotation %q = %q, want %q", key, got, value)
+				}
+			}
+			if desc := builder.extractDescription(fn); desc != "PaymentWorkflow charges a customer." {
+				t.Errorf("Annotations should not be part of the description, got %q", desc)
+			}
+		case "PlainWorkflow":
+			if annotations != nil {
+				t.Errorf("Expected no annotations for PlainWorkflow, got %v", annotations)
+			}
+		}
+	}
+}
+
 func TestExtractReturnType(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	extractor := NewCallExtractor(logger)
@@ -349,7 +423,7 @@ func ReturnsNothing() {}
 	for _, decl := range file.Decls {
 		if fn, ok := decl.(*ast.FuncDecl); ok {
 			expected := tests[fn.Name.Name]
-			got := builder.extractReturnType(fn)
+			got := builder.extractReturnType(fn, nil)
 			if got != expected {
 				t.Errorf("extractReturnType(%s) = %q, want %q", fn.Name.Name, got, expected)
 			}
@@ -357,20 +431,17 @@ func ReturnsNothing() {}
 	}
 }
 
-func TestGraphBuilderTypeToString(t *testing.T) {
+func TestExtractReturnTypes(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	extractor := NewCallExtractor(logger)
 	builder := NewGraphBuilder(logger, extractor).(*graphBuilder)
 
 	code := `package test
 
-var a int
-var b string
-var c *int
-var d []string
-var e map[string]int
-var f interface{}
-var g pkg.Type
+func ReturnsError() error { return nil }
+func ReturnsResult() (*Result, error) { return nil, nil }
+func ReturnsNamed() (a, b int, err error) { return }
+func ReturnsNothing() {}
 `
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, "test.go", code, 0)
@@ -378,27 +449,49 @@ var g pkg.Type
 		t.Fatalf("Failed to parse code: %v", err)
 	}
 
-	tests := map[string]string{
-		"a": "int",
-		"b": "string",
-		"c": "*int",
-		"d": "[]string",
-		"e": "map[string]int",
-		"f": "interface{}",
-		"g": "pkg.Type",
+	tests := map[string][]string{
+		"ReturnsError":   {"error"},
+		"ReturnsResult":  {"*Result", "error"},
+		"ReturnsNamed":   {"int", "int", "error"},
+		"ReturnsNothing": nil,
 	}
 
 	for _, decl := range file.Decls {
-		if gd, ok := decl.(*ast.GenDecl); ok {
-			for _, spec := range gd.Specs {
-				if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Names) > 0 {
-					name := vs.Names[0].Name
-					expected := tests[name]
-					got := builder.typeToString(vs.Type)
-					if got != expected {
-						t.Errorf("typeToString(%s) = %q, want %q", name, got, expected)
-					}
-				}
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			got := builder.extractReturnTypes(fn, nil)
+			if !reflect.DeepEqual(got, tests[fn.Name.Name]) {
+				t.Errorf("extractReturnTypes(%s) = %v, want %v", fn.Name.Name, got, tests[fn.Name.Name])
+			}
+		}
+	}
+}
+
+func TestExtractParameterTypes(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	extractor := NewCallExtractor(logger)
+	builder := NewGraphBuilder(logger, extractor).(*graphBuilder)
+
+	code := `package test
+
+func Ship(ctx context.Context, to, from string, order *orders.Order, _ int) error { return nil }
+func NoParams() {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse code: %v", err)
+	}
+
+	tests := map[string][]string{
+		"Ship":     {"context.Context", "string", "string", "*orders.Order", "int"},
+		"NoParams": nil,
+	}
+
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			got := builder.extractParameterTypes(fn, nil)
+			if !reflect.DeepEqual(got, tests[fn.Name.Name]) {
+				t.Errorf("extractParameterTypes(%s) = %v, want %v", fn.Name.Name, got, tests[fn.Name.Name])
 			}
 		}
 	}
@@ -465,3 +558,192 @@ func TestCalculateNodeDepthCycleDetection(t *testing.T) {
 		t.Error("calculateMaxDepth returned negative for cyclic graph")
 	}
 }
+
+func TestBuildGraphDuplicateNames(t *testing.T) {
+	files := map[string]string{
+		"billing/charge.go": `package billing
+
+func Charge() error { return nil }
+`,
+		"payments/charge.go": `package payments
+
+func Charge() error { return nil }
+`,
+		"billing/workflow.go": `package billing
+
+import "go.temporal.io/sdk/workflow"
+
+func BillingWorkflow(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, Charge).Get(ctx, nil)
+}
+`,
+		"orders/workflow.go": `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, Charge).Get(ctx, nil)
+}
+`,
+	}
+
+	fset := token.NewFileSet()
+	var matches []NodeMatch
+	for _, path := range []string{"billing/charge.go", "payments/charge.go", "billing/workflow.go", "orders/workflow.go"} {
+		file, err := parser.ParseFile(fset, path, files[path], 0)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", path, err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			nodeType := "activity"
+			if strings.HasSuffix(fn.Name.Name, "Workflow") {
+				nodeType = "workflow"
+			}
+			matches = append(matches, NodeMatch{Node: fn, FileSet: fset, FilePath: path, Package: file.Name.Name, NodeType: nodeType})
+		}
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	graph, err := NewGraphBuilder(logger, NewCallExtractor(logger)).BuildGraph(context.Background(), matches)
+	if err != nil {
+		t.Fatalf("BuildGraph failed: %v", err)
+	}
+
+	for _, name := range []string{"billing.Charge", "payments.Charge", "BillingWorkflow", "OrderWorkflow"} {
+		if _, ok := graph.Nodes[name]; !ok {
+			t.Errorf("Expected node %q, got %v", name, graph.Nodes)
+		}
+	}
+	if _, ok := graph.Nodes["Charge"]; ok {
+		t.Error("Ambiguous bare name should not create a stub node")
+	}
+	if charge := graph.Nodes["payments.Charge"]; charge.Name != "Charge" || charge.Key != "payments.Charge" || charge.ID() != "payments.Charge" {
+		t.Errorf("Expected display name Charge with key payments.Charge, got %q / %q", charge.Name, charge.Key)
+	}
+	if workflow := graph.Nodes["OrderWorkflow"]; workflow.Key != "" || workflow.ID() != "OrderWorkflow" {
+		t.Errorf("Unique names should not be keyed, got %q", workflow.Key)
+	}
+
+	// The caller's own package wins
+	for _, cs := range graph.Nodes["BillingWorkflow"].CallSites {
+		if cs.TargetName != "billing.Charge" || cs.Candidates != nil {
+			t.Errorf("Expected call resolved to billing.Charge, got %+v", cs)
+		}
+	}
+	if parents := graph.Nodes["billing.Charge"].Parents; len(parents) != 1 || parents[0] != "BillingWorkflow" {
+		t.Errorf("Unexpected parents of billing.Charge: %v", parents)
+	}
+
+	// Elsewhere the call stays unresolved with its candidates
+	orders := graph.Nodes["OrderWorkflow"].CallSites
+	if len(orders) == 0 {
+		t.Fatal("Expected OrderWorkflow call sites")
+	}
+	for _, cs := range orders {
+		if cs.TargetName != "Charge" {
+			t.Errorf("Expected unresolved call to Charge, got %+v", cs)
+		}
+		if want := "billing.Charge,payments.Charge"; strings.Join(cs.Candidates, ",") != want {
+			t.Errorf("Candidates = %v, want %s", cs.Candidates, want)
+		}
+	}
+	if graph.Stats.UnresolvedTargets != 1 {
+		t.Errorf("UnresolvedTargets = %d, want 1", graph.Stats.UnresolvedTargets)
+	}
+}
+
+func TestBuildGraphUnresolvedStub(t *testing.T) {
+	src := `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, SendEmail).Get(ctx, nil)
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "orders/workflow.go", src, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	fn := file.Decls[1].(*ast.FuncDecl)
+	matches := []NodeMatch{{Node: fn, FileSet: fset, FilePath: "orders/workflow.go", Package: "orders", NodeType: "workflow"}}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	graph, err := NewGraphBuilder(logger, NewCallExtractor(logger)).BuildGraph(context.Background(), matches)
+	if err != nil {
+		t.Fatalf("BuildGraph failed: %v", err)
+	}
+
+	stub, ok := graph.Nodes["SendEmail"]
+	if !ok || !stub.Unresolved {
+		t.Fatalf("Expected an unresolved stub node for SendEmail, got %+v", stub)
+	}
+	if graph.Nodes["OrderWorkflow"].Unresolved {
+		t.Error("Analyzed nodes should not be marked unresolved")
+	}
+	if graph.Stats.UnresolvedTargets != 1 {
+		t.Errorf("UnresolvedTargets = %d, want 1", graph.Stats.UnresolvedTargets)
+	}
+}
+
+// stubCallExtractor returns fixed call sites for every function.
+type stubCallExtractor struct {
+	calls []CallSite
+}
+
+func (e stubCallExtractor) ExtractCalls(ctx context.Context, fn *ast.FuncDecl, filePath string) ([]CallSite, error) {
+	return append([]CallSite(nil), e.calls...), nil
+}
+
+func (e stubCallExtractor) ExtractParameters(fn *ast.FuncDecl) map[string]string { return nil }
+
+func TestBuildGraphUnresolvedStubTypes(t *testing.T) {
+	src := `package orders
+
+func OrderWorkflow() {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "orders/workflow.go", src, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	matches := []NodeMatch{{Node: file.Decls[0].(*ast.FuncDecl), FileSet: fset, FilePath: "orders/workflow.go", Package: "orders", NodeType: "workflow"}}
+
+	extractor := stubCallExtractor{calls: []CallSite{
+		{TargetName: "ShipWorkflow", TargetType: "workflow"},
+		{TargetName: "approve", TargetType: "signal"},
+		{TargetName: "status", TargetType: "query"},
+		{TargetName: "resize", TargetType: "update"},
+		{TargetName: "timer_12", TargetType: "timer"},
+		{TargetName: "v2", TargetType: "version"},
+	}}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	graph, err := NewGraphBuilder(logger, extractor).BuildGraph(context.Background(), matches)
+	if err != nil {
+		t.Fatalf("BuildGraph failed: %v", err)
+	}
+
+	for name, nodeType := range map[string]string{"ShipWorkflow": "workflow", "approve": "signal", "status": "query", "resize": "update"} {
+		stub, ok := graph.Nodes[name]
+		if !ok || !stub.Unresolved || stub.Type != nodeType {
+			t.Errorf("Expected an unresolved %s stub for %s, got %+v", nodeType, name, stub)
+			continue
+		}
+		if len(stub.Parents) != 1 || stub.Parents[0] != "OrderWorkflow" {
+			t.Errorf("Expected OrderWorkflow as the parent of %s, got %v", name, stub.Parents)
+		}
+	}
+	for _, name := range []string{"timer_12", "v2"} {
+		if _, ok := graph.Nodes[name]; ok {
+			t.Errorf("Expected no stub for %s", name)
+		}
+	}
+	if graph.Stats.UnresolvedTargets != 4 {
+		t.Errorf("UnresolvedTargets = %d, want 4", graph.Stats.UnresolvedTargets)
+	}
+}
@@ -0,0 +1,254 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"path/filepath"
+)
+
+// FuncIndex holds the function declarations of the analyzed packages, so that the internal
+// calls of workflows and activities can be resolved to helper nodes. The parser builds it
+// when AnalysisOptions.InternalDepth is set and shares it between all matches.
+type FuncIndex struct {
+	// Depth is how many calls deep helpers are resolved: 1 adds the functions workflows and
+	// activities call, 2 also the functions those call
+	Depth int
+
+	// byDir maps package directories to their functions by "Func" or "Type.Method"
+	byDir map[string]map[string]NodeMatch
+	// dirsByPackage maps package names to their directories, for calls of pkg.Func
+	dirsByPackage map[string][]string
+}
+
+// NewFuncIndex creates an empty index resolving helpers depth calls deep.
+func NewFuncIndex(depth int) *FuncIndex {
+	return &FuncIndex{
+		Depth:         depth,
+		byDir:         make(map[string]map[string]NodeMatch),
+		dirsByPackage: make(map[string][]string),
+	}
+}
+
+// add indexes a function declaration.
+func (idx *FuncIndex) add(match NodeMatch) {
+	fn, ok := match.Node.(*ast.FuncDecl)
+	if !ok || fn.Name == nil || fn.Body == nil {
+		return
+	}
+	dir := filepath.Dir(match.FilePath)
+	funcs, ok := idx.byDir[dir]
+	if !ok {
+		funcs = make(map[string]NodeMatch)
+		idx.byDir[dir] = funcs
+		idx.dirsByPackage[match.Package] = append(idx.dirsByPackage[match.Package], dir)
+	}
+	name := fn.Name.Name
+	if receiver := methodType(fn); receiver != "" {
+		name = receiver + "." + name
+	}
+	// Per-platform files may declare a function twice; the first declaration stands for both
+	if _, exists := funcs[name]; !exists {
+		funcs[name] = match
+	}
+}
+
+// resolve returns the declaration an internal call of the function declared by caller
+// refers to. Calls resolve to functions of the caller's package, to functions of another
+// analyzed package named like the selector (pkg.Func), and to methods of the caller's package
+// whose name is declared by a single type or by the caller's receiver type.
+func (idx *FuncIndex) resolve(call InternalCall, caller NodeMatch) (NodeMatch, bool) {
+	dir := filepath.Dir(caller.FilePath)
+	funcs := idx.byDir[dir]
+
+	if call.CallType == "function" {
+		match, ok := funcs[call.TargetName]
+		return match, ok
+	}
+
+	// pkg.Func, unless a local variable shadows the package name
+	for _, pkgDir := range idx.dirsByPackage[call.Receiver] {
+		if pkgDir == dir {
+			continue
+		}
+		if match, ok := idx.byDir[pkgDir][call.TargetName]; ok {
+			return match, true
+		}
+	}
+
+	// recv.Method of the caller's own receiver
+	fn, _ := caller.Node.(*ast.FuncDecl)
+	if fn != nil && fn.Recv != nil && len(fn.Recv.List) > 0 && len(fn.Recv.List[0].Names) > 0 &&
+		fn.Recv.List[0].Names[0].Name == call.Receiver {
+		match, ok := funcs[methodType(fn)+"."+call.TargetName]
+		return match, ok
+	}
+
+	// x.Method where a single type of the package declares Method
+	var found NodeMatch
+	count := 0
+	for _, match := range funcs {
+		if m, ok := match.Node.(*ast.FuncDecl); ok && m.Recv != nil && m.Name.Name == call.TargetName {
+			found = match
+			count++
+		}
+	}
+	return found, count == 1
+}
+
+// methodType returns the receiver type name of a method, or "" for functions.
+func methodType(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	return receiverTypeName(fn.Recv.List[0].Type)
+}
+
+// resolveHelpers adds the functions that workflows and activities call, and the functions
+// those call, up to the index depth, as "helper" nodes. Each call becomes an "internal" call
+// site of the caller, so Temporal calls made in helpers are reachable from the workflow.
+// Calls of functions that already are nodes, such as helpers taking a workflow.Context,
+// become internal call sites of the node without expanding it.
+func (g *graphBuilder) resolveHelpers(ctx context.Context, matches []NodeMatch, graph *TemporalGraph) {
+	var idx *FuncIndex
+	for _, match := range matches {
+		if match.Funcs != nil {
+			idx = match.Funcs
+			break
+		}
+	}
+	extractor, ok := g.callExtractor.(*callExtractor)
+	if idx == nil || idx.Depth <= 0 || !ok {
+		return
+	}
+
+	type pending struct {
+		node  *TemporalNode
+		match NodeMatch
+		depth int
+	}
+	var queue []pending
+	declared := make(map[*ast.FuncDecl]*TemporalNode)
+	for _, match := range matches {
+		fn, ok := match.Node.(*ast.FuncDecl)
+		if !ok || fn.Name == nil {
+			continue
+		}
+		if node, exists := graph.Nodes[g.nodeKey(fn)]; exists {
+			declared[fn] = node
+			queue = append(queue, pending{node: node, match: match})
+		}
+	}
+
+	// Breadth first, so a helper is expanded at the smallest depth it is called at
+	helpers := make(map[*ast.FuncDecl]*TemporalNode)
+	for len(queue) > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		item := queue[0]
+		queue = queue[1:]
+		if item.depth >= idx.Depth {
+			continue
+		}
+
+		linked := make(map[string]bool)
+		for _, call := range item.node.InternalCalls {
+			target, ok := idx.resolve(call, item.match)
+			if !ok {
+				continue
+			}
+			fn := target.Node.(*ast.FuncDecl)
+
+			callee := declared[fn]
+			if callee == nil {
+				callee = helpers[fn]
+			}
+			if callee == nil {
+				var err error
+				if panicErr := recovered(func() { callee, err = g.createHelperNode(ctx, extractor, target, graph) }); panicErr != nil {
+					recordFileError(ctx, StageGraph, target.FilePath, panicErr)
+					err = panicErr
+				}
+				if err != nil {
+					g.logger.Warn("Failed to resolve helper", "helper", fn.Name.Name, "error", err)
+					continue
+				}
+				helpers[fn] = callee
+				queue = append(queue, pending{node: callee, match: target, depth: item.depth + 1})
+			}
+			if callee == item.node || linked[callee.ID()] {
+				continue
+			}
+			linked[callee.ID()] = true
+
+			callSite := CallSite{
+				TargetName: callee.ID(),
+				TargetType: callee.Type,
+				CallType:   "internal",
+				LineNumber: call.LineNumber,
+				FilePath:   call.FilePath,
+			}
+			item.node.CallSites = append(item.node.CallSites, callSite)
+			g.addParent(callee, item.node.ID(), callSite)
+		}
+	}
+	if len(helpers) > 0 {
+		g.logger.Debug("Resolved helper functions", "helpers", len(helpers), "depth", idx.Depth)
+	}
+}
+
+// createHelperNode adds a helper node for a function declaration, with its Temporal calls
+// linked and its internal calls extracted for the next level.
+func (g *graphBuilder) createHelperNode(ctx context.Context, extractor *callExtractor, match NodeMatch, graph *TemporalGraph) (*TemporalNode, error) {
+	match.NodeType = "helper"
+	node, err := g.buildFuncNode(ctx, extractor, match)
+	if err != nil {
+		return nil, err
+	}
+
+	// Helpers are keyed by name unless a node already has it, then like colliding nodes
+	keys := []string{node.Name, match.Package + "." + node.Name, filepath.ToSlash(filepath.Dir(match.FilePath)) + "." + node.Name}
+	for _, key := range keys {
+		if _, exists := graph.Nodes[key]; !exists {
+			if key != node.Name {
+				node.Key = key
+			}
+			break
+		}
+	}
+	if _, exists := graph.Nodes[node.ID()]; exists {
+		return nil, fmt.Errorf("no free graph key for %s", node.Name)
+	}
+	graph.Nodes[node.ID()] = node
+
+	for i := range node.CallSites {
+		g.linkCallSite(&node.CallSites[i], node, graph)
+	}
+	return node, nil
+}
+
+// buildFuncNode creates a node of match.NodeType for a function declaration outside of graph
+// building, with its Temporal calls and internal calls extracted. Its call sites are not
+// linked to their targets.
+func (g *graphBuilder) buildFuncNode(ctx context.Context, extractor *callExtractor, match NodeMatch) (*TemporalNode, error) {
+	node, err := g.createNodeFromMatch(ctx, match)
+	if err != nil {
+		return nil, err
+	}
+	fn := match.Node.(*ast.FuncDecl)
+
+	details, err := extractor.ExtractAllTemporalInfo(ctx, fn, match.FilePath, match.FileSet)
+	if err != nil {
+		return nil, err
+	}
+	if details != nil {
+		g.applyTemporalInfo(node, details, nil)
+	}
+	node.InternalCalls = extractor.extractInternalCalls(ctx, fn, match.FilePath, match.FileSet)
+	match.Constants.resolveConstants(node)
+	return node, nil
+}
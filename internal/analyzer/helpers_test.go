@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+const helperWorkflows = `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+type steps struct {
+	ctx workflow.Context
+}
+
+func OrderWorkflow(ctx workflow.Context, id string) error {
+	s := &steps{ctx: ctx}
+	return s.process(id)
+}
+
+func (s *steps) process(id string) error {
+	if err := validate(id); err != nil {
+		return err
+	}
+	return s.charge(id)
+}
+
+func (s *steps) charge(id string) error {
+	return workflow.ExecuteActivity(s.ctx, ChargeCard, id).Get(s.ctx, nil)
+}
+
+func validate(id string) error { return nil }
+
+func ChargeCard(ctx context.Context, id string) error { return nil }
+
+func register(worker worker.Worker) {
+	worker.RegisterWorkflow(OrderWorkflow)
+	worker.RegisterActivity(ChargeCard)
+}
+`
+
+func TestResolveHelpers(t *testing.T) {
+	dir := writeTree(t, map[string]string{"orders/orders.go": helperWorkflows})
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	calls := func(graph *TemporalGraph, id string) map[string]string {
+		targets := make(map[string]string)
+		if node := graph.Nodes[id]; node != nil {
+			for _, cs := range node.CallSites {
+				targets[cs.TargetName] = cs.CallType
+			}
+		}
+		return targets
+	}
+
+	tests := []struct {
+		depth   int
+		helpers []string
+	}{
+		{0, nil},
+		{1, []string{"*steps.process"}},
+		{2, []string{"*steps.process", "*steps.charge", "validate"}},
+	}
+	for _, tt := range tests {
+		graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir, InternalDepth: tt.depth})
+		if err != nil {
+			t.Fatalf("Analyze failed: %v", err)
+		}
+		var helpers []string
+		for id, node := range graph.Nodes {
+			if node.Type == "helper" {
+				helpers = append(helpers, id)
+			}
+		}
+		if len(helpers) != len(tt.helpers) {
+			t.Errorf("depth %d: helpers = %v, want %v", tt.depth, helpers, tt.helpers)
+		}
+		for _, h := range tt.helpers {
+			if node := graph.Nodes[h]; node == nil || node.Type != "helper" {
+				t.Errorf("depth %d: expected helper node %s, got %v", tt.depth, h, helpers)
+			}
+		}
+	}
+
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir, InternalDepth: 2})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if got := calls(graph, "OrderWorkflow")["*steps.process"]; got != "internal" {
+		t.Errorf("Expected an internal call of *steps.process from OrderWorkflow, got %v", calls(graph, "OrderWorkflow"))
+	}
+	if got := calls(graph, "*steps.process")["*steps.charge"]; got != "internal" {
+		t.Errorf("Expected an internal call of *steps.charge, got %v", calls(graph, "*steps.process"))
+	}
+	if _, ok := calls(graph, "*steps.charge")["ChargeCard"]; !ok {
+		t.Errorf("Expected the helper two calls deep to execute ChargeCard, got %v", calls(graph, "*steps.charge"))
+	}
+	if activity := graph.Nodes["ChargeCard"]; activity == nil || len(activity.CalledBy) != 1 || activity.CalledBy[0].Name != "*steps.charge" {
+		t.Errorf("Expected ChargeCard to be called by the helper, got %+v", activity)
+	}
+}
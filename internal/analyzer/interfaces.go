@@ -10,6 +10,11 @@ import (
 type Analyzer interface {
 	// Analyze performs a complete analysis of the given directory and returns a temporal graph.
 	Analyze(ctx context.Context, opts config.AnalysisOptions) (*TemporalGraph, error)
+
+	// AnalyzeStream performs the same analysis, calling onPackage with the preliminary nodes
+	// of each package as soon as its files are parsed. Calls are made in order from the
+	// analyzing goroutine, and all of them before the graph is returned.
+	AnalyzeStream(ctx context.Context, opts config.AnalysisOptions, onPackage func(PackageResult)) (*TemporalGraph, error)
 }
 
 // Parser handles parsing of Go source files and AST analysis.
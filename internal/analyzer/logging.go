@@ -0,0 +1,179 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// logPackages are the packages whose package-level functions log directly.
+var logPackages = map[string]bool{"fmt": true, "log": true, "slog": true, "logrus": true, "zap": true}
+
+// loggerConstructors create loggers that bypass the workflow logger, keyed by "pkg.Func".
+var loggerConstructors = map[string]bool{
+	"zap.L": true, "zap.S": true, "zap.NewProduction": true, "zap.NewDevelopment": true, "zap.NewExample": true,
+	"logrus.New": true, "logrus.StandardLogger": true, "logrus.WithField": true, "logrus.WithFields": true,
+	"slog.Default": true, "slog.New": true, "slog.With": true,
+	"log.New": true, "log.Default": true,
+}
+
+// extractLogCalls finds logging that bypasses workflow.GetLogger: fmt printing to stdout,
+// the log, slog, logrus and zap packages, and loggers created by them.
+func (e *callExtractor) extractLogCalls(body *ast.BlockStmt, fset *token.FileSet) []LogCall {
+	// Variables holding loggers created outside the workflow package, and all local
+	// variables, which may shadow a logging package (log := workflow.GetLogger(ctx))
+	loggers := make(map[string]string)
+	locals := make(map[string]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok {
+			return true
+		}
+		for i, rhs := range assign.Rhs {
+			if i >= len(assign.Lhs) {
+				break
+			}
+			ident, ok := assign.Lhs[i].(*ast.Ident)
+			if !ok {
+				continue
+			}
+			locals[ident.Name] = true
+			if origin := loggerOrigin(rhs); origin != "" {
+				loggers[ident.Name] = origin
+			} else {
+				delete(loggers, ident.Name)
+			}
+		}
+		return true
+	})
+
+	var calls []LogCall
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		method := sel.Sel.Name
+		level := logLevel(method)
+		if level == "" {
+			return true
+		}
+
+		var target string
+		switch x := sel.X.(type) {
+		case *ast.Ident:
+			switch {
+			case loggers[x.Name] != "":
+				target = x.Name + "." + method
+			case locals[x.Name]:
+			case x.Name == "fmt" && isStdoutPrint(method, call):
+				target = "fmt." + method
+			case x.Name != "fmt" && logPackages[x.Name]:
+				target = x.Name + "." + method
+			}
+		case *ast.CallExpr:
+			if origin := loggerOrigin(x); origin != "" {
+				target = origin + "()." + method
+			}
+		}
+		if target == "" {
+			return true
+		}
+
+		calls = append(calls, LogCall{
+			Call:       target,
+			Level:      level,
+			Message:    logMessage(method, call),
+			LineNumber: lineOf(fset, call.Pos()),
+		})
+		return true
+	})
+
+	return calls
+}
+
+// loggerOrigin returns "pkg.Func" if expr is a call to a known logger constructor,
+// including chained calls such as zap.L().Named("x") or logrus.WithField(...).
+func loggerOrigin(expr ast.Expr) string {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	switch x := sel.X.(type) {
+	case *ast.Ident:
+		if name := x.Name + "." + sel.Sel.Name; loggerConstructors[name] {
+			return name
+		}
+	case *ast.CallExpr:
+		return loggerOrigin(x)
+	}
+	return ""
+}
+
+// logLevel returns the workflow logger level for a logging method, or "" if it does not log.
+func logLevel(method string) string {
+	name := strings.TrimSuffix(method, "Context")
+	for _, suffix := range []string{"ln", "f", "w"} {
+		if trimmed := strings.TrimSuffix(name, suffix); trimmed != name {
+			name = trimmed
+			break
+		}
+	}
+	switch name {
+	case "Print", "Info", "Log", "Fprint":
+		return "Info"
+	case "Debug", "Trace":
+		return "Debug"
+	case "Warn", "Warning":
+		return "Warn"
+	case "Error", "Fatal", "Panic", "DPanic":
+		return "Error"
+	}
+	return ""
+}
+
+// isStdoutPrint reports whether a fmt call prints to standard output or error.
+func isStdoutPrint(method string, call *ast.CallExpr) bool {
+	switch method {
+	case "Print", "Printf", "Println":
+		return true
+	case "Fprint", "Fprintf", "Fprintln":
+		if len(call.Args) == 0 {
+			return false
+		}
+		if sel, ok := call.Args[0].(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "os" {
+				return sel.Sel.Name == "Stdout" || sel.Sel.Name == "Stderr"
+			}
+		}
+	}
+	return false
+}
+
+// logMessage returns the string literal message of a logging call, if any.
+func logMessage(method string, call *ast.CallExpr) string {
+	args := call.Args
+	if strings.HasPrefix(method, "Fprint") || strings.HasSuffix(method, "Context") {
+		// Skip the writer or context argument
+		if len(args) == 0 {
+			return ""
+		}
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	if lit, ok := args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		return lit.Value
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestExtractLogCalls(t *testing.T) {
+	code := `package test
+
+func LoggingWorkflow(ctx workflow.Context) error {
+	fmt.Println("starting")
+	fmt.Fprintf(os.Stderr, "to stderr %d", 1)
+	fmt.Fprintf(&buf, "to buffer")
+	log.Printf("order %s", id)
+	zap.L().Info("zap global")
+	sugar := zap.S().Named("wf")
+	sugar.Warnw("sugared", "id", id)
+	slog.ErrorContext(context.Background(), "slog error")
+	msg := fmt.Sprintf("not logged %d", 1)
+	logger := workflow.GetLogger(ctx)
+	logger.Info("replay safe")
+	return nil
+}
+
+func ShadowWorkflow(ctx workflow.Context) error {
+	log := workflow.GetLogger(ctx)
+	log.Info("replay safe")
+	return nil
+}
+`
+	details := extractTestFunc(t, code, "LoggingWorkflow")
+
+	want := []LogCall{
+		{Call: "fmt.Println", Level: "Info", Message: `"starting"`, LineNumber: 4},
+		{Call: "fmt.Fprintf", Level: "Info", Message: `"to stderr %d"`, LineNumber: 5},
+		{Call: "log.Printf", Level: "Info", Message: `"order %s"`, LineNumber: 7},
+		{Call: "zap.L().Info", Level: "Info", Message: `"zap global"`, LineNumber: 8},
+		{Call: "sugar.Warnw", Level: "Warn", Message: `"sugared"`, LineNumber: 10},
+		{Call: "slog.ErrorContext", Level: "Error", Message: `"slog error"`, LineNumber: 11},
+	}
+	if len(details.LogCalls) != len(want) {
+		t.Fatalf("Expected %d log calls, got %+v", len(want), details.LogCalls)
+	}
+	for i, w := range want {
+		if details.LogCalls[i] != w {
+			t.Errorf("LogCalls[%d] = %+v, want %+v", i, details.LogCalls[i], w)
+		}
+	}
+
+	if details := extractTestFunc(t, code, "ShadowWorkflow"); len(details.LogCalls) != 0 {
+		t.Errorf("Expected no log calls through a shadowing workflow logger, got %+v", details.LogCalls)
+	}
+}
+
+func TestLogLevel(t *testing.T) {
+	tests := map[string]string{
+		"Println": "Info", "Infof": "Info", "Debugw": "Debug", "Warn": "Warn",
+		"Warningf": "Warn", "Fatalln": "Error", "ErrorContext": "Error", "Sprintf": "", "With": "",
+	}
+	for method, want := range tests {
+		if got := logLevel(method); got != want {
+			t.Errorf("logLevel(%q) = %q, want %q", method, got, want)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// MessageCall is a signal sent to a workflow, or a query or update issued against one, by
+// a client, another workflow or a test environment.
+type MessageCall struct {
+	Kind string `json:"kind"` // "signal", "query", "update"
+	// Name is the signal, query or update name; empty if it is not a string constant
+	Name string `json:"name,omitempty"`
+	// Workflow is the graph key of the workflow type the call starts (SignalWithStartWorkflow),
+	// or its name if it is not in the graph
+	Workflow string `json:"workflow,omitempty"`
+	// Call is the SDK method, e.g. SignalExternalWorkflow or QueryWorkflow
+	Call string `json:"call"`
+	// Caller is the graph key of the node whose function makes the call, or the name of the
+	// function if it is not in the graph; empty outside functions
+	Caller     string `json:"caller,omitempty"`
+	FilePath   string `json:"file_path"`
+	LineNumber int    `json:"line_number"`
+}
+
+// MessagesSentBy returns the calls of the given kind made by a node, given by graph key.
+func (g *TemporalGraph) MessagesSentBy(kind, caller string) []MessageCall {
+	var calls []MessageCall
+	for _, m := range g.Messages {
+		if m.Kind == kind && m.Caller == caller {
+			calls = append(calls, m)
+		}
+	}
+	return calls
+}
+
+// MessagesSent returns the calls of the given kind ("signal", "query" or "update").
+func (g *TemporalGraph) MessagesSent(kind string) []MessageCall {
+	var calls []MessageCall
+	for _, m := range g.Messages {
+		if m.Kind == kind {
+			calls = append(calls, m)
+		}
+	}
+	return calls
+}
+
+// messageMethods maps the SDK methods sending messages to workflows to the message kind.
+// Client, workflow and test environment methods share names.
+var messageMethods = map[string]string{
+	"SignalWorkflow":            "signal",
+	"SignalWorkflowByID":        "signal",
+	"SignalExternalWorkflow":    "signal",
+	"SignalWithStartWorkflow":   "signal",
+	"QueryWorkflow":             "query",
+	"QueryWorkflowWithOptions":  "query",
+	"UpdateWorkflow":            "update",
+	"UpdateWorkflowWithOptions": "update",
+	"UpdateWithStartWorkflow":   "update",
+}
+
+// messageNameFields are the option fields naming a query or update.
+var messageNameFields = map[string]bool{"QueryType": true, "UpdateName": true}
+
+// pendingMessage is a message call whose name and workflow may refer to constants declared
+// in files scanned later.
+type pendingMessage struct {
+	call           MessageCall
+	name, workflow ast.Expr
+}
+
+// messageScanner scans for signals, queries and updates sent to workflows.
+type messageScanner struct {
+	logger *slog.Logger
+
+	calls []pendingMessage
+	// constants maps string constants to their values, to name messages sent by constant
+	constants map[string]string
+}
+
+// NewMessageScanner creates a new message scanner.
+func NewMessageScanner(logger *slog.Logger) *messageScanner {
+	return &messageScanner{
+		logger:    logger,
+		constants: make(map[string]string),
+	}
+}
+
+// ScanDirectory scans all Go files in a directory for messages sent to workflows.
+func (s *messageScanner) ScanDirectory(ctx context.Context, rootDir string, opts config.AnalysisOptions) ([]MessageCall, error) {
+	fset := token.NewFileSet()
+
+	modules := NewModuleFilter(rootDir, opts)
+	err := filepath.Walk(rootDir, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			s.logger.Warn("Error accessing path during message scan", "path", path, "error", err)
+			return nil // Continue walking
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if fileInfo.IsDir() {
+			if opts.ExcludesDir(fileInfo.Name()) {
+				return filepath.SkipDir
+			}
+			if modules.SkipDir(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		if !opts.IncludeTests && IsTestFile(path) {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			s.logger.Warn("Error parsing file for messages", "path", path, "error", err)
+			return nil
+		}
+
+		if err := recovered(func() { s.scanFile(file, fset, path) }); err != nil {
+			s.logger.Warn("Error scanning file for messages", "path", path, "error", err)
+			recordFileError(ctx, StageMessages, path, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]MessageCall, 0, len(s.calls))
+	for _, p := range s.calls {
+		p.call.Name = s.stringValue(p.name)
+		p.call.Workflow = s.workflowName(p.workflow)
+		messages = append(messages, p.call)
+	}
+	s.logger.Info("Scanned for messages", "messages", len(messages))
+
+	return messages, nil
+}
+
+// scanFile records the string constants and message calls of a file.
+func (s *messageScanner) scanFile(file *ast.File, fset *token.FileSet, filePath string) {
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.CONST {
+			for _, spec := range gen.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, name := range valueSpec.Names {
+					if i >= len(valueSpec.Values) {
+						continue
+					}
+					if value, ok := routePath(valueSpec.Values[i]); ok {
+						s.constants[name.Name] = value
+					}
+				}
+			}
+		}
+	}
+
+	for _, decl := range file.Decls {
+		caller := ""
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			caller = fn.Name.Name
+		}
+		ast.Inspect(decl, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			kind, ok := messageMethods[sel.Sel.Name]
+			if !ok {
+				return true
+			}
+
+			p := pendingMessage{call: MessageCall{
+				Kind:       kind,
+				Call:       sel.Sel.Name,
+				Caller:     caller,
+				FilePath:   filePath,
+				LineNumber: fset.Position(call.Pos()).Line,
+			}}
+			p.name, p.workflow = messageTarget(sel.Sel.Name, call.Args)
+			s.calls = append(s.calls, p)
+			return true
+		})
+	}
+}
+
+// messageTarget returns the expressions naming the message and the started workflow of a
+// message call. Client and workflow methods take a context and the workflow ID first; test
+// environment methods start with the message name.
+func messageTarget(method string, args []ast.Expr) (name, workflow ast.Expr) {
+	arg := func(i int) ast.Expr {
+		if i < len(args) {
+			return args[i]
+		}
+		return nil
+	}
+
+	switch method {
+	case "SignalWithStartWorkflow":
+		// (ctx, workflowID, signalName, signalArg, options, workflow, args...)
+		return arg(2), arg(5)
+	case "SignalWorkflowByID":
+		// env.SignalWorkflowByID(workflowID, signalName, arg)
+		return arg(1), nil
+	case "QueryWorkflowWithOptions", "UpdateWorkflowWithOptions", "UpdateWithStartWorkflow":
+		return optionsMessageName(args), nil
+	}
+
+	// Options structs name updates in newer SDKs: UpdateWorkflow(ctx, client.UpdateWorkflowOptions{...})
+	if name := optionsMessageName(args); name != nil {
+		return name, nil
+	}
+	// env.SignalWorkflow(name, arg), env.QueryWorkflow(name, args...)
+	if lit, ok := arg(0).(*ast.BasicLit); ok && lit.Kind == token.STRING || len(args) < 4 {
+		return arg(0), nil
+	}
+	// (ctx, workflowID, runID, name, args...)
+	return arg(3), nil
+}
+
+// optionsMessageName returns the QueryType or UpdateName field set in the options literals
+// of args, or nil.
+func optionsMessageName(args []ast.Expr) ast.Expr {
+	var name ast.Expr
+	for _, arg := range args {
+		ast.Inspect(arg, func(n ast.Node) bool {
+			if kv, ok := n.(*ast.KeyValueExpr); ok && name == nil {
+				if key, ok := kv.Key.(*ast.Ident); ok && messageNameFields[key.Name] {
+					name = kv.Value
+				}
+			}
+			return name == nil
+		})
+	}
+	return name
+}
+
+// stringValue returns the value of a string literal or constant, or "".
+func (s *messageScanner) stringValue(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		value, _ := routePath(e)
+		return value
+	case *ast.Ident:
+		return s.constants[e.Name]
+	case *ast.SelectorExpr:
+		return s.constants[e.Sel.Name]
+	}
+	return ""
+}
+
+// workflowName returns the name of a workflow function, or of the workflow type named by
+// a string.
+func (s *messageScanner) workflowName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if value, ok := s.constants[e.Name]; ok {
+			return value
+		}
+		return e.Name
+	case *ast.SelectorExpr:
+		if value, ok := s.constants[e.Sel.Name]; ok {
+			return value
+		}
+		return e.Sel.Name
+	}
+	return s.stringValue(expr)
+}
+
+// ApplyMessages records the message calls in the graph, naming the started workflows by
+// graph key when they are unique, and the callers by the graph key of the node defined by
+// their function.
+func ApplyMessages(graph *TemporalGraph, messages []MessageCall) {
+	byName := make(map[string][]string)
+	byFunc := make(map[[2]string]string)
+	for id, node := range graph.Nodes {
+		if node.Unresolved {
+			continue
+		}
+		byFunc[[2]string{node.FilePath, node.Name}] = id
+		if node.Type != "workflow" {
+			continue
+		}
+		byName[node.Name] = append(byName[node.Name], id)
+		for _, alias := range node.Aliases {
+			byName[alias] = append(byName[alias], id)
+		}
+	}
+
+	graph.Messages = nil
+	for _, m := range messages {
+		if _, ok := graph.Nodes[m.Workflow]; !ok && m.Workflow != "" {
+			if ids := byName[m.Workflow]; len(ids) == 1 {
+				m.Workflow = ids[0]
+			}
+		}
+		if id, ok := byFunc[[2]string{m.FilePath, m.Caller}]; ok {
+			m.Caller = id
+		}
+		graph.Messages = append(graph.Messages, m)
+	}
+
+	sort.SliceStable(graph.Messages, func(i, j int) bool {
+		a, b := graph.Messages[i], graph.Messages[j]
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.LineNumber < b.LineNumber
+	})
+}
@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+const messageWorkflows = `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+const ApproveSignal = "approve"
+
+func OrderWorkflow(ctx workflow.Context) error {
+	workflow.GetSignalChannel(ctx, ApproveSignal)
+	return workflow.Sleep(ctx, 0)
+}
+
+func NotifyWorkflow(ctx workflow.Context, id string) error {
+	return workflow.SignalExternalWorkflow(ctx, id, "", ApproveSignal, nil).Get(ctx, nil)
+}
+`
+
+const messageClient = `package api
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/client"
+)
+
+func send(ctx context.Context, c client.Client) {
+	c.SignalWorkflow(ctx, "order-1", "", "cancel", nil)
+	c.SignalWithStartWorkflow(ctx, "order-1", orders.ApproveSignal, nil, client.StartWorkflowOptions{}, orders.OrderWorkflow)
+	c.QueryWorkflow(ctx, "order-1", "", "status")
+	c.UpdateWorkflow(ctx, client.UpdateWorkflowOptions{WorkflowID: "order-1", UpdateName: "setAddress"})
+}
+`
+
+func TestAnalyzeMessages(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"orders/orders.go": messageWorkflows,
+		"api/client.go":    messageClient,
+	})
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	client := filepath.Join(dir, "api", "client.go")
+	want := []MessageCall{
+		{Kind: "signal", Name: "cancel", Call: "SignalWorkflow", Caller: "send", FilePath: client, LineNumber: 10},
+		{Kind: "signal", Name: "approve", Workflow: "OrderWorkflow", Call: "SignalWithStartWorkflow", Caller: "send", FilePath: client, LineNumber: 11},
+		{Kind: "query", Name: "status", Call: "QueryWorkflow", Caller: "send", FilePath: client, LineNumber: 12},
+		{Kind: "update", Name: "setAddress", Call: "UpdateWorkflow", Caller: "send", FilePath: client, LineNumber: 13},
+		{Kind: "signal", Name: "approve", Call: "SignalExternalWorkflow", Caller: "NotifyWorkflow", FilePath: filepath.Join(dir, "orders", "orders.go"), LineNumber: 13},
+	}
+	if len(graph.Messages) != len(want) {
+		t.Fatalf("Expected %d messages, got %+v", len(want), graph.Messages)
+	}
+	for i, w := range want {
+		if graph.Messages[i] != w {
+			t.Errorf("Messages[%d] = %+v, want %+v", i, graph.Messages[i], w)
+		}
+	}
+	if got := graph.MessagesSent("query"); len(got) != 1 || got[0].Name != "status" {
+		t.Errorf("MessagesSent(query) = %+v", got)
+	}
+	if got := graph.MessagesSentBy("signal", "NotifyWorkflow"); len(got) != 1 || got[0].Call != "SignalExternalWorkflow" {
+		t.Errorf("MessagesSentBy(signal, NotifyWorkflow) = %+v", got)
+	}
+}
@@ -0,0 +1,232 @@
+package analyzer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MetadataFile is the metadata overlay read from the analyzed root when none is configured.
+const MetadataFile = "analyzer-metadata.yaml"
+
+// TagDeprecated is the tag of nodes marked deprecated in the metadata overlay.
+const TagDeprecated = "deprecated"
+
+// Metadata is a sidecar overlay of manually maintained node metadata.
+type Metadata struct {
+	Nodes []NodeMetadata
+}
+
+// NodeMetadata is the metadata attached to the nodes matching a pattern.
+type NodeMetadata struct {
+	// Match is a glob matched against the node name, its key and its package-qualified name
+	Match       string
+	Tags        []string
+	Description string
+	// Annotations are set like doc comment annotations, e.g. sla: 5m for @sla 5m
+	Annotations map[string]string
+	Deprecated  bool
+}
+
+// LoadMetadata reads a metadata overlay file.
+func LoadMetadata(filePath string) (*Metadata, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metadata file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	m, err := ParseMetadata(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return m, nil
+}
+
+// ParseMetadata parses the YAML subset of metadata overlay files:
+//
+//	nodes:
+//	  - match: payments.Charge*
+//	    tags: [critical, payments]
+//	    description: Charges the customer's card
+//	    deprecated: true
+//	    sla: 5m          # other keys become annotations
+//
+// Tags may also be written as a block list.
+func ParseMetadata(r io.Reader) (*Metadata, error) {
+	m := &Metadata{}
+	var entry *NodeMetadata
+	entryIndent, listKey := 0, ""
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if indent == 0 {
+			if trimmed != "nodes:" && trimmed != "nodes: []" {
+				return nil, fmt.Errorf("invalid metadata line %d: %q (expected nodes:)", lineNum, trimmed)
+			}
+			continue
+		}
+
+		item, isItem := strings.CutPrefix(trimmed, "-")
+		switch {
+		case isItem && (entry == nil || indent <= entryIndent):
+			// A new node entry, with its first field on the same line
+			m.Nodes = append(m.Nodes, NodeMetadata{})
+			entry, entryIndent, listKey = &m.Nodes[len(m.Nodes)-1], indent, ""
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			trimmed = item
+		case isItem && listKey != "":
+			entry.Tags = append(entry.Tags, unquoteMetadata(item))
+			continue
+		case entry == nil:
+			return nil, fmt.Errorf("invalid metadata line %d: %q (expected a - match: entry)", lineNum, trimmed)
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid metadata line %d: %q (expected key: value)", lineNum, trimmed)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		listKey = ""
+		if err := entry.set(key, value); err != nil {
+			return nil, fmt.Errorf("invalid metadata line %d: %w", lineNum, err)
+		}
+		if key == "tags" && value == "" {
+			listKey = key
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	for i, e := range m.Nodes {
+		if e.Match == "" {
+			return nil, fmt.Errorf("metadata entry %d has no match pattern", i+1)
+		}
+		if _, err := path.Match(e.Match, ""); err != nil {
+			return nil, fmt.Errorf("invalid metadata match pattern %q: %w", e.Match, err)
+		}
+	}
+	return m, nil
+}
+
+// set assigns a field of the entry from a "key: value" line.
+func (e *NodeMetadata) set(key, value string) error {
+	switch key {
+	case "match":
+		e.Match = unquoteMetadata(value)
+	case "description":
+		e.Description = unquoteMetadata(value)
+	case "deprecated":
+		deprecated, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("deprecated must be true or false, got %q", value)
+		}
+		e.Deprecated = deprecated
+	case "tags":
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+		for _, tag := range strings.Split(value, ",") {
+			if tag = unquoteMetadata(tag); tag != "" {
+				e.Tags = append(e.Tags, tag)
+			}
+		}
+	default:
+		if e.Annotations == nil {
+			e.Annotations = make(map[string]string)
+		}
+		e.Annotations[strings.TrimPrefix(key, "@")] = unquoteMetadata(value)
+	}
+	return nil
+}
+
+// unquoteMetadata trims whitespace and YAML quotes from a scalar.
+func unquoteMetadata(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' && s[len(s)-1] == '"' || s[0] == '\'' && s[len(s)-1] == '\'') {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// matches reports whether the entry applies to a node.
+func (e *NodeMetadata) matches(n *TemporalNode) bool {
+	names := []string{n.Name, n.ID()}
+	if n.Package != "" {
+		names = append(names, n.Package+"."+n.Name)
+	}
+	for _, name := range names {
+		if ok, _ := path.Match(e.Match, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply merges the metadata into the nodes it matches. Entries apply in file order, so later
+// entries override the descriptions and annotations of earlier ones, and both override doc
+// comments. It returns the patterns that matched no node.
+func (m *Metadata) Apply(graph *TemporalGraph) []string {
+	var unmatched []string
+	for _, e := range m.Nodes {
+		matched := false
+		for _, n := range graph.Nodes {
+			if !e.matches(n) {
+				continue
+			}
+			matched = true
+			n.Tags = append(n.Tags, e.Tags...)
+			if e.Deprecated {
+				n.Tags = append(n.Tags, TagDeprecated)
+			}
+			if e.Description != "" {
+				n.Description = e.Description
+			}
+			for key, value := range e.Annotations {
+				if n.Annotations == nil {
+					n.Annotations = make(map[string]string)
+				}
+				n.Annotations[key] = value
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, e.Match)
+		}
+	}
+
+	for _, n := range graph.Nodes {
+		n.Tags = uniqueSorted(n.Tags)
+	}
+	return unmatched
+}
+
+// uniqueSorted sorts a list and removes duplicates.
+func uniqueSorted(list []string) []string {
+	if len(list) < 2 {
+		return list
+	}
+	sort.Strings(list)
+	out := list[:1]
+	for _, s := range list[1:] {
+		if s != out[len(out)-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
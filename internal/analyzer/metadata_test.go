@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMetadata(t *testing.T) {
+	src := `# Team-maintained metadata
+nodes:
+  - match: payments.Charge*   # all charge workflows
+    tags: [critical, "payments"]
+    description: Charges the customer's card
+    sla: 5m
+  - match: LegacyRefundWorkflow
+    deprecated: true
+    tags:
+      - refunds
+      - 'legacy'
+`
+	m, err := ParseMetadata(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseMetadata failed: %v", err)
+	}
+
+	want := []NodeMetadata{
+		{
+			Match:       "payments.Charge*",
+			Tags:        []string{"critical", "payments"},
+			Description: "Charges the customer's card",
+			Annotations: map[string]string{"sla": "5m"},
+		},
+		{Match: "LegacyRefundWorkflow", Tags: []string{"refunds", "legacy"}, Deprecated: true},
+	}
+	if !reflect.DeepEqual(m.Nodes, want) {
+		t.Errorf("ParseMetadata() = %+v, want %+v", m.Nodes, want)
+	}
+}
+
+func TestParseMetadataErrors(t *testing.T) {
+	for _, src := range []string{
+		"workflows:\n",
+		"nodes:\n  match: Orphan\n",
+		"nodes:\n  - tags: [critical]\n",
+		"nodes:\n  - match: X\n    deprecated: maybe\n",
+		"nodes:\n  - match: '['\n",
+		"nodes:\n  - match: X\n    no colon\n",
+	} {
+		if _, err := ParseMetadata(strings.NewReader(src)); err == nil {
+			t.Errorf("ParseMetadata(%q) should fail", src)
+		}
+	}
+}
+
+func TestMetadataApply(t *testing.T) {
+	graph := &TemporalGraph{Nodes: map[string]*TemporalNode{
+		"ChargeWorkflow": {Name: "ChargeWorkflow", Package: "payments", Description: "From the doc comment",
+			Annotations: map[string]string{"owner": "payments-team"}},
+		"ChargeActivity": {Name: "ChargeActivity", Package: "payments", Tags: []string{"payments"}},
+		"orders.Ship":    {Name: "Ship", Key: "orders.Ship", Package: "orders"},
+	}}
+	m := &Metadata{Nodes: []NodeMetadata{
+		{Match: "payments.Charge*", Tags: []string{"payments", "critical"}, Annotations: map[string]string{"sla": "5m"}},
+		{Match: "ChargeWorkflow", Description: "Charges the card", Deprecated: true},
+		{Match: "orders.Ship", Tags: []string{"shipping"}},
+		{Match: "Missing*"},
+	}}
+
+	if unmatched := m.Apply(graph); !reflect.DeepEqual(unmatched, []string{"Missing*"}) {
+		t.Errorf("Apply() unmatched = %v, want [Missing*]", unmatched)
+	}
+
+	wf := graph.Nodes["ChargeWorkflow"]
+	if !reflect.DeepEqual(wf.Tags, []string{"critical", "deprecated", "payments"}) {
+		t.Errorf("ChargeWorkflow tags = %v", wf.Tags)
+	}
+	if wf.Description != "Charges the card" || wf.Annotations["sla"] != "5m" || wf.Annotations["owner"] != "payments-team" {
+		t.Errorf("ChargeWorkflow metadata not merged: %+v", wf)
+	}
+	if !wf.HasTag(TagDeprecated) || graph.Nodes["ChargeActivity"].HasTag(TagDeprecated) {
+		t.Error("Only ChargeWorkflow should be deprecated")
+	}
+	if got := graph.Nodes["ChargeActivity"].Tags; !reflect.DeepEqual(got, []string{"critical", "payments"}) {
+		t.Errorf("ChargeActivity tags = %v, want deduplicated [critical payments]", got)
+	}
+	if got := graph.Nodes["orders.Ship"].Tags; !reflect.DeepEqual(got, []string{"shipping"}) {
+		t.Errorf("orders.Ship tags = %v, want matched by key", got)
+	}
+}
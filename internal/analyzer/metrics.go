@@ -0,0 +1,131 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// metricPackages are the metrics client packages and the kind of metrics they record.
+var metricPackages = map[string]string{
+	"prometheus": "prometheus", "promauto": "prometheus",
+	"statsd": "statsd", "metrics": "metrics",
+}
+
+// metricMethods are methods that record a metric, with the client they usually belong to.
+var metricMethods = map[string]string{
+	"Inc": "prometheus", "Dec": "prometheus", "Observe": "prometheus",
+	"ObserveDuration": "prometheus", "SetToCurrentTime": "prometheus",
+	"Incr": "statsd", "Decr": "statsd", "Gauge": "statsd", "Timing": "statsd",
+	"Histogram": "statsd", "Distribution": "statsd",
+}
+
+// labeledMetricMethods record a metric only when called on a labeled Prometheus vector;
+// on their own they are too common (workflow.WaitGroup.Add, map setters).
+var labeledMetricMethods = map[string]bool{"Add": true, "Sub": true, "Set": true}
+
+// labelMethods select a metric from a Prometheus vector.
+var labelMethods = map[string]bool{"WithLabelValues": true, "With": true, "GetMetricWithLabelValues": true, "GetMetricWith": true}
+
+// extractMetricCalls finds metrics recorded directly with Prometheus or StatsD clients,
+// skipping metrics recorded through workflow.GetMetricsHandler.
+func (e *callExtractor) extractMetricCalls(body *ast.BlockStmt, fset *token.FileSet) []MetricCall {
+	// Variables derived from the workflow metrics handler
+	handlers := make(map[string]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok {
+			return true
+		}
+		for i, rhs := range assign.Rhs {
+			if i >= len(assign.Lhs) {
+				break
+			}
+			if ident, ok := assign.Lhs[i].(*ast.Ident); ok && fromMetricsHandler(rhs, handlers) {
+				handlers[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	var calls []MetricCall
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || fromMetricsHandler(sel.X, handlers) {
+			return true
+		}
+
+		method := sel.Sel.Name
+		kind := ""
+		if pkg, ok := sel.X.(*ast.Ident); ok && metricPackages[pkg.Name] != "" {
+			kind = metricPackages[pkg.Name]
+		} else if k := metricMethods[method]; k != "" {
+			kind = k
+		} else if labeledMetricMethods[method] && isLabeledMetric(sel.X) {
+			kind = "prometheus"
+		}
+		if kind == "" {
+			return true
+		}
+
+		calls = append(calls, MetricCall{
+			Call:       callChain(sel.X) + "." + method,
+			Kind:       kind,
+			LineNumber: lineOf(fset, call.Pos()),
+		})
+		// Labeled vectors are reported once, at the recording call
+		return false
+	})
+
+	return calls
+}
+
+// fromMetricsHandler reports whether expr is derived from workflow.GetMetricsHandler,
+// directly or through a variable in handlers.
+func fromMetricsHandler(expr ast.Expr, handlers map[string]bool) bool {
+	for {
+		switch x := expr.(type) {
+		case *ast.Ident:
+			return handlers[x.Name]
+		case *ast.CallExpr:
+			if sel, ok := x.Fun.(*ast.SelectorExpr); ok {
+				if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "workflow" && sel.Sel.Name == "GetMetricsHandler" {
+					return true
+				}
+				expr = sel.X
+				continue
+			}
+			return false
+		case *ast.SelectorExpr:
+			expr = x.X
+		default:
+			return false
+		}
+	}
+}
+
+// isLabeledMetric reports whether expr selects a metric from a vector, e.g. vec.WithLabelValues("a").
+func isLabeledMetric(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && labelMethods[sel.Sel.Name]
+}
+
+// callChain renders a selector and call chain such as vec.WithLabelValues().Inc, without arguments.
+func callChain(expr ast.Expr) string {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return x.Name
+	case *ast.SelectorExpr:
+		return callChain(x.X) + "." + x.Sel.Name
+	case *ast.CallExpr:
+		return callChain(x.Fun) + "()"
+	}
+	return "<expr>"
+}
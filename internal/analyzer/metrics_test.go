@@ -0,0 +1,39 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestExtractMetricCalls(t *testing.T) {
+	code := `package test
+
+func MetricsWorkflow(ctx workflow.Context) error {
+	ordersTotal.Inc()
+	ordersByRegion.WithLabelValues("eu").Add(2)
+	timer := prometheus.NewTimer(latency)
+	statsdClient.Incr("orders", nil, 1)
+	workflow.GetMetricsHandler(ctx).Counter("orders").Inc(1)
+	handler := workflow.GetMetricsHandler(ctx).WithTags(map[string]string{"a": "b"})
+	handler.Gauge("queue").Update(1)
+	var wg workflow.WaitGroup
+	wg.Add(1)
+	return nil
+}
+`
+	details := extractTestFunc(t, code, "MetricsWorkflow")
+
+	want := []MetricCall{
+		{Call: "ordersTotal.Inc", Kind: "prometheus", LineNumber: 4},
+		{Call: "ordersByRegion.WithLabelValues().Add", Kind: "prometheus", LineNumber: 5},
+		{Call: "prometheus.NewTimer", Kind: "prometheus", LineNumber: 6},
+		{Call: "statsdClient.Incr", Kind: "statsd", LineNumber: 7},
+	}
+	if len(details.MetricCalls) != len(want) {
+		t.Fatalf("Expected %d metric calls, got %+v", len(want), details.MetricCalls)
+	}
+	for i, w := range want {
+		if details.MetricCalls[i] != w {
+			t.Errorf("MetricCalls[%d] = %+v, want %+v", i, details.MetricCalls[i], w)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package analyzer
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// dependencyModulePrefixes are module paths treated as dependencies wherever they appear in
+// the analyzed tree: the Temporal SDK, its API and samples define functions that look like
+// workflows and activities but are not part of the project.
+var dependencyModulePrefixes = []string{"go.temporal.io/", "github.com/temporalio/"}
+
+// ModuleFilter decides which directories of the analyzed tree belong to its main modules.
+// The main modules are the module at the root and the modules listed by a go.work there;
+// a nested module is a dependency, and skipped, when a main module requires it or its path
+// is a Temporal SDK or samples module, as when the SDK is vendored or copied into the tree.
+// When package patterns select packages, directories outside them are skipped as well.
+type ModuleFilter struct {
+	// include disables the filter (--include-deps)
+	include bool
+	// allow are module path patterns analyzed even when they are dependencies
+	allow []string
+	// mainDirs are the directories of the main modules
+	mainDirs map[string]bool
+	// required are the module paths required by the main modules
+	required map[string]bool
+	// packages are the directories of the selected packages (nil = all)
+	packages []string
+}
+
+// NewModuleFilter reads the go.work and go.mod files at the root of the analyzed tree.
+func NewModuleFilter(rootDir string, opts config.AnalysisOptions) *ModuleFilter {
+	f := &ModuleFilter{
+		include:  opts.IncludeDeps,
+		allow:    opts.AllowModules,
+		mainDirs: map[string]bool{filepath.Clean(rootDir): true},
+		required: make(map[string]bool),
+		packages: opts.PackageDirs,
+	}
+	if f.include {
+		return f
+	}
+
+	for _, use := range readModFile(filepath.Join(rootDir, "go.work"), "use") {
+		f.mainDirs[filepath.Clean(filepath.Join(rootDir, use))] = true
+	}
+	for dir := range f.mainDirs {
+		for _, req := range readModFile(filepath.Join(dir, "go.mod"), "require") {
+			f.required[req] = true
+		}
+	}
+	return f
+}
+
+// SkipDir reports whether a directory is the root of a dependency module, or holds none of
+// the selected packages, and should not be analyzed.
+func (f *ModuleFilter) SkipDir(dir string) bool {
+	if !f.selected(dir) {
+		return true
+	}
+	if f.include || f.mainDirs[filepath.Clean(dir)] {
+		return false
+	}
+
+	paths := readModFile(filepath.Join(dir, "go.mod"), "module")
+	if len(paths) == 0 {
+		return false
+	}
+	modulePath := paths[0]
+
+	if f.allowed(modulePath) {
+		return false
+	}
+	if f.required[modulePath] {
+		return true
+	}
+	for _, prefix := range dependencyModulePrefixes {
+		if strings.HasPrefix(modulePath+"/", prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// selected reports whether a directory is one of the selected packages, or contains one.
+func (f *ModuleFilter) selected(dir string) bool {
+	if f.packages == nil {
+		return true
+	}
+	for _, pkg := range f.packages {
+		if withinDir(pkg, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowed reports whether a module path matches an allowlist pattern. A trailing "/..."
+// matches a module and the modules below it.
+func (f *ModuleFilter) allowed(modulePath string) bool {
+	for _, pattern := range f.allow {
+		if base, ok := strings.CutSuffix(pattern, "/..."); ok {
+			if modulePath == base || strings.HasPrefix(modulePath, base+"/") {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, modulePath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// readModFile returns the arguments of a directive of a go.mod or go.work file, e.g. the
+// module path for "module" or the required module paths for "require". It returns nil if
+// the file does not exist.
+func readModFile(filePath, directive string) []string {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = file.Close() }()
+
+	var args []string
+	inBlock := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case inBlock && fields[0] == ")":
+			inBlock = false
+		case inBlock:
+			args = append(args, strings.Trim(fields[0], `"`))
+		case fields[0] == directive && len(fields) > 1 && fields[1] == "(":
+			inBlock = true
+		case fields[0] == directive && len(fields) > 1:
+			args = append(args, strings.Trim(fields[1], `"`))
+		}
+	}
+	return args
+}
@@ -0,0 +1,156 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// fakeSDKTree is a project with a copy of the Temporal SDK embedded under third_party, as
+// left by a vendoring script; the SDK's own workflow and registration look like the project's.
+var fakeSDKTree = map[string]string{
+	"go.mod": `module example.com/shop
+
+go 1.22
+
+require (
+	go.temporal.io/sdk v1.26.0 // indirect
+)
+
+replace go.temporal.io/sdk => ./third_party/sdk
+`,
+	"orders.go": `package shop
+
+import "go.temporal.io/sdk/workflow"
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return workflow.Sleep(ctx, 0)
+}
+`,
+	"third_party/sdk/go.mod": "module go.temporal.io/sdk\n\ngo 1.22\n",
+	"third_party/sdk/internal/workflow.go": `package internal
+
+import (
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func internalWorkflow(ctx workflow.Context) error {
+	return workflow.Sleep(ctx, 0)
+}
+
+func register(worker worker.Worker) {
+	worker.RegisterWorkflow(internalWorkflow)
+}
+`,
+}
+
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestAnalyzeSkipsDependencyModules(t *testing.T) {
+	dir := writeTree(t, fakeSDKTree)
+
+	tests := []struct {
+		name    string
+		opts    config.AnalysisOptions
+		wantSDK bool
+	}{
+		{"default", config.AnalysisOptions{}, false},
+		{"include deps", config.AnalysisOptions{IncludeDeps: true}, true},
+		{"allowed module", config.AnalysisOptions{AllowModules: []string{"go.temporal.io/..."}}, true},
+		{"other module allowed", config.AnalysisOptions{AllowModules: []string{"github.com/temporalio/samples-go/..."}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.RootDir = dir
+			a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			graph, err := a.Analyze(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("Analyze failed: %v", err)
+			}
+			if _, ok := graph.Nodes["OrderWorkflow"]; !ok {
+				t.Error("OrderWorkflow not found")
+			}
+			if _, ok := graph.Nodes["internalWorkflow"]; ok != tt.wantSDK {
+				t.Errorf("internalWorkflow found = %v, want %v", ok, tt.wantSDK)
+			}
+		})
+	}
+}
+
+func TestModuleFilterSkipDir(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"go.work":                 "go 1.22\n\nuse (\n\t.\n\t./services/billing\n)\n",
+		"go.mod":                  "module example.com/shop\n\nrequire example.com/shared v0.1.0\n",
+		"services/billing/go.mod": "module example.com/billing\n\nrequire github.com/acme/lib v1.0.0\n",
+		"libs/shared/go.mod":      "module example.com/shared\n",
+		"libs/acme/go.mod":        "module github.com/acme/lib\n",
+		"tools/go.mod":            "module example.com/tools\n",
+		"samples/go.mod":          "module github.com/temporalio/samples-go\n",
+	})
+
+	filter := NewModuleFilter(dir, config.AnalysisOptions{})
+	tests := []struct {
+		dir  string
+		want bool
+	}{
+		{".", false},
+		{"services/billing", false},
+		{"libs/shared", true},
+		{"libs/acme", true},
+		{"tools", false},
+		{"samples", true},
+		{"internal", false},
+	}
+	for _, tt := range tests {
+		if got := filter.SkipDir(filepath.Join(dir, tt.dir)); got != tt.want {
+			t.Errorf("SkipDir(%q) = %v, want %v", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestReadModFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "go.mod")
+	content := `module "example.com/shop" // the shop
+
+require go.temporal.io/sdk v1.26.0
+require (
+	// the API
+	go.temporal.io/api v1.29.0
+	github.com/stretchr/testify v1.9.0 // indirect
+)
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readModFile(path, "module"); !reflect.DeepEqual(got, []string{"example.com/shop"}) {
+		t.Errorf("module = %v", got)
+	}
+	want := []string{"go.temporal.io/sdk", "go.temporal.io/api", "github.com/stretchr/testify"}
+	if got := readModFile(path, "require"); !reflect.DeepEqual(got, want) {
+		t.Errorf("require = %v, want %v", got, want)
+	}
+	if got := readModFile(filepath.Join(filepath.Dir(path), "missing.mod"), "module"); got != nil {
+		t.Errorf("missing file = %v, want nil", got)
+	}
+}
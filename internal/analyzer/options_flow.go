@@ -0,0 +1,467 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// optionsScope tracks activity options bound to local variables within a single function body.
+// It lets the extractor attribute options configured on a separate line, e.g.
+//
+//	ao := workflow.ActivityOptions{StartToCloseTimeout: time.Minute}
+//	ctx = workflow.WithActivityOptions(ctx, ao)
+//	workflow.ExecuteActivity(ctx, MyActivity)
+type optionsScope struct {
+	// optionVars maps variable names to ActivityOptions literals assigned to them
+	optionVars map[string]*ActivityOptions
+	// contexts maps workflow.Context variable names to the options attached to them
+	contexts map[string]*ActivityOptions
+	// childContexts holds the workflow.Context variables with child workflow options attached
+	childContexts map[string]bool
+}
+
+// newOptionsScope creates an empty options scope.
+func newOptionsScope() *optionsScope {
+	return &optionsScope{
+		optionVars:    make(map[string]*ActivityOptions),
+		contexts:      make(map[string]*ActivityOptions),
+		childContexts: make(map[string]bool),
+	}
+}
+
+// trackOptionsAssignment records options bound by assignment statements and var declarations.
+func (e *callExtractor) trackOptionsAssignment(scope *optionsScope, n ast.Node) {
+	switch stmt := n.(type) {
+	case *ast.AssignStmt:
+		if len(stmt.Lhs) == len(stmt.Rhs) {
+			for i, lhs := range stmt.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					e.bindOptionsValue(scope, ident.Name, stmt.Rhs[i])
+				}
+			}
+			return
+		}
+		// ctx, cancel := workflow.WithCancel(ctx) keeps the options of the parent context
+		if len(stmt.Rhs) == 1 && len(stmt.Lhs) > 0 {
+			if ident, ok := stmt.Lhs[0].(*ast.Ident); ok {
+				e.bindOptionsValue(scope, ident.Name, stmt.Rhs[0])
+			}
+		}
+	case *ast.ValueSpec:
+		if len(stmt.Names) != len(stmt.Values) {
+			return
+		}
+		for i, name := range stmt.Names {
+			e.bindOptionsValue(scope, name.Name, stmt.Values[i])
+		}
+	}
+}
+
+// bindOptionsValue updates the scope for a single name = value binding.
+func (e *callExtractor) bindOptionsValue(scope *optionsScope, name string, value ast.Expr) {
+	if name == "_" {
+		return
+	}
+
+	// Evaluate the value first: it may derive from the name it rebinds (ctx = workflow.WithCancel(ctx))
+	literal := e.parseOptionsLiteral(value)
+	var opts *ActivityOptions
+	child := false
+	if literal == nil {
+		opts = e.contextArgOptions(scope, value)
+		child = e.contextHasChildOptions(scope, value)
+	}
+
+	// Any rebinding invalidates what we knew about the name
+	delete(scope.optionVars, name)
+	delete(scope.contexts, name)
+	delete(scope.childContexts, name)
+
+	if literal != nil {
+		scope.optionVars[name] = literal
+		return
+	}
+	if opts != nil {
+		scope.contexts[name] = opts
+	}
+	if child {
+		scope.childContexts[name] = true
+	}
+}
+
+// parseOptionsLiteral parses workflow.ActivityOptions{...} and &workflow.ActivityOptions{...} literals.
+func (e *callExtractor) parseOptionsLiteral(expr ast.Expr) *ActivityOptions {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op.String() == "&" {
+		expr = unary.X
+	}
+
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok || lit.Type == nil {
+		return nil
+	}
+
+	if !strings.HasSuffix(e.typeToString(lit.Type), "ActivityOptions") {
+		return nil
+	}
+
+	return e.parseActivityOptionsLiteral(lit)
+}
+
+// contextArgOptions resolves the activity options carried by a workflow.Context expression.
+// It returns nil when the options cannot be determined.
+func (e *callExtractor) contextArgOptions(scope *optionsScope, expr ast.Expr) *ActivityOptions {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return scope.contexts[t.Name]
+
+	case *ast.CallExpr:
+		sel, ok := t.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || pkg.Name != "workflow" || !strings.HasPrefix(sel.Sel.Name, "With") || len(t.Args) == 0 {
+			return nil
+		}
+
+		if sel.Sel.Name == "WithActivityOptions" || sel.Sel.Name == "WithLocalActivityOptions" {
+			if len(t.Args) < 2 {
+				return nil
+			}
+			if ident, ok := t.Args[1].(*ast.Ident); ok {
+				if opts, found := scope.optionVars[ident.Name]; found {
+					return opts
+				}
+			}
+			return e.parseActivityOptionsExpr(t.Args[1])
+		}
+
+		// Other derived contexts (WithCancel, WithTaskQueue, ...) keep the parent's options
+		return e.contextArgOptions(scope, t.Args[0])
+	}
+
+	return nil
+}
+
+// contextHasChildOptions reports whether a workflow.Context expression carries child workflow
+// options set with workflow.WithChildOptions.
+func (e *callExtractor) contextHasChildOptions(scope *optionsScope, expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return scope.childContexts[t.Name]
+
+	case *ast.CallExpr:
+		sel, ok := t.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || pkg.Name != "workflow" || !strings.HasPrefix(sel.Sel.Name, "With") || len(t.Args) == 0 {
+			return false
+		}
+		if sel.Sel.Name == "WithChildOptions" {
+			return true
+		}
+		return e.contextHasChildOptions(scope, t.Args[0])
+	}
+
+	return false
+}
+
+// applyScopedOptions fills in activity options for an ExecuteActivity call whose
+// context was configured earlier in the same function, and marks ExecuteChildWorkflow calls
+// whose context carries activity options but no child workflow options.
+func (e *callExtractor) applyScopedOptions(scope *optionsScope, call *ast.CallExpr, info *TemporalCallInfo) {
+	if info.Type != "activity" && info.Type != "local_activity" && info.Type != "child_workflow" {
+		return
+	}
+
+	// For workflow.ExecuteActivity(...).Get(...) the options live on the inner call
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Get" {
+		if inner, ok := sel.X.(*ast.CallExpr); ok {
+			call = inner
+		}
+	}
+
+	if len(call.Args) == 0 {
+		return
+	}
+
+	opts := e.contextArgOptions(scope, call.Args[0])
+	if info.Type == "child_workflow" {
+		info.ActivityOptionsContext = opts != nil && !e.contextHasChildOptions(scope, call.Args[0])
+		return
+	}
+	if opts != nil {
+		info.ParsedActivityOpts = opts
+	}
+}
+
+// workflowContextParams returns the positions of workflow.Context parameters by name.
+func workflowContextParams(fn *ast.FuncDecl) map[string]int {
+	params := make(map[string]int)
+	if fn.Type.Params == nil {
+		return params
+	}
+
+	index := 0
+	for _, field := range fn.Type.Params.List {
+		isCtx := false
+		if sel, ok := field.Type.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				isCtx = pkg.Name == "workflow" && sel.Sel.Name == "Context"
+			}
+		}
+
+		if len(field.Names) == 0 {
+			index++
+			continue
+		}
+		for _, name := range field.Names {
+			if isCtx {
+				params[name.Name] = index
+			}
+			index++
+		}
+	}
+
+	return params
+}
+
+// dependentCallSite is an activity call site whose options come from a caller-supplied context.
+type dependentCallSite struct {
+	siteIndex  int // index into the helper node's CallSites
+	paramIndex int // position of the workflow.Context parameter used for the call
+}
+
+// contextHelper describes a function that executes activities with a context received from its callers.
+type contextHelper struct {
+	node     *TemporalNode
+	funcName string
+	isMethod bool
+	pkg      string
+	sites    []dependentCallSite
+}
+
+// callerOptions records the options a single caller passes to a helper for one parameter.
+type callerOptions struct {
+	caller     string
+	paramIndex int
+	opts       *ActivityOptions
+}
+
+// propagateContextOptions attributes activity options to call sites in intra-package helper
+// functions that execute activities with a workflow.Context received as a parameter.
+// When every caller passes a context with the same determinable options, the caller options
+// are inherited; otherwise the call site is marked as caller-dependent.
+func (g *graphBuilder) propagateContextOptions(ctx context.Context, matches []NodeMatch, graph *TemporalGraph) {
+	extractor, ok := g.callExtractor.(*callExtractor)
+	if !ok {
+		return
+	}
+
+	helpers := g.findContextHelpers(matches, graph)
+	if len(helpers) == 0 {
+		return
+	}
+
+	for _, helper := range helpers {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		callers := g.collectCallerOptions(extractor, helper, matches)
+
+		for _, site := range helper.sites {
+			callSite := &helper.node.CallSites[site.siteIndex]
+			callSite.ParsedActivityOpts = inheritOptions(callers, site.paramIndex)
+		}
+	}
+}
+
+// findContextHelpers finds functions with activity call sites that depend on a context parameter.
+func (g *graphBuilder) findContextHelpers(matches []NodeMatch, graph *TemporalGraph) []*contextHelper {
+	var helpers []*contextHelper
+
+	for _, match := range matches {
+		fn, ok := match.Node.(*ast.FuncDecl)
+		if !ok || fn.Name == nil || fn.Body == nil {
+			continue
+		}
+
+		ctxParams := workflowContextParams(fn)
+		// A reassigned parameter no longer carries the caller's context
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if assign, ok := n.(*ast.AssignStmt); ok {
+				for _, lhs := range assign.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						delete(ctxParams, ident.Name)
+					}
+				}
+			}
+			return true
+		})
+		if len(ctxParams) == 0 {
+			continue
+		}
+
+		node, exists := graph.Nodes[g.nodeKey(fn)]
+		if !exists {
+			continue
+		}
+
+		helper := &contextHelper{
+			node:     node,
+			funcName: fn.Name.Name,
+			isMethod: fn.Recv != nil,
+			pkg:      match.Package,
+		}
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "ExecuteActivity" && sel.Sel.Name != "ExecuteLocalActivity") {
+				return true
+			}
+			ctxIdent, ok := call.Args[0].(*ast.Ident)
+			if !ok {
+				return true
+			}
+			paramIndex, isParam := ctxParams[ctxIdent.Name]
+			if !isParam {
+				return true
+			}
+
+			line := match.FileSet.Position(call.Pos()).Line
+			for i, cs := range node.CallSites {
+				if cs.LineNumber == line && cs.ParsedActivityOpts == nil &&
+					(cs.TargetType == "activity" || cs.TargetType == "local_activity") {
+					helper.sites = append(helper.sites, dependentCallSite{siteIndex: i, paramIndex: paramIndex})
+				}
+			}
+			return true
+		})
+
+		if len(helper.sites) > 0 {
+			helpers = append(helpers, helper)
+		}
+	}
+
+	return helpers
+}
+
+// collectCallerOptions finds calls to the helper within its package and resolves the
+// options carried by each context argument.
+func (g *graphBuilder) collectCallerOptions(extractor *callExtractor, helper *contextHelper, matches []NodeMatch) []callerOptions {
+	var callers []callerOptions
+
+	for _, match := range matches {
+		if match.Package != helper.pkg {
+			continue
+		}
+		fn, ok := match.Node.(*ast.FuncDecl)
+		if !ok || fn.Name == nil || fn.Body == nil {
+			continue
+		}
+
+		callerName := fn.Name.Name
+		if receiver := g.extractReceiverType(fn); receiver != "" {
+			callerName = receiver + "." + fn.Name.Name
+		}
+		if callerName == helper.node.Name {
+			continue
+		}
+
+		scope := newOptionsScope()
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt, *ast.ValueSpec:
+				extractor.trackOptionsAssignment(scope, node)
+			case *ast.CallExpr:
+				if !callsHelper(node, helper) {
+					return true
+				}
+				for _, site := range helper.sites {
+					if site.paramIndex >= len(node.Args) {
+						continue
+					}
+					callers = append(callers, callerOptions{
+						caller:     callerName,
+						paramIndex: site.paramIndex,
+						opts:       extractor.contextArgOptions(scope, node.Args[site.paramIndex]),
+					})
+				}
+			}
+			return true
+		})
+	}
+
+	return callers
+}
+
+// callsHelper reports whether the call expression invokes the helper function.
+func callsHelper(call *ast.CallExpr, helper *contextHelper) bool {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return !helper.isMethod && fun.Name == helper.funcName
+	case *ast.SelectorExpr:
+		return helper.isMethod && fun.Sel.Name == helper.funcName
+	}
+	return false
+}
+
+// inheritOptions merges the options passed by all callers for a context parameter.
+// Options are inherited only when every caller's context options are known and the same;
+// otherwise they depend on the caller. A parameter without callers in the package keeps
+// no options: its context comes from elsewhere, e.g. from Temporal for a workflow.
+func inheritOptions(callers []callerOptions, paramIndex int) *ActivityOptions {
+	var inherited *ActivityOptions
+	seen := make(map[string]bool)
+	var names []string
+
+	for _, c := range callers {
+		if c.paramIndex != paramIndex {
+			continue
+		}
+		if c.opts == nil {
+			return &ActivityOptions{CallerDependent: true}
+		}
+		if inherited == nil {
+			copied := *c.opts
+			if c.opts.RetryPolicy != nil {
+				policy := *c.opts.RetryPolicy
+				copied.RetryPolicy = &policy
+			}
+			inherited = &copied
+		} else if !sameOptions(inherited, c.opts) {
+			return &ActivityOptions{CallerDependent: true}
+		}
+		if !seen[c.caller] {
+			seen[c.caller] = true
+			names = append(names, c.caller)
+		}
+	}
+
+	if inherited == nil {
+		return nil
+	}
+
+	sort.Strings(names)
+	inherited.InheritedFrom = names
+	return inherited
+}
+
+// sameOptions reports whether two callers pass the same activity options.
+func sameOptions(a, b *ActivityOptions) bool {
+	x, y := *a, *b
+	x.InheritedFrom, y.InheritedFrom = nil, nil
+	return reflect.DeepEqual(x, y)
+}
@@ -0,0 +1,302 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+// buildTestGraph parses code and builds a graph treating every function as a workflow.
+func buildTestGraph(t *testing.T, code string) *TemporalGraph {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse code: %v", err)
+	}
+
+	var matches []NodeMatch
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			matches = append(matches, NodeMatch{
+				Node:     fn,
+				FileSet:  fset,
+				FilePath: "test.go",
+				Package:  "test",
+				NodeType: "workflow",
+			})
+		}
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	builder := NewGraphBuilder(logger, NewCallExtractor(logger))
+
+	graph, err := builder.BuildGraph(context.Background(), matches)
+	if err != nil {
+		t.Fatalf("BuildGraph failed: %v", err)
+	}
+	return graph
+}
+
+// findCallSite returns the first call site in a node targeting the given name.
+func findCallSite(t *testing.T, graph *TemporalGraph, nodeName, target string) CallSite {
+	t.Helper()
+
+	node, ok := graph.Nodes[nodeName]
+	if !ok {
+		t.Fatalf("Node %s not found", nodeName)
+	}
+	for _, cs := range node.CallSites {
+		if cs.TargetName == target {
+			return cs
+		}
+	}
+	t.Fatalf("Call site %s not found in %s", target, nodeName)
+	return CallSite{}
+}
+
+func TestScopedOptionsSameFunction(t *testing.T) {
+	code := `package test
+
+func MyWorkflow(ctx workflow.Context) error {
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{MaximumAttempts: 3},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+	return workflow.ExecuteActivity(ctx, MyActivity).Get(ctx, nil)
+}
+`
+	graph := buildTestGraph(t, code)
+	cs := findCallSite(t, graph, "MyWorkflow", "MyActivity")
+
+	if cs.ParsedActivityOpts == nil {
+		t.Fatal("Expected options to be attributed from the local context")
+	}
+	if cs.ParsedActivityOpts.StartToCloseTimeout != "time.Minute" {
+		t.Errorf("StartToCloseTimeout = %q, want %q", cs.ParsedActivityOpts.StartToCloseTimeout, "time.Minute")
+	}
+	if cs.ParsedActivityOpts.RetryPolicy == nil || cs.ParsedActivityOpts.RetryPolicy.MaximumAttempts != 3 {
+		t.Error("Expected RetryPolicy with MaximumAttempts 3")
+	}
+}
+
+func TestScopedOptionsDerivedContext(t *testing.T) {
+	code := `package test
+
+func MyWorkflow(ctx workflow.Context) error {
+	actCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	cancelCtx, cancel := workflow.WithCancel(actCtx)
+	defer cancel()
+	return workflow.ExecuteActivity(cancelCtx, MyActivity).Get(ctx, nil)
+}
+`
+	graph := buildTestGraph(t, code)
+	cs := findCallSite(t, graph, "MyWorkflow", "MyActivity")
+
+	if cs.ParsedActivityOpts == nil || cs.ParsedActivityOpts.StartToCloseTimeout == "" {
+		t.Error("Expected options to flow through WithCancel")
+	}
+}
+
+func TestScopedOptionsReassignmentClears(t *testing.T) {
+	code := `package test
+
+func MyWorkflow(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	ctx = newContext()
+	return workflow.ExecuteActivity(ctx, MyActivity).Get(ctx, nil)
+}
+`
+	graph := buildTestGraph(t, code)
+	cs := findCallSite(t, graph, "MyWorkflow", "MyActivity")
+
+	if cs.ParsedActivityOpts != nil {
+		t.Error("Expected options to be forgotten after reassignment")
+	}
+}
+
+func TestScopedOptionsRebindingKeepsOptions(t *testing.T) {
+	code := `package test
+
+func MyWorkflow(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	ctx, cancel := workflow.WithCancel(ctx)
+	defer cancel()
+	return workflow.ExecuteActivity(ctx, MyActivity).Get(ctx, nil)
+}
+`
+	graph := buildTestGraph(t, code)
+	cs := findCallSite(t, graph, "MyWorkflow", "MyActivity")
+
+	if cs.ParsedActivityOpts == nil || cs.ParsedActivityOpts.StartToCloseTimeout == "" {
+		t.Error("Expected options to survive rebinding ctx to a context derived from it")
+	}
+}
+
+func TestScopedOptionsChildWorkflowContext(t *testing.T) {
+	code := `package test
+
+func MyWorkflow(ctx workflow.Context) error {
+	workflow.ExecuteChildWorkflow(ctx, PlainChild)
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	workflow.ExecuteChildWorkflow(ctx, MisconfiguredChild).Get(ctx, nil)
+	ctx, cancel := workflow.WithCancel(ctx)
+	defer cancel()
+	workflow.ExecuteChildWorkflow(ctx, CancelableChild)
+	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{WorkflowID: "child"})
+	workflow.ExecuteChildWorkflow(childCtx, ConfiguredChild)
+	return workflow.ExecuteChildWorkflow(workflow.WithTaskQueue(childCtx, "q"), QueuedChild).Get(ctx, nil)
+}
+`
+	graph := buildTestGraph(t, code)
+
+	tests := []struct {
+		target string
+		want   bool
+	}{
+		{"PlainChild", false},
+		{"MisconfiguredChild", true},
+		{"CancelableChild", true},
+		{"ConfiguredChild", false},
+		{"QueuedChild", false},
+	}
+	for _, tt := range tests {
+		if cs := findCallSite(t, graph, "MyWorkflow", tt.target); cs.ActivityOptionsContext != tt.want {
+			t.Errorf("%s: ActivityOptionsContext = %v, want %v", tt.target, cs.ActivityOptionsContext, tt.want)
+		}
+	}
+}
+
+func TestPropagateContextOptionsInherited(t *testing.T) {
+	code := `package test
+
+func runStep(ctx workflow.Context, input string) error {
+	return workflow.ExecuteActivity(ctx, StepActivity, input).Get(ctx, nil)
+}
+
+func FirstWorkflow(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	return runStep(ctx, "a")
+}
+
+func SecondWorkflow(ctx workflow.Context) error {
+	ao := workflow.ActivityOptions{StartToCloseTimeout: time.Minute}
+	return runStep(workflow.WithActivityOptions(ctx, ao), "b")
+}
+`
+	graph := buildTestGraph(t, code)
+	cs := findCallSite(t, graph, "runStep", "StepActivity")
+
+	opts := cs.ParsedActivityOpts
+	if opts == nil {
+		t.Fatal("Expected inherited options")
+	}
+	if opts.CallerDependent {
+		t.Error("Options should not be caller-dependent when all callers are known")
+	}
+	if !opts.OptionsProvided() {
+		t.Error("Inherited options should report OptionsProvided")
+	}
+	if len(opts.InheritedFrom) != 2 || opts.InheritedFrom[0] != "FirstWorkflow" || opts.InheritedFrom[1] != "SecondWorkflow" {
+		t.Errorf("InheritedFrom = %v, want [FirstWorkflow SecondWorkflow]", opts.InheritedFrom)
+	}
+}
+
+func TestPropagateContextOptionsCallerDependent(t *testing.T) {
+	code := `package test
+
+func runStep(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, StepActivity).Get(ctx, nil)
+}
+
+func KnownWorkflow(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	return runStep(ctx)
+}
+
+func UnknownWorkflow(ctx workflow.Context) error {
+	return runStep(ctx)
+}
+`
+	graph := buildTestGraph(t, code)
+	cs := findCallSite(t, graph, "runStep", "StepActivity")
+
+	if cs.ParsedActivityOpts == nil || !cs.ParsedActivityOpts.CallerDependent {
+		t.Error("Expected caller-dependent options when a caller's options are unknown")
+	}
+	if cs.ParsedActivityOpts.OptionsProvided() {
+		t.Error("Caller-dependent options should not report OptionsProvided")
+	}
+}
+
+func TestPropagateContextOptionsDisagreeingCallers(t *testing.T) {
+	code := `package test
+
+func runStep(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, StepActivity).Get(ctx, nil)
+}
+
+func FirstWorkflow(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	return runStep(ctx)
+}
+
+func SecondWorkflow(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{ScheduleToCloseTimeout: time.Hour})
+	return runStep(ctx)
+}
+`
+	graph := buildTestGraph(t, code)
+	cs := findCallSite(t, graph, "runStep", "StepActivity")
+
+	opts := cs.ParsedActivityOpts
+	if opts == nil || !opts.CallerDependent {
+		t.Fatalf("Expected caller-dependent options when callers pass different options, got %+v", opts)
+	}
+	if opts.StartToCloseTimeout != "" || len(opts.InheritedFrom) != 0 {
+		t.Errorf("Expected no inherited options, got %+v", opts)
+	}
+}
+
+func TestPropagateContextOptionsNoCallers(t *testing.T) {
+	code := `package test
+
+func (w *Workflows) Run(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, StepActivity).Get(ctx, nil)
+}
+`
+	graph := buildTestGraph(t, code)
+	cs := findCallSite(t, graph, "*Workflows.Run", "StepActivity")
+
+	// The context of a workflow comes from Temporal, without activity options
+	if cs.ParsedActivityOpts != nil {
+		t.Errorf("Expected no options for a context without callers in the package, got %+v", cs.ParsedActivityOpts)
+	}
+}
+
+func TestWorkflowContextParams(t *testing.T) {
+	code := `package test
+
+func helper(a string, ctx workflow.Context, b, c int) {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse code: %v", err)
+	}
+
+	fn := file.Decls[0].(*ast.FuncDecl)
+	params := workflowContextParams(fn)
+	if len(params) != 1 {
+		t.Fatalf("Expected 1 context param, got %d", len(params))
+	}
+	if params["ctx"] != 1 {
+		t.Errorf("ctx index = %d, want 1", params["ctx"])
+	}
+}
@@ -0,0 +1,72 @@
+package analyzer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ListPackageDirs resolves Go package patterns with go list, run in dir, to the sorted
+// directories of the matched packages. Patterns follow the go command's semantics: a
+// trailing "/..." matches the subpackages, without testdata, vendor and nested modules.
+func ListPackageDirs(ctx context.Context, dir string, patterns []string) ([]string, error) {
+	// Only the directories are needed: -find and -mod=readonly keep go list from resolving
+	// imports, which could download modules
+	args := append([]string{"list", "-e", "-find", "-mod=readonly", "-f", "{{.ImportPath}}\t{{.Dir}}\t{{with .Error}}{{.Err}}{{end}}"}, patterns...)
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go list %s: %w: %s", strings.Join(patterns, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		importPath, rest, _ := strings.Cut(line, "\t")
+		pkgDir, pkgErr, _ := strings.Cut(rest, "\t")
+		if pkgDir == "" {
+			if pkgErr != "" {
+				return nil, fmt.Errorf("package %s: %s", importPath, pkgErr)
+			}
+			continue
+		}
+		if !seen[pkgDir] {
+			seen[pkgDir] = true
+			dirs = append(dirs, pkgDir)
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("%s matched no packages", strings.Join(patterns, " "))
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// PackagesRoot returns the deepest directory containing all the given package directories,
+// the root the analysis of those packages walks from.
+func PackagesRoot(dirs []string) string {
+	root := filepath.Clean(dirs[0])
+	for _, dir := range dirs[1:] {
+		for !withinDir(filepath.Clean(dir), root) {
+			parent := filepath.Dir(root)
+			if parent == root {
+				break
+			}
+			root = parent
+		}
+	}
+	return root
+}
+
+// withinDir reports whether path is dir or below it.
+func withinDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
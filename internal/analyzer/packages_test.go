@@ -0,0 +1,102 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// packagesTree is a module with workflows in two services and a fixture under testdata.
+var packagesTree = map[string]string{
+	"go.mod": "module example.com/shop\n\ngo 1.22\n",
+	"services/payments/payments.go": `package payments
+
+import "go.temporal.io/sdk/workflow"
+
+func PaymentWorkflow(ctx workflow.Context) error { return workflow.Sleep(ctx, 0) }
+`,
+	"services/payments/refunds/refunds.go": `package refunds
+
+import "go.temporal.io/sdk/workflow"
+
+func RefundWorkflow(ctx workflow.Context) error { return workflow.Sleep(ctx, 0) }
+`,
+	"services/payments/testdata/fixture.go": `package fixture
+
+import "go.temporal.io/sdk/workflow"
+
+func FixtureWorkflow(ctx workflow.Context) error { return workflow.Sleep(ctx, 0) }
+`,
+	"services/orders/orders.go": `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+func OrderWorkflow(ctx workflow.Context) error { return workflow.Sleep(ctx, 0) }
+`,
+}
+
+func TestListPackageDirs(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	dir := writeTree(t, packagesTree)
+
+	dirs, err := ListPackageDirs(context.Background(), dir, []string{"./services/payments/..."})
+	if err != nil {
+		t.Fatalf("ListPackageDirs failed: %v", err)
+	}
+	want := []string{filepath.Join(dir, "services/payments"), filepath.Join(dir, "services/payments/refunds")}
+	if !reflect.DeepEqual(dirs, want) {
+		t.Errorf("ListPackageDirs = %v, want %v", dirs, want)
+	}
+
+	if _, err := ListPackageDirs(context.Background(), dir, []string{"./billing/..."}); err == nil || !strings.Contains(err.Error(), "billing") {
+		t.Errorf("Expected an error for a missing directory, got %v", err)
+	}
+}
+
+func TestPackagesRoot(t *testing.T) {
+	tests := []struct {
+		dirs []string
+		want string
+	}{
+		{[]string{"/app/services/payments"}, "/app/services/payments"},
+		{[]string{"/app/services/payments", "/app/services/payments/refunds"}, "/app/services/payments"},
+		{[]string{"/app/services/payments", "/app/services/orders"}, "/app/services"},
+		{[]string{"/app/services/pay", "/app/services/payments"}, "/app/services"},
+	}
+	for _, tt := range tests {
+		if got := PackagesRoot(tt.dirs); got != filepath.FromSlash(tt.want) {
+			t.Errorf("PackagesRoot(%v) = %q, want %q", tt.dirs, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzePackageDirs(t *testing.T) {
+	dir := writeTree(t, packagesTree)
+	payments := filepath.Join(dir, "services/payments")
+	opts := config.AnalysisOptions{
+		RootDir:     payments,
+		PackageDirs: []string{payments, filepath.Join(payments, "refunds")},
+	}
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	var names []string
+	for name := range graph.Nodes {
+		names = append(names, name)
+	}
+	if len(names) != 2 || graph.Nodes["PaymentWorkflow"] == nil || graph.Nodes["RefundWorkflow"] == nil {
+		t.Errorf("Expected only the workflows of the selected packages, got %v", names)
+	}
+}
@@ -17,7 +17,10 @@ import (
 // goParser implements the Parser interface.
 type goParser struct {
 	logger           *slog.Logger
-	registrationInfo *RegistrationInfo // Populated during ParseDirectory
+	registrationInfo *RegistrationInfo           // Populated during ParseDirectory
+	aliases          map[string]TypeAliases      // Type aliases per package directory, populated during ParseDirectory
+	constants        map[string]PackageConstants // Constants per package directory, populated during ParseDirectory
+	funcs            *FuncIndex                  // Functions of all packages with --internal-depth, populated during ParseDirectory
 }
 
 // NewParser creates a new Parser instance.
@@ -42,12 +45,36 @@ func (p *goParser) ParseDirectory(ctx context.Context, rootDir string, opts conf
 		}
 	}
 	p.registrationInfo = regInfo
+	p.aliases = make(map[string]TypeAliases)
+	p.constants = make(map[string]PackageConstants)
+	p.funcs = nil
+	if opts.InternalDepth > 0 {
+		p.funcs = NewFuncIndex(opts.InternalDepth)
+	}
 
 	var matches []NodeMatch
 
+	// Matches are reported per directory so callers can show nodes while parsing continues
+	filesScanned := 0
+	pendingDir := ""
+	var pending []NodeMatch
+	flush := func() {
+		if pendingDir == "" {
+			return
+		}
+		reportPackage(ctx, PackageResult{
+			Package:      pendingDir,
+			Nodes:        previewNodes(pending),
+			FilesScanned: filesScanned,
+			NodesFound:   len(matches),
+		})
+		pendingDir, pending = "", nil
+	}
+
 	// Create file set for tracking position information
 	fset := token.NewFileSet()
 
+	modules := NewModuleFilter(rootDir, opts)
 	err = filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			p.logger.Warn("Error accessing path", "path", path, "error", err)
@@ -64,10 +91,11 @@ func (p *goParser) ParseDirectory(ctx context.Context, rootDir string, opts conf
 		// Skip directories
 		if info.IsDir() {
 			// Skip excluded directories
-			for _, excludeDir := range opts.ExcludeDirs {
-				if info.Name() == excludeDir {
-					return filepath.SkipDir
-				}
+			if opts.ExcludesDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			if modules.SkipDir(path) {
+				return filepath.SkipDir
 			}
 			return nil
 		}
@@ -78,26 +106,38 @@ func (p *goParser) ParseDirectory(ctx context.Context, rootDir string, opts conf
 		}
 
 		// Skip test files if not included
-		if !opts.IncludeTests && strings.HasSuffix(path, "_test.go") {
+		if !opts.IncludeTests && IsTestFile(path) {
 			return nil
 		}
 
+		if dir := filepath.Dir(path); dir != pendingDir {
+			flush()
+			pendingDir = dir
+		}
+		filesScanned++
+
 		// Parse the file
-		fileMatches, err := p.parseFile(ctx, path, fset)
+		var fileMatches []NodeMatch
+		if panicErr := recovered(func() { fileMatches, err = p.parseFile(ctx, path, fset) }); panicErr != nil {
+			err = panicErr
+		}
 		if err != nil {
 			p.logger.Warn("Error parsing file", "path", path, "error", err)
+			recordFileError(ctx, StageParse, path, err)
 			return nil // Continue with other files
 		}
 
 		// Apply filters
 		filteredMatches := p.applyFilters(fileMatches, opts)
 		matches = append(matches, filteredMatches...)
+		pending = append(pending, filteredMatches...)
 
 		return nil
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to walk directory %s: %w", rootDir, err)
 	}
+	flush()
 
 	p.logger.Info("Parsed directory", "root", rootDir, "matches", len(matches))
 	return matches, nil
@@ -116,6 +156,26 @@ func (p *goParser) parseFile(ctx context.Context, filePath string, fset *token.F
 	// Extract package name
 	packageName := node.Name.Name
 
+	// Aliases and constants are shared by the files of a directory, so those declared in
+	// files parsed later still apply to this file's matches
+	dir := filepath.Dir(filePath)
+	aliases, ok := p.aliases[dir]
+	if !ok {
+		aliases = make(TypeAliases)
+		if p.aliases != nil {
+			p.aliases[dir] = aliases
+		}
+	}
+	aliases.collectAliases(node)
+	constants, ok := p.constants[dir]
+	if !ok {
+		constants = make(PackageConstants)
+		if p.constants != nil {
+			p.constants[dir] = constants
+		}
+	}
+	constants.collectConstants(node)
+
 	// Visit all function declarations
 	ast.Inspect(node, func(n ast.Node) bool {
 		// Check context cancellation
@@ -129,6 +189,9 @@ func (p *goParser) parseFile(ctx context.Context, filePath string, fset *token.F
 		if !ok {
 			return true
 		}
+		if p.funcs != nil {
+			p.funcs.add(NodeMatch{Node: fn, FileSet: fset, FilePath: filePath, Package: packageName, Aliases: aliases, Constants: constants})
+		}
 
 		// Check if it's a workflow, activity, or handler
 		nodeType := p.classifyFunction(fn)
@@ -137,11 +200,16 @@ func (p *goParser) parseFile(ctx context.Context, filePath string, fset *token.F
 		}
 
 		matches = append(matches, NodeMatch{
-			Node:     fn,
-			FileSet:  fset,
-			FilePath: filePath,
-			Package:  packageName,
-			NodeType: nodeType,
+			Node:      fn,
+			FileSet:   fset,
+			FilePath:  filePath,
+			Package:   packageName,
+			NodeType:  nodeType,
+			Aliases:   aliases,
+			Constants: constants,
+			Funcs:     p.funcs,
+			Reasons:   p.detectionReasons(fn),
+			Names:     p.registeredNames(fn),
 		})
 
 		return true
@@ -150,6 +218,14 @@ func (p *goParser) parseFile(ctx context.Context, filePath string, fset *token.F
 	return matches, nil
 }
 
+// registeredNames returns the names a function is registered under with RegisterOptions.
+func (p *goParser) registeredNames(fn *ast.FuncDecl) []string {
+	if p.registrationInfo == nil {
+		return nil
+	}
+	return p.registrationInfo.RegisteredNames(fn.Name.Name, p.extractReceiverTypeName(fn))
+}
+
 // classifyFunction determines what type of Temporal function this is.
 func (p *goParser) classifyFunction(fn *ast.FuncDecl) string {
 	if fn == nil || fn.Name == nil {
@@ -178,16 +254,8 @@ func (p *goParser) classifyFunction(fn *ast.FuncDecl) string {
 	}
 
 	// Check based on first parameter type (workflow.Context indicates a workflow)
-	if fn.Type.Params != nil && len(fn.Type.Params.List) > 0 {
-		firstParam := fn.Type.Params.List[0]
-		if p.isWorkflowContext(firstParam.Type) {
-			// Check function body for workflow-specific calls
-			if fn.Body != nil {
-				if p.hasWorkflowCalls(fn.Body) {
-					return "workflow"
-				}
-			}
-		}
+	if p.hasWorkflowSignature(fn) {
+		return "workflow"
 	}
 
 	// Check function body for workflow-specific patterns
@@ -206,6 +274,29 @@ func (p *goParser) classifyFunction(fn *ast.FuncDecl) string {
 	return ""
 }
 
+// hasWorkflowSignature reports whether a function takes a workflow.Context first and calls
+// the workflow SDK.
+func (p *goParser) hasWorkflowSignature(fn *ast.FuncDecl) bool {
+	if fn.Type.Params == nil || len(fn.Type.Params.List) == 0 || fn.Body == nil {
+		return false
+	}
+	return p.isWorkflowContext(fn.Type.Params.List[0].Type) && p.hasWorkflowCalls(fn.Body)
+}
+
+// detectionReasons returns the evidence a Temporal function was classified from.
+func (p *goParser) detectionReasons(fn *ast.FuncDecl) []string {
+	var reasons []string
+	funcName := fn.Name.Name
+	if p.registrationInfo != nil && (p.registrationInfo.IsRegisteredWorkflow(funcName) ||
+		p.registrationInfo.IsRegisteredActivity(funcName, p.extractReceiverTypeName(fn))) {
+		reasons = append(reasons, ReasonRegistered)
+	}
+	if p.hasWorkflowSignature(fn) {
+		reasons = append(reasons, ReasonSignature)
+	}
+	return reasons
+}
+
 // extractReceiverTypeName extracts the receiver type name from a method declaration.
 // Returns empty string for regular functions.
 func (p *goParser) extractReceiverTypeName(fn *ast.FuncDecl) string {
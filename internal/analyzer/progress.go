@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+)
+
+// Analysis stages reported in Progress events.
+const (
+	StageRegistrations = "registrations"
+	StageParse         = "parse"
+	StageGraph         = "graph"
+	StageTests         = "tests"
+	StageWorkers       = "workers"
+	StageEntryPoints   = "entry_points"
+	StageMessages      = "messages"
+	StageWorkflowIDs   = "workflow_ids"
+	StageDone          = "done"
+)
+
+// Progress reports how far an analysis has come. Parsing is reported per package with
+// PackageResult instead.
+type Progress struct {
+	Stage        string
+	FilesScanned int
+	NodesFound   int
+}
+
+// PackageResult is the result of parsing one package, reported by AnalyzeStream as soon as
+// the files of the package are parsed, while the analysis continues.
+type PackageResult struct {
+	// Package is the directory whose files were parsed
+	Package string
+	// Nodes are preliminary nodes found in Package, without call sites or relationships
+	Nodes []*TemporalNode
+	// FilesScanned and NodesFound count the files and nodes of all packages parsed so far
+	FilesScanned int
+	NodesFound   int
+}
+
+type progressKey struct{}
+
+// WithProgress returns a context that makes the analysis send Progress events to ch.
+// Sends block until received or the context is cancelled.
+func WithProgress(ctx context.Context, ch chan<- Progress) context.Context {
+	return context.WithValue(ctx, progressKey{}, ch)
+}
+
+// reportProgress sends p to the progress channel of ctx, if any.
+func reportProgress(ctx context.Context, p Progress) {
+	ch, ok := ctx.Value(progressKey{}).(chan<- Progress)
+	if !ok {
+		return
+	}
+	select {
+	case ch <- p:
+	case <-ctx.Done():
+	}
+}
+
+type packageResultsKey struct{}
+
+// withPackageResults returns a context that makes the analysis call onPackage with the result
+// of each package parsed.
+func withPackageResults(ctx context.Context, onPackage func(PackageResult)) context.Context {
+	return context.WithValue(ctx, packageResultsKey{}, onPackage)
+}
+
+// reportPackage calls the package callback of ctx, if any.
+func reportPackage(ctx context.Context, r PackageResult) {
+	if onPackage, ok := ctx.Value(packageResultsKey{}).(func(PackageResult)); ok && onPackage != nil {
+		onPackage(r)
+	}
+}
+
+// previewNodes creates preliminary nodes for matches, before the graph is built.
+func previewNodes(matches []NodeMatch) []*TemporalNode {
+	var g graphBuilder
+	nodes := make([]*TemporalNode, 0, len(matches))
+	for _, match := range matches {
+		fn, ok := match.Node.(*ast.FuncDecl)
+		if !ok || fn.Name == nil {
+			continue
+		}
+		name := fn.Name.Name
+		if receiver := g.extractReceiverType(fn); receiver != "" {
+			name = receiver + "." + name
+		}
+		nodes = append(nodes, &TemporalNode{
+			Name:       name,
+			Type:       match.NodeType,
+			Package:    match.Package,
+			FilePath:   match.FilePath,
+			LineNumber: match.FileSet.Position(fn.Pos()).Line,
+		})
+	}
+	return nodes
+}
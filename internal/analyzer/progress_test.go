@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+func TestAnalyzeWorkflowsProgress(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `package test
+
+import "go.temporal.io/sdk/workflow"
+
+func MyWorkflow(ctx workflow.Context) error {
+	workflow.ExecuteActivity(ctx, MyActivity).Get(ctx, nil)
+	return nil
+}
+
+func MyActivity() error {
+	return nil
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "workflow.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	service := NewService(logger, NewParser(logger), NewGraphBuilder(logger, NewCallExtractor(logger)), NewRepository(logger))
+
+	ch := make(chan Progress)
+	var events []Progress
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for p := range ch {
+			events = append(events, p)
+		}
+	}()
+
+	_, err := service.AnalyzeWorkflows(WithProgress(context.Background(), ch), config.AnalysisOptions{RootDir: tmpDir})
+	close(ch)
+	<-done
+	if err != nil {
+		t.Fatalf("AnalyzeWorkflows failed: %v", err)
+	}
+
+	if len(events) == 0 || events[0].Stage != StageRegistrations {
+		t.Fatalf("expected the registrations stage first, got %+v", events)
+	}
+	if last := events[len(events)-1]; last.Stage != StageDone {
+		t.Errorf("last stage = %q, want %q", last.Stage, StageDone)
+	}
+}
+
+func TestAnalyzeStream(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"orders/workflow.go": `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, Charge).Get(ctx, nil)
+}
+`,
+		"orders/activity.go": "package orders\n\nfunc Charge() error { return nil }\n",
+		"billing/workflow.go": `package billing
+
+import "go.temporal.io/sdk/workflow"
+
+func InvoiceWorkflow(ctx workflow.Context) error { return workflow.Sleep(ctx, 0) }
+`,
+	})
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	var results []PackageResult
+	graph, err := a.AnalyzeStream(context.Background(), config.AnalysisOptions{RootDir: dir}, func(r PackageResult) {
+		results = append(results, r)
+	})
+	if err != nil {
+		t.Fatalf("AnalyzeStream failed: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected a result per package, got %+v", results)
+	}
+	billing, orders := results[0], results[1]
+	if billing.Package != filepath.Join(dir, "billing") || len(billing.Nodes) != 1 || billing.Nodes[0].Name != "InvoiceWorkflow" {
+		t.Errorf("billing result = %+v", billing)
+	}
+	if orders.Package != filepath.Join(dir, "orders") || orders.FilesScanned != 3 || orders.NodesFound != 2 {
+		t.Errorf("orders result = %+v, want 3 files and 2 nodes so far", orders)
+	}
+	if len(orders.Nodes) != 1 || orders.Nodes[0].LineNumber == 0 || orders.Nodes[0].CallSites != nil {
+		t.Errorf("expected a preliminary OrderWorkflow with a line and no calls, got %+v", orders.Nodes)
+	}
+	// Charge is found through the call of OrderWorkflow, once the graph is built
+	if len(graph.Nodes) != 3 || len(graph.Nodes["OrderWorkflow"].CallSites) != 1 {
+		t.Errorf("expected the complete graph after the packages, got %+v", graph.Nodes)
+	}
+}
+
+func TestReportProgressWithoutChannel(t *testing.T) {
+	// Must not block or panic when no channel is attached
+	reportProgress(context.Background(), Progress{Stage: StageDone})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	reportProgress(WithProgress(ctx, make(chan Progress)), Progress{Stage: StageDone})
+}
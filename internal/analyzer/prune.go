@@ -0,0 +1,241 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// PruneGraph returns a copy of the graph reduced for drawing. Nodes of hidden types are
+// dropped; the nodes of packages matching a collapse pattern are merged into one "package"
+// node per pattern, which counts the merged nodes by type; and with a minimum fan-out only
+// the nodes calling that many distinct targets are kept, along with the nodes they call.
+// Hiding and collapsing apply before the fan-out is measured. Call sites, parents and entry
+// points are rewritten to match; stats are not recalculated.
+func PruneGraph(graph *TemporalGraph, rootDir string, opts config.PruneOptions) *TemporalGraph {
+	if opts.IsZero() {
+		return graph
+	}
+
+	hidden := make(map[string]bool, len(opts.HideTypes))
+	for _, nodeType := range opts.HideTypes {
+		hidden[nodeType] = true
+	}
+
+	ids := make([]string, 0, len(graph.Nodes))
+	for id := range graph.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	// owner maps the ID of every node still drawn to the ID of the node it is drawn as
+	owner := make(map[string]string, len(graph.Nodes))
+	nodes := make(map[string]*TemporalNode, len(graph.Nodes))
+	for _, id := range ids {
+		node := graph.Nodes[id]
+		if hidden[baseNodeType(node.Type)] {
+			continue
+		}
+		if pattern := collapsePattern(opts.CollapsePackages, rootDir, node); pattern != "" {
+			key := "package:" + pattern
+			group, ok := nodes[key]
+			if !ok {
+				group = &TemporalNode{Name: pattern, Key: key, Type: "package", Package: pattern, Collapsed: make(map[string]int)}
+				nodes[key] = group
+			}
+			group.Collapsed[node.Type]++
+			owner[id] = key
+			continue
+		}
+		copied := *node
+		copied.CallSites, copied.Parents, copied.CalledBy = nil, nil, nil
+		nodes[id] = &copied
+		owner[id] = id
+	}
+
+	// Calls are redirected to the nodes their targets are drawn as; calls within a collapsed
+	// package disappear, and calls from or to one are kept once per target and call type
+	for _, id := range ids {
+		from, ok := owner[id]
+		if !ok {
+			continue
+		}
+		for _, call := range graph.Nodes[id].CallSites {
+			if hidden[baseNodeType(call.TargetType)] {
+				continue
+			}
+			to := call.TargetName
+			if _, exists := graph.Nodes[call.TargetName]; exists {
+				if to, ok = owner[call.TargetName]; !ok {
+					continue
+				}
+			}
+			collapsed := from != id || to != call.TargetName
+			if collapsed && (from == to || hasCallTo(nodes[from].CallSites, to, call.CallType)) {
+				continue
+			}
+			call.TargetName = to
+			nodes[from].CallSites = append(nodes[from].CallSites, call)
+		}
+	}
+
+	if opts.MinFanOut > 0 {
+		keep := make(map[string]bool)
+		for id, node := range nodes {
+			targets := distinctTargets(node.CallSites)
+			if len(targets) >= opts.MinFanOut {
+				keep[id] = true
+				for _, target := range targets {
+					keep[target] = true
+				}
+			}
+		}
+		removed := make(map[string]bool)
+		for id := range nodes {
+			if !keep[id] {
+				removed[id] = true
+				delete(nodes, id)
+			}
+		}
+		for _, node := range nodes {
+			calls := node.CallSites[:0]
+			for _, call := range node.CallSites {
+				if !removed[call.TargetName] {
+					calls = append(calls, call)
+				}
+			}
+			node.CallSites = calls
+		}
+	}
+
+	// Parents are rebuilt from the remaining calls
+	pruned := make([]string, 0, len(nodes))
+	for id := range nodes {
+		pruned = append(pruned, id)
+	}
+	sort.Strings(pruned)
+	for _, id := range pruned {
+		for _, call := range nodes[id].CallSites {
+			target, ok := nodes[call.TargetName]
+			if !ok {
+				continue
+			}
+			if !slices.Contains(target.Parents, id) {
+				target.Parents = append(target.Parents, id)
+			}
+			target.CalledBy = append(target.CalledBy, ParentRef{
+				Name:       id,
+				FilePath:   call.FilePath,
+				LineNumber: call.LineNumber,
+				CallType:   call.CallType,
+			})
+		}
+	}
+
+	result := &TemporalGraph{
+		Nodes:      nodes,
+		Stats:      graph.Stats,
+		Workers:    graph.Workers,
+		FileErrors: graph.FileErrors,
+		Engine:     graph.Engine,
+	}
+	seen := make(map[string]bool)
+	for _, ep := range graph.EntryPoints {
+		workflow, ok := owner[ep.Workflow]
+		if _, drawn := nodes[workflow]; !ok || !drawn {
+			continue
+		}
+		if seen[ep.Trigger+"\x00"+workflow] {
+			continue
+		}
+		seen[ep.Trigger+"\x00"+workflow] = true
+		ep.Workflow = workflow
+		result.EntryPoints = append(result.EntryPoints, ep)
+	}
+	return result
+}
+
+// CollapsedSummary describes the nodes merged into a collapsed package node, e.g.
+// "3 activities, 2 workflows".
+func (n *TemporalNode) CollapsedSummary() string {
+	types := make([]string, 0, len(n.Collapsed))
+	for nodeType := range n.Collapsed {
+		types = append(types, nodeType)
+	}
+	sort.Strings(types)
+
+	parts := make([]string, len(types))
+	for i, nodeType := range types {
+		count := n.Collapsed[nodeType]
+		noun := nodeType
+		if count != 1 {
+			noun = pluralType(nodeType)
+		}
+		parts[i] = fmt.Sprintf("%d %s", count, noun)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pluralType returns the plural of a node type, e.g. activities.
+func pluralType(nodeType string) string {
+	if strings.HasSuffix(nodeType, "y") {
+		return strings.TrimSuffix(nodeType, "y") + "ies"
+	}
+	return nodeType + "s"
+}
+
+// baseNodeType maps call target types and handler types to node types, e.g. child_workflow
+// to workflow.
+func baseNodeType(nodeType string) string {
+	switch nodeType {
+	case "child_workflow":
+		return "workflow"
+	case "local_activity":
+		return "activity"
+	}
+	return strings.TrimSuffix(nodeType, "_handler")
+}
+
+// collapsePattern returns the first collapse pattern matching the package of a node, or "".
+// A trailing "/..." matches a package and its subpackages, like "/**".
+func collapsePattern(patterns []string, rootDir string, node *TemporalNode) string {
+	if node.Unresolved {
+		return ""
+	}
+	for _, pattern := range patterns {
+		glob := pattern
+		if glob == "..." || strings.HasSuffix(glob, "/...") {
+			glob = strings.TrimSuffix(glob, "...") + "**"
+		}
+		if MatchesPackagePattern(glob, rootDir, node.FilePath, node.Package) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// hasCallTo reports whether calls include a call of the given type to target.
+func hasCallTo(calls []CallSite, target, callType string) bool {
+	for _, call := range calls {
+		if call.TargetName == target && call.CallType == callType {
+			return true
+		}
+	}
+	return false
+}
+
+// distinctTargets returns the distinct targets of calls, in call order.
+func distinctTargets(calls []CallSite) []string {
+	var targets []string
+	seen := make(map[string]bool, len(calls))
+	for _, call := range calls {
+		if !seen[call.TargetName] {
+			seen[call.TargetName] = true
+			targets = append(targets, call.TargetName)
+		}
+	}
+	return targets
+}
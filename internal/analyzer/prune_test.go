@@ -0,0 +1,139 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// pruneTestGraph builds a graph of an order workflow calling payment activities, a shipping
+// child workflow and a notification activity.
+func pruneTestGraph() *TemporalGraph {
+	return &TemporalGraph{
+		Nodes: map[string]*TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders", FilePath: "/repo/orders/order.go", CallSites: []CallSite{
+				{TargetName: "Charge", TargetType: "activity", CallType: "activity"},
+				{TargetName: "Refund", TargetType: "activity", CallType: "activity"},
+				{TargetName: "ShippingWorkflow", TargetType: "child_workflow", CallType: "child_workflow"},
+				{TargetName: "Notify", TargetType: "activity", CallType: "activity"},
+			}},
+			"ShippingWorkflow": {Name: "ShippingWorkflow", Type: "workflow", Package: "shipping", FilePath: "/repo/shipping/ship.go", CallSites: []CallSite{
+				{TargetName: "Notify", TargetType: "activity", CallType: "activity"},
+			}},
+			"PaymentWorkflow": {Name: "PaymentWorkflow", Type: "workflow", Package: "payments", FilePath: "/repo/services/payments/workflow.go", CallSites: []CallSite{
+				{TargetName: "Charge", TargetType: "activity", CallType: "activity"},
+			}},
+			"Charge": {Name: "Charge", Type: "activity", Package: "payments", FilePath: "/repo/services/payments/activities.go"},
+			"Refund": {Name: "Refund", Type: "activity", Package: "refunds", FilePath: "/repo/services/payments/refunds/refund.go"},
+			"Notify": {Name: "Notify", Type: "activity", Package: "notify", FilePath: "/repo/notify/notify.go"},
+		},
+		EntryPoints: []EntryPoint{
+			{Kind: "http", Trigger: "POST /payments", Workflow: "PaymentWorkflow"},
+			{Kind: "http", Trigger: "POST /orders", Workflow: "OrderWorkflow"},
+		},
+	}
+}
+
+// prunedCalls returns the call targets of each node in a pruned graph.
+func prunedCalls(graph *TemporalGraph) map[string][]string {
+	calls := make(map[string][]string, len(graph.Nodes))
+	for id, node := range graph.Nodes {
+		calls[id] = []string{}
+		for _, call := range node.CallSites {
+			calls[id] = append(calls[id], call.TargetName)
+		}
+	}
+	return calls
+}
+
+func TestPruneGraph(t *testing.T) {
+	tests := []struct {
+		name string
+		opts config.PruneOptions
+		want map[string][]string
+	}{
+		{
+			name: "hide activities",
+			opts: config.PruneOptions{HideTypes: []string{"activity"}},
+			want: map[string][]string{
+				"OrderWorkflow":    {"ShippingWorkflow"},
+				"ShippingWorkflow": {},
+				"PaymentWorkflow":  {},
+			},
+		},
+		{
+			name: "collapse package",
+			opts: config.PruneOptions{CollapsePackages: []string{"services/payments/..."}},
+			want: map[string][]string{
+				"OrderWorkflow":                 {"package:services/payments/...", "ShippingWorkflow", "Notify"},
+				"ShippingWorkflow":              {"Notify"},
+				"Notify":                        {},
+				"package:services/payments/...": {},
+			},
+		},
+		{
+			name: "min fan-out",
+			opts: config.PruneOptions{MinFanOut: 2},
+			want: map[string][]string{
+				"OrderWorkflow":    {"Charge", "Refund", "ShippingWorkflow", "Notify"},
+				"ShippingWorkflow": {"Notify"},
+				"Charge":           {},
+				"Refund":           {},
+				"Notify":           {},
+			},
+		},
+		{
+			name: "min fan-out after hiding",
+			opts: config.PruneOptions{HideTypes: []string{"activity"}, MinFanOut: 2},
+			want: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := pruneTestGraph()
+			pruned := PruneGraph(graph, "/repo", tt.opts)
+
+			if got := prunedCalls(pruned); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("calls = %v, want %v", got, tt.want)
+			}
+			if got := len(graph.Nodes["OrderWorkflow"].CallSites); got != 4 {
+				t.Errorf("PruneGraph modified the input graph: OrderWorkflow has %d call sites", got)
+			}
+		})
+	}
+}
+
+func TestPruneGraphCollapsedNode(t *testing.T) {
+	pruned := PruneGraph(pruneTestGraph(), "/repo", config.PruneOptions{CollapsePackages: []string{"services/payments/..."}})
+
+	group, ok := pruned.Nodes["package:services/payments/..."]
+	if !ok {
+		t.Fatal("Expected a collapsed package node")
+	}
+	if got, want := group.CollapsedSummary(), "2 activities, 1 workflow"; got != want {
+		t.Errorf("CollapsedSummary() = %q, want %q", got, want)
+	}
+	if want := []string{"OrderWorkflow"}; !reflect.DeepEqual(group.Parents, want) {
+		t.Errorf("Parents = %v, want %v", group.Parents, want)
+	}
+	if got := pruned.Nodes["Notify"].Parents; !reflect.DeepEqual(got, []string{"OrderWorkflow", "ShippingWorkflow"}) {
+		t.Errorf("Notify parents = %v", got)
+	}
+
+	var workflows []string
+	for _, ep := range pruned.EntryPoints {
+		workflows = append(workflows, ep.Workflow)
+	}
+	if want := []string{"package:services/payments/...", "OrderWorkflow"}; !reflect.DeepEqual(workflows, want) {
+		t.Errorf("entry point workflows = %v, want %v", workflows, want)
+	}
+}
+
+func TestPruneGraphUnchanged(t *testing.T) {
+	graph := pruneTestGraph()
+	if PruneGraph(graph, "/repo", config.PruneOptions{}) != graph {
+		t.Error("PruneGraph without options should return the graph itself")
+	}
+}
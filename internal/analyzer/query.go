@@ -0,0 +1,456 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Query is a compiled node filter expression, e.g.
+//
+//	type==workflow && package=~'payments' && fanout>5 && has(signals)
+//
+// Expressions combine comparisons with &&, || and !, and support parentheses.
+// String fields support ==, != and the regex operators =~ and !~; numeric fields
+// support ==, !=, <, <=, > and >=. has(field) is true for non-empty fields.
+// Doc comment annotations are available as string fields prefixed with @, e.g. @owner==team-x.
+// Overlay tags are joined with commas in the tags field, e.g. tags=~'critical'.
+type Query struct {
+	src  string
+	root queryExpr
+}
+
+// queryFieldKind is the value type of a query field.
+type queryFieldKind int
+
+const (
+	queryString queryFieldKind = iota
+	queryNumber
+)
+
+// queryField describes a node field usable in query expressions.
+type queryField struct {
+	kind queryFieldKind
+	str  func(n *TemporalNode) string
+	num  func(n *TemporalNode) float64
+}
+
+// queryFields lists the fields usable in query expressions.
+var queryFields = map[string]queryField{
+	"name":        {kind: queryString, str: func(n *TemporalNode) string { return n.Name }},
+	"type":        {kind: queryString, str: func(n *TemporalNode) string { return n.Type }},
+	"package":     {kind: queryString, str: func(n *TemporalNode) string { return n.Package }},
+	"domain":      {kind: queryString, str: func(n *TemporalNode) string { return n.Domain }},
+	"file":        {kind: queryString, str: func(n *TemporalNode) string { return n.FilePath }},
+	"description": {kind: queryString, str: func(n *TemporalNode) string { return n.Description }},
+	"return_type": {kind: queryString, str: func(n *TemporalNode) string { return n.ReturnType }},
+	"tags":        {kind: queryString, str: func(n *TemporalNode) string { return strings.Join(n.Tags, ",") }},
+	"confidence":  {kind: queryString, str: func(n *TemporalNode) string { return n.DetectionConfidence }},
+
+	"line":           {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(n.LineNumber) }},
+	"fanout":         {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.CallSites)) }},
+	"fanin":          {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.Parents)) }},
+	"call_sites":     {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.CallSites)) }},
+	"parents":        {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.Parents)) }},
+	"internal_calls": {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.InternalCalls)) }},
+	"params":         {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.Parameters)) }},
+	"signals":        {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.Signals)) }},
+	"queries":        {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.Queries)) }},
+	"updates":        {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.Updates)) }},
+	"timers":         {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.Timers)) }},
+	"search_attrs":   {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.SearchAttrs)) }},
+	"versioning":     {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.Versioning)) }},
+	"tests":          {kind: queryNumber, num: func(n *TemporalNode) float64 { return float64(len(n.Tests)) }},
+}
+
+// QueryFieldNames returns the names of the fields usable in query expressions.
+func QueryFieldNames() []string {
+	names := make([]string, 0, len(queryFields))
+	for name := range queryFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseQuery compiles a query expression.
+func ParseQuery(src string) (*Query, error) {
+	tokens, err := lexQuery(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	p := &queryParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("invalid query: unexpected %q at position %d", tok.text, tok.pos)
+	}
+
+	return &Query{src: src, root: root}, nil
+}
+
+// String returns the source of the query.
+func (q *Query) String() string {
+	return q.src
+}
+
+// Match reports whether a node matches the query.
+func (q *Query) Match(n *TemporalNode) bool {
+	if q == nil || n == nil {
+		return q == nil
+	}
+	return q.root.eval(n)
+}
+
+// FilterGraph returns a graph containing only the nodes that match the query.
+// Nodes are shared with the input graph; stats are not recalculated.
+func (q *Query) FilterGraph(graph *TemporalGraph) *TemporalGraph {
+	filtered := &TemporalGraph{
+		Nodes:       make(map[string]*TemporalNode),
+		Stats:       graph.Stats,
+		Workers:     graph.Workers,
+		EntryPoints: graph.EntryPoints,
+		Messages:    graph.Messages,
+		WorkflowIDs: graph.WorkflowIDs,
+		FileErrors:  graph.FileErrors,
+		Engine:      graph.Engine,
+	}
+	for name, node := range graph.Nodes {
+		if q.Match(node) {
+			filtered.Nodes[name] = node
+		}
+	}
+	return filtered
+}
+
+// =============================================================================
+// Expressions
+// =============================================================================
+
+type queryExpr interface {
+	eval(n *TemporalNode) bool
+}
+
+type andExpr struct{ left, right queryExpr }
+type orExpr struct{ left, right queryExpr }
+type notExpr struct{ expr queryExpr }
+
+func (e *andExpr) eval(n *TemporalNode) bool { return e.left.eval(n) && e.right.eval(n) }
+func (e *orExpr) eval(n *TemporalNode) bool  { return e.left.eval(n) || e.right.eval(n) }
+func (e *notExpr) eval(n *TemporalNode) bool { return !e.expr.eval(n) }
+
+// hasExpr is true if the field is non-empty (or non-zero for numeric fields).
+type hasExpr struct{ field queryField }
+
+func (e *hasExpr) eval(n *TemporalNode) bool {
+	if e.field.kind == queryNumber {
+		return e.field.num(n) > 0
+	}
+	return e.field.str(n) != ""
+}
+
+// stringCompareExpr compares a string field with a literal or regex.
+type stringCompareExpr struct {
+	field queryField
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+func (e *stringCompareExpr) eval(n *TemporalNode) bool {
+	v := e.field.str(n)
+	switch e.op {
+	case "==":
+		return v == e.value
+	case "!=":
+		return v != e.value
+	case "=~":
+		return e.re.MatchString(v)
+	case "!~":
+		return !e.re.MatchString(v)
+	}
+	return false
+}
+
+// numberCompareExpr compares a numeric field with a number.
+type numberCompareExpr struct {
+	field queryField
+	op    string
+	value float64
+}
+
+func (e *numberCompareExpr) eval(n *TemporalNode) bool {
+	v := e.field.num(n)
+	switch e.op {
+	case "==":
+		return v == e.value
+	case "!=":
+		return v != e.value
+	case ">":
+		return v > e.value
+	case ">=":
+		return v >= e.value
+	case "<":
+		return v < e.value
+	case "<=":
+		return v <= e.value
+	}
+	return false
+}
+
+// =============================================================================
+// Lexer
+// =============================================================================
+
+type queryTokenKind int
+
+const (
+	tokEOF queryTokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+// queryOperators lists operators, longest first so that ">=" wins over ">".
+var queryOperators = []string{"&&", "||", "==", "!=", "=~", "!~", ">=", "<=", ">", "<", "!"}
+
+func lexQuery(src string) ([]queryToken, error) {
+	var tokens []queryToken
+	i := 0
+	for i < len(src) {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, queryToken{kind: tokLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, queryToken{kind: tokRParen, text: ")", pos: i})
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexRune(src[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, queryToken{kind: tokString, text: src[i+1 : i+1+end], pos: i})
+			i += end + 2
+		case unicode.IsDigit(c) || (c == '-' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1]))):
+			start := i
+			i++
+			for i < len(src) && (unicode.IsDigit(rune(src[i])) || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: tokNumber, text: src[start:i], pos: start})
+		case unicode.IsLetter(c) || c == '_' || c == '*' || c == '@':
+			start := i
+			i++
+			for i < len(src) && isQueryIdentChar(rune(src[i])) {
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: tokIdent, text: src[start:i], pos: start})
+		default:
+			matched := false
+			for _, op := range queryOperators {
+				if strings.HasPrefix(src[i:], op) {
+					tokens = append(tokens, queryToken{kind: tokOp, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+		}
+	}
+	tokens = append(tokens, queryToken{kind: tokEOF, pos: len(src)})
+	return tokens, nil
+}
+
+// isQueryIdentChar reports whether c can appear in a bare identifier or value (e.g. *Acts.Charge).
+func isQueryIdentChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '.' || c == '*' || c == '/' || c == '-' || c == ':'
+}
+
+// =============================================================================
+// Parser
+// =============================================================================
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *queryParser) parseOr() (queryExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOp && p.peek().text == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOp && p.peek().text == "&&" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (queryExpr, error) {
+	if tok := p.peek(); tok.kind == tokOp && tok.text == "!" {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryExpr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, fmt.Errorf("expected ')' at position %d", closing.pos)
+		}
+		return expr, nil
+	case tokIdent:
+		if tok.text == "has" && p.peek().kind == tokLParen {
+			return p.parseHas()
+		}
+		return p.parseComparison(tok)
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+}
+
+func (p *queryParser) parseHas() (queryExpr, error) {
+	p.next() // (
+	name := p.next()
+	if name.kind != tokIdent {
+		return nil, fmt.Errorf("expected field name in has() at position %d", name.pos)
+	}
+	field, err := lookupQueryField(name)
+	if err != nil {
+		return nil, err
+	}
+	if closing := p.next(); closing.kind != tokRParen {
+		return nil, fmt.Errorf("expected ')' at position %d", closing.pos)
+	}
+	return &hasExpr{field: field}, nil
+}
+
+func (p *queryParser) parseComparison(name queryToken) (queryExpr, error) {
+	field, err := lookupQueryField(name)
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.next()
+	if op.kind != tokOp || op.text == "&&" || op.text == "||" || op.text == "!" {
+		return nil, fmt.Errorf("expected comparison operator after %q at position %d", name.text, op.pos)
+	}
+
+	value := p.next()
+	if value.kind != tokIdent && value.kind != tokString && value.kind != tokNumber {
+		return nil, fmt.Errorf("expected value after %q at position %d", op.text, value.pos)
+	}
+
+	if field.kind == queryNumber {
+		if value.kind != tokNumber {
+			return nil, fmt.Errorf("field %q is numeric, got %q at position %d", name.text, value.text, value.pos)
+		}
+		if op.text == "=~" || op.text == "!~" {
+			return nil, fmt.Errorf("operator %q is not supported for numeric field %q", op.text, name.text)
+		}
+		num, err := strconv.ParseFloat(value.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", value.text, value.pos)
+		}
+		return &numberCompareExpr{field: field, op: op.text, value: num}, nil
+	}
+
+	expr := &stringCompareExpr{field: field, op: op.text, value: value.text}
+	switch op.text {
+	case "==", "!=":
+	case "=~", "!~":
+		re, err := regexp.Compile(value.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %q: %w", value.text, err)
+		}
+		expr.re = re
+	default:
+		return nil, fmt.Errorf("operator %q is not supported for string field %q", op.text, name.text)
+	}
+	return expr, nil
+}
+
+func lookupQueryField(tok queryToken) (queryField, error) {
+	if key, ok := strings.CutPrefix(tok.text, "@"); ok && key != "" {
+		return annotationQueryField(key), nil
+	}
+	field, ok := queryFields[tok.text]
+	if !ok {
+		return queryField{}, fmt.Errorf("unknown field %q at position %d (available: %s)",
+			tok.text, tok.pos, strings.Join(QueryFieldNames(), ", "))
+	}
+	return field, nil
+}
+
+// annotationQueryField returns a string field reading the given doc comment annotation.
+// has(@key) is true only for annotations with a value.
+func annotationQueryField(key string) queryField {
+	return queryField{kind: queryString, str: func(n *TemporalNode) string { return n.Annotations[key] }}
+}
@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestParseQueryMatch(t *testing.T) {
+	order := &TemporalNode{
+		Name:      "OrderWorkflow",
+		Type:      "workflow",
+		Package:   "payments",
+		Domain:    "Payments",
+		FilePath:  "internal/payments/order.go",
+		CallSites: make([]CallSite, 6),
+		Signals:   []SignalDef{{Name: "cancel"}},
+		Annotations: map[string]string{
+			"owner":              "team-payments",
+			"temporal:taskqueue": "payments",
+		},
+	}
+	charge := &TemporalNode{
+		Name:     "*Activities.Charge",
+		Type:     "activity",
+		Package:  "payments",
+		FilePath: "internal/payments/activities.go",
+		Parents:  []string{"OrderWorkflow"},
+	}
+
+	tests := []struct {
+		query string
+		node  *TemporalNode
+		want  bool
+	}{
+		{"type==workflow && package=~'payments' && fanout>5 && has(signals)", order, true},
+		{"type==workflow && fanout>6", order, false},
+		{"type==workflow || fanin>=1", charge, true},
+		{"!(type==workflow)", charge, true},
+		{"!has(signals)", order, false},
+		{`name=="*Activities.Charge"`, charge, true},
+		{"name==*Activities.Charge", charge, true},
+		{"file=~internal/payments && type!=activity", charge, false},
+		{"package!~'^ship'", order, true},
+		{"(type==activity || type==workflow) && has(parents)", order, false},
+		{"signals==1 && line<=0", order, true},
+		{"@owner==team-payments && @temporal:taskqueue==payments", order, true},
+		{"has(@owner)", charge, false},
+		{"type==activity && !has(@sla)", charge, true},
+		{"domain==Payments", order, true},
+		{"has(domain)", charge, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) failed: %v", tt.query, err)
+			}
+			if got := q.Match(tt.node); got != tt.want {
+				t.Errorf("Match(%s) = %v, want %v", tt.node.Name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	queries := []string{
+		"",
+		"type==",
+		"unknown==x",
+		"fanout>many",
+		"name>5",
+		"fanout=~5",
+		"name=~'['",
+		"type==workflow &&",
+		"(type==workflow",
+		"type==workflow)",
+		"has(nope)",
+		"name=='unterminated",
+		"type==workflow # comment",
+	}
+
+	for _, query := range queries {
+		if _, err := ParseQuery(query); err == nil {
+			t.Errorf("ParseQuery(%q) expected error", query)
+		}
+	}
+}
+
+func TestQueryFilterGraph(t *testing.T) {
+	graph := &TemporalGraph{
+		Nodes: map[string]*TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow"},
+			"Charge":        {Name: "Charge", Type: "activity"},
+		},
+	}
+
+	q, err := ParseQuery("type==activity")
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+
+	filtered := q.FilterGraph(graph)
+	if len(filtered.Nodes) != 1 || filtered.Nodes["Charge"] == nil {
+		t.Errorf("Expected only Charge, got %v", filtered.Nodes)
+	}
+	if len(graph.Nodes) != 2 {
+		t.Error("FilterGraph should not modify the input graph")
+	}
+}
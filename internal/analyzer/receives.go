@@ -0,0 +1,245 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// callContext describes where a call sits within a function body.
+type callContext struct {
+	// loopLine is the line of the innermost enclosing for or range loop (0 if none)
+	loopLine int
+	// inSelector is set inside a callback registered with a workflow.Selector
+	inSelector bool
+	// goroutine is the innermost enclosing function run with workflow.Go or workflow.GoNamed
+	goroutine *ast.FuncLit
+}
+
+// selectorMethods are the workflow.Selector methods that register callbacks.
+var selectorMethods = map[string]bool{"AddReceive": true, "AddFuture": true, "AddDefault": true}
+
+// callContexts returns the context of every call in body.
+func callContexts(body *ast.BlockStmt, fset *token.FileSet) map[*ast.CallExpr]callContext {
+	contexts := make(map[*ast.CallExpr]callContext)
+	callbacks := make(map[*ast.FuncLit]bool)
+	goroutines := make(map[*ast.FuncLit]bool)
+	var stack []ast.Node
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && selectorMethods[sel.Sel.Name] {
+			for _, arg := range call.Args {
+				if lit, ok := arg.(*ast.FuncLit); ok {
+					callbacks[lit] = true
+				}
+			}
+		}
+		if (isPkgCall(call, "workflow", "Go") || isPkgCall(call, "workflow", "GoNamed")) && len(call.Args) > 0 {
+			if lit, ok := call.Args[len(call.Args)-1].(*ast.FuncLit); ok {
+				goroutines[lit] = true
+			}
+		}
+
+		var cc callContext
+		for i := len(stack) - 2; i >= 0; i-- {
+			switch node := stack[i].(type) {
+			case *ast.ForStmt, *ast.RangeStmt:
+				if cc.loopLine == 0 {
+					cc.loopLine = lineOf(fset, node.Pos())
+				}
+			case *ast.FuncLit:
+				cc.inSelector = cc.inSelector || callbacks[node]
+				if cc.goroutine == nil && goroutines[node] {
+					cc.goroutine = node
+				}
+			}
+		}
+		contexts[call] = cc
+		return true
+	})
+
+	return contexts
+}
+
+// extractSignalReceives finds receives from signal channels: channels returned by
+// workflow.GetSignalChannel, directly or through a variable, and channels handed to
+// Selector callbacks.
+func (e *callExtractor) extractSignalReceives(body *ast.BlockStmt, fset *token.FileSet, contexts map[*ast.CallExpr]callContext) []SignalReceive {
+	// Signal channel variables and the signals they receive
+	channels := make(map[string]string)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				if name, ok := e.signalChannelName(rhs); ok && i < len(node.Lhs) {
+					if ident, ok := node.Lhs[i].(*ast.Ident); ok {
+						channels[ident.Name] = name
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for i, value := range node.Values {
+				if name, ok := e.signalChannelName(value); ok && i < len(node.Names) {
+					channels[node.Names[i].Name] = name
+				}
+			}
+		}
+		return true
+	})
+
+	var receives []SignalReceive
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !strings.HasPrefix(sel.Sel.Name, "Receive") {
+			return true
+		}
+
+		cc := contexts[call]
+		receive := SignalReceive{
+			LineNumber: lineOf(fset, call.Pos()),
+			Blocking:   sel.Sel.Name == "Receive",
+			InSelector: cc.inSelector,
+			LoopLine:   cc.loopLine,
+		}
+		if ident, ok := sel.X.(*ast.Ident); ok {
+			name, known := channels[ident.Name]
+			if !known && !cc.inSelector {
+				return true
+			}
+			receive.Channel, receive.Signal = ident.Name, name
+		} else if name, ok := e.signalChannelName(sel.X); ok {
+			receive.Signal = name
+		} else {
+			return true
+		}
+
+		receives = append(receives, receive)
+		return true
+	})
+
+	return receives
+}
+
+// markUnreceivedChannels records the variables of the GetSignalChannel calls of signals,
+// keyed by index, and marks the channels that are never received from: discarded, or held
+// in a variable that is neither received from nor handed on, e.g. to a Selector or a helper.
+func markUnreceivedChannels(body *ast.BlockStmt, signals []SignalDef, channelCalls map[int]*ast.CallExpr) {
+	if len(channelCalls) == 0 {
+		return
+	}
+	parents := make(map[ast.Node]ast.Node)
+	var stack []ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		if len(stack) > 0 {
+			parents[n] = stack[len(stack)-1]
+		}
+		stack = append(stack, n)
+		return true
+	})
+
+	for i, call := range channelCalls {
+		switch parent := parents[call].(type) {
+		case *ast.ExprStmt:
+			signals[i].Unreceived = true
+		case *ast.AssignStmt:
+			for j, rhs := range parent.Rhs {
+				if rhs != call || j >= len(parent.Lhs) {
+					continue
+				}
+				if ident, ok := parent.Lhs[j].(*ast.Ident); ok {
+					signals[i].Channel = ident.Name
+					signals[i].Unreceived = ident.Name == "_" || !channelUsed(ident, parents)
+				}
+			}
+		case *ast.ValueSpec:
+			for j, value := range parent.Values {
+				if value == call && j < len(parent.Names) {
+					signals[i].Channel = parent.Names[j].Name
+					signals[i].Unreceived = parent.Names[j].Name == "_" || !channelUsed(parent.Names[j], parents)
+				}
+			}
+		}
+	}
+}
+
+// channelUsed reports whether the channel variable declared by decl is received from or
+// handed on anywhere in the function. Len and Name calls, and assignments to _, don't count.
+func channelUsed(decl *ast.Ident, parents map[ast.Node]ast.Node) bool {
+	for n, parent := range parents {
+		ident, ok := n.(*ast.Ident)
+		if !ok || ident == decl || ident.Name != decl.Name {
+			continue
+		}
+		switch p := parent.(type) {
+		case *ast.SelectorExpr:
+			if p.X == ident && strings.HasPrefix(p.Sel.Name, "Receive") {
+				return true
+			}
+		case *ast.AssignStmt:
+			blank := true
+			for _, lhs := range p.Lhs {
+				if id, ok := lhs.(*ast.Ident); !ok || id.Name != "_" {
+					blank = false
+				}
+			}
+			if !blank && isRHS(p, ident) {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// isRHS reports whether expr is assigned by assign, rather than assigned to.
+func isRHS(assign *ast.AssignStmt, expr ast.Expr) bool {
+	for _, rhs := range assign.Rhs {
+		if rhs == expr {
+			return true
+		}
+	}
+	return false
+}
+
+// signalChannelName returns the signal name of a workflow.GetSignalChannel call.
+func (e *callExtractor) signalChannelName(expr ast.Expr) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "GetSignalChannel" {
+		return "", false
+	}
+	if ident, ok := sel.X.(*ast.Ident); !ok || ident.Name != "workflow" {
+		return "", false
+	}
+	return e.extractSignalChannel(call, 0).Name, true
+}
+
+// lineOf returns the line of pos, or the raw offset without a file set.
+func lineOf(fset *token.FileSet, pos token.Pos) int {
+	if fset == nil {
+		return int(pos)
+	}
+	return fset.Position(pos).Line
+}
@@ -0,0 +1,174 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func extractTestFunc(t *testing.T, code, name string) *TemporalNodeDetails {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse code: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	e := NewCallExtractor(logger).(*callExtractor)
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == name {
+			details, err := e.ExtractAllTemporalInfo(context.Background(), fn, "test.go", fset)
+			if err != nil {
+				t.Fatalf("ExtractAllTemporalInfo failed: %v", err)
+			}
+			return details
+		}
+	}
+	t.Fatalf("Function %s not found", name)
+	return nil
+}
+
+func TestExtractSignalReceivesInLoop(t *testing.T) {
+	code := `package test
+
+func PollWorkflow(ctx workflow.Context) error {
+	approvals := workflow.GetSignalChannel(ctx, "approve")
+	for {
+		workflow.Sleep(ctx, time.Minute)
+		var v string
+		approvals.Receive(ctx, &v)
+		approvals.ReceiveAsync(&v)
+	}
+	workflow.Sleep(ctx, time.Hour)
+	return nil
+}
+`
+	details := extractTestFunc(t, code, "PollWorkflow")
+
+	if len(details.Timers) != 2 {
+		t.Fatalf("Expected 2 timers, got %d", len(details.Timers))
+	}
+	if details.Timers[0].LoopLine != 5 {
+		t.Errorf("Timers[0].LoopLine = %d, want 5", details.Timers[0].LoopLine)
+	}
+	if details.Timers[1].LoopLine != 0 {
+		t.Errorf("Timers[1].LoopLine = %d, want 0", details.Timers[1].LoopLine)
+	}
+
+	want := []SignalReceive{
+		{Signal: "approve", Channel: "approvals", LineNumber: 8, Blocking: true, LoopLine: 5},
+		{Signal: "approve", Channel: "approvals", LineNumber: 9, Blocking: false, LoopLine: 5},
+	}
+	if len(details.SignalReceives) != len(want) {
+		t.Fatalf("Expected %d receives, got %+v", len(want), details.SignalReceives)
+	}
+	for i, w := range want {
+		if details.SignalReceives[i] != w {
+			t.Errorf("SignalReceives[%d] = %+v, want %+v", i, details.SignalReceives[i], w)
+		}
+	}
+}
+
+func TestExtractSignalReceivesSelector(t *testing.T) {
+	code := `package test
+
+func SelectWorkflow(ctx workflow.Context) error {
+	selector := workflow.NewSelector(ctx)
+	selector.AddReceive(workflow.GetSignalChannel(ctx, "cancel"), func(c workflow.ReceiveChannel, more bool) {
+		c.Receive(ctx, nil)
+	})
+	selector.Select(ctx)
+	workflow.GetSignalChannel(ctx, "done").Receive(ctx, nil)
+	other.Receive(ctx, nil)
+	return nil
+}
+`
+	details := extractTestFunc(t, code, "SelectWorkflow")
+
+	want := []SignalReceive{
+		{Channel: "c", LineNumber: 6, Blocking: true, InSelector: true},
+		{Signal: "done", LineNumber: 9, Blocking: true},
+	}
+	if len(details.SignalReceives) != len(want) {
+		t.Fatalf("Expected %d receives, got %+v", len(want), details.SignalReceives)
+	}
+	for i, w := range want {
+		if details.SignalReceives[i] != w {
+			t.Errorf("SignalReceives[%d] = %+v, want %+v", i, details.SignalReceives[i], w)
+		}
+	}
+}
+
+func TestExtractParallelCalls(t *testing.T) {
+	code := `package test
+
+func OrderWorkflow(ctx workflow.Context) error {
+	if err := workflow.ExecuteActivity(ctx, Reserve).Get(ctx, nil); err != nil {
+		return err
+	}
+	charge := workflow.ExecuteActivity(ctx, Charge)
+	receipt := workflow.ExecuteActivity(ctx, SendReceipt)
+	charge.Get(ctx, nil)
+	receipt.Get(ctx, nil)
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		workflow.ExecuteActivity(ctx, Audit).Get(ctx, nil)
+	})
+	ship := workflow.ExecuteChildWorkflow(ctx, ShippingWorkflow)
+	ship.Get(ctx, nil)
+	workflow.ExecuteActivity(ctx, Notify).Get(ctx, nil)
+	return nil
+}
+`
+	details := extractTestFunc(t, code, "OrderWorkflow")
+
+	want := map[string]bool{
+		"Reserve":          false,
+		"Charge":           true,
+		"SendReceipt":      false,
+		"Audit":            true,
+		"ShippingWorkflow": false,
+		"Notify":           false,
+	}
+	if len(details.CallSites) != len(want) {
+		t.Fatalf("Expected %d call sites, got %d", len(want), len(details.CallSites))
+	}
+	for _, call := range details.CallSites {
+		if call.Parallel != want[call.TargetName] {
+			t.Errorf("%s: Parallel = %v, want %v", call.TargetName, call.Parallel, want[call.TargetName])
+		}
+	}
+}
+
+func TestMarkUnreceivedChannels(t *testing.T) {
+	code := `package test
+
+func ChannelsWorkflow(ctx workflow.Context) error {
+	workflow.GetSignalChannel(ctx, "dropped")
+	_ = workflow.GetSignalChannel(ctx, "blank")
+	unread := workflow.GetSignalChannel(ctx, "unread")
+	_ = unread.Len()
+	approvals := workflow.GetSignalChannel(ctx, "approve")
+	selector := workflow.NewSelector(ctx)
+	selector.AddReceive(approvals, nil)
+	cancels := workflow.GetSignalChannel(ctx, "cancel")
+	watch(ctx, cancels)
+	return workflow.Sleep(ctx, time.Hour)
+}
+`
+	details := extractTestFunc(t, code, "ChannelsWorkflow")
+
+	want := map[string]bool{"dropped": true, "blank": true, "unread": true, "approve": false, "cancel": false}
+	if len(details.Signals) != len(want) {
+		t.Fatalf("Expected %d signals, got %+v", len(want), details.Signals)
+	}
+	for _, signal := range details.Signals {
+		if signal.Unreceived != want[signal.Name] {
+			t.Errorf("Signal %q Unreceived = %v, want %v", signal.Name, signal.Unreceived, want[signal.Name])
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
@@ -25,6 +26,15 @@ type RegistrationInfo struct {
 	// RegisteredTypes maps type names to their registration type ("activity" or "workflow").
 	// When a struct is registered, all its exported methods become activities/workflows.
 	RegisteredTypes map[string]string
+
+	// selectorNames maps the bare names of functions registered as pkg.Function or
+	// receiver.Method to their registration type.
+	selectorNames map[string]string
+
+	// aliases maps the bare names of functions, and the names of struct types, registered
+	// with RegisterOptions{Name: ...} to the names they are registered under. Names of
+	// struct registrations prefix the names of their methods.
+	aliases map[string][]string
 }
 
 // Registration holds details about a single registration call.
@@ -35,9 +45,10 @@ type Registration struct {
 	LineNumber int
 	IsStruct   bool   // True if this is a struct registration (all methods)
 	TypeName   string // For struct registrations, the type name
+	Alias      string // Name set with RegisterOptions{Name: ...}, or for structs the method name prefix
 }
 
-// registrationScanner scans for worker.Register* calls.
+// registrationScanner scans for worker.Register* calls, and Register* calls on workers.
 type registrationScanner struct {
 	logger *slog.Logger
 }
@@ -59,6 +70,7 @@ func (s *registrationScanner) ScanDirectory(ctx context.Context, rootDir string,
 
 	fset := token.NewFileSet()
 
+	modules := NewModuleFilter(rootDir, opts)
 	err := filepath.Walk(rootDir, func(path string, fileInfo os.FileInfo, err error) error {
 		if err != nil {
 			s.logger.Warn("Error accessing path during registration scan", "path", path, "error", err)
@@ -72,10 +84,11 @@ func (s *registrationScanner) ScanDirectory(ctx context.Context, rootDir string,
 		}
 
 		if fileInfo.IsDir() {
-			for _, excludeDir := range opts.ExcludeDirs {
-				if fileInfo.Name() == excludeDir {
-					return filepath.SkipDir
-				}
+			if opts.ExcludesDir(fileInfo.Name()) {
+				return filepath.SkipDir
+			}
+			if modules.SkipDir(path) {
+				return filepath.SkipDir
 			}
 			return nil
 		}
@@ -84,7 +97,7 @@ func (s *registrationScanner) ScanDirectory(ctx context.Context, rootDir string,
 			return nil
 		}
 
-		if !opts.IncludeTests && strings.HasSuffix(path, "_test.go") {
+		if !opts.IncludeTests && IsTestFile(path) {
 			return nil
 		}
 
@@ -96,7 +109,10 @@ func (s *registrationScanner) ScanDirectory(ctx context.Context, rootDir string,
 		}
 
 		// Scan for registration calls
-		s.scanFile(ctx, file, fset, path, info)
+		if err := recovered(func() { s.scanFile(ctx, file, fset, path, info) }); err != nil {
+			s.logger.Warn("Error scanning file for registrations", "path", path, "error", err)
+			recordFileError(ctx, StageRegistrations, path, err)
+		}
 
 		return nil
 	})
@@ -112,8 +128,10 @@ func (s *registrationScanner) ScanDirectory(ctx context.Context, rootDir string,
 	return info, nil
 }
 
-// scanFile scans a single file for registration calls.
+// scanFile scans a single file for registration calls, made on worker or on a worker
+// variable of the file, such as w in w := worker.New(...).
 func (s *registrationScanner) scanFile(ctx context.Context, file *ast.File, fset *token.FileSet, filePath string, info *RegistrationInfo) {
+	workerVars := workerVariables(file)
 	ast.Inspect(file, func(n ast.Node) bool {
 		select {
 		case <-ctx.Done():
@@ -137,7 +155,7 @@ func (s *registrationScanner) scanFile(ctx context.Context, file *ast.File, fset
 			return true
 		}
 
-		if ident.Name != "worker" {
+		if ident.Name != "worker" && !workerVars[ident.Name] {
 			return true
 		}
 
@@ -154,6 +172,52 @@ func (s *registrationScanner) scanFile(ctx context.Context, file *ast.File, fset
 	})
 }
 
+// workerVariables returns the names of the variables and parameters of a file that hold a
+// worker: assigned from worker.New, or declared as worker.Worker or worker.Registry.
+func workerVariables(file *ast.File) map[string]bool {
+	vars := make(map[string]bool)
+	isWorkerType := func(expr ast.Expr) bool {
+		sel, ok := expr.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		return ok && pkg.Name == "worker" && (sel.Sel.Name == "Worker" || sel.Sel.Name == "Registry")
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				call, ok := rhs.(*ast.CallExpr)
+				if !ok || !isPkgCall(call, "worker", "New") || i >= len(node.Lhs) {
+					continue
+				}
+				if ident, ok := node.Lhs[i].(*ast.Ident); ok {
+					vars[ident.Name] = true
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if isWorkerType(node.Type) {
+					vars[name.Name] = true
+				} else if i < len(node.Values) {
+					if call, ok := node.Values[i].(*ast.CallExpr); ok && isPkgCall(call, "worker", "New") {
+						vars[name.Name] = true
+					}
+				}
+			}
+		case *ast.Field:
+			if isWorkerType(node.Type) {
+				for _, name := range node.Names {
+					vars[name.Name] = true
+				}
+			}
+		}
+		return true
+	})
+	return vars
+}
+
 // extractRegistration extracts registration info from a Register* call.
 func (s *registrationScanner) extractRegistration(call *ast.CallExpr, filePath string, lineNum int, regType string, info *RegistrationInfo) {
 	if len(call.Args) == 0 {
@@ -173,6 +237,9 @@ func (s *registrationScanner) extractRegistration(call *ast.CallExpr, filePath s
 		FilePath:   filePath,
 		LineNumber: lineNum,
 	}
+	if len(call.Args) > 1 {
+		reg.Alias = registerOptionsName(call.Args[1])
+	}
 
 	switch expr := arg.(type) {
 	case *ast.Ident:
@@ -189,6 +256,10 @@ func (s *registrationScanner) extractRegistration(call *ast.CallExpr, filePath s
 		} else {
 			reg.Name = expr.Sel.Name
 		}
+		if info.selectorNames == nil {
+			info.selectorNames = make(map[string]string)
+		}
+		info.selectorNames[expr.Sel.Name] = regType
 		s.addRegistration(reg, info)
 
 	case *ast.UnaryExpr:
@@ -254,6 +325,19 @@ func (s *registrationScanner) addRegistration(reg *Registration, info *Registrat
 		info.Workflows[reg.Name] = reg
 	}
 
+	if reg.Alias != "" {
+		key := reg.Name
+		if reg.IsStruct && reg.TypeName != "" {
+			key = reg.TypeName
+		} else if i := strings.LastIndex(key, "."); i >= 0 {
+			key = key[i+1:]
+		}
+		if info.aliases == nil {
+			info.aliases = make(map[string][]string)
+		}
+		info.aliases[key] = append(info.aliases[key], reg.Alias)
+	}
+
 	s.logger.Debug("Found registration",
 		"type", reg.Type,
 		"name", reg.Name,
@@ -262,6 +346,46 @@ func (s *registrationScanner) addRegistration(reg *Registration, info *Registrat
 		"line", reg.LineNumber)
 }
 
+// registerOptionsName returns the Name of the activity.RegisterOptions or workflow.RegisterOptions
+// literal passed to a Register*WithOptions call, or "" if it sets none.
+func registerOptionsName(expr ast.Expr) string {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return ""
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Name" {
+			if value, ok := kv.Value.(*ast.BasicLit); ok && value.Kind == token.STRING {
+				name, err := strconv.Unquote(value.Value)
+				if err == nil {
+					return name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// RegisteredNames returns the names a function or method is registered under with
+// RegisterOptions{Name: ...}: the name itself for function registrations, and the prefix
+// followed by the method name for methods of struct registrations.
+func (info *RegistrationInfo) RegisteredNames(funcName string, receiverType string) []string {
+	names := append([]string(nil), info.aliases[funcName]...)
+	if receiverType != "" {
+		for _, prefix := range info.aliases[strings.TrimPrefix(receiverType, "*")] {
+			names = append(names, prefix+funcName)
+		}
+	}
+	return names
+}
+
 // IsRegisteredActivity checks if a function name is registered as an activity.
 // It checks both direct function registrations and method registrations via struct types.
 func (info *RegistrationInfo) IsRegisteredActivity(funcName string, receiverType string) bool {
@@ -269,6 +393,9 @@ func (info *RegistrationInfo) IsRegisteredActivity(funcName string, receiverType
 	if _, ok := info.Activities[funcName]; ok {
 		return true
 	}
+	if regType := info.selectorNames[funcName]; regType == "activity" || regType == "local_activity" {
+		return true
+	}
 
 	// Check if the receiver type is registered as an activity struct
 	if receiverType != "" {
@@ -285,7 +412,7 @@ func (info *RegistrationInfo) IsRegisteredActivity(funcName string, receiverType
 // IsRegisteredWorkflow checks if a function name is registered as a workflow.
 func (info *RegistrationInfo) IsRegisteredWorkflow(funcName string) bool {
 	_, ok := info.Workflows[funcName]
-	return ok
+	return ok || info.selectorNames[funcName] == "workflow"
 }
 
 // IsRegisteredType checks if a type name is registered (for struct registrations).
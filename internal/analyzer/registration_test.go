@@ -3,9 +3,11 @@ package analyzer
 import (
 	"context"
 	"go/ast"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
@@ -151,6 +153,57 @@ func main() {
 	}
 }
 
+func TestScanDirectoryWithWorkerVariables(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create a file registering on workers held in variables and parameters
+	content := `package main
+
+import (
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+)
+
+func MyActivity() error { return nil }
+
+func MyWorkflow() error { return nil }
+
+func OtherActivity() error { return nil }
+
+func register(r worker.Registry) {
+	r.RegisterWorkflow(MyWorkflow)
+}
+
+func main() {
+	var c client.Client
+	w := worker.New(c, "queue", worker.Options{})
+	w.RegisterActivity(MyActivity)
+	registry.RegisterActivity(OtherActivity)
+}
+`
+	file := filepath.Join(tmpDir, "main.go")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	info, err := NewRegistrationScanner(logger).ScanDirectory(context.Background(), tmpDir, config.AnalysisOptions{})
+	if err != nil {
+		t.Fatalf("ScanDirectory failed: %v", err)
+	}
+
+	if reg, ok := info.Activities["MyActivity"]; !ok || reg.LineNumber != 21 {
+		t.Errorf("Expected MyActivity registered on w at line 21, got %+v", reg)
+	}
+	if _, ok := info.Workflows["MyWorkflow"]; !ok {
+		t.Error("Expected to find MyWorkflow registered on the worker.Registry parameter")
+	}
+	// registry is not known to hold a worker
+	if _, ok := info.Activities["OtherActivity"]; ok {
+		t.Error("Expected OtherActivity registered on an unknown variable to be ignored")
+	}
+}
+
 func TestScanDirectoryWithOptionsRegistration(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -197,6 +250,7 @@ func TestIsRegisteredActivity(t *testing.T) {
 		RegisteredTypes: map[string]string{
 			"MyActivities": "activity",
 		},
+		selectorNames: map[string]string{"ChargeCard": "activity", "OrderWorkflow": "workflow"},
 	}
 
 	tests := []struct {
@@ -206,6 +260,8 @@ func TestIsRegisteredActivity(t *testing.T) {
 		expected     bool
 	}{
 		{"direct registration", "DirectActivity", "", true},
+		{"registered from another package", "ChargeCard", "", true},
+		{"workflow registered from another package", "OrderWorkflow", "", false},
 		{"struct method with matching type", "SendEmail", "MyActivities", true},
 		{"struct method with pointer type", "SendEmail", "*MyActivities", true},
 		{"unregistered function", "UnknownFunc", "", false},
@@ -228,6 +284,7 @@ func TestIsRegisteredWorkflow(t *testing.T) {
 		Workflows: map[string]*Registration{
 			"MyWorkflow": {Name: "MyWorkflow", Type: "workflow"},
 		},
+		selectorNames: map[string]string{"OrderWorkflow": "workflow"},
 	}
 
 	tests := []struct {
@@ -236,6 +293,7 @@ func TestIsRegisteredWorkflow(t *testing.T) {
 		expected bool
 	}{
 		{"registered workflow", "MyWorkflow", true},
+		{"registered from another package", "OrderWorkflow", true},
 		{"unregistered workflow", "UnknownWorkflow", false},
 	}
 
@@ -458,3 +516,104 @@ func main() {
 		t.Error("Expected Workflows to be registered via &Type{}")
 	}
 }
+
+func TestRegisteredNames(t *testing.T) {
+	dir := writeTree(t, map[string]string{"main.go": `package main
+
+import (
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func register(worker worker.Worker) {
+	worker.RegisterActivityWithOptions(Charge, activity.RegisterOptions{Name: "v2.Charge"})
+	worker.RegisterActivityWithOptions(&Activities{}, activity.RegisterOptions{Name: "billing."})
+	worker.RegisterWorkflowWithOptions(OrderWorkflow, workflow.RegisterOptions{Name: "orders"})
+	worker.RegisterActivity(Refund)
+}
+`})
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	info, err := NewRegistrationScanner(logger).ScanDirectory(context.Background(), dir, config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("ScanDirectory failed: %v", err)
+	}
+
+	tests := []struct {
+		funcName, receiver string
+		want               []string
+	}{
+		{"Charge", "", []string{"v2.Charge"}},
+		{"Invoice", "*Activities", []string{"billing.Invoice"}},
+		{"OrderWorkflow", "", []string{"orders"}},
+		{"Refund", "", nil},
+	}
+	for _, tt := range tests {
+		if got := info.RegisteredNames(tt.funcName, tt.receiver); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("RegisteredNames(%q, %q) = %v, want %v", tt.funcName, tt.receiver, got, tt.want)
+		}
+	}
+	if !info.IsRegisteredActivity("Charge", "") {
+		t.Error("Expected Charge to be registered as an activity")
+	}
+}
+
+func TestAnalyzeResolvesRegisteredNames(t *testing.T) {
+	dir := writeTree(t, map[string]string{"billing.go": `package billing
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+type Activities struct{}
+
+func (a *Activities) Invoice(ctx context.Context) error { return nil }
+
+func Charge(ctx context.Context) error { return nil }
+
+func BillingWorkflow(ctx workflow.Context) error {
+	if err := workflow.ExecuteActivity(ctx, "v2.Charge").Get(ctx, nil); err != nil {
+		return err
+	}
+	if err := workflow.ExecuteActivity(ctx, "billing.Invoice").Get(ctx, nil); err != nil {
+		return err
+	}
+	return workflow.ExecuteActivity(ctx, "Unknown").Get(ctx, nil)
+}
+
+func register(worker worker.Worker) {
+	worker.RegisterWorkflow(BillingWorkflow)
+	worker.RegisterActivityWithOptions(Charge, activity.RegisterOptions{Name: "v2.Charge"})
+	worker.RegisterActivityWithOptions(&Activities{}, activity.RegisterOptions{Name: "billing."})
+}
+`})
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	wf, ok := graph.Nodes["BillingWorkflow"]
+	if !ok || len(wf.CallSites) != 3 {
+		t.Fatalf("Expected BillingWorkflow with three calls, got %+v", wf)
+	}
+	for i, want := range []string{"Charge", "*Activities.Invoice", "Unknown"} {
+		if got := wf.CallSites[i].TargetName; got != want {
+			t.Errorf("CallSites[%d].TargetName = %q, want %q", i, got, want)
+		}
+	}
+	if charge := graph.Nodes["Charge"]; charge == nil || !reflect.DeepEqual(charge.Aliases, []string{"v2.Charge"}) || len(charge.CalledBy) != 1 {
+		t.Errorf("Expected Charge aliased v2.Charge and called once, got %+v", charge)
+	}
+	if _, ok := graph.Nodes["v2.Charge"]; ok {
+		t.Error("Expected no unresolved node for the aliased name")
+	}
+	if unknown := graph.Nodes["Unknown"]; unknown == nil || !unknown.Unresolved {
+		t.Errorf("Expected an unresolved node for Unknown, got %+v", unknown)
+	}
+}
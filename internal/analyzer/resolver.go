@@ -0,0 +1,295 @@
+package analyzer
+
+import (
+	"context"
+	"crypto/sha256"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Resolver builds nodes on demand for functions outside the analyzed graph, such as the
+// helpers a user drills into in the TUI or asks the daemon about. Functions are classified,
+// and their calls extracted, as the analysis does, and internal calls resolve as with
+// --internal-depth. Parsed files are cached by the hash of their content, so a file is only
+// parsed again after it changes. A Resolver is safe for concurrent use.
+type Resolver struct {
+	logger    *slog.Logger
+	parser    *goParser
+	builder   *graphBuilder
+	extractor *callExtractor
+
+	mu    sync.Mutex
+	files map[string]*parsedFile // By path
+}
+
+// parsedFile is a cached parse of a Go file.
+type parsedFile struct {
+	hash [sha256.Size]byte
+	fset *token.FileSet
+	file *ast.File
+}
+
+// NewResolver creates a resolver with an empty cache.
+func NewResolver(logger *slog.Logger) *Resolver {
+	extractor := &callExtractor{logger: logger}
+	return &Resolver{
+		logger:    logger,
+		parser:    &goParser{logger: logger},
+		builder:   &graphBuilder{logger: logger, callExtractor: extractor},
+		extractor: extractor,
+		files:     make(map[string]*parsedFile),
+	}
+}
+
+// FindFunction returns a node for the function or method named name ("Func", "Type.Method"
+// or "*Type.Method"; a bare name also matches methods), looked up in searchPath, a file or a
+// directory, then in its package, then in the rest of its module. It returns nil if no such
+// function is declared.
+func (r *Resolver) FindFunction(ctx context.Context, name, searchPath string) *TemporalNode {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	file, dir := "", searchPath
+	if strings.HasSuffix(searchPath, ".go") {
+		file, dir = searchPath, filepath.Dir(searchPath)
+	}
+	if match, ok := r.index(dir).find(dir, name, file); ok {
+		return r.node(ctx, match)
+	}
+	return r.findInModule(ctx, name, dir)
+}
+
+// ResolveCall returns a node for the function an internal call of caller refers to, or nil.
+// Calls resolve within the caller's package as --internal-depth resolves them; calls of other
+// packages are looked up by name in the caller's module.
+func (r *Resolver) ResolveCall(ctx context.Context, call InternalCall, caller *TemporalNode) *TemporalNode {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dir := filepath.Dir(caller.FilePath)
+	match := NodeMatch{FilePath: caller.FilePath}
+	if fn := r.declAt(caller.FilePath, caller.LineNumber, false); fn != nil {
+		match.Node = fn
+	}
+	if target, ok := r.index(dir).resolve(call, match); ok {
+		return r.node(ctx, target)
+	}
+	return r.findInModule(ctx, call.TargetName, dir)
+}
+
+// FunctionAt returns a node for the function declared around line of file, or nil.
+func (r *Resolver) FunctionAt(ctx context.Context, file string, line int) *TemporalNode {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fn := r.declAt(file, line, true)
+	if fn == nil {
+		return nil
+	}
+	dir := filepath.Dir(file)
+	for _, match := range r.index(dir).byDir[dir] {
+		if match.Node == fn {
+			return r.node(ctx, match)
+		}
+	}
+	return nil
+}
+
+// node builds the node of a function declaration; r.mu is held.
+func (r *Resolver) node(ctx context.Context, match NodeMatch) *TemporalNode {
+	fn := match.Node.(*ast.FuncDecl)
+	match.NodeType = r.parser.classifyFunction(fn)
+	if match.NodeType == "" {
+		match.NodeType = "helper"
+	}
+	match.Reasons = r.parser.detectionReasons(fn)
+
+	var node *TemporalNode
+	var err error
+	if panicErr := recovered(func() { node, err = r.builder.buildFuncNode(ctx, r.extractor, match) }); panicErr != nil {
+		err = panicErr
+	}
+	if err != nil {
+		r.logger.Debug("Failed to resolve function", "function", fn.Name.Name, "path", match.FilePath, "error", err)
+		return nil
+	}
+	return node
+}
+
+// findInModule looks up a function in the packages of the module dir belongs to, other than
+// dir's; r.mu is held.
+func (r *Resolver) findInModule(ctx context.Context, name, dir string) *TemporalNode {
+	root := moduleRoot(dir)
+	if root == "" {
+		return nil
+	}
+
+	var found NodeMatch
+	var ok bool
+	_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil // Continue walking
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		base := d.Name()
+		if path != root && (base == "vendor" || base == "node_modules" || base == "testdata" || strings.HasPrefix(base, ".")) {
+			return filepath.SkipDir
+		}
+		if path == dir {
+			return nil
+		}
+		if found, ok = r.index(path).find(path, name, ""); ok {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if !ok {
+		return nil
+	}
+	return r.node(ctx, found)
+}
+
+// moduleRoot returns the nearest directory from dir up containing a go.mod, or "".
+func moduleRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// index returns the functions declared in the package in dir; r.mu is held.
+func (r *Resolver) index(dir string) *FuncIndex {
+	idx := NewFuncIndex(0)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return idx
+	}
+
+	var paths []string
+	var files []*parsedFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		parsed, err := r.parse(path)
+		if err != nil {
+			continue // Skip files that fail to parse, as the analysis does
+		}
+		paths = append(paths, path)
+		files = append(files, parsed)
+	}
+
+	// Aliases and constants are shared by the files of the package, as in the analysis
+	aliases := make(TypeAliases)
+	constants := make(PackageConstants)
+	for _, parsed := range files {
+		aliases.collectAliases(parsed.file)
+		constants.collectConstants(parsed.file)
+	}
+	for i, parsed := range files {
+		for _, decl := range parsed.file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				idx.add(NodeMatch{
+					Node:      fn,
+					FileSet:   parsed.fset,
+					FilePath:  paths[i],
+					Package:   parsed.file.Name.Name,
+					Aliases:   aliases,
+					Constants: constants,
+				})
+			}
+		}
+	}
+	return idx
+}
+
+// declAt returns the function declared on line of path, or with within also the function
+// whose body spans line; r.mu is held.
+func (r *Resolver) declAt(path string, line int, within bool) *ast.FuncDecl {
+	parsed, err := r.parse(path)
+	if err != nil {
+		return nil
+	}
+	for _, decl := range parsed.file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		start, end := parsed.fset.Position(fn.Pos()).Line, parsed.fset.Position(fn.End()).Line
+		if start == line || within && start <= line && line <= end {
+			return fn
+		}
+	}
+	return nil
+}
+
+// parse returns the parsed file at path, from the cache unless its content changed; r.mu
+// is held.
+func (r *Resolver) parse(path string) (*parsedFile, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(src)
+	if cached, ok := r.files[path]; ok && cached.hash == hash {
+		return cached, nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	parsed := &parsedFile{hash: hash, fset: fset, file: file}
+	r.files[path] = parsed
+	return parsed, nil
+}
+
+// find returns the function declared as name in dir ("Func", "Type.Method" or
+// "*Type.Method"). A bare name also matches methods, preferring those declared in file.
+func (idx *FuncIndex) find(dir, name, file string) (NodeMatch, bool) {
+	funcs := idx.byDir[dir]
+	name = strings.TrimPrefix(name, "*")
+	if match, ok := funcs[name]; ok {
+		return match, true
+	}
+
+	keys := make([]string, 0, len(funcs))
+	for key := range funcs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var found NodeMatch
+	ok := false
+	for _, key := range keys {
+		if !strings.HasSuffix(key, "."+name) {
+			continue
+		}
+		match := funcs[key]
+		if !ok || match.FilePath == file && found.FilePath != file {
+			found, ok = match, true
+		}
+	}
+	return found, ok
+}
@@ -0,0 +1,133 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var resolverTree = map[string]string{
+	"go.mod": "module example.com/shop\n\ngo 1.22\n",
+	"orders/orders.go": `package orders
+
+import (
+	"example.com/shop/billing"
+	"go.temporal.io/sdk/workflow"
+)
+
+type steps struct {
+	ctx workflow.Context
+}
+
+// OrderWorkflow places an order.
+func OrderWorkflow(ctx workflow.Context, id string) error {
+	if err := workflow.Sleep(ctx, 0); err != nil {
+		return err
+	}
+	s := &steps{ctx: ctx}
+	if err := s.charge(id); err != nil {
+		return err
+	}
+	return billing.Invoice(id)
+}
+
+func (s *steps) charge(id string) error {
+	return workflow.ExecuteActivity(s.ctx, "ChargeCard", id).Get(s.ctx, nil)
+}
+`,
+	"billing/invoice.go": `package billing
+
+// Invoice sends the invoice of an order.
+func Invoice(id string) error { return nil }
+`,
+}
+
+func TestResolverFindFunction(t *testing.T) {
+	dir := writeTree(t, resolverTree)
+	r := NewResolver(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+	orders := filepath.Join(dir, "orders", "orders.go")
+
+	tests := []struct {
+		name, searchPath string
+		wantType         string
+		wantFile         string
+	}{
+		{"OrderWorkflow", orders, "workflow", orders},
+		{"charge", orders, "helper", orders},
+		{"*steps.charge", filepath.Dir(orders), "helper", orders},
+		{"Invoice", orders, "helper", filepath.Join(dir, "billing", "invoice.go")},
+		{"Refund", orders, "", ""},
+	}
+	for _, tt := range tests {
+		node := r.FindFunction(ctx, tt.name, tt.searchPath)
+		if tt.wantType == "" {
+			if node != nil {
+				t.Errorf("FindFunction(%q) = %+v, want nil", tt.name, node)
+			}
+			continue
+		}
+		if node == nil {
+			t.Errorf("FindFunction(%q) = nil, want a %s", tt.name, tt.wantType)
+			continue
+		}
+		if node.Type != tt.wantType || node.FilePath != tt.wantFile {
+			t.Errorf("FindFunction(%q) = %s in %s, want %s in %s", tt.name, node.Type, node.FilePath, tt.wantType, tt.wantFile)
+		}
+	}
+
+	charge := r.FindFunction(ctx, "charge", orders)
+	if charge == nil || len(charge.CallSites) != 1 || charge.CallSites[0].TargetName != "ChargeCard" {
+		t.Errorf("Expected the helper's activity call to be extracted, got %+v", charge)
+	}
+}
+
+func TestResolverResolveCall(t *testing.T) {
+	dir := writeTree(t, resolverTree)
+	r := NewResolver(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	workflow := r.FindFunction(ctx, "OrderWorkflow", filepath.Join(dir, "orders"))
+	if workflow == nil {
+		t.Fatal("OrderWorkflow not found")
+	}
+	want := map[string]string{"charge": "*steps.charge", "Invoice": "Invoice"}
+	for _, call := range workflow.InternalCalls {
+		name, ok := want[call.TargetName]
+		if !ok {
+			continue
+		}
+		delete(want, call.TargetName)
+		if node := r.ResolveCall(ctx, call, workflow); node == nil || node.Name != name {
+			t.Errorf("ResolveCall(%s) = %+v, want %s", call.TargetName, node, name)
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("Expected internal calls of %v, got %+v", want, workflow.InternalCalls)
+	}
+}
+
+func TestResolverCache(t *testing.T) {
+	dir := writeTree(t, resolverTree)
+	r := NewResolver(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+	invoice := filepath.Join(dir, "billing", "invoice.go")
+
+	if r.FindFunction(ctx, "Invoice", invoice) == nil {
+		t.Fatal("Invoice not found")
+	}
+	cached := r.files[invoice]
+	if r.FindFunction(ctx, "Invoice", invoice) == nil || r.files[invoice] != cached {
+		t.Error("Expected an unchanged file to be served from the cache")
+	}
+
+	if err := os.WriteFile(invoice, []byte("package billing\n\nfunc SendInvoice(id string) error { return nil }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if r.FindFunction(ctx, "Invoice", invoice) != nil || r.FindFunction(ctx, "SendInvoice", invoice) == nil {
+		t.Error("Expected a changed file to be parsed again")
+	}
+}
@@ -0,0 +1,174 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+	"reflect"
+	"sort"
+)
+
+// SerializationFinding is a workflow or activity parameter or result holding a value the
+// default data converter can't serialize.
+type SerializationFinding struct {
+	// Node is the graph key of the workflow or activity
+	Node string `json:"node"`
+	// Path locates the value from the parameter name, or "result", e.g. req.Items[].Callback
+	Path string `json:"path"`
+	// Type is the type of the value, e.g. func(string) error
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// CheckSerialization type-checks the packages declaring the workflows and activities of the
+// graph and reports their parameters and results holding channels, functions, complex
+// numbers, sync primitives, structs with only unexported fields, or maps with unsupported
+// keys: values the JSON payload converter rejects or silently drops. Types implementing
+// json.Marshaler, encoding.TextMarshaler or protobuf messages are trusted, and types from
+// packages outside the module and the standard library are skipped.
+func CheckSerialization(ctx context.Context, graph *TemporalGraph, rootDir string) ([]SerializationFinding, error) {
+	checker := newTypeChecker(rootDir)
+
+	keys := make([]string, 0, len(graph.Nodes))
+	for key, node := range graph.Nodes {
+		if (node.Type == "workflow" || node.Type == "activity") && node.FilePath != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var findings []SerializationFinding
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		node := graph.Nodes[key]
+		_, fn := checker.funcAt(node.FilePath, node.LineNumber)
+		if fn == nil {
+			continue
+		}
+		sig := fn.Type().(*types.Signature)
+		w := &serializationWalker{node: key, seen: make(map[*types.Named]bool)}
+		for i := 0; i < sig.Params().Len(); i++ {
+			param := sig.Params().At(i)
+			name := param.Name()
+			if name == "" || name == "_" {
+				name = fmt.Sprintf("param%d", i+1)
+			}
+			w.walk(name, param.Type())
+		}
+		for i := 0; i < sig.Results().Len(); i++ {
+			w.walk("result", sig.Results().At(i).Type())
+		}
+		findings = append(findings, w.findings...)
+	}
+	return findings, nil
+}
+
+// serializationWalker walks the types of a signature, collecting unserializable values.
+type serializationWalker struct {
+	node     string
+	findings []SerializationFinding
+	// seen holds the named types walked, so that recursive and shared types are reported once
+	seen map[*types.Named]bool
+}
+
+func (w *serializationWalker) report(path string, t types.Type, reason string) {
+	w.findings = append(w.findings, SerializationFinding{
+		Node:   w.node,
+		Path:   path,
+		Type:   types.TypeString(t, func(p *types.Package) string { return p.Name() }),
+		Reason: reason,
+	})
+}
+
+func (w *serializationWalker) walk(path string, t types.Type) {
+	if named, ok := t.(*types.Named); ok {
+		origin := named.Origin()
+		if w.seen[origin] {
+			return
+		}
+		w.seen[origin] = true
+		if marshals(named) {
+			return
+		}
+		if pkg := origin.Obj().Pkg(); pkg != nil && (pkg.Path() == "sync" || pkg.Path() == "sync/atomic") {
+			w.report(path, t, "sync primitive")
+			return
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Chan:
+		w.report(path, t, "channel")
+	case *types.Signature:
+		w.report(path, t, "function")
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsComplex != 0:
+			w.report(path, t, "complex number")
+		case u.Kind() == types.UnsafePointer:
+			w.report(path, t, "unsafe pointer")
+		}
+	case *types.Pointer:
+		w.walk(path, u.Elem())
+	case *types.Slice:
+		w.walk(path+"[]", u.Elem())
+	case *types.Array:
+		w.walk(path+"[]", u.Elem())
+	case *types.Map:
+		if !validMapKey(u.Key()) {
+			w.report(path, t, "map key is not a string, integer or text marshaler")
+			return
+		}
+		w.walk(path+"[]", u.Elem())
+	case *types.Struct:
+		w.walkStruct(path, t, u)
+	}
+}
+
+// walkStruct walks the fields encoding/json serializes, reporting non-empty structs that
+// have none: their values arrive as zero values.
+func (w *serializationWalker) walkStruct(path string, t types.Type, s *types.Struct) {
+	serialized := 0
+	for i := 0; i < s.NumFields(); i++ {
+		field := s.Field(i)
+		if !field.Exported() && !field.Embedded() {
+			continue
+		}
+		if reflect.StructTag(s.Tag(i)).Get("json") == "-" {
+			continue
+		}
+		serialized++
+		w.walk(path+"."+field.Name(), field.Type())
+	}
+	if serialized == 0 && s.NumFields() > 0 {
+		w.report(path, t, "struct with only unexported fields")
+	}
+}
+
+// marshals reports whether a named type or its pointer implements json.Marshaler,
+// encoding.TextMarshaler or the protobuf message interface.
+func marshals(named *types.Named) bool {
+	methods := types.NewMethodSet(types.NewPointer(named))
+	for _, name := range []string{"MarshalJSON", "MarshalText", "ProtoReflect"} {
+		if methods.Lookup(named.Obj().Pkg(), name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// validMapKey reports whether encoding/json accepts a map key type.
+func validMapKey(t types.Type) bool {
+	if named, ok := t.(*types.Named); ok && marshals(named) {
+		return true
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return u.Info()&(types.IsString|types.IsInteger) != 0 || u.Kind() == types.Invalid
+	case *types.Interface:
+		return true
+	}
+	return false
+}
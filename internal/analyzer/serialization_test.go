@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+const serializationTypes = `package types
+
+import (
+	"sync"
+	"time"
+)
+
+type Order struct {
+	ID       string
+	Items    []Item
+	Meta     map[string]string
+	Deadline time.Time
+	mu       sync.Mutex
+}
+
+type Item struct {
+	SKU      string
+	Callback func(string) error
+	Skipped  chan int ` + "`json:\"-\"`" + `
+}
+
+type Cursor struct {
+	offset int
+	limit  int
+}
+
+type Tracker struct {
+	Done *sync.WaitGroup
+	Next *Tracker
+}
+`
+
+const serializationWorkflows = `package orders
+
+import (
+	"context"
+
+	"example.com/shop/types"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func register(worker worker.Worker) {
+	worker.RegisterWorkflow(OrderWorkflow)
+	worker.RegisterActivity(Charge)
+}
+
+func OrderWorkflow(ctx workflow.Context, order types.Order, updates chan string) (types.Cursor, error) {
+	err := workflow.ExecuteActivity(ctx, Charge, order.ID).Get(ctx, nil)
+	return types.Cursor{}, err
+}
+
+func Charge(ctx context.Context, id string, amounts map[types.Item]complex128, t types.Tracker) (*workflow.Future, error) {
+	activity.RecordHeartbeat(ctx, id)
+	return nil, nil
+}
+`
+
+func TestCheckSerialization(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"go.mod":           "module example.com/shop\n\ngo 1.22\n",
+		"types/types.go":   serializationTypes,
+		"orders/orders.go": serializationWorkflows,
+	})
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	findings, err := CheckSerialization(context.Background(), graph, dir)
+	if err != nil {
+		t.Fatalf("CheckSerialization failed: %v", err)
+	}
+
+	want := []SerializationFinding{
+		{Node: "Charge", Path: "amounts", Type: "map[types.Item]complex128", Reason: "map key is not a string, integer or text marshaler"},
+		{Node: "Charge", Path: "t.Done", Type: "sync.WaitGroup", Reason: "sync primitive"},
+		{Node: "OrderWorkflow", Path: "order.Items[].Callback", Type: "func(string) error", Reason: "function"},
+		{Node: "OrderWorkflow", Path: "updates", Type: "chan string", Reason: "channel"},
+		{Node: "OrderWorkflow", Path: "result", Type: "types.Cursor", Reason: "struct with only unexported fields"},
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("Expected %d findings, got %+v", len(want), findings)
+	}
+	for i, w := range want {
+		if findings[i] != w {
+			t.Errorf("findings[%d] = %+v, want %+v", i, findings[i], w)
+		}
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
 )
 
@@ -29,7 +33,21 @@ func NewService(logger *slog.Logger, parser Parser, builder GraphBuilder, repo R
 func (s *service) AnalyzeWorkflows(ctx context.Context, opts config.AnalysisOptions) (*TemporalGraph, error) {
 	s.logger.Info("Starting temporal analysis", "root_dir", opts.RootDir)
 
+	// Compile the node filter up front so syntax errors are reported before parsing
+	var query *Query
+	if opts.Query != "" {
+		q, err := ParseQuery(opts.Query)
+		if err != nil {
+			return nil, err
+		}
+		query = q
+	}
+
+	// Files that fail to parse or panic the analysis are skipped and reported with the graph
+	ctx, fileErrs := withFileErrors(ctx)
+
 	// Parse directory
+	reportProgress(ctx, Progress{Stage: StageRegistrations})
 	nodes, err := s.parser.ParseDirectory(ctx, opts.RootDir, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse directory: %w", err)
@@ -37,18 +55,119 @@ func (s *service) AnalyzeWorkflows(ctx context.Context, opts config.AnalysisOpti
 
 	if len(nodes) == 0 {
 		s.logger.Warn("No temporal workflows or activities found", "root_dir", opts.RootDir)
-		return &TemporalGraph{
-			Nodes: make(map[string]*TemporalNode),
-			Stats: GraphStats{},
-		}, nil
+		reportProgress(ctx, Progress{Stage: StageDone})
+		graph := &TemporalGraph{
+			Nodes:      make(map[string]*TemporalNode),
+			Stats:      GraphStats{},
+			FileErrors: fileErrs.errs,
+		}
+		graph.Stats.FailedFiles = len(graph.FailedFiles())
+		return graph, nil
 	}
 
 	// Build graph
+	reportProgress(ctx, Progress{Stage: StageGraph, NodesFound: len(nodes)})
 	graph, err := s.builder.BuildGraph(ctx, nodes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build graph: %w", err)
 	}
 
+	// Rebind calls resolved by name to the functions passed, by type (--typed)
+	if opts.Typed {
+		if err := ResolveTypedCalls(ctx, s.logger, graph, opts.RootDir); err != nil {
+			return nil, fmt.Errorf("failed to resolve typed calls: %w", err)
+		}
+		assignConfidence(graph)
+		if err := s.builder.CalculateStats(ctx, graph); err != nil {
+			return nil, fmt.Errorf("failed to calculate stats: %w", err)
+		}
+	}
+
+	// Correlate workflows and activities with testsuite usage in test files
+	reportProgress(ctx, Progress{Stage: StageTests, NodesFound: len(graph.Nodes)})
+	coverage, err := NewTestCoverageScanner(s.logger).ScanDirectory(ctx, opts.RootDir, opts)
+	if err != nil {
+		s.logger.Warn("Failed to scan for tests", "error", err)
+	} else {
+		coverage.Apply(graph)
+	}
+
+	// Collect worker task queues and concurrency limits
+	reportProgress(ctx, Progress{Stage: StageWorkers, NodesFound: len(graph.Nodes)})
+	workers, err := NewWorkerScanner(s.logger).ScanDirectory(ctx, opts.RootDir, opts)
+	if err != nil {
+		s.logger.Warn("Failed to scan for workers", "error", err)
+	} else {
+		ApplyWorkers(graph, workers)
+	}
+
+	// Trace API handlers to the workflows they start
+	reportProgress(ctx, Progress{Stage: StageEntryPoints, NodesFound: len(graph.Nodes)})
+	entryPoints, err := NewEntryPointScanner(s.logger).ScanDirectory(ctx, opts.RootDir, opts)
+	if err != nil {
+		s.logger.Warn("Failed to scan for entry points", "error", err)
+	} else {
+		entryPoints.Apply(graph)
+	}
+
+	// Collect the signals, queries and updates sent to workflows
+	reportProgress(ctx, Progress{Stage: StageMessages, NodesFound: len(graph.Nodes)})
+	messages, err := NewMessageScanner(s.logger).ScanDirectory(ctx, opts.RootDir, opts)
+	if err != nil {
+		s.logger.Warn("Failed to scan for messages", "error", err)
+	} else {
+		ApplyMessages(graph, messages)
+	}
+
+	// Collect the workflow IDs clients start workflows with
+	reportProgress(ctx, Progress{Stage: StageWorkflowIDs, NodesFound: len(graph.Nodes)})
+	workflowIDs, err := NewWorkflowIDScanner(s.logger).ScanDirectory(ctx, opts.RootDir, opts)
+	if err != nil {
+		s.logger.Warn("Failed to scan for workflow IDs", "error", err)
+	} else {
+		ApplyWorkflowIDs(graph, workflowIDs)
+	}
+
+	// Date nodes and count recent commits from the git history of their files
+	if opts.Churn {
+		if err := ComputeChurn(ctx, opts.RootDir, graph, time.Now().Add(-ChurnWindow)); err != nil {
+			s.logger.Warn("Failed to compute churn", "error", err)
+		}
+	}
+
+	// Group nodes into business domains; stats are recalculated to include domain coupling
+	if len(opts.Domains) > 0 {
+		AssignDomains(graph, opts.RootDir, opts.Domains)
+		if err := s.builder.CalculateStats(ctx, graph); err != nil {
+			return nil, fmt.Errorf("failed to calculate stats: %w", err)
+		}
+	}
+
+	// Merge the manually maintained metadata overlay
+	if err := s.applyMetadata(graph, opts); err != nil {
+		return nil, err
+	}
+
+	graph.FileErrors = fileErrs.errs
+	graph.Stats.FailedFiles = len(graph.FailedFiles())
+	if graph.Stats.FailedFiles > 0 {
+		s.logger.Warn("Files failed to analyze", "files", graph.Stats.FailedFiles)
+	}
+
+	// Filter nodes after relationships are built so fan-in/fan-out reflect the full graph
+	if query != nil || opts.MinConfidence != "" {
+		if query != nil {
+			graph = query.FilterGraph(graph)
+		}
+		if opts.MinConfidence != "" {
+			graph = FilterByConfidence(graph, opts.MinConfidence)
+		}
+		if err := s.builder.CalculateStats(ctx, graph); err != nil {
+			return nil, fmt.Errorf("failed to calculate stats: %w", err)
+		}
+	}
+
+	reportProgress(ctx, Progress{Stage: StageDone, NodesFound: len(graph.Nodes)})
 	s.logger.Info("Analysis complete",
 		"workflows", graph.Stats.TotalWorkflows,
 		"activities", graph.Stats.TotalActivities,
@@ -57,6 +176,26 @@ func (s *service) AnalyzeWorkflows(ctx context.Context, opts config.AnalysisOpti
 	return graph, nil
 }
 
+// applyMetadata merges the configured metadata overlay, or the one in the root directory, into the graph.
+func (s *service) applyMetadata(graph *TemporalGraph, opts config.AnalysisOptions) error {
+	metadataFile := opts.MetadataFile
+	if metadataFile == "" {
+		metadataFile = filepath.Join(opts.RootDir, MetadataFile)
+		if _, err := os.Stat(metadataFile); err != nil {
+			return nil
+		}
+	}
+
+	metadata, err := LoadMetadata(metadataFile)
+	if err != nil {
+		return err
+	}
+	for _, pattern := range metadata.Apply(graph) {
+		s.logger.Warn("Metadata entry matches no node", "match", pattern, "file", metadataFile)
+	}
+	return nil
+}
+
 // ValidateGraph checks the graph for common issues or anti-patterns.
 func (s *service) ValidateGraph(ctx context.Context, graph *TemporalGraph) ([]ValidationIssue, error) {
 	var issues []ValidationIssue
@@ -74,13 +213,24 @@ func (s *service) ValidateGraph(ctx context.Context, graph *TemporalGraph) ([]Va
 			issues = append(issues, ValidationIssue{
 				Type:       "warning",
 				Message:    fmt.Sprintf("Node '%s' has no connections (orphan)", node.Name),
-				NodeName:   node.Name,
+				NodeName:   node.ID(),
 				Severity:   3,
 				Suggestion: "Consider removing unused code or adding connections",
 			})
 		}
 	}
 
+	// Check that workflows and activities are registered on a worker
+	for _, node := range graph.UnregisteredNodes() {
+		issues = append(issues, ValidationIssue{
+			Type:       "warning",
+			Message:    fmt.Sprintf("%s '%s' is not registered on any worker", node.Type, node.Name),
+			NodeName:   node.ID(),
+			Severity:   5,
+			Suggestion: "Register it with RegisterWorkflow/RegisterActivity on the worker polling its task queue",
+		})
+	}
+
 	// Check for circular dependencies
 	circularDeps := s.findCircularDependencies(ctx, graph)
 	for _, cycle := range circularDeps {
@@ -100,7 +250,7 @@ func (s *service) ValidateGraph(ctx context.Context, graph *TemporalGraph) ([]Va
 				issues = append(issues, ValidationIssue{
 					Type:       "warning",
 					Message:    fmt.Sprintf("Deep call chain starting from '%s' (depth: %d)", node.Name, depth),
-					NodeName:   node.Name,
+					NodeName:   node.ID(),
 					Severity:   5,
 					Suggestion: "Consider breaking down complex workflows",
 				})
@@ -114,7 +264,7 @@ func (s *service) ValidateGraph(ctx context.Context, graph *TemporalGraph) ([]Va
 			issues = append(issues, ValidationIssue{
 				Type:       "warning",
 				Message:    fmt.Sprintf("Node '%s' has many dependencies (%d)", node.Name, len(node.CallSites)),
-				NodeName:   node.Name,
+				NodeName:   node.ID(),
 				Severity:   4,
 				Suggestion: "Consider splitting into smaller, more focused workflows",
 			})
@@ -139,7 +289,7 @@ func (s *service) findCircularDependencies(ctx context.Context, graph *TemporalG
 		default:
 		}
 
-		if !visited[node.Name] {
+		if !visited[node.ID()] {
 			if cycle := s.detectCycle(ctx, node, graph, visited, recStack, []string{}); cycle != "" {
 				cycles = append(cycles, cycle)
 			}
@@ -158,34 +308,34 @@ func (s *service) detectCycle(ctx context.Context, node *TemporalNode, graph *Te
 	default:
 	}
 
-	visited[node.Name] = true
-	recStack[node.Name] = true
-	path = append(path, node.Name)
+	visited[node.ID()] = true
+	recStack[node.ID()] = true
+	path = append(path, node.ID())
 
 	for _, callSite := range node.CallSites {
 		if childNode, exists := graph.Nodes[callSite.TargetName]; exists {
-			if !visited[childNode.Name] {
+			if !visited[childNode.ID()] {
 				if cycle := s.detectCycle(ctx, childNode, graph, visited, recStack, path); cycle != "" {
 					return cycle
 				}
-			} else if recStack[childNode.Name] {
+			} else if recStack[childNode.ID()] {
 				// Found a cycle
 				cycleStart := -1
 				for i, name := range path {
-					if name == childNode.Name {
+					if name == childNode.ID() {
 						cycleStart = i
 						break
 					}
 				}
 				if cycleStart != -1 {
-					cyclePath := append(path[cycleStart:], childNode.Name)
+					cyclePath := append(path[cycleStart:], childNode.ID())
 					return fmt.Sprintf("%v", cyclePath)
 				}
 			}
 		}
 	}
 
-	recStack[node.Name] = false
+	recStack[node.ID()] = false
 	return ""
 }
 
@@ -198,12 +348,12 @@ func (s *service) calculateChainDepth(ctx context.Context, node *TemporalNode, g
 	default:
 	}
 
-	if visited[node.Name] {
+	if visited[node.ID()] {
 		return 0 // Avoid infinite recursion
 	}
 
-	visited[node.Name] = true
-	defer func() { visited[node.Name] = false }()
+	visited[node.ID()] = true
+	defer func() { visited[node.ID()] = false }()
 
 	maxDepth := 0
 	for _, callSite := range node.CallSites {
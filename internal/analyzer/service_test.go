@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
@@ -81,6 +82,96 @@ func MyActivity() error {
 	}
 }
 
+func TestAnalyzeWorkflowsQuery(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := `package test
+
+import "go.temporal.io/sdk/workflow"
+
+func MyWorkflow(ctx workflow.Context) error {
+	workflow.ExecuteActivity(ctx, MyActivity).Get(ctx, nil)
+	return nil
+}
+
+func MyActivity() error {
+	return nil
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "workflow.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	extractor := NewCallExtractor(logger)
+	service := NewService(logger, NewParser(logger), NewGraphBuilder(logger, extractor), NewRepository(logger))
+
+	graph, err := service.AnalyzeWorkflows(context.Background(), config.AnalysisOptions{
+		RootDir: tmpDir,
+		Query:   "type==workflow",
+	})
+	if err != nil {
+		t.Fatalf("AnalyzeWorkflows failed: %v", err)
+	}
+
+	if len(graph.Nodes) != 1 || graph.Nodes["MyWorkflow"] == nil {
+		t.Errorf("Expected only MyWorkflow, got %d nodes", len(graph.Nodes))
+	}
+	if graph.Stats.TotalActivities != 0 || graph.Stats.TotalWorkflows != 1 {
+		t.Errorf("Expected stats recalculated for filtered graph, got %+v", graph.Stats)
+	}
+
+	_, err = service.AnalyzeWorkflows(context.Background(), config.AnalysisOptions{
+		RootDir: tmpDir,
+		Query:   "fanout>",
+	})
+	if err == nil {
+		t.Error("Expected error for invalid query")
+	}
+}
+
+func TestAnalyzeWorkflowsMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := `package test
+
+import "go.temporal.io/sdk/workflow"
+
+func MyWorkflow(ctx workflow.Context) error {
+	workflow.ExecuteActivity(ctx, MyActivity).Get(ctx, nil)
+	return nil
+}
+`
+	metadata := "nodes:\n  - match: MyWorkflow\n    tags: [critical]\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "workflow.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, MetadataFile), []byte(metadata), 0644); err != nil {
+		t.Fatalf("Failed to create metadata file: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	extractor := NewCallExtractor(logger)
+	service := NewService(logger, NewParser(logger), NewGraphBuilder(logger, extractor), NewRepository(logger))
+
+	// The overlay in the root is applied before the query filters nodes
+	graph, err := service.AnalyzeWorkflows(context.Background(), config.AnalysisOptions{RootDir: tmpDir, Query: "tags=~'critical'"})
+	if err != nil {
+		t.Fatalf("AnalyzeWorkflows failed: %v", err)
+	}
+	if len(graph.Nodes) != 1 || !graph.Nodes["MyWorkflow"].HasTag("critical") {
+		t.Errorf("Expected only the tagged MyWorkflow, got %v", graph.Nodes)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.yaml")
+	if err := os.WriteFile(bad, []byte("nodes:\n  - tags: [x]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.AnalyzeWorkflows(context.Background(), config.AnalysisOptions{RootDir: tmpDir, MetadataFile: bad}); err == nil {
+		t.Error("Expected error for an invalid metadata file")
+	}
+}
+
 func TestAnalyzeWorkflowsContextCancellation(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -150,6 +241,27 @@ func TestValidateGraph(t *testing.T) {
 	}
 }
 
+func TestValidateGraphUnregisteredNode(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	service := NewService(logger, NewParser(logger), NewGraphBuilder(logger, NewCallExtractor(logger)), NewRepository(logger))
+
+	graph := &TemporalGraph{
+		Nodes: map[string]*TemporalNode{
+			"Workflow1": {Name: "Workflow1", Type: "workflow", CallSites: []CallSite{{TargetName: "Activity1"}}},
+			"Activity1": {Name: "Activity1", Type: "activity", Parents: []string{"Workflow1"}},
+		},
+		Workers: []WorkerConfig{{TaskQueue: "main", Workflows: []string{"Workflow1"}}},
+	}
+
+	issues, err := service.ValidateGraph(context.Background(), graph)
+	if err != nil {
+		t.Fatalf("ValidateGraph failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].NodeName != "Activity1" || !strings.Contains(issues[0].Message, "not registered") {
+		t.Errorf("Expected unregistered Activity1 issue, got %+v", issues)
+	}
+}
+
 func TestValidateGraphCircularDependency(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	parser := NewParser(logger)
@@ -0,0 +1,249 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// TestCoverageInfo holds the test references found in _test.go files, keyed by target name.
+type TestCoverageInfo struct {
+	// References maps workflow/activity names (as written in the test) to the tests that use them.
+	References map[string][]TestReference
+}
+
+// testCoverageScanner scans test files for Temporal testsuite usage.
+type testCoverageScanner struct {
+	logger *slog.Logger
+}
+
+// NewTestCoverageScanner creates a new test coverage scanner.
+func NewTestCoverageScanner(logger *slog.Logger) *testCoverageScanner {
+	return &testCoverageScanner{
+		logger: logger,
+	}
+}
+
+// IsTestFile reports whether path is a Go test file, by the _test.go suffix of its name.
+func IsTestFile(path string) bool {
+	return strings.HasSuffix(filepath.Base(path), "_test.go")
+}
+
+// ScanDirectory scans all _test.go files in a directory for testsuite usage.
+// Test files are always scanned, regardless of opts.IncludeTests.
+func (s *testCoverageScanner) ScanDirectory(ctx context.Context, rootDir string, opts config.AnalysisOptions) (*TestCoverageInfo, error) {
+	info := &TestCoverageInfo{
+		References: make(map[string][]TestReference),
+	}
+
+	fset := token.NewFileSet()
+
+	modules := NewModuleFilter(rootDir, opts)
+	err := filepath.Walk(rootDir, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			s.logger.Warn("Error accessing path during test scan", "path", path, "error", err)
+			return nil // Continue walking
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if fileInfo.IsDir() {
+			if opts.ExcludesDir(fileInfo.Name()) {
+				return filepath.SkipDir
+			}
+			if modules.SkipDir(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !IsTestFile(path) {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			s.logger.Warn("Error parsing test file", "path", path, "error", err)
+			return nil
+		}
+
+		if !s.usesTestsuite(file) {
+			return nil
+		}
+
+		if err := recovered(func() { s.scanFile(ctx, file, fset, path, info) }); err != nil {
+			s.logger.Warn("Error scanning test file", "path", path, "error", err)
+			recordFileError(ctx, StageTests, path, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Scanned for tests", "tested_targets", len(info.References))
+
+	return info, nil
+}
+
+// usesTestsuite checks whether a test file imports the Temporal testsuite or worker packages.
+func (s *testCoverageScanner) usesTestsuite(file *ast.File) bool {
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if path == "go.temporal.io/sdk/testsuite" || path == "go.temporal.io/sdk/worker" {
+			return true
+		}
+	}
+	return false
+}
+
+// scanFile scans the functions of a single test file for testsuite calls.
+func (s *testCoverageScanner) scanFile(ctx context.Context, file *ast.File, fset *token.FileSet, filePath string, info *TestCoverageInfo) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+
+		testName := fn.Name.Name
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			default:
+			}
+
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+
+			// workflow.ExecuteActivity inside test-defined workflows is not a test call
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "workflow" {
+				return true
+			}
+
+			var kind string
+			switch sel.Sel.Name {
+			case "ExecuteWorkflow":
+				kind = "workflow_test"
+			case "ExecuteActivity":
+				kind = "activity_test"
+			case "OnActivity":
+				kind = "activity_mock"
+			case "RegisterWorkflow":
+				// Registration on a WorkflowReplayer marks a replay test
+				if !s.isReplayer(sel.X) {
+					return true
+				}
+				kind = "replay"
+			default:
+				return true
+			}
+
+			target := s.targetName(call.Args[0])
+			if target == "" {
+				return true
+			}
+
+			info.References[target] = append(info.References[target], TestReference{
+				TestName:   testName,
+				Kind:       kind,
+				FilePath:   filePath,
+				LineNumber: fset.Position(call.Pos()).Line,
+			})
+
+			return true
+		})
+	}
+}
+
+// isReplayer checks whether the receiver looks like a worker.WorkflowReplayer.
+func (s *testCoverageScanner) isReplayer(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	return strings.Contains(strings.ToLower(ident.Name), "replayer")
+}
+
+// targetName extracts the referenced workflow/activity name from a testsuite call argument.
+// Handles MyWorkflow, pkg.MyWorkflow, acts.MyActivity, (*Acts).MyActivity and "MyActivity".
+func (s *testCoverageScanner) targetName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.BasicLit:
+		if t.Kind == token.STRING {
+			if name, err := strconv.Unquote(t.Value); err == nil {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// Apply attaches test references to the matching workflow and activity nodes in the graph.
+// Nodes are matched by exact name or by method name for qualified (Receiver.Method) nodes.
+func (info *TestCoverageInfo) Apply(graph *TemporalGraph) {
+	if info == nil || graph == nil {
+		return
+	}
+
+	for name, node := range graph.Nodes {
+		if node.Type != "workflow" && node.Type != "activity" {
+			continue
+		}
+
+		shortName := name
+		if idx := strings.LastIndex(name, "."); idx >= 0 {
+			shortName = name[idx+1:]
+		}
+
+		refs, ok := info.References[name]
+		if !ok {
+			refs = info.References[shortName]
+		}
+
+		for _, ref := range refs {
+			if !info.refMatchesType(ref, node.Type) {
+				continue
+			}
+			node.Tests = append(node.Tests, ref)
+		}
+	}
+}
+
+// refMatchesType reports whether a test reference kind applies to a node type.
+func (info *TestCoverageInfo) refMatchesType(ref TestReference, nodeType string) bool {
+	switch ref.Kind {
+	case "workflow_test", "replay":
+		return nodeType == "workflow"
+	case "activity_test", "activity_mock":
+		return nodeType == "activity"
+	}
+	return false
+}
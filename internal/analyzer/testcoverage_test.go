@@ -0,0 +1,171 @@
+package analyzer
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+func TestNewTestCoverageScanner(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	scanner := NewTestCoverageScanner(logger)
+	if scanner == nil {
+		t.Fatal("NewTestCoverageScanner returned nil")
+	}
+}
+
+func TestTestCoverageScanDirectory(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := `package app
+
+import (
+	"testing"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+)
+
+func TestOrderWorkflow(t *testing.T) {
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(ChargeActivity, mock.Anything).Return(nil)
+	env.OnActivity("ShipActivity", mock.Anything).Return(nil)
+	env.ExecuteWorkflow(OrderWorkflow, "order-1")
+}
+
+func TestChargeActivity(t *testing.T) {
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestActivityEnvironment()
+	env.ExecuteActivity(acts.ChargeActivity, 10)
+}
+
+func TestReplay(t *testing.T) {
+	replayer := worker.NewWorkflowReplayer()
+	replayer.RegisterWorkflow(RefundWorkflow)
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "app_test.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	// A test file without testsuite imports should be ignored
+	plain := `package app
+
+func TestPlain(t *testing.T) {
+	env.ExecuteWorkflow(PlainWorkflow)
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "plain_test.go"), []byte(plain), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	scanner := NewTestCoverageScanner(logger)
+
+	info, err := scanner.ScanDirectory(context.Background(), tmpDir, config.AnalysisOptions{})
+	if err != nil {
+		t.Fatalf("ScanDirectory failed: %v", err)
+	}
+
+	tests := []struct {
+		target string
+		kind   string
+		test   string
+	}{
+		{"OrderWorkflow", "workflow_test", "TestOrderWorkflow"},
+		{"ShipActivity", "activity_mock", "TestOrderWorkflow"},
+		{"RefundWorkflow", "replay", "TestReplay"},
+	}
+
+	for _, tt := range tests {
+		refs := info.References[tt.target]
+		if len(refs) != 1 {
+			t.Errorf("Expected 1 reference for %s, got %d", tt.target, len(refs))
+			continue
+		}
+		if refs[0].Kind != tt.kind {
+			t.Errorf("%s kind = %q, want %q", tt.target, refs[0].Kind, tt.kind)
+		}
+		if refs[0].TestName != tt.test {
+			t.Errorf("%s test = %q, want %q", tt.target, refs[0].TestName, tt.test)
+		}
+	}
+
+	if len(info.References["ChargeActivity"]) != 2 {
+		t.Errorf("Expected 2 references for ChargeActivity, got %d", len(info.References["ChargeActivity"]))
+	}
+
+	if _, ok := info.References["PlainWorkflow"]; ok {
+		t.Error("Test files without testsuite imports should be ignored")
+	}
+}
+
+func TestTestCoverageApply(t *testing.T) {
+	info := &TestCoverageInfo{
+		References: map[string][]TestReference{
+			"OrderWorkflow":  {{TestName: "TestOrderWorkflow", Kind: "workflow_test"}},
+			"ChargeActivity": {{TestName: "TestCharge", Kind: "activity_test"}},
+			"Mislabeled":     {{TestName: "TestMislabeled", Kind: "activity_mock"}},
+		},
+	}
+
+	graph := &TemporalGraph{
+		Nodes: map[string]*TemporalNode{
+			"OrderWorkflow":              {Name: "OrderWorkflow", Type: "workflow"},
+			"*Activities.ChargeActivity": {Name: "*Activities.ChargeActivity", Type: "activity"},
+			"Mislabeled":                 {Name: "Mislabeled", Type: "workflow"},
+			"UntestedWorkflow":           {Name: "UntestedWorkflow", Type: "workflow"},
+		},
+	}
+
+	info.Apply(graph)
+
+	if !graph.Nodes["OrderWorkflow"].IsTested() {
+		t.Error("Expected OrderWorkflow to be tested")
+	}
+	if !graph.Nodes["*Activities.ChargeActivity"].IsTested() {
+		t.Error("Expected method activity to match by method name")
+	}
+	if graph.Nodes["Mislabeled"].IsTested() {
+		t.Error("Activity mocks should not mark workflows as tested")
+	}
+	if graph.Nodes["UntestedWorkflow"].IsTested() {
+		t.Error("Expected UntestedWorkflow to be untested")
+	}
+}
+
+func TestIsTestFile(t *testing.T) {
+	tests := map[string]bool{
+		"orders/orders_test.go":       true,
+		"orders_test.go":              true,
+		"orders/orders.go":            false,
+		"orders/contest.go":           false,
+		"fixtures_test.go/helpers.go": false,
+		"orders/orders_test.go.orig":  false,
+	}
+	for path, want := range tests {
+		if got := IsTestFile(path); got != want {
+			t.Errorf("IsTestFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestTestOriginNodes(t *testing.T) {
+	opts := config.AnalysisOptions{RootDir: writeTree(t, explainTree), ExcludeDirs: []string{"gen"}, IncludeTests: true}
+	graph, err := NewAnalyzer(slog.Default()).Analyze(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	fake, ok := graph.Nodes["FakeWorkflow"]
+	if !ok || !fake.TestOrigin {
+		t.Errorf("Expected FakeWorkflow from a test file to be marked, got %+v", fake)
+	}
+	if graph.Nodes["OrderWorkflow"].TestOrigin {
+		t.Error("Expected OrderWorkflow not to be marked")
+	}
+}
@@ -0,0 +1,199 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// typeChecker type-checks the packages of the analyzed tree from source with go/types.
+// Packages of the module at the root are checked from their directories and the standard
+// library is imported from GOROOT. Other imports, such as the Temporal SDK, become empty
+// packages: types from them are invalid and skipped rather than failing the check, so it
+// works without downloaded dependencies.
+type typeChecker struct {
+	fset       *token.FileSet
+	modulePath string
+	moduleDir  string
+	std        types.ImporterFrom
+
+	// packages are the checked packages of the module, by directory
+	packages map[string]*checkedPackage
+	// imported are the imported packages by import path, including empty ones
+	imported map[string]*types.Package
+	// checking holds the directories being checked, to break import cycles
+	checking map[string]bool
+}
+
+// checkedPackage is a package of the module with its syntax and type information.
+type checkedPackage struct {
+	pkg   *types.Package
+	info  *types.Info
+	files []*ast.File
+}
+
+// newTypeChecker creates a type checker for the tree at rootDir, reading the module path from
+// the go.mod at rootDir or the nearest directory above it.
+func newTypeChecker(rootDir string) *typeChecker {
+	c := &typeChecker{
+		fset:     token.NewFileSet(),
+		packages: make(map[string]*checkedPackage),
+		imported: make(map[string]*types.Package),
+		checking: make(map[string]bool),
+	}
+	c.std, _ = importer.ForCompiler(c.fset, "source", nil).(types.ImporterFrom)
+
+	dir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return c
+	}
+	for ; ; dir = filepath.Dir(dir) {
+		if paths := readModFile(filepath.Join(dir, "go.mod"), "module"); len(paths) > 0 {
+			c.modulePath, c.moduleDir = paths[0], dir
+			break
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	return c
+}
+
+// Import implements types.Importer.
+func (c *typeChecker) Import(importPath string) (*types.Package, error) {
+	return c.ImportFrom(importPath, "", 0)
+}
+
+// ImportFrom implements types.ImporterFrom. It never fails: imports that can't be checked
+// are empty packages.
+func (c *typeChecker) ImportFrom(importPath, srcDir string, mode types.ImportMode) (*types.Package, error) {
+	if pkg, ok := c.imported[importPath]; ok {
+		return pkg, nil
+	}
+
+	var pkg *types.Package
+	if dir, ok := c.moduleDirOf(importPath); ok {
+		if checked := c.checkDir(dir); checked != nil {
+			pkg = checked.pkg
+		}
+	} else if first, _, _ := strings.Cut(importPath, "/"); !strings.Contains(first, ".") && c.std != nil {
+		// Standard library
+		pkg, _ = c.std.ImportFrom(importPath, srcDir, mode)
+	}
+	if pkg == nil {
+		pkg = types.NewPackage(importPath, path.Base(importPath))
+		pkg.MarkComplete()
+	}
+	c.imported[importPath] = pkg
+	return pkg, nil
+}
+
+// moduleDirOf returns the directory of an import path of the module.
+func (c *typeChecker) moduleDirOf(importPath string) (string, bool) {
+	if c.modulePath == "" {
+		return "", false
+	}
+	if importPath == c.modulePath {
+		return c.moduleDir, true
+	}
+	if rel, ok := strings.CutPrefix(importPath, c.modulePath+"/"); ok {
+		return filepath.Join(c.moduleDir, filepath.FromSlash(rel)), true
+	}
+	return "", false
+}
+
+// importPathOf returns the import path of a directory of the module, or the directory
+// itself outside a module.
+func (c *typeChecker) importPathOf(dir string) string {
+	if c.modulePath != "" {
+		if rel, err := filepath.Rel(c.moduleDir, dir); err == nil && (rel == "." || filepath.IsLocal(rel)) {
+			return path.Join(c.modulePath, filepath.ToSlash(rel))
+		}
+	}
+	return filepath.ToSlash(dir)
+}
+
+// checkDir type-checks the non-test Go files of a directory matching the build context,
+// ignoring type errors. It returns nil if the directory has no such files or is part of an
+// import cycle being checked.
+func (c *typeChecker) checkDir(dir string) *checkedPackage {
+	dir = filepath.Clean(dir)
+	if checked, ok := c.packages[dir]; ok {
+		return checked
+	}
+	if c.checking[dir] {
+		return nil
+	}
+	c.checking[dir] = true
+	defer delete(c.checking, dir)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || IsTestFile(name) {
+			continue
+		}
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+		file, err := parser.ParseFile(c.fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		// A directory holds one package; stray files such as generators are left out
+		if len(files) > 0 && file.Name.Name != files[0].Name.Name {
+			continue
+		}
+		files = append(files, file)
+	}
+
+	checked := &checkedPackage{files: files}
+	if len(files) > 0 {
+		checked.info = &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+		conf := types.Config{Importer: c, Error: func(error) {}, FakeImportC: true}
+		checked.pkg, _ = conf.Check(c.importPathOf(dir), c.fset, files, checked.info)
+	}
+	c.packages[dir] = checked
+	if len(files) == 0 {
+		return nil
+	}
+	c.imported[checked.pkg.Path()] = checked.pkg
+	return checked
+}
+
+// funcAt returns the function declared at a line of a file, with its type, or nil.
+func (c *typeChecker) funcAt(filePath string, line int) (*ast.FuncDecl, *types.Func) {
+	filePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, nil
+	}
+	checked := c.checkDir(filepath.Dir(filePath))
+	if checked == nil {
+		return nil, nil
+	}
+	for _, file := range checked.files {
+		if c.fset.Position(file.Pos()).Filename != filePath {
+			continue
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || c.fset.Position(fn.Pos()).Line != line {
+				continue
+			}
+			obj, _ := checked.info.Defs[fn.Name].(*types.Func)
+			return fn, obj
+		}
+	}
+	return nil, nil
+}
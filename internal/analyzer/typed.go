@@ -0,0 +1,289 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// typedLoadMode loads the packages of the tree with their syntax and type information, and
+// their dependencies type-checked, so functions of other packages resolve to one object.
+const typedLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+	packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo
+
+// ResolveTypedCalls binds the Temporal call targets of the graph to nodes with type
+// information (--typed), loading the packages at rootDir with go/packages. A function or
+// method value passed to ExecuteActivity and the like resolves to the node declaring that
+// function, identified by its fully-qualified symbol, so a.ProcessOrder resolves by the type
+// of a even when several types or packages declare ProcessOrder. A function declared in the
+// tree that is not a node yet, such as an activity method that is never registered, gets a
+// node of the call's target type. Nodes get their symbol, e.g.
+// "(*example.com/shop/orders.Activities).Charge". Targets that are not function values, such
+// as registered names, keep their resolution.
+func ResolveTypedCalls(ctx context.Context, logger *slog.Logger, graph *TemporalGraph, rootDir string) error {
+	pkgs, err := packages.Load(&packages.Config{Context: ctx, Mode: typedLoadMode, Dir: rootDir}, "./...")
+	if err != nil {
+		return fmt.Errorf("failed to load packages: %w", err)
+	}
+	// Packages with errors, such as missing dependencies, still have partial type information
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			logger.Debug("Package loaded with errors", "package", pkg.PkgPath, "error", err)
+		}
+	})
+	decls := newTypedDecls(pkgs)
+	resolver := NewResolver(logger)
+
+	keys := make([]string, 0, len(graph.Nodes))
+	for key := range graph.Nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	bySymbol := make(map[*types.Func]string)
+	for _, key := range keys {
+		node := graph.Nodes[key]
+		if node.Unresolved || node.FilePath == "" {
+			continue
+		}
+		if decl := decls.at(node.FilePath, node.LineNumber); decl != nil && decl.obj != nil {
+			node.Symbol = decl.obj.FullName()
+			bySymbol[decl.obj] = key
+		}
+	}
+
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		caller := graph.Nodes[key]
+		if caller == nil || caller.Unresolved || len(caller.CallSites) == 0 {
+			continue
+		}
+		decl := decls.at(caller.FilePath, caller.LineNumber)
+		if decl == nil || decl.fn.Body == nil {
+			continue
+		}
+		refs := funcRefsByLine(decl.fn.Body, decl.pkg.TypesInfo, decl.pkg.Fset)
+		for i := range caller.CallSites {
+			call := &caller.CallSites[i]
+			if call.CallType == "internal" {
+				continue
+			}
+			if stubTargetTypes[call.TargetType] {
+				addDeclaredNodes(ctx, resolver, graph, decls, call, refs[call.LineNumber], bySymbol)
+			}
+			if target, ok := typedTarget(call.TargetName, refs[call.LineNumber], bySymbol); ok && target != call.TargetName {
+				relinkCallSite(graph, key, call, target)
+			}
+		}
+	}
+	return nil
+}
+
+// typedDecl is a function declaration of a loaded package with its type.
+type typedDecl struct {
+	fn  *ast.FuncDecl
+	obj *types.Func
+	pkg *packages.Package
+}
+
+// typedDecls indexes the function declarations of loaded packages by file and line.
+type typedDecls struct {
+	// fset is the file set shared by the loaded packages
+	fset   *token.FileSet
+	byLine map[string]map[int]*typedDecl
+}
+
+// newTypedDecls indexes the function declarations of pkgs, the packages matched by the load
+// rather than their dependencies.
+func newTypedDecls(pkgs []*packages.Package) *typedDecls {
+	d := &typedDecls{byLine: make(map[string]map[int]*typedDecl)}
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		d.fset = pkg.Fset
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok {
+					continue
+				}
+				pos := pkg.Fset.Position(fn.Pos())
+				lines, ok := d.byLine[pos.Filename]
+				if !ok {
+					lines = make(map[int]*typedDecl)
+					d.byLine[pos.Filename] = lines
+				}
+				obj, _ := pkg.TypesInfo.Defs[fn.Name].(*types.Func)
+				lines[pos.Line] = &typedDecl{fn: fn, obj: obj, pkg: pkg}
+			}
+		}
+	}
+	return d
+}
+
+// at returns the function declared at a line of a file, or nil.
+func (d *typedDecls) at(filePath string, line int) *typedDecl {
+	filePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil
+	}
+	return d.byLine[filePath][line]
+}
+
+// declOf returns the declaration of a function, or nil if it is not declared in the loaded
+// packages.
+func (d *typedDecls) declOf(fn *types.Func) *typedDecl {
+	if d.fset == nil {
+		return nil
+	}
+	pos := d.fset.Position(fn.Pos())
+	if decl := d.byLine[pos.Filename][pos.Line]; decl != nil && decl.obj == fn {
+		return decl
+	}
+	return nil
+}
+
+// funcRefsByLine returns the functions passed as arguments to the calls in body, by the
+// line the call starts on: the function and method values like Charge, pkg.Charge and
+// a.Charge.
+func funcRefsByLine(body *ast.BlockStmt, info *types.Info, fset *token.FileSet) map[int][]*types.Func {
+	refs := make(map[int][]*types.Func)
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		line := fset.Position(call.Pos()).Line
+		for _, arg := range call.Args {
+			var obj types.Object
+			switch x := ast.Unparen(arg).(type) {
+			case *ast.Ident:
+				obj = info.Uses[x]
+			case *ast.SelectorExpr:
+				if sel, ok := info.Selections[x]; ok {
+					obj = sel.Obj()
+				} else {
+					obj = info.Uses[x.Sel]
+				}
+			}
+			if fn, ok := obj.(*types.Func); ok {
+				refs[line] = append(refs[line], fn.Origin())
+			}
+		}
+		return true
+	})
+	return refs
+}
+
+// addDeclaredNodes adds a node of the call's target type for each function passed on the
+// call's line that is named like the target, declared in the loaded packages and not a node
+// yet. The nodes are keyed as helper nodes are, taking the place of an unresolved stub.
+func addDeclaredNodes(ctx context.Context, resolver *Resolver, graph *TemporalGraph, decls *typedDecls, call *CallSite, refs []*types.Func, bySymbol map[*types.Func]string) {
+	name := call.TargetName[strings.LastIndex(call.TargetName, ".")+1:]
+	for _, fn := range refs {
+		if _, ok := bySymbol[fn]; ok || fn.Name() != name {
+			continue
+		}
+		decl := decls.declOf(fn)
+		if decl == nil {
+			continue
+		}
+		pos := decls.fset.Position(decl.fn.Pos())
+		node := resolver.FunctionAt(ctx, pos.Filename, pos.Line)
+		if node == nil {
+			continue
+		}
+		node.Type = call.TargetType
+		node.Symbol = fn.FullName()
+		keys := []string{node.Name, node.Package + "." + node.Name, filepath.ToSlash(filepath.Dir(node.FilePath)) + "." + node.Name}
+		for _, key := range keys {
+			existing, exists := graph.Nodes[key]
+			if exists && !existing.Unresolved {
+				continue
+			}
+			// The function replaces the unresolved stub named like it, with its callers
+			if exists {
+				node.Parents, node.CalledBy = existing.Parents, existing.CalledBy
+			}
+			if key != node.Name {
+				node.Key = key
+			}
+			graph.Nodes[key] = node
+			bySymbol[fn] = key
+			break
+		}
+	}
+}
+
+// typedTarget returns the node key of the function a call target refers to: the function
+// passed on the call's line that is named like the target and declared by a node. It
+// reports false if there is no such function or several.
+func typedTarget(targetName string, refs []*types.Func, bySymbol map[*types.Func]string) (string, bool) {
+	name := targetName[strings.LastIndex(targetName, ".")+1:]
+	found := ""
+	for _, fn := range refs {
+		key, ok := bySymbol[fn]
+		if !ok || fn.Name() != name {
+			continue
+		}
+		if found != "" && found != key {
+			return "", false
+		}
+		found = key
+	}
+	return found, found != ""
+}
+
+// relinkCallSite moves a call site of caller from its current target to the node at target,
+// removing the unresolved stub node it pointed to if nothing else calls it.
+func relinkCallSite(graph *TemporalGraph, caller string, call *CallSite, target string) {
+	if old := graph.Nodes[call.TargetName]; old != nil {
+		stillCalled := false
+		calledBy := old.CalledBy[:0]
+		for _, ref := range old.CalledBy {
+			if ref.Name == caller && ref.FilePath == call.FilePath && ref.LineNumber == call.LineNumber {
+				continue
+			}
+			stillCalled = stillCalled || ref.Name == caller
+			calledBy = append(calledBy, ref)
+		}
+		old.CalledBy = calledBy
+		if !stillCalled {
+			parents := old.Parents[:0]
+			for _, parent := range old.Parents {
+				if parent != caller {
+					parents = append(parents, parent)
+				}
+			}
+			old.Parents = parents
+		}
+		if old.Unresolved && len(old.CalledBy) == 0 {
+			delete(graph.Nodes, call.TargetName)
+		}
+	}
+
+	call.TargetName = target
+	call.Candidates = nil
+	node := graph.Nodes[target]
+	if !slices.Contains(node.Parents, caller) {
+		node.Parents = append(node.Parents, caller)
+	}
+	node.CalledBy = append(node.CalledBy, ParentRef{
+		Name:       caller,
+		FilePath:   call.FilePath,
+		LineNumber: call.LineNumber,
+		CallType:   call.CallType,
+	})
+}
@@ -0,0 +1,156 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+const typedOrders = `package orders
+
+import (
+	"context"
+
+	"example.com/shop/billing"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+type OrderActivities struct{}
+
+func (a *OrderActivities) ProcessOrder(ctx context.Context, id string) error { return nil }
+
+type RefundActivities struct{}
+
+func (r *RefundActivities) ProcessOrder(ctx context.Context, id string) error { return nil }
+
+type Activities struct{}
+
+func (a *Activities) Charge(ctx context.Context, id string) error { return nil }
+
+// PaymentActivities are never registered
+type PaymentActivities struct{}
+
+func (p *PaymentActivities) ChargeActivity(ctx context.Context, id string) error { return nil }
+
+func OrderWorkflow(ctx workflow.Context, id string) error {
+	var a *OrderActivities
+	var b *billing.Activities
+	p, q := &PaymentActivities{}, &PaymentActivities{}
+	if err := workflow.ExecuteActivity(ctx, a.ProcessOrder, id).Get(ctx, nil); err != nil {
+		return err
+	}
+	for i := 0; i < 3; i++ {
+		if err := workflow.ExecuteActivity(ctx, p.ChargeActivity, id).Get(ctx, nil); err != nil {
+			return err
+		}
+	}
+	if err := workflow.ExecuteActivity(ctx, q.ChargeActivity, id).Get(ctx, nil); err != nil {
+		return err
+	}
+	if err := workflow.ExecuteActivity(ctx, "ProcessRefund", id).Get(ctx, nil); err != nil {
+		return err
+	}
+	return workflow.ExecuteActivity(ctx, b.Charge, id).Get(ctx, nil)
+}
+
+func Register(worker worker.Worker) {
+	worker.RegisterWorkflow(OrderWorkflow)
+	worker.RegisterActivity(&OrderActivities{})
+	worker.RegisterActivity(&RefundActivities{})
+	worker.RegisterActivity(&Activities{})
+	worker.RegisterActivity(&billing.Activities{})
+}
+`
+
+const typedBilling = `package billing
+
+import "context"
+
+type Activities struct{}
+
+func (a *Activities) Charge(ctx context.Context, id string) error { return nil }
+`
+
+func TestResolveTypedCalls(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"go.mod":             "module example.com/shop\n\ngo 1.22\n",
+		"orders/orders.go":   typedOrders,
+		"billing/billing.go": typedBilling,
+	})
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// By name, method values of types sharing a method name can't be told apart
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if node := graph.Nodes["a.ProcessOrder"]; node == nil || !node.Unresolved {
+		t.Fatalf("Expected a.ProcessOrder to be unresolved without --typed")
+	}
+
+	graph, err = a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir, Typed: true})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	var targets []string
+	for _, call := range graph.Nodes["OrderWorkflow"].CallSites {
+		targets = append(targets, call.TargetName)
+	}
+	want := []string{"*OrderActivities.ProcessOrder", "*PaymentActivities.ChargeActivity", "*PaymentActivities.ChargeActivity", "ProcessRefund", "billing.*Activities.Charge"}
+	if len(targets) != len(want) {
+		t.Fatalf("Call targets = %v, want %v", targets, want)
+	}
+	for i := range want {
+		if targets[i] != want[i] {
+			t.Errorf("Call targets = %v, want %v", targets, want)
+			break
+		}
+	}
+
+	for _, stub := range []string{"a.ProcessOrder", "b.Charge", "p.ChargeActivity", "q.ChargeActivity"} {
+		if _, ok := graph.Nodes[stub]; ok {
+			t.Errorf("Expected the unresolved node %s to be removed", stub)
+		}
+	}
+	if refs := graph.Nodes["*OrderActivities.ProcessOrder"].CalledBy; len(refs) != 1 || refs[0].Name != "OrderWorkflow" {
+		t.Errorf("*OrderActivities.ProcessOrder CalledBy = %+v, want OrderWorkflow", refs)
+	}
+	if refs := graph.Nodes["*RefundActivities.ProcessOrder"].CalledBy; len(refs) != 0 {
+		t.Errorf("*RefundActivities.ProcessOrder CalledBy = %+v, want none", refs)
+	}
+	if got := graph.Nodes["billing.*Activities.Charge"].Symbol; got != "(*example.com/shop/billing.Activities).Charge" {
+		t.Errorf("Symbol = %q", got)
+	}
+	if got := graph.Nodes["OrderWorkflow"].Symbol; got != "example.com/shop/orders.OrderWorkflow" {
+		t.Errorf("Symbol = %q", got)
+	}
+
+	// The activity method declared but never registered gets a node
+	payment := graph.Nodes["*PaymentActivities.ChargeActivity"]
+	if payment == nil || payment.Unresolved || payment.Type != "activity" || len(payment.CalledBy) != 2 {
+		t.Fatalf("*PaymentActivities.ChargeActivity = %+v, want an activity called twice", payment)
+	}
+	if payment.Symbol != "(*example.com/shop/orders.PaymentActivities).ChargeActivity" {
+		t.Errorf("Symbol = %q", payment.Symbol)
+	}
+
+	// Call sites relinked to one target are weighed as one edge
+	var edge Edge
+	for _, e := range graph.Nodes["OrderWorkflow"].Edges() {
+		if e.To == "*PaymentActivities.ChargeActivity" {
+			edge = e
+		}
+	}
+	if edge.CallSites != 2 || edge.InLoop != 1 || edge.Weight != LoopCallWeight+1 {
+		t.Errorf("Edge to *PaymentActivities.ChargeActivity = %+v, want 2 call sites, 1 in a loop", edge)
+	}
+	if graph.Stats.DistinctConnections != 4 || graph.Stats.WeightedConnections != 4+LoopCallWeight {
+		t.Errorf("Stats connections = %d distinct, %d weighted, want 4 and %d",
+			graph.Stats.DistinctConnections, graph.Stats.WeightedConnections, 4+LoopCallWeight)
+	}
+}
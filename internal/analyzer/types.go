@@ -3,45 +3,211 @@
 package analyzer
 
 import (
+	"encoding/json"
 	"go/ast"
 	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // TemporalNode represents a workflow or activity in the temporal graph.
 type TemporalNode struct {
-	Name        string            `json:"name"`
-	Type        string            `json:"type"` // "workflow", "activity", "signal", "query", "update"
+	Name string `json:"name"`
+	// Key is the package-qualified graph key ("package.Name" or "dir/package.Name") of a name
+	// defined in several packages; it is empty when Name is unique.
+	Key         string            `json:"key,omitempty"`
+	// Symbol is the fully-qualified symbol of the node's function with --typed, e.g.
+	// "(*example.com/shop/orders.Activities).Charge"
+	Symbol      string            `json:"symbol,omitempty"`
+	// Aliases are the names the node is registered under with RegisterOptions{Name: ...},
+	// which calls by name (ExecuteActivity(ctx, "v2.Charge")) resolve to the node
+	Aliases     []string          `json:"aliases,omitempty"`
+	Type        string            `json:"type"` // "workflow", "activity", "signal", "query", "update", "helper"
 	Package     string            `json:"package"`
+	Domain      string            `json:"domain,omitempty"` // Business domain from the configured package mappings
+	// TaskQueue is the task queue of the worker the workflow or activity is registered on,
+	// the first one found if several workers register it
+	TaskQueue   string            `json:"task_queue,omitempty"`
 	FilePath    string            `json:"file_path"`
 	LineNumber  int               `json:"line_number"`
 	Description string            `json:"description,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"` // Doc comment annotations (@owner team-x -> owner: team-x)
+	Tags        []string          `json:"tags,omitempty"`        // Sorted tags from the metadata overlay, e.g. critical
 	Parameters  map[string]string `json:"parameters,omitempty"`
-	ReturnType  string            `json:"return_type,omitempty"`
+	// ParameterTypes lists the parameter types in declaration order, including context parameters
+	ParameterTypes []string `json:"parameter_types,omitempty"`
+	// ReturnType is the first result type; ReturnTypes lists all of them, e.g. [Result error]
+	ReturnType  string   `json:"return_type,omitempty"`
+	ReturnTypes []string `json:"return_types,omitempty"`
 
 	// Relationship data
 	CallSites     []CallSite     `json:"call_sites,omitempty"`
 	InternalCalls []InternalCall `json:"internal_calls,omitempty"` // Non-Temporal function calls
 	Parents       []string       `json:"parents,omitempty"`
+	CalledBy      []ParentRef    `json:"called_by,omitempty"` // One entry per incoming call site
 
 	// Temporal-specific metadata
-	Signals       []SignalDef       `json:"signals,omitempty"`
-	Queries       []QueryDef        `json:"queries,omitempty"`
-	Updates       []UpdateDef       `json:"updates,omitempty"`
-	Timers        []TimerDef        `json:"timers,omitempty"`
-	SearchAttrs   []SearchAttrDef   `json:"search_attrs,omitempty"`
-	WorkflowOpts  *WorkflowOptions  `json:"workflow_opts,omitempty"`
-	ActivityOpts  *ActivityOptions  `json:"activity_opts,omitempty"`
-	ChildWorkflow []ChildWorkflow   `json:"child_workflows,omitempty"`
-	LocalActivity []LocalActivity   `json:"local_activities,omitempty"`
-	ContinueAsNew *ContinueAsNewDef `json:"continue_as_new,omitempty"`
-	Versioning    []VersionDef      `json:"versioning,omitempty"`
+	Signals        []SignalDef       `json:"signals,omitempty"`
+	Queries        []QueryDef        `json:"queries,omitempty"`
+	Updates        []UpdateDef       `json:"updates,omitempty"`
+	Timers         []TimerDef        `json:"timers,omitempty"`
+	SignalReceives []SignalReceive   `json:"signal_receives,omitempty"`
+	LogCalls       []LogCall         `json:"log_calls,omitempty"`       // Logging that bypasses workflow.GetLogger (workflows only)
+	MetricCalls    []MetricCall      `json:"metric_calls,omitempty"`    // Metrics that bypass workflow.GetMetricsHandler (workflows only)
+	ContextEscapes []ContextEscape   `json:"context_escapes,omitempty"` // workflow.Context stored or shared unsafely (workflows only)
+	SearchAttrs    []SearchAttrDef   `json:"search_attrs,omitempty"`
+	WorkflowOpts   *WorkflowOptions  `json:"workflow_opts,omitempty"`
+	ActivityOpts   *ActivityOptions  `json:"activity_opts,omitempty"`
+	ChildWorkflow  []ChildWorkflow   `json:"child_workflows,omitempty"`
+	LocalActivity  []LocalActivity   `json:"local_activities,omitempty"`
+	ContinueAsNew  *ContinueAsNewDef `json:"continue_as_new,omitempty"`
+	Versioning     []VersionDef      `json:"versioning,omitempty"`
+
+	// Test coverage (from testsuite usage in _test.go files)
+	Tests []TestReference `json:"tests,omitempty"`
+
+	// Git history of the defining file (with --churn)
+	Churn *ChurnInfo `json:"churn,omitempty"`
+
+	// TestOrigin marks nodes declared in _test.go files, analyzed with --include-tests; lint
+	// skips them unless --lint-tests is given
+	TestOrigin bool `json:"test_origin,omitempty"`
+
+	// Unresolved marks a synthetic node for a call target that is not defined in the analyzed code
+	Unresolved bool `json:"unresolved,omitempty"`
+
+	// DetectionConfidence is how reliably the node was detected: high, medium or low
+	DetectionConfidence string `json:"detection_confidence,omitempty"`
+	// DetectionReasons is the evidence the node was detected from, e.g. registered, signature
+	DetectionReasons []string `json:"detection_reasons,omitempty"`
+
+	// Collapsed counts, by type, the nodes merged into a synthetic "package" node by PruneGraph,
+	// or the nodes hidden under a node collapsed in an exported TUI tree
+	Collapsed map[string]int `json:"collapsed,omitempty"`
+}
+
+// ID returns the node's key in TemporalGraph.Nodes, which parents, call sites and lint issues refer to.
+func (n *TemporalNode) ID() string {
+	if n.Key != "" {
+		return n.Key
+	}
+	return n.Name
+}
+
+// HasTag reports whether the metadata overlay tagged the node with tag.
+func (n *TemporalNode) HasTag(tag string) bool {
+	for _, t := range n.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Children returns the distinct call targets of the node, in call order.
+//
+// Deprecated: Children is derived from CallSites for consumers of the removed
+// children field. Use CallSites, which also carry call types and locations.
+func (n *TemporalNode) Children() []string {
+	var children []string
+	seen := make(map[string]bool, len(n.CallSites))
+	for _, call := range n.CallSites {
+		if !seen[call.TargetName] {
+			seen[call.TargetName] = true
+			children = append(children, call.TargetName)
+		}
+	}
+	return children
+}
+
+// CallFrom returns the first recorded call into the node from the named caller.
+func (n *TemporalNode) CallFrom(caller string) (ParentRef, bool) {
+	for _, ref := range n.CalledBy {
+		if ref.Name == caller {
+			return ref, true
+		}
+	}
+	return ParentRef{}, false
+}
+
+// results returns the node's result types, falling back to ReturnType for nodes built
+// without ReturnTypes.
+func (n *TemporalNode) results() []string {
+	if len(n.ReturnTypes) == 0 && n.ReturnType != "" {
+		return []string{n.ReturnType}
+	}
+	return n.ReturnTypes
+}
+
+// HasError reports whether the node's last result is an error.
+func (n *TemporalNode) HasError() bool {
+	results := n.results()
+	return len(results) > 0 && results[len(results)-1] == "error"
+}
+
+// ValueType returns the type of the first non-error result, the value a caller reads with
+// Future.Get, or "" when the node returns nothing or only an error.
+func (n *TemporalNode) ValueType() string {
+	for _, result := range n.results() {
+		if result != "error" {
+			return result
+		}
+	}
+	return ""
+}
+
+// ResultSignature renders the result types as in a Go signature: "T", "(T, error)" or "".
+func (n *TemporalNode) ResultSignature() string {
+	results := n.results()
+	if len(results) == 1 {
+		return results[0]
+	}
+	if len(results) > 1 {
+		return "(" + strings.Join(results, ", ") + ")"
+	}
+	return ""
+}
+
+// Signature renders the node's parameter and result types as a Go signature, e.g.
+// ProcessOrder(context.Context, OrderInput) (*Result, error). Nodes without ParameterTypes
+// list their named parameters sorted by name, as declaration order is unknown.
+func (n *TemporalNode) Signature() string {
+	params := n.ParameterTypes
+	if len(params) == 0 && len(n.Parameters) > 0 {
+		params = make([]string, 0, len(n.Parameters))
+		for name, typ := range n.Parameters {
+			params = append(params, name+" "+typ)
+		}
+		sort.Strings(params)
+	}
+
+	sig := n.Name + "(" + strings.Join(params, ", ") + ")"
+	if results := n.ResultSignature(); results != "" {
+		sig += " " + results
+	}
+	return sig
+}
+
+// IsTested returns true if any test exercises this node.
+func (n *TemporalNode) IsTested() bool {
+	return len(n.Tests) > 0
+}
+
+// ParentRef is an incoming call: the calling node and the location of the call in it.
+type ParentRef struct {
+	Name       string `json:"name"`
+	FilePath   string `json:"file_path,omitempty"`
+	LineNumber int    `json:"line_number,omitempty"`
+	CallType   string `json:"call_type,omitempty"`
 }
 
 // CallSite represents a location where a workflow or activity is called.
 type CallSite struct {
 	TargetName string   `json:"target_name"`
 	TargetType string   `json:"target_type,omitempty"` // "workflow", "activity", "signal", etc.
-	CallType   string   `json:"call_type,omitempty"`   // "execute", "signal", "query", "update"
+	CallType   string   `json:"call_type,omitempty"`   // "execute", "signal", "query", "update", "internal"
 	LineNumber int      `json:"line_number"`
 	FilePath   string   `json:"file_path"`
 	Options    []string `json:"options,omitempty"` // Activity/workflow options used
@@ -53,6 +219,28 @@ type CallSite struct {
 
 	// Parsed activity options from the call site
 	ParsedActivityOpts *ActivityOptions `json:"parsed_activity_opts,omitempty"`
+
+	// Parallel marks a call that runs concurrently with the next call site: its future is not
+	// awaited before the next call starts, or it runs in a workflow.Go function
+	Parallel bool `json:"parallel,omitempty"`
+	// LoopLine is the line of the innermost loop enclosing the call, if any
+	LoopLine int `json:"loop_line,omitempty"`
+
+	// ActivityOptionsContext marks a child workflow started with a context that carries
+	// activity options (workflow.WithActivityOptions) but no workflow.WithChildOptions
+	ActivityOptionsContext bool `json:"activity_options_context,omitempty"`
+
+	// Candidates lists the matching nodes when a bare target name is defined in several packages
+	// and none is in the caller's package; the call is then left unresolved.
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+// TestReference represents a test that exercises a workflow or activity.
+type TestReference struct {
+	TestName   string `json:"test_name"`
+	Kind       string `json:"kind"` // "workflow_test", "activity_test", "activity_mock", "replay"
+	FilePath   string `json:"file_path"`
+	LineNumber int    `json:"line_number"`
 }
 
 // InternalCall represents a regular Go function/method call within an activity or workflow.
@@ -74,6 +262,12 @@ type SignalDef struct {
 	LineNumber  int               `json:"line_number"`
 	Parameters  map[string]string `json:"parameters,omitempty"`
 	IsExternal  bool              `json:"is_external,omitempty"` // Signal sent from outside
+	// NameExpr is the name argument as written when it is not a string literal, e.g.
+	// StatusQuery; Name is set from it when it is a constant of the package
+	NameExpr string `json:"name_expr,omitempty"`
+	// Unreceived marks a GetSignalChannel channel that is never received from: it is
+	// discarded, or its variable is neither received from nor handed on
+	Unreceived bool `json:"unreceived,omitempty"`
 }
 
 // QueryDef represents a query definition in a workflow.
@@ -83,6 +277,8 @@ type QueryDef struct {
 	ReturnType  string            `json:"return_type,omitempty"`
 	LineNumber  int               `json:"line_number"`
 	Parameters  map[string]string `json:"parameters,omitempty"`
+	// NameExpr is the name argument as written when it is not a string literal, as in SignalDef
+	NameExpr string `json:"name_expr,omitempty"`
 }
 
 // UpdateDef represents an update definition in a workflow (Temporal SDK 1.20+).
@@ -93,6 +289,8 @@ type UpdateDef struct {
 	ReturnType  string            `json:"return_type,omitempty"`
 	LineNumber  int               `json:"line_number"`
 	Parameters  map[string]string `json:"parameters,omitempty"`
+	// NameExpr is the name argument as written when it is not a string literal, as in SignalDef
+	NameExpr string `json:"name_expr,omitempty"`
 }
 
 // TimerDef represents a timer used in a workflow.
@@ -100,7 +298,43 @@ type TimerDef struct {
 	Name       string `json:"name,omitempty"`
 	Duration   string `json:"duration"`
 	LineNumber int    `json:"line_number"`
-	IsSleep    bool   `json:"is_sleep"` // workflow.Sleep vs workflow.NewTimer
+	IsSleep    bool   `json:"is_sleep"`            // workflow.Sleep vs workflow.NewTimer
+	LoopLine   int    `json:"loop_line,omitempty"` // Line of the innermost enclosing loop, if any
+	// Value is Duration in nanoseconds when it is a constant expression (0 otherwise)
+	Value time.Duration `json:"duration_ns,omitempty"`
+}
+
+// SignalReceive represents a receive from a signal channel in a workflow.
+type SignalReceive struct {
+	Signal     string `json:"signal,omitempty"`  // Signal name, when known
+	Channel    string `json:"channel,omitempty"` // Channel variable, when received through one
+	LineNumber int    `json:"line_number"`
+	Blocking   bool   `json:"blocking"`              // Receive, as opposed to ReceiveAsync or ReceiveWithTimeout
+	InSelector bool   `json:"in_selector,omitempty"` // Inside a Selector callback, where the value is ready
+	LoopLine   int    `json:"loop_line,omitempty"`   // Line of the innermost enclosing loop, if any
+}
+
+// LogCall represents a logging call in a workflow that bypasses workflow.GetLogger.
+type LogCall struct {
+	Call       string `json:"call"`              // Logging call as written, e.g. "fmt.Printf" or "zap.L().Info"
+	Level      string `json:"level"`             // Matching workflow logger method: Debug, Info, Warn or Error
+	Message    string `json:"message,omitempty"` // Quoted message literal, when present
+	LineNumber int    `json:"line_number"`
+}
+
+// ContextEscape represents a workflow.Context leaving the workflow's deterministic scope.
+type ContextEscape struct {
+	Kind       string `json:"kind"`    // One of the Escape constants: field, global, goroutine or coroutine
+	Target     string `json:"target"`  // Where it escapes to, e.g. "s.ctx", "currentCtx" or "go process"
+	Context    string `json:"context"` // Context variable that escapes, e.g. "ctx"
+	LineNumber int    `json:"line_number"`
+}
+
+// MetricCall represents a metric recorded in a workflow without workflow.GetMetricsHandler.
+type MetricCall struct {
+	Call       string `json:"call"` // Recording call, e.g. "ordersTotal.WithLabelValues().Inc"
+	Kind       string `json:"kind"` // Metrics client: prometheus, statsd or metrics
+	LineNumber int    `json:"line_number"`
 }
 
 // SearchAttrDef represents a search attribute used in a workflow.
@@ -135,6 +369,22 @@ type ActivityOptions struct {
 	RetryPolicy            *RetryPolicy `json:"retry_policy,omitempty"`
 	WaitForCancellation    bool         `json:"wait_for_cancellation,omitempty"`
 
+	// Values of the timeouts above, in nanoseconds, when they are constant expressions of
+	// numbers, time units and constants of the calling package (0 otherwise)
+	ScheduleToStartValue time.Duration `json:"schedule_to_start_ns,omitempty"`
+	StartToCloseValue    time.Duration `json:"start_to_close_ns,omitempty"`
+	HeartbeatValue       time.Duration `json:"heartbeat_ns,omitempty"`
+	ScheduleToCloseValue time.Duration `json:"schedule_to_close_ns,omitempty"`
+
+	// InheritedFrom lists the callers whose context options were propagated into this call site
+	// (set when a helper executes activities with a workflow.Context it received as a parameter)
+	InheritedFrom []string `json:"inherited_from,omitempty"`
+	// CallerDependent indicates the options come from the caller's context but could not be determined
+	CallerDependent bool `json:"caller_dependent,omitempty"`
+	// Unparsed indicates options were passed as a variable, field or function result whose
+	// value could not be read statically
+	Unparsed bool `json:"unparsed,omitempty"`
+
 	// optionsProvided indicates that activity options were specified (even if we couldn't parse them)
 	optionsProvided bool
 }
@@ -144,6 +394,30 @@ func (ao *ActivityOptions) OptionsProvided() bool {
 	return ao != nil && ao.optionsProvided
 }
 
+// MarshalJSON encodes the options with options_provided, so that graphs loaded from JSON
+// keep telling options that were specified from options that weren't.
+func (ao *ActivityOptions) MarshalJSON() ([]byte, error) {
+	type plain ActivityOptions
+	return json.Marshal(struct {
+		*plain
+		OptionsProvided bool `json:"options_provided,omitempty"`
+	}{(*plain)(ao), ao.optionsProvided})
+}
+
+// UnmarshalJSON decodes options encoded by MarshalJSON.
+func (ao *ActivityOptions) UnmarshalJSON(data []byte) error {
+	type plain ActivityOptions
+	v := struct {
+		*plain
+		OptionsProvided bool `json:"options_provided"`
+	}{plain: (*plain)(ao)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	ao.optionsProvided = v.OptionsProvided
+	return nil
+}
+
 // HasRetryPolicy returns true if a retry policy was specified.
 func (ao *ActivityOptions) HasRetryPolicy() bool {
 	if ao == nil || ao.RetryPolicy == nil {
@@ -156,9 +430,49 @@ func (ao *ActivityOptions) HasRetryPolicy() bool {
 		rp.BackoffCoefficient != "" ||
 		rp.MaximumInterval != "" ||
 		rp.MaximumAttempts > 0 ||
+		rp.MaximumAttemptsSet() ||
 		len(rp.NonRetryableErrors) > 0
 }
 
+// OptionSetting is a single option value set at a call site.
+type OptionSetting struct {
+	Name  string
+	Value string
+}
+
+// Settings returns the options that have a value, as name/value pairs in declaration order.
+func (ao *ActivityOptions) Settings() []OptionSetting {
+	if ao == nil {
+		return nil
+	}
+	var settings []OptionSetting
+	add := func(name, value string) {
+		if value != "" {
+			settings = append(settings, OptionSetting{Name: name, Value: value})
+		}
+	}
+	add("TaskQueue", ao.TaskQueue)
+	add("StartToCloseTimeout", ao.StartToCloseTimeout)
+	add("ScheduleToCloseTimeout", ao.ScheduleToCloseTimeout)
+	add("ScheduleToStartTimeout", ao.ScheduleToStartTimeout)
+	add("HeartbeatTimeout", ao.HeartbeatTimeout)
+	if rp := ao.RetryPolicy; rp != nil {
+		add("RetryPolicy.InitialInterval", rp.InitialInterval)
+		add("RetryPolicy.BackoffCoefficient", rp.BackoffCoefficient)
+		add("RetryPolicy.MaximumInterval", rp.MaximumInterval)
+		if rp.MaximumAttemptsSet() {
+			add("RetryPolicy.MaximumAttempts", rp.MaximumAttemptsExpr)
+		} else if rp.MaximumAttempts > 0 {
+			add("RetryPolicy.MaximumAttempts", strconv.Itoa(rp.MaximumAttempts))
+		}
+		add("RetryPolicy.NonRetryableErrors", strings.Join(rp.NonRetryableErrors, ", "))
+	}
+	if ao.WaitForCancellation {
+		add("WaitForCancellation", "true")
+	}
+	return settings
+}
+
 // RetryPolicy represents a retry policy configuration.
 type RetryPolicy struct {
 	InitialInterval    string   `json:"initial_interval,omitempty"`
@@ -167,6 +481,13 @@ type RetryPolicy struct {
 	MaximumAttempts    int      `json:"maximum_attempts,omitempty"`
 	NonRetryableErrors []string `json:"non_retryable_errors,omitempty"`
 
+	// MaximumAttemptsExpr is MaximumAttempts as written, "" when it is not set. An explicit 0
+	// means unlimited attempts, like leaving it unset, and 1 means no retries.
+	MaximumAttemptsExpr string `json:"maximum_attempts_expr,omitempty"`
+	// MaximumAttemptsUnresolved is true when MaximumAttemptsExpr is not a constant expression
+	// of the calling package, so MaximumAttempts is unknown
+	MaximumAttemptsUnresolved bool `json:"maximum_attempts_unresolved,omitempty"`
+
 	// policyProvided indicates that a retry policy was specified (even if we couldn't parse details)
 	policyProvided bool
 }
@@ -176,6 +497,34 @@ func (rp *RetryPolicy) PolicyProvided() bool {
 	return rp != nil && rp.policyProvided
 }
 
+// MarshalJSON encodes the retry policy with policy_provided.
+func (rp *RetryPolicy) MarshalJSON() ([]byte, error) {
+	type plain RetryPolicy
+	return json.Marshal(struct {
+		*plain
+		PolicyProvided bool `json:"policy_provided,omitempty"`
+	}{(*plain)(rp), rp.policyProvided})
+}
+
+// UnmarshalJSON decodes a retry policy encoded by MarshalJSON.
+func (rp *RetryPolicy) UnmarshalJSON(data []byte) error {
+	type plain RetryPolicy
+	v := struct {
+		*plain
+		PolicyProvided bool `json:"policy_provided"`
+	}{plain: (*plain)(rp)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	rp.policyProvided = v.PolicyProvided
+	return nil
+}
+
+// MaximumAttemptsSet returns true if MaximumAttempts is set explicitly, even to 0.
+func (rp *RetryPolicy) MaximumAttemptsSet() bool {
+	return rp != nil && rp.MaximumAttemptsExpr != ""
+}
+
 // ChildWorkflow represents a child workflow execution.
 type ChildWorkflow struct {
 	Name            string           `json:"name"`
@@ -207,8 +556,41 @@ type VersionDef struct {
 
 // TemporalGraph represents the complete graph of temporal workflows and activities.
 type TemporalGraph struct {
-	Nodes map[string]*TemporalNode `json:"nodes"`
-	Stats GraphStats               `json:"stats"`
+	Nodes   map[string]*TemporalNode `json:"nodes"`
+	Stats   GraphStats               `json:"stats"`
+	Workers []WorkerConfig           `json:"workers,omitempty"` // Workers created with worker.New
+	// EntryPoints are the HTTP routes and gRPC methods whose handlers start workflows
+	EntryPoints []EntryPoint `json:"entry_points,omitempty"`
+	// Messages are the signals sent to workflows and the queries and updates issued against them
+	Messages []MessageCall `json:"messages,omitempty"`
+	// WorkflowIDs are the workflow IDs clients start workflows with, normalized into patterns
+	WorkflowIDs []WorkflowID `json:"workflow_ids,omitempty"`
+	// FileErrors are the files that could not be parsed or whose analysis panicked
+	FileErrors []FileError `json:"file_errors,omitempty"`
+	// Engine is the analyzer that produced the graph
+	Engine *EngineInfo `json:"engine,omitempty"`
+}
+
+// UnresolvedTargets returns the sorted names of call targets that are not defined in the
+// analyzed code: synthetic unresolved nodes and calls whose name matches several packages.
+func (g *TemporalGraph) UnresolvedTargets() []string {
+	seen := make(map[string]bool)
+	for name, node := range g.Nodes {
+		if node.Unresolved {
+			seen[name] = true
+		}
+		for _, call := range node.CallSites {
+			if len(call.Candidates) > 0 {
+				seen[call.TargetName] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // GraphStats contains statistics about the temporal graph.
@@ -225,15 +607,30 @@ type GraphStats struct {
 	TotalConnections int `json:"total_connections"`
 	AvgFanOut        float64 `json:"avg_fan_out"`
 	MaxFanOut        int `json:"max_fan_out"`
+	// DistinctConnections counts the caller, target and call type edges behind the
+	// TotalConnections call sites; WeightedConnections sums their Edge weights
+	DistinctConnections int `json:"distinct_connections"`
+	WeightedConnections int `json:"weighted_connections"`
+	UnresolvedTargets int `json:"unresolved_targets"` // Call targets not found in the analyzed code
+	FailedFiles       int `json:"failed_files,omitempty"` // Go files that failed to analyze
+
+	// Coupling between business domains (only with domain mappings)
+	CrossDomainCalls int              `json:"cross_domain_calls,omitempty"`
+	DomainCoupling   []DomainCoupling `json:"domain_coupling,omitempty"` // Most coupled domain pairs first
 }
 
 // NodeMatch represents a parsed AST node with its metadata.
 type NodeMatch struct {
-	Node     ast.Node
-	FileSet  *token.FileSet
-	FilePath string
-	Package  string
-	NodeType string // "workflow", "activity", "signal_handler", "query_handler", "update_handler"
+	Node      ast.Node
+	FileSet   *token.FileSet
+	FilePath  string
+	Package   string
+	NodeType  string           // "workflow", "activity", "signal_handler", "query_handler", "update_handler", "helper"
+	Aliases   TypeAliases      // Type aliases declared in the match's package directory
+	Constants PackageConstants // Constants declared in the match's package directory
+	Funcs     *FuncIndex       // Functions of the analyzed packages, set with --internal-depth
+	Reasons   []string         // Evidence the function was classified from, e.g. registered, signature
+	Names     []string         // Names the function is registered under with RegisterOptions{Name: ...}
 }
 
 // NodeCategory groups node types for display purposes.
@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -96,6 +97,57 @@ func TestTemporalNodeStructure(t *testing.T) {
 	}
 }
 
+func TestTemporalNodeResults(t *testing.T) {
+	tests := []struct {
+		name          string
+		node          TemporalNode
+		wantHasError  bool
+		wantValueType string
+		wantSignature string
+	}{
+		{name: "no results", node: TemporalNode{}},
+		{
+			name:          "value and error",
+			node:          TemporalNode{ReturnType: "*Result", ReturnTypes: []string{"*Result", "error"}},
+			wantHasError:  true,
+			wantValueType: "*Result",
+			wantSignature: "(*Result, error)",
+		},
+		{
+			name:          "error only",
+			node:          TemporalNode{ReturnType: "error", ReturnTypes: []string{"error"}},
+			wantHasError:  true,
+			wantSignature: "error",
+		},
+		{
+			name:          "value only",
+			node:          TemporalNode{ReturnType: "string", ReturnTypes: []string{"string"}},
+			wantValueType: "string",
+			wantSignature: "string",
+		},
+		{
+			name:          "ReturnType without ReturnTypes",
+			node:          TemporalNode{ReturnType: "error"},
+			wantHasError:  true,
+			wantSignature: "error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.node.HasError(); got != tt.wantHasError {
+				t.Errorf("HasError() = %v, want %v", got, tt.wantHasError)
+			}
+			if got := tt.node.ValueType(); got != tt.wantValueType {
+				t.Errorf("ValueType() = %q, want %q", got, tt.wantValueType)
+			}
+			if got := tt.node.ResultSignature(); got != tt.wantSignature {
+				t.Errorf("ResultSignature() = %q, want %q", got, tt.wantSignature)
+			}
+		})
+	}
+}
+
 func TestCallSiteStructure(t *testing.T) {
 	cs := CallSite{
 		TargetName: "DoActivity",
@@ -380,3 +432,35 @@ func TestValidationIssueStructure(t *testing.T) {
 		t.Errorf("Severity = %d, want %d", vi.Severity, 5)
 	}
 }
+
+func TestTemporalNodeChildren(t *testing.T) {
+	node := &TemporalNode{CallSites: []CallSite{
+		{TargetName: "Charge", CallType: "activity"},
+		{TargetName: "Ship", CallType: "local_activity"},
+		{TargetName: "Charge", CallType: "activity"},
+	}}
+
+	children := node.Children()
+	if len(children) != 2 || children[0] != "Charge" || children[1] != "Ship" {
+		t.Errorf("Children() = %v, want [Charge Ship]", children)
+	}
+	if (&TemporalNode{}).Children() != nil {
+		t.Error("Children() of a node without calls should be nil")
+	}
+}
+
+func TestUnresolvedTargets(t *testing.T) {
+	graph := &TemporalGraph{Nodes: map[string]*TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", CallSites: []CallSite{
+			{TargetName: "SendEmail", TargetType: "activity"},
+			{TargetName: "Charge", TargetType: "activity", Candidates: []string{"billing.Charge", "payments.Charge"}},
+			{TargetName: "Reserve", TargetType: "activity"},
+		}},
+		"SendEmail": {Name: "SendEmail", Type: "activity", Unresolved: true},
+		"Reserve":   {Name: "Reserve", Type: "activity"},
+	}}
+
+	if got := strings.Join(graph.UnresolvedTargets(), ","); got != "Charge,SendEmail" {
+		t.Errorf("UnresolvedTargets() = %s, want Charge,SendEmail", got)
+	}
+}
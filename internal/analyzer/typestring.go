@@ -0,0 +1,185 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxAliasDepth bounds alias chains (type A = B; type B = C) followed when rendering types.
+const maxAliasDepth = 8
+
+// TypeAliases maps the type aliases declared in a package (type OrderID = string) to
+// their targets.
+type TypeAliases map[string]ast.Expr
+
+// TypeString renders a type expression as written in Go source. Anonymous structs are
+// rendered with their fields, e.g. struct{ID string; Items []Item}.
+func TypeString(expr ast.Expr) string {
+	return TypeAliases(nil).String(expr)
+}
+
+// String renders a type expression like TypeString, with the aliases replaced by their targets.
+func (a TypeAliases) String(expr ast.Expr) string {
+	var sb strings.Builder
+	a.write(&sb, expr, 0)
+	return sb.String()
+}
+
+// PackageAliases collects the type aliases declared in the non-test Go files of a
+// directory. Files that fail to parse are skipped.
+func PackageAliases(dir string) TypeAliases {
+	aliases := make(TypeAliases)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return aliases
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		aliases.collectAliases(file)
+	}
+	return aliases
+}
+
+// collectAliases records the type aliases declared in a file.
+func (a TypeAliases) collectAliases(file *ast.File) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok && ts.Assign.IsValid() && ts.TypeParams == nil {
+				a[ts.Name.Name] = ts.Type
+			}
+		}
+	}
+}
+
+func (a TypeAliases) write(sb *strings.Builder, expr ast.Expr, depth int) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if target, ok := a[t.Name]; ok && depth < maxAliasDepth {
+			a.write(sb, target, depth+1)
+			return
+		}
+		sb.WriteString(t.Name)
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			sb.WriteString(pkg.Name)
+			sb.WriteByte('.')
+		}
+		sb.WriteString(t.Sel.Name)
+	case *ast.StarExpr:
+		sb.WriteByte('*')
+		a.write(sb, t.X, depth)
+	case *ast.ParenExpr:
+		a.write(sb, t.X, depth)
+	case *ast.ArrayType:
+		sb.WriteByte('[')
+		switch n := t.Len.(type) {
+		case nil:
+		case *ast.BasicLit:
+			sb.WriteString(n.Value)
+		case *ast.Ellipsis:
+			sb.WriteString("...")
+		default:
+			a.write(sb, n, depth)
+		}
+		sb.WriteByte(']')
+		a.write(sb, t.Elt, depth)
+	case *ast.MapType:
+		sb.WriteString("map[")
+		a.write(sb, t.Key, depth)
+		sb.WriteByte(']')
+		a.write(sb, t.Value, depth)
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.RECV:
+			sb.WriteString("<-chan ")
+		case ast.SEND:
+			sb.WriteString("chan<- ")
+		default:
+			sb.WriteString("chan ")
+		}
+		a.write(sb, t.Value, depth)
+	case *ast.Ellipsis:
+		sb.WriteString("...")
+		a.write(sb, t.Elt, depth)
+	case *ast.IndexExpr:
+		a.write(sb, t.X, depth)
+		sb.WriteByte('[')
+		a.write(sb, t.Index, depth)
+		sb.WriteByte(']')
+	case *ast.IndexListExpr:
+		a.write(sb, t.X, depth)
+		sb.WriteByte('[')
+		for i, index := range t.Indices {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			a.write(sb, index, depth)
+		}
+		sb.WriteByte(']')
+	case *ast.InterfaceType:
+		if t.Methods != nil && len(t.Methods.List) > 0 {
+			sb.WriteString("interface{...}")
+			return
+		}
+		sb.WriteString("interface{}")
+	case *ast.StructType:
+		sb.WriteString("struct{")
+		a.writeFields(sb, t.Fields, "; ", depth)
+		sb.WriteByte('}')
+	case *ast.FuncType:
+		sb.WriteString("func(")
+		a.writeFields(sb, t.Params, ", ", depth)
+		sb.WriteByte(')')
+		if t.Results != nil && len(t.Results.List) > 0 {
+			sb.WriteByte(' ')
+			if len(t.Results.List) == 1 && len(t.Results.List[0].Names) == 0 {
+				a.write(sb, t.Results.List[0].Type, depth)
+				return
+			}
+			sb.WriteByte('(')
+			a.writeFields(sb, t.Results, ", ", depth)
+			sb.WriteByte(')')
+		}
+	default:
+		sb.WriteString("unknown")
+	}
+}
+
+// writeFields writes a struct's fields or a function's parameters, names first.
+func (a TypeAliases) writeFields(sb *strings.Builder, fields *ast.FieldList, sep string, depth int) {
+	if fields == nil {
+		return
+	}
+	for i, field := range fields.List {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		for j, name := range field.Names {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(name.Name)
+		}
+		if len(field.Names) > 0 {
+			sb.WriteByte(' ')
+		}
+		a.write(sb, field.Type, depth)
+	}
+}
@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTypeString(t *testing.T) {
+	code := `package test
+
+type OrderID = string
+type Items = []Item
+type Loop = Loop
+
+var a int
+var b *int
+var c []string
+var d [3]int
+var e map[string]int
+var f interface{}
+var g interface{ String() string }
+var h pkg.Type
+var i struct{ ID string; Tags, Labels []string; pkg.Embedded }
+var j struct{}
+var k func(ctx context.Context, n int) error
+var l func() (int, error)
+var m <-chan int
+var n chan<- string
+var o List[int]
+var p Map[string, int]
+var q OrderID
+var r map[OrderID]Items
+var s Loop
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse code: %v", err)
+	}
+
+	aliases := make(TypeAliases)
+	aliases.collectAliases(file)
+
+	tests := map[string]struct{ plain, resolved string }{
+		"a": {"int", "int"},
+		"b": {"*int", "*int"},
+		"c": {"[]string", "[]string"},
+		"d": {"[3]int", "[3]int"},
+		"e": {"map[string]int", "map[string]int"},
+		"f": {"interface{}", "interface{}"},
+		"g": {"interface{...}", "interface{...}"},
+		"h": {"pkg.Type", "pkg.Type"},
+		"i": {"struct{ID string; Tags, Labels []string; pkg.Embedded}", "struct{ID string; Tags, Labels []string; pkg.Embedded}"},
+		"j": {"struct{}", "struct{}"},
+		"k": {"func(ctx context.Context, n int) error", "func(ctx context.Context, n int) error"},
+		"l": {"func() (int, error)", "func() (int, error)"},
+		"m": {"<-chan int", "<-chan int"},
+		"n": {"chan<- string", "chan<- string"},
+		"o": {"List[int]", "List[int]"},
+		"p": {"Map[string, int]", "Map[string, int]"},
+		"q": {"OrderID", "string"},
+		"r": {"map[OrderID]Items", "map[string][]Item"},
+		"s": {"Loop", "Loop"}, // self-referencing alias stops at the depth limit
+	}
+
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+		vs := gd.Specs[0].(*ast.ValueSpec)
+		name := vs.Names[0].Name
+		want := tests[name]
+		if got := TypeString(vs.Type); got != want.plain {
+			t.Errorf("TypeString(%s) = %q, want %q", name, got, want.plain)
+		}
+		if got := aliases.String(vs.Type); got != want.resolved {
+			t.Errorf("aliases.String(%s) = %q, want %q", name, got, want.resolved)
+		}
+	}
+}
+
+func TestTypeStringCommonTypes(t *testing.T) {
+	code := `package test
+
+var a int
+var b string
+var c *int
+var d []string
+var e map[string]int
+var f interface{}
+var g pkg.Type
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse code: %v", err)
+	}
+
+	tests := map[string]string{
+		"a": "int",
+		"b": "string",
+		"c": "*int",
+		"d": "[]string",
+		"e": "map[string]int",
+		"f": "interface{}",
+		"g": "pkg.Type",
+	}
+
+	for _, decl := range file.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok {
+			for _, spec := range gd.Specs {
+				if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Names) > 0 {
+					name := vs.Names[0].Name
+					expected := tests[name]
+					got := TypeString(vs.Type)
+					if got != expected {
+						t.Errorf("TypeString(%s) = %q, want %q", name, got, expected)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPackageAliases(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"types.go":      "package test\n\ntype OrderID = string\n",
+		"types_test.go": "package test\n\ntype TestOnly = int\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	aliases := PackageAliases(dir)
+	if _, ok := aliases["OrderID"]; !ok {
+		t.Errorf("PackageAliases() missing OrderID, got %v", aliases)
+	}
+	if _, ok := aliases["TestOnly"]; ok {
+		t.Errorf("PackageAliases() should skip test files, got %v", aliases)
+	}
+}
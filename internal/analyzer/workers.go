@@ -0,0 +1,479 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// DefaultMaxConcurrentActivityExecutionSize is the SDK default for worker.Options.MaxConcurrentActivityExecutionSize.
+const DefaultMaxConcurrentActivityExecutionSize = 1000
+
+// WorkerConfig describes a worker created with worker.New and the options it polls its task queue with.
+type WorkerConfig struct {
+	TaskQueue  string `json:"task_queue"`
+	FilePath   string `json:"file_path"`
+	LineNumber int    `json:"line_number"`
+
+	// Concurrency and rate limits (zero means the SDK default / unlimited)
+	MaxConcurrentActivityExecutionSize      int     `json:"max_concurrent_activity_execution_size,omitempty"`
+	MaxConcurrentLocalActivityExecutionSize int     `json:"max_concurrent_local_activity_execution_size,omitempty"`
+	MaxConcurrentWorkflowTaskExecutionSize  int     `json:"max_concurrent_workflow_task_execution_size,omitempty"`
+	WorkerActivitiesPerSecond               float64 `json:"worker_activities_per_second,omitempty"`
+	TaskQueueActivitiesPerSecond            float64 `json:"task_queue_activities_per_second,omitempty"`
+	MaxConcurrentActivityTaskPollers        int     `json:"max_concurrent_activity_task_pollers,omitempty"`
+	MaxConcurrentWorkflowTaskPollers        int     `json:"max_concurrent_workflow_task_pollers,omitempty"`
+
+	// Sticky execution (the cache size is process-wide, set with worker.SetStickyWorkflowCacheSize)
+	StickyCacheSize              int    `json:"sticky_cache_size,omitempty"`
+	StickyScheduleToStartTimeout string `json:"sticky_schedule_to_start_timeout,omitempty"`
+
+	// Interceptors lists the worker interceptors, by constructor or type name
+	Interceptors []string `json:"interceptors,omitempty"`
+
+	// Names of the workflows and activities registered on the worker
+	Workflows  []string `json:"workflows,omitempty"`
+	Activities []string `json:"activities,omitempty"`
+}
+
+// ActivitySlots returns how many activities the worker executes concurrently.
+func (w *WorkerConfig) ActivitySlots() int {
+	if w.MaxConcurrentActivityExecutionSize > 0 {
+		return w.MaxConcurrentActivityExecutionSize
+	}
+	return DefaultMaxConcurrentActivityExecutionSize
+}
+
+// RegistersWorkflow returns true if the workflow is registered on the worker.
+func (w *WorkerConfig) RegistersWorkflow(name string) bool {
+	return registers(w.Workflows, name)
+}
+
+// RegistersActivity returns true if the activity, or the struct it is a method of, is registered on the worker.
+func (w *WorkerConfig) RegistersActivity(name string) bool {
+	return registers(w.Activities, name)
+}
+
+// registers checks whether a node name (Func, Type.Method or *Type.Method) matches a registration.
+func registers(registered []string, name string) bool {
+	typeName, funcName := "", name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		typeName = strings.TrimPrefix(name[:i], "*")
+		funcName = name[i+1:]
+	}
+	for _, r := range registered {
+		if r == name || r == funcName || (typeName != "" && r == typeName) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnregisteredNodes returns the workflows and activities not registered on any worker, sorted by name.
+// It returns nil when the graph has no workers, as registrations can't be cross-referenced then.
+func (g *TemporalGraph) UnregisteredNodes() []*TemporalNode {
+	if len(g.Workers) == 0 {
+		return nil
+	}
+
+	var unregistered []*TemporalNode
+	for _, node := range g.Nodes {
+		if node.Type != "workflow" && node.Type != "activity" {
+			continue
+		}
+		registered := false
+		for i := range g.Workers {
+			if (node.Type == "workflow" && g.Workers[i].RegistersWorkflow(node.Name)) ||
+				(node.Type == "activity" && g.Workers[i].RegistersActivity(node.Name)) {
+				registered = true
+				break
+			}
+		}
+		if !registered {
+			unregistered = append(unregistered, node)
+		}
+	}
+
+	sort.Slice(unregistered, func(i, j int) bool { return unregistered[i].Name < unregistered[j].Name })
+	return unregistered
+}
+
+// ApplyWorkers sets the workers of the graph and the task queue of each workflow and
+// activity registered on one of them.
+func ApplyWorkers(graph *TemporalGraph, workers []WorkerConfig) {
+	graph.Workers = workers
+	for _, node := range graph.Nodes {
+		node.TaskQueue = ""
+		for i := range workers {
+			if (node.Type == "workflow" && workers[i].RegistersWorkflow(node.Name)) ||
+				(node.Type == "activity" && workers[i].RegistersActivity(node.Name)) {
+				node.TaskQueue = workers[i].TaskQueue
+				break
+			}
+		}
+	}
+}
+
+// QueueWorkers returns the workers polling a task queue. A queue named by a constant matches
+// the constant with or without its package qualifier, so that TaskQueue matches
+// orders.TaskQueue, but not billing.TaskQueue.
+func (g *TemporalGraph) QueueWorkers(queue string) []*WorkerConfig {
+	var workers []*WorkerConfig
+	for i := range g.Workers {
+		if sameQueue(g.Workers[i].TaskQueue, queue) {
+			workers = append(workers, &g.Workers[i])
+		}
+	}
+	return workers
+}
+
+// sameQueue compares task queue names, one of them possibly package-qualified.
+func sameQueue(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if strings.Contains(a, ".") == strings.Contains(b, ".") {
+		return false
+	}
+	return a[strings.LastIndex(a, ".")+1:] == b[strings.LastIndex(b, ".")+1:]
+}
+
+// workerScanner scans for worker.New calls and the registrations made on the created workers.
+type workerScanner struct {
+	logger *slog.Logger
+}
+
+// NewWorkerScanner creates a new worker scanner.
+func NewWorkerScanner(logger *slog.Logger) *workerScanner {
+	return &workerScanner{
+		logger: logger,
+	}
+}
+
+// ScanDirectory scans all Go files in a directory for worker definitions.
+func (s *workerScanner) ScanDirectory(ctx context.Context, rootDir string, opts config.AnalysisOptions) ([]WorkerConfig, error) {
+	var workers []WorkerConfig
+
+	fset := token.NewFileSet()
+
+	modules := NewModuleFilter(rootDir, opts)
+	err := filepath.Walk(rootDir, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			s.logger.Warn("Error accessing path during worker scan", "path", path, "error", err)
+			return nil // Continue walking
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if fileInfo.IsDir() {
+			if opts.ExcludesDir(fileInfo.Name()) {
+				return filepath.SkipDir
+			}
+			if modules.SkipDir(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		if !opts.IncludeTests && IsTestFile(path) {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			s.logger.Warn("Error parsing file for workers", "path", path, "error", err)
+			return nil
+		}
+
+		if err := recovered(func() { workers = append(workers, s.scanFile(ctx, file, fset, path)...) }); err != nil {
+			s.logger.Warn("Error scanning file for workers", "path", path, "error", err)
+			recordFileError(ctx, StageWorkers, path, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Scanned for workers", "workers", len(workers))
+
+	return workers, nil
+}
+
+// scanFile scans the functions of a single file for worker.New calls.
+// Registrations are attributed to the worker variable they are made on within the same function.
+func (s *workerScanner) scanFile(ctx context.Context, file *ast.File, fset *token.FileSet, filePath string) []WorkerConfig {
+	var workers []WorkerConfig
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+
+		optionLits := make(map[string]*ast.CompositeLit) // variable -> worker.Options literal
+		workerVars := make(map[string]*WorkerConfig)     // variable -> worker created in this function
+		var created []*WorkerConfig
+		stickyCacheSize := 0
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			default:
+			}
+
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				if len(node.Lhs) == 0 || len(node.Rhs) != 1 {
+					return true
+				}
+				ident, ok := node.Lhs[0].(*ast.Ident)
+				if !ok {
+					return true
+				}
+				if lit := workerOptionsLiteral(node.Rhs[0]); lit != nil {
+					optionLits[ident.Name] = lit
+				}
+				if call, ok := node.Rhs[0].(*ast.CallExpr); ok && isPkgCall(call, "worker", "New") {
+					if w := s.parseWorkerNew(call, optionLits, fset, filePath); w != nil {
+						workerVars[ident.Name] = w
+						created = append(created, w)
+					}
+					return false
+				}
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if i < len(node.Values) {
+						if lit := workerOptionsLiteral(node.Values[i]); lit != nil {
+							optionLits[name.Name] = lit
+						}
+					}
+				}
+			case *ast.CallExpr:
+				if isPkgCall(node, "worker", "SetStickyWorkflowCacheSize") && len(node.Args) == 1 {
+					stickyCacheSize = literalInt(node.Args[0])
+					return true
+				}
+				sel, ok := node.Fun.(*ast.SelectorExpr)
+				if !ok || len(node.Args) == 0 {
+					return true
+				}
+				recv, ok := sel.X.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				w, ok := workerVars[recv.Name]
+				if !ok {
+					return true
+				}
+				name := registeredName(node.Args[0])
+				if name == "" {
+					return true
+				}
+				switch sel.Sel.Name {
+				case "RegisterWorkflow", "RegisterWorkflowWithOptions":
+					w.Workflows = append(w.Workflows, name)
+				case "RegisterActivity", "RegisterActivityWithOptions":
+					w.Activities = append(w.Activities, name)
+				}
+			}
+			return true
+		})
+
+		for _, w := range created {
+			w.StickyCacheSize = stickyCacheSize
+			workers = append(workers, *w)
+		}
+	}
+
+	return workers
+}
+
+// parseWorkerNew parses worker.New(client, taskQueue, options).
+func (s *workerScanner) parseWorkerNew(call *ast.CallExpr, optionLits map[string]*ast.CompositeLit, fset *token.FileSet, filePath string) *WorkerConfig {
+	if len(call.Args) < 2 {
+		return nil
+	}
+
+	w := &WorkerConfig{
+		TaskQueue:  literalString(call.Args[1]),
+		FilePath:   filePath,
+		LineNumber: fset.Position(call.Pos()).Line,
+	}
+	if w.TaskQueue == "" {
+		return nil
+	}
+
+	if len(call.Args) < 3 {
+		return w
+	}
+	lit := workerOptionsLiteral(call.Args[2])
+	if ident, ok := call.Args[2].(*ast.Ident); ok && lit == nil {
+		lit = optionLits[ident.Name]
+	}
+	if lit == nil {
+		return w
+	}
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		switch key.Name {
+		case "MaxConcurrentActivityExecutionSize":
+			w.MaxConcurrentActivityExecutionSize = literalInt(kv.Value)
+		case "MaxConcurrentLocalActivityExecutionSize":
+			w.MaxConcurrentLocalActivityExecutionSize = literalInt(kv.Value)
+		case "MaxConcurrentWorkflowTaskExecutionSize":
+			w.MaxConcurrentWorkflowTaskExecutionSize = literalInt(kv.Value)
+		case "WorkerActivitiesPerSecond":
+			w.WorkerActivitiesPerSecond = literalFloat(kv.Value)
+		case "TaskQueueActivitiesPerSecond":
+			w.TaskQueueActivitiesPerSecond = literalFloat(kv.Value)
+		case "MaxConcurrentActivityTaskPollers":
+			w.MaxConcurrentActivityTaskPollers = literalInt(kv.Value)
+		case "MaxConcurrentWorkflowTaskPollers":
+			w.MaxConcurrentWorkflowTaskPollers = literalInt(kv.Value)
+		case "StickyScheduleToStartTimeout":
+			w.StickyScheduleToStartTimeout = types.ExprString(kv.Value)
+		case "Interceptors":
+			if list, ok := kv.Value.(*ast.CompositeLit); ok {
+				for _, elt := range list.Elts {
+					w.Interceptors = append(w.Interceptors, interceptorName(elt))
+				}
+			}
+		}
+	}
+
+	return w
+}
+
+// workerOptionsLiteral returns the worker.Options{...} literal of an expression, if it is one.
+func workerOptionsLiteral(expr ast.Expr) *ast.CompositeLit {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	sel, ok := lit.Type.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Options" {
+		return nil
+	}
+	if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "worker" {
+		return nil
+	}
+	return lit
+}
+
+// isPkgCall checks whether a call is pkg.name(...).
+func isPkgCall(call *ast.CallExpr, pkg, name string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+// registeredName returns the workflow/activity name of a Register* argument:
+// the function name, or the type name for struct registrations.
+func registeredName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.UnaryExpr:
+		if lit, ok := t.X.(*ast.CompositeLit); ok {
+			return registeredName(lit.Type)
+		}
+	case *ast.CallExpr:
+		// new(MyActivities)
+		if ident, ok := t.Fun.(*ast.Ident); ok && ident.Name == "new" && len(t.Args) == 1 {
+			return registeredName(t.Args[0])
+		}
+	}
+	return ""
+}
+
+// interceptorName names an interceptor by its constructor or type: NewTracingInterceptor(...) or &audit.Interceptor{}.
+func interceptorName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.CallExpr:
+		return types.ExprString(t.Fun)
+	case *ast.UnaryExpr:
+		if lit, ok := t.X.(*ast.CompositeLit); ok {
+			return types.ExprString(lit.Type)
+		}
+	case *ast.CompositeLit:
+		return types.ExprString(t.Type)
+	}
+	return types.ExprString(expr)
+}
+
+// literalString returns the value of a string literal, or the name of the constant used instead.
+func literalString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.BasicLit:
+		if t.Kind == token.STRING {
+			if s, err := strconv.Unquote(t.Value); err == nil {
+				return s
+			}
+		}
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			return pkg.Name + "." + t.Sel.Name
+		}
+	}
+	return ""
+}
+
+// literalInt returns the value of an integer literal, or 0.
+func literalInt(expr ast.Expr) int {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.INT {
+		if val, err := strconv.Atoi(lit.Value); err == nil {
+			return val
+		}
+	}
+	return 0
+}
+
+// literalFloat returns the value of a numeric literal, or 0.
+func literalFloat(expr ast.Expr) float64 {
+	if lit, ok := expr.(*ast.BasicLit); ok && (lit.Kind == token.INT || lit.Kind == token.FLOAT) {
+		if val, err := strconv.ParseFloat(lit.Value, 64); err == nil {
+			return val
+		}
+	}
+	return 0
+}
@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+func TestWorkerScannerScanDirectory(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := `package main
+
+import "go.temporal.io/sdk/worker"
+
+const BulkQueue = "bulk"
+
+func main() {
+	worker.SetStickyWorkflowCacheSize(512)
+	w := worker.New(c, "orders", worker.Options{
+		MaxConcurrentActivityExecutionSize: 4,
+		TaskQueueActivitiesPerSecond:       2.5,
+		MaxConcurrentActivityTaskPollers:   2,
+		StickyScheduleToStartTimeout:       5 * time.Second,
+		Interceptors: []interceptor.WorkerInterceptor{
+			tracing.NewInterceptor(tracing.Options{}),
+			&audit.Interceptor{},
+		},
+	})
+	w.RegisterWorkflow(OrderWorkflow)
+	w.RegisterActivity(&OrderActivities{})
+	w.RegisterActivity(payments.Charge)
+
+	opts := worker.Options{WorkerActivitiesPerSecond: 10}
+	bulk := worker.New(c, BulkQueue, opts)
+	bulk.RegisterWorkflow(BulkWorkflow)
+
+	worker.New(c, "ignored", worker.Options{})
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	workers, err := NewWorkerScanner(logger).ScanDirectory(context.Background(), tmpDir, config.AnalysisOptions{})
+	if err != nil {
+		t.Fatalf("ScanDirectory failed: %v", err)
+	}
+	if len(workers) != 2 {
+		t.Fatalf("Expected 2 workers assigned to variables, got %d: %+v", len(workers), workers)
+	}
+
+	orders := workers[0]
+	if orders.TaskQueue != "orders" || orders.LineNumber != 9 {
+		t.Errorf("Unexpected orders worker: %+v", orders)
+	}
+	if orders.MaxConcurrentActivityExecutionSize != 4 || orders.ActivitySlots() != 4 {
+		t.Errorf("MaxConcurrentActivityExecutionSize = %d, want 4", orders.MaxConcurrentActivityExecutionSize)
+	}
+	if orders.TaskQueueActivitiesPerSecond != 2.5 {
+		t.Errorf("TaskQueueActivitiesPerSecond = %g, want 2.5", orders.TaskQueueActivitiesPerSecond)
+	}
+	if orders.MaxConcurrentActivityTaskPollers != 2 || orders.StickyCacheSize != 512 || orders.StickyScheduleToStartTimeout != "5 * time.Second" {
+		t.Errorf("Unexpected pollers/sticky options: %+v", orders)
+	}
+	if len(orders.Interceptors) != 2 || orders.Interceptors[0] != "tracing.NewInterceptor" || orders.Interceptors[1] != "audit.Interceptor" {
+		t.Errorf("Unexpected interceptors: %v", orders.Interceptors)
+	}
+	if !orders.RegistersWorkflow("OrderWorkflow") || orders.RegistersWorkflow("BulkWorkflow") {
+		t.Errorf("Unexpected workflows: %v", orders.Workflows)
+	}
+	if len(orders.Activities) != 2 || orders.Activities[0] != "OrderActivities" || orders.Activities[1] != "Charge" {
+		t.Errorf("Unexpected activities: %v", orders.Activities)
+	}
+
+	bulk := workers[1]
+	if bulk.TaskQueue != "BulkQueue" || bulk.WorkerActivitiesPerSecond != 10 {
+		t.Errorf("Unexpected bulk worker: %+v", bulk)
+	}
+	if bulk.ActivitySlots() != DefaultMaxConcurrentActivityExecutionSize {
+		t.Errorf("ActivitySlots() = %d, want SDK default", bulk.ActivitySlots())
+	}
+}
+
+func TestUnregisteredNodes(t *testing.T) {
+	graph := &TemporalGraph{
+		Nodes: map[string]*TemporalNode{
+			"OrderWorkflow":           {Name: "OrderWorkflow", Type: "workflow"},
+			"LegacyWorkflow":          {Name: "LegacyWorkflow", Type: "workflow"},
+			"*OrderActivities.Charge": {Name: "*OrderActivities.Charge", Type: "activity"},
+			"Ship":                    {Name: "Ship", Type: "activity"},
+			"Refund":                  {Name: "Refund", Type: "activity"},
+			"OrderWorkflow.OnCancel":  {Name: "OrderWorkflow.OnCancel", Type: "signal"},
+		},
+	}
+
+	if unregistered := graph.UnregisteredNodes(); unregistered != nil {
+		t.Errorf("Expected nil without workers, got %v", unregistered)
+	}
+
+	graph.Workers = []WorkerConfig{
+		{TaskQueue: "orders", Workflows: []string{"OrderWorkflow"}, Activities: []string{"OrderActivities"}},
+		{TaskQueue: "shipping", Activities: []string{"Ship"}},
+	}
+	unregistered := graph.UnregisteredNodes()
+	if len(unregistered) != 2 || unregistered[0].Name != "LegacyWorkflow" || unregistered[1].Name != "Refund" {
+		names := make([]string, len(unregistered))
+		for i, node := range unregistered {
+			names[i] = node.Name
+		}
+		t.Errorf("UnregisteredNodes() = %v, want [LegacyWorkflow Refund]", names)
+	}
+}
+
+func TestApplyWorkers(t *testing.T) {
+	graph := &TemporalGraph{
+		Nodes: map[string]*TemporalNode{
+			"OrderWorkflow":           {Name: "OrderWorkflow", Type: "workflow"},
+			"*OrderActivities.Charge": {Name: "*OrderActivities.Charge", Type: "activity"},
+			"Ship":                    {Name: "Ship", Type: "activity"},
+			"Refund":                  {Name: "Refund", Type: "activity", TaskQueue: "stale"},
+		},
+	}
+
+	ApplyWorkers(graph, []WorkerConfig{
+		{TaskQueue: "orders.TaskQueue", Workflows: []string{"OrderWorkflow"}, Activities: []string{"OrderActivities"}},
+		{TaskQueue: "shipping", Activities: []string{"Ship"}},
+		{TaskQueue: "shipping-bulk", Activities: []string{"Ship"}},
+	})
+
+	want := map[string]string{
+		"OrderWorkflow":           "orders.TaskQueue",
+		"*OrderActivities.Charge": "orders.TaskQueue",
+		"Ship":                    "shipping",
+		"Refund":                  "",
+	}
+	for key, queue := range want {
+		if got := graph.Nodes[key].TaskQueue; got != queue {
+			t.Errorf("%s: TaskQueue = %q, want %q", key, got, queue)
+		}
+	}
+
+	for queue, count := range map[string]int{"orders.TaskQueue": 1, "TaskQueue": 1, "billing.TaskQueue": 0, "shipping": 1, "payments": 0} {
+		if got := len(graph.QueueWorkers(queue)); got != count {
+			t.Errorf("QueueWorkers(%q) returned %d workers, want %d", queue, got, count)
+		}
+	}
+}
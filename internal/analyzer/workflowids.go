@@ -0,0 +1,468 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// WorkflowID is the workflow ID a client call starts a workflow with, normalized into a
+// pattern of the literal text and the values it is built from.
+type WorkflowID struct {
+	// Workflow is the graph key of the started workflow, or its name if it is not in the graph
+	Workflow string `json:"workflow"`
+	// Pattern is the ID with its values as named placeholders, e.g. "order-{req.OrderID}";
+	// "" if the options set no ID and the server generates one
+	Pattern string `json:"pattern"`
+	// Expr is the ID expression as written in the code
+	Expr string `json:"expr,omitempty"`
+	// TaskQueue is the task queue set in the options, as a string literal or the name of the
+	// constant used; "" if it can't be traced to the options literal
+	TaskQueue  string `json:"task_queue,omitempty"`
+	Call       string `json:"call"` // "ExecuteWorkflow" or "SignalWithStartWorkflow"
+	FilePath   string `json:"file_path"`
+	LineNumber int    `json:"line_number"`
+}
+
+// idPlaceholder matches a named placeholder of a workflow ID pattern.
+var idPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// Shape returns the pattern with unnamed placeholders, e.g. "order-{}", so that IDs built
+// the same way from differently named values compare equal.
+func (id WorkflowID) Shape() string {
+	return idPlaceholder.ReplaceAllString(id.Pattern, "{}")
+}
+
+// Namespace returns the literal text the pattern starts with, without trailing separators,
+// e.g. "order" for "order-{id}". It is "" for IDs starting with a value or generated by the
+// server.
+func (id WorkflowID) Namespace() string {
+	prefix := id.Pattern
+	if i := strings.Index(prefix, "{"); i >= 0 {
+		prefix = prefix[:i]
+	}
+	return strings.TrimRight(prefix, "-_:/.| ")
+}
+
+// pendingWorkflowID is a started workflow whose ID may refer to constants declared in files
+// scanned later.
+type pendingWorkflowID struct {
+	id WorkflowID
+	// options are the StartWorkflowOptions of the call
+	options ast.Expr
+	// expr is the workflow ID argument of SignalWithStartWorkflow, which takes the ID
+	// outside the options
+	expr ast.Expr
+	// locals maps the variables of the calling function, and the fields set on them
+	// (opts.ID), to the value last assigned to them
+	locals map[string]ast.Expr
+}
+
+// workflowIDScanner scans client calls starting workflows for the workflow IDs they use.
+type workflowIDScanner struct {
+	logger *slog.Logger
+
+	ids []pendingWorkflowID
+	// constants maps string constants to their values, to expand IDs built from constants
+	constants map[string]string
+}
+
+// NewWorkflowIDScanner creates a new workflow ID scanner.
+func NewWorkflowIDScanner(logger *slog.Logger) *workflowIDScanner {
+	return &workflowIDScanner{
+		logger:    logger,
+		constants: make(map[string]string),
+	}
+}
+
+// ScanDirectory scans all Go files in a directory for the workflow IDs of the
+// ExecuteWorkflow and SignalWithStartWorkflow calls, sorted by file and line.
+func (s *workflowIDScanner) ScanDirectory(ctx context.Context, rootDir string, opts config.AnalysisOptions) ([]WorkflowID, error) {
+	fset := token.NewFileSet()
+
+	modules := NewModuleFilter(rootDir, opts)
+	err := filepath.Walk(rootDir, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			s.logger.Warn("Error accessing path during workflow ID scan", "path", path, "error", err)
+			return nil // Continue walking
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if fileInfo.IsDir() {
+			if opts.ExcludesDir(fileInfo.Name()) {
+				return filepath.SkipDir
+			}
+			if modules.SkipDir(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Test environments execute workflows with a different signature and no IDs
+		if !strings.HasSuffix(path, ".go") || IsTestFile(path) {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			s.logger.Warn("Error parsing file for workflow IDs", "path", path, "error", err)
+			return nil
+		}
+
+		if err := recovered(func() { s.scanFile(file, fset, path) }); err != nil {
+			s.logger.Warn("Error scanning file for workflow IDs", "path", path, "error", err)
+			recordFileError(ctx, StageWorkflowIDs, path, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]WorkflowID, 0, len(s.ids))
+	for _, p := range s.ids {
+		expr := p.expr
+		if p.options != nil {
+			if queue, _ := optionsField(p.options, "TaskQueue", p.locals, 0); queue != nil {
+				p.id.TaskQueue = taskQueueName(queue, p.locals, 0)
+			}
+		}
+		if p.options != nil && expr == nil {
+			var ok bool
+			if expr, ok = optionsField(p.options, "ID", p.locals, 0); !ok {
+				// Options passed in from elsewhere: the ID is whatever they hold
+				p.id.Pattern = "{" + types.ExprString(p.options) + ".ID}"
+				p.id.Expr = types.ExprString(p.options) + ".ID"
+				ids = append(ids, p.id)
+				continue
+			}
+		}
+		if expr != nil {
+			p.id.Pattern = s.pattern(expr, p.locals, make(map[string]bool))
+			p.id.Expr = types.ExprString(expr)
+		}
+		ids = append(ids, p.id)
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		if ids[i].FilePath != ids[j].FilePath {
+			return ids[i].FilePath < ids[j].FilePath
+		}
+		return ids[i].LineNumber < ids[j].LineNumber
+	})
+	s.logger.Info("Scanned for workflow IDs", "workflow_ids", len(ids))
+
+	return ids, nil
+}
+
+// scanFile records the string constants of a file and the workflows its functions start.
+func (s *workflowIDScanner) scanFile(file *ast.File, fset *token.FileSet, filePath string) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range d.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, name := range valueSpec.Names {
+					if i >= len(valueSpec.Values) {
+						continue
+					}
+					if value, ok := routePath(valueSpec.Values[i]); ok {
+						s.constants[name.Name] = value
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if d.Body != nil {
+				s.scanFunc(d, fset, filePath)
+			}
+		}
+	}
+}
+
+// scanFunc records the workflows a function starts, with the local variables their IDs and
+// options may refer to. Parameters are locals without a value.
+func (s *workflowIDScanner) scanFunc(fn *ast.FuncDecl, fset *token.FileSet, filePath string) {
+	locals := make(map[string]ast.Expr)
+	for _, fields := range []*ast.FieldList{fn.Recv, fn.Type.Params} {
+		if fields == nil {
+			continue
+		}
+		for _, field := range fields.List {
+			for _, name := range field.Names {
+				locals[name.Name] = nil
+			}
+		}
+	}
+	assign := func(lhs, rhs ast.Expr) {
+		switch l := lhs.(type) {
+		case *ast.Ident:
+			if l.Name != "_" {
+				locals[l.Name] = rhs
+			}
+		case *ast.SelectorExpr:
+			// opts.ID = "order-" + id
+			if x, ok := l.X.(*ast.Ident); ok {
+				locals[x.Name+"."+l.Sel.Name] = rhs
+			}
+		}
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) == len(node.Rhs) {
+				for i := range node.Lhs {
+					assign(node.Lhs[i], node.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(node.Names) == len(node.Values) {
+				for i := range node.Names {
+					assign(node.Names[i], node.Values[i])
+				}
+			}
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			p := pendingWorkflowID{
+				id: WorkflowID{
+					Call:       sel.Sel.Name,
+					FilePath:   filePath,
+					LineNumber: fset.Position(node.Pos()).Line,
+				},
+				locals: locals,
+			}
+			switch {
+			case sel.Sel.Name == "ExecuteWorkflow" && len(node.Args) >= 3:
+				// (ctx, options, workflow, args...)
+				p.options = node.Args[1]
+				p.id.Workflow = workflowRef(node.Args[2])
+			case sel.Sel.Name == "SignalWithStartWorkflow" && len(node.Args) >= 6:
+				// (ctx, workflowID, signalName, signalArg, options, workflow, args...)
+				p.expr = node.Args[1]
+				p.options = node.Args[4]
+				p.id.Workflow = workflowRef(node.Args[5])
+			default:
+				return true
+			}
+			if p.id.Workflow != "" {
+				s.ids = append(s.ids, p)
+			}
+		}
+		return true
+	})
+}
+
+// optionsField returns the value of a field set in StartWorkflowOptions, e.g. ID, following
+// variables to the literal assigned to them and to the value assigned to their field. value
+// is nil if the options don't set the field; ok is false if they can't be traced to a literal.
+func optionsField(options ast.Expr, field string, locals map[string]ast.Expr, depth int) (value ast.Expr, ok bool) {
+	if depth > maxEntryPointDepth {
+		return nil, false
+	}
+	switch o := options.(type) {
+	case *ast.ParenExpr:
+		return optionsField(o.X, field, locals, depth+1)
+	case *ast.UnaryExpr:
+		if o.Op == token.AND {
+			return optionsField(o.X, field, locals, depth+1)
+		}
+	case *ast.CompositeLit:
+		for _, elt := range o.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok && key.Name == field {
+					return kv.Value, true
+				}
+			}
+		}
+		return nil, true
+	case *ast.Ident:
+		if value, ok := locals[o.Name+"."+field]; ok {
+			return value, true
+		}
+		if value := locals[o.Name]; value != nil {
+			return optionsField(value, field, locals, depth+1)
+		}
+	}
+	return nil, false
+}
+
+// taskQueueName returns the task queue an expression names as worker.New arguments are
+// recorded: the value of a string literal or the name of a constant, following local
+// variables to the value assigned to them. It returns "" for other values.
+func taskQueueName(expr ast.Expr, locals map[string]ast.Expr, depth int) string {
+	if depth > maxEntryPointDepth {
+		return ""
+	}
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return taskQueueName(e.X, locals, depth+1)
+	case *ast.Ident:
+		if value, local := locals[e.Name]; local {
+			if value == nil {
+				return ""
+			}
+			return taskQueueName(value, locals, depth+1)
+		}
+	case *ast.SelectorExpr:
+		// cfg.TaskQueue of a local value is not a constant
+		if x, ok := e.X.(*ast.Ident); ok {
+			if _, local := locals[x.Name]; local {
+				return ""
+			}
+		}
+	}
+	return literalString(expr)
+}
+
+// pattern normalizes a workflow ID expression: string literals and constants are kept,
+// concatenations and fmt.Sprintf are expanded, UUIDs become {uuid}, local variables are
+// followed to their value and any other value becomes a placeholder named as written.
+// seen holds the variables being expanded, so that id = id + "-retry" terminates.
+func (s *workflowIDScanner) pattern(expr ast.Expr, locals map[string]ast.Expr, seen map[string]bool) string {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if value, err := strconv.Unquote(e.Value); e.Kind == token.STRING && err == nil {
+			return value
+		}
+		return e.Value
+	case *ast.Ident:
+		if value := locals[e.Name]; value != nil && !seen[e.Name] {
+			seen[e.Name] = true
+			defer delete(seen, e.Name)
+			return s.pattern(value, locals, seen)
+		}
+		if value, ok := s.constants[e.Name]; ok {
+			return value
+		}
+	case *ast.SelectorExpr:
+		// ids.OrderPrefix; fields of local values such as req.OrderID stay placeholders
+		if x, ok := e.X.(*ast.Ident); ok {
+			if _, local := locals[x.Name]; local {
+				break
+			}
+			if value, ok := s.constants[e.Sel.Name]; ok {
+				return value
+			}
+		}
+	case *ast.ParenExpr:
+		return s.pattern(e.X, locals, seen)
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			return s.pattern(e.X, locals, seen) + s.pattern(e.Y, locals, seen)
+		}
+	case *ast.CallExpr:
+		return s.callPattern(e, locals, seen)
+	}
+	return "{" + types.ExprString(expr) + "}"
+}
+
+// callPattern normalizes a call building a workflow ID.
+func (s *workflowIDScanner) callPattern(call *ast.CallExpr, locals map[string]ast.Expr, seen map[string]bool) string {
+	name := types.ExprString(call.Fun)
+	switch {
+	case strings.Contains(strings.ToLower(name), "uuid"):
+		// uuid.New().String(), uuid.NewString()
+		return "{uuid}"
+	case name == "fmt.Sprintf" && len(call.Args) > 0:
+		format := s.pattern(call.Args[0], locals, seen)
+		args := make([]string, len(call.Args)-1)
+		for i, arg := range call.Args[1:] {
+			args[i] = s.pattern(arg, locals, seen)
+		}
+		return sprintfPattern(format, args)
+	case strings.HasPrefix(name, "strconv.") && len(call.Args) > 0:
+		// strconv.Itoa(n), strconv.FormatInt(n, 10)
+		return s.pattern(call.Args[0], locals, seen)
+	case len(call.Args) == 0:
+		// id.String()
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "String" {
+			return s.pattern(sel.X, locals, seen)
+		}
+	}
+	return "{" + name + "()}"
+}
+
+// sprintfPattern substitutes the verbs of a format string with the patterns of their
+// arguments, e.g. "order-%s" with "{orderID}" becomes "order-{orderID}".
+func sprintfPattern(format string, args []string) string {
+	var b strings.Builder
+	next := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.WriteByte(format[i])
+			continue
+		}
+		j := i + 1
+		for j < len(format) && strings.IndexByte("+-# 0123456789.[]*", format[j]) >= 0 {
+			j++
+		}
+		switch {
+		case j >= len(format):
+			b.WriteString(format[i:])
+		case format[j] == '%':
+			b.WriteByte('%')
+		case next < len(args):
+			b.WriteString(args[next])
+			next++
+		default:
+			b.WriteString("{}")
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// ApplyWorkflowIDs records the workflow IDs in the graph, naming the started workflows by
+// graph key when they are unique. Workflows are matched by name or alias, or by method name
+// for qualified (Receiver.Method) nodes.
+func ApplyWorkflowIDs(graph *TemporalGraph, ids []WorkflowID) {
+	byName := make(map[string][]string)
+	for id, node := range graph.Nodes {
+		if node.Type != "workflow" || node.Unresolved {
+			continue
+		}
+		shortName := node.Name
+		if idx := strings.LastIndex(shortName, "."); idx >= 0 {
+			shortName = shortName[idx+1:]
+		}
+		byName[shortName] = append(byName[shortName], id)
+		for _, alias := range node.Aliases {
+			byName[alias] = append(byName[alias], id)
+		}
+	}
+
+	graph.WorkflowIDs = nil
+	for _, id := range ids {
+		if _, ok := graph.Nodes[id.Workflow]; !ok {
+			if keys := byName[id.Workflow]; len(keys) == 1 {
+				id.Workflow = keys[0]
+			}
+		}
+		graph.WorkflowIDs = append(graph.WorkflowIDs, id)
+	}
+}
@@ -0,0 +1,107 @@
+package analyzer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+const workflowIDWorkflows = `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+const Prefix = "order-"
+
+func OrderWorkflow(ctx workflow.Context, id string) error {
+	return workflow.Sleep(ctx, 0)
+}
+
+func RefundWorkflow(ctx workflow.Context, id string) error {
+	return workflow.Sleep(ctx, 0)
+}
+`
+
+const workflowIDClient = `package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/client"
+)
+
+func start(ctx context.Context, c client.Client, req Request, opts client.StartWorkflowOptions) {
+	c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{ID: fmt.Sprintf("order-%s", req.OrderID)}, orders.OrderWorkflow)
+	c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{ID: orders.Prefix + req.ID}, orders.RefundWorkflow)
+
+	id := uuid.New().String()
+	options := &client.StartWorkflowOptions{ID: id, TaskQueue: "orders"}
+	c.ExecuteWorkflow(ctx, options, orders.OrderWorkflow)
+
+	var retry client.StartWorkflowOptions
+	retry.ID = fmt.Sprintf("retry-%d-%s%%", req.Attempt, "x")
+	retry.TaskQueue = orders.TaskQueue
+	c.ExecuteWorkflow(ctx, retry, orders.OrderWorkflow)
+
+	c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{TaskQueue: "orders"}, orders.OrderWorkflow)
+	c.ExecuteWorkflow(ctx, opts, orders.OrderWorkflow)
+	c.SignalWithStartWorkflow(ctx, "nightly", "run", nil, opts, orders.RefundWorkflow)
+}
+`
+
+func TestAnalyzeWorkflowIDs(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"orders/orders.go": workflowIDWorkflows,
+		"api/client.go":    workflowIDClient,
+	})
+
+	a := NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	want := []struct{ workflow, pattern, namespace, shape, queue string }{
+		{"OrderWorkflow", "order-{req.OrderID}", "order", "order-{}", ""},
+		{"RefundWorkflow", "order-{req.ID}", "order", "order-{}", ""},
+		{"OrderWorkflow", "{uuid}", "", "{}", "orders"},
+		{"OrderWorkflow", "retry-{req.Attempt}-x%", "retry", "retry-{}-x%", "orders.TaskQueue"},
+		{"OrderWorkflow", "", "", "", "orders"},
+		{"OrderWorkflow", "{opts.ID}", "", "{}", ""},
+		{"RefundWorkflow", "nightly", "nightly", "nightly", ""},
+	}
+	if len(graph.WorkflowIDs) != len(want) {
+		t.Fatalf("Expected %d workflow IDs, got %+v", len(want), graph.WorkflowIDs)
+	}
+	for i, w := range want {
+		id := graph.WorkflowIDs[i]
+		if id.Workflow != w.workflow || id.Pattern != w.pattern || id.Namespace() != w.namespace || id.Shape() != w.shape || id.TaskQueue != w.queue {
+			t.Errorf("WorkflowIDs[%d] = %+v (namespace %q, shape %q), want %+v", i, id, id.Namespace(), id.Shape(), w)
+		}
+	}
+	if id := graph.WorkflowIDs[6]; id.Call != "SignalWithStartWorkflow" || id.Expr != `"nightly"` || id.LineNumber != 26 {
+		t.Errorf("Unexpected SignalWithStartWorkflow ID %+v", id)
+	}
+}
+
+func TestSprintfPattern(t *testing.T) {
+	tests := []struct {
+		format string
+		args   []string
+		want   string
+	}{
+		{"order-%s", []string{"{id}"}, "order-{id}"},
+		{"%s/%05d", []string{"tenant", "{n}"}, "tenant/{n}"},
+		{"100%% %v", nil, "100% {}"},
+		{"trailing %", nil, "trailing %"},
+	}
+	for _, tt := range tests {
+		if got := sprintfPattern(tt.format, tt.args); got != tt.want {
+			t.Errorf("sprintfPattern(%q, %v) = %q, want %q", tt.format, tt.args, got, tt.want)
+		}
+	}
+}
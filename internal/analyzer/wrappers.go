@@ -0,0 +1,337 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+	"sort"
+)
+
+// executeMethods are the workflow package functions that execute a target passed as their
+// second argument.
+var executeMethods = map[string]bool{
+	"ExecuteActivity":      true,
+	"ExecuteLocalActivity": true,
+	"ExecuteChildWorkflow": true,
+}
+
+// executeWrapper is a function that executes a workflow or activity its callers pass as a
+// parameter, e.g.
+//
+//	func runActivity(ctx workflow.Context, fn any, args ...any) error {
+//		return workflow.ExecuteActivity(ctx, fn, args...).Get(ctx, nil)
+//	}
+type executeWrapper struct {
+	node     *TemporalNode
+	funcName string
+	isMethod bool
+	pkg      string
+	sites    []wrappedCallSite
+}
+
+// wrappedCallSite is an Execute call of a wrapper whose target is one of its parameters.
+type wrappedCallSite struct {
+	site        CallSite // the wrapper's own call site, targeting the parameter name
+	targetParam int      // position of the parameter passed as the target
+	ctxParam    int      // position of the workflow.Context parameter passed as the context, or -1
+	argsParam   int      // position of the variadic parameter forwarded as the arguments, or -1
+}
+
+// routeExecuteWrappers replaces the calls to wrappers of workflow.Execute* functions with
+// call sites of the targets their callers pass, so edges point at the real workflows and
+// activities instead of the wrapper and a stub named after its parameter. Options set in the
+// wrapper apply to every routed call; otherwise the options of the context the caller passes do.
+func (g *graphBuilder) routeExecuteWrappers(ctx context.Context, matches []NodeMatch, graph *TemporalGraph) {
+	extractor, ok := g.callExtractor.(*callExtractor)
+	if !ok {
+		return
+	}
+
+	for _, wrapper := range g.findExecuteWrappers(matches, graph) {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		g.routeWrapperCalls(extractor, wrapper, matches, graph)
+
+		// Drop the wrapper's own call sites and the stubs named after its parameters
+		wrapperID := wrapper.node.ID()
+		remaining := wrapper.node.CallSites[:0]
+		for _, cs := range wrapper.node.CallSites {
+			if !wrapper.wraps(cs) {
+				remaining = append(remaining, cs)
+				continue
+			}
+			if target, ok := graph.Nodes[cs.TargetName]; ok {
+				removeParent(target, wrapperID)
+				if target.Unresolved && len(target.Parents) == 0 {
+					delete(graph.Nodes, cs.TargetName)
+				}
+			}
+		}
+		wrapper.node.CallSites = remaining
+
+		// A wrapper that does nothing else is plumbing rather than a workflow
+		if len(remaining) == 0 && len(wrapper.node.Parents) == 0 {
+			delete(graph.Nodes, wrapperID)
+		}
+	}
+}
+
+// wraps reports whether a call site of the wrapper is one of its wrapped Execute calls.
+func (w *executeWrapper) wraps(cs CallSite) bool {
+	for _, s := range w.sites {
+		if s.site.LineNumber == cs.LineNumber && s.site.TargetName == cs.TargetName {
+			return true
+		}
+	}
+	return false
+}
+
+// findExecuteWrappers finds the functions whose body executes one of their own parameters.
+func (g *graphBuilder) findExecuteWrappers(matches []NodeMatch, graph *TemporalGraph) []*executeWrapper {
+	var wrappers []*executeWrapper
+
+	for _, match := range matches {
+		fn, ok := match.Node.(*ast.FuncDecl)
+		if !ok || fn.Name == nil || fn.Body == nil || fn.Type.Params == nil {
+			continue
+		}
+
+		params, variadic := funcParams(fn)
+		// A reassigned parameter no longer carries what the caller passed
+		reassigned := make(map[string]bool)
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if assign, ok := n.(*ast.AssignStmt); ok {
+				for _, lhs := range assign.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						reassigned[ident.Name] = true
+					}
+				}
+			}
+			return true
+		})
+
+		node, exists := graph.Nodes[g.nodeKey(fn)]
+		if !exists {
+			continue
+		}
+		ctxParams := workflowContextParams(fn)
+
+		wrapper := &executeWrapper{
+			node:     node,
+			funcName: fn.Name.Name,
+			isMethod: fn.Recv != nil,
+			pkg:      match.Package,
+		}
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) < 2 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !executeMethods[sel.Sel.Name] {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "workflow" {
+				return true
+			}
+			target, ok := call.Args[1].(*ast.Ident)
+			if !ok {
+				return true
+			}
+			targetParam, isParam := params[target.Name]
+			if !isParam || reassigned[target.Name] {
+				return true
+			}
+
+			wrapped := wrappedCallSite{targetParam: targetParam, ctxParam: -1, argsParam: -1}
+			if ident, ok := call.Args[0].(*ast.Ident); ok && !reassigned[ident.Name] {
+				if i, ok := ctxParams[ident.Name]; ok {
+					wrapped.ctxParam = i
+				}
+			}
+			if len(call.Args) == 3 && call.Ellipsis.IsValid() {
+				if ident, ok := call.Args[2].(*ast.Ident); ok && ident.Name == variadic && !reassigned[variadic] {
+					wrapped.argsParam = params[variadic]
+				}
+			}
+
+			line := match.FileSet.Position(call.Pos()).Line
+			for _, cs := range node.CallSites {
+				if cs.LineNumber == line && cs.TargetName == target.Name {
+					wrapped.site = cs
+					wrapper.sites = append(wrapper.sites, wrapped)
+					break
+				}
+			}
+			return true
+		})
+
+		if len(wrapper.sites) > 0 {
+			wrappers = append(wrappers, wrapper)
+		}
+	}
+
+	return wrappers
+}
+
+// routeWrapperCalls replaces the calls to a wrapper in every node with the call sites of
+// the targets passed to it.
+func (g *graphBuilder) routeWrapperCalls(extractor *callExtractor, wrapper *executeWrapper, matches []NodeMatch, graph *TemporalGraph) {
+	wrapperID := wrapper.node.ID()
+
+	for _, match := range matches {
+		fn, ok := match.Node.(*ast.FuncDecl)
+		if !ok || fn.Name == nil || fn.Body == nil {
+			continue
+		}
+		caller, exists := graph.Nodes[g.nodeKey(fn)]
+		if !exists || caller == wrapper.node {
+			continue
+		}
+
+		scope := newOptionsScope()
+		var routed []CallSite
+		routedLines := make(map[int]bool)
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt, *ast.ValueSpec:
+				extractor.trackOptionsAssignment(scope, node)
+			case *ast.CallExpr:
+				if !callsWrapper(node, wrapper, match.Package) {
+					return true
+				}
+				line := match.FileSet.Position(node.Pos()).Line
+				for _, s := range wrapper.sites {
+					if cs, ok := s.route(extractor, scope, node); ok {
+						cs.LineNumber = line
+						cs.FilePath = match.FilePath
+						routed = append(routed, cs)
+						routedLines[line] = true
+					}
+				}
+			}
+			return true
+		})
+		if len(routed) == 0 {
+			continue
+		}
+
+		// Calls to the wrapper may have been taken for Temporal calls by their name
+		callSites := caller.CallSites[:0]
+		for _, cs := range caller.CallSites {
+			if routedLines[cs.LineNumber] && (cs.TargetName == wrapperID || cs.TargetName == wrapper.funcName) {
+				continue
+			}
+			callSites = append(callSites, cs)
+		}
+		stillCalled := false
+		for _, cs := range callSites {
+			stillCalled = stillCalled || cs.TargetName == wrapperID
+		}
+		if !stillCalled {
+			removeParent(wrapper.node, caller.ID())
+		}
+
+		for i := range routed {
+			g.linkCallSite(&routed[i], caller, graph)
+		}
+		caller.CallSites = append(callSites, routed...)
+		sort.SliceStable(caller.CallSites, func(i, j int) bool {
+			return caller.CallSites[i].LineNumber < caller.CallSites[j].LineNumber
+		})
+	}
+}
+
+// route returns the call site of the target a call to the wrapper passes for this site.
+func (s wrappedCallSite) route(extractor *callExtractor, scope *optionsScope, call *ast.CallExpr) (CallSite, bool) {
+	if s.targetParam >= len(call.Args) {
+		return CallSite{}, false
+	}
+	target := extractor.extractFunctionReference(call.Args[s.targetParam])
+	if target == "" {
+		return CallSite{}, false
+	}
+
+	cs := s.site
+	cs.TargetName = target
+	cs.CallType = "execute"
+
+	if s.argsParam >= 0 && !call.Ellipsis.IsValid() {
+		cs.ArgumentCount, cs.ArgumentTypes = 0, nil
+		for _, arg := range call.Args[min(s.argsParam, len(call.Args)):] {
+			cs.ArgumentCount++
+			cs.ArgumentTypes = append(cs.ArgumentTypes, extractor.inferExprType(arg))
+		}
+	}
+
+	// Options set in the wrapper win over those of the context it receives
+	if cs.ParsedActivityOpts == nil && s.ctxParam >= 0 && s.ctxParam < len(call.Args) &&
+		(cs.TargetType == "activity" || cs.TargetType == "local_activity") {
+		cs.ParsedActivityOpts = extractor.contextArgOptions(scope, call.Args[s.ctxParam])
+	}
+	return cs, true
+}
+
+// callsWrapper reports whether a call expression in package pkg invokes the wrapper.
+func callsWrapper(call *ast.CallExpr, wrapper *executeWrapper, pkg string) bool {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return !wrapper.isMethod && pkg == wrapper.pkg && fun.Name == wrapper.funcName
+	case *ast.SelectorExpr:
+		if fun.Sel.Name != wrapper.funcName {
+			return false
+		}
+		if wrapper.isMethod {
+			return true
+		}
+		x, ok := fun.X.(*ast.Ident)
+		return ok && pkg != wrapper.pkg && x.Name == wrapper.pkg
+	}
+	return false
+}
+
+// funcParams returns the positions of a function's named parameters by name, and the name
+// of its variadic parameter if it has one.
+func funcParams(fn *ast.FuncDecl) (map[string]int, string) {
+	params := make(map[string]int)
+	variadic := ""
+	index := 0
+	for _, field := range fn.Type.Params.List {
+		if len(field.Names) == 0 {
+			index++
+			continue
+		}
+		_, isVariadic := field.Type.(*ast.Ellipsis)
+		for _, name := range field.Names {
+			params[name.Name] = index
+			if isVariadic {
+				variadic = name.Name
+			}
+			index++
+		}
+	}
+	return params, variadic
+}
+
+// removeParent removes every record of calls from caller into target.
+func removeParent(target *TemporalNode, caller string) {
+	parents := target.Parents[:0]
+	for _, p := range target.Parents {
+		if p != caller {
+			parents = append(parents, p)
+		}
+	}
+	target.Parents = parents
+
+	calledBy := target.CalledBy[:0]
+	for _, ref := range target.CalledBy {
+		if ref.Name != caller {
+			calledBy = append(calledBy, ref)
+		}
+	}
+	target.CalledBy = calledBy
+}
@@ -0,0 +1,103 @@
+package analyzer
+
+import "testing"
+
+func TestRouteExecuteWrappers(t *testing.T) {
+	code := `package test
+
+func runActivity(ctx workflow.Context, fn interface{}, args ...interface{}) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	return workflow.ExecuteActivity(ctx, fn, args...).Get(ctx, nil)
+}
+
+func OrderWorkflow(ctx workflow.Context, orderID string) error {
+	if err := runActivity(ctx, ChargeCard, orderID, 42); err != nil {
+		return err
+	}
+	return runActivity(ctx, ShipOrder, orderID)
+}
+`
+	graph := buildTestGraph(t, code)
+
+	charge := findCallSite(t, graph, "OrderWorkflow", "ChargeCard")
+	if charge.TargetType != "activity" || charge.CallType != "execute" || charge.LineNumber != 9 {
+		t.Errorf("Unexpected routed call site: %+v", charge)
+	}
+	if charge.ArgumentCount != 2 || charge.ArgumentTypes[1] != "int" {
+		t.Errorf("Expected the caller's arguments, got %d %v", charge.ArgumentCount, charge.ArgumentTypes)
+	}
+	if charge.ParsedActivityOpts == nil || charge.ParsedActivityOpts.StartToCloseTimeout != "time.Minute" {
+		t.Errorf("Expected the wrapper's options, got %+v", charge.ParsedActivityOpts)
+	}
+	if ship := findCallSite(t, graph, "OrderWorkflow", "ShipOrder"); ship.ArgumentCount != 1 {
+		t.Errorf("Expected 1 argument for ShipOrder, got %d", ship.ArgumentCount)
+	}
+	if len(graph.Nodes["OrderWorkflow"].CallSites) != 2 {
+		t.Errorf("Expected only the routed call sites, got %+v", graph.Nodes["OrderWorkflow"].CallSites)
+	}
+
+	for _, name := range []string{"runActivity", "fn"} {
+		if _, ok := graph.Nodes[name]; ok {
+			t.Errorf("Expected no %s node once calls are routed", name)
+		}
+	}
+}
+
+func TestRouteExecuteWrappersCallerOptions(t *testing.T) {
+	code := `package test
+
+func execute(ctx workflow.Context, activity interface{}, order Order) error {
+	return workflow.ExecuteActivity(ctx, activity, order).Get(ctx, nil)
+}
+
+func OrderWorkflow(ctx workflow.Context, order Order) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{HeartbeatTimeout: time.Second})
+	if err := execute(ctx, ChargeCard, order); err != nil {
+		return err
+	}
+	return workflow.ExecuteActivity(ctx, AuditOrder, order).Get(ctx, nil)
+}
+`
+	graph := buildTestGraph(t, code)
+
+	charge := findCallSite(t, graph, "OrderWorkflow", "ChargeCard")
+	if charge.ParsedActivityOpts == nil || charge.ParsedActivityOpts.HeartbeatTimeout != "time.Second" {
+		t.Errorf("Expected the options of the caller's context, got %+v", charge.ParsedActivityOpts)
+	}
+	if charge.ArgumentCount != 1 {
+		t.Errorf("Expected the wrapper's argument count, got %d", charge.ArgumentCount)
+	}
+	if parents := graph.Nodes["ChargeCard"].Parents; len(parents) != 1 || parents[0] != "OrderWorkflow" {
+		t.Errorf("Expected ChargeCard to be called by OrderWorkflow, got %v", parents)
+	}
+	findCallSite(t, graph, "OrderWorkflow", "AuditOrder")
+}
+
+func TestRouteExecuteWrappersKeepsWorkflows(t *testing.T) {
+	code := `package test
+
+func StepWorkflow(ctx workflow.Context, step interface{}) error {
+	if err := workflow.ExecuteActivity(ctx, RecordStep).Get(ctx, nil); err != nil {
+		return err
+	}
+	return workflow.ExecuteActivity(ctx, step).Get(ctx, nil)
+}
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return StepWorkflow(ctx, ChargeCard)
+}
+`
+	graph := buildTestGraph(t, code)
+
+	step, ok := graph.Nodes["StepWorkflow"]
+	if !ok {
+		t.Fatal("Expected StepWorkflow to stay in the graph")
+	}
+	if len(step.CallSites) != 1 || step.CallSites[0].TargetName != "RecordStep" {
+		t.Errorf("Expected only StepWorkflow's own call site, got %+v", step.CallSites)
+	}
+	findCallSite(t, graph, "OrderWorkflow", "ChargeCard")
+	if _, ok := graph.Nodes["step"]; ok {
+		t.Error("Expected no stub for the wrapper parameter")
+	}
+}
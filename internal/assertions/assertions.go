@@ -0,0 +1,427 @@
+// Package assertions evaluates architecture assertions, such as "OrderWorkflow must call
+// ChargeCard" or "workflows must not call activities of the legacy package", against the
+// analyzed graph, so teams can keep architectural invariants as tests.
+package assertions
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+)
+
+// DefaultFile is the assertions file read from the analyzed root when none is configured.
+const DefaultFile = "analyzer-assertions.yaml"
+
+// Assertion is a rule the graph must satisfy. From selects the nodes it applies to; exactly
+// one of MustCall, MustNotCall and MaxDepth is set.
+type Assertion struct {
+	// Name describes the assertion in results; it defaults to the rule itself
+	Name string `json:"name,omitempty"`
+	From string `json:"from"`
+	// MustCall selects nodes each From node must call directly
+	MustCall string `json:"must_call,omitempty"`
+	// MustNotCall selects nodes no From node may call directly
+	MustNotCall string `json:"must_not_call,omitempty"`
+	// MaxDepth is the longest chain of calls allowed from each From node (0 = unset)
+	MaxDepth int `json:"max_depth,omitempty"`
+	// Line is the line of the assertion in its file
+	Line int `json:"line"`
+
+	from, mustCall, mustNotCall *selector
+}
+
+// String describes the rule of the assertion, e.g. "OrderWorkflow must call ChargeCard".
+func (a *Assertion) String() string {
+	switch {
+	case a.MustCall != "":
+		return fmt.Sprintf("%s must call %s", a.From, a.MustCall)
+	case a.MustNotCall != "":
+		return fmt.Sprintf("%s must not call %s", a.From, a.MustNotCall)
+	default:
+		return fmt.Sprintf("call depth from %s is at most %d", a.From, a.MaxDepth)
+	}
+}
+
+// Title returns the name of the assertion, or its rule if it has none.
+func (a *Assertion) Title() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return a.String()
+}
+
+// Result is the outcome of an assertion.
+type Result struct {
+	Assertion *Assertion `json:"assertion"`
+	Passed    bool       `json:"passed"`
+	// Violations describe why the assertion failed, one per offending node or call
+	Violations []string `json:"violations,omitempty"`
+}
+
+// Load reads an assertions file.
+func Load(filePath string) ([]*Assertion, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open assertions file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	assertions, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return assertions, nil
+}
+
+// Parse parses the YAML subset of assertions files:
+//
+//	assertions:
+//	  - name: Orders charge the card
+//	    from: OrderWorkflow
+//	    must_call: ChargeCard
+//	  - from: type==workflow
+//	    must_not_call: "package=~'^legacy'"
+//	  - from: OrderWorkflow
+//	    max_depth: 4
+//
+// Node selectors are name globs, matched like metadata overlay patterns, or query expressions.
+func Parse(r io.Reader) ([]*Assertion, error) {
+	var assertions []*Assertion
+	var current *Assertion
+	entryIndent := 0
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := stripComment(scanner.Text())
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if indent == 0 {
+			if trimmed != "assertions:" && trimmed != "assertions: []" {
+				return nil, fmt.Errorf("invalid assertions line %d: %q (expected assertions:)", lineNum, trimmed)
+			}
+			continue
+		}
+
+		if item, isItem := strings.CutPrefix(trimmed, "-"); isItem && (current == nil || indent <= entryIndent) {
+			current = &Assertion{Line: lineNum}
+			assertions = append(assertions, current)
+			entryIndent = indent
+			if trimmed = strings.TrimSpace(item); trimmed == "" {
+				continue
+			}
+		} else if current == nil {
+			return nil, fmt.Errorf("invalid assertions line %d: %q (expected a - from: entry)", lineNum, trimmed)
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid assertions line %d: %q (expected key: value)", lineNum, trimmed)
+		}
+		if err := current.set(strings.TrimSpace(key), unquote(value)); err != nil {
+			return nil, fmt.Errorf("invalid assertions line %d: %w", lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read assertions: %w", err)
+	}
+
+	for _, a := range assertions {
+		if err := a.compile(); err != nil {
+			return nil, fmt.Errorf("assertion on line %d: %w", a.Line, err)
+		}
+	}
+	return assertions, nil
+}
+
+// set assigns a field of the assertion from a "key: value" line.
+func (a *Assertion) set(key, value string) error {
+	switch key {
+	case "name":
+		a.Name = value
+	case "from":
+		a.From = value
+	case "must_call":
+		a.MustCall = value
+	case "must_not_call":
+		a.MustNotCall = value
+	case "max_depth":
+		depth, err := strconv.Atoi(value)
+		if err != nil || depth <= 0 {
+			return fmt.Errorf("max_depth must be a positive number, got %q", value)
+		}
+		a.MaxDepth = depth
+	default:
+		return fmt.Errorf("unknown key %q (valid: name, from, must_call, must_not_call, max_depth)", key)
+	}
+	return nil
+}
+
+// compile checks the assertion has one rule and compiles its selectors.
+func (a *Assertion) compile() error {
+	rules := 0
+	for _, set := range []bool{a.MustCall != "", a.MustNotCall != "", a.MaxDepth > 0} {
+		if set {
+			rules++
+		}
+	}
+	if a.From == "" {
+		return fmt.Errorf("from is required")
+	}
+	if rules != 1 {
+		return fmt.Errorf("exactly one of must_call, must_not_call and max_depth is required")
+	}
+
+	var err error
+	if a.from, err = parseSelector(a.From); err != nil {
+		return err
+	}
+	if a.MustCall != "" {
+		if a.mustCall, err = parseSelector(a.MustCall); err != nil {
+			return err
+		}
+	}
+	if a.MustNotCall != "" {
+		if a.mustNotCall, err = parseSelector(a.MustNotCall); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Evaluate checks each assertion against the graph. An assertion whose from selector
+// matches no node fails, so renamed nodes don't silently pass.
+func Evaluate(graph *analyzer.TemporalGraph, assertions []*Assertion) []Result {
+	ids := make([]string, 0, len(graph.Nodes))
+	for id := range graph.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	results := make([]Result, 0, len(assertions))
+	for _, a := range assertions {
+		var violations []string
+		matched := 0
+		for _, id := range ids {
+			node := graph.Nodes[id]
+			if !a.from.match(node, id) {
+				continue
+			}
+			matched++
+			violations = append(violations, a.check(graph, node)...)
+		}
+		if matched == 0 {
+			violations = append(violations, fmt.Sprintf("%s matches no node", a.From))
+		}
+		results = append(results, Result{Assertion: a, Passed: len(violations) == 0, Violations: violations})
+	}
+	return results
+}
+
+// check returns the violations of the assertion by one of the nodes it applies to.
+func (a *Assertion) check(graph *analyzer.TemporalGraph, node *analyzer.TemporalNode) []string {
+	switch {
+	case a.mustCall != nil:
+		for _, call := range node.CallSites {
+			if a.mustCall.matchTarget(graph, call.TargetName) {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("%s (%s:%d) does not call %s", node.Name, node.FilePath, node.LineNumber, a.MustCall)}
+	case a.mustNotCall != nil:
+		var violations []string
+		for _, call := range node.CallSites {
+			if a.mustNotCall.matchTarget(graph, call.TargetName) {
+				violations = append(violations, fmt.Sprintf("%s calls %s (%s:%d)", node.Name, call.TargetName, call.FilePath, call.LineNumber))
+			}
+		}
+		return violations
+	default:
+		if chain := longestChain(graph, node); len(chain)-1 > a.MaxDepth {
+			return []string{fmt.Sprintf("call depth from %s is %d: %s", node.Name, len(chain)-1, strings.Join(chain, " -> "))}
+		}
+		return nil
+	}
+}
+
+// longestChain returns the names of the nodes of the longest acyclic call chain from node,
+// starting with node. Depth is counted like lint rule TA021.
+func longestChain(graph *analyzer.TemporalGraph, node *analyzer.TemporalNode) []string {
+	f := &chainFinder{
+		graph:   graph,
+		depth:   make(map[string]int),
+		next:    make(map[string]*analyzer.TemporalNode),
+		onChain: make(map[string]bool),
+	}
+	f.visit(node)
+
+	var chain []string
+	for n := node; n != nil; n = f.next[n.ID()] {
+		chain = append(chain, n.Name)
+	}
+	return chain
+}
+
+// chainFinder finds longest call chains as longest paths of a DAG: a call back to a node on
+// the chain being followed closes a cycle and is ignored, and the longest chain from each node
+// is computed once, so the search is linear in the calls rather than exponential.
+type chainFinder struct {
+	graph *analyzer.TemporalGraph
+	// depth is the number of calls of the longest chain from a node, by ID, and next is the
+	// node it continues with
+	depth map[string]int
+	next  map[string]*analyzer.TemporalNode
+	// onChain holds the nodes of the chain being followed
+	onChain map[string]bool
+}
+
+// visit returns the number of calls of the longest chain from node.
+func (f *chainFinder) visit(node *analyzer.TemporalNode) int {
+	id := node.ID()
+	if depth, ok := f.depth[id]; ok {
+		return depth
+	}
+	f.onChain[id] = true
+	longest := 0
+	for _, call := range node.CallSites {
+		child, ok := f.graph.Nodes[call.TargetName]
+		if !ok || f.onChain[child.ID()] {
+			continue
+		}
+		if depth := f.visit(child) + 1; depth > longest {
+			longest, f.next[id] = depth, child
+		}
+	}
+	f.onChain[id] = false
+	f.depth[id] = longest
+	return longest
+}
+
+// selector selects nodes by a name glob or a query expression.
+type selector struct {
+	glob  string
+	query *analyzer.Query
+}
+
+// nameGlob matches selectors that are name globs rather than query expressions.
+var nameGlob = regexp.MustCompile(`^[\w.*?/\[\]-]+$`)
+
+func parseSelector(src string) (*selector, error) {
+	if nameGlob.MatchString(src) {
+		if _, err := path.Match(src, ""); err != nil {
+			return nil, fmt.Errorf("invalid name pattern %q: %w", src, err)
+		}
+		return &selector{glob: src}, nil
+	}
+	query, err := analyzer.ParseQuery(src)
+	if err != nil {
+		return nil, err
+	}
+	return &selector{query: query}, nil
+}
+
+// match reports whether a node is selected. Globs are matched against the node name, its
+// ID and its package-qualified name.
+func (s *selector) match(node *analyzer.TemporalNode, id string) bool {
+	if s.query != nil {
+		return s.query.Match(node)
+	}
+	names := []string{node.Name, id}
+	if node.Package != "" {
+		names = append(names, node.Package+"."+node.Name)
+	}
+	for _, name := range names {
+		if ok, _ := path.Match(s.glob, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTarget reports whether a call target is selected; targets missing from the graph
+// are matched by name only.
+func (s *selector) matchTarget(graph *analyzer.TemporalGraph, target string) bool {
+	if node, ok := graph.Nodes[target]; ok {
+		return s.match(node, target)
+	}
+	if s.query != nil {
+		return false
+	}
+	ok, _ := path.Match(s.glob, target)
+	return ok
+}
+
+// WriteText prints a line per assertion, the violations of failed ones and a summary.
+func WriteText(w io.Writer, results []Result, set glyphs.Set) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Passed {
+			printf("%s %s\n", set.Success, r.Assertion.Title())
+			continue
+		}
+		failed++
+		printf("%s %s\n", set.Error, r.Assertion.Title())
+		if r.Assertion.Name != "" {
+			printf("    %s\n", r.Assertion.String())
+		}
+		for _, v := range r.Violations {
+			printf("    %s %s\n", set.Arrow, v)
+		}
+	}
+	printf("%d assertion(s), %d failed\n", len(results), failed)
+	return err
+}
+
+// stripComment removes the " #" comment ending a line, if any. A # inside a quoted value,
+// one starting after a key, a list dash or at the beginning of the line, is kept.
+func stripComment(line string) string {
+	var quote byte
+	prev := byte(0) // Last non-blank character outside quotes
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (prev == 0 || prev == ':' || prev == '-'):
+			quote = c
+		case c == '#' && i > 0 && (line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+		if quote == 0 && c != ' ' && c != '\t' {
+			prev = c
+		}
+	}
+	return line
+}
+
+// unquote trims whitespace and YAML quotes from a scalar.
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' && s[len(s)-1] == '"' || s[0] == '\'' && s[len(s)-1] == '\'') {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
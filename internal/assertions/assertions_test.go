@@ -0,0 +1,178 @@
+package assertions
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+)
+
+// testGraph is OrderWorkflow -> ShippingWorkflow -> legacy.Ship, and OrderWorkflow -> ChargeCard.
+func testGraph() *analyzer.TemporalGraph {
+	return &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name: "OrderWorkflow", Type: "workflow", Package: "orders", FilePath: "orders/workflow.go", LineNumber: 10,
+				CallSites: []analyzer.CallSite{
+					{TargetName: "ChargeCard", CallType: "activity", FilePath: "orders/workflow.go", LineNumber: 12},
+					{TargetName: "ShippingWorkflow", CallType: "child_workflow", FilePath: "orders/workflow.go", LineNumber: 14},
+				},
+			},
+			"ShippingWorkflow": {
+				Name: "ShippingWorkflow", Type: "workflow", Package: "shipping", FilePath: "shipping/workflow.go", LineNumber: 5,
+				CallSites: []analyzer.CallSite{{TargetName: "Ship", CallType: "activity", FilePath: "shipping/workflow.go", LineNumber: 7}},
+				Parents:   []string{"OrderWorkflow"},
+			},
+			"ChargeCard": {Name: "ChargeCard", Type: "activity", Package: "orders", Parents: []string{"OrderWorkflow"}},
+			"Ship":       {Name: "Ship", Type: "activity", Package: "legacy", Parents: []string{"ShippingWorkflow"}},
+		},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	src := `# Architecture invariants
+assertions:
+  - name: Orders charge the card
+    from: OrderWorkflow
+    must_call: ChargeCard
+  - from: ShippingWorkflow
+    must_call: Charge*
+  - name: Workflows stay off legacy activities
+    from: type==workflow
+    must_not_call: "package=='legacy'"
+  - from: OrderWorkflow
+    max_depth: 2
+  - from: OrderWorkflow
+    max_depth: 1
+  - from: RenamedWorkflow
+    must_call: ChargeCard
+`
+	list, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	results := Evaluate(testGraph(), list)
+	want := []struct {
+		passed     bool
+		violations []string
+	}{
+		{true, nil},
+		{false, []string{"ShippingWorkflow (shipping/workflow.go:5) does not call Charge*"}},
+		{false, []string{"ShippingWorkflow calls Ship (shipping/workflow.go:7)"}},
+		{true, nil},
+		{false, []string{"call depth from OrderWorkflow is 2: OrderWorkflow -> ShippingWorkflow -> Ship"}},
+		{false, []string{"RenamedWorkflow matches no node"}},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Passed != w.passed || strings.Join(r.Violations, "\n") != strings.Join(w.violations, "\n") {
+			t.Errorf("%s: passed = %v, violations = %q, want %v, %q", r.Assertion.Title(), r.Passed, r.Violations, w.passed, w.violations)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteText(&buf, results, glyphs.ASCII); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"Orders charge the card\n",
+		"Workflows stay off legacy activities\n    type==workflow must not call package=='legacy'\n",
+		"6 assertion(s), 4 failed\n",
+	} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("output does not contain %q:\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{"missing root key", "- from: A\n", "expected assertions:"},
+		{"missing from", "assertions:\n  - must_call: A\n", "from is required"},
+		{"no rule", "assertions:\n  - from: A\n", "exactly one of must_call"},
+		{"two rules", "assertions:\n  - from: A\n    must_call: B\n    max_depth: 3\n", "exactly one of must_call"},
+		{"bad depth", "assertions:\n  - from: A\n    max_depth: deep\n", "max_depth must be a positive number"},
+		{"unknown key", "assertions:\n  - from: A\n    must_reach: B\n", `unknown key "must_reach"`},
+		{"bad query", "assertions:\n  - from: type===workflow\n    max_depth: 3\n", "assertion on line 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.src))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseComments(t *testing.T) {
+	src := `assertions:
+  - name: "Stay off #legacy" # the name keeps its #
+    from: type==workflow # comment
+    must_not_call: "name=='Ship #2'" # the query keeps its #
+`
+	list, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if a := list[0]; a.Name != "Stay off #legacy" || a.From != "type==workflow" || a.MustNotCall != "name=='Ship #2'" {
+		t.Errorf("assertion = %+v", a)
+	}
+}
+
+func TestLongestChainLayered(t *testing.T) {
+	// 40 layers of 3 nodes, each calling every node of the next layer and the first node of
+	// the layer before: 3^40 chains, with cycles
+	graph := &analyzer.TemporalGraph{Nodes: make(map[string]*analyzer.TemporalNode)}
+	name := func(layer, i int) string { return fmt.Sprintf("L%dN%d", layer, i) }
+	const layers = 40
+	for layer := 0; layer < layers; layer++ {
+		for i := 0; i < 3; i++ {
+			node := &analyzer.TemporalNode{Name: name(layer, i), Type: "workflow"}
+			if layer+1 < layers {
+				for j := 0; j < 3; j++ {
+					node.CallSites = append(node.CallSites, analyzer.CallSite{TargetName: name(layer+1, j)})
+				}
+			}
+			if layer > 0 {
+				node.CallSites = append(node.CallSites, analyzer.CallSite{TargetName: name(layer-1, 0)})
+			}
+			graph.Nodes[node.Name] = node
+		}
+	}
+
+	chain := longestChain(graph, graph.Nodes[name(0, 0)])
+	if len(chain) < layers {
+		t.Fatalf("chain has %d nodes, want at least %d: %v", len(chain), layers, chain)
+	}
+	seen := make(map[string]bool)
+	for i, n := range chain {
+		if seen[n] {
+			t.Fatalf("chain visits %s twice: %v", n, chain)
+		}
+		seen[n] = true
+		if i > 0 && !calls(graph.Nodes[chain[i-1]], n) {
+			t.Fatalf("%s does not call %s: %v", chain[i-1], n, chain)
+		}
+	}
+}
+
+func calls(node *analyzer.TemporalNode, target string) bool {
+	for _, call := range node.CallSites {
+		if call.TargetName == target {
+			return true
+		}
+	}
+	return false
+}
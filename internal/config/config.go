@@ -4,9 +4,14 @@ package config
 import (
 	"flag"
 	"fmt"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 )
 
 // Config holds the application configuration.
@@ -17,15 +22,55 @@ type Config struct {
 	IncludeTests  bool     `json:"include_tests"`
 	FilterPackage string   `json:"filter_package,omitempty"`
 	FilterName    string   `json:"filter_name,omitempty"`
+	Query         string   `json:"query,omitempty"` // Node filter expression, e.g. "type==workflow && fanout>5"
+	Domains       string   `json:"domains,omitempty"` // Comma-separated package glob=domain mappings, e.g. "payments/**=Payments"
+	Churn         bool     `json:"churn,omitempty"`   // Compute per-node churn and age from git history
+	Ref           string   `json:"ref,omitempty"`     // Git revision to analyze instead of the working tree
+	MetadataFile  string   `json:"metadata_file,omitempty"` // Metadata overlay of node tags, descriptions and annotations
+	MinConfidence string   `json:"min_confidence,omitempty"` // Drop nodes detected with a lower confidence: low, medium or high
+	IncludeDeps   bool     `json:"include_deps,omitempty"`   // Also analyze dependency modules in the tree, such as a vendored SDK
+	AllowModules  string   `json:"allow_modules,omitempty"`  // Comma-separated module path patterns analyzed even when they are dependencies
+	InternalDepth int      `json:"internal_depth,omitempty"` // Resolve internal helper calls this many levels deep into helper nodes
+	Typed         bool     `json:"typed,omitempty"`          // Resolve call targets with type information
+	// WorkTreeDir is the original RootDir when RootDir points to a --ref snapshot
+	WorkTreeDir string `json:"-"`
+	// Packages are Go package patterns given as arguments (./services/payments/...), resolved
+	// in RootDir and analyzed instead of all of it; PackageDirs are the directories go list
+	// resolves them to
+	Packages    []string `json:"packages,omitempty"`
+	PackageDirs []string `json:"-"`
+
+	// Resolution options
+	StrictResolution bool `json:"strict_resolution,omitempty"` // Fail when unresolved call targets exceed MaxUnresolved
+	MaxUnresolved    int  `json:"max_unresolved,omitempty"`    // Unresolved call targets tolerated by StrictResolution
+	StrictFiles      bool `json:"strict_files,omitempty"`      // Fail when files that failed to analyze exceed MaxFailedFiles
+	MaxFailedFiles   int  `json:"max_failed_files,omitempty"`  // Files failing to analyze tolerated by StrictFiles
 
 	// Output options
 	OutputFormat string `json:"output_format"` // "tui", "json", "tree", "dot"
+	OutputFormats []OutputFormat `json:"-"` // Formats accepted by --format besides tui
 	OutputFile   string `json:"output_file,omitempty"`
+	Fields       string `json:"fields,omitempty"` // Comma-separated node fields to project in JSON output
+	TemplateFile string `json:"template_file,omitempty"` // Go text/template rendered by the template format
+	LinkBase     string `json:"link_base,omitempty"` // URL of the repository root in a code browser for node links
+	LegacyJSON   bool   `json:"legacy_json,omitempty"` // Also emit the deprecated "children" key in JSON output
+	MaxDepth     int    `json:"max_depth,omitempty"` // Max depth of tree output (0 = unlimited)
+	Plain        bool   `json:"plain,omitempty"`     // Use ASCII instead of Unicode/emoji in non-TUI outputs
 	GraphTool    string `json:"graph_tool"` // "dot", "fdp", "neato", "circo"
+	Emits        []Emit `json:"emits,omitempty"` // Outputs written from a single analysis pass (pipeline mode)
+
+	// Pruning of graph outputs
+	Hide             string   `json:"hide,omitempty"`              // Comma-separated node kinds left out of graph outputs, e.g. "activities"
+	CollapsePackages []string `json:"collapse_packages,omitempty"` // Package patterns (pkg/...) whose nodes are merged into one
+	MinFanOut        int      `json:"min_fanout,omitempty"`        // Keep only nodes calling at least this many targets, and their targets
 
 	// UI options
+	SortBy         string `json:"sort_by,omitempty"` // Initial list order: "name", "type", "package", "connections", "churn"
 	ShowWorkflows  bool `json:"show_workflows"`
 	ShowActivities bool `json:"show_activities"`
+	Accessible     bool `json:"accessible,omitempty"`   // Screen-reader friendly TUI: no colors, ">" selection, spoken view changes
+	LineSpacing    int  `json:"line_spacing,omitempty"` // Blank lines between TUI list and tree rows
+	Theme          string `json:"theme,omitempty"`        // TUI color theme: "default" or "neon"
 
 	// Debug options
 	Verbose   bool   `json:"verbose"`
@@ -40,18 +85,131 @@ type Config struct {
 	LintMinSeverity   string `json:"lint_min_severity"`   // "error", "warning", "info"
 	LintDisabledRules string `json:"lint_disabled_rules"` // Comma-separated rule IDs to disable
 	LintEnabledRules  string `json:"lint_enabled_rules"`  // Comma-separated rule IDs to enable (exclusive)
+	LintCategories    string   `json:"lint_categories,omitempty"` // Comma-separated rule categories to run, e.g. "reliability,security"
+	LintTags          string   `json:"lint_tags,omitempty"`       // Comma-separated rule tags to run, e.g. "determinism"
 	LintListRules     bool   `json:"lint_list_rules"`     // List available lint rules and exit
+	LintDocs          string `json:"lint_docs,omitempty"` // Directory to write rule documentation to, then exit
+	LintCoverage      bool   `json:"lint_coverage,omitempty"` // Report what each rule could check
+	LintTests         bool   `json:"lint_tests,omitempty"`    // Report issues on nodes declared in test files
 
 	// Lint thresholds
 	LintMaxFanOut    int `json:"lint_max_fan_out"`    // Max allowed fan-out before warning
 	LintMaxCallDepth int `json:"lint_max_call_depth"` // Max call chain depth before warning
 
+	// Long-running activity heuristics (TA003)
+	LintLongRunningInclude     string        `json:"lint_long_running_include,omitempty"`      // Regex of long-running activity names
+	LintLongRunningExclude     string        `json:"lint_long_running_exclude,omitempty"`      // Regex of activity names that are never long-running
+	LintLongRunningMinDuration time.Duration `json:"lint_long_running_min_duration,omitempty"` // Duration hint from which an activity is long-running
+
+	// Diff options
+	LintDiffBase string `json:"lint_diff_base,omitempty"` // Git ref to compare workflows against for breaking changes
+
+	// Annotation options
+	LintProfiles string `json:"lint_profiles,omitempty"` // Comma-separated glob=profile mappings, e.g. "payments/**=strict"
+	LintRequireAnnotations string `json:"lint_require_annotations,omitempty"` // Comma-separated required annotations, e.g. "owner,workflow/sla"
+	LintDedicatedTypes string `json:"lint_dedicated_types,omitempty"` // Comma-separated type name globs for dedicated workflow input/output types
+
+	// workflowcheck compatibility options
+	WorkflowcheckConfig  string `json:"workflowcheck_config,omitempty"`  // workflowcheck config whose decls and skips suppress determinism issues
+	WorkflowcheckResults string `json:"workflowcheck_results,omitempty"` // workflowcheck output to import as lint issues
+
+	// Notification options
+	NotifyWebhook string `json:"notify_webhook,omitempty"` // Slack-compatible webhook URL for lint summaries
+
+	// Tracker options
+	FileIssues string `json:"file_issues,omitempty"` // Tracker to file new lint errors in: github:owner/repo or jira:PROJECT
+
+	// Contract options
+	ContractsMode bool `json:"contracts_mode"` // Write workflow contracts (YAML) and exit
+
+	// Onboarding options
+	InitMode   bool   `json:"init_mode"`             // Inspect the project and write a starter config file and CI workflow
+	ConfigPath string `json:"config_path,omitempty"` // Config file of flag defaults (default: .temporal-analyzer.yaml, if present)
+
+	// Assertion options
+	AssertMode     bool   `json:"assert_mode"`               // Evaluate architecture assertions against the graph and exit
+	AssertionsFile string `json:"assertions_file,omitempty"` // Assertions file (default: analyzer-assertions.yaml in the root)
+
+	// Explain options
+	ExplainNodeMode bool `json:"explain_node_mode"` // Explain why the function named by FilterName was or was not detected and exit
+
+	// Rename options
+	RenameMode   bool   `json:"rename_mode"`              // Rename the node Node to RenameTo in its Temporal references and exit
+	Node         string `json:"node,omitempty"`           // Name or graph key of the workflow or activity to rename, or to report the issue history of
+	RenameTo     string `json:"rename_to,omitempty"`      // New function or method name
+	RenameDryRun bool   `json:"rename_dry_run,omitempty"` // Print the edits instead of applying them
+
+	// Fix options
+	FixMode   bool `json:"fix_mode"`              // Apply the code fixes of the lint issues and exit
+	FixDryRun bool `json:"fix_dry_run,omitempty"` // Print the fixes as a unified diff instead of applying them
+
+	// Stats options
+	StatsMode   bool   `json:"stats_mode"`             // Print aggregate tables grouped by StatsBy and exit
+	StatsBy     string `json:"stats_by,omitempty"`     // "package", "taskqueue" or "owner"
+	StatsFormat string `json:"stats_format,omitempty"` // "markdown" or "csv" (--format json for JSON)
+	// StatsTimeouts reports the distribution of activity timeouts per package instead
+	StatsTimeouts bool `json:"stats_timeouts,omitempty"`
+	// StatsWorkflowIDs reports the workflow ID patterns and their collisions instead
+	StatsWorkflowIDs bool `json:"stats_workflow_ids,omitempty"`
+
+	// Load simulation options
+	SimulateMode     bool    `json:"simulate_mode"`               // Estimate task queue backlog and latency for a workflow start rate and exit
+	SimulateWorkflow string  `json:"simulate_workflow,omitempty"` // Workflow started, by name or graph key
+	SimulateRPS      float64 `json:"simulate_rps,omitempty"`      // Workflow starts per second
+	SimulateReplicas int     `json:"simulate_replicas,omitempty"` // Processes running each analyzed worker
+
+	// Inventory options
+	InventoryMode     bool   `json:"inventory_mode"`               // Compare defined types with the types a Temporal server has seen and exit
+	TemporalHTTP      string `json:"temporal_http,omitempty"`      // Temporal HTTP API URL, e.g. http://localhost:7243
+	TemporalNamespace string `json:"temporal_namespace,omitempty"` // Namespace to inspect
+	InventoryDays     int    `json:"inventory_days,omitempty"`     // Days of executions to consider
+
+	// Server options
+	ServeGRPC string `json:"serve_grpc,omitempty"` // Address to serve the AnalyzerService gRPC API on, e.g. :9090
+	// DaemonMode keeps the analyzed graph in memory and answers editor requests on Socket
+	DaemonMode bool   `json:"daemon_mode,omitempty"`
+	Socket     string `json:"socket,omitempty"` // Unix socket path of the daemon
+
+	// History options
+	HistoryDB string `json:"history_db,omitempty"` // Snapshot history database to record each analysis in
+	TrendMode bool   `json:"trend_mode"`           // Report metric trends from the history database and exit
+	// HistoryMode reports when the lint issues of Node appeared and disappeared in the
+	// history database and exits
+	HistoryMode bool `json:"history_mode"`
+
+	// Replay options
+	ReplayMode         bool   `json:"replay_mode"`          // Replay workflow histories against the analyzed code
+	ReplayHistories    string `json:"replay_histories"`     // Directory of workflow history JSON files
+	ReplayGenerateOnly bool   `json:"replay_generate_only"` // Only generate the replay test programs
+	ReplayKeep         bool   `json:"replay_keep"`          // Keep generated replay test files after running
+
 	// LLM enhancement options
 	LLMEnhance bool   `json:"llm_enhance"` // Use LLM to generate context-aware fixes
 	LLMVerify  bool   `json:"llm_verify"`  // Use LLM to verify/filter findings
 	LLMModel   string `json:"llm_model"`   // Override OpenAI model (default: gpt-4o-mini)
 }
 
+// Emit is an output of pipeline mode: an output or lint format written to a path ("-" for stdout).
+type Emit struct {
+	Format string `json:"format"`
+	Path   string `json:"path"`
+	Lint   bool   `json:"lint,omitempty"` // Format is a lint format, written from the lint result
+}
+
+// LintFormatNames are the formats accepted by --lint-format.
+var LintFormatNames = []string{"text", "text-no-color", "json", "github", "sarif", "checkstyle", "heatmap", "heatmap-html", "pr-comment"}
+
+// OutputFormat names an output format and describes it for --help.
+type OutputFormat struct {
+	Name        string
+	Description string
+}
+
+// defaultOutputFormats are the formats accepted until the output registry provides its own.
+var defaultOutputFormats = []OutputFormat{
+	{Name: "json"}, {Name: "tree"}, {Name: "dot"}, {Name: "mermaid"}, {Name: "mermaid-sequence"}, {Name: "markdown"}, {Name: "flows"}, {Name: "sql"}, {Name: "sqlite"}, {Name: "template"},
+}
+
 // NewConfig creates a new configuration with default values.
 func NewConfig() *Config {
 	return &Config{
@@ -59,12 +217,25 @@ func NewConfig() *Config {
 		ExcludeDirs:    []string{"vendor", ".git", "node_modules"},
 		IncludeTests:   false,
 		OutputFormat:   "tui",
+		OutputFormats:  defaultOutputFormats,
 		GraphTool:      "dot",
 		ShowWorkflows:  true,
 		ShowActivities: true,
 		Verbose:        false,
 		Debug:          false,
 
+		// Inventory defaults
+		TemporalHTTP:      "http://localhost:7243",
+		TemporalNamespace: "default",
+		InventoryDays:     30,
+
+		// Stats defaults
+		StatsBy:     "package",
+		StatsFormat: "markdown",
+
+		// Load simulation defaults
+		SimulateReplicas: 1,
+
 		// Lint defaults
 		LintMode:          false,
 		LintFormat:        "text",
@@ -85,27 +256,78 @@ func NewConfig() *Config {
 
 // ParseFlags parses command line flags and updates the config.
 // Supports optional positional argument anywhere: temporal-analyzer [flags] [path] [flags]
-// The path can appear before, after, or between flags.
+// The path can appear before, after, or between flags. Package patterns, or several
+// paths, select Go packages like go vet does: temporal-analyzer ./services/payments/...
 func (c *Config) ParseFlags() error {
 	// Pre-process args to extract positional path argument from anywhere in the command line
 	// This allows: `temporal-analyzer --lint . --format json` to work correctly
-	args, positionalPath := extractPositionalPath(os.Args[1:])
+	args, positional := extractPositionalArgs(os.Args[1:])
+	var positionalPath string
+	if len(positional) == 1 && !isPackagePattern(positional[0]) {
+		positionalPath = positional[0]
+	} else {
+		c.Packages = positional
+	}
 
 	// Create a new flag set for clean parsing
 	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 
-	// Track if --root was explicitly set
-	rootSet := false
-
+	fs.StringVar(&c.ConfigPath, "config", c.ConfigPath, "Read flag defaults from a YAML file of flag names and values (default: "+ConfigFile+" in the current directory, if present)")
 	fs.StringVar(&c.RootDir, "root", c.RootDir, "Root directory to analyze (alternative: positional arg)")
-	fs.StringVar(&c.FilterPackage, "package", c.FilterPackage, "Filter by package name (regex)")
-	fs.StringVar(&c.FilterName, "name", c.FilterName, "Filter by function name (regex)")
-	fs.StringVar(&c.OutputFormat, "format", c.OutputFormat, "Output format (tui, json, tree, dot)")
+	fs.Func("exclude", "Comma-separated directory names or globs to skip besides vendor, .git and node_modules; repeatable", func(value string) error {
+		for _, dir := range strings.Split(value, ",") {
+			if dir = strings.TrimSpace(dir); dir != "" {
+				c.ExcludeDirs = append(c.ExcludeDirs, dir)
+			}
+		}
+		return nil
+	})
+	fs.StringVar(&c.FilterPackage, "package", c.FilterPackage, "Filter by package name (regex; prefer -query \"package=~'...'\")")
+	fs.StringVar(&c.FilterName, "name", c.FilterName, "Filter by function name (regex; prefer -query \"name=~'...'\")")
+	fs.StringVar(&c.MinConfidence, "min-confidence", c.MinConfidence, "Drop nodes detected with a lower confidence (low, medium, high); lint skips low-confidence nodes unless set to low")
+	fs.StringVar(&c.Query, "query", c.Query, "Filter nodes with an expression, e.g. \"type==workflow && package=~'payments' && fanout>5 && has(signals)\"")
+	fs.StringVar(&c.Domains, "domains", c.Domains, "Comma-separated package glob=domain mappings, e.g. \"services/payments/**=Payments,orders=Orders\"")
+	fs.StringVar(&c.Ref, "ref", c.Ref, "Analyze the repository at a git revision (commit, tag or branch) without checking it out")
+	fs.StringVar(&c.MetadataFile, "metadata", c.MetadataFile, "YAML overlay of node tags, descriptions, annotations and deprecations (default: analyzer-metadata.yaml in the root, if present)")
+	fs.BoolVar(&c.Churn, "churn", c.Churn, "Compute per-node churn (commits in the last 90 days) and age from git history; colors DOT output")
+	fs.BoolVar(&c.StrictResolution, "strict-resolution", c.StrictResolution, "Fail when more call targets than --max-unresolved are not found in the analyzed code")
+	fs.IntVar(&c.MaxUnresolved, "max-unresolved", c.MaxUnresolved, "Unresolved call targets tolerated by --strict-resolution (default: 0)")
+	fs.BoolVar(&c.StrictFiles, "strict-files", c.StrictFiles, "Fail when more Go files than --max-failed-files cannot be parsed or crash the analysis")
+	fs.IntVar(&c.MaxFailedFiles, "max-failed-files", c.MaxFailedFiles, "Files failing to analyze tolerated by --strict-files (default: 0)")
+	fs.StringVar(&c.OutputFormat, "format", c.OutputFormat, "Output format ("+strings.Join(c.outputFormatNames(), ", ")+")")
+	fs.Func("emit", "Write an output as format=path from a single analysis pass; repeatable (e.g. --emit json=graph.json --emit sarif=lint.sarif). Lint formats sharing a name with an output format take a lint- prefix (lint-json)", func(value string) error {
+		format, path, ok := strings.Cut(value, "=")
+		if !ok || format == "" || path == "" {
+			return fmt.Errorf("expected format=path, got %q", value)
+		}
+		c.Emits = append(c.Emits, Emit{Format: format, Path: path})
+		return nil
+	})
 	fs.StringVar(&c.OutputFile, "output", c.OutputFile, "Output file (defaults to stdout)")
+	fs.StringVar(&c.Hide, "hide", c.Hide, "Comma-separated node kinds to leave out of graph outputs ("+strings.Join(HideableKinds(), ", ")+")")
+	fs.Func("collapse-package", "Merge the nodes of packages matching a pattern into one node with counts in graph outputs; repeatable (e.g. services/payments/... or **/internal/**)", func(value string) error {
+		c.CollapsePackages = append(c.CollapsePackages, value)
+		return nil
+	})
+	fs.IntVar(&c.MinFanOut, "min-fanout", c.MinFanOut, "Keep only nodes calling at least N distinct targets, and the nodes they call, in graph outputs (0 = all)")
+	fs.BoolVar(&c.Plain, "plain", c.Plain, "Use ASCII instead of Unicode/emoji in non-TUI outputs (auto-enabled for TERM=dumb or non-UTF-8 locales)")
+	fs.IntVar(&c.MaxDepth, "max-depth", c.MaxDepth, "Max depth of tree output (0 = unlimited)")
+	fs.StringVar(&c.Fields, "fields", c.Fields, "Comma-separated node fields for JSON output, e.g. name,type,file,call_sites.target_name")
+	fs.StringVar(&c.TemplateFile, "template-file", c.TemplateFile, "Go text/template file rendered by --format template (funcs: sortNodes, byType, callers, callees)")
+	fs.StringVar(&c.LinkBase, "link-base", c.LinkBase, "URL of the repository root in a code browser that Mermaid nodes link to with their file and line, e.g. https://github.com/org/repo/blob/main (default: the commit in GitHub Actions, else relative paths)")
+	fs.BoolVar(&c.LegacyJSON, "legacy-json", c.LegacyJSON, "Also emit the deprecated \"children\" list of call targets on each node in JSON output")
 	fs.StringVar(&c.GraphTool, "graph-tool", c.GraphTool, "Graph layout tool (dot, fdp, neato, circo)")
 	fs.BoolVar(&c.IncludeTests, "include-tests", c.IncludeTests, "Include test files in analysis")
+	fs.BoolVar(&c.IncludeDeps, "include-deps", c.IncludeDeps, "Also analyze dependency modules in the tree: nested modules required by the root module, and Temporal SDK and samples modules")
+	fs.IntVar(&c.InternalDepth, "internal-depth", c.InternalDepth, "Resolve internal helper calls this many levels deep into helper nodes, so Temporal calls made in helpers are linked to the workflow (0 = off)")
+	fs.BoolVar(&c.Typed, "typed", c.Typed, "Type-check the packages and resolve call targets to fully-qualified symbols, so method values like a.Charge resolve by the receiver's type (slower)")
+	fs.StringVar(&c.AllowModules, "allow-modules", c.AllowModules, "Comma-separated module path patterns analyzed even when they are dependencies (e.g. github.com/temporalio/samples-go/...)")
+	fs.StringVar(&c.SortBy, "sort", c.SortBy, "List view order (name, type, package, connections, churn; churn implies --churn)")
 	fs.BoolVar(&c.ShowWorkflows, "workflows", c.ShowWorkflows, "Show workflows")
 	fs.BoolVar(&c.ShowActivities, "activities", c.ShowActivities, "Show activities")
+	fs.BoolVar(&c.Accessible, "accessible", c.Accessible, "Screen-reader friendly TUI: no colors or gradients, \">\" marks the selection, words for expanded/collapsed, and view changes announced in a status line")
+	fs.IntVar(&c.LineSpacing, "line-spacing", c.LineSpacing, "Blank lines between TUI list and tree rows")
+	fs.StringVar(&c.Theme, "theme", c.Theme, "TUI color theme (default, neon)")
 	fs.BoolVar(&c.Verbose, "verbose", c.Verbose, "Verbose output")
 	fs.BoolVar(&c.Debug, "debug", c.Debug, "Debug output")
 	fs.StringVar(&c.DebugView, "debug-view", c.DebugView, "Debug view rendering (list, tree, details)")
@@ -117,9 +339,87 @@ func (c *Config) ParseFlags() error {
 	fs.StringVar(&c.LintMinSeverity, "lint-level", c.LintMinSeverity, "Minimum severity to report (error, warning, info)")
 	fs.StringVar(&c.LintDisabledRules, "lint-disable", c.LintDisabledRules, "Comma-separated rule IDs to disable")
 	fs.StringVar(&c.LintEnabledRules, "lint-enable", c.LintEnabledRules, "Comma-separated rule IDs to enable (exclusive)")
+	fs.StringVar(&c.LintCategories, "lint-categories", c.LintCategories, "Comma-separated rule categories to run (reliability, best-practice, performance, maintenance, security)")
+	fs.StringVar(&c.LintTags, "lint-tags", c.LintTags, "Comma-separated rule tags to run, e.g. determinism (see --lint-rules)")
 	fs.BoolVar(&c.LintListRules, "lint-rules", c.LintListRules, "List all available lint rules and exit")
+	fs.BoolVar(&c.LintCoverage, "lint-coverage", c.LintCoverage, "Report, per rule, how many nodes or call sites were eligible, checked and skipped for missing data")
+	fs.BoolVar(&c.LintTests, "lint-tests", c.LintTests, "Report lint issues on nodes declared in test files (analyzed with --include-tests), skipped by default")
+	fs.StringVar(&c.LintDocs, "lint-docs", c.LintDocs, "Write Markdown documentation for each lint rule to a directory and exit (e.g. docs/rules)")
 	fs.IntVar(&c.LintMaxFanOut, "lint-max-fan-out", c.LintMaxFanOut, "Max fan-out before warning (default: 15)")
 	fs.IntVar(&c.LintMaxCallDepth, "lint-max-depth", c.LintMaxCallDepth, "Max call chain depth before warning (default: 10)")
+	fs.StringVar(&c.LintLongRunningInclude, "lint-long-running-include", c.LintLongRunningInclude, "Regex of activity names TA003 treats as long-running (default: (?i)process|batch|sync|import|export|migrate|generate|create|cleanup|duplicate)")
+	fs.StringVar(&c.LintLongRunningExclude, "lint-long-running-exclude", c.LintLongRunningExclude, "Regex of activity names TA003 never treats as long-running (e.g. ^Create)")
+	fs.DurationVar(&c.LintLongRunningMinDuration, "lint-long-running-min-duration", c.LintLongRunningMinDuration, "Duration from which activities with a @duration hint are long-running for TA003 (default: 1m)")
+	fs.StringVar(&c.LintDiffBase, "lint-diff-base", c.LintDiffBase, "Git ref to diff workflows against for unversioned breaking changes (e.g. origin/main)")
+	fs.StringVar(&c.LintProfiles, "lint-profiles", c.LintProfiles, "Comma-separated glob=profile mappings applying strict, default, warnings or off to directories or tag:name overlay tags (e.g. payments/**=strict,tag:critical=strict)")
+	fs.StringVar(&c.LintRequireAnnotations, "lint-require-annotations", c.LintRequireAnnotations, "Comma-separated doc comment annotations nodes must declare, optionally per type (e.g. owner,workflow/sla)")
+	fs.StringVar(&c.WorkflowcheckConfig, "workflowcheck-config", c.WorkflowcheckConfig, "Import a workflowcheck config file: its decls and skipped packages suppress determinism issues")
+	fs.StringVar(&c.WorkflowcheckResults, "workflowcheck-results", c.WorkflowcheckResults, "Import workflowcheck output (file:line:col: X is non-deterministic, reason: ...) as lint issues")
+	fs.StringVar(&c.LintDedicatedTypes, "lint-dedicated-types", c.LintDedicatedTypes, "Comma-separated type name globs of dedicated workflow input/output types (default: *Input,*Output,*Request,*Response)")
+
+	// Notification flags
+	fs.StringVar(&c.NotifyWebhook, "notify-webhook", c.NotifyWebhook, "Post a lint summary to a Slack-compatible webhook URL")
+
+	// Tracker flags
+	fs.StringVar(&c.FileIssues, "file-issues", c.FileIssues, "File new lint errors as tickets (github:owner/repo or jira:PROJECT)")
+
+	// Contract flags
+	fs.BoolVar(&c.ContractsMode, "contracts", c.ContractsMode, "Write workflow contracts as YAML (non-interactive)")
+
+	// Onboarding flags
+	fs.BoolVar(&c.InitMode, "init", c.InitMode, "Inspect the project, ask a few questions and write a starter "+ConfigFile+" and GitHub Actions workflow")
+
+	// Assertion flags
+	fs.BoolVar(&c.AssertMode, "assert", c.AssertMode, "Evaluate architecture assertions (must_call, must_not_call, max_depth) against the graph and exit 1 if any fails")
+	fs.StringVar(&c.AssertionsFile, "assertions", c.AssertionsFile, "Assertions file for --assert (default: analyzer-assertions.yaml in the root)")
+
+	// Explain flags
+	fs.BoolVar(&c.ExplainNodeMode, "explain-node", c.ExplainNodeMode, "Explain why the function given with --name (Name or Type.Method) was or was not detected, and exit")
+
+	// Rename flags
+	fs.BoolVar(&c.RenameMode, "rename", c.RenameMode, "Rename the workflow or activity given with --node to --to in its definition, call sites, registrations and string names, and exit")
+	fs.StringVar(&c.Node, "node", c.Node, "Workflow or activity to rename, or to report the issue history of with --history (name or Type.Method)")
+	fs.StringVar(&c.RenameTo, "to", c.RenameTo, "New name of the --node")
+	fs.BoolVar(&c.RenameDryRun, "dry-run", c.RenameDryRun, "Print the rename edits without writing the files")
+
+	// Fix flags
+	fs.BoolVar(&c.FixMode, "fix", c.FixMode, "Apply the lint fixes whose OldText still matches the source, gofmt the touched files and exit")
+	fs.BoolVar(&c.FixDryRun, "fix-dry-run", c.FixDryRun, "Print the lint fixes --fix would apply as a unified diff without writing the files")
+
+	// Stats flags
+	fs.BoolVar(&c.StatsMode, "stats", c.StatsMode, "Print node counts, average fan-out and issue counts grouped by --by (non-interactive)")
+	fs.StringVar(&c.StatsBy, "by", c.StatsBy, "Stats grouping (package, taskqueue, owner)")
+	fs.StringVar(&c.StatsFormat, "stats-format", c.StatsFormat, "Stats table format (markdown, csv)")
+	fs.BoolVar(&c.StatsTimeouts, "timeouts", c.StatsTimeouts, "With --stats, report min/median/max activity StartToClose and ScheduleToClose timeouts per package")
+	fs.BoolVar(&c.StatsWorkflowIDs, "workflow-ids", c.StatsWorkflowIDs, "With --stats, report the workflow ID patterns clients start workflows with and warn when workflow types share one")
+
+	// Load simulation flags
+	fs.BoolVar(&c.SimulateMode, "simulate-load", c.SimulateMode, "Estimate the task queue backlog and end-to-end latency of starting --workflow at --rps per second, from @duration hints, activity timeouts and worker options, and exit")
+	fs.StringVar(&c.SimulateWorkflow, "workflow", c.SimulateWorkflow, "Workflow started by --simulate-load (name or graph key)")
+	fs.Float64Var(&c.SimulateRPS, "rps", c.SimulateRPS, "Workflow starts per second for --simulate-load")
+	fs.IntVar(&c.SimulateReplicas, "replicas", c.SimulateReplicas, "Processes running each analyzed worker for --simulate-load")
+
+	// Inventory flags
+	fs.BoolVar(&c.InventoryMode, "inventory", c.InventoryMode, "Compare defined workflows/activities with the types a Temporal server executed (non-interactive, API key from TEMPORAL_API_KEY)")
+	fs.StringVar(&c.TemporalHTTP, "temporal-http", c.TemporalHTTP, "Temporal HTTP API URL for --inventory")
+	fs.StringVar(&c.TemporalNamespace, "temporal-namespace", c.TemporalNamespace, "Temporal namespace for --inventory")
+	fs.IntVar(&c.InventoryDays, "inventory-days", c.InventoryDays, "Report defined types not executed in this many days")
+
+	// Server flags
+	fs.StringVar(&c.ServeGRPC, "serve-grpc", c.ServeGRPC, "Serve the analyzer over gRPC on this address (e.g. :9090) instead of analyzing RootDir")
+	fs.BoolVar(&c.DaemonMode, "daemon", c.DaemonMode, "Keep the analyzed graph in memory and answer lint and node info requests from editors on --socket")
+	fs.StringVar(&c.Socket, "socket", c.Socket, "Unix socket path the --daemon listens on")
+
+	// History flags
+	fs.StringVar(&c.HistoryDB, "history-db", c.HistoryDB, "Record a dated snapshot of each analysis in this history database")
+	fs.BoolVar(&c.TrendMode, "trend", c.TrendMode, "Report workflow, issue and complexity trends from --history-db (non-interactive)")
+	fs.BoolVar(&c.HistoryMode, "history", c.HistoryMode, "Report when each lint issue of --node appeared and disappeared in --history-db (non-interactive)")
+
+	// Replay flags
+	fs.BoolVar(&c.ReplayMode, "replay", c.ReplayMode, "Replay workflow histories against the analyzed code (non-interactive)")
+	fs.StringVar(&c.ReplayHistories, "replay-histories", c.ReplayHistories, "Directory of workflow history JSON files to replay")
+	fs.BoolVar(&c.ReplayGenerateOnly, "replay-generate-only", c.ReplayGenerateOnly, "Only generate the replay test programs, don't run them")
+	fs.BoolVar(&c.ReplayKeep, "replay-keep", c.ReplayKeep, "Keep generated replay test files after running them")
 
 	// LLM enhancement flags
 	fs.BoolVar(&c.LLMEnhance, "llm-enhance", c.LLMEnhance, "Use LLM to generate context-aware code fixes (requires OPENAI_API_KEY)")
@@ -136,18 +436,25 @@ func (c *Config) ParseFlags() error {
 		fmt.Fprintf(os.Stderr, "        Can appear anywhere in the command line\n\n")
 		fmt.Fprintf(os.Stderr, "Flags:\n")
 		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nOutput formats:\n")
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", "tui", "Interactive terminal UI (default)")
+		for _, format := range c.OutputFormats {
+			fmt.Fprintf(os.Stderr, "  %-10s %s\n", format.Name, format.Description)
+		}
+	}
+
+	// Flags from the config file are set first, so that the command line overrides them
+	configPath, _ := flagArg(args, "config")
+	if err := applyConfigFile(fs, configPath); err != nil {
+		return err
 	}
 
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	// Check if --root was explicitly provided
-	fs.Visit(func(f *flag.Flag) {
-		if f.Name == "root" {
-			rootSet = true
-		}
-	})
+	// Check if --root was explicitly provided on the command line
+	_, rootSet := flagArg(args, "root")
 
 	// Use positional path if found and --root wasn't explicitly set
 	if positionalPath != "" && !rootSet {
@@ -157,40 +464,174 @@ func (c *Config) ParseFlags() error {
 	return c.Validate()
 }
 
-// extractPositionalPath separates flags from a positional path argument.
-// It identifies the first argument that looks like a path (doesn't start with -)
-// and isn't a value for a flag that takes a value.
-// Returns the filtered args (flags only) and the extracted path.
-func extractPositionalPath(args []string) ([]string, string) {
+// isOutputFormat reports whether name is accepted by --format.
+func (c *Config) isOutputFormat(name string) bool {
+	valid := name == "md" // alias of markdown
+	for _, n := range c.outputFormatNames() {
+		valid = valid || n == name
+	}
+	return valid
+}
+
+// usesOutputFormat reports whether the graph is written in an output format, by --format or --emit.
+func (c *Config) usesOutputFormat(name string) bool {
+	if c.OutputFormat == name {
+		return true
+	}
+	for _, emit := range c.Emits {
+		if !emit.Lint && emit.Format == name {
+			return true
+		}
+	}
+	return false
+}
+
+// EmitsLint reports whether any --emit output is a lint format.
+func (c *Config) EmitsLint() bool {
+	for _, emit := range c.Emits {
+		if emit.Lint {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveEmit determines whether an emitted format is an output or a lint format. Output
+// formats take precedence; a lint- prefix selects the lint format of the same name.
+func (c *Config) resolveEmit(emit *Emit) error {
+	if name, ok := strings.CutPrefix(emit.Format, "lint-"); ok && isLintFormat(name) {
+		emit.Format, emit.Lint = name, true
+		return nil
+	}
+	switch {
+	case emit.Format == "tui":
+		return fmt.Errorf("--emit does not support the tui format")
+	case emit.Format == "sqlite" && emit.Path == "-":
+		return fmt.Errorf("--emit sqlite requires a file path")
+	case c.isOutputFormat(emit.Format):
+		return nil
+	case isLintFormat(emit.Format):
+		emit.Lint = true
+		return nil
+	}
+	return fmt.Errorf("invalid --emit format: %s (valid: %s, or lint formats %s)",
+		emit.Format, strings.Join(c.outputFormatNames()[1:], ", "), strings.Join(LintFormatNames, ", "))
+}
+
+func isLintFormat(name string) bool {
+	for _, f := range LintFormatNames {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// outputFormatNames returns the names accepted by --format.
+func (c *Config) outputFormatNames() []string {
+	names := []string{"tui"}
+	for _, format := range c.OutputFormats {
+		names = append(names, format.Name)
+	}
+	return names
+}
+
+// isPackagePattern reports whether a positional argument is a Go package pattern, like
+// "./services/payments/..." or "github.com/acme/shop/orders", rather than a directory.
+func isPackagePattern(arg string) bool {
+	if strings.Contains(arg, "...") {
+		return true
+	}
+	// Import paths start with a domain; relative and absolute directories don't
+	first, _, _ := strings.Cut(filepath.ToSlash(arg), "/")
+	return !filepath.IsAbs(arg) && !strings.HasPrefix(arg, ".") && strings.Contains(first, ".")
+}
+
+// extractPositionalArgs separates flags from positional path arguments.
+// It identifies the arguments that look like paths (don't start with -)
+// and aren't values for flags that take a value.
+// Returns the filtered args (flags only) and the extracted paths.
+func extractPositionalArgs(args []string) ([]string, []string) {
 	if len(args) == 0 {
-		return args, ""
+		return args, nil
 	}
 
 	// Flags that take a value (need to skip their next arg)
 	// NOTE: Keep this map in sync with flag definitions in loadFromFlags()
 	flagsWithValue := map[string]bool{
+		"-config": true, "--config": true,
 		"-root": true, "--root": true,
+		"-exclude": true, "--exclude": true,
 		"-package": true, "--package": true,
 		"-name": true, "--name": true,
+		"-min-confidence": true, "--min-confidence": true,
+		"-query": true, "--query": true,
+		"-domains": true, "--domains": true,
+		"-ref": true, "--ref": true,
+		"-metadata": true, "--metadata": true,
+		"-assertions": true, "--assertions": true,
+		"-allow-modules": true, "--allow-modules": true,
+		"-internal-depth": true, "--internal-depth": true,
+		"-max-unresolved": true, "--max-unresolved": true,
+		"-max-failed-files": true, "--max-failed-files": true,
 		"-format": true, "--format": true,
 		"-output": true, "--output": true,
+		"-hide": true, "--hide": true,
+		"-collapse-package": true, "--collapse-package": true,
+		"-min-fanout": true, "--min-fanout": true,
+		"-emit": true, "--emit": true,
+		"-fields": true, "--fields": true,
+		"-template-file": true, "--template-file": true,
+		"-link-base": true, "--link-base": true,
+		"-max-depth": true, "--max-depth": true,
 		"-graph-tool": true, "--graph-tool": true,
 		"-debug-view": true, "--debug-view": true,
+		"-sort": true, "--sort": true,
+		"-line-spacing": true, "--line-spacing": true,
+		"-theme": true, "--theme": true,
 		"-lint-format": true, "--lint-format": true,
+		"-lint-docs": true, "--lint-docs": true,
 		"-lint-level": true, "--lint-level": true,
 		"-lint-disable": true, "--lint-disable": true,
+		"-lint-categories": true, "--lint-categories": true,
+		"-lint-tags": true, "--lint-tags": true,
 		"-lint-enable": true, "--lint-enable": true,
 		"-lint-max-fan-out": true, "--lint-max-fan-out": true,
 		"-lint-max-depth": true, "--lint-max-depth": true,
+		"-lint-diff-base": true, "--lint-diff-base": true,
+		"-lint-require-annotations": true, "--lint-require-annotations": true,
+		"-lint-profiles": true, "--lint-profiles": true,
+		"-lint-dedicated-types": true, "--lint-dedicated-types": true,
+		"-lint-long-running-include": true, "--lint-long-running-include": true,
+		"-lint-long-running-exclude": true, "--lint-long-running-exclude": true,
+		"-lint-long-running-min-duration": true, "--lint-long-running-min-duration": true,
+		"-workflowcheck-config": true, "--workflowcheck-config": true,
+		"-workflowcheck-results": true, "--workflowcheck-results": true,
+		"-notify-webhook": true, "--notify-webhook": true,
+		"-file-issues": true, "--file-issues": true,
+		"-by": true, "--by": true,
+		"-workflow": true, "--workflow": true,
+		"-rps": true, "--rps": true,
+		"-replicas": true, "--replicas": true,
+		"-temporal-http": true, "--temporal-http": true,
+		"-temporal-namespace": true, "--temporal-namespace": true,
+		"-inventory-days": true, "--inventory-days": true,
+		"-stats-format": true, "--stats-format": true,
+		"-history-db": true, "--history-db": true,
+		"-serve-grpc": true, "--serve-grpc": true,
+		"-socket": true, "--socket": true,
+		"-node": true, "--node": true,
+		"-to": true, "--to": true,
+		"-replay-histories": true, "--replay-histories": true,
 		"-llm-model": true, "--llm-model": true,
 	}
 
 	// Pre-allocate with capacity hint for efficiency
 	filtered := make([]string, 0, len(args))
-	var positionalPath string
+	var positional []string
 	skipNext := false
 
-	for i, arg := range args {
+	for _, arg := range args {
 		if skipNext {
 			filtered = append(filtered, arg)
 			skipNext = false
@@ -208,22 +649,17 @@ func extractPositionalPath(args []string) ([]string, string) {
 			continue
 		}
 
-		// This is a non-flag argument - treat as path if we haven't found one yet
-		if positionalPath == "" {
-			positionalPath = arg
-		} else {
-			// Multiple positional args - keep subsequent ones (shouldn't happen, but be safe)
-			_ = i // silence unused warning
-		}
+		// This is a non-flag argument - a path or package pattern
+		positional = append(positional, arg)
 	}
 
-	return filtered, positionalPath
+	return filtered, positional
 }
 
 // Validate validates the configuration.
 func (c *Config) Validate() error {
-	// Skip some validations if just listing rules
-	if c.LintListRules {
+	// Skip some validations if just listing or documenting rules
+	if c.LintListRules || c.LintDocs != "" {
 		return nil
 	}
 
@@ -237,23 +673,118 @@ func (c *Config) Validate() error {
 	if _, err := os.Stat(c.RootDir); os.IsNotExist(err) {
 		return fmt.Errorf("root directory does not exist: %s", c.RootDir)
 	}
+	if len(c.Packages) > 0 && c.Ref != "" {
+		return fmt.Errorf("package patterns cannot be combined with --ref")
+	}
+
+	// Validate pipeline outputs
+	if len(c.Emits) > 0 {
+		if c.LintMode {
+			return fmt.Errorf("--emit cannot be combined with --lint; emit lint formats instead (e.g. --emit sarif=lint.sarif)")
+		}
+		for i := range c.Emits {
+			if err := c.resolveEmit(&c.Emits[i]); err != nil {
+				return err
+			}
+		}
+	}
 
 	// Validate output format (unless in lint mode)
 	if !c.LintMode {
-		validFormats := map[string]bool{
-			"tui":      true,
-			"json":     true,
-			"tree":     true,
-			"dot":      true,
-			"mermaid":  true,
-			"markdown": true,
-			"md":       true,
+		if !c.isOutputFormat(c.OutputFormat) {
+			return fmt.Errorf("invalid output format: %s (valid: %s)", c.OutputFormat, strings.Join(c.outputFormatNames(), ", "))
+		}
+		if c.OutputFormat == "sqlite" && c.OutputFile == "" {
+			return fmt.Errorf("--format sqlite requires --output")
+		}
+		if c.Fields != "" && !c.usesOutputFormat("json") {
+			return fmt.Errorf("--fields requires --format json")
+		}
+		if c.usesOutputFormat("template") && c.TemplateFile == "" {
+			return fmt.Errorf("--format template requires --template-file")
+		}
+		if c.TemplateFile != "" {
+			if !c.usesOutputFormat("template") {
+				return fmt.Errorf("--template-file requires --format template")
+			}
+			if _, err := os.Stat(c.TemplateFile); err != nil {
+				return fmt.Errorf("template file not found: %s", c.TemplateFile)
+			}
+		}
+		if c.LinkBase != "" && !strings.HasPrefix(c.LinkBase, "http://") && !strings.HasPrefix(c.LinkBase, "https://") {
+			return fmt.Errorf("invalid link base: %s (must be an http or https URL)", c.LinkBase)
+		}
+		if c.LegacyJSON && !c.usesOutputFormat("json") {
+			return fmt.Errorf("--legacy-json requires --format json")
+		}
+		if c.MaxDepth < 0 {
+			return fmt.Errorf("invalid max depth: %d (must be >= 0)", c.MaxDepth)
+		}
+	}
+
+	if _, err := c.parsePruneOptions(); err != nil {
+		return err
+	}
+
+	if c.MaxUnresolved < 0 {
+		return fmt.Errorf("invalid max unresolved: %d (must be >= 0)", c.MaxUnresolved)
+	}
+
+	if c.MaxFailedFiles < 0 {
+		return fmt.Errorf("invalid max failed files: %d (must be >= 0)", c.MaxFailedFiles)
+	}
+
+	if c.InternalDepth < 0 {
+		return fmt.Errorf("invalid internal depth: %d (must be >= 0)", c.InternalDepth)
+	}
+
+	for flag, path := range map[string]string{"workflowcheck-config": c.WorkflowcheckConfig, "workflowcheck-results": c.WorkflowcheckResults} {
+		if path == "" {
+			continue
+		}
+		if !c.LintMode {
+			return fmt.Errorf("--%s requires --lint", flag)
 		}
-		if !validFormats[c.OutputFormat] {
-			return fmt.Errorf("invalid output format: %s (valid: tui, json, dot, mermaid, markdown)", c.OutputFormat)
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("%s file not found: %s", flag, path)
 		}
 	}
 
+	if c.MetadataFile != "" {
+		if _, err := os.Stat(c.MetadataFile); err != nil {
+			return fmt.Errorf("metadata file not found: %s", c.MetadataFile)
+		}
+	}
+
+	// Validate domain mappings
+	if _, err := ParseDomains(c.Domains); err != nil {
+		return err
+	}
+
+	switch c.MinConfidence {
+	case "", "low", "medium", "high":
+	default:
+		return fmt.Errorf("invalid min confidence: %s (valid: low, medium, high)", c.MinConfidence)
+	}
+
+	// Validate list sort order
+	switch c.SortBy {
+	case "", "name", "type", "package", "connections", "churn":
+	default:
+		return fmt.Errorf("invalid sort: %s (valid: name, type, package, connections, churn)", c.SortBy)
+	}
+
+	if c.LineSpacing < 0 || c.LineSpacing > 3 {
+		return fmt.Errorf("invalid line spacing: %d (must be 0-3)", c.LineSpacing)
+	}
+
+	// Validate TUI theme
+	switch c.Theme {
+	case "", "default", "neon":
+	default:
+		return fmt.Errorf("invalid theme: %s (valid: default, neon)", c.Theme)
+	}
+
 	// Validate graph tool
 	validTools := map[string]bool{
 		"dot":   true,
@@ -272,15 +803,6 @@ func (c *Config) Validate() error {
 
 	// Validate lint options
 	if c.LintMode {
-		validLintFormats := map[string]bool{
-			"text":          true,
-			"text-no-color": true,
-			"json":          true,
-			"github":        true,
-			"sarif":         true,
-			"checkstyle":    true,
-		}
-
 		// Parse comma-separated formats
 		c.LintFormats = nil
 		for _, f := range strings.Split(c.LintFormat, ",") {
@@ -288,15 +810,26 @@ func (c *Config) Validate() error {
 			if f == "" {
 				continue
 			}
-			if !validLintFormats[f] {
-				return fmt.Errorf("invalid lint format: %s (valid: text, json, github, sarif, checkstyle)", f)
+			if !isLintFormat(f) {
+				return fmt.Errorf("invalid lint format: %s (valid: %s)", f, strings.Join(LintFormatNames, ", "))
 			}
 			c.LintFormats = append(c.LintFormats, f)
 		}
 		if len(c.LintFormats) == 0 {
 			c.LintFormats = []string{"text"}
 		}
+	}
 
+	// The sql formats include lint issues
+	if c.LintMode || c.DaemonMode || c.EmitsLint() || c.usesOutputFormat("sql") || c.usesOutputFormat("sqlite") {
+		for flag, pattern := range map[string]string{"lint-long-running-include": c.LintLongRunningInclude, "lint-long-running-exclude": c.LintLongRunningExclude} {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("invalid --%s regex: %w", flag, err)
+			}
+		}
+		if c.LintLongRunningMinDuration < 0 {
+			return fmt.Errorf("invalid long-running min duration: %s (must be >= 0)", c.LintLongRunningMinDuration)
+		}
 		validSeverities := map[string]bool{
 			"error":   true,
 			"warning": true,
@@ -307,9 +840,199 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate notification options
+	if c.NotifyWebhook != "" {
+		if !c.LintMode {
+			return fmt.Errorf("--notify-webhook requires --lint")
+		}
+		if u, err := url.Parse(c.NotifyWebhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhook URL: %s", c.NotifyWebhook)
+		}
+	}
+
+	// Validate tracker options
+	if c.FileIssues != "" {
+		if !c.LintMode {
+			return fmt.Errorf("--file-issues requires --lint")
+		}
+		if !strings.HasPrefix(c.FileIssues, "github:") && !strings.HasPrefix(c.FileIssues, "jira:") {
+			return fmt.Errorf("invalid issue tracker: %s (valid: github:owner/repo, jira:PROJECT)", c.FileIssues)
+		}
+	}
+
+	// Validate explain options
+	if c.ExplainNodeMode && c.FilterName == "" {
+		return fmt.Errorf("--explain-node requires --name")
+	}
+
+	// Validate rename options
+	if c.RenameMode && (c.Node == "" || c.RenameTo == "") {
+		return fmt.Errorf("--rename requires --node and --to")
+	}
+	if c.RenameMode && c.Ref != "" {
+		return fmt.Errorf("--rename cannot be combined with --ref")
+	}
+
+	// Validate fix options
+	if (c.FixMode || c.FixDryRun) && c.Ref != "" {
+		return fmt.Errorf("--fix cannot be combined with --ref")
+	}
+
+	// Validate daemon options
+	if c.DaemonMode && c.Socket == "" {
+		return fmt.Errorf("--daemon requires --socket")
+	}
+	if c.Socket != "" && !c.DaemonMode {
+		return fmt.Errorf("--socket requires --daemon")
+	}
+
+	// Validate stats options
+	switch c.StatsBy {
+	case "", "package", "taskqueue", "owner":
+	default:
+		return fmt.Errorf("invalid stats grouping: %s (valid: package, taskqueue, owner)", c.StatsBy)
+	}
+	switch c.StatsFormat {
+	case "", "markdown", "csv":
+	default:
+		return fmt.Errorf("invalid stats format: %s (valid: markdown, csv)", c.StatsFormat)
+	}
+	if c.StatsTimeouts && c.StatsWorkflowIDs {
+		return fmt.Errorf("--timeouts cannot be combined with --workflow-ids")
+	}
+
+	// Validate inventory options
+	if c.InventoryMode {
+		if c.InventoryDays <= 0 {
+			return fmt.Errorf("invalid inventory days: %d (must be > 0)", c.InventoryDays)
+		}
+		if u, err := url.Parse(c.TemporalHTTP); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid Temporal HTTP API URL: %s (expected http(s)://host:port)", c.TemporalHTTP)
+		}
+		if c.TemporalNamespace == "" {
+			return fmt.Errorf("inventory mode requires --temporal-namespace")
+		}
+	}
+
+	// Validate load simulation options
+	if c.SimulateMode {
+		if c.SimulateWorkflow == "" {
+			return fmt.Errorf("--simulate-load requires --workflow")
+		}
+		if c.SimulateRPS <= 0 {
+			return fmt.Errorf("invalid start rate: %g (--simulate-load requires --rps > 0)", c.SimulateRPS)
+		}
+		if c.SimulateReplicas < 1 {
+			return fmt.Errorf("invalid replicas: %d (must be >= 1)", c.SimulateReplicas)
+		}
+	}
+
+	// Validate history options
+	if c.TrendMode && c.HistoryDB == "" {
+		return fmt.Errorf("trend mode requires --history-db")
+	}
+	if c.HistoryMode && (c.HistoryDB == "" || c.Node == "") {
+		return fmt.Errorf("history mode requires --history-db and --node")
+	}
+
+	// Validate replay options
+	if c.ReplayMode {
+		if c.ReplayHistories == "" {
+			return fmt.Errorf("replay mode requires --replay-histories")
+		}
+		if info, err := os.Stat(c.ReplayHistories); err != nil || !info.IsDir() {
+			return fmt.Errorf("replay histories directory does not exist: %s", c.ReplayHistories)
+		}
+	}
+
 	return nil
 }
 
+// GetFields returns the projected JSON fields as a slice.
+func (c *Config) GetFields() []string {
+	if c.Fields == "" {
+		return nil
+	}
+	fields := strings.Split(c.Fields, ",")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields
+}
+
+// GetAllowModules returns the allowed dependency module patterns as a slice.
+func (c *Config) GetAllowModules() []string {
+	if c.AllowModules == "" {
+		return nil
+	}
+	modules := strings.Split(c.AllowModules, ",")
+	for i := range modules {
+		modules[i] = strings.TrimSpace(modules[i])
+	}
+	return modules
+}
+
+// GetDomains returns the parsed domain mappings. Invalid mappings are rejected by Validate.
+func (c *Config) GetDomains() []DomainMapping {
+	domains, _ := ParseDomains(c.Domains)
+	return domains
+}
+
+// hideableKinds maps the node kinds accepted by --hide to node types.
+var hideableKinds = map[string]string{
+	"workflows":  "workflow",
+	"activities": "activity",
+	"signals":    "signal",
+	"queries":    "query",
+	"updates":    "update",
+}
+
+// HideableKinds returns the sorted node kinds accepted by --hide.
+func HideableKinds() []string {
+	kinds := make([]string, 0, len(hideableKinds))
+	for kind := range hideableKinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// GetPruneOptions returns the pruning of graph outputs. Invalid options are rejected by Validate.
+func (c *Config) GetPruneOptions() PruneOptions {
+	opts, _ := c.parsePruneOptions()
+	return opts
+}
+
+// parsePruneOptions parses --hide, --collapse-package and --min-fanout.
+func (c *Config) parsePruneOptions() (PruneOptions, error) {
+	opts := PruneOptions{MinFanOut: c.MinFanOut}
+	for _, kind := range strings.Split(c.Hide, ",") {
+		kind = strings.TrimSpace(kind)
+		if kind == "" {
+			continue
+		}
+		nodeType, ok := hideableKinds[kind]
+		if !ok {
+			return PruneOptions{}, fmt.Errorf("invalid --hide kind: %s (valid: %s)", kind, strings.Join(HideableKinds(), ", "))
+		}
+		opts.HideTypes = append(opts.HideTypes, nodeType)
+	}
+	for _, pattern := range c.CollapsePackages {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			return PruneOptions{}, fmt.Errorf("empty --collapse-package pattern")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return PruneOptions{}, fmt.Errorf("invalid --collapse-package pattern %q: %w", pattern, err)
+		}
+		opts.CollapsePackages = append(opts.CollapsePackages, pattern)
+	}
+	if c.MinFanOut < 0 {
+		return PruneOptions{}, fmt.Errorf("invalid min fan-out: %d (must be >= 0)", c.MinFanOut)
+	}
+	return opts, nil
+}
+
 // GetLintDisabledRules returns the disabled rules as a slice.
 func (c *Config) GetLintDisabledRules() []string {
 	if c.LintDisabledRules == "" {
@@ -322,6 +1045,66 @@ func (c *Config) GetLintDisabledRules() []string {
 	return rules
 }
 
+// GetLintRequiredAnnotations returns the required annotation specs as a slice.
+func (c *Config) GetLintRequiredAnnotations() []string {
+	if c.LintRequireAnnotations == "" {
+		return nil
+	}
+	specs := strings.Split(c.LintRequireAnnotations, ",")
+	for i := range specs {
+		specs[i] = strings.TrimSpace(specs[i])
+	}
+	return specs
+}
+
+// GetLintDedicatedTypes returns the dedicated workflow type patterns as a slice.
+func (c *Config) GetLintDedicatedTypes() []string {
+	if c.LintDedicatedTypes == "" {
+		return nil
+	}
+	patterns := strings.Split(c.LintDedicatedTypes, ",")
+	for i := range patterns {
+		patterns[i] = strings.TrimSpace(patterns[i])
+	}
+	return patterns
+}
+
+// GetLintProfiles returns the lint profile mapping specs as a slice.
+func (c *Config) GetLintProfiles() []string {
+	if c.LintProfiles == "" {
+		return nil
+	}
+	specs := strings.Split(c.LintProfiles, ",")
+	for i := range specs {
+		specs[i] = strings.TrimSpace(specs[i])
+	}
+	return specs
+}
+
+// GetLintCategories returns the selected rule categories as a slice.
+func (c *Config) GetLintCategories() []string {
+	if c.LintCategories == "" {
+		return nil
+	}
+	categories := strings.Split(c.LintCategories, ",")
+	for i := range categories {
+		categories[i] = strings.TrimSpace(categories[i])
+	}
+	return categories
+}
+
+// GetLintTags returns the selected rule tags as a slice.
+func (c *Config) GetLintTags() []string {
+	if c.LintTags == "" {
+		return nil
+	}
+	tags := strings.Split(c.LintTags, ",")
+	for i := range tags {
+		tags[i] = strings.TrimSpace(tags[i])
+	}
+	return tags
+}
+
 // GetLintEnabledRules returns the enabled rules as a slice.
 func (c *Config) GetLintEnabledRules() []string {
 	if c.LintEnabledRules == "" {
@@ -345,11 +1128,26 @@ func GetLintFormatExtension(format string) string {
 		return ".xml"
 	case "github":
 		return ".txt" // GitHub annotations are text-based
+	case "heatmap":
+		return ".md"
+	case "heatmap-html":
+		return ".html"
+	case "pr-comment":
+		return ".md"
 	default:
 		return ".txt"
 	}
 }
 
+// GitDir returns the directory git commands run in: the working tree directory when
+// RootDir is a --ref snapshot, RootDir otherwise.
+func (c *Config) GitDir() string {
+	if c.WorkTreeDir != "" {
+		return c.WorkTreeDir
+	}
+	return c.RootDir
+}
+
 // ToAnalysisOptions converts the config to analyzer options.
 func (c *Config) ToAnalysisOptions() AnalysisOptions {
 	return AnalysisOptions{
@@ -358,6 +1156,16 @@ func (c *Config) ToAnalysisOptions() AnalysisOptions {
 		IncludeTests:  c.IncludeTests,
 		FilterPackage: c.FilterPackage,
 		FilterName:    c.FilterName,
+		Query:         c.Query,
+		Domains:       c.GetDomains(),
+		Churn:         c.Churn || c.SortBy == "churn",
+		MetadataFile:  c.MetadataFile,
+		MinConfidence: c.MinConfidence,
+		IncludeDeps:   c.IncludeDeps,
+		AllowModules:  c.GetAllowModules(),
+		PackageDirs:   c.PackageDirs,
+		InternalDepth: c.InternalDepth,
+		Typed:         c.Typed,
 	}
 }
 
@@ -368,4 +1176,80 @@ type AnalysisOptions struct {
 	IncludeTests  bool     `json:"include_tests"`
 	FilterPackage string   `json:"filter_package,omitempty"`
 	FilterName    string   `json:"filter_name,omitempty"`
+	Query         string   `json:"query,omitempty"`
+	Domains       []DomainMapping `json:"domains,omitempty"`
+	Churn         bool            `json:"churn,omitempty"`
+	// MetadataFile is the metadata overlay; empty reads analyzer-metadata.yaml from RootDir if present
+	MetadataFile string `json:"metadata_file,omitempty"`
+	// MinConfidence drops nodes detected with a lower confidence (low, medium or high)
+	MinConfidence string `json:"min_confidence,omitempty"`
+	// IncludeDeps also analyzes dependency modules found in the tree; AllowModules lists
+	// module path patterns analyzed even when they are dependencies
+	IncludeDeps  bool     `json:"include_deps,omitempty"`
+	AllowModules []string `json:"allow_modules,omitempty"`
+	// PackageDirs restricts the analysis to the directories of the packages selected by
+	// package patterns; empty analyzes the whole tree
+	PackageDirs []string `json:"package_dirs,omitempty"`
+	// InternalDepth resolves the helper functions workflows and activities call, this many
+	// calls deep, into helper nodes; 0 keeps internal calls flat
+	InternalDepth int `json:"internal_depth,omitempty"`
+	// Typed resolves the targets of Temporal calls with type information, to the function
+	// or method value passed rather than by name
+	Typed bool `json:"typed,omitempty"`
+}
+
+// ExcludesDir reports whether a directory name matches one of the excluded directory names
+// or globs.
+func (o AnalysisOptions) ExcludesDir(name string) bool {
+	for _, pattern := range o.ExcludeDirs {
+		if ok, _ := filepath.Match(pattern, name); ok || pattern == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PruneOptions reduces the graph drawn by graph outputs, so large diagrams stay readable.
+type PruneOptions struct {
+	// HideTypes are the node types left out, e.g. activity
+	HideTypes []string `json:"hide_types,omitempty"`
+	// CollapsePackages are package patterns, matched like domain patterns with a trailing
+	// "/..." for a package and its subpackages; the nodes of each are merged into one node
+	CollapsePackages []string `json:"collapse_packages,omitempty"`
+	// MinFanOut keeps only the nodes calling at least this many distinct targets, and their targets
+	MinFanOut int `json:"min_fanout,omitempty"`
+}
+
+// IsZero reports whether the options leave the graph unchanged.
+func (o PruneOptions) IsZero() bool {
+	return len(o.HideTypes) == 0 && len(o.CollapsePackages) == 0 && o.MinFanOut == 0
+}
+
+// DomainMapping assigns the nodes of packages matching Pattern to a business domain.
+type DomainMapping struct {
+	// Pattern is a glob matched against the package directory relative to the root
+	// ("**" matches any number of directories) or against the package name.
+	Pattern string `json:"pattern"`
+	Domain  string `json:"domain"`
+}
+
+// ParseDomains parses comma-separated glob=domain mappings. Earlier mappings take precedence.
+func ParseDomains(spec string) ([]DomainMapping, error) {
+	var domains []DomainMapping
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pattern, domain, ok := strings.Cut(entry, "=")
+		pattern, domain = strings.TrimSpace(pattern), strings.TrimSpace(domain)
+		if !ok || pattern == "" || domain == "" {
+			return nil, fmt.Errorf("invalid domain mapping %q (expected glob=domain)", entry)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid domain pattern %q: %w", pattern, err)
+		}
+		domains = append(domains, DomainMapping{Pattern: pattern, Domain: domain})
+	}
+	return domains, nil
 }
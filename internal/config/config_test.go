@@ -1,9 +1,13 @@
 package config
 
 import (
+	"flag"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewConfig(t *testing.T) {
@@ -47,6 +51,10 @@ func TestNewConfig(t *testing.T) {
 
 func TestValidate(t *testing.T) {
 	tmpDir := t.TempDir()
+	templateFile := filepath.Join(tmpDir, "report.tmpl")
+	if err := os.WriteFile(templateFile, []byte("{{len .Nodes}}"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
 
 	tests := []struct {
 		name    string
@@ -110,6 +118,466 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "fields with json format",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.OutputFormat = "json"
+				c.Fields = "name,type"
+			},
+			wantErr: false,
+		},
+		{
+			name: "fields without json format",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.OutputFormat = "dot"
+				c.Fields = "name,type"
+			},
+			wantErr: true,
+		},
+		{
+			name: "legacy json without json format",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.OutputFormat = "tree"
+				c.LegacyJSON = true
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative max depth",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.OutputFormat = "tree"
+				c.MaxDepth = -1
+			},
+			wantErr: true,
+		},
+		{
+			name: "template format without template file",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.OutputFormat = "template"
+			},
+			wantErr: true,
+		},
+		{
+			name: "template file without template format",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.OutputFormat = "json"
+				c.TemplateFile = templateFile
+			},
+			wantErr: true,
+		},
+		{
+			name: "template format with missing template file",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.OutputFormat = "template"
+				c.TemplateFile = "/non/existent/report.tmpl"
+			},
+			wantErr: true,
+		},
+		{
+			name: "template format with template file",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.OutputFormat = "template"
+				c.TemplateFile = templateFile
+			},
+			wantErr: false,
+		},
+		{
+			name: "link base without scheme",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.OutputFormat = "mermaid"
+				c.LinkBase = "github.com/org/repo/blob/main"
+			},
+			wantErr: true,
+		},
+		{
+			name: "link base",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.OutputFormat = "mermaid"
+				c.LinkBase = "https://github.com/org/repo/blob/main"
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative max unresolved",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.StrictResolution = true
+				c.MaxUnresolved = -1
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative internal depth",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.InternalDepth = -1
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative max failed files",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.StrictFiles = true
+				c.MaxFailedFiles = -1
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid pruning",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.Hide = "activities, signals"
+				c.CollapsePackages = []string{"services/payments/..."}
+				c.MinFanOut = 3
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid hide kind",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.Hide = "timers"
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid collapse pattern",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.CollapsePackages = []string{"services/[payments"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid min confidence",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.MinConfidence = "certain"
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative min fan-out",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.MinFanOut = -1
+			},
+			wantErr: true,
+		},
+		{
+			name: "webhook without lint mode",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.NotifyWebhook = "https://hooks.slack.com/services/T000/B000/XXX"
+			},
+			wantErr: true,
+		},
+		{
+			name: "webhook with invalid URL",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.LintMode = true
+				c.NotifyWebhook = "hooks.slack.com/services"
+			},
+			wantErr: true,
+		},
+		{
+			name: "webhook in lint mode",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.LintMode = true
+				c.NotifyWebhook = "https://hooks.slack.com/services/T000/B000/XXX"
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid domain mapping",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.Domains = "payments/**"
+			},
+			wantErr: true,
+		},
+		{
+			name: "file issues without lint mode",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.FileIssues = "github:org/repo"
+			},
+			wantErr: true,
+		},
+		{
+			name: "file issues with unknown tracker",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.LintMode = true
+				c.FileIssues = "gitlab:org/repo"
+			},
+			wantErr: true,
+		},
+		{
+			name: "file issues in lint mode",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.LintMode = true
+				c.FileIssues = "jira:PAY"
+			},
+			wantErr: false,
+		},
+		{
+			name: "sqlite format without output file",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.OutputFormat = "sqlite"
+			},
+			wantErr: true,
+		},
+		{
+			name: "trend mode without history database",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.TrendMode = true
+			},
+			wantErr: true,
+		},
+		{
+			name: "trend mode with history database",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.TrendMode = true
+				c.HistoryDB = tmpDir + "/history.db"
+			},
+			wantErr: false,
+		},
+		{
+			name: "history mode without node",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.HistoryMode = true
+				c.HistoryDB = tmpDir + "/history.db"
+			},
+			wantErr: true,
+		},
+		{
+			name: "history mode with node",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.HistoryMode = true
+				c.HistoryDB = tmpDir + "/history.db"
+				c.Node = "OrderWorkflow"
+			},
+			wantErr: false,
+		},
+		{
+			name: "replay mode without histories",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.ReplayMode = true
+			},
+			wantErr: true,
+		},
+		{
+			name: "replay mode with missing histories directory",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.ReplayMode = true
+				c.ReplayHistories = "/non/existent/histories"
+			},
+			wantErr: true,
+		},
+		{
+			name: "replay mode with histories directory",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.ReplayMode = true
+				c.ReplayHistories = tmpDir
+			},
+			wantErr: false,
+		},
+		{
+			name: "churn sort",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.SortBy = "churn"
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid stats grouping",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.StatsMode = true
+				c.StatsBy = "team"
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid stats format",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.StatsMode = true
+				c.StatsFormat = "xlsx"
+			},
+			wantErr: true,
+		},
+		{
+			name: "inventory mode with defaults",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.InventoryMode = true
+			},
+			wantErr: false,
+		},
+		{
+			name: "inventory mode with invalid server URL",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.InventoryMode = true
+				c.TemporalHTTP = "localhost:7233"
+			},
+			wantErr: true,
+		},
+		{
+			name: "inventory mode with invalid days",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.InventoryMode = true
+				c.InventoryDays = 0
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid sort",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.SortBy = "age"
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative line spacing",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.LineSpacing = -1
+			},
+			wantErr: true,
+		},
+		{
+			name: "neon theme",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.Theme = "neon"
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid theme",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.Theme = "solarized"
+			},
+			wantErr: true,
+		},
+		{
+			name: "lint with invalid long-running regex",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.LintMode = true
+				c.LintLongRunningExclude = "^(Create"
+			},
+			wantErr: true,
+		},
+		{
+			name: "lint with long-running heuristics",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.LintMode = true
+				c.LintLongRunningInclude = "(?i)reindex|backfill"
+				c.LintLongRunningMinDuration = 5 * time.Minute
+			},
+			wantErr: false,
+		},
+		{
+			name: "rename without to",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.RenameMode = true
+				c.Node = "ChargeCard"
+			},
+			wantErr: true,
+		},
+		{
+			name: "fix dry run",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.FixDryRun = true
+			},
+			wantErr: false,
+		},
+		{
+			name: "fix with ref",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.FixMode = true
+				c.Ref = "main"
+			},
+			wantErr: true,
+		},
+		{
+			name: "simulate load",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.SimulateMode = true
+				c.SimulateWorkflow = "OrderWorkflow"
+				c.SimulateRPS = 50
+			},
+			wantErr: false,
+		},
+		{
+			name: "simulate load without rps",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.SimulateMode = true
+				c.SimulateWorkflow = "OrderWorkflow"
+			},
+			wantErr: true,
+		},
+		{
+			name: "daemon with socket",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.DaemonMode = true
+				c.Socket = "/tmp/temporal-analyzer.sock"
+			},
+			wantErr: false,
+		},
+		{
+			name: "daemon without socket",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.DaemonMode = true
+			},
+			wantErr: true,
+		},
+		{
+			name: "socket without daemon",
+			setup: func(c *Config) {
+				c.RootDir = tmpDir
+				c.Socket = "/tmp/temporal-analyzer.sock"
+			},
+			wantErr: true,
+		},
 		{
 			name: "lint list rules skips validation",
 			setup: func(c *Config) {
@@ -136,13 +604,14 @@ func TestValidate(t *testing.T) {
 func TestValidateOutputFormats(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	validFormats := []string{"tui", "json", "tree", "dot", "mermaid", "markdown", "md"}
+	validFormats := []string{"tui", "json", "tree", "dot", "mermaid", "markdown", "md", "sql", "sqlite"}
 
 	for _, format := range validFormats {
 		t.Run("format_"+format, func(t *testing.T) {
 			cfg := NewConfig()
 			cfg.RootDir = tmpDir
 			cfg.OutputFormat = format
+			cfg.OutputFile = tmpDir + "/out"
 
 			if err := cfg.Validate(); err != nil {
 				t.Errorf("Validate() error for format %q: %v", format, err)
@@ -151,10 +620,83 @@ func TestValidateOutputFormats(t *testing.T) {
 	}
 }
 
+func TestValidateEmits(t *testing.T) {
+	cfg := NewConfig()
+	cfg.RootDir = t.TempDir()
+	cfg.Emits = []Emit{
+		{Format: "json", Path: "graph.json"},
+		{Format: "sarif", Path: "lint.sarif"},
+		{Format: "lint-json", Path: "lint.json"},
+		{Format: "md", Path: "-"},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	want := []Emit{
+		{Format: "json", Path: "graph.json"},
+		{Format: "sarif", Path: "lint.sarif", Lint: true},
+		{Format: "json", Path: "lint.json", Lint: true},
+		{Format: "md", Path: "-"},
+	}
+	for i, emit := range cfg.Emits {
+		if emit != want[i] {
+			t.Errorf("Emits[%d] = %+v, want %+v", i, emit, want[i])
+		}
+	}
+	if !cfg.EmitsLint() {
+		t.Error("EmitsLint() = false, want true")
+	}
+
+	for _, emits := range [][]Emit{
+		{{Format: "pdf", Path: "out.pdf"}},
+		{{Format: "tui", Path: "-"}},
+		{{Format: "sqlite", Path: "-"}},
+	} {
+		cfg := NewConfig()
+		cfg.RootDir = t.TempDir()
+		cfg.Emits = emits
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate() with --emit %s=%s = nil, want error", emits[0].Format, emits[0].Path)
+		}
+	}
+
+	cfg = NewConfig()
+	cfg.RootDir = t.TempDir()
+	cfg.LintMode = true
+	cfg.Emits = []Emit{{Format: "sarif", Path: "lint.sarif"}}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() with --lint and --emit = nil, want error")
+	}
+
+	// --fields applies to an emitted json output
+	cfg = NewConfig()
+	cfg.RootDir = t.TempDir()
+	cfg.Fields = "name,type"
+	cfg.Emits = []Emit{{Format: "json", Path: "graph.json"}}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with --fields and --emit json: %v", err)
+	}
+}
+
+func TestValidateRegisteredOutputFormats(t *testing.T) {
+	cfg := NewConfig()
+	cfg.RootDir = t.TempDir()
+	cfg.OutputFormats = []OutputFormat{{Name: "html", Description: "HTML report"}}
+
+	cfg.OutputFormat = "html"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error for registered format: %v", err)
+	}
+	cfg.OutputFormat = "json"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "valid: tui, html") {
+		t.Errorf("Validate() error = %v, want the registered formats listed", err)
+	}
+}
+
 func TestValidateLintFormats(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	validFormats := []string{"text", "text-no-color", "json", "github", "sarif", "checkstyle"}
+	validFormats := []string{"text", "text-no-color", "json", "github", "sarif", "checkstyle", "heatmap", "heatmap-html", "pr-comment"}
 
 	for _, format := range validFormats {
 		t.Run("lint_format_"+format, func(t *testing.T) {
@@ -207,6 +749,25 @@ func TestValidateGraphTools(t *testing.T) {
 	}
 }
 
+func TestGetFields(t *testing.T) {
+	cfg := NewConfig()
+	if fields := cfg.GetFields(); fields != nil {
+		t.Errorf("GetFields() = %v, want nil", fields)
+	}
+
+	cfg.Fields = "name, type,call_sites.target_name"
+	fields := cfg.GetFields()
+	want := []string{"name", "type", "call_sites.target_name"}
+	if len(fields) != len(want) {
+		t.Fatalf("GetFields() = %v, want %v", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("GetFields()[%d] = %q, want %q", i, fields[i], want[i])
+		}
+	}
+}
+
 func TestGetLintDisabledRules(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -255,6 +816,92 @@ func TestGetLintDisabledRules(t *testing.T) {
 	}
 }
 
+func TestGetLintRequiredAnnotations(t *testing.T) {
+	cfg := NewConfig()
+	if specs := cfg.GetLintRequiredAnnotations(); specs != nil {
+		t.Errorf("GetLintRequiredAnnotations() = %v, want nil", specs)
+	}
+
+	cfg.LintRequireAnnotations = "owner, workflow/sla"
+	specs := cfg.GetLintRequiredAnnotations()
+	if len(specs) != 2 || specs[0] != "owner" || specs[1] != "workflow/sla" {
+		t.Errorf("GetLintRequiredAnnotations() = %v, want [owner workflow/sla]", specs)
+	}
+}
+
+func TestGetLintDedicatedTypes(t *testing.T) {
+	cfg := NewConfig()
+	if patterns := cfg.GetLintDedicatedTypes(); patterns != nil {
+		t.Errorf("GetLintDedicatedTypes() = %v, want nil", patterns)
+	}
+
+	cfg.LintDedicatedTypes = "*Input, api.*"
+	patterns := cfg.GetLintDedicatedTypes()
+	if len(patterns) != 2 || patterns[0] != "*Input" || patterns[1] != "api.*" {
+		t.Errorf("GetLintDedicatedTypes() = %v, want [*Input api.*]", patterns)
+	}
+}
+
+func TestGetLintProfiles(t *testing.T) {
+	cfg := NewConfig()
+	if specs := cfg.GetLintProfiles(); specs != nil {
+		t.Errorf("GetLintProfiles() = %v, want nil", specs)
+	}
+
+	cfg.LintProfiles = "payments/**=strict, experimental/**=warnings"
+	specs := cfg.GetLintProfiles()
+	if len(specs) != 2 || specs[0] != "payments/**=strict" || specs[1] != "experimental/**=warnings" {
+		t.Errorf("GetLintProfiles() = %v, want [payments/**=strict experimental/**=warnings]", specs)
+	}
+}
+
+func TestGetLintCategoriesAndTags(t *testing.T) {
+	cfg := NewConfig()
+	if categories, tags := cfg.GetLintCategories(), cfg.GetLintTags(); categories != nil || tags != nil {
+		t.Errorf("GetLintCategories(), GetLintTags() = %v, %v, want nil", categories, tags)
+	}
+
+	cfg.LintCategories = "reliability, security"
+	cfg.LintTags = "determinism"
+	categories, tags := cfg.GetLintCategories(), cfg.GetLintTags()
+	if len(categories) != 2 || categories[0] != "reliability" || categories[1] != "security" {
+		t.Errorf("GetLintCategories() = %v, want [reliability security]", categories)
+	}
+	if len(tags) != 1 || tags[0] != "determinism" {
+		t.Errorf("GetLintTags() = %v, want [determinism]", tags)
+	}
+}
+
+func TestParseDomains(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []DomainMapping
+		wantErr bool
+	}{
+		{spec: "", want: nil},
+		{
+			spec: "services/payments/** = Payments, orders=Orders,",
+			want: []DomainMapping{{Pattern: "services/payments/**", Domain: "Payments"}, {Pattern: "orders", Domain: "Orders"}},
+		},
+		{spec: "orders", wantErr: true},
+		{spec: "=Orders", wantErr: true},
+		{spec: "orders=", wantErr: true},
+		{spec: "orders[=Orders", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseDomains(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDomains(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDomains(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetLintEnabledRules(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -310,6 +957,8 @@ func TestToAnalysisOptions(t *testing.T) {
 	cfg.IncludeTests = true
 	cfg.FilterPackage = "mypackage"
 	cfg.FilterName = "MyFunc.*"
+	cfg.Domains = "payments/**=Payments"
+	cfg.InternalDepth = 2
 
 	opts := cfg.ToAnalysisOptions()
 
@@ -328,6 +977,20 @@ func TestToAnalysisOptions(t *testing.T) {
 	if opts.FilterName != cfg.FilterName {
 		t.Errorf("FilterName = %q, want %q", opts.FilterName, cfg.FilterName)
 	}
+	if len(opts.Domains) != 1 || opts.Domains[0].Domain != "Payments" {
+		t.Errorf("Domains = %+v, want one Payments mapping", opts.Domains)
+	}
+	if opts.Churn {
+		t.Error("Churn should be off by default")
+	}
+	if opts.InternalDepth != 2 {
+		t.Errorf("InternalDepth = %d, want 2", opts.InternalDepth)
+	}
+
+	cfg.SortBy = "churn"
+	if !cfg.ToAnalysisOptions().Churn {
+		t.Error("Sorting by churn should compute churn")
+	}
 }
 
 func TestValidateRootDirAbsolutePath(t *testing.T) {
@@ -385,6 +1048,27 @@ func TestAnalysisOptions(t *testing.T) {
 	}
 }
 
+func TestIsPackagePattern(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want bool
+	}{
+		{".", false},
+		{"./services/payments", false},
+		{"/app/services", false},
+		{"services", false},
+		{"./services/payments/...", true},
+		{"./...", true},
+		{"github.com/acme/shop/orders", true},
+		{"example.com/shop/...", true},
+	}
+	for _, tt := range tests {
+		if got := isPackagePattern(tt.arg); got != tt.want {
+			t.Errorf("isPackagePattern(%q) = %v, want %v", tt.arg, got, tt.want)
+		}
+	}
+}
+
 func TestExtractPositionalPath(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -458,11 +1142,33 @@ func TestExtractPositionalPath(t *testing.T) {
 			wantFiltered: []string{"--root", "/other/path"},
 			wantPath:     ".",
 		},
+		{
+			name:         "emit flag value preserved",
+			args:         []string{"--emit", "json=graph.json", "."},
+			wantFiltered: []string{"--emit", "json=graph.json"},
+			wantPath:     ".",
+		},
+		{
+			name:         "ref flag value preserved",
+			args:         []string{"--ref", "v1.0.0", "."},
+			wantFiltered: []string{"--ref", "v1.0.0"},
+			wantPath:     ".",
+		},
+		{
+			name:         "prune flag values preserved",
+			args:         []string{"--collapse-package", "services/...", "--min-fanout", "3", "."},
+			wantFiltered: []string{"--collapse-package", "services/...", "--min-fanout", "3"},
+			wantPath:     ".",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			filtered, path := extractPositionalPath(tt.args)
+			filtered, paths := extractPositionalArgs(tt.args)
+			path := ""
+			if len(paths) > 0 {
+				path = paths[0]
+			}
 
 			if path != tt.wantPath {
 				t.Errorf("path = %q, want %q", path, tt.wantPath)
@@ -483,3 +1189,102 @@ func TestExtractPositionalPath(t *testing.T) {
 	}
 }
 
+
+func TestApplyConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analyzer.yaml")
+	content := `# starter settings
+root: services/orders
+exclude:
+  - "gen"
+  - mocks # generated
+lint-strict: true
+hide: activity
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewConfig()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.StringVar(&c.RootDir, "root", c.RootDir, "")
+	fs.BoolVar(&c.LintStrict, "lint-strict", c.LintStrict, "")
+	fs.StringVar(&c.Hide, "hide", c.Hide, "")
+	fs.Func("exclude", "", func(value string) error {
+		c.ExcludeDirs = append(c.ExcludeDirs, value)
+		return nil
+	})
+
+	if err := applyConfigFile(fs, path); err != nil {
+		t.Fatalf("applyConfigFile failed: %v", err)
+	}
+	// The command line overrides the file
+	if err := fs.Parse([]string{"--hide", "signal"}); err != nil {
+		t.Fatal(err)
+	}
+	if c.RootDir != "services/orders" || !c.LintStrict || c.Hide != "signal" {
+		t.Errorf("RootDir = %q, LintStrict = %v, Hide = %q", c.RootDir, c.LintStrict, c.Hide)
+	}
+	want := []string{"vendor", ".git", "node_modules", "gen", "mocks"}
+	if !reflect.DeepEqual(c.ExcludeDirs, want) {
+		t.Errorf("ExcludeDirs = %v, want %v", c.ExcludeDirs, want)
+	}
+
+	if err := applyConfigFile(fs, filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing explicit config file")
+	}
+	if err := os.WriteFile(path, []byte("no-such-flag: 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyConfigFile(fs, path); err == nil || !strings.Contains(err.Error(), `:1: unknown setting "no-such-flag"`) {
+		t.Errorf("error = %v, want unknown setting", err)
+	}
+}
+
+func TestParseConfigFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"item without setting", "- gen\n", "line 1: list item without a setting"},
+		{"missing colon", "lint-strict\n", "line 1: expected name: value"},
+		{"nested mapping", "lint:\n  strict: true\n", "line 2: expected name: value"},
+		{"empty value", "root:\n", "line 1: root has no value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfigFile(strings.NewReader(tt.content))
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFlagArg(t *testing.T) {
+	tests := []struct {
+		args      []string
+		wantValue string
+		wantOK    bool
+	}{
+		{[]string{"--lint", "--config", "ci.yaml"}, "ci.yaml", true},
+		{[]string{"-config=ci.yaml", "."}, "ci.yaml", true},
+		{[]string{"--configure", "x"}, "", false},
+		{[]string{"config"}, "", false},
+	}
+	for _, tt := range tests {
+		value, ok := flagArg(tt.args, "config")
+		if value != tt.wantValue || ok != tt.wantOK {
+			t.Errorf("flagArg(%v) = %q, %v, want %q, %v", tt.args, value, ok, tt.wantValue, tt.wantOK)
+		}
+	}
+}
+
+func TestExcludesDir(t *testing.T) {
+	opts := AnalysisOptions{ExcludeDirs: []string{"vendor", "*_gen", "[mock"}}
+	for name, want := range map[string]bool{"vendor": true, "proto_gen": true, "[mock": true, "gen": false, "internal": false} {
+		if got := opts.ExcludesDir(name); got != want {
+			t.Errorf("ExcludesDir(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ConfigFile is the file flag defaults are read from, in the current directory, when
+// --config is not given.
+const ConfigFile = ".temporal-analyzer.yaml"
+
+// repeatableFlags are the flags set once per list item of a config file setting; the items
+// of other flags are joined with commas.
+var repeatableFlags = map[string]bool{"emit": true, "collapse-package": true, "exclude": true}
+
+// fileSetting is a setting of a config file: a flag name and its values.
+type fileSetting struct {
+	name   string
+	values []string
+	line   int
+}
+
+// flagArg returns the value of a flag taking a value in args, and whether it is given.
+func flagArg(args []string, name string) (string, bool) {
+	for i, arg := range args {
+		flagName, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || flagName != name {
+			continue
+		}
+		if hasValue {
+			return value, true
+		}
+		if i+1 < len(args) {
+			return args[i+1], true
+		}
+		return "", true
+	}
+	return "", false
+}
+
+// applyConfigFile sets the flags listed in a config file, before the command line is parsed
+// so that command line flags take precedence. Without an explicit path, ConfigFile is read
+// if it exists.
+func applyConfigFile(fs *flag.FlagSet, path string) error {
+	explicit := path != ""
+	if !explicit {
+		path = ConfigFile
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	settings, err := parseConfigFile(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, setting := range settings {
+		if setting.name == "config" || fs.Lookup(setting.name) == nil {
+			return fmt.Errorf("%s:%d: unknown setting %q (settings are flag names, e.g. lint-level)", path, setting.line, setting.name)
+		}
+		values := setting.values
+		if !repeatableFlags[setting.name] {
+			values = []string{strings.Join(values, ",")}
+		}
+		for _, value := range values {
+			if err := fs.Set(setting.name, value); err != nil {
+				return fmt.Errorf("%s:%d: %s: %w", path, setting.line, setting.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseConfigFile reads a config file: a flat YAML mapping of flag names to a value or to
+// a list of values, e.g.
+//
+//	lint-level: warning
+//	exclude:
+//	  - gen
+//	  - mocks
+func parseConfigFile(r io.Reader) ([]fileSetting, error) {
+	var settings []fileSetting
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if item, ok := strings.CutPrefix(trimmed, "- "); ok {
+			if len(settings) == 0 || line == trimmed {
+				return nil, fmt.Errorf("line %d: list item without a setting", lineNumber)
+			}
+			last := &settings[len(settings)-1]
+			last.values = append(last.values, unquote(strings.TrimSpace(item)))
+			continue
+		}
+
+		name, value, ok := strings.Cut(trimmed, ":")
+		if !ok || line != trimmed {
+			return nil, fmt.Errorf("line %d: expected name: value", lineNumber)
+		}
+		setting := fileSetting{name: strings.TrimSpace(name), line: lineNumber}
+		if value = strings.TrimSpace(value); value != "" {
+			setting.values = []string{unquote(value)}
+		}
+		settings = append(settings, setting)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, setting := range settings {
+		if len(setting.values) == 0 {
+			return nil, fmt.Errorf("line %d: %s has no value", setting.line, setting.name)
+		}
+	}
+	return settings, nil
+}
+
+// unquote removes the double or single quotes around a YAML scalar.
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
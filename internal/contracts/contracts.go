@@ -0,0 +1,423 @@
+// Package contracts builds machine-readable workflow contracts from the analyzed graph:
+// each workflow's input and output types, signals, queries and updates, together with
+// the struct types they reference, so other services can code against them.
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// Version is the version of the contract document format.
+const Version = 1
+
+// TaskQueueAnnotation is the doc comment annotation that declares a workflow's task queue.
+const TaskQueueAnnotation = "temporal:taskqueue"
+
+// Document is a contract document describing all workflows of a codebase.
+type Document struct {
+	Version   int        `json:"version"`
+	Workflows []Workflow `json:"workflows"`
+	// Types holds the named types referenced by workflows, keyed by package-qualified name
+	Types map[string]*Type `json:"types,omitempty"`
+}
+
+// Workflow is the contract of a single workflow.
+type Workflow struct {
+	Name      string    `json:"name"`
+	Package   string    `json:"package"`
+	File      string    `json:"file,omitempty"`
+	TaskQueue string    `json:"task_queue,omitempty"`
+	Input     []Param   `json:"input,omitempty"`
+	Output    string    `json:"output,omitempty"`
+	Signals   []Handler `json:"signals,omitempty"`
+	Queries   []Handler `json:"queries,omitempty"`
+	Updates   []Handler `json:"updates,omitempty"`
+
+	// refs lists the type keys referenced by the workflow, including nested struct fields
+	refs []string
+}
+
+// Param is a positional workflow argument.
+type Param struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Handler is a signal, query or update exposed by a workflow.
+// Type is the signal payload type or the query/update result type.
+type Handler struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+// Type is a named type referenced by a workflow contract.
+// Struct types list their fields; other named types record their underlying type.
+type Type struct {
+	Fields     []Field `json:"fields,omitempty"`
+	Underlying string  `json:"underlying,omitempty"`
+}
+
+// Field is a struct field of a referenced type.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	JSON string `json:"json,omitempty"`
+}
+
+// Generator builds contract documents.
+type Generator struct {
+	logger *slog.Logger
+}
+
+// NewGenerator creates a new contract generator.
+func NewGenerator(logger *slog.Logger) *Generator {
+	return &Generator{
+		logger: logger,
+	}
+}
+
+// sourceIndex holds the declarations needed to resolve workflow signatures and types.
+type sourceIndex struct {
+	// funcs maps "file:line" to the function declared there
+	funcs map[string]*ast.FuncDecl
+	// types maps package-qualified type names to their declaration
+	types map[string]*typeDecl
+}
+
+// typeDecl is a named type declaration and the package it belongs to.
+type typeDecl struct {
+	pkg  string
+	spec *ast.TypeSpec
+}
+
+// Generate builds the contract document for the workflows in the graph.
+// The source under opts.RootDir is scanned to resolve parameter order and struct fields.
+func (g *Generator) Generate(ctx context.Context, graph *analyzer.TemporalGraph, opts config.AnalysisOptions) (*Document, error) {
+	index, err := g.scan(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan source types: %w", err)
+	}
+
+	doc := &Document{
+		Version:   Version,
+		Workflows: []Workflow{},
+		Types:     make(map[string]*Type),
+	}
+
+	names := make([]string, 0, len(graph.Nodes))
+	for name, node := range graph.Nodes {
+		if node.Type == "workflow" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		node := graph.Nodes[name]
+		wf := g.workflow(node, opts.RootDir, index)
+
+		refs := make(map[string]bool)
+		for _, t := range wf.typeExprs() {
+			index.collect(t, node.Package, doc.Types, refs)
+		}
+		for ref := range refs {
+			wf.refs = append(wf.refs, ref)
+		}
+		sort.Strings(wf.refs)
+
+		doc.Workflows = append(doc.Workflows, wf)
+	}
+
+	g.logger.Info("Generated workflow contracts", "workflows", len(doc.Workflows), "types", len(doc.Types))
+	return doc, nil
+}
+
+// workflow builds the contract of a single workflow node.
+func (g *Generator) workflow(node *analyzer.TemporalNode, rootDir string, index *sourceIndex) Workflow {
+	wf := Workflow{
+		Name:      node.Name,
+		Package:   node.Package,
+		File:      relativePath(rootDir, node.FilePath),
+		TaskQueue: node.Annotations[TaskQueueAnnotation],
+	}
+
+	if fn, ok := index.funcs[funcKey(node.FilePath, node.LineNumber)]; ok {
+		wf.Input = signatureParams(fn)
+		wf.Output = signatureOutput(fn)
+	} else {
+		// Fall back to the graph when the declaration could not be found; argument order is lost
+		wf.Input = graphParams(node.Parameters)
+		wf.Output = node.ValueType()
+	}
+
+	wf.Signals = handlers(node.Signals, func(s analyzer.SignalDef) Handler { return Handler{Name: s.Name, Type: s.PayloadType} })
+	wf.Queries = handlers(node.Queries, func(q analyzer.QueryDef) Handler { return Handler{Name: q.Name, Type: q.ReturnType} })
+	wf.Updates = handlers(node.Updates, func(u analyzer.UpdateDef) Handler { return Handler{Name: u.Name, Type: u.ReturnType} })
+	return wf
+}
+
+// typeExprs returns every type expression mentioned by the workflow contract.
+func (wf *Workflow) typeExprs() []string {
+	var exprs []string
+	for _, p := range wf.Input {
+		exprs = append(exprs, p.Type)
+	}
+	if wf.Output != "" {
+		exprs = append(exprs, wf.Output)
+	}
+	for _, list := range [][]Handler{wf.Signals, wf.Queries, wf.Updates} {
+		for _, h := range list {
+			if h.Type != "" {
+				exprs = append(exprs, h.Type)
+			}
+		}
+	}
+	return exprs
+}
+
+// scan parses the non-test Go files under opts.RootDir and indexes function and type declarations.
+func (g *Generator) scan(ctx context.Context, opts config.AnalysisOptions) (*sourceIndex, error) {
+	index := &sourceIndex{
+		funcs: make(map[string]*ast.FuncDecl),
+		types: make(map[string]*typeDecl),
+	}
+	fset := token.NewFileSet()
+
+	modules := analyzer.NewModuleFilter(opts.RootDir, opts)
+	err := filepath.Walk(opts.RootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			g.logger.Warn("Error accessing path during contract scan", "path", path, "error", err)
+			return nil // Continue walking
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if info.IsDir() {
+			if opts.ExcludesDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			if modules.SkipDir(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			g.logger.Warn("Error parsing file during contract scan", "path", path, "error", err)
+			return nil
+		}
+
+		pkg := file.Name.Name
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				index.funcs[funcKey(path, fset.Position(d.Pos()).Line)] = d
+			case *ast.GenDecl:
+				if d.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						index.types[pkg+"."+ts.Name.Name] = &typeDecl{pkg: pkg, spec: ts}
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+// collect adds the named types referenced by a type expression to types, recursing into struct fields.
+// pkg is the package the expression was written in, used to qualify unqualified names.
+func (idx *sourceIndex) collect(typeExpr, pkg string, types map[string]*Type, refs map[string]bool) {
+	expr, err := parser.ParseExpr(typeExpr)
+	if err != nil {
+		return
+	}
+
+	for _, key := range referencedTypes(expr, pkg) {
+		decl, ok := idx.types[key]
+		if !ok || refs[key] {
+			continue
+		}
+		refs[key] = true
+
+		if _, done := types[key]; !done {
+			t := &Type{}
+			types[key] = t
+			if st, ok := decl.spec.Type.(*ast.StructType); ok {
+				t.Fields = structFields(st)
+			} else {
+				t.Underlying = exprString(decl.spec.Type)
+			}
+		}
+
+		for _, f := range types[key].Fields {
+			idx.collect(f.Type, decl.pkg, types, refs)
+		}
+		if types[key].Underlying != "" {
+			idx.collect(types[key].Underlying, decl.pkg, types, refs)
+		}
+	}
+}
+
+// referencedTypes returns the package-qualified names referenced by a type expression.
+func referencedTypes(expr ast.Expr, pkg string) []string {
+	var keys []string
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := t.X.(*ast.Ident); ok {
+				keys = append(keys, x.Name+"."+t.Sel.Name)
+			}
+			return false
+		case *ast.Ident:
+			keys = append(keys, pkg+"."+t.Name)
+		}
+		return true
+	})
+	return keys
+}
+
+// structFields lists the fields of a struct type with their JSON names.
+func structFields(st *ast.StructType) []Field {
+	var fields []Field
+	for _, field := range st.Fields.List {
+		typ := exprString(field.Type)
+		jsonName := ""
+		if field.Tag != nil {
+			if tag, err := strconv.Unquote(field.Tag.Value); err == nil {
+				jsonName, _, _ = strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+			}
+		}
+
+		if len(field.Names) == 0 {
+			// Embedded field: named after its type
+			name := strings.TrimPrefix(typ, "*")
+			if i := strings.LastIndex(name, "."); i >= 0 {
+				name = name[i+1:]
+			}
+			fields = append(fields, Field{Name: name, Type: typ, JSON: jsonName})
+			continue
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			fields = append(fields, Field{Name: name.Name, Type: typ, JSON: jsonName})
+		}
+	}
+	return fields
+}
+
+// signatureParams returns the workflow arguments of a function, skipping the workflow context.
+func signatureParams(fn *ast.FuncDecl) []Param {
+	var params []Param
+	for i, field := range fn.Type.Params.List {
+		typ := exprString(field.Type)
+		if i == 0 && isContextType(typ) {
+			continue
+		}
+		if len(field.Names) == 0 {
+			params = append(params, Param{Name: fmt.Sprintf("arg%d", len(params)), Type: typ})
+			continue
+		}
+		for _, name := range field.Names {
+			params = append(params, Param{Name: name.Name, Type: typ})
+		}
+	}
+	return params
+}
+
+// signatureOutput returns the first non-error result type of a function.
+func signatureOutput(fn *ast.FuncDecl) string {
+	if fn.Type.Results == nil {
+		return ""
+	}
+	for _, field := range fn.Type.Results.List {
+		if typ := exprString(field.Type); typ != "error" {
+			return typ
+		}
+	}
+	return ""
+}
+
+// graphParams converts the unordered parameter map of a node into params sorted by name.
+func graphParams(parameters map[string]string) []Param {
+	var params []Param
+	for name, typ := range parameters {
+		if isContextType(typ) {
+			continue
+		}
+		params = append(params, Param{Name: name, Type: typ})
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	return params
+}
+
+// handlers converts handler definitions into sorted, de-duplicated contract handlers.
+func handlers[T any](defs []T, convert func(T) Handler) []Handler {
+	seen := make(map[string]bool)
+	var result []Handler
+	for _, def := range defs {
+		h := convert(def)
+		if h.Name == "" || seen[h.Name] {
+			continue
+		}
+		seen[h.Name] = true
+		result = append(result, h)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// isContextType reports whether a type is a workflow or standard library context.
+func isContextType(typ string) bool {
+	return typ == "workflow.Context" || typ == "context.Context"
+}
+
+// funcKey identifies a function declaration by file and line.
+func funcKey(path string, line int) string {
+	return filepath.Clean(path) + ":" + strconv.Itoa(line)
+}
+
+// relativePath returns path relative to root, or path unchanged if it is outside root.
+func relativePath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil && filepath.IsLocal(rel) {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+func exprString(expr ast.Expr) string {
+	return types.ExprString(expr)
+}
@@ -0,0 +1,148 @@
+package contracts
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+const orderSource = `package payments
+
+import "go.temporal.io/sdk/workflow"
+
+type Status string
+
+type Order struct {
+	ID       string ` + "`json:\"id\"`" + `
+	Items    []Item ` + "`json:\"items,omitempty\"`" + `
+	Status   Status
+	internal int
+}
+
+type Item struct {
+	SKU string ` + "`json:\"sku\"`" + `
+	Qty int
+}
+
+type Receipt struct {
+	Total float64
+}
+
+func OrderWorkflow(ctx workflow.Context, order Order, priority int) (*Receipt, error) {
+	return nil, nil
+}
+`
+
+// writeOrderSource writes the test source and returns the graph the analyzer would build for it.
+func writeOrderSource(t *testing.T) (string, *analyzer.TemporalGraph) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "order.go")
+	if err := os.WriteFile(path, []byte(orderSource), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:        "OrderWorkflow",
+				Type:        "workflow",
+				Package:     "payments",
+				FilePath:    path,
+				LineNumber:  24,
+				Parameters:  map[string]string{"ctx": "workflow.Context", "order": "Order", "priority": "int"},
+				ReturnType:  "*Receipt",
+				Annotations: map[string]string{TaskQueueAnnotation: "payments"},
+				Signals: []analyzer.SignalDef{
+					{Name: "cancel", PayloadType: "string"},
+					{Name: "cancel", PayloadType: "string"},
+				},
+				Queries: []analyzer.QueryDef{{Name: "status", ReturnType: "Status"}},
+			},
+			"ChargeActivity": {Name: "ChargeActivity", Type: "activity", FilePath: path},
+		},
+	}
+	return dir, graph
+}
+
+func TestGenerate(t *testing.T) {
+	dir, graph := writeOrderSource(t)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	doc, err := NewGenerator(logger).Generate(context.Background(), graph, config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if len(doc.Workflows) != 1 {
+		t.Fatalf("Expected 1 workflow, got %d", len(doc.Workflows))
+	}
+	wf := doc.Workflows[0]
+	if wf.File != "order.go" || wf.TaskQueue != "payments" {
+		t.Errorf("Unexpected workflow metadata: file=%q task_queue=%q", wf.File, wf.TaskQueue)
+	}
+	if len(wf.Input) != 2 || wf.Input[0] != (Param{Name: "order", Type: "Order"}) || wf.Input[1] != (Param{Name: "priority", Type: "int"}) {
+		t.Errorf("Input should keep declaration order without the context, got %+v", wf.Input)
+	}
+	if wf.Output != "*Receipt" {
+		t.Errorf("Output = %q, want %q", wf.Output, "*Receipt")
+	}
+	if len(wf.Signals) != 1 || wf.Signals[0].Type != "string" {
+		t.Errorf("Signals should be de-duplicated, got %+v", wf.Signals)
+	}
+
+	order, ok := doc.Types["payments.Order"]
+	if !ok {
+		t.Fatalf("Expected payments.Order in types, got %v", doc.Types)
+	}
+	if len(order.Fields) != 3 {
+		t.Errorf("Expected 3 exported fields, got %+v", order.Fields)
+	}
+	if order.Fields[0] != (Field{Name: "ID", Type: "string", JSON: "id"}) {
+		t.Errorf("Unexpected first field: %+v", order.Fields[0])
+	}
+	for _, key := range []string{"payments.Item", "payments.Receipt", "payments.Status"} {
+		if _, ok := doc.Types[key]; !ok {
+			t.Errorf("Expected nested type %s to be resolved", key)
+		}
+	}
+	if doc.Types["payments.Status"].Underlying != "string" {
+		t.Errorf("Status underlying = %q, want string", doc.Types["payments.Status"].Underlying)
+	}
+	if len(wf.refs) != 4 {
+		t.Errorf("Expected 4 referenced types, got %v", wf.refs)
+	}
+}
+
+func TestGenerateWithoutSource(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"RefundWorkflow": {
+				Name:       "RefundWorkflow",
+				Type:       "workflow",
+				FilePath:   "missing.go",
+				Parameters: map[string]string{"ctx": "workflow.Context", "b": "string", "a": "int"},
+				ReturnType: "error",
+			},
+		},
+	}
+
+	doc, err := NewGenerator(logger).Generate(context.Background(), graph, config.AnalysisOptions{RootDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	wf := doc.Workflows[0]
+	if len(wf.Input) != 2 || wf.Input[0].Name != "a" || wf.Input[1].Name != "b" {
+		t.Errorf("Expected graph parameters sorted by name, got %+v", wf.Input)
+	}
+	if wf.Output != "" {
+		t.Errorf("Expected no output for error-only workflows, got %q", wf.Output)
+	}
+}
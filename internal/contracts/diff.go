@@ -0,0 +1,176 @@
+package contracts
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Change is a difference between two contract documents.
+type Change struct {
+	Workflow string `json:"workflow"`
+	// Breaking is true if existing callers or in-flight executions may fail after the change
+	Breaking bool   `json:"breaking"`
+	Message  string `json:"message"`
+}
+
+// Diff compares the contracts of a base and head document. Changes to referenced types are
+// reported once for every head workflow that references them.
+func Diff(base, head *Document) []Change {
+	var changes []Change
+
+	baseWorkflows := make(map[string]*Workflow, len(base.Workflows))
+	for i := range base.Workflows {
+		baseWorkflows[base.Workflows[i].Name] = &base.Workflows[i]
+	}
+	headWorkflows := make(map[string]bool, len(head.Workflows))
+
+	typeChanges := diffTypes(base.Types, head.Types)
+
+	for i := range head.Workflows {
+		wf := &head.Workflows[i]
+		headWorkflows[wf.Name] = true
+
+		old, ok := baseWorkflows[wf.Name]
+		if !ok {
+			changes = append(changes, Change{Workflow: wf.Name, Message: "workflow added"})
+			continue
+		}
+
+		changes = append(changes, diffWorkflow(old, wf)...)
+		for _, ref := range wf.refs {
+			for _, msg := range typeChanges[ref] {
+				changes = append(changes, Change{Workflow: wf.Name, Breaking: msg.breaking, Message: msg.text})
+			}
+		}
+	}
+
+	for _, wf := range base.Workflows {
+		if !headWorkflows[wf.Name] {
+			changes = append(changes, Change{Workflow: wf.Name, Breaking: true, Message: "workflow removed"})
+		}
+	}
+
+	return changes
+}
+
+// diffWorkflow compares the signature and handlers of a workflow.
+func diffWorkflow(base, head *Workflow) []Change {
+	var changes []Change
+	add := func(breaking bool, format string, args ...any) {
+		changes = append(changes, Change{Workflow: head.Name, Breaking: breaking, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if base.TaskQueue != head.TaskQueue {
+		add(true, "task queue changed from %q to %q", base.TaskQueue, head.TaskQueue)
+	}
+
+	if len(base.Input) != len(head.Input) {
+		add(true, "input changed from %d to %d arguments", len(base.Input), len(head.Input))
+	} else {
+		for i := range base.Input {
+			if base.Input[i].Type != head.Input[i].Type {
+				add(true, "input argument %d (%s) changed type from %s to %s", i+1, head.Input[i].Name, base.Input[i].Type, head.Input[i].Type)
+			}
+		}
+	}
+
+	if base.Output != head.Output {
+		add(true, "output changed from %s to %s", orNone(base.Output), orNone(head.Output))
+	}
+
+	for _, kind := range []struct {
+		name       string
+		base, head []Handler
+	}{
+		{"signal", base.Signals, head.Signals},
+		{"query", base.Queries, head.Queries},
+		{"update", base.Updates, head.Updates},
+	} {
+		baseHandlers := make(map[string]string, len(kind.base))
+		for _, h := range kind.base {
+			baseHandlers[h.Name] = h.Type
+		}
+		headHandlers := make(map[string]bool, len(kind.head))
+		for _, h := range kind.head {
+			headHandlers[h.Name] = true
+			oldType, ok := baseHandlers[h.Name]
+			switch {
+			case !ok:
+				add(false, "%s %q added", kind.name, h.Name)
+			case oldType != h.Type:
+				add(true, "%s %q changed type from %s to %s", kind.name, h.Name, orNone(oldType), orNone(h.Type))
+			}
+		}
+		for _, h := range kind.base {
+			if !headHandlers[h.Name] {
+				add(true, "%s %q removed", kind.name, h.Name)
+			}
+		}
+	}
+
+	return changes
+}
+
+// typeChange is a change to a referenced type.
+type typeChange struct {
+	breaking bool
+	text     string
+}
+
+// diffTypes compares the types present in both documents, keyed by type name.
+func diffTypes(base, head map[string]*Type) map[string][]typeChange {
+	changes := make(map[string][]typeChange)
+
+	keys := make([]string, 0, len(head))
+	for key := range head {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		old, ok := base[key]
+		if !ok {
+			continue
+		}
+		cur := head[key]
+		add := func(breaking bool, format string, args ...any) {
+			changes[key] = append(changes[key], typeChange{breaking: breaking, text: fmt.Sprintf("type %s: ", key) + fmt.Sprintf(format, args...)})
+		}
+
+		if old.Underlying != cur.Underlying {
+			add(true, "underlying type changed from %s to %s", orNone(old.Underlying), orNone(cur.Underlying))
+		}
+
+		oldFields := make(map[string]Field, len(old.Fields))
+		for _, f := range old.Fields {
+			oldFields[f.Name] = f
+		}
+		curFields := make(map[string]bool, len(cur.Fields))
+		for _, f := range cur.Fields {
+			curFields[f.Name] = true
+			prev, ok := oldFields[f.Name]
+			switch {
+			case !ok:
+				add(false, "field %s added", f.Name)
+			case prev.Type != f.Type:
+				add(true, "field %s changed type from %s to %s", f.Name, prev.Type, f.Type)
+			case prev.JSON != f.JSON:
+				add(true, "field %s changed JSON name from %q to %q", f.Name, prev.JSON, f.JSON)
+			}
+		}
+		for _, f := range old.Fields {
+			if !curFields[f.Name] {
+				add(true, "field %s removed", f.Name)
+			}
+		}
+	}
+
+	return changes
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package contracts
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	base := &Document{
+		Workflows: []Workflow{
+			{
+				Name:    "OrderWorkflow",
+				Input:   []Param{{Name: "order", Type: "Order"}},
+				Output:  "Receipt",
+				Signals: []Handler{{Name: "cancel", Type: "string"}, {Name: "pause"}},
+				refs:    []string{"payments.Order"},
+			},
+			{Name: "LegacyWorkflow"},
+		},
+		Types: map[string]*Type{
+			"payments.Order": {Fields: []Field{
+				{Name: "ID", Type: "string", JSON: "id"},
+				{Name: "Total", Type: "int"},
+				{Name: "Note", Type: "string"},
+			}},
+		},
+	}
+	head := &Document{
+		Workflows: []Workflow{
+			{
+				Name:    "OrderWorkflow",
+				Input:   []Param{{Name: "order", Type: "Order"}},
+				Output:  "Receipt",
+				Signals: []Handler{{Name: "cancel", Type: "CancelRequest"}, {Name: "resume"}},
+				refs:    []string{"payments.Order"},
+			},
+			{Name: "RefundWorkflow"},
+		},
+		Types: map[string]*Type{
+			"payments.Order": {Fields: []Field{
+				{Name: "ID", Type: "string", JSON: "order_id"},
+				{Name: "Total", Type: "float64"},
+				{Name: "Currency", Type: "string"},
+			}},
+		},
+	}
+
+	want := map[string]bool{
+		"OrderWorkflow|signal \"cancel\" changed type from string to CancelRequest":                 true,
+		"OrderWorkflow|signal \"pause\" removed":                                                    true,
+		"OrderWorkflow|type payments.Order: field ID changed JSON name from \"id\" to \"order_id\"": true,
+		"OrderWorkflow|type payments.Order: field Total changed type from int to float64":           true,
+		"OrderWorkflow|type payments.Order: field Note removed":                                     true,
+		"LegacyWorkflow|workflow removed":                                                           true,
+	}
+	additive := map[string]bool{
+		"OrderWorkflow|signal \"resume\" added":                   true,
+		"OrderWorkflow|type payments.Order: field Currency added": true,
+		"RefundWorkflow|workflow added":                           true,
+	}
+
+	changes := Diff(base, head)
+	if len(changes) != len(want)+len(additive) {
+		t.Errorf("Expected %d changes, got %d: %+v", len(want)+len(additive), len(changes), changes)
+	}
+	for _, c := range changes {
+		key := c.Workflow + "|" + c.Message
+		switch {
+		case want[key]:
+			if !c.Breaking {
+				t.Errorf("Change %q should be breaking", key)
+			}
+		case additive[key]:
+			if c.Breaking {
+				t.Errorf("Change %q should not be breaking", key)
+			}
+		default:
+			t.Errorf("Unexpected change %q", key)
+		}
+	}
+}
+
+func TestDiffSignatureChanges(t *testing.T) {
+	base := &Document{Workflows: []Workflow{{
+		Name:      "OrderWorkflow",
+		TaskQueue: "orders",
+		Input:     []Param{{Name: "id", Type: "string"}},
+		Output:    "Receipt",
+	}}}
+
+	tests := []struct {
+		name string
+		head Workflow
+		want string
+	}{
+		{"unchanged", Workflow{Name: "OrderWorkflow", TaskQueue: "orders", Input: []Param{{Name: "orderID", Type: "string"}}, Output: "Receipt"}, ""},
+		{"argument added", Workflow{Name: "OrderWorkflow", TaskQueue: "orders", Input: []Param{{Name: "id", Type: "string"}, {Name: "n", Type: "int"}}, Output: "Receipt"}, "input changed from 1 to 2 arguments"},
+		{"argument type", Workflow{Name: "OrderWorkflow", TaskQueue: "orders", Input: []Param{{Name: "id", Type: "int"}}, Output: "Receipt"}, "input argument 1 (id) changed type from string to int"},
+		{"output removed", Workflow{Name: "OrderWorkflow", TaskQueue: "orders", Input: []Param{{Name: "id", Type: "string"}}}, "output changed from Receipt to (none)"},
+		{"task queue", Workflow{Name: "OrderWorkflow", TaskQueue: "billing", Input: []Param{{Name: "id", Type: "string"}}, Output: "Receipt"}, `task queue changed from "orders" to "billing"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := Diff(base, &Document{Workflows: []Workflow{tt.head}})
+			if tt.want == "" {
+				if len(changes) != 0 {
+					t.Errorf("Expected no changes, got %+v", changes)
+				}
+				return
+			}
+			if len(changes) != 1 || changes[0].Message != tt.want || !changes[0].Breaking {
+				t.Errorf("Expected breaking change %q, got %+v", tt.want, changes)
+			}
+		})
+	}
+}
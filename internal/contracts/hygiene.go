@@ -0,0 +1,142 @@
+package contracts
+
+import (
+	"fmt"
+	"go/parser"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DefaultDedicatedTypes are the type name patterns treated as dedicated workflow input/output types.
+var DefaultDedicatedTypes = []string{"*Input", "*Output", "*Request", "*Response"}
+
+// volatileTypes are field types whose shape is not captured by the contract.
+var volatileTypes = map[string]bool{
+	"interface{}":            true,
+	"any":                    true,
+	"map[string]interface{}": true,
+	"map[string]any":         true,
+	"json.RawMessage":        true,
+}
+
+// TypeFinding is a versioning hygiene problem with a workflow input or output type.
+type TypeFinding struct {
+	Workflow string
+	// Type is the package-qualified type name, or the parameter type when used directly
+	Type    string
+	Message string
+}
+
+// CheckTypes reports workflow inputs and outputs that are hard to version: named struct types
+// shared with other packages, unless their name matches one of the dedicated patterns, and
+// types with fields whose shape the contract cannot describe.
+func CheckTypes(doc *Document, dedicated []string) []TypeFinding {
+	if doc == nil {
+		return nil
+	}
+
+	// Packages of the workflows using each type directly as input or output
+	users := make(map[string]map[string]bool)
+	for _, wf := range doc.Workflows {
+		for _, key := range wf.ioTypes(doc) {
+			if users[key] == nil {
+				users[key] = make(map[string]bool)
+			}
+			users[key][wf.Package] = true
+		}
+	}
+
+	var findings []TypeFinding
+	for _, wf := range doc.Workflows {
+		seen := make(map[string]bool)
+		add := func(typ, message string) {
+			if !seen[typ+message] {
+				seen[typ+message] = true
+				findings = append(findings, TypeFinding{Workflow: wf.Name, Type: typ, Message: message})
+			}
+		}
+
+		for _, p := range wf.Input {
+			if volatileTypes[p.Type] {
+				add(p.Type, fmt.Sprintf("input '%s' is %s", p.Name, p.Type))
+			}
+		}
+		if volatileTypes[wf.Output] {
+			add(wf.Output, fmt.Sprintf("output is %s", wf.Output))
+		}
+
+		for _, key := range wf.ioTypes(doc) {
+			if isDedicated(key, dedicated) {
+				continue
+			}
+			if pkg, _, _ := strings.Cut(key, "."); pkg != wf.Package {
+				add(key, fmt.Sprintf("uses %s, declared in package %s", key, pkg))
+			} else if others := otherPackages(users[key], wf.Package); len(others) > 0 {
+				add(key, fmt.Sprintf("shares %s with workflows in %s", key, strings.Join(others, ", ")))
+			}
+		}
+
+		for _, key := range wf.refs {
+			for _, f := range doc.Types[key].Fields {
+				if volatileTypes[f.Type] {
+					add(key, fmt.Sprintf("%s.%s is %s", key, f.Name, f.Type))
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// ioTypes returns the struct types the workflow takes or returns directly.
+func (wf *Workflow) ioTypes(doc *Document) []string {
+	exprs := make([]string, 0, len(wf.Input)+1)
+	for _, p := range wf.Input {
+		exprs = append(exprs, p.Type)
+	}
+	if wf.Output != "" {
+		exprs = append(exprs, wf.Output)
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	for _, e := range exprs {
+		expr, err := parser.ParseExpr(e)
+		if err != nil {
+			continue
+		}
+		for _, key := range referencedTypes(expr, wf.Package) {
+			if t, ok := doc.Types[key]; ok && len(t.Fields) > 0 && !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// isDedicated reports whether a type key or its bare name matches one of the patterns.
+func isDedicated(key string, patterns []string) bool {
+	_, name, _ := strings.Cut(key, ".")
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// otherPackages returns the sorted packages other than pkg.
+func otherPackages(pkgs map[string]bool, pkg string) []string {
+	var others []string
+	for p := range pkgs {
+		if p != pkg {
+			others = append(others, p)
+		}
+	}
+	sort.Strings(others)
+	return others
+}
@@ -0,0 +1,84 @@
+package contracts
+
+import (
+	"testing"
+)
+
+func TestCheckTypes(t *testing.T) {
+	doc := &Document{
+		Workflows: []Workflow{
+			{
+				Name:    "OrderWorkflow",
+				Package: "orders",
+				Input:   []Param{{Name: "order", Type: "*models.Order"}, {Name: "meta", Type: "map[string]interface{}"}},
+				Output:  "OrderOutput",
+				refs:    []string{"models.Order", "orders.OrderOutput"},
+			},
+			{
+				Name:    "ShipWorkflow",
+				Package: "shipping",
+				Input:   []Param{{Name: "in", Type: "orders.ShipInput"}},
+				Output:  "orders.Event",
+				refs:    []string{"orders.Event", "orders.ShipInput"},
+			},
+			{
+				Name:    "AuditWorkflow",
+				Package: "orders",
+				Input:   []Param{{Name: "event", Type: "Event"}},
+				refs:    []string{"orders.Event"},
+			},
+		},
+		Types: map[string]*Type{
+			"models.Order":       {Fields: []Field{{Name: "ID", Type: "string"}}},
+			"orders.OrderOutput": {Fields: []Field{{Name: "Extra", Type: "json.RawMessage"}}},
+			"orders.ShipInput":   {Fields: []Field{{Name: "ID", Type: "string"}}},
+			"orders.Event":       {Fields: []Field{{Name: "Kind", Type: "string"}}},
+		},
+	}
+
+	got := make(map[string]bool)
+	for _, f := range CheckTypes(doc, DefaultDedicatedTypes) {
+		got[f.Workflow+"|"+f.Message] = true
+	}
+
+	want := map[string]bool{
+		"OrderWorkflow|input 'meta' is map[string]interface{}":         true,
+		"OrderWorkflow|uses models.Order, declared in package models":  true,
+		"OrderWorkflow|orders.OrderOutput.Extra is json.RawMessage":    true,
+		"ShipWorkflow|uses orders.Event, declared in package orders":   true,
+		"AuditWorkflow|shares orders.Event with workflows in shipping": true,
+	}
+	for key := range want {
+		if !got[key] {
+			t.Errorf("missing finding %q", key)
+		}
+	}
+	for key := range got {
+		if !want[key] {
+			t.Errorf("unexpected finding %q", key)
+		}
+	}
+}
+
+func TestCheckTypesDedicatedPatterns(t *testing.T) {
+	doc := &Document{
+		Workflows: []Workflow{{
+			Name:    "OrderWorkflow",
+			Package: "orders",
+			Input:   []Param{{Name: "order", Type: "api.OrderV2"}},
+		}},
+		Types: map[string]*Type{
+			"api.OrderV2": {Fields: []Field{{Name: "ID", Type: "string"}}},
+		},
+	}
+
+	if findings := CheckTypes(doc, DefaultDedicatedTypes); len(findings) != 1 {
+		t.Errorf("CheckTypes() with default patterns = %v, want 1 finding", findings)
+	}
+	if findings := CheckTypes(doc, []string{"api.*"}); len(findings) != 0 {
+		t.Errorf("CheckTypes() with api.* = %v, want none", findings)
+	}
+	if findings := CheckTypes(nil, nil); findings != nil {
+		t.Errorf("CheckTypes(nil) = %v, want nil", findings)
+	}
+}
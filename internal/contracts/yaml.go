@@ -0,0 +1,127 @@
+package contracts
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// WriteYAML writes the contract document as YAML.
+func WriteYAML(w io.Writer, doc *Document) error {
+	y := &yamlWriter{w: bufio.NewWriter(w)}
+
+	y.line(0, "# Workflow contracts generated by temporal-analyzer")
+	y.field(0, "version", fmt.Sprint(doc.Version))
+
+	if len(doc.Workflows) == 0 {
+		y.line(0, "workflows: []")
+	} else {
+		y.line(0, "workflows:")
+	}
+	for _, wf := range doc.Workflows {
+		y.line(1, "- name: "+yamlString(wf.Name))
+		y.field(2, "package", yamlString(wf.Package))
+		y.optional(2, "file", wf.File)
+		y.optional(2, "task_queue", wf.TaskQueue)
+		if len(wf.Input) > 0 {
+			y.line(2, "input:")
+			for _, p := range wf.Input {
+				y.line(3, "- name: "+yamlString(p.Name))
+				y.field(4, "type", yamlString(p.Type))
+			}
+		}
+		y.optional(2, "output", wf.Output)
+		y.handlers("signals", wf.Signals)
+		y.handlers("queries", wf.Queries)
+		y.handlers("updates", wf.Updates)
+	}
+
+	if len(doc.Types) > 0 {
+		y.line(0, "types:")
+		keys := make([]string, 0, len(doc.Types))
+		for key := range doc.Types {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			t := doc.Types[key]
+			y.line(1, yamlString(key)+":")
+			y.optional(2, "underlying", t.Underlying)
+			if len(t.Fields) > 0 {
+				y.line(2, "fields:")
+				for _, f := range t.Fields {
+					y.line(3, "- name: "+yamlString(f.Name))
+					y.field(4, "type", yamlString(f.Type))
+					y.optional(4, "json", f.JSON)
+				}
+			}
+		}
+	}
+
+	if y.err != nil {
+		return y.err
+	}
+	return y.w.Flush()
+}
+
+// yamlWriter writes indented YAML lines, remembering the first error.
+type yamlWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (y *yamlWriter) line(indent int, text string) {
+	if y.err == nil {
+		_, y.err = fmt.Fprintf(y.w, "%s%s\n", strings.Repeat("  ", indent), text)
+	}
+}
+
+func (y *yamlWriter) field(indent int, key, value string) {
+	y.line(indent, key+": "+value)
+}
+
+func (y *yamlWriter) optional(indent int, key, value string) {
+	if value != "" {
+		y.field(indent, key, yamlString(value))
+	}
+}
+
+func (y *yamlWriter) handlers(key string, handlers []Handler) {
+	if len(handlers) == 0 {
+		return
+	}
+	y.line(2, key+":")
+	for _, h := range handlers {
+		y.line(3, "- name: "+yamlString(h.Name))
+		y.optional(4, "type", h.Type)
+	}
+}
+
+// yamlString returns s as a YAML scalar, double-quoting it when a plain scalar would be ambiguous.
+func yamlString(s string) string {
+	if needsQuoting(s) {
+		// JSON strings are valid YAML double-quoted scalars
+		quoted, _ := json.Marshal(s)
+		return string(quoted)
+	}
+	return s
+}
+
+// needsQuoting reports whether s cannot be written as a plain YAML scalar.
+func needsQuoting(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return true
+	}
+	if strings.ContainsAny(s[:1], "*&!|>'\"%@`[]{},?:-#0123456789.+") {
+		return true
+	}
+	return strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.ContainsAny(s, "\n\t\"\\")
+}
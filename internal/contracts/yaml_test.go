@@ -0,0 +1,97 @@
+package contracts
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteYAML(t *testing.T) {
+	doc := &Document{
+		Version: Version,
+		Workflows: []Workflow{
+			{
+				Name:      "OrderWorkflow",
+				Package:   "payments",
+				File:      "order.go",
+				TaskQueue: "payments",
+				Input:     []Param{{Name: "order", Type: "*Order"}, {Name: "items", Type: "[]Item"}},
+				Output:    "Receipt",
+				Signals:   []Handler{{Name: "cancel", Type: "string"}},
+				Queries:   []Handler{{Name: "status"}},
+			},
+		},
+		Types: map[string]*Type{
+			"payments.Order":  {Fields: []Field{{Name: "ID", Type: "string", JSON: "id"}}},
+			"payments.Status": {Underlying: "string"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, doc); err != nil {
+		t.Fatalf("WriteYAML failed: %v", err)
+	}
+
+	want := `# Workflow contracts generated by temporal-analyzer
+version: 1
+workflows:
+  - name: OrderWorkflow
+    package: payments
+    file: order.go
+    task_queue: payments
+    input:
+      - name: order
+        type: "*Order"
+      - name: items
+        type: "[]Item"
+    output: Receipt
+    signals:
+      - name: cancel
+        type: string
+    queries:
+      - name: status
+types:
+  payments.Order:
+    fields:
+      - name: ID
+        type: string
+        json: id
+  payments.Status:
+    underlying: string
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteYAML() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteYAMLEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, &Document{Version: Version}); err != nil {
+		t.Fatalf("WriteYAML failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "workflows: []\n") {
+		t.Errorf("Expected empty workflow list, got:\n%s", buf.String())
+	}
+}
+
+func TestYAMLString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"OrderWorkflow", "OrderWorkflow"},
+		{"map[string]int", "map[string]int"},
+		{"", `""`},
+		{"*Order", `"*Order"`},
+		{"true", `"true"`},
+		{"5m", `"5m"`},
+		{"a: b", `"a: b"`},
+		{" padded", `" padded"`},
+	}
+
+	for _, tt := range tests {
+		if got := yamlString(tt.in); got != tt.want {
+			t.Errorf("yamlString(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
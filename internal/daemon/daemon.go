@@ -0,0 +1,325 @@
+// Package daemon keeps an analyzed graph and its lint issues in memory and answers editor
+// requests over a unix socket, for editor plugins that don't speak LSP. Requests and
+// responses are JSON objects, one per line:
+//
+//	{"id": 1, "method": "lint", "file": "/app/orders/workflow.go"}
+//	{"id": 1, "issues": [...]}
+//	{"id": 2, "method": "node", "name": "OrderWorkflow"}
+//	{"id": 2, "node": {...}}
+//
+// Reloads are full: a lint or node request for a file modified since the latest analysis
+// re-analyzes and re-lints the whole project, not only that file.
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+// Request methods.
+const (
+	// MethodLint returns the lint issues of File, re-analyzing first if it changed
+	MethodLint = "lint"
+	// MethodNode returns the node named Name, or the node declared at File:Line. Functions
+	// that are not in the graph, such as helpers, are resolved from the source of File
+	MethodNode = "node"
+	// MethodReload re-analyzes the project
+	MethodReload = "reload"
+	// MethodPing checks the daemon is up
+	MethodPing = "ping"
+)
+
+// Request is a request of an editor.
+type Request struct {
+	ID     int    `json:"id,omitempty"`
+	Method string `json:"method"`
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// Response answers a request with the same ID.
+type Response struct {
+	ID    int    `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+	// Issues are the lint issues of the requested file
+	Issues []lint.Issue `json:"issues,omitempty"`
+	// Node is the requested node
+	Node *analyzer.TemporalNode `json:"node,omitempty"`
+	// Reanalyzed is true if the request re-analyzed the project
+	Reanalyzed bool `json:"reanalyzed,omitempty"`
+}
+
+// AnalyzeFunc analyzes the project and lints the resulting graph.
+type AnalyzeFunc func(ctx context.Context) (*analyzer.TemporalGraph, *lint.Result, error)
+
+// Daemon holds the latest analysis of the project.
+type Daemon struct {
+	logger   *slog.Logger
+	analyze  AnalyzeFunc
+	resolver *analyzer.Resolver
+
+	mu    sync.Mutex
+	graph *analyzer.TemporalGraph
+	// issues are the lint issues of the latest analysis by file
+	issues map[string][]lint.Issue
+	// analyzedAt is when the latest analysis started; files modified later are stale
+	analyzedAt time.Time
+}
+
+// New creates a daemon analyzing the project with analyze.
+func New(logger *slog.Logger, analyze AnalyzeFunc) *Daemon {
+	return &Daemon{logger: logger, analyze: analyze, resolver: analyzer.NewResolver(logger)}
+}
+
+// Load analyzes the project, replacing the graph and issues in memory.
+func (d *Daemon) Load(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.load(ctx)
+}
+
+// load analyzes the project; d.mu is held.
+func (d *Daemon) load(ctx context.Context) error {
+	start := time.Now()
+	graph, result, err := d.analyze(ctx)
+	if err != nil {
+		return err
+	}
+
+	issues := make(map[string][]lint.Issue)
+	for _, issue := range result.Issues {
+		file := issueFile(graph, issue)
+		issues[file] = append(issues[file], issue)
+	}
+	d.graph, d.issues, d.analyzedAt = graph, issues, start
+	d.logger.Info("Analyzed project", "nodes", len(graph.Nodes), "issues", len(result.Issues), "duration", time.Since(start))
+	return nil
+}
+
+// issueFile returns the absolute path of the file of an issue. A bare file name is resolved
+// through the node the issue is about, or the node making a call at the issue's line.
+func issueFile(graph *analyzer.TemporalGraph, issue lint.Issue) string {
+	path := issue.FilePath
+	if path != "" && filepath.Base(path) == path {
+		if node, ok := graph.Nodes[issue.NodeName]; ok && filepath.Base(node.FilePath) == path {
+			path = node.FilePath
+		} else if caller := callerAt(graph, path, issue.LineNumber); caller != nil {
+			path = caller.FilePath
+		}
+	}
+	return absPath(path)
+}
+
+// callerAt returns the node with a call site at line of the file with base name base.
+func callerAt(graph *analyzer.TemporalGraph, base string, line int) *analyzer.TemporalNode {
+	ids := make([]string, 0, len(graph.Nodes))
+	for id := range graph.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		node := graph.Nodes[id]
+		if filepath.Base(node.FilePath) != base {
+			continue
+		}
+		for _, call := range node.CallSites {
+			if call.LineNumber == line && filepath.Base(call.FilePath) == base {
+				return node
+			}
+		}
+	}
+	return nil
+}
+
+// Handle answers a request.
+func (d *Daemon) Handle(ctx context.Context, req Request) Response {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	resp := Response{ID: req.ID}
+	fail := func(err error) Response {
+		resp.Error = err.Error()
+		return resp
+	}
+	file := ""
+	if req.File != "" {
+		abs, err := filepath.Abs(req.File)
+		if err != nil {
+			return fail(fmt.Errorf("invalid file: %w", err))
+		}
+		file = abs
+	}
+
+	switch req.Method {
+	case MethodPing:
+		return resp
+	case MethodReload:
+		if err := d.load(ctx); err != nil {
+			return fail(err)
+		}
+		resp.Reanalyzed = true
+		return resp
+	case MethodLint:
+		if file == "" {
+			return fail(errors.New("lint requires a file"))
+		}
+		if d.stale(file) {
+			if err := d.load(ctx); err != nil {
+				return fail(err)
+			}
+			resp.Reanalyzed = true
+		}
+		resp.Issues = d.issues[file]
+		return resp
+	case MethodNode:
+		switch {
+		case req.Name != "":
+			resp.Node = d.nodeNamed(req.Name)
+			if resp.Node == nil && file != "" {
+				resp.Node = d.resolver.FindFunction(ctx, req.Name, file)
+			}
+		case file != "":
+			if d.stale(file) {
+				if err := d.load(ctx); err != nil {
+					return fail(err)
+				}
+				resp.Reanalyzed = true
+			}
+			resp.Node = d.nodeAt(file, req.Line)
+			// A function around the line that is not the node found is resolved from the source
+			if fn := d.resolver.FunctionAt(ctx, file, req.Line); fn != nil && (resp.Node == nil || resp.Node.LineNumber != fn.LineNumber) {
+				resp.Node = fn
+			}
+		default:
+			return fail(errors.New("node requires a name, or a file and line"))
+		}
+		if resp.Node == nil {
+			return fail(errors.New("no node found"))
+		}
+		return resp
+	default:
+		return fail(fmt.Errorf("unknown method %q (valid: lint, node, reload, ping)", req.Method))
+	}
+}
+
+// stale reports whether a file was modified, or removed, since the latest analysis started.
+func (d *Daemon) stale(file string) bool {
+	info, err := os.Stat(file)
+	if err != nil {
+		return d.issues[file] != nil
+	}
+	return info.ModTime().After(d.analyzedAt)
+}
+
+// nodeNamed returns the node with the graph key, or else the name, name.
+func (d *Daemon) nodeNamed(name string) *analyzer.TemporalNode {
+	if node, ok := d.graph.Nodes[name]; ok {
+		return node
+	}
+	ids := make([]string, 0, len(d.graph.Nodes))
+	for id := range d.graph.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if d.graph.Nodes[id].Name == name {
+			return d.graph.Nodes[id]
+		}
+	}
+	return nil
+}
+
+// nodeAt returns the node whose declaration is the last in file before or on line, or the
+// first node of the file if line is 0.
+func (d *Daemon) nodeAt(file string, line int) *analyzer.TemporalNode {
+	var found *analyzer.TemporalNode
+	for _, node := range d.graph.Nodes {
+		if node.Unresolved || node.FilePath == "" || absPath(node.FilePath) != file {
+			continue
+		}
+		if line > 0 && node.LineNumber > line {
+			continue
+		}
+		if found == nil || (line > 0 && node.LineNumber > found.LineNumber) || (line == 0 && node.LineNumber < found.LineNumber) {
+			found = node
+		}
+	}
+	return found
+}
+
+// absPath returns the absolute form of path, or path cleaned if it has none.
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// Serve answers the requests of the connections accepted on lis until ctx is cancelled.
+func Serve(ctx context.Context, lis net.Listener, d *Daemon) error {
+	go func() {
+		<-ctx.Done()
+		_ = lis.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("daemon failed: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.serveConn(ctx, conn)
+		}()
+	}
+}
+
+// serveConn answers the requests of one connection, one per line, until it is closed.
+func (d *Daemon) serveConn(ctx context.Context, conn net.Conn) {
+	closed := make(chan struct{})
+	defer close(closed)
+	defer func() { _ = conn.Close() }()
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-closed:
+		}
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var req Request
+		resp := Response{}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp.Error = fmt.Sprintf("invalid request: %v", err)
+		} else {
+			resp = d.Handle(ctx, req)
+		}
+		if err := encoder.Encode(resp); err != nil {
+			d.logger.Warn("Failed to answer editor request", "error", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+// newTestDaemon returns a loaded daemon over a workflow and an activity declared in one file,
+// and a pointer to the number of analyses run.
+func newTestDaemon(t *testing.T) (*Daemon, string, *int) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "workflow.go")
+	if err := os.WriteFile(file, []byte("package orders\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Back-date the file so it isn't considered modified since the first analysis
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(file, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	analyses := 0
+	analyze := func(context.Context) (*analyzer.TemporalGraph, *lint.Result, error) {
+		analyses++
+		graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", FilePath: file, LineNumber: 3},
+			"ChargeCard":    {Name: "ChargeCard", Type: "activity", FilePath: file, LineNumber: 10},
+		}}
+		result := &lint.Result{Issues: []lint.Issue{
+			{RuleID: "TA001", NodeName: "OrderWorkflow", FilePath: file, LineNumber: 4},
+			{RuleID: "TA002", NodeName: "OtherWorkflow", FilePath: "/elsewhere/other.go", LineNumber: 1},
+		}}
+		return graph, result, nil
+	}
+	d := New(slog.New(slog.NewTextHandler(io.Discard, nil)), analyze)
+	if err := d.Load(context.Background()); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	return d, file, &analyses
+}
+
+func TestHandle(t *testing.T) {
+	d, file, analyses := newTestDaemon(t)
+	ctx := context.Background()
+
+	resp := d.Handle(ctx, Request{ID: 1, Method: MethodLint, File: file})
+	if resp.ID != 1 || resp.Error != "" || len(resp.Issues) != 1 || resp.Issues[0].RuleID != "TA001" {
+		t.Errorf("lint = %+v, want the TA001 issue of the file", resp)
+	}
+	if resp.Reanalyzed || *analyses != 1 {
+		t.Errorf("Expected no re-analysis of an unmodified file, got %d analyses", *analyses)
+	}
+
+	if resp := d.Handle(ctx, Request{Method: MethodNode, Name: "ChargeCard"}); resp.Node == nil || resp.Node.Name != "ChargeCard" {
+		t.Errorf("node by name = %+v, want ChargeCard", resp)
+	}
+	lines := map[int]string{0: "OrderWorkflow", 5: "OrderWorkflow", 12: "ChargeCard"}
+	for line, want := range lines {
+		if resp := d.Handle(ctx, Request{Method: MethodNode, File: file, Line: line}); resp.Node == nil || resp.Node.Name != want {
+			t.Errorf("node at line %d = %+v, want %s", line, resp, want)
+		}
+	}
+	if resp := d.Handle(ctx, Request{Method: MethodNode, File: file, Line: 1}); resp.Error == "" {
+		t.Error("Expected an error for a line before any node")
+	}
+	if resp := d.Handle(ctx, Request{Method: "format"}); resp.Error == "" {
+		t.Error("Expected an error for an unknown method")
+	}
+
+	// Saving the file re-analyzes the project on the next request
+	if err := os.Chtimes(file, time.Now(), time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if resp := d.Handle(ctx, Request{Method: MethodLint, File: file}); !resp.Reanalyzed || *analyses != 2 {
+		t.Errorf("Expected a modified file to be re-analyzed, got %+v after %d analyses", resp, *analyses)
+	}
+}
+
+func TestHandleCallSiteIssues(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "workflow.go")
+	if err := os.WriteFile(file, []byte("package orders\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(file, old, old); err != nil {
+		t.Fatal(err)
+	}
+	analyze := func(context.Context) (*analyzer.TemporalGraph, *lint.Result, error) {
+		graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", FilePath: file, LineNumber: 3, CallSites: []analyzer.CallSite{
+				{TargetName: "ChargeCard", TargetType: "activity", FilePath: file, LineNumber: 4},
+				{TargetName: "ShipOrder", TargetType: "activity", FilePath: "workflow.go", LineNumber: 5},
+			}},
+			"ChargeCard": {Name: "ChargeCard", Type: "activity", FilePath: filepath.Join(dir, "activities.go"), LineNumber: 1},
+		}}
+		// Call-site issues are about the called activity, at a line of the workflow's file
+		result := &lint.Result{Issues: []lint.Issue{
+			{RuleID: "TA001", NodeName: "ChargeCard", FilePath: file, LineNumber: 4},
+			{RuleID: "TA002", NodeName: "ShipOrder", FilePath: "workflow.go", LineNumber: 5},
+		}}
+		return graph, result, nil
+	}
+	d := New(slog.New(slog.NewTextHandler(io.Discard, nil)), analyze)
+	if err := d.Load(context.Background()); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	resp := d.Handle(context.Background(), Request{Method: MethodLint, File: file})
+	if len(resp.Issues) != 2 || resp.Issues[0].RuleID != "TA001" || resp.Issues[1].RuleID != "TA002" {
+		t.Errorf("lint = %+v, want the TA001 and TA002 call-site issues", resp)
+	}
+}
+
+func TestHandleResolvesFunctions(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "workflow.go")
+	source := `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return validate(ctx)
+}
+
+func validate(ctx workflow.Context) error {
+	return nil
+}
+`
+	if err := os.WriteFile(file, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(file, old, old); err != nil {
+		t.Fatal(err)
+	}
+	analyze := func(context.Context) (*analyzer.TemporalGraph, *lint.Result, error) {
+		graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", FilePath: file, LineNumber: 5},
+		}}
+		return graph, &lint.Result{}, nil
+	}
+	d := New(slog.New(slog.NewTextHandler(io.Discard, nil)), analyze)
+	if err := d.Load(context.Background()); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if resp := d.Handle(ctx, Request{Method: MethodNode, File: file, Line: 6}); resp.Node == nil || resp.Node.Type != "workflow" {
+		t.Errorf("node at line 6 = %+v, want the OrderWorkflow node", resp)
+	}
+	resp := d.Handle(ctx, Request{Method: MethodNode, File: file, Line: 10})
+	if resp.Node == nil || resp.Node.Name != "validate" || resp.Node.Type != "helper" {
+		t.Errorf("node at line 10 = %+v, want the validate helper", resp)
+	}
+	if resp := d.Handle(ctx, Request{Method: MethodNode, Name: "validate", File: file}); resp.Node == nil || resp.Node.LineNumber != 9 {
+		t.Errorf("node named validate = %+v, want the helper at line 9", resp)
+	}
+	if resp := d.Handle(ctx, Request{Method: MethodNode, Name: "validate"}); resp.Error == "" {
+		t.Error("Expected an error for a name outside the graph without a file")
+	}
+}
+
+func TestServe(t *testing.T) {
+	d, file, _ := newTestDaemon(t)
+	socket := filepath.Join(t.TempDir(), "daemon.sock")
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("unix sockets not available: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- Serve(ctx, lis, d) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-served; err != nil {
+			t.Errorf("Serve() = %v", err)
+		}
+	})
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+	reader := bufio.NewReader(conn)
+	roundTrip := func(request string) Response {
+		t.Helper()
+		if _, err := io.WriteString(conn, request+"\n"); err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			t.Fatalf("Failed to read response: %v", err)
+		}
+		var resp Response
+		if err := json.Unmarshal(line, &resp); err != nil {
+			t.Fatalf("Invalid response %q: %v", line, err)
+		}
+		return resp
+	}
+
+	request, _ := json.Marshal(Request{ID: 7, Method: MethodLint, File: file})
+	if resp := roundTrip(string(request)); resp.ID != 7 || len(resp.Issues) != 1 {
+		t.Errorf("lint = %+v, want one issue", resp)
+	}
+	if resp := roundTrip(`{"id": 8, "method": "node", "name": "OrderWorkflow"}`); resp.ID != 8 || resp.Node == nil || resp.Node.Name != "OrderWorkflow" {
+		t.Errorf("node = %+v, want OrderWorkflow", resp)
+	}
+	if resp := roundTrip(`not json`); resp.Error == "" {
+		t.Error("Expected an error for an invalid request")
+	}
+}
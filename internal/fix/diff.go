@@ -0,0 +1,162 @@
+package fix
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change of a diff.
+const diffContext = 3
+
+// Diff writes the changes of the plan as a unified diff, with file paths relative to root,
+// as git diff prints them.
+func (p *Plan) Diff(w io.Writer, root string) error {
+	for _, f := range p.files {
+		name := filepath.ToSlash(f.path)
+		if rel, err := filepath.Rel(root, f.path); err == nil && !strings.HasPrefix(rel, "..") {
+			name = filepath.ToSlash(rel)
+		}
+		if _, err := fmt.Fprintf(w, "--- a/%s\n+++ b/%s\n", name, name); err != nil {
+			return err
+		}
+		if err := writeHunks(w, splitLines(f.old), splitLines(f.new)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitLines splits a file into its lines without their newlines.
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+// diffLine is a line of a diff: ' ' kept, '-' removed or '+' added.
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// diffLines returns the lines of a and b as kept, removed and added lines. The lines both
+// start and end with are kept; the lines between are matched by their longest common
+// subsequence, which stays small as fixes change a few lines of a file.
+func diffLines(a, b []string) []diffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the length of the longest common subsequence of midA[i:] and midB[j:]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b)-prefix-suffix)
+	for _, line := range a[:prefix] {
+		lines = append(lines, diffLine{' ', line})
+	}
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			lines = append(lines, diffLine{' ', midA[i]})
+			i++
+			j++
+		case j == len(midB) || (i < len(midA) && lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', midA[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', midB[j]})
+			j++
+		}
+	}
+	for _, line := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{' ', line})
+	}
+	return lines
+}
+
+// writeHunks writes the changes from a to b as unified diff hunks, each with up to
+// diffContext unchanged lines around its changes.
+func writeHunks(w io.Writer, a, b []string) error {
+	lines := diffLines(a, b)
+	for start := 0; start < len(lines); {
+		// Find the next change and the end of the hunk around it
+		first := start
+		for first < len(lines) && lines[first].op == ' ' {
+			first++
+		}
+		if first == len(lines) {
+			return nil
+		}
+		end := first
+		for last := first; last < len(lines); last++ {
+			if lines[last].op == ' ' {
+				if last-end >= 2*diffContext {
+					break
+				}
+				continue
+			}
+			end = last + 1
+		}
+		from := max(first-diffContext, start)
+		to := min(end+diffContext, len(lines))
+
+		// Line numbers of the hunk start in a and b
+		oldStart, newStart := 1, 1
+		for _, l := range lines[:from] {
+			if l.op != '+' {
+				oldStart++
+			}
+			if l.op != '-' {
+				newStart++
+			}
+		}
+		oldCount, newCount := 0, 0
+		for _, l := range lines[from:to] {
+			if l.op != '+' {
+				oldCount++
+			}
+			if l.op != '-' {
+				newCount++
+			}
+		}
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+
+		if _, err := fmt.Fprintf(w, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount); err != nil {
+			return err
+		}
+		for _, l := range lines[from:to] {
+			if _, err := fmt.Fprintf(w, "%c%s\n", l.op, l.text); err != nil {
+				return err
+			}
+		}
+		start = to
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+// Package fix applies the code fixes of lint issues. A fix is applied only if the lines
+// each of its replacements replaces still read as its OldText; the touched files are then
+// gofmt-ed and written together. Fixes without OldText are suggestions to apply by hand.
+package fix
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+// Fix is the outcome of the code fix of one lint issue.
+type Fix struct {
+	RuleID      string `json:"rule_id"`
+	FilePath    string `json:"file_path"`
+	LineNumber  int    `json:"line_number"`
+	Description string `json:"description"`
+	Applied     bool   `json:"applied"`
+	// Reason is why the fix was skipped
+	Reason string `json:"reason,omitempty"`
+
+	replacements []lint.Replacement
+}
+
+// Plan is the set of fixes of a lint run and the files they change.
+type Plan struct {
+	Fixes []Fix `json:"fixes"`
+
+	files []fileChange
+}
+
+// fileChange is a file changed by the applied fixes, as read and as fixed and gofmt-ed.
+type fileChange struct {
+	path     string
+	old, new []byte
+}
+
+// source is a file read for checking fixes, split into lines without their newlines.
+type source struct {
+	data  []byte
+	lines []string
+	err   error
+}
+
+// NewPlan checks the fixes of the issues against their files and computes the fixed files.
+// Fixes without OldText, whose OldText doesn't match, that overlap an earlier fix or that
+// leave a file gofmt can't parse with the fixes before them are skipped with a reason.
+// Relative file paths are relative to root.
+func NewPlan(issues []lint.Issue, root string) *Plan {
+	p := &Plan{}
+	sources := make(map[string]*source)
+	read := func(path string) *source {
+		if s, ok := sources[path]; ok {
+			return s
+		}
+		s := &source{}
+		s.data, s.err = os.ReadFile(path)
+		if s.err == nil {
+			s.lines = strings.Split(strings.TrimSuffix(string(s.data), "\n"), "\n")
+		}
+		sources[path] = s
+		return s
+	}
+
+	// Lines replaced by the fixes accepted so far, by file
+	taken := make(map[string][]lint.Replacement)
+	for _, issue := range issues {
+		if issue.Fix == nil || len(issue.Fix.Replacements) == 0 {
+			continue
+		}
+		p.Fixes = append(p.Fixes, Fix{
+			RuleID:       issue.RuleID,
+			FilePath:     issue.FilePath,
+			LineNumber:   issue.LineNumber,
+			Description:  issue.Fix.Description,
+			replacements: append([]lint.Replacement(nil), issue.Fix.Replacements...),
+		})
+		f := &p.Fixes[len(p.Fixes)-1]
+		for i, r := range f.replacements {
+			if !filepath.IsAbs(r.FilePath) && root != "" {
+				f.replacements[i].FilePath = filepath.Join(root, r.FilePath)
+			}
+		}
+		if f.Reason = check(f.replacements, read, taken); f.Reason != "" {
+			continue
+		}
+
+		// The files must still parse with the fix and the fixes accepted before it
+		f.Applied = true
+		for _, r := range f.replacements {
+			s := read(r.FilePath)
+			if _, err := format.Source(p.fixedSource(r.FilePath, s.lines, s.data)); err != nil {
+				f.Applied, f.Reason = false, fmt.Sprintf("the fixed file does not parse: %v", err)
+				break
+			}
+		}
+		if f.Applied {
+			for _, r := range f.replacements {
+				taken[r.FilePath] = append(taken[r.FilePath], r)
+			}
+		}
+	}
+
+	for _, path := range p.touched() {
+		s := read(path)
+		// Every touched file parsed when its last fix was accepted
+		fixed, _ := format.Source(p.fixedSource(path, s.lines, s.data))
+		if !bytes.Equal(fixed, s.data) {
+			p.files = append(p.files, fileChange{path: path, old: s.data, new: fixed})
+		}
+	}
+	return p
+}
+
+// check returns why replacements can't be applied, or "" if they can: each needs OldText
+// matching the lines it replaces, ignoring indentation, and no line replaced by another fix.
+func check(replacements []lint.Replacement, read func(string) *source, taken map[string][]lint.Replacement) string {
+	for i, r := range replacements {
+		if r.OldText == "" {
+			return "no OldText to verify; apply the suggestion by hand"
+		}
+		s := read(r.FilePath)
+		if s.err != nil {
+			return fmt.Sprintf("cannot read %s: %v", r.FilePath, s.err)
+		}
+		last := r.LastLine()
+		if r.StartLine < 1 || last > len(s.lines) {
+			return fmt.Sprintf("lines %d-%d are outside %s", r.StartLine, last, filepath.Base(r.FilePath))
+		}
+		if !sameLines(s.lines[r.StartLine-1:last], strings.Split(strings.TrimSuffix(r.OldText, "\n"), "\n")) {
+			return fmt.Sprintf("lines %d-%d no longer match OldText", r.StartLine, last)
+		}
+		others := append(taken[r.FilePath][:len(taken[r.FilePath]):len(taken[r.FilePath])], replacements[:i]...)
+		for _, other := range others {
+			if other.FilePath == r.FilePath && r.StartLine <= other.LastLine() && other.StartLine <= last {
+				return fmt.Sprintf("overlaps another fix at line %d", other.StartLine)
+			}
+		}
+	}
+	return ""
+}
+
+// sameLines reports whether two sets of lines match, ignoring indentation and trailing space.
+func sameLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if strings.TrimSpace(a[i]) != strings.TrimSpace(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// touched returns the files replaced in by the applied fixes, sorted.
+func (p *Plan) touched() []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, f := range p.Fixes {
+		if !f.Applied {
+			continue
+		}
+		for _, r := range f.replacements {
+			if !seen[r.FilePath] {
+				seen[r.FilePath] = true
+				paths = append(paths, r.FilePath)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// fixedSource returns a file with the replacements of the applied fixes in it.
+func (p *Plan) fixedSource(path string, lines []string, data []byte) []byte {
+	var replacements []lint.Replacement
+	for _, f := range p.Fixes {
+		if !f.Applied {
+			continue
+		}
+		for _, r := range f.replacements {
+			if r.FilePath == path {
+				replacements = append(replacements, r)
+			}
+		}
+	}
+	// Replace from the end so the lines of earlier replacements stay in place
+	sort.Slice(replacements, func(i, j int) bool { return replacements[i].StartLine > replacements[j].StartLine })
+
+	fixed := append([]string(nil), lines...)
+	for _, r := range replacements {
+		var newLines []string
+		if r.NewText != "" {
+			newLines = strings.Split(strings.TrimSuffix(r.NewText, "\n"), "\n")
+		}
+		fixed = append(fixed[:r.StartLine-1], append(newLines, fixed[r.LastLine():]...)...)
+	}
+	out := strings.Join(fixed, "\n")
+	if bytes.HasSuffix(data, []byte("\n")) {
+		out += "\n"
+	}
+	return []byte(out)
+}
+
+// Applied returns the number of fixes applied.
+func (p *Plan) Applied() int {
+	n := 0
+	for _, f := range p.Fixes {
+		if f.Applied {
+			n++
+		}
+	}
+	return n
+}
+
+// Files returns the number of files the plan changes.
+func (p *Plan) Files() int {
+	return len(p.files)
+}
+
+// Apply writes the fixed files. Every file is written next to itself first and renamed into
+// place once all are written, so a failed write changes no file; if a file changed since
+// the plan was made, no file is written.
+func (p *Plan) Apply() error {
+	for _, f := range p.files {
+		current, err := os.ReadFile(f.path)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(current, f.old) {
+			return fmt.Errorf("%s changed since the fixes were checked", f.path)
+		}
+	}
+
+	temps := make([]string, 0, len(p.files))
+	defer func() {
+		for _, tmp := range temps {
+			_ = os.Remove(tmp)
+		}
+	}()
+	for _, f := range p.files {
+		tmp, err := writeTemp(f.path, f.new)
+		if err != nil {
+			return err
+		}
+		temps = append(temps, tmp)
+	}
+	for i, f := range p.files {
+		if err := os.Rename(temps[i], f.path); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.path, err)
+		}
+	}
+	temps = nil
+	return nil
+}
+
+// writeTemp writes data to a temporary file next to path, with the permissions of path.
+func writeTemp(path string, data []byte) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".fix-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), info.Mode().Perm())
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return tmp.Name(), nil
+}
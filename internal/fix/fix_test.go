@@ -0,0 +1,196 @@
+package fix
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+const workflowSource = `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+func OrderWorkflow(ctx workflow.Context) error {
+	var count int
+	selector := workflow.NewSelector(ctx)
+	selector.Select(ctx)
+	return nil
+}
+
+func Other() {}
+`
+
+// writeTree writes the test file and returns its path.
+func writeTree(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "orders.go")
+	if err := os.WriteFile(path, []byte(workflowSource), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// issue returns an issue whose fix replaces lines start..end of path.
+func issue(rule, path string, start, end int, oldText, newText string) lint.Issue {
+	return lint.Issue{RuleID: rule, FilePath: path, LineNumber: start, Fix: &lint.CodeFix{
+		Description:  rule + " fix",
+		Replacements: []lint.Replacement{{FilePath: path, StartLine: start, EndLine: end, OldText: oldText, NewText: newText}},
+	}}
+}
+
+func TestPlan(t *testing.T) {
+	path := writeTree(t)
+	plan := NewPlan([]lint.Issue{
+		issue("TA001", path, 6, 6, "var count int", "count := 0\n_ = count"),
+		// Suggestion only
+		issue("TA002", path, 7, 0, "", "// select"),
+		// The file was edited since
+		issue("TA003", path, 8, 8, "selector.Select(nil)", ""),
+		// Overlaps TA001
+		issue("TA004", path, 5, 6, "func OrderWorkflow(ctx workflow.Context) error {\nvar count int", ""),
+		// Breaks the file
+		issue("TA005", path, 12, 12, "func Other() {}", "func Other() {"),
+		// Deletes a line, matching OldText ignoring indentation, in a file relative to the root
+		issue("TA006", "orders.go", 8, 8, "  selector.Select(ctx)", ""),
+	}, filepath.Dir(path))
+
+	want := map[string]string{
+		"TA002": "no OldText",
+		"TA003": "no longer match",
+		"TA004": "overlaps",
+		"TA005": "does not parse",
+	}
+	for _, f := range plan.Fixes {
+		if reason, skipped := want[f.RuleID]; skipped != !f.Applied || !strings.Contains(f.Reason, reason) {
+			t.Errorf("%s: applied=%v reason=%q, want skipped=%v reason %q", f.RuleID, f.Applied, f.Reason, skipped, reason)
+		}
+	}
+	if plan.Applied() != 2 || plan.Files() != 1 {
+		t.Fatalf("Expected 2 fixes in 1 file, got %d in %d", plan.Applied(), plan.Files())
+	}
+
+	var diff bytes.Buffer
+	if err := plan.Diff(&diff, filepath.Dir(path)); err != nil {
+		t.Fatal(err)
+	}
+	wantDiff := "--- a/orders.go\n+++ b/orders.go\n@@ -3,9 +3,9 @@\n" +
+		" import \"go.temporal.io/sdk/workflow\"\n \n func OrderWorkflow(ctx workflow.Context) error {\n" +
+		"-\tvar count int\n+\tcount := 0\n+\t_ = count\n \tselector := workflow.NewSelector(ctx)\n" +
+		"-\tselector.Select(ctx)\n \treturn nil\n }\n \n"
+	if diff.String() != wantDiff {
+		t.Errorf("Diff() =\n%s\nwant\n%s", diff.String(), wantDiff)
+	}
+
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "\tcount := 0\n\t_ = count\n") || strings.Contains(string(data), "selector.Select(") {
+		t.Errorf("Expected the fixes gofmt-ed in the file:\n%s", data)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("Expected no temporary files left, got %d entries", len(entries))
+	}
+}
+
+func TestPlanLintFixes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "orders.go")
+	source := `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+func OrderWorkflow(ctx workflow.Context, orderID string) error {
+	return workflow.ExecuteActivity(ctx, ChargeCard, orderID).Get(ctx, nil)
+}
+
+func ChargeCard(ctx context.Context, orderID string) error {
+	return nil
+}
+`
+	if err := os.WriteFile(path, []byte(source), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	lintDir := func() []lint.Issue {
+		t.Helper()
+		a := analyzer.NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+		if err != nil {
+			t.Fatalf("Analyze failed: %v", err)
+		}
+		cfg := lint.DefaultConfig()
+		cfg.EnabledRules = []string{"TA002"}
+		return lint.NewLinter(cfg).Run(context.Background(), graph).Issues
+	}
+
+	issues := lintDir()
+	if len(issues) != 1 || issues[0].FilePath != path {
+		t.Fatalf("Expected one TA002 issue in %s, got %+v", path, issues)
+	}
+	plan := NewPlan(issues, dir)
+	if plan.Applied() != 1 || plan.Files() != 1 {
+		t.Fatalf("Expected the TA002 fix to apply, got %+v", plan.Fixes)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "\tctx = workflow.WithActivityOptions(ctx, ao)\n\treturn workflow.ExecuteActivity(ctx, ChargeCard, orderID).Get(ctx, nil)\n"
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Expected the options before the call:\n%s", data)
+	}
+	if issues := lintDir(); len(issues) != 0 {
+		t.Errorf("Expected no TA002 issue after the fix, got %+v", issues)
+	}
+}
+
+func TestApplyChangedFile(t *testing.T) {
+	path := writeTree(t)
+	plan := NewPlan([]lint.Issue{issue("TA001", path, 6, 6, "var count int", "count := 0")}, "")
+	if err := os.WriteFile(path, []byte(workflowSource+"\n// edited\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := plan.Apply(); err == nil || !strings.Contains(err.Error(), "changed since") {
+		t.Errorf("Expected the changed file to be refused, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.HasSuffix(string(data), "// edited\n") {
+		t.Errorf("Expected the file left as edited:\n%s", data)
+	}
+}
+
+func TestWriteHunks(t *testing.T) {
+	a := strings.Split("1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20", " ")
+	b := append([]string{"0"}, a...)
+	b[2] = "two"
+	b[len(b)-2] = "nineteen"
+	var buf bytes.Buffer
+	if err := writeHunks(&buf, a, b); err != nil {
+		t.Fatal(err)
+	}
+	want := "@@ -1,5 +1,6 @@\n+0\n 1\n-2\n+two\n 3\n 4\n 5\n" +
+		"@@ -16,5 +17,5 @@\n 16\n 17\n 18\n-19\n+nineteen\n 20\n"
+	if buf.String() != want {
+		t.Errorf("writeHunks() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
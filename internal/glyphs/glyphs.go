@@ -0,0 +1,151 @@
+// Package glyphs provides the symbols used by the non-interactive CLI outputs,
+// with an ASCII fallback for terminals and CI logs that can't display Unicode.
+package glyphs
+
+import (
+	"os"
+	"strings"
+)
+
+// Set is the set of symbols an output uses.
+type Set struct {
+	// Severity and status markers
+	Error   string
+	Warning string
+	Info    string
+	Success string
+	Arrow   string
+
+	// Horizontal rules (one character, repeated by callers)
+	Rule       string
+	HeavyRule  string
+	TreeMiddle string // branch to a child that has siblings below it
+	TreeLast   string // branch to the last child
+	TreeIndent string // indentation under a child that has siblings below it
+	TreeSpace  string // indentation under the last child
+
+	// Tree markers
+	Cycle    string
+	SeeAbove string
+	Ellipsis string
+	Times    string
+	// Sparkline levels, lowest first
+	Spark string
+
+	// Node type icons (empty in ASCII mode)
+	Workflow string
+	Activity string
+	Signal   string
+	Query    string
+	// Call target not found in the analyzed code
+	Unresolved string
+
+	// Section icons (empty in ASCII mode)
+	Reliability  string
+	BestPractice string
+	Performance  string
+	Maintenance  string
+	Security     string
+	Stats        string
+	Activities   string
+	Workers      string
+	Graph        string
+	EntryPoints  string
+}
+
+// Unicode is the default symbol set.
+var Unicode = Set{
+	Error:   "✖",
+	Warning: "⚠",
+	Info:    "ℹ",
+	Success: "✓",
+	Arrow:   "→",
+
+	Rule:       "─",
+	HeavyRule:  "═",
+	TreeMiddle: "├── ",
+	TreeLast:   "└── ",
+	TreeIndent: "│   ",
+	TreeSpace:  "    ",
+
+	Cycle:    "↻",
+	SeeAbove: "↑",
+	Ellipsis: "…",
+	Times:    "×",
+	Spark:    "▁▂▃▄▅▆▇█",
+
+	Workflow:   "⚡",
+	Activity:   "⚙",
+	Signal:     "🔔",
+	Query:      "❓",
+	Unresolved: "❔",
+
+	Reliability:  "🔒",
+	BestPractice: "✨",
+	Performance:  "⚡",
+	Maintenance:  "🔧",
+	Security:     "🛡️ ",
+	Stats:        "📊",
+	Activities:   "⚙️",
+	Workers:      "🏭",
+	Graph:        "📈",
+	EntryPoints:  "🚪",
+}
+
+// ASCII is the plain symbol set for environments without Unicode support.
+var ASCII = Set{
+	Error:   "x",
+	Warning: "!",
+	Info:    "i",
+	Success: "OK",
+	Arrow:   "->",
+
+	Rule:       "-",
+	HeavyRule:  "=",
+	TreeMiddle: "|-- ",
+	TreeLast:   "`-- ",
+	TreeIndent: "|   ",
+	TreeSpace:  "    ",
+
+	Cycle:    "@",
+	SeeAbove: "^",
+	Ellipsis: "...",
+	Times:    "x",
+	Spark:    "_.-=+*#@",
+}
+
+// For returns the ASCII set if plain is true, the Unicode set otherwise.
+func For(plain bool) Set {
+	if plain {
+		return ASCII
+	}
+	return Unicode
+}
+
+// Icon prefixes text with an icon, or returns text unchanged if the icon is empty.
+func Icon(icon, text string) string {
+	if icon == "" {
+		return text
+	}
+	return icon + " " + text
+}
+
+// PlainFromEnv reports whether the environment looks unable to display Unicode:
+// TERM is "dumb", or the effective locale (LC_ALL, LC_CTYPE, LANG) is not UTF-8.
+func PlainFromEnv() bool {
+	return plainFromEnv(os.Getenv)
+}
+
+func plainFromEnv(getenv func(string) string) bool {
+	if getenv("TERM") == "dumb" {
+		return true
+	}
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := getenv(key); value != "" {
+			value = strings.ToLower(value)
+			return !strings.Contains(value, "utf-8") && !strings.Contains(value, "utf8")
+		}
+	}
+	// No locale set: most terminals and CI runners still handle UTF-8
+	return false
+}
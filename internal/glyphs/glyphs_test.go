@@ -0,0 +1,65 @@
+package glyphs
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFor(t *testing.T) {
+	if For(false).Error != Unicode.Error {
+		t.Error("For(false) should return the Unicode set")
+	}
+	if For(true).Error != ASCII.Error {
+		t.Error("For(true) should return the ASCII set")
+	}
+}
+
+func TestASCIISetIsASCII(t *testing.T) {
+	for _, s := range []string{
+		ASCII.Error, ASCII.Warning, ASCII.Info, ASCII.Success, ASCII.Arrow,
+		ASCII.Rule, ASCII.HeavyRule, ASCII.TreeMiddle, ASCII.TreeLast, ASCII.TreeIndent, ASCII.TreeSpace,
+		ASCII.Cycle, ASCII.SeeAbove, ASCII.Ellipsis, ASCII.Times, ASCII.Spark,
+	} {
+		if s == "" {
+			t.Error("ASCII symbol should not be empty")
+		}
+		for _, r := range s {
+			if r >= utf8.RuneSelf {
+				t.Errorf("ASCII symbol %q contains non-ASCII rune %q", s, r)
+			}
+		}
+	}
+}
+
+func TestIcon(t *testing.T) {
+	if got := Icon("⚡", "Workflows"); got != "⚡ Workflows" {
+		t.Errorf("Icon() = %q, want %q", got, "⚡ Workflows")
+	}
+	if got := Icon("", "Workflows"); got != "Workflows" {
+		t.Errorf("Icon() with empty icon = %q, want %q", got, "Workflows")
+	}
+}
+
+func TestPlainFromEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{name: "no locale", env: map[string]string{}, want: false},
+		{name: "utf-8 lang", env: map[string]string{"LANG": "en_US.UTF-8"}, want: false},
+		{name: "utf8 lowercase", env: map[string]string{"LANG": "C.utf8"}, want: false},
+		{name: "posix lang", env: map[string]string{"LANG": "C"}, want: true},
+		{name: "dumb terminal", env: map[string]string{"TERM": "dumb", "LANG": "en_US.UTF-8"}, want: true},
+		{name: "LC_ALL overrides LANG", env: map[string]string{"LC_ALL": "POSIX", "LANG": "en_US.UTF-8"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := plainFromEnv(getenv); got != tt.want {
+				t.Errorf("plainFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
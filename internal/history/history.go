@@ -0,0 +1,249 @@
+// Package history stores dated snapshots of the analyzed graph and renders their trends.
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+// Snapshot records the size, issue counts and complexity of a graph at a point in time.
+type Snapshot struct {
+	Time        time.Time `json:"time"`
+	Workflows   int       `json:"workflows"`
+	Activities  int       `json:"activities"`
+	Signals     int       `json:"signals"`
+	Queries     int       `json:"queries"`
+	Updates     int       `json:"updates"`
+	Connections int       `json:"connections"`
+	MaxDepth    int       `json:"max_depth"`
+	MaxFanOut   int       `json:"max_fan_out"`
+	AvgFanOut   float64   `json:"avg_fan_out"`
+	Errors      int       `json:"errors"`
+	Warnings    int       `json:"warnings"`
+	Infos       int       `json:"infos"`
+
+	// NodeIssues are the lint issues of nodes at the time of the snapshot, stored in the
+	// issues table; Load leaves them out
+	NodeIssues []IssueRecord `json:"-"`
+}
+
+// Issues returns the total number of lint issues in the snapshot.
+func (s Snapshot) Issues() int {
+	return s.Errors + s.Warnings + s.Infos
+}
+
+// NewSnapshot summarizes a graph and its lint result.
+func NewSnapshot(graph *analyzer.TemporalGraph, result *lint.Result, now time.Time) Snapshot {
+	stats := graph.Stats
+	s := Snapshot{
+		Time:        now.UTC(),
+		Workflows:   stats.TotalWorkflows,
+		Activities:  stats.TotalActivities,
+		Signals:     stats.TotalSignals,
+		Queries:     stats.TotalQueries,
+		Updates:     stats.TotalUpdates,
+		Connections: stats.TotalConnections,
+		MaxDepth:    stats.MaxDepth,
+		MaxFanOut:   stats.MaxFanOut,
+		AvgFanOut:   stats.AvgFanOut,
+	}
+	if result != nil {
+		s.Errors = result.ErrorCount
+		s.Warnings = result.WarnCount
+		s.Infos = result.InfoCount
+		for _, issue := range result.Issues {
+			if issue.NodeName != "" {
+				s.NodeIssues = append(s.NodeIssues, newIssueRecord(issue, s.Time))
+			}
+		}
+	}
+	return s
+}
+
+// Metric is a trended value of a snapshot.
+type Metric struct {
+	Name  string
+	Value func(Snapshot) float64
+}
+
+// Metrics are the values shown in trend reports, in display order.
+var Metrics = []Metric{
+	{"Workflows", func(s Snapshot) float64 { return float64(s.Workflows) }},
+	{"Activities", func(s Snapshot) float64 { return float64(s.Activities) }},
+	{"Issues", func(s Snapshot) float64 { return float64(s.Issues()) }},
+	{"Errors", func(s Snapshot) float64 { return float64(s.Errors) }},
+	{"Connections", func(s Snapshot) float64 { return float64(s.Connections) }},
+	{"Max depth", func(s Snapshot) float64 { return float64(s.MaxDepth) }},
+	{"Max fan-out", func(s Snapshot) float64 { return float64(s.MaxFanOut) }},
+	{"Avg fan-out", func(s Snapshot) float64 { return s.AvgFanOut }},
+}
+
+// Store persists snapshots.
+type Store interface {
+	// Append adds a snapshot to the store.
+	Append(ctx context.Context, s Snapshot) error
+	// Load returns all snapshots, oldest first.
+	Load(ctx context.Context) ([]Snapshot, error)
+	// LoadIssues returns the issues recorded for a node, by name or graph key, oldest first.
+	LoadIssues(ctx context.Context, node string) ([]IssueRecord, error)
+}
+
+// sqliteStore keeps snapshots in the snapshots table of a SQLite database. Like the sqlite
+// output format it drives the sqlite3 command-line tool, which must be on the PATH.
+type sqliteStore struct {
+	path string
+}
+
+// NewSQLiteStore creates a Store backed by the SQLite database at path, created on first append.
+func NewSQLiteStore(path string) Store {
+	return &sqliteStore{path: path}
+}
+
+// createSnapshotsTable creates the snapshots table. Columns are named after the JSON fields
+// of Snapshot so that rows read with sqlite3 -json decode into snapshots directly.
+const createSnapshotsTable = `CREATE TABLE IF NOT EXISTS snapshots (
+  time TEXT NOT NULL,
+  workflows INTEGER NOT NULL,
+  activities INTEGER NOT NULL,
+  signals INTEGER NOT NULL,
+  queries INTEGER NOT NULL,
+  updates INTEGER NOT NULL,
+  connections INTEGER NOT NULL,
+  max_depth INTEGER NOT NULL,
+  max_fan_out INTEGER NOT NULL,
+  avg_fan_out REAL NOT NULL,
+  errors INTEGER NOT NULL,
+  warnings INTEGER NOT NULL,
+  infos INTEGER NOT NULL
+);
+`
+
+// createIssuesTable creates the issues table, with one row per node issue of a snapshot.
+// Rows join their snapshot by time.
+const createIssuesTable = `CREATE TABLE IF NOT EXISTS issues (
+  time TEXT NOT NULL,
+  node TEXT NOT NULL,
+  rule_id TEXT NOT NULL,
+  fingerprint TEXT NOT NULL,
+  severity TEXT NOT NULL,
+  message TEXT NOT NULL
+);
+`
+
+// Append implements Store.
+func (s *sqliteStore) Append(ctx context.Context, snap Snapshot) error {
+	script := createSnapshotsTable + createIssuesTable + fmt.Sprintf(
+		"INSERT INTO snapshots VALUES ('%s', %d, %d, %d, %d, %d, %d, %d, %d, %s, %d, %d, %d);\n",
+		snap.Time.UTC().Format(time.RFC3339Nano),
+		snap.Workflows, snap.Activities, snap.Signals, snap.Queries, snap.Updates,
+		snap.Connections, snap.MaxDepth, snap.MaxFanOut,
+		strconv.FormatFloat(snap.AvgFanOut, 'g', -1, 64),
+		snap.Errors, snap.Warnings, snap.Infos,
+	)
+	for _, issue := range snap.NodeIssues {
+		script += fmt.Sprintf("INSERT INTO issues VALUES ('%s', %s, %s, %s, %s, %s);\n",
+			snap.Time.UTC().Format(time.RFC3339Nano),
+			sqlString(issue.Node), sqlString(issue.RuleID), sqlString(issue.Fingerprint),
+			sqlString(issue.Severity), sqlString(issue.Message))
+	}
+	if _, err := s.run(ctx, script); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load implements Store.
+func (s *sqliteStore) Load(ctx context.Context) ([]Snapshot, error) {
+	if info, err := os.Stat(s.path); errors.Is(err, os.ErrNotExist) || (err == nil && info.Size() == 0) {
+		return nil, nil
+	}
+
+	out, err := s.run(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' AND name = 'snapshots';\n")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history database: %w", err)
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+
+	out, err = s.run(ctx, "SELECT * FROM snapshots ORDER BY time, rowid;\n")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history database: %w", err)
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+
+	var snapshots []Snapshot
+	if err := json.Unmarshal(out, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshots: %w", err)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+	return snapshots, nil
+}
+
+// LoadIssues implements Store.
+func (s *sqliteStore) LoadIssues(ctx context.Context, node string) ([]IssueRecord, error) {
+	if info, err := os.Stat(s.path); errors.Is(err, os.ErrNotExist) || (err == nil && info.Size() == 0) {
+		return nil, nil
+	}
+
+	out, err := s.run(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' AND name = 'issues';\n")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history database: %w", err)
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+
+	out, err = s.run(ctx, "SELECT * FROM issues ORDER BY time, rowid;\n")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history database: %w", err)
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+
+	var records []IssueRecord
+	if err := json.Unmarshal(out, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse issues: %w", err)
+	}
+	return FilterNode(records, node), nil
+}
+
+// sqlString quotes a string as a SQL literal.
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// run executes a SQL script against the database and returns its output as a JSON array.
+func (s *sqliteStore) run(ctx context.Context, script string) ([]byte, error) {
+	sqlite, err := exec.LookPath("sqlite3")
+	if err != nil {
+		return nil, fmt.Errorf("sqlite3 not found in PATH: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, sqlite, "-bail", "-json", s.path)
+	cmd.Stdin = strings.NewReader(script)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,99 @@
+package history
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+func TestNewSnapshot(t *testing.T) {
+	graph := &analyzer.TemporalGraph{Stats: analyzer.GraphStats{
+		TotalWorkflows: 3, TotalActivities: 7, MaxDepth: 4, MaxFanOut: 5, AvgFanOut: 1.5,
+	}}
+	result := &lint.Result{ErrorCount: 2, WarnCount: 3, InfoCount: 1, Issues: []lint.Issue{
+		{RuleID: "TA002", NodeName: "Charge", Severity: lint.SeverityWarning, Message: "no timeout"},
+		{RuleID: "TA046", Severity: lint.SeverityWarning, Message: "no node"},
+	}}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	s := NewSnapshot(graph, result, now)
+	if s.Workflows != 3 || s.Activities != 7 || s.MaxDepth != 4 || s.AvgFanOut != 1.5 {
+		t.Errorf("Unexpected graph metrics: %+v", s)
+	}
+	if s.Issues() != 6 || s.Errors != 2 {
+		t.Errorf("Unexpected issue counts: %+v", s)
+	}
+	if s.Time.Location() != time.UTC {
+		t.Errorf("Expected UTC time, got %v", s.Time)
+	}
+	if len(s.NodeIssues) != 1 || s.NodeIssues[0].Node != "Charge" || s.NodeIssues[0].Fingerprint == "" || !s.NodeIssues[0].Time.Equal(now) {
+		t.Errorf("Expected the node issue only, got %+v", s.NodeIssues)
+	}
+}
+
+func TestSQLiteStore(t *testing.T) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 not installed")
+	}
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "history.db")
+	store := NewSQLiteStore(path)
+
+	snapshots, err := store.Load(ctx)
+	if err != nil || len(snapshots) != 0 {
+		t.Fatalf("Load() on missing file = %v, %v; want no snapshots", snapshots, err)
+	}
+
+	later := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	earlier := later.Add(-24 * time.Hour)
+	issue := IssueRecord{Time: later, Node: "orders.Charge", RuleID: "TA002", Fingerprint: "f1", Severity: "warning", Message: "Activity 'Charge' has no timeout"}
+	if err := store.Append(ctx, Snapshot{Time: later, Workflows: 5, AvgFanOut: 1.25, NodeIssues: []IssueRecord{issue}}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if err := store.Append(ctx, Snapshot{Time: earlier, Workflows: 4}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	snapshots, err = store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].Workflows != 4 || snapshots[1].Workflows != 5 {
+		t.Fatalf("Expected snapshots oldest first, got %+v", snapshots)
+	}
+	if !snapshots[1].Time.Equal(later) || snapshots[1].AvgFanOut != 1.25 {
+		t.Errorf("Snapshot did not round-trip: %+v", snapshots[1])
+	}
+
+	records, err := store.LoadIssues(ctx, "Charge")
+	if err != nil {
+		t.Fatalf("LoadIssues failed: %v", err)
+	}
+	if len(records) != 1 || records[0] != issue {
+		t.Errorf("Issue did not round-trip: %+v", records)
+	}
+	if records, err := store.LoadIssues(ctx, "Refund"); err != nil || len(records) != 0 {
+		t.Errorf("LoadIssues(Refund) = %+v, %v; want none", records, err)
+	}
+}
+
+func TestSQLiteStoreNotADatabase(t *testing.T) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 not installed")
+	}
+
+	path := filepath.Join(t.TempDir(), "history.db")
+	if err := os.WriteFile(path, []byte("{\"workflows\": 1}\nnot a database\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSQLiteStore(path).Load(context.Background()); err == nil {
+		t.Error("Expected error for a file that is not a SQLite database")
+	}
+}
@@ -0,0 +1,205 @@
+package history
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/tracker"
+)
+
+// IssueRecord is a lint issue of a node recorded in a snapshot. Columns of the issues table
+// are named after its JSON fields.
+type IssueRecord struct {
+	Time time.Time `json:"time"`
+	// Node is the graph key of the node the issue was reported on
+	Node   string `json:"node"`
+	RuleID string `json:"rule_id"`
+	// Fingerprint identifies the issue across snapshots, as it does tracker tickets
+	Fingerprint string `json:"fingerprint"`
+	Severity    string `json:"severity"`
+	Message     string `json:"message"`
+}
+
+func newIssueRecord(issue lint.Issue, now time.Time) IssueRecord {
+	return IssueRecord{
+		Time:        now,
+		Node:        issue.NodeName,
+		RuleID:      issue.RuleID,
+		Fingerprint: tracker.Fingerprint(issue),
+		Severity:    string(issue.Severity),
+		Message:     issue.Message,
+	}
+}
+
+// FilterNode returns the records of a node, given by graph key or by name, which also
+// matches the package-qualified keys of the name.
+func FilterNode(records []IssueRecord, node string) []IssueRecord {
+	var matched []IssueRecord
+	for _, r := range records {
+		if r.Node == node || strings.HasSuffix(r.Node, "."+node) || strings.HasSuffix(r.Node, "/"+node) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// IssueSpan is a period in which a lint issue was reported: from the snapshot it appeared in
+// to the first later snapshot without it. An issue that was fixed and came back has a span
+// for each period.
+type IssueSpan struct {
+	Node        string    `json:"node"`
+	RuleID      string    `json:"rule_id"`
+	Fingerprint string    `json:"fingerprint"`
+	Severity    string    `json:"severity"`
+	Message     string    `json:"message"`
+	Appeared    time.Time `json:"appeared"`
+	// Disappeared is zero while the issue is still reported in the latest snapshot
+	Disappeared time.Time `json:"disappeared,omitempty"`
+}
+
+// Open returns true if the issue is reported in the latest snapshot.
+func (s IssueSpan) Open() bool {
+	return s.Disappeared.IsZero()
+}
+
+// Age returns how long the issue was open, up to now for open issues.
+func (s IssueSpan) Age(now time.Time) time.Duration {
+	if s.Open() {
+		return now.Sub(s.Appeared)
+	}
+	return s.Disappeared.Sub(s.Appeared)
+}
+
+// IssueSpans follows the issue records through the snapshots and returns when each issue
+// appeared and disappeared: open issues first, oldest first, then fixed issues, most recently
+// fixed first.
+func IssueSpans(snapshots []Snapshot, records []IssueRecord) []IssueSpan {
+	// Every snapshot time, including those of records whose snapshot row is missing
+	seenTimes := make(map[time.Time]bool)
+	var times []time.Time
+	addTime := func(t time.Time) {
+		t = t.UTC()
+		if !seenTimes[t] {
+			seenTimes[t] = true
+			times = append(times, t)
+		}
+	}
+	for _, s := range snapshots {
+		addTime(s.Time)
+	}
+
+	byFingerprint := make(map[string]map[time.Time]IssueRecord)
+	var fingerprints []string
+	for _, r := range records {
+		addTime(r.Time)
+		if byFingerprint[r.Fingerprint] == nil {
+			byFingerprint[r.Fingerprint] = make(map[time.Time]IssueRecord)
+			fingerprints = append(fingerprints, r.Fingerprint)
+		}
+		byFingerprint[r.Fingerprint][r.Time.UTC()] = r
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	var spans []IssueSpan
+	for _, fingerprint := range fingerprints {
+		reported := byFingerprint[fingerprint]
+		var open *IssueSpan
+		for _, t := range times {
+			r, ok := reported[t]
+			switch {
+			case ok && open == nil:
+				open = &IssueSpan{Node: r.Node, RuleID: r.RuleID, Fingerprint: fingerprint, Appeared: t}
+				fallthrough
+			case ok:
+				open.Severity, open.Message = r.Severity, r.Message
+			case open != nil:
+				open.Disappeared = t
+				spans = append(spans, *open)
+				open = nil
+			}
+		}
+		if open != nil {
+			spans = append(spans, *open)
+		}
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		if spans[i].Open() != spans[j].Open() {
+			return spans[i].Open()
+		}
+		if spans[i].Open() {
+			return spans[i].Appeared.Before(spans[j].Appeared)
+		}
+		return spans[i].Disappeared.After(spans[j].Disappeared)
+	})
+	return spans
+}
+
+// WriteIssueHistory writes when each issue of a node appeared and disappeared, with how long
+// open issues have been reported, e.g. "open for 6 months".
+func WriteIssueHistory(w io.Writer, node string, spans []IssueSpan, now time.Time) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	if len(spans) == 0 {
+		printf("No issues recorded for %s\n", node)
+		return err
+	}
+
+	var open, fixed []IssueSpan
+	for _, s := range spans {
+		if s.Open() {
+			open = append(open, s)
+		} else {
+			fixed = append(fixed, s)
+		}
+	}
+
+	printf("Issue history of %s: %d open, %d fixed\n", node, len(open), len(fixed))
+	if len(open) > 0 {
+		printf("\nOpen:\n")
+		for _, s := range open {
+			printf("  %s %-7s open for %s (since %s)\n      %s\n", s.RuleID, s.Severity,
+				formatAge(s.Age(now)), s.Appeared.Format(time.DateOnly), s.Message)
+		}
+	}
+	if len(fixed) > 0 {
+		printf("\nFixed:\n")
+		for _, s := range fixed {
+			printf("  %s %-7s %s to %s (%s)\n      %s\n", s.RuleID, s.Severity,
+				s.Appeared.Format(time.DateOnly), s.Disappeared.Format(time.DateOnly),
+				formatAge(s.Age(now)), s.Message)
+		}
+	}
+	return err
+}
+
+// formatAge formats a duration in the largest whole unit of days, weeks, months or years.
+func formatAge(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	unit := func(n int, name string) string {
+		if n == 1 {
+			return "1 " + name
+		}
+		return fmt.Sprintf("%d %ss", n, name)
+	}
+	switch {
+	case days < 1:
+		return "less than a day"
+	case days < 14:
+		return unit(days, "day")
+	case days < 60:
+		return unit(days/7, "week")
+	case days < 730:
+		return unit(days/30, "month")
+	}
+	return unit(days/365, "year")
+}
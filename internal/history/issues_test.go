@@ -0,0 +1,85 @@
+package history
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIssueSpans(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return start.AddDate(0, 0, n) }
+	snapshots := []Snapshot{{Time: day(0)}, {Time: day(10)}, {Time: day(20)}, {Time: day(30)}}
+	record := func(n int, fingerprint, rule string) IssueRecord {
+		return IssueRecord{Time: day(n), Node: "OrderWorkflow", RuleID: rule, Fingerprint: fingerprint, Severity: "warning", Message: rule + " message"}
+	}
+	records := []IssueRecord{
+		// Open since the first snapshot
+		record(0, "a", "TA002"),
+		record(10, "a", "TA002"),
+		record(20, "a", "TA002"),
+		record(30, "a", "TA002"),
+		// Fixed, then back
+		record(0, "b", "TA010"),
+		record(20, "b", "TA010"),
+		record(30, "b", "TA010"),
+		// Fixed
+		record(10, "c", "TA035"),
+	}
+
+	spans := IssueSpans(snapshots, records)
+	want := []struct {
+		rule                  string
+		appeared, disappeared time.Time
+	}{
+		{"TA002", day(0), time.Time{}},
+		{"TA010", day(20), time.Time{}},
+		{"TA035", day(10), day(20)},
+		{"TA010", day(0), day(10)},
+	}
+	if len(spans) != len(want) {
+		t.Fatalf("Expected %d spans, got %+v", len(want), spans)
+	}
+	for i, w := range want {
+		s := spans[i]
+		if s.RuleID != w.rule || !s.Appeared.Equal(w.appeared) || !s.Disappeared.Equal(w.disappeared) {
+			t.Errorf("spans[%d] = %s %v to %v, want %s %v to %v", i, s.RuleID, s.Appeared, s.Disappeared, w.rule, w.appeared, w.disappeared)
+		}
+	}
+}
+
+func TestFilterNode(t *testing.T) {
+	records := []IssueRecord{{Node: "OrderWorkflow"}, {Node: "orders.OrderWorkflow"}, {Node: "BigOrderWorkflow"}, {Node: "Charge"}}
+	if got := FilterNode(records, "OrderWorkflow"); len(got) != 2 {
+		t.Errorf("FilterNode() = %+v, want OrderWorkflow and orders.OrderWorkflow", got)
+	}
+}
+
+func TestWriteIssueHistory(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	spans := []IssueSpan{
+		{RuleID: "TA002", Severity: "warning", Message: "Activity 'Charge' has no timeout", Appeared: now.AddDate(0, -6, 0)},
+		{RuleID: "TA010", Severity: "error", Message: "Circular dependency", Appeared: now.AddDate(0, 0, -30), Disappeared: now.AddDate(0, 0, -9)},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteIssueHistory(&buf, "OrderWorkflow", spans, now); err != nil {
+		t.Fatalf("WriteIssueHistory failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Issue history of OrderWorkflow: 1 open, 1 fixed",
+		"TA002 warning open for 6 months (since 2026-04-01)",
+		"TA010 error   2026-09-01 to 2026-09-22 (3 weeks)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := WriteIssueHistory(&buf, "OrderWorkflow", nil, now); err != nil || !strings.Contains(buf.String(), "No issues recorded for OrderWorkflow") {
+		t.Errorf("Expected empty message, got %q (%v)", buf.String(), err)
+	}
+}
@@ -0,0 +1,85 @@
+package history
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+)
+
+// Values returns the metric's value for each snapshot.
+func (m Metric) Values(snapshots []Snapshot) []float64 {
+	values := make([]float64, len(snapshots))
+	for i, s := range snapshots {
+		values[i] = m.Value(s)
+	}
+	return values
+}
+
+// Sparkline renders values as one level character each, scaled between their minimum and maximum.
+func Sparkline(values []float64, levels string) string {
+	runes := []rune(levels)
+	if len(values) == 0 || len(runes) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	out := make([]rune, len(values))
+	for i, v := range values {
+		level := len(runes) / 2
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(runes)-1))
+		}
+		out[i] = runes[level]
+	}
+	return string(out)
+}
+
+// FormatValue formats a metric value rounded to two decimals, without trailing zeros.
+func FormatValue(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// formatChange formats the difference between two values with an explicit sign.
+func formatChange(from, to float64) string {
+	if to > from {
+		return "+" + FormatValue(to-from)
+	}
+	return FormatValue(to - from)
+}
+
+// WriteTrend writes a table of metrics over the snapshots with a sparkline per metric.
+func WriteTrend(w io.Writer, snapshots []Snapshot, set glyphs.Set) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	if len(snapshots) == 0 {
+		printf("No snapshots recorded\n")
+		return err
+	}
+
+	first, last := snapshots[0], snapshots[len(snapshots)-1]
+	printf("%d snapshot(s) from %s to %s\n\n", len(snapshots),
+		first.Time.Format(time.DateTime), last.Time.Format(time.DateTime))
+	printf("%-12s %10s %10s %10s  %s\n", "Metric", "First", "Last", "Change", "Trend")
+	for _, m := range Metrics {
+		values := m.Values(snapshots)
+		printf("%-12s %10s %10s %10s  %s\n", m.Name,
+			FormatValue(values[0]), FormatValue(values[len(values)-1]),
+			formatChange(values[0], values[len(values)-1]),
+			Sparkline(values, set.Spark))
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package history
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   string
+	}{
+		{nil, ""},
+		{[]float64{1, 2, 3}, "▁▄█"},
+		{[]float64{3, 3}, "▅▅"},
+		{[]float64{0, 7}, "▁█"},
+	}
+	for _, tt := range tests {
+		if got := Sparkline(tt.values, glyphs.Unicode.Spark); got != tt.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestWriteTrend(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []Snapshot{
+		{Time: start, Workflows: 4, Errors: 3, AvgFanOut: 1},
+		{Time: start.AddDate(0, 0, 7), Workflows: 6, Errors: 1, AvgFanOut: 1.333333},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTrend(&buf, snapshots, glyphs.ASCII); err != nil {
+		t.Fatalf("WriteTrend failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"2 snapshot(s) from 2026-03-01 00:00:00 to 2026-03-08 00:00:00",
+		"Workflows             4          6         +2  _@",
+		"Errors                3          1         -2  @_",
+		"Avg fan-out           1       1.33      +0.33  _@",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := WriteTrend(&buf, nil, glyphs.ASCII); err != nil || !strings.Contains(buf.String(), "No snapshots recorded") {
+		t.Errorf("Expected empty message, got %q (%v)", buf.String(), err)
+	}
+}
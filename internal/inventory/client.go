@@ -0,0 +1,230 @@
+package inventory
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxHistoryPages bounds the history pages read per sampled execution.
+const maxHistoryPages = 10
+
+// Client reads workflow executions and task queue pollers from the HTTP API of a
+// Temporal server (or Temporal Cloud).
+type Client struct {
+	client    *http.Client
+	baseURL   string
+	namespace string
+	apiKey    string
+}
+
+// NewClient creates a client for a namespace of the Temporal HTTP API at baseURL,
+// e.g. http://localhost:7243. An empty apiKey sends no Authorization header.
+func NewClient(client *http.Client, baseURL, namespace, apiKey string) *Client {
+	return &Client{
+		client:    client,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		namespace: namespace,
+		apiKey:    apiKey,
+	}
+}
+
+// Observe collects the workflow types started since the given time with their execution
+// counts, the activity types scheduled by the most recent execution of each workflow type,
+// and the pollers of the given task queues.
+func (c *Client) Observe(ctx context.Context, since time.Time, taskQueues []string) (*Observed, error) {
+	obs := &Observed{
+		Since:      since,
+		Workflows:  make(map[string]int),
+		Activities: make(map[string]int),
+		Pollers:    make(map[string]int),
+	}
+
+	query := fmt.Sprintf("StartTime > %q", since.UTC().Format(time.RFC3339))
+	if err := c.countWorkflowTypes(ctx, query, obs.Workflows); err != nil {
+		return nil, err
+	}
+
+	for workflowType := range obs.Workflows {
+		workflowID, runID, err := c.latestExecution(ctx, workflowType, query)
+		if err != nil {
+			return nil, err
+		}
+		if workflowID == "" {
+			continue
+		}
+		if err := c.scheduledActivities(ctx, workflowID, runID, obs.Activities); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, queue := range taskQueues {
+		pollers, err := c.pollers(ctx, queue)
+		if err != nil {
+			return nil, err
+		}
+		obs.Pollers[queue] = pollers
+	}
+	return obs, nil
+}
+
+// countWorkflowTypes counts the executions matching query per workflow type.
+func (c *Client) countWorkflowTypes(ctx context.Context, query string, counts map[string]int) error {
+	var resp struct {
+		Groups []struct {
+			GroupValues []json.RawMessage `json:"groupValues"`
+			Count       json.Number       `json:"count"`
+		} `json:"groups"`
+	}
+	params := url.Values{"query": {query + " GROUP BY WorkflowType"}}
+	if err := c.get(ctx, "/workflow-count", params, &resp); err != nil {
+		return fmt.Errorf("failed to count workflow executions: %w", err)
+	}
+	for _, group := range resp.Groups {
+		if len(group.GroupValues) == 0 {
+			continue
+		}
+		name, err := payloadString(group.GroupValues[0])
+		if err != nil {
+			return fmt.Errorf("failed to decode workflow type: %w", err)
+		}
+		n, _ := strconv.Atoi(group.Count.String())
+		counts[name] += n
+	}
+	return nil
+}
+
+// latestExecution returns the most recent execution of a workflow type matching query.
+func (c *Client) latestExecution(ctx context.Context, workflowType, query string) (workflowID, runID string, err error) {
+	var resp struct {
+		Executions []struct {
+			Execution struct {
+				WorkflowID string `json:"workflowId"`
+				RunID      string `json:"runId"`
+			} `json:"execution"`
+		} `json:"executions"`
+	}
+	params := url.Values{
+		"query":    {fmt.Sprintf("WorkflowType = %q AND %s", workflowType, query)},
+		"pageSize": {"1"},
+	}
+	if err := c.get(ctx, "/workflows", params, &resp); err != nil {
+		return "", "", fmt.Errorf("failed to list %s executions: %w", workflowType, err)
+	}
+	if len(resp.Executions) == 0 {
+		return "", "", nil
+	}
+	return resp.Executions[0].Execution.WorkflowID, resp.Executions[0].Execution.RunID, nil
+}
+
+// scheduledActivities counts the activity types scheduled in an execution's history.
+func (c *Client) scheduledActivities(ctx context.Context, workflowID, runID string, counts map[string]int) error {
+	params := url.Values{"execution.runId": {runID}}
+	for page := 0; page < maxHistoryPages; page++ {
+		var resp struct {
+			History struct {
+				Events []struct {
+					ActivityTaskScheduledEventAttributes *struct {
+						ActivityType struct {
+							Name string `json:"name"`
+						} `json:"activityType"`
+					} `json:"activityTaskScheduledEventAttributes"`
+				} `json:"events"`
+			} `json:"history"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := c.get(ctx, "/workflows/"+url.PathEscape(workflowID)+"/history", params, &resp); err != nil {
+			return fmt.Errorf("failed to read history of %s: %w", workflowID, err)
+		}
+		for _, event := range resp.History.Events {
+			if attrs := event.ActivityTaskScheduledEventAttributes; attrs != nil && attrs.ActivityType.Name != "" {
+				counts[attrs.ActivityType.Name]++
+			}
+		}
+		if resp.NextPageToken == "" {
+			return nil
+		}
+		params.Set("nextPageToken", resp.NextPageToken)
+	}
+	return nil
+}
+
+// pollers returns the number of workflow and activity pollers of a task queue.
+func (c *Client) pollers(ctx context.Context, queue string) (int, error) {
+	total := 0
+	for _, queueType := range []string{"TASK_QUEUE_TYPE_WORKFLOW", "TASK_QUEUE_TYPE_ACTIVITY"} {
+		var resp struct {
+			Pollers []json.RawMessage `json:"pollers"`
+		}
+		params := url.Values{"taskQueueType": {queueType}}
+		if err := c.get(ctx, "/task-queues/"+url.PathEscape(queue), params, &resp); err != nil {
+			return 0, fmt.Errorf("failed to describe task queue %s: %w", queue, err)
+		}
+		total += len(resp.Pollers)
+	}
+	return total, nil
+}
+
+// get sends a GET request for a path of the namespace and decodes the JSON response.
+func (c *Client) get(ctx context.Context, path string, params url.Values, out any) error {
+	u := c.baseURL + "/api/v1/namespaces/" + url.PathEscape(c.namespace) + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("GET %s returned %s: %s", u, resp.Status, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// payloadString decodes a string payload, given either in the HTTP API's shorthand form
+// (a JSON string) or as a full payload with base64 JSON data.
+func payloadString(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+	var payload struct {
+		Data string `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(payload.Data)
+	if err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", err
+	}
+	return s, nil
+}
@@ -0,0 +1,172 @@
+// Package inventory compares the workflows and activities found in the code with the
+// types a Temporal server has actually seen executed.
+package inventory
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// Observed holds what a Temporal server reports for a namespace.
+type Observed struct {
+	// Since is the start of the observation window
+	Since time.Time
+	// Workflows counts the executions started since Since per workflow type
+	Workflows map[string]int
+	// Activities counts activity types scheduled by the sampled (latest) execution of each workflow type
+	Activities map[string]int
+	// Pollers counts the workflow and activity pollers per task queue
+	Pollers map[string]int
+}
+
+// TypeCount is a workflow or activity type with its observed count.
+type TypeCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Report lists the differences between the analyzed code and the server.
+type Report struct {
+	Since time.Time `json:"since"`
+	// UndefinedWorkflows and UndefinedActivities ran on the server but are not defined in the code
+	UndefinedWorkflows  []TypeCount `json:"undefined_workflows,omitempty"`
+	UndefinedActivities []TypeCount `json:"undefined_activities,omitempty"`
+	// UnexecutedWorkflows are defined in the code but had no executions since Since
+	UnexecutedWorkflows []string `json:"unexecuted_workflows,omitempty"`
+	// UnexecutedActivities are defined in the code but not scheduled by any sampled execution
+	UnexecutedActivities []string `json:"unexecuted_activities,omitempty"`
+	// IdleTaskQueues have workers in the code but no pollers on the server
+	IdleTaskQueues []string `json:"idle_task_queues,omitempty"`
+}
+
+// Clean reports whether the code and the server agree.
+func (r *Report) Clean() bool {
+	return len(r.UndefinedWorkflows) == 0 && len(r.UndefinedActivities) == 0 &&
+		len(r.UnexecutedWorkflows) == 0 && len(r.UnexecutedActivities) == 0 && len(r.IdleTaskQueues) == 0
+}
+
+// TaskQueues returns the distinct task queues of the graph's workers, sorted.
+func TaskQueues(graph *analyzer.TemporalGraph) []string {
+	seen := make(map[string]bool)
+	var queues []string
+	for _, w := range graph.Workers {
+		if w.TaskQueue != "" && !seen[w.TaskQueue] {
+			seen[w.TaskQueue] = true
+			queues = append(queues, w.TaskQueue)
+		}
+	}
+	sort.Strings(queues)
+	return queues
+}
+
+// Compare matches the workflows and activities of the graph against the observed types.
+// Types are matched by node name or, for methods, by the method name the SDK registers.
+func Compare(graph *analyzer.TemporalGraph, obs *Observed) *Report {
+	report := &Report{Since: obs.Since}
+
+	defined := map[string]map[string]bool{"workflow": {}, "activity": {}}
+	for _, node := range graph.Nodes {
+		if node.Unresolved || defined[node.Type] == nil {
+			continue
+		}
+		defined[node.Type][node.Name] = true
+		defined[node.Type][typeName(node.Name)] = true
+
+		observed := obs.Workflows
+		if node.Type == "activity" {
+			observed = obs.Activities
+		}
+		if observed[node.Name] == 0 && observed[typeName(node.Name)] == 0 {
+			if node.Type == "workflow" {
+				report.UnexecutedWorkflows = append(report.UnexecutedWorkflows, node.Name)
+			} else {
+				report.UnexecutedActivities = append(report.UnexecutedActivities, node.Name)
+			}
+		}
+	}
+
+	report.UndefinedWorkflows = undefined(obs.Workflows, defined["workflow"])
+	report.UndefinedActivities = undefined(obs.Activities, defined["activity"])
+	sort.Strings(report.UnexecutedWorkflows)
+	sort.Strings(report.UnexecutedActivities)
+
+	for _, queue := range TaskQueues(graph) {
+		if obs.Pollers[queue] == 0 {
+			report.IdleTaskQueues = append(report.IdleTaskQueues, queue)
+		}
+	}
+	return report
+}
+
+// typeName returns the type name the SDK registers for a node: the method name for
+// activities defined as struct methods (Type.Method).
+func typeName(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// undefined returns the observed types missing from defined, most executed first.
+func undefined(observed map[string]int, defined map[string]bool) []TypeCount {
+	var missing []TypeCount
+	for name, count := range observed {
+		if !defined[name] {
+			missing = append(missing, TypeCount{Name: name, Count: count})
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].Count != missing[j].Count {
+			return missing[i].Count > missing[j].Count
+		}
+		return missing[i].Name < missing[j].Name
+	})
+	return missing
+}
+
+// WriteText writes the report as text.
+func WriteText(w io.Writer, report *Report) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("Inventory against the server since %s\n", report.Since.Format(time.DateOnly))
+	if report.Clean() {
+		printf("\nThe analyzed code and the server agree.\n")
+		return err
+	}
+
+	counts := func(title string, types []TypeCount) {
+		if len(types) == 0 {
+			return
+		}
+		printf("\n%s (%d):\n", title, len(types))
+		for _, t := range types {
+			printf("  %s (%d)\n", t.Name, t.Count)
+		}
+	}
+	names := func(title string, list []string) {
+		if len(list) == 0 {
+			return
+		}
+		printf("\n%s (%d):\n", title, len(list))
+		for _, name := range list {
+			printf("  %s\n", name)
+		}
+	}
+
+	counts("Workflows executed on the server but not defined here", report.UndefinedWorkflows)
+	counts("Activities executed on the server but not defined here", report.UndefinedActivities)
+	names("Workflows defined here but never executed", report.UnexecutedWorkflows)
+	names("Activities defined here but not scheduled by the latest execution of any workflow", report.UnexecutedActivities)
+	names("Task queues with workers here but no pollers on the server", report.IdleTaskQueues)
+	return err
+}
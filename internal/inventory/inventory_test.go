@@ -0,0 +1,137 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func TestClientObserve(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/namespaces/payments/workflow-count", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("Missing API key, got %q", r.Header.Get("Authorization"))
+		}
+		if q := r.URL.Query().Get("query"); !strings.HasSuffix(q, "GROUP BY WorkflowType") || !strings.HasPrefix(q, `StartTime > "2024-05-01T00:00:00Z"`) {
+			t.Errorf("Unexpected count query %q", q)
+		}
+		// Shorthand and full payload forms
+		_, _ = w.Write([]byte(`{"count": "7", "groups": [
+			{"groupValues": ["OrderWorkflow"], "count": "5"},
+			{"groupValues": [{"metadata": {"encoding": "anNvbi9wbGFpbg=="}, "data": "IkxlZ2FjeVdvcmtmbG93Ig=="}], "count": "2"}
+		]}`))
+	})
+	mux.HandleFunc("GET /api/v1/namespaces/payments/workflows", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("query"), `"OrderWorkflow"`) {
+			_, _ = w.Write([]byte(`{"executions": [{"execution": {"workflowId": "order-1", "runId": "run-1"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"executions": []}`))
+	})
+	mux.HandleFunc("GET /api/v1/namespaces/payments/workflows/order-1/history", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("execution.runId") != "run-1" {
+			t.Errorf("Unexpected run ID %q", r.URL.Query().Get("execution.runId"))
+		}
+		if r.URL.Query().Get("nextPageToken") == "" {
+			_, _ = w.Write([]byte(`{"history": {"events": [
+				{"eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_STARTED"},
+				{"activityTaskScheduledEventAttributes": {"activityType": {"name": "Charge"}}}
+			]}, "nextPageToken": "page2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"history": {"events": [{"activityTaskScheduledEventAttributes": {"activityType": {"name": "Charge"}}}]}}`))
+	})
+	mux.HandleFunc("GET /api/v1/namespaces/payments/task-queues/orders", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"pollers": [{"identity": "worker-1"}]}`))
+	})
+	mux.HandleFunc("GET /api/v1/namespaces/payments/task-queues/billing", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL+"/", "payments", "key")
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	obs, err := client.Observe(context.Background(), since, []string{"orders", "billing"})
+	if err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+
+	if want := map[string]int{"OrderWorkflow": 5, "LegacyWorkflow": 2}; !reflect.DeepEqual(obs.Workflows, want) {
+		t.Errorf("Workflows = %v, want %v", obs.Workflows, want)
+	}
+	if want := map[string]int{"Charge": 2}; !reflect.DeepEqual(obs.Activities, want) {
+		t.Errorf("Activities = %v, want %v", obs.Activities, want)
+	}
+	// One poller on each of the workflow and activity queues
+	if want := map[string]int{"orders": 2, "billing": 0}; !reflect.DeepEqual(obs.Pollers, want) {
+		t.Errorf("Pollers = %v, want %v", obs.Pollers, want)
+	}
+}
+
+func TestClientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "namespace not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.Client(), server.URL, "missing", "").Observe(context.Background(), time.Now(), nil)
+	if err == nil || !strings.Contains(err.Error(), "namespace not found") {
+		t.Errorf("Observe() error = %v, want the server's error", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow":         {Name: "OrderWorkflow", Type: "workflow"},
+			"RefundWorkflow":        {Name: "RefundWorkflow", Type: "workflow"},
+			"*Activities.Charge":    {Name: "*Activities.Charge", Type: "activity"},
+			"SendEmail":             {Name: "SendEmail", Type: "activity"},
+			"ExternalWorkflow":      {Name: "ExternalWorkflow", Type: "workflow", Unresolved: true},
+			"OrderWorkflow.OnClose": {Name: "OrderWorkflow.OnClose", Type: "signal"},
+		},
+		Workers: []analyzer.WorkerConfig{{TaskQueue: "orders"}, {TaskQueue: "billing"}, {TaskQueue: "orders"}},
+	}
+	obs := &Observed{
+		Workflows:  map[string]int{"OrderWorkflow": 5, "LegacyWorkflow": 2, "ExternalWorkflow": 1},
+		Activities: map[string]int{"Charge": 3, "Archive": 1},
+		Pollers:    map[string]int{"orders": 2},
+	}
+
+	report := Compare(graph, obs)
+	want := &Report{
+		UndefinedWorkflows:   []TypeCount{{Name: "LegacyWorkflow", Count: 2}, {Name: "ExternalWorkflow", Count: 1}},
+		UndefinedActivities:  []TypeCount{{Name: "Archive", Count: 1}},
+		UnexecutedWorkflows:  []string{"RefundWorkflow"},
+		UnexecutedActivities: []string{"SendEmail"},
+		IdleTaskQueues:       []string{"billing"},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("Compare() = %+v, want %+v", report, want)
+	}
+	if report.Clean() {
+		t.Error("Report with differences should not be clean")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteText(&buf, report); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	for _, want := range []string{
+		"Workflows executed on the server but not defined here (2):\n  LegacyWorkflow (2)",
+		"Workflows defined here but never executed (1):\n  RefundWorkflow",
+		"no pollers on the server (1):\n  billing",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Text report missing %q:\n%s", want, buf.String())
+		}
+	}
+}
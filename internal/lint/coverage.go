@@ -0,0 +1,232 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// RuleCoverage reports how much of a graph a rule was able to check.
+type RuleCoverage struct {
+	RuleID   string `json:"ruleId"`
+	RuleName string `json:"ruleName"`
+	// Unit is what the rule inspects, e.g. "call sites" or "nodes"
+	Unit     string `json:"unit"`
+	Eligible int    `json:"eligible"`
+	Checked  int    `json:"checked"`
+	Skipped  int    `json:"skipped"`
+	// SkipReasons counts skipped items by the data that was missing to check them
+	SkipReasons map[string]int `json:"skipReasons,omitempty"`
+}
+
+// skip records an eligible item that could not be checked.
+func (c *RuleCoverage) skip(reason string) {
+	c.Eligible++
+	c.Skipped++
+	if c.SkipReasons == nil {
+		c.SkipReasons = make(map[string]int)
+	}
+	c.SkipReasons[reason]++
+}
+
+// check records an eligible item that was checked.
+func (c *RuleCoverage) check() {
+	c.Eligible++
+	c.Checked++
+}
+
+// CoverageReporter is implemented by rules whose checks depend on data the static
+// analysis can't always extract, so users can see their blind spots.
+type CoverageReporter interface {
+	Coverage(graph *analyzer.TemporalGraph) RuleCoverage
+}
+
+// Coverage reports the coverage of each enabled rule. Rules that don't report their own
+// coverage inspect every node of the graph.
+func (l *Linter) Coverage(graph *analyzer.TemporalGraph) []RuleCoverage {
+	var coverage []RuleCoverage
+	for _, rule := range l.rules {
+		if !l.isRuleEnabled(rule) {
+			continue
+		}
+		c := RuleCoverage{Unit: "nodes", Eligible: len(graph.Nodes), Checked: len(graph.Nodes)}
+		if reporter, ok := rule.(CoverageReporter); ok {
+			c = reporter.Coverage(graph)
+		}
+		c.RuleID, c.RuleName = rule.ID(), rule.Name()
+		coverage = append(coverage, c)
+	}
+	return coverage
+}
+
+// activityOptionsCoverage counts the activity call sites of workflows accepted by eligible,
+// skipping those whose options can't be judged.
+func activityOptionsCoverage(graph *analyzer.TemporalGraph, eligible func(analyzer.CallSite) bool) RuleCoverage {
+	return activityCallCoverage(graph, eligible, unknownOptionsReason)
+}
+
+// activityCallCoverage counts the activity call sites of workflows accepted by eligible,
+// skipping those for which unknown returns a reason.
+func activityCallCoverage(graph *analyzer.TemporalGraph, eligible func(analyzer.CallSite) bool, unknown func(analyzer.CallSite) string) RuleCoverage {
+	c := RuleCoverage{Unit: "call sites"}
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+		for _, callSite := range node.CallSites {
+			if t := executedType(callSite); (t != "activity" && t != "local_activity") || !eligible(callSite) {
+				continue
+			}
+			if reason := unknown(callSite); reason != "" {
+				c.skip(reason)
+			} else {
+				c.check()
+			}
+		}
+	}
+	return c
+}
+
+func (r *ActivityUnlimitedRetryRule) Coverage(graph *analyzer.TemporalGraph) RuleCoverage {
+	return activityCallCoverage(graph, func(analyzer.CallSite) bool { return true }, func(callSite analyzer.CallSite) string {
+		if reason := unknownOptionsReason(callSite); reason != "" {
+			return reason
+		}
+		if opts := callSite.ParsedActivityOpts; opts != nil && opts.RetryPolicy != nil && opts.RetryPolicy.MaximumAttemptsUnresolved {
+			return "MaximumAttempts not a constant"
+		}
+		return ""
+	})
+}
+
+func (r *ActivityWithoutTimeoutRule) Coverage(graph *analyzer.TemporalGraph) RuleCoverage {
+	return activityOptionsCoverage(graph, func(analyzer.CallSite) bool { return true })
+}
+
+func (r *LongRunningActivityWithoutHeartbeatRule) Coverage(graph *analyzer.TemporalGraph) RuleCoverage {
+	return activityOptionsCoverage(graph, func(callSite analyzer.CallSite) bool {
+		return r.isLongRunning(callSite, graph)
+	})
+}
+
+func (r *TimeoutOutlierRule) Coverage(graph *analyzer.TemporalGraph) RuleCoverage {
+	c := RuleCoverage{Unit: "timeouts"}
+	timeouts := graph.ActivityTimeouts()
+	medians := timeoutMedians(timeouts)
+	for _, t := range timeouts {
+		switch _, ok := medians[timeoutGroup{t.Node.Package, t.Option}]; {
+		case !t.Evaluated:
+			c.skip("not a constant duration")
+		case !ok:
+			c.skip("too few timeouts in the package")
+		default:
+			c.check()
+		}
+	}
+	return c
+}
+
+func (r *DeprecatedCallRule) Coverage(graph *analyzer.TemporalGraph) RuleCoverage {
+	return baseGraphCoverage(graph, r.Base)
+}
+
+func (r *UnversionedWorkflowChangeRule) Coverage(graph *analyzer.TemporalGraph) RuleCoverage {
+	return baseGraphCoverage(graph, r.Base)
+}
+
+// baseGraphCoverage counts workflows, all skipped when no diff base graph is available.
+func baseGraphCoverage(graph, base *analyzer.TemporalGraph) RuleCoverage {
+	c := RuleCoverage{Unit: "workflows"}
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+		if base == nil {
+			c.skip("no --lint-diff-base")
+		} else {
+			c.check()
+		}
+	}
+	return c
+}
+
+func (r *UnusedMessageHandlerRule) Coverage(graph *analyzer.TemporalGraph) RuleCoverage {
+	c := RuleCoverage{Unit: "handlers"}
+	count := func(kind, name string) {
+		switch {
+		case name == "":
+			c.skip("non-constant handler name")
+		case unmatchable(graph, kind):
+			c.skip("called with a non-constant name")
+		default:
+			c.check()
+		}
+	}
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+		for _, query := range node.Queries {
+			count("query", query.Name)
+		}
+		for _, update := range node.Updates {
+			count("update", update.Name)
+		}
+	}
+	return c
+}
+
+func (r *SignalToUnhandledWorkflowRule) Coverage(graph *analyzer.TemporalGraph) RuleCoverage {
+	c := RuleCoverage{Unit: "signals"}
+	for _, m := range graph.MessagesSent("signal") {
+		target, ok := graph.Nodes[m.Workflow]
+		switch {
+		case m.Name == "":
+			c.skip("non-constant signal name")
+		case m.Workflow != "" && (!ok || target.Unresolved):
+			c.skip("workflow not in the analyzed code")
+		default:
+			c.check()
+		}
+	}
+	return c
+}
+
+func (r *ArgumentsMismatchRule) Coverage(graph *analyzer.TemporalGraph) RuleCoverage {
+	c := RuleCoverage{Unit: "call sites"}
+	for _, node := range graph.Nodes {
+		for _, callSite := range node.CallSites {
+			switch executedType(callSite) {
+			case "activity", "local_activity", "child_workflow":
+			default:
+				continue
+			}
+			if _, ok := CheckArguments(callSite, graph.Nodes[callSite.TargetName]); ok {
+				c.check()
+			} else {
+				c.skip("target not in the analyzed code")
+			}
+		}
+	}
+	return c
+}
+
+// FormatCoverage writes a rule coverage table.
+func FormatCoverage(coverage []RuleCoverage, w io.Writer) error {
+	fprintf(w, "Rule coverage\n")
+	fprintf(w, "  %-8s %-32s %-11s %8s %8s %8s  %s\n", "RULE", "NAME", "UNIT", "ELIGIBLE", "CHECKED", "SKIPPED", "SKIPPED BECAUSE")
+	for _, c := range coverage {
+		reasons := make([]string, 0, len(c.SkipReasons))
+		for reason, count := range c.SkipReasons {
+			reasons = append(reasons, fmt.Sprintf("%s (%d)", reason, count))
+		}
+		sort.Strings(reasons)
+		line := fmt.Sprintf("  %-8s %-32s %-11s %8d %8d %8d  %s", c.RuleID, c.RuleName, c.Unit, c.Eligible, c.Checked, c.Skipped, strings.Join(reasons, ", "))
+		fprintln(w, strings.TrimRight(line, " "))
+	}
+	fprintln(w)
+	return nil
+}
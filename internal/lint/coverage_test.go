@@ -0,0 +1,87 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func TestLinterCoverage(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name: "OrderWorkflow",
+				Type: "workflow",
+				CallSites: []analyzer.CallSite{
+					{TargetName: "ChargeCard", TargetType: "activity", CallType: "execute", ParsedActivityOpts: &analyzer.ActivityOptions{StartToCloseTimeout: "time.Minute"}},
+					{TargetName: "ProcessBatch", TargetType: "activity", CallType: "execute", ParsedActivityOpts: &analyzer.ActivityOptions{Unparsed: true}},
+					{TargetName: "SyncLedger", TargetType: "activity", CallType: "execute", ParsedActivityOpts: &analyzer.ActivityOptions{CallerDependent: true}},
+					{TargetName: "external.Notify", TargetType: "activity", CallType: "execute"},
+				},
+			},
+			"ChargeCard":   {Name: "ChargeCard", Type: "activity"},
+			"ProcessBatch": {Name: "ProcessBatch", Type: "activity"},
+			"SyncLedger":   {Name: "SyncLedger", Type: "activity"},
+		},
+	}
+
+	cfg := DefaultConfig()
+	cfg.Coverage = true
+	cfg.EnabledRules = []string{"TA001", "TA003", "TA010", "TA036", "TA040"}
+	result := NewLinter(cfg).Run(context.Background(), graph)
+
+	coverage := make(map[string]RuleCoverage)
+	for _, c := range result.Coverage {
+		coverage[c.RuleID] = c
+	}
+	if len(coverage) != 5 {
+		t.Fatalf("Expected coverage of the 5 enabled rules, got %+v", result.Coverage)
+	}
+
+	tests := []struct {
+		ruleID                     string
+		eligible, checked, skipped int
+		reason                     string
+	}{
+		{"TA001", 4, 2, 2, "options passed in a variable"},
+		{"TA003", 2, 0, 2, "options set by callers"},
+		{"TA010", 4, 4, 0, ""},
+		{"TA036", 1, 0, 1, "no --lint-diff-base"},
+		{"TA040", 4, 3, 1, "target not in the analyzed code"},
+	}
+	for _, tt := range tests {
+		c := coverage[tt.ruleID]
+		if c.Eligible != tt.eligible || c.Checked != tt.checked || c.Skipped != tt.skipped {
+			t.Errorf("%s coverage = %d eligible, %d checked, %d skipped, want %d, %d, %d",
+				tt.ruleID, c.Eligible, c.Checked, c.Skipped, tt.eligible, tt.checked, tt.skipped)
+		}
+		if tt.reason != "" && c.SkipReasons[tt.reason] == 0 {
+			t.Errorf("%s skip reasons = %v, want %q", tt.ruleID, c.SkipReasons, tt.reason)
+		}
+	}
+
+	// Call sites with unknown options are skipped rather than reported
+	for _, issue := range result.Issues {
+		if issue.RuleID == "TA001" && issue.NodeName != "ChargeCard" && issue.NodeName != "external.Notify" {
+			t.Errorf("Unexpected TA001 issue for a call site with unknown options: %s", issue.Message)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := FormatCoverage(result.Coverage, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "options passed in a variable (1)") {
+		t.Errorf("Expected skip reasons in the coverage table, got:\n%s", buf.String())
+	}
+}
+
+func TestLinterCoverageDisabled(t *testing.T) {
+	result := NewLinter(DefaultConfig()).Run(context.Background(), &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{}})
+	if result.Coverage != nil {
+		t.Errorf("Expected no coverage unless enabled, got %+v", result.Coverage)
+	}
+}
@@ -0,0 +1,68 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RuleDocsBaseURL is where the generated rule documentation (docs/rules) is published.
+const RuleDocsBaseURL = "https://github.com/ikari-pl/go-temporalio-analyzer/blob/main/docs/rules/"
+
+// DocURL returns the documentation URL of a rule.
+func DocURL(ruleID string) string {
+	return RuleDocsBaseURL + ruleID + ".md"
+}
+
+// RuleDoc renders the Markdown documentation page of a rule.
+func RuleDoc(rule RuleInfo) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s: %s\n\n", rule.ID, rule.Name)
+	sb.WriteString("| Category | Default severity | Tags |\n")
+	sb.WriteString("|----------|------------------|------|\n")
+	fmt.Fprintf(&sb, "| %s | %s | %s |\n\n", rule.Category, rule.Severity, strings.Join(rule.Tags, ", "))
+	sb.WriteString("## Why\n\n")
+	sb.WriteString(rule.Description + "\n\n")
+	sb.WriteString("## Configuration\n\n")
+	sb.WriteString("Disable this rule with:\n\n")
+	sb.WriteString("```bash\n")
+	fmt.Fprintf(&sb, "temporal-analyzer --lint --lint-disable %s .\n", rule.ID)
+	sb.WriteString("```\n\n")
+	if len(rule.Tags) > 0 {
+		fmt.Fprintf(&sb, "Run it with the other %s rules with `--lint-tags %s`.\n\n", rule.Tags[0], rule.Tags[0])
+	}
+	sb.WriteString("Severities can be adjusted per directory with `--lint-profiles`.\n\n")
+	sb.WriteString("_This page is generated by `temporal-analyzer --lint-docs docs/rules`; edit the rule in `internal/lint/rules.go` instead._\n")
+	return sb.String()
+}
+
+// RuleIndex renders the Markdown index of the rule documentation.
+func RuleIndex(rules []RuleInfo) string {
+	var sb strings.Builder
+	sb.WriteString("# Lint Rules\n\n")
+	sb.WriteString("| ID | Name | Category | Severity | Tags |\n")
+	sb.WriteString("|----|------|----------|----------|------|\n")
+	for _, rule := range rules {
+		fmt.Fprintf(&sb, "| [%s](%s.md) | %s | %s | %s | %s |\n", rule.ID, rule.ID, rule.Name, rule.Category, rule.Severity, strings.Join(rule.Tags, ", "))
+	}
+	sb.WriteString("\n_Generated by `temporal-analyzer --lint-docs docs/rules`._\n")
+	return sb.String()
+}
+
+// WriteRuleDocs writes a documentation page per rule and an index (README.md) to dir.
+func WriteRuleDocs(dir string, rules []RuleInfo) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create rule docs directory: %w", err)
+	}
+	for _, rule := range rules {
+		path := filepath.Join(dir, rule.ID+".md")
+		if err := os.WriteFile(path, []byte(RuleDoc(rule)), 0o644); err != nil {
+			return fmt.Errorf("failed to write rule doc %s: %w", rule.ID, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(RuleIndex(rules)), 0o644); err != nil {
+		return fmt.Errorf("failed to write rule docs index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDocURL(t *testing.T) {
+	want := "https://github.com/ikari-pl/go-temporalio-analyzer/blob/main/docs/rules/TA001.md"
+	if got := DocURL("TA001"); got != want {
+		t.Errorf("DocURL() = %q, want %q", got, want)
+	}
+}
+
+func TestRuleDoc(t *testing.T) {
+	doc := RuleDoc(RuleInfo{ID: "TA002", Name: "activity-without-timeout", Category: CategoryReliability, Severity: SeverityError, Description: "Hung activities block workflows."})
+
+	for _, want := range []string{"# TA002: activity-without-timeout", "| reliability | error |", "Hung activities block workflows.", "--lint-disable TA002"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("RuleDoc() missing %q:\n%s", want, doc)
+		}
+	}
+}
+
+func TestWriteRuleDocs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "rules")
+	rules := []RuleInfo{{ID: "TA001", Name: "a"}, {ID: "TA002", Name: "b"}}
+	if err := WriteRuleDocs(dir, rules); err != nil {
+		t.Fatalf("WriteRuleDocs() error = %v", err)
+	}
+
+	for _, name := range []string{"TA001.md", "TA002.md", "README.md"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s to be written: %v", name, err)
+		}
+	}
+}
+
+// TestRuleDocsUpToDate fails when docs/rules is stale; run `make docs` to regenerate it.
+func TestRuleDocsUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "docs", "rules")
+	rules := NewLinter(DefaultConfig()).ListRules()
+
+	files := map[string]string{"README.md": RuleIndex(rules)}
+	for _, rule := range rules {
+		files[rule.ID+".md"] = RuleDoc(rule)
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("Missing rule doc %s (run make docs): %v", name, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("Rule doc %s is out of date (run make docs)", name)
+		}
+	}
+}
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
 )
 
 // Helper functions to suppress errcheck warnings for formatting output.
@@ -35,6 +39,12 @@ func NewFormatter(format string) Formatter {
 		return &SARIFFormatter{}
 	case "checkstyle":
 		return &CheckstyleFormatter{}
+	case "heatmap":
+		return &HeatmapFormatter{}
+	case "heatmap-html":
+		return &HeatmapFormatter{HTML: true}
+	case "pr-comment":
+		return &PRCommentFormatter{}
 	case "text", "":
 		return &TextFormatter{Color: true}
 	case "text-no-color":
@@ -51,6 +61,8 @@ func NewFormatter(format string) Formatter {
 // TextFormatter outputs human-readable text.
 type TextFormatter struct {
 	Color bool
+	// Plain uses ASCII symbols instead of Unicode
+	Plain bool
 }
 
 func (f *TextFormatter) Format(result *Result, w io.Writer) error {
@@ -71,12 +83,17 @@ func (f *TextFormatter) Format(result *Result, w io.Writer) error {
 		dim = "\033[2m"
 	}
 
+	g := glyphs.For(f.Plain)
+
 	// Header
 	fprintf(w, "\n%s%sTemporal Analyzer - Lint Results%s\n", bold, blue, reset)
-	fprintf(w, "%s══════════════════════════════════════════════════════════════════%s\n\n", dim, reset)
+	fprintf(w, "%s%s%s\n\n", dim, strings.Repeat(g.HeavyRule, 66), reset)
 
 	if len(result.Issues) == 0 {
-		fprintf(w, "%s✓ No issues found!%s\n\n", bold, reset)
+		fprintf(w, "%s%s No issues found!%s\n\n", bold, g.Success, reset)
+		if len(result.Coverage) > 0 {
+			return FormatCoverage(result.Coverage, w)
+		}
 		return nil
 	}
 
@@ -96,14 +113,14 @@ func (f *TextFormatter) Format(result *Result, w io.Writer) error {
 		fprintf(w, "%s%s%s\n", bold, filePath, reset)
 		for _, issue := range issues {
 			severityColor := blue
-			severityIcon := "ℹ"
+			severityIcon := g.Info
 			switch issue.Severity {
 			case SeverityError:
 				severityColor = red
-				severityIcon = "✖"
+				severityIcon = g.Error
 			case SeverityWarning:
 				severityColor = yellow
-				severityIcon = "⚠"
+				severityIcon = g.Warning
 			}
 
 			lineInfo := ""
@@ -118,7 +135,10 @@ func (f *TextFormatter) Format(result *Result, w io.Writer) error {
 				issue.Message)
 
 			if issue.Suggestion != "" {
-				fprintf(w, "     %s→ %s%s\n", dim, issue.Suggestion, reset)
+				fprintf(w, "     %s%s %s%s\n", dim, g.Arrow, issue.Suggestion, reset)
+			}
+			if issue.DocURL != "" {
+				fprintf(w, "     %s%s %s%s\n", dim, g.Arrow, issue.DocURL, reset)
 			}
 		}
 		fprintln(w)
@@ -129,14 +149,14 @@ func (f *TextFormatter) Format(result *Result, w io.Writer) error {
 		fprintf(w, "%sGeneral Issues%s\n", bold, reset)
 		for _, issue := range noFile {
 			severityColor := blue
-			severityIcon := "ℹ"
+			severityIcon := g.Info
 			switch issue.Severity {
 			case SeverityError:
 				severityColor = red
-				severityIcon = "✖"
+				severityIcon = g.Error
 			case SeverityWarning:
 				severityColor = yellow
-				severityIcon = "⚠"
+				severityIcon = g.Warning
 			}
 
 			fprintf(w, "  %s%s%s %s%s%s %s\n",
@@ -145,14 +165,17 @@ func (f *TextFormatter) Format(result *Result, w io.Writer) error {
 				issue.Message)
 
 			if issue.Suggestion != "" {
-				fprintf(w, "     %s→ %s%s\n", dim, issue.Suggestion, reset)
+				fprintf(w, "     %s%s %s%s\n", dim, g.Arrow, issue.Suggestion, reset)
+			}
+			if issue.DocURL != "" {
+				fprintf(w, "     %s%s %s%s\n", dim, g.Arrow, issue.DocURL, reset)
 			}
 		}
 		fprintln(w)
 	}
 
 	// Summary
-	fprintf(w, "%s──────────────────────────────────────────────────────────────────%s\n", dim, reset)
+	fprintf(w, "%s%s%s\n", dim, strings.Repeat(g.Rule, 66), reset)
 	summary := []string{}
 	if result.ErrorCount > 0 {
 		summary = append(summary, fmt.Sprintf("%s%d error(s)%s", red, result.ErrorCount, reset))
@@ -165,6 +188,9 @@ func (f *TextFormatter) Format(result *Result, w io.Writer) error {
 	}
 	fprintf(w, "%s %s\n\n", bold, strings.Join(summary, ", "))
 
+	if len(result.Coverage) > 0 {
+		return FormatCoverage(result.Coverage, w)
+	}
 	return nil
 }
 
@@ -177,12 +203,15 @@ type JSONFormatter struct{}
 
 // JSONOutput is the structure for JSON output.
 type JSONOutput struct {
-	Version    string   `json:"version"`
-	Timestamp  string   `json:"timestamp"`
-	TotalNodes int      `json:"totalNodes"`
-	Summary    Summary  `json:"summary"`
-	Issues     []Issue  `json:"issues"`
-	ExitCode   int      `json:"exitCode"`
+	Version    string         `json:"version"`
+	Timestamp  string         `json:"timestamp"`
+	TotalNodes int            `json:"totalNodes"`
+	Summary    Summary        `json:"summary"`
+	Issues     []Issue        `json:"issues"`
+	ExitCode   int            `json:"exitCode"`
+	Coverage   []RuleCoverage `json:"coverage,omitempty"`
+	// Engine identifies the analyzer version and rule set of the run
+	Engine *analyzer.EngineInfo `json:"engine"`
 }
 
 type Summary struct {
@@ -205,6 +234,8 @@ func (f *JSONFormatter) Format(result *Result, w io.Writer) error {
 		},
 		Issues:   result.Issues,
 		ExitCode: result.ExitCode,
+		Coverage: result.Coverage,
+		Engine:   result.engine(),
 	}
 
 	encoder := json.NewEncoder(w)
@@ -252,6 +283,9 @@ func (f *GitHubFormatter) Format(result *Result, w io.Writer) error {
 		if issue.Suggestion != "" {
 			message += " Suggestion: " + issue.Suggestion
 		}
+		if issue.DocURL != "" {
+			message += " Docs: " + issue.DocURL
+		}
 
 		// Format: ::{level} {params}::{message}
 		if len(params) > 0 {
@@ -275,7 +309,10 @@ func (f *GitHubFormatter) Format(result *Result, w io.Writer) error {
 // =============================================================================
 
 // SARIFFormatter outputs SARIF format for Azure DevOps, GitHub Code Scanning, etc.
-type SARIFFormatter struct{}
+type SARIFFormatter struct {
+	// RootDir is stripped from file paths when set, making them relative to %SRCROOT%
+	RootDir string
+}
 
 // SARIF structures
 type SARIFReport struct {
@@ -298,14 +335,17 @@ type SARIFDriver struct {
 	Version         string      `json:"version"`
 	InformationURI  string      `json:"informationUri"`
 	Rules           []SARIFRule `json:"rules"`
+	// Properties hold the graph schema and rule set fingerprint of the engine
+	Properties map[string]interface{} `json:"properties,omitempty"`
 }
 
 type SARIFRule struct {
 	ID               string               `json:"id"`
 	Name             string               `json:"name"`
 	ShortDescription SARIFMessage         `json:"shortDescription"`
-	FullDescription  SARIFMessage         `json:"fullDescription,omitempty"`
+	FullDescription  *SARIFMessage        `json:"fullDescription,omitempty"`
 	DefaultConfig    SARIFRuleConfig      `json:"defaultConfiguration"`
+	HelpURI          string               `json:"helpUri,omitempty"`
 	Properties       SARIFRuleProperties  `json:"properties,omitempty"`
 }
 
@@ -320,6 +360,7 @@ type SARIFRuleProperties struct {
 
 type SARIFResult struct {
 	RuleID    string           `json:"ruleId"`
+	RuleIndex int              `json:"ruleIndex"`
 	Level     string           `json:"level"`
 	Message   SARIFMessage     `json:"message"`
 	Locations []SARIFLocation  `json:"locations,omitempty"`
@@ -340,12 +381,15 @@ type SARIFPhysicalLocation struct {
 }
 
 type SARIFArtifactLocation struct {
-	URI string `json:"uri"`
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
 }
 
 type SARIFRegion struct {
-	StartLine int `json:"startLine"`
-	EndLine   int `json:"endLine,omitempty"`
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
 }
 
 // SARIFFix represents a suggested fix for an issue
@@ -369,23 +413,16 @@ type SARIFTextContent struct {
 }
 
 func (f *SARIFFormatter) Format(result *Result, w io.Writer) error {
-	// Build unique rules from issues
+	// Build unique rules from issues, sorted by ID so results can refer to them by index
 	ruleMap := make(map[string]*SARIFRule)
 	for _, issue := range result.Issues {
 		if _, exists := ruleMap[issue.RuleID]; !exists {
-			level := "note"
-			switch issue.Severity {
-			case SeverityError:
-				level = "error"
-			case SeverityWarning:
-				level = "warning"
-			}
-
 			ruleMap[issue.RuleID] = &SARIFRule{
 				ID:               issue.RuleID,
 				Name:             issue.RuleName,
 				ShortDescription: SARIFMessage{Text: issue.Description},
-				DefaultConfig:    SARIFRuleConfig{Level: level},
+				DefaultConfig:    SARIFRuleConfig{Level: sarifLevel(issue.Severity)},
+				HelpURI:          issue.DocURL,
 				Properties: SARIFRuleProperties{
 					Category: string(issue.Category),
 					Tags:     []string{"temporal", string(issue.Category)},
@@ -398,30 +435,26 @@ func (f *SARIFFormatter) Format(result *Result, w io.Writer) error {
 	for _, rule := range ruleMap {
 		rules = append(rules, *rule)
 	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	ruleIndex := make(map[string]int, len(rules))
+	for i, rule := range rules {
+		ruleIndex[rule.ID] = i
+	}
 
 	// Build results
 	results := make([]SARIFResult, 0, len(result.Issues))
 	for _, issue := range result.Issues {
-		level := "note"
-		switch issue.Severity {
-		case SeverityError:
-			level = "error"
-		case SeverityWarning:
-			level = "warning"
-		}
-
 		r := SARIFResult{
-			RuleID:  issue.RuleID,
-			Level:   level,
-			Message: SARIFMessage{Text: issue.Message},
+			RuleID:    issue.RuleID,
+			RuleIndex: ruleIndex[issue.RuleID],
+			Level:     sarifLevel(issue.Severity),
+			Message:   SARIFMessage{Text: issue.Message},
 		}
 
 		if issue.FilePath != "" {
 			location := SARIFLocation{
 				PhysicalLocation: SARIFPhysicalLocation{
-					ArtifactLocation: SARIFArtifactLocation{
-						URI: filepath.ToSlash(issue.FilePath),
-					},
+					ArtifactLocation: f.artifactLocation(issue.FilePath),
 				},
 			}
 			if issue.LineNumber > 0 {
@@ -434,32 +467,13 @@ func (f *SARIFFormatter) Format(result *Result, w io.Writer) error {
 
 		// Add fix information if available
 		if issue.Fix != nil && len(issue.Fix.Replacements) > 0 {
-			sarifFix := SARIFFix{
-				Description: SARIFMessage{Text: issue.Fix.Description},
-			}
-			for _, repl := range issue.Fix.Replacements {
-				change := SARIFArtifactChange{
-					ArtifactLocation: SARIFArtifactLocation{
-						URI: filepath.ToSlash(repl.FilePath),
-					},
-					Replacements: []SARIFReplacement{{
-						DeletedRegion: SARIFRegion{
-							StartLine: repl.StartLine,
-							EndLine:   repl.StartLine, // Single line replacement
-						},
-						InsertedContent: SARIFTextContent{
-							Text: repl.NewText,
-						},
-					}},
-				}
-				sarifFix.ArtifactChanges = append(sarifFix.ArtifactChanges, change)
-			}
-			r.Fixes = []SARIFFix{sarifFix}
+			r.Fixes = []SARIFFix{f.fix(issue.Fix)}
 		}
 
 		results = append(results, r)
 	}
 
+	engine := result.engine()
 	report := SARIFReport{
 		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
 		Version: "2.1.0",
@@ -468,9 +482,13 @@ func (f *SARIFFormatter) Format(result *Result, w io.Writer) error {
 				Tool: SARIFTool{
 					Driver: SARIFDriver{
 						Name:           "temporal-analyzer",
-						Version:        "1.0.0",
+						Version:        engine.Version,
 						InformationURI: "https://github.com/ikari-pl/go-temporalio-analyzer",
 						Rules:          rules,
+						Properties: map[string]interface{}{
+							"graphSchema": engine.GraphSchema,
+							"ruleSet":     engine.RuleSet,
+						},
 					},
 				},
 				Results: results,
@@ -483,6 +501,70 @@ func (f *SARIFFormatter) Format(result *Result, w io.Writer) error {
 	return encoder.Encode(report)
 }
 
+// sarifLevel returns the SARIF level of a severity.
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
+
+// artifactLocation returns the SARIF location of a file, relative to %SRCROOT% when the
+// file is in RootDir. Relative paths are resolved to full paths first.
+func (f *SARIFFormatter) artifactLocation(path string) SARIFArtifactLocation {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if f.RootDir != "" {
+		root, err := filepath.Abs(f.RootDir)
+		if err == nil {
+			if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+				return SARIFArtifactLocation{URI: filepath.ToSlash(rel), URIBaseID: "%SRCROOT%"}
+			}
+		}
+	}
+	return SARIFArtifactLocation{URI: filepath.ToSlash(path)}
+}
+
+// fix maps a code fix to a SARIF fix with one artifact change per file. Insertions delete
+// the empty region at the start of their line; line replacements delete their whole lines.
+func (f *SARIFFormatter) fix(fix *CodeFix) SARIFFix {
+	sarifFix := SARIFFix{
+		Description: SARIFMessage{Text: fix.Description},
+	}
+	changes := make(map[string]int)
+	for _, repl := range fix.Replacements {
+		replacement := SARIFReplacement{
+			DeletedRegion: SARIFRegion{
+				StartLine:   repl.StartLine,
+				StartColumn: 1,
+				EndLine:     repl.LastLine() + 1,
+				EndColumn:   1,
+			},
+			InsertedContent: SARIFTextContent{
+				Text: strings.TrimSuffix(repl.NewText, "\n") + "\n",
+			},
+		}
+		if repl.Insertion() {
+			replacement.DeletedRegion.EndLine = repl.StartLine
+		}
+
+		i, ok := changes[repl.FilePath]
+		if !ok {
+			i = len(sarifFix.ArtifactChanges)
+			changes[repl.FilePath] = i
+			sarifFix.ArtifactChanges = append(sarifFix.ArtifactChanges, SARIFArtifactChange{
+				ArtifactLocation: f.artifactLocation(repl.FilePath),
+			})
+		}
+		sarifFix.ArtifactChanges[i].Replacements = append(sarifFix.ArtifactChanges[i].Replacements, replacement)
+	}
+	return sarifFix
+}
+
 // =============================================================================
 // Checkstyle Formatter (XML)
 // =============================================================================
@@ -2,9 +2,17 @@ package lint
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
 )
 
 func TestNewFormatter(t *testing.T) {
@@ -16,6 +24,7 @@ func TestNewFormatter(t *testing.T) {
 		{"github", "*lint.GitHubFormatter"},
 		{"sarif", "*lint.SARIFFormatter"},
 		{"checkstyle", "*lint.CheckstyleFormatter"},
+		{"pr-comment", "*lint.PRCommentFormatter"},
 		{"text", "*lint.TextFormatter"},
 		{"text-no-color", "*lint.TextFormatter"},
 		{"", "*lint.TextFormatter"},
@@ -121,6 +130,33 @@ func TestTextFormatterWithColor(t *testing.T) {
 	}
 }
 
+func TestTextFormatterPlain(t *testing.T) {
+	result := &Result{
+		Issues: []Issue{
+			{
+				RuleID:     "TA001",
+				Severity:   SeverityError,
+				Message:    "Test message",
+				FilePath:   "test.go",
+				Suggestion: "Fix it",
+			},
+		},
+		ErrorCount: 1,
+	}
+
+	f := &TextFormatter{Plain: true}
+	var buf bytes.Buffer
+	if err := f.Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	for _, r := range buf.String() {
+		if r > 127 {
+			t.Fatalf("Plain output should be ASCII-only, found %q", r)
+		}
+	}
+}
+
 func TestTextFormatterGeneralIssues(t *testing.T) {
 	result := &Result{
 		Issues: []Issue{
@@ -179,6 +215,9 @@ func TestJSONFormatter(t *testing.T) {
 	if output.Version != "1.0" {
 		t.Errorf("Version = %q, want %q", output.Version, "1.0")
 	}
+	if output.Engine == nil || output.Engine.Version != analyzer.EngineVersion {
+		t.Errorf("Engine = %+v, want version %q", output.Engine, analyzer.EngineVersion)
+	}
 	if output.TotalNodes != 5 {
 		t.Errorf("TotalNodes = %d, want 5", output.TotalNodes)
 	}
@@ -302,6 +341,9 @@ func TestSARIFFormatter(t *testing.T) {
 	if run.Tool.Driver.Name != "temporal-analyzer" {
 		t.Errorf("Tool name = %q, want %q", run.Tool.Driver.Name, "temporal-analyzer")
 	}
+	if run.Tool.Driver.Version != analyzer.EngineVersion || run.Tool.Driver.Properties["graphSchema"] != float64(analyzer.GraphSchemaVersion) {
+		t.Errorf("Driver = %s %v, want the engine version and graph schema", run.Tool.Driver.Version, run.Tool.Driver.Properties)
+	}
 	if len(run.Results) != 1 {
 		t.Errorf("Results count = %d, want 1", len(run.Results))
 	}
@@ -310,6 +352,44 @@ func TestSARIFFormatter(t *testing.T) {
 	}
 }
 
+func TestFormattersDocURL(t *testing.T) {
+	url := DocURL("TA001")
+	result := &Result{
+		Issues: []Issue{{
+			RuleID:     "TA001",
+			RuleName:   "test-rule",
+			Severity:   SeverityWarning,
+			Message:    "Test message",
+			FilePath:   "test.go",
+			LineNumber: 10,
+			DocURL:     url,
+		}},
+		WarnCount: 1,
+	}
+
+	for _, format := range []string{"text", "json", "github"} {
+		var buf bytes.Buffer
+		if err := NewFormatter(format).Format(result, &buf); err != nil {
+			t.Fatalf("%s Format failed: %v", format, err)
+		}
+		if !strings.Contains(buf.String(), url) {
+			t.Errorf("%s output should link to %s:\n%s", format, url, buf.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := (&SARIFFormatter{}).Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	var report SARIFReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid SARIF JSON: %v", err)
+	}
+	if got := report.Runs[0].Tool.Driver.Rules[0].HelpURI; got != url {
+		t.Errorf("SARIF helpUri = %q, want %q", got, url)
+	}
+}
+
 func TestCheckstyleFormatter(t *testing.T) {
 	result := &Result{
 		Issues: []Issue{
@@ -485,6 +565,130 @@ func TestSARIFFormatterMultipleRules(t *testing.T) {
 	}
 }
 
+func TestSARIFFormatterRulesAndFixes(t *testing.T) {
+	result := &Result{
+		Issues: []Issue{
+			{
+				RuleID:     "TA002",
+				Severity:   SeverityWarning,
+				Message:    "Activity without timeout",
+				FilePath:   "/repo/workflows/order.go",
+				LineNumber: 12,
+				Fix: &CodeFix{
+					Description: "Add activity options",
+					Replacements: []Replacement{
+						{FilePath: "/repo/workflows/order.go", StartLine: 12, NewText: "\tctx = workflow.WithActivityOptions(ctx, ao)"},
+						{FilePath: "/repo/workflows/order.go", StartLine: 20, EndLine: 21, OldText: "a\nb", NewText: "c\n"},
+					},
+				},
+			},
+			{RuleID: "TA001", Severity: SeverityError, Message: "Outside the root", FilePath: "/elsewhere/x.go", LineNumber: 3},
+		},
+	}
+
+	f := &SARIFFormatter{RootDir: "/repo"}
+	var buf bytes.Buffer
+	if err := f.Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "fullDescription") {
+		t.Error("Expected no empty fullDescription")
+	}
+	var report SARIFReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid SARIF JSON: %v", err)
+	}
+
+	run := report.Runs[0]
+	if rules := run.Tool.Driver.Rules; len(rules) != 2 || rules[0].ID != "TA001" || rules[1].ID != "TA002" {
+		t.Fatalf("Expected rules sorted by ID, got %+v", rules)
+	}
+	if run.Results[0].RuleIndex != 1 || run.Results[1].RuleIndex != 0 {
+		t.Errorf("Expected results to refer to their rules, got %d and %d", run.Results[0].RuleIndex, run.Results[1].RuleIndex)
+	}
+
+	location := run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation
+	if location.URI != "workflows/order.go" || location.URIBaseID != "%SRCROOT%" {
+		t.Errorf("Expected a location relative to %%SRCROOT%%, got %+v", location)
+	}
+	if location := run.Results[1].Locations[0].PhysicalLocation.ArtifactLocation; location.URI != "/elsewhere/x.go" || location.URIBaseID != "" {
+		t.Errorf("Expected files outside RootDir to keep their path, got %+v", location)
+	}
+
+	fixes := run.Results[0].Fixes
+	if len(fixes) != 1 || len(fixes[0].ArtifactChanges) != 1 {
+		t.Fatalf("Expected one artifact change for the file, got %+v", fixes)
+	}
+	replacements := fixes[0].ArtifactChanges[0].Replacements
+	if len(replacements) != 2 {
+		t.Fatalf("Expected 2 replacements, got %+v", replacements)
+	}
+	insertion := replacements[0]
+	if insertion.DeletedRegion != (SARIFRegion{StartLine: 12, StartColumn: 1, EndLine: 12, EndColumn: 1}) ||
+		insertion.InsertedContent.Text != "\tctx = workflow.WithActivityOptions(ctx, ao)\n" {
+		t.Errorf("Expected an insertion before line 12, got %+v", insertion)
+	}
+	if replacement := replacements[1]; replacement.DeletedRegion != (SARIFRegion{StartLine: 20, StartColumn: 1, EndLine: 22, EndColumn: 1}) ||
+		replacement.InsertedContent.Text != "c\n" {
+		t.Errorf("Expected lines 20 to 21 replaced, got %+v", replacement)
+	}
+}
+
+func TestSARIFFormatterCallSiteLocations(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "orders"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	source := `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, ChargeCard).Get(ctx, nil)
+}
+
+func ChargeCard(ctx context.Context) error {
+	return nil
+}
+`
+	if err := os.WriteFile(filepath.Join(root, "orders", "workflows.go"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a := analyzer.NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), config.AnalysisOptions{RootDir: root})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	cfg := DefaultConfig()
+	cfg.EnabledRules = []string{"TA001", "TA002"}
+	result := NewLinter(cfg).Run(context.Background(), graph)
+	if len(result.Issues) != 2 {
+		t.Fatalf("Expected TA001 and TA002 at the call site, got %+v", result.Issues)
+	}
+
+	var buf bytes.Buffer
+	if err := (&SARIFFormatter{RootDir: root}).Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	var report SARIFReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid SARIF JSON: %v", err)
+	}
+	want := SARIFArtifactLocation{URI: "orders/workflows.go", URIBaseID: "%SRCROOT%"}
+	for _, r := range report.Runs[0].Results {
+		if got := r.Locations[0].PhysicalLocation.ArtifactLocation; got != want {
+			t.Errorf("%s location = %+v, want %+v", r.RuleID, got, want)
+		}
+		if got := r.Fixes[0].ArtifactChanges[0].ArtifactLocation; got != want {
+			t.Errorf("%s fix location = %+v, want %+v", r.RuleID, got, want)
+		}
+	}
+}
+
 func TestCheckstyleFormatterLineZero(t *testing.T) {
 	result := &Result{
 		Issues: []Issue{
@@ -0,0 +1,247 @@
+package lint
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Heatmap Formatter (Lint Density)
+// =============================================================================
+
+// Heatmap severity weights: an error counts as much as five info issues.
+const (
+	heatmapErrorWeight   = 5
+	heatmapWarningWeight = 2
+	heatmapInfoWeight    = 1
+	heatmapBarWidth      = 20
+	noFileLabel          = "(no file)"
+)
+
+// HeatmapFormatter outputs lint density per package and file, weighted by severity,
+// as a markdown table or an HTML treemap.
+type HeatmapFormatter struct {
+	HTML bool
+	// RootDir is stripped from file paths when set
+	RootDir string
+}
+
+// heatmapCell aggregates the issues of a file or package.
+type heatmapCell struct {
+	Name     string
+	Score    int
+	Errors   int
+	Warnings int
+	Infos    int
+	Files    []*heatmapCell
+}
+
+func (c *heatmapCell) add(severity Severity) {
+	switch severity {
+	case SeverityError:
+		c.Errors++
+		c.Score += heatmapErrorWeight
+	case SeverityWarning:
+		c.Warnings++
+		c.Score += heatmapWarningWeight
+	default:
+		c.Infos++
+		c.Score += heatmapInfoWeight
+	}
+}
+
+// buildHeatmap groups issues by package directory and file, hottest first.
+func (f *HeatmapFormatter) buildHeatmap(result *Result) []*heatmapCell {
+	packages := make(map[string]*heatmapCell)
+	files := make(map[string]*heatmapCell)
+
+	for _, issue := range result.Issues {
+		file, dir := noFileLabel, noFileLabel
+		if issue.FilePath != "" {
+			file = f.relPath(issue.FilePath)
+			dir = filepath.ToSlash(filepath.Dir(file))
+		}
+
+		pkg, ok := packages[dir]
+		if !ok {
+			pkg = &heatmapCell{Name: dir}
+			packages[dir] = pkg
+		}
+		fc, ok := files[file]
+		if !ok {
+			fc = &heatmapCell{Name: file}
+			files[file] = fc
+			pkg.Files = append(pkg.Files, fc)
+		}
+		pkg.add(issue.Severity)
+		fc.add(issue.Severity)
+	}
+
+	cells := make([]*heatmapCell, 0, len(packages))
+	for _, pkg := range packages {
+		sortHeatmapCells(pkg.Files)
+		cells = append(cells, pkg)
+	}
+	sortHeatmapCells(cells)
+	return cells
+}
+
+func (f *HeatmapFormatter) relPath(path string) string {
+	if f.RootDir != "" {
+		if rel, err := filepath.Rel(f.RootDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+func sortHeatmapCells(cells []*heatmapCell) {
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].Score != cells[j].Score {
+			return cells[i].Score > cells[j].Score
+		}
+		return cells[i].Name < cells[j].Name
+	})
+}
+
+// Format implements Formatter.
+func (f *HeatmapFormatter) Format(result *Result, w io.Writer) error {
+	packages := f.buildHeatmap(result)
+	if f.HTML {
+		return f.formatHTML(packages, w)
+	}
+	return f.formatMarkdown(packages, w)
+}
+
+func (f *HeatmapFormatter) formatMarkdown(packages []*heatmapCell, w io.Writer) error {
+	fprintln(w, "# Lint Heatmap")
+	fprintln(w)
+	fprintf(w, "Issues weighted by severity: error = %d, warning = %d, info = %d.\n\n",
+		heatmapErrorWeight, heatmapWarningWeight, heatmapInfoWeight)
+
+	if len(packages) == 0 {
+		fprintln(w, "No issues found.")
+		return nil
+	}
+
+	var files []*heatmapCell
+	for _, pkg := range packages {
+		files = append(files, pkg.Files...)
+	}
+	sortHeatmapCells(files)
+
+	writeTable := func(title, column string, cells []*heatmapCell) {
+		fprintf(w, "## %s\n\n", title)
+		fprintf(w, "| %s | Score | Errors | Warnings | Info | Heat |\n", column)
+		fprintln(w, "|---|---:|---:|---:|---:|---|")
+		maxScore := cells[0].Score
+		for _, c := range cells {
+			fprintf(w, "| `%s` | %d | %d | %d | %d | %s |\n",
+				c.Name, c.Score, c.Errors, c.Warnings, c.Infos, heatBar(c.Score, maxScore))
+		}
+		fprintln(w)
+	}
+	writeTable("Packages", "Package", packages)
+	writeTable("Files", "File", files)
+	return nil
+}
+
+// heatBar renders a score as a bar scaled to the highest score.
+func heatBar(score, maxScore int) string {
+	if maxScore <= 0 {
+		return ""
+	}
+	n := (score*heatmapBarWidth + maxScore - 1) / maxScore
+	return strings.Repeat("█", n)
+}
+
+// heatColor interpolates from yellow to red by the share of the highest score.
+func heatColor(score, maxScore int) string {
+	ratio := 1.0
+	if maxScore > 0 {
+		ratio = float64(score) / float64(maxScore)
+	}
+	green := int(220 * (1 - ratio))
+	return fmt.Sprintf("rgb(230, %d, 40)", green)
+}
+
+var heatmapTemplate = template.Must(template.New("heatmap").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Temporal Analyzer - Lint Heatmap</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 24px; color: #222; }
+.treemap { display: flex; flex-wrap: wrap; gap: 4px; min-height: 480px; }
+.package { display: flex; flex-direction: column; border: 1px solid #888; padding: 2px; min-width: 160px; }
+.package > h2 { font-size: 13px; margin: 2px 4px; white-space: nowrap; overflow: hidden; text-overflow: ellipsis; }
+.files { display: flex; flex-wrap: wrap; gap: 2px; flex: 1; }
+.file { flex-basis: 80px; padding: 4px; font-size: 11px; overflow: hidden; word-break: break-all; color: #111; }
+</style>
+</head>
+<body>
+<h1>Lint Heatmap</h1>
+<p>Issues weighted by severity: error = {{.ErrorWeight}}, warning = {{.WarningWeight}}, info = {{.InfoWeight}}. Area is proportional to score.</p>
+{{if .Packages}}<div class="treemap">
+{{range .Packages}}<div class="package" style="flex-grow: {{.Score}}" title="{{.Title}}">
+<h2>{{.Name}} ({{.Score}})</h2>
+<div class="files">
+{{range .Files}}<div class="file" style="flex-grow: {{.Score}}; background: {{.Color}}" title="{{.Title}}">{{.Name}}</div>
+{{end}}</div>
+</div>
+{{end}}</div>
+{{else}}<p>No issues found.</p>
+{{end}}</body>
+</html>
+`))
+
+type heatmapHTMLCell struct {
+	Name  string
+	Title string
+	Score int
+	Color template.CSS
+	Files []heatmapHTMLCell
+}
+
+func (f *HeatmapFormatter) formatHTML(packages []*heatmapCell, w io.Writer) error {
+	maxScore := 0
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			maxScore = max(maxScore, file.Score)
+		}
+	}
+
+	toHTML := func(c *heatmapCell) heatmapHTMLCell {
+		return heatmapHTMLCell{
+			Name:  c.Name,
+			Title: fmt.Sprintf("%s: score %d (%d errors, %d warnings, %d info)", c.Name, c.Score, c.Errors, c.Warnings, c.Infos),
+			Score: c.Score,
+			Color: template.CSS(heatColor(c.Score, maxScore)),
+		}
+	}
+
+	cells := make([]heatmapHTMLCell, 0, len(packages))
+	for _, pkg := range packages {
+		cell := toHTML(pkg)
+		for _, file := range pkg.Files {
+			fc := toHTML(file)
+			fc.Name = filepath.Base(file.Name)
+			cell.Files = append(cell.Files, fc)
+		}
+		cells = append(cells, cell)
+	}
+
+	if err := heatmapTemplate.Execute(w, map[string]any{
+		"ErrorWeight":   heatmapErrorWeight,
+		"WarningWeight": heatmapWarningWeight,
+		"InfoWeight":    heatmapInfoWeight,
+		"Packages":      cells,
+	}); err != nil {
+		return fmt.Errorf("failed to render heatmap: %w", err)
+	}
+	return nil
+}
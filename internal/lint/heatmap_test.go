@@ -0,0 +1,77 @@
+package lint
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func heatmapResult() *Result {
+	return &Result{Issues: []Issue{
+		{RuleID: "TA001", Severity: SeverityError, FilePath: "/repo/payments/charge.go"},
+		{RuleID: "TA002", Severity: SeverityWarning, FilePath: "/repo/payments/refund.go"},
+		{RuleID: "TA030", Severity: SeverityInfo, FilePath: "/repo/orders/order.go"},
+		{RuleID: "TA031", Severity: SeverityWarning, FilePath: "/repo/orders/order.go"},
+		{RuleID: "TA032", Severity: SeverityInfo},
+	}}
+}
+
+func TestHeatmapFormatterMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	f := &HeatmapFormatter{RootDir: "/repo"}
+	if err := f.Format(heatmapResult(), &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"| `payments` | 7 | 1 | 1 | 0 |",
+		"| `orders` | 3 | 0 | 1 | 1 |",
+		"| `payments/charge.go` | 5 | 1 | 0 | 0 |",
+		"| `(no file)` | 1 | 0 | 0 | 1 |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "`payments`") > strings.Index(out, "`orders`") {
+		t.Error("Expected hottest package first")
+	}
+	if strings.Contains(out, "/repo/") {
+		t.Error("Expected paths relative to RootDir")
+	}
+}
+
+func TestHeatmapFormatterHTML(t *testing.T) {
+	var buf bytes.Buffer
+	result := heatmapResult()
+	result.Issues = append(result.Issues, Issue{Severity: SeverityError, FilePath: "/repo/<script>/x.go"})
+
+	f := &HeatmapFormatter{HTML: true, RootDir: "/repo"}
+	if err := f.Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "<!DOCTYPE html>") || !strings.Contains(out, `class="treemap"`) {
+		t.Error("Expected HTML treemap document")
+	}
+	if !strings.Contains(out, "rgb(230, 0, 40)") {
+		t.Error("Expected hottest file to be fully red")
+	}
+	if strings.Contains(out, "<script>") {
+		t.Error("Expected file names to be escaped")
+	}
+}
+
+func TestHeatmapFormatterEmpty(t *testing.T) {
+	for _, f := range []*HeatmapFormatter{{}, {HTML: true}} {
+		var buf bytes.Buffer
+		if err := f.Format(&Result{}, &buf); err != nil {
+			t.Fatalf("Format failed: %v", err)
+		}
+		if !strings.Contains(buf.String(), "No issues found.") {
+			t.Errorf("Expected empty message, got:\n%s", buf.String())
+		}
+	}
+}
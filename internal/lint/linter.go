@@ -2,9 +2,15 @@ package lint
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/contracts"
 )
 
 // Config holds linter configuration.
@@ -15,6 +21,11 @@ type Config struct {
 	EnabledRules []string
 	// DisabledRules contains the IDs of rules to disable
 	DisabledRules []string
+	// Categories and Tags select the rules of the given categories and with any of the
+	// given tags (empty means all). Selections combine with EnabledRules: a rule runs
+	// when it matches all of them and isn't disabled.
+	Categories []Category
+	Tags       []string
 	// FailOnWarning treats warnings as failures for CI
 	FailOnWarning bool
 	// MaxIssues is the maximum number of issues to report (0 = unlimited)
@@ -27,6 +38,44 @@ type Config struct {
 	LLMVerify  bool   // Use LLM to verify/filter findings
 	LLMModel   string // Override OpenAI model (default: gpt-4o-mini)
 	RootDir    string // Project root for file reading
+
+	// BaseGraph is the graph analyzed at the diff base ref (enables TA013 and TA036)
+	BaseGraph *analyzer.TemporalGraph
+
+	// RequiredAnnotations lists doc comment annotations nodes must declare (enables TA037)
+	RequiredAnnotations []AnnotationRequirement
+
+	// BaseContracts and Contracts are the workflow contracts at the diff base ref and HEAD (enables TA038)
+	BaseContracts *contracts.Document
+	Contracts     *contracts.Document
+
+	// DedicatedTypes are type name globs for dedicated workflow input/output types (TA039)
+	DedicatedTypes []string
+
+	// Serialization are the workflow and activity payloads the data converter can't
+	// serialize (enables TA042)
+	Serialization []analyzer.SerializationFinding
+
+	// Workflowcheck is an imported workflowcheck configuration whose decls and skipped
+	// packages suppress determinism issues; WorkflowcheckFindings enables TA009
+	Workflowcheck         *WorkflowcheckConfig
+	WorkflowcheckFindings []WorkflowcheckFinding
+
+	// Profiles adjust severities per directory; the first mapping matching an issue's file applies
+	Profiles []ProfileMapping
+
+	// LongRunning configures which activities TA003 considers long-running
+	LongRunning LongRunningConfig
+
+	// Coverage adds the coverage of each enabled rule to the result
+	Coverage bool
+
+	// MinConfidence skips issues on nodes detected with a lower confidence, such as call
+	// targets known only by name (empty means medium)
+	MinConfidence string
+
+	// LintTests reports issues on nodes declared in test files, which are skipped by default
+	LintTests bool
 }
 
 // Thresholds contains configurable thresholds for various rules.
@@ -34,6 +83,9 @@ type Thresholds struct {
 	MaxFanOut          int `json:"maxFanOut"`
 	MaxCallDepth       int `json:"maxCallDepth"`
 	VersioningRequired int `json:"versioningRequired"` // Activities count to require versioning
+	UntestedComplexity int `json:"untestedComplexity"` // Temporal operations count to require a workflow test
+	// TimeoutOutlierFactor is how many times off its package median an activity timeout must be for TA023
+	TimeoutOutlierFactor int `json:"timeoutOutlierFactor"`
 }
 
 // DefaultConfig returns a default linter configuration.
@@ -45,9 +97,11 @@ func DefaultConfig() *Config {
 		FailOnWarning: false,
 		MaxIssues:     0, // Unlimited
 		Thresholds: Thresholds{
-			MaxFanOut:          15,
-			MaxCallDepth:       10,
-			VersioningRequired: 5,
+			MaxFanOut:            15,
+			MaxCallDepth:         10,
+			VersioningRequired:   5,
+			UntestedComplexity:   5,
+			TimeoutOutlierFactor: 50,
 		},
 	}
 }
@@ -68,6 +122,10 @@ type Result struct {
 	InfoCount  int     `json:"infoCount"`
 	TotalNodes int     `json:"totalNodes"`
 	ExitCode   int     `json:"exitCode"`
+	// Coverage is set when Config.Coverage is enabled
+	Coverage []RuleCoverage `json:"coverage,omitempty"`
+	// Engine is the analyzer and rule set that produced the result
+	Engine *analyzer.EngineInfo `json:"engine,omitempty"`
 }
 
 // Passed returns true if the lint run passed (no errors, and no warnings if strict).
@@ -81,6 +139,15 @@ func (r *Result) Passed(strict bool) bool {
 	return true
 }
 
+// engine returns the engine of the result, or the running engine for results that weren't
+// produced by Run.
+func (r *Result) engine() *analyzer.EngineInfo {
+	if r.Engine != nil {
+		return r.Engine
+	}
+	return analyzer.Engine()
+}
+
 // Summary returns a summary string of the results.
 func (r *Result) Summary() string {
 	if r.ErrorCount == 0 && r.WarnCount == 0 && r.InfoCount == 0 {
@@ -89,11 +156,25 @@ func (r *Result) Summary() string {
 	return ""
 }
 
+// computedValue matches the values computed into issue messages, such as counts, depths and
+// durations: the tokens starting with a digit, which no identifier does.
+var computedValue = regexp.MustCompile(`\b\d[\w.]*`)
+
+// IssueKey identifies an issue across lint runs and checkouts, for comparing a run with a
+// baseline and for tracker tickets. It is made of the rule, node and message, ignoring the
+// file path and line, and with the computed values of the message masked, so an issue keeps
+// its key when the code moves or a count or duration it reports changes.
+func IssueKey(issue Issue) string {
+	return issue.RuleID + "\x00" + issue.NodeName + "\x00" + computedValue.ReplaceAllString(issue.Message, "#")
+}
+
 // Linter orchestrates lint rule execution.
 type Linter struct {
 	config *Config
 	rules  []Rule
 	llm    *LLMEnhancer
+	// sources caches source file lines read for workflowcheck ignore comments
+	sources map[string][]string
 }
 
 // NewLinter creates a new linter with the given configuration.
@@ -103,8 +184,9 @@ func NewLinter(cfg *Config) *Linter {
 	}
 
 	l := &Linter{
-		config: cfg,
-		rules:  make([]Rule, 0),
+		config:  cfg,
+		rules:   make([]Rule, 0),
+		sources: make(map[string][]string),
 	}
 
 	// Initialize LLM enhancer if enabled
@@ -125,33 +207,59 @@ func NewLinter(cfg *Config) *Linter {
 
 // registerRules registers all available lint rules.
 func (l *Linter) registerRules() {
-	// Reliability Rules (TA001-TA004)
+	// Reliability Rules (TA001-TA009)
 	l.rules = append(l.rules, &ActivityUnlimitedRetryRule{})
 	l.rules = append(l.rules, &ActivityWithoutTimeoutRule{})
-	l.rules = append(l.rules, &LongRunningActivityWithoutHeartbeatRule{})
+	l.rules = append(l.rules, NewLongRunningActivityWithoutHeartbeatRule(l.config.LongRunning))
 	l.rules = append(l.rules, &ChildWorkflowUnlimitedRetryRule{})
+	l.rules = append(l.rules, &SleepBlocksSignalsRule{})
+	l.rules = append(l.rules, &BlockingSignalReceiveRule{})
+	l.rules = append(l.rules, &WorkflowDirectLoggingRule{})
+	l.rules = append(l.rules, &WorkflowDirectMetricsRule{})
+	l.rules = append(l.rules, NewWorkflowcheckRule(l.config.WorkflowcheckFindings, l.config.Workflowcheck))
 
-	// Structural Rules (TA010-TA011)
+	// Structural Rules (TA010-TA017)
 	l.rules = append(l.rules, &CircularDependencyRule{})
 	l.rules = append(l.rules, &OrphanNodeRule{})
-
-	// Performance Rules (TA020-TA021)
+	l.rules = append(l.rules, &AmbiguousCallTargetRule{})
+	l.rules = append(l.rules, NewDeprecatedCallRule(l.config.BaseGraph))
+	l.rules = append(l.rules, &UnreceivedSignalChannelRule{})
+	l.rules = append(l.rules, &UnusedMessageHandlerRule{})
+	l.rules = append(l.rules, &SignalToUnhandledWorkflowRule{})
+	l.rules = append(l.rules, &DuplicateMessageHandlerRule{})
+
+	// Performance Rules (TA020-TA023)
 	l.rules = append(l.rules, NewHighFanOutRule(l.config.Thresholds.MaxFanOut))
 	l.rules = append(l.rules, NewDeepCallChainRule(l.config.Thresholds.MaxCallDepth))
+	l.rules = append(l.rules, &QueueStarvationRule{})
+	l.rules = append(l.rules, NewTimeoutOutlierRule(l.config.Thresholds.TimeoutOutlierFactor))
 
-	// Maintenance Rules (TA030-TA034)
+	// Maintenance Rules (TA030-TA039)
 	l.rules = append(l.rules, NewWorkflowWithoutVersioningRule(l.config.Thresholds.VersioningRequired))
 	l.rules = append(l.rules, &SignalWithoutHandlerRule{})
 	l.rules = append(l.rules, &QueryWithoutReturnRule{})
 	l.rules = append(l.rules, &ContinueAsNewWithoutConditionRule{})
 	l.rules = append(l.rules, &ConsiderQueryHandlerRule{})
+	l.rules = append(l.rules, NewUntestedWorkflowRule(l.config.Thresholds.UntestedComplexity))
+	l.rules = append(l.rules, NewUnversionedWorkflowChangeRule(l.config.BaseGraph))
+	l.rules = append(l.rules, NewMissingAnnotationRule(l.config.RequiredAnnotations))
+	l.rules = append(l.rules, NewContractDriftRule(l.config.BaseContracts, l.config.Contracts))
+	l.rules = append(l.rules, NewWorkflowTypeHygieneRule(l.config.Contracts, l.config.DedicatedTypes))
 
 	// Type Safety Rules (TA040+)
 	l.rules = append(l.rules, &ArgumentsMismatchRule{})
+	l.rules = append(l.rules, &ActivityResultUnusedRule{})
+	l.rules = append(l.rules, NewUnserializablePayloadRule(l.config.Serialization))
+	l.rules = append(l.rules, &ChildWorkflowActivityContextRule{})
+	l.rules = append(l.rules, &ActivityCalledDirectlyRule{})
+	l.rules = append(l.rules, &WorkflowContextEscapeRule{})
+	l.rules = append(l.rules, &TaskQueueWithoutWorkerRule{})
 }
 
 // isRuleEnabled checks if a rule should be executed.
-func (l *Linter) isRuleEnabled(ruleID string) bool {
+func (l *Linter) isRuleEnabled(rule Rule) bool {
+	ruleID := rule.ID()
+
 	// Check if explicitly disabled
 	for _, disabled := range l.config.DisabledRules {
 		if disabled == ruleID {
@@ -159,6 +267,10 @@ func (l *Linter) isRuleEnabled(ruleID string) bool {
 		}
 	}
 
+	if !l.config.selected(rule) {
+		return false
+	}
+
 	// If specific rules are enabled, check if this one is in the list
 	if len(l.config.EnabledRules) > 0 {
 		for _, enabled := range l.config.EnabledRules {
@@ -177,12 +289,42 @@ func (l *Linter) shouldReport(issue Issue) bool {
 	return issue.Severity.Level() >= l.config.MinSeverity.Level()
 }
 
+// belowConfidence reports whether an issue is about a node detected with less than the
+// configured confidence. Issues located at call sites in other files, such as a missing
+// timeout on a call to the node, are still reported.
+func (l *Linter) belowConfidence(issue Issue, graph *analyzer.TemporalGraph) bool {
+	node, ok := graph.Nodes[issue.NodeName]
+	if !ok || (issue.FilePath != "" && issue.FilePath != node.FilePath) {
+		return false
+	}
+	min := l.config.MinConfidence
+	if min == "" {
+		min = analyzer.ConfidenceMedium
+	}
+	return !node.ConfidenceAtLeast(min)
+}
+
+// fromTestFile reports whether an issue is about a node declared in a test file while those
+// are skipped. As with belowConfidence, issues located in other files are still reported.
+func (l *Linter) fromTestFile(issue Issue, graph *analyzer.TemporalGraph) bool {
+	if l.config.LintTests {
+		return false
+	}
+	node, ok := graph.Nodes[issue.NodeName]
+	if !ok || (issue.FilePath != "" && issue.FilePath != node.FilePath) {
+		return false
+	}
+	return node.TestOrigin
+}
+
 // Run executes all enabled lint rules against the graph.
 func (l *Linter) Run(ctx context.Context, graph *analyzer.TemporalGraph) *Result {
 	result := &Result{
 		Issues:     make([]Issue, 0),
 		TotalNodes: len(graph.Nodes),
+		Engine:     analyzer.Engine(),
 	}
+	result.Engine.RuleSet = l.RuleSet()
 
 	// Collect all issues from rules first
 	var allIssues []Issue
@@ -195,15 +337,20 @@ func (l *Linter) Run(ctx context.Context, graph *analyzer.TemporalGraph) *Result
 		default:
 		}
 
-		if !l.isRuleEnabled(rule.ID()) {
+		if !l.isRuleEnabled(rule) {
 			continue
 		}
 
 		issues := rule.Check(ctx, graph)
 		for _, issue := range issues {
-			if !l.shouldReport(issue) {
+			if l.workflowcheckSuppressed(issue, graph) || l.belowConfidence(issue, graph) || l.fromTestFile(issue, graph) {
 				continue
 			}
+			issue, ok := l.profileFor(issue, graph).apply(issue)
+			if !ok || !l.shouldReport(issue) {
+				continue
+			}
+			issue.DocURL = DocURL(issue.RuleID)
 			allIssues = append(allIssues, issue)
 		}
 	}
@@ -244,6 +391,10 @@ func (l *Linter) Run(ctx context.Context, graph *analyzer.TemporalGraph) *Result
 		return result.Issues[i].LineNumber < result.Issues[j].LineNumber
 	})
 
+	if l.config.Coverage {
+		result.Coverage = l.Coverage(graph)
+	}
+
 	// Determine exit code
 	if result.ErrorCount > 0 {
 		result.ExitCode = 1
@@ -254,6 +405,20 @@ func (l *Linter) Run(ctx context.Context, graph *analyzer.TemporalGraph) *Result
 	return result
 }
 
+// RuleSet fingerprints the enabled rules and their severities. Lint results of two runs
+// with different fingerprints may differ because of the rules rather than the code.
+func (l *Linter) RuleSet() string {
+	var lines []string
+	for _, rule := range l.rules {
+		if l.isRuleEnabled(rule) {
+			lines = append(lines, fmt.Sprintf("%s %s %s", rule.ID(), rule.Name(), rule.Severity()))
+		}
+	}
+	sort.Strings(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:6])
+}
+
 // ListRules returns all available rules.
 func (l *Linter) ListRules() []RuleInfo {
 	info := make([]RuleInfo, 0, len(l.rules))
@@ -263,8 +428,10 @@ func (l *Linter) ListRules() []RuleInfo {
 			Name:        rule.Name(),
 			Category:    rule.Category(),
 			Severity:    rule.Severity(),
+			Tags:        RuleTags(rule.ID()),
 			Description: rule.Description(),
-			Enabled:     l.isRuleEnabled(rule.ID()),
+			Enabled:     l.isRuleEnabled(rule),
+			DocURL:      DocURL(rule.ID()),
 		})
 	}
 	return info
@@ -276,8 +443,8 @@ type RuleInfo struct {
 	Name        string   `json:"name"`
 	Category    Category `json:"category"`
 	Severity    Severity `json:"severity"`
+	Tags        []string `json:"tags,omitempty"`
 	Description string   `json:"description"`
 	Enabled     bool     `json:"enabled"`
+	DocURL      string   `json:"docUrl"`
 }
-
-
@@ -2,6 +2,7 @@ package lint
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
@@ -84,6 +85,23 @@ func TestLinterRun(t *testing.T) {
 	}
 }
 
+func TestLinterRuleSet(t *testing.T) {
+	all := NewLinter(nil)
+	result := all.Run(context.Background(), &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{}})
+	if result.Engine == nil || result.Engine.RuleSet != all.RuleSet() || result.Engine.GraphSchema != analyzer.GraphSchemaVersion {
+		t.Errorf("Engine = %+v, want the rule set %s", result.Engine, all.RuleSet())
+	}
+
+	cfg := DefaultConfig()
+	cfg.DisabledRules = []string{"TA011"}
+	if NewLinter(cfg).RuleSet() == all.RuleSet() {
+		t.Error("Expected disabling a rule to change the rule set fingerprint")
+	}
+	if NewLinter(nil).RuleSet() != all.RuleSet() {
+		t.Error("Expected the same rules to have the same fingerprint")
+	}
+}
+
 func TestLinterRunWithIssues(t *testing.T) {
 	// Create graph with a workflow calling an activity without retry policy
 	graph := &analyzer.TemporalGraph{
@@ -120,6 +138,78 @@ func TestLinterRunWithIssues(t *testing.T) {
 	}
 }
 
+func TestLinterSkipsLowConfidenceNodes(t *testing.T) {
+	graph := &analyzer.TemporalGraph{Nodes: make(map[string]*analyzer.TemporalNode)}
+	for name, confidence := range map[string]string{"OrderWorkflow": analyzer.ConfidenceHigh, "GuessedWorkflow": analyzer.ConfidenceLow} {
+		graph.Nodes[name] = &analyzer.TemporalNode{
+			Name:                name,
+			Type:                "workflow",
+			FilePath:            name + ".go",
+			DetectionConfidence: confidence,
+			CallSites: []analyzer.CallSite{
+				{TargetName: "A", CallType: "activity", FilePath: name + ".go"},
+				{TargetName: "B", CallType: "activity", FilePath: name + ".go"},
+				{TargetName: "C", CallType: "activity", FilePath: name + ".go"},
+			},
+		}
+	}
+
+	run := func(minConfidence string) map[string]int {
+		cfg := DefaultConfig()
+		cfg.EnabledRules = []string{"TA020"}
+		cfg.Thresholds.MaxFanOut = 2
+		cfg.MinConfidence = minConfidence
+		issues := make(map[string]int)
+		for _, issue := range NewLinter(cfg).Run(context.Background(), graph).Issues {
+			issues[issue.NodeName]++
+		}
+		return issues
+	}
+
+	issues := run("")
+	if issues["OrderWorkflow"] == 0 {
+		t.Error("Expected issues on the high-confidence workflow")
+	}
+	if issues["GuessedWorkflow"] != 0 {
+		t.Errorf("Expected no issues on the low-confidence workflow, got %d", issues["GuessedWorkflow"])
+	}
+	if issues := run(analyzer.ConfidenceLow); issues["GuessedWorkflow"] == 0 {
+		t.Error("Expected issues on the low-confidence workflow with MinConfidence low")
+	}
+}
+
+func TestLinterSkipsTestOriginNodes(t *testing.T) {
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", FilePath: "orders.go"},
+		"FakeWorkflow":  {Name: "FakeWorkflow", Type: "workflow", FilePath: "orders_test.go", TestOrigin: true},
+	}}
+	for _, node := range graph.Nodes {
+		for _, target := range []string{"A", "B", "C"} {
+			node.CallSites = append(node.CallSites, analyzer.CallSite{TargetName: target, CallType: "activity", FilePath: node.FilePath})
+		}
+	}
+
+	run := func(lintTests bool) map[string]int {
+		cfg := DefaultConfig()
+		cfg.EnabledRules = []string{"TA020"}
+		cfg.Thresholds.MaxFanOut = 2
+		cfg.LintTests = lintTests
+		issues := make(map[string]int)
+		for _, issue := range NewLinter(cfg).Run(context.Background(), graph).Issues {
+			issues[issue.NodeName]++
+		}
+		return issues
+	}
+
+	issues := run(false)
+	if issues["OrderWorkflow"] == 0 || issues["FakeWorkflow"] != 0 {
+		t.Errorf("Expected issues on OrderWorkflow only, got %v", issues)
+	}
+	if issues := run(true); issues["FakeWorkflow"] == 0 {
+		t.Error("Expected issues on FakeWorkflow with LintTests")
+	}
+}
+
 func TestLinterRunContextCancellation(t *testing.T) {
 	graph := &analyzer.TemporalGraph{
 		Nodes: map[string]*analyzer.TemporalNode{
@@ -145,6 +235,8 @@ func TestLinterIsRuleEnabled(t *testing.T) {
 		name          string
 		enabledRules  []string
 		disabledRules []string
+		categories    []Category
+		tags          []string
 		ruleID        string
 		want          bool
 	}{
@@ -171,6 +263,44 @@ func TestLinterIsRuleEnabled(t *testing.T) {
 			ruleID:       "TA001",
 			want:         true,
 		},
+		{
+			name:       "rule in selected category",
+			categories: []Category{CategoryPerformance, CategoryReliability},
+			ruleID:     "TA002",
+			want:       true,
+		},
+		{
+			name:       "rule outside selected categories",
+			categories: []Category{CategoryPerformance},
+			ruleID:     "TA002",
+			want:       false,
+		},
+		{
+			name:   "rule with a selected tag",
+			tags:   []string{"timeouts", "determinism"},
+			ruleID: "TA007",
+			want:   true,
+		},
+		{
+			name:   "rule without a selected tag",
+			tags:   []string{"determinism"},
+			ruleID: "TA002",
+			want:   false,
+		},
+		{
+			name:       "category and tag both required",
+			categories: []Category{CategoryMaintenance},
+			tags:       []string{"determinism"},
+			ruleID:     "TA007",
+			want:       false,
+		},
+		{
+			name:          "disabled rule with a selected tag",
+			tags:          []string{"determinism"},
+			disabledRules: []string{"TA009"},
+			ruleID:        "TA009",
+			want:          false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -178,9 +308,17 @@ func TestLinterIsRuleEnabled(t *testing.T) {
 			cfg := DefaultConfig()
 			cfg.EnabledRules = tt.enabledRules
 			cfg.DisabledRules = tt.disabledRules
+			cfg.Categories = tt.categories
+			cfg.Tags = tt.tags
 			l := NewLinter(cfg)
 
-			got := l.isRuleEnabled(tt.ruleID)
+			var rule Rule
+			for _, r := range l.rules {
+				if r.ID() == tt.ruleID {
+					rule = r
+				}
+			}
+			got := l.isRuleEnabled(rule)
 			if got != tt.want {
 				t.Errorf("isRuleEnabled(%q) = %v, want %v", tt.ruleID, got, tt.want)
 			}
@@ -261,6 +399,13 @@ func TestLinterListRules(t *testing.T) {
 		if rule.Description == "" {
 			t.Error("Rule Description should not be empty")
 		}
+		// --lint-rules --plain prints descriptions verbatim
+		for _, r := range rule.Name + rule.Description {
+			if r > 127 {
+				t.Errorf("Rule %s description contains non-ASCII %q", rule.ID, r)
+				break
+			}
+		}
 	}
 }
 
@@ -461,3 +606,49 @@ func TestLinterSortIssues(t *testing.T) {
 	}
 }
 
+func TestRuleTags(t *testing.T) {
+	// Every rule is tagged, so tag selections can reach it
+	for _, rule := range NewLinter(DefaultConfig()).ListRules() {
+		if len(rule.Tags) == 0 {
+			t.Errorf("Rule %s has no tags", rule.ID)
+		}
+	}
+
+	if _, err := ParseTags([]string{"determinism", "timeouts"}); err != nil {
+		t.Errorf("ParseTags failed: %v", err)
+	}
+	if _, err := ParseTags([]string{"determinsm"}); err == nil || !strings.Contains(err.Error(), "valid: correctness") {
+		t.Errorf("ParseTags should reject unknown tags and list the valid ones, got %v", err)
+	}
+	categories, err := ParseCategories([]string{"reliability", "security"})
+	if err != nil || len(categories) != 2 || categories[1] != CategorySecurity {
+		t.Errorf("ParseCategories = %v, %v; want reliability and security", categories, err)
+	}
+	if _, err := ParseCategories([]string{"style"}); err == nil {
+		t.Error("ParseCategories should reject unknown categories")
+	}
+}
+
+func TestIssueKey(t *testing.T) {
+	issue := Issue{RuleID: "TA010", NodeName: "OrderWorkflow", Message: "workflow 'OrderWorkflow' has 12 direct calls (threshold: 10)", FilePath: "/a/orders.go", LineNumber: 3}
+	moved := issue
+	moved.FilePath, moved.LineNumber = "/b/orders.go", 30
+	grown := issue
+	grown.Message = "workflow 'OrderWorkflow' has 15 direct calls (threshold: 10)"
+	other := issue
+	other.Message = "workflow 'OrderWorkflowV2' has 12 direct calls (threshold: 10)"
+
+	if IssueKey(issue) != IssueKey(moved) {
+		t.Error("IssueKey should not depend on the file location")
+	}
+	if IssueKey(issue) != IssueKey(grown) {
+		t.Error("IssueKey should not depend on the counts in the message")
+	}
+	if IssueKey(issue) == IssueKey(other) {
+		t.Error("IssueKey should depend on the names in the message")
+	}
+	duration := Issue{RuleID: "TA040", NodeName: "Charge", Message: "StartToCloseTimeout of activity 'Charge' is 2h30m0s, 12.5x the package median of 12m0s"}
+	if got := IssueKey(duration); !strings.HasSuffix(got, "is #, # the package median of #") {
+		t.Errorf("IssueKey() = %q, want the durations and ratio masked", got)
+	}
+}
@@ -546,7 +546,7 @@ func (e *LLMEnhancer) getCodeContext(graph *analyzer.TemporalGraph, issue Issue)
 
 	// Add metadata from the graph
 	for _, node := range graph.Nodes {
-		if node.Name == issue.NodeName || strings.HasSuffix(node.Name, "."+issue.NodeName) {
+		if node.ID() == issue.NodeName || strings.HasSuffix(node.ID(), "."+issue.NodeName) {
 			ctx = append(ctx, fmt.Sprintf("Node: %s (%s)", node.Name, node.Type))
 			ctx = append(ctx, fmt.Sprintf("File: %s:%d", node.FilePath, node.LineNumber))
 
@@ -0,0 +1,175 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxNotifiedIssues limits how many errors and files are listed in a notification.
+const maxNotifiedIssues = 5
+
+// WebhookPayload is a Slack-compatible incoming webhook message.
+type WebhookPayload struct {
+	Text string `json:"text"`
+}
+
+// WebhookNotifier posts lint summaries to a Slack-compatible incoming webhook.
+type WebhookNotifier struct {
+	url        string
+	rootDir    string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a notifier posting to url. File paths are shown relative to rootDir.
+func NewWebhookNotifier(url, rootDir string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:     url,
+		rootDir: rootDir,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Notify posts a summary of the lint result. If baseline is not nil, only errors that are
+// not present in the baseline are listed as new.
+func (n *WebhookNotifier) Notify(ctx context.Context, result, baseline *Result) error {
+	body, err := json.Marshal(n.Payload(result, baseline))
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Payload builds the webhook message for a lint result.
+func (n *WebhookNotifier) Payload(result, baseline *Result) WebhookPayload {
+	var sb strings.Builder
+
+	status := ":white_check_mark: *Temporal lint passed*"
+	if result.ExitCode != 0 {
+		status = ":x: *Temporal lint failed*"
+	}
+	fmt.Fprintf(&sb, "%s: %d errors, %d warnings, %d info (%d nodes)\n",
+		status, result.ErrorCount, result.WarnCount, result.InfoCount, result.TotalNodes)
+
+	newErrs := n.newErrors(result, baseline)
+	if len(newErrs) > 0 {
+		title := "Errors"
+		if baseline != nil {
+			title = "New errors"
+		}
+		fmt.Fprintf(&sb, "\n*%s* (%d):\n", title, len(newErrs))
+		for i, issue := range newErrs {
+			if i == maxNotifiedIssues {
+				fmt.Fprintf(&sb, "• …and %d more\n", len(newErrs)-maxNotifiedIssues)
+				break
+			}
+			fmt.Fprintf(&sb, "• `%s` %s%s\n", issue.RuleID, n.location(issue), issue.Message)
+		}
+	}
+
+	if files := n.topFiles(result); len(files) > 0 {
+		sb.WriteString("\n*Top files:*\n")
+		for _, f := range files {
+			fmt.Fprintf(&sb, "• `%s`: %d issues\n", f.path, f.count)
+		}
+	}
+
+	return WebhookPayload{Text: strings.TrimRight(sb.String(), "\n")}
+}
+
+// newErrors returns the errors of result that are not in baseline.
+// Issues are matched by IssueKey since file paths differ between checkouts.
+func (n *WebhookNotifier) newErrors(result, baseline *Result) []Issue {
+	known := make(map[string]bool)
+	if baseline != nil {
+		for _, issue := range baseline.Issues {
+			known[IssueKey(issue)] = true
+		}
+	}
+
+	var newErrs []Issue
+	for _, issue := range result.Issues {
+		if issue.Severity == SeverityError && !known[IssueKey(issue)] {
+			newErrs = append(newErrs, issue)
+		}
+	}
+	return newErrs
+}
+
+// fileCount is the number of issues reported in a file.
+type fileCount struct {
+	path  string
+	count int
+}
+
+// topFiles returns the files with the most issues.
+func (n *WebhookNotifier) topFiles(result *Result) []fileCount {
+	counts := make(map[string]int)
+	for _, issue := range result.Issues {
+		if issue.FilePath != "" {
+			counts[n.relPath(issue.FilePath)]++
+		}
+	}
+
+	files := make([]fileCount, 0, len(counts))
+	for path, count := range counts {
+		files = append(files, fileCount{path: path, count: count})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].count != files[j].count {
+			return files[i].count > files[j].count
+		}
+		return files[i].path < files[j].path
+	})
+
+	if len(files) > maxNotifiedIssues {
+		files = files[:maxNotifiedIssues]
+	}
+	return files
+}
+
+// location formats the file position of an issue followed by a separator, or "" if it has none.
+func (n *WebhookNotifier) location(issue Issue) string {
+	if issue.FilePath == "" {
+		return ""
+	}
+	if issue.LineNumber > 0 {
+		return fmt.Sprintf("%s:%d: ", n.relPath(issue.FilePath), issue.LineNumber)
+	}
+	return n.relPath(issue.FilePath) + ": "
+}
+
+func (n *WebhookNotifier) relPath(path string) string {
+	if n.rootDir != "" {
+		if rel, err := filepath.Rel(n.rootDir, path); err == nil && filepath.IsLocal(rel) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(path)
+}
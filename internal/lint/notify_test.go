@@ -0,0 +1,98 @@
+package lint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func notifyTestResult() *Result {
+	return &Result{
+		Issues: []Issue{
+			{RuleID: "TA001", Severity: SeverityError, Message: "Activity 'Charge' has unlimited retries", NodeName: "Charge", FilePath: "/repo/payments/charge.go", LineNumber: 12},
+			{RuleID: "TA002", Severity: SeverityError, Message: "Activity 'Ship' has no timeout", NodeName: "Ship", FilePath: "/repo/shipping/ship.go", LineNumber: 8},
+			{RuleID: "TA031", Severity: SeverityWarning, Message: "Signal without handler", NodeName: "Order", FilePath: "/repo/payments/order.go", LineNumber: 3},
+			{RuleID: "TA030", Severity: SeverityInfo, Message: "Workflow without versioning", NodeName: "Order", FilePath: "/repo/payments/charge.go", LineNumber: 30},
+		},
+		ErrorCount: 2,
+		WarnCount:  1,
+		InfoCount:  1,
+		TotalNodes: 10,
+		ExitCode:   1,
+	}
+}
+
+func TestWebhookPayload(t *testing.T) {
+	n := NewWebhookNotifier("http://example.invalid", "/repo")
+	text := n.Payload(notifyTestResult(), nil).Text
+
+	for _, want := range []string{
+		":x: *Temporal lint failed*: 2 errors, 1 warnings, 1 info (10 nodes)",
+		"*Errors* (2):",
+		"• `TA001` payments/charge.go:12: Activity 'Charge' has unlimited retries",
+		"*Top files:*\n• `payments/charge.go`: 2 issues",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Payload missing %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestWebhookPayloadNewErrors(t *testing.T) {
+	n := NewWebhookNotifier("http://example.invalid", "/repo")
+	baseline := &Result{Issues: []Issue{
+		// Same issue in a different checkout is not new
+		{RuleID: "TA001", Severity: SeverityError, Message: "Activity 'Charge' has unlimited retries", NodeName: "Charge", FilePath: "/tmp/base/payments/charge.go"},
+	}}
+
+	text := n.Payload(notifyTestResult(), baseline).Text
+	if !strings.Contains(text, "*New errors* (1):") || strings.Contains(text, "unlimited retries") {
+		t.Errorf("Expected only the new TA002 error, got:\n%s", text)
+	}
+}
+
+func TestWebhookPayloadPassed(t *testing.T) {
+	n := NewWebhookNotifier("http://example.invalid", "")
+	text := n.Payload(&Result{TotalNodes: 3}, nil).Text
+	if text != ":white_check_mark: *Temporal lint passed*: 0 errors, 0 warnings, 0 info (3 nodes)" {
+		t.Errorf("Unexpected payload for clean run: %q", text)
+	}
+}
+
+func TestWebhookNotify(t *testing.T) {
+	var received WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(server.URL, "/repo")
+	if err := n.Notify(context.Background(), notifyTestResult(), nil); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	if !strings.Contains(received.Text, "Temporal lint failed") {
+		t.Errorf("Unexpected payload received: %q", received.Text)
+	}
+}
+
+func TestWebhookNotifyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(server.URL, "")
+	err := n.Notify(context.Background(), &Result{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Expected 403 error, got %v", err)
+	}
+}
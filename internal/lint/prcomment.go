@@ -0,0 +1,318 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// =============================================================================
+// Pull Request Comment Formatter
+// =============================================================================
+
+// PRCommentMarker starts every pull request comment, so a CI step can find the comment it
+// posted before and update it in place instead of adding a new one.
+const PRCommentMarker = "<!-- temporal-analyzer:pr-comment -->"
+
+// maxPRCommentRows limits how many findings and graph changes a comment lists, keeping it
+// below the size limits of code hosts.
+const maxPRCommentRows = 50
+
+// PRCommentFormatter outputs one markdown block for a pull request comment: the graph
+// changes and the new lint findings between the base and head of the pull request, in
+// collapsible sections.
+type PRCommentFormatter struct {
+	// Graph and BaseGraph are the head and base graphs; without a BaseGraph the comment
+	// has no graph changes
+	Graph     *analyzer.TemporalGraph
+	BaseGraph *analyzer.TemporalGraph
+	// Baseline is the lint result of the base; without one every finding is new
+	Baseline *Result
+	// RootDir is stripped from file paths when set
+	RootDir string
+	// Plain leaves out emoji
+	Plain bool
+}
+
+// graphChange is a node or call added or removed between the base and head graphs.
+type graphChange struct {
+	added bool
+	text  string
+}
+
+func (f *PRCommentFormatter) Format(result *Result, w io.Writer) error {
+	known := make(map[string]bool)
+	if f.Baseline != nil {
+		for _, issue := range f.Baseline.Issues {
+			known[IssueKey(issue)] = true
+		}
+	}
+	var newIssues []Issue
+	current := make(map[string]bool, len(result.Issues))
+	for _, issue := range result.Issues {
+		current[IssueKey(issue)] = true
+		if !known[IssueKey(issue)] {
+			newIssues = append(newIssues, issue)
+		}
+	}
+	resolved := 0
+	if f.Baseline != nil {
+		for _, issue := range f.Baseline.Issues {
+			if !current[IssueKey(issue)] {
+				resolved++
+			}
+		}
+	}
+
+	fprintln(w, PRCommentMarker)
+	fprintf(w, "### %s\n\n", f.headline(result, newIssues))
+
+	if f.Graph != nil {
+		f.writeCounts(w, result)
+	}
+
+	if f.Graph != nil && f.BaseGraph != nil {
+		changes := diffGraphs(f.BaseGraph, f.Graph)
+		if len(changes) == 0 {
+			fprintf(w, "No workflows, activities or calls were added or removed.\n\n")
+		} else {
+			// A diff block colors additions and removals
+			fprintf(w, "<details>\n<summary>Graph changes (%d)</summary>\n\n```diff\n", len(changes))
+			for i, c := range changes {
+				if i == maxPRCommentRows {
+					fprintf(w, "  ...and %d more\n", len(changes)-maxPRCommentRows)
+					break
+				}
+				sign := "-"
+				if c.added {
+					sign = "+"
+				}
+				fprintf(w, "%s %s\n", sign, c.text)
+			}
+			fprintf(w, "```\n\n</details>\n\n")
+		}
+	}
+
+	title := "Findings"
+	if f.Baseline != nil {
+		title = "New findings"
+	}
+	if len(newIssues) == 0 {
+		fprintf(w, "No %s.\n", strings.ToLower(title))
+	} else {
+		fprintf(w, "<details%s>\n<summary>%s (%d)</summary>\n\n", f.openAttr(newIssues), title, len(newIssues))
+		fprintln(w, "| Severity | Rule | Location | Message |")
+		fprintln(w, "|---|---|---|---|")
+		for i, issue := range newIssues {
+			if i == maxPRCommentRows {
+				fprintf(w, "\n...and %d more\n", len(newIssues)-maxPRCommentRows)
+				break
+			}
+			rule := "`" + issue.RuleID + "`"
+			if issue.DocURL != "" {
+				rule = fmt.Sprintf("[%s](%s)", rule, issue.DocURL)
+			}
+			fprintf(w, "| %s | %s | %s | %s |\n", f.severity(issue.Severity), rule, f.location(issue), escapeCell(issue.Message))
+		}
+		fprintf(w, "\n</details>\n")
+	}
+	if resolved > 0 {
+		fprintf(w, "\n%s fixed since the base.\n", plural(resolved, "finding"))
+	}
+	return nil
+}
+
+// headline summarizes the result: whether lint passed and how many findings are new.
+func (f *PRCommentFormatter) headline(result *Result, newIssues []Issue) string {
+	var errors, warnings int
+	for _, issue := range newIssues {
+		switch issue.Severity {
+		case SeverityError:
+			errors++
+		case SeverityWarning:
+			warnings++
+		}
+	}
+	status := ":white_check_mark: Temporal analysis passed"
+	if f.Plain {
+		status = "Temporal analysis passed"
+	}
+	if result.ExitCode != 0 {
+		status = ":x: Temporal analysis failed"
+		if f.Plain {
+			status = "Temporal analysis failed"
+		}
+	}
+	qualifier := ""
+	if f.Baseline != nil {
+		qualifier = "new "
+	}
+	return fmt.Sprintf("%s: %s, %s", status, plural(errors, qualifier+"error"), plural(warnings, qualifier+"warning"))
+}
+
+// writeCounts writes a table of node counts and lint findings, with the change from the
+// base when there is one.
+func (f *PRCommentFormatter) writeCounts(w io.Writer, result *Result) {
+	counts := func(g *analyzer.TemporalGraph) []int {
+		return []int{g.Stats.TotalWorkflows, g.Stats.TotalActivities, g.Stats.TotalSignals,
+			g.Stats.TotalQueries, g.Stats.TotalUpdates, g.Stats.DistinctConnections}
+	}
+	names := []string{"Workflows", "Activities", "Signals", "Queries", "Updates", "Calls"}
+	head := append(counts(f.Graph), result.ErrorCount, result.WarnCount)
+	names = append(names, "Errors", "Warnings")
+
+	var base []int
+	if f.BaseGraph != nil {
+		base = counts(f.BaseGraph)
+		if f.Baseline != nil {
+			base = append(base, f.Baseline.ErrorCount, f.Baseline.WarnCount)
+		}
+	}
+
+	if base == nil {
+		fprintln(w, "| | Count |")
+		fprintln(w, "|---|---:|")
+	} else {
+		fprintln(w, "| | Base | Head | Change |")
+		fprintln(w, "|---|---:|---:|---:|")
+	}
+	for i, name := range names {
+		switch {
+		case base == nil:
+			fprintf(w, "| %s | %d |\n", name, head[i])
+		case i < len(base):
+			fprintf(w, "| %s | %d | %d | %s |\n", name, base[i], head[i], formatDelta(head[i]-base[i]))
+		default:
+			fprintf(w, "| %s | | %d | |\n", name, head[i])
+		}
+	}
+	fprintln(w)
+}
+
+// openAttr expands the findings section when there are new errors.
+func (f *PRCommentFormatter) openAttr(issues []Issue) string {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return " open"
+		}
+	}
+	return ""
+}
+
+func (f *PRCommentFormatter) severity(s Severity) string {
+	if f.Plain {
+		return string(s)
+	}
+	switch s {
+	case SeverityError:
+		return ":x: error"
+	case SeverityWarning:
+		return ":warning: warning"
+	}
+	return ":information_source: " + string(s)
+}
+
+// location formats the file position of an issue relative to RootDir, or its node name.
+func (f *PRCommentFormatter) location(issue Issue) string {
+	if issue.FilePath == "" {
+		if issue.NodeName != "" {
+			return "`" + issue.NodeName + "`"
+		}
+		return ""
+	}
+	path := filepath.ToSlash(issue.FilePath)
+	if f.RootDir != "" {
+		if rel, err := filepath.Rel(f.RootDir, issue.FilePath); err == nil && filepath.IsLocal(rel) {
+			path = filepath.ToSlash(rel)
+		}
+	}
+	if issue.LineNumber > 0 {
+		return fmt.Sprintf("`%s:%d`", path, issue.LineNumber)
+	}
+	return "`" + path + "`"
+}
+
+// diffGraphs returns the nodes, then the calls, added or removed between two graphs, with
+// the removals of each first. Helpers and unresolved call targets are left out.
+func diffGraphs(base, head *analyzer.TemporalGraph) []graphChange {
+	baseNodes, baseCalls := graphEntries(base)
+	headNodes, headCalls := graphEntries(head)
+
+	var changes []graphChange
+	for _, entries := range []struct {
+		base, head map[string]bool
+	}{{baseNodes, headNodes}, {baseCalls, headCalls}} {
+		var removed, added []string
+		for entry := range entries.base {
+			if !entries.head[entry] {
+				removed = append(removed, entry)
+			}
+		}
+		for entry := range entries.head {
+			if !entries.base[entry] {
+				added = append(added, entry)
+			}
+		}
+		sort.Strings(removed)
+		sort.Strings(added)
+		for _, text := range removed {
+			changes = append(changes, graphChange{text: text})
+		}
+		for _, text := range added {
+			changes = append(changes, graphChange{added: true, text: text})
+		}
+	}
+	return changes
+}
+
+// graphEntries returns the nodes of a graph, as "workflow Name", and its calls, as
+// "Caller -> Target (call type)".
+func graphEntries(g *analyzer.TemporalGraph) (nodes, calls map[string]bool) {
+	nodes = make(map[string]bool)
+	calls = make(map[string]bool)
+	for _, node := range g.Nodes {
+		if node.Unresolved || node.Type == "helper" {
+			continue
+		}
+		nodes[node.Type+" "+node.Name] = true
+		for _, call := range node.CallSites {
+			if call.CallType == "internal" {
+				continue
+			}
+			entry := node.Name + " -> " + call.TargetName
+			if call.CallType != "" {
+				entry += " (" + call.CallType + ")"
+			}
+			calls[entry] = true
+		}
+	}
+	return nodes, calls
+}
+
+func formatDelta(d int) string {
+	switch {
+	case d > 0:
+		return fmt.Sprintf("+%d", d)
+	case d < 0:
+		return fmt.Sprintf("%d", d)
+	}
+	return "0"
+}
+
+// plural formats a count of things, e.g. "1 error" or "3 errors".
+func plural(n int, thing string) string {
+	if n == 1 {
+		return "1 " + thing
+	}
+	return fmt.Sprintf("%d %ss", n, thing)
+}
+
+// escapeCell escapes pipes and line breaks in a markdown table cell.
+func escapeCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
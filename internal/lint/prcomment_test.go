@@ -0,0 +1,98 @@
+package lint
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func TestPRCommentFormatter(t *testing.T) {
+	base := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{
+				{TargetName: "Charge", CallType: "execute"},
+				{TargetName: "Notify", CallType: "execute"},
+			}},
+			"Charge": {Name: "Charge", Type: "activity"},
+			"Notify": {Name: "Notify", Type: "activity"},
+		},
+		Stats: analyzer.GraphStats{TotalWorkflows: 1, TotalActivities: 2, DistinctConnections: 2},
+	}
+	head := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{
+				{TargetName: "Charge", CallType: "execute"},
+				{TargetName: "format", CallType: "internal"},
+			}},
+			"RefundWorkflow": {Name: "RefundWorkflow", Type: "workflow"},
+			"Charge":         {Name: "Charge", Type: "activity"},
+			"format":         {Name: "format", Type: "helper"},
+		},
+		Stats: analyzer.GraphStats{TotalWorkflows: 2, TotalActivities: 1, DistinctConnections: 1},
+	}
+	known := Issue{RuleID: "TA002", Severity: SeverityWarning, NodeName: "OrderWorkflow", Message: "missing timeout"}
+	baseline := &Result{Issues: []Issue{known, {RuleID: "TA010", Severity: SeverityInfo, NodeName: "Notify", Message: "orphan"}}, WarnCount: 1}
+	result := &Result{
+		Issues: []Issue{known, {
+			RuleID: "TA001", Severity: SeverityError, FilePath: "/repo/refund.go", LineNumber: 7,
+			NodeName: "RefundWorkflow", Message: "no retry policy | set one",
+		}},
+		ErrorCount: 1,
+		WarnCount:  1,
+		ExitCode:   1,
+	}
+
+	var buf bytes.Buffer
+	f := &PRCommentFormatter{Graph: head, BaseGraph: base, Baseline: baseline, RootDir: "/repo"}
+	if err := f.Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, PRCommentMarker+"\n") {
+		t.Errorf("Expected the comment to start with the marker:\n%s", out)
+	}
+	for _, want := range []string{
+		"### :x: Temporal analysis failed: 1 new error, 0 new warnings",
+		"| Workflows | 1 | 2 | +1 |",
+		"| Activities | 2 | 1 | -1 |",
+		"| Errors | 0 | 1 | +1 |",
+		"```diff\n- activity Notify\n+ workflow RefundWorkflow\n- OrderWorkflow -> Notify (execute)\n```",
+		"<details open>\n<summary>New findings (1)</summary>",
+		"| :x: error | `TA001` | `refund.go:7` | no retry policy \\| set one |",
+		"1 finding fixed since the base.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "TA002") || strings.Contains(out, "format") {
+		t.Errorf("Expected known issues and helpers to be left out:\n%s", out)
+	}
+}
+
+func TestPRCommentFormatterPlain(t *testing.T) {
+	result := &Result{Issues: []Issue{{RuleID: "TA002", Severity: SeverityWarning, NodeName: "OrderWorkflow", Message: "missing timeout"}}, WarnCount: 1}
+
+	var buf bytes.Buffer
+	f := &PRCommentFormatter{Plain: true}
+	if err := f.Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"### Temporal analysis passed: 0 errors, 1 warning",
+		"<details>\n<summary>Findings (1)</summary>",
+		"| warning | `TA002` | `OrderWorkflow` | missing timeout |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, ":warning:") || strings.Contains(out, "Graph changes") {
+		t.Errorf("Expected no emoji and no graph changes without graphs:\n%s", out)
+	}
+}
@@ -0,0 +1,83 @@
+package lint
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// Profile adjusts the severity of the issues found in the directories it applies to.
+type Profile string
+
+const (
+	ProfileStrict   Profile = "strict"   // Warnings are reported as errors
+	ProfileDefault  Profile = "default"  // Severities are unchanged
+	ProfileWarnings Profile = "warnings" // Errors are reported as warnings
+	ProfileOff      Profile = "off"      // Issues are not reported
+)
+
+// ProfileMapping applies a profile to the issues of files in packages matching Pattern.
+type ProfileMapping struct {
+	// Pattern is a glob matched against the package directory relative to the root
+	// ("**" matches any number of directories) or against the package name. A pattern of
+	// the form "tag:critical" instead matches the issues of nodes with that overlay tag.
+	Pattern string
+	Profile Profile
+}
+
+// ParseProfileMapping parses a mapping of the form "payments/**=strict".
+func ParseProfileMapping(spec string) (ProfileMapping, error) {
+	pattern, profile, ok := strings.Cut(spec, "=")
+	m := ProfileMapping{Pattern: strings.TrimSpace(pattern), Profile: Profile(strings.TrimSpace(profile))}
+	if !ok || m.Pattern == "" {
+		return m, fmt.Errorf("invalid lint profile mapping %q (expected glob=profile)", spec)
+	}
+	if _, err := path.Match(m.Pattern, ""); err != nil {
+		return m, fmt.Errorf("invalid lint profile pattern %q: %w", m.Pattern, err)
+	}
+	switch m.Profile {
+	case ProfileStrict, ProfileDefault, ProfileWarnings, ProfileOff:
+		return m, nil
+	}
+	return m, fmt.Errorf("invalid lint profile %q in %q (valid: strict, default, warnings, off)", m.Profile, spec)
+}
+
+// apply returns the issue with the severity of the profile, or false if it is not reported.
+func (p Profile) apply(issue Issue) (Issue, bool) {
+	switch {
+	case p == ProfileOff:
+		return issue, false
+	case p == ProfileStrict && issue.Severity == SeverityWarning:
+		issue.Severity = SeverityError
+	case p == ProfileWarnings && issue.Severity == SeverityError:
+		issue.Severity = SeverityWarning
+	}
+	return issue, true
+}
+
+// profileFor returns the profile of the first mapping matching the issue's file or node tags.
+func (l *Linter) profileFor(issue Issue, graph *analyzer.TemporalGraph) Profile {
+	filePath, pkg := issue.FilePath, ""
+	node, ok := graph.Nodes[issue.NodeName]
+	if ok {
+		pkg = node.Package
+		if filePath == "" {
+			filePath = node.FilePath
+		}
+	}
+
+	for _, m := range l.config.Profiles {
+		if tag, isTag := strings.CutPrefix(m.Pattern, "tag:"); isTag {
+			if ok && node.HasTag(tag) {
+				return m.Profile
+			}
+			continue
+		}
+		if filePath != "" && analyzer.MatchesPackagePattern(m.Pattern, l.config.RootDir, filePath, pkg) {
+			return m.Profile
+		}
+	}
+	return ProfileDefault
+}
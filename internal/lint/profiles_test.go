@@ -0,0 +1,122 @@
+package lint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func TestParseProfileMapping(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    ProfileMapping
+		wantErr bool
+	}{
+		{spec: "payments/**=strict", want: ProfileMapping{Pattern: "payments/**", Profile: ProfileStrict}},
+		{spec: " experimental/** = warnings ", want: ProfileMapping{Pattern: "experimental/**", Profile: ProfileWarnings}},
+		{spec: "legacy=off", want: ProfileMapping{Pattern: "legacy", Profile: ProfileOff}},
+		{spec: "tag:critical=strict", want: ProfileMapping{Pattern: "tag:critical", Profile: ProfileStrict}},
+		{spec: "payments/**", wantErr: true},
+		{spec: "=strict", wantErr: true},
+		{spec: "payments/**=lenient", wantErr: true},
+		{spec: "[=strict", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseProfileMapping(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseProfileMapping(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseProfileMapping(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinterProfiles(t *testing.T) {
+	// Each workflow calls two activities without timeouts (errors) and exceeds the fan-out (warning)
+	workflow := func(name, dir string) *analyzer.TemporalNode {
+		file := "/repo/" + dir + "/workflow.go"
+		return &analyzer.TemporalNode{Name: name, Type: "workflow", Package: "pkg", FilePath: file, CallSites: []analyzer.CallSite{
+			{TargetName: name + "A", CallType: "activity", FilePath: file, LineNumber: 10},
+			{TargetName: name + "B", CallType: "activity", FilePath: file, LineNumber: 11},
+		}}
+	}
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"Payments":     workflow("Payments", "services/payments"),
+		"Experimental": workflow("Experimental", "experimental/new"),
+		"Legacy":       workflow("Legacy", "legacy"),
+		"Orders":       workflow("Orders", "orders"),
+	}}
+
+	cfg := DefaultConfig()
+	cfg.EnabledRules = []string{"TA002", "TA020"}
+	cfg.Thresholds.MaxFanOut = 1
+	cfg.RootDir = "/repo"
+	cfg.Profiles = []ProfileMapping{
+		{Pattern: "**/payments/**", Profile: ProfileStrict},
+		{Pattern: "experimental/**", Profile: ProfileWarnings},
+		{Pattern: "legacy", Profile: ProfileOff},
+	}
+	result := NewLinter(cfg).Run(context.Background(), graph)
+
+	counts := make(map[string]map[Severity]int)
+	for _, issue := range result.Issues {
+		dir := issue.FilePath
+		if counts[dir] == nil {
+			counts[dir] = make(map[Severity]int)
+		}
+		counts[dir][issue.Severity]++
+	}
+
+	want := map[string]map[Severity]int{
+		"/repo/services/payments/workflow.go": {SeverityError: 3},
+		"/repo/experimental/new/workflow.go":  {SeverityWarning: 3},
+		"/repo/orders/workflow.go":            {SeverityError: 2, SeverityWarning: 1},
+	}
+	for file, severities := range want {
+		for severity, n := range severities {
+			if counts[file][severity] != n {
+				t.Errorf("%s: %d %s issues, want %d (all: %v)", file, counts[file][severity], severity, n, counts[file])
+			}
+		}
+	}
+	if len(counts["/repo/legacy/workflow.go"]) != 0 {
+		t.Errorf("Issues in directories with the off profile should not be reported, got %v", counts["/repo/legacy/workflow.go"])
+	}
+	if result.ErrorCount != 5 || result.WarnCount != 4 {
+		t.Errorf("ErrorCount = %d, WarnCount = %d; want 5 and 4", result.ErrorCount, result.WarnCount)
+	}
+}
+
+func TestLinterTagProfiles(t *testing.T) {
+	// Each workflow exceeds the fan-out (warning); only the critical one is strict
+	workflow := func(name string, tags ...string) *analyzer.TemporalNode {
+		file := "/repo/" + name + ".go"
+		return &analyzer.TemporalNode{Name: name, Type: "workflow", FilePath: file, Tags: tags, CallSites: []analyzer.CallSite{
+			{TargetName: name + "A", CallType: "activity", FilePath: file, LineNumber: 10},
+			{TargetName: name + "B", CallType: "activity", FilePath: file, LineNumber: 11},
+		}}
+	}
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"Charge": workflow("Charge", "critical"),
+		"Report": workflow("Report"),
+	}}
+
+	cfg := DefaultConfig()
+	cfg.EnabledRules = []string{"TA020"}
+	cfg.Thresholds.MaxFanOut = 1
+	cfg.Profiles = []ProfileMapping{{Pattern: "tag:critical", Profile: ProfileStrict}}
+	result := NewLinter(cfg).Run(context.Background(), graph)
+
+	severities := make(map[string]Severity)
+	for _, issue := range result.Issues {
+		severities[issue.NodeName] = issue.Severity
+	}
+	if severities["Charge"] != SeverityError || severities["Report"] != SeverityWarning {
+		t.Errorf("Expected the tagged workflow to be strict, got %v", severities)
+	}
+}
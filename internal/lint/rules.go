@@ -6,9 +6,14 @@ package lint
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/contracts"
 )
 
 // Severity represents the severity level of a lint issue.
@@ -59,6 +64,7 @@ type Issue struct {
 	EndLine     int      `json:"endLine,omitempty"`
 	NodeName    string   `json:"nodeName,omitempty"`
 	NodeType    string   `json:"nodeType,omitempty"`
+	DocURL      string   `json:"docUrl,omitempty"` // Documentation page of the rule
 	// Fix contains a suggested code fix that can be applied automatically
 	Fix *CodeFix `json:"fix,omitempty"`
 
@@ -75,7 +81,9 @@ type CodeFix struct {
 	Replacements []Replacement `json:"replacements"`
 }
 
-// Replacement represents a single text replacement in a file.
+// Replacement represents a single text replacement in a file: NewText replaces the lines
+// StartLine to EndLine, or is inserted before StartLine when the replacement has neither
+// OldText nor EndLine.
 type Replacement struct {
 	FilePath  string `json:"filePath"`
 	StartLine int    `json:"startLine"`
@@ -86,6 +94,51 @@ type Replacement struct {
 	NewText string `json:"newText"`
 }
 
+// Insertion returns true if the replacement inserts NewText before StartLine rather than
+// replacing lines.
+func (r Replacement) Insertion() bool {
+	return r.OldText == "" && r.EndLine == 0
+}
+
+// LastLine returns the last line replaced: EndLine, or the last line of OldText when the
+// replacement has no EndLine.
+func (r Replacement) LastLine() int {
+	if r.EndLine > 0 {
+		return max(r.EndLine, r.StartLine)
+	}
+	return r.StartLine + strings.Count(strings.TrimSuffix(r.OldText, "\n"), "\n")
+}
+
+// insertBefore returns a replacement inserting text before line n of a file. It replaces
+// the line with the text followed by the line, so the fix can be verified against OldText;
+// when the line can't be read it is a plain insertion.
+func insertBefore(path string, n int, text string) Replacement {
+	line, ok := sourceLine(path, n)
+	if !ok || strings.TrimSpace(line) == "" {
+		return Replacement{FilePath: path, StartLine: n, NewText: text}
+	}
+	return Replacement{FilePath: path, StartLine: n, EndLine: n, OldText: line, NewText: text + "\n" + line}
+}
+
+// replaceLine returns a replacement of line n of a file with text, with the line as OldText.
+func replaceLine(path string, n int, text string) Replacement {
+	line, _ := sourceLine(path, n)
+	return Replacement{FilePath: path, StartLine: n, EndLine: n, OldText: line, NewText: text}
+}
+
+// sourceLine returns line n of a file.
+func sourceLine(path string, n int) (string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil || n < 1 {
+		return "", false
+	}
+	lines := strings.Split(string(data), "\n")
+	if n > len(lines) {
+		return "", false
+	}
+	return strings.TrimRight(lines[n-1], "\r"), true
+}
+
 // Rule defines a lint rule interface.
 type Rule interface {
 	// ID returns the unique identifier for this rule (e.g., "TA001")
@@ -134,19 +187,43 @@ func (r *ActivityUnlimitedRetryRule) Check(ctx context.Context, graph *analyzer.
 
 		for _, callSite := range node.CallSites {
 			// Only check activity and local_activity calls
-			if callSite.CallType != "activity" && callSite.CallType != "local_activity" {
+			if t := executedType(callSite); t != "activity" && t != "local_activity" {
 				continue
 			}
 
-			// Check if retry policy explicitly sets MaximumAttempts
-			hasMaxAttempts := false
-			if callSite.ParsedActivityOpts != nil && callSite.ParsedActivityOpts.RetryPolicy != nil {
-				// MaximumAttempts > 0 means bounded retries
-				// MaximumAttempts == 1 means no retries (intentionally disabled)
-				hasMaxAttempts = callSite.ParsedActivityOpts.RetryPolicy.MaximumAttempts > 0
+			// Options supplied by callers of a shared helper or passed in a variable can't be judged here
+			if unknownOptionsReason(callSite) != "" {
+				continue
 			}
 
-			if !hasMaxAttempts {
+			var policy *analyzer.RetryPolicy
+			if callSite.ParsedActivityOpts != nil {
+				policy = callSite.ParsedActivityOpts.RetryPolicy
+			}
+			switch {
+			case policy != nil && policy.MaximumAttemptsUnresolved:
+				// MaximumAttempts set from a variable or another package can't be judged
+				continue
+			case policy != nil && policy.MaximumAttempts > 0:
+				// MaximumAttempts > 0 means bounded retries
+				// MaximumAttempts == 1 means no retries (intentionally disabled)
+				continue
+			case policy.MaximumAttemptsSet():
+				// An explicit 0 is a deliberate choice of unlimited retries
+				issues = append(issues, Issue{
+					RuleID:      r.ID(),
+					RuleName:    r.Name(),
+					Severity:    SeverityInfo,
+					Category:    r.Category(),
+					Message:     fmt.Sprintf("Activity '%s' explicitly allows unlimited retry attempts (MaximumAttempts: %s)", callSite.TargetName, policy.MaximumAttemptsExpr),
+					Description: r.Description(),
+					Suggestion:  "Make sure the activity is idempotent; MaximumAttempts: 0 means unlimited attempts, 1 disables retries",
+					FilePath:    callSite.FilePath,
+					LineNumber:  callSite.LineNumber,
+					NodeName:    callSite.TargetName,
+					NodeType:    executedType(callSite),
+				})
+			default:
 				issues = append(issues, Issue{
 					RuleID:      r.ID(),
 					RuleName:    r.Name(),
@@ -158,13 +235,10 @@ func (r *ActivityUnlimitedRetryRule) Check(ctx context.Context, graph *analyzer.
 					FilePath:    callSite.FilePath,
 					LineNumber:  callSite.LineNumber,
 					NodeName:    callSite.TargetName,
-					NodeType:    callSite.CallType,
+					NodeType:    executedType(callSite),
 					Fix: &CodeFix{
 						Description: "Add bounded retry policy to activity options",
-						Replacements: []Replacement{{
-							FilePath:  callSite.FilePath,
-							StartLine: callSite.LineNumber,
-							NewText: `ao := workflow.ActivityOptions{
+						Replacements: []Replacement{insertBefore(callSite.FilePath, callSite.LineNumber, `ao := workflow.ActivityOptions{
 	StartToCloseTimeout: 10 * time.Minute,
 	RetryPolicy: &temporal.RetryPolicy{
 		InitialInterval:    time.Second,
@@ -173,8 +247,7 @@ func (r *ActivityUnlimitedRetryRule) Check(ctx context.Context, graph *analyzer.
 		MaximumAttempts:    3, // Bounded retries prevent infinite loops
 	},
 }
-ctx = workflow.WithActivityOptions(ctx, ao)`,
-						}},
+ctx = workflow.WithActivityOptions(ctx, ao)`)},
 					},
 				})
 			}
@@ -205,7 +278,12 @@ func (r *ActivityWithoutTimeoutRule) Check(ctx context.Context, graph *analyzer.
 		}
 
 		for _, callSite := range node.CallSites {
-			if callSite.CallType != "activity" && callSite.CallType != "local_activity" {
+			if t := executedType(callSite); t != "activity" && t != "local_activity" {
+				continue
+			}
+
+			// Options supplied by callers of a shared helper or passed in a variable can't be judged here
+			if unknownOptionsReason(callSite) != "" {
 				continue
 			}
 
@@ -230,17 +308,13 @@ func (r *ActivityWithoutTimeoutRule) Check(ctx context.Context, graph *analyzer.
 					FilePath:    callSite.FilePath,
 					LineNumber:  callSite.LineNumber,
 					NodeName:    callSite.TargetName,
-					NodeType:    callSite.CallType,
+					NodeType:    executedType(callSite),
 					Fix: &CodeFix{
 						Description: "Add timeout to activity options",
-						Replacements: []Replacement{{
-							FilePath:  callSite.FilePath,
-							StartLine: callSite.LineNumber,
-							NewText: `ao := workflow.ActivityOptions{
+						Replacements: []Replacement{insertBefore(callSite.FilePath, callSite.LineNumber, `ao := workflow.ActivityOptions{
 	StartToCloseTimeout: 10 * time.Minute,
 }
-ctx = workflow.WithActivityOptions(ctx, ao)`,
-						}},
+ctx = workflow.WithActivityOptions(ctx, ao)`)},
 					},
 				})
 			}
@@ -249,8 +323,38 @@ ctx = workflow.WithActivityOptions(ctx, ao)`,
 	return issues
 }
 
+// DefaultLongRunningPattern matches activity names that suggest long-running work.
+const DefaultLongRunningPattern = `(?i)process|batch|sync|import|export|migrate|generate|create|cleanup|duplicate`
+
+// DefaultLongRunningMinDuration is the duration hint from which an activity is long-running.
+const DefaultLongRunningMinDuration = time.Minute
+
+// DurationAnnotation is the annotation giving an activity's typical duration (e.g. @duration 10m
+// in a doc comment, or duration: 10m in the metadata overlay, as exported from metrics). It
+// takes precedence over the name heuristics of TA003.
+const DurationAnnotation = "duration"
+
+var defaultLongRunning = regexp.MustCompile(DefaultLongRunningPattern)
+
+// LongRunningConfig configures which activities TA003 considers long-running.
+type LongRunningConfig struct {
+	// Include matches long-running activity names (nil means DefaultLongRunningPattern)
+	Include *regexp.Regexp
+	// Exclude matches activity names that are never long-running, e.g. ^Create
+	Exclude *regexp.Regexp
+	// MinDuration is the duration hint from which an activity is long-running
+	// (0 means DefaultLongRunningMinDuration)
+	MinDuration time.Duration
+}
+
 // LongRunningActivityWithoutHeartbeatRule checks for potentially long-running activities without heartbeat.
-type LongRunningActivityWithoutHeartbeatRule struct{}
+type LongRunningActivityWithoutHeartbeatRule struct {
+	LongRunning LongRunningConfig
+}
+
+func NewLongRunningActivityWithoutHeartbeatRule(cfg LongRunningConfig) *LongRunningActivityWithoutHeartbeatRule {
+	return &LongRunningActivityWithoutHeartbeatRule{LongRunning: cfg}
+}
 
 func (r *LongRunningActivityWithoutHeartbeatRule) ID() string { return "TA003" }
 func (r *LongRunningActivityWithoutHeartbeatRule) Name() string {
@@ -273,24 +377,17 @@ func (r *LongRunningActivityWithoutHeartbeatRule) Check(ctx context.Context, gra
 		}
 
 		for _, callSite := range node.CallSites {
-			if callSite.CallType != "activity" && callSite.CallType != "local_activity" {
+			if t := executedType(callSite); t != "activity" && t != "local_activity" {
 				continue
 			}
 
-			// Check if activity appears to be long-running based on naming
-			targetName := strings.ToLower(callSite.TargetName)
-			isLongRunning := strings.Contains(targetName, "process") ||
-				strings.Contains(targetName, "batch") ||
-				strings.Contains(targetName, "sync") ||
-				strings.Contains(targetName, "import") ||
-				strings.Contains(targetName, "export") ||
-				strings.Contains(targetName, "migrate") ||
-				strings.Contains(targetName, "generate") ||
-				strings.Contains(targetName, "create") ||
-				strings.Contains(targetName, "cleanup") ||
-				strings.Contains(targetName, "duplicate")
-
-			if !isLongRunning {
+			// Check if activity appears to be long-running based on its duration hint or naming
+			if !r.isLongRunning(callSite, graph) {
+				continue
+			}
+
+			// Options supplied by callers of a shared helper or passed in a variable can't be judged here
+			if unknownOptionsReason(callSite) != "" {
 				continue
 			}
 
@@ -312,18 +409,14 @@ func (r *LongRunningActivityWithoutHeartbeatRule) Check(ctx context.Context, gra
 					FilePath:    callSite.FilePath,
 					LineNumber:  callSite.LineNumber,
 					NodeName:    callSite.TargetName,
-					NodeType:    callSite.CallType,
+					NodeType:    executedType(callSite),
 					Fix: &CodeFix{
 						Description: "Add heartbeat timeout to activity options",
-						Replacements: []Replacement{{
-							FilePath:  callSite.FilePath,
-							StartLine: callSite.LineNumber,
-							NewText: `ao := workflow.ActivityOptions{
+						Replacements: []Replacement{insertBefore(callSite.FilePath, callSite.LineNumber, `ao := workflow.ActivityOptions{
 	StartToCloseTimeout: 30 * time.Minute,
 	HeartbeatTimeout:    30 * time.Second,
 }
-ctx = workflow.WithActivityOptions(ctx, ao)`,
-						}},
+ctx = workflow.WithActivityOptions(ctx, ao)`)},
 					},
 				})
 			}
@@ -332,6 +425,55 @@ ctx = workflow.WithActivityOptions(ctx, ao)`,
 	return issues
 }
 
+// executedType returns the type of workflow or activity a call site executes. Extracted
+// call sites have the "execute" call type and carry the executed type as their target type.
+func executedType(callSite analyzer.CallSite) string {
+	if callSite.CallType == "execute" || callSite.CallType == "" {
+		return callSite.TargetType
+	}
+	return callSite.CallType
+}
+
+// unknownOptionsReason explains why the activity options of a call site can't be judged,
+// or returns "" when they were parsed or are known to be missing.
+func unknownOptionsReason(callSite analyzer.CallSite) string {
+	switch opts := callSite.ParsedActivityOpts; {
+	case opts == nil:
+		return ""
+	case opts.CallerDependent:
+		return "options set by callers"
+	case opts.Unparsed:
+		return "options passed in a variable"
+	}
+	return ""
+}
+
+// isLongRunning reports whether the activity of a call site is long-running, from the
+// duration hint of the activity node if it has one and from its name otherwise.
+func (r *LongRunningActivityWithoutHeartbeatRule) isLongRunning(callSite analyzer.CallSite, graph *analyzer.TemporalGraph) bool {
+	cfg := r.LongRunning
+	if target, ok := graph.Nodes[callSite.TargetName]; ok {
+		if d, err := time.ParseDuration(target.Annotations[DurationAnnotation]); err == nil {
+			if cfg.MinDuration <= 0 {
+				cfg.MinDuration = DefaultLongRunningMinDuration
+			}
+			return d >= cfg.MinDuration
+		}
+	}
+
+	name := callSite.TargetName
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	if cfg.Exclude != nil && cfg.Exclude.MatchString(name) {
+		return false
+	}
+	if cfg.Include == nil {
+		cfg.Include = defaultLongRunning
+	}
+	return cfg.Include.MatchString(name)
+}
+
 // ChildWorkflowUnlimitedRetryRule checks for child workflows with unlimited retry attempts.
 // NOTE: Child workflows do NOT inherit RetryPolicy from parent workflows.
 // They get Temporal server defaults (unlimited retries) if not explicitly set.
@@ -356,7 +498,7 @@ func (r *ChildWorkflowUnlimitedRetryRule) Check(ctx context.Context, graph *anal
 
 		for _, callSite := range node.CallSites {
 			// Only check child_workflow calls
-			if callSite.CallType != "child_workflow" {
+			if executedType(callSite) != "child_workflow" {
 				continue
 			}
 
@@ -382,13 +524,10 @@ func (r *ChildWorkflowUnlimitedRetryRule) Check(ctx context.Context, graph *anal
 					FilePath:    callSite.FilePath,
 					LineNumber:  callSite.LineNumber,
 					NodeName:    callSite.TargetName,
-					NodeType:    callSite.CallType,
+					NodeType:    executedType(callSite),
 					Fix: &CodeFix{
 						Description: "Add bounded retry policy to child workflow options",
-						Replacements: []Replacement{{
-							FilePath:  callSite.FilePath,
-							StartLine: callSite.LineNumber,
-							NewText: `childOpts := workflow.ChildWorkflowOptions{
+						Replacements: []Replacement{insertBefore(callSite.FilePath, callSite.LineNumber, `childOpts := workflow.ChildWorkflowOptions{
 	WorkflowExecutionTimeout: 1 * time.Hour,
 	RetryPolicy: &temporal.RetryPolicy{
 		InitialInterval:    time.Second,
@@ -397,8 +536,7 @@ func (r *ChildWorkflowUnlimitedRetryRule) Check(ctx context.Context, graph *anal
 		MaximumAttempts:    3, // Child workflows do NOT inherit parent's retry policy
 	},
 }
-ctx = workflow.WithChildOptions(ctx, childOpts)`,
-						}},
+ctx = workflow.WithChildOptions(ctx, childOpts)`)},
 					},
 				})
 			}
@@ -407,6 +545,233 @@ ctx = workflow.WithChildOptions(ctx, childOpts)`,
 	return issues
 }
 
+// SleepBlocksSignalsRule checks for workflow.Sleep in loops that also receive signals.
+// A Sleep cannot be interrupted, so signals arriving during it wait until it returns.
+type SleepBlocksSignalsRule struct{}
+
+func (r *SleepBlocksSignalsRule) ID() string         { return "TA005" }
+func (r *SleepBlocksSignalsRule) Name() string       { return "sleep-blocks-signals" }
+func (r *SleepBlocksSignalsRule) Category() Category { return CategoryReliability }
+func (r *SleepBlocksSignalsRule) Severity() Severity { return SeverityWarning }
+func (r *SleepBlocksSignalsRule) Description() string {
+	return "workflow.Sleep cannot be cancelled by a signal. In a loop that also receives signals, signals are not handled until the sleep ends. Use workflow.NewTimer with a Selector so the workflow wakes on whichever comes first."
+}
+
+func (r *SleepBlocksSignalsRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" || len(node.SignalReceives) == 0 {
+			continue
+		}
+
+		for _, timer := range node.Timers {
+			if !timer.IsSleep || timer.LoopLine == 0 {
+				continue
+			}
+
+			var signals []string
+			for _, receive := range node.SignalReceives {
+				if receive.LoopLine == timer.LoopLine {
+					signals = append(signals, signalLabel(receive))
+				}
+			}
+			if len(signals) == 0 {
+				continue
+			}
+
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("Workflow '%s' sleeps in a loop that receives signals (%s); signals wait until the sleep ends", node.Name, strings.Join(signals, ", ")),
+				Description: r.Description(),
+				Suggestion:  "Replace workflow.Sleep with workflow.NewTimer and wait on a Selector with AddFuture for the timer and AddReceive for the signal channel",
+				FilePath:    node.FilePath,
+				LineNumber:  timer.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+				Fix: &CodeFix{
+					Description: "Wait on a cancellable timer and the signal channel together",
+					Replacements: []Replacement{replaceLine(node.FilePath, timer.LineNumber, fmt.Sprintf(`timerCtx, cancelTimer := workflow.WithCancel(ctx)
+timer := workflow.NewTimer(timerCtx, %s)
+selector := workflow.NewSelector(ctx)
+selector.AddFuture(timer, func(f workflow.Future) {})
+selector.AddReceive(signalChan, func(c workflow.ReceiveChannel, more bool) {
+	cancelTimer()
+	c.Receive(ctx, &signal)
+})
+selector.Select(ctx)`, sleepDuration(timer)))},
+				},
+			})
+		}
+	}
+	return issues
+}
+
+// BlockingSignalReceiveRule checks for blocking Receive calls on signal channels outside a Selector.
+// If the signal never arrives the workflow waits forever, with no timer to fall back on.
+type BlockingSignalReceiveRule struct{}
+
+func (r *BlockingSignalReceiveRule) ID() string         { return "TA006" }
+func (r *BlockingSignalReceiveRule) Name() string       { return "blocking-signal-receive" }
+func (r *BlockingSignalReceiveRule) Category() Category { return CategoryReliability }
+func (r *BlockingSignalReceiveRule) Severity() Severity { return SeverityWarning }
+func (r *BlockingSignalReceiveRule) Description() string {
+	return "A blocking Receive on a signal channel waits forever if the signal is never sent. Wait on a Selector with AddReceive for the channel and AddFuture for a timer, so the workflow can time out, escalate or continue."
+}
+
+func (r *BlockingSignalReceiveRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+
+		for _, receive := range node.SignalReceives {
+			if !receive.Blocking || receive.InSelector {
+				continue
+			}
+
+			channel := receive.Channel
+			if channel == "" {
+				channel = fmt.Sprintf("workflow.GetSignalChannel(ctx, %q)", receive.Signal)
+			}
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("Workflow '%s' blocks on signal '%s' with no timeout", node.Name, signalLabel(receive)),
+				Description: r.Description(),
+				Suggestion:  "Use a Selector with AddReceive for the signal channel and AddFuture for a workflow.NewTimer, or ReceiveWithTimeout",
+				FilePath:    node.FilePath,
+				LineNumber:  receive.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+				Fix: &CodeFix{
+					Description: "Wait for the signal or a timeout, whichever comes first",
+					Replacements: []Replacement{replaceLine(node.FilePath, receive.LineNumber, fmt.Sprintf(`selector := workflow.NewSelector(ctx)
+selector.AddReceive(%s, func(c workflow.ReceiveChannel, more bool) {
+	c.Receive(ctx, &signal)
+})
+selector.AddFuture(workflow.NewTimer(ctx, 24*time.Hour), func(f workflow.Future) {
+	// Signal not received in time: escalate, fail or continue
+})
+selector.Select(ctx)`, channel))},
+				},
+			})
+		}
+	}
+	return issues
+}
+
+// WorkflowDirectLoggingRule checks for workflows logging without workflow.GetLogger.
+// Direct loggers write again every time the workflow is replayed.
+type WorkflowDirectLoggingRule struct{}
+
+func (r *WorkflowDirectLoggingRule) ID() string         { return "TA007" }
+func (r *WorkflowDirectLoggingRule) Name() string       { return "workflow-direct-logging" }
+func (r *WorkflowDirectLoggingRule) Category() Category { return CategoryReliability }
+func (r *WorkflowDirectLoggingRule) Severity() Severity { return SeverityWarning }
+func (r *WorkflowDirectLoggingRule) Description() string {
+	return "fmt, log, slog, zap and logrus calls in workflow code run again on every replay, duplicating log lines and losing workflow context. workflow.GetLogger(ctx) skips logging during replay and tags entries with the workflow and run IDs."
+}
+
+func (r *WorkflowDirectLoggingRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+
+		for _, logCall := range node.LogCalls {
+			message := logCall.Message
+			if message == "" {
+				message = `"message"`
+			}
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("Workflow '%s' logs with %s instead of workflow.GetLogger(ctx)", node.Name, logCall.Call),
+				Description: r.Description(),
+				Suggestion:  fmt.Sprintf("Use workflow.GetLogger(ctx).%s with key-value pairs instead of %s", logCall.Level, logCall.Call),
+				FilePath:    node.FilePath,
+				LineNumber:  logCall.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+				Fix: &CodeFix{
+					Description:  "Log through the replay-aware workflow logger",
+					Replacements: []Replacement{replaceLine(node.FilePath, logCall.LineNumber, fmt.Sprintf(`workflow.GetLogger(ctx).%s(%s, "key", value)`, logCall.Level, message))},
+				},
+			})
+		}
+	}
+	return issues
+}
+
+// WorkflowDirectMetricsRule checks for workflows recording metrics without workflow.GetMetricsHandler.
+type WorkflowDirectMetricsRule struct{}
+
+func (r *WorkflowDirectMetricsRule) ID() string         { return "TA008" }
+func (r *WorkflowDirectMetricsRule) Name() string       { return "workflow-direct-metrics" }
+func (r *WorkflowDirectMetricsRule) Category() Category { return CategoryReliability }
+func (r *WorkflowDirectMetricsRule) Severity() Severity { return SeverityWarning }
+func (r *WorkflowDirectMetricsRule) Description() string {
+	return "Prometheus and StatsD calls in workflow code run again on every replay, so counters and timers are inflated by each worker restart or cache eviction. workflow.GetMetricsHandler(ctx) skips recording during replay."
+}
+
+func (r *WorkflowDirectMetricsRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+
+		for _, metricCall := range node.MetricCalls {
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("Workflow '%s' records %s metrics directly with %s", node.Name, metricCall.Kind, metricCall.Call),
+				Description: r.Description(),
+				Suggestion:  "Record the metric with workflow.GetMetricsHandler(ctx) (e.g. .Counter(name).Inc(1)), or move it into an activity",
+				FilePath:    node.FilePath,
+				LineNumber:  metricCall.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+			})
+		}
+	}
+	return issues
+}
+
+// signalLabel returns a readable name for the signal of a receive.
+func signalLabel(receive analyzer.SignalReceive) string {
+	switch {
+	case receive.Signal != "":
+		return receive.Signal
+	case receive.Channel != "":
+		return receive.Channel
+	}
+	return "signal channel"
+}
+
+// sleepDuration returns the duration expression of a timer, or a placeholder.
+func sleepDuration(timer analyzer.TimerDef) string {
+	if timer.Duration == "" {
+		return "duration"
+	}
+	return timer.Duration
+}
+
 // =============================================================================
 // Reliability Rules
 // =============================================================================
@@ -489,7 +854,7 @@ func (r *OrphanNodeRule) Check(ctx context.Context, graph *analyzer.TemporalGrap
 				Suggestion:  "Consider removing unused code, or verify it's called from another repository or registered with a worker",
 				FilePath:    node.FilePath,
 				LineNumber:  node.LineNumber,
-				NodeName:    node.Name,
+				NodeName:    node.ID(),
 				NodeType:    node.Type,
 			})
 		}
@@ -497,45 +862,35 @@ func (r *OrphanNodeRule) Check(ctx context.Context, graph *analyzer.TemporalGrap
 	return issues
 }
 
-// =============================================================================
-// Performance Rules
-// =============================================================================
-
-// HighFanOutRule checks for workflows with too many direct calls.
-type HighFanOutRule struct {
-	Threshold int
-}
-
-func NewHighFanOutRule(threshold int) *HighFanOutRule {
-	if threshold <= 0 {
-		threshold = 15 // Default
-	}
-	return &HighFanOutRule{Threshold: threshold}
-}
+// AmbiguousCallTargetRule checks for calls by a bare name that is defined in several packages.
+type AmbiguousCallTargetRule struct{}
 
-func (r *HighFanOutRule) ID() string         { return "TA020" }
-func (r *HighFanOutRule) Name() string       { return "high-fan-out" }
-func (r *HighFanOutRule) Category() Category { return CategoryPerformance }
-func (r *HighFanOutRule) Severity() Severity { return SeverityWarning }
-func (r *HighFanOutRule) Description() string {
-	return "High fan-out creates blast radius issues: one change affects many dependencies. It makes testing harder, increases coupling, and often indicates a missing abstraction layer or orchestration pattern."
+func (r *AmbiguousCallTargetRule) ID() string         { return "TA012" }
+func (r *AmbiguousCallTargetRule) Name() string       { return "ambiguous-call-target" }
+func (r *AmbiguousCallTargetRule) Category() Category { return CategoryMaintenance }
+func (r *AmbiguousCallTargetRule) Severity() Severity { return SeverityInfo }
+func (r *AmbiguousCallTargetRule) Description() string {
+	return "The call target's name is defined in several packages and none of them is the caller's, so the analyzer cannot tell which one is called. The call is left out of the graph, hiding it from relationship-based checks."
 }
 
-func (r *HighFanOutRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+func (r *AmbiguousCallTargetRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
 	var issues []Issue
 	for _, node := range graph.Nodes {
-		if len(node.CallSites) > r.Threshold {
+		for _, callSite := range node.CallSites {
+			if len(callSite.Candidates) < 2 {
+				continue
+			}
 			issues = append(issues, Issue{
 				RuleID:      r.ID(),
 				RuleName:    r.Name(),
 				Severity:    r.Severity(),
 				Category:    r.Category(),
-				Message:     fmt.Sprintf("%s '%s' has %d direct calls (threshold: %d)", node.Type, node.Name, len(node.CallSites), r.Threshold),
+				Message:     fmt.Sprintf("Call to '%s' matches %d definitions: %s", callSite.TargetName, len(callSite.Candidates), strings.Join(callSite.Candidates, ", ")),
 				Description: r.Description(),
-				Suggestion:  "Consider breaking down into smaller, more focused workflows or using sub-workflows",
-				FilePath:    node.FilePath,
-				LineNumber:  node.LineNumber,
-				NodeName:    node.Name,
+				Suggestion:  "Rename one of the definitions, or register and call the target by a name that is unique across packages",
+				FilePath:    callSite.FilePath,
+				LineNumber:  callSite.LineNumber,
+				NodeName:    node.ID(),
 				NodeType:    node.Type,
 			})
 		}
@@ -543,147 +898,418 @@ func (r *HighFanOutRule) Check(ctx context.Context, graph *analyzer.TemporalGrap
 	return issues
 }
 
-// DeepCallChainRule checks for excessively deep call chains.
-type DeepCallChainRule struct {
-	MaxDepth int
+// DeprecatedCallRule flags call edges to nodes tagged deprecated in the metadata overlay that
+// are not in the graph of a base git ref, so deprecated workflows and activities gain no new
+// callers while existing ones are migrated. The rule does nothing unless a base graph is set.
+type DeprecatedCallRule struct {
+	Base *analyzer.TemporalGraph
 }
 
-func NewDeepCallChainRule(maxDepth int) *DeepCallChainRule {
-	if maxDepth <= 0 {
-		maxDepth = 10 // Default
-	}
-	return &DeepCallChainRule{MaxDepth: maxDepth}
+func NewDeprecatedCallRule(base *analyzer.TemporalGraph) *DeprecatedCallRule {
+	return &DeprecatedCallRule{Base: base}
 }
 
-func (r *DeepCallChainRule) ID() string         { return "TA021" }
-func (r *DeepCallChainRule) Name() string       { return "deep-call-chain" }
-func (r *DeepCallChainRule) Category() Category { return CategoryPerformance }
-func (r *DeepCallChainRule) Severity() Severity { return SeverityWarning }
-func (r *DeepCallChainRule) Description() string {
-	return "Deep call chains make stack traces hard to read, increase end-to-end latency, and make it difficult to understand the business flow. Consider flattening or using child workflows for clarity."
+func (r *DeprecatedCallRule) ID() string         { return "TA013" }
+func (r *DeprecatedCallRule) Name() string       { return "new-deprecated-call" }
+func (r *DeprecatedCallRule) Category() Category { return CategoryMaintenance }
+func (r *DeprecatedCallRule) Severity() Severity { return SeverityError }
+func (r *DeprecatedCallRule) Description() string {
+	return "The call target is marked deprecated in the metadata overlay and the caller did not call it at the diff base ref. Decommissioning a workflow or activity only finishes if its callers shrink; each new caller must be migrated again later."
 }
 
-func (r *DeepCallChainRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+func (r *DeprecatedCallRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	if r.Base == nil {
+		return nil
+	}
+
 	var issues []Issue
-	for _, node := range graph.Nodes {
-		if len(node.Parents) == 0 { // Root node
-			depth := calculateChainDepth(ctx, node, graph, make(map[string]bool))
-			if depth > r.MaxDepth {
-				issues = append(issues, Issue{
-					RuleID:      r.ID(),
-					RuleName:    r.Name(),
-					Severity:    r.Severity(),
-					Category:    r.Category(),
-					Message:     fmt.Sprintf("Call chain starting from '%s' has depth %d (max: %d)", node.Name, depth, r.MaxDepth),
-					Description: r.Description(),
-					Suggestion:  "Consider flattening the workflow structure or using child workflows strategically",
-					FilePath:    node.FilePath,
-					LineNumber:  node.LineNumber,
-					NodeName:    node.Name,
-					NodeType:    node.Type,
-				})
+	for name, node := range graph.Nodes {
+		baseTargets := make(map[string]bool)
+		if baseNode, ok := r.Base.Nodes[name]; ok {
+			for _, callSite := range baseNode.CallSites {
+				baseTargets[callSite.TargetName] = true
 			}
 		}
-	}
-	return issues
+
+		reported := make(map[string]bool)
+		for _, callSite := range node.CallSites {
+			target, ok := graph.Nodes[callSite.TargetName]
+			if !ok || !target.HasTag(analyzer.TagDeprecated) || baseTargets[callSite.TargetName] || reported[callSite.TargetName] {
+				continue
+			}
+			reported[callSite.TargetName] = true
+
+			suggestion := fmt.Sprintf("Call the replacement of '%s' instead", target.Name)
+			if target.Description != "" {
+				suggestion += " (" + target.Description + ")"
+			}
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("'%s' adds a call to deprecated %s '%s'", node.Name, target.Type, target.Name),
+				Description: r.Description(),
+				Suggestion:  suggestion,
+				FilePath:    node.FilePath,
+				LineNumber:  callSite.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+			})
+		}
+	}
+	return issues
 }
 
-// =============================================================================
-// Maintenance Rules
-// =============================================================================
+// UnreceivedSignalChannelRule checks for signal channels that are never received from.
+type UnreceivedSignalChannelRule struct{}
 
-// WorkflowWithoutVersioningRule checks for complex workflows without versioning.
-type WorkflowWithoutVersioningRule struct {
-	ComplexityThreshold int
+func (r *UnreceivedSignalChannelRule) ID() string         { return "TA014" }
+func (r *UnreceivedSignalChannelRule) Name() string       { return "unreceived-signal-channel" }
+func (r *UnreceivedSignalChannelRule) Category() Category { return CategoryReliability }
+func (r *UnreceivedSignalChannelRule) Severity() Severity { return SeverityWarning }
+func (r *UnreceivedSignalChannelRule) Description() string {
+	return "The workflow obtains a signal channel with GetSignalChannel but never receives from it, hands it to a Selector or passes it on. Signals sent on it are accepted by the server and recorded in history, then never read: senders believe they were handled."
 }
 
-func NewWorkflowWithoutVersioningRule(threshold int) *WorkflowWithoutVersioningRule {
-	if threshold <= 0 {
-		threshold = 5 // Default: workflows with 5+ activities should consider versioning
+func (r *UnreceivedSignalChannelRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+		for _, signal := range node.Signals {
+			if !signal.Unreceived {
+				continue
+			}
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("Signal channel '%s' in workflow '%s' is never received from", signal.Name, node.Name),
+				Description: r.Description(),
+				Suggestion:  "Receive from the channel, e.g. with selector.AddReceive or a ReceiveAsync loop before completing, or remove the GetSignalChannel call",
+				FilePath:    node.FilePath,
+				LineNumber:  signal.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+			})
+		}
 	}
-	return &WorkflowWithoutVersioningRule{ComplexityThreshold: threshold}
+	return issues
 }
 
-func (r *WorkflowWithoutVersioningRule) ID() string         { return "TA030" }
-func (r *WorkflowWithoutVersioningRule) Name() string       { return "workflow-without-versioning" }
-func (r *WorkflowWithoutVersioningRule) Category() Category { return CategoryMaintenance }
-func (r *WorkflowWithoutVersioningRule) Severity() Severity { return SeverityInfo }
-func (r *WorkflowWithoutVersioningRule) Description() string {
-	return "Long-running workflows may execute for days or weeks. Without versioning, deploying logic changes can break in-flight executions mid-run, causing failures and data inconsistencies."
+// UnusedMessageHandlerRule checks for query and update handlers no analyzed code calls.
+type UnusedMessageHandlerRule struct{}
+
+func (r *UnusedMessageHandlerRule) ID() string         { return "TA015" }
+func (r *UnusedMessageHandlerRule) Name() string       { return "unused-message-handler" }
+func (r *UnusedMessageHandlerRule) Category() Category { return CategoryMaintenance }
+func (r *UnusedMessageHandlerRule) Severity() Severity { return SeverityInfo }
+func (r *UnusedMessageHandlerRule) Description() string {
+	return "The workflow registers a query or update handler that no analyzed client, workflow or test queries or updates by name. The handler may be dead code left after a client migration; handlers used only from the Temporal UI, CLI or another repository are expected to be reported."
 }
 
-func (r *WorkflowWithoutVersioningRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+// unmatchable reports whether some call of the kind names its query or update with a
+// value that isn't a string constant, so that any handler may be called.
+func unmatchable(graph *analyzer.TemporalGraph, kind string) bool {
+	for _, m := range graph.MessagesSent(kind) {
+		if m.Name == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *UnusedMessageHandlerRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	called := make(map[string]bool)
+	for _, m := range graph.Messages {
+		called[m.Kind+"\x00"+m.Name] = true
+	}
+
 	var issues []Issue
+	report := func(node *analyzer.TemporalNode, kind, name string, line int) {
+		if name == "" || called[kind+"\x00"+name] || unmatchable(graph, kind) {
+			return
+		}
+		verb := "queried"
+		if kind == "update" {
+			verb = "updated"
+		}
+		issues = append(issues, Issue{
+			RuleID:      r.ID(),
+			RuleName:    r.Name(),
+			Severity:    r.Severity(),
+			Category:    r.Category(),
+			Message:     fmt.Sprintf("%s handler '%s' of workflow '%s' is never %s by the analyzed code", strings.ToUpper(kind[:1])+kind[1:], name, node.Name, verb),
+			Description: r.Description(),
+			Suggestion:  fmt.Sprintf("Remove the handler if nothing calls it, or disable %s for handlers used from the Temporal UI, CLI or other services", r.ID()),
+			FilePath:    node.FilePath,
+			LineNumber:  line,
+			NodeName:    node.ID(),
+			NodeType:    node.Type,
+		})
+	}
 	for _, node := range graph.Nodes {
 		if node.Type != "workflow" {
 			continue
 		}
-		// Check if workflow is complex (has many calls)
-		isComplex := len(node.CallSites) >= r.ComplexityThreshold
-		hasVersioning := len(node.Versioning) > 0
+		for _, query := range node.Queries {
+			report(node, "query", query.Name, query.LineNumber)
+		}
+		for _, update := range node.Updates {
+			report(node, "update", update.Name, update.LineNumber)
+		}
+	}
+	return issues
+}
 
-		if isComplex && !hasVersioning {
+// SignalToUnhandledWorkflowRule checks for signals sent to workflows that don't handle them.
+type SignalToUnhandledWorkflowRule struct{}
+
+func (r *SignalToUnhandledWorkflowRule) ID() string         { return "TA016" }
+func (r *SignalToUnhandledWorkflowRule) Name() string       { return "signal-to-unhandled-workflow" }
+func (r *SignalToUnhandledWorkflowRule) Category() Category { return CategoryReliability }
+func (r *SignalToUnhandledWorkflowRule) Severity() Severity { return SeverityWarning }
+func (r *SignalToUnhandledWorkflowRule) Description() string {
+	return "A signal is sent to a workflow type that registers no handler or channel for its name. The server accepts the signal and records it in history, but the workflow never reads it, usually because of a typo or a renamed signal. Signals without a known target type are checked against all analyzed workflows."
+}
+
+// handlesSignal reports whether a workflow handles the signal name, or may: it handles a
+// signal whose name isn't a string literal.
+func handlesSignal(node *analyzer.TemporalNode, name string) bool {
+	for _, signal := range node.Signals {
+		if signal.Name == name || signal.Name == "" {
+			return true
+		}
+	}
+	for _, receive := range node.SignalReceives {
+		if receive.Signal == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *SignalToUnhandledWorkflowRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var workflows []*analyzer.TemporalNode
+	for _, node := range graph.Nodes {
+		if node.Type == "workflow" && !node.Unresolved {
+			workflows = append(workflows, node)
+		}
+	}
+	if len(workflows) == 0 {
+		return nil
+	}
+
+	var issues []Issue
+	for _, m := range graph.MessagesSent("signal") {
+		if m.Name == "" {
+			continue
+		}
+		issue := Issue{
+			RuleID:      r.ID(),
+			RuleName:    r.Name(),
+			Severity:    r.Severity(),
+			Category:    r.Category(),
+			Description: r.Description(),
+			FilePath:    m.FilePath,
+			LineNumber:  m.LineNumber,
+		}
+		if m.Workflow != "" {
+			target, ok := graph.Nodes[m.Workflow]
+			if !ok || target.Unresolved || target.Type != "workflow" || handlesSignal(target, m.Name) {
+				continue
+			}
+			issue.Message = fmt.Sprintf("Signal '%s' is sent to workflow '%s', which has no handler or channel for it", m.Name, target.Name)
+			issue.Suggestion = fmt.Sprintf("Check the signal name, or receive it in '%s' with workflow.GetSignalChannel", target.Name)
+			issue.NodeName, issue.NodeType = target.ID(), target.Type
+			issues = append(issues, issue)
+			continue
+		}
+
+		handled := false
+		for _, node := range workflows {
+			if handlesSignal(node, m.Name) {
+				handled = true
+				break
+			}
+		}
+		if !handled {
+			issue.Message = fmt.Sprintf("Signal '%s' is sent, but no analyzed workflow has a handler or channel for it", m.Name)
+			issue.Suggestion = "Check the signal name against the workflow's GetSignalChannel calls, or ignore this if the workflow is defined in another repository"
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// DuplicateMessageHandlerRule checks for signal and query names registered more than once
+// in a workflow.
+type DuplicateMessageHandlerRule struct{}
+
+func (r *DuplicateMessageHandlerRule) ID() string         { return "TA017" }
+func (r *DuplicateMessageHandlerRule) Name() string       { return "duplicate-message-handler" }
+func (r *DuplicateMessageHandlerRule) Category() Category { return CategoryReliability }
+func (r *DuplicateMessageHandlerRule) Severity() Severity { return SeverityWarning }
+func (r *DuplicateMessageHandlerRule) Description() string {
+	return "The workflow registers the same signal or query name more than once, possibly through different constants with the same value. A second SetQueryHandler silently replaces the first handler, and signal channels of the same name share their signals, so each signal is read by only one of the receiving code paths."
+}
+
+// registration is a signal or query name registered at a line of a workflow.
+type registration struct {
+	name string
+	line int
+}
+
+func (r *DuplicateMessageHandlerRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	report := func(node *analyzer.TemporalNode, kind, consequence string, registrations []registration) {
+		byName := make(map[string][]int)
+		var names []string
+		for _, reg := range registrations {
+			if reg.name == "" {
+				continue
+			}
+			if _, ok := byName[reg.name]; !ok {
+				names = append(names, reg.name)
+			}
+			byName[reg.name] = append(byName[reg.name], reg.line)
+		}
+		for _, name := range names {
+			lines := byName[name]
+			if len(lines) < 2 {
+				continue
+			}
+			at := make([]string, len(lines)-1)
+			for i, line := range lines[:len(lines)-1] {
+				at[i] = fmt.Sprint(line)
+			}
 			issues = append(issues, Issue{
 				RuleID:      r.ID(),
 				RuleName:    r.Name(),
 				Severity:    r.Severity(),
 				Category:    r.Category(),
-				Message:     fmt.Sprintf("Complex workflow '%s' (%d calls) has no versioning", node.Name, len(node.CallSites)),
+				Message:     fmt.Sprintf("%s '%s' is registered %d times in workflow '%s' (lines %s and %d); %s", strings.ToUpper(kind[:1])+kind[1:], name, len(lines), node.Name, strings.Join(at, ", "), lines[len(lines)-1], consequence),
 				Description: r.Description(),
-				Suggestion:  "Consider using workflow.GetVersion() for safe deployments with running workflows",
+				Suggestion:  fmt.Sprintf("Register %s '%s' once and share the handler or channel between the code paths that need it", kind, name),
 				FilePath:    node.FilePath,
-				LineNumber:  node.LineNumber,
-				NodeName:    node.Name,
+				LineNumber:  lines[len(lines)-1],
+				NodeName:    node.ID(),
 				NodeType:    node.Type,
-				Fix: &CodeFix{
-					Description: "Add workflow versioning for safe deployments",
-					Replacements: []Replacement{{
-						FilePath:  node.FilePath,
-						StartLine: node.LineNumber,
-						NewText: `v := workflow.GetVersion(ctx, "initial-version", workflow.DefaultVersion, 1)
-if v == workflow.DefaultVersion {
-	// existing logic
-} else {
-	// new logic
-}`,
-					}},
-				},
 			})
 		}
 	}
+
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+		var signals, queries []registration
+		for _, signal := range node.Signals {
+			signals = append(signals, registration{messageName(signal.Name, signal.NameExpr), signal.LineNumber})
+		}
+		for _, query := range node.Queries {
+			queries = append(queries, registration{messageName(query.Name, query.NameExpr), query.LineNumber})
+		}
+		report(node, "signal", "each signal is read by only one of them", signals)
+		report(node, "query", "only the last handler answers queries", queries)
+	}
 	return issues
 }
 
-// SignalWithoutHandlerRule checks for signal definitions without handlers.
-type SignalWithoutHandlerRule struct{}
+// messageName returns a signal or query name, or the constant naming it when its value is unknown.
+func messageName(name, expr string) string {
+	if name != "" {
+		return name
+	}
+	return expr
+}
 
-func (r *SignalWithoutHandlerRule) ID() string         { return "TA031" }
-func (r *SignalWithoutHandlerRule) Name() string       { return "signal-without-handler" }
-func (r *SignalWithoutHandlerRule) Category() Category { return CategoryReliability }
-func (r *SignalWithoutHandlerRule) Severity() Severity { return SeverityWarning }
-func (r *SignalWithoutHandlerRule) Description() string {
-	return "Unhandled signals are silently dropped. External systems sending signals believe they're communicating with the workflow, but the data goes nowhere—a silent failure that's hard to debug."
+// =============================================================================
+// Performance Rules
+// =============================================================================
+
+// HighFanOutRule checks for workflows with too many direct calls.
+type HighFanOutRule struct {
+	Threshold int
 }
 
-func (r *SignalWithoutHandlerRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+func NewHighFanOutRule(threshold int) *HighFanOutRule {
+	if threshold <= 0 {
+		threshold = 15 // Default
+	}
+	return &HighFanOutRule{Threshold: threshold}
+}
+
+func (r *HighFanOutRule) ID() string         { return "TA020" }
+func (r *HighFanOutRule) Name() string       { return "high-fan-out" }
+func (r *HighFanOutRule) Category() Category { return CategoryPerformance }
+func (r *HighFanOutRule) Severity() Severity { return SeverityWarning }
+func (r *HighFanOutRule) Description() string {
+	return "High fan-out creates blast radius issues: one change affects many dependencies. It makes testing harder, increases coupling, and often indicates a missing abstraction layer or orchestration pattern."
+}
+
+func (r *HighFanOutRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
 	var issues []Issue
 	for _, node := range graph.Nodes {
-		if node.Type != "workflow" {
-			continue
+		if len(node.CallSites) > r.Threshold {
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("%s '%s' has %d direct calls (threshold: %d)", node.Type, node.Name, len(node.CallSites), r.Threshold),
+				Description: r.Description(),
+				Suggestion:  "Consider breaking down into smaller, more focused workflows or using sub-workflows",
+				FilePath:    node.FilePath,
+				LineNumber:  node.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+			})
 		}
-		for _, signal := range node.Signals {
-			if signal.Handler == "" {
+	}
+	return issues
+}
+
+// DeepCallChainRule checks for excessively deep call chains.
+type DeepCallChainRule struct {
+	MaxDepth int
+}
+
+func NewDeepCallChainRule(maxDepth int) *DeepCallChainRule {
+	if maxDepth <= 0 {
+		maxDepth = 10 // Default
+	}
+	return &DeepCallChainRule{MaxDepth: maxDepth}
+}
+
+func (r *DeepCallChainRule) ID() string         { return "TA021" }
+func (r *DeepCallChainRule) Name() string       { return "deep-call-chain" }
+func (r *DeepCallChainRule) Category() Category { return CategoryPerformance }
+func (r *DeepCallChainRule) Severity() Severity { return SeverityWarning }
+func (r *DeepCallChainRule) Description() string {
+	return "Deep call chains make stack traces hard to read, increase end-to-end latency, and make it difficult to understand the business flow. Consider flattening or using child workflows for clarity."
+}
+
+func (r *DeepCallChainRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	for _, node := range graph.Nodes {
+		if len(node.Parents) == 0 { // Root node
+			depth := calculateChainDepth(ctx, node, graph, make(map[string]bool))
+			if depth > r.MaxDepth {
 				issues = append(issues, Issue{
 					RuleID:      r.ID(),
 					RuleName:    r.Name(),
 					Severity:    r.Severity(),
 					Category:    r.Category(),
-					Message:     fmt.Sprintf("Signal '%s' in workflow '%s' has no handler", signal.Name, node.Name),
+					Message:     fmt.Sprintf("Call chain starting from '%s' has depth %d (max: %d)", node.Name, depth, r.MaxDepth),
 					Description: r.Description(),
-					Suggestion:  "Add a signal handler using workflow.SetSignalHandler()",
+					Suggestion:  "Consider flattening the workflow structure or using child workflows strategically",
 					FilePath:    node.FilePath,
-					LineNumber:  signal.LineNumber,
-					NodeName:    node.Name,
+					LineNumber:  node.LineNumber,
+					NodeName:    node.ID(),
 					NodeType:    node.Type,
 				})
 			}
@@ -692,240 +1318,1359 @@ func (r *SignalWithoutHandlerRule) Check(ctx context.Context, graph *analyzer.Te
 	return issues
 }
 
-// QueryWithoutReturnRule checks for query handlers that might not return values.
-type QueryWithoutReturnRule struct{}
+// QueueStarvationRule checks for workflows that fan out more activities onto a task queue
+// than the workers polling it can run at once.
+type QueueStarvationRule struct{}
+
+func (r *QueueStarvationRule) ID() string         { return "TA022" }
+func (r *QueueStarvationRule) Name() string       { return "worker-queue-starvation" }
+func (r *QueueStarvationRule) Category() Category { return CategoryPerformance }
+func (r *QueueStarvationRule) Severity() Severity { return SeverityWarning }
+func (r *QueueStarvationRule) Description() string {
+	return "When a workflow schedules more activities on a task queue than its workers have concurrency slots (or rate limit) for, the excess waits in the queue. Other workflows sharing the queue are starved, and activities with a ScheduleToStartTimeout fail before they ever run."
+}
+
+// queueLoad is the activity fan-out of a workflow onto a single task queue.
+type queueLoad struct {
+	activities      int
+	scheduleToStart string // First ScheduleToStartTimeout seen on the queue's calls
+}
+
+func (r *QueueStarvationRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	if len(graph.Workers) == 0 {
+		return nil
+	}
+
+	var issues []Issue
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+
+		loads := make(map[string]*queueLoad)
+		var queues []string
+		for _, cs := range node.CallSites {
+			if executedType(cs) != "activity" {
+				continue
+			}
+			queue := r.taskQueue(node.Name, cs, graph.Workers)
+			if queue == "" {
+				continue
+			}
+			load, ok := loads[queue]
+			if !ok {
+				load = &queueLoad{}
+				loads[queue] = load
+				queues = append(queues, queue)
+			}
+			load.activities++
+			if cs.ParsedActivityOpts != nil && load.scheduleToStart == "" {
+				load.scheduleToStart = cs.ParsedActivityOpts.ScheduleToStartTimeout
+			}
+		}
+
+		for _, queue := range queues {
+			if issue := r.checkQueue(node, queue, loads[queue], graph.Workers); issue != nil {
+				issues = append(issues, *issue)
+			}
+		}
+	}
+	return issues
+}
+
+// taskQueue returns the queue an activity call is scheduled on: the queue set in its options, or
+// the queue of the worker running the workflow. It returns "" when the queue can't be determined.
+func (r *QueueStarvationRule) taskQueue(workflow string, cs analyzer.CallSite, workers []analyzer.WorkerConfig) string {
+	if cs.ParsedActivityOpts != nil && cs.ParsedActivityOpts.TaskQueue != "" {
+		return cs.ParsedActivityOpts.TaskQueue
+	}
+	for i := range workers {
+		if workers[i].RegistersWorkflow(workflow) {
+			return workers[i].TaskQueue
+		}
+	}
+	if len(workers) == 1 {
+		return workers[0].TaskQueue
+	}
+	return ""
+}
+
+// checkQueue compares a workflow's fan-out onto a queue with the capacity of the queue's workers.
+func (r *QueueStarvationRule) checkQueue(node *analyzer.TemporalNode, queue string, load *queueLoad, workers []analyzer.WorkerConfig) *Issue {
+	slots, found := 0, false
+	var rateLimit float64
+	for i := range workers {
+		if workers[i].TaskQueue != queue {
+			continue
+		}
+		found = true
+		slots += workers[i].ActivitySlots()
+		if limit := workers[i].TaskQueueActivitiesPerSecond; limit > 0 && (rateLimit == 0 || limit < rateLimit) {
+			rateLimit = limit
+		}
+	}
+	if !found {
+		return nil // Queue is polled by workers outside the analyzed code
+	}
+
+	var limits []string
+	if load.activities > slots {
+		limits = append(limits, fmt.Sprintf("its workers run at most %d concurrently", slots))
+	}
+	if rateLimit > 0 && float64(load.activities) > rateLimit {
+		limits = append(limits, fmt.Sprintf("the queue is rate limited to %g/s", rateLimit))
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("Workflow '%s' schedules %d activities on task queue '%s', but %s", node.Name, load.activities, queue, strings.Join(limits, " and "))
+	if load.scheduleToStart != "" {
+		message += fmt.Sprintf("; queued activities may exceed ScheduleToStartTimeout (%s)", load.scheduleToStart)
+	}
+
+	return &Issue{
+		RuleID:      r.ID(),
+		RuleName:    r.Name(),
+		Severity:    r.Severity(),
+		Category:    r.Category(),
+		Message:     message,
+		Description: r.Description(),
+		Suggestion:  "Raise MaxConcurrentActivityExecutionSize on the queue's workers, move bulk activities to a dedicated task queue, or batch the fan-out (e.g. with child workflows)",
+		FilePath:    node.FilePath,
+		LineNumber:  node.LineNumber,
+		NodeName:    node.ID(),
+		NodeType:    node.Type,
+	}
+}
+
+// minTimeoutSamples is the number of constant timeouts of an option a package needs for
+// its median to be meaningful.
+const minTimeoutSamples = 3
+
+// TimeoutOutlierRule checks for activity timeouts far from the other timeouts of their
+// package, which are often unit typos such as time.Hour for time.Minute.
+type TimeoutOutlierRule struct {
+	// Factor is how many times longer or shorter than the package median a timeout must be
+	Factor int
+}
+
+func NewTimeoutOutlierRule(factor int) *TimeoutOutlierRule {
+	if factor <= 1 {
+		factor = 50 // Default: catches h vs m (60x) and m vs s typos
+	}
+	return &TimeoutOutlierRule{Factor: factor}
+}
+
+func (r *TimeoutOutlierRule) ID() string         { return "TA023" }
+func (r *TimeoutOutlierRule) Name() string       { return "timeout-outlier" }
+func (r *TimeoutOutlierRule) Category() Category { return CategoryPerformance }
+func (r *TimeoutOutlierRule) Severity() Severity { return SeverityWarning }
+func (r *TimeoutOutlierRule) Description() string {
+	return "A StartToClose or ScheduleToClose timeout many times longer or shorter than the other timeouts of its package is usually a unit typo, such as 10 * time.Hour for 10 * time.Minute. Too long, a hung activity holds its workflow for hours before being retried; too short, the activity times out on every attempt."
+}
+
+// timeoutGroup is the package and option of an activity timeout.
+type timeoutGroup struct{ pkg, option string }
+
+// timeoutMedians returns the median of the constant timeouts of each package and option
+// with at least minTimeoutSamples of them.
+func timeoutMedians(timeouts []analyzer.ActivityTimeout) map[timeoutGroup]time.Duration {
+	durations := make(map[timeoutGroup][]time.Duration)
+	for _, t := range timeouts {
+		if t.Evaluated && t.Duration > 0 {
+			group := timeoutGroup{t.Node.Package, t.Option}
+			durations[group] = append(durations[group], t.Duration)
+		}
+	}
+	medians := make(map[timeoutGroup]time.Duration)
+	for group, values := range durations {
+		if len(values) >= minTimeoutSamples {
+			medians[group] = analyzer.MedianDuration(values)
+		}
+	}
+	return medians
+}
+
+func (r *TimeoutOutlierRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	timeouts := graph.ActivityTimeouts()
+	medians := timeoutMedians(timeouts)
+	for _, t := range timeouts {
+		median, ok := medians[timeoutGroup{t.Node.Package, t.Option}]
+		if !ok || !t.Evaluated || t.Duration <= 0 {
+			continue
+		}
+		var ratio string
+		switch factor := float64(r.Factor); {
+		case float64(t.Duration) >= float64(median)*factor:
+			ratio = fmt.Sprintf("%.0fx longer than", float64(t.Duration)/float64(median))
+		case float64(t.Duration)*factor <= float64(median):
+			ratio = fmt.Sprintf("%.0fx shorter than", float64(median)/float64(t.Duration))
+		default:
+			continue
+		}
+
+		issues = append(issues, Issue{
+			RuleID:      r.ID(),
+			RuleName:    r.Name(),
+			Severity:    r.Severity(),
+			Category:    r.Category(),
+			Message:     fmt.Sprintf("%s of activity '%s' in '%s' is %s (%s), %s the package median of %s", t.Option, t.CallSite.TargetName, t.Node.Name, t.Expr, t.Duration, ratio, median),
+			Description: r.Description(),
+			Suggestion:  "Check the unit of the timeout (time.Hour vs time.Minute, time.Minute vs time.Second); if the duration is intended, document why next to it",
+			FilePath:    t.CallSite.FilePath,
+			LineNumber:  t.CallSite.LineNumber,
+			NodeName:    t.Node.ID(),
+			NodeType:    t.Node.Type,
+		})
+	}
+	return issues
+}
+
+// =============================================================================
+// Maintenance Rules
+// =============================================================================
+
+// WorkflowWithoutVersioningRule checks for complex workflows without versioning.
+type WorkflowWithoutVersioningRule struct {
+	ComplexityThreshold int
+}
+
+func NewWorkflowWithoutVersioningRule(threshold int) *WorkflowWithoutVersioningRule {
+	if threshold <= 0 {
+		threshold = 5 // Default: workflows with 5+ activities should consider versioning
+	}
+	return &WorkflowWithoutVersioningRule{ComplexityThreshold: threshold}
+}
+
+func (r *WorkflowWithoutVersioningRule) ID() string         { return "TA030" }
+func (r *WorkflowWithoutVersioningRule) Name() string       { return "workflow-without-versioning" }
+func (r *WorkflowWithoutVersioningRule) Category() Category { return CategoryMaintenance }
+func (r *WorkflowWithoutVersioningRule) Severity() Severity { return SeverityInfo }
+func (r *WorkflowWithoutVersioningRule) Description() string {
+	return "Long-running workflows may execute for days or weeks. Without versioning, deploying logic changes can break in-flight executions mid-run, causing failures and data inconsistencies."
+}
+
+func (r *WorkflowWithoutVersioningRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+		// Check if workflow is complex (has many calls)
+		isComplex := len(node.CallSites) >= r.ComplexityThreshold
+		hasVersioning := len(node.Versioning) > 0
+
+		if isComplex && !hasVersioning {
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("Complex workflow '%s' (%d calls) has no versioning", node.Name, len(node.CallSites)),
+				Description: r.Description(),
+				Suggestion:  "Consider using workflow.GetVersion() for safe deployments with running workflows",
+				FilePath:    node.FilePath,
+				LineNumber:  node.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+				Fix: &CodeFix{
+					Description: "Add workflow versioning for safe deployments",
+					Replacements: []Replacement{{
+						FilePath:  node.FilePath,
+						StartLine: node.LineNumber,
+						NewText: `v := workflow.GetVersion(ctx, "initial-version", workflow.DefaultVersion, 1)
+if v == workflow.DefaultVersion {
+	// existing logic
+} else {
+	// new logic
+}`,
+					}},
+				},
+			})
+		}
+	}
+	return issues
+}
+
+// SignalWithoutHandlerRule checks for signal definitions without handlers.
+type SignalWithoutHandlerRule struct{}
+
+func (r *SignalWithoutHandlerRule) ID() string         { return "TA031" }
+func (r *SignalWithoutHandlerRule) Name() string       { return "signal-without-handler" }
+func (r *SignalWithoutHandlerRule) Category() Category { return CategoryReliability }
+func (r *SignalWithoutHandlerRule) Severity() Severity { return SeverityWarning }
+func (r *SignalWithoutHandlerRule) Description() string {
+	return "Unhandled signals are silently dropped. External systems sending signals believe they're communicating with the workflow, but the data goes nowhere - a silent failure that's hard to debug."
+}
+
+func (r *SignalWithoutHandlerRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+		for _, signal := range node.Signals {
+			if signal.Handler == "" {
+				issues = append(issues, Issue{
+					RuleID:      r.ID(),
+					RuleName:    r.Name(),
+					Severity:    r.Severity(),
+					Category:    r.Category(),
+					Message:     fmt.Sprintf("Signal '%s' in workflow '%s' has no handler", signal.Name, node.Name),
+					Description: r.Description(),
+					Suggestion:  "Add a signal handler using workflow.SetSignalHandler()",
+					FilePath:    node.FilePath,
+					LineNumber:  signal.LineNumber,
+					NodeName:    node.ID(),
+					NodeType:    node.Type,
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// QueryWithoutReturnRule checks for query handlers that might not return values.
+type QueryWithoutReturnRule struct{}
+
+func (r *QueryWithoutReturnRule) ID() string         { return "TA032" }
+func (r *QueryWithoutReturnRule) Name() string       { return "query-without-return" }
+func (r *QueryWithoutReturnRule) Category() Category { return CategoryBestPractice }
+func (r *QueryWithoutReturnRule) Severity() Severity { return SeverityInfo }
+func (r *QueryWithoutReturnRule) Description() string {
+	return "Queries exist so external systems can inspect workflow state without affecting execution. A query that returns nothing defeats its purpose and leaves callers without the insight they need."
+}
+
+func (r *QueryWithoutReturnRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+		for _, query := range node.Queries {
+			if query.ReturnType == "" || query.ReturnType == "interface{}" {
+				issues = append(issues, Issue{
+					RuleID:      r.ID(),
+					RuleName:    r.Name(),
+					Severity:    r.Severity(),
+					Category:    r.Category(),
+					Message:     fmt.Sprintf("Query '%s' in workflow '%s' has no typed return", query.Name, node.Name),
+					Description: r.Description(),
+					Suggestion:  "Define a concrete return type for better type safety",
+					FilePath:    node.FilePath,
+					LineNumber:  query.LineNumber,
+					NodeName:    node.ID(),
+					NodeType:    node.Type,
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// ContinueAsNewWithoutConditionRule checks for continue-as-new that might run indefinitely.
+type ContinueAsNewWithoutConditionRule struct{}
+
+func (r *ContinueAsNewWithoutConditionRule) ID() string         { return "TA033" }
+func (r *ContinueAsNewWithoutConditionRule) Name() string       { return "continue-as-new-risk" }
+func (r *ContinueAsNewWithoutConditionRule) Category() Category { return CategoryReliability }
+func (r *ContinueAsNewWithoutConditionRule) Severity() Severity { return SeverityInfo }
+func (r *ContinueAsNewWithoutConditionRule) Description() string {
+	return "Without termination conditions, continue-as-new workflows run forever, accumulating costs and never completing. Every long-running workflow should have a defined end state or maximum iterations."
+}
+
+func (r *ContinueAsNewWithoutConditionRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+		if node.ContinueAsNew != nil {
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("Workflow '%s' uses continue-as-new", node.Name),
+				Description: r.Description(),
+				Suggestion:  "Ensure there's a clear termination condition to prevent infinite continuation",
+				FilePath:    node.FilePath,
+				LineNumber:  node.ContinueAsNew.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+			})
+		}
+	}
+	return issues
+}
+
+// ConsiderQueryHandlerRule suggests using QueryHandlers for workflows with rich heartbeat data.
+// QueryHandlers provide on-demand progress queries at the workflow level, which can be
+// more efficient than storing detailed progress in activity heartbeats.
+type ConsiderQueryHandlerRule struct{}
+
+func (r *ConsiderQueryHandlerRule) ID() string         { return "TA034" }
+func (r *ConsiderQueryHandlerRule) Name() string       { return "consider-query-handler" }
+func (r *ConsiderQueryHandlerRule) Category() Category { return CategoryBestPractice }
+func (r *ConsiderQueryHandlerRule) Severity() Severity { return SeverityInfo }
+func (r *ConsiderQueryHandlerRule) Description() string {
+	return "Workflows with long-running activities often need progress tracking. QueryHandlers provide on-demand progress queries without the serialization overhead of rich heartbeat payloads. Consider using SetQueryHandler for progress state."
+}
+
+func (r *ConsiderQueryHandlerRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" {
+			continue
+		}
+
+		// Check if workflow has activities with heartbeat timeouts but no query handlers
+		hasLongActivities := false
+		for _, callSite := range node.CallSites {
+			if t := executedType(callSite); t == "activity" || t == "local_activity" {
+				if callSite.ParsedActivityOpts != nil && callSite.ParsedActivityOpts.HeartbeatTimeout != "" {
+					hasLongActivities = true
+					break
+				}
+			}
+		}
+
+		// Check if workflow already has query handlers
+		hasQueryHandler := len(node.Queries) > 0
+
+		// Suggest QueryHandler if workflow has long activities but no query handlers
+		if hasLongActivities && !hasQueryHandler {
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("Workflow '%s' has long-running activities but no QueryHandler for progress tracking", node.Name),
+				Description: r.Description(),
+				Suggestion:  "Consider adding a QueryHandler for progress state instead of or in addition to rich heartbeat payloads",
+				FilePath:    node.FilePath,
+				LineNumber:  node.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+				Fix: &CodeFix{
+					Description: "Add QueryHandler for progress tracking",
+					Replacements: []Replacement{{
+						FilePath:  node.FilePath,
+						StartLine: node.LineNumber,
+						NewText: `err := workflow.SetQueryHandler(ctx, "progress", func() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"phase":     currentPhase,
+		"processed": itemsProcessed,
+		"total":     totalItems,
+	}, nil
+})
+if err != nil {
+	return err
+}`,
+					}},
+				},
+			})
+		}
+	}
+
+	return issues
+}
+
+// UntestedWorkflowRule checks for complex workflows that no test exercises.
+// Test coverage is correlated from testsuite usage (env.ExecuteWorkflow, replayers) in _test.go files.
+type UntestedWorkflowRule struct {
+	ComplexityThreshold int
+}
+
+func NewUntestedWorkflowRule(threshold int) *UntestedWorkflowRule {
+	if threshold <= 0 {
+		threshold = 5 // Default: workflows with 5+ Temporal operations should have a test
+	}
+	return &UntestedWorkflowRule{ComplexityThreshold: threshold}
+}
+
+func (r *UntestedWorkflowRule) ID() string         { return "TA035" }
+func (r *UntestedWorkflowRule) Name() string       { return "workflow-without-test" }
+func (r *UntestedWorkflowRule) Category() Category { return CategoryMaintenance }
+func (r *UntestedWorkflowRule) Severity() Severity { return SeverityInfo }
+func (r *UntestedWorkflowRule) Description() string {
+	return "Complex workflows without a testsuite or replay test are risky to change. Determinism regressions and broken activity wiring only surface in production, often in long-running executions."
+}
+
+func (r *UntestedWorkflowRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	for _, node := range graph.Nodes {
+		if node.Type != "workflow" || node.FilePath == "" {
+			continue
+		}
+
+		complexity := len(node.CallSites) + len(node.Signals) + len(node.Queries) + len(node.Updates) + len(node.Timers)
+		if complexity < r.ComplexityThreshold || node.IsTested() {
+			continue
+		}
+
+		issues = append(issues, Issue{
+			RuleID:      r.ID(),
+			RuleName:    r.Name(),
+			Severity:    r.Severity(),
+			Category:    r.Category(),
+			Message:     fmt.Sprintf("Complex workflow '%s' (%d Temporal operations) has no workflow test", node.Name, complexity),
+			Description: r.Description(),
+			Suggestion:  "Add a unit test using testsuite.WorkflowTestSuite (env.ExecuteWorkflow) or a replay test with worker.WorkflowReplayer",
+			FilePath:    node.FilePath,
+			LineNumber:  node.LineNumber,
+			NodeName:    node.ID(),
+			NodeType:    node.Type,
+		})
+	}
+	return issues
+}
+
+// UnversionedWorkflowChangeRule compares workflows against the graph of a base git ref and flags
+// workflows whose activity/child workflow calls were added, removed or reordered without a new
+// workflow.GetVersion guard. The rule does nothing unless a base graph is set.
+type UnversionedWorkflowChangeRule struct {
+	Base *analyzer.TemporalGraph
+}
+
+func NewUnversionedWorkflowChangeRule(base *analyzer.TemporalGraph) *UnversionedWorkflowChangeRule {
+	return &UnversionedWorkflowChangeRule{Base: base}
+}
+
+func (r *UnversionedWorkflowChangeRule) ID() string         { return "TA036" }
+func (r *UnversionedWorkflowChangeRule) Name() string       { return "unversioned-workflow-change" }
+func (r *UnversionedWorkflowChangeRule) Category() Category { return CategoryReliability }
+func (r *UnversionedWorkflowChangeRule) Severity() Severity { return SeverityError }
+func (r *UnversionedWorkflowChangeRule) Description() string {
+	return "Running executions replay their history against the new code. Adding, removing or reordering activity and child workflow calls without a workflow.GetVersion guard makes replay diverge from history and fails in-flight workflows with non-determinism errors."
+}
+
+func (r *UnversionedWorkflowChangeRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	if r.Base == nil {
+		return nil
+	}
+
+	var issues []Issue
+	for name, node := range graph.Nodes {
+		select {
+		case <-ctx.Done():
+			return issues
+		default:
+		}
+
+		if node.Type != "workflow" {
+			continue
+		}
+		baseNode, ok := r.Base.Nodes[name]
+		if !ok || baseNode.Type != "workflow" {
+			continue // New workflows have no running executions
+		}
+		if hasNewVersionGuard(baseNode, node) {
+			continue
+		}
+
+		baseCalls := commandCalls(baseNode)
+		headCalls := commandCalls(node)
+		added, removed, reordered := diffCommandCalls(baseCalls, headCalls)
+
+		for _, cs := range added {
+			issues = append(issues, r.issue(node, cs.LineNumber,
+				fmt.Sprintf("Workflow '%s' adds a call to '%s' without a workflow.GetVersion guard", node.Name, cs.TargetName)))
+		}
+		for _, cs := range removed {
+			issues = append(issues, r.issue(node, node.LineNumber,
+				fmt.Sprintf("Workflow '%s' removes the call to '%s' (base line %d) without a workflow.GetVersion guard", node.Name, cs.TargetName, cs.LineNumber)))
+		}
+		if reordered {
+			issues = append(issues, r.issue(node, node.LineNumber,
+				fmt.Sprintf("Workflow '%s' reorders its activity calls without a workflow.GetVersion guard", node.Name)))
+		}
+	}
+	return issues
+}
+
+func (r *UnversionedWorkflowChangeRule) issue(node *analyzer.TemporalNode, line int, message string) Issue {
+	return Issue{
+		RuleID:      r.ID(),
+		RuleName:    r.Name(),
+		Severity:    r.Severity(),
+		Category:    r.Category(),
+		Message:     message,
+		Description: r.Description(),
+		Suggestion:  "Wrap the change in workflow.GetVersion(ctx, \"change-id\", workflow.DefaultVersion, 1) so running executions keep the old behavior",
+		FilePath:    node.FilePath,
+		LineNumber:  line,
+		NodeName:    node.ID(),
+		NodeType:    node.Type,
+	}
+}
+
+// hasNewVersionGuard reports whether the workflow introduced a GetVersion change ID, or raised
+// the max version of an existing one, compared to the base.
+func hasNewVersionGuard(base, head *analyzer.TemporalNode) bool {
+	baseMax := make(map[string]int)
+	for _, v := range base.Versioning {
+		baseMax[v.ChangeID] = v.MaxVersion
+	}
+	for _, v := range head.Versioning {
+		baseVersion, ok := baseMax[v.ChangeID]
+		if !ok || v.MaxVersion > baseVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// commandCalls returns the call sites that produce history commands, in source order.
+func commandCalls(node *analyzer.TemporalNode) []analyzer.CallSite {
+	var calls []analyzer.CallSite
+	for _, cs := range node.CallSites {
+		switch cs.TargetType {
+		case "activity", "local_activity", "child_workflow":
+			calls = append(calls, cs)
+		}
+	}
+	sort.SliceStable(calls, func(i, j int) bool {
+		return calls[i].LineNumber < calls[j].LineNumber
+	})
+	return calls
+}
+
+// diffCommandCalls compares two call sequences by target name. Calls whose count changed are
+// reported as added or removed; the remaining calls are reordered if their order differs.
+func diffCommandCalls(base, head []analyzer.CallSite) (added, removed []analyzer.CallSite, reordered bool) {
+	baseCount := make(map[string]int)
+	for _, cs := range base {
+		baseCount[cs.TargetName]++
+	}
+	headCount := make(map[string]int)
+	for _, cs := range head {
+		headCount[cs.TargetName]++
+	}
+
+	// keep returns the calls shared by both sequences and collects the extra ones
+	keep := func(calls []analyzer.CallSite, other map[string]int, extra *[]analyzer.CallSite) []string {
+		seen := make(map[string]int)
+		var common []string
+		for _, cs := range calls {
+			seen[cs.TargetName]++
+			if seen[cs.TargetName] > other[cs.TargetName] {
+				*extra = append(*extra, cs)
+				continue
+			}
+			common = append(common, cs.TargetName)
+		}
+		return common
+	}
+
+	baseCommon := keep(base, headCount, &removed)
+	headCommon := keep(head, baseCount, &added)
+
+	for i := range baseCommon {
+		if baseCommon[i] != headCommon[i] {
+			reordered = true
+			break
+		}
+	}
+	return added, removed, reordered
+}
+
+// AnnotationRequirement is a doc comment annotation that nodes of a given type must declare.
+type AnnotationRequirement struct {
+	NodeType   string // "workflow", "activity", or empty for both
+	Annotation string // Annotation key without the leading @, e.g. "owner"
+}
+
+// ParseAnnotationRequirement parses a requirement of the form "owner", "@owner" or "workflow/sla".
+func ParseAnnotationRequirement(spec string) (AnnotationRequirement, error) {
+	spec = strings.TrimSpace(spec)
+	var req AnnotationRequirement
+	if nodeType, annotation, ok := strings.Cut(spec, "/"); ok {
+		if nodeType != "workflow" && nodeType != "activity" {
+			return req, fmt.Errorf("invalid node type %q in annotation requirement %q (valid: workflow, activity)", nodeType, spec)
+		}
+		req.NodeType = nodeType
+		spec = annotation
+	}
+	req.Annotation = strings.TrimPrefix(spec, "@")
+	if req.Annotation == "" {
+		return req, fmt.Errorf("empty annotation in requirement %q", spec)
+	}
+	return req, nil
+}
+
+// MissingAnnotationRule checks that workflows and activities declare user-required
+// doc comment annotations such as @owner or @sla.
+type MissingAnnotationRule struct {
+	Required []AnnotationRequirement
+}
+
+func NewMissingAnnotationRule(required []AnnotationRequirement) *MissingAnnotationRule {
+	return &MissingAnnotationRule{Required: required}
+}
+
+func (r *MissingAnnotationRule) ID() string         { return "TA037" }
+func (r *MissingAnnotationRule) Name() string       { return "missing-annotation" }
+func (r *MissingAnnotationRule) Category() Category { return CategoryMaintenance }
+func (r *MissingAnnotationRule) Severity() Severity { return SeverityWarning }
+func (r *MissingAnnotationRule) Description() string {
+	return "Team conventions require workflows and activities to declare annotations such as @owner or @sla in their doc comments. Without them, on-call engineers cannot tell who owns a failing execution or what latency it promises."
+}
+
+func (r *MissingAnnotationRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	if len(r.Required) == 0 {
+		return nil
+	}
+
+	var issues []Issue
+	for _, node := range graph.Nodes {
+		if (node.Type != "workflow" && node.Type != "activity") || node.FilePath == "" {
+			continue
+		}
+
+		for _, req := range r.Required {
+			if req.NodeType != "" && req.NodeType != node.Type {
+				continue
+			}
+			if _, ok := node.Annotations[req.Annotation]; ok {
+				continue
+			}
+
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("Missing required @%s annotation on %s '%s'", req.Annotation, node.Type, node.Name),
+				Description: r.Description(),
+				Suggestion:  fmt.Sprintf("Add a '// @%s <value>' line to the doc comment of %s", req.Annotation, node.Name),
+				FilePath:    node.FilePath,
+				LineNumber:  node.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+			})
+		}
+	}
+	return issues
+}
+
+// ContractDriftRule compares workflow contracts against the contracts of a base git ref and
+// flags changes to inputs, outputs, handlers and referenced types. Breaking changes are errors;
+// additive changes are reported as info.
+type ContractDriftRule struct {
+	Base *contracts.Document
+	Head *contracts.Document
+}
+
+func NewContractDriftRule(base, head *contracts.Document) *ContractDriftRule {
+	return &ContractDriftRule{Base: base, Head: head}
+}
+
+func (r *ContractDriftRule) ID() string         { return "TA038" }
+func (r *ContractDriftRule) Name() string       { return "workflow-contract-drift" }
+func (r *ContractDriftRule) Category() Category { return CategoryReliability }
+func (r *ContractDriftRule) Severity() Severity { return SeverityError }
+func (r *ContractDriftRule) Description() string {
+	return "Workflow inputs, outputs, signals, queries and updates are a contract with callers in other services. Changing them breaks clients that were built against the old contract and executions already in flight."
+}
+
+func (r *ContractDriftRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	if r.Base == nil || r.Head == nil {
+		return nil
+	}
+
+	var issues []Issue
+	for _, change := range contracts.Diff(r.Base, r.Head) {
+		severity := SeverityInfo
+		suggestion := "Publish the updated contract (temporal-analyzer contracts) so callers can adopt the new fields"
+		if change.Breaking {
+			severity = r.Severity()
+			suggestion = "Keep the old contract working: add new fields instead of changing existing ones, or introduce a new workflow/handler name"
+		}
+
+		issue := Issue{
+			RuleID:      r.ID(),
+			RuleName:    r.Name(),
+			Severity:    severity,
+			Category:    r.Category(),
+			Message:     fmt.Sprintf("Contract of workflow '%s' changed: %s", change.Workflow, change.Message),
+			Description: r.Description(),
+			Suggestion:  suggestion,
+			NodeName:    change.Workflow,
+			NodeType:    "workflow",
+		}
+		if node, ok := graph.Nodes[change.Workflow]; ok {
+			issue.FilePath = node.FilePath
+			issue.LineNumber = node.LineNumber
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// WorkflowTypeHygieneRule checks that workflow inputs and outputs use dedicated, versionable types.
+type WorkflowTypeHygieneRule struct {
+	Contracts *contracts.Document
+	// Dedicated are type name globs for types owned by a workflow contract (e.g. "*Input")
+	Dedicated []string
+}
+
+func NewWorkflowTypeHygieneRule(doc *contracts.Document, dedicated []string) *WorkflowTypeHygieneRule {
+	if len(dedicated) == 0 {
+		dedicated = contracts.DefaultDedicatedTypes
+	}
+	return &WorkflowTypeHygieneRule{Contracts: doc, Dedicated: dedicated}
+}
+
+func (r *WorkflowTypeHygieneRule) ID() string         { return "TA039" }
+func (r *WorkflowTypeHygieneRule) Name() string       { return "workflow-type-hygiene" }
+func (r *WorkflowTypeHygieneRule) Category() Category { return CategoryMaintenance }
+func (r *WorkflowTypeHygieneRule) Severity() Severity { return SeverityWarning }
+func (r *WorkflowTypeHygieneRule) Description() string {
+	return "Workflow inputs and outputs are persisted in history and decoded by running executions. Types shared with other packages change for unrelated reasons, and loosely typed fields (interface{}, maps of interface{}, json.RawMessage) change shape silently. Dedicated input/output types can be versioned with the workflow."
+}
+
+func (r *WorkflowTypeHygieneRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	for _, finding := range contracts.CheckTypes(r.Contracts, r.Dedicated) {
+		issue := Issue{
+			RuleID:      r.ID(),
+			RuleName:    r.Name(),
+			Severity:    r.Severity(),
+			Category:    r.Category(),
+			Message:     fmt.Sprintf("Workflow '%s' %s", finding.Workflow, finding.Message),
+			Description: r.Description(),
+			Suggestion:  fmt.Sprintf("Introduce dedicated types such as %sInput/%sOutput with explicit fields, and copy data into them at the workflow boundary", finding.Workflow, finding.Workflow),
+			NodeName:    finding.Workflow,
+			NodeType:    "workflow",
+		}
+		if node, ok := graph.Nodes[finding.Workflow]; ok {
+			issue.FilePath = node.FilePath
+			issue.LineNumber = node.LineNumber
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// =============================================================================
+// Type Safety Rules
+// =============================================================================
+
+// ArgumentsMismatchRule checks for activities/workflows called with wrong arguments or return types.
+type ArgumentsMismatchRule struct{}
+
+func (r *ArgumentsMismatchRule) ID() string         { return "TA040" }
+func (r *ArgumentsMismatchRule) Name() string       { return "arguments-mismatch" }
+func (r *ArgumentsMismatchRule) Category() Category { return CategoryReliability }
+func (r *ArgumentsMismatchRule) Severity() Severity { return SeverityError }
+func (r *ArgumentsMismatchRule) Description() string {
+	return "Calling an activity or workflow with wrong number/types of arguments, or reading results into wrong types, causes runtime errors. Temporal deserializes by position and type, so mismatches fail at runtime."
+}
+
+func (r *ArgumentsMismatchRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+
+	for _, node := range graph.Nodes {
+		// Check each call site
+		for _, callSite := range node.CallSites {
+			// Find the target node
+			targetNode, exists := graph.Nodes[callSite.TargetName]
+			if !exists {
+				continue
+			}
+
+			// Check arguments against the signature of the workflow or activity being called
+			if check, ok := CheckArguments(callSite, targetNode); ok && check.Expected != callSite.ArgumentCount {
+				issues = append(issues, Issue{
+					RuleID:   r.ID(),
+					RuleName: r.Name(),
+					Severity: r.Severity(),
+					Category: r.Category(),
+					Message: fmt.Sprintf(
+						"Call to '%s' passes %d argument(s), but %s '%s' expects %d",
+						callSite.TargetName,
+						callSite.ArgumentCount,
+						targetNode.Type,
+						targetNode.Name,
+						check.Expected,
+					),
+					Description: r.Description(),
+					Suggestion:  fmt.Sprintf("Update the call to pass exactly %d argument(s) matching the %s signature", check.Expected, targetNode.Type),
+					FilePath:    callSite.FilePath,
+					LineNumber:  callSite.LineNumber,
+					NodeName:    node.ID(),
+					NodeType:    node.Type,
+				})
+			} else if ok && check.Position > 0 {
+				issues = append(issues, Issue{
+					RuleID:   r.ID(),
+					RuleName: r.Name(),
+					Severity: r.Severity(),
+					Category: r.Category(),
+					Message: fmt.Sprintf(
+						"Call to '%s' passes '%s' as argument %d, but %s '%s' expects '%s'",
+						callSite.TargetName,
+						check.ArgType,
+						check.Position,
+						targetNode.Type,
+						targetNode.Name,
+						check.ParamType,
+					),
+					Description: r.Description(),
+					Suggestion:  fmt.Sprintf("Pass a '%s' as argument %d", check.ParamType, check.Position),
+					FilePath:    callSite.FilePath,
+					LineNumber:  callSite.LineNumber,
+					NodeName:    node.ID(),
+					NodeType:    node.Type,
+				})
+			}
+
+			// Check the result read by the caller against the target's value result
+			if callSite.ResultType != "" && targetNode.ResultSignature() != "" {
+				valueType := targetNode.ValueType()
+				returns := fmt.Sprintf("returns '%s'", valueType)
+				suggestion := fmt.Sprintf("Use a variable of type '%s' to receive the result", valueType)
+				if valueType == "" {
+					// Only an error: nothing to read
+					valueType = "error"
+					returns = "returns only an error"
+					suggestion = "Pass nil to Get, or return a value from " + targetNode.Name
+				}
+				if !isTypeCompatible(callSite.ResultType, valueType) {
+					issues = append(issues, Issue{
+						RuleID:   r.ID(),
+						RuleName: r.Name(),
+						Severity: r.Severity(),
+						Category: r.Category(),
+						Message: fmt.Sprintf(
+							"Call to '%s' reads result as '%s', but %s '%s' %s",
+							callSite.TargetName,
+							callSite.ResultType,
+							targetNode.Type,
+							targetNode.Name,
+							returns,
+						),
+						Description: r.Description(),
+						Suggestion:  suggestion,
+						FilePath:    callSite.FilePath,
+						LineNumber:  callSite.LineNumber,
+						NodeName:    node.ID(),
+						NodeType:    node.Type,
+					})
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+// ArgumentCheck is the result of comparing the arguments of a call with the parameters of its target.
+type ArgumentCheck struct {
+	Expected  int    // Non-context parameters of the target
+	Position  int    // 1-based position of the first incompatible argument, 0 if none
+	ArgType   string // Type passed at Position
+	ParamType string // Type expected at Position
+}
+
+// CheckArguments compares the arguments of a call with the parameters of its target, as TA040
+// does. ok is false when the target's signature is unknown: it is not a workflow or activity
+// declared in the analyzed code.
+func CheckArguments(call analyzer.CallSite, target *analyzer.TemporalNode) (check ArgumentCheck, ok bool) {
+	if target == nil || target.Unresolved || (target.Type != "workflow" && target.Type != "activity") {
+		return ArgumentCheck{}, false
+	}
+	check.Expected = countNonContextParams(target.Parameters)
+	if check.Expected == call.ArgumentCount {
+		check.Position, check.ArgType, check.ParamType = firstArgumentMismatch(call.ArgumentTypes, target.ParameterTypes)
+	}
+	return check, true
+}
+
+// ActivityResultUnusedRule checks for activity results discarded with .Get(ctx, nil).
+type ActivityResultUnusedRule struct{}
+
+func (r *ActivityResultUnusedRule) ID() string         { return "TA041" }
+func (r *ActivityResultUnusedRule) Name() string       { return "activity-result-unused" }
+func (r *ActivityResultUnusedRule) Category() Category { return CategoryMaintenance }
+func (r *ActivityResultUnusedRule) Severity() Severity { return SeverityInfo }
+func (r *ActivityResultUnusedRule) Description() string {
+	return "Waiting on an activity with .Get(ctx, nil) discards a result the activity computes and records in history. This often hides a missed data dependency; if only completion matters, the activity may not need to return a value, or the work could run fire-and-forget in a child workflow."
+}
+
+func (r *ActivityResultUnusedRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+
+	for _, node := range graph.Nodes {
+		for _, callSite := range node.CallSites {
+			if callSite.TargetType != "activity" && callSite.TargetType != "local_activity" {
+				continue
+			}
+			if callSite.ResultType != "nil" {
+				continue
+			}
+
+			targetNode, exists := graph.Nodes[callSite.TargetName]
+			if !exists || targetNode.ValueType() == "" {
+				continue
+			}
+
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("Result of activity '%s' (%s) is discarded with .Get(ctx, nil)", callSite.TargetName, targetNode.ValueType()),
+				Description: r.Description(),
+				Suggestion:  fmt.Sprintf("Read the result into a '%s' variable, return only error from '%s', or start the work as a child workflow if it should not be awaited", targetNode.ValueType(), callSite.TargetName),
+				FilePath:    node.FilePath,
+				LineNumber:  callSite.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+			})
+		}
+	}
+
+	return issues
+}
+
+// UnserializablePayloadRule checks workflow and activity signatures for values the default
+// data converter can't serialize, as found by analyzer.CheckSerialization.
+type UnserializablePayloadRule struct {
+	Findings []analyzer.SerializationFinding
+}
+
+func NewUnserializablePayloadRule(findings []analyzer.SerializationFinding) *UnserializablePayloadRule {
+	return &UnserializablePayloadRule{Findings: findings}
+}
+
+func (r *UnserializablePayloadRule) ID() string         { return "TA042" }
+func (r *UnserializablePayloadRule) Name() string       { return "unserializable-payload" }
+func (r *UnserializablePayloadRule) Category() Category { return CategoryReliability }
+func (r *UnserializablePayloadRule) Severity() Severity { return SeverityError }
+func (r *UnserializablePayloadRule) Description() string {
+	return "Workflow and activity arguments and results are encoded by the data converter, JSON by default. Channels, functions and complex numbers fail to encode at runtime, maps need string or integer keys, and sync primitives and structs with only unexported fields encode as {} and arrive as zero values."
+}
+
+func (r *UnserializablePayloadRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	for _, finding := range r.Findings {
+		node, ok := graph.Nodes[finding.Node]
+		if !ok {
+			continue
+		}
+		issues = append(issues, Issue{
+			RuleID:      r.ID(),
+			RuleName:    r.Name(),
+			Severity:    r.Severity(),
+			Category:    r.Category(),
+			Message:     fmt.Sprintf("%s '%s' passes '%s' of type %s, which the data converter can't serialize (%s)", strings.ToUpper(node.Type[:1])+node.Type[1:], node.Name, finding.Path, finding.Type, finding.Reason),
+			Description: r.Description(),
+			Suggestion:  fmt.Sprintf("Keep '%s' out of the %s's signature: pass plain data such as IDs or exported fields and rebuild the value inside, or give its type a MarshalJSON method", finding.Path, node.Type),
+			FilePath:    node.FilePath,
+			LineNumber:  node.LineNumber,
+			NodeName:    node.ID(),
+			NodeType:    node.Type,
+		})
+	}
+	return issues
+}
+
+// ChildWorkflowActivityContextRule checks for child workflows started with a context that
+// carries activity options instead of child workflow options.
+type ChildWorkflowActivityContextRule struct{}
 
-func (r *QueryWithoutReturnRule) ID() string         { return "TA032" }
-func (r *QueryWithoutReturnRule) Name() string       { return "query-without-return" }
-func (r *QueryWithoutReturnRule) Category() Category { return CategoryBestPractice }
-func (r *QueryWithoutReturnRule) Severity() Severity { return SeverityInfo }
-func (r *QueryWithoutReturnRule) Description() string {
-	return "Queries exist so external systems can inspect workflow state without affecting execution. A query that returns nothing defeats its purpose and leaves callers without the insight they need."
+func (r *ChildWorkflowActivityContextRule) ID() string         { return "TA043" }
+func (r *ChildWorkflowActivityContextRule) Name() string       { return "child-workflow-activity-context" }
+func (r *ChildWorkflowActivityContextRule) Category() Category { return CategoryReliability }
+func (r *ChildWorkflowActivityContextRule) Severity() Severity { return SeverityWarning }
+func (r *ChildWorkflowActivityContextRule) Description() string {
+	return "ExecuteChildWorkflow reads ChildWorkflowOptions set with workflow.WithChildOptions and ignores the ActivityOptions of its context. A child started on a context configured with workflow.WithActivityOptions runs with the default options instead: no timeouts, no retry policy and the parent's task queue."
 }
 
-func (r *QueryWithoutReturnRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+func (r *ChildWorkflowActivityContextRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
 	var issues []Issue
 	for _, node := range graph.Nodes {
-		if node.Type != "workflow" {
-			continue
-		}
-		for _, query := range node.Queries {
-			if query.ReturnType == "" || query.ReturnType == "interface{}" {
-				issues = append(issues, Issue{
-					RuleID:      r.ID(),
-					RuleName:    r.Name(),
-					Severity:    r.Severity(),
-					Category:    r.Category(),
-					Message:     fmt.Sprintf("Query '%s' in workflow '%s' has no typed return", query.Name, node.Name),
-					Description: r.Description(),
-					Suggestion:  "Define a concrete return type for better type safety",
-					FilePath:    node.FilePath,
-					LineNumber:  query.LineNumber,
-					NodeName:    node.Name,
-					NodeType:    node.Type,
-				})
+		for _, callSite := range node.CallSites {
+			if !callSite.ActivityOptionsContext || executedType(callSite) != "child_workflow" {
+				continue
 			}
+			issues = append(issues, Issue{
+				RuleID:      r.ID(),
+				RuleName:    r.Name(),
+				Severity:    r.Severity(),
+				Category:    r.Category(),
+				Message:     fmt.Sprintf("Child workflow '%s' is started with a context carrying activity options but no child workflow options", callSite.TargetName),
+				Description: r.Description(),
+				Suggestion:  fmt.Sprintf("Set workflow.ChildWorkflowOptions with workflow.WithChildOptions before starting '%s'; activity timeouts and retry policies don't apply to child workflows", callSite.TargetName),
+				FilePath:    node.FilePath,
+				LineNumber:  callSite.LineNumber,
+				NodeName:    node.ID(),
+				NodeType:    node.Type,
+			})
 		}
 	}
 	return issues
 }
 
-// ContinueAsNewWithoutConditionRule checks for continue-as-new that might run indefinitely.
-type ContinueAsNewWithoutConditionRule struct{}
+// ActivityCalledDirectlyRule checks for workflows calling an activity function as a plain Go
+// function instead of executing it with workflow.ExecuteActivity.
+type ActivityCalledDirectlyRule struct{}
 
-func (r *ContinueAsNewWithoutConditionRule) ID() string         { return "TA033" }
-func (r *ContinueAsNewWithoutConditionRule) Name() string       { return "continue-as-new-risk" }
-func (r *ContinueAsNewWithoutConditionRule) Category() Category { return CategoryReliability }
-func (r *ContinueAsNewWithoutConditionRule) Severity() Severity { return SeverityInfo }
-func (r *ContinueAsNewWithoutConditionRule) Description() string {
-	return "Without termination conditions, continue-as-new workflows run forever, accumulating costs and never completing. Every long-running workflow should have a defined end state or maximum iterations."
+func (r *ActivityCalledDirectlyRule) ID() string         { return "TA044" }
+func (r *ActivityCalledDirectlyRule) Name() string       { return "activity-called-directly" }
+func (r *ActivityCalledDirectlyRule) Category() Category { return CategoryReliability }
+func (r *ActivityCalledDirectlyRule) Severity() Severity { return SeverityError }
+func (r *ActivityCalledDirectlyRule) Description() string {
+	return "An activity called as a plain Go function runs inside the workflow task: it gets no timeouts or retries, its result is not recorded in the history, and its side effects run again on every replay. Activities must be executed with workflow.ExecuteActivity (or ExecuteLocalActivity)."
 }
 
-func (r *ContinueAsNewWithoutConditionRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+func (r *ActivityCalledDirectlyRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	// Activity functions are matched by package and name. Activity methods are matched by
+	// method name, since the type of the receiver variable is unknown; names shared by
+	// several activity methods are skipped.
+	index := directCallIndex{
+		functions: make(map[[2]string][]*analyzer.TemporalNode),
+		methods:   make(map[string][]*analyzer.TemporalNode),
+	}
+	for _, node := range graph.Nodes {
+		if node.Type != "activity" || node.Unresolved {
+			continue
+		}
+		if i := strings.LastIndex(node.Name, "."); i >= 0 {
+			index.methods[node.Name[i+1:]] = append(index.methods[node.Name[i+1:]], node)
+		} else {
+			key := [2]string{node.Package, node.Name}
+			index.functions[key] = append(index.functions[key], node)
+		}
+	}
+
 	var issues []Issue
 	for _, node := range graph.Nodes {
 		if node.Type != "workflow" {
 			continue
 		}
-		if node.ContinueAsNew != nil {
+
+		reported := make(map[string]bool)
+		report := func(activity *analyzer.TemporalNode, call string, line int) {
+			evidence := activityEvidence(graph, activity)
+			if evidence == "" || reported[activity.ID()] {
+				return
+			}
+			reported[activity.ID()] = true
 			issues = append(issues, Issue{
 				RuleID:      r.ID(),
 				RuleName:    r.Name(),
 				Severity:    r.Severity(),
 				Category:    r.Category(),
-				Message:     fmt.Sprintf("Workflow '%s' uses continue-as-new", node.Name),
+				Message:     fmt.Sprintf("Workflow '%s' calls activity '%s' directly as %s(); it is %s", node.Name, activity.Name, call, evidence),
 				Description: r.Description(),
-				Suggestion:  "Ensure there's a clear termination condition to prevent infinite continuation",
+				Suggestion:  fmt.Sprintf("Execute it with workflow.ExecuteActivity(ctx, %s, ...).Get(ctx, &result) on a context with activity options", call),
 				FilePath:    node.FilePath,
-				LineNumber:  node.ContinueAsNew.LineNumber,
-				NodeName:    node.Name,
+				LineNumber:  line,
+				NodeName:    node.ID(),
 				NodeType:    node.Type,
 			})
 		}
+
+		// Calls resolved through helper functions (--internal-depth) link the activity node
+		for _, cs := range node.CallSites {
+			if cs.CallType == "internal" && cs.TargetType == "activity" {
+				if activity, ok := graph.Nodes[cs.TargetName]; ok {
+					report(activity, activity.Name, cs.LineNumber)
+				}
+			}
+		}
+
+		for _, call := range node.InternalCalls {
+			name := call.TargetName
+			if call.Receiver != "" {
+				name = call.Receiver + "." + call.TargetName
+			}
+			for _, activity := range index.targets(node, call) {
+				report(activity, name, call.LineNumber)
+			}
+		}
 	}
 	return issues
 }
 
-// ConsiderQueryHandlerRule suggests using QueryHandlers for workflows with rich heartbeat data.
-// QueryHandlers provide on-demand progress queries at the workflow level, which can be
-// more efficient than storing detailed progress in activity heartbeats.
-type ConsiderQueryHandlerRule struct{}
+// directCallIndex indexes activities by the plain Go calls that can refer to them.
+type directCallIndex struct {
+	functions map[[2]string][]*analyzer.TemporalNode // By package and function name
+	methods   map[string][]*analyzer.TemporalNode    // By method name
+}
 
-func (r *ConsiderQueryHandlerRule) ID() string         { return "TA034" }
-func (r *ConsiderQueryHandlerRule) Name() string       { return "consider-query-handler" }
-func (r *ConsiderQueryHandlerRule) Category() Category { return CategoryBestPractice }
-func (r *ConsiderQueryHandlerRule) Severity() Severity { return SeverityInfo }
-func (r *ConsiderQueryHandlerRule) Description() string {
-	return "Workflows with long-running activities often need progress tracking. QueryHandlers provide on-demand progress queries without the serialization overhead of rich heartbeat payloads. Consider using SetQueryHandler for progress state."
+// targets returns the activity a workflow's plain Go call can refer to: a function of the
+// workflow's package, a function of the package named by the receiver, or the only activity
+// method with the called name.
+func (idx directCallIndex) targets(workflow *analyzer.TemporalNode, call analyzer.InternalCall) []*analyzer.TemporalNode {
+	if call.Receiver == "" {
+		return idx.functions[[2]string{workflow.Package, call.TargetName}]
+	}
+	if targets := idx.functions[[2]string{call.Receiver, call.TargetName}]; len(targets) > 0 {
+		return targets
+	}
+	if len(idx.methods[call.TargetName]) == 1 {
+		return idx.methods[call.TargetName]
+	}
+	return nil
 }
 
-func (r *ConsiderQueryHandlerRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
-	var issues []Issue
+// activityEvidence describes why a node is known to be an activity: the worker registering
+// it, or a workflow executing it. It returns "" without such evidence. It has no file
+// positions, so the message matches the same issue at a diff base where lines moved.
+func activityEvidence(graph *analyzer.TemporalGraph, activity *analyzer.TemporalNode) string {
+	for i := range graph.Workers {
+		w := &graph.Workers[i]
+		if w.RegistersActivity(activity.Name) {
+			return fmt.Sprintf("registered on the worker for task queue '%s'", w.TaskQueue)
+		}
+	}
+	for _, ref := range activity.CalledBy {
+		if ref.CallType != "internal" {
+			return fmt.Sprintf("executed as an activity by '%s'", ref.Name)
+		}
+	}
+	for _, reason := range activity.DetectionReasons {
+		if reason == analyzer.ReasonRegistered {
+			return "registered as an activity"
+		}
+	}
+	return ""
+}
+
+// WorkflowContextEscapeRule checks for workflow.Context values stored in struct fields or
+// package variables, or shared across goroutine boundaries.
+type WorkflowContextEscapeRule struct{}
+
+func (r *WorkflowContextEscapeRule) ID() string         { return "TA045" }
+func (r *WorkflowContextEscapeRule) Name() string       { return "workflow-context-escape" }
+func (r *WorkflowContextEscapeRule) Category() Category { return CategoryReliability }
+func (r *WorkflowContextEscapeRule) Severity() Severity { return SeverityWarning }
+func (r *WorkflowContextEscapeRule) Description() string {
+	return "A workflow.Context is bound to one workflow execution and its coroutine. Stored in a struct field or package variable it outlives the call and can be used by another execution or after a replay, and used from a native goroutine or a workflow.Go function other than its own it breaks the deterministic scheduling of the workflow. Pass the context explicitly and use the one workflow.Go provides."
+}
 
+func (r *WorkflowContextEscapeRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
 	for _, node := range graph.Nodes {
 		if node.Type != "workflow" {
 			continue
 		}
 
-		// Check if workflow has activities with heartbeat timeouts but no query handlers
-		hasLongActivities := false
-		for _, callSite := range node.CallSites {
-			if callSite.CallType == "activity" || callSite.CallType == "local_activity" {
-				if callSite.ParsedActivityOpts != nil && callSite.ParsedActivityOpts.HeartbeatTimeout != "" {
-					hasLongActivities = true
-					break
-				}
+		for _, escape := range node.ContextEscapes {
+			var message, suggestion string
+			switch escape.Kind {
+			case analyzer.EscapeField:
+				message = fmt.Sprintf("Workflow '%s' stores workflow.Context '%s' in field '%s'", node.Name, escape.Context, escape.Target)
+				suggestion = "Pass the context as the first parameter of the functions that need it instead of keeping it in a struct"
+			case analyzer.EscapeGlobal:
+				message = fmt.Sprintf("Workflow '%s' stores workflow.Context '%s' in package variable '%s'", node.Name, escape.Context, escape.Target)
+				suggestion = "Package variables are shared by all executions on the worker; pass the context explicitly instead"
+			case analyzer.EscapeGoroutine:
+				message = fmt.Sprintf("Workflow '%s' uses workflow.Context '%s' in a native goroutine (%s)", node.Name, escape.Context, escape.Target)
+				suggestion = "Start the goroutine with workflow.Go(ctx, func(ctx workflow.Context) { ... }) and use the context it provides"
+			case analyzer.EscapeCoroutine:
+				message = fmt.Sprintf("Workflow '%s' uses the parent context '%s' inside workflow.Go instead of the function's own context", node.Name, escape.Context)
+				suggestion = "Use the workflow.Context parameter of the workflow.Go function for calls made inside it"
+			default:
+				continue
 			}
-		}
-
-		// Check if workflow already has query handlers
-		hasQueryHandler := len(node.Queries) > 0
-
-		// Suggest QueryHandler if workflow has long activities but no query handlers
-		if hasLongActivities && !hasQueryHandler {
 			issues = append(issues, Issue{
 				RuleID:      r.ID(),
 				RuleName:    r.Name(),
 				Severity:    r.Severity(),
 				Category:    r.Category(),
-				Message:     fmt.Sprintf("Workflow '%s' has long-running activities but no QueryHandler for progress tracking", node.Name),
+				Message:     message,
 				Description: r.Description(),
-				Suggestion:  "Consider adding a QueryHandler for progress state instead of or in addition to rich heartbeat payloads",
+				Suggestion:  suggestion,
 				FilePath:    node.FilePath,
-				LineNumber:  node.LineNumber,
-				NodeName:    node.Name,
+				LineNumber:  escape.LineNumber,
+				NodeName:    node.ID(),
 				NodeType:    node.Type,
-				Fix: &CodeFix{
-					Description: "Add QueryHandler for progress tracking",
-					Replacements: []Replacement{{
-						FilePath:  node.FilePath,
-						StartLine: node.LineNumber,
-						NewText: `err := workflow.SetQueryHandler(ctx, "progress", func() (map[string]interface{}, error) {
-	return map[string]interface{}{
-		"phase":     currentPhase,
-		"processed": itemsProcessed,
-		"total":     totalItems,
-	}, nil
-})
-if err != nil {
-	return err
-}`,
-					}},
-				},
 			})
 		}
 	}
-
 	return issues
 }
 
-// =============================================================================
-// Type Safety Rules
-// =============================================================================
-
-// ArgumentsMismatchRule checks for activities/workflows called with wrong arguments or return types.
-type ArgumentsMismatchRule struct{}
+// TaskQueueWithoutWorkerRule checks for workflows started on task queues that no worker in the
+// analyzed code polls, or whose workers don't register the workflow.
+type TaskQueueWithoutWorkerRule struct{}
 
-func (r *ArgumentsMismatchRule) ID() string         { return "TA040" }
-func (r *ArgumentsMismatchRule) Name() string       { return "arguments-mismatch" }
-func (r *ArgumentsMismatchRule) Category() Category { return CategoryReliability }
-func (r *ArgumentsMismatchRule) Severity() Severity { return SeverityError }
-func (r *ArgumentsMismatchRule) Description() string {
-	return "Calling an activity or workflow with wrong number/types of arguments, or reading results into wrong types, causes runtime errors. Temporal deserializes by position and type, so mismatches fail at runtime."
+func (r *TaskQueueWithoutWorkerRule) ID() string         { return "TA046" }
+func (r *TaskQueueWithoutWorkerRule) Name() string       { return "task-queue-without-worker" }
+func (r *TaskQueueWithoutWorkerRule) Category() Category { return CategoryReliability }
+func (r *TaskQueueWithoutWorkerRule) Severity() Severity { return SeverityWarning }
+func (r *TaskQueueWithoutWorkerRule) Description() string {
+	return "A workflow started on a task queue that no worker polls, or whose workers don't register the workflow type, is accepted by the server but never makes progress: its workflow tasks wait in the queue until the workflow times out. Queues are compared as written in StartWorkflowOptions and worker.New, by string literal or constant name; the rule only runs when the analyzed code creates workers, so disable it for repositories whose workers live elsewhere."
 }
 
-func (r *ArgumentsMismatchRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
-	var issues []Issue
-
-	for _, node := range graph.Nodes {
-		// Check each call site
-		for _, callSite := range node.CallSites {
-			// Find the target node
-			targetNode, exists := graph.Nodes[callSite.TargetName]
-			if !exists {
-				continue
-			}
+func (r *TaskQueueWithoutWorkerRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	if len(graph.Workers) == 0 {
+		return nil
+	}
 
-			// Check argument count mismatch for activity/workflow calls
-			// Only check for call types where we extract argument info
-			if callSite.CallType == "activity" || callSite.CallType == "child_workflow" || callSite.CallType == "local_activity" {
-				expectedCount := countNonContextParams(targetNode.Parameters)
+	var issues []Issue
+	for _, id := range graph.WorkflowIDs {
+		if id.TaskQueue == "" {
+			continue
+		}
+		node := graph.Nodes[id.Workflow]
+		name := id.Workflow
+		if node != nil {
+			name = node.Name
+		}
 
-				if callSite.ArgumentCount != expectedCount {
-					issues = append(issues, Issue{
-						RuleID:   r.ID(),
-						RuleName: r.Name(),
-						Severity: r.Severity(),
-						Category: r.Category(),
-						Message: fmt.Sprintf(
-							"Call to '%s' passes %d argument(s), but %s '%s' expects %d",
-							callSite.TargetName,
-							callSite.ArgumentCount,
-							targetNode.Type,
-							targetNode.Name,
-							expectedCount,
-						),
-						Description: r.Description(),
-						Suggestion:  fmt.Sprintf("Update the call to pass exactly %d argument(s) matching the %s signature", expectedCount, targetNode.Type),
-						FilePath:    callSite.FilePath,
-						LineNumber:  callSite.LineNumber,
-						NodeName:    node.Name,
-						NodeType:    node.Type,
-					})
-				}
-			}
+		workers := graph.QueueWorkers(id.TaskQueue)
+		var message, suggestion string
+		switch {
+		case len(workers) == 0:
+			message = fmt.Sprintf("Workflow '%s' is started on task queue '%s', which no worker polls", name, id.TaskQueue)
+			suggestion = "Start the workflow on the task queue of the worker that registers it, or create a worker for the queue with worker.New"
+		case node != nil && !registeredOnAny(workers, node):
+			message = fmt.Sprintf("Workflow '%s' is started on task queue '%s', but the workers polling it don't register it", name, id.TaskQueue)
+			suggestion = fmt.Sprintf("Register the workflow with w.RegisterWorkflow(%s) on the worker for '%s', or start it on the queue it is registered on", node.Name, id.TaskQueue)
+		default:
+			continue
+		}
 
-			// Check return type mismatch
-			if callSite.ResultType != "" && targetNode.ReturnType != "" {
-				if !isTypeCompatible(callSite.ResultType, targetNode.ReturnType) {
-					issues = append(issues, Issue{
-						RuleID:   r.ID(),
-						RuleName: r.Name(),
-						Severity: r.Severity(),
-						Category: r.Category(),
-						Message: fmt.Sprintf(
-							"Call to '%s' reads result as '%s', but %s '%s' returns '%s'",
-							callSite.TargetName,
-							callSite.ResultType,
-							targetNode.Type,
-							targetNode.Name,
-							targetNode.ReturnType,
-						),
-						Description: r.Description(),
-						Suggestion:  fmt.Sprintf("Use a variable of type '%s' to receive the result", targetNode.ReturnType),
-						FilePath:    callSite.FilePath,
-						LineNumber:  callSite.LineNumber,
-						NodeName:    node.Name,
-						NodeType:    node.Type,
-					})
-				}
-			}
+		nodeName, nodeType := id.Workflow, "workflow"
+		if node != nil {
+			nodeName, nodeType = node.ID(), node.Type
 		}
+		issues = append(issues, Issue{
+			RuleID:      r.ID(),
+			RuleName:    r.Name(),
+			Severity:    r.Severity(),
+			Category:    r.Category(),
+			Message:     message,
+			Description: r.Description(),
+			Suggestion:  suggestion,
+			FilePath:    id.FilePath,
+			LineNumber:  id.LineNumber,
+			NodeName:    nodeName,
+			NodeType:    nodeType,
+		})
 	}
-
 	return issues
 }
 
+// registeredOnAny returns true if one of the workers registers the workflow.
+func registeredOnAny(workers []*analyzer.WorkerConfig, node *analyzer.TemporalNode) bool {
+	for _, w := range workers {
+		if w.RegistersWorkflow(node.Name) {
+			return true
+		}
+	}
+	return false
+}
+
 // isTypeCompatible checks if the result type is compatible with the expected return type.
 func isTypeCompatible(resultType, returnType string) bool {
 	// Handle pointer types - result is usually a pointer to the actual type
@@ -952,7 +2697,8 @@ func isTypeCompatible(resultType, returnType string) bool {
 	}
 
 	// Handle interface{} / any - compatible with anything
-	if returnType == "interface{}" || returnType == "any" {
+	resultType, returnType = normalizeAny(resultType), normalizeAny(returnType)
+	if resultType == returnType || returnType == "any" {
 		return true
 	}
 
@@ -965,6 +2711,131 @@ func isTypeCompatible(resultType, returnType string) bool {
 	return false
 }
 
+// firstArgumentMismatch compares argument types inferred at a call site positionally with the
+// target's parameter types (context parameters excluded). It returns the 1-based position and
+// both types of the first incompatible argument, or 0 when all are compatible or unknown.
+func firstArgumentMismatch(argTypes, paramTypes []string) (int, string, string) {
+	var params []string
+	for _, paramType := range paramTypes {
+		if paramType != "context.Context" && paramType != "workflow.Context" {
+			params = append(params, paramType)
+		}
+	}
+	if len(argTypes) != len(params) {
+		return 0, "", ""
+	}
+
+	for i, argType := range argTypes {
+		if !isArgumentCompatible(argType, params[i]) {
+			return i + 1, argType, params[i]
+		}
+	}
+	return 0, "", ""
+}
+
+// isArgumentCompatible reports whether an argument of argType can be decoded into a parameter
+// of paramType. Arguments travel as JSON, so pointers are ignored and numeric types are
+// interchangeable. Placeholders for types that could not be inferred (var:, call:, selector:)
+// and named parameter types, which may be interfaces or have an unknown underlying type, are
+// assumed compatible.
+func isArgumentCompatible(argType, paramType string) bool {
+	// A pointer parameter names a concrete type, never an interface
+	concreteParam := strings.HasPrefix(paramType, "*")
+	argType = normalizeAny(strings.TrimLeft(argType, "*"))
+	paramType = normalizeAny(strings.TrimLeft(paramType, "*"))
+
+	if strings.Contains(argType, ":") || argType == "unknown" || argType == "nil" ||
+		paramType == "" || paramType == "any" || strings.HasPrefix(paramType, "interface{") {
+		return true
+	}
+	if argType == paramType || unqualifiedType(argType) == unqualifiedType(paramType) {
+		return true
+	}
+
+	argKind, paramKind := basicKind(argType), basicKind(paramType)
+	if argKind != "" {
+		// A literal: only builtin parameter types tell what JSON they accept
+		switch {
+		case paramKind != "":
+			return argKind == paramKind
+		case paramType == "[]byte":
+			return argKind == "string" // base64
+		case strings.HasPrefix(paramType, "[]") || strings.HasPrefix(paramType, "map["):
+			return false
+		}
+		return true
+	}
+
+	// A composite literal of a named type, slice or map
+	switch {
+	case paramKind != "":
+		return false
+	case strings.HasPrefix(argType, "[]") && strings.HasPrefix(paramType, "[]"):
+		return isArgumentCompatible(argType[2:], paramType[2:])
+	case strings.HasPrefix(argType, "map[") && strings.HasPrefix(paramType, "map["):
+		argKey, argValue := splitMapType(argType)
+		paramKey, paramValue := splitMapType(paramType)
+		return isArgumentCompatible(argKey, paramKey) && isArgumentCompatible(argValue, paramValue)
+	case isUnnamedComposite(argType) && isUnnamedComposite(paramType):
+		return false // e.g. a slice passed for a map
+	case concreteParam && !isUnnamedComposite(argType):
+		return false // a different named type
+	}
+	// Otherwise the parameter may be an interface or have an unknown underlying type
+	return true
+}
+
+// normalizeAny spells every empty interface as any, so []interface{} matches []any.
+func normalizeAny(t string) string {
+	return strings.ReplaceAll(t, "interface{}", "any")
+}
+
+// isUnnamedComposite reports whether t is a slice, array or map type literal.
+func isUnnamedComposite(t string) bool {
+	return strings.HasPrefix(t, "[") || strings.HasPrefix(t, "map[")
+}
+
+// splitMapType splits "map[K]V" into K and V, respecting brackets nested in K.
+func splitMapType(t string) (string, string) {
+	depth := 0
+	for i := len("map"); i < len(t); i++ {
+		switch t[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return t[len("map["):i], t[i+1:]
+			}
+		}
+	}
+	return "", ""
+}
+
+// basicKind returns the JSON kind of a builtin basic type: "number", "string" or "bool".
+func basicKind(t string) string {
+	switch t {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"float32", "float64", "byte", "rune":
+		return "number"
+	case "string":
+		return "string"
+	case "bool":
+		return "bool"
+	}
+	return ""
+}
+
+// qualifierPattern matches package qualifiers in type strings, e.g. "orders." in "[]orders.Item".
+var qualifierPattern = regexp.MustCompile(`\b\w+\.`)
+
+// unqualifiedType strips package qualifiers, so a type named from another package
+// (orders.Input) matches its declaration inside the package (Input).
+func unqualifiedType(t string) string {
+	return qualifierPattern.ReplaceAllString(t, "")
+}
+
 // countNonContextParams counts parameters that aren't context.Context or workflow.Context.
 func countNonContextParams(params map[string]string) int {
 	count := 0
@@ -994,7 +2865,7 @@ func findCircularDependencies(ctx context.Context, graph *analyzer.TemporalGraph
 		default:
 		}
 
-		if !visited[node.Name] {
+		if !visited[node.ID()] {
 			if cycle := detectCycle(ctx, node, graph, visited, recStack, []string{}); cycle != "" {
 				cycles = append(cycles, cycle)
 			}
@@ -1011,40 +2882,40 @@ func detectCycle(ctx context.Context, node *analyzer.TemporalNode, graph *analyz
 	default:
 	}
 
-	visited[node.Name] = true
-	recStack[node.Name] = true
-	path = append(path, node.Name)
+	visited[node.ID()] = true
+	recStack[node.ID()] = true
+	path = append(path, node.ID())
 
 	for _, callSite := range node.CallSites {
 		// Skip self-referential calls (recursion) - these are not circular dependencies.
 		// A method calling itself is valid recursion, not a deadlock-causing cycle.
 		// Circular dependencies require at least 2 different nodes (A -> B -> A).
-		if callSite.TargetName == node.Name {
+		if callSite.TargetName == node.ID() {
 			continue
 		}
 
 		if childNode, exists := graph.Nodes[callSite.TargetName]; exists {
-			if !visited[childNode.Name] {
+			if !visited[childNode.ID()] {
 				if cycle := detectCycle(ctx, childNode, graph, visited, recStack, path); cycle != "" {
 					return cycle
 				}
-			} else if recStack[childNode.Name] {
+			} else if recStack[childNode.ID()] {
 				cycleStart := -1
 				for i, name := range path {
-					if name == childNode.Name {
+					if name == childNode.ID() {
 						cycleStart = i
 						break
 					}
 				}
 				if cycleStart != -1 {
-					cyclePath := append(path[cycleStart:], childNode.Name)
+					cyclePath := append(path[cycleStart:], childNode.ID())
 					return strings.Join(cyclePath, " -> ")
 				}
 			}
 		}
 	}
 
-	recStack[node.Name] = false
+	recStack[node.ID()] = false
 	return ""
 }
 
@@ -1055,12 +2926,12 @@ func calculateChainDepth(ctx context.Context, node *analyzer.TemporalNode, graph
 	default:
 	}
 
-	if visited[node.Name] {
+	if visited[node.ID()] {
 		return 0
 	}
 
-	visited[node.Name] = true
-	defer func() { visited[node.Name] = false }()
+	visited[node.ID()] = true
+	defer func() { visited[node.ID()] = false }()
 
 	maxDepth := 0
 	for _, callSite := range node.CallSites {
@@ -2,10 +2,21 @@ package lint
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/contracts"
 )
 
 func TestSeverityLevel(t *testing.T) {
@@ -90,6 +101,29 @@ func TestActivityUnlimitedRetryRule(t *testing.T) {
 	if len(issues) != 0 {
 		t.Error("Should not report issue for activity with MaximumAttempts=1 (intentionally disabled)")
 	}
+
+	// Test with an explicit MaximumAttempts: 0 (deliberately unlimited - info)
+	graph.Nodes["TestWorkflow"].CallSites[0].ParsedActivityOpts = &analyzer.ActivityOptions{
+		RetryPolicy: &analyzer.RetryPolicy{MaximumAttemptsExpr: "0"},
+	}
+	issues = rule.Check(ctx, graph)
+	if len(issues) != 1 || issues[0].Severity != SeverityInfo || issues[0].Fix != nil {
+		t.Fatalf("Expected an info issue without a fix for an explicit MaximumAttempts: 0, got %+v", issues)
+	}
+	if want := "Activity 'TestActivity' explicitly allows unlimited retry attempts (MaximumAttempts: 0)"; issues[0].Message != want {
+		t.Errorf("Message = %q, want %q", issues[0].Message, want)
+	}
+
+	// Test with MaximumAttempts set from a variable (unknown - no issue, skipped in coverage)
+	graph.Nodes["TestWorkflow"].CallSites[0].ParsedActivityOpts = &analyzer.ActivityOptions{
+		RetryPolicy: &analyzer.RetryPolicy{MaximumAttemptsExpr: "cfg.Attempts", MaximumAttemptsUnresolved: true},
+	}
+	if issues = rule.Check(ctx, graph); len(issues) != 0 {
+		t.Errorf("Should not report issue for an unresolved MaximumAttempts, got %+v", issues)
+	}
+	if coverage := rule.Coverage(graph); coverage.SkipReasons["MaximumAttempts not a constant"] != 1 {
+		t.Errorf("Expected the call site skipped in coverage, got %+v", coverage)
+	}
 }
 
 func TestActivityWithoutTimeoutRule(t *testing.T) {
@@ -136,6 +170,32 @@ func TestActivityWithoutTimeoutRule(t *testing.T) {
 	if len(issues) != 0 {
 		t.Error("Should not report issue for activity with timeout")
 	}
+
+	// Test with options supplied by callers of a shared helper
+	graph.Nodes["TestWorkflow"].CallSites[0].ParsedActivityOpts = &analyzer.ActivityOptions{CallerDependent: true}
+	issues = rule.Check(ctx, graph)
+	if len(issues) != 0 {
+		t.Error("Should not report issue for caller-dependent activity options")
+	}
+
+	// Test with the call type the extractor records, carrying the executed type as target type
+	graph.Nodes["TestWorkflow"].CallSites[0] = analyzer.CallSite{
+		TargetName:         "TestActivity",
+		TargetType:         "activity",
+		CallType:           "execute",
+		ParsedActivityOpts: &analyzer.ActivityOptions{},
+	}
+	issues = rule.Check(ctx, graph)
+	if len(issues) != 1 || issues[0].NodeType != "activity" {
+		t.Errorf("Expected one issue for an executed activity without timeout, got %+v", issues)
+	}
+
+	// Test with options passed in a variable
+	graph.Nodes["TestWorkflow"].CallSites[0].ParsedActivityOpts = &analyzer.ActivityOptions{Unparsed: true}
+	issues = rule.Check(ctx, graph)
+	if len(issues) != 0 {
+		t.Error("Should not report issue for unparsed activity options")
+	}
 }
 
 func TestLongRunningActivityWithoutHeartbeatRule(t *testing.T) {
@@ -181,6 +241,212 @@ func TestLongRunningActivityWithoutHeartbeatRule(t *testing.T) {
 	}
 }
 
+func TestLongRunningActivityHeuristics(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name: "OrderWorkflow",
+				Type: "workflow",
+				CallSites: []analyzer.CallSite{
+					{TargetName: "CreateOrder", TargetType: "activity", CallType: "execute"},
+					{TargetName: "ReindexCatalog", TargetType: "activity", CallType: "execute"},
+					{TargetName: "ProcessRefund", TargetType: "activity", CallType: "execute"},
+					{TargetName: "SendReceipt", TargetType: "activity", CallType: "execute"},
+				},
+			},
+			"CreateOrder":    {Name: "CreateOrder", Type: "activity"},
+			"ReindexCatalog": {Name: "ReindexCatalog", Type: "activity"},
+			"ProcessRefund":  {Name: "ProcessRefund", Type: "activity", Annotations: map[string]string{DurationAnnotation: "2s"}},
+			"SendReceipt":    {Name: "SendReceipt", Type: "activity", Annotations: map[string]string{DurationAnnotation: "15m"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		cfg  LongRunningConfig
+		want []string
+	}{
+		{"defaults", LongRunningConfig{}, []string{"CreateOrder", "SendReceipt"}},
+		{"exclude", LongRunningConfig{Exclude: regexp.MustCompile(`^Create`)}, []string{"SendReceipt"}},
+		{"include", LongRunningConfig{Include: regexp.MustCompile(`(?i)reindex`)}, []string{"ReindexCatalog", "SendReceipt"}},
+		{"min duration", LongRunningConfig{MinDuration: time.Hour}, []string{"CreateOrder"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range NewLongRunningActivityWithoutHeartbeatRule(tt.cfg).Check(context.Background(), graph) {
+				got = append(got, issue.NodeName)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Flagged %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSleepBlocksSignalsRule(t *testing.T) {
+	rule := &SleepBlocksSignalsRule{}
+
+	if rule.ID() != "TA005" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA005")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"PollWorkflow": {
+				Name:     "PollWorkflow",
+				Type:     "workflow",
+				FilePath: "/src/poll.go",
+				Timers: []analyzer.TimerDef{
+					{Duration: "time.Minute", LineNumber: 12, IsSleep: true, LoopLine: 10},
+					{Duration: "time.Hour", LineNumber: 20, IsSleep: true},
+				},
+				SignalReceives: []analyzer.SignalReceive{
+					{Signal: "approve", Channel: "approvals", LineNumber: 14, Blocking: true, LoopLine: 10},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	issues := rule.Check(ctx, graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].FilePath != "/src/poll.go" || issues[0].LineNumber != 12 {
+		t.Errorf("Issue location = %s:%d, want /src/poll.go:12", issues[0].FilePath, issues[0].LineNumber)
+	}
+	if !strings.Contains(issues[0].Message, "approve") {
+		t.Errorf("Message should name the signal: %s", issues[0].Message)
+	}
+	if issues[0].Fix == nil || !strings.Contains(issues[0].Fix.Replacements[0].NewText, "workflow.NewTimer(timerCtx, time.Minute)") {
+		t.Error("Expected a NewTimer fix using the sleep duration")
+	}
+
+	// A timer in the loop is cancellable and not reported
+	graph.Nodes["PollWorkflow"].Timers[0].IsSleep = false
+	if issues := rule.Check(ctx, graph); len(issues) != 0 {
+		t.Errorf("Should not report NewTimer, got %d issues", len(issues))
+	}
+
+	// A sleep in a loop without signal receives is not reported
+	graph.Nodes["PollWorkflow"].Timers[0].IsSleep = true
+	graph.Nodes["PollWorkflow"].SignalReceives[0].LoopLine = 30
+	if issues := rule.Check(ctx, graph); len(issues) != 0 {
+		t.Errorf("Should not report sleep in a different loop, got %d issues", len(issues))
+	}
+}
+
+func TestBlockingSignalReceiveRule(t *testing.T) {
+	rule := &BlockingSignalReceiveRule{}
+
+	if rule.ID() != "TA006" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA006")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"ApprovalWorkflow": {
+				Name:     "ApprovalWorkflow",
+				Type:     "workflow",
+				FilePath: "/src/approval.go",
+				SignalReceives: []analyzer.SignalReceive{
+					{Signal: "approve", LineNumber: 8, Blocking: true},
+					{Signal: "cancel", Channel: "c", LineNumber: 12, Blocking: true, InSelector: true},
+					{Signal: "update", Channel: "updates", LineNumber: 15},
+				},
+			},
+		},
+	}
+
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].FilePath != "/src/approval.go" || issues[0].LineNumber != 8 {
+		t.Errorf("Issue location = %s:%d, want /src/approval.go:8", issues[0].FilePath, issues[0].LineNumber)
+	}
+	if issues[0].Fix == nil || !strings.Contains(issues[0].Fix.Replacements[0].NewText, `selector.AddReceive(workflow.GetSignalChannel(ctx, "approve")`) {
+		t.Error("Expected a selector fix receiving from the signal channel")
+	}
+}
+
+func TestWorkflowDirectLoggingRule(t *testing.T) {
+	rule := &WorkflowDirectLoggingRule{}
+
+	if rule.ID() != "TA007" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA007")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:     "OrderWorkflow",
+				Type:     "workflow",
+				FilePath: "/src/order.go",
+				LogCalls: []analyzer.LogCall{
+					{Call: "log.Printf", Level: "Info", Message: `"order %s"`, LineNumber: 9},
+				},
+			},
+			"ChargeActivity": {
+				Name:     "ChargeActivity",
+				Type:     "activity",
+				LogCalls: []analyzer.LogCall{{Call: "fmt.Println", Level: "Info", LineNumber: 3}},
+			},
+		},
+	}
+
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].FilePath != "/src/order.go" || issues[0].LineNumber != 9 {
+		t.Errorf("Issue location = %s:%d, want /src/order.go:9", issues[0].FilePath, issues[0].LineNumber)
+	}
+	want := `workflow.GetLogger(ctx).Info("order %s", "key", value)`
+	if issues[0].Fix == nil || issues[0].Fix.Replacements[0].NewText != want {
+		t.Errorf("Fix = %+v, want NewText %s", issues[0].Fix, want)
+	}
+}
+
+func TestWorkflowDirectMetricsRule(t *testing.T) {
+	rule := &WorkflowDirectMetricsRule{}
+
+	if rule.ID() != "TA008" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA008")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:     "OrderWorkflow",
+				Type:     "workflow",
+				FilePath: "/src/order.go",
+				MetricCalls: []analyzer.MetricCall{
+					{Call: "ordersTotal.WithLabelValues().Inc", Kind: "prometheus", LineNumber: 14},
+				},
+			},
+			"ChargeActivity": {
+				Name:        "ChargeActivity",
+				Type:        "activity",
+				MetricCalls: []analyzer.MetricCall{{Call: "statsd.Incr", Kind: "statsd", LineNumber: 3}},
+			},
+		},
+	}
+
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].FilePath != "/src/order.go" || issues[0].LineNumber != 14 {
+		t.Errorf("Issue location = %s:%d, want /src/order.go:14", issues[0].FilePath, issues[0].LineNumber)
+	}
+	if !strings.Contains(issues[0].Message, "prometheus") {
+		t.Errorf("Message should name the metrics client: %s", issues[0].Message)
+	}
+}
+
 func TestCircularDependencyRule(t *testing.T) {
 	rule := &CircularDependencyRule{}
 
@@ -316,6 +582,208 @@ func TestCircularDependencyRule(t *testing.T) {
 	}
 }
 
+func TestAmbiguousCallTargetRule(t *testing.T) {
+	rule := &AmbiguousCallTargetRule{}
+	if rule.ID() != "TA012" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA012")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name: "OrderWorkflow",
+				Type: "workflow",
+				CallSites: []analyzer.CallSite{
+					{TargetName: "Charge", FilePath: "order.go", LineNumber: 12, Candidates: []string{"billing.Charge", "payments.Charge"}},
+					{TargetName: "Ship", FilePath: "order.go", LineNumber: 13},
+				},
+			},
+		},
+	}
+
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	issue := issues[0]
+	if issue.Severity != SeverityInfo || issue.LineNumber != 12 || issue.NodeName != "OrderWorkflow" {
+		t.Errorf("Unexpected issue %+v", issue)
+	}
+	if !strings.Contains(issue.Message, "billing.Charge, payments.Charge") {
+		t.Errorf("Expected candidates in message, got %q", issue.Message)
+	}
+}
+
+func TestDeprecatedCallRule(t *testing.T) {
+	workflow := func(name string, targets ...string) *analyzer.TemporalNode {
+		node := &analyzer.TemporalNode{Name: name, Type: "workflow", FilePath: "/src/" + name + ".go"}
+		for i, target := range targets {
+			node.CallSites = append(node.CallSites, analyzer.CallSite{TargetName: target, LineNumber: 10 + i})
+		}
+		return node
+	}
+	legacy := &analyzer.TemporalNode{Name: "LegacyCharge", Type: "activity", Tags: []string{"deprecated"}, Description: "use Charge"}
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow":  workflow("OrderWorkflow", "LegacyCharge", "Ship"),
+		"RefundWorkflow": workflow("RefundWorkflow", "Ship", "LegacyCharge", "LegacyCharge"),
+		"LegacyCharge":   legacy,
+		"Ship":           {Name: "Ship", Type: "activity"},
+	}}
+	base := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": workflow("OrderWorkflow", "LegacyCharge"),
+	}}
+
+	if issues := NewDeprecatedCallRule(nil).Check(context.Background(), graph); len(issues) != 0 {
+		t.Errorf("Expected no issues without a base graph, got %v", issues)
+	}
+
+	rule := NewDeprecatedCallRule(base)
+	if rule.ID() != "TA013" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA013")
+	}
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue for the new caller, got %v", issues)
+	}
+	issue := issues[0]
+	if issue.NodeName != "RefundWorkflow" || issue.LineNumber != 11 || issue.FilePath != "/src/RefundWorkflow.go" {
+		t.Errorf("Unexpected issue location %+v", issue)
+	}
+	if issue.Message != "'RefundWorkflow' adds a call to deprecated activity 'LegacyCharge'" || !strings.Contains(issue.Suggestion, "use Charge") {
+		t.Errorf("Unexpected issue %+v", issue)
+	}
+}
+
+func TestUnreceivedSignalChannelRule(t *testing.T) {
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", FilePath: "/src/order.go", Signals: []analyzer.SignalDef{
+			{Name: "approve", LineNumber: 12},
+			{Name: "cancel", LineNumber: 14, Unreceived: true},
+		}},
+	}}
+
+	rule := &UnreceivedSignalChannelRule{}
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %v", issues)
+	}
+	if issue := issues[0]; issue.RuleID != "TA014" || issue.FilePath != "/src/order.go" || issue.LineNumber != 14 || !strings.Contains(issue.Message, "'cancel'") {
+		t.Errorf("Unexpected issue %+v", issue)
+	}
+}
+
+func TestUnusedMessageHandlerRule(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name: "OrderWorkflow", Type: "workflow", FilePath: "/src/order.go",
+				Queries: []analyzer.QueryDef{{Name: "status", LineNumber: 10}, {Name: "history", LineNumber: 11}, {LineNumber: 12}},
+				Updates: []analyzer.UpdateDef{{Name: "setAddress", LineNumber: 20}},
+			},
+		},
+		Messages: []analyzer.MessageCall{
+			{Kind: "query", Name: "status"},
+			{Kind: "signal", Name: "history"},
+		},
+	}
+
+	rule := &UnusedMessageHandlerRule{}
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %v", issues)
+	}
+	if issue := issues[0]; issue.RuleID != "TA015" || issue.LineNumber != 11 || issue.Message != "Query handler 'history' of workflow 'OrderWorkflow' is never queried by the analyzed code" {
+		t.Errorf("Unexpected issue %+v", issue)
+	}
+	if issue := issues[1]; issue.LineNumber != 20 || !strings.Contains(issue.Message, "never updated") {
+		t.Errorf("Unexpected issue %+v", issue)
+	}
+
+	// An update named by a variable may call any update handler
+	graph.Messages = append(graph.Messages, analyzer.MessageCall{Kind: "update"})
+	if issues := rule.Check(context.Background(), graph); len(issues) != 1 {
+		t.Errorf("Expected only the query handler reported, got %v", issues)
+	}
+}
+
+func TestSignalToUnhandledWorkflowRule(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Signals: []analyzer.SignalDef{{Name: "approve"}}},
+			"ShipWorkflow":  {Name: "ShipWorkflow", Type: "workflow", SignalReceives: []analyzer.SignalReceive{{Signal: "delay"}}},
+		},
+		Messages: []analyzer.MessageCall{
+			{Kind: "signal", Name: "approve", Workflow: "OrderWorkflow", FilePath: "/src/api.go", LineNumber: 5},
+			{Kind: "signal", Name: "aprove", Workflow: "OrderWorkflow", FilePath: "/src/api.go", LineNumber: 6},
+			{Kind: "signal", Name: "delay", FilePath: "/src/api.go", LineNumber: 7},
+			{Kind: "signal", Name: "pause", FilePath: "/src/api.go", LineNumber: 8},
+			{Kind: "signal", Name: "pause", Workflow: "ExternalWorkflow", FilePath: "/src/api.go", LineNumber: 9},
+			{Kind: "signal", FilePath: "/src/api.go", LineNumber: 10},
+		},
+	}
+
+	rule := &SignalToUnhandledWorkflowRule{}
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %v", issues)
+	}
+	if issue := issues[0]; issue.RuleID != "TA016" || issue.LineNumber != 6 || issue.NodeName != "OrderWorkflow" || !strings.Contains(issue.Message, "'aprove'") {
+		t.Errorf("Unexpected issue %+v", issue)
+	}
+	if issue := issues[1]; issue.LineNumber != 8 || issue.NodeName != "" || issue.FilePath != "/src/api.go" {
+		t.Errorf("Unexpected issue %+v", issue)
+	}
+
+	// A workflow receiving a signal named by a variable may handle any signal
+	graph.Nodes["ShipWorkflow"].Signals = []analyzer.SignalDef{{LineNumber: 3}}
+	if issues := rule.Check(context.Background(), graph); len(issues) != 1 {
+		t.Errorf("Expected only the signal to OrderWorkflow reported, got %v", issues)
+	}
+}
+
+func TestDuplicateMessageHandlerRule(t *testing.T) {
+	rule := &DuplicateMessageHandlerRule{}
+
+	if rule.ID() != "TA017" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA017")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:     "OrderWorkflow",
+				Type:     "workflow",
+				FilePath: "/src/order.go",
+				Queries: []analyzer.QueryDef{
+					{Name: "status", LineNumber: 10},
+					{Name: "progress", LineNumber: 11},
+					{Name: "status", NameExpr: "StatusQuery", LineNumber: 14},
+					{NameExpr: "msgs.Items", LineNumber: 15},
+				},
+				Signals: []analyzer.SignalDef{
+					{NameExpr: "msgs.Cancel", LineNumber: 20},
+					{NameExpr: "msgs.Cancel", LineNumber: 30},
+					{NameExpr: "msgs.Cancel", LineNumber: 40},
+					{Name: "approve", LineNumber: 21},
+				},
+			},
+			"ShipWorkflow": {Name: "ShipWorkflow", Type: "workflow", Queries: []analyzer.QueryDef{{Name: "status", LineNumber: 5}}},
+		},
+	}
+
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].LineNumber < issues[j].LineNumber })
+	if issues[0].LineNumber != 14 || !strings.Contains(issues[0].Message, "Query 'status' is registered 2 times in workflow 'OrderWorkflow' (lines 10 and 14)") {
+		t.Errorf("Unexpected query issue at line %d: %s", issues[0].LineNumber, issues[0].Message)
+	}
+	if issues[1].LineNumber != 40 || !strings.Contains(issues[1].Message, "Signal 'msgs.Cancel' is registered 3 times in workflow 'OrderWorkflow' (lines 20, 30 and 40)") {
+		t.Errorf("Unexpected signal issue at line %d: %s", issues[1].LineNumber, issues[1].Message)
+	}
+}
+
 func TestOrphanNodeRule(t *testing.T) {
 	rule := &OrphanNodeRule{}
 
@@ -408,6 +876,145 @@ func TestHighFanOutRule(t *testing.T) {
 	}
 }
 
+func TestQueueStarvationRule(t *testing.T) {
+	rule := &QueueStarvationRule{}
+	if rule.ID() != "TA022" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA022")
+	}
+
+	activityCalls := func(n int, opts *analyzer.ActivityOptions) []analyzer.CallSite {
+		calls := make([]analyzer.CallSite, n)
+		for i := range calls {
+			calls[i] = analyzer.CallSite{TargetName: "Activity" + string(rune('A'+i)), CallType: "activity", ParsedActivityOpts: opts}
+		}
+		return calls
+	}
+	workers := []analyzer.WorkerConfig{
+		{TaskQueue: "orders", MaxConcurrentActivityExecutionSize: 2, Workflows: []string{"OrderWorkflow"}},
+		{TaskQueue: "bulk", TaskQueueActivitiesPerSecond: 3},
+	}
+
+	tests := []struct {
+		name      string
+		workflow  string
+		callSites []analyzer.CallSite
+		workers   []analyzer.WorkerConfig
+		want      string
+	}{
+		{
+			name:      "fan-out exceeds worker concurrency",
+			workflow:  "OrderWorkflow",
+			callSites: activityCalls(3, &analyzer.ActivityOptions{ScheduleToStartTimeout: "time.Minute"}),
+			workers:   workers,
+			want:      "schedules 3 activities on task queue 'orders', but its workers run at most 2 concurrently; queued activities may exceed ScheduleToStartTimeout (time.Minute)",
+		},
+		{
+			name:      "fan-out within worker concurrency",
+			workflow:  "OrderWorkflow",
+			callSites: activityCalls(2, nil),
+			workers:   workers,
+		},
+		{
+			name:      "explicit task queue exceeds rate limit",
+			workflow:  "OrderWorkflow",
+			callSites: activityCalls(4, &analyzer.ActivityOptions{TaskQueue: "bulk"}),
+			workers:   workers,
+			want:      "the queue is rate limited to 3/s",
+		},
+		{
+			name:      "workflow queue unknown",
+			workflow:  "OtherWorkflow",
+			callSites: activityCalls(5, nil),
+			workers:   workers,
+		},
+		{
+			name:      "single worker is the default queue",
+			workflow:  "OtherWorkflow",
+			callSites: activityCalls(5, nil),
+			workers:   workers[:1],
+			want:      "at most 2 concurrently",
+		},
+		{
+			name:      "no workers in code",
+			workflow:  "OrderWorkflow",
+			callSites: activityCalls(5, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := &analyzer.TemporalGraph{
+				Nodes: map[string]*analyzer.TemporalNode{
+					tt.workflow: {Name: tt.workflow, Type: "workflow", CallSites: tt.callSites},
+				},
+				Workers: tt.workers,
+			}
+
+			issues := rule.Check(context.Background(), graph)
+			if tt.want == "" {
+				if len(issues) != 0 {
+					t.Errorf("Expected no issues, got %v", issues)
+				}
+				return
+			}
+			if len(issues) != 1 {
+				t.Fatalf("Expected 1 issue, got %d", len(issues))
+			}
+			if !strings.Contains(issues[0].Message, tt.want) {
+				t.Errorf("Message %q does not contain %q", issues[0].Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeoutOutlierRule(t *testing.T) {
+	rule := NewTimeoutOutlierRule(0)
+	if rule.ID() != "TA023" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA023")
+	}
+	if rule.Factor != 50 {
+		t.Errorf("Factor = %d, want 50 (default)", rule.Factor)
+	}
+
+	call := func(target, timeout string, line int) analyzer.CallSite {
+		return analyzer.CallSite{TargetName: target, TargetType: "activity", CallType: "execute", FilePath: "orders.go", LineNumber: line,
+			ParsedActivityOpts: &analyzer.ActivityOptions{StartToCloseTimeout: timeout}}
+	}
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders", CallSites: []analyzer.CallSite{
+			call("Reserve", "10 * time.Minute", 10),
+			call("Charge", "5 * time.Minute", 11),
+			call("Ship", "10 * time.Hour", 12),
+			call("Email", "2 * time.Second", 13),
+			call("Audit", "cfg.Timeout", 14),
+			call("Notify", "15 * time.Minute", 15),
+		}},
+		"ReportWorkflow": {Name: "ReportWorkflow", Type: "workflow", Package: "reports", CallSites: []analyzer.CallSite{
+			call("Render", "time.Minute", 20),
+			call("Archive", "24 * time.Hour", 21),
+		}},
+	}}
+
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %+v", issues)
+	}
+	if want := "StartToCloseTimeout of activity 'Ship' in 'OrderWorkflow' is 10 * time.Hour (10h0m0s), 60x longer than the package median of 10m0s"; issues[0].Message != want {
+		t.Errorf("Message = %q, want %q", issues[0].Message, want)
+	}
+	if issues[0].LineNumber != 12 || issues[0].NodeName != "OrderWorkflow" {
+		t.Errorf("Unexpected location: %+v", issues[0])
+	}
+	if !strings.Contains(issues[1].Message, "'Email'") || !strings.Contains(issues[1].Message, "300x shorter than") {
+		t.Errorf("Unexpected short outlier message: %q", issues[1].Message)
+	}
+
+	coverage := rule.Coverage(graph)
+	if coverage.Eligible != 8 || coverage.Checked != 5 || coverage.SkipReasons["not a constant duration"] != 1 || coverage.SkipReasons["too few timeouts in the package"] != 2 {
+		t.Errorf("Unexpected coverage: %+v", coverage)
+	}
+}
+
 func TestDeepCallChainRule(t *testing.T) {
 	rule := NewDeepCallChainRule(0) // Should use default
 
@@ -668,31 +1275,72 @@ func TestIssueFields(t *testing.T) {
 	}
 }
 
-func TestArgumentsMismatchRule(t *testing.T) {
-	rule := &ArgumentsMismatchRule{}
+func TestWorkflowTypeHygieneRule(t *testing.T) {
+	rule := NewWorkflowTypeHygieneRule(nil, nil)
 
-	if rule.ID() != "TA040" {
-		t.Errorf("ID() = %q, want %q", rule.ID(), "TA040")
-	}
-	if rule.Name() != "arguments-mismatch" {
-		t.Errorf("Name() = %q, want %q", rule.Name(), "arguments-mismatch")
+	if rule.ID() != "TA039" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA039")
 	}
-	if rule.Severity() != SeverityError {
-		t.Errorf("Severity() = %v, want %v", rule.Severity(), SeverityError)
+	if len(rule.Dedicated) == 0 {
+		t.Error("Expected default dedicated type patterns")
 	}
 
-	// Test with wrong argument count
 	graph := &analyzer.TemporalGraph{
 		Nodes: map[string]*analyzer.TemporalNode{
-			"MyWorkflow": {
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", FilePath: "/src/orders/workflow.go", LineNumber: 7},
+		},
+	}
+
+	// Without contracts there is nothing to check
+	ctx := context.Background()
+	if issues := rule.Check(ctx, graph); len(issues) != 0 {
+		t.Errorf("Expected no issues without contracts, got %d", len(issues))
+	}
+
+	rule.Contracts = &contracts.Document{
+		Workflows: []contracts.Workflow{{
+			Name:    "OrderWorkflow",
+			Package: "orders",
+			Input:   []contracts.Param{{Name: "payload", Type: "map[string]any"}},
+		}},
+	}
+	issues := rule.Check(ctx, graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].FilePath != "/src/orders/workflow.go" || issues[0].LineNumber != 7 {
+		t.Errorf("Issue location = %s:%d, want /src/orders/workflow.go:7", issues[0].FilePath, issues[0].LineNumber)
+	}
+	if !strings.Contains(issues[0].Message, "input 'payload' is map[string]any") {
+		t.Errorf("Unexpected message: %s", issues[0].Message)
+	}
+}
+
+func TestArgumentsMismatchRule(t *testing.T) {
+	rule := &ArgumentsMismatchRule{}
+
+	if rule.ID() != "TA040" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA040")
+	}
+	if rule.Name() != "arguments-mismatch" {
+		t.Errorf("Name() = %q, want %q", rule.Name(), "arguments-mismatch")
+	}
+	if rule.Severity() != SeverityError {
+		t.Errorf("Severity() = %v, want %v", rule.Severity(), SeverityError)
+	}
+
+	// Test with wrong argument count
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"MyWorkflow": {
 				Name: "MyWorkflow",
 				Type: "workflow",
 				CallSites: []analyzer.CallSite{
 					{
 						TargetName:    "SendEmailActivity",
 						TargetType:    "activity",
-						CallType:      "activity", // Required for argument count check
-						ArgumentCount: 1,          // Only passing 1 arg
+						CallType:      "execute",
+						ArgumentCount: 1, // Only passing 1 arg
 						LineNumber:    10,
 						FilePath:      "workflow.go",
 					},
@@ -734,6 +1382,18 @@ func TestArgumentsMismatchRule(t *testing.T) {
 		t.Errorf("Should report issue when 0 args passed but %d expected, got %d issues", 3, len(issues))
 	}
 
+	// Unresolved stubs and non-workflow targets have no signature to check
+	graph.Nodes["SendEmailActivity"].Unresolved = true
+	if issues = rule.Check(ctx, graph); len(issues) != 0 {
+		t.Errorf("Should not check arguments of an unresolved target, got %v", issues)
+	}
+	graph.Nodes["SendEmailActivity"].Unresolved = false
+	graph.Nodes["SendEmailActivity"].Type = "signal_handler"
+	if issues = rule.Check(ctx, graph); len(issues) != 0 {
+		t.Errorf("Should not check arguments of a %s target, got %v", "signal_handler", issues)
+	}
+	graph.Nodes["SendEmailActivity"].Type = "activity"
+
 	// Test return type mismatch
 	graphWithReturnType := &analyzer.TemporalGraph{
 		Nodes: map[string]*analyzer.TemporalNode{
@@ -796,6 +1456,222 @@ func TestArgumentsMismatchRule(t *testing.T) {
 	if len(issues) != 0 {
 		t.Errorf("Should skip check when ResultType has 'var:' prefix (type unknown), got %d", len(issues))
 	}
+
+	// The value result is compared, not the trailing error
+	target := graphWithReturnType.Nodes["CalculateTotalActivity"]
+	target.ReturnTypes = []string{"int", "error"}
+	graphWithReturnType.Nodes["ProcessOrderWorkflow"].CallSites[0].ResultType = "*int"
+	if issues = rule.Check(ctx, graphWithReturnType); len(issues) != 0 {
+		t.Errorf("Should not report issue for (int, error) read into *int, got %v", issues)
+	}
+
+	// Reading a result of an error-only activity
+	target.ReturnType = "error"
+	target.ReturnTypes = []string{"error"}
+	issues = rule.Check(ctx, graphWithReturnType)
+	if len(issues) != 1 || !strings.Contains(issues[0].Message, "returns only an error") {
+		t.Errorf("Expected 1 issue for reading the result of an error-only activity, got %v", issues)
+	}
+	graphWithReturnType.Nodes["ProcessOrderWorkflow"].CallSites[0].ResultType = "nil"
+	if issues = rule.Check(ctx, graphWithReturnType); len(issues) != 0 {
+		t.Errorf("Should not report issue for Get(ctx, nil) on an error-only activity, got %v", issues)
+	}
+}
+
+func TestArgumentsMismatchRuleTypes(t *testing.T) {
+	rule := &ArgumentsMismatchRule{}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		argTypes  []string
+		wantIssue string
+	}{
+		{name: "matching types", argTypes: []string{"string", "int", "*orders.Order"}},
+		{name: "unknown variables", argTypes: []string{"var:to", "call:count", "selector:orders.Default"}},
+		{name: "pointer to variable", argTypes: []string{"string", "int", "*var:order"}},
+		{name: "float literal for int", argTypes: []string{"string", "float64", "Order"}},
+		{name: "nil argument", argTypes: []string{"nil", "int", "nil"}},
+		{
+			name:      "int literal for string",
+			argTypes:  []string{"int", "int", "Order"},
+			wantIssue: "passes 'int' as argument 1, but activity 'ShipActivity' expects 'string'",
+		},
+		{
+			name:      "wrong struct",
+			argTypes:  []string{"string", "int", "*Invoice"},
+			wantIssue: "passes '*Invoice' as argument 3, but activity 'ShipActivity' expects '*Order'",
+		},
+		{
+			name:      "reports first mismatch only",
+			argTypes:  []string{"string", "bool", "Invoice"},
+			wantIssue: "passes 'bool' as argument 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := &analyzer.TemporalGraph{
+				Nodes: map[string]*analyzer.TemporalNode{
+					"OrderWorkflow": {
+						Name: "OrderWorkflow",
+						Type: "workflow",
+						CallSites: []analyzer.CallSite{{
+							TargetName:    "ShipActivity",
+							CallType:      "execute",
+							ArgumentCount: len(tt.argTypes),
+							ArgumentTypes: tt.argTypes,
+						}},
+					},
+					"ShipActivity": {
+						Name:           "ShipActivity",
+						Type:           "activity",
+						Parameters:     map[string]string{"ctx": "context.Context", "address": "string", "count": "int", "order": "*Order"},
+						ParameterTypes: []string{"context.Context", "string", "int", "*Order"},
+					},
+				},
+			}
+
+			issues := rule.Check(ctx, graph)
+			if tt.wantIssue == "" {
+				if len(issues) != 0 {
+					t.Errorf("Expected no issues, got %v", issues)
+				}
+				return
+			}
+			if len(issues) != 1 || !strings.Contains(issues[0].Message, tt.wantIssue) {
+				t.Errorf("Expected 1 issue containing %q, got %v", tt.wantIssue, issues)
+			}
+		})
+	}
+}
+
+func TestArgumentsMismatchRuleAnalyzed(t *testing.T) {
+	dir := t.TempDir()
+	src := `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func Register(worker worker.Worker) {
+	worker.RegisterWorkflow(OrderWorkflow)
+	worker.RegisterActivity(ChargeActivity)
+	worker.RegisterActivity(ShipActivity)
+}
+
+func OrderWorkflow(ctx workflow.Context, orderID string) error {
+	if err := workflow.ExecuteActivity(ctx, ChargeActivity, orderID).Get(ctx, nil); err != nil {
+		return err
+	}
+	return workflow.ExecuteActivity(ctx, ShipActivity, orderID, 3).Get(ctx, nil)
+}
+
+func ChargeActivity(ctx context.Context, orderID string, amount int) error {
+	return nil
+}
+
+func ShipActivity(ctx context.Context, orderID string, items []any) error {
+	return nil
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "workflow.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	graph, err := analyzer.NewAnalyzer(logger).Analyze(context.Background(), config.AnalysisOptions{RootDir: dir})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	issues := (&ArgumentsMismatchRule{}).Check(context.Background(), graph)
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %v", issues)
+	}
+	var messages []string
+	for _, issue := range issues {
+		messages = append(messages, issue.Message)
+	}
+	all := strings.Join(messages, "\n")
+	for _, want := range []string{
+		"Call to 'ChargeActivity' passes 1 argument(s), but activity 'ChargeActivity' expects 2",
+		"Call to 'ShipActivity' passes 'int' as argument 2, but activity 'ShipActivity' expects '[]any'",
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("Expected an issue %q, got:\n%s", want, all)
+		}
+	}
+}
+
+func TestIsArgumentCompatible(t *testing.T) {
+	tests := []struct {
+		argType, paramType string
+		want               bool
+	}{
+		{"string", "Status", true},     // named type, underlying unknown
+		{"int", "time.Duration", true}, // named type, underlying unknown
+		{"string", "[]byte", true},     // base64
+		{"string", "[]string", false},  // literal into slice
+		{"orders.Item", "Item", true},  // qualified in the caller's package
+		{"[]orders.Item", "[]Item", true},
+		{"map[string]int", "map[string]string", false},
+		{"Order", "any", true},
+		{"Order", "interface{}", true},
+		{"Order", "string", false},
+		{"[]interface{}", "[]any", true},
+		{"map[string]interface{}", "map[string]any", true},
+		{"map[string][]orders.Item", "map[string][]Item", true},
+		{"Order", "Shipment", true},   // the parameter may be an interface
+		{"Order", "*Shipment", false}, // a pointer parameter is concrete
+		{"[]string", "Tags", true},    // named type, underlying unknown
+		{"[]string", "map[string]bool", false},
+	}
+	for _, tt := range tests {
+		if got := isArgumentCompatible(tt.argType, tt.paramType); got != tt.want {
+			t.Errorf("isArgumentCompatible(%q, %q) = %v, want %v", tt.argType, tt.paramType, got, tt.want)
+		}
+	}
+}
+
+func TestActivityResultUnusedRule(t *testing.T) {
+	rule := &ActivityResultUnusedRule{}
+
+	if rule.ID() != "TA041" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA041")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:     "OrderWorkflow",
+				Type:     "workflow",
+				FilePath: "/src/order.go",
+				CallSites: []analyzer.CallSite{
+					{TargetName: "ReserveStockActivity", TargetType: "activity", CallType: "execute", ResultType: "nil", LineNumber: 10},
+					{TargetName: "NotifyActivity", TargetType: "activity", CallType: "execute", ResultType: "nil", LineNumber: 12},
+					{TargetName: "ChargeActivity", TargetType: "activity", CallType: "execute", ResultType: "var:receipt", LineNumber: 14},
+				},
+			},
+			"ReserveStockActivity": {Name: "ReserveStockActivity", Type: "activity", ReturnType: "*Reservation"},
+			"NotifyActivity":       {Name: "NotifyActivity", Type: "activity", ReturnType: "error"},
+			"ChargeActivity":       {Name: "ChargeActivity", Type: "activity", ReturnType: "Receipt"},
+		},
+	}
+
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].FilePath != "/src/order.go" || issues[0].LineNumber != 10 {
+		t.Errorf("Issue location = %s:%d, want /src/order.go:10", issues[0].FilePath, issues[0].LineNumber)
+	}
+	if !strings.Contains(issues[0].Message, "*Reservation") {
+		t.Errorf("Message should name the discarded type: %s", issues[0].Message)
+	}
 }
 
 func TestCountNonContextParams(t *testing.T) {
@@ -852,3 +1728,455 @@ func TestCountNonContextParams(t *testing.T) {
 	}
 }
 
+func TestUntestedWorkflowRule(t *testing.T) {
+	rule := NewUntestedWorkflowRule(0)
+
+	if rule.ID() != "TA035" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA035")
+	}
+	if rule.ComplexityThreshold != 5 {
+		t.Errorf("ComplexityThreshold = %d, want default 5", rule.ComplexityThreshold)
+	}
+
+	ctx := context.Background()
+
+	callSites := make([]analyzer.CallSite, 5)
+	for i := range callSites {
+		callSites[i] = analyzer.CallSite{TargetName: fmt.Sprintf("Activity%d", i), CallType: "activity"}
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"ComplexWorkflow": {
+				Name:      "ComplexWorkflow",
+				Type:      "workflow",
+				FilePath:  "workflow.go",
+				CallSites: callSites,
+			},
+			"SimpleWorkflow": {
+				Name:      "SimpleWorkflow",
+				Type:      "workflow",
+				FilePath:  "workflow.go",
+				CallSites: callSites[:1],
+			},
+		},
+	}
+
+	issues := rule.Check(ctx, graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].NodeName != "ComplexWorkflow" {
+		t.Errorf("NodeName = %q, want %q", issues[0].NodeName, "ComplexWorkflow")
+	}
+
+	// Test with a workflow test present
+	graph.Nodes["ComplexWorkflow"].Tests = []analyzer.TestReference{
+		{TestName: "TestComplexWorkflow", Kind: "workflow_test"},
+	}
+	issues = rule.Check(ctx, graph)
+	if len(issues) != 0 {
+		t.Error("Should not report issue for tested workflow")
+	}
+}
+
+func TestUnversionedWorkflowChangeRule(t *testing.T) {
+	ctx := context.Background()
+
+	calls := func(names ...string) []analyzer.CallSite {
+		sites := make([]analyzer.CallSite, len(names))
+		for i, name := range names {
+			sites[i] = analyzer.CallSite{TargetName: name, TargetType: "activity", CallType: "execute", LineNumber: 10 + i}
+		}
+		return sites
+	}
+	graphOf := func(node *analyzer.TemporalNode) *analyzer.TemporalGraph {
+		return &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{node.Name: node}}
+	}
+
+	base := graphOf(&analyzer.TemporalNode{
+		Name: "OrderWorkflow", Type: "workflow",
+		CallSites:  calls("Reserve", "Charge", "Ship"),
+		Versioning: []analyzer.VersionDef{{ChangeID: "charge-v2", MaxVersion: 1}},
+	})
+
+	rule := NewUnversionedWorkflowChangeRule(base)
+	if rule.ID() != "TA036" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA036")
+	}
+
+	tests := []struct {
+		name       string
+		head       *analyzer.TemporalNode
+		wantIssues int
+	}{
+		{
+			name:       "unchanged",
+			head:       &analyzer.TemporalNode{Name: "OrderWorkflow", Type: "workflow", CallSites: calls("Reserve", "Charge", "Ship")},
+			wantIssues: 0,
+		},
+		{
+			name:       "added activity",
+			head:       &analyzer.TemporalNode{Name: "OrderWorkflow", Type: "workflow", CallSites: calls("Reserve", "Charge", "Notify", "Ship")},
+			wantIssues: 1,
+		},
+		{
+			name:       "removed activity",
+			head:       &analyzer.TemporalNode{Name: "OrderWorkflow", Type: "workflow", CallSites: calls("Reserve", "Ship")},
+			wantIssues: 1,
+		},
+		{
+			name:       "reordered activities",
+			head:       &analyzer.TemporalNode{Name: "OrderWorkflow", Type: "workflow", CallSites: calls("Charge", "Reserve", "Ship")},
+			wantIssues: 1,
+		},
+		{
+			name: "guarded by new change ID",
+			head: &analyzer.TemporalNode{
+				Name: "OrderWorkflow", Type: "workflow",
+				CallSites:  calls("Reserve", "Charge", "Notify", "Ship"),
+				Versioning: []analyzer.VersionDef{{ChangeID: "charge-v2", MaxVersion: 1}, {ChangeID: "notify", MaxVersion: 1}},
+			},
+			wantIssues: 0,
+		},
+		{
+			name: "guarded by raised max version",
+			head: &analyzer.TemporalNode{
+				Name: "OrderWorkflow", Type: "workflow",
+				CallSites:  calls("Reserve", "Ship"),
+				Versioning: []analyzer.VersionDef{{ChangeID: "charge-v2", MaxVersion: 2}},
+			},
+			wantIssues: 0,
+		},
+		{
+			name:       "new workflow",
+			head:       &analyzer.TemporalNode{Name: "RefundWorkflow", Type: "workflow", CallSites: calls("Refund")},
+			wantIssues: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := rule.Check(ctx, graphOf(tt.head))
+			if len(issues) != tt.wantIssues {
+				t.Errorf("Expected %d issues, got %d: %+v", tt.wantIssues, len(issues), issues)
+			}
+		})
+	}
+
+	// Without a base graph the rule is a no-op
+	if issues := NewUnversionedWorkflowChangeRule(nil).Check(ctx, base); len(issues) != 0 {
+		t.Error("Expected no issues without a base graph")
+	}
+}
+
+func TestParseAnnotationRequirement(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    AnnotationRequirement
+		wantErr bool
+	}{
+		{spec: "owner", want: AnnotationRequirement{Annotation: "owner"}},
+		{spec: " @owner ", want: AnnotationRequirement{Annotation: "owner"}},
+		{spec: "workflow/sla", want: AnnotationRequirement{NodeType: "workflow", Annotation: "sla"}},
+		{spec: "activity/@temporal:taskqueue", want: AnnotationRequirement{NodeType: "activity", Annotation: "temporal:taskqueue"}},
+		{spec: "signal/owner", wantErr: true},
+		{spec: "@", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseAnnotationRequirement(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAnnotationRequirement(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseAnnotationRequirement(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingAnnotationRule(t *testing.T) {
+	ctx := context.Background()
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name: "OrderWorkflow", Type: "workflow", FilePath: "order.go",
+				Annotations: map[string]string{"owner": "team-payments"},
+			},
+			"ChargeActivity": {
+				Name: "ChargeActivity", Type: "activity", FilePath: "charge.go",
+			},
+			"OrderSignal": {
+				Name: "OrderSignal", Type: "signal", FilePath: "order.go",
+			},
+		},
+	}
+
+	if issues := NewMissingAnnotationRule(nil).Check(ctx, graph); len(issues) != 0 {
+		t.Errorf("Expected no issues without requirements, got %d", len(issues))
+	}
+
+	rule := NewMissingAnnotationRule([]AnnotationRequirement{
+		{Annotation: "owner"},
+		{NodeType: "workflow", Annotation: "sla"},
+	})
+	if rule.ID() != "TA037" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA037")
+	}
+
+	issues := rule.Check(ctx, graph)
+	got := make(map[string]bool)
+	for _, issue := range issues {
+		got[issue.NodeName+" "+issue.Message] = true
+	}
+	want := []string{
+		"ChargeActivity Missing required @owner annotation on activity 'ChargeActivity'",
+		"OrderWorkflow Missing required @sla annotation on workflow 'OrderWorkflow'",
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %d: %v", len(want), len(issues), got)
+	}
+	for _, w := range want {
+		if !got[w] {
+			t.Errorf("Missing expected issue %q", w)
+		}
+	}
+}
+
+func TestContractDriftRule(t *testing.T) {
+	ctx := context.Background()
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", FilePath: "order.go", LineNumber: 12},
+		},
+	}
+
+	if issues := NewContractDriftRule(nil, nil).Check(ctx, graph); len(issues) != 0 {
+		t.Errorf("Expected no issues without base contracts, got %d", len(issues))
+	}
+
+	base := &contracts.Document{Workflows: []contracts.Workflow{
+		{Name: "OrderWorkflow", Input: []contracts.Param{{Name: "id", Type: "string"}}},
+	}}
+	head := &contracts.Document{Workflows: []contracts.Workflow{
+		{
+			Name:    "OrderWorkflow",
+			Input:   []contracts.Param{{Name: "id", Type: "int"}},
+			Queries: []contracts.Handler{{Name: "status", Type: "string"}},
+		},
+	}}
+
+	rule := NewContractDriftRule(base, head)
+	if rule.ID() != "TA038" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA038")
+	}
+
+	issues := rule.Check(ctx, graph)
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+	bySeverity := make(map[Severity]Issue)
+	for _, issue := range issues {
+		bySeverity[issue.Severity] = issue
+	}
+	breaking, ok := bySeverity[SeverityError]
+	if !ok || !strings.Contains(breaking.Message, "changed type from string to int") {
+		t.Errorf("Expected breaking input change as error, got %+v", issues)
+	}
+	if breaking.FilePath != "order.go" || breaking.LineNumber != 12 {
+		t.Errorf("Expected issue at workflow location, got %s:%d", breaking.FilePath, breaking.LineNumber)
+	}
+	if added, ok := bySeverity[SeverityInfo]; !ok || !strings.Contains(added.Message, `query "status" added`) {
+		t.Errorf("Expected additive query change as info, got %+v", issues)
+	}
+}
+
+func TestUnserializablePayloadRule(t *testing.T) {
+	rule := NewUnserializablePayloadRule([]analyzer.SerializationFinding{
+		{Node: "orders.Charge", Path: "req.Items[].Callback", Type: "func(string) error", Reason: "function"},
+		{Node: "Missing", Path: "done", Type: "chan bool", Reason: "channel"},
+	})
+
+	if rule.ID() != "TA042" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA042")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"orders.Charge": {Name: "Charge", Key: "orders.Charge", Type: "activity", FilePath: "/src/orders/activities.go", LineNumber: 12},
+		},
+	}
+
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].FilePath != "/src/orders/activities.go" || issues[0].LineNumber != 12 || issues[0].NodeName != "orders.Charge" {
+		t.Errorf("Issue location = %s:%d (%s), want /src/orders/activities.go:12 (orders.Charge)", issues[0].FilePath, issues[0].LineNumber, issues[0].NodeName)
+	}
+	if !strings.Contains(issues[0].Message, "Activity 'Charge' passes 'req.Items[].Callback' of type func(string) error") {
+		t.Errorf("Unexpected message: %s", issues[0].Message)
+	}
+}
+
+func TestChildWorkflowActivityContextRule(t *testing.T) {
+	rule := &ChildWorkflowActivityContextRule{}
+
+	if rule.ID() != "TA043" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA043")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:     "OrderWorkflow",
+				Type:     "workflow",
+				FilePath: "/src/order.go",
+				CallSites: []analyzer.CallSite{
+					{TargetName: "ShippingWorkflow", TargetType: "child_workflow", CallType: "execute", LineNumber: 12, ActivityOptionsContext: true},
+					{TargetName: "InvoiceWorkflow", TargetType: "child_workflow", CallType: "execute", LineNumber: 14},
+					{TargetName: "ChargeActivity", TargetType: "activity", CallType: "execute", LineNumber: 16},
+				},
+			},
+		},
+	}
+
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].FilePath != "/src/order.go" || issues[0].LineNumber != 12 || issues[0].NodeName != "OrderWorkflow" {
+		t.Errorf("Issue location = %s:%d (%s), want /src/order.go:12 (OrderWorkflow)", issues[0].FilePath, issues[0].LineNumber, issues[0].NodeName)
+	}
+	if !strings.Contains(issues[0].Message, "ShippingWorkflow") {
+		t.Errorf("Message should name the child workflow: %s", issues[0].Message)
+	}
+}
+
+func TestActivityCalledDirectlyRule(t *testing.T) {
+	rule := &ActivityCalledDirectlyRule{}
+
+	if rule.ID() != "TA044" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA044")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:     "OrderWorkflow",
+				Type:     "workflow",
+				Package:  "orders",
+				FilePath: "/src/orders/workflow.go",
+				InternalCalls: []analyzer.InternalCall{
+					{TargetName: "Charge", CallType: "function", LineNumber: 12},
+					{TargetName: "Notify", Receiver: "a", CallType: "method", LineNumber: 14},
+					{TargetName: "Ship", Receiver: "shipping", CallType: "method", LineNumber: 16},
+					{TargetName: "validate", CallType: "function", LineNumber: 18},
+				},
+			},
+			"Charge": {Name: "Charge", Type: "activity", Package: "orders"},
+			"*Activities.Notify": {Name: "*Activities.Notify", Type: "activity", Package: "orders",
+				CalledBy: []analyzer.ParentRef{{Name: "ReminderWorkflow", FilePath: "/src/orders/reminder.go", LineNumber: 30, CallType: "execute"}}},
+			// Never registered or executed, so not known to be an activity
+			"Ship": {Name: "Ship", Type: "activity", Package: "shipping"},
+		},
+		Workers: []analyzer.WorkerConfig{
+			{TaskQueue: "orders", FilePath: "/src/cmd/worker.go", LineNumber: 20, Activities: []string{"Charge"}},
+		},
+	}
+
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].LineNumber < issues[j].LineNumber })
+
+	if issues[0].LineNumber != 12 || !strings.Contains(issues[0].Message, "registered on the worker for task queue 'orders'") {
+		t.Errorf("Issue 0 = line %d, %q; want line 12 with the worker registration", issues[0].LineNumber, issues[0].Message)
+	}
+	if issues[1].LineNumber != 14 || !strings.Contains(issues[1].Message, "as a.Notify()") ||
+		!strings.Contains(issues[1].Message, "executed as an activity by 'ReminderWorkflow'") {
+		t.Errorf("Issue 1 = line %d, %q; want line 14 with the executing workflow", issues[1].LineNumber, issues[1].Message)
+	}
+}
+
+func TestWorkflowContextEscapeRule(t *testing.T) {
+	rule := &WorkflowContextEscapeRule{}
+
+	if rule.ID() != "TA045" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA045")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:     "OrderWorkflow",
+				Type:     "workflow",
+				FilePath: "/src/order.go",
+				ContextEscapes: []analyzer.ContextEscape{
+					{Kind: analyzer.EscapeField, Target: "s.ctx", Context: "ctx", LineNumber: 12},
+					{Kind: analyzer.EscapeGoroutine, Target: "go process", Context: "ctx", LineNumber: 14},
+				},
+			},
+			// Only workflows are checked
+			"Helper": {
+				Name:           "Helper",
+				Type:           "activity",
+				ContextEscapes: []analyzer.ContextEscape{{Kind: analyzer.EscapeGlobal, Target: "current", Context: "ctx", LineNumber: 3}},
+			},
+		},
+	}
+
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+	if issues[0].LineNumber != 12 || !strings.Contains(issues[0].Message, "in field 's.ctx'") {
+		t.Errorf("Issue 0 = line %d, %q; want the field at line 12", issues[0].LineNumber, issues[0].Message)
+	}
+	if issues[1].LineNumber != 14 || !strings.Contains(issues[1].Message, "native goroutine (go process)") {
+		t.Errorf("Issue 1 = line %d, %q; want the goroutine at line 14", issues[1].LineNumber, issues[1].Message)
+	}
+}
+
+func TestTaskQueueWithoutWorkerRule(t *testing.T) {
+	rule := &TaskQueueWithoutWorkerRule{}
+
+	if rule.ID() != "TA046" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA046")
+	}
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow":  {Name: "OrderWorkflow", Type: "workflow"},
+			"RefundWorkflow": {Name: "RefundWorkflow", Type: "workflow"},
+		},
+		WorkflowIDs: []analyzer.WorkflowID{
+			{Workflow: "OrderWorkflow", TaskQueue: "orders.TaskQueue", FilePath: "/src/api.go", LineNumber: 10},
+			{Workflow: "OrderWorkflow", TaskQueue: "ordres", FilePath: "/src/api.go", LineNumber: 11},
+			{Workflow: "RefundWorkflow", TaskQueue: "TaskQueue", FilePath: "/src/api.go", LineNumber: 12},
+			// Unknown queues and workflows outside the graph on polled queues are not reported
+			{Workflow: "OrderWorkflow", FilePath: "/src/api.go", LineNumber: 13},
+			{Workflow: "ExternalWorkflow", TaskQueue: "TaskQueue", FilePath: "/src/api.go", LineNumber: 14},
+		},
+	}
+
+	if issues := rule.Check(context.Background(), graph); len(issues) != 0 {
+		t.Fatalf("Expected no issues without workers, got %+v", issues)
+	}
+
+	graph.Workers = []analyzer.WorkerConfig{{TaskQueue: "TaskQueue", Workflows: []string{"OrderWorkflow"}}}
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+	if issues[0].LineNumber != 11 || !strings.Contains(issues[0].Message, "'ordres', which no worker polls") {
+		t.Errorf("Issue 0 = line %d, %q; want the unpolled queue at line 11", issues[0].LineNumber, issues[0].Message)
+	}
+	if issues[1].LineNumber != 12 || !strings.Contains(issues[1].Message, "Workflow 'RefundWorkflow'") ||
+		!strings.Contains(issues[1].Message, "don't register it") {
+		t.Errorf("Issue 1 = line %d, %q; want the unregistered workflow at line 12", issues[1].LineNumber, issues[1].Message)
+	}
+}
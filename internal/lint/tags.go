@@ -0,0 +1,143 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ruleTags are the topics of each rule, which --lint-tags selects rules by. They cut across
+// categories: the determinism tag covers reliability and best-practice rules alike.
+var ruleTags = map[string][]string{
+	"TA001": {"retries"},
+	"TA002": {"timeouts"},
+	"TA003": {"timeouts"},
+	"TA004": {"retries"},
+	"TA005": {"messages"},
+	"TA006": {"messages"},
+	"TA007": {"determinism", "observability"},
+	"TA008": {"determinism", "observability"},
+	"TA009": {"determinism"},
+	"TA010": {"structure"},
+	"TA011": {"structure"},
+	"TA012": {"structure"},
+	"TA013": {"versioning"},
+	"TA014": {"messages"},
+	"TA015": {"messages"},
+	"TA016": {"messages"},
+	"TA017": {"messages"},
+	"TA020": {"scalability", "structure"},
+	"TA021": {"structure"},
+	"TA022": {"scalability", "workers"},
+	"TA023": {"timeouts"},
+	"TA030": {"versioning"},
+	"TA031": {"messages"},
+	"TA032": {"messages"},
+	"TA033": {"history"},
+	"TA034": {"messages"},
+	"TA035": {"testing"},
+	"TA036": {"determinism", "versioning"},
+	"TA037": {"documentation"},
+	"TA038": {"payloads", "versioning"},
+	"TA039": {"payloads"},
+	"TA040": {"payloads"},
+	"TA041": {"correctness"},
+	"TA042": {"payloads"},
+	"TA043": {"retries", "timeouts"},
+	"TA044": {"determinism", "retries"},
+	"TA045": {"determinism"},
+	"TA046": {"workers"},
+}
+
+// RuleTags returns the tags of a rule.
+func RuleTags(ruleID string) []string {
+	return ruleTags[ruleID]
+}
+
+// Tags returns all rule tags, sorted.
+func Tags() []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, ruleTags := range ruleTags {
+		for _, tag := range ruleTags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Categories returns all rule categories.
+func Categories() []Category {
+	return []Category{CategoryReliability, CategoryBestPractice, CategoryPerformance, CategoryMaintenance, CategorySecurity}
+}
+
+// ParseCategories parses the category names given to --lint-categories.
+func ParseCategories(names []string) ([]Category, error) {
+	var categories []Category
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, c := range Categories() {
+			if string(c) == name {
+				categories = append(categories, c)
+				found = true
+			}
+		}
+		if !found {
+			var valid []string
+			for _, c := range Categories() {
+				valid = append(valid, string(c))
+			}
+			return nil, fmt.Errorf("invalid lint category: %s (valid: %s)", name, strings.Join(valid, ", "))
+		}
+	}
+	return categories, nil
+}
+
+// ParseTags checks the tag names given to --lint-tags.
+func ParseTags(names []string) ([]string, error) {
+	known := Tags()
+	var tags []string
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		i := sort.SearchStrings(known, name)
+		if i == len(known) || known[i] != name {
+			return nil, fmt.Errorf("invalid lint tag: %s (valid: %s)", name, strings.Join(known, ", "))
+		}
+		tags = append(tags, name)
+	}
+	return tags, nil
+}
+
+// selected reports whether a rule matches the category and tag selection of the config.
+// Empty selections match every rule.
+func (c *Config) selected(rule Rule) bool {
+	if len(c.Categories) > 0 {
+		found := false
+		for _, category := range c.Categories {
+			found = found || category == rule.Category()
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(c.Tags) > 0 {
+		for _, tag := range c.Tags {
+			for _, ruleTag := range RuleTags(rule.ID()) {
+				if tag == ruleTag {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return true
+}
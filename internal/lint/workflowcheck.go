@@ -0,0 +1,303 @@
+package lint
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// =============================================================================
+// workflowcheck compatibility
+// =============================================================================
+
+// WorkflowcheckIgnore is the comment Temporal's workflowcheck tool honors to suppress a
+// finding on the same or next line, or for a whole function when in its doc comment.
+const WorkflowcheckIgnore = "//workflowcheck:ignore"
+
+// determinismRules are the rules suppressed by workflowcheck ignore comments and configuration.
+var determinismRules = map[string]bool{"TA007": true, "TA008": true, "TA009": true}
+
+// WorkflowcheckConfig is a configuration file of Temporal's workflowcheck tool.
+type WorkflowcheckConfig struct {
+	// Decls overrides whether qualified functions are non-deterministic (true) or deterministic (false)
+	Decls map[string]bool
+	// Skip lists package paths that are not checked
+	Skip []string
+}
+
+// LoadWorkflowcheckConfig reads a workflowcheck configuration file.
+func LoadWorkflowcheckConfig(path string) (*WorkflowcheckConfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workflowcheck config: %w", err)
+	}
+	defer f.Close()
+	return ParseWorkflowcheckConfig(f)
+}
+
+// ParseWorkflowcheckConfig parses the YAML subset used by workflowcheck configuration:
+//
+//	decls:
+//	  example.com/pkg.Func: false
+//	skip:
+//	  - example.com/pkg/generated
+func ParseWorkflowcheckConfig(r io.Reader) (*WorkflowcheckConfig, error) {
+	cfg := &WorkflowcheckConfig{Decls: make(map[string]bool)}
+	section := ""
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			key, value, ok := strings.Cut(trimmed, ":")
+			if !ok || strings.TrimSpace(value) != "" && strings.TrimSpace(value) != "[]" && strings.TrimSpace(value) != "{}" {
+				return nil, fmt.Errorf("invalid workflowcheck config line %d: %q", lineNum, trimmed)
+			}
+			section = key
+			continue
+		}
+
+		switch section {
+		case "decls":
+			key, value, ok := strings.Cut(trimmed, ":")
+			nonDeterministic, err := strconv.ParseBool(strings.TrimSpace(value))
+			if !ok || err != nil {
+				return nil, fmt.Errorf("invalid workflowcheck decl on line %d: %q (expected name: true|false)", lineNum, trimmed)
+			}
+			cfg.Decls[unquoteYAML(key)] = nonDeterministic
+		case "skip":
+			item, ok := strings.CutPrefix(trimmed, "-")
+			if !ok {
+				return nil, fmt.Errorf("invalid workflowcheck skip entry on line %d: %q", lineNum, trimmed)
+			}
+			cfg.Skip = append(cfg.Skip, unquoteYAML(item))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read workflowcheck config: %w", err)
+	}
+	return cfg, nil
+}
+
+// unquoteYAML trims whitespace and YAML quotes from a scalar.
+func unquoteYAML(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' && s[len(s)-1] == '"' || s[0] == '\'' && s[len(s)-1] == '\'') {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// WorkflowcheckFinding is a non-determinism finding reported by workflowcheck.
+type WorkflowcheckFinding struct {
+	FilePath   string
+	LineNumber int
+	// Func is the qualified name of the non-deterministic workflow or function
+	Func   string
+	Reason string
+}
+
+// workflowcheckLine matches "file.go:12:2: example.com/pkg.Workflow is non-deterministic, reason: ...".
+var workflowcheckLine = regexp.MustCompile(`^(.+?):(\d+):\d+: (\S+) is non-deterministic, reason: (.+)$`)
+
+// LoadWorkflowcheckFindings reads workflowcheck output from a file.
+func LoadWorkflowcheckFindings(path string) ([]WorkflowcheckFinding, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workflowcheck results: %w", err)
+	}
+	defer f.Close()
+	return ParseWorkflowcheckFindings(f)
+}
+
+// ParseWorkflowcheckFindings parses workflowcheck output. Indented -show-pos detail lines
+// and other output are skipped.
+func ParseWorkflowcheckFindings(r io.Reader) ([]WorkflowcheckFinding, error) {
+	var findings []WorkflowcheckFinding
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m := workflowcheckLine.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		line, _ := strconv.Atoi(m[2])
+		findings = append(findings, WorkflowcheckFinding{FilePath: m[1], LineNumber: line, Func: m[3], Reason: m[4]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read workflowcheck results: %w", err)
+	}
+	return findings, nil
+}
+
+// WorkflowcheckRule reports the findings imported from workflowcheck.
+type WorkflowcheckRule struct {
+	Findings []WorkflowcheckFinding
+	Config   *WorkflowcheckConfig
+}
+
+func NewWorkflowcheckRule(findings []WorkflowcheckFinding, cfg *WorkflowcheckConfig) *WorkflowcheckRule {
+	return &WorkflowcheckRule{Findings: findings, Config: cfg}
+}
+
+func (r *WorkflowcheckRule) ID() string         { return "TA009" }
+func (r *WorkflowcheckRule) Name() string       { return "workflowcheck-nondeterminism" }
+func (r *WorkflowcheckRule) Category() Category { return CategoryReliability }
+func (r *WorkflowcheckRule) Severity() Severity { return SeverityError }
+func (r *WorkflowcheckRule) Description() string {
+	return "Temporal's workflowcheck found a workflow that reaches non-deterministic code (time, randomness, goroutines, map iteration, global state). Replaying its history on another worker can take a different path and fail. Findings are imported with --workflowcheck-results."
+}
+
+func (r *WorkflowcheckRule) Check(ctx context.Context, graph *analyzer.TemporalGraph) []Issue {
+	var issues []Issue
+	for _, finding := range r.Findings {
+		if r.Config != nil && r.Config.deterministic(finding.Reason) {
+			continue
+		}
+
+		name := finding.Func
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		issue := Issue{
+			RuleID:      r.ID(),
+			RuleName:    r.Name(),
+			Severity:    r.Severity(),
+			Category:    r.Category(),
+			Message:     fmt.Sprintf("%s is non-deterministic: %s", finding.Func, finding.Reason),
+			Description: r.Description(),
+			Suggestion:  "Use the workflow package equivalents (workflow.Now, workflow.Go, workflow.SideEffect) or move the code into an activity; mark intentional cases with //workflowcheck:ignore",
+			FilePath:    finding.FilePath,
+			LineNumber:  finding.LineNumber,
+			NodeName:    name,
+		}
+		if node, ok := graph.Nodes[name]; ok {
+			issue.NodeType = node.Type
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// deterministic reports whether a finding's reason only involves functions the
+// configuration declares deterministic.
+func (c *WorkflowcheckConfig) deterministic(reason string) bool {
+	for decl, nonDeterministic := range c.Decls {
+		if !nonDeterministic && strings.HasSuffix(reason, " "+decl) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipped reports whether an import path is excluded by the configuration.
+func (c *WorkflowcheckConfig) skipped(importPath string) bool {
+	for _, skip := range c.Skip {
+		skip = strings.TrimSuffix(strings.TrimSuffix(skip, "..."), "/")
+		if importPath == skip || strings.HasPrefix(importPath, skip+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// workflowcheckSuppressed reports whether a determinism issue is suppressed by an ignore
+// comment or by a package skipped in the workflowcheck configuration.
+func (l *Linter) workflowcheckSuppressed(issue Issue, graph *analyzer.TemporalGraph) bool {
+	if !determinismRules[issue.RuleID] {
+		return false
+	}
+
+	filePath := issue.FilePath
+	node := graph.Nodes[issue.NodeName]
+	if filePath == "" && node != nil {
+		filePath = node.FilePath
+	}
+	if filePath == "" {
+		return false
+	}
+	if !filepath.IsAbs(filePath) && l.config.RootDir != "" {
+		filePath = filepath.Join(l.config.RootDir, filePath)
+	}
+
+	if cfg := l.config.Workflowcheck; cfg != nil && len(cfg.Skip) > 0 {
+		if importPath := l.importPath(filePath); importPath != "" && cfg.skipped(importPath) {
+			return true
+		}
+	}
+
+	lines := l.sourceLines(filePath)
+	if lines == nil {
+		return false
+	}
+	// Same line or the line above the finding
+	for _, n := range []int{issue.LineNumber, issue.LineNumber - 1} {
+		if n >= 1 && n <= len(lines) && strings.Contains(lines[n-1], WorkflowcheckIgnore) {
+			return true
+		}
+	}
+	// Doc comment of the enclosing workflow
+	if node != nil && node.FilePath == filePath {
+		for n := node.LineNumber - 1; n >= 1 && n <= len(lines); n-- {
+			text := strings.TrimSpace(lines[n-1])
+			if !strings.HasPrefix(text, "//") {
+				break
+			}
+			if strings.HasPrefix(text, WorkflowcheckIgnore) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sourceLines returns the lines of a source file, cached for the linter run.
+func (l *Linter) sourceLines(path string) []string {
+	if lines, ok := l.sources[path]; ok {
+		return lines
+	}
+	var lines []string
+	if data, err := os.ReadFile(path); err == nil {
+		lines = strings.Split(string(data), "\n")
+	}
+	l.sources[path] = lines
+	return lines
+}
+
+// importPath returns the import path of the package containing a file under RootDir,
+// using the module path declared in RootDir/go.mod.
+func (l *Linter) importPath(filePath string) string {
+	if l.config.RootDir == "" {
+		return ""
+	}
+	modLines := l.sourceLines(filepath.Join(l.config.RootDir, "go.mod"))
+	module := ""
+	for _, line := range modLines {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			module = unquoteYAML(rest)
+			break
+		}
+	}
+	rel, err := filepath.Rel(l.config.RootDir, filepath.Dir(filePath))
+	if module == "" || err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	if rel == "." {
+		return module
+	}
+	return module + "/" + filepath.ToSlash(rel)
+}
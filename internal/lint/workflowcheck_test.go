@@ -0,0 +1,139 @@
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func TestParseWorkflowcheckConfig(t *testing.T) {
+	cfg, err := ParseWorkflowcheckConfig(strings.NewReader(`# workflowcheck config
+decls:
+  example.com/app/clock.Now: false # deterministic wrapper
+  "example.com/app/cache.Get": true
+skip:
+  - example.com/app/generated
+`))
+	if err != nil {
+		t.Fatalf("ParseWorkflowcheckConfig() error = %v", err)
+	}
+	if nonDet, ok := cfg.Decls["example.com/app/clock.Now"]; !ok || nonDet {
+		t.Errorf("Decls[clock.Now] = %v, %v, want false, true", nonDet, ok)
+	}
+	if !cfg.Decls["example.com/app/cache.Get"] {
+		t.Error("Decls[cache.Get] should be non-deterministic")
+	}
+	if len(cfg.Skip) != 1 || cfg.Skip[0] != "example.com/app/generated" {
+		t.Errorf("Skip = %v, want [example.com/app/generated]", cfg.Skip)
+	}
+
+	for _, invalid := range []string{"decls:\n  example.com/app.F: maybe\n", "skip:\n  example.com/app\n", "decls: yes\n"} {
+		if _, err := ParseWorkflowcheckConfig(strings.NewReader(invalid)); err == nil {
+			t.Errorf("ParseWorkflowcheckConfig(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestParseWorkflowcheckFindings(t *testing.T) {
+	findings, err := ParseWorkflowcheckFindings(strings.NewReader(`/src/orders/workflow.go:12:6: example.com/app/orders.OrderWorkflow is non-deterministic, reason: calls non-deterministic function time.Now
+	example.com/app/orders.helper is non-deterministic, reason: calls non-deterministic function time.Now
+some unrelated line
+`))
+	if err != nil {
+		t.Fatalf("ParseWorkflowcheckFindings() error = %v", err)
+	}
+	want := WorkflowcheckFinding{
+		FilePath:   "/src/orders/workflow.go",
+		LineNumber: 12,
+		Func:       "example.com/app/orders.OrderWorkflow",
+		Reason:     "calls non-deterministic function time.Now",
+	}
+	if len(findings) != 1 || findings[0] != want {
+		t.Errorf("ParseWorkflowcheckFindings() = %+v, want [%+v]", findings, want)
+	}
+}
+
+func TestWorkflowcheckRule(t *testing.T) {
+	findings := []WorkflowcheckFinding{
+		{FilePath: "/src/orders/workflow.go", LineNumber: 12, Func: "example.com/app/orders.OrderWorkflow", Reason: "calls non-deterministic function time.Now"},
+		{FilePath: "/src/orders/workflow.go", LineNumber: 30, Func: "example.com/app/orders.OrderWorkflow", Reason: "calls non-deterministic function example.com/app/clock.Now"},
+	}
+	cfg := &WorkflowcheckConfig{Decls: map[string]bool{"example.com/app/clock.Now": false}}
+	rule := NewWorkflowcheckRule(findings, cfg)
+
+	if rule.ID() != "TA009" {
+		t.Errorf("ID() = %q, want %q", rule.ID(), "TA009")
+	}
+
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow"},
+	}}
+	issues := rule.Check(context.Background(), graph)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue (clock.Now is declared deterministic), got %d", len(issues))
+	}
+	if issues[0].NodeName != "OrderWorkflow" || issues[0].NodeType != "workflow" || issues[0].LineNumber != 12 {
+		t.Errorf("Unexpected issue: %+v", issues[0])
+	}
+}
+
+func TestWorkflowcheckSuppression(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) string {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("go.mod", "module example.com/app\n")
+	orders := write("orders/workflow.go", `package orders
+
+func OrderWorkflow(ctx workflow.Context) error {
+	//workflowcheck:ignore
+	fmt.Println("ignored")
+	fmt.Println("reported")
+	return nil
+}
+
+//workflowcheck:ignore
+func AuditWorkflow(ctx workflow.Context) error {
+	fmt.Println("ignored")
+	return nil
+}
+`)
+	generated := write("generated/workflow.go", "package generated\n\nfunc GenWorkflow(ctx workflow.Context) error {\n\tfmt.Println(\"x\")\n\treturn nil\n}\n")
+
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", FilePath: orders, LineNumber: 3, LogCalls: []analyzer.LogCall{
+			{Call: "fmt.Println", Level: "Info", LineNumber: 5},
+			{Call: "fmt.Println", Level: "Info", LineNumber: 6},
+		}},
+		"AuditWorkflow": {Name: "AuditWorkflow", Type: "workflow", FilePath: orders, LineNumber: 11, LogCalls: []analyzer.LogCall{
+			{Call: "fmt.Println", Level: "Info", LineNumber: 12},
+		}},
+		"GenWorkflow": {Name: "GenWorkflow", Type: "workflow", FilePath: generated, LineNumber: 3, LogCalls: []analyzer.LogCall{
+			{Call: "fmt.Println", Level: "Info", LineNumber: 4},
+		}},
+	}}
+
+	cfg := DefaultConfig()
+	cfg.EnabledRules = []string{"TA007"}
+	cfg.RootDir = root
+	cfg.Workflowcheck = &WorkflowcheckConfig{Skip: []string{"example.com/app/generated"}}
+
+	result := NewLinter(cfg).Run(context.Background(), graph)
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected 1 unsuppressed issue, got %+v", result.Issues)
+	}
+	if result.Issues[0].NodeName != "OrderWorkflow" || result.Issues[0].LineNumber != 6 {
+		t.Errorf("Unexpected issue: %+v", result.Issues[0])
+	}
+}
@@ -0,0 +1,361 @@
+// Package onboarding inspects a project analyzed for the first time and writes a starter
+// config file and CI workflow for it, asking a few questions on the way.
+package onboarding
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// SDKModule is the module path of the Temporal Go SDK.
+const SDKModule = "go.temporal.io/sdk"
+
+// CIWorkflowFile is the GitHub Actions workflow written by the wizard.
+const CIWorkflowFile = ".github/workflows/temporal-analyzer.yml"
+
+// Project is what the wizard found in the analyzed project.
+type Project struct {
+	// Root is the analyzed directory
+	Root string
+	// SDKVersion is the version of the Temporal Go SDK required by go.mod, or ""
+	SDKVersion string
+	Workflows  int
+	Activities int
+	// TaskQueues are the task queues of the workers created with worker.New
+	TaskQueues []string
+	// SourceDir is the deepest directory, relative to Root, containing every workflow and activity
+	SourceDir string
+	// GeneratedDirs are directory names whose Go files are all generated, and testdata
+	// directories containing Go files
+	GeneratedDirs []string
+	// HasTests is true if the project has Go test files
+	HasTests bool
+}
+
+// Answers are the settings chosen for the project.
+type Answers struct {
+	// Root is the directory to analyze, as written to the config file
+	Root string
+	// Exclude are the directory names to skip besides the default ones
+	Exclude      []string
+	IncludeTests bool
+	// Strict fails lint on warnings
+	Strict bool
+	// CIWorkflow writes a GitHub Actions workflow running lint
+	CIWorkflow bool
+}
+
+// Inspect describes a project from its analyzed graph and its files.
+func Inspect(graph *analyzer.TemporalGraph, opts config.AnalysisOptions) (*Project, error) {
+	p := &Project{Root: opts.RootDir, SourceDir: "."}
+
+	var dirs []string
+	for _, node := range graph.Nodes {
+		if node.Unresolved {
+			continue
+		}
+		switch node.Type {
+		case "workflow":
+			p.Workflows++
+		case "activity":
+			p.Activities++
+		default:
+			continue
+		}
+		if rel, err := filepath.Rel(opts.RootDir, filepath.Dir(node.FilePath)); err == nil {
+			dirs = append(dirs, rel)
+		}
+	}
+	if len(dirs) > 0 {
+		p.SourceDir = commonDir(dirs)
+	}
+
+	seen := make(map[string]bool)
+	for _, w := range graph.Workers {
+		if w.TaskQueue != "" && !seen[w.TaskQueue] {
+			seen[w.TaskQueue] = true
+			p.TaskQueues = append(p.TaskQueues, w.TaskQueue)
+		}
+	}
+	sort.Strings(p.TaskQueues)
+
+	version, err := sdkVersion(filepath.Join(opts.RootDir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	p.SDKVersion = version
+
+	if err := p.inspectFiles(opts); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// inspectFiles finds the generated directories and test files of the project.
+func (p *Project) inspectFiles(opts config.AnalysisOptions) error {
+	// generated and handwritten count the Go files directly in directories of each name
+	generated := make(map[string]int)
+	handwritten := make(map[string]int)
+	err := filepath.Walk(opts.RootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != opts.RootDir && opts.ExcludesDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		if strings.HasSuffix(path, "_test.go") {
+			p.HasTests = true
+		}
+		dir := filepath.Base(filepath.Dir(path))
+		if dir == "testdata" || isGenerated(path) {
+			generated[dir]++
+		} else {
+			handwritten[dir]++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", opts.RootDir, err)
+	}
+
+	for dir := range generated {
+		if handwritten[dir] == 0 && dir != filepath.Base(opts.RootDir) {
+			p.GeneratedDirs = append(p.GeneratedDirs, dir)
+		}
+	}
+	sort.Strings(p.GeneratedDirs)
+	return nil
+}
+
+// Propose returns the answers suggested for the project: analyze the directory containing
+// the Temporal code, without its generated directories.
+func (p *Project) Propose() Answers {
+	return Answers{
+		Root:       filepath.Join(p.Root, p.SourceDir),
+		Exclude:    p.GeneratedDirs,
+		CIWorkflow: true,
+	}
+}
+
+// Summary describes what was found, one finding per line.
+func (p *Project) Summary() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d workflows and %d activities", p.Workflows, p.Activities)
+	if p.SourceDir != "." {
+		fmt.Fprintf(&sb, " under %s", p.SourceDir)
+	}
+	sb.WriteString("\n")
+	if p.SDKVersion != "" {
+		fmt.Fprintf(&sb, "Temporal Go SDK: %s\n", p.SDKVersion)
+	} else {
+		fmt.Fprintf(&sb, "Temporal Go SDK: not required by go.mod\n")
+	}
+	if len(p.TaskQueues) > 0 {
+		fmt.Fprintf(&sb, "Task queues: %s\n", strings.Join(p.TaskQueues, ", "))
+	}
+	if len(p.GeneratedDirs) > 0 {
+		fmt.Fprintf(&sb, "Generated or fixture directories: %s\n", strings.Join(p.GeneratedDirs, ", "))
+	}
+	return sb.String()
+}
+
+// Ask prints what was found and asks for each setting, offering the proposed answer as the
+// default. Empty lines, and the end of the input, accept the defaults.
+func Ask(in io.Reader, out io.Writer, p *Project) (Answers, error) {
+	answers := p.Propose()
+	reader := bufio.NewReader(in)
+	ask := func(question, def string) (string, error) {
+		if _, err := fmt.Fprintf(out, "%s [%s]: ", question, def); err != nil {
+			return "", err
+		}
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		if errors.Is(err, io.EOF) {
+			_, _ = fmt.Fprintln(out)
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			return line, nil
+		}
+		return def, nil
+	}
+	confirm := func(question string, def bool) (bool, error) {
+		hint := "y/N"
+		if def {
+			hint = "Y/n"
+		}
+		answer, err := ask(question, hint)
+		if err != nil || answer == hint {
+			return def, err
+		}
+		return strings.HasPrefix(strings.ToLower(answer), "y"), nil
+	}
+
+	if _, err := fmt.Fprint(out, p.Summary(), "\n"); err != nil {
+		return answers, err
+	}
+
+	var err error
+	if answers.Root, err = ask("Directory to analyze", answers.Root); err != nil {
+		return answers, err
+	}
+	exclude, err := ask("Directories to exclude, comma-separated (none to exclude no more)", orNone(answers.Exclude))
+	if err != nil {
+		return answers, err
+	}
+	answers.Exclude = nil
+	if exclude != "none" {
+		for _, dir := range strings.Split(exclude, ",") {
+			if dir = strings.TrimSpace(dir); dir != "" {
+				answers.Exclude = append(answers.Exclude, dir)
+			}
+		}
+	}
+	if p.HasTests {
+		if answers.IncludeTests, err = confirm("Analyze test files too", false); err != nil {
+			return answers, err
+		}
+	}
+	if answers.Strict, err = confirm("Fail lint on warnings, not only errors", false); err != nil {
+		return answers, err
+	}
+	if answers.CIWorkflow, err = confirm("Write a GitHub Actions workflow to "+CIWorkflowFile, true); err != nil {
+		return answers, err
+	}
+	return answers, nil
+}
+
+// WriteConfig writes the config file for the answers.
+func WriteConfig(w io.Writer, p *Project, a Answers) error {
+	var sb strings.Builder
+	sb.WriteString("# temporal-analyzer settings, read from the directory it runs in.\n")
+	sb.WriteString("# Each setting is a command line flag; flags given on the command line override them.\n")
+	if p.SDKVersion != "" {
+		fmt.Fprintf(&sb, "# Temporal Go SDK: %s\n", p.SDKVersion)
+	}
+	if len(p.TaskQueues) > 0 {
+		fmt.Fprintf(&sb, "# Task queues: %s\n", strings.Join(p.TaskQueues, ", "))
+	}
+	sb.WriteString("\n")
+	if root := filepath.ToSlash(filepath.Clean(a.Root)); root != "." {
+		fmt.Fprintf(&sb, "root: %s\n", root)
+	}
+	if len(a.Exclude) > 0 {
+		sb.WriteString("exclude:\n")
+		for _, dir := range a.Exclude {
+			fmt.Fprintf(&sb, "  - %q\n", dir)
+		}
+	}
+	fmt.Fprintf(&sb, "include-tests: %t\n", a.IncludeTests)
+	fmt.Fprintf(&sb, "lint-strict: %t\n", a.Strict)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteCIWorkflow writes a GitHub Actions workflow running lint with the config file.
+func WriteCIWorkflow(w io.Writer) error {
+	_, err := io.WriteString(w, `name: Temporal Workflow Analysis
+on: [push, pull_request]
+
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+
+      - name: Set up Go
+        uses: actions/setup-go@v5
+        with:
+          go-version-file: go.mod
+
+      - name: Install Temporal Analyzer
+        run: go install github.com/ikari-pl/go-temporalio-analyzer@latest
+
+      # Settings are read from `+config.ConfigFile+`
+      - name: Run Lint
+        run: temporal-analyzer lint --lint-format github
+`)
+	return err
+}
+
+// sdkVersion returns the version of the Temporal Go SDK required by a go.mod file, or "" if
+// the file does not exist or does not require it.
+func sdkVersion(goModPath string) (string, error) {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read %s: %w", goModPath, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), "require"))
+		if len(fields) >= 2 && fields[0] == SDKModule {
+			return fields[1], nil
+		}
+	}
+	return "", nil
+}
+
+// isGenerated reports whether a Go file carries the standard "Code generated ... DO NOT
+// EDIT." header.
+func isGenerated(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "// Code generated ") && strings.HasSuffix(line, " DO NOT EDIT.") {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+	}
+	return false
+}
+
+// commonDir returns the deepest directory containing all the given relative directories.
+func commonDir(dirs []string) string {
+	common := strings.Split(filepath.ToSlash(dirs[0]), "/")
+	for _, dir := range dirs[1:] {
+		parts := strings.Split(filepath.ToSlash(dir), "/")
+		n := 0
+		for n < len(common) && n < len(parts) && common[n] == parts[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	if len(common) == 0 {
+		return "."
+	}
+	return filepath.FromSlash(strings.Join(common, "/"))
+}
+
+// orNone returns the comma-separated values, or "none" if there are none.
+func orNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
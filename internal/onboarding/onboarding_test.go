@@ -0,0 +1,180 @@
+package onboarding
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+var projectFiles = map[string]string{
+	"go.mod": `module example.com/shop
+
+go 1.22
+
+require (
+	go.temporal.io/sdk v1.26.0
+	go.temporal.io/api v1.29.0 // indirect
+)
+`,
+	"main.go": "package main\n\nfunc main() {}\n",
+	"internal/temporal/orders/workflow.go": `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, Charge).Get(ctx, nil)
+}
+
+func Charge(ctx context.Context) error { return nil }
+
+func run(c client.Client) {
+	w := worker.New(c, "orders", worker.Options{})
+	register(w)
+}
+
+func register(worker worker.Worker) {
+	worker.RegisterActivity(Charge)
+}
+`,
+	"internal/temporal/shipping/workflow.go": `package shipping
+
+import "go.temporal.io/sdk/workflow"
+
+func ShippingWorkflow(ctx workflow.Context) error {
+	return workflow.Sleep(ctx, 0)
+}
+`,
+	"internal/temporal/shipping/workflow_test.go": "package shipping\n",
+	"internal/gen/api.pb.go":                      "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage gen\n",
+	"internal/temporal/testdata/fixture.go":       "package testdata\n",
+}
+
+func inspectProject(t *testing.T) *Project {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range projectFiles {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := config.NewConfig().ToAnalysisOptions()
+	opts.RootDir = dir
+	a := analyzer.NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	graph, err := a.Analyze(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	p, err := Inspect(graph, opts)
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+	return p
+}
+
+func TestInspect(t *testing.T) {
+	p := inspectProject(t)
+
+	if p.SDKVersion != "v1.26.0" {
+		t.Errorf("SDKVersion = %q, want v1.26.0", p.SDKVersion)
+	}
+	if p.Workflows != 2 || p.Activities != 1 {
+		t.Errorf("Workflows = %d, Activities = %d, want 2 and 1", p.Workflows, p.Activities)
+	}
+	if !reflect.DeepEqual(p.TaskQueues, []string{"orders"}) {
+		t.Errorf("TaskQueues = %v, want [orders]", p.TaskQueues)
+	}
+	if p.SourceDir != filepath.Join("internal", "temporal") {
+		t.Errorf("SourceDir = %q, want internal/temporal", p.SourceDir)
+	}
+	if !reflect.DeepEqual(p.GeneratedDirs, []string{"gen", "testdata"}) {
+		t.Errorf("GeneratedDirs = %v, want [gen testdata]", p.GeneratedDirs)
+	}
+	if !p.HasTests {
+		t.Error("HasTests = false, want true")
+	}
+}
+
+func TestAskDefaults(t *testing.T) {
+	p := &Project{Root: ".", SourceDir: "internal", GeneratedDirs: []string{"gen"}, HasTests: true, Workflows: 2}
+
+	var out bytes.Buffer
+	answers, err := Ask(strings.NewReader(""), &out, p)
+	if err != nil {
+		t.Fatalf("Ask failed: %v", err)
+	}
+	want := Answers{Root: "internal", Exclude: []string{"gen"}, CIWorkflow: true}
+	if !reflect.DeepEqual(answers, want) {
+		t.Errorf("answers = %+v, want %+v", answers, want)
+	}
+	for _, s := range []string{"Found 2 workflows and 0 activities under internal", "Directory to analyze [internal]:", "Analyze test files too [y/N]:"} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("output does not contain %q:\n%s", s, out.String())
+		}
+	}
+}
+
+func TestAskAnswers(t *testing.T) {
+	p := &Project{Root: ".", SourceDir: ".", GeneratedDirs: []string{"gen"}}
+
+	answers, err := Ask(strings.NewReader("services\nmocks, proto\ny\nn\n"), io.Discard, p)
+	if err != nil {
+		t.Fatalf("Ask failed: %v", err)
+	}
+	// Without test files, the test question is not asked
+	want := Answers{Root: "services", Exclude: []string{"mocks", "proto"}, Strict: true}
+	if !reflect.DeepEqual(answers, want) {
+		t.Errorf("answers = %+v, want %+v", answers, want)
+	}
+
+	answers, err = Ask(strings.NewReader("\nnone\n"), io.Discard, p)
+	if err != nil {
+		t.Fatalf("Ask failed: %v", err)
+	}
+	if answers.Exclude != nil {
+		t.Errorf("Exclude = %v, want none", answers.Exclude)
+	}
+}
+
+func TestWriteConfig(t *testing.T) {
+	p := &Project{SDKVersion: "v1.26.0", TaskQueues: []string{"orders", "payments"}}
+	var buf bytes.Buffer
+	err := WriteConfig(&buf, p, Answers{Root: "internal/temporal", Exclude: []string{"gen"}, IncludeTests: true})
+	if err != nil {
+		t.Fatalf("WriteConfig failed: %v", err)
+	}
+
+	want := `# temporal-analyzer settings, read from the directory it runs in.
+# Each setting is a command line flag; flags given on the command line override them.
+# Temporal Go SDK: v1.26.0
+# Task queues: orders, payments
+
+root: internal/temporal
+exclude:
+  - "gen"
+include-tests: true
+lint-strict: false
+`
+	if buf.String() != want {
+		t.Errorf("config =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -5,22 +5,45 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
+
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
 )
 
 // Exporter provides export functionality for the graph.
-type Exporter struct{}
+type Exporter struct {
+	glyphs glyphs.Set
+	// rootDir is stripped from the file paths of node links
+	rootDir string
+	// linkBase is the URL of rootDir in a code browser; without one, links are relative paths
+	linkBase string
+}
 
 // NewExporter creates a new Exporter instance.
 func NewExporter() *Exporter {
-	return &Exporter{}
+	return NewExporterWithGlyphs(glyphs.Unicode)
+}
+
+// NewExporterWithGlyphs creates a new Exporter that labels nodes and sections with the given symbols.
+func NewExporterWithGlyphs(set glyphs.Set) *Exporter {
+	return &Exporter{glyphs: set}
 }
 
-// ExportJSON exports the graph as pretty-printed JSON.
+// WithLinks makes Mermaid nodes link to their file and line: paths relative to rootDir,
+// appended to linkBase when set, e.g. https://github.com/org/repo/blob/main/.
+func (e *Exporter) WithLinks(rootDir, linkBase string) *Exporter {
+	e.rootDir = rootDir
+	e.linkBase = linkBase
+	return e
+}
+
+// ExportJSON exports the graph as pretty-printed JSON, with its weighted edges and call options.
 func (e *Exporter) ExportJSON(graph *analyzer.TemporalGraph) ([]byte, error) {
-	return json.MarshalIndent(graph, "", "  ")
+	return json.MarshalIndent(toGraphJSON(graph), "", "  ")
 }
 
 // ExportDOT exports the graph as DOT format for Graphviz.
@@ -35,6 +58,10 @@ func (e *Exporter) ExportDOT(graph *analyzer.TemporalGraph) (string, error) {
 
 	// Define color schemes for different node types
 	buf.WriteString("  // Node type colors\n")
+	maxChurn := graph.MaxChurn()
+	if maxChurn > 0 {
+		buf.WriteString("  // Fill colors show churn (commits in the last 90 days), blue borders nodes first committed in that window\n")
+	}
 
 	// Sort nodes for consistent output
 	var nodeNames []string
@@ -43,6 +70,31 @@ func (e *Exporter) ExportDOT(graph *analyzer.TemporalGraph) (string, error) {
 	}
 	sort.Strings(nodeNames)
 
+	// Nodes with a business domain are clustered by domain, the rest by type
+	domains := e.domainNames(graph)
+	for i, domain := range domains {
+		color := e.domainColor(i)
+		buf.WriteString(fmt.Sprintf("  // Domain: %s\n", domain))
+		buf.WriteString(fmt.Sprintf("  subgraph cluster_domain_%d {\n", i))
+		buf.WriteString(fmt.Sprintf("    label=\"%s\";\n", e.escapeString(domain)))
+		buf.WriteString("    style=\"rounded,filled\";\n")
+		buf.WriteString(fmt.Sprintf("    color=\"%s\";\n", color))
+		buf.WriteString(fmt.Sprintf("    fillcolor=\"%s22\";\n", color))
+		for _, name := range nodeNames {
+			node := graph.Nodes[name]
+			if node.Domain != domain {
+				continue
+			}
+			fontColor := "black"
+			if node.Type == "workflow" {
+				fontColor = "white"
+			}
+			buf.WriteString(fmt.Sprintf("    \"%s\" [label=\"%s\\n%s%s\", fillcolor=\"%s\", fontcolor=\"%s\"%s];\n",
+				e.escapeString(name), e.escapeString(node.Name), node.Package, e.collapsedLabel(node), e.getNodeColor(node.Type), fontColor, e.churnAttrs(node, maxChurn)+e.collapsedAttrs(node)))
+		}
+		buf.WriteString("  }\n\n")
+	}
+
 	// Group nodes by type for subgraphs
 	workflows := []string{}
 	activities := []string{}
@@ -50,6 +102,9 @@ func (e *Exporter) ExportDOT(graph *analyzer.TemporalGraph) (string, error) {
 
 	for _, name := range nodeNames {
 		node := graph.Nodes[name]
+		if node.Domain != "" || node.Unresolved {
+			continue
+		}
 		switch node.Type {
 		case "workflow":
 			workflows = append(workflows, name)
@@ -69,8 +124,8 @@ func (e *Exporter) ExportDOT(graph *analyzer.TemporalGraph) (string, error) {
 		buf.WriteString("    color=\"#a371f7\";\n")
 		for _, name := range workflows {
 			node := graph.Nodes[name]
-			buf.WriteString(fmt.Sprintf("    \"%s\" [label=\"%s\\n%s\", fillcolor=\"#a371f7\", fontcolor=\"white\"];\n",
-				e.escapeString(name), e.escapeString(name), node.Package))
+			buf.WriteString(fmt.Sprintf("    \"%s\" [label=\"%s\\n%s%s\", fillcolor=\"#a371f7\", fontcolor=\"white\"%s];\n",
+				e.escapeString(name), e.escapeString(node.Name), node.Package, e.collapsedLabel(node), e.churnAttrs(node, maxChurn)+e.collapsedAttrs(node)))
 		}
 		buf.WriteString("  }\n\n")
 	}
@@ -84,8 +139,8 @@ func (e *Exporter) ExportDOT(graph *analyzer.TemporalGraph) (string, error) {
 		buf.WriteString("    color=\"#7ee787\";\n")
 		for _, name := range activities {
 			node := graph.Nodes[name]
-			buf.WriteString(fmt.Sprintf("    \"%s\" [label=\"%s\\n%s\", fillcolor=\"#7ee787\", fontcolor=\"black\"];\n",
-				e.escapeString(name), e.escapeString(name), node.Package))
+			buf.WriteString(fmt.Sprintf("    \"%s\" [label=\"%s\\n%s%s\", fillcolor=\"#7ee787\", fontcolor=\"black\"%s];\n",
+				e.escapeString(name), e.escapeString(node.Name), node.Package, e.collapsedLabel(node), e.churnAttrs(node, maxChurn)+e.collapsedAttrs(node)))
 		}
 		buf.WriteString("  }\n\n")
 	}
@@ -93,20 +148,54 @@ func (e *Exporter) ExportDOT(graph *analyzer.TemporalGraph) (string, error) {
 	// Write other nodes
 	for _, name := range others {
 		node := graph.Nodes[name]
+		if node.Type == "package" && len(node.Collapsed) > 0 {
+			buf.WriteString(fmt.Sprintf("  \"%s\" [label=\"%s\\n%s\", shape=folder, fillcolor=\"%s\"];\n",
+				e.escapeString(name), e.escapeString(node.Name), node.CollapsedSummary(), e.getNodeColor(node.Type)))
+			continue
+		}
 		color := e.getNodeColor(node.Type)
-		buf.WriteString(fmt.Sprintf("  \"%s\" [label=\"%s\\n(%s)\", fillcolor=\"%s\"];\n",
-			e.escapeString(name), e.escapeString(name), node.Type, color))
+		buf.WriteString(fmt.Sprintf("  \"%s\" [label=\"%s\\n(%s)%s\", fillcolor=\"%s\"%s];\n",
+			e.escapeString(name), e.escapeString(node.Name), node.Type, e.collapsedLabel(node), color, e.churnAttrs(node, maxChurn)+e.collapsedAttrs(node)))
+	}
+
+	// Write unresolved call targets
+	if unresolved := graph.UnresolvedTargets(); len(unresolved) > 0 {
+		buf.WriteString("\n  // Unresolved call targets\n")
+		buf.WriteString("  subgraph cluster_unresolved {\n")
+		buf.WriteString("    label=\"External / Unresolved\";\n")
+		buf.WriteString("    style=dashed;\n")
+		buf.WriteString("    color=\"#6e7681\";\n")
+		for _, name := range unresolved {
+			buf.WriteString(fmt.Sprintf("    \"%s\" [label=\"%s\\n(unresolved)\", style=\"rounded,dashed\", fillcolor=\"#f6f8fa\", fontcolor=\"#6e7681\"];\n",
+				e.escapeString(name), e.escapeString(name)))
+		}
+		buf.WriteString("  }\n")
 	}
 
 	buf.WriteString("\n  // Edges\n")
 
-	// Write edges
+	// Write edges, one per target and call type, drawn wider the more often the target is called
 	for _, name := range nodeNames {
 		node := graph.Nodes[name]
-		for _, call := range node.CallSites {
-			edgeStyle := e.getEdgeStyle(call.CallType)
+		for _, edge := range node.Edges() {
+			edgeStyle := e.getEdgeStyle(edge.CallType) + e.weightAttrs(edge)
 			buf.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [%s];\n",
-				e.escapeString(name), e.escapeString(call.TargetName), edgeStyle))
+				e.escapeString(name), e.escapeString(edge.To), edgeStyle))
+		}
+	}
+
+	// Write the API endpoints and other triggers starting workflows
+	if triggers := e.entryPointTriggers(graph); len(triggers) > 0 {
+		buf.WriteString("\n  // Entry points\n")
+		for _, trigger := range triggers {
+			buf.WriteString(fmt.Sprintf("  \"entry:%s\" [label=\"%s\", shape=cds, style=filled, fillcolor=\"#e3b341\", fontcolor=\"black\"];\n",
+				e.escapeString(trigger), e.escapeString(trigger)))
+		}
+		for _, ep := range graph.EntryPoints {
+			if _, ok := graph.Nodes[ep.Workflow]; ok {
+				buf.WriteString(fmt.Sprintf("  \"entry:%s\" -> \"%s\" [label=\"starts\", style=bold, color=\"#e3b341\"];\n",
+					e.escapeString(ep.Trigger), e.escapeString(ep.Workflow)))
+			}
 		}
 	}
 
@@ -132,20 +221,32 @@ func (e *Exporter) ExportMermaid(graph *analyzer.TemporalGraph) (string, error)
 
 	for _, name := range nodeNames {
 		node := graph.Nodes[name]
-		nodeID := e.toMermaidID(name)
+		switch {
+		case len(node.Collapsed) > 0:
+			buf.WriteString(fmt.Sprintf("    %s[[\"%s<br/>%s\"]]\n", e.toMermaidID(name), e.escapeString(node.Name), node.CollapsedSummary()))
+		case node.Domain == "":
+			buf.WriteString("    " + e.mermaidNode(name, node.Name, node.Type) + "\n")
+		}
+	}
 
-		switch node.Type {
-		case "workflow":
-			buf.WriteString(fmt.Sprintf("    %s[\"⚡ %s\"]\n", nodeID, name))
-		case "activity":
-			buf.WriteString(fmt.Sprintf("    %s([\"⚙ %s\"])\n", nodeID, name))
-		case "signal", "signal_handler":
-			buf.WriteString(fmt.Sprintf("    %s{{\"🔔 %s\"}}\n", nodeID, name))
-		case "query", "query_handler":
-			buf.WriteString(fmt.Sprintf("    %s>\"❓ %s\"]\n", nodeID, name))
-		default:
-			buf.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", nodeID, name))
+	// Call targets that match several packages have no node of their own
+	unresolved := graph.UnresolvedTargets()
+	for _, name := range unresolved {
+		if _, exists := graph.Nodes[name]; !exists {
+			buf.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", e.toMermaidID(name), name))
+		}
+	}
+
+	// Nodes with a business domain are grouped in a subgraph per domain
+	domains := e.domainNames(graph)
+	for i, domain := range domains {
+		buf.WriteString(fmt.Sprintf("\n    subgraph domain_%d[\"%s\"]\n", i, e.escapeString(domain)))
+		for _, name := range nodeNames {
+			if node := graph.Nodes[name]; node.Domain == domain {
+				buf.WriteString("        " + e.mermaidNode(name, node.Name, node.Type) + "\n")
+			}
 		}
+		buf.WriteString("    end\n")
 	}
 
 	buf.WriteString("\n    %% Connections\n")
@@ -155,18 +256,39 @@ func (e *Exporter) ExportMermaid(graph *analyzer.TemporalGraph) (string, error)
 		node := graph.Nodes[name]
 		fromID := e.toMermaidID(name)
 
-		for _, call := range node.CallSites {
-			toID := e.toMermaidID(call.TargetName)
-			
-			switch call.CallType {
+		for _, edge := range node.Edges() {
+			toID := e.toMermaidID(edge.To)
+			weight := e.weightLabel(edge)
+
+			switch edge.CallType {
 			case "activity":
-				buf.WriteString(fmt.Sprintf("    %s -->|execute| %s\n", fromID, toID))
+				buf.WriteString(fmt.Sprintf("    %s -->|execute%s| %s\n", fromID, weight, toID))
 			case "child_workflow":
-				buf.WriteString(fmt.Sprintf("    %s ==>|child| %s\n", fromID, toID))
+				buf.WriteString(fmt.Sprintf("    %s ==>|child%s| %s\n", fromID, weight, toID))
 			case "signal":
-				buf.WriteString(fmt.Sprintf("    %s -.->|signal| %s\n", fromID, toID))
+				buf.WriteString(fmt.Sprintf("    %s -.->|signal%s| %s\n", fromID, weight, toID))
 			default:
-				buf.WriteString(fmt.Sprintf("    %s --> %s\n", fromID, toID))
+				if weight == "" {
+					buf.WriteString(fmt.Sprintf("    %s --> %s\n", fromID, toID))
+				} else {
+					buf.WriteString(fmt.Sprintf("    %s -->|%s| %s\n", fromID, strings.TrimSpace(weight), toID))
+				}
+			}
+		}
+	}
+
+	// Entry points are numbered, as triggers such as "GET /orders" make poor IDs
+	triggers := e.entryPointTriggers(graph)
+	if len(triggers) > 0 {
+		buf.WriteString("\n    %% Entry points\n")
+		entryIDs := make(map[string]string, len(triggers))
+		for i, trigger := range triggers {
+			entryIDs[trigger] = fmt.Sprintf("entry_%d", i)
+			buf.WriteString(fmt.Sprintf("    %s[/\"%s\"/]\n", entryIDs[trigger], e.escapeString(trigger)))
+		}
+		for _, ep := range graph.EntryPoints {
+			if _, ok := graph.Nodes[ep.Workflow]; ok {
+				buf.WriteString(fmt.Sprintf("    %s ==>|starts| %s\n", entryIDs[ep.Trigger], e.toMermaidID(ep.Workflow)))
 			}
 		}
 	}
@@ -177,16 +299,27 @@ func (e *Exporter) ExportMermaid(graph *analyzer.TemporalGraph) (string, error)
 	buf.WriteString("    classDef activity fill:#7ee787,stroke:#22c55e,color:#000\n")
 	buf.WriteString("    classDef signal fill:#ffa657,stroke:#f97316,color:#000\n")
 	buf.WriteString("    classDef query fill:#79c0ff,stroke:#3b82f6,color:#000\n")
+	buf.WriteString("    classDef unresolved fill:#f6f8fa,stroke:#6e7681,stroke-dasharray:5 5,color:#6e7681\n")
+	buf.WriteString("    classDef entry fill:#e3b341,stroke:#9e6a03,color:#000\n")
+	buf.WriteString("    classDef package fill:#58a6ff,stroke:#1f6feb,color:#000\n")
+	for i := range domains {
+		color := e.domainColor(i)
+		buf.WriteString(fmt.Sprintf("    style domain_%d fill:%s22,stroke:%s\n", i, color, color))
+	}
 
 	// Apply styles
 	workflows := []string{}
 	activities := []string{}
 	signals := []string{}
 	queries := []string{}
+	packages := []string{}
 
 	for _, name := range nodeNames {
 		node := graph.Nodes[name]
 		nodeID := e.toMermaidID(name)
+		if node.Unresolved {
+			continue
+		}
 
 		switch node.Type {
 		case "workflow":
@@ -197,6 +330,8 @@ func (e *Exporter) ExportMermaid(graph *analyzer.TemporalGraph) (string, error)
 			signals = append(signals, nodeID)
 		case "query", "query_handler":
 			queries = append(queries, nodeID)
+		case "package":
+			packages = append(packages, nodeID)
 		}
 	}
 
@@ -212,6 +347,156 @@ func (e *Exporter) ExportMermaid(graph *analyzer.TemporalGraph) (string, error)
 	if len(queries) > 0 {
 		buf.WriteString(fmt.Sprintf("    class %s query\n", strings.Join(queries, ",")))
 	}
+	if len(packages) > 0 {
+		buf.WriteString(fmt.Sprintf("    class %s package\n", strings.Join(packages, ",")))
+	}
+	if len(triggers) > 0 {
+		ids := make([]string, len(triggers))
+		for i := range triggers {
+			ids[i] = fmt.Sprintf("entry_%d", i)
+		}
+		buf.WriteString(fmt.Sprintf("    class %s entry\n", strings.Join(ids, ",")))
+	}
+	if len(unresolved) > 0 {
+		ids := make([]string, len(unresolved))
+		for i, name := range unresolved {
+			ids[i] = e.toMermaidID(name)
+		}
+		buf.WriteString(fmt.Sprintf("    class %s unresolved\n", strings.Join(ids, ",")))
+	}
+
+	// Clicking a node opens its declaration
+	var links []string
+	for _, name := range nodeNames {
+		node := graph.Nodes[name]
+		if node.Unresolved || len(node.Collapsed) > 0 || node.FilePath == "" {
+			continue
+		}
+		href, position := e.nodeLink(node)
+		links = append(links, fmt.Sprintf("    click %s href \"%s\" \"%s\"\n", e.toMermaidID(name), e.escapeString(href), e.escapeString(position)))
+	}
+	if len(links) > 0 {
+		buf.WriteString("\n    %% Links\n")
+		buf.WriteString(strings.Join(links, ""))
+	}
+
+	buf.WriteString("```\n")
+	return buf.String(), nil
+}
+
+// ExportMermaidSequence exports the calls of each workflow as a Mermaid sequence diagram, in
+// the order of their lines: executions and child workflows as requests, signals as async
+// messages. Participants are grouped by type and link to their declaration.
+func (e *Exporter) ExportMermaidSequence(graph *analyzer.TemporalGraph) (string, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("```mermaid\nsequenceDiagram\n")
+
+	var nodeNames []string
+	for name := range graph.Nodes {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+
+	// Workflows making Temporal calls and the targets they call take part
+	var callers []string
+	participants := make(map[string]bool)
+	for _, name := range nodeNames {
+		node := graph.Nodes[name]
+		if node.Type != "workflow" {
+			continue
+		}
+		calls := false
+		for _, call := range node.CallSites {
+			if call.CallType != "internal" {
+				participants[call.TargetName] = true
+				calls = true
+			}
+		}
+		if calls {
+			callers = append(callers, name)
+			participants[name] = true
+		}
+	}
+
+	// Boxes color the participants by type, workflows first
+	groups := []struct {
+		title, color string
+		match        func(node *analyzer.TemporalNode) bool
+	}{
+		{"Workflows", "rgba(163,113,247,0.15)", func(n *analyzer.TemporalNode) bool { return n != nil && !n.Unresolved && n.Type == "workflow" }},
+		{"Activities", "rgba(126,231,135,0.15)", func(n *analyzer.TemporalNode) bool { return n != nil && !n.Unresolved && n.Type == "activity" }},
+		{"Signals and queries", "rgba(255,166,87,0.15)", func(n *analyzer.TemporalNode) bool {
+			return n != nil && !n.Unresolved && n.Type != "workflow" && n.Type != "activity"
+		}},
+		{"External / Unresolved", "rgba(110,118,129,0.15)", func(n *analyzer.TemporalNode) bool { return n == nil || n.Unresolved }},
+	}
+	var targets []string
+	for name := range participants {
+		targets = append(targets, name)
+	}
+	sort.Strings(targets)
+	for _, group := range groups {
+		var members []string
+		for _, name := range targets {
+			if group.match(graph.Nodes[name]) {
+				members = append(members, name)
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("    box %s %s\n", group.color, group.title))
+		for _, name := range members {
+			label := name
+			if node := graph.Nodes[name]; node != nil {
+				label = e.sequenceLabel(node)
+			}
+			buf.WriteString(fmt.Sprintf("        participant %s as %s\n", e.toMermaidID(name), label))
+		}
+		buf.WriteString("    end\n")
+	}
+
+	for _, name := range callers {
+		node := graph.Nodes[name]
+		calls := make([]analyzer.CallSite, 0, len(node.CallSites))
+		for _, call := range node.CallSites {
+			if call.CallType != "internal" {
+				calls = append(calls, call)
+			}
+		}
+		sort.SliceStable(calls, func(i, j int) bool { return calls[i].LineNumber < calls[j].LineNumber })
+
+		fromID := e.toMermaidID(name)
+		buf.WriteString(fmt.Sprintf("\n    %%%% %s\n", name))
+		buf.WriteString(fmt.Sprintf("    Note over %s: %s\n", fromID, node.Name))
+		for _, call := range calls {
+			arrow := "->>"
+			if call.CallType == "signal" {
+				arrow = "-)"
+			}
+			label := e.sequenceMessage(call)
+			if call.LoopLine > 0 {
+				label += " (in loop)"
+			}
+			buf.WriteString(fmt.Sprintf("    %s%s%s: %s\n", fromID, arrow, e.toMermaidID(call.TargetName), label))
+		}
+	}
+
+	// Participant menus link to the declarations
+	var links []string
+	for _, name := range targets {
+		node := graph.Nodes[name]
+		if node == nil || node.Unresolved || len(node.Collapsed) > 0 || node.FilePath == "" {
+			continue
+		}
+		href, position := e.nodeLink(node)
+		links = append(links, fmt.Sprintf("    link %s: %s @ %s\n", e.toMermaidID(name), position, href))
+	}
+	if len(links) > 0 {
+		buf.WriteString("\n")
+		buf.WriteString(strings.Join(links, ""))
+	}
 
 	buf.WriteString("```\n")
 	return buf.String(), nil
@@ -225,7 +510,7 @@ func (e *Exporter) ExportMarkdown(graph *analyzer.TemporalGraph) (string, error)
 	buf.WriteString("# Temporal Workflow Analysis\n\n")
 
 	// Statistics
-	buf.WriteString("## 📊 Statistics\n\n")
+	buf.WriteString("## " + glyphs.Icon(e.glyphs.Stats, "Statistics") + "\n\n")
 	buf.WriteString("| Metric | Count |\n")
 	buf.WriteString("|--------|-------|\n")
 	buf.WriteString(fmt.Sprintf("| Workflows | %d |\n", graph.Stats.TotalWorkflows))
@@ -235,6 +520,17 @@ func (e *Exporter) ExportMarkdown(graph *analyzer.TemporalGraph) (string, error)
 	buf.WriteString(fmt.Sprintf("| Updates | %d |\n", graph.Stats.TotalUpdates))
 	buf.WriteString(fmt.Sprintf("| Max Depth | %d |\n", graph.Stats.MaxDepth))
 	buf.WriteString(fmt.Sprintf("| Orphan Nodes | %d |\n", graph.Stats.OrphanNodes))
+	buf.WriteString(fmt.Sprintf("| Distinct Connections | %d |\n", graph.Stats.DistinctConnections))
+	buf.WriteString(fmt.Sprintf("| Weighted Connections | %d |\n", graph.Stats.WeightedConnections))
+	if len(graph.Stats.DomainCoupling) > 0 {
+		buf.WriteString(fmt.Sprintf("| Cross-Domain Calls | %d |\n", graph.Stats.CrossDomainCalls))
+	}
+	if graph.Stats.UnresolvedTargets > 0 {
+		buf.WriteString(fmt.Sprintf("| Unresolved Targets | %d |\n", graph.Stats.UnresolvedTargets))
+	}
+	if graph.Stats.FailedFiles > 0 {
+		buf.WriteString(fmt.Sprintf("| Failed Files | %d |\n", graph.Stats.FailedFiles))
+	}
 	buf.WriteString("\n")
 
 	// Sort nodes
@@ -245,39 +541,59 @@ func (e *Exporter) ExportMarkdown(graph *analyzer.TemporalGraph) (string, error)
 	sort.Strings(nodeNames)
 
 	// Workflows section
-	buf.WriteString("## ⚡ Workflows\n\n")
+	buf.WriteString("## " + glyphs.Icon(e.glyphs.Workflow, "Workflows") + "\n\n")
 	for _, name := range nodeNames {
 		node := graph.Nodes[name]
 		if node.Type != "workflow" {
 			continue
 		}
 
-		buf.WriteString(fmt.Sprintf("### %s\n\n", name))
+		buf.WriteString(fmt.Sprintf("### %s\n\n", node.Name))
 		buf.WriteString(fmt.Sprintf("- **Package:** `%s`\n", node.Package))
+		if len(node.Aliases) > 0 {
+			buf.WriteString(fmt.Sprintf("- **Registered as:** `%s`\n", strings.Join(node.Aliases, "`, `")))
+		}
+		if node.Domain != "" {
+			buf.WriteString(fmt.Sprintf("- **Domain:** %s\n", node.Domain))
+		}
 		buf.WriteString(fmt.Sprintf("- **File:** `%s:%d`\n", node.FilePath, node.LineNumber))
+		buf.WriteString(fmt.Sprintf("- **Signature:** `%s`\n", node.Signature()))
 
 		if node.Description != "" {
 			buf.WriteString(fmt.Sprintf("- **Description:** %s\n", node.Description))
 		}
+		if len(node.Tags) > 0 {
+			buf.WriteString(fmt.Sprintf("- **Tags:** %s\n", strings.Join(node.Tags, ", ")))
+		}
+		if len(node.Annotations) > 0 {
+			buf.WriteString(fmt.Sprintf("- **Annotations:** %s\n", e.formatAnnotations(node.Annotations)))
+		}
+
+		if startedBy := graph.StartedBy(name); len(startedBy) > 0 {
+			buf.WriteString("\n**Started by:**\n")
+			for _, ep := range startedBy {
+				buf.WriteString(fmt.Sprintf("- `%s` (%s, `%s` at `%s:%d`)\n", ep.Trigger, ep.Kind, ep.Handler, ep.FilePath, ep.LineNumber))
+			}
+		}
 
 		if len(node.CallSites) > 0 {
 			buf.WriteString("\n**Calls:**\n")
 			for _, call := range node.CallSites {
-				buf.WriteString(fmt.Sprintf("- `%s` (%s)\n", call.TargetName, call.TargetType))
+				buf.WriteString(fmt.Sprintf("- `%s` (%s)\n", call.TargetName, describeCallType(call)))
 			}
 		}
 
 		if len(node.Signals) > 0 {
 			buf.WriteString("\n**Signals:**\n")
 			for _, sig := range node.Signals {
-				buf.WriteString(fmt.Sprintf("- 🔔 `%s`\n", sig.Name))
+				buf.WriteString(fmt.Sprintf("- %s\n", glyphs.Icon(e.glyphs.Signal, "`"+sig.Name+"`")))
 			}
 		}
 
 		if len(node.Queries) > 0 {
 			buf.WriteString("\n**Queries:**\n")
 			for _, q := range node.Queries {
-				buf.WriteString(fmt.Sprintf("- ❓ `%s`\n", q.Name))
+				buf.WriteString(fmt.Sprintf("- %s\n", glyphs.Icon(e.glyphs.Query, "`"+q.Name+"`")))
 			}
 		}
 
@@ -285,39 +601,194 @@ func (e *Exporter) ExportMarkdown(graph *analyzer.TemporalGraph) (string, error)
 	}
 
 	// Activities section
-	buf.WriteString("## ⚙️ Activities\n\n")
+	buf.WriteString("## " + glyphs.Icon(e.glyphs.Activities, "Activities") + "\n\n")
 	for _, name := range nodeNames {
 		node := graph.Nodes[name]
-		if node.Type != "activity" {
+		if node.Type != "activity" || node.Unresolved {
 			continue
 		}
 
-		buf.WriteString(fmt.Sprintf("### %s\n\n", name))
+		buf.WriteString(fmt.Sprintf("### %s\n\n", node.Name))
 		buf.WriteString(fmt.Sprintf("- **Package:** `%s`\n", node.Package))
+		if len(node.Aliases) > 0 {
+			buf.WriteString(fmt.Sprintf("- **Registered as:** `%s`\n", strings.Join(node.Aliases, "`, `")))
+		}
+		if node.Domain != "" {
+			buf.WriteString(fmt.Sprintf("- **Domain:** %s\n", node.Domain))
+		}
 		buf.WriteString(fmt.Sprintf("- **File:** `%s:%d`\n", node.FilePath, node.LineNumber))
+		buf.WriteString(fmt.Sprintf("- **Signature:** `%s`\n", node.Signature()))
 
 		if node.Description != "" {
 			buf.WriteString(fmt.Sprintf("- **Description:** %s\n", node.Description))
 		}
+		if len(node.Tags) > 0 {
+			buf.WriteString(fmt.Sprintf("- **Tags:** %s\n", strings.Join(node.Tags, ", ")))
+		}
+		if len(node.Annotations) > 0 {
+			buf.WriteString(fmt.Sprintf("- **Annotations:** %s\n", e.formatAnnotations(node.Annotations)))
+		}
 
 		if len(node.Parents) > 0 {
 			buf.WriteString("\n**Called by:**\n")
 			for _, parent := range node.Parents {
-				buf.WriteString(fmt.Sprintf("- `%s`\n", parent))
+				if ref, ok := node.CallFrom(parent); ok && ref.LineNumber > 0 {
+					buf.WriteString(fmt.Sprintf("- `%s` at `%s:%d`\n", parent, ref.FilePath, ref.LineNumber))
+				} else {
+					buf.WriteString(fmt.Sprintf("- `%s`\n", parent))
+				}
 			}
 		}
 
 		buf.WriteString("\n")
 	}
 
+	if len(graph.EntryPoints) > 0 {
+		e.writeEntryPointsMarkdown(&buf, graph)
+	}
+
+	// Workers section
+	if len(graph.Workers) > 0 {
+		e.writeWorkersMarkdown(&buf, graph)
+	}
+
+	if unresolved := graph.UnresolvedTargets(); len(unresolved) > 0 {
+		e.writeUnresolvedMarkdown(&buf, graph, unresolved)
+	}
+
+	if len(graph.FileErrors) > 0 {
+		buf.WriteString("## " + glyphs.Icon(e.glyphs.Warning, "Files That Failed to Analyze") + "\n\n")
+		buf.WriteString("Workflows and activities in these files may be missing from the graph.\n\n")
+		for _, fe := range graph.FileErrors {
+			buf.WriteString(fmt.Sprintf("- `%s` (%s): %s\n", fe.FilePath, fe.Stage, fe.Error))
+		}
+		buf.WriteString("\n")
+	}
+
 	// Add Mermaid diagram
 	mermaid, _ := e.ExportMermaid(graph)
-	buf.WriteString("## 📈 Dependency Graph\n\n")
+	buf.WriteString("## " + glyphs.Icon(e.glyphs.Graph, "Dependency Graph") + "\n\n")
 	buf.WriteString(mermaid)
 
 	return buf.String(), nil
 }
 
+// writeUnresolvedMarkdown lists the call targets not found in the analyzed code with their callers.
+func (e *Exporter) writeUnresolvedMarkdown(buf *bytes.Buffer, graph *analyzer.TemporalGraph, unresolved []string) {
+	callers := make(map[string][]string)
+	candidates := make(map[string][]string)
+	var nodeNames []string
+	for name := range graph.Nodes {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+	for _, name := range nodeNames {
+		for _, call := range graph.Nodes[name].CallSites {
+			if len(call.Candidates) > 0 {
+				candidates[call.TargetName] = call.Candidates
+			}
+			if list := callers[call.TargetName]; len(list) == 0 || list[len(list)-1] != name {
+				callers[call.TargetName] = append(callers[call.TargetName], name)
+			}
+		}
+	}
+
+	buf.WriteString("## " + glyphs.Icon(e.glyphs.Unresolved, "Unresolved Call Targets") + "\n\n")
+	buf.WriteString("Targets called from the analyzed code but not defined in it.\n\n")
+	for _, name := range unresolved {
+		line := fmt.Sprintf("- `%s`", name)
+		if len(callers[name]) > 0 {
+			line += " called by `" + strings.Join(callers[name], "`, `") + "`"
+		}
+		if len(candidates[name]) > 0 {
+			line += " (ambiguous: `" + strings.Join(candidates[name], "`, `") + "`)"
+		}
+		buf.WriteString(line + "\n")
+	}
+	buf.WriteString("\n")
+}
+
+// writeEntryPointsMarkdown writes a table of the triggers starting workflows.
+func (e *Exporter) writeEntryPointsMarkdown(buf *bytes.Buffer, graph *analyzer.TemporalGraph) {
+	buf.WriteString("## " + glyphs.Icon(e.glyphs.EntryPoints, "Entry Points") + "\n\n")
+	buf.WriteString("| Trigger | Kind | Workflow | Handler | Location |\n")
+	buf.WriteString("|---------|------|----------|---------|----------|\n")
+	for _, ep := range graph.EntryPoints {
+		buf.WriteString(fmt.Sprintf("| `%s` | %s | `%s` | `%s` | `%s:%d` |\n", ep.Trigger, ep.Kind, ep.Workflow, ep.Handler, ep.FilePath, ep.LineNumber))
+	}
+	buf.WriteString("\n")
+}
+
+// writeWorkersMarkdown writes the workers with their options and registrations, followed by
+// the workflows and activities no worker registers.
+func (e *Exporter) writeWorkersMarkdown(buf *bytes.Buffer, graph *analyzer.TemporalGraph) {
+	buf.WriteString("## " + glyphs.Icon(e.glyphs.Workers, "Workers") + "\n\n")
+	for _, w := range graph.Workers {
+		buf.WriteString(fmt.Sprintf("### %s\n\n", w.TaskQueue))
+		buf.WriteString(fmt.Sprintf("- **File:** `%s:%d`\n", w.FilePath, w.LineNumber))
+		if limits := e.formatWorkerLimits(w); limits != "" {
+			buf.WriteString(fmt.Sprintf("- **Limits:** %s\n", limits))
+		}
+		if w.StickyCacheSize > 0 || w.StickyScheduleToStartTimeout != "" {
+			var sticky []string
+			if w.StickyCacheSize > 0 {
+				sticky = append(sticky, fmt.Sprintf("cache size %d", w.StickyCacheSize))
+			}
+			if w.StickyScheduleToStartTimeout != "" {
+				sticky = append(sticky, fmt.Sprintf("schedule-to-start `%s`", w.StickyScheduleToStartTimeout))
+			}
+			buf.WriteString(fmt.Sprintf("- **Sticky execution:** %s\n", strings.Join(sticky, ", ")))
+		}
+		if len(w.Interceptors) > 0 {
+			buf.WriteString(fmt.Sprintf("- **Interceptors:** `%s`\n", strings.Join(w.Interceptors, "`, `")))
+		}
+
+		if len(w.Workflows) > 0 {
+			buf.WriteString("\n**Workflows:**\n")
+			for _, name := range w.Workflows {
+				buf.WriteString(fmt.Sprintf("- `%s`\n", name))
+			}
+		}
+		if len(w.Activities) > 0 {
+			buf.WriteString("\n**Activities:**\n")
+			for _, name := range w.Activities {
+				buf.WriteString(fmt.Sprintf("- `%s`\n", name))
+			}
+		}
+		buf.WriteString("\n")
+	}
+
+	if unregistered := graph.UnregisteredNodes(); len(unregistered) > 0 {
+		buf.WriteString("### " + glyphs.Icon(e.glyphs.Warning, "Not Registered on Any Worker") + "\n\n")
+		for _, node := range unregistered {
+			buf.WriteString(fmt.Sprintf("- `%s` (%s) at `%s:%d`\n", node.Name, node.Type, node.FilePath, node.LineNumber))
+		}
+		buf.WriteString("\n")
+	}
+}
+
+// formatWorkerLimits lists the concurrency and rate limits set on a worker.
+func (e *Exporter) formatWorkerLimits(w analyzer.WorkerConfig) string {
+	var limits []string
+	add := func(label string, value int) {
+		if value > 0 {
+			limits = append(limits, fmt.Sprintf("%s %d", label, value))
+		}
+	}
+	add("activities", w.MaxConcurrentActivityExecutionSize)
+	add("local activities", w.MaxConcurrentLocalActivityExecutionSize)
+	add("workflow tasks", w.MaxConcurrentWorkflowTaskExecutionSize)
+	add("activity pollers", w.MaxConcurrentActivityTaskPollers)
+	add("workflow pollers", w.MaxConcurrentWorkflowTaskPollers)
+	if w.WorkerActivitiesPerSecond > 0 {
+		limits = append(limits, fmt.Sprintf("%g activities/s per worker", w.WorkerActivitiesPerSecond))
+	}
+	if w.TaskQueueActivitiesPerSecond > 0 {
+		limits = append(limits, fmt.Sprintf("%g activities/s per task queue", w.TaskQueueActivitiesPerSecond))
+	}
+	return strings.Join(limits, ", ")
+}
+
 // Helper functions
 
 func (e *Exporter) escapeString(s string) string {
@@ -326,6 +797,129 @@ func (e *Exporter) escapeString(s string) string {
 	return s
 }
 
+// formatAnnotations renders annotations as a sorted list of `@key value` entries.
+func (e *Exporter) formatAnnotations(annotations map[string]string) string {
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = "`" + strings.TrimSpace("@"+key+" "+annotations[key]) + "`"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// mermaidNode returns the Mermaid definition of a node, shaped by its type.
+// The graph key is the node ID; the label is the short display name.
+func (e *Exporter) mermaidNode(key, label, nodeType string) string {
+	nodeID := e.toMermaidID(key)
+	switch nodeType {
+	case "workflow":
+		return fmt.Sprintf("%s[\"%s\"]", nodeID, glyphs.Icon(e.glyphs.Workflow, label))
+	case "activity":
+		return fmt.Sprintf("%s([\"%s\"])", nodeID, glyphs.Icon(e.glyphs.Activity, label))
+	case "signal", "signal_handler":
+		return fmt.Sprintf("%s{{\"%s\"}}", nodeID, glyphs.Icon(e.glyphs.Signal, label))
+	case "query", "query_handler":
+		return fmt.Sprintf("%s>\"%s\"]", nodeID, glyphs.Icon(e.glyphs.Query, label))
+	default:
+		return fmt.Sprintf("%s[\"%s\"]", nodeID, label)
+	}
+}
+
+// sequenceLabel labels a sequence diagram participant with its type symbol. Colons and
+// semicolons would end the participant declaration.
+func (e *Exporter) sequenceLabel(node *analyzer.TemporalNode) string {
+	label := strings.NewReplacer(":", " ", ";", " ").Replace(node.Name)
+	switch node.Type {
+	case "workflow":
+		return glyphs.Icon(e.glyphs.Workflow, label)
+	case "activity":
+		return glyphs.Icon(e.glyphs.Activity, label)
+	case "signal", "signal_handler":
+		return glyphs.Icon(e.glyphs.Signal, label)
+	case "query", "query_handler":
+		return glyphs.Icon(e.glyphs.Query, label)
+	}
+	return label
+}
+
+// sequenceMessage names a call in a sequence diagram like the flowchart labels its edge.
+func (e *Exporter) sequenceMessage(call analyzer.CallSite) string {
+	callType := call.CallType
+	if callType == "" || callType == "execute" {
+		callType = call.TargetType
+	}
+	switch callType {
+	case "signal":
+		return "signal"
+	case "local_activity":
+		return "execute local"
+	case "child_workflow", "workflow":
+		return "child"
+	}
+	return "execute"
+}
+
+// nodeLink returns the link to the declaration of a node and its position, e.g.
+// "orders/workflow.go:42". Paths are relative to the root directory, and the link is
+// appended to the link base when there is one.
+func (e *Exporter) nodeLink(node *analyzer.TemporalNode) (href, position string) {
+	path := filepath.ToSlash(node.FilePath)
+	if e.rootDir != "" {
+		if rel, err := filepath.Rel(e.rootDir, node.FilePath); err == nil && filepath.IsLocal(rel) {
+			path = filepath.ToSlash(rel)
+		}
+	}
+	href, position = path, path
+	if e.linkBase != "" {
+		href = strings.TrimSuffix(e.linkBase, "/") + "/" + path
+	}
+	if node.LineNumber > 0 {
+		href += fmt.Sprintf("#L%d", node.LineNumber)
+		position += fmt.Sprintf(":%d", node.LineNumber)
+	}
+	return href, position
+}
+
+// entryPointTriggers returns the sorted distinct triggers of the entry points starting
+// workflows in the graph.
+func (e *Exporter) entryPointTriggers(graph *analyzer.TemporalGraph) []string {
+	seen := make(map[string]bool)
+	var triggers []string
+	for _, ep := range graph.EntryPoints {
+		if _, ok := graph.Nodes[ep.Workflow]; ok && !seen[ep.Trigger] {
+			seen[ep.Trigger] = true
+			triggers = append(triggers, ep.Trigger)
+		}
+	}
+	sort.Strings(triggers)
+	return triggers
+}
+
+// domainNames returns the sorted business domains of the graph's nodes.
+func (e *Exporter) domainNames(graph *analyzer.TemporalGraph) []string {
+	seen := make(map[string]bool)
+	var domains []string
+	for _, node := range graph.Nodes {
+		if node.Domain != "" && !seen[node.Domain] {
+			seen[node.Domain] = true
+			domains = append(domains, node.Domain)
+		}
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+// domainColor returns the color of the i-th domain, cycling through a fixed palette.
+func (e *Exporter) domainColor(i int) string {
+	palette := []string{"#58a6ff", "#d2a8ff", "#3fb950", "#f0883e", "#ff7b72", "#39c5cf", "#e3b341", "#db61a2"}
+	return palette[i%len(palette)]
+}
+
 func (e *Exporter) toMermaidID(name string) string {
 	// Convert to valid Mermaid ID (alphanumeric and underscore only)
 	result := strings.Builder{}
@@ -354,6 +948,73 @@ func (e *Exporter) getNodeColor(nodeType string) string {
 	}
 }
 
+// churnColors is the cold-to-hot scale of nodes colored by churn.
+var churnColors = []string{"#dbe9f6", "#fff1a8", "#ffc76b", "#ff8f52", "#e5383b"}
+
+// churnAttrs returns DOT attributes coloring a node by its churn relative to the graph's
+// busiest node and outlining nodes first committed within the churn window. It returns ""
+// for nodes without churn data.
+func (e *Exporter) churnAttrs(node *analyzer.TemporalNode, maxChurn int) string {
+	if node.Churn == nil || maxChurn == 0 {
+		return ""
+	}
+	level := node.Churn.Commits * (len(churnColors) - 1) / maxChurn
+	attrs := fmt.Sprintf(", fillcolor=\"%s\", fontcolor=\"black\", tooltip=\"%d commits in 90 days, first committed %s\"",
+		churnColors[level], node.Churn.Commits, node.Churn.FirstCommit.Format("2006-01-02"))
+	if node.Churn.Age(time.Now()) < analyzer.ChurnWindow {
+		attrs += ", color=\"#1f6feb\", penwidth=2.5"
+	}
+	return attrs
+}
+
+// collapsedLabel returns the label line counting the nodes hidden under a collapsed node,
+// e.g. "\n+3 hidden: 2 activities, 1 workflow", or "" for other nodes.
+func (e *Exporter) collapsedLabel(node *analyzer.TemporalNode) string {
+	if len(node.Collapsed) == 0 {
+		return ""
+	}
+	hidden := 0
+	for _, count := range node.Collapsed {
+		hidden += count
+	}
+	return fmt.Sprintf("\\n+%d hidden: %s", hidden, node.CollapsedSummary())
+}
+
+// collapsedAttrs returns the DOT attributes drawing a collapsed node as a summary box.
+func (e *Exporter) collapsedAttrs(node *analyzer.TemporalNode) string {
+	if len(node.Collapsed) == 0 {
+		return ""
+	}
+	return ", shape=box3d"
+}
+
+// maxPenwidth caps the width of DOT edges, so that calls in many loops stay readable.
+const maxPenwidth = 6
+
+// weightAttrs returns the DOT attributes drawing an edge called more than once wider, with a
+// tooltip counting its call sites, or "" for single calls.
+func (e *Exporter) weightAttrs(edge analyzer.Edge) string {
+	if edge.Weight <= 1 {
+		return ""
+	}
+	tooltip := fmt.Sprintf("%d call sites", edge.CallSites)
+	if edge.CallSites == 1 {
+		tooltip = "1 call site"
+	}
+	if edge.InLoop > 0 {
+		tooltip += fmt.Sprintf(", %d in loops", edge.InLoop)
+	}
+	return fmt.Sprintf(", penwidth=%d, tooltip=\"%s\"", min(edge.Weight, maxPenwidth), tooltip)
+}
+
+// weightLabel returns the Mermaid label suffix of an edge called more than once, e.g. " ×3".
+func (e *Exporter) weightLabel(edge analyzer.Edge) string {
+	if edge.Weight <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" %s%d", e.glyphs.Times, edge.Weight)
+}
+
 func (e *Exporter) getEdgeStyle(callType string) string {
 	switch callType {
 	case "activity":
@@ -369,3 +1030,15 @@ func (e *Exporter) getEdgeStyle(callType string) string {
 	}
 }
 
+
+// describeCallType returns the target type, with the call type when it adds information
+// (e.g. "activity, local_activity"). The generic "execute" call type adds none.
+func describeCallType(call analyzer.CallSite) string {
+	switch {
+	case call.CallType == "" || call.CallType == "execute" || call.CallType == call.TargetType:
+		return call.TargetType
+	case call.TargetType == "":
+		return call.CallType
+	}
+	return call.TargetType + ", " + call.CallType
+}
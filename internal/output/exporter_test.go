@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
 )
 
 func TestNewExporter(t *testing.T) {
@@ -204,6 +206,26 @@ func TestExportDOT(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "graph with domains",
+			graph: &analyzer.TemporalGraph{
+				Nodes: map[string]*analyzer.TemporalNode{
+					"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders", Domain: "Orders"},
+					"Charge":        {Name: "Charge", Type: "activity", Package: "payments", Domain: "Payments"},
+					"Notify":        {Name: "Notify", Type: "activity", Package: "notify"},
+				},
+			},
+			wantContains: []string{
+				"subgraph cluster_domain_0 {\n    label=\"Orders\";",
+				"subgraph cluster_domain_1 {\n    label=\"Payments\";",
+				"fillcolor=\"#d2a8ff22\";\n    \"Charge\" [label=\"Charge\\npayments\", fillcolor=\"#7ee787\", fontcolor=\"black\"];",
+				"subgraph cluster_activities {\n    label=\"Activities\";\n    style=dashed;\n    color=\"#7ee787\";\n    \"Notify\"",
+			},
+			wantNotContain: []string{
+				"subgraph cluster_workflows",
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -266,6 +288,23 @@ func TestExportMermaid(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "graph with domains",
+			graph: &analyzer.TemporalGraph{
+				Nodes: map[string]*analyzer.TemporalNode{
+					"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Domain: "Orders"},
+					"Charge":        {Name: "Charge", Type: "activity", Domain: "Payments"},
+					"Notify":        {Name: "Notify", Type: "activity"},
+				},
+			},
+			wantContains: []string{
+				"    Notify([\"⚙ Notify\"])\n",
+				"    subgraph domain_0[\"Orders\"]\n        OrderWorkflow[\"⚡ OrderWorkflow\"]\n    end\n",
+				"    subgraph domain_1[\"Payments\"]\n        Charge([\"⚙ Charge\"])\n    end\n",
+				"style domain_1 fill:#d2a8ff22,stroke:#d2a8ff",
+			},
+			wantErr: false,
+		},
 		{
 			name: "graph with activity",
 			graph: &analyzer.TemporalGraph{
@@ -406,6 +445,83 @@ func TestExportMermaid(t *testing.T) {
 	}
 }
 
+func TestExportMermaidLinks(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", FilePath: "/repo/orders/workflow.go", LineNumber: 42},
+			"Charge":        {Name: "Charge", Type: "activity", Unresolved: true},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		linkBase string
+		want     string
+	}{
+		{"relative", "", `click OrderWorkflow href "orders/workflow.go#L42" "orders/workflow.go:42"`},
+		{"link base", "https://github.com/org/repo/blob/main/", `click OrderWorkflow href "https://github.com/org/repo/blob/main/orders/workflow.go#L42" "orders/workflow.go:42"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewExporter().WithLinks("/repo", tt.linkBase).ExportMermaid(graph)
+			if err != nil {
+				t.Fatalf("ExportMermaid() error = %v", err)
+			}
+			if !strings.Contains(result, tt.want) {
+				t.Errorf("ExportMermaid() missing %q:\n%s", tt.want, result)
+			}
+			if strings.Contains(result, "click Charge") {
+				t.Errorf("ExportMermaid() links an unresolved node:\n%s", result)
+			}
+		})
+	}
+}
+
+func TestExportMermaidSequence(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name: "OrderWorkflow", Type: "workflow", FilePath: "/repo/orders/workflow.go", LineNumber: 10,
+				CallSites: []analyzer.CallSite{
+					{TargetName: "ShipWorkflow", CallType: "execute", TargetType: "child_workflow", LineNumber: 20},
+					{TargetName: "Charge", CallType: "execute", TargetType: "activity", LineNumber: 12, LoopLine: 11},
+					{TargetName: "Done", CallType: "signal", LineNumber: 30},
+					{TargetName: "validate", CallType: "internal", LineNumber: 14},
+				},
+			},
+			"ShipWorkflow": {Name: "ShipWorkflow", Type: "workflow"},
+			"Charge":       {Name: "Charge", Type: "activity", FilePath: "/repo/orders/activities.go", LineNumber: 5},
+			"Done":         {Name: "Done", Type: "signal", Unresolved: true},
+			"Idle":         {Name: "Idle", Type: "workflow"},
+		},
+	}
+
+	result, err := NewExporter().WithLinks("/repo", "").ExportMermaidSequence(graph)
+	if err != nil {
+		t.Fatalf("ExportMermaidSequence() error = %v", err)
+	}
+	for _, want := range []string{
+		"sequenceDiagram",
+		"box rgba(163,113,247,0.15) Workflows\n        participant OrderWorkflow as ⚡ OrderWorkflow\n        participant ShipWorkflow as ⚡ ShipWorkflow\n    end",
+		"box rgba(126,231,135,0.15) Activities\n        participant Charge as ⚙ Charge\n    end",
+		"box rgba(110,118,129,0.15) External / Unresolved\n        participant Done as 🔔 Done\n    end",
+		"Note over OrderWorkflow: OrderWorkflow\n" +
+			"    OrderWorkflow->>Charge: execute (in loop)\n" +
+			"    OrderWorkflow->>ShipWorkflow: child\n" +
+			"    OrderWorkflow-)Done: signal\n",
+		"link Charge: orders/activities.go:5 @ orders/activities.go#L5",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("ExportMermaidSequence() missing %q:\n%s", want, result)
+		}
+	}
+	for _, unwanted := range []string{"validate", "Idle", "Note over ShipWorkflow"} {
+		if strings.Contains(result, unwanted) {
+			t.Errorf("ExportMermaidSequence() contains %q:\n%s", unwanted, result)
+		}
+	}
+}
+
 func TestExportMarkdown(t *testing.T) {
 	e := NewExporter()
 
@@ -441,8 +557,10 @@ func TestExportMarkdown(t *testing.T) {
 						FilePath:    "workflow.go",
 						LineNumber:  10,
 						Description: "This is a test workflow",
+						Annotations: map[string]string{"sla": "5m", "owner": "team-x"},
 						CallSites: []analyzer.CallSite{
-							{TargetName: "Activity", TargetType: "activity"},
+							{TargetName: "Activity", TargetType: "activity", CallType: "execute"},
+							{TargetName: "Audit", TargetType: "activity", CallType: "local_activity"},
 						},
 					Signals: []analyzer.SignalDef{{Name: "MySignal"}},
 					Queries: []analyzer.QueryDef{{Name: "MyQuery"}},
@@ -458,8 +576,10 @@ func TestExportMarkdown(t *testing.T) {
 				"**Package:** `main`",
 				"**File:** `workflow.go:10`",
 				"**Description:** This is a test workflow",
+				"**Annotations:** `@owner team-x`, `@sla 5m`",
 				"**Calls:**",
 				"`Activity` (activity)",
+				"`Audit` (activity, local_activity)",
 				"**Signals:**",
 				"🔔 `MySignal`",
 				"**Queries:**",
@@ -479,6 +599,7 @@ func TestExportMarkdown(t *testing.T) {
 						LineNumber:  20,
 						Description: "This is a test activity",
 						Parents:     []string{"Workflow1", "Workflow2"},
+						CalledBy:    []analyzer.ParentRef{{Name: "Workflow1", FilePath: "workflow.go", LineNumber: 14}},
 					},
 				},
 				Stats: analyzer.GraphStats{
@@ -492,8 +613,41 @@ func TestExportMarkdown(t *testing.T) {
 				"**File:** `activity.go:20`",
 				"**Description:** This is a test activity",
 				"**Called by:**",
-				"`Workflow1`",
-				"`Workflow2`",
+				"- `Workflow1` at `workflow.go:14`",
+				"- `Workflow2`\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "graph with workers",
+			graph: &analyzer.TemporalGraph{
+				Nodes: map[string]*analyzer.TemporalNode{
+					"OrderWorkflow":  {Name: "OrderWorkflow", Type: "workflow", FilePath: "order.go", LineNumber: 5},
+					"Acts.Charge":    {Name: "Acts.Charge", Type: "activity", FilePath: "acts.go", LineNumber: 9},
+					"LegacyWorkflow": {Name: "LegacyWorkflow", Type: "workflow", FilePath: "legacy.go", LineNumber: 3},
+				},
+				Workers: []analyzer.WorkerConfig{{
+					TaskQueue:                          "orders",
+					FilePath:                           "main.go",
+					LineNumber:                         12,
+					MaxConcurrentActivityExecutionSize: 8,
+					TaskQueueActivitiesPerSecond:       2.5,
+					StickyCacheSize:                    512,
+					Interceptors:                       []string{"tracing.NewInterceptor"},
+					Workflows:                          []string{"OrderWorkflow"},
+					Activities:                         []string{"Acts"},
+				}},
+			},
+			wantContains: []string{
+				"## 🏭 Workers",
+				"### orders",
+				"**File:** `main.go:12`",
+				"**Limits:** activities 8, 2.5 activities/s per task queue",
+				"**Sticky execution:** cache size 512",
+				"**Interceptors:** `tracing.NewInterceptor`",
+				"**Workflows:**\n- `OrderWorkflow`",
+				"**Activities:**\n- `Acts`",
+				"### ⚠ Not Registered on Any Worker\n\n- `LegacyWorkflow` (workflow) at `legacy.go:3`\n\n",
 			},
 			wantErr: false,
 		},
@@ -978,3 +1132,273 @@ func TestExportActivityWithoutParents(t *testing.T) {
 	}
 }
 
+
+func TestExportPlainGlyphs(t *testing.T) {
+	e := NewExporterWithGlyphs(glyphs.ASCII)
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:      "OrderWorkflow",
+				Type:      "workflow",
+				CallSites: []analyzer.CallSite{{TargetName: "ChargeActivity", TargetType: "activity"}},
+				Signals:   []analyzer.SignalDef{{Name: "Cancel"}},
+			},
+			"ChargeActivity": {
+				Name:    "ChargeActivity",
+				Type:    "activity",
+				Parents: []string{"OrderWorkflow"},
+			},
+		},
+		Stats: analyzer.GraphStats{TotalWorkflows: 1, TotalActivities: 1},
+	}
+
+	markdown, err := e.ExportMarkdown(graph)
+	if err != nil {
+		t.Fatalf("ExportMarkdown failed: %v", err)
+	}
+	mermaid, err := e.ExportMermaid(graph)
+	if err != nil {
+		t.Fatalf("ExportMermaid failed: %v", err)
+	}
+
+	for name, out := range map[string]string{"markdown": markdown, "mermaid": mermaid} {
+		for _, r := range out {
+			if r > 127 {
+				t.Errorf("%s output should be ASCII-only, found %q", name, r)
+				break
+			}
+		}
+	}
+	if !strings.Contains(markdown, "## Workflows") {
+		t.Error("Plain markdown should keep section headings without icons")
+	}
+}
+
+func TestExportUnresolvedTargets(t *testing.T) {
+	e := NewExporter()
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name: "OrderWorkflow",
+				Type: "workflow",
+				CallSites: []analyzer.CallSite{
+					{TargetName: "SendEmail", TargetType: "activity"},
+					{TargetName: "Charge", TargetType: "activity", Candidates: []string{"billing.Charge", "payments.Charge"}},
+				},
+			},
+			"SendEmail": {Name: "SendEmail", Type: "activity", Parents: []string{"OrderWorkflow"}, Unresolved: true},
+		},
+		Stats: analyzer.GraphStats{TotalWorkflows: 1, UnresolvedTargets: 2},
+	}
+
+	dot, _ := e.ExportDOT(graph)
+	for _, want := range []string{
+		"subgraph cluster_unresolved {",
+		`"Charge" [label="Charge\n(unresolved)", style="rounded,dashed"`,
+		`"SendEmail" [label="SendEmail\n(unresolved)", style="rounded,dashed"`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output should contain %q", want)
+		}
+	}
+	if strings.Contains(dot, "cluster_activities") {
+		t.Error("Unresolved activities should not be grouped with the analyzed activities")
+	}
+
+	mermaid, _ := e.ExportMermaid(graph)
+	for _, want := range []string{
+		"classDef unresolved",
+		`Charge["Charge"]`,
+		"class Charge,SendEmail unresolved",
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Mermaid output should contain %q", want)
+		}
+	}
+	if strings.Contains(mermaid, "class SendEmail activity") {
+		t.Error("Unresolved nodes should not get the activity class")
+	}
+
+	markdown, _ := e.ExportMarkdown(graph)
+	for _, want := range []string{
+		"| Unresolved Targets | 2 |",
+		"Unresolved Call Targets",
+		"- `Charge` called by `OrderWorkflow` (ambiguous: `billing.Charge`, `payments.Charge`)",
+		"- `SendEmail` called by `OrderWorkflow`",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Markdown output should contain %q", want)
+		}
+	}
+	if strings.Contains(markdown, "### SendEmail") {
+		t.Error("Unresolved activities should not be documented as activities")
+	}
+}
+
+func TestExportMarkdownFailedFiles(t *testing.T) {
+	e := NewExporter()
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders"},
+		},
+		FileErrors: []analyzer.FileError{
+			{FilePath: "orders/broken.go", Stage: analyzer.StageParse, Error: "expected ')'"},
+		},
+		Stats: analyzer.GraphStats{TotalWorkflows: 1, FailedFiles: 1},
+	}
+
+	markdown, _ := e.ExportMarkdown(graph)
+	for _, want := range []string{
+		"| Failed Files | 1 |",
+		"Files That Failed to Analyze",
+		"- `orders/broken.go` (parse): expected ')'",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Markdown output should contain %q", want)
+		}
+	}
+}
+
+func TestExportEntryPoints(t *testing.T) {
+	e := NewExporter()
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders"},
+		},
+		EntryPoints: []analyzer.EntryPoint{
+			{Kind: "http", Trigger: "POST /orders", Handler: "h.CreateOrder", Workflow: "OrderWorkflow", FilePath: "api/http.go", LineNumber: 12},
+			{Kind: "http", Trigger: "POST /external", Handler: "startExternal", Workflow: "ExternalWorkflow", FilePath: "api/http.go", LineNumber: 13},
+		},
+	}
+
+	dot, _ := e.ExportDOT(graph)
+	if !strings.Contains(dot, `"entry:POST /orders" -> "OrderWorkflow" [label="starts"`) {
+		t.Errorf("DOT output should link the entry point to its workflow:\n%s", dot)
+	}
+	if strings.Contains(dot, "POST /external") {
+		t.Error("DOT output should skip entry points of workflows not in the graph")
+	}
+
+	mermaid, _ := e.ExportMermaid(graph)
+	for _, want := range []string{`entry_0[/"POST /orders"/]`, "entry_0 ==>|starts| OrderWorkflow", "class entry_0 entry"} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Mermaid output should contain %q", want)
+		}
+	}
+
+	markdown, _ := e.ExportMarkdown(graph)
+	for _, want := range []string{
+		"**Started by:**\n- `POST /orders` (http, `h.CreateOrder` at `api/http.go:12`)",
+		"Entry Points",
+		"| `POST /external` | http | `ExternalWorkflow` | `startExternal` | `api/http.go:13` |",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Markdown output should contain %q", want)
+		}
+	}
+}
+
+func TestExportCollapsedPackages(t *testing.T) {
+	e := NewExporter()
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders", CallSites: []analyzer.CallSite{
+				{TargetName: "package:payments/...", CallType: "activity"},
+			}},
+			"package:payments/...": {Name: "payments/...", Key: "package:payments/...", Type: "package", Collapsed: map[string]int{"activity": 12, "workflow": 1}},
+		},
+	}
+
+	dot, _ := e.ExportDOT(graph)
+	if !strings.Contains(dot, `"package:payments/..." [label="payments/...\n12 activities, 1 workflow", shape=folder`) {
+		t.Errorf("DOT output should label the collapsed package with its counts:\n%s", dot)
+	}
+
+	mermaid, _ := e.ExportMermaid(graph)
+	for _, want := range []string{`packagepayments[["payments/...<br/>12 activities, 1 workflow"]]`, "OrderWorkflow -->|execute| packagepayments", "class packagepayments package"} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Mermaid output should contain %q:\n%s", want, mermaid)
+		}
+	}
+}
+
+func TestExportDOTChurn(t *testing.T) {
+	e := NewExporter()
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders",
+				Churn: &analyzer.ChurnInfo{Commits: 8, FirstCommit: time.Now().Add(-24 * time.Hour)}},
+			"Charge": {Name: "Charge", Type: "activity", Package: "billing",
+				Churn: &analyzer.ChurnInfo{Commits: 0, FirstCommit: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)}},
+			"Refund": {Name: "Refund", Type: "activity", Package: "billing"},
+		},
+	}
+
+	dot, _ := e.ExportDOT(graph)
+	for _, want := range []string{
+		"// Fill colors show churn",
+		`fillcolor="#e5383b", fontcolor="black", tooltip="8 commits in 90 days`,
+		`color="#1f6feb", penwidth=2.5`,
+		`fillcolor="#dbe9f6", fontcolor="black", tooltip="0 commits in 90 days, first committed 2020-01-02"];`,
+		`"Refund" [label="Refund\nbilling", fillcolor="#7ee787", fontcolor="black"];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output should contain %q", want)
+		}
+	}
+
+	plain, _ := e.ExportDOT(&analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"Refund": {Name: "Refund", Type: "activity", Package: "billing"},
+	}})
+	if strings.Contains(plain, "churn") {
+		t.Error("DOT output without churn data should not mention churn")
+	}
+}
+
+func TestExportEdgeWeights(t *testing.T) {
+	e := NewExporter()
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"BatchWorkflow": {Name: "BatchWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{
+				{TargetName: "Charge", CallType: "activity", LoopLine: 5},
+				{TargetName: "Charge", CallType: "activity"},
+				{TargetName: "Notify", CallType: "activity"},
+			}},
+			"Charge": {Name: "Charge", Type: "activity"},
+			"Notify": {Name: "Notify", Type: "activity"},
+		},
+	}
+
+	dot, _ := e.ExportDOT(graph)
+	if !strings.Contains(dot, `"BatchWorkflow" -> "Charge" [style=solid, color="#7ee787", penwidth=3, tooltip="2 call sites, 1 in loops"];`) {
+		t.Errorf("DOT output should draw the repeated edge once with its weight:\n%s", dot)
+	}
+	if strings.Count(dot, `"BatchWorkflow" -> "Charge"`) != 1 {
+		t.Error("DOT output should merge call sites to the same target")
+	}
+	if !strings.Contains(dot, `"BatchWorkflow" -> "Notify" [style=solid, color="#7ee787"];`) {
+		t.Error("DOT output should not widen single calls")
+	}
+
+	mermaid, _ := e.ExportMermaid(graph)
+	if !strings.Contains(mermaid, "BatchWorkflow -->|execute ×3| Charge") {
+		t.Errorf("Mermaid output should label the repeated edge with its weight:\n%s", mermaid)
+	}
+	if !strings.Contains(mermaid, "BatchWorkflow -->|execute| Notify") {
+		t.Error("Mermaid output should not label single calls with a weight")
+	}
+
+	data, err := e.ExportJSON(graph)
+	if err != nil {
+		t.Fatalf("ExportJSON failed: %v", err)
+	}
+	var decoded struct {
+		Edges []analyzer.Edge `json:"edges"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(decoded.Edges) != 2 || decoded.Edges[0].Weight != 3 || decoded.Edges[0].InLoop != 1 {
+		t.Errorf("JSON edges = %+v, want the Charge edge with weight 3 and the Notify edge", decoded.Edges)
+	}
+}
@@ -0,0 +1,224 @@
+package output
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// flowsFormatter implements the Formatter interface for business flows: one document per
+// root workflow listing its steps in call order.
+type flowsFormatter struct {
+	// maxDepth limits how many levels of child workflows are expanded (0 = unlimited)
+	maxDepth int
+}
+
+// NewFlowsFormatter creates a new flows formatter. maxDepth limits how many levels of child
+// workflows are expanded (0 = unlimited).
+func NewFlowsFormatter(maxDepth int) Formatter {
+	return &flowsFormatter{maxDepth: maxDepth}
+}
+
+// flowStep is a step of a workflow: a call, a timer or a wait for a signal.
+type flowStep struct {
+	line int
+	text string
+	// call is set for call sites, whose targets may expand into sub-steps
+	call *analyzer.CallSite
+}
+
+// Format writes a numbered list of steps for every workflow without parents. Calls that run
+// concurrently are grouped as parallel branches, and child workflows are expanded in place.
+func (f *flowsFormatter) Format(ctx context.Context, graph *analyzer.TemporalGraph, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	names := make([]string, 0, len(graph.Nodes))
+	for name, node := range graph.Nodes {
+		if node.Type == "workflow" && !node.Unresolved && len(node.Parents) == 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		f.writeFlow(bw, graph, graph.Nodes[name])
+	}
+
+	return bw.Flush()
+}
+
+// writeFlow writes the document of a root workflow.
+func (f *flowsFormatter) writeFlow(w io.Writer, graph *analyzer.TemporalGraph, node *analyzer.TemporalNode) {
+	fmt.Fprintln(w, node.Name)
+	fmt.Fprintln(w, strings.Repeat("=", len(node.Name)))
+	if startedBy := graph.StartedBy(node.ID()); len(startedBy) > 0 {
+		triggers := make([]string, len(startedBy))
+		for i, ep := range startedBy {
+			triggers[i] = ep.Trigger
+		}
+		fmt.Fprintf(w, "Started by: %s\n", strings.Join(triggers, ", "))
+	}
+	if node.Description != "" {
+		fmt.Fprintln(w, node.Description)
+	}
+	fmt.Fprintln(w)
+
+	if !f.writeSteps(w, graph, node, "", "", 1, map[string]bool{node.ID(): true}) {
+		fmt.Fprintln(w, "No steps found.")
+	}
+}
+
+// writeSteps writes the numbered steps of a workflow, prefixing numbers with number (e.g.
+// "3.") and indenting lines with indent. It returns false if the workflow has no steps.
+func (f *flowsFormatter) writeSteps(w io.Writer, graph *analyzer.TemporalGraph, node *analyzer.TemporalNode, number, indent string, depth int, path map[string]bool) bool {
+	steps := f.steps(graph, node)
+
+	n := 0
+	for i := 0; i < len(steps); i++ {
+		n++
+		// A call running concurrently with the next one starts a group of parallel branches
+		end := i
+		for end < len(steps)-1 && steps[end].call != nil && steps[end].call.Parallel && steps[end+1].call != nil {
+			end++
+		}
+
+		if end == i {
+			stepNumber := fmt.Sprintf("%s%d.", number, n)
+			f.writeStep(w, graph, steps[i], stepNumber, indent, depth, path)
+			continue
+		}
+
+		fmt.Fprintf(w, "%s%s%d. In parallel:\n", indent, number, n)
+		for b, step := range steps[i : end+1] {
+			branch := fmt.Sprintf("%s%d%s.", number, n, branchLetter(b))
+			f.writeStep(w, graph, step, branch, indent+"   ", depth, path)
+		}
+		i = end
+	}
+	return len(steps) > 0
+}
+
+// writeStep writes a step, expanding the steps of a child workflow below it.
+func (f *flowsFormatter) writeStep(w io.Writer, graph *analyzer.TemporalGraph, step flowStep, number, indent string, depth int, path map[string]bool) {
+	fmt.Fprintf(w, "%s%s %s\n", indent, number, step.text)
+	if step.call == nil {
+		return
+	}
+
+	child, ok := graph.Nodes[step.call.TargetName]
+	if !ok || child.Type != "workflow" || child.Unresolved {
+		return
+	}
+	subIndent := indent + strings.Repeat(" ", len(number)+1)
+	switch {
+	case path[child.ID()]:
+		fmt.Fprintf(w, "%s(repeats the steps of %s above)\n", subIndent, child.Name)
+	case f.maxDepth > 0 && depth >= f.maxDepth:
+		if len(f.steps(graph, child)) > 0 {
+			fmt.Fprintf(w, "%s(steps of %s not shown)\n", subIndent, child.Name)
+		}
+	default:
+		path[child.ID()] = true
+		f.writeSteps(w, graph, child, number, subIndent, depth+1, path)
+		delete(path, child.ID())
+	}
+}
+
+// steps returns the calls, timers and signal waits of a workflow in source order.
+func (f *flowsFormatter) steps(graph *analyzer.TemporalGraph, node *analyzer.TemporalNode) []flowStep {
+	var steps []flowStep
+	for i := range node.CallSites {
+		call := &node.CallSites[i]
+		steps = append(steps, flowStep{line: call.LineNumber, text: describeStep(graph, *call), call: call})
+	}
+	for _, timer := range node.Timers {
+		text := "Wait " + timer.Duration
+		if !timer.IsSleep {
+			text = "Start a " + timer.Duration + " timer"
+		}
+		steps = append(steps, flowStep{line: timer.LineNumber, text: text})
+	}
+	for _, receive := range node.SignalReceives {
+		signal := receive.Signal
+		if signal == "" {
+			signal = receive.Channel
+		}
+		text := "Wait for the " + signal + " signal"
+		if !receive.Blocking && !receive.InSelector {
+			text = "Check for the " + signal + " signal"
+		}
+		steps = append(steps, flowStep{line: receive.LineNumber, text: text})
+	}
+
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].line < steps[j].line })
+	return steps
+}
+
+// describeStep describes a call site in plain words, e.g. "Run the ChargeCard activity".
+func describeStep(graph *analyzer.TemporalGraph, call analyzer.CallSite) string {
+	name := call.TargetName
+	unresolved := len(call.Candidates) > 0
+	if target, ok := graph.Nodes[call.TargetName]; ok {
+		name = target.Name
+		unresolved = unresolved || target.Unresolved
+	}
+
+	var text string
+	switch call.TargetType {
+	case "activity":
+		text = "Run the " + name + " activity"
+	case "local_activity":
+		text = "Run the " + name + " local activity"
+	case "child_workflow", "workflow":
+		text = "Start the " + name + " child workflow"
+	case "signal":
+		text = "Send the " + name + " signal"
+	case "query":
+		text = "Query " + name
+	case "update":
+		text = "Send the " + name + " update"
+	default:
+		text = "Call " + name
+	}
+	if unresolved {
+		text += " (not in the analyzed code)"
+	}
+	return text
+}
+
+// branchLetter returns the letter of the i-th parallel branch: a, b, ..., z, aa, ab, ...
+func branchLetter(i int) string {
+	if i < 26 {
+		return string(rune('a' + i))
+	}
+	return branchLetter(i/26-1) + branchLetter(i%26)
+}
+
+// Name returns the name of the formatter.
+func (f *flowsFormatter) Name() string {
+	return "flows"
+}
+
+// Description returns a description of the output format.
+func (f *flowsFormatter) Description() string {
+	return "Numbered steps of each root workflow, for non-engineers"
+}
+
+// ContentType returns the MIME type of the output.
+func (f *flowsFormatter) ContentType() string {
+	return "text/plain"
+}
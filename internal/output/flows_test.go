@@ -0,0 +1,87 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// flowsTestGraph builds a graph with parallel activities, timers, signals and a child workflow.
+func flowsTestGraph() *analyzer.TemporalGraph {
+	return &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:        "OrderWorkflow",
+				Type:        "workflow",
+				Description: "OrderWorkflow processes an order.",
+				CallSites: []analyzer.CallSite{
+					{TargetName: "Reserve", TargetType: "activity", LineNumber: 10},
+					{TargetName: "Charge", TargetType: "activity", LineNumber: 12, Parallel: true},
+					{TargetName: "SendReceipt", TargetType: "activity", LineNumber: 13, Parallel: true},
+					{TargetName: "Audit", TargetType: "local_activity", LineNumber: 14},
+					{TargetName: "ShippingWorkflow", TargetType: "child_workflow", LineNumber: 30},
+				},
+				SignalReceives: []analyzer.SignalReceive{{Signal: "approve", LineNumber: 20, Blocking: true}},
+				Timers:         []analyzer.TimerDef{{Duration: "24h", LineNumber: 25, IsSleep: true}},
+			},
+			"ShippingWorkflow": {
+				Name:    "ShippingWorkflow",
+				Type:    "workflow",
+				Parents: []string{"OrderWorkflow", "ReturnWorkflow"},
+				CallSites: []analyzer.CallSite{
+					{TargetName: "Ship", TargetType: "activity", LineNumber: 5},
+					{TargetName: "ReturnWorkflow", TargetType: "child_workflow", LineNumber: 6},
+				},
+			},
+			"ReturnWorkflow": {
+				Name:      "ReturnWorkflow",
+				Type:      "workflow",
+				Parents:   []string{"ShippingWorkflow"},
+				CallSites: []analyzer.CallSite{{TargetName: "ShippingWorkflow", TargetType: "child_workflow", LineNumber: 3}},
+			},
+			"CleanupWorkflow": {Name: "CleanupWorkflow", Type: "workflow"},
+			"Reserve":         {Name: "Reserve", Type: "activity", Parents: []string{"OrderWorkflow"}},
+			"Charge":          {Name: "Charge", Type: "activity", Parents: []string{"OrderWorkflow"}},
+			"Ship":            {Name: "Ship", Type: "activity", Parents: []string{"ShippingWorkflow"}},
+		},
+		EntryPoints: []analyzer.EntryPoint{{Kind: "http", Trigger: "POST /orders", Handler: "createOrder", Workflow: "OrderWorkflow"}},
+	}
+}
+
+func TestFlowsFormatterGolden(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDepth int
+	}{
+		{name: "flows_full"},
+		{name: "flows_max_depth", maxDepth: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewFlowsFormatter(tt.maxDepth).Format(context.Background(), flowsTestGraph(), &buf); err != nil {
+				t.Fatalf("Format failed: %v", err)
+			}
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *updateGolden {
+				if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatalf("Failed to update golden file: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Failed to read golden file (run with -update to create): %v", err)
+			}
+			if buf.String() != string(want) {
+				t.Errorf("Output mismatch for %s:\n--- got ---\n%s\n--- want ---\n%s", golden, buf.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package output
+
+import (
+	"context"
+	"io"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// exportFormatter adapts an Exporter method to the Formatter interface.
+type exportFormatter struct {
+	name        string
+	description string
+	contentType string
+	export      func(graph *analyzer.TemporalGraph) (string, error)
+}
+
+// NewDOTFormatter creates a formatter for Graphviz DOT output.
+func NewDOTFormatter(exporter *Exporter) Formatter {
+	return &exportFormatter{
+		name:        "dot",
+		description: "Graphviz DOT graph",
+		contentType: "text/vnd.graphviz",
+		export:      exporter.ExportDOT,
+	}
+}
+
+// NewMermaidFormatter creates a formatter for Mermaid flowchart output.
+func NewMermaidFormatter(exporter *Exporter) Formatter {
+	return &exportFormatter{
+		name:        "mermaid",
+		description: "Mermaid flowchart in a Markdown code block",
+		contentType: "text/vnd.mermaid",
+		export:      exporter.ExportMermaid,
+	}
+}
+
+// NewMermaidSequenceFormatter creates a formatter for Mermaid sequence diagram output.
+func NewMermaidSequenceFormatter(exporter *Exporter) Formatter {
+	return &exportFormatter{
+		name:        "mermaid-sequence",
+		description: "Mermaid sequence diagram of the calls of each workflow in a Markdown code block",
+		contentType: "text/vnd.mermaid",
+		export:      exporter.ExportMermaidSequence,
+	}
+}
+
+// NewMarkdownFormatter creates a formatter for Markdown documentation.
+func NewMarkdownFormatter(exporter *Exporter) Formatter {
+	return &exportFormatter{
+		name:        "markdown",
+		description: "Markdown documentation with a Mermaid diagram",
+		contentType: "text/markdown",
+		export:      exporter.ExportMarkdown,
+	}
+}
+
+// Format formats the given graph and writes it to the writer.
+func (f *exportFormatter) Format(ctx context.Context, graph *analyzer.TemporalGraph, w io.Writer) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	out, err := f.export(graph)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
+
+// Name returns the name of the formatter.
+func (f *exportFormatter) Name() string {
+	return f.name
+}
+
+// Description returns a description of the output format.
+func (f *exportFormatter) Description() string {
+	return f.description
+}
+
+// ContentType returns the MIME type of the output.
+func (f *exportFormatter) ContentType() string {
+	return f.contentType
+}
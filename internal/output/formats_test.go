@@ -0,0 +1,44 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func TestExportFormatters(t *testing.T) {
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow"},
+	}}
+	exporter := NewExporter()
+
+	tests := []struct {
+		formatter Formatter
+		want      string
+	}{
+		{NewDOTFormatter(exporter), "digraph TemporalGraph {"},
+		{NewMermaidFormatter(exporter), "flowchart TB"},
+		{NewMarkdownFormatter(exporter), "# Temporal Workflow Analysis"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.formatter.Name(), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.formatter.Format(context.Background(), graph, &buf); err != nil {
+				t.Fatalf("Format failed: %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("Output should contain %q, got:\n%s", tt.want, buf.String())
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if err := tt.formatter.Format(ctx, graph, &bytes.Buffer{}); err != context.Canceled {
+				t.Errorf("Format() with cancelled context = %v, want context.Canceled", err)
+			}
+		})
+	}
+}
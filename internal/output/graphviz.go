@@ -0,0 +1,41 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// ErrGraphvizNotFound is returned by ExportGraphvizBundle when the dot command is missing,
+// after the .dot file is written.
+var ErrGraphvizNotFound = errors.New("dot not found in PATH; install Graphviz to render the SVG")
+
+// ExportGraphvizBundle writes the graph as DOT to base.dot and renders it to base.svg with
+// Graphviz, which must be on the PATH for the SVG. It returns the paths written.
+func (e *Exporter) ExportGraphvizBundle(ctx context.Context, graph *analyzer.TemporalGraph, base string) ([]string, error) {
+	dot, err := e.ExportDOT(graph)
+	if err != nil {
+		return nil, err
+	}
+	dotPath := base + ".dot"
+	if err := os.WriteFile(dotPath, []byte(dot), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", dotPath, err)
+	}
+	written := []string{dotPath}
+
+	graphviz, err := exec.LookPath("dot")
+	if err != nil {
+		return written, ErrGraphvizNotFound
+	}
+	svgPath := base + ".svg"
+	cmd := exec.CommandContext(ctx, graphviz, "-Tsvg", "-o", svgPath, dotPath)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return written, fmt.Errorf("failed to render %s: %w: %s", svgPath, err, strings.TrimSpace(string(out)))
+	}
+	return append(written, svgPath), nil
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func TestExportGraphvizBundle(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake dot is a shell script")
+	}
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders", Collapsed: map[string]int{"activity": 2, "workflow": 1}},
+		},
+	}
+	ctx := context.Background()
+
+	// Without Graphviz only the .dot file is written
+	t.Setenv("PATH", t.TempDir())
+	base := filepath.Join(t.TempDir(), "subtree")
+	files, err := NewExporter().ExportGraphvizBundle(ctx, graph, base)
+	if !errors.Is(err, ErrGraphvizNotFound) || len(files) != 1 {
+		t.Fatalf("Expected only the .dot file and ErrGraphvizNotFound, got %v, %v", files, err)
+	}
+	dot, err := os.ReadFile(base + ".dot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(dot), `label="OrderWorkflow\norders\n+3 hidden: 2 activities, 1 workflow"`) ||
+		!strings.Contains(string(dot), "shape=box3d") {
+		t.Errorf("Expected the collapsed node drawn as a summary box:\n%s", dot)
+	}
+
+	// A dot on the PATH renders the SVG next to it
+	bin := t.TempDir()
+	script := "#!/bin/sh\n[ \"$1\" = -Tsvg ] && [ \"$2\" = -o ] && echo '<svg/>' > \"$3\"\n"
+	if err := os.WriteFile(filepath.Join(bin, "dot"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+	files, err = NewExporter().ExportGraphvizBundle(ctx, graph, base)
+	if err != nil || len(files) != 2 || files[1] != base+".svg" {
+		t.Fatalf("Expected the .dot and .svg files, got %v, %v", files, err)
+	}
+	if svg, err := os.ReadFile(base + ".svg"); err != nil || !strings.Contains(string(svg), "<svg") {
+		t.Errorf("Expected the rendered SVG, got %q, %v", svg, err)
+	}
+}
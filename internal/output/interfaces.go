@@ -5,6 +5,8 @@ import (
 	"context"
 	"io"
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
 )
 
 // Formatter provides methods for formatting temporal graphs into different output formats.
@@ -17,6 +19,22 @@ type Formatter interface {
 
 	// Description returns a description of the output format.
 	Description() string
+
+	// ContentType returns the MIME type of the output.
+	ContentType() string
+}
+
+// Options configures the formatters registered by NewDefaultManager.
+type Options struct {
+	Fields       []string     // JSON node fields to project (empty means the full graph)
+	LegacyJSON   bool         // Also emit the deprecated "children" key in JSON output
+	MaxDepth     int          // Max depth of tree output (0 = unlimited)
+	Glyphs       glyphs.Set   // Symbols used by text outputs
+	OutputFile   string       // Database path for the sqlite format
+	TemplateFile string       // Go text/template for the template format
+	LintIssues   []lint.Issue // Lint findings written by the sql and sqlite formats
+	RootDir      string       // Project root that Mermaid node links are relative to
+	LinkBase     string       // URL of RootDir in a code browser for Mermaid node links
 }
 
 // Manager manages multiple output formatters.
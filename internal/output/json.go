@@ -3,23 +3,195 @@ package output
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"sort"
+	"strings"
+
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
 )
 
+// fieldAliases maps short field names accepted by --fields to JSON keys.
+var fieldAliases = map[string]string{
+	"file": "file_path",
+	"line": "line_number",
+}
+
 // jsonFormatter implements the Formatter interface for JSON output.
-type jsonFormatter struct{}
+type jsonFormatter struct {
+	// fields is the projection tree built from --fields (nil means the full graph)
+	fields fieldTree
+	// legacy adds the deprecated "children" key to each node
+	legacy bool
+}
+
+// graphJSON is the JSON encoding of a graph with its edges, weighted by call multiplicity, and
+// the options of its activity calls.
+type graphJSON struct {
+	*analyzer.TemporalGraph
+	Edges       []analyzer.Edge        `json:"edges,omitempty"`
+	CallOptions []analyzer.CallOptions `json:"call_options,omitempty"`
+}
+
+// toGraphJSON returns the JSON encoding of a graph.
+func toGraphJSON(graph *analyzer.TemporalGraph) graphJSON {
+	return graphJSON{TemporalGraph: graph, Edges: graph.Edges(), CallOptions: graph.CallOptions()}
+}
+
+// fieldTree is a set of projected JSON keys; nested trees select fields of objects and arrays.
+type fieldTree map[string]fieldTree
 
 // NewJSONFormatter creates a new JSON formatter.
 func NewJSONFormatter() Formatter {
 	return &jsonFormatter{}
 }
 
+// NewJSONFormatterWithFields creates a JSON formatter that outputs only the given node fields,
+// as an array of nodes sorted by name. Nested fields use dots, e.g. "call_sites.target_name".
+func NewJSONFormatterWithFields(fields []string) Formatter {
+	return &jsonFormatter{fields: parseFieldTree(fields)}
+}
+
+// NewLegacyJSONFormatter creates a JSON formatter that also emits the deprecated "children"
+// list of call target names on each node, for consumers that have not moved to call_sites.
+// A non-empty fields list projects the output as in NewJSONFormatterWithFields.
+func NewLegacyJSONFormatter(fields []string) Formatter {
+	f := &jsonFormatter{legacy: true}
+	if len(fields) > 0 {
+		f.fields = parseFieldTree(fields)
+	}
+	return f
+}
+
 // Format formats the given graph and writes it to the writer as JSON.
 func (f *jsonFormatter) Format(ctx context.Context, graph *analyzer.TemporalGraph, w io.Writer) error {
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(graph)
+
+	if len(f.fields) == 0 && !f.legacy {
+		return encoder.Encode(toGraphJSON(graph))
+	}
+
+	if len(f.fields) == 0 {
+		nodes := make(map[string]interface{}, len(graph.Nodes))
+		for name, node := range graph.Nodes {
+			value, err := f.nodeValue(node)
+			if err != nil {
+				return err
+			}
+			nodes[name] = value
+		}
+		return encoder.Encode(struct {
+			Nodes   map[string]interface{}  `json:"nodes"`
+			Stats   analyzer.GraphStats     `json:"stats"`
+			Workers []analyzer.WorkerConfig `json:"workers,omitempty"`
+			Engine  *analyzer.EngineInfo    `json:"engine,omitempty"`
+		}{nodes, graph.Stats, graph.Workers, graph.Engine})
+	}
+
+	projected, err := f.projectNodes(ctx, graph)
+	if err != nil {
+		return err
+	}
+	return encoder.Encode(projected)
+}
+
+// projectNodes converts nodes to generic JSON values and keeps only the selected fields.
+func (f *jsonFormatter) projectNodes(ctx context.Context, graph *analyzer.TemporalGraph) ([]interface{}, error) {
+	names := make([]string, 0, len(graph.Nodes))
+	for name := range graph.Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	projected := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		value, err := f.nodeValue(graph.Nodes[name])
+		if err != nil {
+			return nil, err
+		}
+		projected = append(projected, f.fields.project(value))
+	}
+	return projected, nil
+}
+
+// nodeValue converts a node to a generic JSON value, adding "children" in legacy mode.
+func (f *jsonFormatter) nodeValue(node *analyzer.TemporalNode) (map[string]interface{}, error) {
+	data, err := json.Marshal(node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode node %s: %w", node.Name, err)
+	}
+	var value map[string]interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode node %s: %w", node.Name, err)
+	}
+	if f.legacy {
+		children := node.Children()
+		if children == nil {
+			children = []string{}
+		}
+		value["children"] = children
+	}
+	return value, nil
+}
+
+// parseFieldTree builds a projection tree from dotted field paths.
+func parseFieldTree(fields []string) fieldTree {
+	tree := make(fieldTree)
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		node := tree
+		for i, part := range strings.Split(field, ".") {
+			if i == 0 {
+				if alias, ok := fieldAliases[part]; ok {
+					part = alias
+				}
+			}
+			child, ok := node[part]
+			if !ok {
+				child = make(fieldTree)
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+// project keeps the selected keys of objects (applied element-wise to arrays).
+// An empty tree keeps the whole value.
+func (t fieldTree) project(value interface{}) interface{} {
+	if len(t) == 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for key, sub := range t {
+			if fieldValue, ok := v[key]; ok {
+				out[key] = sub.project(fieldValue)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, elem := range v {
+			out[i] = t.project(elem)
+		}
+		return out
+	}
+	return value
 }
 
 // Name returns the name of the formatter.
@@ -31,3 +203,8 @@ func (f *jsonFormatter) Name() string {
 func (f *jsonFormatter) Description() string {
 	return "JSON format for programmatic consumption"
 }
+
+// ContentType returns the MIME type of the output.
+func (f *jsonFormatter) ContentType() string {
+	return "application/json"
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
 )
@@ -487,3 +489,154 @@ func TestJSONFormatterComplexGraph(t *testing.T) {
 	}
 }
 
+
+func TestJSONFormatterWithFields(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:       "OrderWorkflow",
+				Type:       "workflow",
+				FilePath:   "order.go",
+				LineNumber: 10,
+				CallSites: []analyzer.CallSite{
+					{TargetName: "Charge", TargetType: "activity", LineNumber: 12},
+					{TargetName: "Ship", TargetType: "activity", LineNumber: 13},
+				},
+			},
+			"Charge": {Name: "Charge", Type: "activity", FilePath: "charge.go"},
+		},
+	}
+
+	f := NewJSONFormatterWithFields([]string{"name", "file", "call_sites.target_name", "missing"})
+
+	var buf bytes.Buffer
+	if err := f.Format(context.Background(), graph, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var nodes []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &nodes); err != nil {
+		t.Fatalf("Output is not a JSON array: %v\n%s", err, buf.String())
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("Expected 2 nodes, got %d", len(nodes))
+	}
+
+	// Nodes are sorted by name
+	if nodes[0]["name"] != "Charge" || nodes[1]["name"] != "OrderWorkflow" {
+		t.Errorf("Unexpected node order: %v", nodes)
+	}
+	if nodes[1]["file_path"] != "order.go" {
+		t.Errorf("Expected file alias to project file_path, got %v", nodes[1])
+	}
+	if _, ok := nodes[1]["type"]; ok {
+		t.Error("Unselected field 'type' should not be present")
+	}
+	if _, ok := nodes[0]["call_sites"]; ok {
+		t.Error("Empty call_sites should be omitted")
+	}
+
+	callSites, ok := nodes[1]["call_sites"].([]interface{})
+	if !ok || len(callSites) != 2 {
+		t.Fatalf("Expected 2 projected call sites, got %v", nodes[1]["call_sites"])
+	}
+	first := callSites[0].(map[string]interface{})
+	if first["target_name"] != "Charge" || len(first) != 1 {
+		t.Errorf("Expected call site projected to target_name, got %v", first)
+	}
+}
+
+func TestJSONFormatterCallOptions(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name: "OrderWorkflow",
+				Type: "workflow",
+				CallSites: []analyzer.CallSite{
+					{TargetName: "Charge", TargetType: "activity", CallType: "execute", FilePath: "order.go", LineNumber: 12,
+						ParsedActivityOpts: &analyzer.ActivityOptions{
+							StartToCloseTimeout: "time.Minute",
+							StartToCloseValue:   time.Minute,
+							RetryPolicy:         &analyzer.RetryPolicy{MaximumAttempts: 3, MaximumAttemptsExpr: "3"},
+						}},
+					{TargetName: "ShipWorkflow", TargetType: "child_workflow", CallType: "execute", FilePath: "order.go", LineNumber: 13},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewJSONFormatter().Format(context.Background(), graph, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var decoded struct {
+		CallOptions []analyzer.CallOptions `json:"call_options"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(decoded.CallOptions) != 1 {
+		t.Fatalf("Expected the activity call only, got %+v", decoded.CallOptions)
+	}
+	call := decoded.CallOptions[0]
+	if call.Caller != "OrderWorkflow" || call.Target != "Charge" || call.CallType != "activity" || call.LineNumber != 12 {
+		t.Errorf("call = %+v, want OrderWorkflow calling Charge at line 12", call)
+	}
+	if call.Options == nil || call.Options.StartToCloseValue != time.Minute || call.Options.RetryPolicy.MaximumAttempts != 3 {
+		t.Errorf("Options = %+v, want the timeout and retry policy", call.Options)
+	}
+}
+
+func TestLegacyJSONFormatter(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name: "OrderWorkflow",
+				Type: "workflow",
+				CallSites: []analyzer.CallSite{
+					{TargetName: "Charge", TargetType: "activity"},
+					{TargetName: "Charge", TargetType: "activity"},
+					{TargetName: "Ship", TargetType: "activity"},
+				},
+			},
+			"Charge": {Name: "Charge", Type: "activity"},
+		},
+		Stats: analyzer.GraphStats{TotalWorkflows: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := NewLegacyJSONFormatter(nil).Format(context.Background(), graph, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var out struct {
+		Nodes map[string]map[string]interface{} `json:"nodes"`
+		Stats analyzer.GraphStats                `json:"stats"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, buf.String())
+	}
+	if out.Stats.TotalWorkflows != 1 {
+		t.Errorf("Expected stats to be kept, got %+v", out.Stats)
+	}
+	children, ok := out.Nodes["OrderWorkflow"]["children"].([]interface{})
+	if !ok || len(children) != 2 || children[0] != "Charge" || children[1] != "Ship" {
+		t.Errorf("Expected children [Charge Ship], got %v", out.Nodes["OrderWorkflow"]["children"])
+	}
+	if _, ok := out.Nodes["OrderWorkflow"]["call_sites"]; !ok {
+		t.Error("Expected call_sites alongside children")
+	}
+	if children, ok := out.Nodes["Charge"]["children"].([]interface{}); !ok || len(children) != 0 {
+		t.Errorf("Expected empty children for a leaf, got %v", out.Nodes["Charge"]["children"])
+	}
+
+	// Projection can select the legacy key
+	buf.Reset()
+	if err := NewLegacyJSONFormatter([]string{"name", "children"}).Format(context.Background(), graph, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"children"`) || strings.Contains(buf.String(), `"type"`) {
+		t.Errorf("Unexpected projected output:\n%s", buf.String())
+	}
+}
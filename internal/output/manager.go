@@ -0,0 +1,87 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// formatAliases maps alternative format names to registered ones.
+var formatAliases = map[string]string{
+	"md": "markdown",
+}
+
+// manager implements the Manager interface.
+type manager struct {
+	formatters map[string]Formatter
+}
+
+// NewManager creates a Manager without any formatters.
+func NewManager() Manager {
+	return &manager{formatters: make(map[string]Formatter)}
+}
+
+// NewDefaultManager creates a Manager with the built-in formatters configured by opts.
+func NewDefaultManager(opts Options) Manager {
+	m := NewManager()
+
+	json := NewJSONFormatter()
+	if opts.LegacyJSON {
+		json = NewLegacyJSONFormatter(opts.Fields)
+	} else if len(opts.Fields) > 0 {
+		json = NewJSONFormatterWithFields(opts.Fields)
+	}
+	m.RegisterFormatter(json)
+
+	exporter := NewExporterWithGlyphs(opts.Glyphs).WithLinks(opts.RootDir, opts.LinkBase)
+	m.RegisterFormatter(NewTreeFormatter(opts.MaxDepth, opts.Glyphs))
+	m.RegisterFormatter(NewDOTFormatter(exporter))
+	m.RegisterFormatter(NewMermaidFormatter(exporter))
+	m.RegisterFormatter(NewMermaidSequenceFormatter(exporter))
+	m.RegisterFormatter(NewMarkdownFormatter(exporter))
+	m.RegisterFormatter(NewFlowsFormatter(opts.MaxDepth))
+	m.RegisterFormatter(NewSQLFormatter(opts.LintIssues))
+	m.RegisterFormatter(&sqliteFormatter{path: opts.OutputFile, issues: opts.LintIssues})
+	m.RegisterFormatter(NewTemplateFormatter(opts.TemplateFile))
+	return m
+}
+
+// RegisterFormatter registers a formatter under its name, replacing any previous one.
+func (m *manager) RegisterFormatter(formatter Formatter) {
+	m.formatters[formatter.Name()] = formatter
+}
+
+// GetFormatter returns a formatter by name.
+func (m *manager) GetFormatter(name string) (Formatter, error) {
+	formatter, ok := m.formatters[name]
+	if !ok && formatAliases[name] != "" {
+		formatter, ok = m.formatters[formatAliases[name]]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unsupported output format: %s (supported: %s)", name, strings.Join(m.ListFormatters(), ", "))
+	}
+	return formatter, nil
+}
+
+// ListFormatters returns the sorted names of the registered formatters.
+func (m *manager) ListFormatters() []string {
+	names := make([]string, 0, len(m.formatters))
+	for name := range m.formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Format formats the graph using the specified formatter.
+func (m *manager) Format(ctx context.Context, formatName string, graph *analyzer.TemporalGraph, w io.Writer) error {
+	formatter, err := m.GetFormatter(formatName)
+	if err != nil {
+		return err
+	}
+	return formatter.Format(ctx, graph, w)
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+)
+
+func TestDefaultManagerFormatters(t *testing.T) {
+	m := NewDefaultManager(Options{Glyphs: glyphs.Unicode})
+
+	want := "dot,flows,json,markdown,mermaid,mermaid-sequence,sql,sqlite,template,tree"
+	if got := strings.Join(m.ListFormatters(), ","); got != want {
+		t.Errorf("ListFormatters() = %s, want %s", got, want)
+	}
+	for _, name := range m.ListFormatters() {
+		f, err := m.GetFormatter(name)
+		if err != nil {
+			t.Fatalf("GetFormatter(%q) failed: %v", name, err)
+		}
+		if f.Name() != name || f.Description() == "" || f.ContentType() == "" {
+			t.Errorf("Formatter %q has name %q, description %q, content type %q", name, f.Name(), f.Description(), f.ContentType())
+		}
+	}
+
+	if f, err := m.GetFormatter("md"); err != nil || f.Name() != "markdown" {
+		t.Errorf("GetFormatter(md) = %v, %v; want the markdown formatter", f, err)
+	}
+	if _, err := m.GetFormatter("html"); err == nil || !strings.Contains(err.Error(), "unsupported output format: html") {
+		t.Errorf("GetFormatter(html) error = %v, want unsupported output format", err)
+	}
+}
+
+func TestManagerFormat(t *testing.T) {
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow"},
+	}}
+
+	m := NewManager()
+	m.RegisterFormatter(NewTreeFormatter(0, glyphs.ASCII))
+
+	var buf bytes.Buffer
+	if err := m.Format(context.Background(), "tree", graph, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if buf.String() != "OrderWorkflow [workflow]\n" {
+		t.Errorf("Format() = %q", buf.String())
+	}
+	if err := m.Format(context.Background(), "json", graph, &buf); err == nil {
+		t.Error("Format() with an unregistered format should fail")
+	}
+}
+
+func TestDefaultManagerOptions(t *testing.T) {
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", FilePath: "orders.go"},
+	}}
+
+	var buf bytes.Buffer
+	m := NewDefaultManager(Options{Fields: []string{"name"}})
+	if err := m.Format(context.Background(), "json", graph, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"name": "OrderWorkflow"`) || strings.Contains(buf.String(), "orders.go") {
+		t.Errorf("JSON output should be projected to the name field, got %s", buf.String())
+	}
+}
@@ -0,0 +1,277 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+// sqlSchema creates the relational tables of the graph. Indexes are created after the inserts.
+const sqlSchema = `CREATE TABLE nodes (
+  name TEXT PRIMARY KEY,
+  type TEXT NOT NULL,
+  package TEXT,
+  domain TEXT,
+  file_path TEXT,
+  line_number INTEGER,
+  description TEXT,
+  return_type TEXT,
+  unresolved INTEGER NOT NULL
+);
+CREATE TABLE parameters (
+  node_name TEXT NOT NULL REFERENCES nodes(name),
+  name TEXT NOT NULL,
+  type TEXT
+);
+CREATE TABLE node_tags (
+  node_name TEXT NOT NULL REFERENCES nodes(name),
+  tag TEXT NOT NULL
+);
+CREATE TABLE node_aliases (
+  node_name TEXT NOT NULL REFERENCES nodes(name),
+  alias TEXT NOT NULL
+);
+CREATE TABLE call_sites (
+  id INTEGER PRIMARY KEY,
+  caller TEXT NOT NULL REFERENCES nodes(name),
+  target TEXT NOT NULL,
+  target_type TEXT,
+  call_type TEXT,
+  file_path TEXT,
+  line_number INTEGER,
+  argument_count INTEGER,
+  result_type TEXT
+);
+CREATE TABLE call_options (
+  call_site_id INTEGER NOT NULL REFERENCES call_sites(id),
+  name TEXT NOT NULL,
+  value TEXT
+);
+CREATE TABLE edges (
+  source TEXT NOT NULL,
+  target TEXT NOT NULL,
+  calls INTEGER NOT NULL,
+  PRIMARY KEY (source, target)
+);
+CREATE TABLE lint_issues (
+  rule_id TEXT NOT NULL,
+  rule_name TEXT,
+  severity TEXT,
+  category TEXT,
+  message TEXT,
+  file_path TEXT,
+  line_number INTEGER,
+  node_name TEXT,
+  node_type TEXT
+);
+`
+
+const sqlIndexes = `CREATE INDEX idx_nodes_type ON nodes(type);
+CREATE INDEX idx_nodes_package ON nodes(package);
+CREATE INDEX idx_parameters_node ON parameters(node_name);
+CREATE INDEX idx_node_tags_tag ON node_tags(tag);
+CREATE INDEX idx_node_aliases_alias ON node_aliases(alias);
+CREATE INDEX idx_call_sites_caller ON call_sites(caller);
+CREATE INDEX idx_call_sites_target ON call_sites(target);
+CREATE INDEX idx_call_options_call_site ON call_options(call_site_id);
+CREATE INDEX idx_edges_target ON edges(target);
+CREATE INDEX idx_lint_issues_node ON lint_issues(node_name);
+CREATE INDEX idx_lint_issues_rule ON lint_issues(rule_id);
+`
+
+// sqlFormatter writes the graph as a SQLite-compatible SQL script.
+type sqlFormatter struct {
+	issues []lint.Issue
+}
+
+// IncludesLint reports whether an output format writes lint issues along with the graph,
+// so that callers lint the graph with their configured rules before formatting.
+func IncludesLint(format string) bool {
+	return format == "sql" || format == "sqlite"
+}
+
+// NewSQLFormatter creates a formatter that writes the graph and the given lint issues
+// as a SQL script of CREATE TABLE and INSERT statements.
+func NewSQLFormatter(issues []lint.Issue) Formatter {
+	return &sqlFormatter{issues: issues}
+}
+
+// Format formats the given graph and writes it to the writer as SQL.
+func (f *sqlFormatter) Format(ctx context.Context, graph *analyzer.TemporalGraph, w io.Writer) error {
+	issues := f.issues
+
+	bw := bufio.NewWriter(w)
+	_, _ = bw.WriteString("BEGIN TRANSACTION;\n")
+	_, _ = bw.WriteString(sqlSchema)
+
+	names := make([]string, 0, len(graph.Nodes))
+	for name := range graph.Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	edges := make(map[[2]string]int)
+	var edgeOrder [][2]string
+	callID := 0
+
+	for _, name := range names {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		node := graph.Nodes[name]
+		writeInsert(bw, "nodes", name, node.Type, node.Package, node.Domain,
+			node.FilePath, node.LineNumber, node.Description, node.ReturnType, node.Unresolved)
+
+		params := make([]string, 0, len(node.Parameters))
+		for param := range node.Parameters {
+			params = append(params, param)
+		}
+		sort.Strings(params)
+		for _, param := range params {
+			writeInsert(bw, "parameters", name, param, node.Parameters[param])
+		}
+
+		for _, tag := range node.Tags {
+			writeInsert(bw, "node_tags", name, tag)
+		}
+		for _, alias := range node.Aliases {
+			writeInsert(bw, "node_aliases", name, alias)
+		}
+
+		for _, call := range node.CallSites {
+			callID++
+			writeInsert(bw, "call_sites", callID, name, call.TargetName, call.TargetType, call.CallType,
+				call.FilePath, call.LineNumber, call.ArgumentCount, call.ResultType)
+			for _, setting := range call.ParsedActivityOpts.Settings() {
+				writeInsert(bw, "call_options", callID, setting.Name, setting.Value)
+			}
+
+			edge := [2]string{name, call.TargetName}
+			if _, ok := edges[edge]; !ok {
+				edgeOrder = append(edgeOrder, edge)
+			}
+			edges[edge]++
+		}
+	}
+
+	for _, edge := range edgeOrder {
+		writeInsert(bw, "edges", edge[0], edge[1], edges[edge])
+	}
+
+	for _, issue := range issues {
+		writeInsert(bw, "lint_issues", issue.RuleID, issue.RuleName, string(issue.Severity), string(issue.Category),
+			issue.Message, issue.FilePath, issue.LineNumber, issue.NodeName, issue.NodeType)
+	}
+
+	_, _ = bw.WriteString(sqlIndexes)
+	_, _ = bw.WriteString("COMMIT;\n")
+	return bw.Flush()
+}
+
+// Name returns the name of the formatter.
+func (f *sqlFormatter) Name() string {
+	return "sql"
+}
+
+// Description returns a description of the output format.
+func (f *sqlFormatter) Description() string {
+	return "SQL script with relational tables of nodes, tags, edges, call sites, options and lint issues"
+}
+
+// ContentType returns the MIME type of the output.
+func (f *sqlFormatter) ContentType() string {
+	return "application/sql"
+}
+
+// writeInsert writes an INSERT statement for a row of string and integer values.
+func writeInsert(w *bufio.Writer, table string, values ...any) {
+	literals := make([]string, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case int:
+			literals[i] = strconv.Itoa(v)
+		case bool:
+			literals[i] = "0"
+			if v {
+				literals[i] = "1"
+			}
+		case string:
+			literals[i] = sqlString(v)
+		default:
+			literals[i] = sqlString(fmt.Sprint(v))
+		}
+	}
+	_, _ = fmt.Fprintf(w, "INSERT INTO %s VALUES (%s);\n", table, strings.Join(literals, ", "))
+}
+
+// sqlString quotes a string literal, using NULL for empty strings.
+func sqlString(s string) string {
+	if s == "" {
+		return "NULL"
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// ExportSQLite writes the graph and lint issues into a new SQLite database at path.
+// The database is built by the sqlite3 command-line tool, which must be on the PATH.
+func ExportSQLite(ctx context.Context, graph *analyzer.TemporalGraph, issues []lint.Issue, path string) error {
+	sqlite, err := exec.LookPath("sqlite3")
+	if err != nil {
+		return fmt.Errorf("sqlite3 not found in PATH (use --format sql to write the SQL script instead): %w", err)
+	}
+
+	var script bytes.Buffer
+	if err := NewSQLFormatter(issues).Format(ctx, graph, &script); err != nil {
+		return fmt.Errorf("failed to generate SQL: %w", err)
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	cmd := exec.CommandContext(ctx, sqlite, "-bail", path)
+	cmd.Stdin = &script
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to write SQLite database: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// sqliteFormatter writes the graph into a SQLite database file instead of the writer.
+type sqliteFormatter struct {
+	path   string
+	issues []lint.Issue
+}
+
+// Format writes the graph and the formatter's lint issues into the database.
+func (f *sqliteFormatter) Format(ctx context.Context, graph *analyzer.TemporalGraph, _ io.Writer) error {
+	return ExportSQLite(ctx, graph, f.issues, f.path)
+}
+
+// Name returns the name of the formatter.
+func (f *sqliteFormatter) Name() string {
+	return "sqlite"
+}
+
+// Description returns a description of the output format.
+func (f *sqliteFormatter) Description() string {
+	return "SQLite database with the tables of the sql format (needs sqlite3 and --output)"
+}
+
+// ContentType returns the MIME type of the output.
+func (f *sqliteFormatter) ContentType() string {
+	return "application/vnd.sqlite3"
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+func sqlTestGraph() *analyzer.TemporalGraph {
+	return &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {
+			Name: "OrderWorkflow", Type: "workflow", Package: "orders", FilePath: "/app/orders/workflow.go", LineNumber: 10,
+			Description: "Handles the customer's order",
+			CallSites: []analyzer.CallSite{
+				{TargetName: "Charge", TargetType: "activity", CallType: "activity", LineNumber: 20, ArgumentCount: 1,
+					ParsedActivityOpts: &analyzer.ActivityOptions{StartToCloseTimeout: "time.Minute"}},
+				{TargetName: "Charge", TargetType: "activity", CallType: "activity", LineNumber: 30, ArgumentCount: 1},
+			},
+		},
+		"Charge": {
+			Name: "Charge", Type: "activity", Package: "payments", Aliases: []string{"v2.Charge"},
+			Parameters: map[string]string{"ctx": "context.Context", "amount": "int"},
+		},
+	}}
+}
+
+func sqlTestIssues() []lint.Issue {
+	return []lint.Issue{{RuleID: "TA002", RuleName: "activity-no-timeout", Severity: lint.SeverityError, NodeName: "OrderWorkflow", LineNumber: 30}}
+}
+
+func TestSQLFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewSQLFormatter(sqlTestIssues()).Format(context.Background(), sqlTestGraph(), &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"CREATE TABLE nodes (",
+		"INSERT INTO nodes VALUES ('OrderWorkflow', 'workflow', 'orders', NULL, '/app/orders/workflow.go', 10, 'Handles the customer''s order', NULL, 0);",
+		"INSERT INTO parameters VALUES ('Charge', 'amount', 'int');",
+		"INSERT INTO node_aliases VALUES ('Charge', 'v2.Charge');",
+		"INSERT INTO call_sites VALUES (1, 'OrderWorkflow', 'Charge', 'activity', 'activity', NULL, 20, 1, NULL);",
+		"INSERT INTO call_options VALUES (1, 'StartToCloseTimeout', 'time.Minute');",
+		"INSERT INTO edges VALUES ('OrderWorkflow', 'Charge', 2);",
+		"INSERT INTO lint_issues VALUES ('TA002', 'activity-no-timeout', 'error', NULL, NULL, NULL, 30, 'OrderWorkflow', NULL);",
+		"CREATE INDEX idx_call_sites_target ON call_sites(target);",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected SQL to contain %q", want)
+		}
+	}
+	if !strings.HasPrefix(out, "BEGIN TRANSACTION;") || !strings.HasSuffix(out, "COMMIT;\n") {
+		t.Error("Expected SQL to be wrapped in a transaction")
+	}
+}
+
+func TestExportSQLite(t *testing.T) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 not available")
+	}
+
+	path := filepath.Join(t.TempDir(), "graph.db")
+	// Exporting twice replaces the database instead of failing on existing tables
+	for i := 0; i < 2; i++ {
+		if err := ExportSQLite(context.Background(), sqlTestGraph(), sqlTestIssues(), path); err != nil {
+			t.Fatalf("ExportSQLite failed: %v", err)
+		}
+	}
+
+	out, err := exec.Command("sqlite3", path,
+		"SELECT n.name, COUNT(c.id) FROM nodes n JOIN call_sites c ON c.caller = n.name GROUP BY n.name; SELECT COUNT(*) FROM lint_issues;").Output()
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "OrderWorkflow|2\n1" {
+		t.Errorf("Unexpected query result %q", got)
+	}
+}
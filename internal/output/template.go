@@ -0,0 +1,122 @@
+package output
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// templateFormatter renders the graph through a user-provided text/template.
+type templateFormatter struct {
+	path string
+}
+
+// NewTemplateFormatter creates a formatter that executes the Go text/template at path
+// with the graph as data. Besides the built-in template functions it provides:
+//
+//	sortNodes .Nodes       nodes sorted by name
+//	byType "workflow" ...  nodes of the given type
+//	callers $node          nodes that call $node
+//	callees $node          distinct call targets of $node, in call order
+func NewTemplateFormatter(path string) Formatter {
+	return &templateFormatter{path: path}
+}
+
+// Format executes the template with the graph and writes the result to the writer.
+func (f *templateFormatter) Format(ctx context.Context, graph *analyzer.TemporalGraph, w io.Writer) error {
+	if f.path == "" {
+		return fmt.Errorf("template format requires a template file")
+	}
+	text, err := os.ReadFile(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(f.path)).Funcs(templateFuncs(graph)).Parse(string(text))
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	bw := bufio.NewWriter(w)
+	if err := tmpl.Execute(bw, graph); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return bw.Flush()
+}
+
+// Name returns the name of the formatter.
+func (f *templateFormatter) Name() string {
+	return "template"
+}
+
+// Description returns a description of the output format.
+func (f *templateFormatter) Description() string {
+	return "Custom report rendered by the Go text/template given with --template-file"
+}
+
+// ContentType returns the MIME type of the output.
+func (f *templateFormatter) ContentType() string {
+	return "text/plain"
+}
+
+// templateFuncs returns the helper functions available to templates of the graph.
+func templateFuncs(graph *analyzer.TemporalGraph) template.FuncMap {
+	return template.FuncMap{
+		"sortNodes": func(nodes map[string]*analyzer.TemporalNode) []*analyzer.TemporalNode {
+			sorted := make([]*analyzer.TemporalNode, 0, len(nodes))
+			for _, node := range nodes {
+				sorted = append(sorted, node)
+			}
+			sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+			return sorted
+		},
+		"byType": func(nodeType string, nodes []*analyzer.TemporalNode) []*analyzer.TemporalNode {
+			var matching []*analyzer.TemporalNode
+			for _, node := range nodes {
+				if node.Type == nodeType {
+					matching = append(matching, node)
+				}
+			}
+			return matching
+		},
+		"callers": func(node *analyzer.TemporalNode) []*analyzer.TemporalNode {
+			callers := make([]*analyzer.TemporalNode, 0, len(node.Parents))
+			for _, name := range node.Parents {
+				if caller, ok := graph.Nodes[name]; ok {
+					callers = append(callers, caller)
+				}
+			}
+			return callers
+		},
+		"callees": func(node *analyzer.TemporalNode) []*analyzer.TemporalNode {
+			var callees []*analyzer.TemporalNode
+			seen := make(map[string]bool)
+			for _, call := range node.CallSites {
+				if seen[call.TargetName] {
+					continue
+				}
+				seen[call.TargetName] = true
+				callee, ok := graph.Nodes[call.TargetName]
+				if !ok {
+					// Targets outside the graph are returned as unresolved placeholders
+					callee = &analyzer.TemporalNode{Name: call.TargetName, Type: call.TargetType, Unresolved: true}
+				}
+				callees = append(callees, callee)
+			}
+			return callees
+		},
+	}
+}
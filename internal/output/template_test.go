@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func writeTemplate(t *testing.T, text string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	return path
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"RefundWorkflow": {Name: "RefundWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{
+				{TargetName: "Charge", TargetType: "activity"},
+			}},
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{
+				{TargetName: "Charge", TargetType: "activity"},
+				{TargetName: "Charge", TargetType: "activity"},
+				{TargetName: "Notify", TargetType: "activity"},
+			}},
+			"Charge": {Name: "Charge", Type: "activity", Parents: []string{"OrderWorkflow", "RefundWorkflow"}},
+		},
+		Stats: analyzer.GraphStats{TotalWorkflows: 2},
+	}
+
+	path := writeTemplate(t, `h1. {{.Stats.TotalWorkflows}} workflows
+{{range sortNodes .Nodes | byType "workflow"}}* {{.Name}}:{{range callees .}} {{.Name}}{{if .Unresolved}}?{{end}}{{end}}
+{{end}}{{range sortNodes .Nodes | byType "activity"}}{{.Name}} <-{{range callers .}} {{.Name}}{{end}}
+{{end}}`)
+
+	var buf bytes.Buffer
+	if err := NewTemplateFormatter(path).Format(context.Background(), graph, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	want := `h1. 2 workflows
+* OrderWorkflow: Charge Notify?
+* RefundWorkflow: Charge
+Charge <- OrderWorkflow RefundWorkflow
+`
+	if buf.String() != want {
+		t.Errorf("Format() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestTemplateFormatterErrors(t *testing.T) {
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{}}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "no template", path: "", want: "requires a template file"},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.tmpl"), want: "failed to read template"},
+		{name: "parse error", path: writeTemplate(t, "{{range}}"), want: "failed to parse template"},
+		{name: "unknown field", path: writeTemplate(t, "{{.Missing}}"), want: "failed to execute template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewTemplateFormatter(tt.path).Format(context.Background(), graph, &bytes.Buffer{})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Format() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package output
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+)
+
+// treeFormatter implements the Formatter interface for plain-text call trees.
+type treeFormatter struct {
+	// maxDepth limits how many levels below each root are printed (0 = unlimited)
+	maxDepth int
+	glyphs   glyphs.Set
+}
+
+// NewTreeFormatter creates a new tree formatter. maxDepth limits the printed depth (0 = unlimited).
+func NewTreeFormatter(maxDepth int, set glyphs.Set) Formatter {
+	return &treeFormatter{
+		maxDepth: maxDepth,
+		glyphs:   set,
+	}
+}
+
+// Format writes the call tree of the graph, starting from nodes without parents.
+// Nodes that were already expanded are printed once more with a "see above" marker,
+// and calls back into the current path are marked as cycles.
+func (f *treeFormatter) Format(ctx context.Context, graph *analyzer.TemporalGraph, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	r := &treeRenderer{
+		formatter: f,
+		graph:     graph,
+		w:         bw,
+		expanded:  make(map[string]bool),
+		printed:   make(map[string]bool),
+	}
+
+	names := make([]string, 0, len(graph.Nodes))
+	for name := range graph.Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var roots []string
+	for _, name := range names {
+		if len(graph.Nodes[name].Parents) == 0 {
+			roots = append(roots, name)
+		}
+	}
+	reachable := r.reachableFrom(roots)
+
+	// Roots first, then anything only reachable through cycles
+	for _, pass := range []func(node *analyzer.TemporalNode) bool{
+		func(node *analyzer.TemporalNode) bool { return len(node.Parents) == 0 },
+		func(node *analyzer.TemporalNode) bool { return !reachable[node.ID()] },
+	} {
+		for _, name := range names {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			node := graph.Nodes[name]
+			if r.printed[name] || !pass(node) {
+				continue
+			}
+			r.renderRoot(node)
+		}
+	}
+
+	if r.err != nil {
+		return r.err
+	}
+	return bw.Flush()
+}
+
+// Name returns the name of the formatter.
+func (f *treeFormatter) Name() string {
+	return "tree"
+}
+
+// Description returns a description of the output format.
+func (f *treeFormatter) Description() string {
+	return "Plain-text call tree"
+}
+
+// ContentType returns the MIME type of the output.
+func (f *treeFormatter) ContentType() string {
+	return "text/plain"
+}
+
+// treeRenderer holds the state of a single tree rendering.
+type treeRenderer struct {
+	formatter *treeFormatter
+	graph     *analyzer.TemporalGraph
+	w         io.Writer
+	err       error
+
+	// expanded tracks nodes whose children were already printed
+	expanded map[string]bool
+	// printed tracks nodes that appear anywhere in the output
+	printed map[string]bool
+}
+
+// treeChild is a distinct call target of a node.
+type treeChild struct {
+	name       string // graph key
+	label      string // display name
+	targetType string
+	count      int
+	unresolved bool // not defined in the analyzed code
+}
+
+func (r *treeRenderer) writeLine(line string) {
+	if r.err == nil {
+		_, r.err = fmt.Fprintln(r.w, line)
+	}
+}
+
+func (r *treeRenderer) renderRoot(node *analyzer.TemporalNode) {
+	line := r.label(node.Name, node.Type, 1)
+	if startedBy := r.graph.StartedBy(node.ID()); len(startedBy) > 0 {
+		triggers := make([]string, len(startedBy))
+		for i, ep := range startedBy {
+			triggers[i] = ep.Trigger
+		}
+		line = strings.Join(triggers, ", ") + " " + r.formatter.glyphs.Arrow + " " + line
+	}
+	r.writeLine(line)
+	r.printed[node.ID()] = true
+	r.expanded[node.ID()] = true
+	r.renderChildren(node, "", 1, map[string]bool{node.ID(): true})
+}
+
+func (r *treeRenderer) renderChildren(node *analyzer.TemporalNode, prefix string, depth int, path map[string]bool) {
+	children := r.children(node)
+	g := r.formatter.glyphs
+
+	for i, child := range children {
+		last := i == len(children)-1
+		branch, indent := g.TreeMiddle, g.TreeIndent
+		if last {
+			branch, indent = g.TreeLast, g.TreeSpace
+		}
+
+		line := prefix + branch + r.label(child.label, child.targetType, child.count)
+		childNode := r.graph.Nodes[child.name]
+		r.printed[child.name] = true
+		if child.unresolved {
+			line += " (unresolved)"
+		}
+
+		switch {
+		case childNode == nil || len(r.children(childNode)) == 0:
+			r.writeLine(line)
+		case path[child.name]:
+			r.writeLine(line + " " + g.Cycle + " (cycle)")
+		case r.expanded[child.name]:
+			r.writeLine(line + " " + g.SeeAbove + " (see above)")
+		case r.formatter.maxDepth > 0 && depth >= r.formatter.maxDepth:
+			r.writeLine(line + fmt.Sprintf(" %s (%d more)", g.Ellipsis, len(r.children(childNode))))
+		default:
+			r.writeLine(line)
+			r.expanded[child.name] = true
+			path[child.name] = true
+			r.renderChildren(childNode, prefix+indent, depth+1, path)
+			delete(path, child.name)
+		}
+	}
+}
+
+// reachableFrom returns the nodes reachable from the given roots, ignoring depth limits.
+func (r *treeRenderer) reachableFrom(roots []string) map[string]bool {
+	reachable := make(map[string]bool)
+	queue := append([]string(nil), roots...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if reachable[name] {
+			continue
+		}
+		reachable[name] = true
+		if node, ok := r.graph.Nodes[name]; ok {
+			for _, cs := range node.CallSites {
+				queue = append(queue, cs.TargetName)
+			}
+		}
+	}
+	return reachable
+}
+
+// children returns the distinct call targets of a node in call order.
+func (r *treeRenderer) children(node *analyzer.TemporalNode) []treeChild {
+	var children []treeChild
+	index := make(map[string]int)
+	for _, cs := range node.CallSites {
+		if i, ok := index[cs.TargetName]; ok {
+			children[i].count++
+			continue
+		}
+
+		label, targetType := cs.TargetName, cs.TargetType
+		unresolved := len(cs.Candidates) > 0
+		if target, ok := r.graph.Nodes[cs.TargetName]; ok {
+			label = target.Name
+			if target.Type != "" {
+				targetType = target.Type
+			}
+			unresolved = unresolved || target.Unresolved
+		}
+		index[cs.TargetName] = len(children)
+		children = append(children, treeChild{name: cs.TargetName, label: label, targetType: targetType, count: 1, unresolved: unresolved})
+	}
+	return children
+}
+
+// label formats a node name with its type and call count.
+func (r *treeRenderer) label(name, nodeType string, count int) string {
+	label := name
+	if nodeType != "" {
+		label += " [" + nodeType + "]"
+	}
+	if count > 1 {
+		label += fmt.Sprintf(" %s%d", r.formatter.glyphs.Times, count)
+	}
+	return label
+}
@@ -0,0 +1,148 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// treeTestGraph builds a graph with shared activities, a child workflow cycle and repeated calls.
+func treeTestGraph() *analyzer.TemporalGraph {
+	calls := func(targets ...string) []analyzer.CallSite {
+		sites := make([]analyzer.CallSite, len(targets))
+		for i, target := range targets {
+			sites[i] = analyzer.CallSite{TargetName: target, TargetType: "activity"}
+		}
+		return sites
+	}
+
+	return &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:      "OrderWorkflow",
+				Type:      "workflow",
+				CallSites: calls("Reserve", "Charge", "Charge", "ShippingWorkflow"),
+			},
+			"RefundWorkflow": {
+				Name:      "RefundWorkflow",
+				Type:      "workflow",
+				CallSites: calls("Charge", "ShippingWorkflow"),
+			},
+			"ShippingWorkflow": {
+				Name:      "ShippingWorkflow",
+				Type:      "workflow",
+				Parents:   []string{"OrderWorkflow", "RefundWorkflow", "TrackingWorkflow"},
+				CallSites: calls("Ship", "TrackingWorkflow"),
+			},
+			"TrackingWorkflow": {
+				Name:      "TrackingWorkflow",
+				Type:      "workflow",
+				Parents:   []string{"ShippingWorkflow"},
+				CallSites: calls("ShippingWorkflow", "ExternalNotify"),
+			},
+			"Reserve": {Name: "Reserve", Type: "activity", Parents: []string{"OrderWorkflow"}},
+			"Charge":  {Name: "Charge", Type: "activity", Parents: []string{"OrderWorkflow", "RefundWorkflow"}},
+			"Ship":    {Name: "Ship", Type: "activity", Parents: []string{"ShippingWorkflow"}},
+			"Unused":  {Name: "Unused", Type: "activity"},
+		},
+	}
+}
+
+func TestTreeFormatterGolden(t *testing.T) {
+	tests := []struct {
+		name     string
+		graph    *analyzer.TemporalGraph
+		maxDepth int
+		plain    bool
+	}{
+		{name: "tree_full", graph: treeTestGraph()},
+		{name: "tree_full_plain", graph: treeTestGraph(), plain: true},
+		{name: "tree_max_depth", graph: treeTestGraph(), maxDepth: 1},
+		{
+			name: "tree_cycle_only",
+			graph: &analyzer.TemporalGraph{
+				Nodes: map[string]*analyzer.TemporalNode{
+					"A": {Name: "A", Type: "workflow", Parents: []string{"B"}, CallSites: []analyzer.CallSite{{TargetName: "B", TargetType: "child_workflow"}}},
+					"B": {Name: "B", Type: "workflow", Parents: []string{"A"}, CallSites: []analyzer.CallSite{{TargetName: "A", TargetType: "child_workflow"}}},
+				},
+			},
+		},
+		{
+			name: "tree_unresolved",
+			graph: &analyzer.TemporalGraph{
+				Nodes: map[string]*analyzer.TemporalNode{
+					"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{
+						{TargetName: "SendEmail", TargetType: "activity"},
+						{TargetName: "Charge", TargetType: "activity", Candidates: []string{"billing.Charge", "payments.Charge"}},
+					}},
+					"SendEmail": {Name: "SendEmail", Type: "activity", Parents: []string{"OrderWorkflow"}, Unresolved: true},
+				},
+			},
+		},
+		{
+			name: "tree_entry_points",
+			graph: &analyzer.TemporalGraph{
+				Nodes: map[string]*analyzer.TemporalNode{
+					"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{{TargetName: "Charge", TargetType: "activity"}}},
+					"Charge":        {Name: "Charge", Type: "activity", Parents: []string{"OrderWorkflow"}},
+				},
+				EntryPoints: []analyzer.EntryPoint{
+					{Kind: "http", Trigger: "POST /orders", Handler: "createOrder", Workflow: "OrderWorkflow"},
+					{Kind: "grpc", Trigger: "OrderService/CreateOrder", Handler: "orderServer.CreateOrder", Workflow: "OrderWorkflow"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewTreeFormatter(tt.maxDepth, glyphs.For(tt.plain)).Format(context.Background(), tt.graph, &buf); err != nil {
+				t.Fatalf("Format failed: %v", err)
+			}
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *updateGolden {
+				if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatalf("Failed to update golden file: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Failed to read golden file (run with -update to create): %v", err)
+			}
+			if buf.String() != string(want) {
+				t.Errorf("Output mismatch for %s:\n--- got ---\n%s\n--- want ---\n%s", golden, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestTreeFormatterName(t *testing.T) {
+	f := NewTreeFormatter(0, glyphs.Unicode)
+	if f.Name() != "tree" {
+		t.Errorf("Name() = %q, want %q", f.Name(), "tree")
+	}
+	if f.Description() == "" {
+		t.Error("Description() returned empty string")
+	}
+}
+
+func TestTreeFormatterContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	if err := NewTreeFormatter(0, glyphs.Unicode).Format(ctx, treeTestGraph(), &buf); err == nil {
+		t.Error("Expected error for cancelled context")
+	}
+}
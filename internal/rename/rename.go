@@ -0,0 +1,375 @@
+// Package rename renames a workflow or activity in the references Temporal resolves it by:
+// its declaration, the call sites the graph links to it, its registrations and the string
+// names it is registered and called under. Plain Go references, such as direct calls in
+// tests, are left to the compiler or gopls.
+package rename
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+// Kinds of edits.
+const (
+	// KindDefinition renames the function or method declaration
+	KindDefinition = "definition"
+	// KindCall renames the target of a call site the graph links to the node
+	KindCall = "call"
+	// KindRegistration renames the function registered with Register*
+	KindRegistration = "registration"
+	// KindStringName renames a string name: a RegisterOptions name, or the target of a call by name
+	KindStringName = "string"
+)
+
+// Edit replaces one reference to the renamed node.
+type Edit struct {
+	FilePath string `json:"file_path"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Kind     string `json:"kind"`
+	// Old is the replaced text and New its replacement, quoted for string names
+	Old string `json:"old"`
+	New string `json:"new"`
+
+	// offset is the byte offset of Old in the file
+	offset int
+}
+
+// Plan is the set of edits renaming a node.
+type Plan struct {
+	// Node is the graph key of the renamed node
+	Node  string `json:"node"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Edits []Edit `json:"edits"`
+}
+
+// Files returns the number of files the plan edits.
+func (p *Plan) Files() int {
+	files := make(map[string]bool)
+	for _, e := range p.Edits {
+		files[e.FilePath] = true
+	}
+	return len(files)
+}
+
+// executeMethods are the SDK calls whose target is named by a function or string, by the
+// type of node they execute.
+var executeMethods = map[string]map[string]bool{
+	"activity": {"ExecuteActivity": true, "ExecuteLocalActivity": true},
+	"workflow": {"ExecuteChildWorkflow": true, "ExecuteWorkflow": true, "SignalWithStartWorkflow": true},
+}
+
+// registerMethods are the registration calls of workers and test environments.
+var registerMethods = map[string]bool{
+	"RegisterActivity":            true,
+	"RegisterActivityWithOptions": true,
+	"RegisterWorkflow":            true,
+	"RegisterWorkflowWithOptions": true,
+}
+
+// FindNode returns the workflow or activity named name, by graph key, name, or Type.Method
+// for methods.
+func FindNode(graph *analyzer.TemporalGraph, name string) (*analyzer.TemporalNode, error) {
+	node, ok := graph.Nodes[name]
+	if !ok {
+		var matches []*analyzer.TemporalNode
+		for _, n := range graph.Nodes {
+			if strings.TrimPrefix(n.Name, "*") == strings.TrimPrefix(name, "*") {
+				matches = append(matches, n)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("no workflow or activity named %s in the analyzed code", name)
+		case 1:
+			node = matches[0]
+		default:
+			keys := make([]string, 0, len(matches))
+			for _, n := range matches {
+				keys = append(keys, n.ID())
+			}
+			sort.Strings(keys)
+			return nil, fmt.Errorf("%s is defined in several packages, name one of: %s", name, strings.Join(keys, ", "))
+		}
+	}
+	if node.Unresolved {
+		return nil, fmt.Errorf("%s is called but not defined in the analyzed code", name)
+	}
+	if executeMethods[node.Type] == nil {
+		return nil, fmt.Errorf("%s is a %s; only workflows and activities can be renamed", name, node.Type)
+	}
+	return node, nil
+}
+
+// NewPlan finds the references to node in the Go files under opts.RootDir, including tests,
+// and returns the edits renaming it to to. Call sites are taken from the graph's CalledBy
+// index; calls by string name are also found outside the graph, e.g. in client code.
+func NewPlan(ctx context.Context, graph *analyzer.TemporalGraph, node *analyzer.TemporalNode, to string, opts config.AnalysisOptions) (*Plan, error) {
+	from := node.Name
+	if i := strings.LastIndex(from, "."); i >= 0 {
+		from = from[i+1:]
+	}
+	if !token.IsIdentifier(to) {
+		return nil, fmt.Errorf("invalid name %q: not a Go identifier", to)
+	}
+	if to == from {
+		return nil, fmt.Errorf("%s is already named %s", node.ID(), to)
+	}
+	renamed := strings.TrimSuffix(node.Name, from) + to
+	for _, n := range graph.Nodes {
+		if n != node && !n.Unresolved && n.Package == node.Package && n.Name == renamed {
+			return nil, fmt.Errorf("%s is already defined in package %s", to, node.Package)
+		}
+	}
+
+	r := &renamer{
+		node:  node,
+		from:  from,
+		to:    to,
+		calls: make(map[string]map[int]bool),
+		seen:  make(map[string]map[int]bool),
+	}
+	for _, ref := range node.CalledBy {
+		if ref.FilePath == "" {
+			continue
+		}
+		file := filepath.Clean(ref.FilePath)
+		if r.calls[file] == nil {
+			r.calls[file] = make(map[int]bool)
+		}
+		r.calls[file][ref.LineNumber] = true
+	}
+
+	modules := analyzer.NewModuleFilter(opts.RootDir, opts)
+	err := filepath.Walk(opts.RootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" || opts.ExcludesDir(info.Name()) || modules.SkipDir(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		return r.scanFile(path)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(r.edits, func(i, j int) bool {
+		a, b := r.edits[i], r.edits[j]
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.offset < b.offset
+	})
+	return &Plan{Node: node.ID(), From: from, To: to, Edits: r.edits}, nil
+}
+
+// renamer collects the edits of a rename.
+type renamer struct {
+	node     *analyzer.TemporalNode
+	from, to string
+	// calls are the lines of the node's call sites by file
+	calls map[string]map[int]bool
+	// seen are the offsets already edited by file
+	seen  map[string]map[int]bool
+	edits []Edit
+}
+
+// scanFile records the edits of a file.
+func (r *renamer) scanFile(path string) error {
+	src, err := os.ReadFile(path)
+	if err != nil || !bytes.Contains(src, []byte(r.from)) {
+		return nil
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		// Files that don't parse aren't analyzed either
+		return nil
+	}
+
+	path = filepath.Clean(path)
+	isMethod := strings.Contains(r.node.Name, ".")
+	add := func(pos token.Pos, kind, old, new string) {
+		position := fset.Position(pos)
+		if r.seen[path] == nil {
+			r.seen[path] = make(map[int]bool)
+		}
+		if r.seen[path][position.Offset] {
+			return
+		}
+		r.seen[path][position.Offset] = true
+		r.edits = append(r.edits, Edit{
+			FilePath: path,
+			Line:     position.Line,
+			Column:   position.Column,
+			Kind:     kind,
+			Old:      old,
+			New:      new,
+			offset:   position.Offset,
+		})
+	}
+	// reference records the edit of expr if it refers to the node: a function, a selector
+	// of a function or method, or its string name
+	reference := func(expr ast.Expr, kind string) bool {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			if !isMethod && e.Name == r.from {
+				add(e.Pos(), kind, r.from, r.to)
+				return true
+			}
+		case *ast.SelectorExpr:
+			if e.Sel.Name == r.from {
+				add(e.Sel.Pos(), kind, r.from, r.to)
+				return true
+			}
+		case *ast.BasicLit:
+			if name, err := strconv.Unquote(e.Value); err == nil && e.Kind == token.STRING && name == r.from {
+				add(e.Pos(), KindStringName, e.Value, quote(e.Value, r.to))
+				return true
+			}
+		}
+		return false
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if path == filepath.Clean(r.node.FilePath) && n.Name.Name == r.from && fset.Position(n.Pos()).Line == r.node.LineNumber {
+				add(n.Name.Pos(), KindDefinition, r.from, r.to)
+				// Doc comments start with the name they document
+				if n.Doc != nil && strings.HasPrefix(n.Doc.List[0].Text, "// "+r.from+" ") {
+					add(n.Doc.List[0].Pos()+3, KindDefinition, r.from, r.to)
+				}
+			}
+		case *ast.CallExpr:
+			method := ""
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+				method = sel.Sel.Name
+			}
+			switch {
+			case registerMethods[method]:
+				if len(n.Args) > 0 {
+					reference(n.Args[0], KindRegistration)
+				}
+				if len(n.Args) > 1 {
+					if name := registerOptionsName(n.Args[1]); name != nil {
+						reference(name, KindStringName)
+					}
+				}
+			case r.calls[path][fset.Position(n.Pos()).Line]:
+				// A call site of the node: an SDK call or an execute helper naming it
+				for _, arg := range n.Args {
+					if reference(arg, KindCall) {
+						break
+					}
+				}
+			case executeMethods[r.node.Type][method]:
+				// Calls by string name resolve at run time, in workflows or clients alike
+				for _, arg := range n.Args {
+					if lit, ok := arg.(*ast.BasicLit); ok && reference(lit, KindStringName) {
+						break
+					}
+				}
+			}
+		}
+		return true
+	})
+	return nil
+}
+
+// registerOptionsName returns the Name literal of a RegisterOptions argument, or nil.
+func registerOptionsName(expr ast.Expr) *ast.BasicLit {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Name" {
+			if value, ok := kv.Value.(*ast.BasicLit); ok && value.Kind == token.STRING {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+// quote quotes name the way literal is quoted.
+func quote(literal, name string) string {
+	if strings.HasPrefix(literal, "`") {
+		return "`" + name + "`"
+	}
+	return strconv.Quote(name)
+}
+
+// Apply writes the edits of the plan. If a file changed since the plan was made, no file
+// is written.
+func (p *Plan) Apply() error {
+	byFile := make(map[string][]Edit)
+	var files []string
+	for _, e := range p.Edits {
+		if byFile[e.FilePath] == nil {
+			files = append(files, e.FilePath)
+		}
+		byFile[e.FilePath] = append(byFile[e.FilePath], e)
+	}
+
+	contents := make([][]byte, len(files))
+	for i, path := range files {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		edits := byFile[path]
+		// Edit from the end so the offsets of earlier edits stay valid
+		sort.Slice(edits, func(i, j int) bool { return edits[i].offset > edits[j].offset })
+		for _, e := range edits {
+			end := e.offset + len(e.Old)
+			if end > len(src) || string(src[e.offset:end]) != e.Old {
+				return fmt.Errorf("%s changed since the rename was planned (expected %s at line %d)", path, e.Old, e.Line)
+			}
+			src = append(src[:e.offset], append([]byte(e.New), src[end:]...)...)
+		}
+		contents[i] = src
+	}
+
+	for i, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, contents[i], info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
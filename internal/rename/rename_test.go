@@ -0,0 +1,192 @@
+package rename
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+)
+
+const ordersSource = `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func OrderWorkflow(ctx workflow.Context, id string) error {
+	if err := workflow.ExecuteActivity(ctx, ChargeCard, id).Get(ctx, nil); err != nil {
+		return err
+	}
+	return workflow.ExecuteActivity(ctx, "ChargeCard", id).Get(ctx, nil)
+}
+
+// ChargeCard charges the card of the order.
+func ChargeCard(ctx context.Context, id string) error { return nil }
+
+func ChargeCardFee(ctx context.Context, id string) error { return nil }
+
+func register(worker worker.Worker) {
+	worker.RegisterWorkflow(OrderWorkflow)
+	worker.RegisterActivityWithOptions(ChargeCard, activity.RegisterOptions{Name: "ChargeCard"})
+	worker.RegisterActivity(ChargeCardFee)
+}
+`
+
+const clientSource = `package api
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/client"
+)
+
+func start(c client.Client) error {
+	_, err := c.ExecuteWorkflow(context.Background(), client.StartWorkflowOptions{}, "OrderWorkflow", "1")
+	return err
+}
+`
+
+// analyzeTree writes the files of the test project and analyzes them.
+func analyzeTree(t *testing.T) (string, *analyzer.TemporalGraph, config.AnalysisOptions) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"orders/orders.go": ordersSource,
+		"api/start.go":     clientSource,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := config.AnalysisOptions{RootDir: dir}
+	graph, err := analyzer.NewAnalyzer(slog.New(slog.NewTextHandler(io.Discard, nil))).Analyze(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	return dir, graph, opts
+}
+
+func TestRenameActivity(t *testing.T) {
+	dir, graph, opts := analyzeTree(t)
+	node, err := FindNode(graph, "ChargeCard")
+	if err != nil {
+		t.Fatalf("FindNode failed: %v", err)
+	}
+	plan, err := NewPlan(context.Background(), graph, node, "CollectPayment", opts)
+	if err != nil {
+		t.Fatalf("NewPlan failed: %v", err)
+	}
+
+	want := []struct {
+		line int
+		kind string
+	}{
+		{12, KindCall},
+		{15, KindStringName},
+		{18, KindDefinition},
+		{19, KindDefinition},
+		{25, KindRegistration},
+		{25, KindStringName},
+	}
+	if len(plan.Edits) != len(want) {
+		t.Fatalf("Expected %d edits, got %+v", len(want), plan.Edits)
+	}
+	for i, w := range want {
+		if e := plan.Edits[i]; e.Line != w.line || e.Kind != w.kind {
+			t.Errorf("Edit %d = %s at line %d, want %s at line %d", i, e.Kind, e.Line, w.kind, w.line)
+		}
+	}
+
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "orders/orders.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSource := `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func OrderWorkflow(ctx workflow.Context, id string) error {
+	if err := workflow.ExecuteActivity(ctx, CollectPayment, id).Get(ctx, nil); err != nil {
+		return err
+	}
+	return workflow.ExecuteActivity(ctx, "CollectPayment", id).Get(ctx, nil)
+}
+
+// CollectPayment charges the card of the order.
+func CollectPayment(ctx context.Context, id string) error { return nil }
+
+func ChargeCardFee(ctx context.Context, id string) error { return nil }
+
+func register(worker worker.Worker) {
+	worker.RegisterWorkflow(OrderWorkflow)
+	worker.RegisterActivityWithOptions(CollectPayment, activity.RegisterOptions{Name: "CollectPayment"})
+	worker.RegisterActivity(ChargeCardFee)
+}
+`
+	if string(got) != wantSource {
+		t.Errorf("Renamed source:\n%s\nwant:\n%s", got, wantSource)
+	}
+
+	// The applied plan no longer matches the files
+	if err := plan.Apply(); err == nil {
+		t.Error("Expected an error applying a plan to changed files")
+	}
+}
+
+func TestRenameWorkflowStartedByName(t *testing.T) {
+	_, graph, opts := analyzeTree(t)
+	node, err := FindNode(graph, "OrderWorkflow")
+	if err != nil {
+		t.Fatalf("FindNode failed: %v", err)
+	}
+	plan, err := NewPlan(context.Background(), graph, node, "PlaceOrderWorkflow", opts)
+	if err != nil {
+		t.Fatalf("NewPlan failed: %v", err)
+	}
+	if len(plan.Edits) != 3 || plan.Files() != 2 {
+		t.Fatalf("Expected 3 edits in 2 files, got %+v", plan.Edits)
+	}
+	if e := plan.Edits[0]; filepath.Base(e.FilePath) != "start.go" || e.Kind != KindStringName || e.New != `"PlaceOrderWorkflow"` {
+		t.Errorf("Expected the client start by name to be renamed, got %+v", e)
+	}
+}
+
+func TestRenameErrors(t *testing.T) {
+	_, graph, opts := analyzeTree(t)
+	if _, err := FindNode(graph, "RefundCard"); err == nil {
+		t.Error("Expected an error for an unknown node")
+	}
+
+	node, err := FindNode(graph, "ChargeCard")
+	if err != nil {
+		t.Fatalf("FindNode failed: %v", err)
+	}
+	for _, to := range []string{"Collect-Payment", "ChargeCard", "ChargeCardFee"} {
+		if _, err := NewPlan(context.Background(), graph, node, to, opts); err == nil {
+			t.Errorf("Expected an error renaming ChargeCard to %s", to)
+		}
+	}
+}
@@ -0,0 +1,457 @@
+// Package replay checks workflow code for non-determinism by replaying recorded
+// workflow histories against the analyzed code with worker.WorkflowReplayer.
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+)
+
+// GeneratedFileName is the name of the replay test file written into each workflow package.
+const GeneratedFileName = "temporal_analyzer_replay_test.go"
+
+// TestName is the name of the generated replay test function.
+const TestName = "TestTemporalAnalyzerReplay"
+
+// failureMarker prefixes failure lines emitted by the generated test so they can be parsed back.
+const failureMarker = "TEMPORAL_ANALYZER_REPLAY_FAIL"
+
+// History is a recorded workflow history file.
+type History struct {
+	Path         string `json:"path"`
+	WorkflowType string `json:"workflowType"`
+}
+
+// Failure is a replay failure mapped back to a workflow node.
+type Failure struct {
+	WorkflowType string `json:"workflowType"`
+	HistoryPath  string `json:"historyPath"`
+	Error        string `json:"error"`
+	// NonDeterministic is true if the replayer reported a non-determinism error
+	NonDeterministic bool   `json:"nonDeterministic"`
+	NodeName         string `json:"nodeName,omitempty"`
+	FilePath         string `json:"filePath,omitempty"`
+	LineNumber       int    `json:"lineNumber,omitempty"`
+}
+
+// Result holds the outcome of a replay run.
+type Result struct {
+	Histories []History `json:"histories"`
+	// Unmatched lists histories whose workflow type has no replayable workflow in the graph
+	Unmatched      []History `json:"unmatched,omitempty"`
+	GeneratedFiles []string  `json:"generatedFiles"`
+	Failures       []Failure `json:"failures"`
+	// Output is the raw output of `go test` (empty when only generating)
+	Output string `json:"-"`
+}
+
+// Passed returns true if all replayed histories passed.
+func (r *Result) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// Options configures a replay run.
+type Options struct {
+	// HistoryDir is the directory containing workflow history JSON files
+	HistoryDir string
+	// GenerateOnly writes the replay test programs without running them
+	GenerateOnly bool
+	// KeepGenerated keeps the generated test files after running them
+	KeepGenerated bool
+}
+
+// Runner generates and runs replay test programs for analyzed workflows.
+type Runner struct {
+	logger *slog.Logger
+}
+
+// NewRunner creates a new replay runner.
+func NewRunner(logger *slog.Logger) *Runner {
+	return &Runner{
+		logger: logger,
+	}
+}
+
+// historyFile is the subset of the Temporal history JSON format needed to find the workflow type.
+type historyFile struct {
+	Events []struct {
+		EventType  string `json:"eventType"`
+		Attributes *struct {
+			WorkflowType struct {
+				Name string `json:"name"`
+			} `json:"workflowType"`
+		} `json:"workflowExecutionStartedEventAttributes"`
+	} `json:"events"`
+}
+
+// LoadHistories finds workflow history JSON files in a directory and reads their workflow types.
+func LoadHistories(dir string) ([]History, error) {
+	var histories []History
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read history %s: %w", path, err)
+		}
+
+		var hf historyFile
+		if err := json.Unmarshal(data, &hf); err != nil {
+			return fmt.Errorf("failed to parse history %s: %w", path, err)
+		}
+
+		workflowType := ""
+		for _, event := range hf.Events {
+			if event.Attributes != nil && event.Attributes.WorkflowType.Name != "" {
+				workflowType = event.Attributes.WorkflowType.Name
+				break
+			}
+		}
+		if workflowType == "" {
+			return fmt.Errorf("history %s has no WorkflowExecutionStarted event", path)
+		}
+
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve history path %s: %w", path, err)
+		}
+
+		histories = append(histories, History{Path: absPath, WorkflowType: workflowType})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
+
+// replayPackage groups the workflows and histories replayed by one generated test file.
+type replayPackage struct {
+	Dir       string
+	Package   string
+	Workflows []string
+	Histories []History
+}
+
+// Run generates a replay test in every package that defines a workflow with a recorded
+// history, runs it with `go test` (unless GenerateOnly is set), and maps failures to graph nodes.
+func (r *Runner) Run(ctx context.Context, graph *analyzer.TemporalGraph, opts Options) (*Result, error) {
+	histories, err := LoadHistories(opts.HistoryDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load histories: %w", err)
+	}
+
+	result := &Result{
+		Histories: histories,
+		Failures:  []Failure{},
+	}
+
+	packages := r.groupByPackage(graph, histories, result)
+
+	for _, pkg := range packages {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		default:
+		}
+
+		if err := r.replayPackage(ctx, pkg, opts, result); err != nil {
+			return result, err
+		}
+	}
+
+	if !opts.GenerateOnly && !opts.KeepGenerated {
+		result.GeneratedFiles = nil
+	}
+
+	MapFailures(result.Failures, graph)
+	return result, nil
+}
+
+// replayPackage generates the replay test of a package and, unless GenerateOnly is set, runs
+// it and adds its failures to result. The generated file is removed when replayPackage
+// returns, unless it is kept.
+func (r *Runner) replayPackage(ctx context.Context, pkg *replayPackage, opts Options, result *Result) error {
+	path := filepath.Join(pkg.Dir, GeneratedFileName)
+	if err := r.writeTestFile(path, pkg); err != nil {
+		return err
+	}
+	result.GeneratedFiles = append(result.GeneratedFiles, path)
+	r.logger.Info("Generated replay test", "file", path, "workflows", len(pkg.Workflows), "histories", len(pkg.Histories))
+
+	if opts.GenerateOnly {
+		return nil
+	}
+	if !opts.KeepGenerated {
+		defer func() {
+			if err := os.Remove(path); err != nil {
+				r.logger.Warn("Failed to remove generated replay test", "file", path, "error", err)
+			}
+		}()
+	}
+
+	output, runErr := r.runTest(ctx, pkg.Dir)
+	result.Output += output
+
+	failures := ParseFailures(output)
+	if runErr != nil && len(failures) == 0 {
+		// The test did not get to replay anything (e.g. compile error)
+		return fmt.Errorf("failed to run replay test in %s: %w\n%s", pkg.Dir, runErr, output)
+	}
+	result.Failures = append(result.Failures, failures...)
+	return nil
+}
+
+// groupByPackage matches histories to workflow nodes and groups them by package directory.
+// Only plain functions can be registered by the generated program; method workflows are skipped.
+func (r *Runner) groupByPackage(graph *analyzer.TemporalGraph, histories []History, result *Result) []*replayPackage {
+	byDir := make(map[string]*replayPackage)
+
+	for _, history := range histories {
+		node := findWorkflow(graph, history.WorkflowType)
+		if node == nil || node.FilePath == "" || strings.Contains(node.Name, ".") {
+			result.Unmatched = append(result.Unmatched, history)
+			continue
+		}
+
+		dir := filepath.Dir(node.FilePath)
+		pkg, ok := byDir[dir]
+		if !ok {
+			pkg = &replayPackage{Dir: dir, Package: node.Package}
+			byDir[dir] = pkg
+		}
+		if !containsString(pkg.Workflows, node.Name) {
+			pkg.Workflows = append(pkg.Workflows, node.Name)
+		}
+		pkg.Histories = append(pkg.Histories, history)
+	}
+
+	dirs := make([]string, 0, len(byDir))
+	for dir := range byDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	packages := make([]*replayPackage, 0, len(dirs))
+	for _, dir := range dirs {
+		sort.Strings(byDir[dir].Workflows)
+		packages = append(packages, byDir[dir])
+	}
+	return packages
+}
+
+// generatedHeader starts every generated replay test, telling it from a file of the package.
+const generatedHeader = "// Code generated by temporal-analyzer replay. DO NOT EDIT.\n"
+
+// testTemplate is the replay test program written into each workflow package.
+var testTemplate = template.Must(template.New("replay").Parse(generatedHeader + `
+
+package {{.Package}}
+
+import (
+	"testing"
+
+	"go.temporal.io/sdk/worker"
+)
+
+func ` + TestName + `(t *testing.T) {
+	replayer := worker.NewWorkflowReplayer()
+{{- range .Workflows}}
+	replayer.RegisterWorkflow({{.}})
+{{- end}}
+
+	histories := []struct{ workflowType, path string }{
+{{- range .Histories}}
+		{{"{"}}{{printf "%q" .WorkflowType}}, {{printf "%q" .Path}}},
+{{- end}}
+	}
+
+	for _, h := range histories {
+		if err := replayer.ReplayWorkflowHistoryFromJSONFile(nil, h.path); err != nil {
+			t.Errorf("` + failureMarker + `\t%s\t%s\t%v", h.workflowType, h.path, err)
+		}
+	}
+}
+`))
+
+// Generate renders the replay test program for a package.
+func Generate(pkg string, workflows []string, histories []History) ([]byte, error) {
+	var buf bytes.Buffer
+	err := testTemplate.Execute(&buf, replayPackage{Package: pkg, Workflows: workflows, Histories: histories})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render replay test: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeTestFile writes the generated replay test for a package. It refuses to overwrite a
+// file other than one generated by an earlier run, such as a kept replay test.
+func (r *Runner) writeTestFile(path string, pkg *replayPackage) error {
+	src, err := Generate(pkg.Package, pkg.Workflows, pkg.Histories)
+	if err != nil {
+		return err
+	}
+	flag := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if existing, err := os.ReadFile(path); err == nil {
+		if !bytes.HasPrefix(existing, []byte(generatedHeader)) {
+			return fmt.Errorf("refusing to overwrite %s: the file exists and was not generated by replay", path)
+		}
+		flag = os.O_WRONLY | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flag, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write replay test %s: %w", path, err)
+	}
+	_, err = f.Write(src)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write replay test %s: %w", path, err)
+	}
+	return nil
+}
+
+// runTest runs the generated replay test in a package directory.
+func (r *Runner) runTest(ctx context.Context, dir string) (string, error) {
+	cmd := exec.CommandContext(ctx, "go", "test", "-count=1", "-run", "^"+TestName+"$", ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// ParseFailures extracts replay failures from the output of the generated test.
+func ParseFailures(output string) []Failure {
+	var failures []Failure
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		idx := strings.Index(line, failureMarker+"\t")
+		if idx < 0 {
+			continue
+		}
+
+		parts := strings.SplitN(line[idx+len(failureMarker)+1:], "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		failures = append(failures, Failure{
+			WorkflowType:     parts[0],
+			HistoryPath:      parts[1],
+			Error:            parts[2],
+			NonDeterministic: isNonDeterminismError(parts[2]),
+		})
+	}
+
+	return failures
+}
+
+// isNonDeterminismError reports whether a replay error message describes non-determinism.
+func isNonDeterminismError(msg string) bool {
+	lower := strings.ToLower(msg)
+	return strings.Contains(lower, "nondeterministic") || strings.Contains(lower, "non-deterministic") ||
+		strings.Contains(lower, "nondeterminism")
+}
+
+// WriteText writes a human-readable replay report using the given symbols.
+func WriteText(w io.Writer, result *Result, set glyphs.Set) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("Replayed %d history file(s)\n", len(result.Histories)-len(result.Unmatched))
+
+	for _, h := range result.Unmatched {
+		printf("  %s skipped %s: no replayable workflow %q found\n", set.Warning, h.Path, h.WorkflowType)
+	}
+
+	for _, f := range result.GeneratedFiles {
+		printf("  generated %s\n", f)
+	}
+
+	for _, f := range result.Failures {
+		kind := "replay error"
+		if f.NonDeterministic {
+			kind = "non-determinism"
+		}
+		location := f.WorkflowType
+		if f.FilePath != "" {
+			location = fmt.Sprintf("%s (%s:%d)", f.NodeName, f.FilePath, f.LineNumber)
+		}
+		printf("  %s %s: %s\n", set.Error, location, kind)
+		printf("      history: %s\n", f.HistoryPath)
+		printf("      %s\n", f.Error)
+	}
+
+	if result.Passed() {
+		printf("No replay failures\n")
+	} else {
+		printf("%d replay failure(s)\n", len(result.Failures))
+	}
+
+	return err
+}
+
+// MapFailures fills in node locations for failures from the graph.
+func MapFailures(failures []Failure, graph *analyzer.TemporalGraph) {
+	for i := range failures {
+		node := findWorkflow(graph, failures[i].WorkflowType)
+		if node == nil {
+			continue
+		}
+		failures[i].NodeName = node.Name
+		failures[i].FilePath = node.FilePath
+		failures[i].LineNumber = node.LineNumber
+	}
+}
+
+// findWorkflow finds the workflow node for a registered workflow type name.
+// Falls back to matching the method name of qualified (Receiver.Method) nodes.
+func findWorkflow(graph *analyzer.TemporalGraph, workflowType string) *analyzer.TemporalNode {
+	if node, ok := graph.Nodes[workflowType]; ok && node.Type == "workflow" {
+		return node
+	}
+	for name, node := range graph.Nodes {
+		if node.Type == "workflow" && strings.HasSuffix(name, "."+workflowType) {
+			return node
+		}
+	}
+	return nil
+}
+
+// containsString reports whether a slice contains a string.
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,243 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+)
+
+func writeHistory(t *testing.T, dir, name, workflowType string) string {
+	t.Helper()
+	content := `{"events":[{"eventId":"1","eventType":"EVENT_TYPE_WORKFLOW_EXECUTION_STARTED",` +
+		`"workflowExecutionStartedEventAttributes":{"workflowType":{"name":"` + workflowType + `"}}}]}`
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write history: %v", err)
+	}
+	return path
+}
+
+func TestLoadHistories(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeHistory(t, tmpDir, "order.json", "OrderWorkflow")
+	if err := os.WriteFile(filepath.Join(tmpDir, "notes.txt"), []byte("ignored"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	histories, err := LoadHistories(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadHistories failed: %v", err)
+	}
+	if len(histories) != 1 {
+		t.Fatalf("Expected 1 history, got %d", len(histories))
+	}
+	if histories[0].WorkflowType != "OrderWorkflow" {
+		t.Errorf("WorkflowType = %q, want OrderWorkflow", histories[0].WorkflowType)
+	}
+	if !filepath.IsAbs(histories[0].Path) {
+		t.Errorf("Expected absolute path, got %q", histories[0].Path)
+	}
+}
+
+func TestLoadHistoriesInvalid(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "bad.json"), []byte(`{"events":[]}`), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if _, err := LoadHistories(tmpDir); err == nil {
+		t.Error("Expected error for history without WorkflowExecutionStarted event")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate("orders", []string{"OrderWorkflow"}, []History{
+		{Path: "/tmp/order.json", WorkflowType: "OrderWorkflow"},
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	out := string(src)
+	for _, want := range []string{
+		"package orders",
+		"func " + TestName + "(t *testing.T)",
+		"replayer.RegisterWorkflow(OrderWorkflow)",
+		`{"OrderWorkflow", "/tmp/order.json"}`,
+		"ReplayWorkflowHistoryFromJSONFile",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Generated test missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestParseFailures(t *testing.T) {
+	output := `--- FAIL: TestTemporalAnalyzerReplay (0.01s)
+    temporal_analyzer_replay_test.go:20: ` + failureMarker + "\tOrderWorkflow\t/tmp/order.json\tnondeterministic workflow definition: history mismatch\n" +
+		"    temporal_analyzer_replay_test.go:20: " + failureMarker + "\tRefundWorkflow\t/tmp/refund.json\tunable to find workflow type\n" +
+		"FAIL\n"
+
+	failures := ParseFailures(output)
+	if len(failures) != 2 {
+		t.Fatalf("Expected 2 failures, got %d", len(failures))
+	}
+	if failures[0].WorkflowType != "OrderWorkflow" || failures[0].HistoryPath != "/tmp/order.json" {
+		t.Errorf("Unexpected first failure: %+v", failures[0])
+	}
+	if !failures[0].NonDeterministic {
+		t.Error("Expected first failure to be non-deterministic")
+	}
+	if failures[1].NonDeterministic {
+		t.Error("Expected second failure not to be non-deterministic")
+	}
+}
+
+func TestMapFailures(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow":     {Name: "OrderWorkflow", Type: "workflow", FilePath: "orders/workflow.go", LineNumber: 12},
+			"*Workflows.Refund": {Name: "*Workflows.Refund", Type: "workflow", FilePath: "refunds/workflow.go", LineNumber: 30},
+			"ChargeActivity":    {Name: "ChargeActivity", Type: "activity"},
+		},
+	}
+
+	failures := []Failure{
+		{WorkflowType: "OrderWorkflow"},
+		{WorkflowType: "Refund"},
+		{WorkflowType: "ChargeActivity"},
+	}
+	MapFailures(failures, graph)
+
+	if failures[0].FilePath != "orders/workflow.go" || failures[0].LineNumber != 12 {
+		t.Errorf("Expected OrderWorkflow mapped, got %+v", failures[0])
+	}
+	if failures[1].NodeName != "*Workflows.Refund" {
+		t.Errorf("Expected method workflow mapped by name, got %+v", failures[1])
+	}
+	if failures[2].NodeName != "" {
+		t.Errorf("Activities should not be mapped, got %+v", failures[2])
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	result := &Result{
+		Histories: []History{{Path: "/tmp/order.json", WorkflowType: "OrderWorkflow"}},
+		Failures: []Failure{{
+			WorkflowType:     "OrderWorkflow",
+			HistoryPath:      "/tmp/order.json",
+			Error:            "nondeterministic workflow definition",
+			NonDeterministic: true,
+			NodeName:         "OrderWorkflow",
+			FilePath:         "orders/workflow.go",
+			LineNumber:       12,
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteText(&buf, result, glyphs.Unicode); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"orders/workflow.go:12", "non-determinism", "1 replay failure(s)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunGenerateOnly(t *testing.T) {
+	srcDir := t.TempDir()
+	historyDir := t.TempDir()
+	writeHistory(t, historyDir, "order.json", "OrderWorkflow")
+	writeHistory(t, historyDir, "refund.json", "Refund")
+	writeHistory(t, historyDir, "unknown.json", "UnknownWorkflow")
+
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow":     {Name: "OrderWorkflow", Type: "workflow", Package: "orders", FilePath: filepath.Join(srcDir, "workflow.go")},
+			"*Workflows.Refund": {Name: "*Workflows.Refund", Type: "workflow", Package: "orders", FilePath: filepath.Join(srcDir, "workflow.go")},
+		},
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	runner := NewRunner(logger)
+
+	result, err := runner.Run(context.Background(), graph, Options{HistoryDir: historyDir, GenerateOnly: true})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(result.GeneratedFiles) != 1 {
+		t.Fatalf("Expected 1 generated file, got %d", len(result.GeneratedFiles))
+	}
+	if _, err := os.Stat(filepath.Join(srcDir, GeneratedFileName)); err != nil {
+		t.Errorf("Expected generated test file: %v", err)
+	}
+	if len(result.Unmatched) != 2 {
+		t.Errorf("Expected method and unknown workflows to be unmatched, got %d", len(result.Unmatched))
+	}
+	if !result.Passed() {
+		t.Error("Generate-only run should not report failures")
+	}
+}
+
+func TestRunExistingTestFile(t *testing.T) {
+	srcDir := t.TempDir()
+	historyDir := t.TempDir()
+	writeHistory(t, historyDir, "order.json", "OrderWorkflow")
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders", FilePath: filepath.Join(srcDir, "workflow.go")},
+		},
+	}
+	runner := NewRunner(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+	path := filepath.Join(srcDir, GeneratedFileName)
+
+	// A replay test kept by an earlier run is regenerated
+	for i := 0; i < 2; i++ {
+		if _, err := runner.Run(context.Background(), graph, Options{HistoryDir: historyDir, GenerateOnly: true}); err != nil {
+			t.Fatalf("Run %d failed: %v", i+1, err)
+		}
+	}
+
+	// A file of the package is left alone
+	userFile := "package orders\n\n// Written by hand\n"
+	if err := os.WriteFile(path, []byte(userFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runner.Run(context.Background(), graph, Options{HistoryDir: historyDir}); err == nil || !strings.Contains(err.Error(), "refusing to overwrite") {
+		t.Errorf("Expected the existing file to be refused, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != userFile {
+		t.Errorf("Expected the existing file unchanged, got:\n%s", data)
+	}
+}
+
+func TestRunRemovesGeneratedFile(t *testing.T) {
+	// Outside a module the generated test fails to build
+	srcDir := t.TempDir()
+	historyDir := t.TempDir()
+	writeHistory(t, historyDir, "order.json", "OrderWorkflow")
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders", FilePath: filepath.Join(srcDir, "workflow.go")},
+		},
+	}
+	runner := NewRunner(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+
+	if _, err := runner.Run(context.Background(), graph, Options{HistoryDir: historyDir}); err == nil {
+		t.Fatal("Expected the replay test to fail to build")
+	}
+	if _, err := os.Stat(filepath.Join(srcDir, GeneratedFileName)); !os.IsNotExist(err) {
+		t.Errorf("Expected the generated test removed after a failed run, got %v", err)
+	}
+}
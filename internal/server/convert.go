@@ -0,0 +1,171 @@
+package server
+
+import (
+	analyzerv1 "github.com/ikari-pl/go-temporalio-analyzer/gen/temporalanalyzer/v1"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+// progressEvent converts an analysis progress report. Done counts the nodes found; totals are
+// not known in advance.
+func progressEvent(p analyzer.Progress) *analyzerv1.AnalyzeEvent {
+	return &analyzerv1.AnalyzeEvent{Event: &analyzerv1.AnalyzeEvent_Progress{Progress: &analyzerv1.Progress{
+		Stage: p.Stage,
+		Done:  int32(p.NodesFound),
+	}}}
+}
+
+// packageEvent converts the result of a parsed package to a parse progress report naming the
+// package, with Done counting the files scanned so far.
+func packageEvent(r analyzer.PackageResult) *analyzerv1.AnalyzeEvent {
+	return &analyzerv1.AnalyzeEvent{Event: &analyzerv1.AnalyzeEvent_Progress{Progress: &analyzerv1.Progress{
+		Stage:   analyzer.StageParse,
+		Message: r.Package,
+		Done:    int32(r.FilesScanned),
+	}}}
+}
+
+// graphToProto converts a graph to its protobuf message.
+func graphToProto(graph *analyzer.TemporalGraph) *analyzerv1.Graph {
+	msg := &analyzerv1.Graph{
+		Nodes: make(map[string]*analyzerv1.Node, len(graph.Nodes)),
+		Stats: &analyzerv1.GraphStats{
+			TotalWorkflows:   int32(graph.Stats.TotalWorkflows),
+			TotalActivities:  int32(graph.Stats.TotalActivities),
+			TotalSignals:     int32(graph.Stats.TotalSignals),
+			TotalQueries:     int32(graph.Stats.TotalQueries),
+			TotalUpdates:     int32(graph.Stats.TotalUpdates),
+			TotalTimers:      int32(graph.Stats.TotalTimers),
+			MaxDepth:         int32(graph.Stats.MaxDepth),
+			OrphanNodes:      int32(graph.Stats.OrphanNodes),
+			CircularDeps:     int32(graph.Stats.CircularDeps),
+			TotalConnections: int32(graph.Stats.TotalConnections),
+			AvgFanOut:        graph.Stats.AvgFanOut,
+			MaxFanOut:        int32(graph.Stats.MaxFanOut),
+			CrossDomainCalls: int32(graph.Stats.CrossDomainCalls),
+		},
+	}
+	for name, node := range graph.Nodes {
+		msg.Nodes[name] = nodeToProto(node)
+	}
+	for _, w := range graph.Workers {
+		msg.Workers = append(msg.Workers, &analyzerv1.WorkerConfig{
+			TaskQueue:                               w.TaskQueue,
+			FilePath:                                w.FilePath,
+			LineNumber:                              int32(w.LineNumber),
+			MaxConcurrentActivityExecutionSize:      int32(w.MaxConcurrentActivityExecutionSize),
+			MaxConcurrentLocalActivityExecutionSize: int32(w.MaxConcurrentLocalActivityExecutionSize),
+			MaxConcurrentWorkflowTaskExecutionSize:  int32(w.MaxConcurrentWorkflowTaskExecutionSize),
+			WorkerActivitiesPerSecond:               w.WorkerActivitiesPerSecond,
+			TaskQueueActivitiesPerSecond:            w.TaskQueueActivitiesPerSecond,
+			MaxConcurrentActivityTaskPollers:        int32(w.MaxConcurrentActivityTaskPollers),
+			MaxConcurrentWorkflowTaskPollers:        int32(w.MaxConcurrentWorkflowTaskPollers),
+			StickyCacheSize:                         int32(w.StickyCacheSize),
+			StickyScheduleToStartTimeout:            w.StickyScheduleToStartTimeout,
+			Interceptors:                            w.Interceptors,
+			Workflows:                               w.Workflows,
+			Activities:                              w.Activities,
+		})
+	}
+	return msg
+}
+
+func nodeToProto(node *analyzer.TemporalNode) *analyzerv1.Node {
+	msg := &analyzerv1.Node{
+		Name:           node.Name,
+		Type:           node.Type,
+		Package:        node.Package,
+		Domain:         node.Domain,
+		FilePath:       node.FilePath,
+		LineNumber:     int32(node.LineNumber),
+		Description:    node.Description,
+		Annotations:    node.Annotations,
+		Parameters:     node.Parameters,
+		ReturnType:     node.ReturnType,
+		Parents:        node.Parents,
+		ActivityOpts:   activityOptionsToProto(node.ActivityOpts),
+		ParameterTypes: node.ParameterTypes,
+		ReturnTypes:    node.ReturnTypes,
+	}
+	for _, call := range node.CallSites {
+		msg.CallSites = append(msg.CallSites, &analyzerv1.CallSite{
+			TargetName:         call.TargetName,
+			TargetType:         call.TargetType,
+			CallType:           call.CallType,
+			FilePath:           call.FilePath,
+			LineNumber:         int32(call.LineNumber),
+			Options:            call.Options,
+			ArgumentCount:      int32(call.ArgumentCount),
+			ArgumentTypes:      call.ArgumentTypes,
+			ResultType:         call.ResultType,
+			ParsedActivityOpts: activityOptionsToProto(call.ParsedActivityOpts),
+		})
+	}
+	for _, s := range node.Signals {
+		msg.Signals = append(msg.Signals, &analyzerv1.Handler{Name: s.Name, Handler: s.Handler, PayloadType: s.PayloadType, LineNumber: int32(s.LineNumber)})
+	}
+	for _, q := range node.Queries {
+		msg.Queries = append(msg.Queries, &analyzerv1.Handler{Name: q.Name, Handler: q.Handler, ReturnType: q.ReturnType, LineNumber: int32(q.LineNumber)})
+	}
+	for _, u := range node.Updates {
+		msg.Updates = append(msg.Updates, &analyzerv1.Handler{Name: u.Name, Handler: u.Handler, ReturnType: u.ReturnType, LineNumber: int32(u.LineNumber)})
+	}
+	for _, t := range node.Tests {
+		msg.Tests = append(msg.Tests, &analyzerv1.TestReference{TestName: t.TestName, Kind: t.Kind, FilePath: t.FilePath, LineNumber: int32(t.LineNumber)})
+	}
+	return msg
+}
+
+func activityOptionsToProto(opts *analyzer.ActivityOptions) *analyzerv1.ActivityOptions {
+	if opts == nil {
+		return nil
+	}
+	msg := &analyzerv1.ActivityOptions{
+		TaskQueue:              opts.TaskQueue,
+		ScheduleToStartTimeout: opts.ScheduleToStartTimeout,
+		StartToCloseTimeout:    opts.StartToCloseTimeout,
+		HeartbeatTimeout:       opts.HeartbeatTimeout,
+		ScheduleToCloseTimeout: opts.ScheduleToCloseTimeout,
+		WaitForCancellation:    opts.WaitForCancellation,
+		InheritedFrom:          opts.InheritedFrom,
+		CallerDependent:        opts.CallerDependent,
+	}
+	if rp := opts.RetryPolicy; rp != nil {
+		msg.RetryPolicy = &analyzerv1.RetryPolicy{
+			InitialInterval:    rp.InitialInterval,
+			BackoffCoefficient: rp.BackoffCoefficient,
+			MaximumInterval:    rp.MaximumInterval,
+			MaximumAttempts:    int32(rp.MaximumAttempts),
+			NonRetryableErrors: rp.NonRetryableErrors,
+		}
+	}
+	return msg
+}
+
+// lintResultToProto converts a lint result to its protobuf message.
+func lintResultToProto(result *lint.Result) *analyzerv1.LintResult {
+	msg := &analyzerv1.LintResult{
+		ErrorCount:   int32(result.ErrorCount),
+		WarningCount: int32(result.WarnCount),
+		InfoCount:    int32(result.InfoCount),
+		TotalNodes:   int32(result.TotalNodes),
+		ExitCode:     int32(result.ExitCode),
+	}
+	for _, issue := range result.Issues {
+		msg.Issues = append(msg.Issues, &analyzerv1.Issue{
+			RuleId:      issue.RuleID,
+			RuleName:    issue.RuleName,
+			Severity:    string(issue.Severity),
+			Category:    string(issue.Category),
+			Message:     issue.Message,
+			Description: issue.Description,
+			Suggestion:  issue.Suggestion,
+			FilePath:    issue.FilePath,
+			LineNumber:  int32(issue.LineNumber),
+			EndLine:     int32(issue.EndLine),
+			NodeName:    issue.NodeName,
+			NodeType:    issue.NodeType,
+		})
+	}
+	return msg
+}
@@ -0,0 +1,183 @@
+// Package server serves analysis results over gRPC, following the AnalyzerService of
+// proto/temporalanalyzer/v1/analyzer.proto.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	analyzerv1 "github.com/ikari-pl/go-temporalio-analyzer/gen/temporalanalyzer/v1"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+// StageLint is the progress stage reported while the linter runs.
+const StageLint = "lint"
+
+// Server implements AnalyzerService with an Analyzer.
+type Server struct {
+	analyzerv1.UnimplementedAnalyzerServiceServer
+
+	logger   *slog.Logger
+	analyzer analyzer.Analyzer
+}
+
+// New creates a Server that analyzes requests with a.
+func New(logger *slog.Logger, a analyzer.Analyzer) *Server {
+	return &Server{logger: logger, analyzer: a}
+}
+
+// Serve registers the service on a new gRPC server and serves connections on lis until
+// ctx is cancelled, then stops gracefully.
+func Serve(ctx context.Context, lis net.Listener, s *Server) error {
+	grpcServer := grpc.NewServer()
+	analyzerv1.RegisterAnalyzerServiceServer(grpcServer, s)
+
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			grpcServer.GracefulStop()
+		case <-stopped:
+		}
+	}()
+	defer close(stopped)
+
+	if err := grpcServer.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("gRPC server failed: %w", err)
+	}
+	return nil
+}
+
+// Analyze implements AnalyzerService. Progress events are streamed as each package is parsed
+// and as the analysis reaches each later stage, followed by one result event.
+func (s *Server) Analyze(req *analyzerv1.AnalyzeRequest, stream grpc.ServerStreamingServer[analyzerv1.AnalyzeEvent]) error {
+	opts, err := analysisOptions(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx := stream.Context()
+	s.logger.Info("Analyzing for gRPC client", "root_dir", opts.RootDir, "lint", req.GetLint())
+
+	// Progress is sent from this goroutine only, as streams are not safe for concurrent sends.
+	// Both channels are sent to by the analysis one event at a time, so events keep their order.
+	progress := make(chan analyzer.Progress)
+	packages := make(chan analyzer.PackageResult)
+	type outcome struct {
+		graph *analyzer.TemporalGraph
+		err   error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		graph, err := s.analyzer.AnalyzeStream(analyzer.WithProgress(ctx, progress), opts, func(r analyzer.PackageResult) {
+			select {
+			case packages <- r:
+			case <-ctx.Done():
+			}
+		})
+		done <- outcome{graph, err}
+		close(progress)
+		close(packages)
+	}()
+	var sendErr error
+	for progress != nil || packages != nil {
+		var ev *analyzerv1.AnalyzeEvent
+		select {
+		case p, ok := <-progress:
+			if !ok {
+				progress = nil
+				continue
+			}
+			ev = progressEvent(p)
+		case r, ok := <-packages:
+			if !ok {
+				packages = nil
+				continue
+			}
+			ev = packageEvent(r)
+		}
+		// After a failed send, keep draining so the analysis can observe the cancelled
+		// context and finish
+		if sendErr == nil {
+			sendErr = stream.Send(ev)
+		}
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+
+	res := <-done
+	if res.err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Errorf(codes.Internal, "analysis failed: %v", res.err)
+	}
+
+	result := &analyzerv1.AnalyzeResult{Graph: graphToProto(res.graph)}
+	if req.GetLint() {
+		if err := stream.Send(progressEvent(analyzer.Progress{Stage: StageLint, NodesFound: len(res.graph.Nodes)})); err != nil {
+			return err
+		}
+		result.Lint = lintResultToProto(lint.NewLinter(lintConfig(req.GetLintOptions())).Run(ctx, res.graph))
+	}
+
+	return stream.Send(&analyzerv1.AnalyzeEvent{Event: &analyzerv1.AnalyzeEvent_Result{Result: result}})
+}
+
+// analysisOptions converts a request to analyzer options.
+func analysisOptions(req *analyzerv1.AnalyzeRequest) (config.AnalysisOptions, error) {
+	if req.GetRootDir() == "" {
+		return config.AnalysisOptions{}, fmt.Errorf("root_dir is required")
+	}
+	if info, err := os.Stat(req.GetRootDir()); err != nil || !info.IsDir() {
+		return config.AnalysisOptions{}, fmt.Errorf("root directory does not exist: %s", req.GetRootDir())
+	}
+	domains, err := config.ParseDomains(strings.Join(req.GetDomains(), ","))
+	if err != nil {
+		return config.AnalysisOptions{}, err
+	}
+
+	return config.AnalysisOptions{
+		RootDir:      req.GetRootDir(),
+		ExcludeDirs:  req.GetExcludeDirs(),
+		IncludeTests: req.GetIncludeTests(),
+		Query:        req.GetQuery(),
+		Domains:      domains,
+	}, nil
+}
+
+// lintConfig converts request lint options to a linter configuration, starting from the defaults.
+func lintConfig(opts *analyzerv1.LintOptions) *lint.Config {
+	cfg := lint.DefaultConfig()
+	if opts == nil {
+		return cfg
+	}
+
+	switch opts.GetMinSeverity() {
+	case "error":
+		cfg.MinSeverity = lint.SeverityError
+	case "warning":
+		cfg.MinSeverity = lint.SeverityWarning
+	}
+	cfg.EnabledRules = opts.GetEnabledRules()
+	cfg.DisabledRules = opts.GetDisabledRules()
+	cfg.FailOnWarning = opts.GetStrict()
+	if opts.GetMaxFanOut() > 0 {
+		cfg.Thresholds.MaxFanOut = int(opts.GetMaxFanOut())
+	}
+	if opts.GetMaxCallDepth() > 0 {
+		cfg.Thresholds.MaxCallDepth = int(opts.GetMaxCallDepth())
+	}
+	return cfg
+}
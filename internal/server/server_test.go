@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	analyzerv1 "github.com/ikari-pl/go-temporalio-analyzer/gen/temporalanalyzer/v1"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+const testWorkflow = `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+func OrderWorkflow(ctx workflow.Context, orderID string) error {
+	return workflow.ExecuteActivity(ctx, ChargeCard, orderID).Get(ctx, nil)
+}
+
+func ChargeCard(ctx context.Context, orderID string) error {
+	return nil
+}
+`
+
+// startServer serves s on an in-memory listener and returns a connected client.
+func startServer(t *testing.T, s *Server) analyzerv1.AnalyzerServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- Serve(ctx, lis, s) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-served; err != nil {
+			t.Errorf("Serve() = %v", err)
+		}
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return analyzerv1.NewAnalyzerServiceClient(conn)
+}
+
+func TestAnalyzeStreamsProgressAndResult(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "workflow.go"), []byte(testWorkflow), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	client := startServer(t, New(logger, analyzer.NewAnalyzer(logger)))
+
+	stream, err := client.Analyze(context.Background(), &analyzerv1.AnalyzeRequest{RootDir: dir, Lint: true})
+	if err != nil {
+		t.Fatalf("Analyze() = %v", err)
+	}
+
+	var stages []string
+	var parsed []string
+	var result *analyzerv1.AnalyzeResult
+	for {
+		ev, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() = %v", err)
+		}
+		if result != nil {
+			t.Fatalf("Event after the result: %v", ev)
+		}
+		if p := ev.GetProgress(); p != nil {
+			stages = append(stages, p.GetStage())
+			if p.GetStage() == analyzer.StageParse {
+				parsed = append(parsed, p.GetMessage())
+			}
+		}
+		result = ev.GetResult()
+	}
+
+	if len(stages) == 0 || stages[len(stages)-1] != StageLint {
+		t.Errorf("Expected progress stages ending with lint, got %v", stages)
+	}
+	if len(parsed) != 1 || parsed[0] != dir {
+		t.Errorf("Expected a parse event naming the package, got %v", parsed)
+	}
+	if result == nil {
+		t.Fatal("Stream ended without a result")
+	}
+	if result.GetGraph().GetNodes()["OrderWorkflow"].GetType() != "workflow" {
+		t.Errorf("Expected OrderWorkflow in the graph, got %v", result.GetGraph().GetNodes())
+	}
+	if result.GetGraph().GetStats().GetTotalActivities() != 1 {
+		t.Errorf("Expected 1 activity, got %v", result.GetGraph().GetStats())
+	}
+	if result.GetLint().GetTotalNodes() != 2 {
+		t.Errorf("Expected a lint result for 2 nodes, got %v", result.GetLint())
+	}
+}
+
+func TestAnalyzeInvalidRequest(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	client := startServer(t, New(logger, analyzer.NewAnalyzer(logger)))
+
+	for _, req := range []*analyzerv1.AnalyzeRequest{
+		{},
+		{RootDir: filepath.Join(t.TempDir(), "missing")},
+		{RootDir: t.TempDir(), Domains: []string{"no-equals-sign"}},
+	} {
+		stream, err := client.Analyze(context.Background(), req)
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Analyze(%v) = %v, want InvalidArgument", req, err)
+		}
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	cfg := lintConfig(&analyzerv1.LintOptions{MinSeverity: "warning", DisabledRules: []string{"TA001"}, MaxFanOut: 3})
+	if cfg.MinSeverity != "warning" || len(cfg.DisabledRules) != 1 || cfg.Thresholds.MaxFanOut != 3 {
+		t.Errorf("Unexpected lint config: %+v", cfg)
+	}
+	if cfg.Thresholds.MaxCallDepth != 10 {
+		t.Errorf("Expected the default max call depth, got %d", cfg.Thresholds.MaxCallDepth)
+	}
+}
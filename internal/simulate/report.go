@@ -0,0 +1,121 @@
+package simulate
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var queueHeader = []string{"Task Queue", "Workers", "Slots", "Rate Limit", "Arrival/s", "Utilization", "Wait", "Backlog/s"}
+
+func (q *Queue) cells() []string {
+	cells := []string{q.TaskQueue, strconv.Itoa(q.Workers), strconv.Itoa(q.Slots), "", formatRate(q.Arrival), "", "", ""}
+	if q.RateLimit > 0 {
+		cells[3] = formatRate(q.RateLimit)
+	}
+	switch {
+	case q.Workers == 0:
+		cells[1], cells[2] = "0", "unknown"
+	case q.Saturated:
+		cells[5] = fmt.Sprintf("%.0f%%", q.Utilization*100)
+		cells[6] = "unbounded"
+		cells[7] = "+" + formatRate(q.BacklogGrowth)
+	default:
+		cells[5] = fmt.Sprintf("%.0f%%", q.Utilization*100)
+		cells[6] = formatDuration(q.Wait)
+	}
+	return cells
+}
+
+var activityHeader = []string{"Activity", "Task Queue", "Per Workflow", "Rate/s", "Duration", "Source"}
+
+func (a *Activity) cells() []string {
+	queue := a.TaskQueue
+	if a.Local {
+		queue = "(local)"
+	}
+	duration := ""
+	if a.DurationSource != SourceUnknown {
+		duration = formatDuration(a.Duration)
+	}
+	return []string{a.Name, queue, formatRate(a.PerWorkflow), formatRate(a.Rate), duration, a.DurationSource}
+}
+
+// WriteMarkdown writes the result as a markdown report: the estimated latency, then tables of
+// the task queues and activities, then the notes.
+func WriteMarkdown(w io.Writer, r *Result) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+	table := func(header []string, rows [][]string) {
+		printf("| %s |\n", strings.Join(header, " | "))
+		printf("|---|%s\n", strings.Repeat("---:|", len(header)-1))
+		for _, cells := range rows {
+			for i := range cells {
+				cells[i] = strings.ReplaceAll(cells[i], "|", "\\|")
+			}
+			printf("| %s |\n", strings.Join(cells, " | "))
+		}
+	}
+
+	replicas := "1 replica"
+	if r.Replicas != 1 {
+		replicas = fmt.Sprintf("%d replicas", r.Replicas)
+	}
+	printf("# Load simulation: %s at %s starts/s (%s per worker)\n\n", r.Workflow, formatRate(r.RPS), replicas)
+	if r.Saturated {
+		var saturated []string
+		for _, q := range r.Queues {
+			if q.Saturated {
+				saturated = append(saturated, q.TaskQueue)
+			}
+		}
+		printf("Estimated latency: unbounded, the backlog of %s grows without limit\n\n", strings.Join(saturated, ", "))
+	} else {
+		printf("Estimated latency: %s\n\n", formatDuration(r.Latency))
+	}
+
+	var rows [][]string
+	for _, q := range r.Queues {
+		rows = append(rows, q.cells())
+	}
+	printf("## Task Queues\n\n")
+	table(queueHeader, rows)
+
+	rows = nil
+	for _, a := range r.Activities {
+		rows = append(rows, a.cells())
+	}
+	printf("\n## Activities\n\n")
+	table(activityHeader, rows)
+
+	if len(r.Notes) > 0 {
+		printf("\n## Notes\n\n")
+		for _, note := range r.Notes {
+			printf("- %s\n", note)
+		}
+	}
+	return err
+}
+
+// formatRate formats a rate or count with at most two decimals, e.g. 12.5.
+func formatRate(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+// formatDuration rounds a duration for display, e.g. 1.25s, 310ms or 905µs.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Microsecond).String()
+}
@@ -0,0 +1,422 @@
+// Package simulate estimates the task queue load a workflow start rate puts on the analyzed
+// workers, for capacity planning from the static graph.
+package simulate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// durationAnnotation is the annotation giving an activity's typical duration, e.g. @duration 10m
+// in a doc comment or duration: 10m in the metadata overlay, as exported from metrics.
+const durationAnnotation = "duration"
+
+// Duration sources, from the most to the least accurate.
+const (
+	SourceAnnotation = "annotation" // @duration hint or metadata overlay
+	SourceTimeout    = "timeout"    // StartToCloseTimeout or ScheduleToCloseTimeout, an upper bound
+	SourceUnknown    = "unknown"    // no hint and no constant timeout: the activity adds no load
+)
+
+// Options configures a simulation.
+type Options struct {
+	// Workflow is the started workflow, by graph key or name
+	Workflow string
+	// RPS is the number of workflows started per second
+	RPS float64
+	// Replicas is the number of processes running each analyzed worker (0 means 1)
+	Replicas int
+}
+
+// Activity is the load the simulated start rate puts on one activity.
+type Activity struct {
+	Name string `json:"name"`
+	// TaskQueue is the queue the activity is scheduled on, "" if it can't be determined
+	TaskQueue string `json:"task_queue,omitempty"`
+	// Local marks local activities, which run in the workflow worker without a task queue
+	Local bool `json:"local,omitempty"`
+	// PerWorkflow is the number of executions per workflow start; calls in loops count twice
+	PerWorkflow float64 `json:"per_workflow"`
+	// Rate is the number of executions per second
+	Rate           float64       `json:"rate"`
+	Duration       time.Duration `json:"duration_ns,omitempty"`
+	DurationSource string        `json:"duration_source"`
+}
+
+// Queue is the estimated state of an activity task queue at the simulated start rate.
+type Queue struct {
+	TaskQueue string `json:"task_queue"`
+	// Workers is the number of analyzed workers polling the queue; 0 means the queue is polled
+	// outside the analyzed code and its capacity is unknown
+	Workers int `json:"workers"`
+	// Slots is the number of concurrent activity executions over all replicas
+	Slots int `json:"slots"`
+	// RateLimit is the number of activities the workers start per second, 0 if unlimited
+	RateLimit float64 `json:"rate_limit,omitempty"`
+	// Arrival is the number of activity tasks scheduled per second
+	Arrival float64 `json:"arrival"`
+	// Utilization is the busy fraction of the slots, or of the rate limit if it binds first
+	Utilization float64 `json:"utilization"`
+	// Wait is the mean schedule-to-start wait of an unsaturated queue
+	Wait      time.Duration `json:"wait_ns,omitempty"`
+	Saturated bool          `json:"saturated,omitempty"`
+	// BacklogGrowth is the number of tasks per second the backlog of a saturated queue grows by
+	BacklogGrowth float64 `json:"backlog_growth,omitempty"`
+}
+
+// Result is the outcome of a simulation.
+type Result struct {
+	Workflow   string      `json:"workflow"`
+	RPS        float64     `json:"rps"`
+	Replicas   int         `json:"replicas"`
+	Activities []*Activity `json:"activities"`
+	Queues     []*Queue    `json:"queues"`
+	// Latency is the estimated end-to-end latency of a workflow, valid if no queue is saturated
+	Latency   time.Duration `json:"latency_ns,omitempty"`
+	Saturated bool          `json:"saturated,omitempty"`
+	// Notes lists the assumptions the estimate rests on
+	Notes []string `json:"notes,omitempty"`
+}
+
+// Simulate estimates the backlog and latency of starting opts.Workflow opts.RPS times per
+// second. The workflow's calls are followed into child workflows, counting each activity
+// execution per start. Each task queue is modeled as an M/M/c queue over the slots of the
+// workers polling it (Erlang C), capped by their rate limits. End-to-end latency sums the
+// waits, durations and sleeps of the calls in order, taking the longest of parallel calls.
+func Simulate(graph *analyzer.TemporalGraph, opts Options) (*Result, error) {
+	if opts.RPS <= 0 {
+		return nil, fmt.Errorf("invalid start rate: %g (must be > 0)", opts.RPS)
+	}
+	root, err := findWorkflow(graph, opts.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	replicas := max(opts.Replicas, 1)
+
+	s := &simulation{
+		graph:      graph,
+		activities: make(map[activityKey]*Activity),
+		result:     &Result{Workflow: root.Name, RPS: opts.RPS, Replicas: replicas},
+		notes:      make(map[string]bool),
+	}
+	s.count(root, 1, make(map[*analyzer.TemporalNode]bool))
+	for _, a := range s.result.Activities {
+		a.Rate = a.PerWorkflow * opts.RPS
+		if a.DurationSource == SourceUnknown {
+			s.note(fmt.Sprintf("%s has no @duration hint or constant timeout and adds no load", a.Name))
+		}
+	}
+	s.queues(replicas)
+	s.result.Latency = s.latency(root, make(map[*analyzer.TemporalNode]bool))
+	if s.result.Saturated {
+		s.result.Latency = 0
+	}
+	return s.result, nil
+}
+
+// findWorkflow returns the workflow named name, by graph key or name.
+func findWorkflow(graph *analyzer.TemporalGraph, name string) (*analyzer.TemporalNode, error) {
+	if node, ok := graph.Nodes[name]; ok && node.Type == "workflow" {
+		return node, nil
+	}
+	var matches []string
+	for key, node := range graph.Nodes {
+		if node.Type == "workflow" && node.Name == name {
+			matches = append(matches, key)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no workflow named %s in the analyzed code", name)
+	case 1:
+		return graph.Nodes[matches[0]], nil
+	}
+	sort.Strings(matches)
+	return nil, fmt.Errorf("%s is defined in several packages, name one of: %s", name, strings.Join(matches, ", "))
+}
+
+type activityKey struct{ name, queue string }
+
+type simulation struct {
+	graph      *analyzer.TemporalGraph
+	activities map[activityKey]*Activity
+	queueIndex map[string]*Queue
+	result     *Result
+	notes      map[string]bool
+}
+
+func (s *simulation) note(note string) {
+	if !s.notes[note] {
+		s.notes[note] = true
+		s.result.Notes = append(s.result.Notes, note)
+	}
+}
+
+// executedType returns what a call site executes: activity, local_activity, child_workflow, ...
+func executedType(cs analyzer.CallSite) string {
+	if cs.CallType != "execute" && cs.CallType != "" {
+		return cs.CallType
+	}
+	return cs.TargetType
+}
+
+// count adds the activity executions of one run of workflow, run times per start.
+func (s *simulation) count(workflow *analyzer.TemporalNode, runs float64, visiting map[*analyzer.TemporalNode]bool) {
+	if visiting[workflow] {
+		s.note(fmt.Sprintf("%s starts itself recursively; one level is counted", workflow.Name))
+		return
+	}
+	visiting[workflow] = true
+	defer delete(visiting, workflow)
+
+	for _, cs := range workflow.CallSites {
+		calls := runs * float64(cs.Weight())
+		switch executedType(cs) {
+		case "activity", "local_activity":
+			a := s.activity(workflow, cs)
+			a.PerWorkflow += calls
+		case "child_workflow":
+			if child, ok := s.graph.Nodes[cs.TargetName]; ok && child.Type == "workflow" {
+				s.count(child, calls, visiting)
+			} else {
+				s.note(fmt.Sprintf("child workflow %s is not in the analyzed code and adds no load", cs.TargetName))
+			}
+		}
+	}
+}
+
+// activity returns the activity a call site executes, recording it on first use.
+func (s *simulation) activity(workflow *analyzer.TemporalNode, cs analyzer.CallSite) *Activity {
+	local := executedType(cs) == "local_activity"
+	queue := ""
+	if !local {
+		queue = s.taskQueue(workflow, cs)
+	}
+	key := activityKey{cs.TargetName, queue}
+	a, ok := s.activities[key]
+	if !ok {
+		a = &Activity{Name: cs.TargetName, TaskQueue: queue, Local: local, DurationSource: SourceUnknown}
+		if target, ok := s.graph.Nodes[cs.TargetName]; ok {
+			if d, err := time.ParseDuration(target.Annotations[durationAnnotation]); err == nil {
+				a.Duration, a.DurationSource = d, SourceAnnotation
+			}
+		}
+		s.activities[key] = a
+		s.result.Activities = append(s.result.Activities, a)
+	}
+	// Without a hint, the longest timeout of the call sites bounds the duration
+	if a.DurationSource != SourceAnnotation {
+		if d, ok := timeout(cs); ok && d > a.Duration {
+			a.Duration, a.DurationSource = d, SourceTimeout
+		}
+	}
+	return a
+}
+
+// timeout returns the StartToClose timeout of an activity call, or its ScheduleToClose timeout.
+func timeout(cs analyzer.CallSite) (time.Duration, bool) {
+	opts := cs.ParsedActivityOpts
+	if opts == nil {
+		return 0, false
+	}
+	for _, option := range []struct {
+		expr  string
+		value time.Duration
+	}{
+		{opts.StartToCloseTimeout, opts.StartToCloseValue},
+		{opts.ScheduleToCloseTimeout, opts.ScheduleToCloseValue},
+	} {
+		if option.value > 0 {
+			return option.value, true
+		}
+		if option.expr != "" {
+			if d, ok := analyzer.EvalDuration(option.expr); ok {
+				return d, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// taskQueue returns the queue an activity call is scheduled on: the queue set in its options, or
+// the queue of the worker running the workflow. It returns "" when the queue can't be determined.
+func (s *simulation) taskQueue(workflow *analyzer.TemporalNode, cs analyzer.CallSite) string {
+	if cs.ParsedActivityOpts != nil && cs.ParsedActivityOpts.TaskQueue != "" {
+		return cs.ParsedActivityOpts.TaskQueue
+	}
+	workers := s.graph.Workers
+	for i := range workers {
+		if workers[i].RegistersWorkflow(workflow.Name) {
+			return workers[i].TaskQueue
+		}
+	}
+	if len(workers) == 1 {
+		return workers[0].TaskQueue
+	}
+	return ""
+}
+
+// queues estimates the state of the task queues the activities are scheduled on.
+func (s *simulation) queues(replicas int) {
+	s.queueIndex = make(map[string]*Queue)
+	load := make(map[string]float64)
+	for _, a := range s.result.Activities {
+		if a.Local {
+			continue
+		}
+		if a.TaskQueue == "" {
+			s.note(fmt.Sprintf("the task queue of %s is unknown; its wait is not estimated", a.Name))
+			continue
+		}
+		q, ok := s.queueIndex[a.TaskQueue]
+		if !ok {
+			q = s.newQueue(a.TaskQueue, replicas)
+			s.queueIndex[a.TaskQueue] = q
+			s.result.Queues = append(s.result.Queues, q)
+		}
+		q.Arrival += a.Rate
+		load[a.TaskQueue] += a.Rate * a.Duration.Seconds()
+	}
+	sort.Slice(s.result.Queues, func(i, j int) bool { return s.result.Queues[i].TaskQueue < s.result.Queues[j].TaskQueue })
+
+	for _, q := range s.result.Queues {
+		if q.Workers == 0 {
+			s.note(fmt.Sprintf("task queue %s is not polled by an analyzed worker; its wait is not estimated", q.TaskQueue))
+			continue
+		}
+		estimate(q, load[q.TaskQueue])
+		if q.Saturated {
+			s.result.Saturated = true
+		}
+	}
+}
+
+// newQueue returns a queue with the capacity of the analyzed workers polling it.
+func (s *simulation) newQueue(name string, replicas int) *Queue {
+	q := &Queue{TaskQueue: name}
+	var workerLimit float64
+	workerUnlimited := false
+	for i := range s.graph.Workers {
+		w := &s.graph.Workers[i]
+		if w.TaskQueue != name {
+			continue
+		}
+		q.Workers++
+		q.Slots += w.ActivitySlots() * replicas
+		if limit := w.TaskQueueActivitiesPerSecond; limit > 0 && (q.RateLimit == 0 || limit < q.RateLimit) {
+			q.RateLimit = limit
+		}
+		if w.WorkerActivitiesPerSecond > 0 {
+			workerLimit += w.WorkerActivitiesPerSecond * float64(replicas)
+		} else {
+			workerUnlimited = true
+		}
+	}
+	if q.Workers > 0 && !workerUnlimited && (q.RateLimit == 0 || workerLimit < q.RateLimit) {
+		q.RateLimit = workerLimit
+	}
+	return q
+}
+
+// estimate sets the utilization and wait of a queue offered load Erlangs of work.
+func estimate(q *Queue, load float64) {
+	c := float64(q.Slots)
+	q.Utilization = load / c
+	if q.RateLimit > 0 {
+		q.Utilization = max(q.Utilization, q.Arrival/q.RateLimit)
+	}
+	if q.Utilization >= 1 {
+		q.Saturated = true
+		throughput := math.Inf(1)
+		if load > 0 {
+			throughput = c / (load / q.Arrival)
+		}
+		if q.RateLimit > 0 {
+			throughput = min(throughput, q.RateLimit)
+		}
+		q.BacklogGrowth = q.Arrival - throughput
+		return
+	}
+
+	var wait float64
+	if load > 0 {
+		service := load / q.Arrival
+		wait = erlangC(q.Slots, load) * service / (c - load)
+	}
+	if q.RateLimit > 0 {
+		// The rate limiter serves tasks like a single server at RateLimit per second
+		wait = max(wait, q.Arrival/q.RateLimit/(q.RateLimit-q.Arrival))
+	}
+	q.Wait = time.Duration(wait * float64(time.Second))
+}
+
+// erlangC returns the probability that a task waits in an M/M/c queue with c servers offered
+// load Erlangs, computed from the Erlang B recurrence.
+func erlangC(c int, load float64) float64 {
+	b := 1.0
+	for k := 1; k <= c; k++ {
+		b = load * b / (float64(k) + load*b)
+	}
+	rho := load / float64(c)
+	return b / (1 - rho*(1-b))
+}
+
+// latency returns the end-to-end latency of one run of workflow.
+func (s *simulation) latency(workflow *analyzer.TemporalNode, visiting map[*analyzer.TemporalNode]bool) time.Duration {
+	if visiting[workflow] {
+		return 0
+	}
+	visiting[workflow] = true
+	defer delete(visiting, workflow)
+
+	var total, group time.Duration
+	for _, cs := range workflow.CallSites {
+		group = max(group, s.callLatency(workflow, cs, visiting)*time.Duration(cs.Weight()))
+		// A parallel call runs concurrently with the next one
+		if !cs.Parallel {
+			total += group
+			group = 0
+		}
+	}
+	total += group
+
+	for _, timer := range workflow.Timers {
+		if timer.IsSleep {
+			weight := time.Duration(1)
+			if timer.LoopLine > 0 {
+				weight = analyzer.LoopCallWeight
+			}
+			total += timer.Value * weight
+		}
+	}
+	return total
+}
+
+// callLatency returns the latency of one execution of a call site.
+func (s *simulation) callLatency(workflow *analyzer.TemporalNode, cs analyzer.CallSite, visiting map[*analyzer.TemporalNode]bool) time.Duration {
+	switch executedType(cs) {
+	case "activity", "local_activity":
+		queue := ""
+		if executedType(cs) == "activity" {
+			queue = s.taskQueue(workflow, cs)
+		}
+		a := s.activities[activityKey{cs.TargetName, queue}]
+		if a == nil {
+			return 0
+		}
+		if q := s.queueIndex[queue]; q != nil {
+			return q.Wait + a.Duration
+		}
+		return a.Duration
+	case "child_workflow":
+		if child, ok := s.graph.Nodes[cs.TargetName]; ok && child.Type == "workflow" {
+			return s.latency(child, visiting)
+		}
+	}
+	return 0
+}
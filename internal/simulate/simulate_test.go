@@ -0,0 +1,138 @@
+package simulate
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func testGraph() *analyzer.TemporalGraph {
+	return &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{
+				{TargetName: "Charge", TargetType: "activity", CallType: "execute", Parallel: true},
+				{TargetName: "Notify", TargetType: "activity", CallType: "execute", LoopLine: 12,
+					ParsedActivityOpts: &analyzer.ActivityOptions{StartToCloseTimeout: "time.Second"}},
+				{TargetName: "ShipWorkflow", TargetType: "child_workflow", CallType: "execute"},
+				{TargetName: "Audit", TargetType: "activity", CallType: "execute"},
+			}, Timers: []analyzer.TimerDef{{Duration: "10 * time.Second", IsSleep: true, Value: 10 * time.Second}}},
+			"ShipWorkflow": {Name: "ShipWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{
+				{TargetName: "Ship", TargetType: "activity", CallType: "execute"},
+			}},
+			"Charge": {Name: "Charge", Type: "activity", Annotations: map[string]string{"duration": "200ms"}},
+			"Notify": {Name: "Notify", Type: "activity"},
+			"Ship":   {Name: "Ship", Type: "activity", Annotations: map[string]string{"duration": "500ms"}},
+			"Audit":  {Name: "Audit", Type: "activity"},
+		},
+		Workers: []analyzer.WorkerConfig{
+			{TaskQueue: "orders", Workflows: []string{"OrderWorkflow"}, Activities: []string{"Charge", "Notify", "Audit"}},
+			{TaskQueue: "shipping", Workflows: []string{"ShipWorkflow"}, Activities: []string{"Ship"}, MaxConcurrentActivityExecutionSize: 2},
+		},
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	result, err := Simulate(testGraph(), Options{Workflow: "OrderWorkflow", RPS: 1})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+
+	want := []Activity{
+		{Name: "Charge", TaskQueue: "orders", PerWorkflow: 1, Rate: 1, Duration: 200 * time.Millisecond, DurationSource: SourceAnnotation},
+		{Name: "Notify", TaskQueue: "orders", PerWorkflow: 2, Rate: 2, Duration: time.Second, DurationSource: SourceTimeout},
+		{Name: "Ship", TaskQueue: "shipping", PerWorkflow: 1, Rate: 1, Duration: 500 * time.Millisecond, DurationSource: SourceAnnotation},
+		{Name: "Audit", TaskQueue: "orders", PerWorkflow: 1, Rate: 1, DurationSource: SourceUnknown},
+	}
+	if len(result.Activities) != len(want) {
+		t.Fatalf("Expected %d activities, got %+v", len(want), result.Activities)
+	}
+	for i, w := range want {
+		if *result.Activities[i] != w {
+			t.Errorf("Activities[%d] = %+v, want %+v", i, *result.Activities[i], w)
+		}
+	}
+
+	if len(result.Queues) != 2 {
+		t.Fatalf("Expected 2 queues, got %+v", result.Queues)
+	}
+	orders, shipping := result.Queues[0], result.Queues[1]
+	if orders.Slots != analyzer.DefaultMaxConcurrentActivityExecutionSize || orders.Arrival != 4 || orders.Wait > time.Millisecond {
+		t.Errorf("orders = %+v, want the default slots, 4 tasks/s and no wait", orders)
+	}
+	// M/M/2 with 0.5 Erlangs: P(wait) = 0.1, mean wait 0.1 * 0.5s / 1.5 = 33.3ms
+	if shipping.Slots != 2 || shipping.Utilization != 0.25 || math.Abs(shipping.Wait.Seconds()-0.1/3) > 1e-6 {
+		t.Errorf("shipping = %+v, want 2 slots, 25%% utilization and a 33.3ms wait", shipping)
+	}
+
+	// Charge runs in parallel with the Notify loop (2s), then ShipWorkflow, Audit and the 10s sleep
+	wantLatency := 2*time.Second + 500*time.Millisecond + shipping.Wait + 10*time.Second + 3*orders.Wait
+	if result.Saturated || result.Latency != wantLatency {
+		t.Errorf("Latency = %s (saturated %v), want %s", result.Latency, result.Saturated, wantLatency)
+	}
+	if len(result.Notes) != 1 || !strings.Contains(result.Notes[0], "Audit has no @duration hint") {
+		t.Errorf("Notes = %q, want a note on Audit", result.Notes)
+	}
+}
+
+func TestSimulateSaturated(t *testing.T) {
+	result, err := Simulate(testGraph(), Options{Workflow: "OrderWorkflow", RPS: 500, Replicas: 2})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+
+	orders, shipping := result.Queues[0], result.Queues[1]
+	// 1100 Erlangs on 2000 slots; 250 Erlangs on 4 slots
+	if orders.Saturated || orders.Slots != 2000 {
+		t.Errorf("orders = %+v, want 2000 unsaturated slots", orders)
+	}
+	if !shipping.Saturated || shipping.BacklogGrowth != 492 {
+		t.Errorf("shipping = %+v, want a backlog growing by 500 - 4/0.5s = 492 tasks/s", shipping)
+	}
+	if !result.Saturated || result.Latency != 0 {
+		t.Errorf("Saturated = %v, Latency = %s; want saturated without a latency", result.Saturated, result.Latency)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, result); err != nil {
+		t.Fatalf("WriteMarkdown failed: %v", err)
+	}
+	for _, want := range []string{
+		"# Load simulation: OrderWorkflow at 500 starts/s (2 replicas per worker)",
+		"Estimated latency: unbounded, the backlog of shipping grows without limit",
+		"| shipping | 1 | 4 |  | 500 | 6250% | unbounded | +492 |",
+		"| Notify | orders | 2 | 1000 | 1s | timeout |",
+		"| Audit | orders | 1 | 500 |  | unknown |",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestSimulateRateLimit(t *testing.T) {
+	graph := testGraph()
+	graph.Workers[1].TaskQueueActivitiesPerSecond = 4
+	graph.Workers[1].MaxConcurrentActivityExecutionSize = 0
+
+	result, err := Simulate(graph, Options{Workflow: "ShipWorkflow", RPS: 5})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	shipping := result.Queues[0]
+	if !shipping.Saturated || shipping.RateLimit != 4 || shipping.BacklogGrowth != 1 {
+		t.Errorf("shipping = %+v, want the rate limit to saturate it by 1 task/s", shipping)
+	}
+}
+
+func TestSimulateUnknownWorkflow(t *testing.T) {
+	if _, err := Simulate(testGraph(), Options{Workflow: "Charge", RPS: 1}); err == nil {
+		t.Error("Expected an error simulating an activity")
+	}
+	if _, err := Simulate(testGraph(), Options{Workflow: "OrderWorkflow"}); err == nil {
+		t.Error("Expected an error without a start rate")
+	}
+}
@@ -0,0 +1,219 @@
+// Package stats aggregates the analyzed graph and its lint issues into tables grouped by
+// package, task queue or owner, for architecture reports.
+package stats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+// Dimensions nodes can be grouped by.
+const (
+	ByPackage   = "package"
+	ByTaskQueue = "taskqueue"
+	ByOwner     = "owner"
+)
+
+// Dimensions lists the accepted grouping dimensions.
+var Dimensions = []string{ByPackage, ByTaskQueue, ByOwner}
+
+// Labels of nodes without a value for the grouping dimension.
+const (
+	NoOwner      = "(no owner)"
+	Unregistered = "(unregistered)"
+)
+
+// Row aggregates the nodes of one group.
+type Row struct {
+	Group      string  `json:"group"`
+	Workflows  int     `json:"workflows"`
+	Activities int     `json:"activities"`
+	Nodes      int     `json:"nodes"`
+	AvgFanOut  float64 `json:"avg_fan_out"`
+	Errors     int     `json:"errors"`
+	Warnings   int     `json:"warnings"`
+	Infos      int     `json:"infos"`
+
+	fanOut int
+}
+
+// Issues returns the total number of lint issues in the group.
+func (r *Row) Issues() int {
+	return r.Errors + r.Warnings + r.Infos
+}
+
+// Aggregate groups the graph's nodes by a dimension and counts their lint issues.
+// Nodes registered on workers of several task queues count towards each of them.
+// Rows are sorted by issue count, then node count, then group name.
+func Aggregate(graph *analyzer.TemporalGraph, result *lint.Result, by string) ([]*Row, error) {
+	if !validDimension(by) {
+		return nil, fmt.Errorf("invalid stats dimension: %s (valid: %s)", by, strings.Join(Dimensions, ", "))
+	}
+
+	rows := make(map[string]*Row)
+	row := func(group string) *Row {
+		r, ok := rows[group]
+		if !ok {
+			r = &Row{Group: group}
+			rows[group] = r
+		}
+		return r
+	}
+
+	for _, node := range graph.Nodes {
+		if node.Unresolved {
+			continue
+		}
+		for _, group := range groupsOf(graph, node, by) {
+			r := row(group)
+			r.Nodes++
+			r.fanOut += len(node.CallSites)
+			switch node.Type {
+			case "workflow":
+				r.Workflows++
+			case "activity":
+				r.Activities++
+			}
+		}
+	}
+
+	if result != nil {
+		for _, issue := range result.Issues {
+			node := graph.Nodes[issue.NodeName]
+			if node == nil || node.Unresolved {
+				continue
+			}
+			for _, group := range groupsOf(graph, node, by) {
+				r := row(group)
+				switch issue.Severity {
+				case lint.SeverityError:
+					r.Errors++
+				case lint.SeverityWarning:
+					r.Warnings++
+				default:
+					r.Infos++
+				}
+			}
+		}
+	}
+
+	sorted := make([]*Row, 0, len(rows))
+	for _, r := range rows {
+		if r.Nodes > 0 {
+			r.AvgFanOut = float64(r.fanOut) / float64(r.Nodes)
+		}
+		sorted = append(sorted, r)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Issues() != b.Issues() {
+			return a.Issues() > b.Issues()
+		}
+		if a.Nodes != b.Nodes {
+			return a.Nodes > b.Nodes
+		}
+		return a.Group < b.Group
+	})
+	return sorted, nil
+}
+
+func validDimension(by string) bool {
+	for _, d := range Dimensions {
+		if d == by {
+			return true
+		}
+	}
+	return false
+}
+
+// groupsOf returns the groups a node belongs to for a dimension.
+func groupsOf(graph *analyzer.TemporalGraph, node *analyzer.TemporalNode, by string) []string {
+	switch by {
+	case ByOwner:
+		if owner := node.Annotations["owner"]; owner != "" {
+			return []string{owner}
+		}
+		return []string{NoOwner}
+	case ByTaskQueue:
+		var queues []string
+		seen := make(map[string]bool)
+		for i := range graph.Workers {
+			w := &graph.Workers[i]
+			if seen[w.TaskQueue] {
+				continue
+			}
+			if (node.Type == "workflow" && w.RegistersWorkflow(node.Name)) ||
+				(node.Type == "activity" && w.RegistersActivity(node.Name)) {
+				seen[w.TaskQueue] = true
+				queues = append(queues, w.TaskQueue)
+			}
+		}
+		if len(queues) == 0 {
+			return []string{Unregistered}
+		}
+		return queues
+	default:
+		return []string{node.Package}
+	}
+}
+
+// header returns the table header for a dimension.
+func header(by string) []string {
+	group := map[string]string{ByPackage: "Package", ByTaskQueue: "Task Queue", ByOwner: "Owner"}[by]
+	return []string{group, "Workflows", "Activities", "Nodes", "Avg Fan-Out", "Errors", "Warnings", "Info"}
+}
+
+func (r *Row) cells() []string {
+	return []string{
+		r.Group,
+		strconv.Itoa(r.Workflows),
+		strconv.Itoa(r.Activities),
+		strconv.Itoa(r.Nodes),
+		strconv.FormatFloat(r.AvgFanOut, 'f', 2, 64),
+		strconv.Itoa(r.Errors),
+		strconv.Itoa(r.Warnings),
+		strconv.Itoa(r.Infos),
+	}
+}
+
+// WriteMarkdown writes the rows as a markdown table.
+func WriteMarkdown(w io.Writer, rows []*Row, by string) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	cols := header(by)
+	printf("| %s |\n", strings.Join(cols, " | "))
+	printf("|---|%s\n", strings.Repeat("---:|", len(cols)-1))
+	for _, r := range rows {
+		cells := r.cells()
+		cells[0] = strings.ReplaceAll(cells[0], "|", "\\|")
+		printf("| %s |\n", strings.Join(cells, " | "))
+	}
+	return err
+}
+
+// WriteCSV writes the rows as CSV with a header line.
+func WriteCSV(w io.Writer, rows []*Row, by string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header(by)); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write(r.cells()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
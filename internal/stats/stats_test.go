@@ -0,0 +1,115 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+func testGraph() *analyzer.TemporalGraph {
+	return &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders",
+				Annotations: map[string]string{"owner": "team-orders"},
+				CallSites:   []analyzer.CallSite{{TargetName: "Charge"}, {TargetName: "Ship"}, {TargetName: "Email"}}},
+			"Charge": {Name: "Charge", Type: "activity", Package: "billing",
+				Annotations: map[string]string{"owner": "team-billing"}},
+			"Ship":  {Name: "Ship", Type: "activity", Package: "orders"},
+			"Email": {Name: "Email", Type: "activity", Unresolved: true},
+		},
+		Workers: []analyzer.WorkerConfig{
+			{TaskQueue: "orders", Workflows: []string{"OrderWorkflow"}, Activities: []string{"Ship", "Charge"}},
+			{TaskQueue: "billing", Activities: []string{"Charge"}},
+		},
+	}
+}
+
+func testResult() *lint.Result {
+	return &lint.Result{Issues: []lint.Issue{
+		{NodeName: "OrderWorkflow", Severity: lint.SeverityError},
+		{NodeName: "OrderWorkflow", Severity: lint.SeverityWarning},
+		{NodeName: "Charge", Severity: lint.SeverityInfo},
+		{NodeName: "Email", Severity: lint.SeverityError},
+	}}
+}
+
+func TestAggregateByPackage(t *testing.T) {
+	rows, err := Aggregate(testGraph(), testResult(), ByPackage)
+	if err != nil {
+		t.Fatalf("Aggregate() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 packages (unresolved nodes skipped), got %+v", rows)
+	}
+	orders := rows[0]
+	if orders.Group != "orders" || orders.Workflows != 1 || orders.Activities != 1 || orders.Nodes != 2 {
+		t.Errorf("Unexpected orders row: %+v", orders)
+	}
+	if orders.AvgFanOut != 1.5 || orders.Errors != 1 || orders.Warnings != 1 || orders.Infos != 0 {
+		t.Errorf("Unexpected orders fan-out or issues: %+v", orders)
+	}
+	if rows[1].Group != "billing" || rows[1].Infos != 1 {
+		t.Errorf("Unexpected billing row: %+v", rows[1])
+	}
+}
+
+func TestAggregateByTaskQueueAndOwner(t *testing.T) {
+	rows, err := Aggregate(testGraph(), nil, ByTaskQueue)
+	if err != nil {
+		t.Fatalf("Aggregate() error = %v", err)
+	}
+	nodes := make(map[string]int)
+	for _, r := range rows {
+		nodes[r.Group] = r.Nodes
+	}
+	// Charge is registered on both queues and counts towards each
+	if nodes["orders"] != 3 || nodes["billing"] != 1 || len(nodes) != 2 {
+		t.Errorf("Nodes per task queue = %v, want orders=3 billing=1", nodes)
+	}
+
+	rows, err = Aggregate(testGraph(), nil, ByOwner)
+	if err != nil {
+		t.Fatalf("Aggregate() error = %v", err)
+	}
+	owners := make(map[string]int)
+	for _, r := range rows {
+		owners[r.Group] = r.Nodes
+	}
+	if owners["team-orders"] != 1 || owners["team-billing"] != 1 || owners[NoOwner] != 1 {
+		t.Errorf("Nodes per owner = %v", owners)
+	}
+
+	if _, err := Aggregate(testGraph(), nil, "team"); err == nil {
+		t.Error("Expected an error for an unknown dimension")
+	}
+}
+
+func TestWriteMarkdownAndCSV(t *testing.T) {
+	rows, _ := Aggregate(testGraph(), testResult(), ByPackage)
+
+	var md bytes.Buffer
+	if err := WriteMarkdown(&md, rows, ByPackage); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	for _, want := range []string{
+		"| Package | Workflows | Activities | Nodes | Avg Fan-Out | Errors | Warnings | Info |",
+		"|---|---:|---:|---:|---:|---:|---:|---:|",
+		"| orders | 1 | 1 | 2 | 1.50 | 1 | 1 | 0 |",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md.String())
+		}
+	}
+
+	var csv bytes.Buffer
+	if err := WriteCSV(&csv, rows, ByPackage); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := "Package,Workflows,Activities,Nodes,Avg Fan-Out,Errors,Warnings,Info\norders,1,1,2,1.50,1,1,0\nbilling,0,1,1,0.00,0,0,1\n"
+	if csv.String() != want {
+		t.Errorf("CSV = %q, want %q", csv.String(), want)
+	}
+}
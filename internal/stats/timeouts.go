@@ -0,0 +1,138 @@
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// TimeoutRow is the distribution of one activity timeout option in one package.
+type TimeoutRow struct {
+	Package string
+	// Option is "StartToCloseTimeout" or "ScheduleToCloseTimeout"
+	Option string
+	// Count is the number of call sites setting the option to a constant duration
+	Count int
+	// Dynamic is the number of call sites setting it to a value that can't be evaluated
+	Dynamic int
+	Min     time.Duration
+	Median  time.Duration
+	Max     time.Duration
+}
+
+// MarshalJSON renders the durations as Go duration strings, e.g. "10m0s".
+func (r *TimeoutRow) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Package string `json:"package"`
+		Option  string `json:"option"`
+		Count   int    `json:"count"`
+		Dynamic int    `json:"dynamic"`
+		Min     string `json:"min,omitempty"`
+		Median  string `json:"median,omitempty"`
+		Max     string `json:"max,omitempty"`
+	}{r.Package, r.Option, r.Count, r.Dynamic, r.duration(r.Min), r.duration(r.Median), r.duration(r.Max)})
+}
+
+// duration formats a duration of the row, or "" if no duration was evaluated.
+func (r *TimeoutRow) duration(d time.Duration) string {
+	if r.Count == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// Timeouts reports the distribution of the StartToClose and ScheduleToClose timeouts set at
+// activity call sites, per package of the calling node. Rows are sorted by package, then option.
+func Timeouts(graph *analyzer.TemporalGraph) []*TimeoutRow {
+	type key struct{ pkg, option string }
+	rows := make(map[key]*TimeoutRow)
+	values := make(map[key][]time.Duration)
+	for _, t := range graph.ActivityTimeouts() {
+		k := key{t.Node.Package, t.Option}
+		r, ok := rows[k]
+		if !ok {
+			r = &TimeoutRow{Package: t.Node.Package, Option: t.Option}
+			rows[k] = r
+		}
+		if !t.Evaluated {
+			r.Dynamic++
+			continue
+		}
+		r.Count++
+		values[k] = append(values[k], t.Duration)
+	}
+
+	result := make([]*TimeoutRow, 0, len(rows))
+	for k, r := range rows {
+		if durations := values[k]; len(durations) > 0 {
+			r.Min, r.Max = durations[0], durations[0]
+			for _, d := range durations {
+				r.Min, r.Max = min(r.Min, d), max(r.Max, d)
+			}
+			r.Median = analyzer.MedianDuration(durations)
+		}
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Package != result[j].Package {
+			return result[i].Package < result[j].Package
+		}
+		return result[i].Option > result[j].Option
+	})
+	return result
+}
+
+var timeoutHeader = []string{"Package", "Timeout", "Call Sites", "Dynamic", "Min", "Median", "Max"}
+
+func (r *TimeoutRow) cells() []string {
+	return []string{
+		r.Package,
+		r.Option,
+		strconv.Itoa(r.Count),
+		strconv.Itoa(r.Dynamic),
+		r.duration(r.Min),
+		r.duration(r.Median),
+		r.duration(r.Max),
+	}
+}
+
+// WriteTimeoutsMarkdown writes the timeout distribution as a markdown table.
+func WriteTimeoutsMarkdown(w io.Writer, rows []*TimeoutRow) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("| %s |\n", strings.Join(timeoutHeader, " | "))
+	printf("|---|---|%s\n", strings.Repeat("---:|", len(timeoutHeader)-2))
+	for _, r := range rows {
+		cells := r.cells()
+		cells[0] = strings.ReplaceAll(cells[0], "|", "\\|")
+		printf("| %s |\n", strings.Join(cells, " | "))
+	}
+	return err
+}
+
+// WriteTimeoutsCSV writes the timeout distribution as CSV with a header line.
+func WriteTimeoutsCSV(w io.Writer, rows []*TimeoutRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(timeoutHeader); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write(r.cells()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
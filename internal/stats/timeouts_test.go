@@ -0,0 +1,82 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func timeoutGraph() *analyzer.TemporalGraph {
+	call := func(target string, opts *analyzer.ActivityOptions) analyzer.CallSite {
+		return analyzer.CallSite{TargetName: target, TargetType: "activity", CallType: "execute", ParsedActivityOpts: opts}
+	}
+	return &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", Package: "orders", CallSites: []analyzer.CallSite{
+			call("Reserve", &analyzer.ActivityOptions{StartToCloseTimeout: "time.Minute", ScheduleToCloseTimeout: "time.Hour"}),
+			call("Charge", &analyzer.ActivityOptions{StartToCloseTimeout: "10 * time.Minute"}),
+			call("Ship", &analyzer.ActivityOptions{StartToCloseTimeout: "30 * time.Second"}),
+			call("Audit", &analyzer.ActivityOptions{StartToCloseTimeout: "cfg.Timeout"}),
+		}},
+		"BillingWorkflow": {Name: "BillingWorkflow", Type: "workflow", Package: "billing", CallSites: []analyzer.CallSite{
+			call("Invoice", &analyzer.ActivityOptions{StartToCloseTimeout: "2 * time.Hour"}),
+		}},
+	}}
+}
+
+func TestTimeouts(t *testing.T) {
+	rows := Timeouts(timeoutGraph())
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 rows, got %+v", rows)
+	}
+	if rows[0].Package != "billing" || rows[0].Count != 1 || rows[0].Median != 2*time.Hour {
+		t.Errorf("Unexpected billing row: %+v", rows[0])
+	}
+	orders := rows[1]
+	if orders.Package != "orders" || orders.Option != "StartToCloseTimeout" || orders.Count != 3 || orders.Dynamic != 1 {
+		t.Errorf("Unexpected orders row: %+v", orders)
+	}
+	if orders.Min != 30*time.Second || orders.Median != time.Minute || orders.Max != 10*time.Minute {
+		t.Errorf("Unexpected orders distribution: %+v", orders)
+	}
+	if rows[2].Option != "ScheduleToCloseTimeout" || rows[2].Count != 1 {
+		t.Errorf("Unexpected orders ScheduleToClose row: %+v", rows[2])
+	}
+}
+
+func TestWriteTimeouts(t *testing.T) {
+	rows := Timeouts(timeoutGraph())
+
+	var md bytes.Buffer
+	if err := WriteTimeoutsMarkdown(&md, rows); err != nil {
+		t.Fatalf("WriteTimeoutsMarkdown() error = %v", err)
+	}
+	for _, want := range []string{
+		"| Package | Timeout | Call Sites | Dynamic | Min | Median | Max |",
+		"|---|---|---:|---:|---:|---:|---:|",
+		"| orders | StartToCloseTimeout | 3 | 1 | 30s | 1m0s | 10m0s |",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md.String())
+		}
+	}
+
+	var csv bytes.Buffer
+	if err := WriteTimeoutsCSV(&csv, rows[:1]); err != nil {
+		t.Fatalf("WriteTimeoutsCSV() error = %v", err)
+	}
+	if want := "Package,Timeout,Call Sites,Dynamic,Min,Median,Max\nbilling,StartToCloseTimeout,1,0,2h0m0s,2h0m0s,2h0m0s\n"; csv.String() != want {
+		t.Errorf("CSV = %q, want %q", csv.String(), want)
+	}
+
+	data, err := json.Marshal(&TimeoutRow{Package: "orders", Option: "StartToCloseTimeout", Dynamic: 2})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"package":"orders","option":"StartToCloseTimeout","count":0,"dynamic":2}`; string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+}
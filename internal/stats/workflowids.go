@@ -0,0 +1,149 @@
+package stats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+// IDPatternRow is a workflow ID pattern and the workflow types started with IDs of its shape.
+type IDPatternRow struct {
+	// Namespace is the literal text the IDs start with, e.g. "order"; "" for IDs starting with
+	// a value or generated by the server
+	Namespace string `json:"namespace"`
+	// Pattern is the first pattern found of the shape, e.g. "order-{req.OrderID}"; "" for IDs
+	// generated by the server
+	Pattern   string   `json:"pattern"`
+	Workflows []string `json:"workflows"`
+	CallSites int      `json:"call_sites"`
+	// Collision is set when different workflow types share a pattern with literal text: an ID
+	// of one type can then be taken by a running workflow of the other
+	Collision bool `json:"collision,omitempty"`
+}
+
+// WorkflowIDs reports the workflow ID taxonomy: the ID patterns clients start workflows
+// with, grouped by shape, so that order-{id} and order-{orderID} are one row. Rows are sorted
+// by namespace, then pattern.
+func WorkflowIDs(graph *analyzer.TemporalGraph) []*IDPatternRow {
+	rows := make(map[string]*IDPatternRow)
+	var result []*IDPatternRow
+	for _, id := range graph.WorkflowIDs {
+		shape := id.Shape()
+		r, ok := rows[shape]
+		if !ok {
+			r = &IDPatternRow{Namespace: id.Namespace(), Pattern: id.Pattern}
+			rows[shape] = r
+			result = append(result, r)
+		}
+		r.CallSites++
+		if !slices.Contains(r.Workflows, id.Workflow) {
+			r.Workflows = append(r.Workflows, id.Workflow)
+		}
+	}
+
+	for shape, r := range rows {
+		sort.Strings(r.Workflows)
+		r.Collision = len(r.Workflows) > 1 && hasLiteralText(shape)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Pattern < result[j].Pattern
+	})
+	return result
+}
+
+// hasLiteralText reports whether a shape has letters or digits besides its placeholders.
+// IDs made of values only, such as {uuid}, don't collide by construction.
+func hasLiteralText(shape string) bool {
+	return strings.IndexFunc(strings.ReplaceAll(shape, "{}", ""), func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) >= 0
+}
+
+// Collisions returns the rows whose pattern is shared by different workflow types.
+func Collisions(rows []*IDPatternRow) []*IDPatternRow {
+	var collisions []*IDPatternRow
+	for _, r := range rows {
+		if r.Collision {
+			collisions = append(collisions, r)
+		}
+	}
+	return collisions
+}
+
+var workflowIDHeader = []string{"Namespace", "Pattern", "Workflows", "Call Sites", "Collision"}
+
+func (r *IDPatternRow) cells() []string {
+	namespace, pattern, collision := r.Namespace, r.Pattern, ""
+	if namespace == "" {
+		namespace = "(dynamic)"
+	}
+	if pattern == "" {
+		namespace, pattern = "(server)", "(generated)"
+	}
+	if r.Collision {
+		collision = "yes"
+	}
+	return []string{
+		namespace,
+		pattern,
+		strings.Join(r.Workflows, ", "),
+		strconv.Itoa(r.CallSites),
+		collision,
+	}
+}
+
+// WriteWorkflowIDsMarkdown writes the workflow ID taxonomy as a markdown table, followed by a
+// warning for each pattern shared by different workflow types.
+func WriteWorkflowIDsMarkdown(w io.Writer, rows []*IDPatternRow) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("| %s |\n", strings.Join(workflowIDHeader, " | "))
+	printf("|---|---|---|---:|---|\n")
+	for _, r := range rows {
+		cells := r.cells()
+		for i := range cells {
+			cells[i] = strings.ReplaceAll(cells[i], "|", "\\|")
+		}
+		cells[1] = "`" + cells[1] + "`"
+		printf("| %s |\n", strings.Join(cells, " | "))
+	}
+
+	if collisions := Collisions(rows); len(collisions) > 0 {
+		printf("\n")
+		for _, r := range collisions {
+			printf("- ⚠️ Collision risk: `%s` is used by %s; a workflow of one type can block starting, or receive the signals of, the other\n",
+				r.Pattern, strings.Join(r.Workflows, " and "))
+		}
+	}
+	return err
+}
+
+// WriteWorkflowIDsCSV writes the workflow ID taxonomy as CSV with a header line.
+func WriteWorkflowIDsCSV(w io.Writer, rows []*IDPatternRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(workflowIDHeader); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write(r.cells()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
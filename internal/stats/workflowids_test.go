@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func workflowIDGraph() *analyzer.TemporalGraph {
+	return &analyzer.TemporalGraph{WorkflowIDs: []analyzer.WorkflowID{
+		{Workflow: "OrderWorkflow", Pattern: "order-{req.OrderID}"},
+		{Workflow: "RefundWorkflow", Pattern: "order-{id}"},
+		{Workflow: "OrderWorkflow", Pattern: "order-{orderID}"},
+		{Workflow: "OrderWorkflow", Pattern: "{uuid}"},
+		{Workflow: "RefundWorkflow", Pattern: "{uuid}"},
+		{Workflow: "ReportWorkflow", Pattern: ""},
+	}}
+}
+
+func TestWorkflowIDs(t *testing.T) {
+	rows := WorkflowIDs(workflowIDGraph())
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 rows, got %+v", rows)
+	}
+	if rows[0].Pattern != "" || rows[0].Collision {
+		t.Errorf("Expected server-generated IDs first, got %+v", rows[0])
+	}
+	if rows[1].Pattern != "{uuid}" || len(rows[1].Workflows) != 2 || rows[1].Collision {
+		t.Errorf("Expected UUIDs shared without collision, got %+v", rows[1])
+	}
+	order := rows[2]
+	if order.Namespace != "order" || order.Pattern != "order-{req.OrderID}" || order.CallSites != 3 || !order.Collision {
+		t.Errorf("Unexpected order row: %+v", order)
+	}
+	if got := Collisions(rows); len(got) != 1 || got[0] != order {
+		t.Errorf("Collisions() = %+v", got)
+	}
+}
+
+func TestWriteWorkflowIDs(t *testing.T) {
+	rows := WorkflowIDs(workflowIDGraph())
+
+	var md bytes.Buffer
+	if err := WriteWorkflowIDsMarkdown(&md, rows); err != nil {
+		t.Fatalf("WriteWorkflowIDsMarkdown() error = %v", err)
+	}
+	for _, want := range []string{
+		"| Namespace | Pattern | Workflows | Call Sites | Collision |",
+		"| (server) | `(generated)` | ReportWorkflow | 1 |  |",
+		"| (dynamic) | `{uuid}` | OrderWorkflow, RefundWorkflow | 2 |  |",
+		"| order | `order-{req.OrderID}` | OrderWorkflow, RefundWorkflow | 3 | yes |",
+		"Collision risk: `order-{req.OrderID}` is used by OrderWorkflow and RefundWorkflow",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md.String())
+		}
+	}
+
+	var csv bytes.Buffer
+	if err := WriteWorkflowIDsCSV(&csv, rows); err != nil {
+		t.Fatalf("WriteWorkflowIDsCSV() error = %v", err)
+	}
+	if !strings.Contains(csv.String(), `order,order-{req.OrderID},"OrderWorkflow, RefundWorkflow",3,yes`) {
+		t.Errorf("Unexpected CSV:\n%s", csv.String())
+	}
+}
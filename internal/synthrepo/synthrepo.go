@@ -0,0 +1,224 @@
+// Package synthrepo generates synthetic Temporal projects of a given size and shape, so the
+// analyzer's throughput and memory can be measured at scale.
+package synthrepo
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Module is the module path of generated projects.
+const Module = "example.com/synth"
+
+// Options controls the size and shape of a generated project.
+type Options struct {
+	Workflows  int
+	Activities int
+	// Packages is the number of packages the workflows and activities are spread over
+	Packages int
+	// FanOut is the number of activities each workflow executes
+	FanOut int
+	// Depth is the length of the chains of child workflows; 1 generates no child workflows
+	Depth int
+}
+
+// normalize fills in defaults and clamps the options to a consistent shape.
+func (o Options) normalize() Options {
+	o.Packages = max(o.Packages, 1)
+	o.Depth = max(o.Depth, 1)
+	o.FanOut = min(max(o.FanOut, 0), o.Activities)
+	return o
+}
+
+// WorkflowName returns the name of the i-th workflow.
+func WorkflowName(i int) string {
+	return fmt.Sprintf("Order%dWorkflow", i)
+}
+
+// ActivityName returns the name of the i-th activity.
+func ActivityName(i int) string {
+	return fmt.Sprintf("Step%dActivity", i)
+}
+
+// packageName returns the name of the i-th package.
+func packageName(i int) string {
+	return fmt.Sprintf("domain%d", i)
+}
+
+// ChildWorkflow returns the index of the child workflow the i-th workflow executes, or -1.
+// Workflows form chains of opts.Depth workflows.
+func (o Options) ChildWorkflow(i int) int {
+	o = o.normalize()
+	if (i+1)%o.Depth == 0 || i+1 >= o.Workflows {
+		return -1
+	}
+	return i + 1
+}
+
+// ExecutedActivities returns the indexes of the activities the i-th workflow executes.
+func (o Options) ExecutedActivities(i int) []int {
+	o = o.normalize()
+	activities := make([]int, 0, o.FanOut)
+	for k := range o.FanOut {
+		activities = append(activities, (i*o.FanOut+k)%o.Activities)
+	}
+	return activities
+}
+
+// Generate writes a project to dir: a go.mod, one file per workflow, one activities file
+// per package and a worker registering every workflow and activity.
+func Generate(dir string, opts Options) error {
+	opts = opts.normalize()
+
+	files := make(map[string]string)
+	files["go.mod"] = "module " + Module + "\n\ngo 1.25\n"
+
+	for i := range opts.Workflows {
+		pkg := i % opts.Packages
+		files[filepath.Join(packageName(pkg), fmt.Sprintf("workflow_%d.go", i))] = opts.workflowFile(i)
+	}
+
+	activities := make([][]int, opts.Packages)
+	for j := range opts.Activities {
+		activities[j%opts.Packages] = append(activities[j%opts.Packages], j)
+	}
+	for pkg, indexes := range activities {
+		if len(indexes) > 0 {
+			files[filepath.Join(packageName(pkg), "activities.go")] = activitiesFile(pkg, indexes)
+		}
+	}
+
+	files[filepath.Join("worker", "main.go")] = opts.workerFile()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+		}
+		src := []byte(content)
+		if strings.HasSuffix(name, ".go") {
+			formatted, err := format.Source(src)
+			if err != nil {
+				return fmt.Errorf("failed to format %s: %w", name, err)
+			}
+			src = formatted
+		}
+		if err := os.WriteFile(path, src, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// fileBuilder writes a Go file, collecting the packages of the project it refers to.
+type fileBuilder struct {
+	pkg     int
+	imports map[int]bool
+	body    strings.Builder
+}
+
+// ref returns a reference to a function of package pkg.
+func (f *fileBuilder) ref(pkg int, name string) string {
+	if pkg == f.pkg {
+		return name
+	}
+	f.imports[pkg] = true
+	return packageName(pkg) + "." + name
+}
+
+// String returns the file with the package clause and imports.
+func (f *fileBuilder) String(name string, stdImports ...string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", name)
+	for _, imp := range stdImports {
+		fmt.Fprintf(&b, "\t%q\n", imp)
+	}
+
+	pkgs := make([]int, 0, len(f.imports))
+	for pkg := range f.imports {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Ints(pkgs)
+	if len(pkgs) > 0 {
+		b.WriteString("\n")
+	}
+	for _, pkg := range pkgs {
+		fmt.Fprintf(&b, "\t%q\n", Module+"/"+packageName(pkg))
+	}
+	b.WriteString(")\n")
+	b.WriteString(f.body.String())
+	return b.String()
+}
+
+// workflowFile returns the source of the i-th workflow.
+func (o Options) workflowFile(i int) string {
+	f := &fileBuilder{pkg: i % o.Packages, imports: make(map[int]bool)}
+	b := &f.body
+
+	fmt.Fprintf(b, "\n// %s is a generated workflow.\n", WorkflowName(i))
+	fmt.Fprintf(b, "func %s(ctx workflow.Context, input string) (string, error) {\n", WorkflowName(i))
+	b.WriteString("\tctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})\n")
+	b.WriteString("\tresult := input\n")
+	for _, j := range o.ExecutedActivities(i) {
+		target := f.ref(j%o.Packages, ActivityName(j))
+		fmt.Fprintf(b, "\tif err := workflow.ExecuteActivity(ctx, %s, result).Get(ctx, &result); err != nil {\n", target)
+		b.WriteString("\t\treturn \"\", err\n\t}\n")
+	}
+	if child := o.ChildWorkflow(i); child >= 0 {
+		target := f.ref(child%o.Packages, WorkflowName(child))
+		fmt.Fprintf(b, "\tif err := workflow.ExecuteChildWorkflow(ctx, %s, result).Get(ctx, &result); err != nil {\n", target)
+		b.WriteString("\t\treturn \"\", err\n\t}\n")
+	}
+	b.WriteString("\treturn result, nil\n}\n")
+
+	return f.String(packageName(i%o.Packages), "time", "go.temporal.io/sdk/workflow")
+}
+
+// activitiesFile returns the source of the activities of a package.
+func activitiesFile(pkg int, indexes []int) string {
+	f := &fileBuilder{pkg: pkg, imports: make(map[int]bool)}
+	b := &f.body
+	for _, j := range indexes {
+		fmt.Fprintf(b, "\n// %s is a generated activity.\n", ActivityName(j))
+		fmt.Fprintf(b, "func %s(ctx context.Context, input string) (string, error) {\n", ActivityName(j))
+		b.WriteString("\tif err := ctx.Err(); err != nil {\n\t\treturn \"\", err\n\t}\n")
+		b.WriteString("\treturn input, nil\n}\n")
+	}
+	return f.String(packageName(pkg), "context")
+}
+
+// workerFile returns the source of a worker registering every workflow and activity.
+func (o Options) workerFile() string {
+	f := &fileBuilder{pkg: -1, imports: make(map[int]bool)}
+	b := &f.body
+
+	b.WriteString("\nfunc register(worker worker.Worker) {\n")
+	for i := range o.Workflows {
+		fmt.Fprintf(b, "\tworker.RegisterWorkflow(%s)\n", f.ref(i%o.Packages, WorkflowName(i)))
+	}
+	for j := range o.Activities {
+		fmt.Fprintf(b, "\tworker.RegisterActivity(%s)\n", f.ref(j%o.Packages, ActivityName(j)))
+	}
+	b.WriteString("}\n")
+
+	b.WriteString(`
+func main() {
+	c, err := client.Dial(client.Options{})
+	if err != nil {
+		panic(err)
+	}
+	defer c.Close()
+
+	w := worker.New(c, "synth", worker.Options{})
+	register(w)
+	if err := w.Run(worker.InterruptCh()); err != nil {
+		panic(err)
+	}
+}
+`)
+	return f.String("main", "go.temporal.io/sdk/client", "go.temporal.io/sdk/worker")
+}
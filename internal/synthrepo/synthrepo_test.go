@@ -0,0 +1,86 @@
+package synthrepo
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{Workflows: 7, Activities: 5, Packages: 3, FanOut: 2, Depth: 3}
+	if err := Generate(dir, opts); err != nil {
+		t.Fatalf("Generate() = %v", err)
+	}
+
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		files = append(files, filepath.ToSlash(rel))
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), path, src, 0); err != nil {
+			t.Errorf("%s does not parse: %v", rel, err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 7 workflow files, an activities file in each of the 3 packages and the worker
+	if len(files) != 11 || !slices.Contains(files, "worker/main.go") || !slices.Contains(files, "domain2/activities.go") {
+		t.Errorf("Unexpected generated files: %v", files)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+		t.Errorf("Expected a go.mod: %v", err)
+	}
+
+	// Order1Workflow is in domain1 and calls into domain0 and domain2
+	src, err := os.ReadFile(filepath.Join(dir, "domain1", "workflow_1.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"example.com/synth/domain0"`,
+		"workflow.ExecuteActivity(ctx, domain2.Step2Activity, result)",
+		"workflow.ExecuteActivity(ctx, domain0.Step3Activity, result)",
+		"workflow.ExecuteChildWorkflow(ctx, domain2.Order2Workflow, result)",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("Expected %s in workflow_1.go:\n%s", want, src)
+		}
+	}
+}
+
+func TestOptionsShape(t *testing.T) {
+	opts := Options{Workflows: 7, Activities: 3, FanOut: 5, Depth: 3}
+
+	// Chains of 3 workflows: 0 → 1 → 2, 3 → 4 → 5, 6
+	var children []int
+	for i := range opts.Workflows {
+		children = append(children, opts.ChildWorkflow(i))
+	}
+	if want := []int{1, 2, -1, 4, 5, -1, -1}; !slices.Equal(children, want) {
+		t.Errorf("ChildWorkflow() = %v, want %v", children, want)
+	}
+
+	// The fan-out is capped at the number of activities
+	if got := opts.ExecutedActivities(1); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("ExecutedActivities(1) = %v, want [0 1 2]", got)
+	}
+	if got := (Options{Workflows: 2}).ExecutedActivities(0); len(got) != 0 {
+		t.Errorf("Expected no activities without any, got %v", got)
+	}
+}
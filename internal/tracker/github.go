@@ -0,0 +1,88 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// gitHubTracker files tickets as GitHub issues. Tickets are found by their fingerprint label.
+type gitHubTracker struct {
+	client *http.Client
+	apiURL string
+	owner  string
+	repo   string
+	token  string
+}
+
+// NewGitHubTracker creates a tracker for the issues of a GitHub repository.
+func NewGitHubTracker(client *http.Client, apiURL, owner, repo, token string) Tracker {
+	return &gitHubTracker{
+		client: client,
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		owner:  owner,
+		repo:   repo,
+		token:  token,
+	}
+}
+
+// gitHubIssue is the subset of the GitHub issue API used by the tracker.
+type gitHubIssue struct {
+	Number int      `json:"number,omitempty"`
+	Title  string   `json:"title,omitempty"`
+	Body   string   `json:"body,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// Name returns the name of the tracker.
+func (t *gitHubTracker) Name() string {
+	return "github"
+}
+
+// Find returns the number of the open issue labeled with the fingerprint.
+func (t *gitHubTracker) Find(ctx context.Context, fingerprint string) (string, error) {
+	query := url.Values{"labels": {fingerprint}, "state": {"open"}, "per_page": {"1"}}
+	var issues []struct {
+		Number int `json:"number"`
+	}
+	if err := doJSON(ctx, t.client, http.MethodGet, t.issuesURL()+"?"+query.Encode(), nil, &issues, t.authorize); err != nil {
+		return "", fmt.Errorf("failed to search GitHub issues: %w", err)
+	}
+	if len(issues) == 0 {
+		return "", nil
+	}
+	return strconv.Itoa(issues[0].Number), nil
+}
+
+// Create opens a GitHub issue labeled with the fingerprint.
+func (t *gitHubTracker) Create(ctx context.Context, ticket Ticket) (string, error) {
+	var created gitHubIssue
+	in := gitHubIssue{Title: ticket.Title, Body: ticket.Body, Labels: ticket.Labels}
+	if err := doJSON(ctx, t.client, http.MethodPost, t.issuesURL(), in, &created, t.authorize); err != nil {
+		return "", fmt.Errorf("failed to create GitHub issue: %w", err)
+	}
+	return strconv.Itoa(created.Number), nil
+}
+
+// Update replaces the title and body of an existing issue.
+func (t *gitHubTracker) Update(ctx context.Context, id string, ticket Ticket) error {
+	in := gitHubIssue{Title: ticket.Title, Body: ticket.Body}
+	if err := doJSON(ctx, t.client, http.MethodPatch, t.issuesURL()+"/"+id, in, nil, t.authorize); err != nil {
+		return fmt.Errorf("failed to update GitHub issue #%s: %w", id, err)
+	}
+	return nil
+}
+
+// issuesURL returns the issues endpoint of the repository.
+func (t *gitHubTracker) issuesURL() string {
+	return fmt.Sprintf("%s/repos/%s/%s/issues", t.apiURL, url.PathEscape(t.owner), url.PathEscape(t.repo))
+}
+
+// authorize adds the token authentication headers.
+func (t *gitHubTracker) authorize(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+}
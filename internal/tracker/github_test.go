@@ -0,0 +1,64 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitHubTracker(t *testing.T) {
+	var created, updated gitHubIssue
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/org/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Missing token, got %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Query().Get("labels") == "temporal-analyzer-existing" {
+			_, _ = w.Write([]byte(`[{"number": 7}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("POST /repos/org/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&created)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number": 42}`))
+	})
+	mux.HandleFunc("PATCH /repos/org/repo/issues/7", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&updated)
+		_, _ = w.Write([]byte(`{"number": 7}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tr := NewGitHubTracker(server.Client(), server.URL+"/", "org", "repo", "token")
+	ctx := context.Background()
+
+	if id, err := tr.Find(ctx, "temporal-analyzer-missing"); err != nil || id != "" {
+		t.Errorf("Find(missing) = %q, %v; want no issue", id, err)
+	}
+	if id, err := tr.Find(ctx, "temporal-analyzer-existing"); err != nil || id != "7" {
+		t.Errorf("Find(existing) = %q, %v; want 7", id, err)
+	}
+
+	id, err := tr.Create(ctx, Ticket{Title: "[TA001] title", Body: "body", Labels: []string{"temporal-analyzer", "temporal-analyzer-new"}})
+	if err != nil || id != "42" {
+		t.Fatalf("Create() = %q, %v; want 42", id, err)
+	}
+	if created.Title != "[TA001] title" || len(created.Labels) != 2 {
+		t.Errorf("Unexpected created issue: %+v", created)
+	}
+
+	if err := tr.Update(ctx, "7", Ticket{Title: "new title", Body: "new body"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated.Body != "new body" {
+		t.Errorf("Unexpected updated issue: %+v", updated)
+	}
+
+	if err := tr.Update(ctx, "8", Ticket{}); err == nil {
+		t.Error("Expected error for unknown issue")
+	}
+}
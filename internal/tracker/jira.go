@@ -0,0 +1,98 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// jiraTracker files tickets as Jira issues. Tickets are found by their fingerprint label.
+type jiraTracker struct {
+	client  *http.Client
+	baseURL string
+	project string
+	email   string
+	token   string
+}
+
+// NewJiraTracker creates a tracker for a Jira project, authenticating with an API token.
+func NewJiraTracker(client *http.Client, baseURL, project, email, token string) Tracker {
+	return &jiraTracker{
+		client:  client,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		project: project,
+		email:   email,
+		token:   token,
+	}
+}
+
+// jiraFields is the subset of Jira issue fields set by the tracker.
+type jiraFields struct {
+	Project     *jiraKey `json:"project,omitempty"`
+	IssueType   *jiraKey `json:"issuetype,omitempty"`
+	Summary     string   `json:"summary,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+type jiraKey struct {
+	Key  string `json:"key,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Name returns the name of the tracker.
+func (t *jiraTracker) Name() string {
+	return "jira"
+}
+
+// Find returns the key of the unresolved issue labeled with the fingerprint.
+func (t *jiraTracker) Find(ctx context.Context, fingerprint string) (string, error) {
+	jql := fmt.Sprintf(`project = "%s" AND labels = "%s" AND statusCategory != Done`, t.project, fingerprint)
+	query := url.Values{"jql": {jql}, "fields": {"key"}, "maxResults": {"1"}}
+	var result struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	if err := doJSON(ctx, t.client, http.MethodGet, t.baseURL+"/rest/api/2/search?"+query.Encode(), nil, &result, t.authorize); err != nil {
+		return "", fmt.Errorf("failed to search Jira issues: %w", err)
+	}
+	if len(result.Issues) == 0 {
+		return "", nil
+	}
+	return result.Issues[0].Key, nil
+}
+
+// Create creates a Jira bug labeled with the fingerprint.
+func (t *jiraTracker) Create(ctx context.Context, ticket Ticket) (string, error) {
+	in := map[string]jiraFields{"fields": {
+		Project:     &jiraKey{Key: t.project},
+		IssueType:   &jiraKey{Name: "Bug"},
+		Summary:     ticket.Title,
+		Description: ticket.Body,
+		Labels:      ticket.Labels,
+	}}
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := doJSON(ctx, t.client, http.MethodPost, t.baseURL+"/rest/api/2/issue", in, &created, t.authorize); err != nil {
+		return "", fmt.Errorf("failed to create Jira issue: %w", err)
+	}
+	return created.Key, nil
+}
+
+// Update replaces the summary and description of an existing issue.
+func (t *jiraTracker) Update(ctx context.Context, id string, ticket Ticket) error {
+	in := map[string]jiraFields{"fields": {Summary: ticket.Title, Description: ticket.Body}}
+	if err := doJSON(ctx, t.client, http.MethodPut, t.baseURL+"/rest/api/2/issue/"+url.PathEscape(id), in, nil, t.authorize); err != nil {
+		return fmt.Errorf("failed to update Jira issue %s: %w", id, err)
+	}
+	return nil
+}
+
+// authorize adds basic authentication with the API token.
+func (t *jiraTracker) authorize(req *http.Request) {
+	req.SetBasicAuth(t.email, t.token)
+}
@@ -0,0 +1,66 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestJiraTracker(t *testing.T) {
+	var created, updated map[string]jiraFields
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "ci@example.com" || pass != "token" {
+			t.Errorf("Unexpected basic auth %q/%q", user, pass)
+		}
+		jql := r.URL.Query().Get("jql")
+		if !strings.Contains(jql, `project = "PAY"`) {
+			t.Errorf("JQL should be scoped to the project, got %q", jql)
+		}
+		if strings.Contains(jql, "temporal-analyzer-existing") {
+			_, _ = w.Write([]byte(`{"issues": [{"key": "PAY-7"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"issues": []}`))
+	})
+	mux.HandleFunc("POST /rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&created)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"key": "PAY-42"}`))
+	})
+	mux.HandleFunc("PUT /rest/api/2/issue/PAY-7", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&updated)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tr := NewJiraTracker(server.Client(), server.URL, "PAY", "ci@example.com", "token")
+	ctx := context.Background()
+
+	if id, err := tr.Find(ctx, "temporal-analyzer-missing"); err != nil || id != "" {
+		t.Errorf("Find(missing) = %q, %v; want no issue", id, err)
+	}
+	if id, err := tr.Find(ctx, "temporal-analyzer-existing"); err != nil || id != "PAY-7" {
+		t.Errorf("Find(existing) = %q, %v; want PAY-7", id, err)
+	}
+
+	id, err := tr.Create(ctx, Ticket{Title: "[TA001] title", Body: "body", Labels: []string{"temporal-analyzer-new"}})
+	if err != nil || id != "PAY-42" {
+		t.Fatalf("Create() = %q, %v; want PAY-42", id, err)
+	}
+	fields := created["fields"]
+	if fields.Project == nil || fields.Project.Key != "PAY" || fields.IssueType == nil || fields.IssueType.Name != "Bug" {
+		t.Errorf("Unexpected created fields: %+v", fields)
+	}
+
+	if err := tr.Update(ctx, "PAY-7", Ticket{Title: "new title", Body: "new body"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated["fields"].Description != "new body" || updated["fields"].Project != nil {
+		t.Errorf("Unexpected updated fields: %+v", updated["fields"])
+	}
+}
@@ -0,0 +1,287 @@
+// Package tracker files lint errors as tickets in issue trackers (GitHub Issues, Jira),
+// deduplicated by a stable issue fingerprint so repeated CI runs update existing tickets.
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+// FingerprintPrefix prefixes fingerprints in ticket labels and markers.
+const FingerprintPrefix = "temporal-analyzer-"
+
+// Ticket is the tracker-independent content of a ticket for a lint issue.
+type Ticket struct {
+	Fingerprint string
+	Title       string
+	Body        string
+	Labels      []string
+}
+
+// Tracker creates and updates tickets in an issue tracker.
+type Tracker interface {
+	// Name returns the name of the tracker.
+	Name() string
+
+	// Find returns the ID of the open ticket with the given fingerprint, or "" if there is none.
+	Find(ctx context.Context, fingerprint string) (string, error)
+
+	// Create creates a ticket and returns its ID.
+	Create(ctx context.Context, ticket Ticket) (string, error)
+
+	// Update replaces the content of an existing ticket.
+	Update(ctx context.Context, id string, ticket Ticket) error
+}
+
+// New creates a tracker from a spec of the form "github:owner/repo" or "jira:PROJECT".
+// Credentials and endpoints are read with getenv (GITHUB_TOKEN, GITHUB_API_URL,
+// JIRA_URL, JIRA_EMAIL, JIRA_API_TOKEN).
+func New(spec string, getenv func(string) string) (Tracker, error) {
+	kind, target, ok := strings.Cut(spec, ":")
+	if !ok || target == "" {
+		return nil, fmt.Errorf("invalid tracker %q (expected github:owner/repo or jira:PROJECT)", spec)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	switch kind {
+	case "github":
+		owner, repo, ok := strings.Cut(target, "/")
+		if !ok || owner == "" || repo == "" {
+			return nil, fmt.Errorf("invalid GitHub repository %q (expected owner/repo)", target)
+		}
+		token := getenv("GITHUB_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("GITHUB_TOKEN is required to file GitHub issues")
+		}
+		apiURL := getenv("GITHUB_API_URL")
+		if apiURL == "" {
+			apiURL = "https://api.github.com"
+		}
+		return NewGitHubTracker(client, apiURL, owner, repo, token), nil
+	case "jira":
+		baseURL, email, token := getenv("JIRA_URL"), getenv("JIRA_EMAIL"), getenv("JIRA_API_TOKEN")
+		if baseURL == "" || email == "" || token == "" {
+			return nil, fmt.Errorf("JIRA_URL, JIRA_EMAIL and JIRA_API_TOKEN are required to file Jira issues")
+		}
+		return NewJiraTracker(client, baseURL, target, email, token), nil
+	default:
+		return nil, fmt.Errorf("unknown tracker %q (valid: github, jira)", kind)
+	}
+}
+
+// Fingerprint returns a stable identifier for a lint issue, the hash of its lint.IssueKey, so
+// tickets survive unrelated edits, different checkout directories and changing counts.
+func Fingerprint(issue lint.Issue) string {
+	sum := sha256.Sum256([]byte(lint.IssueKey(issue)))
+	return FingerprintPrefix + hex.EncodeToString(sum[:])[:12]
+}
+
+// Options configures a Filer.
+type Options struct {
+	// RootDir is used to show file paths relative to the project
+	RootDir string
+	// LinkBase is the URL of RootDir in a code browser, e.g. https://github.com/org/repo/blob/<sha>
+	LinkBase string
+}
+
+// Summary reports what a Filer did.
+type Summary struct {
+	Created []string `json:"created,omitempty"`
+	Updated []string `json:"updated,omitempty"`
+	Failed  int      `json:"failed,omitempty"`
+}
+
+// Filer files lint errors as tracker tickets.
+type Filer struct {
+	tracker Tracker
+	opts    Options
+	logger  *slog.Logger
+}
+
+// NewFiler creates a new Filer.
+func NewFiler(tracker Tracker, opts Options, logger *slog.Logger) *Filer {
+	return &Filer{
+		tracker: tracker,
+		opts:    opts,
+		logger:  logger,
+	}
+}
+
+// File creates or updates a ticket for every error in result that is not in baseline.
+// A nil baseline files all errors. Errors of individual tickets are logged and counted;
+// the returned error is set if any ticket failed.
+func (f *Filer) File(ctx context.Context, result, baseline *lint.Result) (*Summary, error) {
+	known := make(map[string]bool)
+	if baseline != nil {
+		for _, issue := range baseline.Issues {
+			known[Fingerprint(issue)] = true
+		}
+	}
+
+	summary := &Summary{}
+	seen := make(map[string]bool)
+	var firstErr error
+
+	for _, issue := range result.Issues {
+		select {
+		case <-ctx.Done():
+			return summary, ctx.Err()
+		default:
+		}
+
+		fp := Fingerprint(issue)
+		if issue.Severity != lint.SeverityError || known[fp] || seen[fp] {
+			continue
+		}
+		seen[fp] = true
+
+		id, created, err := f.fileIssue(ctx, f.Ticket(issue))
+		if err != nil {
+			f.logger.Warn("Failed to file issue", "tracker", f.tracker.Name(), "rule", issue.RuleID, "error", err)
+			summary.Failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if created {
+			summary.Created = append(summary.Created, id)
+		} else {
+			summary.Updated = append(summary.Updated, id)
+		}
+	}
+
+	if firstErr != nil {
+		return summary, fmt.Errorf("failed to file %d issue(s) in %s: %w", summary.Failed, f.tracker.Name(), firstErr)
+	}
+	return summary, nil
+}
+
+// fileIssue updates the ticket with the same fingerprint, or creates one.
+func (f *Filer) fileIssue(ctx context.Context, ticket Ticket) (id string, created bool, err error) {
+	id, err = f.tracker.Find(ctx, ticket.Fingerprint)
+	if err != nil {
+		return "", false, err
+	}
+	if id != "" {
+		return id, false, f.tracker.Update(ctx, id, ticket)
+	}
+	id, err = f.tracker.Create(ctx, ticket)
+	return id, true, err
+}
+
+// Ticket builds the ticket content for a lint issue.
+func (f *Filer) Ticket(issue lint.Issue) Ticket {
+	fp := Fingerprint(issue)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "**%s** (%s) — %s\n\n", issue.RuleID, issue.RuleName, issue.Message)
+	if loc := f.location(issue); loc != "" {
+		fmt.Fprintf(&body, "**Location:** %s\n\n", loc)
+	}
+	if issue.Description != "" {
+		fmt.Fprintf(&body, "%s\n\n", issue.Description)
+	}
+	if issue.Suggestion != "" {
+		fmt.Fprintf(&body, "**Suggested fix:** %s\n\n", issue.Suggestion)
+	}
+	if issue.Fix != nil && issue.Fix.Description != "" {
+		fmt.Fprintf(&body, "**Automatic fix:** %s\n\n", issue.Fix.Description)
+	}
+	fmt.Fprintf(&body, "<!-- %s -->\n", fp)
+	fmt.Fprintf(&body, "_Filed by temporal-analyzer. Fingerprint: `%s`_\n", fp)
+
+	title := fmt.Sprintf("[%s] %s", issue.RuleID, issue.Message)
+	labels := []string{"temporal-analyzer", fp}
+
+	return Ticket{
+		Fingerprint: fp,
+		Title:       title,
+		Body:        body.String(),
+		Labels:      labels,
+	}
+}
+
+// location formats the issue position, linked to the code when LinkBase is set.
+func (f *Filer) location(issue lint.Issue) string {
+	if issue.FilePath == "" {
+		return ""
+	}
+
+	path := filepath.ToSlash(issue.FilePath)
+	if f.opts.RootDir != "" {
+		if rel, err := filepath.Rel(f.opts.RootDir, issue.FilePath); err == nil && filepath.IsLocal(rel) {
+			path = filepath.ToSlash(rel)
+		}
+	}
+
+	text := path
+	if issue.LineNumber > 0 {
+		text = fmt.Sprintf("%s:%d", path, issue.LineNumber)
+	}
+	if f.opts.LinkBase == "" {
+		return "`" + text + "`"
+	}
+
+	link := strings.TrimSuffix(f.opts.LinkBase, "/") + "/" + path
+	if issue.LineNumber > 0 {
+		link += fmt.Sprintf("#L%d", issue.LineNumber)
+	}
+	return fmt.Sprintf("[%s](%s)", text, link)
+}
+
+// doJSON sends a JSON request and decodes the JSON response into out (if not nil).
+func doJSON(ctx context.Context, client *http.Client, method, url string, in, out any, authorize func(*http.Request)) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	authorize(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %s: %s", method, url, resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
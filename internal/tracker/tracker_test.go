@@ -0,0 +1,199 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+)
+
+// fakeTracker records tickets in memory.
+type fakeTracker struct {
+	tickets map[string]Ticket // keyed by ID
+	ids     map[string]string // fingerprint -> ID
+	failOn  string
+}
+
+func newFakeTracker() *fakeTracker {
+	return &fakeTracker{tickets: make(map[string]Ticket), ids: make(map[string]string)}
+}
+
+func (t *fakeTracker) Name() string { return "fake" }
+
+func (t *fakeTracker) Find(ctx context.Context, fingerprint string) (string, error) {
+	return t.ids[fingerprint], nil
+}
+
+func (t *fakeTracker) Create(ctx context.Context, ticket Ticket) (string, error) {
+	if strings.Contains(ticket.Title, t.failOn) && t.failOn != "" {
+		return "", errors.New("boom")
+	}
+	id := "T-" + ticket.Fingerprint[len(FingerprintPrefix):]
+	t.tickets[id] = ticket
+	t.ids[ticket.Fingerprint] = id
+	return id, nil
+}
+
+func (t *fakeTracker) Update(ctx context.Context, id string, ticket Ticket) error {
+	t.tickets[id] = ticket
+	return nil
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testResult() *lint.Result {
+	return &lint.Result{Issues: []lint.Issue{
+		{RuleID: "TA001", Severity: lint.SeverityError, Message: "Activity 'Charge' has unlimited retries", NodeName: "Charge", FilePath: "/repo/charge.go", LineNumber: 12},
+		{RuleID: "TA001", Severity: lint.SeverityError, Message: "Activity 'Charge' has unlimited retries", NodeName: "Charge", FilePath: "/repo/charge.go", LineNumber: 40},
+		{RuleID: "TA002", Severity: lint.SeverityError, Message: "Activity 'Ship' has no timeout", NodeName: "Ship"},
+		{RuleID: "TA031", Severity: lint.SeverityWarning, Message: "Signal without handler", NodeName: "Order"},
+	}}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := lint.Issue{RuleID: "TA001", NodeName: "Charge", Message: "m", FilePath: "/a/charge.go", LineNumber: 1}
+	b := lint.Issue{RuleID: "TA001", NodeName: "Charge", Message: "m", FilePath: "/b/charge.go", LineNumber: 9}
+	c := lint.Issue{RuleID: "TA002", NodeName: "Charge", Message: "m"}
+
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("Fingerprint should not depend on file location")
+	}
+	if Fingerprint(a) == Fingerprint(c) {
+		t.Error("Fingerprint should differ between rules")
+	}
+	if !strings.HasPrefix(Fingerprint(a), FingerprintPrefix) || len(Fingerprint(a)) != len(FingerprintPrefix)+12 {
+		t.Errorf("Unexpected fingerprint format: %q", Fingerprint(a))
+	}
+}
+
+func TestFilerFile(t *testing.T) {
+	fake := newFakeTracker()
+	filer := NewFiler(fake, Options{RootDir: "/repo"}, testLogger())
+
+	summary, err := filer.File(context.Background(), testResult(), nil)
+	if err != nil {
+		t.Fatalf("File failed: %v", err)
+	}
+	if len(summary.Created) != 2 || len(summary.Updated) != 0 {
+		t.Errorf("Expected 2 created tickets (duplicates and warnings skipped), got %+v", summary)
+	}
+
+	// A second run updates the existing tickets instead of creating new ones
+	summary, err = filer.File(context.Background(), testResult(), nil)
+	if err != nil {
+		t.Fatalf("File failed: %v", err)
+	}
+	if len(summary.Created) != 0 || len(summary.Updated) != 2 {
+		t.Errorf("Expected 2 updated tickets, got %+v", summary)
+	}
+	if len(fake.tickets) != 2 {
+		t.Errorf("Expected 2 tickets in tracker, got %d", len(fake.tickets))
+	}
+}
+
+func TestFilerFileBaseline(t *testing.T) {
+	fake := newFakeTracker()
+	filer := NewFiler(fake, Options{}, testLogger())
+	baseline := &lint.Result{Issues: []lint.Issue{
+		{RuleID: "TA001", Severity: lint.SeverityError, Message: "Activity 'Charge' has unlimited retries", NodeName: "Charge"},
+	}}
+
+	summary, err := filer.File(context.Background(), testResult(), baseline)
+	if err != nil {
+		t.Fatalf("File failed: %v", err)
+	}
+	if len(summary.Created) != 1 {
+		t.Fatalf("Expected only the new error to be filed, got %+v", summary)
+	}
+	if ticket := fake.tickets[summary.Created[0]]; !strings.Contains(ticket.Title, "TA002") {
+		t.Errorf("Expected TA002 ticket, got %q", ticket.Title)
+	}
+}
+
+func TestFilerFileErrors(t *testing.T) {
+	fake := newFakeTracker()
+	fake.failOn = "TA002"
+	filer := NewFiler(fake, Options{}, testLogger())
+
+	summary, err := filer.File(context.Background(), testResult(), nil)
+	if err == nil {
+		t.Fatal("Expected error when a ticket fails")
+	}
+	if summary.Failed != 1 || len(summary.Created) != 1 {
+		t.Errorf("Expected 1 failed and 1 created ticket, got %+v", summary)
+	}
+}
+
+func TestFilerTicket(t *testing.T) {
+	filer := NewFiler(newFakeTracker(), Options{RootDir: "/repo", LinkBase: "https://github.com/org/repo/blob/abc123/"}, testLogger())
+	issue := lint.Issue{
+		RuleID:     "TA001",
+		RuleName:   "activity-unlimited-retry",
+		Severity:   lint.SeverityError,
+		Message:    "Activity 'Charge' has unlimited retries",
+		Suggestion: "Set RetryPolicy.MaximumAttempts",
+		NodeName:   "Charge",
+		FilePath:   "/repo/payments/charge.go",
+		LineNumber: 12,
+	}
+
+	ticket := filer.Ticket(issue)
+	if ticket.Title != "[TA001] Activity 'Charge' has unlimited retries" {
+		t.Errorf("Unexpected title %q", ticket.Title)
+	}
+	for _, want := range []string{
+		"[payments/charge.go:12](https://github.com/org/repo/blob/abc123/payments/charge.go#L12)",
+		"**Suggested fix:** Set RetryPolicy.MaximumAttempts",
+		ticket.Fingerprint,
+	} {
+		if !strings.Contains(ticket.Body, want) {
+			t.Errorf("Ticket body missing %q:\n%s", want, ticket.Body)
+		}
+	}
+	if len(ticket.Labels) != 2 || ticket.Labels[1] != ticket.Fingerprint {
+		t.Errorf("Expected fingerprint label, got %v", ticket.Labels)
+	}
+}
+
+func TestNew(t *testing.T) {
+	env := map[string]string{
+		"GITHUB_TOKEN":   "gh-token",
+		"JIRA_URL":       "https://example.atlassian.net",
+		"JIRA_EMAIL":     "ci@example.com",
+		"JIRA_API_TOKEN": "jira-token",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	tests := []struct {
+		spec    string
+		getenv  func(string) string
+		want    string
+		wantErr bool
+	}{
+		{spec: "github:org/repo", getenv: getenv, want: "github"},
+		{spec: "jira:PAY", getenv: getenv, want: "jira"},
+		{spec: "github:org", getenv: getenv, wantErr: true},
+		{spec: "github:org/repo", getenv: func(string) string { return "" }, wantErr: true},
+		{spec: "jira:PAY", getenv: func(string) string { return "" }, wantErr: true},
+		{spec: "gitlab:org/repo", getenv: getenv, wantErr: true},
+		{spec: "github", getenv: getenv, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			tr, err := New(tt.spec, tt.getenv)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && tr.Name() != tt.want {
+				t.Errorf("New(%q).Name() = %q, want %q", tt.spec, tr.Name(), tt.want)
+			}
+		})
+	}
+}
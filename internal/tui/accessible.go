@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// viewNames are the spoken names of the views, announced when the view changes.
+var viewNames = map[string]string{
+	ViewList:    "List view",
+	ViewTree:    "Tree view",
+	ViewDetails: "Details view",
+	ViewStats:   "Statistics view",
+	ViewHelp:    "Help view",
+	ViewWalk:    "Walk view",
+	ViewGraph:   "Graph view",
+}
+
+// describeView describes the current view for the accessible status line,
+// e.g. "Tree view, 12 items" or "Details view, workflow OrderWorkflow".
+func describeView(state *State) string {
+	name, ok := viewNames[state.CurrentView]
+	if !ok {
+		name = state.CurrentView + " view"
+	}
+
+	switch state.CurrentView {
+	case ViewList:
+		return fmt.Sprintf("%s, %s", name, pluralItems(len(state.List.Items())))
+	case ViewTree:
+		if state.TreeState != nil {
+			return fmt.Sprintf("%s, %s", name, pluralItems(len(state.TreeState.Items)))
+		}
+	case ViewDetails, ViewWalk:
+		if node := state.SelectedNode; node != nil {
+			return fmt.Sprintf("%s, %s %s", name, node.Type, node.Name)
+		}
+	}
+	return name
+}
+
+func pluralItems(n int) string {
+	if n == 1 {
+		return "1 item"
+	}
+	return fmt.Sprintf("%d items", n)
+}
+
+// renderStatusLine renders the accessible status line: the last view change followed by
+// the status message, as plain text.
+func renderStatusLine(state *State) string {
+	parts := []string{"Status:"}
+	if state.Announcement != "" {
+		parts = append(parts, state.Announcement+".")
+	}
+	if state.StatusMessage != "" {
+		parts = append(parts, state.StatusMessage)
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderAccessibleTreeItem renders a tree row as plain text: a ">" before the selected row,
+// the kind and name, and whether it is expanded or collapsed, e.g.
+// "> workflow OrderWorkflow, 3 children, expanded".
+func renderAccessibleTreeItem(item TreeItem, isSelected bool) string {
+	var line strings.Builder
+	line.WriteString(strings.Repeat("  ", item.Depth))
+
+	switch {
+	case item.Cluster != nil:
+		line.WriteString("family " + item.DisplayText)
+	case item.IsQueue:
+		line.WriteString("task queue " + item.DisplayText)
+	case item.Node == nil && item.CallType != "":
+		line.WriteString(callLabel(item.CallType) + " " + item.DisplayText)
+	case item.Node == nil:
+		displayName := item.DisplayText
+		if displayName == "" {
+			displayName = "(root)"
+		}
+		line.WriteString("package " + displayName)
+	default:
+		displayName := item.Node.Name
+		if item.DisplayText != "" {
+			displayName = item.DisplayText
+		}
+		nodeType := item.Node.Type
+		if item.CallType != "" {
+			nodeType = callLabel(item.CallType)
+		}
+		line.WriteString(nodeType + " " + displayName)
+	}
+
+	if item.HasChildren {
+		if item.ChildCount == 1 {
+			line.WriteString(", 1 child")
+		} else if item.ChildCount > 1 {
+			line.WriteString(fmt.Sprintf(", %d children", item.ChildCount))
+		}
+		if item.IsExpanded {
+			line.WriteString(", expanded")
+		} else {
+			line.WriteString(", collapsed")
+		}
+	}
+	return accessibleRow(line.String(), isSelected)
+}
+
+// accessibleRow prefixes a selectable row with ">" when selected, or with spaces to align it.
+func accessibleRow(text string, isSelected bool) string {
+	if isSelected {
+		return "> " + text
+	}
+	return "  " + text
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAccessibleModel(t *testing.T) {
+	styles := NewStyleManager()
+	m := NewModel(createTestGraph(), NewViewManager(styles, NewFilterManager()), NewNavigator(), styles, NewFilterManager()).(*model)
+	m.setAccessible(true, 1)
+
+	if m.state.Announcement != "List view, 2 items" {
+		t.Errorf("Announcement = %q, want the initial list view", m.state.Announcement)
+	}
+	list := m.View()
+	if !strings.Contains(list, "> ⚡ MainWorkflow") || strings.Contains(list, "▀") {
+		t.Errorf("List should mark the selection with > and have no gradient:\n%s", list)
+	}
+	if !strings.HasSuffix(list, "Status: List view, 2 items.") {
+		t.Errorf("List should end with the status line:\n%s", list)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if m.state.Announcement != "Tree view, 2 items" {
+		t.Errorf("Announcement = %q, want the tree view announced", m.state.Announcement)
+	}
+
+	tree := m.View()
+	for _, want := range []string{
+		"> workflow MainWorkflow, 2 children, collapsed\n\n  workflow OrphanWorkflow\n",
+		"Status: Tree view, 2 items.",
+	} {
+		if !strings.Contains(tree, want) {
+			t.Errorf("Tree should contain %q:\n%s", want, tree)
+		}
+	}
+	if strings.ContainsAny(tree, "▀▶▼") {
+		t.Errorf("Tree should have no gradient or expansion glyphs:\n%s", tree)
+	}
+
+	// Moving within a view is not announced again
+	m.state.Announcement = ""
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if m.state.Announcement != "" {
+		t.Errorf("Announcement = %q, want none without a view change", m.state.Announcement)
+	}
+}
+
+func TestRenderAccessibleTreeItem(t *testing.T) {
+	graph := createTestGraph()
+	tests := []struct {
+		item     TreeItem
+		selected bool
+		want     string
+	}{
+		{TreeItem{Node: graph.Nodes["MainWorkflow"], HasChildren: true, ChildCount: 2, IsExpanded: true}, true,
+			"> workflow MainWorkflow, 2 children, expanded"},
+		{TreeItem{Node: graph.Nodes["ChildWorkflow"], Depth: 1, HasChildren: true, ChildCount: 1}, false,
+			"    workflow ChildWorkflow, 1 child, collapsed"},
+		{TreeItem{DisplayText: "workflows", HasChildren: true, ChildCount: 3}, false,
+			"  package workflows, 3 children, collapsed"},
+		{TreeItem{DisplayText: "orders", HasChildren: true, ChildCount: 2, IsExpanded: true, IsQueue: true}, false,
+			"  task queue orders, 2 children, expanded"},
+	}
+	for _, tt := range tests {
+		if got := renderAccessibleTreeItem(tt.item, tt.selected); got != tt.want {
+			t.Errorf("renderAccessibleTreeItem() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/tui/theme"
+)
+
+// breadcrumbPrefix starts the breadcrumb bar.
+const breadcrumbPrefix = "📍 "
+
+// breadcrumbStart returns the index of the first breadcrumb shown for a path: the last
+// MaxBreadcrumbs breadcrumbs, fewer if their names don't fit in width. The current
+// breadcrumb is always shown.
+func breadcrumbStart(path []PathItem, width int) int {
+	if len(path) == 0 {
+		return 0
+	}
+	start := len(path) - 1
+	used := lipgloss.Width(breadcrumbPrefix) + lipgloss.Width(breadcrumbText(path, start, 1))
+	for start > 0 && len(path)-start < MaxBreadcrumbs {
+		// Room for the hidden count, e.g. "+12 … ", is kept while breadcrumbs are hidden
+		next := lipgloss.Width(breadcrumbText(path, start-1, len(path)-start+1))
+		hidden := 0
+		if start > 1 {
+			hidden = lipgloss.Width(fmt.Sprintf("+%d … ", start-1))
+		}
+		if used+next+hidden > width-2 {
+			break
+		}
+		start--
+		used += next
+	}
+	return start
+}
+
+// breadcrumbText renders the breadcrumb at index of a path, numbered n: the direction it
+// was reached in, then its number and name, e.g. "→ 2 Charge".
+func breadcrumbText(path []PathItem, index, n int) string {
+	item := path[index]
+	text := fmt.Sprintf("%d %s", n, item.DisplayName)
+	if item.Direction != "" {
+		text = item.Direction + " " + text
+	}
+	if index > 0 {
+		text = " " + text
+	}
+	return text
+}
+
+// renderBreadcrumb renders the navigation path in one line of width, for every view
+// showing the graph: the breadcrumbs that fit, numbered from 1 for jumping to them with
+// Alt+1…9, with the count of earlier ones hidden. It is empty without a path.
+func renderBreadcrumb(state *State, t *theme.Theme, width int) string {
+	if state.Navigator == nil || !state.ShowBreadcrumb {
+		return ""
+	}
+	path := state.Navigator.GetPath()
+	if len(path) == 0 {
+		return ""
+	}
+
+	pathStyle := lipgloss.NewStyle().
+		Background(t.Overlay).
+		Foreground(t.Subtle).
+		Padding(0, 1).
+		MaxWidth(width)
+	currentStyle := lipgloss.NewStyle().
+		Background(t.Overlay).
+		Foreground(t.Text).
+		Bold(true)
+
+	start := breadcrumbStart(path, width)
+	var b strings.Builder
+	b.WriteString(breadcrumbPrefix)
+	if start > 0 {
+		fmt.Fprintf(&b, "+%d … ", start)
+	}
+	for i := start; i < len(path); i++ {
+		text := breadcrumbText(path, i, i-start+1)
+		if i == start {
+			text = strings.TrimPrefix(text, " ")
+		}
+		if i == len(path)-1 {
+			text = currentStyle.Render(text)
+		}
+		b.WriteString(text)
+	}
+	return pathStyle.Render(b.String())
+}
+
+// handleBreadcrumbJump returns to the details of the breadcrumb numbered n in the
+// breadcrumb bar, from any view showing it.
+func (m *model) handleBreadcrumbJump(n int) (tea.Model, tea.Cmd) {
+	path := m.navigator.GetPath()
+	width := m.state.WindowWidth
+	if width < 40 {
+		width = 80
+	}
+	index := breadcrumbStart(path, width) + n - 1
+	if index >= len(path) || (index == len(path)-1 && m.state.CurrentView == ViewDetails) {
+		return m, nil
+	}
+	viewState, ok := m.navigator.JumpTo(index)
+	if !ok {
+		return m, nil
+	}
+
+	m.state.PreviousView = m.state.CurrentView
+	m.restoreState(viewState)
+	m.state.StatusMessage = fmt.Sprintf("Back at %s", viewState.SelectedNode.Name)
+	m.state.StatusType = StatusInfo
+	return m, nil
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/tui/theme"
+)
+
+func TestRenderBreadcrumb(t *testing.T) {
+	nav := NewNavigator()
+	state := &State{Navigator: nav, ShowBreadcrumb: true}
+	if got := renderBreadcrumb(state, theme.DefaultTheme(), 80); got != "" {
+		t.Errorf("renderBreadcrumb() = %q, want nothing without a path", got)
+	}
+
+	nav.AddToPath(&analyzer.TemporalNode{Name: "OrderWorkflow"}, DirectionStart)
+	nav.AddToPath(&analyzer.TemporalNode{Name: "ChargeCard"}, DirectionCalls)
+	nav.AddToPath(&analyzer.TemporalNode{Name: "RefundWorkflow"}, DirectionCalledBy)
+	if got := renderBreadcrumb(state, theme.DefaultTheme(), 80); !strings.Contains(got, "📍 📁 1 OrderWorkflow → 2 ChargeCard ← 3 RefundWorkflow") {
+		t.Errorf("renderBreadcrumb() = %q, want all breadcrumbs numbered", got)
+	}
+
+	for i := 0; i < 12; i++ {
+		nav.AddToPath(&analyzer.TemporalNode{Name: fmt.Sprintf("Step%d", i)}, DirectionCalls)
+	}
+	wide := renderBreadcrumb(state, theme.DefaultTheme(), 200)
+	if !strings.Contains(wide, "📍 +6 … → 1 Step3 → ") || !strings.Contains(wide, "→ 9 Step11") {
+		t.Errorf("renderBreadcrumb() = %q, want the last %d breadcrumbs", wide, MaxBreadcrumbs)
+	}
+	narrow := renderBreadcrumb(state, theme.DefaultTheme(), 60)
+	if start := breadcrumbStart(nav.GetPath(), 60); start <= 6 || !strings.Contains(narrow, fmt.Sprintf("+%d … ", start)) {
+		t.Errorf("renderBreadcrumb() = %q, want fewer breadcrumbs in a narrow window", narrow)
+	}
+	if !strings.Contains(narrow, "Step11") {
+		t.Errorf("renderBreadcrumb() = %q, want the current breadcrumb shown", narrow)
+	}
+}
+
+func TestBreadcrumbsAcrossViews(t *testing.T) {
+	styles := NewStyleManager()
+	m := NewModel(createTestGraph(), NewViewManager(styles, NewFilterManager()), NewNavigator(), styles, NewFilterManager()).(*model)
+	m.handleWindowResize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	// Each key is followed by rendering, which builds the details sections
+	press := func(msg tea.KeyMsg) {
+		m.Update(msg)
+		m.View()
+	}
+	key := func(k string) {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+	alt := func(k string) {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k), Alt: true})
+	}
+
+	// MainWorkflow, then its second call, ChildWorkflow, then its activity
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	key("j")
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state.CurrentView != ViewDetails || m.state.SelectedNode.Name != "ProcessActivity" {
+		t.Fatalf("Expected the details of ProcessActivity, got %s %v", m.state.CurrentView, m.state.SelectedNode)
+	}
+
+	// The breadcrumbs stay in the other views
+	for _, view := range []string{"1", "2", "3"} {
+		key(view)
+		if out := m.View(); !strings.Contains(out, "1 MainWorkflow → 2 ChildWorkflow → 3 ProcessActivity") {
+			t.Errorf("View %s lost the breadcrumbs:\n%s", m.state.CurrentView, out)
+		}
+	}
+
+	// Jumping returns to the details with the selection made there
+	alt("1")
+	if m.state.CurrentView != ViewDetails || m.state.SelectedNode.Name != "MainWorkflow" {
+		t.Fatalf("Expected the details of MainWorkflow, got %s %v", m.state.CurrentView, m.state.SelectedNode)
+	}
+	if m.state.DetailsState.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want the ChildWorkflow call selected", m.state.DetailsState.SelectedIndex)
+	}
+	if path := m.navigator.GetPath(); len(path) != 1 {
+		t.Errorf("Path = %+v, want only MainWorkflow", path)
+	}
+
+	// Going back leaves the breadcrumb's details for the list it was opened from
+	key("q")
+	if m.state.CurrentView != ViewList || len(m.navigator.GetPath()) != 0 {
+		t.Errorf("Expected the list without breadcrumbs, got %s %+v", m.state.CurrentView, m.navigator.GetPath())
+	}
+}
@@ -0,0 +1,257 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// MinClusterSize is the fewest nodes a family groups.
+const MinClusterSize = 2
+
+// roleWords are trailing name words naming the node's role rather than its family, so that
+// OrderWorkflow and OrderActivity both belong to Order*.
+var roleWords = map[string]bool{
+	"Workflow": true, "Workflows": true, "Activity": true, "Activities": true,
+	"Signal": true, "Query": true, "Update": true, "Handler": true,
+}
+
+// Cluster is a family of nodes whose names share a prefix (Order*) or a suffix (*Order).
+type Cluster struct {
+	Label string // "Order*" or "*Order"; "" for the nodes of no family
+	Nodes []*analyzer.TemporalNode
+}
+
+// ClusterStats summarizes a family.
+type ClusterStats struct {
+	Workflows  int
+	Activities int
+	Other      int
+	CallsOut   int // Calls of nodes outside the family
+	CallsIn    int // Calls from nodes outside the family
+	Tested     int
+}
+
+// ClusterNodes groups nodes into families by the CamelCase words of their names: first by
+// the leading word, labelled with the longest word prefix the family shares, then the rest
+// by the trailing word. Families are ordered largest first and keep the order of nodes.
+// Nodes of no family of at least MinClusterSize are returned as ungrouped.
+func ClusterNodes(nodes []*analyzer.TemporalNode) (clusters []Cluster, ungrouped []*analyzer.TemporalNode) {
+	words := make(map[*analyzer.TemporalNode][]string, len(nodes))
+	for _, node := range nodes {
+		words[node] = familyWords(node.Name)
+	}
+
+	// Prefix families
+	var rest []*analyzer.TemporalNode
+	for _, group := range groupBy(nodes, func(n *analyzer.TemporalNode) string { return words[n][0] }) {
+		if len(group) < MinClusterSize {
+			rest = append(rest, group...)
+			continue
+		}
+		prefix := words[group[0]]
+		for _, node := range group[1:] {
+			prefix = commonPrefix(prefix, words[node])
+		}
+		clusters = append(clusters, Cluster{Label: strings.Join(prefix, "") + "*", Nodes: group})
+	}
+
+	// Suffix families of the remaining multi-word names
+	rest = inOrder(rest, nodes)
+	for _, group := range groupBy(rest, func(n *analyzer.TemporalNode) string {
+		if w := words[n]; len(w) > 1 {
+			return w[len(w)-1]
+		}
+		return ""
+	}) {
+		last := words[group[0]]
+		if len(group) < MinClusterSize || len(last) < 2 {
+			ungrouped = append(ungrouped, group...)
+			continue
+		}
+		clusters = append(clusters, Cluster{Label: "*" + last[len(last)-1], Nodes: group})
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if len(clusters[i].Nodes) != len(clusters[j].Nodes) {
+			return len(clusters[i].Nodes) > len(clusters[j].Nodes)
+		}
+		return clusters[i].Label < clusters[j].Label
+	})
+	return clusters, inOrder(ungrouped, nodes)
+}
+
+// Stats counts the node types of the family and its calls across the family boundary.
+func (c Cluster) Stats() ClusterStats {
+	members := make(map[string]bool, len(c.Nodes))
+	for _, node := range c.Nodes {
+		members[node.ID()] = true
+	}
+
+	var s ClusterStats
+	for _, node := range c.Nodes {
+		switch node.Type {
+		case "workflow":
+			s.Workflows++
+		case "activity":
+			s.Activities++
+		default:
+			s.Other++
+		}
+		if node.IsTested() {
+			s.Tested++
+		}
+		for _, call := range node.CallSites {
+			if !members[call.TargetName] {
+				s.CallsOut++
+			}
+		}
+		for _, parent := range node.Parents {
+			if !members[parent] {
+				s.CallsIn++
+			}
+		}
+	}
+	return s
+}
+
+// String formats the stats for a family header.
+func (s ClusterStats) String() string {
+	var counts []string
+	if s.Workflows > 0 {
+		counts = append(counts, fmt.Sprintf("%d workflows", s.Workflows))
+	}
+	if s.Activities > 0 {
+		counts = append(counts, fmt.Sprintf("%d activities", s.Activities))
+	}
+	if s.Other > 0 {
+		counts = append(counts, fmt.Sprintf("%d other", s.Other))
+	}
+	total := s.Workflows + s.Activities + s.Other
+	return fmt.Sprintf("%s │ %d calls out, %d in │ %d/%d tested",
+		strings.Join(counts, ", "), s.CallsOut, s.CallsIn, s.Tested, total)
+}
+
+// ClusterItem is a family header in the grouped list.
+type ClusterItem struct {
+	Cluster Cluster
+}
+
+// FilterValue implements list.Item interface.
+func (ci ClusterItem) FilterValue() string {
+	return ci.Cluster.Label
+}
+
+// Title implements list.Item interface.
+func (ci ClusterItem) Title() string {
+	if ci.Cluster.Label == "" {
+		return fmt.Sprintf("▤ Ungrouped (%d)", len(ci.Cluster.Nodes))
+	}
+	return fmt.Sprintf("▤ %s (%d)", ci.Cluster.Label, len(ci.Cluster.Nodes))
+}
+
+// Description implements list.Item interface.
+func (ci ClusterItem) Description() string {
+	return "family │ " + ci.Cluster.Stats().String()
+}
+
+// groupListItems orders list items by family, each family after its header item.
+func groupListItems(items []list.Item) []list.Item {
+	nodes := make([]*analyzer.TemporalNode, 0, len(items))
+	for _, item := range items {
+		if li, ok := item.(ListItem); ok {
+			nodes = append(nodes, li.Node)
+		}
+	}
+	clusters, ungrouped := ClusterNodes(nodes)
+	if len(ungrouped) > 0 {
+		clusters = append(clusters, Cluster{Nodes: ungrouped})
+	}
+
+	grouped := make([]list.Item, 0, len(nodes)+len(clusters))
+	for _, cluster := range clusters {
+		grouped = append(grouped, ClusterItem{Cluster: cluster})
+		for _, node := range cluster.Nodes {
+			grouped = append(grouped, ListItem{Node: node})
+		}
+	}
+	return grouped
+}
+
+// familyWords splits the name of a node into CamelCase words, without the receiver type of
+// methods and without trailing role words. Acronyms stay one word (HTTPFetch is HTTP, Fetch).
+func familyWords(name string) []string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		boundary := i == len(runes) || runes[i] == '_' ||
+			unicode.IsUpper(runes[i]) && (!unicode.IsUpper(runes[i-1]) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1]))
+		if !boundary {
+			continue
+		}
+		if word := strings.Trim(string(runes[start:i]), "_"); word != "" {
+			words = append(words, word)
+		}
+		start = i
+	}
+
+	for len(words) > 1 && roleWords[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	if len(words) == 0 {
+		return []string{name}
+	}
+	return words
+}
+
+// groupBy groups nodes by key in order of first appearance.
+func groupBy(nodes []*analyzer.TemporalNode, key func(*analyzer.TemporalNode) string) [][]*analyzer.TemporalNode {
+	index := make(map[string]int)
+	var groups [][]*analyzer.TemporalNode
+	for _, node := range nodes {
+		k := key(node)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], node)
+	}
+	return groups
+}
+
+// commonPrefix returns the words a and b start with.
+func commonPrefix(a, b []string) []string {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return a[:n]
+}
+
+// inOrder returns subset in the order its nodes appear in all.
+func inOrder(subset, all []*analyzer.TemporalNode) []*analyzer.TemporalNode {
+	keep := make(map[*analyzer.TemporalNode]bool, len(subset))
+	for _, node := range subset {
+		keep[node] = true
+	}
+	ordered := make([]*analyzer.TemporalNode, 0, len(subset))
+	for _, node := range all {
+		if keep[node] {
+			ordered = append(ordered, node)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFamilyWords(t *testing.T) {
+	tests := map[string][]string{
+		"OrderCreateWorkflow":        {"Order", "Create"},
+		"*Activities.ChargeCard":     {"Charge", "Card"},
+		"HTTPFetchActivity":          {"HTTP", "Fetch"},
+		"sync_inventory":             {"sync", "inventory"},
+		"Workflow":                   {"Workflow"},
+		"RefundPaymentSignalHandler": {"Refund", "Payment"},
+	}
+	for name, want := range tests {
+		if got := familyWords(name); !reflect.DeepEqual(got, want) {
+			t.Errorf("familyWords(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestClusterNodes(t *testing.T) {
+	var nodes []*analyzer.TemporalNode
+	for _, name := range []string{
+		"OrderCreateWorkflow", "OrderCancelWorkflow", "OrderCreateActivity",
+		"PaymentRefundWorkflow", "PaymentRefundActivity",
+		"ShipParcel", "TrackParcel", "Cleanup",
+	} {
+		nodes = append(nodes, &analyzer.TemporalNode{Name: name, Type: "workflow"})
+	}
+	nodes[0].CallSites = []analyzer.CallSite{{TargetName: "OrderCreateActivity"}, {TargetName: "PaymentRefundWorkflow"}}
+	nodes[2].Type = "activity"
+	nodes[2].Parents = []string{"OrderCreateWorkflow"}
+
+	clusters, ungrouped := ClusterNodes(nodes)
+	var labels []string
+	for _, c := range clusters {
+		labels = append(labels, c.Label)
+	}
+	if want := []string{"Order*", "*Parcel", "PaymentRefund*"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("Labels = %v, want %v", labels, want)
+	}
+	if len(ungrouped) != 1 || ungrouped[0].Name != "Cleanup" {
+		t.Errorf("Expected Cleanup ungrouped, got %v", ungrouped)
+	}
+
+	stats := clusters[0].Stats()
+	want := ClusterStats{Workflows: 2, Activities: 1, CallsOut: 1}
+	if stats != want {
+		t.Errorf("Stats = %+v, want %+v", stats, want)
+	}
+	if s := stats.String(); !strings.Contains(s, "2 workflows, 1 activities") || !strings.Contains(s, "1 calls out, 0 in") {
+		t.Errorf("Unexpected stats text %q", s)
+	}
+}
+
+func TestGroupedListAndTree(t *testing.T) {
+	graph := createTestGraph()
+	m := NewModel(graph, NewViewManager(NewStyleManager(), NewFilterManager()), NewNavigator(), NewStyleManager(), NewFilterManager()).(*model)
+	m.state.ShowActivities = true
+	m.updateFilteredItems()
+
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	items := m.state.List.Items()
+	if m.state.ListState.GroupBy != GroupByFamily || len(items) == 0 {
+		t.Fatalf("Expected a grouped list, got %d items", len(items))
+	}
+	headers := 0
+	for _, item := range items {
+		if _, ok := item.(ClusterItem); ok {
+			headers++
+		}
+	}
+	if headers == 0 || len(items) != len(m.state.AllItems)+headers {
+		t.Errorf("Expected every node once plus family headers, got %d items, %d headers", len(items), headers)
+	}
+
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	if m.state.ListState.GroupBy != GroupByNone || len(m.state.List.Items()) != len(m.state.AllItems) {
+		t.Errorf("Expected F to ungroup the list")
+	}
+
+	tv := NewTreeView(NewStyleManager()).(*treeView)
+	state := &State{Graph: graph, WindowWidth: 100, WindowHeight: 40}
+	tv.buildTreeItems(state)
+	state, _ = tv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")}, state)
+	if state.TreeState.GroupBy != GroupByFamily || state.TreeState.Items[0].Cluster == nil {
+		t.Fatalf("Expected family headers in the tree, got %+v", state.TreeState.Items)
+	}
+	collapsed := len(state.TreeState.Items)
+	state, _ = tv.Update(tea.KeyMsg{Type: tea.KeyEnter}, state)
+	if len(state.TreeState.Items) <= collapsed {
+		t.Errorf("Expected enter to expand the family")
+	}
+	if view := tv.Render(state); !strings.Contains(view, "BY FAMILY") {
+		t.Errorf("Expected the family title in the tree")
+	}
+}
@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// EdgeExplanation collects everything known about a single call edge.
+type EdgeExplanation struct {
+	Caller     *analyzer.TemporalNode
+	Call       analyzer.CallSite
+	Target     *analyzer.TemporalNode // nil when the target is not in the graph
+	SourceLine string                 // Trimmed source line of the call, empty if unreadable
+	Options    []string               // Options in effect, as "Name: value"
+	Issues     []lint.Issue           // Lint issues reported at the call site
+	ArgsStatus string                 // "match", "mismatch" or "unchecked"
+	ArgsDetail string
+}
+
+// explainEdge builds the explanation of a call from caller, given the lint issues of the graph.
+func explainEdge(graph *analyzer.TemporalGraph, caller *analyzer.TemporalNode, call analyzer.CallSite, issues []lint.Issue) *EdgeExplanation {
+	e := &EdgeExplanation{
+		Caller:     caller,
+		Call:       call,
+		Target:     graph.Nodes[call.TargetName],
+		SourceLine: readSourceLine(call.FilePath, call.LineNumber),
+		Options:    describeCallOptions(call),
+	}
+
+	for _, issue := range issues {
+		if issue.FilePath == call.FilePath && issue.LineNumber == call.LineNumber && call.LineNumber > 0 {
+			e.Issues = append(e.Issues, issue)
+		}
+	}
+
+	check, checked := lint.CheckArguments(call, e.Target)
+	switch {
+	case e.Target == nil || e.Target.Unresolved:
+		e.ArgsStatus = "unchecked"
+		e.ArgsDetail = "target not found in the analyzed code"
+	case !checked:
+		e.ArgsStatus = "unchecked"
+		e.ArgsDetail = fmt.Sprintf("arguments are not checked for %s targets", e.Target.Type)
+	case check.Expected != call.ArgumentCount:
+		e.ArgsStatus = "mismatch"
+		e.ArgsDetail = fmt.Sprintf("%d argument(s) passed, but %s expects %d", call.ArgumentCount, e.Target.Signature(), check.Expected)
+	case check.Position > 0:
+		e.ArgsStatus = "mismatch"
+		e.ArgsDetail = fmt.Sprintf("argument %d is '%s', but %s expects '%s'", check.Position, check.ArgType, e.Target.Signature(), check.ParamType)
+	default:
+		e.ArgsStatus = "match"
+		e.ArgsDetail = fmt.Sprintf("%d argument(s) match %s", call.ArgumentCount, e.Target.Signature())
+	}
+
+	return e
+}
+
+// readSourceLine returns the trimmed text of a 1-based line, or "" if it cannot be read.
+func readSourceLine(filePath string, line int) string {
+	if filePath == "" || line <= 0 {
+		return ""
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if n == line {
+			return strings.TrimSpace(scanner.Text())
+		}
+	}
+	return ""
+}
+
+// describeCallOptions lists the options parsed at the call site.
+func describeCallOptions(call analyzer.CallSite) []string {
+	var opts []string
+	if o := call.ParsedActivityOpts; o != nil {
+		for _, setting := range o.Settings() {
+			opts = append(opts, setting.Name+": "+setting.Value)
+		}
+		if len(o.InheritedFrom) > 0 {
+			opts = append(opts, "Inherited from: "+strings.Join(o.InheritedFrom, ", "))
+		}
+		if o.CallerDependent {
+			opts = append(opts, "Options depend on the caller's context")
+		}
+	}
+	if len(opts) == 0 && len(call.Options) > 0 {
+		opts = append(opts, call.Options...)
+	}
+	return opts
+}
+
+// graphIssues lints the graph once and caches the result.
+func (dv *detailsView) graphIssues(graph *analyzer.TemporalGraph) []lint.Issue {
+	if dv.lintGraph != graph {
+		dv.lintGraph = graph
+		dv.lintIssues = lint.NewLinter(lint.DefaultConfig()).Run(context.Background(), graph).Issues
+	}
+	return dv.lintIssues
+}
+
+// selectedCall returns the call site of the selected item, if it is a callee.
+func selectedCall(state *State) *analyzer.CallSite {
+	ds := state.DetailsState
+	if ds == nil || ds.SelectedIndex >= len(ds.SelectableItems) {
+		return nil
+	}
+	return ds.SelectableItems[ds.SelectedIndex].Call
+}
+
+// toggleExplain opens or closes the explainer panel for the selected call.
+func (dv *detailsView) toggleExplain(state *State) {
+	if state.DetailsState == nil {
+		return
+	}
+	if state.DetailsState.Explain != nil {
+		state.DetailsState.Explain = nil
+		return
+	}
+	call := selectedCall(state)
+	if call == nil {
+		state.StatusMessage = "Select a call to explain"
+		state.StatusType = StatusWarning
+		return
+	}
+	state.DetailsState.Explain = explainEdge(state.Graph, state.SelectedNode, *call, dv.graphIssues(state.Graph))
+}
+
+// refreshExplain keeps an open explainer panel in sync with the selection.
+func (dv *detailsView) refreshExplain(state *State) {
+	if state.DetailsState.Explain == nil {
+		return
+	}
+	call := selectedCall(state)
+	if call == nil {
+		state.DetailsState.Explain = nil
+		return
+	}
+	state.DetailsState.Explain = explainEdge(state.Graph, state.SelectedNode, *call, dv.graphIssues(state.Graph))
+}
+
+// renderExplainPanel renders the explainer panel for a call edge.
+func (dv *detailsView) renderExplainPanel(e *EdgeExplanation, width int) string {
+	t := dv.styles.GetTheme()
+	boxStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(t.Timer).
+		Padding(0, 1).
+		Width(width - 4)
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(t.Timer).
+		Bold(true)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(t.Subtle).
+		Width(12)
+
+	valueStyle := lipgloss.NewStyle().
+		Foreground(t.Text)
+
+	codeStyle := lipgloss.NewStyle().
+		Foreground(t.Tertiary).
+		Background(t.Surface)
+
+	emptyStyle := lipgloss.NewStyle().
+		Foreground(t.Subtle).
+		Italic(true)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("🔎 Explain %s → %s", e.Caller.Name, e.Call.TargetName)) + "\n\n")
+
+	callType := e.Call.CallType
+	if e.Call.TargetType != "" {
+		callType += " (" + e.Call.TargetType + ")"
+	}
+	content.WriteString(labelStyle.Render("Call type:") + valueStyle.Render(callType) + "\n")
+	content.WriteString(labelStyle.Render("Location:") + valueStyle.Render(fmt.Sprintf("%s:%d", e.Call.FilePath, e.Call.LineNumber)) + "\n")
+	if e.SourceLine != "" {
+		content.WriteString(labelStyle.Render("Code:") + codeStyle.Render(e.SourceLine) + "\n")
+	} else {
+		content.WriteString(labelStyle.Render("Code:") + emptyStyle.Render("source unavailable") + "\n")
+	}
+
+	content.WriteString("\n" + labelStyle.Render("Options:"))
+	if len(e.Options) == 0 {
+		content.WriteString(emptyStyle.Render("none detected") + "\n")
+	} else {
+		content.WriteString("\n")
+		for _, opt := range e.Options {
+			content.WriteString("  • " + valueStyle.Render(opt) + "\n")
+		}
+	}
+
+	content.WriteString("\n" + labelStyle.Render("Lint:"))
+	if len(e.Issues) == 0 {
+		content.WriteString(lipgloss.NewStyle().Foreground(t.Success).Render("no issues at this call site") + "\n")
+	} else {
+		content.WriteString("\n")
+		for _, issue := range e.Issues {
+			severityStyle := lipgloss.NewStyle().Foreground(t.SeverityColor(string(issue.Severity)))
+			content.WriteString("  " + severityStyle.Render(issue.RuleID) +
+				" " + valueStyle.Render(issue.Message) + "\n")
+		}
+	}
+
+	argsColor := t.Subtle
+	argsIcon := "–"
+	switch e.ArgsStatus {
+	case "match":
+		argsColor, argsIcon = t.Success, "✓"
+	case "mismatch":
+		argsColor, argsIcon = t.Error, "✗"
+	}
+	content.WriteString("\n" + labelStyle.Render("Arguments:") +
+		lipgloss.NewStyle().Foreground(argsColor).Render(argsIcon+" "+e.ArgsDetail) + "\n")
+
+	return boxStyle.Render(content.String())
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestExplainEdge(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "order.go")
+	src := "package orders\n\nfunc OrderWorkflow(ctx workflow.Context) error {\n\treturn workflow.ExecuteActivity(ctx, Charge, amount).Get(ctx, nil)\n}\n"
+	if err := os.WriteFile(file, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	call := analyzer.CallSite{
+		TargetName: "Charge", TargetType: "activity", CallType: "execute",
+		FilePath: file, LineNumber: 4, ArgumentCount: 1,
+		ParsedActivityOpts: &analyzer.ActivityOptions{
+			StartToCloseTimeout: "time.Minute",
+			RetryPolicy:         &analyzer.RetryPolicy{MaximumAttempts: 3},
+		},
+	}
+	caller := &analyzer.TemporalNode{Name: "OrderWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{call}}
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": caller,
+		"Charge": {
+			Name: "Charge", Type: "activity",
+			Parameters:     map[string]string{"ctx": "context.Context", "amount": "int"},
+			ParameterTypes: []string{"context.Context", "int"},
+		},
+	}}
+
+	e := explainEdge(graph, caller, call, nil)
+	if e.SourceLine != "return workflow.ExecuteActivity(ctx, Charge, amount).Get(ctx, nil)" {
+		t.Errorf("Unexpected source line %q", e.SourceLine)
+	}
+	if strings.Join(e.Options, "; ") != "StartToCloseTimeout: time.Minute; RetryPolicy.MaximumAttempts: 3" {
+		t.Errorf("Unexpected options %v", e.Options)
+	}
+	if e.ArgsStatus != "match" {
+		t.Errorf("Expected arguments to match, got %s: %s", e.ArgsStatus, e.ArgsDetail)
+	}
+
+	issues := []lint.Issue{
+		{RuleID: "TA040", Message: "Call to 'Charge' passes 1 argument(s), but activity 'Charge' expects 2", FilePath: file, LineNumber: 4},
+		{RuleID: "TA001", Message: "elsewhere", FilePath: file, LineNumber: 9},
+	}
+	if e = explainEdge(graph, caller, call, issues); len(e.Issues) != 1 {
+		t.Errorf("Expected the issue at the call site, got %+v", e.Issues)
+	}
+
+	// Arguments are compared with the signature, whether or not TA040 reported the call
+	call.ArgumentCount = 2
+	if e = explainEdge(graph, caller, call, nil); e.ArgsStatus != "mismatch" || !strings.Contains(e.ArgsDetail, "expects 1") {
+		t.Errorf("Expected an argument count mismatch, got %s: %s", e.ArgsStatus, e.ArgsDetail)
+	}
+	call.ArgumentCount, call.ArgumentTypes = 1, []string{"string"}
+	if e = explainEdge(graph, caller, call, nil); e.ArgsStatus != "mismatch" || !strings.Contains(e.ArgsDetail, "argument 1 is 'string'") {
+		t.Errorf("Expected an argument type mismatch, got %s: %s", e.ArgsStatus, e.ArgsDetail)
+	}
+	graph.Nodes["Charge"].Type = "signal_handler"
+	if e = explainEdge(graph, caller, call, nil); e.ArgsStatus != "unchecked" {
+		t.Errorf("Expected a signal handler target to be unchecked, got %s: %s", e.ArgsStatus, e.ArgsDetail)
+	}
+
+	call.TargetName = "Missing"
+	if e = explainEdge(graph, caller, call, nil); e.ArgsStatus != "unchecked" || e.Target != nil {
+		t.Errorf("Expected unresolved target to be unchecked, got %+v", e)
+	}
+}
+
+func TestDetailsViewExplainToggle(t *testing.T) {
+	graph := createTestGraph()
+	dv := NewDetailsView(NewStyleManager()).(*detailsView)
+	state := &State{Graph: graph, SelectedNode: graph.Nodes["MainWorkflow"], CurrentView: ViewDetails, WindowWidth: 100, Navigator: NewNavigator()}
+	state.DetailsState = dv.buildDetailsState(state)
+
+	x := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+	state, _ = dv.Update(x, state)
+	if state.DetailsState.Explain == nil || state.DetailsState.Explain.Call.TargetName != "ProcessActivity" {
+		t.Fatalf("Expected explainer for ProcessActivity, got %+v", state.DetailsState.Explain)
+	}
+	if !strings.Contains(dv.Render(state), "Explain MainWorkflow → ProcessActivity") {
+		t.Error("Expected explainer panel in rendered view")
+	}
+
+	// Moving the selection follows the next call
+	state, _ = dv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}, state)
+	if state.DetailsState.Explain == nil || state.DetailsState.Explain.Call.TargetName != "ChildWorkflow" {
+		t.Errorf("Expected explainer to follow selection, got %+v", state.DetailsState.Explain)
+	}
+
+	state, _ = dv.Update(x, state)
+	if state.DetailsState.Explain != nil {
+		t.Error("Expected x to close the explainer")
+	}
+}
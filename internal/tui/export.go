@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/output"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exportDoneMsg reports the files written by a subtree export.
+type exportDoneMsg struct {
+	files []string
+	err   error
+}
+
+// exportSubtree returns a command exporting the selected subtree of the tree view as a
+// Graphviz bundle named after its root in dir.
+func exportSubtree(state *State, dir string) (tea.Cmd, error) {
+	graph, root := treeSubgraph(state)
+	if graph == nil {
+		return nil, errors.New("nothing to export: select a node or expand a group")
+	}
+	base := filepath.Join(dir, "temporal-subtree-"+fileSafe(root))
+	return func() tea.Msg {
+		files, err := output.NewExporter().ExportGraphvizBundle(context.Background(), graph, base)
+		return exportDoneMsg{files: files, err: err}
+	}, nil
+}
+
+// applyExportDone reports the result of an export in the status line.
+func applyExportDone(state *State, msg exportDoneMsg) {
+	switch {
+	case errors.Is(msg.err, output.ErrGraphvizNotFound):
+		state.StatusMessage = fmt.Sprintf("Exported %s; %v", strings.Join(msg.files, ", "), msg.err)
+		state.StatusType = StatusWarning
+	case msg.err != nil:
+		state.StatusMessage = "Export failed: " + msg.err.Error()
+		state.StatusType = StatusError
+	default:
+		state.StatusMessage = "Exported " + strings.Join(msg.files, ", ")
+		state.StatusType = StatusSuccess
+	}
+}
+
+// treeSubgraph returns the subtree of the tree view rooted at the selected item as drawn:
+// its visible nodes, the calls between them shown by the tree, and for collapsed nodes the
+// count of the nodes hidden under them. Group headers, timers and signals sent are left out. It returns nil if the
+// subtree shows no nodes, along with the name of its root.
+func treeSubgraph(state *State) (*analyzer.TemporalGraph, string) {
+	ts := state.TreeState
+	if ts == nil || ts.SelectedIndex >= len(ts.Items) {
+		return nil, ""
+	}
+	root := ts.Items[ts.SelectedIndex]
+	rootName := root.DisplayText
+	if root.Node != nil {
+		rootName = root.Node.Name
+	}
+
+	graph := &analyzer.TemporalGraph{Nodes: make(map[string]*analyzer.TemporalNode)}
+	// ancestors holds the node shown at each depth on the way to the current item
+	var ancestors []*analyzer.TemporalNode
+	for i := ts.SelectedIndex; i < len(ts.Items); i++ {
+		item := ts.Items[i]
+		if i > ts.SelectedIndex && item.Depth <= root.Depth {
+			break
+		}
+		depth := item.Depth - root.Depth
+		if depth < len(ancestors) {
+			ancestors = ancestors[:depth]
+		}
+		for len(ancestors) <= depth {
+			ancestors = append(ancestors, nil)
+		}
+		if item.Node == nil {
+			continue
+		}
+
+		node, ok := graph.Nodes[item.Node.ID()]
+		if !ok {
+			copied := *item.Node
+			copied.CallSites, copied.Parents, copied.CalledBy = nil, nil, nil
+			copied.Collapsed = nil
+			if item.HasChildren && !item.IsExpanded {
+				copied.Collapsed = hiddenUnder(state.Graph, item.Node)
+			}
+			node = &copied
+			graph.Nodes[node.ID()] = node
+		}
+		ancestors[depth] = node
+
+		if depth > 0 && ancestors[depth-1] != nil {
+			linkTreeCall(ancestors[depth-1], node, item.Node, state.Graph)
+		}
+	}
+	if len(graph.Nodes) == 0 {
+		return nil, rootName
+	}
+	return graph, rootName
+}
+
+// linkTreeCall adds the calls of parent to node, as found in the analyzed graph, to the
+// copies drawn in the subgraph.
+func linkTreeCall(parent, node, original *analyzer.TemporalNode, graph *analyzer.TemporalGraph) {
+	source, ok := graph.Nodes[parent.ID()]
+	if !ok {
+		return
+	}
+	for _, call := range source.CallSites {
+		if call.TargetName != original.ID() || hasCall(parent.CallSites, call) {
+			continue
+		}
+		parent.CallSites = append(parent.CallSites, call)
+		node.Parents = append(node.Parents, parent.ID())
+	}
+}
+
+// hasCall reports whether calls holds a call of the same target and type.
+func hasCall(calls []analyzer.CallSite, call analyzer.CallSite) bool {
+	for _, c := range calls {
+		if c.TargetName == call.TargetName && c.CallType == call.CallType {
+			return true
+		}
+	}
+	return false
+}
+
+// hiddenUnder counts, by type, the distinct nodes a collapsed node reaches through its calls.
+func hiddenUnder(graph *analyzer.TemporalGraph, node *analyzer.TemporalNode) map[string]int {
+	seen := map[string]bool{node.ID(): true}
+	counts := make(map[string]int)
+	queue := []*analyzer.TemporalNode{node}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, call := range current.CallSites {
+			target, ok := graph.Nodes[call.TargetName]
+			if !ok || seen[target.ID()] {
+				continue
+			}
+			seen[target.ID()] = true
+			counts[target.Type]++
+			queue = append(queue, target)
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	return counts
+}
+
+// fileSafe turns a node name into a file name, e.g. *Activities.Charge into Activities-Charge.
+func fileSafe(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, name)
+	safe = strings.Trim(safe, "-")
+	if safe == "" {
+		return "tree"
+	}
+	return safe
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTreeSubgraph(t *testing.T) {
+	state := &State{Graph: createTestGraph(), TreeState: &TreeViewState{
+		ExpansionStates: map[string]bool{"MainWorkflow": true},
+		GroupBy:         "hierarchy",
+	}}
+	tv := NewTreeView(NewStyleManager()).(*treeView)
+	tv.buildTreeItems(state)
+	state.TreeState.SelectedIndex = 0
+
+	graph, root := treeSubgraph(state)
+	if root != "MainWorkflow" || graph == nil || len(graph.Nodes) != 3 {
+		t.Fatalf("Expected MainWorkflow and its 2 callees, got %s: %v", root, graph)
+	}
+	if _, ok := graph.Nodes["OrphanWorkflow"]; ok {
+		t.Error("Expected nodes outside the subtree to be left out")
+	}
+	if calls := graph.Nodes["MainWorkflow"].CallSites; len(calls) != 2 {
+		t.Errorf("Expected the 2 calls shown by the tree, got %+v", calls)
+	}
+	child := graph.Nodes["ChildWorkflow"]
+	if len(child.CallSites) != 0 || child.Collapsed["activity"] != 1 {
+		t.Errorf("Expected ChildWorkflow collapsed over 1 activity, got %+v, %v", child.CallSites, child.Collapsed)
+	}
+	if graph.Nodes["ProcessActivity"].Collapsed != nil {
+		t.Error("Expected leaves not to be collapsed")
+	}
+
+	// A node without callees is exported alone
+	state.TreeState.SelectedIndex = len(state.TreeState.Items) - 1
+	if graph, root := treeSubgraph(state); root != "OrphanWorkflow" || len(graph.Nodes) != 1 {
+		t.Errorf("Expected OrphanWorkflow alone, got %s: %v", root, graph)
+	}
+}
+
+func TestExportSubtreeKey(t *testing.T) {
+	// Without Graphviz the .dot file is written and the missing SVG reported
+	t.Setenv("PATH", t.TempDir())
+	dir := t.TempDir()
+	state := &State{Graph: createTestGraph()}
+	tv := &treeView{styles: NewStyleManager(), exportDir: dir}
+	tv.buildTreeItems(state)
+
+	state, cmd := tv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")}, state)
+	if cmd == nil {
+		t.Fatalf("Expected an export command, got status %q", state.StatusMessage)
+	}
+	applyExportDone(state, cmd().(exportDoneMsg))
+	if state.StatusType != StatusWarning {
+		t.Errorf("Expected a warning about the missing SVG, got %s: %q", state.StatusType, state.StatusMessage)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "temporal-subtree-MainWorkflow.dot")); err != nil {
+		t.Errorf("Expected the .dot file: %v", err)
+	}
+}
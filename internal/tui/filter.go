@@ -3,11 +3,17 @@ package tui
 import (
 	"strings"
 
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// QueryPrefix switches the filter box to advanced mode, where the rest of the
+// input is a query expression (e.g. "?type==workflow && fanout>5").
+const QueryPrefix = "?"
+
 // filterManager implements the FilterManager interface.
 type filterManager struct {
 	input    textinput.Model
@@ -35,6 +41,10 @@ func (fm *filterManager) ApplyFilter(items []list.Item, filter string) []list.It
 		return items
 	}
 
+	if strings.HasPrefix(filter, QueryPrefix) {
+		return fm.applyQuery(items, strings.TrimPrefix(filter, QueryPrefix))
+	}
+
 	filter = strings.ToLower(filter)
 	var filtered []list.Item
 
@@ -52,6 +62,12 @@ func (fm *filterManager) ApplyFilter(items []list.Item, filter string) []list.It
 				continue
 			}
 
+			// Check domain
+			if strings.Contains(strings.ToLower(li.Node.Domain), filter) {
+				filtered = append(filtered, item)
+				continue
+			}
+
 			// Check file path
 			if strings.Contains(strings.ToLower(li.Node.FilePath), filter) {
 				filtered = append(filtered, item)
@@ -75,6 +91,23 @@ func (fm *filterManager) ApplyFilter(items []list.Item, filter string) []list.It
 	return filtered
 }
 
+// applyQuery filters items with a query expression. Incomplete or invalid
+// expressions leave the items unfiltered so the list doesn't blank while typing.
+func (fm *filterManager) applyQuery(items []list.Item, expr string) []list.Item {
+	query, err := analyzer.ParseQuery(expr)
+	if err != nil {
+		return items
+	}
+
+	var filtered []list.Item
+	for _, item := range items {
+		if li, ok := item.(ListItem); ok && query.Match(li.Node) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
 // IsActive returns true if filtering is currently active.
 func (fm *filterManager) IsActive() bool {
 	return fm.active
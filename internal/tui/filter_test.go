@@ -94,6 +94,17 @@ func TestFilterManagerApplyFilter(t *testing.T) {
 			filter:        "nonexistent",
 			expectedCount: 0,
 		},
+		{
+			name:          "query mode",
+			filter:        "?type==activity && package=~'pay'",
+			expectedCount: 1,
+			expectedNames: []string{"PaymentActivity"},
+		},
+		{
+			name:          "incomplete query returns all",
+			filter:        "?type==",
+			expectedCount: 4,
+		},
 	}
 
 	for _, tt := range tests {
@@ -17,6 +17,11 @@ import (
 type TUI interface {
 	// Run starts the TUI with the given graph and blocks until the user exits.
 	Run(ctx context.Context, graph *analyzer.TemporalGraph) error
+
+	// RunAnalysis shows a loading screen while analyze runs, then the analyzed graph.
+	// Cancelling from the loading screen cancels the context passed to analyze; the nodes of
+	// the packages analyze passes to onPackage are listed while the analysis continues.
+	RunAnalysis(ctx context.Context, analyze func(ctx context.Context, onPackage func(analyzer.PackageResult)) (*analyzer.TemporalGraph, error)) error
 }
 
 // Model represents the application state for the TUI.
@@ -81,6 +86,10 @@ type Navigator interface {
 	// ClearPath clears the navigation path.
 	ClearPath()
 
+	// JumpTo returns to the breadcrumb at index of the path, dropping the states saved
+	// after it was reached, and returns the state showing it.
+	JumpTo(index int) (ViewState, bool)
+
 	// RenderPath renders the navigation path as a string.
 	RenderPath() string
 
@@ -0,0 +1,262 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/history"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/tui/theme"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxRecentPackages is how many finished packages the loading screen lists.
+const maxRecentPackages = 5
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// LoadingState tracks an analysis running behind the loading screen.
+type LoadingState struct {
+	Stage          string
+	FilesScanned   int
+	NodesFound     int
+	RecentPackages []string // Most recently finished packages, newest last
+	Started        time.Time
+	Frame          int
+	Dismissed      bool // The user is browsing partial results
+}
+
+// progressMsg carries an analysis progress event.
+type progressMsg analyzer.Progress
+
+// packageMsg carries the result of a parsed package.
+type packageMsg analyzer.PackageResult
+
+// analysisDoneMsg carries the result of the analysis.
+type analysisDoneMsg struct {
+	graph   *analyzer.TemporalGraph
+	err     error
+	history []history.Snapshot
+}
+
+// loadingTickMsg advances the loading spinner.
+type loadingTickMsg struct{}
+
+func loadingTick() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return loadingTickMsg{}
+	})
+}
+
+// handleLoadingKey handles keys while the loading screen is shown.
+func (m *model) handleLoadingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "esc", "q":
+		if m.cancel != nil {
+			m.cancel()
+		}
+		return m, tea.Quit
+	case "enter":
+		if len(m.state.Graph.Nodes) > 0 {
+			m.state.Loading.Dismissed = true
+			m.setLoadingStatus()
+		}
+	}
+	return m, nil
+}
+
+// applyProgress records a progress event.
+func (m *model) applyProgress(p analyzer.Progress) {
+	ls := m.state.Loading
+	if ls == nil {
+		return
+	}
+
+	ls.Stage = p.Stage
+	ls.FilesScanned = max(ls.FilesScanned, p.FilesScanned)
+	ls.NodesFound = max(ls.NodesFound, p.NodesFound)
+	if ls.Dismissed {
+		m.setLoadingStatus()
+	}
+}
+
+// applyPackage records a parsed package and adds its preliminary nodes to the views.
+func (m *model) applyPackage(r analyzer.PackageResult) {
+	ls := m.state.Loading
+	if ls == nil {
+		return
+	}
+
+	ls.Stage = analyzer.StageParse
+	ls.FilesScanned = max(ls.FilesScanned, r.FilesScanned)
+	ls.NodesFound = max(ls.NodesFound, r.NodesFound)
+	ls.RecentPackages = append(ls.RecentPackages, r.Package)
+	if len(ls.RecentPackages) > maxRecentPackages {
+		ls.RecentPackages = ls.RecentPackages[len(ls.RecentPackages)-maxRecentPackages:]
+	}
+
+	added := false
+	for _, node := range r.Nodes {
+		if _, exists := m.state.Graph.Nodes[node.ID()]; exists {
+			continue
+		}
+		m.state.Graph.Nodes[node.ID()] = node
+		m.state.AllItems = append(m.state.AllItems, ListItem{Node: node})
+		added = true
+	}
+	if added {
+		SortListItems(m.state.AllItems, m.state.ListState.SortBy)
+		m.updateFilteredItems()
+	}
+
+	if ls.Dismissed {
+		m.setLoadingStatus()
+	}
+}
+
+// setLoadingStatus shows analysis progress in the footer while browsing partial results.
+func (m *model) setLoadingStatus() {
+	ls := m.state.Loading
+	m.state.StatusMessage = fmt.Sprintf("Analyzing (%s): %d files, %d nodes so far", ls.Stage, ls.FilesScanned, len(m.state.Graph.Nodes))
+	m.state.StatusType = StatusInfo
+}
+
+// finishAnalysis replaces the partial graph with the analyzed one.
+func (m *model) finishAnalysis(msg analysisDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.analysisErr = msg.err
+		return m, tea.Quit
+	}
+
+	m.state.Loading = nil
+	if msg.history != nil {
+		m.state.History = msg.history
+	}
+	m.setGraph(msg.graph)
+	m.state.StatusMessage = fmt.Sprintf("Analysis complete: %d nodes", len(msg.graph.Nodes))
+	m.state.StatusType = StatusSuccess
+	if m.state.Accessible {
+		m.state.Announcement = describeView(m.state)
+	}
+	return m, nil
+}
+
+// setGraph swaps in a new graph, keeping the current view and selection where possible.
+func (m *model) setGraph(graph *analyzer.TemporalGraph) {
+	m.state.Graph = graph
+
+	allItems := make([]list.Item, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		allItems = append(allItems, ListItem{Node: node})
+	}
+	SortListItems(allItems, m.state.ListState.SortBy)
+	m.state.AllItems = allItems
+	m.updateFilteredItems()
+
+	if m.state.SelectedNode != nil {
+		m.state.SelectedNode = graph.Nodes[m.state.SelectedNode.ID()]
+		if m.state.SelectedNode == nil && m.state.CurrentView == ViewDetails {
+			m.state.CurrentView = ViewList
+			_ = m.viewManager.SwitchView(ViewList)
+		}
+	}
+	m.state.DetailsState = nil
+
+	if len(m.state.TreeState.Items) > 0 || m.state.CurrentView == ViewTree {
+		m.buildTreeItems()
+	}
+}
+
+// SortListItems sorts list items by one of the SortBy orders, then by node name.
+// Connections and churn sort busiest first; unknown orders sort by name.
+func SortListItems(items []list.Item, sortBy string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].(ListItem).Node, items[j].(ListItem).Node
+		switch sortBy {
+		case SortByType:
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+		case SortByPackage:
+			if a.Package != b.Package {
+				return a.Package < b.Package
+			}
+		case SortByConnections:
+			ca, cb := len(a.CallSites)+len(a.Parents), len(b.CallSites)+len(b.Parents)
+			if ca != cb {
+				return ca > cb
+			}
+		case SortByChurn:
+			if ca, cb := churnCommits(a), churnCommits(b); ca != cb {
+				return ca > cb
+			}
+		}
+		return a.Name < b.Name
+	})
+}
+
+// churnCommits returns the recent commits of a node, or -1 without churn data.
+func churnCommits(node *analyzer.TemporalNode) int {
+	if node.Churn == nil {
+		return -1
+	}
+	return node.Churn.Commits
+}
+
+// renderLoading renders the loading screen in theme t.
+func renderLoading(state *State, t *theme.Theme) string {
+	ls := state.Loading
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(t.Subtle).
+		Width(16)
+
+	valueStyle := lipgloss.NewStyle().
+		Foreground(t.Text)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(t.Subtle).
+		Italic(true)
+
+	stage := ls.Stage
+	if stage == "" {
+		stage = "starting"
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(spinnerFrames[ls.Frame%len(spinnerFrames)]+" Analyzing Temporal code") + "\n\n")
+	content.WriteString(labelStyle.Render("Stage:") + valueStyle.Render(stage) + "\n")
+	content.WriteString(labelStyle.Render("Files scanned:") + valueStyle.Render(fmt.Sprintf("%d", ls.FilesScanned)) + "\n")
+	content.WriteString(labelStyle.Render("Nodes found:") + valueStyle.Render(fmt.Sprintf("%d", max(ls.NodesFound, len(state.Graph.Nodes)))) + "\n")
+	content.WriteString(labelStyle.Render("Elapsed:") + valueStyle.Render(time.Since(ls.Started).Round(100*time.Millisecond).String()) + "\n")
+
+	if len(ls.RecentPackages) > 0 {
+		content.WriteString("\n" + dimStyle.Render("Recently parsed:") + "\n")
+		for _, pkg := range ls.RecentPackages {
+			content.WriteString("  • " + valueStyle.Render(pkg) + "\n")
+		}
+	}
+
+	hint := "esc cancel"
+	if len(state.Graph.Nodes) > 0 {
+		hint = "enter browse partial results • " + hint
+	}
+	content.WriteString("\n" + dimStyle.Render(hint))
+
+	box := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(1, 3).
+		Render(content.String())
+
+	return lipgloss.Place(state.WindowWidth, state.WindowHeight, lipgloss.Center, lipgloss.Center, box)
+}
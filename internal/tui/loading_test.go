@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newLoadingModel() *model {
+	styles := NewStyleManager()
+	filter := NewFilterManager()
+	m := NewModel(&analyzer.TemporalGraph{Nodes: make(map[string]*analyzer.TemporalNode)},
+		NewViewManager(styles, filter), NewNavigator(), styles, filter).(*model)
+	m.state.Loading = &LoadingState{Started: time.Now()}
+	return m
+}
+
+func TestLoadingProgressAndCompletion(t *testing.T) {
+	m := newLoadingModel()
+	if m.Init() == nil {
+		t.Error("Expected Init to start the spinner while loading")
+	}
+
+	m.Update(progressMsg(analyzer.Progress{Stage: analyzer.StageRegistrations}))
+	m.Update(packageMsg(analyzer.PackageResult{
+		Package: "orders", FilesScanned: 3, NodesFound: 1,
+		Nodes: []*analyzer.TemporalNode{{Name: "OrderWorkflow", Type: "workflow"}},
+	}))
+	if len(m.state.List.Items()) != 1 {
+		t.Fatalf("Expected the preliminary workflow in the list, got %d items", len(m.state.List.Items()))
+	}
+
+	view := m.View()
+	for _, want := range []string{"Analyzing", "orders", "parse", "browse partial results"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Loading screen missing %q", want)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.state.Loading.Dismissed {
+		t.Fatal("Expected enter to dismiss the loading screen")
+	}
+	if !strings.Contains(m.state.StatusMessage, "Analyzing") {
+		t.Errorf("Expected analysis status in the footer, got %q", m.state.StatusMessage)
+	}
+
+	final := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{{TargetName: "Charge"}}},
+		"Charge":        {Name: "Charge", Type: "activity", Parents: []string{"OrderWorkflow"}},
+	}}
+	m.Update(analysisDoneMsg{graph: final})
+	if m.state.Loading != nil {
+		t.Error("Expected loading state to be cleared")
+	}
+	if m.state.Graph != final || len(m.state.AllItems) != 2 {
+		t.Errorf("Expected the final graph with 2 items, got %d items", len(m.state.AllItems))
+	}
+
+	// Late package results must not modify the final graph
+	m.Update(packageMsg(analyzer.PackageResult{Nodes: []*analyzer.TemporalNode{{Name: "Stale"}}}))
+	if _, ok := m.state.Graph.Nodes["Stale"]; ok {
+		t.Error("Progress after completion was applied")
+	}
+}
+
+func TestLoadingCancel(t *testing.T) {
+	m := newLoadingModel()
+	cancelled := false
+	m.cancel = func() { cancelled = true }
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !cancelled {
+		t.Error("Expected esc to cancel the analysis")
+	}
+	if cmd == nil {
+		t.Fatal("Expected a quit command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected esc to quit")
+	}
+}
+
+func TestLoadingError(t *testing.T) {
+	m := newLoadingModel()
+	wantErr := errors.New("boom")
+
+	_, cmd := m.Update(analysisDoneMsg{err: wantErr})
+	if !errors.Is(m.analysisErr, wantErr) {
+		t.Errorf("analysisErr = %v, want %v", m.analysisErr, wantErr)
+	}
+	if cmd == nil {
+		t.Fatal("Expected a quit command")
+	}
+}
+
+func TestSortListItemsByChurn(t *testing.T) {
+	m := newLoadingModel()
+	m.state.Loading = nil
+	m.setGraph(&analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"AuditWorkflow":  {Name: "AuditWorkflow", Type: "workflow"},
+		"BillWorkflow":   {Name: "BillWorkflow", Type: "workflow", Churn: &analyzer.ChurnInfo{Commits: 1}},
+		"OrderWorkflow":  {Name: "OrderWorkflow", Type: "workflow", Churn: &analyzer.ChurnInfo{Commits: 9}},
+		"RefundWorkflow": {Name: "RefundWorkflow", Type: "workflow", Churn: &analyzer.ChurnInfo{Commits: 1}},
+	}})
+
+	m.setSort(SortByChurn)
+	var names []string
+	for _, item := range m.state.List.Items() {
+		names = append(names, item.(ListItem).Node.Name)
+	}
+	if got, want := strings.Join(names, ","), "OrderWorkflow,BillWorkflow,RefundWorkflow,AuditWorkflow"; got != want {
+		t.Errorf("List order = %s, want %s", got, want)
+	}
+	if m.state.ListState.SortBy != SortByChurn {
+		t.Errorf("SortBy = %q, want %q", m.state.ListState.SortBy, SortByChurn)
+	}
+}
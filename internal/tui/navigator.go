@@ -82,6 +82,39 @@ func (n *navigator) ClearPath() {
 	n.path = make([]PathItem, 0)
 }
 
+// JumpTo returns to the breadcrumb at index of the path. The states saved after the
+// breadcrumb was reached are dropped, so going back afterwards leaves it as usual; the
+// state saved when leaving it, with its selection, is returned if still on the stack.
+// It reports false for indexes out of the path.
+func (n *navigator) JumpTo(index int) (ViewState, bool) {
+	if index < 0 || index >= len(n.path) {
+		return ViewState{}, false
+	}
+
+	target := n.path[index]
+	state := ViewState{View: ViewDetails, SelectedNode: target.Node}
+	keep := len(n.stack)
+	for i := len(n.stack) - 1; i >= 0; i-- {
+		saved := n.stack[i]
+		if len(saved.NavPath) <= index {
+			break
+		}
+		if len(saved.NavPath) > index+1 {
+			keep = i
+			continue
+		}
+		if saved.View == ViewDetails && saved.SelectedNode == target.Node {
+			state, keep = saved, i
+			break
+		}
+	}
+	n.stack = n.stack[:keep]
+
+	n.path = n.path[:index+1]
+	state.NavPath = n.GetPath()
+	return state, true
+}
+
 // RenderPath renders the navigation path as a formatted string.
 func (n *navigator) RenderPath() string {
 	if len(n.path) == 0 {
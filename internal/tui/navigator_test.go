@@ -206,6 +206,37 @@ func TestNavigatorClearPath(t *testing.T) {
 	}
 }
 
+func TestNavigatorJumpTo(t *testing.T) {
+	nav := NewNavigator()
+	a := &analyzer.TemporalNode{Name: "A", Type: "workflow"}
+	b := &analyzer.TemporalNode{Name: "B", Type: "workflow"}
+	c := &analyzer.TemporalNode{Name: "C", Type: "activity"}
+
+	// List -> A -> B -> C, then the stats view
+	nav.PushState(ViewState{View: ViewList, ListIndex: 3})
+	nav.AddToPath(a, DirectionStart)
+	nav.PushState(ViewState{View: ViewDetails, SelectedNode: a, DetailsIndex: 2, NavPath: nav.GetPath()})
+	nav.AddToPath(b, DirectionCalls)
+	nav.PushState(ViewState{View: ViewDetails, SelectedNode: b, NavPath: nav.GetPath()})
+	nav.AddToPath(c, DirectionCalls)
+	nav.PushState(ViewState{View: ViewDetails, SelectedNode: c, NavPath: nav.GetPath()})
+
+	if _, ok := nav.JumpTo(3); ok {
+		t.Error("JumpTo past the path should fail")
+	}
+
+	state, ok := nav.JumpTo(0)
+	if !ok || state.View != ViewDetails || state.SelectedNode != a || state.DetailsIndex != 2 {
+		t.Fatalf("JumpTo(0) = %+v, %v, want the saved details of A", state, ok)
+	}
+	if len(state.NavPath) != 1 || len(nav.GetPath()) != 1 {
+		t.Errorf("Path = %+v, want only A", nav.GetPath())
+	}
+	if top, _ := nav.(*navigator).PeekState(); nav.GetDepth() != 1 || top.View != ViewList {
+		t.Errorf("Stack = %d states, top %+v, want the list A was opened from", nav.GetDepth(), top)
+	}
+}
+
 func TestNavigatorRenderPath(t *testing.T) {
 	nav := NewNavigator()
 
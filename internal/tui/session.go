@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Session is the TUI state kept between runs in the session file: the node type toggles
+// of the list view.
+type Session struct {
+	ShowWorkflows  bool `json:"show_workflows"`
+	ShowActivities bool `json:"show_activities"`
+	ShowSignals    bool `json:"show_signals"`
+	ShowQueries    bool `json:"show_queries"`
+	ShowUpdates    bool `json:"show_updates"`
+}
+
+// DefaultSessionFile returns the session file in the user's cache directory, or "" if
+// there is none.
+func DefaultSessionFile() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "temporal-analyzer", "session.json")
+}
+
+// LoadSession reads the session file at path. It returns nil without an error if the file
+// does not exist yet.
+func LoadSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse session file %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// SaveSession writes the session file at path, creating its directory. The file is
+// replaced in one step, so a TUI exiting mid-write leaves the previous session.
+func SaveSession(path string, s Session) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".session-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+	return nil
+}
+
+// sessionOf returns the session state of the TUI.
+func sessionOf(state *State) Session {
+	return Session{
+		ShowWorkflows:  state.ShowWorkflows,
+		ShowActivities: state.ShowActivities,
+		ShowSignals:    state.ShowSignals,
+		ShowQueries:    state.ShowQueries,
+		ShowUpdates:    state.ShowUpdates,
+	}
+}
+
+// apply restores the session state into the TUI state.
+func (s Session) apply(state *State) {
+	state.ShowWorkflows = s.ShowWorkflows
+	state.ShowActivities = s.ShowActivities
+	state.ShowSignals = s.ShowSignals
+	state.ShowQueries = s.ShowQueries
+	state.ShowUpdates = s.ShowUpdates
+}
+
+// restoreSession applies the session file of the TUI to m, leaving the defaults without one.
+func (t *tui) restoreSession(m *model) {
+	m.sessionFile = t.sessionFile
+	if t.sessionFile == "" {
+		return
+	}
+	s, err := LoadSession(t.sessionFile)
+	if err != nil {
+		t.logger.Warn("Failed to load session", "error", err)
+		return
+	}
+	if s != nil {
+		s.apply(m.state)
+		m.updateFilteredItems()
+	}
+}
+
+// saveSession writes the session state to the session file, if any, reporting a failure in
+// the status line.
+func (m *model) saveSession() {
+	if m.sessionFile == "" {
+		return
+	}
+	if err := SaveSession(m.sessionFile, sessionOf(m.state)); err != nil {
+		m.state.StatusMessage = err.Error()
+		m.state.StatusType = StatusWarning
+	}
+}
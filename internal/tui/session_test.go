@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "session.json")
+	if s, err := LoadSession(path); s != nil || err != nil {
+		t.Fatalf("LoadSession() of a missing file = %v, %v, want nil, nil", s, err)
+	}
+
+	want := Session{ShowWorkflows: true, ShowQueries: true, ShowUpdates: true}
+	if err := SaveSession(path, want); err != nil {
+		t.Fatalf("SaveSession failed: %v", err)
+	}
+	got, err := LoadSession(path)
+	if err != nil || got == nil || *got != want {
+		t.Errorf("LoadSession() = %+v, %v, want %+v", got, err, want)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSession(path); err == nil {
+		t.Error("Expected an error for a malformed session file")
+	}
+}
+
+func TestTypeTogglesPersist(t *testing.T) {
+	graph := createTestGraph()
+	graph.Nodes["StatusQuery"] = &analyzer.TemporalNode{Name: "StatusQuery", Type: "query_handler", Package: "workflows"}
+	graph.Nodes["Approve"] = &analyzer.TemporalNode{Name: "Approve", Type: "update_handler", Package: "workflows"}
+	app := NewTUIWithOptions(slog.Default(), Options{SessionFile: filepath.Join(t.TempDir(), "session.json")}).(*tui)
+	newModel := func() *model {
+		m := NewModel(graph, app.viewManager, NewNavigator(), app.styles, app.filter).(*model)
+		app.restoreSession(m)
+		m.handleWindowResize(tea.WindowSizeMsg{Width: 160, Height: 40})
+		return m
+	}
+	listed := func(m *model, name string) bool {
+		for _, item := range m.state.List.Items() {
+			if li, ok := item.(ListItem); ok && li.Node.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	m := newModel()
+	if listed(m, "StatusQuery") || listed(m, "Approve") {
+		t.Fatal("Expected queries and updates hidden by default")
+	}
+	for _, key := range []string{"Q", "u"} {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+	if !listed(m, "StatusQuery") || !listed(m, "Approve") {
+		t.Errorf("Expected Q and u to show queries and updates")
+	}
+	if header := m.View(); !strings.Contains(header, "❓Queries 1") || !strings.Contains(header, "🔄Updates 1") {
+		t.Errorf("Expected the header to count the shown types:\n%s", header)
+	}
+
+	// A new run starts with the saved toggles
+	m = newModel()
+	if !m.state.ShowQueries || !m.state.ShowUpdates || !listed(m, "StatusQuery") || !listed(m, "Approve") {
+		t.Errorf("Expected the toggles restored from the session file, got %+v", sessionOf(m.state))
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if listed(m, "Approve") {
+		t.Error("Expected u to hide updates again")
+	}
+}
@@ -33,7 +33,11 @@ type styleManager struct {
 
 // NewStyleManager creates a new StyleManager instance with the beautiful theme.
 func NewStyleManager() StyleManager {
-	t := theme.DefaultTheme()
+	return NewStyleManagerWithTheme(theme.DefaultTheme())
+}
+
+// NewStyleManagerWithTheme creates a new StyleManager instance rendering with t.
+func NewStyleManagerWithTheme(t *theme.Theme) StyleManager {
 	s := theme.NewStyles(t)
 	
 	return &styleManager{
@@ -41,7 +45,7 @@ func NewStyleManager() StyleManager {
 		styles: s,
 		
 		headerStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff")).
+			Foreground(t.Bright).
 			Background(t.Surface).
 			Bold(true).
 			Padding(0, 2).
@@ -53,7 +57,7 @@ func NewStyleManager() StyleManager {
 			Padding(0, 1),
 
 		highlightStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#0d1117")).
+			Foreground(t.Base).
 			Background(t.Selection).
 			Bold(true),
 
@@ -63,13 +67,13 @@ func NewStyleManager() StyleManager {
 			Padding(0, 1),
 
 		errorStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff")).
+			Foreground(t.Bright).
 			Background(t.Error).
 			Bold(true).
 			Padding(0, 1),
 
 		successStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff")).
+			Foreground(t.Bright).
 			Background(t.Success).
 			Bold(true).
 			Padding(0, 1),
@@ -130,7 +134,7 @@ func (s *styleManager) Header(text string) string {
 
 	// Build the header
 	header := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
+		Foreground(s.theme.Bright).
 		Background(s.theme.Surface).
 		Bold(true).
 		Padding(0, 1).
@@ -149,7 +153,7 @@ func (s *styleManager) HeaderWithWidth(text string, width int) string {
 	headerText := fmt.Sprintf(" %s %s ", icon, text)
 
 	header := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
+		Foreground(s.theme.Bright).
 		Background(s.theme.Surface).
 		Bold(true).
 		Padding(0, 1).
@@ -293,41 +297,12 @@ func (s *styleManager) Subtitle(text string) string {
 
 // NodeBadge renders a badge for a node type with appropriate color.
 func (s *styleManager) NodeBadge(nodeType string) string {
-	var badge lipgloss.Style
-	var icon, label string
-
-	switch nodeType {
-	case "workflow":
-		badge = s.styles.WorkflowBadge
-		icon = theme.NodeIcon(nodeType, s.useNerdFonts)
-		label = "WORKFLOW"
-	case "activity":
-		badge = s.styles.ActivityBadge
-		icon = theme.NodeIcon(nodeType, s.useNerdFonts)
-		label = "ACTIVITY"
-	case "signal", "signal_handler":
-		badge = s.styles.SignalBadge
-		icon = theme.NodeIcon(nodeType, s.useNerdFonts)
-		label = "SIGNAL"
-	case "query", "query_handler":
-		badge = s.styles.QueryBadge
-		icon = theme.NodeIcon(nodeType, s.useNerdFonts)
-		label = "QUERY"
-	case "update", "update_handler":
-		badge = s.styles.UpdateBadge
-		icon = theme.NodeIcon(nodeType, s.useNerdFonts)
-		label = "UPDATE"
-	case "timer":
-		badge = s.styles.TimerBadge
-		icon = theme.NodeIcon(nodeType, s.useNerdFonts)
-		label = "TIMER"
-	default:
-		badge = s.styles.WorkflowBadge
-		icon = "?"
-		label = strings.ToUpper(nodeType)
+	badge := s.styles.NodeBadge(nodeType)
+	kind := theme.NodeKind(nodeType)
+	if kind == "" {
+		return badge.Render(fmt.Sprintf("? %s", strings.ToUpper(nodeType)))
 	}
-
-	return badge.Render(fmt.Sprintf("%s %s", icon, label))
+	return badge.Render(fmt.Sprintf("%s %s", theme.NodeIcon(nodeType, s.useNerdFonts), strings.ToUpper(kind)))
 }
 
 // NodeIcon returns the icon for a node type.
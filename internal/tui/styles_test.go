@@ -190,6 +190,7 @@ func TestStyleManagerNodeBadge(t *testing.T) {
 		{"update", "UPDATE"},
 		{"update_handler", "UPDATE"},
 		{"timer", "TIMER"},
+		{"nexus_operation", "NEXUS"},
 		{"unknown", "UNKNOWN"},
 	}
 
@@ -3,6 +3,8 @@
 package theme
 
 import (
+	"sort"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -15,6 +17,8 @@ type Theme struct {
 	Muted      lipgloss.Color
 	Subtle     lipgloss.Color
 	Text       lipgloss.Color
+	TextMuted  lipgloss.Color // Secondary text, between Subtle and Text
+	Bright     lipgloss.Color // Text on headers and selected or colored backgrounds
 	
 	// Accent colors
 	Primary    lipgloss.Color
@@ -26,6 +30,8 @@ type Theme struct {
 	Warning    lipgloss.Color
 	Error      lipgloss.Color
 	Info       lipgloss.Color
+	// SuccessBackground is the background of confirmations, e.g. an applied filter
+	SuccessBackground lipgloss.Color
 	
 	// Temporal-specific colors
 	Workflow   lipgloss.Color
@@ -34,6 +40,11 @@ type Theme struct {
 	Query      lipgloss.Color
 	Update     lipgloss.Color
 	Timer      lipgloss.Color
+	Nexus      lipgloss.Color
+	// Call type colors telling local activities and child workflows from activities and
+	// workflows in the call hierarchy
+	LocalActivity lipgloss.Color
+	ChildWorkflow lipgloss.Color
 	
 	// UI element colors
 	Border     lipgloss.Color
@@ -55,6 +66,8 @@ func DefaultTheme() *Theme {
 		Muted:   lipgloss.Color("#484f58"),
 		Subtle:  lipgloss.Color("#6e7681"),
 		Text:    lipgloss.Color("#e6edf3"),
+		TextMuted: lipgloss.Color("#8b949e"),
+		Bright:  lipgloss.Color("#ffffff"),
 		
 		// Vibrant accent colors
 		Primary:   lipgloss.Color("#58a6ff"), // Electric blue
@@ -66,6 +79,7 @@ func DefaultTheme() *Theme {
 		Warning: lipgloss.Color("#d29922"),
 		Error:   lipgloss.Color("#f85149"),
 		Info:    lipgloss.Color("#58a6ff"),
+		SuccessBackground: lipgloss.Color("#238636"),
 		
 		// Temporal type colors - distinct and beautiful
 		Workflow: lipgloss.Color("#a371f7"), // Purple for workflows
@@ -74,6 +88,9 @@ func DefaultTheme() *Theme {
 		Query:    lipgloss.Color("#79c0ff"), // Blue for queries
 		Update:   lipgloss.Color("#ff7b72"), // Red for updates
 		Timer:    lipgloss.Color("#d2a8ff"), // Light purple for timers
+		Nexus:    lipgloss.Color("#39c5cf"), // Teal for Nexus operations
+		LocalActivity: lipgloss.Color("#d1e86b"), // Lime for local activities
+		ChildWorkflow: lipgloss.Color("#f778ba"), // Pink for child workflows
 		
 		// UI elements
 		Border:     lipgloss.Color("#30363d"),
@@ -95,6 +112,8 @@ func NeonTheme() *Theme {
 		Muted:   lipgloss.Color("#3a3a4a"),
 		Subtle:  lipgloss.Color("#5a5a6a"),
 		Text:    lipgloss.Color("#f0f0f5"),
+		TextMuted: lipgloss.Color("#9a9aaa"),
+		Bright:  lipgloss.Color("#ffffff"),
 		
 		Primary:   lipgloss.Color("#00ffff"), // Cyan
 		Secondary: lipgloss.Color("#ff00ff"), // Magenta
@@ -104,6 +123,7 @@ func NeonTheme() *Theme {
 		Warning: lipgloss.Color("#ffff00"),
 		Error:   lipgloss.Color("#ff0055"),
 		Info:    lipgloss.Color("#00ffff"),
+		SuccessBackground: lipgloss.Color("#008844"),
 		
 		Workflow: lipgloss.Color("#ff00ff"),
 		Activity: lipgloss.Color("#00ff88"),
@@ -111,6 +131,9 @@ func NeonTheme() *Theme {
 		Query:    lipgloss.Color("#00ffff"),
 		Update:   lipgloss.Color("#ff0055"),
 		Timer:    lipgloss.Color("#ff88ff"),
+		Nexus:    lipgloss.Color("#0088ff"),
+		LocalActivity: lipgloss.Color("#ccff00"),
+		ChildWorkflow: lipgloss.Color("#ff66aa"),
 		
 		Border:     lipgloss.Color("#2a2a3a"),
 		Selection:  lipgloss.Color("#00ffff"),
@@ -150,6 +173,7 @@ type Styles struct {
 	QueryBadge     lipgloss.Style
 	UpdateBadge    lipgloss.Style
 	TimerBadge     lipgloss.Style
+	NexusBadge     lipgloss.Style
 	
 	// Status styles
 	Success  lipgloss.Style
@@ -287,6 +311,12 @@ func NewStyles(theme *Theme) *Styles {
 		Padding(0, 1).
 		Bold(true)
 	
+	s.NexusBadge = lipgloss.NewStyle().
+		Foreground(theme.Base).
+		Background(theme.Nexus).
+		Padding(0, 1).
+		Bold(true)
+	
 	// Status styles
 	s.Success = lipgloss.NewStyle().
 		Foreground(theme.Success)
@@ -378,6 +408,11 @@ var Icons = struct {
 	Query       string
 	Update      string
 	Timer       string
+	Nexus       string
+	Node        string
+	ChildWorkflow string
+	LocalActivity string
+	SignalSent  string
 	Package     string
 	File        string
 	Line        string
@@ -414,6 +449,11 @@ var Icons = struct {
 	Query:        "󰘦",  // nf-md-help_circle
 	Update:       "󰁮",  // nf-md-update
 	Timer:        "󰔛",  // nf-md-timer
+	Nexus:        "󰖟",  // nf-md-web
+	Node:         "•",
+	ChildWorkflow: "󰘬",  // nf-md-source_branch
+	LocalActivity: "󰢻",  // nf-md-cog_outline
+	SignalSent:   "󰒊",  // nf-md-send
 	Package:      "󰏗",  // nf-md-package
 	File:         "󰈙",  // nf-md-file
 	Line:         "󰯂",  // nf-md-numeric
@@ -453,6 +493,11 @@ var FallbackIcons = struct {
 	Query       string
 	Update      string
 	Timer       string
+	Nexus       string
+	Node        string
+	ChildWorkflow string
+	LocalActivity string
+	SignalSent  string
 	Package     string
 	File        string
 	Line        string
@@ -473,9 +518,14 @@ var FallbackIcons = struct {
 	Workflow:     "⚡",
 	Activity:     "⚙",
 	Signal:       "🔔",
-	Query:        "?",
-	Update:       "↻",
+	Query:        "❓",
+	Update:       "🔄",
 	Timer:        "⏱",
+	Nexus:        "🌐",
+	Node:         "•",
+	ChildWorkflow: "⤷",
+	LocalActivity: "⌂",
+	SignalSent:   "📤",
 	Package:      "📦",
 	File:         "📄",
 	Line:         "#",
@@ -494,62 +544,223 @@ var FallbackIcons = struct {
 	Filter:       "~",
 }
 
-// NodeIcon returns the appropriate icon for a node type.
+// Node kinds are the groups of node types a theme styles alike: every call target and
+// node type maps to one of them, so a new type gets the icon and color of its kind
+// everywhere.
+const (
+	KindWorkflow = "workflow"
+	KindActivity = "activity"
+	KindSignal   = "signal"
+	KindQuery    = "query"
+	KindUpdate   = "update"
+	KindTimer    = "timer"
+	KindNexus    = "nexus"
+)
+
+// NodeKinds lists every node kind.
+var NodeKinds = []string{KindWorkflow, KindActivity, KindSignal, KindQuery, KindUpdate, KindTimer, KindNexus}
+
+// NodeKind returns the kind of a node or call target type, e.g. "signal" for
+// "signal_handler", "workflow" for "child_workflow" and "nexus" for "nexus_operation".
+// It returns "" for types of no kind, such as helpers.
+func NodeKind(nodeType string) string {
+	switch nodeType {
+	case "workflow", "child_workflow":
+		return KindWorkflow
+	case "activity", "local_activity":
+		return KindActivity
+	case "signal", "signal_handler":
+		return KindSignal
+	case "query", "query_handler":
+		return KindQuery
+	case "update", "update_handler":
+		return KindUpdate
+	case "timer":
+		return KindTimer
+	case "nexus", "nexus_operation":
+		return KindNexus
+	}
+	return ""
+}
+
+// NodeIcon returns the icon for a node type, or a bullet for types of no kind.
 func NodeIcon(nodeType string, nerdFonts bool) string {
 	if nerdFonts {
-		switch nodeType {
-		case "workflow":
+		switch NodeKind(nodeType) {
+		case KindWorkflow:
 			return Icons.Workflow
-		case "activity":
+		case KindActivity:
 			return Icons.Activity
-		case "signal", "signal_handler":
+		case KindSignal:
 			return Icons.Signal
-		case "query", "query_handler":
+		case KindQuery:
 			return Icons.Query
-		case "update", "update_handler":
+		case KindUpdate:
 			return Icons.Update
-		case "timer":
+		case KindTimer:
 			return Icons.Timer
-		default:
-			return Icons.Workflow
+		case KindNexus:
+			return Icons.Nexus
 		}
+		return Icons.Node
 	}
-	
-	switch nodeType {
-	case "workflow":
+
+	switch NodeKind(nodeType) {
+	case KindWorkflow:
 		return FallbackIcons.Workflow
-	case "activity":
+	case KindActivity:
 		return FallbackIcons.Activity
-	case "signal", "signal_handler":
+	case KindSignal:
 		return FallbackIcons.Signal
-	case "query", "query_handler":
+	case KindQuery:
 		return FallbackIcons.Query
-	case "update", "update_handler":
+	case KindUpdate:
 		return FallbackIcons.Update
-	case "timer":
+	case KindTimer:
 		return FallbackIcons.Timer
-	default:
-		return FallbackIcons.Workflow
+	case KindNexus:
+		return FallbackIcons.Nexus
 	}
+	return FallbackIcons.Node
 }
 
-// NodeColor returns the color for a node type from the theme.
+// NodeColor returns the color for a node type from the theme, Primary for types of no kind.
 func (t *Theme) NodeColor(nodeType string) lipgloss.Color {
-	switch nodeType {
-	case "workflow":
+	switch NodeKind(nodeType) {
+	case KindWorkflow:
 		return t.Workflow
-	case "activity":
+	case KindActivity:
 		return t.Activity
-	case "signal", "signal_handler":
+	case KindSignal:
 		return t.Signal
-	case "query", "query_handler":
+	case KindQuery:
 		return t.Query
-	case "update", "update_handler":
+	case KindUpdate:
 		return t.Update
-	case "timer":
+	case KindTimer:
 		return t.Timer
-	default:
-		return t.Primary
+	case KindNexus:
+		return t.Nexus
 	}
+	return t.Primary
 }
 
+// NodeBadge returns the badge style for a node type, the workflow badge for types of no kind.
+func (s *Styles) NodeBadge(nodeType string) lipgloss.Style {
+	switch NodeKind(nodeType) {
+	case KindActivity:
+		return s.ActivityBadge
+	case KindSignal:
+		return s.SignalBadge
+	case KindQuery:
+		return s.QueryBadge
+	case KindUpdate:
+		return s.UpdateBadge
+	case KindTimer:
+		return s.TimerBadge
+	case KindNexus:
+		return s.NexusBadge
+	}
+	return s.WorkflowBadge
+}
+
+// Call types are what a workflow does at a call site, each drawn with its own icon and
+// color in the call hierarchy: unlike node kinds, they tell local activities and child
+// workflows from activities and workflows.
+const (
+	CallActivity      = "activity"
+	CallLocalActivity = "local_activity"
+	CallChildWorkflow = "child_workflow"
+	CallSignal        = "signal"
+	CallTimer         = "timer"
+)
+
+// CallTypes lists every call type, in legend order.
+var CallTypes = []string{CallActivity, CallLocalActivity, CallChildWorkflow, CallSignal, CallTimer}
+
+// CallIcon returns the icon for a call type, the icon of its node type for other types.
+func CallIcon(callType string, nerdFonts bool) string {
+	switch callType {
+	case CallChildWorkflow:
+		if nerdFonts {
+			return Icons.ChildWorkflow
+		}
+		return FallbackIcons.ChildWorkflow
+	case CallLocalActivity:
+		if nerdFonts {
+			return Icons.LocalActivity
+		}
+		return FallbackIcons.LocalActivity
+	case CallSignal:
+		if nerdFonts {
+			return Icons.SignalSent
+		}
+		return FallbackIcons.SignalSent
+	}
+	return NodeIcon(callType, nerdFonts)
+}
+
+// CallColor returns the color for a call type from the theme, the color of its node type
+// for other types.
+func (t *Theme) CallColor(callType string) lipgloss.Color {
+	switch callType {
+	case CallChildWorkflow:
+		return t.ChildWorkflow
+	case CallLocalActivity:
+		return t.LocalActivity
+	}
+	return t.NodeColor(callType)
+}
+
+// StatusColor returns the color of a status message: "success", "warning" or "error",
+// Subtle for info.
+func (t *Theme) StatusColor(status string) lipgloss.Color {
+	switch status {
+	case "success":
+		return t.Success
+	case "warning":
+		return t.Warning
+	case "error":
+		return t.Error
+	}
+	return t.Subtle
+}
+
+// SeverityColor returns the color of a lint severity: "error" or "warning", Info for info.
+func (t *Theme) SeverityColor(severity string) lipgloss.Color {
+	switch severity {
+	case "error":
+		return t.Error
+	case "warning":
+		return t.Warning
+	}
+	return t.Info
+}
+
+// themes are the themes selectable by name with --theme.
+var themes = map[string]func() *Theme{
+	"default": DefaultTheme,
+	"neon":    NeonTheme,
+}
+
+// ByName returns the theme with a name of Names, the default theme for "".
+func ByName(name string) (*Theme, bool) {
+	if name == "" {
+		return DefaultTheme(), true
+	}
+	newTheme, ok := themes[name]
+	if !ok {
+		return nil, false
+	}
+	return newTheme(), true
+}
+
+// Names returns the names of the themes, sorted.
+func Names() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,6 +1,7 @@
 package theme
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/charmbracelet/lipgloss"
@@ -222,7 +223,9 @@ func TestNodeIconWithNerdFonts(t *testing.T) {
 		{"update", Icons.Update},
 		{"update_handler", Icons.Update},
 		{"timer", Icons.Timer},
-		{"unknown", Icons.Workflow}, // defaults to workflow
+		{"child_workflow", Icons.Workflow},
+		{"nexus_operation", Icons.Nexus},
+		{"unknown", Icons.Node}, // defaults to a bullet
 	}
 
 	for _, tt := range tests {
@@ -249,7 +252,9 @@ func TestNodeIconWithoutNerdFonts(t *testing.T) {
 		{"update", FallbackIcons.Update},
 		{"update_handler", FallbackIcons.Update},
 		{"timer", FallbackIcons.Timer},
-		{"unknown", FallbackIcons.Workflow}, // defaults to workflow
+		{"local_activity", FallbackIcons.Activity},
+		{"nexus_operation", FallbackIcons.Nexus},
+		{"unknown", FallbackIcons.Node}, // defaults to a bullet
 	}
 
 	for _, tt := range tests {
@@ -278,6 +283,7 @@ func TestThemeNodeColor(t *testing.T) {
 		{"update", theme.Update},
 		{"update_handler", theme.Update},
 		{"timer", theme.Timer},
+		{"nexus_operation", theme.Nexus},
 		{"unknown", theme.Primary}, // defaults to primary
 	}
 
@@ -441,3 +447,125 @@ func TestStylesWithDifferentThemes(t *testing.T) {
 	}
 }
 
+// TestThemeContract checks what the views rely on from every theme: all colors set, and a
+// distinct color and icon for each node kind.
+func TestThemeContract(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			theme, ok := ByName(name)
+			if !ok {
+				t.Fatalf("ByName(%q) found no theme", name)
+			}
+			v := reflect.ValueOf(*theme)
+			for i := 0; i < v.NumField(); i++ {
+				if v.Field(i).String() == "" {
+					t.Errorf("%s is not set", v.Type().Field(i).Name)
+				}
+			}
+
+			colors := make(map[lipgloss.Color]string)
+			for _, kind := range NodeKinds {
+				color := theme.NodeColor(kind)
+				if other, ok := colors[color]; ok {
+					t.Errorf("%s and %s share color %s", kind, other, color)
+				}
+				colors[color] = kind
+			}
+
+			// Call types, and the workflows calling them, are told apart in the hierarchy
+			callColors := map[lipgloss.Color]string{theme.NodeColor(KindWorkflow): KindWorkflow}
+			for _, callType := range CallTypes {
+				color := theme.CallColor(callType)
+				if other, ok := callColors[color]; ok {
+					t.Errorf("call type %s and %s share color %s", callType, other, color)
+				}
+				callColors[color] = callType
+			}
+		})
+	}
+
+	for _, nerdFonts := range []bool{false, true} {
+		icons := make(map[string]string)
+		for _, kind := range NodeKinds {
+			icon := NodeIcon(kind, nerdFonts)
+			if other, ok := icons[icon]; ok || icon == NodeIcon("helper", nerdFonts) {
+				t.Errorf("%s has the icon %q of %s (nerd fonts: %v)", kind, icon, other, nerdFonts)
+			}
+			icons[icon] = kind
+		}
+
+		callIcons := map[string]string{NodeIcon(KindWorkflow, nerdFonts): KindWorkflow}
+		for _, callType := range CallTypes {
+			icon := CallIcon(callType, nerdFonts)
+			if other, ok := callIcons[icon]; ok {
+				t.Errorf("call type %s has the icon %q of %s (nerd fonts: %v)", callType, icon, other, nerdFonts)
+			}
+			callIcons[icon] = callType
+		}
+	}
+}
+
+func TestNodeKind(t *testing.T) {
+	tests := []struct {
+		nodeType string
+		expected string
+	}{
+		{"workflow", KindWorkflow},
+		{"child_workflow", KindWorkflow},
+		{"local_activity", KindActivity},
+		{"signal_handler", KindSignal},
+		{"query_handler", KindQuery},
+		{"update", KindUpdate},
+		{"update_handler", KindUpdate},
+		{"timer", KindTimer},
+		{"nexus_operation", KindNexus},
+		{"helper", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NodeKind(tt.nodeType); got != tt.expected {
+			t.Errorf("NodeKind(%q) = %q, want %q", tt.nodeType, got, tt.expected)
+		}
+	}
+}
+
+func TestStatusAndSeverityColors(t *testing.T) {
+	theme := DefaultTheme()
+	statuses := map[string]lipgloss.Color{
+		"success": theme.Success,
+		"warning": theme.Warning,
+		"error":   theme.Error,
+		"info":    theme.Subtle,
+	}
+	for status, expected := range statuses {
+		if got := theme.StatusColor(status); got != expected {
+			t.Errorf("StatusColor(%q) = %q, want %q", status, got, expected)
+		}
+	}
+	severities := map[string]lipgloss.Color{
+		"error":   theme.Error,
+		"warning": theme.Warning,
+		"info":    theme.Info,
+	}
+	for severity, expected := range severities {
+		if got := theme.SeverityColor(severity); got != expected {
+			t.Errorf("SeverityColor(%q) = %q, want %q", severity, got, expected)
+		}
+	}
+}
+
+func TestByName(t *testing.T) {
+	if theme, ok := ByName("neon"); !ok || theme.Primary != NeonTheme().Primary {
+		t.Errorf("ByName(neon) = %v, %v, want the neon theme", theme, ok)
+	}
+	if theme, ok := ByName(""); !ok || theme.Primary != DefaultTheme().Primary {
+		t.Errorf("ByName(\"\") = %v, %v, want the default theme", theme, ok)
+	}
+	if _, ok := ByName("solarized"); ok {
+		t.Error("ByName(solarized) found a theme")
+	}
+	if names := Names(); !reflect.DeepEqual(names, []string{"default", "neon"}) {
+		t.Errorf("Names() = %v", names)
+	}
+}
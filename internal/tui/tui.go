@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"log/slog"
 	"sort"
+	"time"
+
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/history"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/tui/theme"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // tui implements the TUI interface.
@@ -19,12 +25,33 @@ type tui struct {
 	navigator   Navigator
 	styles      StyleManager
 	filter      FilterManager
+	history     history.Store // Optional snapshot history for the stats trend panel
+	sortBy      string        // Initial list order
+	accessible  bool          // Screen-reader friendly rendering
+	lineSpacing int           // Blank lines between list and tree rows
+	sessionFile string        // Session file keeping the list toggles between runs
+}
+
+// Options configures optional TUI features.
+type Options struct {
+	History history.Store // Snapshot history charted by the stats view
+	SortBy  string        // Initial list order, one of the SortBy constants (default: name)
+	// Accessible renders without colors or gradients, marks the selection with ">" and
+	// expansion with words, and announces view changes in a status line.
+	Accessible  bool
+	LineSpacing int    // Blank lines between list and tree rows
+	Theme       string // Color theme, one of theme.Names() (default: default)
+	SessionFile string // Keeps the list's node type toggles between runs (default: none)
 }
 
 // NewTUI creates a new TUI instance.
 func NewTUI(logger *slog.Logger) TUI {
+	return newTUI(logger, NewStyleManager())
+}
+
+// newTUI creates a new TUI instance whose views all render with styles.
+func newTUI(logger *slog.Logger, styles StyleManager) TUI {
 	navigator := NewNavigator()
-	styles := NewStyleManager()
 	filter := NewFilterManager()
 	viewManager := NewViewManager(styles, filter)
 
@@ -37,6 +64,26 @@ func NewTUI(logger *slog.Logger) TUI {
 	}
 }
 
+// NewTUIWithHistory creates a new TUI instance whose stats view charts the snapshots in store.
+func NewTUIWithHistory(logger *slog.Logger, store history.Store) TUI {
+	return NewTUIWithOptions(logger, Options{History: store})
+}
+
+// NewTUIWithOptions creates a new TUI instance with optional features.
+func NewTUIWithOptions(logger *slog.Logger, opts Options) TUI {
+	colors, ok := theme.ByName(opts.Theme)
+	if !ok {
+		colors = theme.DefaultTheme()
+	}
+	t := newTUI(logger, NewStyleManagerWithTheme(colors)).(*tui)
+	t.history = opts.History
+	t.sortBy = opts.SortBy
+	t.accessible = opts.Accessible
+	t.lineSpacing = opts.LineSpacing
+	t.sessionFile = opts.SessionFile
+	return t
+}
+
 // Run starts the TUI with the given graph and blocks until the user exits.
 func (t *tui) Run(ctx context.Context, graph *analyzer.TemporalGraph) error {
 	if graph == nil {
@@ -44,10 +91,14 @@ func (t *tui) Run(ctx context.Context, graph *analyzer.TemporalGraph) error {
 	}
 
 	// Create initial model
-	model := NewModel(graph, t.viewManager, t.navigator, t.styles, t.filter)
+	m := NewModel(graph, t.viewManager, t.navigator, t.styles, t.filter)
+	m.(*model).state.History = t.loadHistory(ctx)
+	m.(*model).setSort(t.sortBy)
+	t.restoreSession(m.(*model))
+	t.applyAccessibility(m.(*model))
 
 	// Create Bubble Tea program with alt screen for full terminal control
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	p := tea.NewProgram(m, tea.WithAltScreen())
 
 	// Run the program
 	if _, err := p.Run(); err != nil {
@@ -57,6 +108,101 @@ func (t *tui) Run(ctx context.Context, graph *analyzer.TemporalGraph) error {
 	return nil
 }
 
+// RunAnalysis shows a loading screen with live progress and the nodes of each package parsed
+// while analyze runs, then the graph.
+func (t *tui) RunAnalysis(ctx context.Context, analyze func(ctx context.Context, onPackage func(analyzer.PackageResult)) (*analyzer.TemporalGraph, error)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m := NewModel(&analyzer.TemporalGraph{Nodes: make(map[string]*analyzer.TemporalNode)},
+		t.viewManager, t.navigator, t.styles, t.filter).(*model)
+	m.state.Loading = &LoadingState{Started: time.Now()}
+	m.cancel = cancel
+	m.setSort(t.sortBy)
+	t.restoreSession(m)
+	t.applyAccessibility(m)
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+
+	// Progress and packages are forwarded in order, followed by the result, so no event lands
+	// after the final graph. Both are sent by the analysis one event at a time.
+	progress := make(chan analyzer.Progress)
+	packages := make(chan analyzer.PackageResult)
+	result := make(chan analysisDoneMsg, 1)
+	forwarded := make(chan struct{})
+	go func() {
+		graph, err := analyze(analyzer.WithProgress(ctx, progress), func(r analyzer.PackageResult) {
+			select {
+			case packages <- r:
+			case <-ctx.Done():
+			}
+		})
+		done := analysisDoneMsg{graph: graph, err: err}
+		if err == nil {
+			done.history = t.loadHistory(ctx)
+		}
+		result <- done
+		close(progress)
+		close(packages)
+	}()
+	go func() {
+		defer close(forwarded)
+		for progress != nil || packages != nil {
+			select {
+			case ev, ok := <-progress:
+				if !ok {
+					progress = nil
+					continue
+				}
+				p.Send(progressMsg(ev))
+			case r, ok := <-packages:
+				if !ok {
+					packages = nil
+					continue
+				}
+				p.Send(packageMsg(r))
+			}
+		}
+		p.Send(<-result)
+	}()
+
+	final, err := p.Run()
+	cancel()
+	<-forwarded
+	if err != nil {
+		return fmt.Errorf("failed to run TUI: %w", err)
+	}
+
+	if fm, ok := final.(*model); ok && fm.analysisErr != nil {
+		return fm.analysisErr
+	}
+	return nil
+}
+
+// applyAccessibility switches the model to accessible rendering when enabled. Colors are
+// dropped for the whole program, since lipgloss renders with a global color profile.
+func (t *tui) applyAccessibility(m *model) {
+	if !t.accessible && t.lineSpacing == 0 {
+		return
+	}
+	if t.accessible {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	m.setAccessible(t.accessible, t.lineSpacing)
+}
+
+// loadHistory loads recorded snapshots, or nil without a history store.
+func (t *tui) loadHistory(ctx context.Context) []history.Snapshot {
+	if t.history == nil {
+		return nil
+	}
+	snapshots, err := t.history.Load(ctx)
+	if err != nil {
+		t.logger.Warn("Failed to load history", "error", err)
+	}
+	return snapshots
+}
+
 // model implements the Model interface and serves as the main application model.
 type model struct {
 	state       *State
@@ -65,6 +211,9 @@ type model struct {
 	styles      StyleManager
 	filter      FilterManager
 	logger      *slog.Logger
+	cancel      context.CancelFunc // Cancels a running analysis
+	analysisErr error              // Analysis failure reported after the program exits
+	sessionFile string             // Where the node type toggles are saved, if anywhere
 }
 
 // NewModel creates a new model instance.
@@ -76,9 +225,7 @@ func NewModel(graph *analyzer.TemporalGraph, vm ViewManager, nav Navigator, styl
 	}
 	
 	// Sort all items by name for consistent ordering
-	sort.Slice(allItems, func(i, j int) bool {
-		return allItems[i].(ListItem).Node.Name < allItems[j].(ListItem).Node.Name
-	})
+	SortListItems(allItems, SortByName)
 
 	// Create initial list items - only top-level workflows (no parents)
 	// This shows the entry points into the workflow system
@@ -101,18 +248,8 @@ func NewModel(graph *analyzer.TemporalGraph, vm ViewManager, nav Navigator, styl
 		}
 	}
 
-	// Create custom list delegate with beautiful styling
-	delegate := list.NewDefaultDelegate()
-	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(styles.GetTheme().Text).
-		Background(styles.GetTheme().Selection).
-		Bold(true)
-	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-		Foreground(styles.GetTheme().Subtle).
-		Background(styles.GetTheme().Selection)
-
 	// Create list model with initial (filtered) items
-	listModel := list.New(initialItems, delegate, 80, 30)
+	listModel := list.New(initialItems, newListDelegate(styles, false, 0), 80, 30)
 	listModel.Title = ""
 	listModel.SetShowTitle(false)
 	listModel.SetShowStatusBar(true)
@@ -164,8 +301,50 @@ func NewModel(graph *analyzer.TemporalGraph, vm ViewManager, nav Navigator, styl
 	}
 }
 
+// newListDelegate creates the list delegate: themed selection colors, or in accessible mode
+// plain rows with a ">" before the selected title. lineSpacing adds blank lines between rows.
+func newListDelegate(styles StyleManager, accessible bool, lineSpacing int) list.DefaultDelegate {
+	delegate := list.NewDefaultDelegate()
+	delegate.SetSpacing(delegate.Spacing() + lineSpacing)
+
+	if accessible {
+		plain := lipgloss.NewStyle().PaddingLeft(2)
+		delegate.Styles.NormalTitle = plain
+		delegate.Styles.NormalDesc = plain
+		delegate.Styles.DimmedTitle = plain
+		delegate.Styles.DimmedDesc = plain
+		delegate.Styles.SelectedTitle = lipgloss.NewStyle().SetString(">")
+		delegate.Styles.SelectedDesc = plain
+		return delegate
+	}
+
+	// Create custom list delegate with beautiful styling
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(styles.GetTheme().Text).
+		Background(styles.GetTheme().Selection).
+		Bold(true)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
+		Foreground(styles.GetTheme().Subtle).
+		Background(styles.GetTheme().Selection)
+	return delegate
+}
+
+// setAccessible switches the model to accessible rendering and sets the spacing between
+// list and tree rows.
+func (m *model) setAccessible(accessible bool, lineSpacing int) {
+	m.state.Accessible = accessible
+	m.state.LineSpacing = lineSpacing
+	m.state.List.SetDelegate(newListDelegate(m.styles, accessible, lineSpacing))
+	if accessible {
+		m.state.Announcement = describeView(m.state)
+	}
+}
+
 // Init initializes the model.
 func (m *model) Init() tea.Cmd {
+	if m.state.Loading != nil {
+		return loadingTick()
+	}
 	return nil
 }
 
@@ -177,8 +356,41 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.state.Loading != nil && !m.state.Loading.Dismissed {
+			return m.handleLoadingKey(msg)
+		}
+		if m.state.Accessible {
+			previous := m.state.CurrentView
+			model, cmd := m.handleKeyPress(msg)
+			if m.state.CurrentView != previous {
+				m.state.Announcement = describeView(m.state)
+			}
+			return model, cmd
+		}
 		return m.handleKeyPress(msg)
 
+	case progressMsg:
+		m.applyProgress(analyzer.Progress(msg))
+		return m, nil
+
+	case packageMsg:
+		m.applyPackage(analyzer.PackageResult(msg))
+		return m, nil
+
+	case analysisDoneMsg:
+		return m.finishAnalysis(msg)
+
+	case exportDoneMsg:
+		applyExportDone(m.state, msg)
+		return m, nil
+
+	case loadingTickMsg:
+		if m.state.Loading == nil {
+			return m, nil
+		}
+		m.state.Loading.Frame++
+		return m, loadingTick()
+
 	default:
 		// Handle filter input updates when filter is active
 		if m.filter.IsActive() {
@@ -204,11 +416,18 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the current view.
 func (m *model) View() string {
+	if m.state.Loading != nil && !m.state.Loading.Dismissed {
+		return renderLoading(m.state, m.styles.GetTheme())
+	}
+
 	currentView := m.viewManager.GetCurrentView(m.state)
 	if currentView == nil {
 		return "Error: No view available"
 	}
 
+	if m.state.Accessible {
+		return currentView.Render(m.state) + "\n" + renderStatusLine(m.state)
+	}
 	return currentView.Render(m.state)
 }
 
@@ -216,6 +435,11 @@ func (m *model) View() string {
 func (m *model) handleWindowResize(msg tea.WindowSizeMsg) {
 	m.state.WindowWidth = msg.Width
 	m.state.WindowHeight = msg.Height
+	if m.state.Accessible {
+		// Leave room for the status line
+		m.state.WindowHeight--
+		msg.Height--
+	}
 
 	// Calculate content dimensions
 	headerHeight := 3
@@ -294,11 +518,12 @@ func (m *model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "?":
 		return m.handleHelpToggle()
 
+	case "W":
+		return m.handleWalk()
+
 	case "1":
 		// Switch to list view
-		m.state.PreviousView = m.state.CurrentView
-		m.state.CurrentView = ViewList
-		_ = m.viewManager.SwitchView(ViewList)
+		m.switchView(ViewList)
 		return m, nil
 
 	case "2":
@@ -307,11 +532,12 @@ func (m *model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "3":
 		// Switch to stats view
-		m.state.PreviousView = m.state.CurrentView
-		m.state.CurrentView = ViewStats
-		_ = m.viewManager.SwitchView(ViewStats)
+		m.switchView(ViewStats)
 		return m, nil
 
+	case "alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9":
+		return m.handleBreadcrumbJump(int(msg.String()[len("alt+")] - '0'))
+
 	case "w":
 		if m.state.CurrentView == ViewList {
 			return m.handleWorkflowToggle()
@@ -327,6 +553,21 @@ func (m *model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m.handleSignalToggle()
 		}
 
+	case "Q":
+		if m.state.CurrentView == ViewList {
+			return m.handleQueryToggle()
+		}
+
+	case "u":
+		if m.state.CurrentView == ViewList {
+			return m.handleUpdateToggle()
+		}
+
+	case "F":
+		if m.state.CurrentView == ViewList {
+			return m.handleFamilyToggle()
+		}
+
 	case "C":
 		// Clear all filters
 		m.state.ShowWorkflows = true
@@ -336,6 +577,7 @@ func (m *model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.state.ShowUpdates = true
 		m.filter.ClearFilter()
 		m.updateFilteredItems()
+		m.saveSession()
 		return m, nil
 	}
 
@@ -364,6 +606,18 @@ func (m *model) handleBackNavigation() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Close the edge explainer before leaving the details view
+	if m.state.CurrentView == ViewDetails && m.state.DetailsState != nil && m.state.DetailsState.Explain != nil {
+		m.state.DetailsState.Explain = nil
+		return m, nil
+	}
+
+	// Close the walk picker before leaving the walk
+	if m.state.CurrentView == ViewWalk && m.state.WalkState != nil && m.state.WalkState.Picker != nil {
+		m.state.WalkState.Picker = nil
+		return m, nil
+	}
+
 	// Try to pop state from navigator
 	if prevState, ok := m.navigator.PopState(); ok {
 		m.restoreState(prevState)
@@ -380,15 +634,21 @@ func (m *model) handleBackNavigation() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleTreeView handles switching to tree view.
-func (m *model) handleTreeView() (tea.Model, tea.Cmd) {
-	// Save current state
+// switchView switches to another view, saving the current one so going back returns to it
+// with its selection and breadcrumbs.
+func (m *model) switchView(view string) {
+	if m.state.CurrentView == view {
+		return
+	}
 	m.navigator.PushState(m.getCurrentViewState())
-
-	// Switch to tree view
 	m.state.PreviousView = m.state.CurrentView
-	m.state.CurrentView = ViewTree
-	_ = m.viewManager.SwitchView(ViewTree)
+	m.state.CurrentView = view
+	_ = m.viewManager.SwitchView(view)
+}
+
+// handleTreeView handles switching to tree view.
+func (m *model) handleTreeView() (tea.Model, tea.Cmd) {
+	m.switchView(ViewTree)
 
 	// Initialize tree state if needed
 	if len(m.state.TreeState.Items) == 0 {
@@ -398,6 +658,34 @@ func (m *model) handleTreeView() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleWalk starts walking the call graph from the selected node of the list, tree or
+// details view.
+func (m *model) handleWalk() (tea.Model, tea.Cmd) {
+	var node *analyzer.TemporalNode
+	switch m.state.CurrentView {
+	case ViewList:
+		if item, ok := m.state.List.SelectedItem().(ListItem); ok {
+			node = item.Node
+		}
+	case ViewTree:
+		if ts := m.state.TreeState; ts != nil && ts.SelectedIndex < len(ts.Items) {
+			node = ts.Items[ts.SelectedIndex].Node
+		}
+	case ViewDetails:
+		node = m.state.SelectedNode
+	}
+	if node == nil {
+		m.state.StatusMessage = "Select a node to walk from"
+		m.state.StatusType = StatusWarning
+		return m, nil
+	}
+
+	m.navigator.PushState(m.getCurrentViewState())
+	startWalk(m.state, node)
+	_ = m.viewManager.SwitchView(ViewWalk)
+	return m, nil
+}
+
 // handleFilterToggle handles toggling the filter.
 func (m *model) handleFilterToggle() (tea.Model, tea.Cmd) {
 	isActive := m.filter.IsActive()
@@ -430,6 +718,7 @@ func (m *model) handleHelpToggle() (tea.Model, tea.Cmd) {
 func (m *model) handleWorkflowToggle() (tea.Model, tea.Cmd) {
 	m.state.ShowWorkflows = !m.state.ShowWorkflows
 	m.updateFilteredItems()
+	m.saveSession()
 	return m, nil
 }
 
@@ -437,6 +726,7 @@ func (m *model) handleWorkflowToggle() (tea.Model, tea.Cmd) {
 func (m *model) handleActivityToggle() (tea.Model, tea.Cmd) {
 	m.state.ShowActivities = !m.state.ShowActivities
 	m.updateFilteredItems()
+	m.saveSession()
 	return m, nil
 }
 
@@ -444,6 +734,38 @@ func (m *model) handleActivityToggle() (tea.Model, tea.Cmd) {
 func (m *model) handleSignalToggle() (tea.Model, tea.Cmd) {
 	m.state.ShowSignals = !m.state.ShowSignals
 	m.updateFilteredItems()
+	m.saveSession()
+	return m, nil
+}
+
+// handleQueryToggle handles toggling query display.
+func (m *model) handleQueryToggle() (tea.Model, tea.Cmd) {
+	m.state.ShowQueries = !m.state.ShowQueries
+	m.updateFilteredItems()
+	m.saveSession()
+	return m, nil
+}
+
+// handleUpdateToggle handles toggling update display.
+func (m *model) handleUpdateToggle() (tea.Model, tea.Cmd) {
+	m.state.ShowUpdates = !m.state.ShowUpdates
+	m.updateFilteredItems()
+	m.saveSession()
+	return m, nil
+}
+
+// handleFamilyToggle handles toggling the grouping of the list by workflow family.
+func (m *model) handleFamilyToggle() (tea.Model, tea.Cmd) {
+	if m.state.ListState.GroupBy == GroupByFamily {
+		m.state.ListState.GroupBy = GroupByNone
+		m.state.StatusMessage = "Ungrouped"
+	} else {
+		m.state.ListState.GroupBy = GroupByFamily
+		m.state.StatusMessage = "Grouped by family"
+	}
+	m.state.StatusType = StatusInfo
+	m.updateFilteredItems()
+	m.state.List.Select(0)
 	return m, nil
 }
 
@@ -485,12 +807,12 @@ func (m *model) restoreState(viewState ViewState) {
 	case ViewTree:
 		m.state.TreeState.SelectedIndex = viewState.TreeIndex
 	case ViewDetails:
-		if m.state.DetailsState != nil {
-			m.state.DetailsState.SelectedIndex = viewState.DetailsIndex
-		}
 		if m.state.SelectedNode != nil {
 			m.buildDetailsItems()
 		}
+		if ds := m.state.DetailsState; ds != nil && viewState.DetailsIndex < len(ds.SelectableItems) {
+			ds.SelectedIndex = viewState.DetailsIndex
+		}
 	}
 }
 
@@ -520,41 +842,7 @@ func (m *model) buildTreeItems() {
 
 	visited := make(map[string]bool)
 	for _, root := range rootNodes {
-		m.addTreeItemRecursive(root, 0, m.state.TreeState.ExpansionStates, visited)
-	}
-}
-
-// addTreeItemRecursive adds a node and its children to the tree.
-func (m *model) addTreeItemRecursive(node *analyzer.TemporalNode, depth int, expansionStates map[string]bool, visited map[string]bool) {
-	if depth > MaxTreeDepth || visited[node.Name] {
-		return
-	}
-	visited[node.Name] = true
-	defer func() { visited[node.Name] = false }()
-
-	hasChildren := len(node.CallSites) > 0
-	isExpanded := hasChildren && expansionStates[node.Name]
-
-	item := TreeItem{
-		Node:        node,
-		Depth:       depth,
-		HasChildren: hasChildren,
-		IsExpanded:  isExpanded,
-		ChildCount:  len(node.CallSites),
-	}
-
-	m.state.TreeState.Items = append(m.state.TreeState.Items, item)
-
-	// Add children if expanded
-	if isExpanded && hasChildren {
-		for _, callSite := range node.CallSites {
-			for _, targetNode := range m.state.Graph.Nodes {
-				if targetNode.Name == callSite.TargetName {
-					m.addTreeItemRecursive(targetNode, depth+1, expansionStates, visited)
-					break
-				}
-			}
-		}
+		addHierarchyItem(m.state, root, "", 0, visited)
 	}
 }
 
@@ -569,32 +857,27 @@ func (m *model) buildDetailsItems() {
 	node := m.state.SelectedNode
 
 	// Add calls section
-	for _, call := range node.CallSites {
-		for _, targetNode := range m.state.Graph.Nodes {
-			if targetNode.Name == call.TargetName {
-				selectableItems = append(selectableItems, SelectableItem{
-					LineIndex:   len(selectableItems),
-					Node:        targetNode,
-					ItemType:    "callee",
-					DisplayText: call.TargetName,
-				})
-				break
-			}
+	for i, call := range node.CallSites {
+		if targetNode, ok := m.state.Graph.Nodes[call.TargetName]; ok {
+			selectableItems = append(selectableItems, SelectableItem{
+				LineIndex:   len(selectableItems),
+				Node:        targetNode,
+				Call:        &node.CallSites[i],
+				ItemType:    "callee",
+				DisplayText: targetNode.Name,
+			})
 		}
 	}
 
 	// Add called by section
 	for _, parentName := range node.Parents {
-		for _, parentNode := range m.state.Graph.Nodes {
-			if parentNode.Name == parentName {
-				selectableItems = append(selectableItems, SelectableItem{
-					LineIndex:   len(selectableItems),
-					Node:        parentNode,
-					ItemType:    "caller",
-					DisplayText: parentName,
-				})
-				break
-			}
+		if parentNode, ok := m.state.Graph.Nodes[parentName]; ok {
+			selectableItems = append(selectableItems, SelectableItem{
+				LineIndex:   len(selectableItems),
+				Node:        parentNode,
+				ItemType:    "caller",
+				DisplayText: parentNode.Name,
+			})
 		}
 	}
 
@@ -605,42 +888,24 @@ func (m *model) buildDetailsItems() {
 	}
 }
 
+// setSort reorders the list; an empty order keeps the current one.
+func (m *model) setSort(sortBy string) {
+	if sortBy == "" {
+		return
+	}
+	m.state.ListState.SortBy = sortBy
+	SortListItems(m.state.AllItems, sortBy)
+	m.updateFilteredItems()
+}
+
 // updateFilteredItems updates the list based on current filter and toggle settings.
 func (m *model) updateFilteredItems() {
 	filteredItems := make([]list.Item, 0, len(m.state.AllItems))
 
-	// Check if we're in "top-level only" mode (only workflows shown, nothing else)
-	topLevelOnly := m.state.ShowWorkflows && !m.state.ShowActivities && 
-		!m.state.ShowSignals && !m.state.ShowQueries && !m.state.ShowUpdates
-
 	for _, item := range m.state.AllItems {
 		if listItem, ok := item.(ListItem); ok {
-			// Apply type filters
-			switch listItem.Node.Type {
-			case "workflow":
-				if !m.state.ShowWorkflows {
-					continue
-				}
-				// In top-level only mode, only show workflows with no parents
-				if topLevelOnly && len(listItem.Node.Parents) > 0 {
-					continue
-				}
-			case "activity":
-				if !m.state.ShowActivities {
-					continue
-				}
-			case "signal", "signal_handler":
-				if !m.state.ShowSignals {
-					continue
-				}
-			case "query", "query_handler":
-				if !m.state.ShowQueries {
-					continue
-				}
-			case "update", "update_handler":
-				if !m.state.ShowUpdates {
-					continue
-				}
+			if !typeShown(m.state, listItem.Node) {
+				continue
 			}
 
 			// Apply text filter if active
@@ -656,46 +921,45 @@ func (m *model) updateFilteredItems() {
 		}
 	}
 
-	m.state.List.SetItems(filteredItems)
-	m.state.ListState.Items = filteredItems
+	setListItems(m.state, filteredItems)
+}
+
+// typeShown reports whether the node type toggles show a node. While workflows are the
+// only type shown, the list shows the top-level workflows, the entry points.
+func typeShown(state *State, node *analyzer.TemporalNode) bool {
+	switch theme.NodeKind(node.Type) {
+	case theme.KindWorkflow:
+		topLevelOnly := !state.ShowActivities && !state.ShowSignals && !state.ShowQueries && !state.ShowUpdates
+		return state.ShowWorkflows && (!topLevelOnly || len(node.Parents) == 0)
+	case theme.KindActivity:
+		return state.ShowActivities
+	case theme.KindSignal:
+		return state.ShowSignals
+	case theme.KindQuery:
+		return state.ShowQueries
+	case theme.KindUpdate:
+		return state.ShowUpdates
+	}
+	return true
+}
+
+// setListItems shows items in the list, grouped by family when the list is grouped.
+func setListItems(state *State, items []list.Item) {
+	if state.ListState.GroupBy == GroupByFamily {
+		items = groupListItems(items)
+	}
+	state.List.SetItems(items)
+	state.ListState.Items = items
 }
 
 // updateFilteredItemsWithFilterText updates the list with a specific filter text.
 func (m *model) updateFilteredItemsWithFilterText(filterText string) {
 	filteredItems := make([]list.Item, 0, len(m.state.AllItems))
 
-	// Check if we're in "top-level only" mode
-	topLevelOnly := m.state.ShowWorkflows && !m.state.ShowActivities && 
-		!m.state.ShowSignals && !m.state.ShowQueries && !m.state.ShowUpdates
-
 	for _, item := range m.state.AllItems {
 		if listItem, ok := item.(ListItem); ok {
-			// Apply type filters
-			switch listItem.Node.Type {
-			case "workflow":
-				if !m.state.ShowWorkflows {
-					continue
-				}
-				// In top-level only mode, only show workflows with no parents
-				if topLevelOnly && len(listItem.Node.Parents) > 0 {
-					continue
-				}
-			case "activity":
-				if !m.state.ShowActivities {
-					continue
-				}
-			case "signal", "signal_handler":
-				if !m.state.ShowSignals {
-					continue
-				}
-			case "query", "query_handler":
-				if !m.state.ShowQueries {
-					continue
-				}
-			case "update", "update_handler":
-				if !m.state.ShowUpdates {
-					continue
-				}
+			if !typeShown(m.state, listItem.Node) {
+				continue
 			}
 
 			// Apply text filter if provided
@@ -710,6 +974,5 @@ func (m *model) updateFilteredItemsWithFilterText(filterText string) {
 		}
 	}
 
-	m.state.List.SetItems(filteredItems)
-	m.state.ListState.Items = filteredItems
+	setListItems(m.state, filteredItems)
 }
@@ -3,6 +3,8 @@ package tui
 import (
 	"fmt"
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/history"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/tui/theme"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -13,6 +15,8 @@ type State struct {
 	// Core data
 	Graph    *analyzer.TemporalGraph
 	AllItems []list.Item
+	History  []history.Snapshot // Recorded snapshots, oldest first (empty without --history-db)
+	Loading  *LoadingState      // Set while the analysis is still running
 
 	// Current view state
 	CurrentView  string
@@ -35,6 +39,7 @@ type State struct {
 	DetailsState *DetailsViewState
 	StatsState   *StatsViewState
 	HelpState    *HelpViewState
+	WalkState    *WalkViewState
 
 	// Navigation
 	Navigator Navigator
@@ -54,10 +59,13 @@ type State struct {
 	ShowBreadcrumb bool
 	CompactMode    bool
 	UseNerdFonts   bool
+	Accessible     bool // Screen-reader friendly: no colors or gradients, words instead of glyphs
+	LineSpacing    int  // Blank lines between list and tree rows
 
 	// Status
 	StatusMessage string
 	StatusType    string // "info", "success", "warning", "error"
+	Announcement  string // Last view change, shown in the accessible status line
 }
 
 // ViewState represents a saved navigation state.
@@ -83,9 +91,9 @@ type ListViewState struct {
 	Items         []list.Item
 	SelectedIndex int
 	ScrollOffset  int
-	SortBy        string // "name", "type", "package", "connections"
+	SortBy        string // "name", "type", "package", "connections", "churn"
 	SortAsc       bool
-	GroupBy       string // "", "type", "package"
+	GroupBy       string // "", "type", "package", "family"
 }
 
 // TreeViewState holds state specific to the tree view.
@@ -96,7 +104,17 @@ type TreeViewState struct {
 	ExpansionStates map[string]bool // Node name -> expanded state
 	MaxVisibleDepth int
 	ShowOrphans     bool
-	GroupBy         string // "hierarchy" (default) or "package"
+	GroupBy         string // "hierarchy" (default), "package", "family" or "queue"
+	HiddenCalls     map[string]bool // Call types hidden from the call hierarchy, e.g. timers
+}
+
+// Hierarchy returns true if the tree shows the call hierarchy rather than groups.
+func (ts *TreeViewState) Hierarchy() bool {
+	switch ts.GroupBy {
+	case GroupByPackage, GroupByFamily, GroupByQueue:
+		return false
+	}
+	return true
 }
 
 // DetailsViewState holds state specific to the details view.
@@ -107,6 +125,7 @@ type DetailsViewState struct {
 	ScrollOffset    int
 	Sections        []DetailSection
 	ActiveSection   int
+	Explain         *EdgeExplanation // Explainer panel for the selected call, nil when closed
 }
 
 // DetailSection represents a collapsible section in details view.
@@ -117,6 +136,23 @@ type DetailSection struct {
 	Selectable []SelectableItem
 }
 
+// WalkViewState holds the state of the call-graph walk: the path walked from the start node,
+// and the picker choosing the next step when the current node has several callers or callees.
+type WalkViewState struct {
+	Path            []PathItem // Nodes walked, the current node last
+	Picker          []WalkStep // Candidate next steps, nil when no picker is open
+	PickerDirection string     // DirectionCalls or DirectionCalledBy
+	SelectedIndex   int        // Selected candidate in the picker
+}
+
+// WalkStep is a caller or callee the walk can step to.
+type WalkStep struct {
+	Node       *analyzer.TemporalNode
+	CallType   string
+	FilePath   string // File of the call
+	LineNumber int    // Line of the call
+}
+
 // StatsViewState holds state for the statistics dashboard.
 type StatsViewState struct {
 	RefreshInterval int
@@ -148,12 +184,17 @@ type KeyBinding struct {
 // TreeItem represents an item in the tree view.
 type TreeItem struct {
 	Node        *analyzer.TemporalNode
-	Depth       int    // Indentation level
-	DisplayText string // Formatted text with tree graphics
-	IsExpanded  bool   // Whether children are shown
-	HasChildren bool   // Whether this item has children
-	IsOrphan    bool   // Whether this node has no connections
-	ChildCount  int    // Number of children
+	Depth       int      // Indentation level
+	DisplayText string   // Formatted text with tree graphics
+	IsExpanded  bool     // Whether children are shown
+	HasChildren bool     // Whether this item has children
+	IsOrphan    bool     // Whether this node has no connections
+	ChildCount  int      // Number of children
+	Cluster     *Cluster // Family of a family header (nil otherwise)
+	IsQueue     bool     // Whether this is a task queue header
+	// CallType is the theme call type of the call the item is drawn for in the call
+	// hierarchy, "" for roots and groups; timers and signals sent have no Node
+	CallType string
 }
 
 // SelectableItem represents a navigable item in details view.
@@ -161,6 +202,7 @@ type SelectableItem struct {
 	LineIndex    int                    // Which line this item is on
 	Node         *analyzer.TemporalNode // The node to navigate to (nil for internal calls)
 	InternalCall *analyzer.InternalCall // Internal call info (nil for temporal calls)
+	Call         *analyzer.CallSite     // Call site info (set for callees)
 	ItemType     string                 // "caller", "callee", "signal", "query", "update", "internal"
 	DisplayText  string                 // Text shown for this item
 	Section      string                 // Which section this belongs to
@@ -181,6 +223,9 @@ func (li ListItem) FilterValue() string {
 // Title implements list.Item interface.
 func (li ListItem) Title() string {
 	icon := getNodeIcon(li.Node.Type)
+	if li.Node.Unresolved {
+		icon = "❔"
+	}
 	name := li.Node.Name
 	if len(name) > MaxDisplayNameLength {
 		return icon + " " + name[:TruncateLength] + EllipsisString
@@ -205,28 +250,22 @@ func (li ListItem) Description() string {
 	if len(li.Node.Queries) > 0 {
 		extra += fmt.Sprintf(" │ %d queries", len(li.Node.Queries))
 	}
+	if li.Node.IsTested() {
+		extra += " │ ✓ tested"
+	}
+	if li.Node.Churn != nil && li.Node.Churn.Commits > 0 {
+		extra += fmt.Sprintf(" │ 🔥 %d commits/90d", li.Node.Churn.Commits)
+	}
 	
+	if li.Node.Unresolved {
+		return li.Node.Type + " │ unresolved" + extra
+	}
 	return li.Node.Type + " │ " + li.Node.Package + extra
 }
 
-// getNodeIcon returns an icon for the node type.
+// getNodeIcon returns an icon for the node type, the same in every view.
 func getNodeIcon(nodeType string) string {
-	switch nodeType {
-	case "workflow":
-		return "⚡"
-	case "activity":
-		return "⚙"
-	case "signal", "signal_handler":
-		return "🔔"
-	case "query", "query_handler":
-		return "❓"
-	case "update", "update_handler":
-		return "🔄"
-	case "timer":
-		return "⏱"
-	default:
-		return "•"
-	}
+	return theme.NodeIcon(nodeType, false)
 }
 
 // Constants for view names.
@@ -237,6 +276,7 @@ const (
 	ViewStats   = "stats"
 	ViewHelp    = "help"
 	ViewGraph   = "graph"
+	ViewWalk    = "walk"
 )
 
 // Constants for navigation directions.
@@ -268,7 +308,8 @@ const (
 	MaxDisplayNameLength = 75
 	TruncateLength       = 72
 	EllipsisString       = "..."
-	MaxNavPathLength     = 10
+	MaxNavPathLength     = 50
+	MaxBreadcrumbs       = 9 // Breadcrumbs shown at once, numbered for Alt+1…9
 	MaxTreeDepth         = 50
 	DefaultPageSize      = 20
 )
@@ -279,6 +320,7 @@ const (
 	SortByType        = "type"
 	SortByPackage     = "package"
 	SortByConnections = "connections"
+	SortByChurn       = "churn"
 )
 
 // Constants for group options.
@@ -286,6 +328,8 @@ const (
 	GroupByNone    = ""
 	GroupByType    = "type"
 	GroupByPackage = "package"
+	GroupByFamily  = "family"
+	GroupByQueue   = "queue"
 )
 
 // StatusType constants
@@ -306,6 +350,7 @@ func DefaultKeyBindings() []HelpSection {
 				{Key: "k/↑", Description: "Move up", Context: "global"},
 				{Key: "Enter", Description: "Select / Open details", Context: "global"},
 				{Key: "Esc/q", Description: "Go back / Quit", Context: "global"},
+				{Key: "Alt+1…9", Description: "Jump to a numbered breadcrumb", Context: "global"},
 				{Key: "g", Description: "Go to top", Context: "list"},
 				{Key: "G", Description: "Go to bottom", Context: "list"},
 			},
@@ -317,6 +362,7 @@ func DefaultKeyBindings() []HelpSection {
 				{Key: "2", Description: "Tree view", Context: "global"},
 				{Key: "3", Description: "Stats dashboard", Context: "global"},
 				{Key: "t", Description: "Toggle tree view", Context: "list"},
+				{Key: "F", Description: "Group by workflow family", Context: "list"},
 				{Key: "?", Description: "Help", Context: "global"},
 			},
 		},
@@ -324,9 +370,12 @@ func DefaultKeyBindings() []HelpSection {
 			Title: "Filtering",
 			Bindings: []KeyBinding{
 				{Key: "/", Description: "Search / Filter", Context: "global"},
+				{Key: "/?<expr>", Description: "Query filter (e.g. /?type==workflow && fanout>5)", Context: "global"},
 				{Key: "w", Description: "Toggle workflows", Context: "list"},
 				{Key: "a", Description: "Toggle activities", Context: "list"},
 				{Key: "s", Description: "Toggle signals", Context: "list"},
+				{Key: "Q", Description: "Toggle queries", Context: "list"},
+				{Key: "u", Description: "Toggle updates", Context: "list"},
 				{Key: "C", Description: "Clear filters", Context: "global"},
 			},
 		},
@@ -337,6 +386,12 @@ func DefaultKeyBindings() []HelpSection {
 				{Key: "l/→", Description: "Expand node", Context: "tree"},
 				{Key: "e", Description: "Expand all", Context: "tree"},
 				{Key: "c", Description: "Collapse all", Context: "tree"},
+				{Key: "p", Description: "Group by package", Context: "tree"},
+				{Key: "F", Description: "Group by workflow family", Context: "tree"},
+				{Key: "Q", Description: "Group by task queue", Context: "tree"},
+				{Key: "H", Description: "Call hierarchy", Context: "tree"},
+				{Key: "a/o/w/s/z", Description: "Show/hide activities, local activities, child workflows, signals sent, timers", Context: "tree"},
+				{Key: "E", Description: "Export the selected subtree as .dot and .svg", Context: "tree"},
 			},
 		},
 		{
@@ -346,6 +401,18 @@ func DefaultKeyBindings() []HelpSection {
 				{Key: "Shift+Tab", Description: "Previous section", Context: "details"},
 				{Key: "o", Description: "Open file in editor", Context: "details"},
 				{Key: "y", Description: "Copy name to clipboard", Context: "details"},
+				{Key: "x", Description: "Explain selected call edge", Context: "details"},
+			},
+		},
+		{
+			Title: "Walk Mode",
+			Bindings: []KeyBinding{
+				{Key: "W", Description: "Walk the call graph from the selected node", Context: "global"},
+				{Key: "h/←", Description: "Step to a caller", Context: "walk"},
+				{Key: "l/→", Description: "Step to a callee", Context: "walk"},
+				{Key: "j/k", Description: "Choose in the picker", Context: "walk"},
+				{Key: "Enter", Description: "Take the chosen step / Open details", Context: "walk"},
+				{Key: "u/Backspace", Description: "Step back along the path", Context: "walk"},
 			},
 		},
 		{
@@ -194,6 +194,28 @@ func TestListItemDescription(t *testing.T) {
 			},
 			contains: []string{"1 queries"},
 		},
+		{
+			name: "tested workflow",
+			node: &analyzer.TemporalNode{
+				Name:    "Test",
+				Type:    "workflow",
+				Package: "main",
+				Tests: []analyzer.TestReference{
+					{TestName: "TestWorkflow", Kind: "workflow_test"},
+				},
+			},
+			contains: []string{"tested"},
+		},
+		{
+			name: "workflow with churn",
+			node: &analyzer.TemporalNode{
+				Name:    "Test",
+				Type:    "workflow",
+				Package: "main",
+				Churn:   &analyzer.ChurnInfo{Commits: 7},
+			},
+			contains: []string{"7 commits/90d"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -224,6 +246,8 @@ func TestGetNodeIcon(t *testing.T) {
 		{"update", "🔄"},
 		{"update_handler", "🔄"},
 		{"timer", "⏱"},
+		{"child_workflow", "⚡"},
+		{"nexus_operation", "🌐"},
 		{"unknown", "•"},
 		{"", "•"},
 	}
@@ -359,7 +383,7 @@ func TestDisplayConstants(t *testing.T) {
 
 func TestSortConstants(t *testing.T) {
 	// Verify sort constants are defined and unique
-	sorts := []string{SortByName, SortByType, SortByPackage, SortByConnections}
+	sorts := []string{SortByName, SortByType, SortByPackage, SortByConnections, SortByChurn}
 	seen := make(map[string]bool)
 
 	for _, s := range sorts {
@@ -29,6 +29,7 @@ func NewViewManager(styles StyleManager, filter FilterManager) ViewManager {
 	vm.RegisterView(NewDetailsView(styles))
 	vm.RegisterView(NewStatsView(styles))
 	vm.RegisterView(NewHelpView(styles))
+	vm.RegisterView(NewWalkView(styles))
 
 	return vm
 }
@@ -132,8 +132,8 @@ func TestViewManagerGetAllViews(t *testing.T) {
 
 	views := vm.GetAllViews()
 
-	if len(views) != 5 {
-		t.Errorf("GetAllViews() returned %d views, want 5", len(views))
+	if len(views) != 6 {
+		t.Errorf("GetAllViews() returned %d views, want 6", len(views))
 	}
 
 	// Verify it's a copy (modifying shouldn't affect manager)
@@ -1,11 +1,19 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
+
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/history"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/tui/theme"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -45,29 +53,34 @@ func (lv *listView) Render(state *State) string {
 	// Build stunning header
 	headerText := "TEMPORAL ANALYZER"
 	
-	// Build filter status
-	var filterStatus []string
-	if state.ShowWorkflows {
-		filterStatus = append(filterStatus, "⚡Workflows")
-	}
-	if state.ShowActivities {
-		filterStatus = append(filterStatus, "⚙Activities")
-	}
-	if state.ShowSignals {
-		filterStatus = append(filterStatus, "🔔Signals")
+	// Build filter status, with the number of nodes of each type shown
+	counts, topLevel := countNodeKinds(state.AllItems)
+	toggles := []struct {
+		shown bool
+		kind  string
+		label string
+	}{
+		{state.ShowWorkflows, theme.KindWorkflow, "Workflows"},
+		{state.ShowActivities, theme.KindActivity, "Activities"},
+		{state.ShowSignals, theme.KindSignal, "Signals"},
+		{state.ShowQueries, theme.KindQuery, "Queries"},
+		{state.ShowUpdates, theme.KindUpdate, "Updates"},
 	}
-	if state.ShowQueries {
-		filterStatus = append(filterStatus, "❓Queries")
+	var filterStatus []string
+	for _, toggle := range toggles {
+		if toggle.shown {
+			filterStatus = append(filterStatus, fmt.Sprintf("%s%s %d", getNodeIcon(toggle.kind), toggle.label, counts[toggle.kind]))
+		}
 	}
 	
 	// Show current view mode
-	if !state.ShowActivities && !state.ShowSignals && !state.ShowQueries && state.ShowWorkflows {
-		headerText += " │ Top-Level Entry Points"
+	if state.ShowWorkflows && !state.ShowActivities && !state.ShowSignals && !state.ShowQueries && !state.ShowUpdates {
+		headerText += fmt.Sprintf(" │ Top-Level Entry Points %d", topLevel)
 	} else if len(filterStatus) > 0 {
 		headerText += " │ " + strings.Join(filterStatus, " ")
 	}
 
-	header := lv.renderHeader(headerText, width)
+	header := lv.renderHeader(state, headerText, width)
 
 	// Stats bar (includes filter when active)
 	statsBar := lv.renderStatsBar(state, width)
@@ -92,27 +105,48 @@ func (lv *listView) Render(state *State) string {
 	return strings.Join(parts, "\n")
 }
 
+// countNodeKinds counts the nodes of items by kind, and the top-level workflows.
+func countNodeKinds(items []list.Item) (map[string]int, int) {
+	counts := make(map[string]int)
+	topLevel := 0
+	for _, item := range items {
+		if li, ok := item.(ListItem); ok {
+			kind := theme.NodeKind(li.Node.Type)
+			counts[kind]++
+			if kind == theme.KindWorkflow && len(li.Node.Parents) == 0 {
+				topLevel++
+			}
+		}
+	}
+	return counts, topLevel
+}
+
 // renderHeader creates a beautiful header.
-func (lv *listView) renderHeader(text string, width int) string {
+func (lv *listView) renderHeader(state *State, text string, width int) string {
+	t := lv.styles.GetTheme()
 	// Create gradient header bar
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#ffffff")).
-		Background(lipgloss.Color("#161b22")).
+		Foreground(t.Bright).
+		Background(t.Surface).
 		Padding(0, 2).
 		Width(width)
 
 	header := headerStyle.Render("⚡ " + text)
 
-	// Add gradient line
-	gradient := lv.renderGradient(width)
+	// Add gradient line, left blank in accessible mode
+	gradient := ""
+	if !state.Accessible {
+		gradient = lv.renderGradient(width)
+	}
 
 	return header + "\n" + gradient
 }
 
 // renderGradient creates a beautiful gradient line.
 func (lv *listView) renderGradient(width int) string {
-	colors := []string{"#58a6ff", "#bc8cff", "#79c0ff", "#bc8cff", "#58a6ff"}
+	t := lv.styles.GetTheme()
+	colors := []lipgloss.Color{t.Primary, t.Secondary, t.Tertiary, t.Secondary, t.Primary}
 	segmentWidth := width / len(colors)
 	var gradient strings.Builder
 
@@ -121,7 +155,7 @@ func (lv *listView) renderGradient(width int) string {
 		if i == len(colors)-1 {
 			segment = strings.Repeat("▀", width-i*segmentWidth)
 		}
-		gradient.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(segment))
+		gradient.WriteString(lipgloss.NewStyle().Foreground(color).Render(segment))
 	}
 
 	return gradient.String()
@@ -129,20 +163,25 @@ func (lv *listView) renderGradient(width int) string {
 
 // renderFilterBar creates the filter input bar - always rendered for stable layout.
 func (lv *listView) renderFilterBar(state *State, width int) string {
+	t := lv.styles.GetTheme()
 	if lv.filter.IsActive() {
 		// Active filter mode - show input with blinking cursor effect
 		style := lipgloss.NewStyle().
-			Background(lipgloss.Color("#1f6feb")).
-			Foreground(lipgloss.Color("#ffffff")).
+			Background(t.Highlight).
+			Foreground(t.Bright).
 			Bold(true).
 			Padding(0, 1).
 			Width(width)
 
 		filterText := lv.filter.GetFilterText()
 		cursor := "▌" // Block cursor
-		
+
 		// Add visual indicator that we're in input mode
-		return style.Render("⌨️  FILTER MODE: " + filterText + cursor + "  │  Enter=apply  Esc=cancel  ↑↓=navigate")
+		mode := "FILTER MODE"
+		if strings.HasPrefix(filterText, QueryPrefix) {
+			mode = "QUERY MODE"
+		}
+		return style.Render("⌨️  " + mode + ": " + filterText + cursor + "  │  Enter=apply  Esc=cancel  ↑↓=navigate")
 	}
 	
 	// Check if there's an applied filter
@@ -150,18 +189,21 @@ func (lv *listView) renderFilterBar(state *State, width int) string {
 	if filterText != "" {
 		// Filter applied but not actively editing
 		style := lipgloss.NewStyle().
-			Background(lipgloss.Color("#238636")).
-			Foreground(lipgloss.Color("#ffffff")).
+			Background(t.SuccessBackground).
+			Foreground(t.Bright).
 			Padding(0, 1).
 			Width(width)
 		
 		return style.Render("✓ Filtered: \"" + filterText + "\"  │  / to edit  C to clear all")
 	}
 	
-	// No filter - show hint (subtle)
+	// No filter - show the breadcrumbs of the last details, else a hint (subtle)
+	if breadcrumb := renderBreadcrumb(state, t, width); breadcrumb != "" {
+		return breadcrumb
+	}
 	style := lipgloss.NewStyle().
-		Background(lipgloss.Color("#161b22")).
-		Foreground(lipgloss.Color("#484f58")).
+		Background(t.Surface).
+		Foreground(t.Muted).
 		Padding(0, 1).
 		Width(width)
 
@@ -170,24 +212,28 @@ func (lv *listView) renderFilterBar(state *State, width int) string {
 
 // renderStatsBar creates a compact stats summary.
 func (lv *listView) renderStatsBar(state *State, width int) string {
+	t := lv.styles.GetTheme()
 	stats := state.Graph.Stats
 
 	// Build stats items
 	items := []string{
-		fmt.Sprintf("⚡%d workflows", stats.TotalWorkflows),
-		fmt.Sprintf("⚙%d activities", stats.TotalActivities),
+		fmt.Sprintf("%s%d workflows", getNodeIcon("workflow"), stats.TotalWorkflows),
+		fmt.Sprintf("%s%d activities", getNodeIcon("activity"), stats.TotalActivities),
 	}
 	if stats.TotalSignals > 0 {
-		items = append(items, fmt.Sprintf("🔔%d signals", stats.TotalSignals))
+		items = append(items, fmt.Sprintf("%s%d signals", getNodeIcon("signal"), stats.TotalSignals))
 	}
 	if stats.TotalQueries > 0 {
-		items = append(items, fmt.Sprintf("❓%d queries", stats.TotalQueries))
+		items = append(items, fmt.Sprintf("%s%d queries", getNodeIcon("query"), stats.TotalQueries))
+	}
+	if stats.TotalUpdates > 0 {
+		items = append(items, fmt.Sprintf("%s%d updates", getNodeIcon("update"), stats.TotalUpdates))
 	}
 	items = append(items, fmt.Sprintf("📊 depth:%d", stats.MaxDepth))
 
 	statsStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6e7681")).
-		Background(lipgloss.Color("#0d1117")).
+		Foreground(t.Subtle).
+		Background(t.Base).
 		Padding(0, 1)
 
 	return statsStyle.Render(strings.Join(items, "  │  "))
@@ -195,12 +241,14 @@ func (lv *listView) renderStatsBar(state *State, width int) string {
 
 // renderFooter creates the footer with keybindings.
 func (lv *listView) renderFooter(width int) string {
+	t := lv.styles.GetTheme()
 	bindings := []struct {
 		key   string
 		label string
 	}{
 		{"Enter", "Details"},
 		{"t", "Tree"},
+		{"F", "Families"},
 		{"/", "Filter"},
 		{"w", "Workflows"},
 		{"a", "Activities"},
@@ -209,13 +257,13 @@ func (lv *listView) renderFooter(width int) string {
 	}
 
 	keyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#58a6ff")).
-		Background(lipgloss.Color("#21262d")).
+		Foreground(t.Primary).
+		Background(t.Overlay).
 		Padding(0, 1).
 		Bold(true)
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6e7681"))
+		Foreground(t.Subtle)
 
 	var parts []string
 	for _, b := range bindings {
@@ -223,7 +271,7 @@ func (lv *listView) renderFooter(width int) string {
 	}
 
 	footerStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("#161b22")).
+		Background(t.Surface).
 		Padding(0, 1).
 		Width(width)
 
@@ -299,10 +347,7 @@ func (lv *listView) updateFilteredItemsInView(state *State) {
 
 	for _, item := range state.AllItems {
 		if listItem, ok := item.(ListItem); ok {
-			if listItem.Node.Type == "workflow" && !state.ShowWorkflows {
-				continue
-			}
-			if listItem.Node.Type == "activity" && !state.ShowActivities {
+			if !typeShown(state, listItem.Node) {
 				continue
 			}
 
@@ -318,8 +363,7 @@ func (lv *listView) updateFilteredItemsInView(state *State) {
 		}
 	}
 
-	state.List.SetItems(filteredItems)
-	state.ListState.Items = filteredItems
+	setListItems(state, filteredItems)
 }
 
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -328,7 +372,8 @@ func (lv *listView) updateFilteredItemsInView(state *State) {
 
 // treeView implements the View interface for the tree view.
 type treeView struct {
-	styles StyleManager
+	styles    StyleManager
+	exportDir string // Directory subtree exports are written to, the working directory if empty
 }
 
 // NewTreeView creates a new tree view.
@@ -345,6 +390,7 @@ func (tv *treeView) Name() string {
 
 // Render renders the view with the given model state.
 func (tv *treeView) Render(state *State) string {
+	t := tv.styles.GetTheme()
 	width := state.WindowWidth
 	if width < 40 {
 		width = 80
@@ -354,8 +400,8 @@ func (tv *treeView) Render(state *State) string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#ffffff")).
-		Background(lipgloss.Color("#161b22")).
+		Foreground(t.Bright).
+		Background(t.Surface).
 		Padding(0, 2).
 		Width(width)
 
@@ -368,12 +414,32 @@ func (tv *treeView) Render(state *State) string {
 	title := "🌳 CALL HIERARCHY"
 	if state.TreeState != nil && state.TreeState.GroupBy == "package" {
 		title = "📦 BY PACKAGE"
+	} else if state.TreeState != nil && state.TreeState.GroupBy == GroupByFamily {
+		title = "▤ BY FAMILY"
+	} else if state.TreeState != nil && state.TreeState.GroupBy == GroupByQueue {
+		title = "🏭 BY TASK QUEUE"
 	}
 
 	header := headerStyle.Render(title + selectionInfo)
 
 	// Gradient line
-	gradient := tv.renderGradient(width, "#7ee787", "#58a6ff")
+	gradient := ""
+	if !state.Accessible {
+		gradient = tv.renderGradient(width, t.Activity, t.Primary)
+	}
+
+	// Breadcrumbs of the last details, taking a line from the tree
+	breadcrumb := renderBreadcrumb(state, t, width)
+	if breadcrumb != "" {
+		gradient += "\n" + breadcrumb
+		height--
+	}
+
+	// Legend of the call types, which the call hierarchy can hide
+	if state.TreeState == nil || state.TreeState.Hierarchy() {
+		gradient += "\n" + renderCallLegend(state, t, width)
+		height--
+	}
 
 	// Tree content with proper scrolling
 	content := tv.buildTreeContent(state, height)
@@ -385,8 +451,8 @@ func (tv *treeView) Render(state *State) string {
 }
 
 // renderGradient creates a gradient line with specified colors.
-func (tv *treeView) renderGradient(width int, startColor, endColor string) string {
-	colors := []string{startColor, endColor, startColor}
+func (tv *treeView) renderGradient(width int, startColor, endColor lipgloss.Color) string {
+	colors := []lipgloss.Color{startColor, endColor, startColor}
 	segmentWidth := width / len(colors)
 	var gradient strings.Builder
 
@@ -395,7 +461,7 @@ func (tv *treeView) renderGradient(width int, startColor, endColor string) strin
 		if i == len(colors)-1 {
 			segment = strings.Repeat("▀", width-i*segmentWidth)
 		}
-		gradient.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(segment))
+		gradient.WriteString(lipgloss.NewStyle().Foreground(color).Render(segment))
 	}
 
 	return gradient.String()
@@ -403,6 +469,7 @@ func (tv *treeView) renderGradient(width int, startColor, endColor string) strin
 
 // renderFooter creates the footer for tree view.
 func (tv *treeView) renderFooter(state *State, width int) string {
+	t := tv.styles.GetTheme()
 	viewMode := "hierarchy"
 	if state.TreeState != nil && state.TreeState.GroupBy == "package" {
 		viewMode = "package"
@@ -416,32 +483,45 @@ func (tv *treeView) renderFooter(state *State, width int) string {
 		{"h/l", "±"},
 		{"Enter", "Open"},
 		{"p", "ByPkg"},
+		{"F", "ByFamily"},
+		{"Q", "ByQueue"},
 		{"H", "ByCall"},
+		{"E", "Export"},
 		{"q", "Back"},
 	}
 	
 	_ = viewMode // Will use for display
 
 	keyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#7ee787")).
-		Background(lipgloss.Color("#21262d")).
+		Foreground(t.Activity).
+		Background(t.Overlay).
 		Padding(0, 1).
 		Bold(true)
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6e7681"))
+		Foreground(t.Subtle)
 
 	var parts []string
 	for _, b := range bindings {
 		parts = append(parts, keyStyle.Render(b.key)+labelStyle.Render(b.label))
 	}
 
+	footerContent := strings.Join(parts, " ")
+
+	// Show status message if present, e.g. where an export was written
+	if state.StatusMessage != "" {
+		statusStyle := lipgloss.NewStyle().
+			Foreground(t.StatusColor(state.StatusType)).
+			Italic(true)
+		footerContent += "  " + statusStyle.Render(state.StatusMessage)
+	}
+
 	footerStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("#161b22")).
+		Background(t.Surface).
 		Padding(0, 1).
 		Width(width)
 
-	return footerStyle.Render(strings.Join(parts, " "))
+	return footerStyle.Render(footerContent)
 }
 
 // Update handles view-specific updates.
@@ -470,7 +550,7 @@ func (tv *treeView) Update(msg tea.Msg, state *State) (*State, tea.Cmd) {
 					// Get the key for expansion state (node name or display text for packages)
 					expansionKey := selectedItem.DisplayText
 					if selectedItem.Node != nil {
-						expansionKey = selectedItem.Node.Name
+						expansionKey = selectedItem.Node.ID()
 					}
 					state.TreeState.ExpansionStates[expansionKey] = true
 					tv.buildTreeItems(state)
@@ -488,7 +568,7 @@ func (tv *treeView) Update(msg tea.Msg, state *State) (*State, tea.Cmd) {
 					}
 					expansionKey := selectedItem.DisplayText
 					if selectedItem.Node != nil {
-						expansionKey = selectedItem.Node.Name
+						expansionKey = selectedItem.Node.ID()
 					}
 					state.TreeState.ExpansionStates[expansionKey] = false
 					tv.buildTreeItems(state)
@@ -509,6 +589,30 @@ func (tv *treeView) Update(msg tea.Msg, state *State) (*State, tea.Cmd) {
 			}
 			return state, nil
 
+		case "F":
+			// Toggle to family view
+			if state.TreeState != nil {
+				state.TreeState.GroupBy = GroupByFamily
+				state.TreeState.ExpansionStates = make(map[string]bool)
+				state.TreeState.SelectedIndex = 0
+				tv.buildTreeItems(state)
+				state.StatusMessage = "Grouped by family"
+				state.StatusType = "info"
+			}
+			return state, nil
+
+		case "Q":
+			// Toggle to task queue view
+			if state.TreeState != nil {
+				state.TreeState.GroupBy = GroupByQueue
+				state.TreeState.ExpansionStates = make(map[string]bool)
+				state.TreeState.SelectedIndex = 0
+				tv.buildTreeItems(state)
+				state.StatusMessage = "Grouped by task queue"
+				state.StatusType = "info"
+			}
+			return state, nil
+
 		case "H":
 			// Toggle to hierarchy view
 			if state.TreeState != nil {
@@ -521,6 +625,29 @@ func (tv *treeView) Update(msg tea.Msg, state *State) (*State, tea.Cmd) {
 			}
 			return state, nil
 
+		case "a", "o", "w", "s", "z":
+			// Show or hide a call type in the call hierarchy
+			if state.TreeState != nil && state.TreeState.Hierarchy() {
+				for _, toggle := range callToggles {
+					if toggle.key == keyMsg.String() {
+						tv.toggleCallType(state, toggle.callType)
+					}
+				}
+			}
+			return state, nil
+
+		case "E":
+			// Export the selected subtree as shown
+			cmd, err := exportSubtree(state, tv.exportDir)
+			if err != nil {
+				state.StatusMessage = err.Error()
+				state.StatusType = StatusWarning
+				return state, nil
+			}
+			state.StatusMessage = "Exporting subtree..."
+			state.StatusType = StatusInfo
+			return state, cmd
+
 		case "e":
 			// Expand all
 			if state.TreeState != nil {
@@ -528,7 +655,7 @@ func (tv *treeView) Update(msg tea.Msg, state *State) (*State, tea.Cmd) {
 					if item.HasChildren {
 						key := item.DisplayText
 						if item.Node != nil {
-							key = item.Node.Name
+							key = item.Node.ID()
 						}
 						state.TreeState.ExpansionStates[key] = true
 					}
@@ -586,19 +713,24 @@ func (tv *treeView) CanHandle(msg tea.Msg, state *State) bool {
 
 // buildTreeContent builds the tree view content with proper styling.
 func (tv *treeView) buildTreeContent(state *State, maxHeight int) string {
+	t := tv.styles.GetTheme()
 	if state.TreeState == nil || len(state.TreeState.Items) == 0 {
 		tv.buildTreeItems(state)
 	}
 
 	if state.TreeState == nil || len(state.TreeState.Items) == 0 {
 		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6e7681")).
+			Foreground(t.Subtle).
 			Italic(true).
 			Render("  No workflow hierarchy to display")
 	}
 
 	var content strings.Builder
 
+	// Each row takes LineSpacing extra lines
+	rowHeight := 1 + state.LineSpacing
+	maxHeight /= rowHeight
+
 	// Calculate visible range
 	visibleStart := 0
 	visibleEnd := len(state.TreeState.Items)
@@ -621,8 +753,13 @@ func (tv *treeView) buildTreeContent(state *State, maxHeight int) string {
 
 	for i := visibleStart; i < visibleEnd; i++ {
 		item := state.TreeState.Items[i]
-		line := tv.renderTreeItem(item, i == state.TreeState.SelectedIndex)
-		content.WriteString(line + "\n")
+		var line string
+		if state.Accessible {
+			line = renderAccessibleTreeItem(item, i == state.TreeState.SelectedIndex)
+		} else {
+			line = tv.renderTreeItem(item, i == state.TreeState.SelectedIndex)
+		}
+		content.WriteString(line + "\n" + strings.Repeat("\n", state.LineSpacing))
 	}
 
 	return content.String()
@@ -630,6 +767,7 @@ func (tv *treeView) buildTreeContent(state *State, maxHeight int) string {
 
 // renderTreeItem renders a single tree item with beautiful styling.
 func (tv *treeView) renderTreeItem(item TreeItem, isSelected bool) string {
+	t := tv.styles.GetTheme()
 	// Build indentation with tree graphics
 	var indent strings.Builder
 	for d := 0; d < item.Depth; d++ {
@@ -657,23 +795,43 @@ func (tv *treeView) renderTreeItem(item TreeItem, isSelected bool) string {
 	// Build the line
 	var line strings.Builder
 	if item.Depth > 0 {
-		line.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#30363d")).Render(indent.String()+branchChar))
+		line.WriteString(lipgloss.NewStyle().Foreground(t.Border).Render(indent.String()+branchChar))
 	}
 
 	// Format: [expand] [icon] name (count)
-	expandStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#58a6ff"))
-	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#e6edf3"))
-	countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6e7681"))
+	expandStyle := lipgloss.NewStyle().Foreground(t.Primary)
+	nameStyle := lipgloss.NewStyle().Foreground(t.Text)
+	countStyle := lipgloss.NewStyle().Foreground(t.Subtle)
 
 	if item.IsExpanded {
-		expandStyle = expandStyle.Foreground(lipgloss.Color("#7ee787"))
+		expandStyle = expandStyle.Foreground(t.Activity)
 	}
 
-	// Handle package headers (nil Node) vs regular nodes
+	// Handle family and package headers (nil Node) vs regular nodes
 	var itemText string
-	if item.Node == nil {
+	if item.Cluster != nil {
+		// Family header with its stats
+		familyStyle := lipgloss.NewStyle().Foreground(t.Timer).Bold(true)
+		itemText = fmt.Sprintf(" %s ▤ %s",
+			expandStyle.Render(expandIcon),
+			familyStyle.Render(item.DisplayText))
+		itemText += countStyle.Render(fmt.Sprintf(" (%d)  %s", item.ChildCount, item.Cluster.Stats()))
+	} else if item.IsQueue {
+		// Task queue header
+		queueStyle := lipgloss.NewStyle().Foreground(t.Activity).Bold(true)
+		itemText = fmt.Sprintf(" %s 🏭 %s",
+			expandStyle.Render(expandIcon),
+			queueStyle.Render(item.DisplayText))
+		itemText += countStyle.Render(fmt.Sprintf(" (%d)", item.ChildCount))
+	} else if item.Node == nil && item.CallType != "" {
+		// Timer or signal sent in the call hierarchy
+		callStyle := lipgloss.NewStyle().Foreground(t.CallColor(item.CallType))
+		itemText = fmt.Sprintf(" %s %s",
+			expandStyle.Render(expandIcon),
+			callStyle.Render(theme.CallIcon(item.CallType, false)+" "+item.DisplayText))
+	} else if item.Node == nil {
 		// Package/directory header
-		pkgStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ffa657")).Bold(true)
+		pkgStyle := lipgloss.NewStyle().Foreground(t.Signal).Bold(true)
 		displayName := item.DisplayText
 		if displayName == "" {
 			displayName = "(root)"
@@ -685,8 +843,12 @@ func (tv *treeView) renderTreeItem(item TreeItem, isSelected bool) string {
 			itemText += countStyle.Render(fmt.Sprintf(" (%d)", item.ChildCount))
 		}
 	} else {
-		// Regular node
+		// Regular node, drawn as the call reaching it in the call hierarchy
 		nodeIcon := getNodeIcon(item.Node.Type)
+		if item.CallType != "" {
+			nodeIcon = lipgloss.NewStyle().Foreground(t.CallColor(item.CallType)).Render(theme.CallIcon(item.CallType, false))
+			nameStyle = nameStyle.Foreground(t.CallColor(item.CallType))
+		}
 		displayName := item.Node.Name
 		if item.DisplayText != "" {
 			displayName = item.DisplayText
@@ -706,8 +868,8 @@ func (tv *treeView) renderTreeItem(item TreeItem, isSelected bool) string {
 	finalLine := line.String()
 	if isSelected {
 		selectedStyle := lipgloss.NewStyle().
-			Background(lipgloss.Color("#388bfd")).
-			Foreground(lipgloss.Color("#ffffff")).
+			Background(t.Selection).
+			Foreground(t.Bright).
 			Bold(true)
 		finalLine = selectedStyle.Render("▶" + finalLine)
 	} else {
@@ -730,6 +892,10 @@ func (tv *treeView) buildTreeItems(state *State) {
 
 	if state.TreeState.GroupBy == "package" {
 		tv.buildTreeByPackage(state)
+	} else if state.TreeState.GroupBy == GroupByFamily {
+		tv.buildTreeByFamily(state)
+	} else if state.TreeState.GroupBy == GroupByQueue {
+		tv.buildTreeByQueue(state)
 	} else {
 		tv.buildTreeByHierarchy(state)
 	}
@@ -758,7 +924,7 @@ func (tv *treeView) buildTreeByHierarchy(state *State) {
 	// Build tree recursively
 	visited := make(map[string]bool)
 	for _, root := range rootNodes {
-		tv.addTreeItemRecursive(state, root, 0, state.TreeState.ExpansionStates, visited)
+		addHierarchyItem(state, root, "", 0, visited)
 	}
 }
 
@@ -828,6 +994,111 @@ func (tv *treeView) buildTreeByPackage(state *State) {
 	tv.renderPackageTree(state, root, 0)
 }
 
+// buildTreeByFamily groups nodes by workflow family, each family a header with its stats.
+func (tv *treeView) buildTreeByFamily(state *State) {
+	nodes := make([]*analyzer.TemporalNode, 0, len(state.Graph.Nodes))
+	for _, node := range state.Graph.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	clusters, ungrouped := ClusterNodes(nodes)
+	if len(ungrouped) > 0 {
+		clusters = append(clusters, Cluster{Nodes: ungrouped})
+	}
+	for i := range clusters {
+		cluster := &clusters[i]
+		label := cluster.Label
+		if label == "" {
+			label = "Ungrouped"
+		}
+		isExpanded := state.TreeState.ExpansionStates[label]
+		state.TreeState.Items = append(state.TreeState.Items, TreeItem{
+			Depth:       0,
+			DisplayText: label, // Label for expansion key
+			HasChildren: true,
+			IsExpanded:  isExpanded,
+			ChildCount:  len(cluster.Nodes),
+			Cluster:     cluster,
+		})
+		if !isExpanded {
+			continue
+		}
+		for _, n := range cluster.Nodes {
+			state.TreeState.Items = append(state.TreeState.Items, TreeItem{
+				Node:        n,
+				Depth:       1,
+				DisplayText: n.Name,
+				ChildCount:  len(n.CallSites),
+			})
+		}
+	}
+}
+
+// buildTreeByQueue groups workflows and activities by the task queue of the worker they are
+// registered on, with a last group for those not registered on any worker.
+func (tv *treeView) buildTreeByQueue(state *State) {
+	byQueue := make(map[string][]*analyzer.TemporalNode)
+	var queues []string
+	var unassigned []*analyzer.TemporalNode
+	for _, node := range state.Graph.Nodes {
+		if node.Type != "workflow" && node.Type != "activity" {
+			continue
+		}
+		if node.TaskQueue == "" {
+			unassigned = append(unassigned, node)
+			continue
+		}
+		if _, ok := byQueue[node.TaskQueue]; !ok {
+			queues = append(queues, node.TaskQueue)
+		}
+		byQueue[node.TaskQueue] = append(byQueue[node.TaskQueue], node)
+	}
+	sort.Strings(queues)
+
+	groups := make([][]*analyzer.TemporalNode, 0, len(queues)+1)
+	for _, queue := range queues {
+		groups = append(groups, byQueue[queue])
+	}
+	if len(unassigned) > 0 {
+		queues = append(queues, "No task queue")
+		groups = append(groups, unassigned)
+	}
+
+	for i, queue := range queues {
+		nodes := groups[i]
+		// Workflows first, then activities, each by name
+		sort.Slice(nodes, func(i, j int) bool {
+			if nodes[i].Type != nodes[j].Type {
+				return nodes[i].Type == "workflow"
+			}
+			return nodes[i].Name < nodes[j].Name
+		})
+		isExpanded := state.TreeState.ExpansionStates[queue]
+		state.TreeState.Items = append(state.TreeState.Items, TreeItem{
+			Depth:       0,
+			DisplayText: queue, // Queue for expansion key
+			HasChildren: true,
+			IsExpanded:  isExpanded,
+			ChildCount:  len(nodes),
+			IsQueue:     true,
+		})
+		if !isExpanded {
+			continue
+		}
+		for _, n := range nodes {
+			state.TreeState.Items = append(state.TreeState.Items, TreeItem{
+				Node:        n,
+				Depth:       1,
+				DisplayText: n.Name,
+				ChildCount:  len(n.CallSites),
+			})
+		}
+	}
+}
+
 // findCommonPrefix finds the longest common directory prefix.
 func findCommonPrefix(paths []string) string {
 	if len(paths) == 0 {
@@ -942,39 +1213,183 @@ func (tv *treeView) countNodesInTree(node *packageTreeNode) int {
 	return count
 }
 
-// addTreeItemRecursive adds a node and its children to the tree.
-func (tv *treeView) addTreeItemRecursive(state *State, node *analyzer.TemporalNode, depth int, expansionStates map[string]bool, visited map[string]bool) {
+// addHierarchyItem adds a node and, if expanded, its calls to the call hierarchy. callType
+// is the call type of the call site the node is drawn for, "" for roots.
+func addHierarchyItem(state *State, node *analyzer.TemporalNode, callType string, depth int, visited map[string]bool) {
 	// Prevent infinite recursion
-	if depth > MaxTreeDepth || visited[node.Name] {
+	if depth > MaxTreeDepth || visited[node.ID()] {
 		return
 	}
-	visited[node.Name] = true
-	defer func() { visited[node.Name] = false }()
+	visited[node.ID()] = true
+	defer func() { visited[node.ID()] = false }()
 
-	hasChildren := len(node.CallSites) > 0
-	isExpanded := hasChildren && expansionStates[node.Name]
+	calls := hierarchyCalls(state, node)
+	hasChildren := len(calls) > 0
+	isExpanded := hasChildren && state.TreeState.ExpansionStates[node.ID()]
 
-	item := TreeItem{
+	state.TreeState.Items = append(state.TreeState.Items, TreeItem{
 		Node:        node,
 		Depth:       depth,
 		HasChildren: hasChildren,
 		IsExpanded:  isExpanded,
-		ChildCount:  len(node.CallSites),
+		ChildCount:  len(calls),
+		CallType:    callType,
+	})
+
+	// Add children if expanded
+	if !isExpanded {
+		return
+	}
+	for _, call := range calls {
+		if call.target != nil {
+			addHierarchyItem(state, call.target, call.callType, depth+1, visited)
+			continue
+		}
+		state.TreeState.Items = append(state.TreeState.Items, TreeItem{
+			Depth:       depth + 1,
+			DisplayText: call.label,
+			CallType:    call.callType,
+		})
 	}
+}
 
-	state.TreeState.Items = append(state.TreeState.Items, item)
+// hierarchyCall is a child drawn under a node in the call hierarchy: a call of a node in
+// the graph, or a timer or signal sent, which have no node.
+type hierarchyCall struct {
+	callType string
+	line     int
+	target   *analyzer.TemporalNode
+	label    string
+}
 
-	// Add children if expanded
-	if isExpanded && hasChildren {
-		for _, callSite := range node.CallSites {
-			for _, targetNode := range state.Graph.Nodes {
-				if targetNode.Name == callSite.TargetName {
-					tv.addTreeItemRecursive(state, targetNode, depth+1, expansionStates, visited)
-					break
-				}
+// hierarchyCalls returns the children drawn under a node in the call hierarchy in source
+// order, leaving out the call types hidden in the tree state.
+func hierarchyCalls(state *State, node *analyzer.TemporalNode) []hierarchyCall {
+	hidden := state.TreeState.HiddenCalls
+	var calls []hierarchyCall
+	for _, call := range node.CallSites {
+		target, ok := state.Graph.Nodes[call.TargetName]
+		callType := hierarchyCallType(call)
+		if !ok || hidden[callType] {
+			continue
+		}
+		calls = append(calls, hierarchyCall{callType: callType, line: call.LineNumber, target: target})
+	}
+	if !hidden[theme.CallTimer] {
+		for _, timer := range node.Timers {
+			label := "Timer " + timer.Duration
+			if timer.IsSleep {
+				label = "Sleep " + timer.Duration
+			}
+			calls = append(calls, hierarchyCall{callType: theme.CallTimer, line: timer.LineNumber, label: label})
+		}
+	}
+	if !hidden[theme.CallSignal] {
+		for _, m := range state.Graph.MessagesSentBy("signal", node.ID()) {
+			label := m.Name
+			if label == "" {
+				label = m.Call
+			}
+			if m.Workflow != "" {
+				label += " → " + m.Workflow
 			}
+			calls = append(calls, hierarchyCall{callType: theme.CallSignal, line: m.LineNumber, label: label})
+		}
+	}
+	sort.SliceStable(calls, func(i, j int) bool {
+		return calls[i].line < calls[j].line
+	})
+	return calls
+}
+
+// hierarchyCallType returns the call type a call site is drawn with: what it executes, an
+// activity, local activity or child workflow, or "" for other calls such as internal ones.
+func hierarchyCallType(call analyzer.CallSite) string {
+	executed := call.TargetType
+	if call.CallType != "execute" && call.CallType != "" {
+		executed = call.CallType
+	}
+	switch executed {
+	case theme.CallActivity, theme.CallLocalActivity, theme.CallChildWorkflow:
+		return executed
+	}
+	return ""
+}
+
+// callToggles are the keys showing and hiding each call type in the call hierarchy, in
+// legend order.
+var callToggles = []struct {
+	key, callType, label string
+}{
+	{"a", theme.CallActivity, "activity"},
+	{"o", theme.CallLocalActivity, "local activity"},
+	{"w", theme.CallChildWorkflow, "child workflow"},
+	{"s", theme.CallSignal, "signal sent"},
+	{"z", theme.CallTimer, "timer"},
+}
+
+// callLabel returns the legend label of a call type, e.g. "local activity".
+func callLabel(callType string) string {
+	for _, toggle := range callToggles {
+		if toggle.callType == callType {
+			return toggle.label
+		}
+	}
+	return callType
+}
+
+// renderCallLegend renders the legend line of the call hierarchy: the icon of each call
+// type with the key showing and hiding it, hidden call types struck through.
+func renderCallLegend(state *State, t *theme.Theme, width int) string {
+	var hidden map[string]bool
+	if state.TreeState != nil {
+		hidden = state.TreeState.HiddenCalls
+	}
+	var parts []string
+	for _, toggle := range callToggles {
+		if state.Accessible {
+			part := toggle.key + " " + toggle.label
+			if hidden[toggle.callType] {
+				part += " (hidden)"
+			}
+			parts = append(parts, part)
+			continue
 		}
+		style := lipgloss.NewStyle().Foreground(t.CallColor(toggle.callType))
+		if hidden[toggle.callType] {
+			style = lipgloss.NewStyle().Foreground(t.Muted).Strikethrough(true)
+		}
+		key := lipgloss.NewStyle().Foreground(t.Subtle).Render(toggle.key)
+		parts = append(parts, key+" "+style.Render(theme.CallIcon(toggle.callType, false)+" "+toggle.label))
+	}
+	if state.Accessible {
+		return "Calls: " + strings.Join(parts, ", ")
+	}
+	return lipgloss.NewStyle().PaddingLeft(1).MaxWidth(width).Render(strings.Join(parts, " "))
+}
+
+// toggleCallType shows or hides a call type in the call hierarchy, keeping the selection.
+func (tv *treeView) toggleCallType(state *State, callType string) {
+	ts := state.TreeState
+	selected := ""
+	if ts.SelectedIndex < len(ts.Items) {
+		selected = ts.Items[ts.SelectedIndex].DisplayText
+		if node := ts.Items[ts.SelectedIndex].Node; node != nil {
+			selected = node.ID()
+		}
+	}
+	if ts.HiddenCalls == nil {
+		ts.HiddenCalls = make(map[string]bool)
 	}
+	ts.HiddenCalls[callType] = !ts.HiddenCalls[callType]
+	tv.buildTreeItems(state)
+	tv.restoreSelection(state, selected)
+
+	state.StatusMessage = "Showing " + callLabel(callType) + " calls"
+	if ts.HiddenCalls[callType] {
+		state.StatusMessage = "Hiding " + callLabel(callType) + " calls"
+	}
+	state.StatusType = StatusInfo
 }
 
 // restoreSelection finds and selects the item with the given name.
@@ -985,7 +1400,7 @@ func (tv *treeView) restoreSelection(state *State, name string) {
 
 	for i, item := range state.TreeState.Items {
 		// Check node name or display text (for package headers)
-		if item.Node != nil && item.Node.Name == name {
+		if item.Node != nil && item.Node.ID() == name {
 			state.TreeState.SelectedIndex = i
 			return
 		}
@@ -1010,15 +1425,19 @@ func (tv *treeView) restoreSelection(state *State, name string) {
 
 // detailsView implements the View interface for the details view.
 type detailsView struct {
-	styles        StyleManager
-	runtimeParser *RuntimeParser
+	styles   StyleManager
+	resolver *analyzer.Resolver // Builds nodes for functions drilled into that are not in the graph
+
+	// Lint issues of the graph, computed on first use by the edge explainer
+	lintGraph  *analyzer.TemporalGraph
+	lintIssues []lint.Issue
 }
 
 // NewDetailsView creates a new details view.
 func NewDetailsView(styles StyleManager) View {
 	return &detailsView{
-		styles:        styles,
-		runtimeParser: NewRuntimeParser(),
+		styles:   styles,
+		resolver: analyzer.NewResolver(slog.Default()),
 	}
 }
 
@@ -1046,10 +1465,10 @@ func (dv *detailsView) Render(state *State) string {
 	node := state.SelectedNode
 
 	// Header with node type badge
-	header := dv.renderHeader(node, width)
+	header := dv.renderHeader(state, node, width)
 
 	// Navigation breadcrumb
-	breadcrumb := dv.renderBreadcrumb(state, width)
+	breadcrumb := renderBreadcrumb(state, dv.styles.GetTheme(), width)
 
 	// Build content sections
 	content := dv.buildContent(state, node, width)
@@ -1061,11 +1480,12 @@ func (dv *detailsView) Render(state *State) string {
 }
 
 // renderHeader creates the details header with type badge.
-func (dv *detailsView) renderHeader(node *analyzer.TemporalNode, width int) string {
+func (dv *detailsView) renderHeader(state *State, node *analyzer.TemporalNode, width int) string {
+	t := dv.styles.GetTheme()
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#ffffff")).
-		Background(lipgloss.Color("#161b22")).
+		Foreground(t.Bright).
+		Background(t.Surface).
 		Padding(0, 2).
 		Width(width)
 
@@ -1073,7 +1493,7 @@ func (dv *detailsView) renderHeader(node *analyzer.TemporalNode, width int) stri
 	badgeColor := dv.getTypeColor(node.Type)
 	badge := lipgloss.NewStyle().
 		Background(badgeColor).
-		Foreground(lipgloss.Color("#0d1117")).
+		Foreground(t.Base).
 		Bold(true).
 		Padding(0, 1).
 		Render(strings.ToUpper(node.Type))
@@ -1082,27 +1502,17 @@ func (dv *detailsView) renderHeader(node *analyzer.TemporalNode, width int) stri
 	header := headerStyle.Render(fmt.Sprintf("%s %s  %s", icon, node.Name, badge))
 
 	// Type-specific gradient
-	gradient := dv.renderGradient(width, badgeColor)
+	gradient := ""
+	if !state.Accessible {
+		gradient = dv.renderGradient(width, badgeColor)
+	}
 
 	return header + "\n" + gradient
 }
 
 // getTypeColor returns the color for a node type.
 func (dv *detailsView) getTypeColor(nodeType string) lipgloss.Color {
-	switch nodeType {
-	case "workflow":
-		return lipgloss.Color("#a371f7")
-	case "activity":
-		return lipgloss.Color("#7ee787")
-	case "signal", "signal_handler":
-		return lipgloss.Color("#ffa657")
-	case "query", "query_handler":
-		return lipgloss.Color("#79c0ff")
-	case "update", "update_handler":
-		return lipgloss.Color("#ff7b72")
-	default:
-		return lipgloss.Color("#58a6ff")
-	}
+	return dv.styles.GetTheme().NodeColor(nodeType)
 }
 
 // renderGradient creates a gradient from the type color.
@@ -1113,26 +1523,6 @@ func (dv *detailsView) renderGradient(width int, accentColor lipgloss.Color) str
 	return gradient.String()
 }
 
-// renderBreadcrumb renders the navigation path.
-func (dv *detailsView) renderBreadcrumb(state *State, width int) string {
-	path := state.Navigator.GetPath()
-	if len(path) == 0 {
-		return ""
-	}
-
-	pathStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("#21262d")).
-		Foreground(lipgloss.Color("#6e7681")).
-		Padding(0, 1)
-
-	var parts []string
-	for _, item := range path {
-		parts = append(parts, item.DisplayName)
-	}
-
-	return pathStyle.Render("📍 " + strings.Join(parts, " → "))
-}
-
 // buildContent builds the main content sections.
 func (dv *detailsView) buildContent(state *State, node *analyzer.TemporalNode, width int) string {
 	var sections []string
@@ -1143,6 +1533,11 @@ func (dv *detailsView) buildContent(state *State, node *analyzer.TemporalNode, w
 	// Always show Calls section (Temporal SDK calls)
 	sections = append(sections, dv.renderCallsSection(state, node, width))
 
+	// Explainer panel for the selected call edge
+	if state.DetailsState != nil && state.DetailsState.Explain != nil {
+		sections = append(sections, dv.renderExplainPanel(state.DetailsState.Explain, width))
+	}
+
 	// Always show Called by section
 	sections = append(sections, dv.renderCallersSection(state, node, width))
 
@@ -1171,51 +1566,120 @@ func (dv *detailsView) buildContent(state *State, node *analyzer.TemporalNode, w
 
 // renderInfoSection renders the node information section.
 func (dv *detailsView) renderInfoSection(node *analyzer.TemporalNode, width int) string {
+	t := dv.styles.GetTheme()
 	boxStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#30363d")).
+		BorderForeground(t.Border).
 		Padding(0, 1).
 		Width(width - 4)
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#58a6ff")).
+		Foreground(t.Primary).
 		Bold(true)
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6e7681")).
+		Foreground(t.Subtle).
 		Width(12)
 
 	valueStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#e6edf3"))
+		Foreground(t.Text)
 
 	var content strings.Builder
 	content.WriteString(titleStyle.Render("📋 Information") + "\n\n")
+	if node.Unresolved {
+		unresolvedStyle := lipgloss.NewStyle().
+			Foreground(t.Warning).
+			Italic(true)
+		content.WriteString(unresolvedStyle.Render("❔ Unresolved: called from the analyzed code but not defined in it") + "\n")
+	}
 	content.WriteString(labelStyle.Render("📁 File:") + valueStyle.Render(node.FilePath) + "\n")
 	content.WriteString(labelStyle.Render("📦 Package:") + valueStyle.Render(node.Package) + "\n")
+	if len(node.Aliases) > 0 {
+		content.WriteString(labelStyle.Render("🔖 Aliases:") + valueStyle.Render(strings.Join(node.Aliases, ", ")) + "\n")
+	}
+	if node.Domain != "" {
+		content.WriteString(labelStyle.Render("🗂 Domain:") + valueStyle.Render(node.Domain) + "\n")
+	}
+	if node.TaskQueue != "" {
+		content.WriteString(labelStyle.Render("🏭 Queue:") + valueStyle.Render(node.TaskQueue) + "\n")
+	}
 	if node.LineNumber > 0 {
 		content.WriteString(labelStyle.Render("📍 Line:") + valueStyle.Render(fmt.Sprintf("%d", node.LineNumber)) + "\n")
 	}
 	if node.Description != "" {
 		content.WriteString(labelStyle.Render("📄 Desc:") + valueStyle.Render(node.Description) + "\n")
 	}
+	if !node.Unresolved && (len(node.ParameterTypes) > 0 || len(node.Parameters) > 0 || node.ResultSignature() != "") {
+		content.WriteString(labelStyle.Render("✍ Signature:") + valueStyle.Render(node.Signature()) + "\n")
+	}
+	if len(node.Tags) > 0 || len(node.Annotations) > 0 {
+		content.WriteString(labelStyle.Render("🏷 Tags:") + valueStyle.Render(dv.formatAnnotations(node)) + "\n")
+	}
+	if node.Type == "workflow" || node.Type == "activity" {
+		content.WriteString(labelStyle.Render("🧪 Tested:") + valueStyle.Render(dv.formatTests(node)) + "\n")
+	}
+	if node.Churn != nil {
+		age := int(node.Churn.Age(time.Now()).Hours() / 24)
+		churn := fmt.Sprintf("%d commits in 90 days, first committed %s (%dd ago)",
+			node.Churn.Commits, node.Churn.FirstCommit.Format("2006-01-02"), age)
+		content.WriteString(labelStyle.Render("🔥 Churn:") + valueStyle.Render(churn) + "\n")
+	}
 
 	return boxStyle.Render(content.String())
 }
 
+// formatAnnotations lists the overlay tags of a node, then its annotations sorted by key.
+func (dv *detailsView) formatAnnotations(node *analyzer.TemporalNode) string {
+	keys := make([]string, 0, len(node.Annotations))
+	for key := range node.Annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	if len(node.Tags) > 0 {
+		parts = append(parts, strings.Join(node.Tags, ", "))
+	}
+	for _, key := range keys {
+		parts = append(parts, strings.TrimSpace("@"+key+" "+node.Annotations[key]))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// formatTests summarizes the tests that exercise a node.
+func (dv *detailsView) formatTests(node *analyzer.TemporalNode) string {
+	if !node.IsTested() {
+		return "no"
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, test := range node.Tests {
+		if seen[test.TestName] {
+			continue
+		}
+		seen[test.TestName] = true
+		names = append(names, test.TestName)
+	}
+
+	return fmt.Sprintf("yes (%s)", strings.Join(names, ", "))
+}
+
 // renderCallsSection renders the outgoing calls section.
 func (dv *detailsView) renderCallsSection(state *State, node *analyzer.TemporalNode, width int) string {
+	t := dv.styles.GetTheme()
 	boxStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#7ee787")).
+		BorderForeground(t.Activity).
 		Padding(0, 1).
 		Width(width - 4)
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#7ee787")).
+		Foreground(t.Activity).
 		Bold(true)
 
 	emptyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6e7681")).
+		Foreground(t.Subtle).
 		Italic(true)
 
 	var content strings.Builder
@@ -1240,20 +1704,29 @@ func (dv *detailsView) renderCallsSection(state *State, node *analyzer.TemporalN
 
 // renderCallItem renders a single call item.
 func (dv *detailsView) renderCallItem(state *State, call analyzer.CallSite, isSelected bool) string {
+	t := dv.styles.GetTheme()
 	icon := getNodeIcon(call.TargetType)
 	
-	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#e6edf3"))
-	metaStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6e7681"))
+	nameStyle := lipgloss.NewStyle().Foreground(t.Text)
+	metaStyle := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	displayName := call.TargetName
+	if target, ok := state.Graph.Nodes[call.TargetName]; ok {
+		displayName = target.Name
+	}
 
 	line := fmt.Sprintf("  %s %s %s",
 		icon,
-		nameStyle.Render(call.TargetName),
-		metaStyle.Render(fmt.Sprintf("(%s:%d)", call.FilePath, call.LineNumber)))
+		nameStyle.Render(displayName),
+		metaStyle.Render(fmt.Sprintf("(%s:%d)", filepath.Base(call.FilePath), call.LineNumber)))
 
+	if state.Accessible {
+		return accessibleRow(fmt.Sprintf("%s %s (%s:%d)", call.TargetType, displayName, filepath.Base(call.FilePath), call.LineNumber), isSelected)
+	}
 			if isSelected {
 		return lipgloss.NewStyle().
-			Background(lipgloss.Color("#388bfd")).
-			Foreground(lipgloss.Color("#ffffff")).
+			Background(t.Selection).
+			Foreground(t.Bright).
 			Bold(true).
 			Render("▶" + line)
 	}
@@ -1263,18 +1736,19 @@ func (dv *detailsView) renderCallItem(state *State, call analyzer.CallSite, isSe
 
 // renderCallersSection renders the incoming callers section.
 func (dv *detailsView) renderCallersSection(state *State, node *analyzer.TemporalNode, width int) string {
+	t := dv.styles.GetTheme()
 	boxStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#ffa657")).
+		BorderForeground(t.Signal).
 		Padding(0, 1).
 		Width(width - 4)
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffa657")).
+		Foreground(t.Signal).
 		Bold(true)
 
 	emptyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6e7681")).
+		Foreground(t.Subtle).
 		Italic(true)
 
 	var content strings.Builder
@@ -1290,24 +1764,30 @@ func (dv *detailsView) renderCallersSection(state *State, node *analyzer.Tempora
 				callsOffset+i < len(state.DetailsState.SelectableItems) &&
 				state.DetailsState.SelectedIndex == callsOffset+i
 
-			// Find parent node type
-			parentType := "workflow"
-			for _, n := range state.Graph.Nodes {
-				if n.Name == parentName {
-					parentType = n.Type
-					break
-				}
+			parentType, displayName := "workflow", parentName
+			if parent, ok := state.Graph.Nodes[parentName]; ok {
+				parentType, displayName = parent.Type, parent.Name
 			}
 
 			icon := getNodeIcon(parentType)
-			nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#e6edf3"))
+			nameStyle := lipgloss.NewStyle().Foreground(t.Text)
+			metaStyle := lipgloss.NewStyle().Foreground(t.Subtle)
 
-			line := fmt.Sprintf("  %s %s", icon, nameStyle.Render(parentName))
+			line := fmt.Sprintf("  %s %s", icon, nameStyle.Render(displayName))
+			if ref, ok := node.CallFrom(parentName); ok && ref.LineNumber > 0 {
+				line += " " + metaStyle.Render(fmt.Sprintf("(%s:%d)", filepath.Base(ref.FilePath), ref.LineNumber))
+			}
 
-			if isSelected {
+			if state.Accessible {
+				text := parentType + " " + displayName
+				if ref, ok := node.CallFrom(parentName); ok && ref.LineNumber > 0 {
+					text += fmt.Sprintf(" (%s:%d)", filepath.Base(ref.FilePath), ref.LineNumber)
+				}
+				line = accessibleRow(text, isSelected)
+			} else if isSelected {
 				line = lipgloss.NewStyle().
-					Background(lipgloss.Color("#388bfd")).
-					Foreground(lipgloss.Color("#ffffff")).
+					Background(t.Selection).
+					Foreground(t.Bright).
 					Bold(true).
 					Render("▶" + line)
 			} else {
@@ -1323,36 +1803,37 @@ func (dv *detailsView) renderCallersSection(state *State, node *analyzer.Tempora
 
 // renderInternalCallsSection renders the internal (non-Temporal) function calls section.
 func (dv *detailsView) renderInternalCallsSection(state *State, node *analyzer.TemporalNode, width int) string {
+	t := dv.styles.GetTheme()
 	boxStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#8b949e")).
+		BorderForeground(t.TextMuted).
 		Padding(0, 1).
 		Width(width - 4)
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#8b949e")).
+		Foreground(t.TextMuted).
 		Bold(true)
 
 	funcStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#d2a8ff"))
+		Foreground(t.Timer)
 
 	methodStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#79c0ff"))
+		Foreground(t.Tertiary)
 
 	receiverStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#7ee787"))
+		Foreground(t.Activity)
 
 	lineNumStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6e7681"))
+		Foreground(t.Subtle)
 
 	selectedStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("#388bfd")).
-		Foreground(lipgloss.Color("#ffffff")).
+		Background(t.Selection).
+		Foreground(t.Bright).
 		Bold(true)
 
 	var content strings.Builder
 	content.WriteString(titleStyle.Render(fmt.Sprintf("🔧 Internal Calls (%d)", len(node.InternalCalls))) + "  ")
-	content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#6e7681")).Italic(true).Render("Enter to drill in"))
+	content.WriteString(lipgloss.NewStyle().Foreground(t.Subtle).Italic(true).Render("Enter to drill in"))
 	content.WriteString("\n\n")
 
 	// Calculate offset for internal calls in selectable items
@@ -1375,7 +1856,13 @@ func (dv *detailsView) renderInternalCallsSection(state *State, node *analyzer.T
 			}
 		line += lineNumStyle.Render(fmt.Sprintf("  :%d", call.LineNumber))
 
-		if isSelected {
+		if state.Accessible {
+			text := call.TargetName + "()"
+			if call.Receiver != "" {
+				text = call.Receiver + "." + text
+			}
+			content.WriteString(accessibleRow(fmt.Sprintf("%s line %d", text, call.LineNumber), isSelected) + "\n")
+		} else if isSelected {
 			content.WriteString(selectedStyle.Render("▶" + line) + "\n")
 		} else {
 			content.WriteString(" " + line + "\n")
@@ -1387,18 +1874,19 @@ func (dv *detailsView) renderInternalCallsSection(state *State, node *analyzer.T
 
 // renderSignalsSection renders the signals section.
 func (dv *detailsView) renderSignalsSection(node *analyzer.TemporalNode, width int) string {
+	t := dv.styles.GetTheme()
 	boxStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#ffa657")).
+		BorderForeground(t.Signal).
 		Padding(0, 1).
 		Width(width - 4)
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffa657")).
+		Foreground(t.Signal).
 		Bold(true)
 
 	var content strings.Builder
-	content.WriteString(titleStyle.Render(fmt.Sprintf("🔔 Signals (%d)", len(node.Signals))) + "\n\n")
+	content.WriteString(titleStyle.Render(fmt.Sprintf("%s Signals (%d)", getNodeIcon("signal"), len(node.Signals))) + "\n\n")
 
 	for _, signal := range node.Signals {
 		content.WriteString(fmt.Sprintf("  • %s (handler: %s)\n", signal.Name, signal.Handler))
@@ -1409,18 +1897,19 @@ func (dv *detailsView) renderSignalsSection(node *analyzer.TemporalNode, width i
 
 // renderQueriesSection renders the queries section.
 func (dv *detailsView) renderQueriesSection(node *analyzer.TemporalNode, width int) string {
+	t := dv.styles.GetTheme()
 	boxStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#79c0ff")).
+		BorderForeground(t.Query).
 		Padding(0, 1).
 		Width(width - 4)
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#79c0ff")).
+		Foreground(t.Query).
 		Bold(true)
 
 	var content strings.Builder
-	content.WriteString(titleStyle.Render(fmt.Sprintf("❓ Queries (%d)", len(node.Queries))) + "\n\n")
+	content.WriteString(titleStyle.Render(fmt.Sprintf("%s Queries (%d)", getNodeIcon("query"), len(node.Queries))) + "\n\n")
 
 	for _, query := range node.Queries {
 		content.WriteString(fmt.Sprintf("  • %s (handler: %s)\n", query.Name, query.Handler))
@@ -1431,14 +1920,15 @@ func (dv *detailsView) renderQueriesSection(node *analyzer.TemporalNode, width i
 
 // renderTimersSection renders the timers section.
 func (dv *detailsView) renderTimersSection(node *analyzer.TemporalNode, width int) string {
+	t := dv.styles.GetTheme()
 	boxStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#d2a8ff")).
+		BorderForeground(t.Timer).
 		Padding(0, 1).
 		Width(width - 4)
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#d2a8ff")).
+		Foreground(t.Timer).
 		Bold(true)
 
 	var content strings.Builder
@@ -1457,24 +1947,27 @@ func (dv *detailsView) renderTimersSection(node *analyzer.TemporalNode, width in
 
 // renderFooter creates the footer for details view.
 func (dv *detailsView) renderFooter(state *State, width int) string {
+	t := dv.styles.GetTheme()
 	bindings := []struct {
 		key   string
 		label string
 	}{
 		{"j/k", "Navigate"},
 		{"Enter", "Drill In"},
+		{"x", "Explain"},
 		{"t", "Tree"},
+		{"Alt+1…9", "Crumb"},
 		{"q", "Back"},
 	}
 
 	keyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#a371f7")).
-		Background(lipgloss.Color("#21262d")).
+		Foreground(t.Workflow).
+		Background(t.Overlay).
 		Padding(0, 1).
 		Bold(true)
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6e7681"))
+		Foreground(t.Subtle)
 
 	var parts []string
 	for _, b := range bindings {
@@ -1485,23 +1978,14 @@ func (dv *detailsView) renderFooter(state *State, width int) string {
 	
 	// Show status message if present
 	if state.StatusMessage != "" {
-		statusColor := "#6e7681"
-		switch state.StatusType {
-		case "success":
-			statusColor = "#7ee787"
-		case "warning":
-			statusColor = "#d29922"
-		case "error":
-			statusColor = "#f85149"
-		}
 		statusStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(statusColor)).
+			Foreground(t.StatusColor(state.StatusType)).
 			Italic(true)
 		footerContent = footerContent + "  " + statusStyle.Render(state.StatusMessage)
 	}
 
 	footerStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("#161b22")).
+		Background(t.Surface).
 		Padding(0, 1).
 		Width(width)
 
@@ -1517,6 +2001,7 @@ func (dv *detailsView) Update(msg tea.Msg, state *State) (*State, tea.Cmd) {
 				if state.DetailsState.SelectedIndex < len(state.DetailsState.SelectableItems)-1 {
 					state.DetailsState.SelectedIndex++
 				}
+				dv.refreshExplain(state)
 			}
 			return state, nil
 
@@ -1525,15 +2010,20 @@ func (dv *detailsView) Update(msg tea.Msg, state *State) (*State, tea.Cmd) {
 				if state.DetailsState.SelectedIndex > 0 {
 					state.DetailsState.SelectedIndex--
 				}
+				dv.refreshExplain(state)
 			}
 			return state, nil
 
+		case "x":
+			dv.toggleExplain(state)
+			return state, nil
+
 		case "enter":
 			if state.DetailsState != nil && len(state.DetailsState.SelectableItems) > 0 &&
 				state.DetailsState.SelectedIndex < len(state.DetailsState.SelectableItems) {
 				selected := state.DetailsState.SelectableItems[state.DetailsState.SelectedIndex]
 
-				// Handle internal calls - dynamically parse the source
+				// Handle internal calls - resolve the called function from the source
 				if selected.ItemType == "internal" {
 					// Get the target function name and receiver
 					var targetName, receiver string
@@ -1554,10 +2044,23 @@ func (dv *detailsView) Update(msg tea.Msg, state *State) (*State, tea.Cmd) {
 						callerNode := state.SelectedNode // Remember who called this
 
 						// Try to find the function in the source
-						foundNode := dv.runtimeParser.FindFunction(targetName, searchPath)
+						var foundNode *analyzer.TemporalNode
+						if selected.InternalCall != nil {
+							foundNode = dv.resolver.ResolveCall(context.Background(), *selected.InternalCall, callerNode)
+						} else {
+							foundNode = dv.resolver.FindFunction(context.Background(), targetName, searchPath)
+						}
 						if foundNode != nil {
 							// Add the caller to Parents so "Called By" shows correctly
-							foundNode.Parents = append(foundNode.Parents, callerNode.Name)
+							foundNode.Parents = append(foundNode.Parents, callerNode.ID())
+							if selected.InternalCall != nil {
+								foundNode.CalledBy = append(foundNode.CalledBy, analyzer.ParentRef{
+									Name:       callerNode.ID(),
+									FilePath:   callerNode.FilePath,
+									LineNumber: selected.InternalCall.LineNumber,
+									CallType:   "internal",
+								})
+							}
 
 							// Push current state for back navigation
 							state.Navigator.PushState(ViewState{
@@ -1611,8 +2114,8 @@ func (dv *detailsView) Update(msg tea.Msg, state *State) (*State, tea.Cmd) {
 
 					state.DetailsState = dv.buildDetailsState(state)
 				} else if selected.ItemType == "caller" {
-					// Try to find the caller via runtime parser (for runtime-parsed callers)
-					callerNode := dv.runtimeParser.FindFunction(selected.DisplayText, state.SelectedNode.FilePath)
+					// Try to find the caller in the source (for callers resolved on demand)
+					callerNode := dv.resolver.FindFunction(context.Background(), selected.DisplayText, state.SelectedNode.FilePath)
 					if callerNode != nil {
 						state.Navigator.PushState(ViewState{
 							View:         ViewDetails,
@@ -1652,49 +2155,36 @@ func (dv *detailsView) buildDetailsState(state *State) *DetailsViewState {
 	node := state.SelectedNode
 
 	// Add call sites as selectable items
-	for _, call := range node.CallSites {
-			for _, targetNode := range state.Graph.Nodes {
-				if targetNode.Name == call.TargetName {
-					selectableItems = append(selectableItems, SelectableItem{
-					LineIndex:   len(selectableItems),
-						Node:        targetNode,
-						ItemType:    "callee",
-						DisplayText: call.TargetName,
-					Section:     "calls",
-					FilePath:    targetNode.FilePath,
-					LineNumber:  targetNode.LineNumber,
-					})
-					break
-				}
-			}
+	for i, call := range node.CallSites {
+		if targetNode, ok := state.Graph.Nodes[call.TargetName]; ok {
+			selectableItems = append(selectableItems, SelectableItem{
+				LineIndex:   len(selectableItems),
+				Node:        targetNode,
+				Call:        &node.CallSites[i],
+				ItemType:    "callee",
+				DisplayText: targetNode.Name,
+				Section:     "calls",
+				FilePath:    targetNode.FilePath,
+				LineNumber:  targetNode.LineNumber,
+			})
+		}
 	}
 
 	// Add parents as selectable items
 	for _, parentName := range node.Parents {
-		var parentNode *analyzer.TemporalNode
-		var filePath string
-		var lineNum int
-		
-		// Try to find parent in graph
-		for _, pn := range state.Graph.Nodes {
-			if pn.Name == parentName {
-				parentNode = pn
-				filePath = pn.FilePath
-				lineNum = pn.LineNumber
-				break
-			}
-		}
-		
-		// Add even if not in graph (for runtime-parsed callers)
-		selectableItems = append(selectableItems, SelectableItem{
+		item := SelectableItem{
 			LineIndex:   len(selectableItems),
-			Node:        parentNode, // May be nil for runtime callers
 			ItemType:    "caller",
 			DisplayText: parentName,
 			Section:     "callers",
-			FilePath:    filePath,
-			LineNumber:  lineNum,
-		})
+		}
+		// Add even if not in graph (for runtime-parsed callers)
+		if parentNode, ok := state.Graph.Nodes[parentName]; ok {
+			item.Node = parentNode
+			item.FilePath = parentNode.FilePath
+			item.LineNumber = parentNode.LineNumber
+		}
+		selectableItems = append(selectableItems, item)
 	}
 
 	// Add internal calls as selectable items
@@ -1745,6 +2235,7 @@ func (sv *statsView) Name() string {
 
 // Render renders the statistics dashboard.
 func (sv *statsView) Render(state *State) string {
+	t := sv.styles.GetTheme()
 	width := state.WindowWidth
 	if width < 40 {
 		width = 80
@@ -1755,21 +2246,27 @@ func (sv *statsView) Render(state *State) string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#ffffff")).
-		Background(lipgloss.Color("#161b22")).
+		Foreground(t.Bright).
+		Background(t.Surface).
 		Padding(0, 2).
 		Width(width)
 
 	header := headerStyle.Render("📊 STATISTICS DASHBOARD")
-	gradient := sv.renderGradient(width)
+	gradient := ""
+	if !state.Accessible {
+		gradient = sv.renderGradient(width)
+	}
+	if breadcrumb := renderBreadcrumb(state, t, width); breadcrumb != "" {
+		gradient += "\n" + breadcrumb
+	}
 
 	// Stats boxes
 	boxWidth := (width - 8) / 4
 
-	workflowBox := sv.renderStatBox("⚡ Workflows", stats.TotalWorkflows, "#a371f7", boxWidth)
-	activityBox := sv.renderStatBox("⚙ Activities", stats.TotalActivities, "#7ee787", boxWidth)
-	signalBox := sv.renderStatBox("🔔 Signals", stats.TotalSignals, "#ffa657", boxWidth)
-	depthBox := sv.renderStatBox("📏 Max Depth", stats.MaxDepth, "#79c0ff", boxWidth)
+	workflowBox := sv.renderStatBox(getNodeIcon("workflow")+" Workflows", stats.TotalWorkflows, t.NodeColor("workflow"), boxWidth)
+	activityBox := sv.renderStatBox(getNodeIcon("activity")+" Activities", stats.TotalActivities, t.NodeColor("activity"), boxWidth)
+	signalBox := sv.renderStatBox(getNodeIcon("signal")+" Signals", stats.TotalSignals, t.NodeColor("signal"), boxWidth)
+	depthBox := sv.renderStatBox("📏 Max Depth", stats.MaxDepth, t.Tertiary, boxWidth)
 
 	statsRow := lipgloss.JoinHorizontal(
 		lipgloss.Top,
@@ -1781,6 +2278,15 @@ func (sv *statsView) Render(state *State) string {
 
 	// Additional stats
 	detailsBox := sv.renderDetailsBox(stats, width-4)
+	if len(state.Graph.Workers) > 0 {
+		detailsBox += "\n" + sv.renderWorkersBox(state.Graph, width-4)
+	}
+	if families := sv.renderFamiliesBox(state.Graph, width-4); families != "" {
+		detailsBox += "\n" + families
+	}
+	if len(state.History) > 0 {
+		detailsBox += "\n" + sv.renderTrendBox(state.History, width-4)
+	}
 
 	// Footer
 	footer := sv.renderFooter(width)
@@ -1790,7 +2296,8 @@ func (sv *statsView) Render(state *State) string {
 
 // renderGradient creates a beautiful gradient line.
 func (sv *statsView) renderGradient(width int) string {
-	colors := []string{"#58a6ff", "#a371f7", "#7ee787", "#ffa657", "#58a6ff"}
+	t := sv.styles.GetTheme()
+	colors := []lipgloss.Color{t.Primary, t.Workflow, t.Activity, t.Signal, t.Primary}
 	segmentWidth := width / len(colors)
 	var gradient strings.Builder
 
@@ -1799,27 +2306,28 @@ func (sv *statsView) renderGradient(width int) string {
 		if i == len(colors)-1 {
 			segment = strings.Repeat("▀", width-i*segmentWidth)
 		}
-		gradient.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(segment))
+		gradient.WriteString(lipgloss.NewStyle().Foreground(color).Render(segment))
 	}
 
 	return gradient.String()
 }
 
 // renderStatBox renders a single statistics box.
-func (sv *statsView) renderStatBox(label string, value int, color string, width int) string {
+func (sv *statsView) renderStatBox(label string, value int, color lipgloss.Color, width int) string {
+	t := sv.styles.GetTheme()
 	boxStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(color)).
+		BorderForeground(color).
 		Padding(1, 2).
 		Width(width).
 		Align(lipgloss.Center)
 
 	valueStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(color)).
+		Foreground(color).
 		Bold(true)
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6e7681"))
+		Foreground(t.Subtle)
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -1832,27 +2340,38 @@ func (sv *statsView) renderStatBox(label string, value int, color string, width
 
 // renderDetailsBox renders additional statistics details.
 func (sv *statsView) renderDetailsBox(stats analyzer.GraphStats, width int) string {
+	t := sv.styles.GetTheme()
 	boxStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#30363d")).
+		BorderForeground(t.Border).
 		Padding(1, 2).
 		Width(width)
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#58a6ff")).
+		Foreground(t.Primary).
 		Bold(true)
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6e7681")).
+		Foreground(t.Subtle).
 		Width(20)
 
 	valueStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#e6edf3"))
+		Foreground(t.Text)
 
 	var content strings.Builder
 	content.WriteString(titleStyle.Render("📈 Additional Metrics") + "\n\n")
 	content.WriteString(labelStyle.Render("Orphan Nodes:") + valueStyle.Render(fmt.Sprintf("%d", stats.OrphanNodes)) + "\n")
+	if stats.UnresolvedTargets > 0 {
+		content.WriteString(labelStyle.Render("Unresolved Targets:") + valueStyle.Render(fmt.Sprintf("%d", stats.UnresolvedTargets)) + "\n")
+	}
+	if stats.FailedFiles > 0 {
+		content.WriteString(labelStyle.Render("Failed Files:") + valueStyle.Render(fmt.Sprintf("%d", stats.FailedFiles)) + "\n")
+	}
 	content.WriteString(labelStyle.Render("Total Connections:") + valueStyle.Render(fmt.Sprintf("%d", stats.TotalConnections)) + "\n")
+	if stats.WeightedConnections != stats.TotalConnections || stats.DistinctConnections != stats.TotalConnections {
+		content.WriteString(labelStyle.Render("Distinct Connections:") + valueStyle.Render(fmt.Sprintf("%d", stats.DistinctConnections)) + "\n")
+		content.WriteString(labelStyle.Render("Weighted Connections:") + valueStyle.Render(fmt.Sprintf("%d", stats.WeightedConnections)) + "\n")
+	}
 	content.WriteString(labelStyle.Render("Queries:") + valueStyle.Render(fmt.Sprintf("%d", stats.TotalQueries)) + "\n")
 	content.WriteString(labelStyle.Render("Updates:") + valueStyle.Render(fmt.Sprintf("%d", stats.TotalUpdates)) + "\n")
 	content.WriteString(labelStyle.Render("Timers:") + valueStyle.Render(fmt.Sprintf("%d", stats.TotalTimers)) + "\n")
@@ -1863,12 +2382,163 @@ func (sv *statsView) renderDetailsBox(stats analyzer.GraphStats, width int) stri
 	if stats.MaxFanOut > 0 {
 		content.WriteString(labelStyle.Render("Max Fan-Out:") + valueStyle.Render(fmt.Sprintf("%d", stats.MaxFanOut)) + "\n")
 	}
+	if len(stats.DomainCoupling) > 0 {
+		content.WriteString(labelStyle.Render("Cross-Domain:") + valueStyle.Render(fmt.Sprintf("%d calls", stats.CrossDomainCalls)) + "\n")
+		// Show the most coupled domain pairs
+		for i, c := range stats.DomainCoupling {
+			if i == 3 {
+				break
+			}
+			content.WriteString(labelStyle.Render("") + valueStyle.Render(fmt.Sprintf("%s → %s: %d", c.From, c.To, c.Calls)) + "\n")
+		}
+	}
+
+	return boxStyle.Render(content.String())
+}
+
+// renderWorkersBox renders the workers found in code with their limits and registrations.
+func (sv *statsView) renderWorkersBox(graph *analyzer.TemporalGraph, width int) string {
+	t := sv.styles.GetTheme()
+	boxStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(1, 2).
+		Width(width)
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	queueStyle := lipgloss.NewStyle().
+		Foreground(t.Activity).
+		Bold(true)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(t.Subtle)
+
+	warnStyle := lipgloss.NewStyle().
+		Foreground(t.Warning)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("🏭 Workers") + "\n\n")
+	for _, w := range graph.Workers {
+		content.WriteString(queueStyle.Render(w.TaskQueue))
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  %d workflows, %d activities, %d activity slots",
+			len(w.Workflows), len(w.Activities), w.ActivitySlots())))
+		if w.StickyCacheSize > 0 {
+			content.WriteString(mutedStyle.Render(fmt.Sprintf(", sticky cache %d", w.StickyCacheSize)))
+		}
+		if len(w.Interceptors) > 0 {
+			content.WriteString(mutedStyle.Render(", interceptors: " + strings.Join(w.Interceptors, ", ")))
+		}
+		content.WriteString("\n")
+	}
+
+	if unregistered := graph.UnregisteredNodes(); len(unregistered) > 0 {
+		names := make([]string, 0, len(unregistered))
+		for _, node := range unregistered {
+			names = append(names, node.Name)
+		}
+		content.WriteString("\n" + warnStyle.Render(fmt.Sprintf("⚠ Not registered on any worker: %s", strings.Join(names, ", "))) + "\n")
+	}
+
+	return boxStyle.Render(content.String())
+}
+
+// renderFamiliesBox renders the largest workflow families with their stats, or "" if no
+// names share a family.
+func (sv *statsView) renderFamiliesBox(graph *analyzer.TemporalGraph, width int) string {
+	t := sv.styles.GetTheme()
+	nodes := make([]*analyzer.TemporalNode, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	clusters, _ := ClusterNodes(nodes)
+	if len(clusters) == 0 {
+		return ""
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(1, 2).
+		Width(width)
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	familyStyle := lipgloss.NewStyle().
+		Foreground(t.Timer).
+		Bold(true).
+		Width(24)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(t.Subtle)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("▤ Largest Families") + mutedStyle.Render(fmt.Sprintf("  %d families", len(clusters))) + "\n\n")
+	for i, cluster := range clusters {
+		if i == 5 {
+			break
+		}
+		content.WriteString(familyStyle.Render(fmt.Sprintf("%s (%d)", cluster.Label, len(cluster.Nodes))))
+		content.WriteString(mutedStyle.Render(cluster.Stats().String()) + "\n")
+	}
+
+	return boxStyle.Render(content.String())
+}
+
+// renderTrendBox charts the recorded snapshot metrics over time.
+func (sv *statsView) renderTrendBox(snapshots []history.Snapshot, width int) string {
+	t := sv.styles.GetTheme()
+	boxStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(1, 2).
+		Width(width)
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(t.TextMuted).
+		Width(14)
+
+	sparkStyle := lipgloss.NewStyle().
+		Foreground(t.Activity)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(t.Subtle)
+
+	// Keep the most recent snapshots that fit next to the labels
+	maxPoints := max(width-40, 10)
+	if len(snapshots) > maxPoints {
+		snapshots = snapshots[len(snapshots)-maxPoints:]
+	}
+	first, last := snapshots[0], snapshots[len(snapshots)-1]
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("📈 Trend") + mutedStyle.Render(fmt.Sprintf("  %d snapshot(s), %s → %s",
+		len(snapshots), first.Time.Format(time.DateOnly), last.Time.Format(time.DateOnly))) + "\n\n")
+	for _, m := range history.Metrics {
+		values := m.Values(snapshots)
+		content.WriteString(labelStyle.Render(m.Name))
+		content.WriteString(sparkStyle.Render(history.Sparkline(values, glyphs.Unicode.Spark)))
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  %s → %s",
+			history.FormatValue(values[0]), history.FormatValue(values[len(values)-1]))) + "\n")
+	}
 
 	return boxStyle.Render(content.String())
 }
 
 // renderFooter creates the footer for stats view.
 func (sv *statsView) renderFooter(width int) string {
+	t := sv.styles.GetTheme()
 	bindings := []struct {
 		key   string
 		label string
@@ -1881,13 +2551,13 @@ func (sv *statsView) renderFooter(width int) string {
 	}
 
 	keyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#58a6ff")).
-		Background(lipgloss.Color("#21262d")).
+		Foreground(t.Primary).
+		Background(t.Overlay).
 		Padding(0, 1).
 		Bold(true)
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6e7681"))
+		Foreground(t.Subtle)
 
 	var parts []string
 	for _, b := range bindings {
@@ -1895,7 +2565,7 @@ func (sv *statsView) renderFooter(width int) string {
 	}
 
 	footerStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("#161b22")).
+		Background(t.Surface).
 		Padding(0, 1).
 		Width(width)
 
@@ -1935,6 +2605,7 @@ func (hv *helpView) Name() string {
 
 // Render renders the help overlay.
 func (hv *helpView) Render(state *State) string {
+	t := hv.styles.GetTheme()
 	width := state.WindowWidth
 	if width < 40 {
 		width = 80
@@ -1946,8 +2617,8 @@ func (hv *helpView) Render(state *State) string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#ffffff")).
-		Background(lipgloss.Color("#161b22")).
+		Foreground(t.Bright).
+		Background(t.Surface).
 		Padding(0, 2).
 		Width(width)
 
@@ -1959,7 +2630,7 @@ func (hv *helpView) Render(state *State) string {
 
 	for _, section := range sections {
 		sectionStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#58a6ff")).
+			Foreground(t.Primary).
 			Bold(true).
 			MarginTop(1)
 
@@ -1967,11 +2638,11 @@ func (hv *helpView) Render(state *State) string {
 
 		for _, binding := range section.Bindings {
 			keyStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#7ee787")).
+				Foreground(t.Activity).
 				Width(16)
 
 			descStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#e6edf3"))
+				Foreground(t.Text)
 
 			content.WriteString(fmt.Sprintf("  %s %s\n",
 				keyStyle.Render(binding.Key),
@@ -1981,14 +2652,14 @@ func (hv *helpView) Render(state *State) string {
 
 	boxStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#30363d")).
+		BorderForeground(t.Border).
 		Padding(1, 2).
 		Width(width - 4)
 
 	// Footer
 	footerStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("#161b22")).
-		Foreground(lipgloss.Color("#6e7681")).
+		Background(t.Surface).
+		Foreground(t.Subtle).
 		Padding(0, 1).
 		Width(width)
 
@@ -1,9 +1,15 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/history"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/tui/theme"
 )
 
 // =============================================================================
@@ -276,6 +282,84 @@ func TestBuildTreeByHierarchyExpanded(t *testing.T) {
 	}
 }
 
+func TestHierarchyCallTypes(t *testing.T) {
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name: "OrderWorkflow",
+				Type: "workflow",
+				CallSites: []analyzer.CallSite{
+					{TargetName: "Charge", TargetType: "activity", CallType: "execute", LineNumber: 10},
+					{TargetName: "ShipWorkflow", TargetType: "child_workflow", CallType: "execute", LineNumber: 11},
+					{TargetName: "Notify", TargetType: "local_activity", CallType: "execute", LineNumber: 15},
+				},
+				Timers: []analyzer.TimerDef{{Duration: "time.Minute", LineNumber: 13, IsSleep: true}},
+			},
+			"ShipWorkflow": {Name: "ShipWorkflow", Type: "workflow", Parents: []string{"OrderWorkflow"}},
+			"Charge":       {Name: "Charge", Type: "activity", Parents: []string{"OrderWorkflow"}},
+			"Notify":       {Name: "Notify", Type: "local_activity", Parents: []string{"OrderWorkflow"}},
+		},
+		Messages: []analyzer.MessageCall{
+			{Kind: "signal", Name: "shipped", Workflow: "BillingWorkflow", Caller: "OrderWorkflow", LineNumber: 12},
+			{Kind: "signal", Name: "other", Caller: "send", LineNumber: 3},
+		},
+	}
+	state := &State{Graph: graph, WindowWidth: 100, WindowHeight: 30, TreeState: &TreeViewState{
+		ExpansionStates: map[string]bool{"OrderWorkflow": true},
+		GroupBy:         "hierarchy",
+	}}
+	tv := NewTreeView(NewStyleManager()).(*treeView)
+	tv.buildTreeItems(state)
+
+	// Children in source order, each with its call type
+	want := []struct{ text, callType string }{
+		{"OrderWorkflow", ""},
+		{"Charge", theme.CallActivity},
+		{"ShipWorkflow", theme.CallChildWorkflow},
+		{"shipped → BillingWorkflow", theme.CallSignal},
+		{"Sleep time.Minute", theme.CallTimer},
+		{"Notify", theme.CallLocalActivity},
+	}
+	items := state.TreeState.Items
+	if len(items) != len(want) {
+		t.Fatalf("Expected %d items, got %+v", len(want), items)
+	}
+	for i, w := range want {
+		text := items[i].DisplayText
+		if items[i].Node != nil {
+			text = items[i].Node.Name
+		}
+		if text != w.text || items[i].CallType != w.callType {
+			t.Errorf("Items[%d] = %s (%s), want %s (%s)", i, text, items[i].CallType, w.text, w.callType)
+		}
+	}
+	if items[0].ChildCount != 5 {
+		t.Errorf("Expected 5 calls under OrderWorkflow, got %d", items[0].ChildCount)
+	}
+
+	// z hides the timers
+	tv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")}, state)
+	if len(state.TreeState.Items) != len(want)-1 || state.TreeState.Items[0].ChildCount != 4 {
+		t.Errorf("Expected the timer hidden, got %+v", state.TreeState.Items)
+	}
+	for _, item := range state.TreeState.Items {
+		if item.CallType == theme.CallTimer {
+			t.Errorf("Expected no timers, got %+v", item)
+		}
+	}
+
+	output := tv.Render(state)
+	for _, label := range []string{"child workflow", "local activity", "signal sent", "timer"} {
+		if !strings.Contains(output, label) {
+			t.Errorf("Expected the legend to show %q:\n%s", label, output)
+		}
+	}
+	state.Accessible = true
+	if output := tv.Render(state); !strings.Contains(output, "z timer (hidden)") || !strings.Contains(output, "local activity Notify") {
+		t.Errorf("Expected the accessible tree to name call types:\n%s", output)
+	}
+}
+
 func TestBuildTreeByPackage(t *testing.T) {
 	styles := NewStyleManager()
 	tv := NewTreeView(styles).(*treeView)
@@ -308,6 +392,34 @@ func TestBuildTreeByPackage(t *testing.T) {
 	}
 }
 
+func TestBuildTreeByQueue(t *testing.T) {
+	styles := NewStyleManager()
+	tv := NewTreeView(styles).(*treeView)
+	state := createTestState()
+	state.Graph.Nodes["MainWorkflow"].TaskQueue = "orders"
+	state.Graph.Nodes["ProcessActivity"].TaskQueue = "orders"
+	state.Graph.Nodes["ChildWorkflow"].TaskQueue = "bulk"
+
+	state.TreeState = &TreeViewState{
+		ExpansionStates: map[string]bool{"orders": true},
+		GroupBy:         GroupByQueue,
+	}
+	tv.buildTreeItems(state)
+
+	var got []string
+	for _, item := range state.TreeState.Items {
+		if item.IsQueue {
+			got = append(got, fmt.Sprintf("%s (%d)", item.DisplayText, item.ChildCount))
+		} else {
+			got = append(got, "  "+item.Node.Name)
+		}
+	}
+	want := []string{"bulk (1)", "orders (2)", "  MainWorkflow", "  ProcessActivity", "No task queue (1)"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Tree items = %q, want %q", got, want)
+	}
+}
+
 func TestCountNodesInTree(t *testing.T) {
 	styles := NewStyleManager()
 	tv := NewTreeView(styles).(*treeView)
@@ -433,6 +545,58 @@ func TestBuildDetailsStateWithParents(t *testing.T) {
 	}
 }
 
+func TestNavigationByNodeKey(t *testing.T) {
+	styles := NewStyleManager()
+	state := createTestState()
+	// Two Charge activities in different packages, keyed by package-qualified names
+	state.Graph.Nodes = map[string]*analyzer.TemporalNode{
+		"BillingWorkflow": {
+			Name: "BillingWorkflow", Type: "workflow", Package: "billing",
+			CallSites: []analyzer.CallSite{{TargetName: "billing.Charge", TargetType: "activity", CallType: "activity"}},
+		},
+		"billing.Charge":  {Name: "Charge", Key: "billing.Charge", Type: "activity", Package: "billing", Parents: []string{"BillingWorkflow"}},
+		"payments.Charge": {Name: "Charge", Key: "payments.Charge", Type: "activity", Package: "payments"},
+	}
+
+	dv := NewDetailsView(styles).(*detailsView)
+	state.SelectedNode = state.Graph.Nodes["BillingWorkflow"]
+	details := dv.buildDetailsState(state)
+	if len(details.SelectableItems) != 1 {
+		t.Fatalf("Expected 1 selectable call, got %d", len(details.SelectableItems))
+	}
+	callee := details.SelectableItems[0]
+	if callee.Node != state.Graph.Nodes["billing.Charge"] || callee.DisplayText != "Charge" {
+		t.Errorf("Expected the call to navigate to billing.Charge shown as Charge, got %+v", callee)
+	}
+
+	state.SelectedNode = state.Graph.Nodes["billing.Charge"]
+	details = dv.buildDetailsState(state)
+	if len(details.SelectableItems) != 1 || details.SelectableItems[0].Node != state.Graph.Nodes["BillingWorkflow"] {
+		t.Errorf("Expected BillingWorkflow as the caller, got %+v", details.SelectableItems)
+	}
+
+	tv := NewTreeView(styles).(*treeView)
+	state.TreeState = &TreeViewState{
+		ExpansionStates: map[string]bool{"BillingWorkflow": true},
+		GroupBy:         "hierarchy",
+	}
+	tv.buildTreeByHierarchy(state)
+	var children []*analyzer.TemporalNode
+	for _, item := range state.TreeState.Items {
+		if item.Depth == 1 {
+			children = append(children, item.Node)
+		}
+	}
+	if len(children) != 1 || children[0].ID() != "billing.Charge" {
+		t.Errorf("Expected billing.Charge under BillingWorkflow, got %v", children)
+	}
+
+	tv.restoreSelection(state, "payments.Charge")
+	if selected := state.TreeState.Items[state.TreeState.SelectedIndex].Node; selected.ID() != "payments.Charge" {
+		t.Errorf("Expected payments.Charge selected, got %s", selected.ID())
+	}
+}
+
 func TestBuildDetailsStateEmpty(t *testing.T) {
 	styles := NewStyleManager()
 	dv := NewDetailsView(styles).(*detailsView)
@@ -481,6 +645,39 @@ func TestGetTypeColor(t *testing.T) {
 	}
 }
 
+func TestNodeStylingFollowsTheme(t *testing.T) {
+	neon := theme.NeonTheme()
+	styles := NewStyleManagerWithTheme(neon)
+	dv := NewDetailsView(styles).(*detailsView)
+	tv := NewTreeView(styles).(*treeView)
+
+	// Every node type of a kind gets its icon and color in the list, tree and details
+	for _, nodeType := range []string{"workflow", "activity", "signal_handler", "query_handler", "update", "update_handler", "timer", "nexus_operation"} {
+		t.Run(nodeType, func(t *testing.T) {
+			icon := getNodeIcon(nodeType)
+			if icon == theme.FallbackIcons.Node {
+				t.Fatalf("getNodeIcon(%q) = %q, want the icon of its kind", nodeType, icon)
+			}
+			if color := dv.getTypeColor(nodeType); color != neon.NodeColor(nodeType) {
+				t.Errorf("getTypeColor(%q) = %q, want the neon color of its kind", nodeType, color)
+			}
+
+			node := &analyzer.TemporalNode{Name: "Node", Type: nodeType, Package: "orders"}
+			if title := (ListItem{Node: node}).Title(); !strings.HasPrefix(title, icon) {
+				t.Errorf("list title %q does not start with %q", title, icon)
+			}
+			if row := tv.renderTreeItem(TreeItem{Node: node}, false); !strings.Contains(row, icon) {
+				t.Errorf("tree row %q does not contain %q", row, icon)
+			}
+			state := createTestState()
+			state.SelectedNode = node
+			if details := dv.Render(state); !strings.Contains(details, icon+" Node") {
+				t.Errorf("details header does not show %q", icon+" Node")
+			}
+		})
+	}
+}
+
 // =============================================================================
 // View Render Tests (non-fragile - just test structure)
 // =============================================================================
@@ -533,6 +730,36 @@ func TestDetailsViewRender(t *testing.T) {
 	}
 }
 
+func TestDetailsViewRenderCalledByLocation(t *testing.T) {
+	dv := NewDetailsView(NewStyleManager())
+
+	state := createTestState()
+	state.CurrentView = ViewDetails
+	state.WindowWidth = 160
+	state.WindowHeight = 60
+	node := state.Graph.Nodes["ProcessActivity"]
+	node.CalledBy = []analyzer.ParentRef{{Name: "ChildWorkflow", FilePath: "child.go", LineNumber: 55, CallType: "execute"}}
+	state.SelectedNode = node
+
+	output := dv.Render(state)
+	if !strings.Contains(output, "(child.go:55)") {
+		t.Error("Expected the called-by entry to show the call location")
+	}
+}
+
+func TestDetailsViewFormatAnnotations(t *testing.T) {
+	dv := NewDetailsView(NewStyleManager()).(*detailsView)
+	node := &analyzer.TemporalNode{
+		Name:        "OrderWorkflow",
+		Annotations: map[string]string{"sla": "5m", "owner": "team-x", "deprecated": ""},
+	}
+
+	want := "@deprecated  @owner team-x  @sla 5m"
+	if got := dv.formatAnnotations(node); got != want {
+		t.Errorf("formatAnnotations() = %q, want %q", got, want)
+	}
+}
+
 func TestDetailsViewRenderNoNode(t *testing.T) {
 	styles := NewStyleManager()
 	dv := NewDetailsView(styles)
@@ -562,6 +789,70 @@ func TestStatsViewRender(t *testing.T) {
 	}
 }
 
+func TestStatsViewRenderWorkers(t *testing.T) {
+	styles := NewStyleManager()
+	sv := NewStatsView(styles)
+
+	state := createTestState()
+	state.CurrentView = ViewStats
+	state.WindowWidth = 160
+	state.Graph.Workers = []analyzer.WorkerConfig{{
+		TaskQueue:                          "orders",
+		MaxConcurrentActivityExecutionSize: 8,
+		Workflows:                          []string{"OrderWorkflow"},
+	}}
+
+	output := sv.Render(state)
+
+	for _, want := range []string{"Workers", "orders", "1 workflows, 0 activities, 8 activity slots", "Not registered on any worker"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("StatsView.Render missing %q", want)
+		}
+	}
+}
+
+func TestStatsViewRenderTrend(t *testing.T) {
+	sv := NewStatsView(NewStyleManager())
+
+	state := createTestState()
+	state.CurrentView = ViewStats
+	state.WindowWidth = 160
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	state.History = []history.Snapshot{
+		{Time: start, Workflows: 2, Errors: 4},
+		{Time: start.AddDate(0, 1, 0), Workflows: 5, Errors: 1},
+	}
+
+	output := sv.Render(state)
+
+	for _, want := range []string{"Trend", "2026-03-01 → 2026-04-01", "Workflows", "▁█", "2 → 5"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("StatsView.Render missing %q", want)
+		}
+	}
+}
+
+func TestStatsViewRenderDomainCoupling(t *testing.T) {
+	styles := NewStyleManager()
+	sv := NewStatsView(styles)
+
+	state := createTestState()
+	state.CurrentView = ViewStats
+	state.Graph.Stats.CrossDomainCalls = 3
+	state.Graph.Stats.DomainCoupling = []analyzer.DomainCoupling{
+		{From: "Orders", To: "Payments", Calls: 2},
+		{From: "Payments", To: "Orders", Calls: 1},
+	}
+
+	output := sv.Render(state)
+
+	for _, want := range []string{"Cross-Domain:", "3 calls", "Orders → Payments: 2", "Payments → Orders: 1"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("StatsView.Render missing %q", want)
+		}
+	}
+}
+
 func TestHelpViewRender(t *testing.T) {
 	styles := NewStyleManager()
 	hv := NewHelpView(styles)
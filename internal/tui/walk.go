@@ -0,0 +1,384 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// WALK VIEW
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// walkView implements the View interface for walking the call graph one edge at a time,
+// keeping the path walked visible, e.g. to find how a workflow reaches a failing activity.
+type walkView struct {
+	styles StyleManager
+}
+
+// NewWalkView creates a new walk view.
+func NewWalkView(styles StyleManager) View {
+	return &walkView{
+		styles: styles,
+	}
+}
+
+// Name returns the view's name.
+func (wv *walkView) Name() string {
+	return ViewWalk
+}
+
+// startWalk starts walking the call graph from node.
+func startWalk(state *State, node *analyzer.TemporalNode) {
+	state.WalkState = &WalkViewState{
+		Path: []PathItem{{Node: node, Direction: DirectionStart, DisplayName: node.Name}},
+	}
+	state.CurrentView = ViewWalk
+}
+
+// current returns the node the walk is at.
+func (ws *WalkViewState) current() *analyzer.TemporalNode {
+	return ws.Path[len(ws.Path)-1].Node
+}
+
+// walkCallees returns the distinct graph nodes a node calls, by name.
+func walkCallees(graph *analyzer.TemporalGraph, node *analyzer.TemporalNode) []WalkStep {
+	var steps []WalkStep
+	seen := make(map[string]bool)
+	for _, call := range node.CallSites {
+		target, ok := graph.Nodes[call.TargetName]
+		if !ok || seen[target.ID()] {
+			continue
+		}
+		seen[target.ID()] = true
+		steps = append(steps, WalkStep{Node: target, CallType: call.CallType, FilePath: call.FilePath, LineNumber: call.LineNumber})
+	}
+	sortWalkSteps(steps)
+	return steps
+}
+
+// walkCallers returns the distinct graph nodes calling a node, by name.
+func walkCallers(graph *analyzer.TemporalGraph, node *analyzer.TemporalNode) []WalkStep {
+	var steps []WalkStep
+	seen := make(map[string]bool)
+	for _, ref := range node.CalledBy {
+		caller, ok := graph.Nodes[ref.Name]
+		if !ok || seen[caller.ID()] {
+			continue
+		}
+		seen[caller.ID()] = true
+		steps = append(steps, WalkStep{Node: caller, CallType: ref.CallType, FilePath: ref.FilePath, LineNumber: ref.LineNumber})
+	}
+	// Nodes linked before call details were recorded only list their parents
+	for _, parent := range node.Parents {
+		caller, ok := graph.Nodes[parent]
+		if !ok || seen[caller.ID()] {
+			continue
+		}
+		seen[caller.ID()] = true
+		steps = append(steps, WalkStep{Node: caller})
+	}
+	sortWalkSteps(steps)
+	return steps
+}
+
+func sortWalkSteps(steps []WalkStep) {
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].Node.ID() < steps[j].Node.ID()
+	})
+}
+
+// step moves the walk to a caller (DirectionCalledBy) or callee (DirectionCalls) of the
+// current node: directly if there is a single one, else through the picker.
+func (wv *walkView) step(state *State, direction string) {
+	ws := state.WalkState
+	node := ws.current()
+
+	var steps []WalkStep
+	what := "callees"
+	if direction == DirectionCalledBy {
+		steps = walkCallers(state.Graph, node)
+		what = "callers"
+	} else {
+		steps = walkCallees(state.Graph, node)
+	}
+
+	switch len(steps) {
+	case 0:
+		state.StatusMessage = fmt.Sprintf("%s has no %s", node.Name, what)
+		state.StatusType = StatusWarning
+	case 1:
+		wv.take(state, steps[0], direction)
+	default:
+		ws.Picker = steps
+		ws.PickerDirection = direction
+		ws.SelectedIndex = 0
+		state.StatusMessage = fmt.Sprintf("%d %s - j/k to choose, Enter to step", len(steps), what)
+		state.StatusType = StatusInfo
+	}
+}
+
+// take appends a step to the walked path and closes the picker.
+func (wv *walkView) take(state *State, step WalkStep, direction string) {
+	ws := state.WalkState
+	ws.Path = append(ws.Path, PathItem{Node: step.Node, Direction: direction, DisplayName: step.Node.Name})
+	ws.Picker = nil
+	state.StatusMessage = fmt.Sprintf("%s %s", direction, step.Node.Name)
+	state.StatusType = StatusSuccess
+}
+
+// Update handles view-specific updates.
+func (wv *walkView) Update(msg tea.Msg, state *State) (*State, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || state.WalkState == nil || len(state.WalkState.Path) == 0 {
+		return state, nil
+	}
+	ws := state.WalkState
+
+	switch keyMsg.String() {
+	case "j", "down":
+		if ws.Picker != nil && ws.SelectedIndex < len(ws.Picker)-1 {
+			ws.SelectedIndex++
+		}
+
+	case "k", "up":
+		if ws.Picker != nil && ws.SelectedIndex > 0 {
+			ws.SelectedIndex--
+		}
+
+	case "right", "l":
+		if ws.Picker != nil && ws.PickerDirection == DirectionCalls {
+			wv.take(state, ws.Picker[ws.SelectedIndex], DirectionCalls)
+		} else {
+			wv.step(state, DirectionCalls)
+		}
+
+	case "left", "h":
+		if ws.Picker != nil && ws.PickerDirection == DirectionCalledBy {
+			wv.take(state, ws.Picker[ws.SelectedIndex], DirectionCalledBy)
+		} else {
+			wv.step(state, DirectionCalledBy)
+		}
+
+	case "enter":
+		if ws.Picker != nil {
+			wv.take(state, ws.Picker[ws.SelectedIndex], ws.PickerDirection)
+			return state, nil
+		}
+		// Open the details of the current node; going back returns to the walk
+		node := ws.current()
+		state.Navigator.PushState(ViewState{
+			View:         ViewWalk,
+			SelectedNode: node,
+			NavPath:      state.Navigator.GetPath(),
+		})
+		state.SelectedNode = node
+		state.CurrentView = ViewDetails
+		state.DetailsState = nil
+		state.Navigator.ClearPath()
+		for _, item := range ws.Path {
+			state.Navigator.AddToPath(item.Node, item.Direction)
+		}
+
+	case "u", "backspace":
+		switch {
+		case ws.Picker != nil:
+			ws.Picker = nil
+		case len(ws.Path) > 1:
+			ws.Path = ws.Path[:len(ws.Path)-1]
+			state.StatusMessage = fmt.Sprintf("Back at %s", ws.current().Name)
+			state.StatusType = StatusInfo
+		}
+	}
+
+	return state, nil
+}
+
+// CanHandle returns true if this view can handle the given message.
+func (wv *walkView) CanHandle(msg tea.Msg, state *State) bool {
+	return state.CurrentView == ViewWalk
+}
+
+// Render renders the walked path, the current node and the open picker.
+func (wv *walkView) Render(state *State) string {
+	t := wv.styles.GetTheme()
+	ws := state.WalkState
+	if ws == nil || len(ws.Path) == 0 {
+		return "No walk in progress"
+	}
+
+	width := state.WindowWidth
+	if width < 40 {
+		width = 80
+	}
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Bright).
+		Background(t.Surface).
+		Padding(0, 2).
+		Width(width)
+	header := headerStyle.Render(fmt.Sprintf("🧭 CALL GRAPH WALK  %d step(s)", len(ws.Path)-1))
+
+	sections := []string{wv.renderPath(ws, width), wv.renderNeighbours(state, width)}
+	if ws.Picker != nil {
+		sections = append(sections, wv.renderPicker(state, width))
+	}
+
+	return header + "\n\n" + strings.Join(sections, "\n\n") + "\n\n" + wv.renderFooter(state, width)
+}
+
+// renderPath renders the path stack, the current node last.
+func (wv *walkView) renderPath(ws *WalkViewState, width int) string {
+	t := wv.styles.GetTheme()
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(t.Primary)
+	dimStyle := lipgloss.NewStyle().Foreground(t.Subtle)
+	currentStyle := lipgloss.NewStyle().Bold(true).Background(t.Overlay)
+
+	lines := []string{titleStyle.Render("📍 Path")}
+	for i, item := range ws.Path {
+		node := item.Node
+		line := fmt.Sprintf("%2d %s %s %s", i+1, item.Direction, getNodeIcon(node.Type), wv.styles.ColoredText(node.Name, node.Type))
+		if loc := nodeLocation(node); loc != "" {
+			line += "  " + dimStyle.Render(loc)
+		}
+		if i == len(ws.Path)-1 {
+			line = currentStyle.Render(line + "  ◀")
+		}
+		lines = append(lines, line)
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+// renderNeighbours summarizes the callers and callees of the current node.
+func (wv *walkView) renderNeighbours(state *State, width int) string {
+	t := wv.styles.GetTheme()
+	node := state.WalkState.current()
+	callers := walkCallers(state.Graph, node)
+	callees := walkCallees(state.Graph, node)
+
+	names := func(steps []WalkStep) string {
+		if len(steps) == 0 {
+			return "none"
+		}
+		var parts []string
+		for i, s := range steps {
+			if i == 3 {
+				parts = append(parts, fmt.Sprintf("+%d more", len(steps)-i))
+				break
+			}
+			parts = append(parts, s.Node.Name)
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(t.Subtle)
+	lines := []string{
+		labelStyle.Render(fmt.Sprintf("← %d caller(s): ", len(callers))) + names(callers),
+		labelStyle.Render(fmt.Sprintf("→ %d callee(s): ", len(callees))) + names(callees),
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+// renderPicker renders the candidates of the next step, with the calls' location and how
+// many calls each candidate makes in turn.
+func (wv *walkView) renderPicker(state *State, width int) string {
+	t := wv.styles.GetTheme()
+	ws := state.WalkState
+	title := "→ Choose a callee"
+	if ws.PickerDirection == DirectionCalledBy {
+		title = "← Choose a caller"
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(t.Workflow)
+	dimStyle := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	lines := []string{titleStyle.Render(title)}
+	for i, step := range ws.Picker {
+		marker := "  "
+		if i == ws.SelectedIndex {
+			marker = "▸ "
+		}
+		line := marker + getNodeIcon(step.Node.Type) + " " + wv.styles.ColoredText(step.Node.Name, step.Node.Type)
+		if state.Accessible {
+			line = accessibleRow(step.Node.Type+" "+step.Node.Name, i == ws.SelectedIndex)
+		}
+		var info []string
+		if step.CallType != "" {
+			info = append(info, step.CallType)
+		}
+		if step.LineNumber > 0 {
+			info = append(info, fmt.Sprintf("%s:%d", filepath.Base(step.FilePath), step.LineNumber))
+		}
+		if n := len(walkCallees(state.Graph, step.Node)); n > 0 {
+			info = append(info, fmt.Sprintf("→%d", n))
+		}
+		if len(info) > 0 {
+			line += "  " + dimStyle.Render(strings.Join(info, "  "))
+		}
+		if i == ws.SelectedIndex && !state.Accessible {
+			line = wv.styles.SelectedItem(line)
+		}
+		lines = append(lines, line)
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+// nodeLocation returns the file:line of a node's declaration, or "".
+func nodeLocation(node *analyzer.TemporalNode) string {
+	if node.FilePath == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", filepath.Base(node.FilePath), node.LineNumber)
+}
+
+// renderFooter renders the key bindings and the status message.
+func (wv *walkView) renderFooter(state *State, width int) string {
+	t := wv.styles.GetTheme()
+	bindings := []struct {
+		key   string
+		label string
+	}{
+		{"←/h", "Callers"},
+		{"→/l", "Callees"},
+		{"j/k", "Choose"},
+		{"Enter", "Step/Details"},
+		{"u", "Step Back"},
+		{"q", "Exit"},
+	}
+
+	keyStyle := lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Background(t.Overlay).
+		Padding(0, 1).
+		Bold(true)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(t.Subtle)
+
+	var parts []string
+	for _, b := range bindings {
+		parts = append(parts, keyStyle.Render(b.key)+labelStyle.Render(b.label))
+	}
+	footerContent := strings.Join(parts, " ")
+
+	if state.StatusMessage != "" {
+		statusStyle := lipgloss.NewStyle().
+			Foreground(t.StatusColor(state.StatusType)).
+			Italic(true)
+		footerContent += "  " + statusStyle.Render(state.StatusMessage)
+	}
+
+	footerStyle := lipgloss.NewStyle().
+		Background(t.Surface).
+		Padding(0, 1).
+		Width(width)
+
+	return footerStyle.Render(footerContent)
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWalkView(t *testing.T) {
+	graph := createTestGraph()
+	wv := NewWalkView(NewStyleManager()).(*walkView)
+	state := &State{Graph: graph, WindowWidth: 100, Navigator: NewNavigator()}
+	startWalk(state, graph.Nodes["MainWorkflow"])
+
+	key := func(k string) {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if k == "enter" {
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		state, _ = wv.Update(msg, state)
+	}
+	current := func() string { return state.WalkState.current().Name }
+
+	// Two callees open the picker
+	key("l")
+	if len(state.WalkState.Picker) != 2 || current() != "MainWorkflow" {
+		t.Fatalf("Expected a picker of 2 callees, got %+v", state.WalkState.Picker)
+	}
+	key("j")
+	key("enter")
+	if current() != "ProcessActivity" || state.WalkState.Picker != nil {
+		t.Fatalf("Expected to step to ProcessActivity, at %s", current())
+	}
+
+	// Callers pick among the parents; a single callee steps directly
+	key("h")
+	if len(state.WalkState.Picker) != 2 || state.WalkState.PickerDirection != DirectionCalledBy {
+		t.Fatalf("Expected a picker of 2 callers, got %+v", state.WalkState.Picker)
+	}
+	key("h")
+	if current() != "ChildWorkflow" {
+		t.Fatalf("Expected to step to ChildWorkflow, at %s", current())
+	}
+	key("l")
+	if current() != "ProcessActivity" || len(state.WalkState.Path) != 4 {
+		t.Fatalf("Expected a single callee to be taken directly, at %s", current())
+	}
+
+	key("l")
+	if state.StatusType != StatusWarning || len(state.WalkState.Path) != 4 {
+		t.Errorf("Expected a warning for a node without callees, got %q", state.StatusMessage)
+	}
+
+	view := wv.Render(state)
+	for _, want := range []string{"MainWorkflow", "ChildWorkflow", "3 step(s)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the rendered walk", want)
+		}
+	}
+
+	key("u")
+	if current() != "ChildWorkflow" {
+		t.Errorf("Expected u to step back to ChildWorkflow, at %s", current())
+	}
+
+	// Enter without a picker opens the details of the current node
+	key("enter")
+	if state.CurrentView != ViewDetails || state.SelectedNode.Name != "ChildWorkflow" {
+		t.Errorf("Expected the details of ChildWorkflow, got %s of %v", state.CurrentView, state.SelectedNode)
+	}
+	if prev, ok := state.Navigator.PopState(); !ok || prev.View != ViewWalk {
+		t.Errorf("Expected going back to return to the walk, got %+v", prev)
+	}
+}
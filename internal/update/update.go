@@ -0,0 +1,282 @@
+// Package update checks for new releases of temporal-analyzer and installs them. Releases
+// publish a binary per platform, named as AssetName returns, and a checksums.txt file in
+// sha256sum format. checksums.txt.sig holds the raw ed25519 signature of checksums.txt:
+// a binary is installed only if the signature verifies with the release key built into the
+// running binary and the binary matches its checksum.
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// DefaultReleasesURL is the GitHub releases API of the repository.
+const DefaultReleasesURL = "https://api.github.com/repos/ikari-pl/go-temporalio-analyzer/releases"
+
+// Release files besides the binaries.
+const (
+	ChecksumsAsset = "checksums.txt"
+	SignatureAsset = "checksums.txt.sig"
+)
+
+// maxAssetSize bounds downloads, so a misbehaving server can't exhaust memory.
+const maxAssetSize = 200 << 20
+
+// Release is a published release.
+type Release struct {
+	Version string  `json:"tag_name"`
+	URL     string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is a file of a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// asset returns the URL of the named asset, or "".
+func (r *Release) asset(name string) string {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a.URL
+		}
+	}
+	return ""
+}
+
+// Client fetches releases.
+type Client struct {
+	HTTP *http.Client
+	// ReleasesURL is the releases API, DefaultReleasesURL unless set
+	ReleasesURL string
+	// PublicKey verifies the signature of release checksums; Download fails without it
+	PublicKey ed25519.PublicKey
+}
+
+// ParsePublicKey decodes a base64 ed25519 public key, as built in with -ldflags.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid release key: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid release key: %d bytes, want %d", len(key), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// Latest returns the latest release.
+func (c *Client) Latest(ctx context.Context) (*Release, error) {
+	return c.release(ctx, "/latest")
+}
+
+// Tagged returns the release of a version, e.g. v1.4.0, for pinning installs to it.
+func (c *Client) Tagged(ctx context.Context, version string) (*Release, error) {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return c.release(ctx, "/tags/"+version)
+}
+
+func (c *Client) release(ctx context.Context, path string) (*Release, error) {
+	base := c.ReleasesURL
+	if base == "" {
+		base = DefaultReleasesURL
+	}
+	body, err := c.get(ctx, strings.TrimSuffix(base, "/")+path)
+	if err != nil {
+		return nil, err
+	}
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("invalid release: %w", err)
+	}
+	if release.Version == "" {
+		return nil, errors.New("invalid release: no tag")
+	}
+	return &release, nil
+}
+
+// get fetches a URL.
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxAssetSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxAssetSize {
+		return nil, fmt.Errorf("GET %s: response larger than %d bytes", url, maxAssetSize)
+	}
+	return body, nil
+}
+
+// AssetName returns the name of the release binary of a platform.
+func AssetName(goos, goarch string) string {
+	name := "temporal-analyzer-" + goos + "-" + goarch
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Download fetches the binary of the running platform from a release and verifies it
+// against the signed checksums.
+func (c *Client) Download(ctx context.Context, release *Release) ([]byte, error) {
+	return c.download(ctx, release, AssetName(runtime.GOOS, runtime.GOARCH))
+}
+
+func (c *Client) download(ctx context.Context, release *Release, name string) ([]byte, error) {
+	if len(c.PublicKey) == 0 {
+		return nil, errors.New("this build has no release key to verify downloads; install releases manually")
+	}
+	urls := make(map[string]string)
+	for _, asset := range []string{name, ChecksumsAsset, SignatureAsset} {
+		if urls[asset] = release.asset(asset); urls[asset] == "" {
+			return nil, fmt.Errorf("release %s has no %s", release.Version, asset)
+		}
+	}
+
+	checksums, err := c.get(ctx, urls[ChecksumsAsset])
+	if err != nil {
+		return nil, err
+	}
+	signature, err := c.get(ctx, urls[SignatureAsset])
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(c.PublicKey, checksums, signature) {
+		return nil, fmt.Errorf("release %s: checksums signature does not verify with the release key", release.Version)
+	}
+	want, err := checksum(checksums, name)
+	if err != nil {
+		return nil, fmt.Errorf("release %s: %w", release.Version, err)
+	}
+
+	binary, err := c.get(ctx, urls[name])
+	if err != nil {
+		return nil, err
+	}
+	if got := sha256.Sum256(binary); !bytes.Equal(got[:], want) {
+		return nil, fmt.Errorf("release %s: %s does not match its checksum", release.Version, name)
+	}
+	return binary, nil
+}
+
+// checksum returns the SHA-256 of name in a sha256sum listing.
+func checksum(checksums []byte, name string) ([]byte, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// Binary mode listings mark names with a leading *
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum, err := hex.DecodeString(fields[0])
+		if err != nil || len(sum) != sha256.Size {
+			return nil, fmt.Errorf("invalid checksum of %s", name)
+		}
+		return sum, nil
+	}
+	return nil, fmt.Errorf("no checksum of %s", name)
+}
+
+// Install replaces the executable at path with binary, keeping its permissions. The binary
+// is written next to it and renamed over it, so an interrupted install leaves it intact.
+func Install(binary []byte, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("cannot write next to %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(binary); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+	if runtime.GOOS == "windows" {
+		// A running executable can't be replaced on Windows, only renamed
+		old := path + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(path, old); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Newer reports whether version latest is newer than current. ok is false if either isn't
+// a release version, e.g. for development builds.
+func Newer(current, latest string) (newer, ok bool) {
+	c, ok1 := parseVersion(current)
+	l, ok2 := parseVersion(latest)
+	if !ok1 || !ok2 {
+		return false, false
+	}
+	for i := range c {
+		if l[i] != c[i] {
+			return l[i] > c[i], true
+		}
+	}
+	return false, true
+}
+
+// parseVersion parses vMAJOR.MINOR.PATCH, ignoring pre-release and build suffixes.
+func parseVersion(v string) ([3]int, bool) {
+	var parts [3]int
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	fields := strings.Split(v, ".")
+	if len(fields) != 3 {
+		return parts, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
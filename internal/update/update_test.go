@@ -0,0 +1,154 @@
+package update
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// releaseServer serves a release of a binary named name with signed checksums, and returns
+// the client verifying it.
+func releaseServer(t *testing.T, name string, binary []byte, tamper func(files map[string][]byte)) *Client {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(binary)
+	checksums := []byte(fmt.Sprintf("%s  %s\n%s  other\n", hex.EncodeToString(sum[:]), name, strings.Repeat("0", 64)))
+	files := map[string][]byte{
+		name:           binary,
+		ChecksumsAsset: checksums,
+		SignatureAsset: ed25519.Sign(private, checksums),
+	}
+	if tamper != nil {
+		tamper(files)
+	}
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc("/releases/tags/v1.4.0", func(w http.ResponseWriter, r *http.Request) {
+		var assets []string
+		for file := range files {
+			assets = append(assets, fmt.Sprintf(`{"name": %q, "browser_download_url": %q}`, file, srv.URL+"/download/"+file))
+		}
+		_, _ = fmt.Fprintf(w, `{"tag_name": "v1.4.0", "assets": [%s]}`, strings.Join(assets, ","))
+	})
+	mux.HandleFunc("/download/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(files[strings.TrimPrefix(r.URL.Path, "/download/")])
+	})
+
+	key, err := ParsePublicKey(base64.StdEncoding.EncodeToString(public))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{HTTP: srv.Client(), ReleasesURL: srv.URL + "/releases", PublicKey: key}
+}
+
+func TestDownload(t *testing.T) {
+	name := AssetName("linux", "amd64")
+	binary := []byte("new binary")
+	ctx := context.Background()
+
+	c := releaseServer(t, name, binary, nil)
+	release, err := c.Tagged(ctx, "1.4.0")
+	if err != nil {
+		t.Fatalf("Tagged failed: %v", err)
+	}
+	got, err := c.download(ctx, release, name)
+	if err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if string(got) != string(binary) {
+		t.Errorf("download = %q, want %q", got, binary)
+	}
+	if _, err := c.download(ctx, release, AssetName("windows", "amd64")); err == nil {
+		t.Error("Expected an error for a platform without a binary")
+	}
+
+	tampered := map[string]func(files map[string][]byte){
+		"binary":    func(files map[string][]byte) { files[name] = []byte("evil binary") },
+		"checksums": func(files map[string][]byte) { files[ChecksumsAsset] = append(files[ChecksumsAsset], '\n') },
+		"signature": func(files map[string][]byte) { delete(files, SignatureAsset) },
+	}
+	for what, tamper := range tampered {
+		c := releaseServer(t, name, binary, tamper)
+		release, err := c.Tagged(ctx, "v1.4.0")
+		if err != nil {
+			t.Fatalf("Tagged failed: %v", err)
+		}
+		if _, err := c.download(ctx, release, name); err == nil {
+			t.Errorf("Expected an error downloading a release with a tampered %s", what)
+		}
+	}
+
+	c.PublicKey = nil
+	if _, err := c.download(ctx, release, name); err == nil {
+		t.Error("Expected an error downloading without a release key")
+	}
+}
+
+func TestInstall(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "temporal-analyzer")
+	if err := os.WriteFile(path, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Install([]byte("new"), path); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "new" || info.Mode().Perm() != 0o755 {
+		t.Errorf("Installed %q with mode %v, want %q with mode 0755", got, info.Mode().Perm(), "new")
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected no temporary files left, got %v", entries)
+	}
+}
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		newer, ok       bool
+	}{
+		{"v1.2.0", "v1.3.0", true, true},
+		{"v1.2.10", "v1.2.9", false, true},
+		{"1.2.0", "v1.2.0", false, true},
+		{"v1.2.0-3-gabc1234-dirty", "v1.2.1", true, true},
+		{"v2.0.0", "v1.9.9", false, true},
+		{"dev", "v1.3.0", false, false},
+		{"v1.2", "v1.3.0", false, false},
+	}
+	for _, tt := range tests {
+		newer, ok := Newer(tt.current, tt.latest)
+		if newer != tt.newer || ok != tt.ok {
+			t.Errorf("Newer(%q, %q) = %v, %v, want %v, %v", tt.current, tt.latest, newer, ok, tt.newer, tt.ok)
+		}
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	if _, err := ParsePublicKey("not base64!"); err == nil {
+		t.Error("Expected an error for invalid base64")
+	}
+	if _, err := ParsePublicKey(base64.StdEncoding.EncodeToString([]byte("short"))); err == nil {
+		t.Error("Expected an error for a key of the wrong size")
+	}
+}
@@ -1,18 +1,46 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/assertions"
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/contracts"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/daemon"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/fix"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/history"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/inventory"
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/onboarding"
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/output"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/rename"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/replay"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/server"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/simulate"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/stats"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/tracker"
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/tui"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/tui/theme"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/update"
 
 	"github.com/charmbracelet/bubbles/list"
 )
@@ -21,14 +49,27 @@ import (
 var (
 	Version   = "dev"
 	BuildTime = "unknown"
+	// ReleaseKey is the base64 ed25519 public key verifying the releases self-update installs
+	ReleaseKey = ""
 )
 
 func main() {
+	analyzer.EngineVersion = Version
+
+	// Handle the version and self-update subcommands, which need no analysis config
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "version":
+			os.Exit(runVersion(os.Args[2:], os.Stdout))
+		case "self-update":
+			os.Exit(runSelfUpdate(os.Args[2:], os.Stdout))
+		}
+	}
+
 	// Handle --version before anything else (check args directly)
 	for _, arg := range os.Args[1:] {
 		if arg == "--version" || arg == "-version" || arg == "-v" {
-			fmt.Printf("temporal-analyzer %s\n", Version)
-			fmt.Printf("Built: %s\n", BuildTime)
+			printVersion(os.Stdout)
 			return
 		}
 	}
@@ -38,8 +79,14 @@ func main() {
 	// to work the same as: `temporal-analyzer --lint [flags] [path]`
 	os.Args = transformLintSubcommand(os.Args)
 
+	// Handle the other subcommands the same way, e.g. `temporal-analyzer replay [flags] [path]`
+	for _, sub := range flagSubcommands {
+		os.Args = transformSubcommand(os.Args, sub.name, sub.flag)
+	}
+
 	// Create config
 	cfg := config.NewConfig()
+	cfg.OutputFormats = outputFormats()
 
 	// Parse command line flags
 	if err := cfg.ParseFlags(); err != nil {
@@ -49,32 +96,145 @@ func main() {
 
 	// Handle --lint-rules: list available rules and exit
 	if cfg.LintListRules {
-		listLintRules()
+		categories, tags, err := parseLintSelection(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		listLintRules(outputGlyphs(cfg), categories, tags)
+		return
+	}
+
+	// Handle --lint-docs: write rule documentation and exit
+	if cfg.LintDocs != "" {
+		rules := lint.NewLinter(lint.DefaultConfig()).ListRules()
+		if err := lint.WriteRuleDocs(cfg.LintDocs, rules); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote documentation for %d rules to %s\n", len(rules), cfg.LintDocs)
 		return
 	}
 
 	// Create logger
 	logger := NewLogger(cfg)
 
+	// Handle package patterns: analyze the packages go list resolves them to
+	if err := usePackages(context.Background(), cfg, logger); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Handle --ref: analyze a snapshot of the repository at a git revision.
+	// exit removes the snapshot before exiting.
+	cleanup, err := useGitRef(context.Background(), cfg, logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer cleanup()
+	exit := func(code int) {
+		cleanup()
+		os.Exit(code)
+	}
+
+	// Handle trend mode: reads the history database, no analysis needed
+	if cfg.TrendMode {
+		exit(runTrend(cfg, logger))
+	}
+	if cfg.HistoryMode {
+		exit(runIssueHistory(cfg, logger))
+	}
+
 	// Create analyzer
 	analyzerInstance := analyzer.NewAnalyzer(logger)
 
+	// Handle server mode: clients send the directories to analyze
+	if cfg.ServeGRPC != "" {
+		exit(runServer(cfg, logger, analyzerInstance))
+	}
+
+	// Handle daemon mode: editors request lint issues and node info on a unix socket
+	if cfg.DaemonMode {
+		exit(runDaemon(cfg, logger, analyzerInstance))
+	}
+
+	// Handle init mode: inspect the project and write a starter config
+	if cfg.InitMode {
+		exit(runInit(cfg, logger, analyzerInstance, os.Stdin, os.Stdout))
+	}
+
+	// Handle assert mode: architecture assertions as tests
+	if cfg.AssertMode {
+		exit(runAssert(cfg, logger, analyzerInstance, os.Stdout))
+	}
+
+	// Handle explain mode: why a function was or was not detected
+	if cfg.ExplainNodeMode {
+		exit(runExplainNode(cfg, logger, analyzerInstance, os.Stdout))
+	}
+
+	// Handle rename mode: rename a workflow or activity in its Temporal references
+	if cfg.RenameMode {
+		exit(runRename(cfg, logger, analyzerInstance, os.Stdout))
+	}
+
+	// Handle fix mode: apply the verified code fixes of the lint issues
+	if cfg.FixMode || cfg.FixDryRun {
+		exit(runFix(cfg, logger, analyzerInstance, os.Stdout))
+	}
+
 	// Handle lint mode separately
 	if cfg.LintMode {
 		exitCode := runLint(cfg, logger, analyzerInstance)
-		os.Exit(exitCode)
+		exit(exitCode)
+	}
+
+	// Handle contracts mode separately
+	if cfg.ContractsMode {
+		exit(runContracts(cfg, logger, analyzerInstance))
+	}
+
+	// Handle stats mode separately
+	if cfg.StatsMode {
+		exit(runStats(cfg, logger, analyzerInstance))
+	}
+
+	// Handle load simulation mode separately
+	if cfg.SimulateMode {
+		exit(runSimulateLoad(cfg, logger, analyzerInstance, os.Stdout))
+	}
+
+	// Handle inventory mode separately
+	if cfg.InventoryMode {
+		exit(runInventory(cfg, logger, analyzerInstance))
+	}
+
+	// Handle replay mode separately
+	if cfg.ReplayMode {
+		exitCode := runReplay(cfg, logger, analyzerInstance)
+		exit(exitCode)
+	}
+
+	// Handle pipeline mode: one analysis, several --emit outputs
+	if len(cfg.Emits) > 0 {
+		exit(runPipeline(cfg, logger, analyzerInstance))
 	}
 
 	// Create TUI (only needed for tui format)
 	var tuiApp tui.TUI
 	if cfg.OutputFormat == "tui" || cfg.DebugView != "" {
-		tuiApp = tui.NewTUI(logger)
+		tuiOpts := tui.Options{SortBy: cfg.SortBy, Accessible: cfg.Accessible, LineSpacing: cfg.LineSpacing, Theme: cfg.Theme, SessionFile: tui.DefaultSessionFile()}
+		if cfg.HistoryDB != "" {
+			tuiOpts.History = history.NewSQLiteStore(cfg.HistoryDB)
+		}
+		tuiApp = tui.NewTUIWithOptions(logger, tuiOpts)
 	}
 
 	// Run the application
 	if err := run(cfg, logger, analyzerInstance, tuiApp); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 }
 
@@ -95,6 +255,61 @@ func NewLogger(cfg *config.Config) *slog.Logger {
 	return slog.New(handler)
 }
 
+// analyze runs the analysis, passing the result of each parsed package to onPackage if set,
+// and records a history snapshot when configured.
+func analyze(ctx context.Context, cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer, opts config.AnalysisOptions, onPackage func(analyzer.PackageResult)) (*analyzer.TemporalGraph, error) {
+	graph, err := analyzerInstance.AnalyzeStream(ctx, opts, onPackage)
+	if err != nil {
+		logger.Error("Failed to analyze workflows", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Analysis completed",
+		"workflows", graph.Stats.TotalWorkflows,
+		"activities", graph.Stats.TotalActivities,
+		"total_nodes", len(graph.Nodes))
+
+	if err := checkResolution(cfg, graph); err != nil {
+		return nil, err
+	}
+	if err := checkFailedFiles(cfg, graph); err != nil {
+		return nil, err
+	}
+
+	if cfg.HistoryDB != "" {
+		if err := recordHistory(ctx, cfg, graph, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "Error recording history snapshot: %v\n", err)
+		}
+	}
+	return graph, nil
+}
+
+// checkResolution fails with --strict-resolution when more call targets than --max-unresolved
+// are not found in the analyzed code.
+func checkResolution(cfg *config.Config, graph *analyzer.TemporalGraph) error {
+	if !cfg.StrictResolution {
+		return nil
+	}
+	unresolved := graph.UnresolvedTargets()
+	if len(unresolved) <= cfg.MaxUnresolved {
+		return nil
+	}
+	return fmt.Errorf("%d unresolved call targets (max %d): %s", len(unresolved), cfg.MaxUnresolved, strings.Join(unresolved, ", "))
+}
+
+// checkFailedFiles fails with --strict-files when more Go files than --max-failed-files could
+// not be parsed or crashed the analysis.
+func checkFailedFiles(cfg *config.Config, graph *analyzer.TemporalGraph) error {
+	if !cfg.StrictFiles {
+		return nil
+	}
+	failed := graph.FailedFiles()
+	if len(failed) <= cfg.MaxFailedFiles {
+		return nil
+	}
+	return fmt.Errorf("%d files failed to analyze (max %d): %s", len(failed), cfg.MaxFailedFiles, strings.Join(failed, ", "))
+}
+
 // run is the main application function.
 func run(
 	cfg *config.Config,
@@ -109,73 +324,83 @@ func run(
 	// Create analysis options
 	opts := cfg.ToAnalysisOptions()
 
-	// Perform analysis
 	ctx := context.Background()
-	graph, err := analyzerInstance.Analyze(ctx, opts)
+
+	// The TUI analyzes behind a loading screen that shows progress and can cancel the analysis
+	if cfg.OutputFormat == "tui" && cfg.DebugView == "" {
+		if tuiApp == nil {
+			return fmt.Errorf("TUI not initialized")
+		}
+		return tuiApp.RunAnalysis(ctx, func(ctx context.Context, onPackage func(analyzer.PackageResult)) (*analyzer.TemporalGraph, error) {
+			return analyze(ctx, cfg, logger, analyzerInstance, opts, onPackage)
+		})
+	}
+
+	graph, err := analyze(ctx, cfg, logger, analyzerInstance, opts, nil)
 	if err != nil {
-		logger.Error("Failed to analyze workflows", "error", err)
 		return err
 	}
 
-	logger.Info("Analysis completed",
-		"workflows", graph.Stats.TotalWorkflows,
-		"activities", graph.Stats.TotalActivities,
-		"total_nodes", len(graph.Nodes))
-
 	// Handle debug view rendering
 	if cfg.DebugView != "" {
 		return renderDebugView(cfg, graph)
 	}
 
-	// Handle different output formats
-	switch cfg.OutputFormat {
-	case "tui":
-		if tuiApp == nil {
-			return fmt.Errorf("TUI not initialized")
-		}
-		return tuiApp.Run(ctx, graph)
-
-	case "json":
-		formatter := output.NewJSONFormatter()
-		return formatter.Format(ctx, graph, os.Stdout)
-
-	case "dot":
-		exporter := output.NewExporter()
-		dot, err := exporter.ExportDOT(graph)
-		if err != nil {
-			return err
-		}
-		fmt.Println(dot)
-		return nil
-
-	case "mermaid":
-		exporter := output.NewExporter()
-		mermaid, err := exporter.ExportMermaid(graph)
+	// The sql formats include the findings of the configured lint rules
+	var issues []lint.Issue
+	if output.IncludesLint(cfg.OutputFormat) {
+		_, result, _, err := lintGraph(ctx, cfg, logger, analyzerInstance, graph, opts)
 		if err != nil {
 			return err
 		}
-		fmt.Println(mermaid)
-		return nil
-
-	case "markdown", "md":
-		exporter := output.NewExporter()
-		md, err := exporter.ExportMarkdown(graph)
-		if err != nil {
-			return err
-		}
-		fmt.Println(md)
-		return nil
+		issues = result.Issues
+	}
+	rebaseSnapshotPaths(cfg, graph, issues)
+
+	// Handle the output formats of the registry
+	manager := output.NewDefaultManager(output.Options{
+		Fields:       cfg.GetFields(),
+		LegacyJSON:   cfg.LegacyJSON,
+		MaxDepth:     cfg.MaxDepth,
+		Glyphs:       outputGlyphs(cfg),
+		OutputFile:   cfg.OutputFile,
+		TemplateFile: cfg.TemplateFile,
+		LintIssues:   issues,
+		RootDir:      cfg.GitDir(),
+		LinkBase:     linkBase(cfg),
+	})
+	// Pruning only changes what is drawn; lint issues were found on the full graph
+	graph = analyzer.PruneGraph(graph, cfg.RootDir, cfg.GetPruneOptions())
+	if err := manager.Format(ctx, cfg.OutputFormat, graph, os.Stdout); err != nil {
+		return err
+	}
+	if cfg.OutputFormat == "sqlite" {
+		logger.Info("Wrote SQLite database", "file", cfg.OutputFile)
+	}
+	return nil
+}
 
-	default:
-		return fmt.Errorf("unsupported output format: %s (supported: tui, json, dot, mermaid, markdown)", cfg.OutputFormat)
+// outputFormats lists the registered output formats for --help and --format validation.
+func outputFormats() []config.OutputFormat {
+	manager := output.NewDefaultManager(output.Options{})
+	names := manager.ListFormatters()
+	formats := make([]config.OutputFormat, 0, len(names))
+	for _, name := range names {
+		formatter, _ := manager.GetFormatter(name)
+		formats = append(formats, config.OutputFormat{Name: name, Description: formatter.Description()})
 	}
+	return formats
 }
 
 // renderDebugView renders a single view for debugging without TUI interaction.
 func renderDebugView(cfg *config.Config, graph *analyzer.TemporalGraph) error {
 	// Create TUI components for debugging
 	navigator := tui.NewNavigator()
-	styles := tui.NewStyleManager()
+	colors, ok := theme.ByName(cfg.Theme)
+	if !ok {
+		colors = theme.DefaultTheme()
+	}
+	styles := tui.NewStyleManagerWithTheme(colors)
 	filter := tui.NewFilterManager()
 	viewManager := tui.NewViewManager(styles, filter)
 
@@ -184,6 +409,11 @@ func renderDebugView(cfg *config.Config, graph *analyzer.TemporalGraph) error {
 	for _, node := range graph.Nodes {
 		allItems = append(allItems, tui.ListItem{Node: node})
 	}
+	sortBy := cfg.SortBy
+	if sortBy == "" {
+		sortBy = tui.SortByName
+	}
+	tui.SortListItems(allItems, sortBy)
 
 	// Create initial list items - only top-level workflows (no parents)
 	initialItems := make([]list.Item, 0)
@@ -218,7 +448,8 @@ func renderDebugView(cfg *config.Config, graph *analyzer.TemporalGraph) error {
 		WindowWidth:  80,
 		WindowHeight: 24,
 		ListState: &tui.ListViewState{
-			Items: initialItems,
+			Items:  initialItems,
+			SortBy: sortBy,
 		},
 		TreeState: &tui.TreeViewState{
 			ExpansionStates: make(map[string]bool),
@@ -231,6 +462,8 @@ func renderDebugView(cfg *config.Config, graph *analyzer.TemporalGraph) error {
 		ShowQueries:    false,
 		ShowUpdates:    false,
 		FilterActive:   false,
+		Accessible:     cfg.Accessible,
+		LineSpacing:    cfg.LineSpacing,
 	}
 
 	// Set up for details view debug
@@ -260,6 +493,70 @@ func renderDebugView(cfg *config.Config, graph *analyzer.TemporalGraph) error {
 	return nil
 }
 
+// parseRequiredAnnotations parses the --lint-require-annotations specs.
+func parseRequiredAnnotations(specs []string) ([]lint.AnnotationRequirement, error) {
+	var required []lint.AnnotationRequirement
+	for _, spec := range specs {
+		if spec == "" {
+			continue
+		}
+		req, err := lint.ParseAnnotationRequirement(spec)
+		if err != nil {
+			return nil, err
+		}
+		required = append(required, req)
+	}
+	return required, nil
+}
+
+// loadWorkflowcheck loads the workflowcheck config and results given with --workflowcheck-config
+// and --workflowcheck-results.
+func loadWorkflowcheck(cfg *config.Config) (*lint.WorkflowcheckConfig, []lint.WorkflowcheckFinding, error) {
+	var wcCfg *lint.WorkflowcheckConfig
+	var findings []lint.WorkflowcheckFinding
+	var err error
+	if cfg.WorkflowcheckConfig != "" {
+		if wcCfg, err = lint.LoadWorkflowcheckConfig(cfg.WorkflowcheckConfig); err != nil {
+			return nil, nil, err
+		}
+	}
+	if cfg.WorkflowcheckResults != "" {
+		if findings, err = lint.LoadWorkflowcheckFindings(cfg.WorkflowcheckResults); err != nil {
+			return nil, nil, err
+		}
+	}
+	return wcCfg, findings, nil
+}
+
+// parseLintProfiles parses --lint-profiles mappings, keeping their order.
+func parseLintProfiles(specs []string) ([]lint.ProfileMapping, error) {
+	var profiles []lint.ProfileMapping
+	for _, spec := range specs {
+		if spec == "" {
+			continue
+		}
+		m, err := lint.ParseProfileMapping(spec)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, m)
+	}
+	return profiles, nil
+}
+
+// parseLintSelection parses the --lint-categories and --lint-tags rule selection.
+func parseLintSelection(cfg *config.Config) ([]lint.Category, []string, error) {
+	categories, err := lint.ParseCategories(cfg.GetLintCategories())
+	if err != nil {
+		return nil, nil, err
+	}
+	tags, err := lint.ParseTags(cfg.GetLintTags())
+	if err != nil {
+		return nil, nil, err
+	}
+	return categories, tags, nil
+}
+
 // runLint executes the linter and returns the exit code.
 func runLint(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer) int {
 	logger.Info("Starting temporal analyzer in lint mode",
@@ -289,27 +586,21 @@ func runLint(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.
 		"activities", graph.Stats.TotalActivities,
 		"total_nodes", len(graph.Nodes))
 
-	// Create linter config from CLI options
-	lintCfg := &lint.Config{
-		MinSeverity:   severityFromString(cfg.LintMinSeverity),
-		EnabledRules:  cfg.GetLintEnabledRules(),
-		DisabledRules: cfg.GetLintDisabledRules(),
-		FailOnWarning: cfg.LintStrict,
-		Thresholds: lint.Thresholds{
-			MaxFanOut:          cfg.LintMaxFanOut,
-			MaxCallDepth:       cfg.LintMaxCallDepth,
-			VersioningRequired: 5,
-		},
-		// LLM enhancement options
-		LLMEnhance: cfg.LLMEnhance,
-		LLMVerify:  cfg.LLMVerify,
-		LLMModel:   cfg.LLMModel,
-		RootDir:    cfg.RootDir,
+	if err := checkResolution(cfg, graph); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if err := checkFailedFiles(cfg, graph); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
 	}
 
-	// Create linter and run
-	linter := lint.NewLinter(lintCfg)
-	result := linter.Run(ctx, graph)
+	linter, result, baseGraph, err := lintGraph(ctx, cfg, logger, analyzerInstance, graph, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	rebaseSnapshotPaths(cfg, graph, result.Issues)
 
 	// Output results in all requested formats
 	formats := cfg.LintFormats
@@ -317,8 +608,17 @@ func runLint(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.
 		formats = []string{cfg.LintFormat}
 	}
 
+	// Issues already present at the diff base are not reported as new
+	var baseline *lint.Result
+	if baseGraph != nil && (cfg.NotifyWebhook != "" || cfg.FileIssues != "" || slices.Contains(formats, "pr-comment")) {
+		baseline = linter.Run(ctx, baseGraph)
+	}
+
 	for i, format := range formats {
-		formatter := lint.NewFormatter(format)
+		formatter := newLintFormatter(cfg, format)
+		if comment, ok := formatter.(*lint.PRCommentFormatter); ok {
+			comment.Graph, comment.BaseGraph, comment.Baseline = graph, baseGraph, baseline
+		}
 
 		// Determine output destination for this format
 		var out *os.File
@@ -360,108 +660,1477 @@ func runLint(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.
 		}
 	}
 
-	return result.ExitCode
-}
-
-// listLintRules prints all available lint rules.
-func listLintRules() {
-	linter := lint.NewLinter(lint.DefaultConfig())
-	rules := linter.ListRules()
-
-	fmt.Println("\nTemporal Analyzer - Available Lint Rules")
-	fmt.Println("═══════════════════════════════════════════════════════════════════")
-	fmt.Println()
-
-	// Group by category
-	categories := make(map[lint.Category][]lint.RuleInfo)
-	for _, rule := range rules {
-		categories[rule.Category] = append(categories[rule.Category], rule)
-	}
-
-	categoryOrder := []lint.Category{
-		lint.CategoryReliability,
-		lint.CategoryBestPractice,
-		lint.CategoryPerformance,
-		lint.CategoryMaintenance,
-		lint.CategorySecurity,
+	if cfg.HistoryDB != "" {
+		if err := recordHistory(ctx, cfg, graph, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error recording history snapshot: %v\n", err)
+		}
 	}
 
-	for _, cat := range categoryOrder {
-		catRules, ok := categories[cat]
-		if !ok || len(catRules) == 0 {
-			continue
+	// Post a summary to the webhook; failures are reported but don't change the exit code
+	if cfg.NotifyWebhook != "" {
+		notifier := lint.NewWebhookNotifier(cfg.NotifyWebhook, cfg.GitDir())
+		if err := notifier.Notify(ctx, result, baseline); err != nil {
+			fmt.Fprintf(os.Stderr, "Error sending webhook notification: %v\n", err)
+		} else {
+			logger.Info("Sent lint summary to webhook")
 		}
+	}
 
-		fmt.Printf("  %s\n", categoryTitle(cat))
-		fmt.Println("  " + strings.Repeat("─", 60))
-		for _, rule := range catRules {
-			severityIcon := "ℹ"
-			switch rule.Severity {
-			case lint.SeverityError:
-				severityIcon = "✖"
-			case lint.SeverityWarning:
-				severityIcon = "⚠"
-			}
-			fmt.Printf("    %s %-8s %-30s %s\n", severityIcon, rule.ID, rule.Name, rule.Severity)
-			fmt.Printf("              %s\n", rule.Description)
-			fmt.Println()
+	// File new errors as tickets; failures are reported but don't change the exit code
+	if cfg.FileIssues != "" {
+		if err := fileIssues(ctx, cfg, logger, result, baseline); err != nil {
+			fmt.Fprintf(os.Stderr, "Error filing issues: %v\n", err)
 		}
 	}
 
-	fmt.Println("Usage:")
-	fmt.Println("  temporal-analyzer --lint                    # Run all rules")
-	fmt.Println("  temporal-analyzer --lint --lint-strict      # Fail on warnings")
-	fmt.Println("  temporal-analyzer --lint --lint-disable TA001,TA002")
-	fmt.Println("  temporal-analyzer --lint --lint-format github  # For GitHub Actions")
-	fmt.Println()
+	return result.ExitCode
 }
 
-func categoryTitle(cat lint.Category) string {
-	switch cat {
-	case lint.CategoryReliability:
-		return "🔒 Reliability"
-	case lint.CategoryBestPractice:
-		return "✨ Best Practices"
-	case lint.CategoryPerformance:
-		return "⚡ Performance"
-	case lint.CategoryMaintenance:
-		return "🔧 Maintenance"
-	case lint.CategorySecurity:
-		return "🛡️  Security"
-	default:
-		return string(cat)
+// lintGraph lints an analyzed graph with the lint options of cfg. With --lint-diff-base it
+// also analyzes the base ref, returned for baseline comparisons along with the linter.
+func lintGraph(
+	ctx context.Context,
+	cfg *config.Config,
+	logger *slog.Logger,
+	analyzerInstance analyzer.Analyzer,
+	graph *analyzer.TemporalGraph,
+	opts config.AnalysisOptions,
+) (*lint.Linter, *lint.Result, *analyzer.TemporalGraph, error) {
+	requiredAnnotations, err := parseRequiredAnnotations(cfg.GetLintRequiredAnnotations())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	profiles, err := parseLintProfiles(cfg.GetLintProfiles())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	categories, tags, err := parseLintSelection(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	workflowcheckCfg, workflowcheckFindings, err := loadWorkflowcheck(cfg)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-}
 
-func severityFromString(s string) lint.Severity {
-	switch s {
-	case "error":
-		return lint.SeverityError
-	case "warning":
-		return lint.SeverityWarning
-	case "info":
-		return lint.SeverityInfo
-	default:
-		return lint.SeverityInfo
+	// Analyze the diff base ref for breaking change and contract drift detection
+	var baseGraph *analyzer.TemporalGraph
+	var baseContracts *contracts.Document
+	if cfg.LintDiffBase != "" {
+		baseGraph, baseContracts, err = analyzeGitRef(ctx, cfg, logger, analyzerInstance, cfg.LintDiffBase)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to analyze diff base %s: %w", cfg.LintDiffBase, err)
+		}
+		logger.Info("Diff base analysis completed", "ref", cfg.LintDiffBase, "total_nodes", len(baseGraph.Nodes))
 	}
-}
 
-// transformLintSubcommand transforms "lint" subcommand style into flag style.
-// This allows: `temporal-analyzer lint --format=github ./...`
-// to work the same as: `temporal-analyzer --lint --lint-format=github ./...`
-func transformLintSubcommand(args []string) []string {
-	if len(args) < 2 {
-		return args
+	// Workflow contracts drive contract drift (with a diff base) and type hygiene checks
+	headContracts, err := contracts.NewGenerator(logger).Generate(ctx, graph, opts)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to generate workflow contracts: %w", err)
 	}
 
-	// Check if first argument after program name is "lint"
-	if args[1] != "lint" {
-		return args
+	// Type-checked signatures drive the unserializable payload checks
+	serialization, err := analyzer.CheckSerialization(ctx, graph, cfg.RootDir)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to check payload serialization: %w", err)
 	}
 
-	// Transform the arguments:
-	// 1. Remove "lint" subcommand and add --lint flag
-	// 2. Transform --format= to --lint-format= (common mistake)
+	// Create linter config from CLI options
+	lintCfg := &lint.Config{
+		MinSeverity:   severityFromString(cfg.LintMinSeverity),
+		EnabledRules:  cfg.GetLintEnabledRules(),
+		DisabledRules: cfg.GetLintDisabledRules(),
+		Categories:    categories,
+		Tags:          tags,
+		FailOnWarning: cfg.LintStrict,
+		Thresholds: lint.Thresholds{
+			MaxFanOut:            cfg.LintMaxFanOut,
+			MaxCallDepth:         cfg.LintMaxCallDepth,
+			VersioningRequired:   5,
+			UntestedComplexity:   5,
+			TimeoutOutlierFactor: 50,
+		},
+		// LLM enhancement options
+		LLMEnhance: cfg.LLMEnhance,
+		LLMVerify:  cfg.LLMVerify,
+		LLMModel:   cfg.LLMModel,
+		RootDir:    cfg.RootDir,
+		BaseGraph:  baseGraph,
+
+		RequiredAnnotations: requiredAnnotations,
+		BaseContracts:       baseContracts,
+		Contracts:           headContracts,
+		DedicatedTypes:      cfg.GetLintDedicatedTypes(),
+		Serialization:       serialization,
+		Profiles:            profiles,
+
+		Workflowcheck:         workflowcheckCfg,
+		WorkflowcheckFindings: workflowcheckFindings,
+
+		LongRunning:   longRunningConfig(cfg),
+		Coverage:      cfg.LintCoverage,
+		MinConfidence: cfg.MinConfidence,
+		LintTests:     cfg.LintTests,
+	}
+
+	// Create linter and run
+	linter := lint.NewLinter(lintCfg)
+	return linter, linter.Run(ctx, graph), baseGraph, nil
+}
+
+// longRunningConfig returns the TA003 long-running activity heuristics of cfg, whose
+// regexes were checked by Validate.
+func longRunningConfig(cfg *config.Config) lint.LongRunningConfig {
+	lr := lint.LongRunningConfig{MinDuration: cfg.LintLongRunningMinDuration}
+	if cfg.LintLongRunningInclude != "" {
+		lr.Include = regexp.MustCompile(cfg.LintLongRunningInclude)
+	}
+	if cfg.LintLongRunningExclude != "" {
+		lr.Exclude = regexp.MustCompile(cfg.LintLongRunningExclude)
+	}
+	return lr
+}
+
+// newLintFormatter creates the formatter of a lint format, configured by cfg.
+func newLintFormatter(cfg *config.Config, format string) lint.Formatter {
+	formatter := lint.NewFormatter(format)
+	if text, ok := formatter.(*lint.TextFormatter); ok {
+		text.Plain = usePlainOutput(cfg)
+	}
+	if heatmap, ok := formatter.(*lint.HeatmapFormatter); ok {
+		heatmap.RootDir = cfg.GitDir()
+	}
+	if sarif, ok := formatter.(*lint.SARIFFormatter); ok {
+		sarif.RootDir = cfg.GitDir()
+	}
+	if comment, ok := formatter.(*lint.PRCommentFormatter); ok {
+		comment.RootDir = cfg.GitDir()
+		comment.Plain = usePlainOutput(cfg)
+	}
+	return formatter
+}
+
+// runPipeline analyzes once and writes every --emit output concurrently, linting once when a
+// lint format or a format that includes lint issues is emitted. It returns the lint exit code,
+// 0 without lint outputs, or 2 on errors.
+func runPipeline(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer) int {
+	logger.Info("Starting temporal analyzer in pipeline mode",
+		"root_dir", cfg.RootDir,
+		"outputs", len(cfg.Emits))
+
+	opts := cfg.ToAnalysisOptions()
+	ctx := context.Background()
+	graph, err := analyzerInstance.Analyze(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing workflows: %v\n", err)
+		return 2
+	}
+	if graph == nil {
+		fmt.Fprintf(os.Stderr, "Error: analyzer returned nil graph\n")
+		return 2
+	}
+
+	logger.Info("Analysis completed",
+		"workflows", graph.Stats.TotalWorkflows,
+		"activities", graph.Stats.TotalActivities,
+		"total_nodes", len(graph.Nodes))
+
+	if err := checkResolution(cfg, graph); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if err := checkFailedFiles(cfg, graph); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	var result, baseline *lint.Result
+	var baseGraph *analyzer.TemporalGraph
+	if cfg.EmitsLint() || emitsLintIssues(cfg.Emits) {
+		var linter *lint.Linter
+		linter, result, baseGraph, err = lintGraph(ctx, cfg, logger, analyzerInstance, graph, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		rebaseSnapshotPaths(cfg, graph, result.Issues)
+		// A pull request comment only reports the issues not present at the diff base
+		if baseGraph != nil && slices.ContainsFunc(cfg.Emits, func(e config.Emit) bool { return e.Lint && e.Format == "pr-comment" }) {
+			baseline = linter.Run(ctx, baseGraph)
+		}
+	} else {
+		rebaseSnapshotPaths(cfg, graph, nil)
+	}
+
+	// Outputs only read the graph and the lint result, so they are written in parallel;
+	// outputs to stdout take turns so they don't interleave
+	var wg sync.WaitGroup
+	var stdout sync.Mutex
+	errs := make([]error, len(cfg.Emits))
+	for i, emit := range cfg.Emits {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if emit.Path == "-" {
+				stdout.Lock()
+				defer stdout.Unlock()
+			}
+			errs[i] = writeEmit(ctx, cfg, graph, result, emit, baseGraph, baseline)
+		}()
+	}
+	wg.Wait()
+
+	code := 0
+	for i, emit := range cfg.Emits {
+		if errs[i] != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s to %s: %v\n", emit.Format, emit.Path, errs[i])
+			code = 2
+			continue
+		}
+		logger.Info("Wrote output", "format", emit.Format, "lint", emit.Lint, "file", emit.Path)
+	}
+	if code != 0 {
+		return code
+	}
+
+	if cfg.HistoryDB != "" {
+		if err := recordHistory(ctx, cfg, graph, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error recording history snapshot: %v\n", err)
+		}
+	}
+
+	if result == nil {
+		return 0
+	}
+	return result.ExitCode
+}
+
+// emitsLintIssues reports whether any --emit output format includes lint issues.
+func emitsLintIssues(emits []config.Emit) bool {
+	for _, emit := range emits {
+		if !emit.Lint && output.IncludesLint(emit.Format) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeEmit writes one pipeline output: the lint result in a lint format, or the graph
+// in an output format. A path of "-" writes to stdout. The pr-comment lint format compares
+// the graph and result against the diff base graph and its lint result, if any.
+func writeEmit(ctx context.Context, cfg *config.Config, graph *analyzer.TemporalGraph, result *lint.Result, emit config.Emit, baseGraph *analyzer.TemporalGraph, baseline *lint.Result) error {
+	var issues []lint.Issue
+	if result != nil {
+		issues = result.Issues
+	}
+	manager := output.NewDefaultManager(output.Options{
+		Fields:       cfg.GetFields(),
+		LegacyJSON:   cfg.LegacyJSON,
+		MaxDepth:     cfg.MaxDepth,
+		Glyphs:       outputGlyphs(cfg),
+		OutputFile:   emit.Path,
+		TemplateFile: cfg.TemplateFile,
+		LintIssues:   issues,
+		RootDir:      cfg.GitDir(),
+		LinkBase:     linkBase(cfg),
+	})
+	unpruned := graph
+	graph = analyzer.PruneGraph(graph, cfg.RootDir, cfg.GetPruneOptions())
+
+	// The sqlite format writes its database file itself
+	if !emit.Lint && emit.Format == "sqlite" {
+		return manager.Format(ctx, emit.Format, graph, io.Discard)
+	}
+
+	out := os.Stdout
+	if emit.Path != "-" {
+		f, err := os.Create(emit.Path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	if emit.Lint {
+		formatter := newLintFormatter(cfg, emit.Format)
+		if comment, ok := formatter.(*lint.PRCommentFormatter); ok {
+			comment.Graph, comment.BaseGraph, comment.Baseline = unpruned, baseGraph, baseline
+		}
+		return formatter.Format(result, out)
+	}
+	return manager.Format(ctx, emit.Format, graph, out)
+}
+
+// fileIssues creates or updates tracker tickets for lint errors not present in the baseline.
+func fileIssues(ctx context.Context, cfg *config.Config, logger *slog.Logger, result, baseline *lint.Result) error {
+	t, err := tracker.New(cfg.FileIssues, os.Getenv)
+	if err != nil {
+		return err
+	}
+
+	opts := tracker.Options{RootDir: cfg.GitDir(), LinkBase: linkBase(cfg)}
+	summary, err := tracker.NewFiler(t, opts, logger).File(ctx, result, baseline)
+	logger.Info("Filed lint issues", "tracker", t.Name(), "created", len(summary.Created), "updated", len(summary.Updated), "failed", summary.Failed)
+	return err
+}
+
+// linkBase returns the URL of the working tree in a code browser for links to the code:
+// --link-base, or the one derived from the GitHub Actions environment.
+func linkBase(cfg *config.Config) string {
+	if cfg.LinkBase != "" {
+		return cfg.LinkBase
+	}
+	return issueLinkBase(cfg.GitDir(), os.Getenv)
+}
+
+// issueLinkBase returns the URL that file paths relative to rootDir are appended to for code
+// links in tickets. It is derived from the GitHub Actions environment and empty elsewhere.
+func issueLinkBase(rootDir string, getenv func(string) string) string {
+	server, repo, sha := getenv("GITHUB_SERVER_URL"), getenv("GITHUB_REPOSITORY"), getenv("GITHUB_SHA")
+	if server == "" || repo == "" || sha == "" {
+		return ""
+	}
+
+	base := fmt.Sprintf("%s/%s/blob/%s/", strings.TrimSuffix(server, "/"), repo, sha)
+	if workspace := getenv("GITHUB_WORKSPACE"); workspace != "" {
+		absRoot, err := filepath.Abs(rootDir)
+		if err != nil {
+			return ""
+		}
+		rel, err := filepath.Rel(workspace, absRoot)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return ""
+		}
+		if rel != "." {
+			base += filepath.ToSlash(rel) + "/"
+		}
+	}
+	return base
+}
+
+// usePackages resolves the package patterns given as arguments with go list, and points
+// cfg.RootDir at the deepest directory containing the packages, whose directories are
+// analyzed. Without package patterns, nothing changes.
+func usePackages(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
+	if len(cfg.Packages) == 0 {
+		return nil
+	}
+	dirs, err := analyzer.ListPackageDirs(ctx, cfg.RootDir, cfg.Packages)
+	if err != nil {
+		return fmt.Errorf("failed to resolve packages: %w", err)
+	}
+	cfg.RootDir = analyzer.PackagesRoot(dirs)
+	cfg.PackageDirs = dirs
+	logger.Info("Analyzing packages", "patterns", strings.Join(cfg.Packages, " "), "packages", len(dirs), "root_dir", cfg.RootDir)
+	return nil
+}
+
+// useGitRef points cfg.RootDir at a snapshot of the repository at --ref, keeping the
+// original directory in cfg.WorkTreeDir for git commands. It returns a function removing
+// the snapshot; without --ref, nothing changes.
+func useGitRef(ctx context.Context, cfg *config.Config, logger *slog.Logger) (func(), error) {
+	if cfg.Ref == "" {
+		return func() {}, nil
+	}
+	dir, cleanup, err := analyzer.SnapshotGitRef(ctx, cfg.RootDir, cfg.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot %s: %w", cfg.Ref, err)
+	}
+	logger.Info("Analyzing git revision", "ref", cfg.Ref, "snapshot", dir)
+	cfg.WorkTreeDir = cfg.RootDir
+	cfg.RootDir = dir
+	return cleanup, nil
+}
+
+// rebaseSnapshotPaths moves the file paths of a graph analyzed from a --ref snapshot, and of
+// its lint issues, to the working tree, as the snapshot is removed on exit. It is called once
+// nothing reads the snapshot's sources anymore; without --ref, nothing changes.
+func rebaseSnapshotPaths(cfg *config.Config, graph *analyzer.TemporalGraph, issues []lint.Issue) {
+	if cfg.WorkTreeDir == "" {
+		return
+	}
+	graph.RebasePaths(cfg.RootDir, cfg.WorkTreeDir)
+	for i := range issues {
+		issues[i].FilePath = analyzer.RebasePath(issues[i].FilePath, cfg.RootDir, cfg.WorkTreeDir)
+		if fix := issues[i].Fix; fix != nil {
+			for j := range fix.Replacements {
+				fix.Replacements[j].FilePath = analyzer.RebasePath(fix.Replacements[j].FilePath, cfg.RootDir, cfg.WorkTreeDir)
+			}
+		}
+	}
+}
+
+// analyzeGitRef analyzes the project as of a git ref and generates its workflow contracts.
+// File paths in the graph are moved from the removed snapshot to the working tree.
+func analyzeGitRef(ctx context.Context, cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer, ref string) (*analyzer.TemporalGraph, *contracts.Document, error) {
+	baseDir, cleanup, err := analyzer.SnapshotGitRef(ctx, cfg.GitDir(), ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cleanup()
+
+	opts := cfg.ToAnalysisOptions()
+	opts.RootDir = baseDir
+	graph, err := analyzerInstance.Analyze(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := contracts.NewGenerator(logger).Generate(ctx, graph, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	graph.RebasePaths(baseDir, cfg.GitDir())
+	return graph, doc, nil
+}
+
+// runContracts writes the workflow contracts of the analyzed code and returns the exit code.
+func runContracts(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer) int {
+	logger.Info("Starting temporal analyzer in contracts mode", "root_dir", cfg.RootDir)
+
+	ctx := context.Background()
+	opts := cfg.ToAnalysisOptions()
+	graph, err := analyzerInstance.Analyze(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing workflows: %v\n", err)
+		return 2
+	}
+
+	doc, err := contracts.NewGenerator(logger).Generate(ctx, graph, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating workflow contracts: %v\n", err)
+		return 2
+	}
+
+	out := os.Stdout
+	if cfg.OutputFile != "" {
+		f, err := os.Create(cfg.OutputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating output file %s: %v\n", cfg.OutputFile, err)
+			return 2
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	if cfg.OutputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(doc)
+	} else {
+		err = contracts.WriteYAML(out, doc)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing workflow contracts: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// recordHistory appends a snapshot of the analysis to the history database.
+// Without a lint result, the graph is linted with the default rules so issue counts are always recorded.
+func recordHistory(ctx context.Context, cfg *config.Config, graph *analyzer.TemporalGraph, result *lint.Result) error {
+	if result == nil {
+		result = lint.NewLinter(lint.DefaultConfig()).Run(ctx, graph)
+	}
+	return history.NewSQLiteStore(cfg.HistoryDB).Append(ctx, history.NewSnapshot(graph, result, time.Now()))
+}
+
+// runTrend reports metric trends from the history database and returns the exit code.
+func runTrend(cfg *config.Config, logger *slog.Logger) int {
+	logger.Info("Starting temporal analyzer in trend mode", "history_db", cfg.HistoryDB)
+
+	snapshots, err := history.NewSQLiteStore(cfg.HistoryDB).Load(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+		return 2
+	}
+
+	out := os.Stdout
+	if cfg.OutputFile != "" {
+		f, err := os.Create(cfg.OutputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating output file %s: %v\n", cfg.OutputFile, err)
+			return 2
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	if cfg.OutputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(snapshots)
+	} else {
+		err = history.WriteTrend(out, snapshots, outputGlyphs(cfg))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing trend: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runIssueHistory reports when the lint issues of --node appeared and disappeared in the
+// history database and returns the exit code.
+func runIssueHistory(cfg *config.Config, logger *slog.Logger) int {
+	logger.Info("Starting temporal analyzer in history mode", "history_db", cfg.HistoryDB, "node", cfg.Node)
+
+	ctx := context.Background()
+	store := history.NewSQLiteStore(cfg.HistoryDB)
+	snapshots, err := store.Load(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+		return 2
+	}
+	records, err := store.LoadIssues(ctx, cfg.Node)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+		return 2
+	}
+	spans := history.IssueSpans(snapshots, records)
+
+	out := os.Stdout
+	if cfg.OutputFile != "" {
+		f, err := os.Create(cfg.OutputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating output file %s: %v\n", cfg.OutputFile, err)
+			return 2
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	if cfg.OutputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(spans)
+	} else {
+		err = history.WriteIssueHistory(out, cfg.Node, spans, time.Now())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing issue history: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runStats prints node counts, average fan-out and lint issue counts grouped by --by
+// and returns the exit code. Issues are counted with the default rules, honoring
+// --lint-enable, --lint-disable, --lint-categories and --lint-tags. With --timeouts it prints the distribution of
+// activity timeouts per package instead, and with --workflow-ids the workflow ID taxonomy.
+func runStats(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer) int {
+	logger.Info("Starting temporal analyzer in stats mode", "root_dir", cfg.RootDir, "by", cfg.StatsBy)
+
+	ctx := context.Background()
+	graph, err := analyzerInstance.Analyze(ctx, cfg.ToAnalysisOptions())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing workflows: %v\n", err)
+		return 2
+	}
+
+	var write func(out io.Writer) error
+	if cfg.StatsWorkflowIDs {
+		rows := stats.WorkflowIDs(graph)
+		for _, r := range stats.Collisions(rows) {
+			fmt.Fprintf(os.Stderr, "Warning: workflow ID pattern %s is shared by %s\n", r.Pattern, strings.Join(r.Workflows, ", "))
+		}
+		write = func(out io.Writer) error {
+			switch {
+			case cfg.OutputFormat == "json":
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(rows)
+			case cfg.StatsFormat == "csv":
+				return stats.WriteWorkflowIDsCSV(out, rows)
+			default:
+				return stats.WriteWorkflowIDsMarkdown(out, rows)
+			}
+		}
+	} else if cfg.StatsTimeouts {
+		rows := stats.Timeouts(graph)
+		write = func(out io.Writer) error {
+			switch {
+			case cfg.OutputFormat == "json":
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(rows)
+			case cfg.StatsFormat == "csv":
+				return stats.WriteTimeoutsCSV(out, rows)
+			default:
+				return stats.WriteTimeoutsMarkdown(out, rows)
+			}
+		}
+	} else {
+		categories, tags, err := parseLintSelection(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		lintCfg := lint.DefaultConfig()
+		lintCfg.EnabledRules = cfg.GetLintEnabledRules()
+		lintCfg.DisabledRules = cfg.GetLintDisabledRules()
+		lintCfg.Categories = categories
+		lintCfg.Tags = tags
+		lintCfg.RootDir = cfg.RootDir
+		result := lint.NewLinter(lintCfg).Run(ctx, graph)
+		rebaseSnapshotPaths(cfg, graph, result.Issues)
+
+		rows, err := stats.Aggregate(graph, result, cfg.StatsBy)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		write = func(out io.Writer) error {
+			switch {
+			case cfg.OutputFormat == "json":
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(rows)
+			case cfg.StatsFormat == "csv":
+				return stats.WriteCSV(out, rows, cfg.StatsBy)
+			default:
+				return stats.WriteMarkdown(out, rows, cfg.StatsBy)
+			}
+		}
+	}
+
+	out := os.Stdout
+	if cfg.OutputFile != "" {
+		f, err := os.Create(cfg.OutputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating output file %s: %v\n", cfg.OutputFile, err)
+			return 2
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	if err := write(out); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing stats: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runSimulateLoad estimates the task queue backlog and latency of starting a workflow at a
+// rate, writes the report and returns the exit code: 1 if a task queue saturates.
+func runSimulateLoad(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer, stdout io.Writer) int {
+	logger.Info("Starting temporal analyzer in load simulation mode",
+		"root_dir", cfg.RootDir,
+		"workflow", cfg.SimulateWorkflow,
+		"rps", cfg.SimulateRPS,
+	)
+
+	graph, err := analyzerInstance.Analyze(context.Background(), cfg.ToAnalysisOptions())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing workflows: %v\n", err)
+		return 2
+	}
+	result, err := simulate.Simulate(graph, simulate.Options{
+		Workflow: cfg.SimulateWorkflow,
+		RPS:      cfg.SimulateRPS,
+		Replicas: cfg.SimulateReplicas,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	out := stdout
+	if cfg.OutputFile != "" {
+		f, err := os.Create(cfg.OutputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating output file %s: %v\n", cfg.OutputFile, err)
+			return 2
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	if cfg.OutputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(result)
+	} else {
+		err = simulate.WriteMarkdown(out, result)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing load simulation: %v\n", err)
+		return 2
+	}
+	if result.Saturated {
+		return 1
+	}
+	return 0
+}
+
+// runInventory compares the analyzed workflows and activities with the types the Temporal
+// server executed in the last --inventory-days days and returns the exit code.
+func runInventory(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer) int {
+	logger.Info("Starting temporal analyzer in inventory mode",
+		"root_dir", cfg.RootDir,
+		"server", cfg.TemporalHTTP,
+		"namespace", cfg.TemporalNamespace)
+
+	ctx := context.Background()
+	graph, err := analyzerInstance.Analyze(ctx, cfg.ToAnalysisOptions())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing workflows: %v\n", err)
+		return 2
+	}
+
+	client := inventory.NewClient(&http.Client{Timeout: 30 * time.Second},
+		cfg.TemporalHTTP, cfg.TemporalNamespace, os.Getenv("TEMPORAL_API_KEY"))
+	since := time.Now().AddDate(0, 0, -cfg.InventoryDays)
+	observed, err := client.Observe(ctx, since, inventory.TaskQueues(graph))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading the Temporal server: %v\n", err)
+		return 2
+	}
+	report := inventory.Compare(graph, observed)
+
+	out := os.Stdout
+	if cfg.OutputFile != "" {
+		f, err := os.Create(cfg.OutputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating output file %s: %v\n", cfg.OutputFile, err)
+			return 2
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	if cfg.OutputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	} else {
+		err = inventory.WriteText(out, report)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing inventory: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runServer serves the AnalyzerService gRPC API until interrupted.
+func runServer(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer) int {
+	lis, err := net.Listen("tcp", cfg.ServeGRPC)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("Serving gRPC AnalyzerService", "address", lis.Addr().String())
+	if err := server.Serve(ctx, lis, server.New(logger, analyzerInstance)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runDaemon analyzes and lints the project, then answers editor requests on cfg.Socket
+// until interrupted, re-analyzing when a requested file changed. It returns the exit code.
+func runDaemon(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer) int {
+	opts := cfg.ToAnalysisOptions()
+	d := daemon.New(logger, func(ctx context.Context) (*analyzer.TemporalGraph, *lint.Result, error) {
+		graph, err := analyzerInstance.Analyze(ctx, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to analyze workflows: %w", err)
+		}
+		_, result, _, err := lintGraph(ctx, cfg, logger, analyzerInstance, graph, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return graph, result, nil
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := d.Load(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// A socket left behind by a daemon that didn't exit cleanly would fail the listen
+	if info, err := os.Lstat(cfg.Socket); err == nil && info.Mode()&os.ModeSocket != 0 {
+		_ = os.Remove(cfg.Socket)
+	}
+	lis, err := net.Listen("unix", cfg.Socket)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	defer func() { _ = os.Remove(cfg.Socket) }()
+
+	logger.Info("Serving editor requests", "socket", cfg.Socket, "root_dir", cfg.RootDir)
+	if err := daemon.Serve(ctx, lis, d); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runInit inspects the project, asks for its settings on in and writes the config file
+// (--output, or .temporal-analyzer.yaml) and the CI workflow in the current directory.
+// It returns the exit code.
+func runInit(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer, in io.Reader, out io.Writer) int {
+	logger.Info("Starting temporal analyzer in init mode", "root_dir", cfg.RootDir)
+
+	configPath := cfg.OutputFile
+	if configPath == "" {
+		configPath = config.ConfigFile
+	}
+	if _, err := os.Stat(configPath); err == nil {
+		fmt.Fprintf(os.Stderr, "Error: %s already exists; remove it to start over\n", configPath)
+		return 1
+	}
+
+	opts := cfg.ToAnalysisOptions()
+	graph, err := analyzerInstance.Analyze(context.Background(), opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing workflows: %v\n", err)
+		return 2
+	}
+	project, err := onboarding.Inspect(graph, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	answers, err := onboarding.Ask(in, out, project)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	var buf bytes.Buffer
+	if err := onboarding.WriteConfig(&buf, project, answers); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if err := os.WriteFile(configPath, buf.Bytes(), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", configPath, err)
+		return 2
+	}
+	fmt.Fprintf(out, "Wrote %s\n", configPath)
+
+	if !answers.CIWorkflow {
+		return 0
+	}
+	if _, err := os.Stat(onboarding.CIWorkflowFile); err == nil {
+		fmt.Fprintf(out, "Kept the existing %s\n", onboarding.CIWorkflowFile)
+		return 0
+	}
+	buf.Reset()
+	if err := onboarding.WriteCIWorkflow(&buf); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if err := os.MkdirAll(filepath.Dir(onboarding.CIWorkflowFile), 0o755); err == nil {
+		err = os.WriteFile(onboarding.CIWorkflowFile, buf.Bytes(), 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", onboarding.CIWorkflowFile, err)
+		return 2
+	}
+	fmt.Fprintf(out, "Wrote %s\n", onboarding.CIWorkflowFile)
+	return 0
+}
+
+// runAssert evaluates the assertions file against the graph and returns the exit code:
+// 1 if an assertion fails.
+func runAssert(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer, out io.Writer) int {
+	assertionsFile := cfg.AssertionsFile
+	if assertionsFile == "" {
+		assertionsFile = filepath.Join(cfg.RootDir, assertions.DefaultFile)
+	}
+	logger.Info("Starting temporal analyzer in assert mode", "root_dir", cfg.RootDir, "assertions", assertionsFile)
+
+	list, err := assertions.Load(assertionsFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	graph, err := analyzerInstance.Analyze(context.Background(), cfg.ToAnalysisOptions())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing workflows: %v\n", err)
+		return 2
+	}
+	results := assertions.Evaluate(graph, list)
+
+	if cfg.OutputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(results)
+	} else {
+		err = assertions.WriteText(out, results, outputGlyphs(cfg))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing assertion results: %v\n", err)
+		return 2
+	}
+
+	for _, r := range results {
+		if !r.Passed {
+			return 1
+		}
+	}
+	return 0
+}
+
+// runExplainNode prints why the function named by --name was or was not detected and
+// returns the exit code: 1 if no declaration of it is in the output.
+func runExplainNode(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer, out io.Writer) int {
+	logger.Info("Starting temporal analyzer in explain mode", "root_dir", cfg.RootDir, "name", cfg.FilterName)
+
+	// The graph is analyzed without node filters; the explanation reports the ones dropping the node.
+	// --name names the function to explain rather than filtering by name.
+	opts := cfg.ToAnalysisOptions()
+	opts.FilterName = ""
+	unfiltered := opts
+	unfiltered.FilterPackage, unfiltered.Query, unfiltered.MinConfidence = "", "", ""
+
+	ctx := context.Background()
+	graph, err := analyzerInstance.Analyze(ctx, unfiltered)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing workflows: %v\n", err)
+		return 2
+	}
+	explanation, err := analyzer.ExplainDetection(ctx, logger, graph, cfg.FilterName, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if cfg.OutputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(explanation)
+	} else {
+		err = writeDetectionExplanation(out, explanation, outputGlyphs(cfg))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing explanation: %v\n", err)
+		return 2
+	}
+
+	for _, d := range explanation.Declarations {
+		if d.Detected() {
+			return 0
+		}
+	}
+	return 1
+}
+
+// writeDetectionExplanation prints the detection decision trail of each declaration.
+func writeDetectionExplanation(w io.Writer, e *analyzer.DetectionExplanation, g glyphs.Set) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	if len(e.Declarations) == 0 {
+		printf("%s No function or method named %s is declared in the analyzed files\n", g.Error, e.Name)
+	}
+	for _, d := range e.Declarations {
+		name := e.Name
+		if d.Receiver != "" && !strings.Contains(name, ".") {
+			name = d.Receiver + "." + name
+		}
+		printf("%s (%s:%d, package %s)\n", name, d.FilePath, d.LineNumber, d.Package)
+		if d.Skipped != "" {
+			printf("  %s not analyzed: %s\n", g.Error, d.Skipped)
+		}
+		for _, check := range d.Checks {
+			marker := g.Error
+			switch {
+			case check.Ignored:
+				marker = g.Info
+			case check.Passed:
+				marker = g.Success
+			}
+			line := check.Name
+			if check.Detail != "" {
+				line += " (" + check.Detail + ")"
+			}
+			printf("  %s %s\n", marker, line)
+		}
+		switch {
+		case d.Skipped != "":
+		case d.Type == "":
+			printf("  %s not detected: no registration, and no workflow.Context parameter with workflow SDK calls\n", g.Error)
+		case d.Filtered != "":
+			printf("  %s detected as %s, but left out: %s\n", g.Warning, d.Type, d.Filtered)
+		default:
+			printf("  %s detected as %s", g.Success, d.Type)
+			if d.Confidence != "" {
+				printf(" with %s confidence (%s)", d.Confidence, strings.Join(d.Reasons, ", "))
+			}
+			printf("\n")
+		}
+		printf("\n")
+	}
+
+	if len(e.CalledBy) > 0 {
+		printf("Executed by:\n")
+		for _, ref := range e.CalledBy {
+			printf("  %s %s (%s:%d)\n", g.Arrow, ref.Name, ref.FilePath, ref.LineNumber)
+		}
+		if e.Unresolved {
+			printf("%s The calls name %s, but no analyzed declaration was detected for it\n", g.Warning, e.Name)
+		}
+	}
+	return err
+}
+
+// runRename renames the workflow or activity given with --node to --to, or prints the edits
+// with --dry-run, and returns the exit code.
+func runRename(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer, out io.Writer) int {
+	logger.Info("Starting temporal analyzer in rename mode", "root_dir", cfg.RootDir, "node", cfg.Node, "to", cfg.RenameTo)
+
+	// Node filters would hide call sites of the node
+	opts := cfg.ToAnalysisOptions()
+	opts.FilterPackage, opts.FilterName, opts.Query, opts.MinConfidence = "", "", "", ""
+
+	ctx := context.Background()
+	graph, err := analyzerInstance.Analyze(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing workflows: %v\n", err)
+		return 2
+	}
+	node, err := rename.FindNode(graph, cfg.Node)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	plan, err := rename.NewPlan(ctx, graph, node, cfg.RenameTo, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if !cfg.RenameDryRun {
+		if err := plan.Apply(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+	}
+
+	if cfg.OutputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(plan)
+	} else {
+		err = writeRenamePlan(out, plan, cfg.RenameDryRun)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing rename: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// writeRenamePlan prints the edits of a rename, one per line.
+func writeRenamePlan(w io.Writer, plan *rename.Plan, dryRun bool) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	for _, e := range plan.Edits {
+		printf("%s:%d:%d: %-12s %s -> %s\n", e.FilePath, e.Line, e.Column, e.Kind, e.Old, e.New)
+	}
+	verb := "Renamed"
+	if dryRun {
+		verb = "Would rename"
+	}
+	printf("%s %s to %s: %d edits in %d files\n", verb, plan.Node, plan.To, len(plan.Edits), plan.Files())
+	return err
+}
+
+// runFix applies the code fixes of the lint issues whose OldText still matches the source,
+// or prints them as a unified diff with --fix-dry-run, and returns the exit code. With the
+// diff, the summary of applied and skipped fixes goes to stderr.
+func runFix(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer, out io.Writer) int {
+	logger.Info("Starting temporal analyzer in fix mode", "root_dir", cfg.RootDir, "dry_run", cfg.FixDryRun)
+
+	opts := cfg.ToAnalysisOptions()
+	ctx := context.Background()
+	graph, err := analyzerInstance.Analyze(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing workflows: %v\n", err)
+		return 2
+	}
+	if err := checkResolution(cfg, graph); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if err := checkFailedFiles(cfg, graph); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	_, result, _, err := lintGraph(ctx, cfg, logger, analyzerInstance, graph, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	plan := fix.NewPlan(result.Issues, cfg.RootDir)
+	summary := out
+	if cfg.FixDryRun {
+		summary = os.Stderr
+		if err := plan.Diff(out, cfg.RootDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing diff: %v\n", err)
+			return 2
+		}
+	} else if err := plan.Apply(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if err := writeFixSummary(summary, plan, cfg.FixDryRun); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing fixes: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// writeFixSummary prints the applied and skipped fixes of a plan, one per line.
+func writeFixSummary(w io.Writer, plan *fix.Plan, dryRun bool) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	for _, f := range plan.Fixes {
+		if f.Applied {
+			printf("applied %s %s:%d: %s\n", f.RuleID, f.FilePath, f.LineNumber, f.Description)
+		} else {
+			printf("skipped %s %s:%d: %s: %s\n", f.RuleID, f.FilePath, f.LineNumber, f.Description, f.Reason)
+		}
+	}
+	verb := "Applied"
+	if dryRun {
+		verb = "Would apply"
+	}
+	printf("%s %d fixes in %d files, skipped %d\n", verb, plan.Applied(), plan.Files(), len(plan.Fixes)-plan.Applied())
+	return err
+}
+
+// printVersion prints the version of the binary and of the graph schema it exports.
+func printVersion(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "temporal-analyzer %s\n", Version)
+	_, _ = fmt.Fprintf(w, "Built: %s\n", BuildTime)
+	_, _ = fmt.Fprintf(w, "Graph schema: %d\n", analyzer.GraphSchemaVersion)
+}
+
+// releaseClient returns the client fetching releases, verifying them with the built-in key.
+func releaseClient() (*update.Client, error) {
+	c := &update.Client{HTTP: &http.Client{Timeout: 5 * time.Minute}}
+	if ReleaseKey != "" {
+		key, err := update.ParsePublicKey(ReleaseKey)
+		if err != nil {
+			return nil, err
+		}
+		c.PublicKey = key
+	}
+	return c, nil
+}
+
+// runVersion prints the version and, with --check, whether a newer release is available.
+// It returns 1 if one is.
+func runVersion(args []string, out io.Writer) int {
+	fs := flag.NewFlagSet("version", flag.ContinueOnError)
+	check := fs.Bool("check", false, "Check whether a newer release is available")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	printVersion(out)
+	if !*check {
+		return 0
+	}
+
+	c, err := releaseClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	release, err := c.Latest(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking for updates: %v\n", err)
+		return 2
+	}
+
+	newer, ok := update.Newer(Version, release.Version)
+	switch {
+	case !ok:
+		fmt.Fprintf(out, "Latest release: %s (this is a development build)\n", release.Version)
+	case newer:
+		fmt.Fprintf(out, "A newer release is available: %s %s\n", release.Version, release.URL)
+		fmt.Fprintf(out, "Run `temporal-analyzer self-update` to install it\n")
+		return 1
+	default:
+		fmt.Fprintf(out, "Up to date\n")
+	}
+	return 0
+}
+
+// runSelfUpdate replaces the running binary with the latest release, or with the release
+// of the version given as argument, after verifying its signed checksum.
+func runSelfUpdate(args []string, out io.Writer) int {
+	fs := flag.NewFlagSet("self-update", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: temporal-analyzer self-update [VERSION]\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	c, err := releaseClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	var release *update.Release
+	if pinned := fs.Arg(0); pinned != "" {
+		release, err = c.Tagged(ctx, pinned)
+	} else {
+		release, err = c.Latest(ctx)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching release: %v\n", err)
+		return 2
+	}
+	if newer, ok := update.Newer(Version, release.Version); fs.NArg() == 0 && ok && !newer {
+		fmt.Fprintf(out, "temporal-analyzer %s is up to date\n", Version)
+		return 0
+	}
+
+	binary, err := c.Download(ctx, release)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error downloading %s: %v\n", release.Version, err)
+		return 2
+	}
+	exe, err := os.Executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error locating the running binary: %v\n", err)
+		return 2
+	}
+	if err := update.Install(binary, exe); err != nil {
+		fmt.Fprintf(os.Stderr, "Error installing %s: %v\n", release.Version, err)
+		return 2
+	}
+	fmt.Fprintf(out, "Installed temporal-analyzer %s at %s\n", release.Version, exe)
+	return 0
+}
+
+// runReplay replays workflow histories against the analyzed code and returns the exit code.
+func runReplay(cfg *config.Config, logger *slog.Logger, analyzerInstance analyzer.Analyzer) int {
+	logger.Info("Starting temporal analyzer in replay mode",
+		"root_dir", cfg.RootDir,
+		"histories", cfg.ReplayHistories,
+		"generate_only", cfg.ReplayGenerateOnly)
+
+	ctx := context.Background()
+	graph, err := analyzerInstance.Analyze(ctx, cfg.ToAnalysisOptions())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing workflows: %v\n", err)
+		return 2
+	}
+
+	runner := replay.NewRunner(logger)
+	result, err := runner.Run(ctx, graph, replay.Options{
+		HistoryDir:    cfg.ReplayHistories,
+		GenerateOnly:  cfg.ReplayGenerateOnly,
+		KeepGenerated: cfg.ReplayKeep,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error replaying histories: %v\n", err)
+		return 2
+	}
+	if cfg.WorkTreeDir != "" {
+		for i := range result.Failures {
+			result.Failures[i].FilePath = analyzer.RebasePath(result.Failures[i].FilePath, cfg.RootDir, cfg.WorkTreeDir)
+		}
+	}
+
+	out := os.Stdout
+	if cfg.OutputFile != "" {
+		f, err := os.Create(cfg.OutputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating output file %s: %v\n", cfg.OutputFile, err)
+			return 2
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	if cfg.OutputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(result)
+	} else {
+		err = replay.WriteText(out, result, outputGlyphs(cfg))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing replay results: %v\n", err)
+		return 2
+	}
+
+	if !result.Passed() {
+		return 1
+	}
+	return 0
+}
+
+// listLintRules prints the available lint rules, or those matching the given
+// categories and tags, with their tags.
+func listLintRules(g glyphs.Set, onlyCategories []lint.Category, onlyTags []string) {
+	lintCfg := lint.DefaultConfig()
+	lintCfg.Categories = onlyCategories
+	lintCfg.Tags = onlyTags
+	linter := lint.NewLinter(lintCfg)
+	var rules []lint.RuleInfo
+	for _, rule := range linter.ListRules() {
+		if rule.Enabled {
+			rules = append(rules, rule)
+		}
+	}
+
+	fmt.Println("\nTemporal Analyzer - Available Lint Rules")
+	fmt.Println(strings.Repeat(g.HeavyRule, 67))
+	fmt.Println()
+
+	// Group by category
+	categories := make(map[lint.Category][]lint.RuleInfo)
+	for _, rule := range rules {
+		categories[rule.Category] = append(categories[rule.Category], rule)
+	}
+
+	categoryOrder := []lint.Category{
+		lint.CategoryReliability,
+		lint.CategoryBestPractice,
+		lint.CategoryPerformance,
+		lint.CategoryMaintenance,
+		lint.CategorySecurity,
+	}
+
+	for _, cat := range categoryOrder {
+		catRules, ok := categories[cat]
+		if !ok || len(catRules) == 0 {
+			continue
+		}
+
+		fmt.Printf("  %s\n", categoryTitle(cat, g))
+		fmt.Println("  " + strings.Repeat(g.Rule, 60))
+		for _, rule := range catRules {
+			severityIcon := g.Info
+			switch rule.Severity {
+			case lint.SeverityError:
+				severityIcon = g.Error
+			case lint.SeverityWarning:
+				severityIcon = g.Warning
+			}
+			fmt.Printf("    %s %-8s %-30s %s\n", severityIcon, rule.ID, rule.Name, rule.Severity)
+			fmt.Printf("              %s\n", rule.Description)
+			if len(rule.Tags) > 0 {
+				fmt.Printf("              Tags: %s\n", strings.Join(rule.Tags, ", "))
+			}
+			fmt.Println()
+		}
+	}
+
+	fmt.Println("Usage:")
+	fmt.Println("  temporal-analyzer --lint                    # Run all rules")
+	fmt.Println("  temporal-analyzer --lint --lint-strict      # Fail on warnings")
+	fmt.Println("  temporal-analyzer --lint --lint-disable TA001,TA002")
+	fmt.Println("  temporal-analyzer --lint --lint-categories reliability,security")
+	fmt.Println("  temporal-analyzer --lint --lint-tags determinism")
+	fmt.Println("  temporal-analyzer --lint --lint-format github  # For GitHub Actions")
+	fmt.Println()
+}
+
+// usePlainOutput reports whether CLI outputs should use ASCII symbols,
+// either because --plain was given or the environment can't display Unicode.
+func usePlainOutput(cfg *config.Config) bool {
+	return cfg.Plain || glyphs.PlainFromEnv()
+}
+
+// outputGlyphs returns the symbols used by non-TUI outputs.
+func outputGlyphs(cfg *config.Config) glyphs.Set {
+	return glyphs.For(usePlainOutput(cfg))
+}
+
+func categoryTitle(cat lint.Category, g glyphs.Set) string {
+	switch cat {
+	case lint.CategoryReliability:
+		return glyphs.Icon(g.Reliability, "Reliability")
+	case lint.CategoryBestPractice:
+		return glyphs.Icon(g.BestPractice, "Best Practices")
+	case lint.CategoryPerformance:
+		return glyphs.Icon(g.Performance, "Performance")
+	case lint.CategoryMaintenance:
+		return glyphs.Icon(g.Maintenance, "Maintenance")
+	case lint.CategorySecurity:
+		return glyphs.Icon(g.Security, "Security")
+	default:
+		return string(cat)
+	}
+}
+
+func severityFromString(s string) lint.Severity {
+	switch s {
+	case "error":
+		return lint.SeverityError
+	case "warning":
+		return lint.SeverityWarning
+	case "info":
+		return lint.SeverityInfo
+	default:
+		return lint.SeverityInfo
+	}
+}
+
+// transformLintSubcommand transforms "lint" subcommand style into flag style.
+// This allows: `temporal-analyzer lint --format=github ./...`
+// to work the same as: `temporal-analyzer --lint --lint-format=github ./...`
+func transformLintSubcommand(args []string) []string {
+	if len(args) < 2 {
+		return args
+	}
+
+	// Check if first argument after program name is "lint"
+	if args[1] != "lint" {
+		return args
+	}
+
+	// Transform the arguments:
+	// 1. Remove "lint" subcommand and add --lint flag
+	// 2. Transform --format= to --lint-format= (common mistake)
 	// 3. Transform -format= to -lint-format=
 	newArgs := make([]string, 0, len(args))
 	newArgs = append(newArgs, args[0]) // program name
@@ -478,6 +2147,8 @@ func transformLintSubcommand(args []string) []string {
 		} else if arg == "--format" || arg == "-format" {
 			// Handle --format X (space-separated) form
 			arg = "--lint-format"
+		} else if arg == "--coverage" || arg == "-coverage" {
+			arg = "--lint-coverage"
 		}
 
 		// Transform github-actions to github (the actual valid format name)
@@ -490,3 +2161,36 @@ func transformLintSubcommand(args []string) []string {
 
 	return newArgs
 }
+
+// flagSubcommands maps subcommands to the mode flag they stand for, so that
+// `temporal-analyzer replay --replay-histories ./histories .` runs the same as
+// `temporal-analyzer --replay --replay-histories ./histories .`.
+var flagSubcommands = []struct {
+	name string
+	flag string
+}{
+	{"replay", "--replay"},               // temporal-analyzer replay --replay-histories ./histories .
+	{"contracts", "--contracts"},         // temporal-analyzer contracts --output contracts.yaml .
+	{"trend", "--trend"},                 // temporal-analyzer trend --history-db history.db
+	{"history", "--history"},             // temporal-analyzer history --history-db history.db --node OrderWorkflow
+	{"stats", "--stats"},                 // temporal-analyzer stats --by owner .
+	{"inventory", "--inventory"},         // temporal-analyzer inventory --temporal-http URL .
+	{"simulate-load", "--simulate-load"}, // temporal-analyzer simulate-load --workflow OrderWorkflow --rps 50 .
+	{"init", "--init"},                   // temporal-analyzer init .
+	{"explain-node", "--explain-node"},   // temporal-analyzer explain-node --name OrderWorkflow .
+	{"assert", "--assert"},               // temporal-analyzer assert --assertions architecture.yaml .
+	{"rename", "--rename"},               // temporal-analyzer rename --node ChargeCard --to CollectPayment --dry-run .
+}
+
+// transformSubcommand replaces the subcommand name with its mode flag when the first
+// argument after the program name is name, and returns the other arguments unchanged.
+func transformSubcommand(args []string, name, flag string) []string {
+	if len(args) < 2 || args[1] != name {
+		return args
+	}
+
+	newArgs := make([]string, 0, len(args))
+	newArgs = append(newArgs, args[0], flag)
+	newArgs = append(newArgs, args[2:]...)
+	return newArgs
+}
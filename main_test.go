@@ -6,13 +6,16 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"os/exec"
 	"strings"
 	"testing"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/analyzer"
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/config"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/glyphs"
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/lint"
+	"github.com/ikari-pl/go-temporalio-analyzer/internal/onboarding"
 	"github.com/ikari-pl/go-temporalio-analyzer/internal/tui"
 )
 
@@ -29,6 +32,10 @@ func (m *mockAnalyzer) Analyze(ctx context.Context, opts config.AnalysisOptions)
 	return m.graph, nil
 }
 
+func (m *mockAnalyzer) AnalyzeStream(ctx context.Context, opts config.AnalysisOptions, onPackage func(analyzer.PackageResult)) (*analyzer.TemporalGraph, error) {
+	return m.Analyze(ctx, opts)
+}
+
 // mockTUI implements tui.TUI for testing
 type mockTUI struct {
 	runCalled bool
@@ -40,6 +47,13 @@ func (m *mockTUI) Run(ctx context.Context, graph *analyzer.TemporalGraph) error
 	return m.runErr
 }
 
+func (m *mockTUI) RunAnalysis(ctx context.Context, analyze func(context.Context, func(analyzer.PackageResult)) (*analyzer.TemporalGraph, error)) error {
+	if _, err := analyze(ctx, nil); err != nil {
+		return err
+	}
+	return m.Run(ctx, nil)
+}
+
 // =============================================================================
 // NewLogger Tests
 // =============================================================================
@@ -185,7 +199,7 @@ func TestCategoryTitle(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := categoryTitle(tt.category)
+			result := categoryTitle(tt.category, glyphs.Unicode)
 			if result != tt.expected {
 				t.Errorf("categoryTitle(%v) = %q, want %q", tt.category, result, tt.expected)
 			}
@@ -193,6 +207,12 @@ func TestCategoryTitle(t *testing.T) {
 	}
 }
 
+func TestCategoryTitlePlain(t *testing.T) {
+	if got := categoryTitle(lint.CategorySecurity, glyphs.ASCII); got != "Security" {
+		t.Errorf("categoryTitle(security, ASCII) = %q, want %q", got, "Security")
+	}
+}
+
 // =============================================================================
 // run() Tests
 // =============================================================================
@@ -956,13 +976,41 @@ func TestRunLintDisabledRules(t *testing.T) {
 // listLintRules Tests
 // =============================================================================
 
+func TestParseRequiredAnnotations(t *testing.T) {
+	required, err := parseRequiredAnnotations([]string{"owner", "", "workflow/sla"})
+	if err != nil {
+		t.Fatalf("parseRequiredAnnotations failed: %v", err)
+	}
+	if len(required) != 2 || required[1].NodeType != "workflow" || required[1].Annotation != "sla" {
+		t.Errorf("parseRequiredAnnotations() = %+v", required)
+	}
+
+	if _, err := parseRequiredAnnotations([]string{"query/owner"}); err == nil {
+		t.Error("Expected error for invalid node type")
+	}
+}
+
+func TestParseLintProfiles(t *testing.T) {
+	profiles, err := parseLintProfiles([]string{"payments/**=strict", "", "experimental/**=warnings"})
+	if err != nil {
+		t.Fatalf("parseLintProfiles failed: %v", err)
+	}
+	if len(profiles) != 2 || profiles[1].Pattern != "experimental/**" || profiles[1].Profile != lint.ProfileWarnings {
+		t.Errorf("parseLintProfiles() = %+v", profiles)
+	}
+
+	if _, err := parseLintProfiles([]string{"payments/**=lenient"}); err == nil {
+		t.Error("Expected error for unknown profile")
+	}
+}
+
 func TestListLintRules(t *testing.T) {
 	// Capture stdout
 	oldStdout := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	listLintRules()
+	listLintRules(glyphs.Unicode, nil, nil)
 
 	// Restore stdout
 	_ = w.Close()
@@ -987,6 +1035,28 @@ func TestListLintRules(t *testing.T) {
 	}
 }
 
+func TestListLintRulesSelection(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	listLintRules(glyphs.Unicode, []lint.Category{lint.CategoryReliability}, []string{"determinism"})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	output := buf.String()
+
+	if !strings.Contains(output, "TA044") || !strings.Contains(output, "Tags: determinism, retries") {
+		t.Errorf("listLintRules() should list TA044 with its tags:\n%s", output)
+	}
+	if strings.Contains(output, "TA001 ") {
+		t.Errorf("listLintRules() should leave out rules without the determinism tag:\n%s", output)
+	}
+}
+
 // =============================================================================
 // Integration-style Tests
 // =============================================================================
@@ -1101,6 +1171,11 @@ func TestTransformLintSubcommand(t *testing.T) {
 			args:     []string{"temporal-analyzer", "lint", "--format", "json", "./..."},
 			expected: []string{"temporal-analyzer", "--lint", "--lint-format", "json", "./..."},
 		},
+		{
+			name:     "lint subcommand with --coverage",
+			args:     []string{"temporal-analyzer", "lint", "--coverage", "./..."},
+			expected: []string{"temporal-analyzer", "--lint", "--lint-coverage", "./..."},
+		},
 		{
 			name:     "not a lint subcommand - regular flag usage",
 			args:     []string{"temporal-analyzer", "--lint", "--lint-format=github", "./..."},
@@ -1139,3 +1214,653 @@ func TestTransformLintSubcommand(t *testing.T) {
 	}
 }
 
+func TestTransformSubcommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		sub      string
+		flag     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "no args",
+			sub:      "replay",
+			flag:     "--replay",
+			args:     []string{"temporal-analyzer"},
+			expected: []string{"temporal-analyzer"},
+		},
+		{
+			name:     "replay subcommand with flags and path",
+			sub:      "replay",
+			flag:     "--replay",
+			args:     []string{"temporal-analyzer", "replay", "--replay-histories", "./histories", "./..."},
+			expected: []string{"temporal-analyzer", "--replay", "--replay-histories", "./histories", "./..."},
+		},
+		{
+			name:     "not a replay subcommand",
+			sub:      "replay",
+			flag:     "--replay",
+			args:     []string{"temporal-analyzer", "lint", "./..."},
+			expected: []string{"temporal-analyzer", "lint", "./..."},
+		},
+		{
+			name:     "contracts subcommand with flags and path",
+			sub:      "contracts",
+			flag:     "--contracts",
+			args:     []string{"temporal-analyzer", "contracts", "--output", "contracts.yaml", "./..."},
+			expected: []string{"temporal-analyzer", "--contracts", "--output", "contracts.yaml", "./..."},
+		},
+		{
+			name:     "trend subcommand",
+			sub:      "trend",
+			flag:     "--trend",
+			args:     []string{"temporal-analyzer", "trend", "--history-db", "history.db"},
+			expected: []string{"temporal-analyzer", "--trend", "--history-db", "history.db"},
+		},
+		{
+			name:     "history subcommand",
+			sub:      "history",
+			flag:     "--history",
+			args:     []string{"temporal-analyzer", "history", "--history-db", "history.db", "--node", "OrderWorkflow"},
+			expected: []string{"temporal-analyzer", "--history", "--history-db", "history.db", "--node", "OrderWorkflow"},
+		},
+		{
+			name:     "stats subcommand",
+			sub:      "stats",
+			flag:     "--stats",
+			args:     []string{"temporal-analyzer", "stats", "--by", "owner", "."},
+			expected: []string{"temporal-analyzer", "--stats", "--by", "owner", "."},
+		},
+		{
+			name:     "inventory subcommand",
+			sub:      "inventory",
+			flag:     "--inventory",
+			args:     []string{"temporal-analyzer", "inventory", "--temporal-namespace", "payments", "."},
+			expected: []string{"temporal-analyzer", "--inventory", "--temporal-namespace", "payments", "."},
+		},
+		{
+			name:     "subcommand name not in first position",
+			sub:      "stats",
+			flag:     "--stats",
+			args:     []string{"temporal-analyzer", "--format", "json", "stats"},
+			expected: []string{"temporal-analyzer", "--format", "json", "stats"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := transformSubcommand(tt.args, tt.sub, tt.flag)
+			if strings.Join(result, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("transformSubcommand(%v, %q) = %v, want %v", tt.args, tt.sub, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunSQLUsesLintConfig(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:      "OrderWorkflow",
+				Type:      "workflow",
+				Package:   "orders",
+				FilePath:  "orders/workflow.go",
+				CallSites: []analyzer.CallSite{{TargetName: "ChargeCard", CallType: "activity"}},
+			},
+			"ChargeCard": {Name: "ChargeCard", Type: "activity", Package: "orders", FilePath: "orders/activity.go"},
+		},
+	}
+
+	cfg := config.NewConfig()
+	cfg.RootDir = t.TempDir()
+	cfg.OutputFormat = "sql"
+	cfg.LintDisabledRules = "TA002"
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := run(cfg, logger, &mockAnalyzer{graph: graph}, nil)
+	_ = w.Close()
+	os.Stdout = oldStdout
+	data, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("run() failed: %v", err)
+	}
+
+	if !strings.Contains(string(data), "'TA001'") {
+		t.Errorf("sql output is missing the enabled rule TA001:\n%s", data)
+	}
+	if strings.Contains(string(data), "'TA002'") {
+		t.Errorf("sql output contains TA002 disabled by --lint-disable:\n%s", data)
+	}
+}
+
+func TestFlagSubcommandsAreDistinct(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, sub := range flagSubcommands {
+		if seen[sub.name] || sub.name == "lint" {
+			t.Errorf("subcommand %q is registered more than once", sub.name)
+		}
+		seen[sub.name] = true
+		if sub.flag != "--"+sub.name {
+			t.Errorf("subcommand %q maps to %q, want --%s", sub.name, sub.flag, sub.name)
+		}
+	}
+}
+
+func TestRecordHistoryAndRunTrend(t *testing.T) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 not installed")
+	}
+	tmpDir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{},
+		Stats: analyzer.GraphStats{TotalWorkflows: 2, TotalActivities: 3},
+	}
+
+	cfg := config.NewConfig()
+	cfg.HistoryDB = tmpDir + "/history.db"
+	cfg.OutputFile = tmpDir + "/trend.txt"
+	cfg.Plain = true
+
+	for i := 0; i < 2; i++ {
+		if err := recordHistory(context.Background(), cfg, graph, nil); err != nil {
+			t.Fatalf("recordHistory failed: %v", err)
+		}
+	}
+
+	if code := runTrend(cfg, logger); code != 0 {
+		t.Fatalf("runTrend() = %d, want 0", code)
+	}
+	out, err := os.ReadFile(cfg.OutputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "2 snapshot(s)") || !strings.Contains(string(out), "Activities") {
+		t.Errorf("Unexpected trend output:\n%s", out)
+	}
+}
+
+func TestUseGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	if err := os.WriteFile(dir+"/a.go", []byte("package a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "first")
+	if err := os.WriteFile(dir+"/a.go", []byte("package a\n\nfunc Changed() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{RootDir: dir}
+	cleanup, err := useGitRef(context.Background(), cfg, logger)
+	if err != nil {
+		t.Fatalf("useGitRef() without --ref = %v", err)
+	}
+	cleanup()
+	if cfg.RootDir != dir || cfg.GitDir() != dir {
+		t.Errorf("useGitRef() without --ref changed RootDir to %q", cfg.RootDir)
+	}
+
+	cfg = &config.Config{RootDir: dir, Ref: "HEAD"}
+	cleanup, err = useGitRef(context.Background(), cfg, logger)
+	if err != nil {
+		t.Fatalf("useGitRef() = %v", err)
+	}
+	if cfg.RootDir == dir || cfg.WorkTreeDir != dir || cfg.GitDir() != dir {
+		t.Errorf("useGitRef() RootDir = %q, WorkTreeDir = %q", cfg.RootDir, cfg.WorkTreeDir)
+	}
+	data, err := os.ReadFile(cfg.RootDir + "/a.go")
+	if err != nil || string(data) != "package a\n" {
+		t.Errorf("snapshot a.go = %q, %v; want committed content", data, err)
+	}
+	cleanup()
+	if _, err := os.Stat(cfg.RootDir); !os.IsNotExist(err) {
+		t.Errorf("snapshot not removed: %v", err)
+	}
+
+	cfg = &config.Config{RootDir: dir, Ref: "no-such-ref"}
+	if _, err := useGitRef(context.Background(), cfg, logger); err == nil {
+		t.Error("useGitRef() with an unknown ref = nil, want error")
+	}
+}
+
+func TestRunLintGitRefPaths(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	src := `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+func OrderWorkflow(ctx workflow.Context) error {
+	workflow.GetSignalChannel(ctx, "approve").Receive(ctx, nil)
+	return nil
+}
+`
+	if err := os.WriteFile(dir+"/workflow.go", []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "first")
+
+	out := t.TempDir() + "/lint.json"
+	cfg := config.NewConfig()
+	cfg.RootDir = dir
+	cfg.Ref = "HEAD"
+	cfg.LintMode = true
+	cfg.LintFormat = "json"
+	cfg.OutputFile = out
+	cleanup, err := useGitRef(context.Background(), cfg, logger)
+	if err != nil {
+		t.Fatalf("useGitRef() = %v", err)
+	}
+	snapshot := cfg.RootDir
+	runLint(cfg, logger, analyzer.NewAnalyzer(logger))
+	cleanup()
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("lint.json not written: %v", err)
+	}
+	if strings.Contains(string(data), snapshot) {
+		t.Errorf("lint.json refers to the removed snapshot %s:\n%s", snapshot, data)
+	}
+	if want := dir + "/workflow.go"; !strings.Contains(string(data), want) {
+		t.Errorf("lint.json does not refer to %s:\n%s", want, data)
+	}
+}
+
+func TestRunPipelineGitRefPaths(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	src := `package orders
+
+import "go.temporal.io/sdk/workflow"
+
+func OrderWorkflow(ctx workflow.Context) error {
+	workflow.GetSignalChannel(ctx, "approve").Receive(ctx, nil)
+	return nil
+}
+`
+	if err := os.WriteFile(dir+"/workflow.go", []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "first")
+
+	out := t.TempDir()
+	cfg := config.NewConfig()
+	cfg.RootDir = dir
+	cfg.Ref = "HEAD"
+	cfg.Emits = []config.Emit{
+		{Format: "json", Path: out + "/graph.json"},
+		{Format: "json", Lint: true, Path: out + "/lint.json"},
+	}
+	cleanup, err := useGitRef(context.Background(), cfg, logger)
+	if err != nil {
+		t.Fatalf("useGitRef() = %v", err)
+	}
+	snapshot := cfg.RootDir
+	runPipeline(cfg, logger, analyzer.NewAnalyzer(logger))
+	cleanup()
+
+	want := dir + "/workflow.go"
+	for _, file := range []string{"graph.json", "lint.json"} {
+		data, err := os.ReadFile(out + "/" + file)
+		if err != nil {
+			t.Fatalf("%s not written: %v", file, err)
+		}
+		if strings.Contains(string(data), snapshot) {
+			t.Errorf("%s refers to the removed snapshot %s:\n%s", file, snapshot, data)
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s does not refer to %s:\n%s", file, want, data)
+		}
+	}
+}
+
+func TestRunPipeline(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:      "OrderWorkflow",
+				Type:      "workflow",
+				Package:   "orders",
+				FilePath:  "orders/workflow.go",
+				CallSites: []analyzer.CallSite{{TargetName: "ChargeCard", CallType: "activity"}},
+			},
+			"ChargeCard": {
+				Name:     "ChargeCard",
+				Type:     "activity",
+				Package:  "orders",
+				FilePath: "orders/activity.go",
+				Parents:  []string{"OrderWorkflow"},
+			},
+		},
+		Stats: analyzer.GraphStats{TotalWorkflows: 1, TotalActivities: 1},
+	}
+
+	cfg := config.NewConfig()
+	cfg.RootDir = tmpDir
+	cfg.Emits = []config.Emit{
+		{Format: "json", Path: tmpDir + "/graph.json"},
+		{Format: "sarif", Path: tmpDir + "/lint.sarif", Lint: true},
+		{Format: "markdown", Path: tmpDir + "/report.md"},
+	}
+
+	// The activity call without options is a lint error, so the lint exit code is 1
+	mockA := &countingAnalyzer{graph: graph}
+	if code := runPipeline(cfg, logger, mockA); code != 1 {
+		t.Fatalf("runPipeline() = %d, want 1", code)
+	}
+	if mockA.calls != 1 {
+		t.Errorf("Analyze called %d times, want 1", mockA.calls)
+	}
+
+	for file, want := range map[string]string{
+		"graph.json": `"OrderWorkflow"`,
+		"lint.sarif": `"version": "2.1.0"`,
+		"report.md":  "OrderWorkflow",
+	} {
+		data, err := os.ReadFile(tmpDir + "/" + file)
+		if err != nil {
+			t.Errorf("%s not written: %v", file, err)
+			continue
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s does not contain %q:\n%s", file, want, data)
+		}
+	}
+
+	cfg.Emits = cfg.Emits[:1]
+	if code := runPipeline(cfg, logger, &mockAnalyzer{graph: graph}); code != 0 {
+		t.Errorf("runPipeline() without lint outputs = %d, want 0", code)
+	}
+	if code := runPipeline(cfg, logger, &mockAnalyzer{err: io.EOF}); code != 2 {
+		t.Errorf("runPipeline() with analyzer error = %d, want 2", code)
+	}
+	cfg.Emits = []config.Emit{{Format: "json", Path: tmpDir + "/missing/graph.json"}}
+	if code := runPipeline(cfg, logger, &mockAnalyzer{graph: graph}); code != 2 {
+		t.Errorf("runPipeline() with unwritable path = %d, want 2", code)
+	}
+}
+
+func TestRunPipelineSQLUsesLintConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {
+				Name:      "OrderWorkflow",
+				Type:      "workflow",
+				Package:   "orders",
+				FilePath:  "orders/workflow.go",
+				CallSites: []analyzer.CallSite{{TargetName: "ChargeCard", CallType: "activity"}},
+			},
+			"ChargeCard": {Name: "ChargeCard", Type: "activity", Package: "orders", FilePath: "orders/activity.go"},
+		},
+	}
+
+	cfg := config.NewConfig()
+	cfg.RootDir = tmpDir
+	cfg.LintDisabledRules = "TA002"
+	cfg.Emits = []config.Emit{
+		{Format: "sql", Path: tmpDir + "/graph.sql"},
+		{Format: "json", Lint: true, Path: tmpDir + "/lint.json"},
+	}
+	mockA := &countingAnalyzer{graph: graph}
+	runPipeline(cfg, logger, mockA)
+	if mockA.calls != 1 {
+		t.Errorf("Analyze called %d times, want 1", mockA.calls)
+	}
+
+	data, err := os.ReadFile(tmpDir + "/graph.sql")
+	if err != nil {
+		t.Fatalf("graph.sql not written: %v", err)
+	}
+	if !strings.Contains(string(data), "'TA001'") {
+		t.Errorf("sql output is missing the enabled rule TA001:\n%s", data)
+	}
+	if strings.Contains(string(data), "'TA002'") {
+		t.Errorf("sql output contains TA002 disabled by --lint-disable:\n%s", data)
+	}
+}
+
+// countingAnalyzer counts Analyze calls.
+type countingAnalyzer struct {
+	graph *analyzer.TemporalGraph
+	calls int
+}
+
+func (c *countingAnalyzer) Analyze(ctx context.Context, opts config.AnalysisOptions) (*analyzer.TemporalGraph, error) {
+	c.calls++
+	return c.graph, nil
+}
+
+func (c *countingAnalyzer) AnalyzeStream(ctx context.Context, opts config.AnalysisOptions, onPackage func(analyzer.PackageResult)) (*analyzer.TemporalGraph, error) {
+	return c.Analyze(ctx, opts)
+}
+
+func TestCheckResolution(t *testing.T) {
+	graph := &analyzer.TemporalGraph{Nodes: map[string]*analyzer.TemporalNode{
+		"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{{TargetName: "SendEmail"}}},
+		"SendEmail":     {Name: "SendEmail", Type: "activity", Unresolved: true},
+	}}
+
+	if err := checkResolution(&config.Config{}, graph); err != nil {
+		t.Errorf("checkResolution() without --strict-resolution = %v, want nil", err)
+	}
+	if err := checkResolution(&config.Config{StrictResolution: true, MaxUnresolved: 1}, graph); err != nil {
+		t.Errorf("checkResolution() within the threshold = %v, want nil", err)
+	}
+	err := checkResolution(&config.Config{StrictResolution: true}, graph)
+	if err == nil || !strings.Contains(err.Error(), "SendEmail") {
+		t.Errorf("checkResolution() over the threshold = %v, want error naming SendEmail", err)
+	}
+}
+
+func TestCheckFailedFiles(t *testing.T) {
+	graph := &analyzer.TemporalGraph{FileErrors: []analyzer.FileError{
+		{FilePath: "orders/broken.go", Stage: analyzer.StageParse, Error: "expected ')'"},
+		{FilePath: "orders/broken.go", Stage: analyzer.StageGraph, Error: "panic: boom"},
+	}}
+
+	if err := checkFailedFiles(&config.Config{}, graph); err != nil {
+		t.Errorf("checkFailedFiles() without --strict-files = %v, want nil", err)
+	}
+	if err := checkFailedFiles(&config.Config{StrictFiles: true, MaxFailedFiles: 1}, graph); err != nil {
+		t.Errorf("checkFailedFiles() within the threshold = %v, want nil", err)
+	}
+	err := checkFailedFiles(&config.Config{StrictFiles: true}, graph)
+	if err == nil || !strings.Contains(err.Error(), "1 files failed") || !strings.Contains(err.Error(), "orders/broken.go") {
+		t.Errorf("checkFailedFiles() over the threshold = %v, want error naming orders/broken.go", err)
+	}
+}
+
+func TestIssueLinkBase(t *testing.T) {
+	env := map[string]string{
+		"GITHUB_SERVER_URL": "https://github.com",
+		"GITHUB_REPOSITORY": "org/repo",
+		"GITHUB_SHA":        "abc123",
+		"GITHUB_WORKSPACE":  "/work/repo",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	tests := []struct {
+		rootDir string
+		want    string
+	}{
+		{rootDir: "/work/repo", want: "https://github.com/org/repo/blob/abc123/"},
+		{rootDir: "/work/repo/services/payments", want: "https://github.com/org/repo/blob/abc123/services/payments/"},
+		{rootDir: "/elsewhere", want: ""},
+	}
+	for _, tt := range tests {
+		if got := issueLinkBase(tt.rootDir, getenv); got != tt.want {
+			t.Errorf("issueLinkBase(%q) = %q, want %q", tt.rootDir, got, tt.want)
+		}
+	}
+
+	if got := issueLinkBase("/work/repo", func(string) string { return "" }); got != "" {
+		t.Errorf("Expected no link base outside GitHub Actions, got %q", got)
+	}
+}
+
+func TestRunInit(t *testing.T) {
+	t.Chdir(t.TempDir())
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", FilePath: "orders/workflow.go"},
+		},
+	}
+	cfg := config.NewConfig()
+
+	var out bytes.Buffer
+	if code := runInit(cfg, logger, &mockAnalyzer{graph: graph}, strings.NewReader("\n\ny\n"), &out); code != 0 {
+		t.Fatalf("runInit() = %d, want 0\n%s", code, out.String())
+	}
+	data, err := os.ReadFile(config.ConfigFile)
+	if err != nil {
+		t.Fatalf("config file not written: %v", err)
+	}
+	if !strings.Contains(string(data), "root: orders\n") || !strings.Contains(string(data), "lint-strict: true\n") {
+		t.Errorf("unexpected config file:\n%s", data)
+	}
+	if _, err := os.Stat(onboarding.CIWorkflowFile); err != nil {
+		t.Errorf("CI workflow not written: %v", err)
+	}
+
+	// An existing config file is not overwritten
+	if code := runInit(cfg, logger, &mockAnalyzer{graph: graph}, strings.NewReader(""), io.Discard); code != 1 {
+		t.Errorf("runInit() with an existing config file = %d, want 1", code)
+	}
+}
+
+func TestRunExplainNode(t *testing.T) {
+	dir := t.TempDir()
+	src := `package orders
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+func OrderWorkflow(ctx workflow.Context) error {
+	return workflow.ExecuteActivity(ctx, SendEmail).Get(ctx, nil)
+}
+
+func SendEmail(ctx context.Context) error { return nil }
+`
+	if err := os.WriteFile(dir+"/orders.go", []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := config.NewConfig()
+	cfg.RootDir = dir
+	cfg.Plain = true
+
+	cfg.FilterName = "OrderWorkflow"
+	var out bytes.Buffer
+	if code := runExplainNode(cfg, logger, analyzer.NewAnalyzer(logger), &out); code != 0 {
+		t.Errorf("runExplainNode(OrderWorkflow) = %d, want 0\n%s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "detected as workflow with medium confidence (signature)") {
+		t.Errorf("unexpected explanation:\n%s", out.String())
+	}
+
+	cfg.FilterName = "SendEmail"
+	out.Reset()
+	if code := runExplainNode(cfg, logger, analyzer.NewAnalyzer(logger), &out); code != 1 {
+		t.Errorf("runExplainNode(SendEmail) = %d, want 1\n%s", code, out.String())
+	}
+	for _, want := range []string{"not detected: no registration", "Executed by:", "OrderWorkflow ("} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("explanation does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunAssert(t *testing.T) {
+	dir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	graph := &analyzer.TemporalGraph{
+		Nodes: map[string]*analyzer.TemporalNode{
+			"OrderWorkflow": {Name: "OrderWorkflow", Type: "workflow", CallSites: []analyzer.CallSite{{TargetName: "ChargeCard"}}},
+			"ChargeCard":    {Name: "ChargeCard", Type: "activity"},
+		},
+	}
+	cfg := config.NewConfig()
+	cfg.RootDir = dir
+
+	// The assertions file defaults to analyzer-assertions.yaml in the root
+	if code := runAssert(cfg, logger, &mockAnalyzer{graph: graph}, io.Discard); code != 2 {
+		t.Errorf("runAssert() without an assertions file = %d, want 2", code)
+	}
+
+	src := "assertions:\n  - from: OrderWorkflow\n    must_call: ChargeCard\n"
+	if err := os.WriteFile(dir+"/analyzer-assertions.yaml", []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code := runAssert(cfg, logger, &mockAnalyzer{graph: graph}, io.Discard); code != 0 {
+		t.Errorf("runAssert() = %d, want 0", code)
+	}
+
+	cfg.AssertionsFile = dir + "/failing.yaml"
+	src = "assertions:\n  - from: OrderWorkflow\n    must_not_call: type==activity\n"
+	if err := os.WriteFile(cfg.AssertionsFile, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.OutputFormat = "json"
+	var out bytes.Buffer
+	if code := runAssert(cfg, logger, &mockAnalyzer{graph: graph}, &out); code != 1 {
+		t.Errorf("runAssert() with a failing assertion = %d, want 1", code)
+	}
+	if !strings.Contains(out.String(), `"passed": false`) {
+		t.Errorf("unexpected JSON output:\n%s", out.String())
+	}
+}